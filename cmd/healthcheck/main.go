@@ -0,0 +1,31 @@
+// Команда healthcheck опрашивает /health/ready локального сервера и завершается с кодом 0,
+// если сервер готов, и 1 в остальных случаях. Предназначена для Docker HEALTHCHECK
+// и exec-проб Kubernetes: в образе нет curl/wget, а адрес берётся из тех же SERVER_HOST/SERVER_PORT.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"net"
+	"os"
+	"time"
+
+	"workout-app/internal/config"
+	"workout-app/pkg/healthprobe"
+)
+
+func main() {
+	var (
+		path    = flag.String("path", "/health/ready", "Проверяемый эндпоинт")
+		timeout = flag.Duration("timeout", 3*time.Second, "Таймаут запроса")
+	)
+	flag.Parse()
+
+	cfg := config.LoadServer()
+	url := "http://" + net.JoinHostPort(healthprobe.ProbeHost(cfg.Host), cfg.Port) + *path
+
+	if err := healthprobe.Check(url, *timeout); err != nil {
+		fmt.Fprintf(os.Stderr, "healthcheck: %v\n", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,142 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	"workout-app/internal/config"
+	"workout-app/internal/database"
+	"workout-app/internal/events"
+	"workout-app/internal/mailer"
+	pgrepo "workout-app/internal/repository/postgres"
+	"workout-app/pkg/emailaddr"
+	"workout-app/pkg/logger"
+	"workout-app/pkg/secretbox"
+)
+
+func main() {
+	// Определяем флаги
+	var (
+		subscriber = flag.String("subscriber", "", "Имя подписчика, которому нужно повторно доставить события")
+		fromID     = flag.Int64("from", 0, "Первый ID события (включительно); по умолчанию — с контрольной точки")
+		toID       = flag.Int64("to", 0, "Последний ID события (включительно); по умолчанию — до конца журнала")
+		reset      = flag.Bool("reset", false, "Начать с начала журнала, игнорируя контрольную точку")
+		dryRun     = flag.Bool("dry-run", false, "Только показать, что изменилось бы, без применения изменений")
+		batch      = flag.Int("batch", 500, "Количество событий, читаемых за один запрос")
+		list       = flag.Bool("list", false, "Показать зарегистрированных подписчиков")
+	)
+
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Использование: %s [опции]\n\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "Опции:\n")
+		flag.PrintDefaults()
+		fmt.Fprintf(os.Stderr, "\nПримеры:\n")
+		fmt.Fprintf(os.Stderr, "  %s -list                                  # Показать подписчиков\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s -subscriber achievements -dry-run      # Показать, что изменится\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s -subscriber achievements -reset        # Доставить все события заново\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s -subscriber achievements -from 100 -to 200\n", os.Args[0])
+	}
+
+	flag.Parse()
+
+	// Загружаем конфигурацию
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatalf("Ошибка загрузки конфигурации: %v", err)
+	}
+
+	// Инициализируем подключение к базе данных
+	db, err := database.NewConnection(&cfg.Database, cfg.AppEnv)
+	if err != nil {
+		log.Fatalf("Ошибка подключения к базе данных: %v", err)
+	}
+	defer func() {
+		if err := db.Close(); err != nil {
+			log.Printf("Ошибка закрытия подключения к базе данных: %v", err)
+		}
+	}()
+
+	// Письма и уведомления webhooks подписчики ставят в очереди: их доставят воркеры работающего сервиса.
+	deps := events.Dependencies{
+		DB:     db.DB,
+		Users:  pgrepo.NewUserRepository(db.DB, emailaddr.Policy{FoldGmail: cfg.Email.FoldGmail, FoldPlusTags: cfg.Email.FoldPlusTags}),
+		Email:  mailer.NewOutboxSender(pgrepo.NewEmailOutboxRepository(db.DB)),
+		Logger: logger.Default(),
+
+		Notifications: pgrepo.NewNotificationRepository(db.DB),
+	}
+	if cfg.Webhook.SecretKey != "" {
+		// Ключ валидирован в config.Validate, ошибки здесь невозможны.
+		key, _ := secretbox.ParseKey(cfg.Webhook.SecretKey)
+		box, _ := secretbox.New(key)
+		deps.WebhookEndpoints = pgrepo.NewWebhookEndpointRepository(db.DB, box)
+		deps.WebhookDeliveries = pgrepo.NewWebhookDeliveryRepository(db.DB)
+	}
+	registry := events.NewRegistry(events.DefaultSubscribers(deps)...)
+
+	if *list {
+		names := registry.Names()
+		if len(names) == 0 {
+			log.Println("Нет зарегистрированных подписчиков")
+			return
+		}
+		for _, name := range names {
+			fmt.Println(name)
+		}
+		return
+	}
+
+	if *subscriber == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+	sub, ok := registry.Get(*subscriber)
+	if !ok {
+		log.Fatalf("Ошибка: неизвестный подписчик %q (доступны: %s)", *subscriber, strings.Join(registry.Names(), ", "))
+	}
+	if *fromID > 0 && *reset {
+		log.Fatal("Ошибка: -from и -reset нельзя указывать одновременно")
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	mode := "применение"
+	if *dryRun {
+		mode = "пробный прогон"
+	}
+	log.Printf("Повторная доставка событий подписчику %s (%s)...\n", sub.Name(), mode)
+
+	replayer := events.NewReplayer(pgrepo.NewEventRepository(db.DB))
+	result, err := replayer.Replay(ctx, sub, events.ReplayOptions{
+		FromID:    *fromID,
+		ToID:      *toID,
+		Reset:     *reset,
+		DryRun:    *dryRun,
+		BatchSize: *batch,
+	}, printOutcome)
+	if result != nil {
+		log.Printf("Доставлено: %d, с изменениями: %d, пропущено: %d, последнее событие: %d\n",
+			result.Delivered, result.Changed, result.Skipped, result.LastEventID)
+	}
+	if err != nil {
+		log.Fatalf("Ошибка повторной доставки: %v", err)
+	}
+}
+
+// printOutcome печатает изменения, вызванные одним событием
+func printOutcome(o events.Outcome) {
+	if len(o.Changes) == 0 {
+		return
+	}
+	fmt.Printf("#%d %s %s\n", o.Event.ID, o.Event.Type, o.Event.AggregateID)
+	for _, change := range o.Changes {
+		fmt.Printf("  - %s\n", change)
+	}
+}
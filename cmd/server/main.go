@@ -1,10 +1,15 @@
 package main
 
 import (
+	"context"
+	"flag"
+	"io"
 	"log"
+	"os"
 
 	"workout-app/internal/config"
 	"workout-app/internal/database"
+	"workout-app/internal/doctor"
 	"workout-app/internal/server"
 )
 
@@ -18,6 +23,12 @@ import (
 // @name                       Authorization
 // @description                В формате: "Bearer <access_token>"
 func main() {
+	check := flag.Bool("check", false, "Проверить конфигурацию, БД, миграции, SMTP и ключи JWT и выйти (то же, что подкоманда doctor)")
+	flag.Parse()
+	if *check || flag.Arg(0) == "doctor" {
+		os.Exit(runDoctor())
+	}
+
 	log.Println("Workout App Server Starting...")
 
 	// Загружаем конфигурацию
@@ -48,3 +59,15 @@ func main() {
 		log.Fatalf("Ошибка запуска сервера: %v", err)
 	}
 }
+
+// runDoctor печатает отчёт самопроверки и возвращает код выхода: 0 — окружение готово, 1 — нет.
+func runDoctor() int {
+	// Служебные сообщения подключения к БД не нужны в отчёте.
+	log.SetOutput(io.Discard)
+	report := doctor.Run(context.Background())
+	report.Write(os.Stdout)
+	if report.Failed() {
+		return 1
+	}
+	return 0
+}
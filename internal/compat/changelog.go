@@ -0,0 +1,207 @@
+package compat
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ChangelogPath — путь эндпоинта с журналом изменений публичного API.
+const ChangelogPath = "/api/v1/meta/changelog"
+
+// dateLayout — формат дат в журнале изменений.
+const dateLayout = "2006-01-02"
+
+// ChangeType — вид изменения публичного API.
+type ChangeType string
+
+const (
+	ChangeAdded      ChangeType = "added"      // Новый эндпоинт или поле
+	ChangeChanged    ChangeType = "changed"    // Изменено поведение без нарушения совместимости
+	ChangeDeprecated ChangeType = "deprecated" // Эндпоинт устарел и будет удалён
+	ChangeRemoved    ChangeType = "removed"    // Эндпоинт или поле удалены
+)
+
+// Endpoint — маршрут API, которого касается изменение.
+type Endpoint struct {
+	Method string `json:"method"`
+	Route  string `json:"route"` // Шаблон маршрута Gin, например /api/v1/users/me
+}
+
+// ChangelogEntry — запись журнала изменений публичного API.
+type ChangelogEntry struct {
+	ID          string
+	Type        ChangeType
+	Date        time.Time // Дата изменения; для deprecated — дата, с которой эндпоинт считается устаревшим
+	Title       string
+	Description string
+	Endpoints   []Endpoint
+	// Sunset — дата, после которой устаревший эндпоинт может быть удалён; только для deprecated.
+	Sunset *time.Time
+	// Replacement — эндпоинт, которым заменяется устаревший, в свободной форме.
+	Replacement string
+}
+
+// Changelog хранит журнал изменений публичного API и устаревшие маршруты из него.
+type Changelog struct {
+	entries      []ChangelogEntry
+	deprecations map[string]*ChangelogEntry
+}
+
+// changelogJSON — журнал изменений публичного API. При изменении API добавьте запись в changelog.json;
+// маршруты из записей deprecated получают заголовки Deprecation, Sunset и Link (middleware.Deprecation).
+//
+//go:embed changelog.json
+var changelogJSON []byte
+
+// MustLoadChangelog загружает встроенный журнал изменений и паникует при ошибке:
+// журнал проверяется тестами, поэтому ошибка означает сломанную сборку.
+func MustLoadChangelog() *Changelog {
+	changelog, err := ParseChangelog(changelogJSON)
+	if err != nil {
+		panic(fmt.Sprintf("compat: invalid changelog.json: %v", err))
+	}
+	return changelog
+}
+
+// rawChangelogEntry — запись журнала в формате changelog.json.
+type rawChangelogEntry struct {
+	ID          string     `json:"id"`
+	Type        ChangeType `json:"type"`
+	Date        string     `json:"date"`
+	Title       string     `json:"title"`
+	Description string     `json:"description"`
+	Endpoints   []Endpoint `json:"endpoints"`
+	Sunset      string     `json:"sunset"`
+	Replacement string     `json:"replacement"`
+}
+
+// ParseChangelog разбирает и проверяет журнал изменений в формате changelog.json.
+// Записи сортируются от новых к старым.
+func ParseChangelog(data []byte) (*Changelog, error) {
+	var raw []rawChangelogEntry
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+
+	changelog := &Changelog{deprecations: map[string]*ChangelogEntry{}}
+	ids := make(map[string]bool, len(raw))
+	for _, r := range raw {
+		entry, err := r.toEntry()
+		if err != nil {
+			return nil, fmt.Errorf("entry %q: %w", r.ID, err)
+		}
+		if ids[entry.ID] {
+			return nil, fmt.Errorf("entry %q: duplicate id", entry.ID)
+		}
+		ids[entry.ID] = true
+		changelog.entries = append(changelog.entries, entry)
+	}
+	sort.SliceStable(changelog.entries, func(i, j int) bool {
+		return changelog.entries[i].Date.After(changelog.entries[j].Date)
+	})
+
+	for i := range changelog.entries {
+		entry := &changelog.entries[i]
+		if entry.Type != ChangeDeprecated {
+			continue
+		}
+		for _, e := range entry.Endpoints {
+			key := endpointKey(e.Method, e.Route)
+			if _, ok := changelog.deprecations[key]; ok {
+				return nil, fmt.Errorf("entry %q: %s %s is already deprecated", entry.ID, e.Method, e.Route)
+			}
+			changelog.deprecations[key] = entry
+		}
+	}
+	return changelog, nil
+}
+
+func (r rawChangelogEntry) toEntry() (ChangelogEntry, error) {
+	entry := ChangelogEntry{
+		ID:          strings.TrimSpace(r.ID),
+		Type:        r.Type,
+		Title:       strings.TrimSpace(r.Title),
+		Description: strings.TrimSpace(r.Description),
+		Endpoints:   r.Endpoints,
+		Replacement: strings.TrimSpace(r.Replacement),
+	}
+	if entry.ID == "" {
+		return entry, fmt.Errorf("id is required")
+	}
+	if entry.Title == "" {
+		return entry, fmt.Errorf("title is required")
+	}
+	switch entry.Type {
+	case ChangeAdded, ChangeChanged, ChangeDeprecated, ChangeRemoved:
+	default:
+		return entry, fmt.Errorf("unknown type %q", entry.Type)
+	}
+
+	date, err := time.Parse(dateLayout, r.Date)
+	if err != nil {
+		return entry, fmt.Errorf("invalid date %q", r.Date)
+	}
+	entry.Date = date
+
+	for i, e := range entry.Endpoints {
+		e.Method = strings.ToUpper(strings.TrimSpace(e.Method))
+		if e.Method == "" || !strings.HasPrefix(e.Route, "/") {
+			return entry, fmt.Errorf("invalid endpoint %q %q", e.Method, e.Route)
+		}
+		entry.Endpoints[i] = e
+	}
+
+	if entry.Type == ChangeDeprecated && len(entry.Endpoints) == 0 {
+		return entry, fmt.Errorf("deprecated entry must list endpoints")
+	}
+	if r.Sunset != "" {
+		if entry.Type != ChangeDeprecated {
+			return entry, fmt.Errorf("sunset is allowed only for deprecated entries")
+		}
+		sunset, err := time.Parse(dateLayout, r.Sunset)
+		if err != nil || !sunset.After(date) {
+			return entry, fmt.Errorf("invalid sunset %q", r.Sunset)
+		}
+		entry.Sunset = &sunset
+	}
+	return entry, nil
+}
+
+// Entries возвращает записи не старше since (нулевое значение — все записи), новые первыми.
+func (c *Changelog) Entries(since time.Time) []ChangelogEntry {
+	if since.IsZero() {
+		return c.entries
+	}
+	n := sort.Search(len(c.entries), func(i int) bool {
+		return c.entries[i].Date.Before(since)
+	})
+	return c.entries[:n]
+}
+
+// Deprecation возвращает запись, объявившую маршрут устаревшим.
+func (c *Changelog) Deprecation(method, route string) (*ChangelogEntry, bool) {
+	entry, ok := c.deprecations[endpointKey(method, route)]
+	return entry, ok
+}
+
+// DeprecationHeader возвращает значение заголовка Deprecation (RFC 9745): дату в формате @<unix>.
+func (e *ChangelogEntry) DeprecationHeader() string {
+	return fmt.Sprintf("@%d", e.Date.Unix())
+}
+
+// SunsetHeader возвращает значение заголовка Sunset (RFC 8594) или пустую строку, если дата не задана.
+func (e *ChangelogEntry) SunsetHeader() string {
+	if e.Sunset == nil {
+		return ""
+	}
+	return e.Sunset.UTC().Format(http.TimeFormat)
+}
+
+func endpointKey(method, route string) string {
+	return method + " " + route
+}
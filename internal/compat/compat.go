@@ -0,0 +1,135 @@
+package compat
+
+import (
+	"strconv"
+	"strings"
+)
+
+// HeaderAppVersion — заголовок, в котором мобильный клиент сообщает свою версию.
+const HeaderAppVersion = "X-App-Version"
+
+// Version — семантическая версия клиента (major.minor.patch).
+type Version struct {
+	Major, Minor, Patch int
+}
+
+// ParseVersion разбирает версию вида "1.4" или "1.4.2" (допускается префикс "v" и суффикс "-beta").
+func ParseVersion(s string) (Version, bool) {
+	s = strings.TrimPrefix(strings.TrimSpace(s), "v")
+	if i := strings.IndexAny(s, "-+ "); i >= 0 {
+		s = s[:i]
+	}
+	parts := strings.Split(s, ".")
+	if len(parts) == 0 || len(parts) > 3 {
+		return Version{}, false
+	}
+
+	nums := [3]int{}
+	for i, p := range parts {
+		n, err := strconv.Atoi(p)
+		if err != nil || n < 0 {
+			return Version{}, false
+		}
+		nums[i] = n
+	}
+	return Version{Major: nums[0], Minor: nums[1], Patch: nums[2]}, true
+}
+
+// Less сообщает, что v старше (меньше) other.
+func (v Version) Less(other Version) bool {
+	if v.Major != other.Major {
+		return v.Major < other.Major
+	}
+	if v.Minor != other.Minor {
+		return v.Minor < other.Minor
+	}
+	return v.Patch < other.Patch
+}
+
+// String возвращает версию в виде major.minor.patch.
+func (v Version) String() string {
+	return strconv.Itoa(v.Major) + "." + strconv.Itoa(v.Minor) + "." + strconv.Itoa(v.Patch)
+}
+
+// FieldRename описывает переименование поля JSON. Path — путь через точку ("profile.first_name");
+// массивы на пути обходятся поэлементно. To — новое имя последнего сегмента пути.
+type FieldRename struct {
+	Path string
+	To   string
+}
+
+// Rule описывает преобразования для одного маршрута и клиентов версии ниже Before.
+type Rule struct {
+	Method string  // HTTP-метод маршрута
+	Route  string  // Шаблон маршрута Gin, например /api/v1/users/me
+	Before Version // Правило применяется к клиентам с версией строго ниже Before
+
+	// Request переименовывает устаревшие поля запроса в актуальные (legacy -> current).
+	Request []FieldRename
+	// Response переименовывает актуальные поля ответа в устаревшие (current -> legacy).
+	Response []FieldRename
+
+	// RemoveAfter — дата или версия, после которой правило планируется удалить (для логов).
+	RemoveAfter string
+}
+
+// Registry хранит правила совместимости.
+type Registry struct {
+	rules []Rule
+}
+
+// NewRegistry создаёт реестр правил совместимости.
+func NewRegistry(rules ...Rule) *Registry {
+	return &Registry{rules: rules}
+}
+
+// Match возвращает правила, применимые к маршруту и версии клиента.
+func (r *Registry) Match(method, route string, v Version) []Rule {
+	var matched []Rule
+	for _, rule := range r.rules {
+		if rule.Method == method && rule.Route == route && v.Less(rule.Before) {
+			matched = append(matched, rule)
+		}
+	}
+	return matched
+}
+
+// Empty сообщает, что в реестре нет правил.
+func (r *Registry) Empty() bool {
+	return len(r.rules) == 0
+}
+
+// Rename применяет переименование к разобранному JSON-документу.
+// Возвращает true, если хотя бы одно поле было переименовано.
+// Если поле с новым именем уже есть, устаревшее поле удаляется без перезаписи.
+func Rename(doc any, f FieldRename) bool {
+	return rename(doc, strings.Split(f.Path, "."), f.To)
+}
+
+func rename(node any, path []string, to string) bool {
+	switch v := node.(type) {
+	case []any:
+		changed := false
+		for _, item := range v {
+			if rename(item, path, to) {
+				changed = true
+			}
+		}
+		return changed
+	case map[string]any:
+		value, ok := v[path[0]]
+		if !ok {
+			return false
+		}
+		if len(path) > 1 {
+			return rename(value, path[1:], to)
+		}
+		delete(v, path[0])
+		if _, exists := v[to]; !exists {
+			v[to] = value
+		}
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,10 @@
+package compat
+
+// DefaultRules возвращает правила совместимости для выпущенных версий мобильного приложения.
+//
+// Когда поле запроса или ответа переименовывается, добавьте сюда правило с Before — первой
+// версией клиента, которая использует новое имя, и RemoveAfter — когда правило можно удалить.
+// Логи compat_legacy_request_field показывают, какие устаревшие поля ещё присылают клиенты.
+func DefaultRules() []Rule {
+	return []Rule{}
+}
@@ -2,28 +2,157 @@ package config
 
 import (
 	"fmt"
+	"net"
+	"net/url"
 	"os"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/joho/godotenv"
+
+	"workout-app/pkg/secretbox"
 )
 
 // Config хранит всю конфигурацию приложения
 type Config struct {
-	Server   ServerConfig
-	Database DatabaseConfig
-	CORS     CORSConfig
-	JWT      JWTConfig
-	Email    EmailConfig
-	AppEnv   string // Окружение приложения: development, production, etc.
+	Server        ServerConfig
+	Startup       StartupConfig
+	Database      DatabaseConfig
+	CORS          CORSConfig
+	JWT           JWTConfig
+	Email         EmailConfig
+	Outbox        OutboxConfig
+	Redis         RedisConfig
+	RateLimit     RateLimitConfig
+	Storage       StorageConfig
+	Log           LogConfig
+	Region        RegionConfig
+	Cleanup       CleanupConfig
+	Retention     RetentionConfig
+	Workout       WorkoutConfig
+	Export        ExportConfig
+	Draft         DraftConfig
+	Import        ImportConfig
+	HealthSync    HealthSyncConfig
+	OAuth         OAuthConfig
+	Password      PasswordConfig
+	Username      UsernameConfig
+	Webhook       WebhookConfig
+	Push          PushConfig
+	Reminder      ReminderConfig
+	WeeklySummary WeeklySummaryConfig
+	HTTPClient    HTTPClientConfig
+	Playground    PlaygroundConfig
+	AppEnv        string // Окружение приложения: development, production, etc.
+}
+
+// LogConfig хранит настройки структурированного логирования.
+type LogConfig struct {
+	Level  string // Минимальный уровень: debug, info, warn, error
+	Format string // Формат вывода: json (production) или text (локальная разработка)
+}
+
+// CleanupConfig хранит настройки фоновой очистки устаревших записей (истёкших кодов подтверждения и т.п.).
+type CleanupConfig struct {
+	Interval  time.Duration // Период запуска очистки; 0 отключает фоновую очистку
+	BatchSize int           // Сколько строк удаляется одним запросом
+}
+
+// RetentionConfig хранит настройки окончательного удаления мягко удалённых аккаунтов.
+type RetentionConfig struct {
+	DeletedUserDays int           // Через сколько дней после мягкого удаления аккаунт обезличивается; 0 отключает
+	Interval        time.Duration // Период запуска проверки
+}
+
+// WorkoutConfig хранит настройки учёта тренировок.
+type WorkoutConfig struct {
+	AutoPauseAfter time.Duration // Порог автопаузы по умолчанию: более долгий простой не входит в активное время
+	// ValidationMode — что делать с подходами и импортированными тренировками за пределами правдоподобных
+	// границ: off (не проверять), warn (сохранить с пометкой, исключить из статистики), reject (отклонить).
+	ValidationMode string
+	MaxWeightKg    int           // Правдоподобный вес подхода, если для упражнения нет границы в справочнике
+	MaxReps        int           // Правдоподобное число повторений подхода, если для упражнения нет границы
+	MaxDuration    time.Duration // Правдоподобная длительность импортированной тренировки
+}
+
+// ExportConfig хранит настройки выгрузки данных аккаунта (GDPR).
+type ExportConfig struct {
+	TTL      time.Duration // Срок хранения архива и действия ссылки на скачивание
+	Interval time.Duration // Период проверки очереди выгрузок
+}
+
+// DraftConfig хранит настройки черновиков редактора программ и тренировок.
+type DraftConfig struct {
+	TTL time.Duration // Черновик удаляется, если его не сохраняли дольше этого срока
+}
+
+// ImportConfig хранит настройки импорта исторических данных из других приложений.
+type ImportConfig struct {
+	MaxBytes      int64         // Максимальный размер файла NDJSON
+	MaxRecords    int           // Максимум записей в одном файле
+	BatchSize     int           // Записей, обрабатываемых одной транзакцией
+	Interval      time.Duration // Период проверки очереди импортов
+	UploadTimeout time.Duration // Время на загрузку файла (вместо таймаутов чтения и записи сервера)
+	Retention     time.Duration // Срок хранения завершённых импортов и результатов по строкам
+}
+
+// HealthSyncConfig хранит настройки импорта из Apple Health и Google Fit.
+type HealthSyncConfig struct {
+	MaxBytes int64 // Максимальный размер выгрузки
+	MaxItems int   // Максимум записей в одной выгрузке
+}
+
+// OAuthConfig хранит настройки входа через Google и Apple по ID-токенам.
+// Провайдер без client ID выключен.
+type OAuthConfig struct {
+	GoogleClientIDs []string // Допустимые aud ID-токенов Google (веб, iOS, Android)
+	AppleClientIDs  []string // Допустимые aud ID-токенов Apple (bundle ID приложения, Services ID сайта)
+}
+
+// PasswordConfig хранит политику паролей, проверяемую при регистрации и смене пароля.
+type PasswordConfig struct {
+	MinLength       int      // Минимальная длина в символах
+	RequiredClasses []string // Обязательные классы символов: lower, upper, digit, symbol
+	DenyCommon      bool     // Запрещать распространённые пароли из встроенного списка
+	Denylist        []string // Дополнительные запрещённые пароли (без учёта регистра)
+}
+
+// UsernameConfig хранит правила выбора и смены username.
+type UsernameConfig struct {
+	// ReservationPeriod — сколько после смены username старое имя зарезервировано за пользователем; 0 — не резервируется.
+	ReservationPeriod time.Duration
+
+	BlockReserved    bool     // Запрещать встроенные служебные имена (admin, support, system и т.п.)
+	ProfanityLocales []string // Локали встроенных списков ненормативной лексики (en, ru); none — без проверки
+	Blocklist        []string // Дополнительные запрещённые username (совпадение целиком, без учёта регистра)
+}
+
+// RegionConfig хранит настройки определения региона пользователя и региональных ограничений.
+type RegionConfig struct {
+	CountryHeader    string   // Заголовок с кодом страны клиента от CDN/балансировщика
+	DisabledFeatures []string // Отключённые функции в формате регион:функция, например eu:experiments
 }
 
 // ServerConfig хранит конфигурацию сервера
 type ServerConfig struct {
 	Host string
 	Port string
+
+	// TimingEnabled включает заголовок Server-Timing (время в БД, кеше и внешних сервисах).
+	TimingEnabled bool
+
+	// TrustedProxies — адреса и подсети (CIDR) обратных прокси, которым доверяется X-Forwarded-For.
+	// Пусто — не доверять никому: IP клиента (и лимиты по IP) берётся из адреса соединения.
+	TrustedProxies []string
+}
+
+// StartupConfig хранит настройки запуска сервера: до их прохождения /health/ready отвечает 503.
+type StartupConfig struct {
+	// Timeout — сколько запуск ждёт зависимостей (применения миграций, Redis); затем сервер завершается с ошибкой.
+	Timeout time.Duration
+	// PasswordHashWarn — если проверка одного пароля bcrypt при прогреве дольше, в лог пишется предупреждение.
+	PasswordHashWarn time.Duration
 }
 
 // DatabaseConfig хранит конфигурацию базы данных
@@ -38,6 +167,11 @@ type DatabaseConfig struct {
 	MaxIdleConns    int           // Максимальное количество неактивных соединений
 	ConnMaxLifetime time.Duration // Максимальное время жизни соединения
 	ConnMaxIdleTime time.Duration // Максимальное время простоя соединения
+
+	// UserFastPath — режим чтения пользователей по ID и email через pgx в обход GORM: off, shadow (ответ
+	// из GORM, pgx сверяется в фоне) или on (ответ из pgx). Нужен для поэтапного отказа от GORM на входе.
+	UserFastPath         string
+	UserFastPathMaxConns int // Размер отдельного пула pgx; он же — предел одновременных теневых чтений
 }
 
 // CORSConfig хранит конфигурацию CORS
@@ -51,24 +185,219 @@ type CORSConfig struct {
 }
 
 // JWTConfig хранит конфигурацию JWT-токенов (access + refresh).
+//
+// Для ротации секретов токены подписываются текущим ключом из набора и несут его идентификатор
+// в заголовке kid, а проверяются любым ключом набора: новый ключ добавляется в набор и становится
+// текущим, старый удаляется после истечения выданных им токенов. Без текущего ключа токены
+// подписываются AccessSecret/RefreshSecret без kid, как до появления наборов ключей.
 type JWTConfig struct {
-	AccessSecret  string        // Секрет для подписи access-токенов
-	RefreshSecret string        // Секрет для подписи refresh-токенов
+	AccessSecret  string        // Секрет для подписи access-токенов без kid; также основа производных ключей ссылок
+	RefreshSecret string        // Секрет для подписи refresh-токенов без kid
 	AccessTTL     time.Duration // Время жизни access-токена
 	RefreshTTL    time.Duration // Время жизни refresh-токена
 	Issuer        string        // Issuer (iss) для токенов
+
+	AccessKeys   map[string]string // Ключи access-токенов по kid
+	AccessKeyID  string            // kid текущего ключа подписи access-токенов; пусто — подпись AccessSecret без kid
+	RefreshKeys  map[string]string // Ключи refresh-токенов по kid
+	RefreshKeyID string            // kid текущего ключа подписи refresh-токенов; пусто — подпись RefreshSecret без kid
+	// AcceptTokensWithoutKID — принимать токены без kid, подписанные AccessSecret/RefreshSecret.
+	// Отключается, когда токены, выданные до перехода на набор ключей, истекли.
+	AcceptTokensWithoutKID bool
+
+	// SigningAlgorithm — подпись access-токенов: HS256 (общий секрет) или асимметричная RS256/EdDSA,
+	// при которой другие сервисы проверяют токены по открытым ключам из /.well-known/jwks.json.
+	// Refresh-токены проверяет только этот сервис, поэтому они всегда подписываются HS256.
+	SigningAlgorithm string
+	PrivateKeyPath   string   // PEM закрытого ключа подписи для RS256/EdDSA
+	PublicKeyPaths   []string // PEM открытых ключей прежних пар: проверяются и публикуются до истечения их токенов
 }
 
 // EmailConfig хранит конфигурацию для отправки email и параметров верификации.
 type EmailConfig struct {
-	SMTPHost                string        // SMTP host
-	SMTPPort                int           // SMTP port
-	SMTPUsername            string        // SMTP username
-	SMTPPassword            string        // SMTP password
+	// Provider — способ отправки писем платформы: smtp, sendgrid, ses или mailgun.
+	Provider string
+	// ProviderTimeout — таймаут запроса к API провайдера (sendgrid, ses, mailgun).
+	ProviderTimeout time.Duration
+
+	SendGridAPIKey     string // API-ключ SendGrid
+	SESRegion          string // Регион Amazon SES, например eu-west-1
+	SESAccessKeyID     string // Ключ доступа AWS с правом ses:SendEmail
+	SESSecretAccessKey string // Секретный ключ AWS
+	MailgunAPIKey      string // API-ключ Mailgun
+	MailgunDomain      string // Домен отправки Mailgun
+	MailgunBaseURL     string // Адрес API Mailgun (для региона EU — https://api.eu.mailgun.net)
+
+	SMTPHost     string // SMTP host
+	SMTPPort     int    // SMTP port
+	SMTPUsername string // SMTP username
+	SMTPPassword string // SMTP password
+	// SMTPTLS — защита соединения: starttls (обязательный STARTTLS, порт 587), implicit (SMTPS, порт 465)
+	// или none (без шифрования, только для локальных серверов разработки).
+	SMTPTLS                 string
+	SMTPTimeout             time.Duration // Таймаут подключения и обмена командами при отправке письма
+	SMTPPoolSize            int           // Сколько простаивающих соединений держать для повторного использования; 0 — без пула
+	SMTPIdleTimeout         time.Duration // Простаивающее дольше соединение не используется повторно
+	SMTPMaxMessagesPerConn  int           // Писем через одно соединение, после чего оно закрывается; 0 — без ограничения
 	FromEmail               string        // From email address
 	VerificationTTL         time.Duration // Время жизни кода подтверждения email
 	VerificationMaxAttempts int           // Максимальное количество попыток ввода кода
 	VerificationCodeLength  int           // Длина кода подтверждения email
+
+	// PasswordChangeConfirmRoles — роли, для которых смена пароля подтверждается кодом из email.
+	// Пустой список отключает подтверждение.
+	PasswordChangeConfirmRoles []string
+
+	// WebhookSecret — общий секрет webhooks почтовых провайдеров (события доставки).
+	// Пустое значение отключает приём webhooks.
+	WebhookSecret string
+
+	// TenantSecretKey — ключ шифрования секретов почтовых настроек организаций (base64, 32 байта).
+	// Пустое значение отключает собственные настройки отправки писем организаций.
+	TenantSecretKey string
+	// TenantMaxPerHour — максимальный и используемый по умолчанию лимит писем организации в час.
+	TenantMaxPerHour int
+
+	// DefaultLocale — язык писем пользователям, не выбравшим язык (ru или en).
+	DefaultLocale string
+
+	// FoldGmail — считать одним адресом варианты Gmail с точками и +меткой (a.b+x@gmail.com = ab@gmail.com).
+	FoldGmail bool
+	// FoldPlusTags — считать одним адресом варианты с +меткой для всех доменов (user+x@example.com = user@example.com).
+	FoldPlusTags bool
+}
+
+// OutboxConfig хранит настройки фоновой отправки писем из очереди исходящих писем.
+type OutboxConfig struct {
+	Interval    time.Duration // Период проверки очереди
+	BatchSize   int           // Писем за один запуск
+	MaxAttempts int           // Попыток до перевода письма в dead letter
+	RetryBase   time.Duration // Задержка после первой неудачной попытки; далее удваивается
+	RetryMax    time.Duration // Максимальная задержка между попытками
+	Retention   time.Duration // Срок хранения отправленных и dead-letter писем
+}
+
+// WebhookConfig хранит настройки уведомлений внешних систем о событиях аккаунта (webhooks).
+type WebhookConfig struct {
+	// SecretKey — ключ шифрования ключей подписи адресов (base64, 32 байта).
+	// Пустое значение отключает webhooks.
+	SecretKey   string
+	Interval    time.Duration // Период проверки очереди уведомлений
+	BatchSize   int           // Уведомлений за один запуск
+	MaxAttempts int           // Попыток до перевода уведомления в dead letter
+	RetryBase   time.Duration // Задержка после первой неудачной попытки; далее удваивается
+	RetryMax    time.Duration // Максимальная задержка между попытками
+	Timeout     time.Duration // Таймаут запроса к адресу
+	Retention   time.Duration // Срок хранения доставленных и dead-letter уведомлений
+}
+
+// PlaygroundConfig хранит настройки песочницы API (/playground): тестовые пользователи каждой роли
+// и выпуск для них токенов без входа. Только для разработки и QA: в production включить нельзя.
+type PlaygroundConfig struct {
+	Enabled bool // Включается только явно: PLAYGROUND_ENABLED=true
+}
+
+// PushConfig хранит настройки push-уведомлений: FCM для устройств android, APNs для ios.
+// Для платформы без учётных данных push-уведомления записываются в лог вместо отправки.
+type PushConfig struct {
+	Interval  time.Duration // Период проверки очереди push-копий уведомлений
+	BatchSize int           // Уведомлений за один запуск воркера
+	Timeout   time.Duration // Таймаут запроса к FCM и APNs
+
+	// FCMCredentialsFile — JSON-ключ сервисного аккаунта Firebase с правом отправки сообщений.
+	FCMCredentialsFile string
+
+	APNsKeyFile string // Ключ .p8 из Apple Developer (Keys → Apple Push Notifications service)
+	APNsKeyID   string // ID ключа .p8
+	APNsTeamID  string // ID команды Apple Developer
+	APNsTopic   string // Bundle ID приложения
+	// APNsEnvironment — sandbox (отладочные сборки) или production (App Store и TestFlight).
+	// По умолчанию production при APP_ENV=production, иначе sandbox.
+	APNsEnvironment string
+}
+
+// ReminderConfig хранит настройки напоминаний о тренировках дня.
+type ReminderConfig struct {
+	Interval  time.Duration // Период проверки, у кого из пользователей наступило время напоминания
+	BatchSize int           // Пользователей, читаемых из БД за один запрос
+}
+
+// WeeklySummaryConfig хранит настройки еженедельной сводки тренировок письмом.
+type WeeklySummaryConfig struct {
+	Interval  time.Duration // Период проверки, у кого из пользователей наступило время сводки
+	BatchSize int           // Пользователей, читаемых из БД за один запрос
+	SendHour  int           // Час понедельника по часовому поясу пользователя, с которого отправляется сводка
+}
+
+// HTTPClientConfig хранит настройки HTTP-клиентов внешних сервисов (OAuth-провайдеры, почтовые API,
+// хранилище, webhooks). Таймауты отдельных интеграций (EMAIL_PROVIDER_TIMEOUT, WEBHOOK_TIMEOUT) имеют приоритет.
+type HTTPClientConfig struct {
+	Timeout         time.Duration // Таймаут запроса по умолчанию
+	MaxConnsPerHost int           // Одновременных соединений с одним хостом
+	MaxRetries      int           // Повторных попыток идемпотентных запросов; 0 отключает повторы
+	RetryBase       time.Duration // Задержка перед первым повтором; далее удваивается
+	RetryMax        time.Duration // Максимальная задержка между попытками
+}
+
+// RedisConfig хранит конфигурацию подключения к Redis.
+// Redis опционален: при пустом URL используются in-memory реализации.
+type RedisConfig struct {
+	URL string // URL подключения, например redis://localhost:6379/0
+
+	// UserProfileCacheTTL — время жизни профилей пользователей в кеше Redis.
+	// 0 отключает кеш; без REDIS_URL кеш не используется.
+	UserProfileCacheTTL time.Duration
+
+	// FeedCacheTTL — время жизни страниц ленты активности в кеше Redis. Подписки и новые тренировки
+	// попадают в ленту по истечении этого срока. 0 отключает кеш; без REDIS_URL кеш не используется.
+	FeedCacheTTL time.Duration
+
+	// JobLockTTL — срок блокировки периодической задачи; блокировка продлевается, пока запуск идёт.
+	// Без REDIS_URL блокировки не используются: задачи выполняет каждый инстанс.
+	JobLockTTL time.Duration
+}
+
+// RateLimitConfig хранит конфигурацию ограничения частоты запросов к auth-эндпоинтам.
+// Лимиты задаются на окно Window отдельно по IP клиента и по email из тела запроса.
+type RateLimitConfig struct {
+	Enabled        bool          // Включено ли ограничение
+	Backend        string        // Хранилище счётчиков: memory или redis
+	Window         time.Duration // Длина окна
+	LoginPerIP     int           // Попыток входа с одного IP за окно
+	LoginPerEmail  int           // Попыток входа в один аккаунт за окно
+	RegisterPerIP  int           // Регистраций с одного IP за окно
+	VerifyPerIP    int           // Попыток подтверждения email с одного IP за окно
+	VerifyPerEmail int           // Попыток подтверждения одного email за окно
+	ResendPerIP    int           // Повторных отправок кода с одного IP за окно
+	ResendPerEmail int           // Повторных отправок кода на один email за окно
+	CheckPerIP     int           // Проверок занятости username/email с одного IP за окно
+	SharedPerIP    int           // Открытий публичных ссылок на тренировки и программы с одного IP за окно
+}
+
+// StorageConfig хранит конфигурацию хранилища пользовательских файлов (аватаров, видео упражнений).
+type StorageConfig struct {
+	Backend        string // Хранилище: local или s3
+	LocalDir       string // Каталог для файлов при Backend=local
+	LocalURLPrefix string // Путь, по которому сервер раздаёт локальные файлы, например /uploads
+	PublicBaseURL  string // Внешний адрес сервиса для ссылок на локальные файлы (пусто — относительные ссылки)
+
+	S3Endpoint  string // Адрес S3-совместимого API
+	S3Region    string // Регион для подписи запросов
+	S3Bucket    string // Имя бакета
+	S3AccessKey string // Ключ доступа
+	S3SecretKey string // Секретный ключ
+	S3PathStyle bool   // Адресация endpoint/bucket/key (MinIO)
+	S3PublicURL string // Базовый URL публичных ссылок (CDN); пусто — адрес бакета
+
+	AvatarMaxBytes int64 // Максимальный размер загружаемого аватара
+
+	VideoMaxBytes  int64         // Максимальный размер загружаемого видео упражнения
+	VideoLinkTTL   time.Duration // Срок действия подписанной ссылки на воспроизведение видео
+	VideoURLSecret string        // Ключ подписи ссылок на видео; пусто — выводится из JWT_ACCESS_SECRET
+
+	BackupMaxBytes    int64 // Максимальный размер одной версии зашифрованной резервной копии
+	BackupQuotaBytes  int64 // Суммарный размер хранимых версий резервной копии пользователя
+	BackupMaxVersions int   // Сколько последних версий резервной копии хранить
 }
 
 // DSN возвращает строку подключения к базе данных
@@ -82,6 +411,22 @@ func (s *ServerConfig) Address() string {
 	return fmt.Sprintf("%s:%s", s.Host, s.Port)
 }
 
+// LoadServer загружает только конфигурацию HTTP-сервера, без проверки остальных секций.
+// Нужна утилитам рядом с сервером (например, cmd/healthcheck), которым не заданы секреты.
+func LoadServer() ServerConfig {
+	_ = godotenv.Load()
+	return loadServer()
+}
+
+func loadServer() ServerConfig {
+	return ServerConfig{
+		Host:           getEnv("SERVER_HOST", "localhost"),
+		Port:           getEnv("SERVER_PORT", "8080"),
+		TimingEnabled:  getEnv("SERVER_TIMING_ENABLED", "true") == "true",
+		TrustedProxies: getEnvAsSlice("SERVER_TRUSTED_PROXIES", nil),
+	}
+}
+
 // Load загружает конфигурацию из переменных окружения
 func Load() (*Config, error) {
 	// Загружаем .env файл (если существует)
@@ -91,8 +436,13 @@ func Load() (*Config, error) {
 	cfg := &Config{}
 
 	// Загружаем конфигурацию сервера
-	cfg.Server.Host = getEnv("SERVER_HOST", "localhost")
-	cfg.Server.Port = getEnv("SERVER_PORT", "8080")
+	cfg.Server = loadServer()
+
+	// Загружаем настройки запуска
+	cfg.Startup = StartupConfig{
+		Timeout:          getEnvAsDuration("STARTUP_TIMEOUT", 2*time.Minute),
+		PasswordHashWarn: getEnvAsDuration("STARTUP_PASSWORD_HASH_WARN", 500*time.Millisecond),
+	}
 
 	// Загружаем конфигурацию базы данных
 	cfg.Database.Host = getEnv("DB_HOST", "localhost")
@@ -107,10 +457,18 @@ func Load() (*Config, error) {
 	cfg.Database.MaxIdleConns = getEnvAsInt("DB_MAX_IDLE_CONNS", 5)
 	cfg.Database.ConnMaxLifetime = getEnvAsDuration("DB_CONN_MAX_LIFETIME", 5*time.Minute)
 	cfg.Database.ConnMaxIdleTime = getEnvAsDuration("DB_CONN_MAX_IDLE_TIME", 10*time.Minute)
+	cfg.Database.UserFastPath = getEnv("DB_USER_FAST_PATH", "off")
+	cfg.Database.UserFastPathMaxConns = getEnvAsInt("DB_USER_FAST_PATH_MAX_CONNS", 10)
 
 	// Загружаем окружение приложения
 	cfg.AppEnv = getEnv("APP_ENV", "development")
 
+	// Загружаем настройки логирования
+	cfg.Log = LogConfig{
+		Level:  strings.ToLower(getEnv("LOG_LEVEL", "info")),
+		Format: strings.ToLower(getEnv("LOG_FORMAT", "json")),
+	}
+
 	// Загружаем конфигурацию JWT
 	cfg.JWT = JWTConfig{
 		AccessSecret:  getEnv("JWT_ACCESS_SECRET", ""),
@@ -118,18 +476,236 @@ func Load() (*Config, error) {
 		AccessTTL:     getEnvAsDuration("JWT_ACCESS_TTL", 15*time.Minute),
 		RefreshTTL:    getEnvAsDuration("JWT_REFRESH_TTL", 7*24*time.Hour),
 		Issuer:        getEnv("JWT_ISSUER", "workout-app"),
+
+		AccessKeys:             getEnvAsKeyset("JWT_ACCESS_KEYS"),
+		AccessKeyID:            getEnv("JWT_ACCESS_KEY_ID", ""),
+		RefreshKeys:            getEnvAsKeyset("JWT_REFRESH_KEYS"),
+		RefreshKeyID:           getEnv("JWT_REFRESH_KEY_ID", ""),
+		AcceptTokensWithoutKID: getEnv("JWT_ACCEPT_TOKENS_WITHOUT_KID", "true") == "true",
+		SigningAlgorithm:       getEnv("JWT_SIGNING_ALGORITHM", "HS256"),
+		PrivateKeyPath:         getEnv("JWT_PRIVATE_KEY_PATH", ""),
+		PublicKeyPaths:         getEnvAsSlice("JWT_PUBLIC_KEY_PATHS", nil),
 	}
 
 	// Загружаем конфигурацию Email/verification
 	cfg.Email = EmailConfig{
+		Provider:                getEnv("EMAIL_PROVIDER", "smtp"),
+		ProviderTimeout:         getEnvAsDuration("EMAIL_PROVIDER_TIMEOUT", 10*time.Second),
+		SendGridAPIKey:          getEnv("EMAIL_SENDGRID_API_KEY", ""),
+		SESRegion:               getEnv("EMAIL_SES_REGION", ""),
+		SESAccessKeyID:          getEnv("EMAIL_SES_ACCESS_KEY_ID", ""),
+		SESSecretAccessKey:      getEnv("EMAIL_SES_SECRET_ACCESS_KEY", ""),
+		MailgunAPIKey:           getEnv("EMAIL_MAILGUN_API_KEY", ""),
+		MailgunDomain:           getEnv("EMAIL_MAILGUN_DOMAIN", ""),
+		MailgunBaseURL:          getEnv("EMAIL_MAILGUN_BASE_URL", "https://api.mailgun.net"),
 		SMTPHost:                getEnv("EMAIL_SMTP_HOST", ""),
 		SMTPPort:                getEnvAsInt("EMAIL_SMTP_PORT", 587),
 		SMTPUsername:            getEnv("EMAIL_SMTP_USER", ""),
 		SMTPPassword:            getEnv("EMAIL_SMTP_PASSWORD", ""),
+		SMTPTLS:                 getEnv("EMAIL_SMTP_TLS", "starttls"),
+		SMTPTimeout:             getEnvAsDuration("EMAIL_SMTP_TIMEOUT", 10*time.Second),
+		SMTPPoolSize:            getEnvAsInt("EMAIL_SMTP_POOL_SIZE", 4),
+		SMTPIdleTimeout:         getEnvAsDuration("EMAIL_SMTP_IDLE_TIMEOUT", 30*time.Second),
+		SMTPMaxMessagesPerConn:  getEnvAsInt("EMAIL_SMTP_MAX_MESSAGES_PER_CONN", 100),
 		FromEmail:               getEnv("EMAIL_FROM", ""),
 		VerificationTTL:         getEnvAsDuration("EMAIL_VERIFICATION_TTL", 15*time.Minute),
 		VerificationMaxAttempts: getEnvAsInt("EMAIL_VERIFICATION_MAX_ATTEMPTS", 5),
 		VerificationCodeLength:  getEnvAsInt("EMAIL_VERIFICATION_CODE_LENGTH", 6),
+
+		PasswordChangeConfirmRoles: getEnvAsSlice("EMAIL_PASSWORD_CHANGE_CONFIRM_ROLES", nil),
+		WebhookSecret:              getEnv("EMAIL_WEBHOOK_SECRET", ""),
+		TenantSecretKey:            getEnv("EMAIL_TENANT_SECRET_KEY", ""),
+		TenantMaxPerHour:           getEnvAsInt("EMAIL_TENANT_MAX_PER_HOUR", 500),
+		DefaultLocale:              getEnv("EMAIL_DEFAULT_LOCALE", "en"),
+		FoldGmail:                  getEnv("EMAIL_FOLD_GMAIL", "false") == "true",
+		FoldPlusTags:               getEnv("EMAIL_FOLD_PLUS_TAGS", "false") == "true",
+	}
+
+	// Загружаем настройки очереди исходящих писем
+	cfg.Outbox = OutboxConfig{
+		Interval:    getEnvAsDuration("EMAIL_OUTBOX_INTERVAL", 5*time.Second),
+		BatchSize:   getEnvAsInt("EMAIL_OUTBOX_BATCH_SIZE", 50),
+		MaxAttempts: getEnvAsInt("EMAIL_OUTBOX_MAX_ATTEMPTS", 8),
+		RetryBase:   getEnvAsDuration("EMAIL_OUTBOX_RETRY_BASE", 30*time.Second),
+		RetryMax:    getEnvAsDuration("EMAIL_OUTBOX_RETRY_MAX", time.Hour),
+		Retention:   getEnvAsDuration("EMAIL_OUTBOX_RETENTION", 7*24*time.Hour),
+	}
+
+	// Загружаем настройки webhooks
+	cfg.Webhook = WebhookConfig{
+		SecretKey:   getEnv("WEBHOOK_SECRET_KEY", ""),
+		Interval:    getEnvAsDuration("WEBHOOK_INTERVAL", 5*time.Second),
+		BatchSize:   getEnvAsInt("WEBHOOK_BATCH_SIZE", 50),
+		MaxAttempts: getEnvAsInt("WEBHOOK_MAX_ATTEMPTS", 10),
+		RetryBase:   getEnvAsDuration("WEBHOOK_RETRY_BASE", 30*time.Second),
+		RetryMax:    getEnvAsDuration("WEBHOOK_RETRY_MAX", 6*time.Hour),
+		Timeout:     getEnvAsDuration("WEBHOOK_TIMEOUT", 10*time.Second),
+		Retention:   getEnvAsDuration("WEBHOOK_RETENTION", 7*24*time.Hour),
+	}
+
+	// Загружаем настройки песочницы API
+	cfg.Playground = PlaygroundConfig{
+		Enabled: getEnv("PLAYGROUND_ENABLED", "false") == "true",
+	}
+
+	// Загружаем настройки push-уведомлений
+	apnsEnvironment := "sandbox"
+	if cfg.AppEnv == "production" {
+		apnsEnvironment = "production"
+	}
+	cfg.Push = PushConfig{
+		Interval:           getEnvAsDuration("PUSH_INTERVAL", 5*time.Second),
+		BatchSize:          getEnvAsInt("PUSH_BATCH_SIZE", 100),
+		Timeout:            getEnvAsDuration("PUSH_TIMEOUT", 10*time.Second),
+		FCMCredentialsFile: getEnv("PUSH_FCM_CREDENTIALS_FILE", ""),
+		APNsKeyFile:        getEnv("PUSH_APNS_KEY_FILE", ""),
+		APNsKeyID:          getEnv("PUSH_APNS_KEY_ID", ""),
+		APNsTeamID:         getEnv("PUSH_APNS_TEAM_ID", ""),
+		APNsTopic:          getEnv("PUSH_APNS_TOPIC", ""),
+		APNsEnvironment:    getEnv("PUSH_APNS_ENVIRONMENT", apnsEnvironment),
+	}
+
+	// Загружаем настройки напоминаний о тренировках
+	cfg.Reminder = ReminderConfig{
+		Interval:  getEnvAsDuration("REMINDER_INTERVAL", time.Minute),
+		BatchSize: getEnvAsInt("REMINDER_BATCH_SIZE", 500),
+	}
+
+	// Загружаем настройки еженедельной сводки тренировок
+	cfg.WeeklySummary = WeeklySummaryConfig{
+		Interval:  getEnvAsDuration("WEEKLY_SUMMARY_INTERVAL", 15*time.Minute),
+		BatchSize: getEnvAsInt("WEEKLY_SUMMARY_BATCH_SIZE", 500),
+		SendHour:  getEnvAsInt("WEEKLY_SUMMARY_SEND_HOUR", 9),
+	}
+
+	// Загружаем настройки HTTP-клиентов внешних сервисов
+	cfg.HTTPClient = HTTPClientConfig{
+		Timeout:         getEnvAsDuration("HTTP_CLIENT_TIMEOUT", 10*time.Second),
+		MaxConnsPerHost: getEnvAsInt("HTTP_CLIENT_MAX_CONNS_PER_HOST", 32),
+		MaxRetries:      getEnvAsInt("HTTP_CLIENT_MAX_RETRIES", 2),
+		RetryBase:       getEnvAsDuration("HTTP_CLIENT_RETRY_BASE", 200*time.Millisecond),
+		RetryMax:        getEnvAsDuration("HTTP_CLIENT_RETRY_MAX", 5*time.Second),
+	}
+
+	// Загружаем конфигурацию Redis и ограничения частоты запросов
+	cfg.Redis = RedisConfig{
+		URL:                 getEnv("REDIS_URL", ""),
+		UserProfileCacheTTL: getEnvAsDuration("CACHE_USER_PROFILE_TTL", 5*time.Minute),
+		FeedCacheTTL:        getEnvAsDuration("CACHE_FEED_TTL", 30*time.Second),
+		JobLockTTL:          getEnvAsDuration("REDIS_JOB_LOCK_TTL", 30*time.Second),
+	}
+	cfg.RateLimit = RateLimitConfig{
+		Enabled:        getEnv("RATE_LIMIT_ENABLED", "true") == "true",
+		Backend:        getEnv("RATE_LIMIT_BACKEND", "memory"),
+		Window:         getEnvAsDuration("RATE_LIMIT_WINDOW", 15*time.Minute),
+		LoginPerIP:     getEnvAsInt("RATE_LIMIT_LOGIN_PER_IP", 50),
+		LoginPerEmail:  getEnvAsInt("RATE_LIMIT_LOGIN_PER_EMAIL", 10),
+		RegisterPerIP:  getEnvAsInt("RATE_LIMIT_REGISTER_PER_IP", 10),
+		VerifyPerIP:    getEnvAsInt("RATE_LIMIT_VERIFY_PER_IP", 30),
+		VerifyPerEmail: getEnvAsInt("RATE_LIMIT_VERIFY_PER_EMAIL", 10),
+		ResendPerIP:    getEnvAsInt("RATE_LIMIT_RESEND_PER_IP", 10),
+		ResendPerEmail: getEnvAsInt("RATE_LIMIT_RESEND_PER_EMAIL", 3),
+		CheckPerIP:     getEnvAsInt("RATE_LIMIT_CHECK_PER_IP", 60),
+		SharedPerIP:    getEnvAsInt("RATE_LIMIT_SHARED_PER_IP", 300),
+	}
+
+	// Загружаем конфигурацию хранилища файлов
+	cfg.Storage = StorageConfig{
+		Backend:        getEnv("STORAGE_BACKEND", "local"),
+		LocalDir:       getEnv("STORAGE_LOCAL_DIR", "./uploads"),
+		LocalURLPrefix: getEnv("STORAGE_LOCAL_URL_PREFIX", "/uploads"),
+		PublicBaseURL:  getEnv("STORAGE_PUBLIC_BASE_URL", ""),
+		S3Endpoint:     getEnv("STORAGE_S3_ENDPOINT", ""),
+		S3Region:       getEnv("STORAGE_S3_REGION", "us-east-1"),
+		S3Bucket:       getEnv("STORAGE_S3_BUCKET", ""),
+		S3AccessKey:    getEnv("STORAGE_S3_ACCESS_KEY", ""),
+		S3SecretKey:    getEnv("STORAGE_S3_SECRET_KEY", ""),
+		S3PathStyle:    getEnv("STORAGE_S3_PATH_STYLE", "false") == "true",
+		S3PublicURL:    getEnv("STORAGE_S3_PUBLIC_URL", ""),
+		AvatarMaxBytes: int64(getEnvAsInt("STORAGE_AVATAR_MAX_BYTES", 5<<20)),
+		VideoMaxBytes:  int64(getEnvAsInt("STORAGE_VIDEO_MAX_BYTES", 200<<20)),
+		VideoLinkTTL:   getEnvAsDuration("STORAGE_VIDEO_LINK_TTL", time.Hour),
+		VideoURLSecret: getEnv("STORAGE_VIDEO_URL_SECRET", ""),
+
+		BackupMaxBytes:    int64(getEnvAsInt("STORAGE_BACKUP_MAX_BYTES", 50<<20)),
+		BackupQuotaBytes:  int64(getEnvAsInt("STORAGE_BACKUP_QUOTA_BYTES", 150<<20)),
+		BackupMaxVersions: getEnvAsInt("STORAGE_BACKUP_MAX_VERSIONS", 3),
+	}
+
+	// Загружаем настройки регионов
+	cfg.Region = RegionConfig{
+		CountryHeader:    getEnv("REGION_COUNTRY_HEADER", "CF-IPCountry"),
+		DisabledFeatures: getEnvAsSlice("REGION_DISABLED_FEATURES", nil),
+	}
+
+	// Загружаем настройки фоновой очистки
+	cfg.Cleanup = CleanupConfig{
+		Interval:  getEnvAsDuration("CLEANUP_INTERVAL", time.Hour),
+		BatchSize: getEnvAsInt("CLEANUP_BATCH_SIZE", 1000),
+	}
+
+	// Загружаем срок хранения удалённых аккаунтов
+	cfg.Retention = RetentionConfig{
+		DeletedUserDays: getEnvAsInt("RETENTION_DELETED_USER_DAYS", 30),
+		Interval:        getEnvAsDuration("RETENTION_INTERVAL", 6*time.Hour),
+	}
+
+	// Загружаем настройки тренировок
+	cfg.Workout = WorkoutConfig{
+		AutoPauseAfter: getEnvAsDuration("WORKOUT_AUTO_PAUSE_AFTER", 5*time.Minute),
+		ValidationMode: getEnv("WORKOUT_VALIDATION_MODE", "warn"),
+		MaxWeightKg:    getEnvAsInt("WORKOUT_MAX_WEIGHT_KG", 500),
+		MaxReps:        getEnvAsInt("WORKOUT_MAX_REPS", 200),
+		MaxDuration:    getEnvAsDuration("WORKOUT_MAX_DURATION", 6*time.Hour),
+	}
+
+	// Загружаем настройки выгрузки данных аккаунта
+	cfg.Export = ExportConfig{
+		TTL:      getEnvAsDuration("EXPORT_TTL", 7*24*time.Hour),
+		Interval: getEnvAsDuration("EXPORT_INTERVAL", 30*time.Second),
+	}
+
+	// Загружаем настройки черновиков
+	cfg.Draft = DraftConfig{
+		TTL: getEnvAsDuration("DRAFT_TTL", 7*24*time.Hour),
+	}
+
+	// Загружаем настройки импорта исторических данных
+	cfg.Import = ImportConfig{
+		MaxBytes:      int64(getEnvAsInt("IMPORT_MAX_BYTES", 100<<20)),
+		MaxRecords:    getEnvAsInt("IMPORT_MAX_RECORDS", 100000),
+		BatchSize:     getEnvAsInt("IMPORT_BATCH_SIZE", 200),
+		Interval:      getEnvAsDuration("IMPORT_INTERVAL", 5*time.Second),
+		UploadTimeout: getEnvAsDuration("IMPORT_UPLOAD_TIMEOUT", 10*time.Minute),
+		Retention:     getEnvAsDuration("IMPORT_RETENTION", 30*24*time.Hour),
+	}
+
+	// Загружаем настройки импорта из Apple Health и Google Fit
+	cfg.HealthSync = HealthSyncConfig{
+		MaxBytes: int64(getEnvAsInt("HEALTH_SYNC_MAX_BYTES", 20<<20)),
+		MaxItems: getEnvAsInt("HEALTH_SYNC_MAX_ITEMS", 50000),
+	}
+
+	// Загружаем настройки входа через Google и Apple
+	cfg.OAuth = OAuthConfig{
+		GoogleClientIDs: getEnvAsSlice("OAUTH_GOOGLE_CLIENT_IDS", nil),
+		AppleClientIDs:  getEnvAsSlice("OAUTH_APPLE_CLIENT_IDS", nil),
+	}
+
+	// Загружаем политику паролей
+	cfg.Password = PasswordConfig{
+		MinLength:       getEnvAsInt("PASSWORD_MIN_LENGTH", 8),
+		RequiredClasses: getEnvAsSlice("PASSWORD_REQUIRED_CLASSES", nil),
+		DenyCommon:      getEnv("PASSWORD_DENY_COMMON", "true") == "true",
+		Denylist:        getEnvAsSlice("PASSWORD_DENYLIST", nil),
+	}
+
+	// Загружаем правила username
+	cfg.Username = UsernameConfig{
+		ReservationPeriod: getEnvAsDuration("USERNAME_RESERVATION_PERIOD", 30*24*time.Hour),
+		BlockReserved:     getEnv("USERNAME_BLOCK_RESERVED", "true") == "true",
+		ProfanityLocales:  getEnvAsSlice("USERNAME_PROFANITY_LOCALES", []string{"en", "ru"}),
+		Blocklist:         getEnvAsSlice("USERNAME_BLOCKLIST", nil),
 	}
 
 	// Загружаем конфигурацию CORS
@@ -151,6 +727,19 @@ func (c *Config) Validate() error {
 	if c.Server.Port == "" {
 		return fmt.Errorf("SERVER_PORT must not be empty")
 	}
+	for _, proxy := range c.Server.TrustedProxies {
+		if net.ParseIP(proxy) == nil {
+			if _, _, err := net.ParseCIDR(proxy); err != nil {
+				return fmt.Errorf("SERVER_TRUSTED_PROXIES must contain IP addresses or CIDR ranges, got %q", proxy)
+			}
+		}
+	}
+	if c.Startup.Timeout <= 0 {
+		return fmt.Errorf("STARTUP_TIMEOUT must be positive")
+	}
+	if c.Startup.PasswordHashWarn <= 0 {
+		return fmt.Errorf("STARTUP_PASSWORD_HASH_WARN must be positive")
+	}
 	if c.Database.Host == "" {
 		return fmt.Errorf("DB_HOST must not be empty")
 	}
@@ -160,16 +749,75 @@ func (c *Config) Validate() error {
 	if c.Database.DBName == "" {
 		return fmt.Errorf("DB_NAME must not be empty")
 	}
+	switch c.Database.UserFastPath {
+	case "off", "shadow", "on":
+	default:
+		return fmt.Errorf("DB_USER_FAST_PATH must be one of off, shadow, on")
+	}
+	if c.Database.UserFastPathMaxConns <= 0 {
+		return fmt.Errorf("DB_USER_FAST_PATH_MAX_CONNS must be positive")
+	}
+	switch c.Log.Level {
+	case "debug", "info", "warn", "error":
+	default:
+		return fmt.Errorf("LOG_LEVEL must be one of debug, info, warn, error")
+	}
+	if c.Log.Format != "json" && c.Log.Format != "text" {
+		return fmt.Errorf("LOG_FORMAT must be json or text")
+	}
 	if c.JWT.AccessSecret == "" {
 		return fmt.Errorf("JWT_ACCESS_SECRET must not be empty")
 	}
 	if c.JWT.RefreshSecret == "" {
 		return fmt.Errorf("JWT_REFRESH_SECRET must not be empty")
 	}
+	if err := validateKeyset("JWT_ACCESS", c.JWT.AccessKeys, c.JWT.AccessKeyID, c.JWT.AcceptTokensWithoutKID); err != nil {
+		return err
+	}
+	if err := validateKeyset("JWT_REFRESH", c.JWT.RefreshKeys, c.JWT.RefreshKeyID, c.JWT.AcceptTokensWithoutKID); err != nil {
+		return err
+	}
+	switch c.JWT.SigningAlgorithm {
+	case "HS256":
+		if c.JWT.PrivateKeyPath != "" || len(c.JWT.PublicKeyPaths) > 0 {
+			return fmt.Errorf("JWT_PRIVATE_KEY_PATH and JWT_PUBLIC_KEY_PATHS require JWT_SIGNING_ALGORITHM RS256 or EdDSA")
+		}
+	case "RS256", "EdDSA":
+		if c.JWT.PrivateKeyPath == "" {
+			return fmt.Errorf("JWT_PRIVATE_KEY_PATH is required for JWT_SIGNING_ALGORITHM %s", c.JWT.SigningAlgorithm)
+		}
+	default:
+		return fmt.Errorf("JWT_SIGNING_ALGORITHM must be one of HS256, RS256, EdDSA")
+	}
 
 	// Валидация email/verification настроек.
+	switch c.Email.Provider {
+	case "smtp":
+	case "sendgrid":
+		if c.Email.SendGridAPIKey == "" {
+			return fmt.Errorf("EMAIL_SENDGRID_API_KEY must be set when EMAIL_PROVIDER is sendgrid")
+		}
+	case "ses":
+		if c.Email.SESRegion == "" || c.Email.SESAccessKeyID == "" || c.Email.SESSecretAccessKey == "" {
+			return fmt.Errorf("EMAIL_SES_REGION, EMAIL_SES_ACCESS_KEY_ID and EMAIL_SES_SECRET_ACCESS_KEY must be set when EMAIL_PROVIDER is ses")
+		}
+	case "mailgun":
+		if c.Email.MailgunAPIKey == "" || c.Email.MailgunDomain == "" {
+			return fmt.Errorf("EMAIL_MAILGUN_API_KEY and EMAIL_MAILGUN_DOMAIN must be set when EMAIL_PROVIDER is mailgun")
+		}
+	default:
+		return fmt.Errorf("EMAIL_PROVIDER must be one of: smtp, sendgrid, ses, mailgun")
+	}
+	if c.Email.Provider != "smtp" {
+		if c.Email.FromEmail == "" {
+			return fmt.Errorf("EMAIL_FROM must be set when EMAIL_PROVIDER is %s", c.Email.Provider)
+		}
+		if c.Email.ProviderTimeout <= 0 {
+			return fmt.Errorf("EMAIL_PROVIDER_TIMEOUT must be positive")
+		}
+	}
 	// SMTP блок считается "выключенным", если не задан EMAIL_SMTP_HOST.
-	if c.Email.SMTPHost != "" {
+	if c.Email.Provider == "smtp" && c.Email.SMTPHost != "" {
 		if c.Email.SMTPPort <= 0 {
 			return fmt.Errorf("EMAIL_SMTP_PORT must be positive")
 		}
@@ -182,6 +830,23 @@ func (c *Config) Validate() error {
 		if c.Email.FromEmail == "" {
 			return fmt.Errorf("EMAIL_FROM must be set when EMAIL_SMTP_HOST is set")
 		}
+		switch c.Email.SMTPTLS {
+		case "starttls", "implicit", "none":
+		default:
+			return fmt.Errorf("EMAIL_SMTP_TLS must be one of: starttls, implicit, none")
+		}
+		if c.Email.SMTPTimeout <= 0 {
+			return fmt.Errorf("EMAIL_SMTP_TIMEOUT must be positive")
+		}
+		if c.Email.SMTPPoolSize < 0 {
+			return fmt.Errorf("EMAIL_SMTP_POOL_SIZE must not be negative")
+		}
+		if c.Email.SMTPIdleTimeout <= 0 {
+			return fmt.Errorf("EMAIL_SMTP_IDLE_TIMEOUT must be positive")
+		}
+		if c.Email.SMTPMaxMessagesPerConn < 0 {
+			return fmt.Errorf("EMAIL_SMTP_MAX_MESSAGES_PER_CONN must not be negative")
+		}
 	}
 	if c.Email.VerificationTTL <= 0 {
 		return fmt.Errorf("EMAIL_VERIFICATION_TTL must be positive")
@@ -189,9 +854,303 @@ func (c *Config) Validate() error {
 	if c.Email.VerificationMaxAttempts <= 0 {
 		return fmt.Errorf("EMAIL_VERIFICATION_MAX_ATTEMPTS must be positive")
 	}
+	for _, role := range c.Email.PasswordChangeConfirmRoles {
+		if role != "user" && role != "coach" && role != "admin" {
+			return fmt.Errorf("EMAIL_PASSWORD_CHANGE_CONFIRM_ROLES contains unknown role %q", role)
+		}
+	}
+	if c.Email.DefaultLocale != "ru" && c.Email.DefaultLocale != "en" {
+		return fmt.Errorf("EMAIL_DEFAULT_LOCALE must be one of: ru, en")
+	}
+	if c.Outbox.Interval <= 0 {
+		return fmt.Errorf("EMAIL_OUTBOX_INTERVAL must be positive")
+	}
+	if c.Outbox.BatchSize <= 0 {
+		return fmt.Errorf("EMAIL_OUTBOX_BATCH_SIZE must be positive")
+	}
+	if c.Outbox.MaxAttempts <= 0 {
+		return fmt.Errorf("EMAIL_OUTBOX_MAX_ATTEMPTS must be positive")
+	}
+	if c.Outbox.RetryBase <= 0 || c.Outbox.RetryMax < c.Outbox.RetryBase {
+		return fmt.Errorf("EMAIL_OUTBOX_RETRY_BASE must be positive and not greater than EMAIL_OUTBOX_RETRY_MAX")
+	}
+	if c.Outbox.Retention <= 0 {
+		return fmt.Errorf("EMAIL_OUTBOX_RETENTION must be positive")
+	}
+	if c.Webhook.SecretKey != "" {
+		if _, err := secretbox.ParseKey(c.Webhook.SecretKey); err != nil {
+			return fmt.Errorf("WEBHOOK_SECRET_KEY must be 32 bytes encoded in base64")
+		}
+	}
+	if c.Webhook.Interval <= 0 {
+		return fmt.Errorf("WEBHOOK_INTERVAL must be positive")
+	}
+	if c.Webhook.BatchSize <= 0 {
+		return fmt.Errorf("WEBHOOK_BATCH_SIZE must be positive")
+	}
+	if c.Webhook.MaxAttempts <= 0 {
+		return fmt.Errorf("WEBHOOK_MAX_ATTEMPTS must be positive")
+	}
+	if c.Webhook.RetryBase <= 0 || c.Webhook.RetryMax < c.Webhook.RetryBase {
+		return fmt.Errorf("WEBHOOK_RETRY_BASE must be positive and not greater than WEBHOOK_RETRY_MAX")
+	}
+	if c.Webhook.Timeout <= 0 {
+		return fmt.Errorf("WEBHOOK_TIMEOUT must be positive")
+	}
+	if c.Webhook.Retention <= 0 {
+		return fmt.Errorf("WEBHOOK_RETENTION must be positive")
+	}
+	// Песочница выпускает токены без пароля, в том числе администратора.
+	if c.Playground.Enabled && c.AppEnv == "production" {
+		return fmt.Errorf("PLAYGROUND_ENABLED must not be true when APP_ENV=production")
+	}
+	if c.Push.Interval <= 0 {
+		return fmt.Errorf("PUSH_INTERVAL must be positive")
+	}
+	if c.Push.BatchSize <= 0 {
+		return fmt.Errorf("PUSH_BATCH_SIZE must be positive")
+	}
+	if c.Push.Timeout <= 0 {
+		return fmt.Errorf("PUSH_TIMEOUT must be positive")
+	}
+	if c.Push.APNsKeyFile != "" && (c.Push.APNsKeyID == "" || c.Push.APNsTeamID == "" || c.Push.APNsTopic == "") {
+		return fmt.Errorf("PUSH_APNS_KEY_ID, PUSH_APNS_TEAM_ID and PUSH_APNS_TOPIC are required with PUSH_APNS_KEY_FILE")
+	}
+	if c.Push.APNsEnvironment != "sandbox" && c.Push.APNsEnvironment != "production" {
+		return fmt.Errorf("PUSH_APNS_ENVIRONMENT must be sandbox or production")
+	}
+	// Напоминание отправляется не позже чем через 2 часа после своего времени, поэтому проверять нужно чаще.
+	if c.Reminder.Interval <= 0 || c.Reminder.Interval > time.Hour {
+		return fmt.Errorf("REMINDER_INTERVAL must be positive and at most 1h")
+	}
+	if c.Reminder.BatchSize <= 0 {
+		return fmt.Errorf("REMINDER_BATCH_SIZE must be positive")
+	}
+	if c.WeeklySummary.Interval <= 0 {
+		return fmt.Errorf("WEEKLY_SUMMARY_INTERVAL must be positive")
+	}
+	if c.WeeklySummary.BatchSize <= 0 {
+		return fmt.Errorf("WEEKLY_SUMMARY_BATCH_SIZE must be positive")
+	}
+	if c.WeeklySummary.SendHour < 0 || c.WeeklySummary.SendHour > 23 {
+		return fmt.Errorf("WEEKLY_SUMMARY_SEND_HOUR must be between 0 and 23")
+	}
+	if c.HTTPClient.Timeout <= 0 {
+		return fmt.Errorf("HTTP_CLIENT_TIMEOUT must be positive")
+	}
+	if c.HTTPClient.MaxConnsPerHost <= 0 {
+		return fmt.Errorf("HTTP_CLIENT_MAX_CONNS_PER_HOST must be positive")
+	}
+	if c.HTTPClient.MaxRetries < 0 {
+		return fmt.Errorf("HTTP_CLIENT_MAX_RETRIES must not be negative")
+	}
+	if c.HTTPClient.RetryBase <= 0 || c.HTTPClient.RetryMax < c.HTTPClient.RetryBase {
+		return fmt.Errorf("HTTP_CLIENT_RETRY_BASE must be positive and not greater than HTTP_CLIENT_RETRY_MAX")
+	}
+	// Пароли длиннее 72 байт bcrypt не хеширует.
+	if c.Password.MinLength < 1 || c.Password.MinLength > 72 {
+		return fmt.Errorf("PASSWORD_MIN_LENGTH must be between 1 and 72")
+	}
+	for _, class := range c.Password.RequiredClasses {
+		switch class {
+		case "lower", "upper", "digit", "symbol":
+		default:
+			return fmt.Errorf("PASSWORD_REQUIRED_CLASSES must contain only lower, upper, digit, symbol")
+		}
+	}
+	if c.Username.ReservationPeriod < 0 {
+		return fmt.Errorf("USERNAME_RESERVATION_PERIOD must not be negative")
+	}
+	for _, locale := range c.Username.ProfanityLocales {
+		switch locale {
+		case "en", "ru", "none":
+		default:
+			return fmt.Errorf("USERNAME_PROFANITY_LOCALES must contain only en, ru or none")
+		}
+	}
 	if c.Email.VerificationCodeLength <= 0 {
 		return fmt.Errorf("EMAIL_VERIFICATION_CODE_LENGTH must be positive")
 	}
+	if c.Email.WebhookSecret != "" && len(c.Email.WebhookSecret) < 16 {
+		return fmt.Errorf("EMAIL_WEBHOOK_SECRET must be at least 16 characters")
+	}
+	if c.Email.TenantSecretKey != "" {
+		if _, err := secretbox.ParseKey(c.Email.TenantSecretKey); err != nil {
+			return fmt.Errorf("EMAIL_TENANT_SECRET_KEY must be 32 bytes encoded in base64")
+		}
+	}
+	if c.Email.TenantMaxPerHour <= 0 {
+		return fmt.Errorf("EMAIL_TENANT_MAX_PER_HOUR must be positive")
+	}
+
+	if c.Redis.URL != "" {
+		u, err := url.Parse(c.Redis.URL)
+		if err != nil || (u.Scheme != "redis" && u.Scheme != "rediss") {
+			return fmt.Errorf("REDIS_URL must be a redis:// or rediss:// URL")
+		}
+	}
+	if c.Redis.UserProfileCacheTTL < 0 {
+		return fmt.Errorf("CACHE_USER_PROFILE_TTL must not be negative")
+	}
+	if c.Redis.FeedCacheTTL < 0 {
+		return fmt.Errorf("CACHE_FEED_TTL must not be negative")
+	}
+	if c.Redis.JobLockTTL < time.Second {
+		return fmt.Errorf("REDIS_JOB_LOCK_TTL must be at least 1s")
+	}
+
+	// Валидация ограничения частоты запросов.
+	if c.RateLimit.Enabled {
+		switch c.RateLimit.Backend {
+		case "memory":
+		case "redis":
+			if c.Redis.URL == "" {
+				return fmt.Errorf("REDIS_URL must be set when RATE_LIMIT_BACKEND=redis")
+			}
+		default:
+			return fmt.Errorf("RATE_LIMIT_BACKEND must be memory or redis")
+		}
+		if c.RateLimit.Window <= 0 {
+			return fmt.Errorf("RATE_LIMIT_WINDOW must be positive")
+		}
+		limits := []int{
+			c.RateLimit.LoginPerIP, c.RateLimit.LoginPerEmail, c.RateLimit.RegisterPerIP,
+			c.RateLimit.VerifyPerIP, c.RateLimit.VerifyPerEmail,
+			c.RateLimit.ResendPerIP, c.RateLimit.ResendPerEmail,
+		}
+		for _, limit := range limits {
+			if limit <= 0 {
+				return fmt.Errorf("RATE_LIMIT_* limits must be positive")
+			}
+		}
+	}
+	switch c.Storage.Backend {
+	case "local":
+		if c.Storage.LocalDir == "" || !strings.HasPrefix(c.Storage.LocalURLPrefix, "/") {
+			return fmt.Errorf("STORAGE_LOCAL_DIR must be set and STORAGE_LOCAL_URL_PREFIX must start with /")
+		}
+	case "s3":
+		if c.Storage.S3Endpoint == "" || c.Storage.S3Bucket == "" || c.Storage.S3AccessKey == "" || c.Storage.S3SecretKey == "" {
+			return fmt.Errorf("STORAGE_S3_ENDPOINT, STORAGE_S3_BUCKET and credentials must be set when STORAGE_BACKEND=s3")
+		}
+		if u, err := url.Parse(c.Storage.S3Endpoint); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("STORAGE_S3_ENDPOINT must be an http(s) URL")
+		}
+		if c.Storage.S3Region == "" {
+			return fmt.Errorf("STORAGE_S3_REGION must not be empty")
+		}
+	default:
+		return fmt.Errorf("STORAGE_BACKEND must be local or s3")
+	}
+	if c.Storage.AvatarMaxBytes <= 0 {
+		return fmt.Errorf("STORAGE_AVATAR_MAX_BYTES must be positive")
+	}
+	if c.Storage.VideoMaxBytes <= 0 {
+		return fmt.Errorf("STORAGE_VIDEO_MAX_BYTES must be positive")
+	}
+	if c.Storage.VideoLinkTTL < time.Minute || c.Storage.VideoLinkTTL > 7*24*time.Hour {
+		return fmt.Errorf("STORAGE_VIDEO_LINK_TTL must be between 1m and 168h")
+	}
+	if c.Storage.BackupMaxBytes <= 0 {
+		return fmt.Errorf("STORAGE_BACKUP_MAX_BYTES must be positive")
+	}
+	if c.Storage.BackupQuotaBytes < c.Storage.BackupMaxBytes {
+		return fmt.Errorf("STORAGE_BACKUP_QUOTA_BYTES must not be less than STORAGE_BACKUP_MAX_BYTES")
+	}
+	if c.Storage.BackupMaxVersions < 1 {
+		return fmt.Errorf("STORAGE_BACKUP_MAX_VERSIONS must be at least 1")
+	}
+	for _, entry := range c.Region.DisabledFeatures {
+		region, feature, ok := strings.Cut(entry, ":")
+		if !ok || (region != "eu" && region != "global") {
+			return fmt.Errorf("REGION_DISABLED_FEATURES entries must look like eu:feature or global:feature")
+		}
+		switch feature {
+		case "experiments", "avatar_upload", "presence":
+		default:
+			return fmt.Errorf("REGION_DISABLED_FEATURES feature must be one of experiments, avatar_upload, presence")
+		}
+	}
+	if c.Cleanup.Interval < 0 {
+		return fmt.Errorf("CLEANUP_INTERVAL must not be negative")
+	}
+	if c.Cleanup.BatchSize <= 0 {
+		return fmt.Errorf("CLEANUP_BATCH_SIZE must be positive")
+	}
+	if c.Retention.DeletedUserDays < 0 {
+		return fmt.Errorf("RETENTION_DELETED_USER_DAYS must not be negative")
+	}
+	if c.Retention.DeletedUserDays > 0 && c.Retention.Interval <= 0 {
+		return fmt.Errorf("RETENTION_INTERVAL must be positive")
+	}
+	if c.Workout.AutoPauseAfter < 30*time.Second || c.Workout.AutoPauseAfter > time.Hour {
+		return fmt.Errorf("WORKOUT_AUTO_PAUSE_AFTER must be between 30s and 1h")
+	}
+	switch c.Workout.ValidationMode {
+	case "off", "warn", "reject":
+	default:
+		return fmt.Errorf("WORKOUT_VALIDATION_MODE must be one of off, warn, reject")
+	}
+	// Границы выше жёстких ограничений записи подхода (1000 кг, 1000 повторений) не имеют смысла.
+	if c.Workout.MaxWeightKg < 1 || c.Workout.MaxWeightKg > 1000 {
+		return fmt.Errorf("WORKOUT_MAX_WEIGHT_KG must be between 1 and 1000")
+	}
+	if c.Workout.MaxReps < 1 || c.Workout.MaxReps > 1000 {
+		return fmt.Errorf("WORKOUT_MAX_REPS must be between 1 and 1000")
+	}
+	if c.Workout.MaxDuration < time.Hour || c.Workout.MaxDuration > 24*time.Hour {
+		return fmt.Errorf("WORKOUT_MAX_DURATION must be between 1h and 24h")
+	}
+	if c.Export.TTL < time.Hour || c.Export.TTL > 30*24*time.Hour {
+		return fmt.Errorf("EXPORT_TTL must be between 1h and 720h")
+	}
+	if c.Export.Interval <= 0 {
+		return fmt.Errorf("EXPORT_INTERVAL must be positive")
+	}
+	if c.Draft.TTL < time.Hour || c.Draft.TTL > 90*24*time.Hour {
+		return fmt.Errorf("DRAFT_TTL must be between 1h and 2160h")
+	}
+	if c.Import.MaxBytes <= 0 {
+		return fmt.Errorf("IMPORT_MAX_BYTES must be positive")
+	}
+	if c.Import.MaxRecords <= 0 {
+		return fmt.Errorf("IMPORT_MAX_RECORDS must be positive")
+	}
+	if c.Import.BatchSize <= 0 || c.Import.BatchSize > 1000 {
+		return fmt.Errorf("IMPORT_BATCH_SIZE must be between 1 and 1000")
+	}
+	if c.Import.Interval <= 0 {
+		return fmt.Errorf("IMPORT_INTERVAL must be positive")
+	}
+	if c.Import.UploadTimeout < time.Minute {
+		return fmt.Errorf("IMPORT_UPLOAD_TIMEOUT must be at least 1m")
+	}
+	if c.Import.Retention <= 0 {
+		return fmt.Errorf("IMPORT_RETENTION must be positive")
+	}
+	if c.HealthSync.MaxBytes <= 0 {
+		return fmt.Errorf("HEALTH_SYNC_MAX_BYTES must be positive")
+	}
+	if c.HealthSync.MaxItems <= 0 {
+		return fmt.Errorf("HEALTH_SYNC_MAX_ITEMS must be positive")
+	}
+	return nil
+}
+
+// validateKeyset проверяет набор ключей подписи токенов с префиксом переменных prefix.
+func validateKeyset(prefix string, keys map[string]string, currentKID string, acceptWithoutKID bool) error {
+	for kid, secret := range keys {
+		if kid == "" || secret == "" {
+			return fmt.Errorf("%s_KEYS must be a comma-separated list of kid:secret pairs", prefix)
+		}
+	}
+	if currentKID != "" {
+		if _, ok := keys[currentKID]; !ok {
+			return fmt.Errorf("%s_KEY_ID must name a key from %s_KEYS", prefix, prefix)
+		}
+	} else if !acceptWithoutKID {
+		return fmt.Errorf("%s_KEY_ID is required when JWT_ACCEPT_TOKENS_WITHOUT_KID is false", prefix)
+	}
 	return nil
 }
 
@@ -248,8 +1207,11 @@ func loadCORSConfig(appEnv string) CORSConfig {
 		"Accept",
 		"Accept-Encoding",
 		"X-CSRF-Token",
+		"X-App-Version",
+		"X-App-Platform",
+		"X-Request-ID",
 	}
-	defaultExposedHeaders := []string{"Content-Length", "Content-Type", "Authorization"}
+	defaultExposedHeaders := []string{"Content-Length", "Content-Type", "Authorization", "Server-Timing", "X-Request-ID", "Deprecation", "Sunset", "Link"}
 
 	cfg := CORSConfig{
 		AllowedOrigins:   getEnvAsSlice("CORS_ALLOWED_ORIGINS", defaultOrigins),
@@ -288,3 +1250,15 @@ func getEnvAsSlice(key string, defaultValue []string) []string {
 	}
 	return result
 }
+
+// getEnvAsKeyset разбирает набор ключей вида "kid1:secret1,kid2:secret2".
+// Секрет может содержать двоеточия: идентификатор отделяется по первому.
+// Некорректные элементы сохраняются с пустым kid или секретом, чтобы их отклонила валидация.
+func getEnvAsKeyset(key string) map[string]string {
+	keys := make(map[string]string)
+	for _, part := range getEnvAsSlice(key, nil) {
+		kid, secret, _ := strings.Cut(part, ":")
+		keys[strings.TrimSpace(kid)] = strings.TrimSpace(secret)
+	}
+	return keys
+}
@@ -1,6 +1,7 @@
 package database
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strings"
@@ -60,6 +61,11 @@ func NewConnection(cfg *config.DatabaseConfig, appEnv string) (*DB, error) {
 		return nil, fmt.Errorf("ошибка подключения к базе данных: %w", err)
 	}
 
+	// Учитываем SQL-запросы в заголовке Server-Timing
+	if err := registerServerTiming(db); err != nil {
+		return nil, fmt.Errorf("ошибка регистрации callbacks Server-Timing: %w", err)
+	}
+
 	// Получаем sql.DB для настройки пула соединений
 	sqlDB, err := db.DB()
 	if err != nil {
@@ -132,3 +138,17 @@ func (db *DB) Ping() error {
 
 	return nil
 }
+
+// PingContext проверяет доступность базы данных с учётом контекста (таймаута/отмены).
+func (db *DB) PingContext(ctx context.Context) error {
+	sqlDB, err := db.DB.DB()
+	if err != nil {
+		return fmt.Errorf("ошибка получения sql.DB: %w", err)
+	}
+
+	if err := sqlDB.PingContext(ctx); err != nil {
+		return fmt.Errorf("ошибка ping базы данных: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,4 @@
+-- 000005_create_body_metrics_table.down.sql
+-- Откат создания таблицы body_metrics
+
+DROP TABLE IF EXISTS body_metrics;
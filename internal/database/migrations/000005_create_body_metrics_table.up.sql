@@ -0,0 +1,23 @@
+-- 000005_create_body_metrics_table.up.sql
+-- Таблица замеров параметров тела пользователя (вес, процент жира, произвольные замеры).
+
+CREATE TABLE IF NOT EXISTS body_metrics (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    measured_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    weight_kg NUMERIC(6,2) CHECK (weight_kg IS NULL OR weight_kg > 0),
+    body_fat_percent NUMERIC(5,2) CHECK (body_fat_percent IS NULL OR (body_fat_percent >= 0 AND body_fat_percent <= 100)),
+    measurements JSONB NOT NULL DEFAULT '{}'::jsonb,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CHECK (weight_kg IS NOT NULL OR body_fat_percent IS NOT NULL OR measurements <> '{}'::jsonb)
+);
+
+CREATE INDEX IF NOT EXISTS idx_body_metrics_user_id_measured_at
+    ON body_metrics (user_id, measured_at DESC);
+
+COMMENT ON TABLE body_metrics IS 'Замеры параметров тела пользователей';
+COMMENT ON COLUMN body_metrics.user_id IS 'ID пользователя, которому принадлежит замер';
+COMMENT ON COLUMN body_metrics.measured_at IS 'Момент замера';
+COMMENT ON COLUMN body_metrics.weight_kg IS 'Вес в килограммах';
+COMMENT ON COLUMN body_metrics.body_fat_percent IS 'Процент жира в организме';
+COMMENT ON COLUMN body_metrics.measurements IS 'Произвольные замеры в формате {"название": значение}';
@@ -0,0 +1,8 @@
+-- 000006_create_experiments_tables.down.sql
+-- Откат создания таблиц экспериментов
+
+DROP TABLE IF EXISTS experiment_events;
+
+DROP TRIGGER IF EXISTS update_experiments_updated_at ON experiments;
+
+DROP TABLE IF EXISTS experiments;
@@ -0,0 +1,47 @@
+-- 000006_create_experiments_tables.up.sql
+-- Таблицы A/B-экспериментов и событий показа/конверсии.
+-- Назначения вариантов не хранятся: они вычисляются детерминированно по хэшу (user_id + key).
+
+CREATE TABLE IF NOT EXISTS experiments (
+    key VARCHAR(64) PRIMARY KEY,
+    description TEXT NOT NULL DEFAULT '',
+    variants JSONB NOT NULL,
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+DO $$
+BEGIN
+    IF NOT EXISTS (
+        SELECT 1 FROM pg_trigger WHERE tgname = 'update_experiments_updated_at'
+    ) THEN
+        CREATE TRIGGER update_experiments_updated_at
+            BEFORE UPDATE ON experiments
+            FOR EACH ROW
+            EXECUTE FUNCTION update_updated_at_column();
+    END IF;
+END;
+$$;
+
+CREATE TABLE IF NOT EXISTS experiment_events (
+    id BIGSERIAL PRIMARY KEY,
+    experiment_key VARCHAR(64) NOT NULL REFERENCES experiments(key) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    variant VARCHAR(64) NOT NULL,
+    event_type TEXT NOT NULL CHECK (event_type IN ('exposure', 'conversion')),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Индекс для агрегации результатов эксперимента по вариантам
+CREATE INDEX IF NOT EXISTS idx_experiment_events_key_type_variant
+    ON experiment_events (experiment_key, event_type, variant);
+
+CREATE INDEX IF NOT EXISTS idx_experiment_events_user_id
+    ON experiment_events (user_id);
+
+COMMENT ON TABLE experiments IS 'A/B-эксперименты';
+COMMENT ON COLUMN experiments.variants IS 'Варианты в формате [{"name": "...", "weight": N}]';
+COMMENT ON COLUMN experiments.is_active IS 'Участвует ли эксперимент в распределении пользователей';
+COMMENT ON TABLE experiment_events IS 'События показа и конверсии в экспериментах';
+COMMENT ON COLUMN experiment_events.variant IS 'Вариант, назначенный пользователю на момент события';
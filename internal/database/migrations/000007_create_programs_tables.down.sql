@@ -0,0 +1,10 @@
+-- 000007_create_programs_tables.down.sql
+-- Откат создания таблиц тренировочных программ
+
+DROP TABLE IF EXISTS program_assignments;
+
+DROP TABLE IF EXISTS program_workouts;
+
+DROP TRIGGER IF EXISTS update_programs_updated_at ON programs;
+
+DROP TABLE IF EXISTS programs;
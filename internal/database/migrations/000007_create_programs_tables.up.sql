@@ -0,0 +1,64 @@
+-- 000007_create_programs_tables.up.sql
+-- Тренировочные программы: программа -> недели -> дни -> тренировки, а также назначения программ пользователям.
+-- Недели и дни не выделены в отдельные таблицы: каждая тренировка хранит номер недели и дня.
+
+CREATE TABLE IF NOT EXISTS programs (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    owner_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    title VARCHAR(200) NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    weeks_count INTEGER NOT NULL CHECK (weeks_count BETWEEN 1 AND 52),
+    source_program_id UUID REFERENCES programs(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_programs_owner_id ON programs (owner_id, created_at DESC);
+
+DO $$
+BEGIN
+    IF NOT EXISTS (
+        SELECT 1 FROM pg_trigger WHERE tgname = 'update_programs_updated_at'
+    ) THEN
+        CREATE TRIGGER update_programs_updated_at
+            BEFORE UPDATE ON programs
+            FOR EACH ROW
+            EXECUTE FUNCTION update_updated_at_column();
+    END IF;
+END;
+$$;
+
+CREATE TABLE IF NOT EXISTS program_workouts (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    program_id UUID NOT NULL REFERENCES programs(id) ON DELETE CASCADE,
+    week_number INTEGER NOT NULL CHECK (week_number >= 1),
+    day_number INTEGER NOT NULL CHECK (day_number BETWEEN 1 AND 7),
+    position INTEGER NOT NULL CHECK (position >= 0),
+    title VARCHAR(200) NOT NULL,
+    notes TEXT NOT NULL DEFAULT '',
+    exercises JSONB NOT NULL DEFAULT '[]'::jsonb,
+    CONSTRAINT program_workouts_slot_key UNIQUE (program_id, week_number, day_number, position)
+);
+
+CREATE TABLE IF NOT EXISTS program_assignments (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    program_id UUID NOT NULL REFERENCES programs(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    assigned_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    start_date DATE NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT program_assignments_program_user_key UNIQUE (program_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_program_assignments_user_id ON program_assignments (user_id, start_date DESC);
+
+COMMENT ON TABLE programs IS 'Многонедельные тренировочные программы';
+COMMENT ON COLUMN programs.owner_id IS 'Автор программы';
+COMMENT ON COLUMN programs.weeks_count IS 'Количество недель в программе';
+COMMENT ON COLUMN programs.source_program_id IS 'Исходная программа, если эта программа является копией';
+COMMENT ON TABLE program_workouts IS 'Тренировки программы с привязкой к неделе и дню';
+COMMENT ON COLUMN program_workouts.day_number IS 'День недели: 1 (понедельник) ... 7 (воскресенье)';
+COMMENT ON COLUMN program_workouts.position IS 'Порядок тренировки внутри дня';
+COMMENT ON COLUMN program_workouts.exercises IS 'Упражнения в формате [{"name": "...", "sets": N, "reps": N, "weight_kg": N, "notes": "..."}]';
+COMMENT ON TABLE program_assignments IS 'Назначения программ пользователям';
+COMMENT ON COLUMN program_assignments.assigned_by IS 'Кто назначил программу';
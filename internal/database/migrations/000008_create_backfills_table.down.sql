@@ -0,0 +1,6 @@
+-- 000008_create_backfills_table.down.sql
+-- Откат создания таблицы backfills
+
+DROP TRIGGER IF EXISTS update_backfills_updated_at ON backfills;
+
+DROP TABLE IF EXISTS backfills;
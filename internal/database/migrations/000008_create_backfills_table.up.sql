@@ -0,0 +1,47 @@
+-- 000008_create_backfills_table.up.sql
+-- Задачи пересчёта исторических данных (backfill) с сохранением прогресса для продолжения после рестарта.
+
+CREATE TABLE IF NOT EXISTS backfills (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    job VARCHAR(64) NOT NULL,
+    status TEXT NOT NULL DEFAULT 'pending'
+        CHECK (status IN ('pending', 'running', 'completed', 'failed', 'cancelled')),
+    batch_size INTEGER NOT NULL CHECK (batch_size BETWEEN 1 AND 10000),
+    cursor_value TEXT NOT NULL DEFAULT '',
+    processed BIGINT NOT NULL DEFAULT 0,
+    total BIGINT NOT NULL DEFAULT 0,
+    last_error TEXT NOT NULL DEFAULT '',
+    cancel_requested BOOLEAN NOT NULL DEFAULT FALSE,
+    lease_owner TEXT NOT NULL DEFAULT '',
+    lease_expires_at TIMESTAMPTZ,
+    created_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    started_at TIMESTAMPTZ,
+    finished_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Не более одной незавершённой задачи каждого типа
+CREATE UNIQUE INDEX IF NOT EXISTS idx_backfills_active_job
+    ON backfills (job) WHERE status IN ('pending', 'running');
+
+CREATE INDEX IF NOT EXISTS idx_backfills_created_at ON backfills (created_at DESC);
+
+DO $$
+BEGIN
+    IF NOT EXISTS (
+        SELECT 1 FROM pg_trigger WHERE tgname = 'update_backfills_updated_at'
+    ) THEN
+        CREATE TRIGGER update_backfills_updated_at
+            BEFORE UPDATE ON backfills
+            FOR EACH ROW
+            EXECUTE FUNCTION update_updated_at_column();
+    END IF;
+END;
+$$;
+
+COMMENT ON TABLE backfills IS 'Задачи пересчёта исторических агрегатов';
+COMMENT ON COLUMN backfills.job IS 'Имя зарегистрированного типа задачи';
+COMMENT ON COLUMN backfills.cursor_value IS 'Курсор последнего обработанного элемента, с которого задача продолжится';
+COMMENT ON COLUMN backfills.lease_owner IS 'Идентификатор процесса, выполняющего задачу';
+COMMENT ON COLUMN backfills.lease_expires_at IS 'Момент, после которого задачу может подхватить другой процесс';
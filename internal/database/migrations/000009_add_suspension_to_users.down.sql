@@ -0,0 +1,9 @@
+-- 000009_add_suspension_to_users.down.sql
+-- Откат добавления блокировки аккаунта
+
+DROP INDEX IF EXISTS idx_users_suspended_at;
+
+ALTER TABLE users
+    DROP COLUMN IF EXISTS suspension_reason,
+    DROP COLUMN IF EXISTS suspended_until,
+    DROP COLUMN IF EXISTS suspended_at;
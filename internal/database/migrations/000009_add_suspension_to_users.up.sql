@@ -0,0 +1,14 @@
+-- 000009_add_suspension_to_users.up.sql
+-- Добавляет блокировку (suspend) аккаунта администратором.
+
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS suspended_at TIMESTAMPTZ,
+    ADD COLUMN IF NOT EXISTS suspended_until TIMESTAMPTZ,
+    ADD COLUMN IF NOT EXISTS suspension_reason TEXT NOT NULL DEFAULT '';
+
+COMMENT ON COLUMN users.suspended_at IS 'Время блокировки аккаунта (NULL, если аккаунт не заблокирован)';
+COMMENT ON COLUMN users.suspended_until IS 'Окончание блокировки (NULL — бессрочно)';
+COMMENT ON COLUMN users.suspension_reason IS 'Причина блокировки, указанная администратором';
+
+CREATE INDEX IF NOT EXISTS idx_users_suspended_at
+    ON users (suspended_at) WHERE suspended_at IS NOT NULL;
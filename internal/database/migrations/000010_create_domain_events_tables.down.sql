@@ -0,0 +1,6 @@
+-- 000010_create_domain_events_tables.down.sql
+-- Откат создания журнала доменных событий
+
+DROP TABLE IF EXISTS event_checkpoints;
+
+DROP TABLE IF EXISTS domain_events;
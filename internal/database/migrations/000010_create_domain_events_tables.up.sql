@@ -0,0 +1,24 @@
+-- 000010_create_domain_events_tables.up.sql
+-- Журнал доменных событий и контрольные точки подписчиков для повторной доставки (replay).
+
+CREATE TABLE IF NOT EXISTS domain_events (
+    id BIGSERIAL PRIMARY KEY,
+    type VARCHAR(100) NOT NULL,
+    aggregate_id TEXT NOT NULL DEFAULT '',
+    payload JSONB NOT NULL DEFAULT '{}'::jsonb,
+    occurred_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_domain_events_type_id ON domain_events (type, id);
+CREATE INDEX IF NOT EXISTS idx_domain_events_occurred_at ON domain_events (occurred_at);
+
+CREATE TABLE IF NOT EXISTS event_checkpoints (
+    name VARCHAR(150) PRIMARY KEY,
+    last_event_id BIGINT NOT NULL DEFAULT 0,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+COMMENT ON TABLE domain_events IS 'Журнал доменных событий (только добавление)';
+COMMENT ON COLUMN domain_events.aggregate_id IS 'Идентификатор сущности, к которой относится событие';
+COMMENT ON TABLE event_checkpoints IS 'Последнее обработанное событие для каждого процесса доставки';
+COMMENT ON COLUMN event_checkpoints.name IS 'Имя контрольной точки (например, replay:<подписчик>)';
@@ -0,0 +1,5 @@
+-- 000011_add_tokens_valid_after_to_users.down.sql
+-- Откат добавления отзыва refresh-токенов
+
+ALTER TABLE users
+    DROP COLUMN IF EXISTS tokens_valid_after;
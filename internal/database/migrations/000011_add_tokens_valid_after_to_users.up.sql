@@ -0,0 +1,7 @@
+-- 000011_add_tokens_valid_after_to_users.up.sql
+-- Добавляет отзыв ранее выданных refresh-токенов (например, после смены пароля).
+
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS tokens_valid_after TIMESTAMPTZ;
+
+COMMENT ON COLUMN users.tokens_valid_after IS 'Refresh-токены, выданные раньше этого момента, недействительны (NULL — ограничений нет)';
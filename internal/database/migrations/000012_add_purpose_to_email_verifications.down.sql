@@ -0,0 +1,9 @@
+-- 000012_add_purpose_to_email_verifications.down.sql
+-- Откат добавления назначения кода подтверждения
+
+DELETE FROM email_verifications WHERE purpose = 'password_change';
+
+DROP INDEX IF EXISTS idx_email_verifications_user_id_purpose;
+
+ALTER TABLE email_verifications
+    DROP COLUMN IF EXISTS purpose;
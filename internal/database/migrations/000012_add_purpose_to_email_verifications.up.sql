@@ -0,0 +1,14 @@
+-- 000012_add_purpose_to_email_verifications.up.sql
+-- Добавляет назначение кода подтверждения (регистрация, смена email, смена пароля).
+
+ALTER TABLE email_verifications
+    ADD COLUMN IF NOT EXISTS purpose VARCHAR(32) NOT NULL DEFAULT 'registration';
+
+UPDATE email_verifications
+SET purpose = 'email_change'
+WHERE new_email IS NOT NULL;
+
+CREATE INDEX IF NOT EXISTS idx_email_verifications_user_id_purpose
+    ON email_verifications (user_id, purpose);
+
+COMMENT ON COLUMN email_verifications.purpose IS 'Назначение кода: registration, email_change, password_change';
@@ -0,0 +1,4 @@
+-- 000013_create_client_version_policies.down.sql
+-- Откат создания таблицы минимальных версий клиента
+
+DROP TABLE IF EXISTS client_version_policies;
@@ -0,0 +1,11 @@
+-- 000013_create_client_version_policies.up.sql
+-- Минимальные поддерживаемые версии мобильного приложения по платформам.
+
+CREATE TABLE IF NOT EXISTS client_version_policies (
+    platform    VARCHAR(16) PRIMARY KEY,
+    min_version VARCHAR(32) NOT NULL,
+    store_url   TEXT        NOT NULL,
+    updated_at  TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+COMMENT ON TABLE client_version_policies IS 'Клиенты с версией ниже min_version получают 426 Upgrade Required со ссылкой store_url';
@@ -0,0 +1,15 @@
+-- 000014_constrain_email_verification_purpose.down.sql
+-- Откат ограничений назначения кода подтверждения
+
+DELETE FROM email_verifications WHERE purpose IN ('password_reset', 'account_deletion');
+
+ALTER TABLE email_verifications
+    DROP CONSTRAINT IF EXISTS chk_email_verifications_new_email;
+
+ALTER TABLE email_verifications
+    DROP CONSTRAINT IF EXISTS chk_email_verifications_purpose;
+
+ALTER TABLE email_verifications
+    ALTER COLUMN purpose SET DEFAULT 'registration';
+
+COMMENT ON COLUMN email_verifications.purpose IS 'Назначение кода: registration, email_change, password_change';
@@ -0,0 +1,21 @@
+-- 000014_constrain_email_verification_purpose.up.sql
+-- Назначение кода подтверждения становится обязательным перечислением:
+-- сценарий определяется колонкой purpose, а не наличием new_email.
+
+UPDATE email_verifications
+SET purpose = 'email_change'
+WHERE new_email IS NOT NULL AND purpose = 'registration';
+
+ALTER TABLE email_verifications
+    ALTER COLUMN purpose DROP DEFAULT;
+
+ALTER TABLE email_verifications
+    ADD CONSTRAINT chk_email_verifications_purpose
+        CHECK (purpose IN ('registration', 'email_change', 'password_change', 'password_reset', 'account_deletion'));
+
+ALTER TABLE email_verifications
+    ADD CONSTRAINT chk_email_verifications_new_email
+        CHECK ((purpose = 'email_change') = (new_email IS NOT NULL));
+
+COMMENT ON COLUMN email_verifications.purpose IS 'Назначение кода: registration, email_change, password_change, password_reset, account_deletion';
+COMMENT ON COLUMN email_verifications.new_email IS 'Новый email; заполняется только для purpose = email_change';
@@ -0,0 +1,4 @@
+-- 000015_create_coach_consents.down.sql
+-- Откат создания таблицы согласий клиентов на доступ тренеров
+
+DROP TABLE IF EXISTS coach_consents;
@@ -0,0 +1,12 @@
+-- 000015_create_coach_consents.up.sql
+-- Согласия клиентов на доступ тренеров к классам данных (тренировки, замеры, питание).
+
+CREATE TABLE IF NOT EXISTS coach_consents (
+    client_id  UUID        NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    coach_id   UUID        NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    scopes     JSONB       NOT NULL DEFAULT '[]'::jsonb,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (client_id, coach_id)
+);
+
+COMMENT ON TABLE coach_consents IS 'Тренер видит данные клиента только из классов, перечисленных в scopes';
@@ -0,0 +1,5 @@
+-- 000016_add_anonymized_at_to_users.down.sql
+-- Откат добавления отметки об обезличивании пользователя
+
+ALTER TABLE users
+    DROP COLUMN IF EXISTS anonymized_at;
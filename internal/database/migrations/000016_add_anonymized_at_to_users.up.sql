@@ -0,0 +1,7 @@
+-- 000016_add_anonymized_at_to_users.up.sql
+-- Добавляет отметку об обезличивании пользователя при окончательном удалении аккаунта.
+
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS anonymized_at TIMESTAMPTZ;
+
+COMMENT ON COLUMN users.anonymized_at IS 'Время обезличивания: персональные данные удалены, запись сохранена для ссылок из контента (NULL — не обезличен)';
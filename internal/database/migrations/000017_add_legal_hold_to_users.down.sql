@@ -0,0 +1,9 @@
+-- 000017_add_legal_hold_to_users.down.sql
+-- Откат юридического удержания данных аккаунта
+
+DROP TABLE IF EXISTS legal_hold_audit;
+
+ALTER TABLE users
+    DROP COLUMN IF EXISTS legal_hold_reason,
+    DROP COLUMN IF EXISTS legal_hold_by,
+    DROP COLUMN IF EXISTS legal_hold_at;
@@ -0,0 +1,25 @@
+-- 000017_add_legal_hold_to_users.up.sql
+-- Юридическое удержание данных аккаунта: запрещает обезличивание и автоматическое удаление до снятия.
+
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS legal_hold_at     TIMESTAMPTZ,
+    ADD COLUMN IF NOT EXISTS legal_hold_by     UUID,
+    ADD COLUMN IF NOT EXISTS legal_hold_reason TEXT NOT NULL DEFAULT '';
+
+COMMENT ON COLUMN users.legal_hold_at IS 'Время установки юридического удержания (NULL — удержания нет)';
+COMMENT ON COLUMN users.legal_hold_by IS 'Администратор, установивший удержание';
+COMMENT ON COLUMN users.legal_hold_reason IS 'Основание удержания';
+
+-- Журнал аудита: записи не изменяются и не удаляются, в том числе при обезличивании пользователя.
+CREATE TABLE IF NOT EXISTS legal_hold_audit (
+    id         BIGSERIAL PRIMARY KEY,
+    user_id    UUID        NOT NULL,
+    action     VARCHAR(16) NOT NULL CHECK (action IN ('placed', 'released')),
+    actor_id   UUID        NOT NULL,
+    reason     TEXT        NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_legal_hold_audit_user ON legal_hold_audit (user_id, id);
+
+COMMENT ON TABLE legal_hold_audit IS 'Журнал установки и снятия юридических удержаний';
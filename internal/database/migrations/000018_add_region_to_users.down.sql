@@ -0,0 +1,11 @@
+-- 000018_add_region_to_users.down.sql
+-- Откат страны регистрации и региона хранения данных
+
+ALTER TABLE experiment_events
+    DROP COLUMN IF EXISTS region;
+
+DROP INDEX IF EXISTS idx_users_region;
+
+ALTER TABLE users
+    DROP COLUMN IF EXISTS region,
+    DROP COLUMN IF EXISTS country;
@@ -0,0 +1,17 @@
+-- 000018_add_region_to_users.up.sql
+-- Страна регистрации и регион хранения данных пользователя; регион дублируется в аналитических событиях.
+-- Задел под требования к размещению данных граждан ЕС (data residency).
+
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS country VARCHAR(2)  NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS region  VARCHAR(16) NOT NULL DEFAULT 'global';
+
+CREATE INDEX IF NOT EXISTS idx_users_region ON users (region);
+
+COMMENT ON COLUMN users.country IS 'Страна регистрации (ISO 3166-1 alpha-2, пустая строка — неизвестна)';
+COMMENT ON COLUMN users.region IS 'Регион хранения данных: eu или global';
+
+ALTER TABLE experiment_events
+    ADD COLUMN IF NOT EXISTS region VARCHAR(16) NOT NULL DEFAULT 'global';
+
+COMMENT ON COLUMN experiment_events.region IS 'Регион пользователя на момент события';
@@ -0,0 +1,5 @@
+-- 000019_create_email_deliverability_tables.down.sql
+-- Откат журнала доставки писем и списка недоставляемых адресов
+
+DROP TABLE IF EXISTS undeliverable_emails;
+DROP TABLE IF EXISTS email_delivery_events;
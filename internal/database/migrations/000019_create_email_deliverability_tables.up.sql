@@ -0,0 +1,31 @@
+-- 000019_create_email_deliverability_tables.up.sql
+-- Журнал исходов доставки писем по провайдерам и список адресов, отправка на которые заблокирована.
+
+CREATE TABLE IF NOT EXISTS email_delivery_events (
+    id          BIGSERIAL PRIMARY KEY,
+    provider    VARCHAR(32)  NOT NULL,
+    message_id  VARCHAR(255) NOT NULL DEFAULT '',
+    email       VARCHAR(255) NOT NULL,
+    outcome     VARCHAR(16)  NOT NULL CHECK (outcome IN ('accepted', 'bounced', 'complaint')),
+    bounce_type VARCHAR(8)   NOT NULL DEFAULT '' CHECK (bounce_type IN ('', 'hard', 'soft')),
+    reason      TEXT         NOT NULL DEFAULT '',
+    occurred_at TIMESTAMPTZ  NOT NULL,
+    created_at  TIMESTAMPTZ  NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_email_delivery_events_occurred
+    ON email_delivery_events (occurred_at, provider);
+CREATE INDEX IF NOT EXISTS idx_email_delivery_events_email
+    ON email_delivery_events (email);
+
+COMMENT ON TABLE email_delivery_events IS 'События доставки писем от почтовых провайдеров (webhooks)';
+
+CREATE TABLE IF NOT EXISTS undeliverable_emails (
+    email     VARCHAR(255) PRIMARY KEY,
+    provider  VARCHAR(32)  NOT NULL,
+    outcome   VARCHAR(16)  NOT NULL CHECK (outcome IN ('bounced', 'complaint')),
+    reason    TEXT         NOT NULL DEFAULT '',
+    marked_at TIMESTAMPTZ  NOT NULL DEFAULT NOW()
+);
+
+COMMENT ON TABLE undeliverable_emails IS 'Адреса с постоянной недоставкой или жалобой: письма на них не отправляются';
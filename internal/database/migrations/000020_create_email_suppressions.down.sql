@@ -0,0 +1,21 @@
+-- 000020_create_email_suppressions.down.sql
+-- Откат списка подавления к списку недоставляемых адресов (ручные записи удаляются)
+
+DELETE FROM email_suppressions WHERE source = 'manual';
+
+DROP INDEX IF EXISTS idx_email_suppressions_source_created;
+
+ALTER TABLE email_suppressions
+    DROP COLUMN IF EXISTS created_by,
+    DROP CONSTRAINT IF EXISTS email_suppressions_source_check;
+
+UPDATE email_suppressions SET source = 'bounced' WHERE source = 'bounce';
+
+ALTER INDEX IF EXISTS email_suppressions_pkey RENAME TO undeliverable_emails_pkey;
+ALTER TABLE email_suppressions RENAME COLUMN created_at TO marked_at;
+ALTER TABLE email_suppressions RENAME COLUMN source TO outcome;
+ALTER TABLE email_suppressions
+    ADD CONSTRAINT undeliverable_emails_outcome_check CHECK (outcome IN ('bounced', 'complaint'));
+ALTER TABLE email_suppressions RENAME TO undeliverable_emails;
+
+COMMENT ON TABLE undeliverable_emails IS 'Адреса с постоянной недоставкой или жалобой: письма на них не отправляются';
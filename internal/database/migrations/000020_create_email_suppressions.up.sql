@@ -0,0 +1,23 @@
+-- 000020_create_email_suppressions.up.sql
+-- Глобальный список подавления писем: заменяет список недоставляемых адресов
+-- и дополнительно хранит ручные записи администраторов и импорт из выгрузок провайдеров.
+
+ALTER TABLE undeliverable_emails RENAME TO email_suppressions;
+ALTER TABLE email_suppressions RENAME COLUMN outcome TO source;
+ALTER TABLE email_suppressions RENAME COLUMN marked_at TO created_at;
+ALTER TABLE email_suppressions DROP CONSTRAINT IF EXISTS undeliverable_emails_outcome_check;
+
+UPDATE email_suppressions SET source = 'bounce' WHERE source = 'bounced';
+
+ALTER TABLE email_suppressions
+    ADD CONSTRAINT email_suppressions_source_check CHECK (source IN ('bounce', 'complaint', 'manual')),
+    ADD COLUMN IF NOT EXISTS created_by UUID;
+
+ALTER INDEX IF EXISTS undeliverable_emails_pkey RENAME TO email_suppressions_pkey;
+
+CREATE INDEX IF NOT EXISTS idx_email_suppressions_source_created
+    ON email_suppressions (source, created_at DESC);
+
+COMMENT ON TABLE email_suppressions IS 'Список подавления: письма на эти адреса не отправляются';
+COMMENT ON COLUMN email_suppressions.source IS 'Источник записи: bounce, complaint или manual';
+COMMENT ON COLUMN email_suppressions.created_by IS 'Администратор, добавивший запись (NULL — автоматически по webhook)';
@@ -0,0 +1,6 @@
+-- 000021_create_organizations.down.sql
+-- Откат создания организаций и их настроек отправки писем
+
+DROP TABLE IF EXISTS organization_email_settings;
+DROP TABLE IF EXISTS organization_members;
+DROP TABLE IF EXISTS organizations;
@@ -0,0 +1,39 @@
+-- 000021_create_organizations.up.sql
+-- Организации (залы) с участниками и собственными настройками отправки писем.
+
+CREATE TABLE IF NOT EXISTS organizations (
+    id         UUID PRIMARY KEY,
+    name       VARCHAR(200) NOT NULL,
+    created_at TIMESTAMPTZ  NOT NULL DEFAULT NOW()
+);
+
+COMMENT ON TABLE organizations IS 'Организации (залы, студии) — клиенты платформы';
+
+CREATE TABLE IF NOT EXISTS organization_members (
+    organization_id UUID        NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    user_id         UUID        NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    role            VARCHAR(16) NOT NULL CHECK (role IN ('owner', 'member')),
+    created_at      TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (organization_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_organization_members_user ON organization_members (user_id, created_at);
+
+COMMENT ON TABLE organization_members IS 'Членство пользователей в организациях';
+
+CREATE TABLE IF NOT EXISTS organization_email_settings (
+    organization_id         UUID PRIMARY KEY REFERENCES organizations(id) ON DELETE CASCADE,
+    from_email              VARCHAR(255) NOT NULL,
+    from_name               VARCHAR(100) NOT NULL DEFAULT '',
+    provider                VARCHAR(32)  NOT NULL,
+    smtp_host               VARCHAR(255) NOT NULL,
+    smtp_port               INTEGER      NOT NULL,
+    smtp_username           VARCHAR(255) NOT NULL,
+    smtp_password_encrypted BYTEA        NOT NULL,
+    rate_limit_per_hour     INTEGER      NOT NULL CHECK (rate_limit_per_hour > 0),
+    updated_by              UUID         NOT NULL,
+    updated_at              TIMESTAMPTZ  NOT NULL DEFAULT NOW()
+);
+
+COMMENT ON TABLE organization_email_settings IS 'Собственные отправитель и провайдер писем организации';
+COMMENT ON COLUMN organization_email_settings.smtp_password_encrypted IS 'Пароль SMTP, зашифрованный ключом EMAIL_TENANT_SECRET_KEY (AES-256-GCM)';
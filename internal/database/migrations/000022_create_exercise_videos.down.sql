@@ -0,0 +1,4 @@
+-- 000022_create_exercise_videos.down.sql
+-- Откат таблицы видео упражнений
+
+DROP TABLE IF EXISTS exercise_videos;
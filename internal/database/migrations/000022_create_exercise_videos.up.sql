@@ -0,0 +1,19 @@
+-- 000022_create_exercise_videos.up.sql
+-- Видео с техникой выполнения упражнений, загруженные тренерами.
+
+CREATE TABLE IF NOT EXISTS exercise_videos (
+    id           UUID PRIMARY KEY,
+    owner_id     UUID         NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    title        VARCHAR(200) NOT NULL,
+    storage_key  VARCHAR(512) NOT NULL,
+    content_type VARCHAR(64)  NOT NULL,
+    size_bytes   BIGINT       NOT NULL,
+    status       VARCHAR(16)  NOT NULL CHECK (status IN ('processing', 'ready', 'failed')),
+    created_at   TIMESTAMPTZ  NOT NULL DEFAULT NOW(),
+    updated_at   TIMESTAMPTZ  NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_exercise_videos_owner ON exercise_videos (owner_id, created_at DESC);
+
+COMMENT ON TABLE exercise_videos IS 'Видео упражнений; отдаются по подписанным ссылкам с поддержкой Range';
+COMMENT ON COLUMN exercise_videos.storage_key IS 'Ключ объекта в хранилище файлов (после перекодирования — ключ результата)';
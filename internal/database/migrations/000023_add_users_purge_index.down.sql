@@ -0,0 +1,4 @@
+-- 000023_add_users_purge_index.down.sql
+-- Откат индекса мягко удалённых аккаунтов
+
+DROP INDEX IF EXISTS idx_users_purge_candidates;
@@ -0,0 +1,6 @@
+-- 000023_add_users_purge_index.up.sql
+-- Индекс для поиска мягко удалённых аккаунтов с истёкшим сроком хранения.
+
+CREATE INDEX IF NOT EXISTS idx_users_purge_candidates
+    ON users (deleted_at)
+    WHERE deleted_at IS NOT NULL AND anonymized_at IS NULL AND legal_hold_at IS NULL;
@@ -0,0 +1,5 @@
+-- 000024_create_workout_sessions.down.sql
+-- Откат таблиц тренировок и подходов
+
+DROP TABLE IF EXISTS workout_sets;
+DROP TABLE IF EXISTS workout_sessions;
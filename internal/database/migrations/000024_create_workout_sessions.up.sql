@@ -0,0 +1,34 @@
+-- 000024_create_workout_sessions.up.sql
+-- Тренировки пользователей и выполненные подходы со временем записи на сервере.
+
+CREATE TABLE IF NOT EXISTS workout_sessions (
+    id                       UUID PRIMARY KEY,
+    user_id                  UUID         NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    assignment_id            UUID         REFERENCES program_assignments(id) ON DELETE SET NULL,
+    title                    VARCHAR(200) NOT NULL,
+    auto_pause_after_seconds INTEGER      NOT NULL,
+    started_at               TIMESTAMPTZ  NOT NULL,
+    finished_at              TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_workout_sessions_user_started ON workout_sessions (user_id, started_at DESC);
+-- Одновременно у пользователя может идти только одна тренировка.
+CREATE UNIQUE INDEX IF NOT EXISTS idx_workout_sessions_one_active
+    ON workout_sessions (user_id)
+    WHERE finished_at IS NULL;
+
+COMMENT ON TABLE workout_sessions IS 'Тренировки пользователей';
+COMMENT ON COLUMN workout_sessions.auto_pause_after_seconds IS 'Простой дольше порога исключается из активного времени';
+
+CREATE TABLE IF NOT EXISTS workout_sets (
+    id         UUID PRIMARY KEY,
+    session_id UUID         NOT NULL REFERENCES workout_sessions(id) ON DELETE CASCADE,
+    exercise   VARCHAR(100) NOT NULL,
+    reps       INTEGER      NOT NULL,
+    weight_kg  NUMERIC(6,2),
+    logged_at  TIMESTAMPTZ  NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_workout_sets_session ON workout_sets (session_id, logged_at);
+
+COMMENT ON TABLE workout_sets IS 'Выполненные подходы; logged_at задаёт сервер в момент записи';
@@ -0,0 +1,4 @@
+-- 000025_create_data_exports.down.sql
+-- Откат таблицы выгрузок данных аккаунта
+
+DROP TABLE IF EXISTS data_exports;
@@ -0,0 +1,26 @@
+-- 000025_create_data_exports.up.sql
+-- Выгрузки данных аккаунта (GDPR): очередь сборки архивов и подписанные ссылки на скачивание.
+
+CREATE TABLE IF NOT EXISTS data_exports (
+    id            UUID PRIMARY KEY,
+    user_id       UUID         NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    status        VARCHAR(16)  NOT NULL,
+    storage_key   VARCHAR(512) NOT NULL DEFAULT '',
+    size_bytes    BIGINT       NOT NULL DEFAULT 0,
+    error         TEXT         NOT NULL DEFAULT '',
+    requested_at  TIMESTAMPTZ  NOT NULL,
+    completed_at  TIMESTAMPTZ,
+    expires_at    TIMESTAMPTZ,
+    claimed_until TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_data_exports_user_requested ON data_exports (user_id, requested_at DESC);
+CREATE INDEX IF NOT EXISTS idx_data_exports_pending ON data_exports (requested_at) WHERE status = 'pending';
+CREATE INDEX IF NOT EXISTS idx_data_exports_expires ON data_exports (expires_at) WHERE expires_at IS NOT NULL;
+-- В очереди у пользователя может быть только одна выгрузка.
+CREATE UNIQUE INDEX IF NOT EXISTS idx_data_exports_one_pending
+    ON data_exports (user_id)
+    WHERE status = 'pending';
+
+COMMENT ON TABLE data_exports IS 'Выгрузки данных аккаунта по запросу пользователя';
+COMMENT ON COLUMN data_exports.claimed_until IS 'Аренда сборки архива процессом; по истечении выгрузку подхватит другой процесс';
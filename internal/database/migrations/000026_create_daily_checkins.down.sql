@@ -0,0 +1,4 @@
+-- 000026_create_daily_checkins.down.sql
+-- Откат таблицы ежедневных анкет готовности
+
+DROP TABLE IF EXISTS daily_checkins;
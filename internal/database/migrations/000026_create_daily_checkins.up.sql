@@ -0,0 +1,17 @@
+-- 000026_create_daily_checkins.up.sql
+-- Ежедневные анкеты готовности к тренировке (боль, энергия, мотивация); одна анкета в день.
+
+CREATE TABLE IF NOT EXISTS daily_checkins (
+    id         UUID PRIMARY KEY,
+    user_id    UUID         NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    day        DATE         NOT NULL,
+    soreness   SMALLINT     NOT NULL CHECK (soreness BETWEEN 1 AND 10),
+    energy     SMALLINT     NOT NULL CHECK (energy BETWEEN 1 AND 10),
+    motivation SMALLINT     NOT NULL CHECK (motivation BETWEEN 1 AND 10),
+    note       VARCHAR(500) NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ  NOT NULL,
+    updated_at TIMESTAMPTZ  NOT NULL,
+    CONSTRAINT uq_daily_checkins_user_day UNIQUE (user_id, day)
+);
+
+COMMENT ON TABLE daily_checkins IS 'Ежедневные анкеты готовности; повторная анкета за день заменяет предыдущую';
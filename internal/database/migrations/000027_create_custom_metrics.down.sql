@@ -0,0 +1,5 @@
+-- 000027_create_custom_metrics.down.sql
+-- Откат таблиц пользовательских метрик
+
+DROP TABLE IF EXISTS custom_metric_entries;
+DROP TABLE IF EXISTS custom_metrics;
@@ -0,0 +1,31 @@
+-- 000027_create_custom_metrics.up.sql
+-- Пользовательские метрики (название, единица измерения) и их значения во времени.
+
+CREATE TABLE IF NOT EXISTS custom_metrics (
+    id               UUID PRIMARY KEY,
+    user_id          UUID        NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name             VARCHAR(64) NOT NULL,
+    unit             VARCHAR(16) NOT NULL,
+    higher_is_better BOOLEAN     NOT NULL DEFAULT TRUE,
+    created_at       TIMESTAMPTZ NOT NULL,
+    updated_at       TIMESTAMPTZ NOT NULL
+);
+
+-- Название метрики уникально в пределах пользователя без учёта регистра.
+CREATE UNIQUE INDEX IF NOT EXISTS idx_custom_metrics_user_name ON custom_metrics (user_id, LOWER(name));
+
+CREATE TABLE IF NOT EXISTS custom_metric_entries (
+    id          UUID PRIMARY KEY,
+    metric_id   UUID             NOT NULL REFERENCES custom_metrics(id) ON DELETE CASCADE,
+    user_id     UUID             NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    value       DOUBLE PRECISION NOT NULL,
+    recorded_at TIMESTAMPTZ      NOT NULL,
+    note        VARCHAR(500)     NOT NULL DEFAULT '',
+    created_at  TIMESTAMPTZ      NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_custom_metric_entries_metric_recorded ON custom_metric_entries (metric_id, recorded_at);
+CREATE INDEX IF NOT EXISTS idx_custom_metric_entries_user ON custom_metric_entries (user_id);
+
+COMMENT ON TABLE custom_metrics IS 'Пользовательские метрики для нишевых измерений без изменения схемы';
+COMMENT ON TABLE custom_metric_entries IS 'Значения пользовательских метрик во времени';
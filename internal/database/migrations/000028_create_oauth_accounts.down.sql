@@ -0,0 +1,4 @@
+-- 000028_create_oauth_accounts.down.sql
+-- Откат таблицы привязок аккаунтов внешних провайдеров
+
+DROP TABLE IF EXISTS oauth_accounts;
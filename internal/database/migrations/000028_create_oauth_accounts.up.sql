@@ -0,0 +1,16 @@
+-- 000028_create_oauth_accounts.up.sql
+-- Привязки аккаунтов Google и Apple к пользователям для входа по ID-токену провайдера.
+
+CREATE TABLE IF NOT EXISTS oauth_accounts (
+    id            UUID PRIMARY KEY,
+    user_id       UUID         NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    provider      VARCHAR(16)  NOT NULL,
+    subject       VARCHAR(255) NOT NULL,
+    email         VARCHAR(255) NOT NULL DEFAULT '',
+    created_at    TIMESTAMPTZ  NOT NULL,
+    last_login_at TIMESTAMPTZ  NOT NULL,
+    CONSTRAINT uq_oauth_accounts_provider_subject UNIQUE (provider, subject),
+    CONSTRAINT uq_oauth_accounts_user_provider UNIQUE (user_id, provider)
+);
+
+COMMENT ON TABLE oauth_accounts IS 'Аккаунты внешних провайдеров входа (google, apple), привязанные к пользователям';
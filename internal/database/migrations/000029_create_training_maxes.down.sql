@@ -0,0 +1,4 @@
+-- 000029_create_training_maxes.down.sql
+-- Откат таблицы тренировочных максимумов
+
+DROP TABLE IF EXISTS training_maxes;
@@ -0,0 +1,14 @@
+-- 000029_create_training_maxes.up.sql
+-- Тренировочные максимумы пользователей, от которых считаются процентные назначения программ.
+
+CREATE TABLE IF NOT EXISTS training_maxes (
+    user_id      UUID             NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    exercise_key VARCHAR(100)     NOT NULL,
+    exercise     VARCHAR(100)     NOT NULL,
+    weight_kg    DOUBLE PRECISION NOT NULL,
+    updated_at   TIMESTAMPTZ      NOT NULL,
+    PRIMARY KEY (user_id, exercise_key)
+);
+
+COMMENT ON TABLE training_maxes IS 'Тренировочные максимумы пользователей по упражнениям';
+COMMENT ON COLUMN training_maxes.exercise_key IS 'Название упражнения в нижнем регистре без лишних пробелов';
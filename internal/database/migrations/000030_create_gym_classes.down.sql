@@ -0,0 +1,6 @@
+-- 000030_create_gym_classes.down.sql
+-- Откат таблиц групповых занятий
+
+DROP TABLE IF EXISTS gym_class_bookings;
+DROP TABLE IF EXISTS gym_class_slots;
+DROP TABLE IF EXISTS gym_classes;
@@ -0,0 +1,46 @@
+-- 000030_create_gym_classes.up.sql
+-- Групповые занятия организаций: занятия, еженедельные слоты и записи участников с листом ожидания.
+
+CREATE TABLE IF NOT EXISTS gym_classes (
+    id               UUID PRIMARY KEY,
+    organization_id  UUID          NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    title            VARCHAR(200)  NOT NULL,
+    description      VARCHAR(2000) NOT NULL DEFAULT '',
+    coach_id         UUID          REFERENCES users(id) ON DELETE SET NULL,
+    capacity         INTEGER       NOT NULL,
+    duration_minutes INTEGER       NOT NULL,
+    created_at       TIMESTAMPTZ   NOT NULL,
+    updated_at       TIMESTAMPTZ   NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_gym_classes_organization ON gym_classes (organization_id);
+
+CREATE TABLE IF NOT EXISTS gym_class_slots (
+    id           UUID PRIMARY KEY,
+    class_id     UUID        NOT NULL REFERENCES gym_classes(id) ON DELETE CASCADE,
+    weekday      SMALLINT    NOT NULL,
+    start_minute INTEGER     NOT NULL,
+    created_at   TIMESTAMPTZ NOT NULL,
+    CONSTRAINT uq_gym_class_slots_time UNIQUE (class_id, weekday, start_minute)
+);
+
+CREATE TABLE IF NOT EXISTS gym_class_bookings (
+    id         UUID PRIMARY KEY,
+    class_id   UUID        NOT NULL REFERENCES gym_classes(id) ON DELETE CASCADE,
+    user_id    UUID        NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    starts_at  TIMESTAMPTZ NOT NULL,
+    status     VARCHAR(16) NOT NULL,
+    attended   BOOLEAN,
+    created_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL
+);
+
+-- Одна активная запись пользователя на занятие; отменённые остаются в истории.
+CREATE UNIQUE INDEX IF NOT EXISTS idx_gym_class_bookings_active
+    ON gym_class_bookings (class_id, starts_at, user_id) WHERE status <> 'cancelled';
+CREATE INDEX IF NOT EXISTS idx_gym_class_bookings_occurrence ON gym_class_bookings (class_id, starts_at, created_at);
+CREATE INDEX IF NOT EXISTS idx_gym_class_bookings_user ON gym_class_bookings (user_id, starts_at);
+
+COMMENT ON TABLE gym_classes IS 'Групповые занятия организаций';
+COMMENT ON TABLE gym_class_slots IS 'Еженедельное расписание групповых занятий (UTC)';
+COMMENT ON TABLE gym_class_bookings IS 'Записи участников на групповые занятия и посещаемость';
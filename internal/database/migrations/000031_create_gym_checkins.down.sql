@@ -0,0 +1,5 @@
+-- 000031_create_gym_checkins.down.sql
+-- Откат таблиц залов и отметок посещения
+
+DROP TABLE IF EXISTS gym_visits;
+DROP TABLE IF EXISTS gym_locations;
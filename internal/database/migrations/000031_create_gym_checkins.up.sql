@@ -0,0 +1,28 @@
+-- 000031_create_gym_checkins.up.sql
+-- Залы организаций и отметки участников по ротируемым QR-кодам.
+
+CREATE TABLE IF NOT EXISTS gym_locations (
+    id              UUID PRIMARY KEY,
+    organization_id UUID         NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    name            VARCHAR(200) NOT NULL,
+    address         VARCHAR(500) NOT NULL DEFAULT '',
+    secret          BYTEA        NOT NULL,
+    created_at      TIMESTAMPTZ  NOT NULL,
+    updated_at      TIMESTAMPTZ  NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_gym_locations_organization ON gym_locations (organization_id);
+
+CREATE TABLE IF NOT EXISTS gym_visits (
+    id              UUID PRIMARY KEY,
+    location_id     UUID        NOT NULL REFERENCES gym_locations(id) ON DELETE CASCADE,
+    organization_id UUID        NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    user_id         UUID        NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    checked_in_at   TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_gym_visits_user ON gym_visits (user_id, checked_in_at DESC);
+CREATE INDEX IF NOT EXISTS idx_gym_visits_organization ON gym_visits (organization_id, checked_in_at);
+
+COMMENT ON TABLE gym_locations IS 'Залы организаций с ключом подписи QR-кодов';
+COMMENT ON TABLE gym_visits IS 'Отметки участников в залах (посещаемость)';
@@ -0,0 +1,5 @@
+-- 000032_create_strength_standards.down.sql
+-- Откат таблиц нормативов силы
+
+DROP TABLE IF EXISTS strength_standard_aliases;
+DROP TABLE IF EXISTS strength_standards;
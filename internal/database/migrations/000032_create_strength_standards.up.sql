@@ -0,0 +1,74 @@
+-- 000032_create_strength_standards.up.sql
+-- Нормативы силы по весу тела и полу (пороги разового максимума для уровней) и синонимы упражнений.
+
+CREATE TABLE IF NOT EXISTS strength_standards (
+    exercise        VARCHAR(100)  NOT NULL,
+    gender          VARCHAR(10)   NOT NULL,
+    body_weight_kg  NUMERIC(5,1)  NOT NULL,
+    beginner_kg     NUMERIC(6,1)  NOT NULL,
+    novice_kg       NUMERIC(6,1)  NOT NULL,
+    intermediate_kg NUMERIC(6,1)  NOT NULL,
+    advanced_kg     NUMERIC(6,1)  NOT NULL,
+    elite_kg        NUMERIC(6,1)  NOT NULL,
+    PRIMARY KEY (exercise, gender, body_weight_kg)
+);
+
+CREATE TABLE IF NOT EXISTS strength_standard_aliases (
+    alias_key VARCHAR(100) PRIMARY KEY,
+    exercise  VARCHAR(100) NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_strength_standard_aliases_exercise ON strength_standard_aliases (exercise);
+
+COMMENT ON TABLE strength_standards IS 'Пороги разового максимума (кг) для уровней beginner…elite по весу тела и полу';
+COMMENT ON TABLE strength_standard_aliases IS 'Синонимы упражнений нормативов (ключ — название в нижнем регистре)';
+
+-- Базовые нормативы; администратор может заменить их через API.
+INSERT INTO strength_standards (exercise, gender, body_weight_kg, beginner_kg, novice_kg, intermediate_kg, advanced_kg, elite_kg) VALUES
+    ('squat', 'male', 60, 40, 60, 85, 115, 150),
+    ('squat', 'male', 80, 55, 80, 110, 150, 190),
+    ('squat', 'male', 100, 65, 95, 130, 175, 220),
+    ('squat', 'male', 120, 75, 105, 145, 190, 240),
+    ('bench press', 'male', 60, 30, 45, 65, 85, 110),
+    ('bench press', 'male', 80, 40, 60, 85, 110, 140),
+    ('bench press', 'male', 100, 50, 75, 100, 130, 165),
+    ('bench press', 'male', 120, 55, 85, 110, 145, 180),
+    ('deadlift', 'male', 60, 55, 80, 105, 140, 175),
+    ('deadlift', 'male', 80, 70, 100, 135, 175, 220),
+    ('deadlift', 'male', 100, 85, 120, 160, 205, 255),
+    ('deadlift', 'male', 120, 95, 135, 175, 225, 275),
+    ('overhead press', 'male', 60, 20, 30, 40, 55, 70),
+    ('overhead press', 'male', 80, 25, 40, 55, 70, 90),
+    ('overhead press', 'male', 100, 35, 50, 65, 85, 105),
+    ('overhead press', 'male', 120, 40, 55, 75, 95, 115),
+    ('squat', 'female', 50, 25, 40, 60, 80, 105),
+    ('squat', 'female', 70, 35, 55, 75, 100, 130),
+    ('squat', 'female', 90, 45, 65, 90, 115, 145),
+    ('bench press', 'female', 50, 15, 25, 35, 50, 65),
+    ('bench press', 'female', 70, 20, 35, 45, 60, 80),
+    ('bench press', 'female', 90, 25, 40, 55, 70, 90),
+    ('deadlift', 'female', 50, 35, 50, 70, 95, 120),
+    ('deadlift', 'female', 70, 45, 65, 90, 115, 145),
+    ('deadlift', 'female', 90, 55, 80, 105, 135, 165),
+    ('overhead press', 'female', 50, 10, 15, 25, 35, 45),
+    ('overhead press', 'female', 70, 15, 20, 30, 40, 55),
+    ('overhead press', 'female', 90, 20, 25, 35, 50, 60)
+ON CONFLICT DO NOTHING;
+
+INSERT INTO strength_standard_aliases (alias_key, exercise) VALUES
+    ('back squat', 'squat'),
+    ('приседания', 'squat'),
+    ('приседания со штангой', 'squat'),
+    ('присед', 'squat'),
+    ('bench', 'bench press'),
+    ('жим лёжа', 'bench press'),
+    ('жим лежа', 'bench press'),
+    ('жим штанги лёжа', 'bench press'),
+    ('становая тяга', 'deadlift'),
+    ('становая', 'deadlift'),
+    ('тяга', 'deadlift'),
+    ('ohp', 'overhead press'),
+    ('military press', 'overhead press'),
+    ('жим стоя', 'overhead press'),
+    ('армейский жим', 'overhead press')
+ON CONFLICT DO NOTHING;
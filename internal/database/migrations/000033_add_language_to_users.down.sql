@@ -0,0 +1,5 @@
+-- 000033_add_language_to_users.down.sql
+-- Откат добавления языка писем пользователя
+
+ALTER TABLE users
+    DROP COLUMN IF EXISTS language;
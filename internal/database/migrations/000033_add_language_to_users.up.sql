@@ -0,0 +1,7 @@
+-- 000033_add_language_to_users.up.sql
+-- Добавляет язык, на котором пользователь получает письма.
+
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS language VARCHAR(8) NOT NULL DEFAULT '';
+
+COMMENT ON COLUMN users.language IS 'Язык писем (ru, en); пустая строка — язык по умолчанию';
@@ -0,0 +1,4 @@
+-- 000034_create_email_outbox.down.sql
+-- Откат создания очереди исходящих писем
+
+DROP TABLE IF EXISTS email_outbox;
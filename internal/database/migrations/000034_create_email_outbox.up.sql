@@ -0,0 +1,23 @@
+-- 000034_create_email_outbox.up.sql
+-- Очередь исходящих писем: письма ставятся в очередь в транзакции запроса и отправляются воркером с повторными попытками.
+
+CREATE TABLE IF NOT EXISTS email_outbox (
+    id              BIGSERIAL PRIMARY KEY,
+    kind            VARCHAR(32)  NOT NULL,
+    recipient       VARCHAR(255) NOT NULL,
+    locale          VARCHAR(8)   NOT NULL DEFAULT '',
+    payload         JSONB        NOT NULL DEFAULT '{}',
+    status          VARCHAR(16)  NOT NULL DEFAULT 'pending',
+    attempts        INTEGER      NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMPTZ  NOT NULL,
+    last_error      TEXT         NOT NULL DEFAULT '',
+    created_at      TIMESTAMPTZ  NOT NULL,
+    finished_at     TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_email_outbox_due ON email_outbox (next_attempt_at) WHERE status = 'pending';
+CREATE INDEX IF NOT EXISTS idx_email_outbox_finished ON email_outbox (finished_at) WHERE finished_at IS NOT NULL;
+
+COMMENT ON TABLE email_outbox IS 'Очередь исходящих писем (outbox) с повторными попытками и dead letter';
+COMMENT ON COLUMN email_outbox.payload IS 'Данные письма (код, ссылка); очищаются после отправки';
+COMMENT ON COLUMN email_outbox.next_attempt_at IS 'Время следующей попытки; захват письма воркером сдвигает его вперёд на время аренды';
@@ -0,0 +1,12 @@
+-- 000035_normalize_user_emails.down.sql
+-- Откат канонической формы email пользователей
+
+DROP INDEX IF EXISTS idx_users_email_normalized_unique;
+
+ALTER TABLE users
+    DROP COLUMN IF EXISTS email_normalized;
+
+-- Приведение адресов к нижнему регистру не откатывается; исходный индекс восстанавливается
+-- и не создастся, если после нормализации остались совпадающие адреса.
+CREATE UNIQUE INDEX IF NOT EXISTS idx_users_email_unique
+    ON users (email) WHERE deleted_at IS NULL;
@@ -0,0 +1,37 @@
+-- 000035_normalize_user_emails.up.sql
+-- Приводит email пользователей к нижнему регистру без пробелов и переносит уникальность на каноническую форму адреса.
+
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS email_normalized VARCHAR(255) NOT NULL DEFAULT '';
+
+-- Старый индекс удаляется заранее: адреса, различавшиеся только регистром, после нормализации совпадут.
+DROP INDEX IF EXISTS idx_users_email_unique;
+
+UPDATE users
+SET email = LOWER(TRIM(email))
+WHERE email <> LOWER(TRIM(email));
+
+-- Каноническая форма по умолчанию совпадает с нормализованным адресом; свёртку Gmail и +меток
+-- по настройкам EMAIL_FOLD_* пересчитывает backfill-задача user_emails.
+-- Если после нормализации у нескольких активных пользователей совпал адрес, адрес сохраняет
+-- самая ранняя учётная запись, а у остальных каноническая форма помечается их ID: такие записи
+-- не находятся по email и требуют ручного разбора.
+WITH ranked AS (
+    SELECT id,
+           ROW_NUMBER() OVER (PARTITION BY email ORDER BY created_at, id) AS rn
+    FROM users
+    WHERE deleted_at IS NULL
+)
+UPDATE users u
+SET email_normalized = CASE WHEN r.rn = 1 THEN u.email ELSE u.email || '#' || u.id::text END
+FROM ranked r
+WHERE u.id = r.id;
+
+UPDATE users
+SET email_normalized = email
+WHERE deleted_at IS NOT NULL;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_users_email_normalized_unique
+    ON users (email_normalized) WHERE deleted_at IS NULL;
+
+COMMENT ON COLUMN users.email_normalized IS 'Каноническая форма email для проверки уникальности и поиска';
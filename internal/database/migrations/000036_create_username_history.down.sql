@@ -0,0 +1,4 @@
+-- 000036_create_username_history.down.sql
+-- Откат создания истории смены username
+
+DROP TABLE IF EXISTS username_history;
@@ -0,0 +1,17 @@
+-- 000036_create_username_history.up.sql
+-- История смены username: старое имя резервируется за пользователем и служит для перенаправления старых ссылок на профиль.
+
+CREATE TABLE IF NOT EXISTS username_history (
+    id             UUID PRIMARY KEY,
+    user_id        UUID        NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    old_username   VARCHAR(50) NOT NULL,
+    new_username   VARCHAR(50) NOT NULL,
+    changed_at     TIMESTAMPTZ NOT NULL,
+    reserved_until TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_username_history_old_username ON username_history (old_username, changed_at DESC);
+CREATE INDEX IF NOT EXISTS idx_username_history_user ON username_history (user_id, changed_at DESC);
+
+COMMENT ON TABLE username_history IS 'История смены username пользователей';
+COMMENT ON COLUMN username_history.reserved_until IS 'До этого момента старый username может занять только сам пользователь';
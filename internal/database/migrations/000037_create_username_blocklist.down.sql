@@ -0,0 +1,4 @@
+-- 000037_create_username_blocklist.down.sql
+-- Откат создания списка запрещённых username
+
+DROP TABLE IF EXISTS username_blocklist;
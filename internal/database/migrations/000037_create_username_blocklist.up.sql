@@ -0,0 +1,20 @@
+-- 000037_create_username_blocklist.up.sql
+-- Запрещённые username, которые администраторы добавляют в дополнение к встроенным спискам.
+
+CREATE TABLE IF NOT EXISTS username_blocklist (
+    id         UUID PRIMARY KEY,
+    term       VARCHAR(50) NOT NULL,
+    mode       VARCHAR(16) NOT NULL,
+    category   VARCHAR(16) NOT NULL,
+    reason     TEXT        NOT NULL DEFAULT '',
+    created_by UUID        REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    CONSTRAINT chk_username_blocklist_mode CHECK (mode IN ('exact', 'contains')),
+    CONSTRAINT chk_username_blocklist_category CHECK (category IN ('reserved', 'profanity'))
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_username_blocklist_term_mode ON username_blocklist (term, mode);
+
+COMMENT ON TABLE username_blocklist IS 'Запрещённые username, добавленные администраторами';
+COMMENT ON COLUMN username_blocklist.term IS 'Слово в нормализованной форме: нижний регистр, без цифрового суффикса и цифр-подстановок';
+COMMENT ON COLUMN username_blocklist.mode IS 'exact — запрещён username, совпадающий со словом целиком; contains — содержащий слово';
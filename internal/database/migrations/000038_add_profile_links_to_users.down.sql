@@ -0,0 +1,13 @@
+-- 000038_add_profile_links_to_users.down.sql
+-- Откат добавления ссылок профиля
+
+ALTER TABLE users
+    DROP CONSTRAINT IF EXISTS chk_users_instagram_visibility,
+    DROP CONSTRAINT IF EXISTS chk_users_youtube_visibility,
+    DROP CONSTRAINT IF EXISTS chk_users_website_visibility,
+    DROP COLUMN IF EXISTS instagram,
+    DROP COLUMN IF EXISTS instagram_visibility,
+    DROP COLUMN IF EXISTS youtube,
+    DROP COLUMN IF EXISTS youtube_visibility,
+    DROP COLUMN IF EXISTS website,
+    DROP COLUMN IF EXISTS website_visibility;
@@ -0,0 +1,20 @@
+-- 000038_add_profile_links_to_users.up.sql
+-- Добавляет ссылки на Instagram, YouTube и личный сайт с настройкой видимости для каждой ссылки.
+
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS instagram            VARCHAR(30)  NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS instagram_visibility VARCHAR(16)  NOT NULL DEFAULT 'public',
+    ADD COLUMN IF NOT EXISTS youtube              VARCHAR(32)  NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS youtube_visibility   VARCHAR(16)  NOT NULL DEFAULT 'public',
+    ADD COLUMN IF NOT EXISTS website              VARCHAR(255) NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS website_visibility   VARCHAR(16)  NOT NULL DEFAULT 'public';
+
+ALTER TABLE users
+    ADD CONSTRAINT chk_users_instagram_visibility CHECK (instagram_visibility IN ('public', 'private')),
+    ADD CONSTRAINT chk_users_youtube_visibility CHECK (youtube_visibility IN ('public', 'private')),
+    ADD CONSTRAINT chk_users_website_visibility CHECK (website_visibility IN ('public', 'private'));
+
+COMMENT ON COLUMN users.instagram IS 'Handle Instagram без @ в нижнем регистре; пустая строка — не указан';
+COMMENT ON COLUMN users.youtube IS 'Handle YouTube с @ или ID канала (UC...); пустая строка — не указан';
+COMMENT ON COLUMN users.website IS 'Адрес личного сайта (http или https); пустая строка — не указан';
+COMMENT ON COLUMN users.instagram_visibility IS 'public — ссылка видна в публичном профиле, private — только владельцу';
@@ -0,0 +1,4 @@
+-- 000039_create_coach_profiles.down.sql
+-- Откат создания анкет тренеров
+
+DROP TABLE IF EXISTS coach_profiles;
@@ -0,0 +1,27 @@
+-- 000039_create_coach_profiles.up.sql
+-- Анкеты тренеров для публичного каталога и отметки «проверенный тренер».
+
+CREATE TABLE IF NOT EXISTS coach_profiles (
+    user_id           UUID PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    listed            BOOLEAN      NOT NULL DEFAULT FALSE,
+    headline          VARCHAR(120) NOT NULL DEFAULT '',
+    bio               TEXT         NOT NULL DEFAULT '',
+    specialties       JSONB        NOT NULL DEFAULT '[]'::jsonb,
+    languages         JSONB        NOT NULL DEFAULT '[]'::jsonb,
+    country           VARCHAR(2)   NOT NULL DEFAULT '',
+    city              VARCHAR(100) NOT NULL DEFAULT '',
+    online            BOOLEAN      NOT NULL DEFAULT FALSE,
+    verified_at       TIMESTAMPTZ,
+    verified_by       UUID         REFERENCES users(id) ON DELETE SET NULL,
+    verification_note TEXT         NOT NULL DEFAULT '',
+    created_at        TIMESTAMPTZ  NOT NULL DEFAULT NOW(),
+    updated_at        TIMESTAMPTZ  NOT NULL DEFAULT NOW()
+);
+
+-- Каталог читает только опубликованные анкеты; фильтры по направлениям и языкам — через @>.
+CREATE INDEX IF NOT EXISTS idx_coach_profiles_listed ON coach_profiles (country, lower(city)) WHERE listed;
+CREATE INDEX IF NOT EXISTS idx_coach_profiles_specialties ON coach_profiles USING GIN (specialties) WHERE listed;
+CREATE INDEX IF NOT EXISTS idx_coach_profiles_languages ON coach_profiles USING GIN (languages) WHERE listed;
+
+COMMENT ON TABLE coach_profiles IS 'Анкеты тренеров; в публичный каталог попадают только анкеты с listed = true';
+COMMENT ON COLUMN coach_profiles.verified_at IS 'Когда администратор поставил отметку «проверенный тренер» (NULL — отметки нет)';
@@ -0,0 +1,5 @@
+-- 000040_create_webhooks.down.sql
+-- Откат создания webhooks
+
+DROP TABLE IF EXISTS webhook_deliveries;
+DROP TABLE IF EXISTS webhook_endpoints;
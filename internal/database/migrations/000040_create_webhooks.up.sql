@@ -0,0 +1,39 @@
+-- 000040_create_webhooks.up.sql
+-- Webhooks: адреса внешних систем, подписанные на события аккаунта, и очередь уведомлений с повторными попытками.
+
+CREATE TABLE IF NOT EXISTS webhook_endpoints (
+    id               UUID PRIMARY KEY,
+    url              VARCHAR(2048) NOT NULL,
+    secret_encrypted BYTEA         NOT NULL,
+    event_types      JSONB         NOT NULL DEFAULT '[]'::jsonb,
+    active           BOOLEAN       NOT NULL DEFAULT TRUE,
+    description      VARCHAR(255)  NOT NULL DEFAULT '',
+    created_by       UUID          REFERENCES users(id) ON DELETE SET NULL,
+    created_at       TIMESTAMPTZ   NOT NULL DEFAULT NOW(),
+    updated_at       TIMESTAMPTZ   NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+    id               BIGSERIAL PRIMARY KEY,
+    endpoint_id      UUID         NOT NULL REFERENCES webhook_endpoints(id) ON DELETE CASCADE,
+    event_id         BIGINT       NOT NULL,
+    event_type       VARCHAR(64)  NOT NULL,
+    body             JSONB        NOT NULL,
+    status           VARCHAR(16)  NOT NULL DEFAULT 'pending',
+    attempts         INTEGER      NOT NULL DEFAULT 0,
+    next_attempt_at  TIMESTAMPTZ  NOT NULL,
+    last_error       TEXT         NOT NULL DEFAULT '',
+    last_status_code INTEGER      NOT NULL DEFAULT 0,
+    created_at       TIMESTAMPTZ  NOT NULL,
+    finished_at      TIMESTAMPTZ,
+    -- Повторная доставка события (cmd/replay) не создаёт второго уведомления.
+    CONSTRAINT uq_webhook_deliveries_endpoint_event UNIQUE (endpoint_id, event_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_due ON webhook_deliveries (next_attempt_at) WHERE status = 'pending';
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_finished ON webhook_deliveries (finished_at) WHERE finished_at IS NOT NULL;
+
+COMMENT ON TABLE webhook_endpoints IS 'Адреса внешних систем, получающие уведомления о событиях аккаунта';
+COMMENT ON COLUMN webhook_endpoints.secret_encrypted IS 'Ключ подписи запросов, зашифрованный WEBHOOK_SECRET_KEY (AES-256-GCM)';
+COMMENT ON TABLE webhook_deliveries IS 'Очередь уведомлений webhooks с повторными попытками и dead letter';
+COMMENT ON COLUMN webhook_deliveries.next_attempt_at IS 'Время следующей попытки; захват уведомления воркером сдвигает его вперёд на время аренды';
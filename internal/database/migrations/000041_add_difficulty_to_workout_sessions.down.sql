@@ -0,0 +1,9 @@
+-- 000041_add_difficulty_to_workout_sessions.down.sql
+-- Откат оценки сложности тренировок
+
+DROP INDEX IF EXISTS idx_workout_sessions_assignment_rated;
+
+ALTER TABLE workout_sessions
+    DROP CONSTRAINT IF EXISTS chk_workout_sessions_difficulty,
+    DROP COLUMN IF EXISTS difficulty_rated_at,
+    DROP COLUMN IF EXISTS difficulty;
@@ -0,0 +1,17 @@
+-- 000041_add_difficulty_to_workout_sessions.up.sql
+-- Добавляет оценку субъективной сложности завершённой тренировки (шкала RPE 1–10).
+
+ALTER TABLE workout_sessions
+    ADD COLUMN IF NOT EXISTS difficulty          SMALLINT,
+    ADD COLUMN IF NOT EXISTS difficulty_rated_at TIMESTAMPTZ;
+
+ALTER TABLE workout_sessions
+    ADD CONSTRAINT chk_workout_sessions_difficulty CHECK (difficulty BETWEEN 1 AND 10);
+
+COMMENT ON COLUMN workout_sessions.difficulty IS 'Сложность по оценке пользователя, 1 (очень легко) — 10 (предел); NULL — не оценена';
+COMMENT ON COLUMN workout_sessions.difficulty_rated_at IS 'Время последней оценки сложности';
+
+-- Средняя сложность программы и последние оценки назначения считаются по оценённым тренировкам назначений.
+CREATE INDEX IF NOT EXISTS idx_workout_sessions_assignment_rated
+    ON workout_sessions (assignment_id, finished_at DESC)
+    WHERE difficulty IS NOT NULL AND assignment_id IS NOT NULL;
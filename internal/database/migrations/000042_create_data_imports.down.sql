@@ -0,0 +1,5 @@
+-- 000042_create_data_imports.down.sql
+-- Откат таблиц импорта исторических данных
+
+DROP TABLE IF EXISTS data_import_records;
+DROP TABLE IF EXISTS data_imports;
@@ -0,0 +1,53 @@
+-- 000042_create_data_imports.up.sql
+-- Импорт исторических данных из других приложений: загруженные файлы NDJSON и результат обработки каждой строки.
+
+CREATE TABLE IF NOT EXISTS data_imports (
+    id            UUID PRIMARY KEY,
+    user_id       UUID         NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    source        VARCHAR(64)  NOT NULL DEFAULT '',
+    status        VARCHAR(16)  NOT NULL,
+    total         INTEGER      NOT NULL DEFAULT 0,
+    processed     INTEGER      NOT NULL DEFAULT 0,
+    imported      INTEGER      NOT NULL DEFAULT 0,
+    invalid       INTEGER      NOT NULL DEFAULT 0,
+    duplicates    INTEGER      NOT NULL DEFAULT 0,
+    last_error    TEXT         NOT NULL DEFAULT '',
+    created_at    TIMESTAMPTZ  NOT NULL,
+    started_at    TIMESTAMPTZ,
+    finished_at   TIMESTAMPTZ,
+    claimed_until TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_data_imports_user_created ON data_imports (user_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_data_imports_runnable ON data_imports (created_at) WHERE status IN ('pending', 'running');
+-- Одновременно у пользователя обрабатывается только один импорт.
+CREATE UNIQUE INDEX IF NOT EXISTS idx_data_imports_one_active
+    ON data_imports (user_id)
+    WHERE status IN ('receiving', 'pending', 'running');
+
+COMMENT ON TABLE data_imports IS 'Импорты исторических данных пользователя из других приложений';
+COMMENT ON COLUMN data_imports.claimed_until IS 'Аренда обработки процессом; по истечении импорт продолжит другой процесс';
+
+CREATE TABLE IF NOT EXISTS data_import_records (
+    import_id   UUID         NOT NULL REFERENCES data_imports(id) ON DELETE CASCADE,
+    line        INTEGER      NOT NULL,
+    user_id     UUID         NOT NULL,
+    kind        VARCHAR(16)  NOT NULL DEFAULT '',
+    external_id VARCHAR(128) NOT NULL DEFAULT '',
+    payload     TEXT         NOT NULL,
+    status      VARCHAR(16)  NOT NULL,
+    error       TEXT         NOT NULL DEFAULT '',
+    entity_id   UUID,
+    PRIMARY KEY (import_id, line)
+);
+
+CREATE INDEX IF NOT EXISTS idx_data_import_records_pending
+    ON data_import_records (import_id, line)
+    WHERE status = 'pending';
+-- Запись с тем же внешним ID импортируется один раз: повторная загрузка того же файла не дублирует данные.
+CREATE UNIQUE INDEX IF NOT EXISTS idx_data_import_records_external
+    ON data_import_records (user_id, kind, external_id)
+    WHERE status = 'imported' AND external_id <> '';
+
+COMMENT ON TABLE data_import_records IS 'Строки файлов импорта и результат их проверки';
+COMMENT ON COLUMN data_import_records.payload IS 'Исходная строка NDJSON';
@@ -0,0 +1,4 @@
+-- 000043_create_audit_logs.down.sql
+-- Откат журнала действий администраторов.
+
+DROP TABLE IF EXISTS audit_logs;
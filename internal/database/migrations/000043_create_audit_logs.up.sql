@@ -0,0 +1,19 @@
+-- 000043_create_audit_logs.up.sql
+-- Журнал действий администраторов: кто, что и над кем сделал, с изменениями и IP.
+
+CREATE TABLE IF NOT EXISTS audit_logs (
+    id         UUID PRIMARY KEY,
+    actor_id   UUID         NOT NULL,
+    action     VARCHAR(255) NOT NULL,
+    target_id  VARCHAR(255) NOT NULL DEFAULT '',
+    query      TEXT         NOT NULL DEFAULT '',
+    diff       JSONB,
+    status     SMALLINT     NOT NULL,
+    ip         VARCHAR(64)  NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ  NOT NULL
+);
+
+-- Записи не ссылаются на users по внешнему ключу: журнал должен пережить окончательное удаление аккаунтов.
+CREATE INDEX IF NOT EXISTS idx_audit_logs_created_at ON audit_logs (created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_audit_logs_actor ON audit_logs (actor_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_audit_logs_target ON audit_logs (target_id, created_at DESC) WHERE target_id <> '';
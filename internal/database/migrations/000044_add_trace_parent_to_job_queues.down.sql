@@ -0,0 +1,5 @@
+-- 000044_add_trace_parent_to_job_queues.down.sql
+-- Откат контекста трассировки в очередях писем и уведомлений.
+
+ALTER TABLE webhook_deliveries DROP COLUMN IF EXISTS trace_parent;
+ALTER TABLE email_outbox DROP COLUMN IF EXISTS trace_parent;
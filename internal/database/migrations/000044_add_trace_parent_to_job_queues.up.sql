@@ -0,0 +1,9 @@
+-- 000044_add_trace_parent_to_job_queues.up.sql
+-- Контекст трассировки запроса, поставившего письмо или уведомление в очередь:
+-- span фоновой отправки продолжает трассу исходного запроса.
+
+ALTER TABLE email_outbox ADD COLUMN IF NOT EXISTS trace_parent VARCHAR(55) NOT NULL DEFAULT '';
+ALTER TABLE webhook_deliveries ADD COLUMN IF NOT EXISTS trace_parent VARCHAR(55) NOT NULL DEFAULT '';
+
+COMMENT ON COLUMN email_outbox.trace_parent IS 'W3C traceparent запроса, поставившего письмо в очередь (пусто — вне запроса)';
+COMMENT ON COLUMN webhook_deliveries.trace_parent IS 'W3C traceparent запроса, вызвавшего событие (пусто — вне запроса)';
@@ -0,0 +1,8 @@
+-- 000045_create_follows.down.sql
+-- Откат подписок и настройки видимости тренировок
+
+ALTER TABLE users
+    DROP CONSTRAINT IF EXISTS chk_users_workouts_visibility,
+    DROP COLUMN IF EXISTS workouts_visibility;
+
+DROP TABLE IF EXISTS follows;
@@ -0,0 +1,23 @@
+-- 000045_create_follows.up.sql
+-- Подписки пользователей друг на друга и настройка видимости тренировок в профиле.
+
+CREATE TABLE IF NOT EXISTS follows (
+    follower_id UUID        NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    followee_id UUID        NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (follower_id, followee_id),
+    CONSTRAINT chk_follows_not_self CHECK (follower_id <> followee_id)
+);
+
+-- Список подписчиков читается по followee_id, новые подписки первыми.
+CREATE INDEX IF NOT EXISTS idx_follows_followee ON follows (followee_id, created_at DESC);
+
+COMMENT ON TABLE follows IS 'Подписки: follower_id подписан на тренировки followee_id';
+
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS workouts_visibility VARCHAR(16) NOT NULL DEFAULT 'private';
+
+ALTER TABLE users
+    ADD CONSTRAINT chk_users_workouts_visibility CHECK (workouts_visibility IN ('public', 'followers', 'private'));
+
+COMMENT ON COLUMN users.workouts_visibility IS 'Кто видит тренировки: public — все пользователи, followers — подписчики, private — только владелец';
@@ -0,0 +1,4 @@
+-- 000046_add_feed_index_to_workout_sessions.down.sql
+-- Откат индекса ленты активности
+
+DROP INDEX IF EXISTS idx_workout_sessions_user_finished;
@@ -0,0 +1,7 @@
+-- 000046_add_feed_index_to_workout_sessions.up.sql
+-- Лента активности собирается при чтении: для каждого автора из подписок берутся
+-- завершённые тренировки, новые по времени завершения первыми.
+
+CREATE INDEX IF NOT EXISTS idx_workout_sessions_user_finished
+    ON workout_sessions (user_id, finished_at DESC)
+    WHERE finished_at IS NOT NULL;
@@ -0,0 +1,5 @@
+-- 000047_create_coach_client_notes.down.sql
+-- Откат заметок и тегов тренера о клиентах
+
+DROP TABLE IF EXISTS coach_client_tags;
+DROP TABLE IF EXISTS coach_client_notes;
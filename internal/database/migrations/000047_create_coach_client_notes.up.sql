@@ -0,0 +1,29 @@
+-- 000047_create_coach_client_notes.up.sql
+-- Личные заметки и теги тренера о клиентах. Клиенту не показываются.
+
+CREATE TABLE IF NOT EXISTS coach_client_notes (
+    id         UUID PRIMARY KEY,
+    coach_id   UUID        NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    client_id  UUID        NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    body       TEXT        NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Заметки читаются по паре «тренер — клиент», новые первыми.
+CREATE INDEX IF NOT EXISTS idx_coach_client_notes_pair ON coach_client_notes (coach_id, client_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_coach_client_notes_client ON coach_client_notes (client_id);
+
+COMMENT ON TABLE coach_client_notes IS 'Заметки тренера о клиенте; видны только тренеру';
+
+CREATE TABLE IF NOT EXISTS coach_client_tags (
+    coach_id   UUID        NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    client_id  UUID        NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    tag        VARCHAR(32) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (coach_id, client_id, tag)
+);
+
+CREATE INDEX IF NOT EXISTS idx_coach_client_tags_client ON coach_client_tags (client_id);
+
+COMMENT ON TABLE coach_client_tags IS 'Теги тренера для клиентов (нижний регистр); видны только тренеру';
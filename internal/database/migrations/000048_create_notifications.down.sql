@@ -0,0 +1,5 @@
+-- 000048_create_notifications.down.sql
+-- Откат уведомлений и настроек уведомлений
+
+DROP TABLE IF EXISTS notification_preferences;
+DROP TABLE IF EXISTS notifications;
@@ -0,0 +1,29 @@
+-- 000048_create_notifications.up.sql
+-- Уведомления в приложении и настройки писем по типам уведомлений.
+
+CREATE TABLE IF NOT EXISTS notifications (
+    id         UUID PRIMARY KEY,
+    user_id    UUID        NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    type       VARCHAR(64) NOT NULL,
+    data       JSONB       NOT NULL DEFAULT '{}',
+    event_id   BIGINT      NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    read_at    TIMESTAMPTZ
+);
+
+-- Повторная доставка события (cmd/replay) не создаёт второго уведомления тому же пользователю.
+CREATE UNIQUE INDEX IF NOT EXISTS idx_notifications_event_user ON notifications (event_id, user_id);
+CREATE INDEX IF NOT EXISTS idx_notifications_user_created ON notifications (user_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_notifications_user_unread ON notifications (user_id) WHERE read_at IS NULL;
+
+COMMENT ON TABLE notifications IS 'Уведомления пользователя в приложении, созданные по событиям журнала';
+
+CREATE TABLE IF NOT EXISTS notification_preferences (
+    user_id       UUID        NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    type          VARCHAR(64) NOT NULL,
+    email_enabled BOOLEAN     NOT NULL,
+    updated_at    TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (user_id, type)
+);
+
+COMMENT ON TABLE notification_preferences IS 'Настройки писем по типам уведомлений; отсутствие строки — письмо включено';
@@ -0,0 +1,7 @@
+-- 000049_create_push_devices.down.sql
+-- Откат устройств для push-уведомлений
+
+DROP INDEX IF EXISTS idx_notifications_push_pending;
+ALTER TABLE notifications DROP COLUMN IF EXISTS push_pending;
+ALTER TABLE notification_preferences DROP COLUMN IF EXISTS push_enabled;
+DROP TABLE IF EXISTS push_devices;
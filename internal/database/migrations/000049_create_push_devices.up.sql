@@ -0,0 +1,23 @@
+-- 000049_create_push_devices.up.sql
+-- Устройства пользователей для push-уведомлений, очередь push-копий уведомлений и настройка push по типам.
+
+CREATE TABLE IF NOT EXISTS push_devices (
+    id           UUID PRIMARY KEY,
+    user_id      UUID        NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    platform     VARCHAR(16) NOT NULL,
+    token        TEXT        NOT NULL,
+    created_at   TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    last_seen_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Токен принадлежит одному пользователю: повторная регистрация переносит его к текущему.
+CREATE UNIQUE INDEX IF NOT EXISTS idx_push_devices_token ON push_devices (token);
+CREATE INDEX IF NOT EXISTS idx_push_devices_user ON push_devices (user_id);
+
+COMMENT ON TABLE push_devices IS 'Токены FCM (android) и APNs (ios) устройств пользователей';
+
+ALTER TABLE notification_preferences ADD COLUMN IF NOT EXISTS push_enabled BOOLEAN NOT NULL DEFAULT TRUE;
+
+-- push_pending — копия уведомления ждёт отправки воркером push; очередь читается по частичному индексу.
+ALTER TABLE notifications ADD COLUMN IF NOT EXISTS push_pending BOOLEAN NOT NULL DEFAULT FALSE;
+CREATE INDEX IF NOT EXISTS idx_notifications_push_pending ON notifications (created_at) WHERE push_pending;
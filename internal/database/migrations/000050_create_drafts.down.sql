@@ -0,0 +1,4 @@
+-- 000050_create_drafts.down.sql
+-- Откат черновиков
+
+DROP TABLE IF EXISTS drafts;
@@ -0,0 +1,23 @@
+-- 000050_create_drafts.up.sql
+-- Автосохраняемые черновики программ и тренировок по устройствам пользователя.
+
+CREATE TABLE IF NOT EXISTS drafts (
+    id           UUID PRIMARY KEY,
+    user_id      UUID         NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    device_id    VARCHAR(128) NOT NULL,
+    kind         VARCHAR(16)  NOT NULL,
+    target_id    UUID,
+    base_version TIMESTAMPTZ,
+    content      JSONB        NOT NULL,
+    version      INTEGER      NOT NULL,
+    created_at   TIMESTAMPTZ  NOT NULL DEFAULT NOW(),
+    updated_at   TIMESTAMPTZ  NOT NULL DEFAULT NOW(),
+    expires_at   TIMESTAMPTZ  NOT NULL
+);
+
+-- Один черновик на устройство и редактируемый объект; черновик нового объекта (target_id IS NULL) — один на вид.
+CREATE UNIQUE INDEX IF NOT EXISTS idx_drafts_target ON drafts (user_id, device_id, kind, target_id) WHERE target_id IS NOT NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_drafts_new ON drafts (user_id, device_id, kind) WHERE target_id IS NULL;
+CREATE INDEX IF NOT EXISTS idx_drafts_expires_at ON drafts (expires_at);
+
+COMMENT ON TABLE drafts IS 'Черновики редактора программ и тренировок; удаляются фоновой очисткой после expires_at';
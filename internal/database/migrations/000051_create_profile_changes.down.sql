@@ -0,0 +1,4 @@
+-- 000051_create_profile_changes.down.sql
+-- Откат истории изменений профиля
+
+DROP TABLE IF EXISTS profile_changes;
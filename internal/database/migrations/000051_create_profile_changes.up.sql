@@ -0,0 +1,15 @@
+-- 000051_create_profile_changes.up.sql
+-- История изменений профиля: какие поля изменились (старое и новое значение), кто и когда их изменил.
+
+CREATE TABLE IF NOT EXISTS profile_changes (
+    id         UUID PRIMARY KEY,
+    user_id    UUID        NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    actor_id   UUID        NOT NULL,
+    changes    JSONB       NOT NULL,
+    changed_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_profile_changes_user_changed_at ON profile_changes (user_id, changed_at DESC);
+
+-- actor_id без внешнего ключа: запись остаётся, даже если администратор позже удалён (как в audit_logs).
+COMMENT ON TABLE profile_changes IS 'История изменений профиля пользователя; actor_id = user_id, если профиль изменил сам пользователь';
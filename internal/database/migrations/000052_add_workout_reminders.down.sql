@@ -0,0 +1,8 @@
+-- 000052_add_workout_reminders.down.sql
+-- Откат напоминаний о тренировках
+
+DROP TABLE IF EXISTS workout_reminders;
+DROP INDEX IF EXISTS idx_users_reminder;
+ALTER TABLE users
+    DROP COLUMN IF EXISTS reminder_time,
+    DROP COLUMN IF EXISTS timezone;
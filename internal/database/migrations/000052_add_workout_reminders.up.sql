@@ -0,0 +1,24 @@
+-- 000052_add_workout_reminders.up.sql
+-- Напоминания о тренировках дня: часовой пояс и время напоминания в профиле, отметки отправленных напоминаний.
+
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS timezone      VARCHAR(64) NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS reminder_time SMALLINT CHECK (reminder_time BETWEEN 0 AND 1439);
+
+COMMENT ON COLUMN users.timezone IS 'Часовой пояс IANA; пустая строка — UTC';
+COMMENT ON COLUMN users.reminder_time IS 'Время напоминания о тренировках дня, минуты от полуночи по часовому поясу; NULL — напоминания выключены';
+
+-- Воркер обходит только пользователей с включёнными напоминаниями.
+CREATE INDEX IF NOT EXISTS idx_users_reminder ON users (id) WHERE reminder_time IS NOT NULL AND deleted_at IS NULL;
+
+-- Одна отметка на пользователя и день в его часовом поясе: напоминание не отправляется дважды.
+CREATE TABLE IF NOT EXISTS workout_reminders (
+    user_id    UUID        NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    local_date DATE        NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (user_id, local_date)
+);
+
+CREATE INDEX IF NOT EXISTS idx_workout_reminders_local_date ON workout_reminders (local_date);
+
+COMMENT ON TABLE workout_reminders IS 'Дни, за которые напоминание о тренировках пользователю уже обработано; удаляются фоновой очисткой';
@@ -0,0 +1,10 @@
+-- 000053_add_user_locale.down.sql
+-- Откат локали пользователя
+
+ALTER TABLE email_outbox
+    DROP COLUMN IF EXISTS timezone;
+UPDATE email_outbox SET locale = split_part(locale, '-', 1) WHERE length(locale) > 8;
+ALTER TABLE email_outbox
+    ALTER COLUMN locale TYPE VARCHAR(8);
+ALTER TABLE users
+    DROP COLUMN IF EXISTS locale;
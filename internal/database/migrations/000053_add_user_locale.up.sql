@@ -0,0 +1,12 @@
+-- 000053_add_user_locale.up.sql
+-- Локаль пользователя для форматов дат и часовой пояс писем в очереди.
+
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS locale VARCHAR(35) NOT NULL DEFAULT '';
+
+COMMENT ON COLUMN users.locale IS 'Локаль BCP 47 для форматов дат (например, en-GB); пустая строка — форматы языка писем';
+
+-- Письмо в очереди рендерится с локалью и часовым поясом получателя на момент постановки.
+ALTER TABLE email_outbox
+    ALTER COLUMN locale TYPE VARCHAR(35),
+    ADD COLUMN IF NOT EXISTS timezone VARCHAR(64) NOT NULL DEFAULT '';
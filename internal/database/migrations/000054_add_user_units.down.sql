@@ -0,0 +1,5 @@
+-- 000054_add_user_units.down.sql
+-- Откат системы единиц пользователя
+
+ALTER TABLE users
+    DROP COLUMN IF EXISTS units;
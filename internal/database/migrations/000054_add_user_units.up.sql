@@ -0,0 +1,7 @@
+-- 000054_add_user_units.up.sql
+-- Система единиц пользователя: в ней принимаются и отдаются вес и замеры, хранятся они в СИ.
+
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS units VARCHAR(16) NOT NULL DEFAULT '';
+
+COMMENT ON COLUMN users.units IS 'Система единиц в API: metric или imperial; пустая строка — metric';
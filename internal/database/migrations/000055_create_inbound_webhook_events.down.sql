@@ -0,0 +1,4 @@
+-- 000055_create_inbound_webhook_events.down.sql
+-- Откат создания входящих событий webhooks
+
+DROP TABLE IF EXISTS inbound_webhook_events;
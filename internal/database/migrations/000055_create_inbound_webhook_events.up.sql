@@ -0,0 +1,26 @@
+-- 000055_create_inbound_webhook_events.up.sql
+-- Входящие события webhooks внешних провайдеров: сохраняются после проверки подписи и обрабатываются воркером.
+
+CREATE TABLE IF NOT EXISTS inbound_webhook_events (
+    id              BIGSERIAL PRIMARY KEY,
+    provider        VARCHAR(32)  NOT NULL,
+    external_id     VARCHAR(255) NOT NULL,
+    event_type      VARCHAR(128) NOT NULL DEFAULT '',
+    payload         BYTEA        NOT NULL,
+    status          VARCHAR(16)  NOT NULL DEFAULT 'pending',
+    attempts        INTEGER      NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMPTZ  NOT NULL,
+    last_error      TEXT         NOT NULL DEFAULT '',
+    trace_parent    VARCHAR(55)  NOT NULL DEFAULT '',
+    received_at     TIMESTAMPTZ  NOT NULL,
+    finished_at     TIMESTAMPTZ,
+    -- Повторная доставка события провайдером не создаёт второй записи.
+    CONSTRAINT uq_inbound_webhook_events_provider_external UNIQUE (provider, external_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_inbound_webhook_events_due ON inbound_webhook_events (next_attempt_at) WHERE status = 'pending';
+CREATE INDEX IF NOT EXISTS idx_inbound_webhook_events_finished ON inbound_webhook_events (finished_at) WHERE finished_at IS NOT NULL;
+
+COMMENT ON TABLE inbound_webhook_events IS 'Входящие события webhooks провайдеров с повторными попытками обработки и dead letter';
+COMMENT ON COLUMN inbound_webhook_events.payload IS 'Тело запроса провайдера без изменений';
+COMMENT ON COLUMN inbound_webhook_events.next_attempt_at IS 'Время следующей попытки; захват события воркером сдвигает его вперёд на время аренды';
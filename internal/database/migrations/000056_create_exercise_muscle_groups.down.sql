@@ -0,0 +1,4 @@
+-- 000056_create_exercise_muscle_groups.down.sql
+-- Откат справочника групп мышц упражнений
+
+DROP TABLE IF EXISTS exercise_muscle_groups;
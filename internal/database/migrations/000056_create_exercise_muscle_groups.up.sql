@@ -0,0 +1,103 @@
+-- 000056_create_exercise_muscle_groups.up.sql
+-- Справочник основных групп мышц упражнений для статистики тренировок.
+
+CREATE TABLE IF NOT EXISTS exercise_muscle_groups (
+    exercise_key VARCHAR(100) PRIMARY KEY,
+    muscle_group VARCHAR(16)  NOT NULL
+        CHECK (muscle_group IN ('chest', 'back', 'shoulders', 'arms', 'legs', 'core'))
+);
+
+COMMENT ON TABLE exercise_muscle_groups IS 'Основная группа мышц упражнения (ключ — название в нижнем регистре без лишних пробелов)';
+
+INSERT INTO exercise_muscle_groups (exercise_key, muscle_group) VALUES
+    ('bench press', 'chest'),
+    ('bench', 'chest'),
+    ('incline bench press', 'chest'),
+    ('dumbbell bench press', 'chest'),
+    ('dumbbell fly', 'chest'),
+    ('push-up', 'chest'),
+    ('push-ups', 'chest'),
+    ('dips', 'chest'),
+    ('жим лёжа', 'chest'),
+    ('жим лежа', 'chest'),
+    ('жим штанги лёжа', 'chest'),
+    ('жим гантелей лёжа', 'chest'),
+    ('жим на наклонной скамье', 'chest'),
+    ('разводка гантелей', 'chest'),
+    ('отжимания', 'chest'),
+    ('отжимания на брусьях', 'chest'),
+    ('deadlift', 'back'),
+    ('romanian deadlift', 'back'),
+    ('pull-up', 'back'),
+    ('pull-ups', 'back'),
+    ('chin-up', 'back'),
+    ('barbell row', 'back'),
+    ('bent over row', 'back'),
+    ('dumbbell row', 'back'),
+    ('lat pulldown', 'back'),
+    ('seated cable row', 'back'),
+    ('становая тяга', 'back'),
+    ('становая', 'back'),
+    ('тяга', 'back'),
+    ('румынская тяга', 'back'),
+    ('подтягивания', 'back'),
+    ('тяга штанги в наклоне', 'back'),
+    ('тяга гантели в наклоне', 'back'),
+    ('тяга верхнего блока', 'back'),
+    ('тяга нижнего блока', 'back'),
+    ('overhead press', 'shoulders'),
+    ('ohp', 'shoulders'),
+    ('military press', 'shoulders'),
+    ('dumbbell shoulder press', 'shoulders'),
+    ('lateral raise', 'shoulders'),
+    ('face pull', 'shoulders'),
+    ('жим стоя', 'shoulders'),
+    ('армейский жим', 'shoulders'),
+    ('жим гантелей сидя', 'shoulders'),
+    ('махи гантелями в стороны', 'shoulders'),
+    ('biceps curl', 'arms'),
+    ('bicep curl', 'arms'),
+    ('barbell curl', 'arms'),
+    ('hammer curl', 'arms'),
+    ('triceps extension', 'arms'),
+    ('tricep pushdown', 'arms'),
+    ('skull crusher', 'arms'),
+    ('close grip bench press', 'arms'),
+    ('подъём штанги на бицепс', 'arms'),
+    ('подъем штанги на бицепс', 'arms'),
+    ('молотки', 'arms'),
+    ('французский жим', 'arms'),
+    ('разгибание рук на блоке', 'arms'),
+    ('жим узким хватом', 'arms'),
+    ('squat', 'legs'),
+    ('back squat', 'legs'),
+    ('front squat', 'legs'),
+    ('leg press', 'legs'),
+    ('lunges', 'legs'),
+    ('bulgarian split squat', 'legs'),
+    ('leg extension', 'legs'),
+    ('leg curl', 'legs'),
+    ('hip thrust', 'legs'),
+    ('calf raise', 'legs'),
+    ('приседания', 'legs'),
+    ('приседания со штангой', 'legs'),
+    ('присед', 'legs'),
+    ('фронтальные приседания', 'legs'),
+    ('жим ногами', 'legs'),
+    ('выпады', 'legs'),
+    ('разгибание ног', 'legs'),
+    ('сгибание ног', 'legs'),
+    ('ягодичный мост', 'legs'),
+    ('подъём на носки', 'legs'),
+    ('plank', 'core'),
+    ('crunches', 'core'),
+    ('sit-ups', 'core'),
+    ('hanging leg raise', 'core'),
+    ('russian twist', 'core'),
+    ('ab wheel', 'core'),
+    ('планка', 'core'),
+    ('скручивания', 'core'),
+    ('пресс', 'core'),
+    ('подъём ног в висе', 'core'),
+    ('русский твист', 'core')
+ON CONFLICT DO NOTHING;
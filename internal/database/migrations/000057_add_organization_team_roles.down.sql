@@ -0,0 +1,10 @@
+-- 000057_add_organization_team_roles.down.sql
+-- Откат ролей команды организации: менеджеры и сотрудники становятся участниками.
+
+DROP TABLE IF EXISTS organization_invitations;
+
+UPDATE organization_members SET role = 'member' WHERE role IN ('manager', 'staff');
+
+ALTER TABLE organization_members
+    DROP CONSTRAINT IF EXISTS organization_members_role_check,
+    ADD CONSTRAINT organization_members_role_check CHECK (role IN ('owner', 'member'));
@@ -0,0 +1,21 @@
+-- 000057_add_organization_team_roles.up.sql
+-- Роли команды организации (менеджер, сотрудник) и приглашения в команду.
+
+ALTER TABLE organization_members
+    DROP CONSTRAINT IF EXISTS organization_members_role_check,
+    ADD CONSTRAINT organization_members_role_check CHECK (role IN ('owner', 'manager', 'staff', 'member'));
+
+CREATE TABLE IF NOT EXISTS organization_invitations (
+    id              UUID PRIMARY KEY,
+    organization_id UUID        NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    user_id         UUID        NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    role            VARCHAR(16) NOT NULL CHECK (role IN ('manager', 'staff')),
+    invited_by      UUID        NOT NULL,
+    created_at      TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    expires_at      TIMESTAMPTZ NOT NULL,
+    CONSTRAINT uq_organization_invitations_user UNIQUE (organization_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_organization_invitations_user ON organization_invitations (user_id, created_at);
+
+COMMENT ON TABLE organization_invitations IS 'Приглашения пользователей в команду организации; удаляются при принятии или отклонении';
@@ -0,0 +1,4 @@
+-- 000058_add_weekly_summaries.down.sql
+-- Откат еженедельной сводки тренировок
+
+DROP TABLE IF EXISTS weekly_summaries;
@@ -0,0 +1,14 @@
+-- 000058_add_weekly_summaries.up.sql
+-- Еженедельная сводка тренировок письмом: отметки обработанных недель.
+
+-- Одна отметка на пользователя и неделю в его часовом поясе: сводка не отправляется дважды.
+CREATE TABLE IF NOT EXISTS weekly_summaries (
+    user_id    UUID        NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    week_start DATE        NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (user_id, week_start)
+);
+
+CREATE INDEX IF NOT EXISTS idx_weekly_summaries_week_start ON weekly_summaries (week_start);
+
+COMMENT ON TABLE weekly_summaries IS 'Недели (с понедельника), за которые сводка тренировок пользователю уже обработана; удаляются фоновой очисткой';
@@ -0,0 +1,6 @@
+-- 000059_add_workout_validation.down.sql
+-- Откат проверки правдоподобия подходов
+
+DROP TABLE IF EXISTS exercise_limits;
+
+ALTER TABLE workout_sets DROP COLUMN IF EXISTS implausible;
@@ -0,0 +1,56 @@
+-- 000059_add_workout_validation.up.sql
+-- Проверка правдоподобия записанных подходов: пометка подходов за пределами границ
+-- и справочник границ упражнений.
+
+ALTER TABLE workout_sets ADD COLUMN IF NOT EXISTS implausible BOOLEAN NOT NULL DEFAULT FALSE;
+
+COMMENT ON COLUMN workout_sets.implausible IS 'Значения за пределами правдоподобных границ: подход не входит в статистику и рекорды';
+
+CREATE TABLE IF NOT EXISTS exercise_limits (
+    exercise_key  VARCHAR(100) PRIMARY KEY,
+    max_weight_kg NUMERIC(6,2) CHECK (max_weight_kg > 0),
+    max_reps      INTEGER      CHECK (max_reps > 0)
+);
+
+COMMENT ON TABLE exercise_limits IS 'Правдоподобные границы подхода упражнения (ключ — название в нижнем регистре без лишних пробелов); NULL — общая граница';
+
+-- Границы по весу взяты с запасом над мировыми рекордами.
+INSERT INTO exercise_limits (exercise_key, max_weight_kg, max_reps) VALUES
+    ('squat', 600, NULL),
+    ('back squat', 600, NULL),
+    ('front squat', 450, NULL),
+    ('приседания', 600, NULL),
+    ('приседания со штангой', 600, NULL),
+    ('присед', 600, NULL),
+    ('фронтальные приседания', 450, NULL),
+    ('bench press', 450, NULL),
+    ('bench', 450, NULL),
+    ('incline bench press', 350, NULL),
+    ('жим лёжа', 450, NULL),
+    ('жим лежа', 450, NULL),
+    ('жим штанги лёжа', 450, NULL),
+    ('жим на наклонной скамье', 350, NULL),
+    ('deadlift', 550, NULL),
+    ('romanian deadlift', 450, NULL),
+    ('становая тяга', 550, NULL),
+    ('становая', 550, NULL),
+    ('румынская тяга', 450, NULL),
+    ('overhead press', 250, NULL),
+    ('ohp', 250, NULL),
+    ('military press', 250, NULL),
+    ('жим стоя', 250, NULL),
+    ('армейский жим', 250, NULL),
+    ('biceps curl', 150, NULL),
+    ('bicep curl', 150, NULL),
+    ('barbell curl', 150, NULL),
+    ('подъём штанги на бицепс', 150, NULL),
+    ('подъем штанги на бицепс', 150, NULL),
+    ('pull-up', 200, 150),
+    ('pull-ups', 200, 150),
+    ('chin-up', 200, 150),
+    ('подтягивания', 200, 150),
+    ('dips', 250, 300),
+    ('отжимания на брусьях', 250, 300),
+    ('leg press', 1000, NULL),
+    ('жим ногами', 1000, NULL)
+ON CONFLICT DO NOTHING;
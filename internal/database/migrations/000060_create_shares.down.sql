@@ -0,0 +1,4 @@
+-- 000060_create_shares.down.sql
+-- Откат публичных ссылок
+
+DROP TABLE IF EXISTS shares;
@@ -0,0 +1,17 @@
+-- 000060_create_shares.up.sql
+-- Публичные ссылки на тренировки и программы.
+
+-- Токен ссылки хранится только в виде SHA-256: по утёкшей БД ссылку не восстановить.
+CREATE TABLE IF NOT EXISTS shares (
+    id            UUID        PRIMARY KEY,
+    owner_id      UUID        NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    resource_type VARCHAR(16) NOT NULL CHECK (resource_type IN ('workout', 'program')),
+    resource_id   UUID        NOT NULL,
+    token_hash    CHAR(64)    NOT NULL UNIQUE,
+    created_at    TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    revoked_at    TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_shares_owner ON shares (owner_id, created_at DESC) WHERE revoked_at IS NULL;
+
+COMMENT ON TABLE shares IS 'Ссылки только для чтения на тренировку или программу; отозванная ссылка перестаёт открываться';
@@ -0,0 +1,5 @@
+-- 000061_create_coach_clients.down.sql
+-- Откат приглашений тренеров и связей «тренер — клиент»
+
+DROP TABLE IF EXISTS coach_clients;
+DROP TABLE IF EXISTS coach_invitations;
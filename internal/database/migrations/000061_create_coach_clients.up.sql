@@ -0,0 +1,27 @@
+-- 000061_create_coach_clients.up.sql
+-- Приглашения тренеров и связи «тренер — клиент», установленные принятым приглашением.
+
+CREATE TABLE IF NOT EXISTS coach_invitations (
+    id         UUID PRIMARY KEY,
+    coach_id   UUID         NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    client_id  UUID         NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    email      VARCHAR(255) NOT NULL,
+    created_at TIMESTAMPTZ  NOT NULL DEFAULT NOW(),
+    expires_at TIMESTAMPTZ  NOT NULL,
+    CONSTRAINT uq_coach_invitations_pair UNIQUE (coach_id, client_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_coach_invitations_client ON coach_invitations (client_id, created_at);
+
+CREATE TABLE IF NOT EXISTS coach_clients (
+    coach_id   UUID        NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    client_id  UUID        NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (coach_id, client_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_coach_clients_client ON coach_clients (client_id);
+
+COMMENT ON TABLE coach_invitations IS 'Приглашения пользователей в клиенты тренера; удаляются при принятии или отклонении';
+COMMENT ON COLUMN coach_invitations.email IS 'Адрес, на который отправлено приглашение';
+COMMENT ON TABLE coach_clients IS 'Клиенты тренера, принявшие приглашение';
@@ -0,0 +1,4 @@
+-- 000062_add_assignment_index_to_workout_sessions.down.sql
+-- Откат индекса завершённых тренировок по назначениям
+
+DROP INDEX IF EXISTS idx_workout_sessions_assignment_finished;
@@ -0,0 +1,6 @@
+-- 000062_add_assignment_index_to_workout_sessions.up.sql
+-- Выполнение программ клиентом считается по завершённым тренировкам его назначений.
+
+CREATE INDEX IF NOT EXISTS idx_workout_sessions_assignment_finished
+    ON workout_sessions (assignment_id)
+    WHERE finished_at IS NOT NULL AND assignment_id IS NOT NULL;
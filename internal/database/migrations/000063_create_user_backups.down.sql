@@ -0,0 +1,4 @@
+-- 000063_create_user_backups.down.sql
+-- Откат таблицы зашифрованных резервных копий
+
+DROP TABLE IF EXISTS user_backups;
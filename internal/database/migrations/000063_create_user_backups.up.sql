@@ -0,0 +1,16 @@
+-- 000063_create_user_backups.up.sql
+-- Зашифрованные на устройстве резервные копии локальных данных мобильного приложения.
+
+CREATE TABLE IF NOT EXISTS user_backups (
+    id          UUID PRIMARY KEY,
+    user_id     UUID         NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    version     INTEGER      NOT NULL CHECK (version > 0),
+    size_bytes  BIGINT       NOT NULL CHECK (size_bytes > 0),
+    sha256      CHAR(64)     NOT NULL,
+    storage_key VARCHAR(512) NOT NULL,
+    created_at  TIMESTAMPTZ  NOT NULL DEFAULT NOW(),
+    CONSTRAINT uq_user_backups_user_version UNIQUE (user_id, version)
+);
+
+COMMENT ON TABLE user_backups IS 'Версии зашифрованных резервных копий; содержимое хранится в хранилище файлов';
+COMMENT ON COLUMN user_backups.sha256 IS 'SHA-256 зашифрованного содержимого в hex, проверяется при загрузке';
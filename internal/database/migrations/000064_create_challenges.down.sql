@@ -0,0 +1,5 @@
+-- 000064_create_challenges.down.sql
+-- Откат таблиц челленджей
+
+DROP TABLE IF EXISTS challenge_participants;
+DROP TABLE IF EXISTS challenges;
@@ -0,0 +1,28 @@
+-- 000064_create_challenges.up.sql
+-- Челленджи («30 тренировок за 30 дней») и их участники.
+
+CREATE TABLE IF NOT EXISTS challenges (
+    id          UUID PRIMARY KEY,
+    creator_id  UUID          NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    title       VARCHAR(100)  NOT NULL,
+    description VARCHAR(1000) NOT NULL DEFAULT '',
+    metric      VARCHAR(16)   NOT NULL CHECK (metric IN ('sessions', 'active_days')),
+    target      INTEGER       NOT NULL CHECK (target > 0),
+    starts_at   TIMESTAMPTZ   NOT NULL,
+    ends_at     TIMESTAMPTZ   NOT NULL,
+    created_at  TIMESTAMPTZ   NOT NULL DEFAULT NOW(),
+    CHECK (ends_at > starts_at)
+);
+
+CREATE INDEX IF NOT EXISTS idx_challenges_ends_at ON challenges (ends_at);
+
+CREATE TABLE IF NOT EXISTS challenge_participants (
+    challenge_id UUID        NOT NULL REFERENCES challenges(id) ON DELETE CASCADE,
+    user_id      UUID        NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    joined_at    TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (challenge_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_challenge_participants_user ON challenge_participants (user_id);
+
+COMMENT ON TABLE challenges IS 'Челленджи; прогресс участников считается по завершённым тренировкам за [starts_at, ends_at)';
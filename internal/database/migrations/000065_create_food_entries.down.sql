@@ -0,0 +1,4 @@
+-- 000065_create_food_entries.down.sql
+-- Откат дневника питания
+
+DROP TABLE IF EXISTS food_entries;
@@ -0,0 +1,22 @@
+-- 000065_create_food_entries.up.sql
+-- Дневник питания: продукты и блюда с калорийностью и БЖУ по дням.
+
+CREATE TABLE IF NOT EXISTS food_entries (
+    id         UUID PRIMARY KEY,
+    user_id    UUID         NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    date       DATE         NOT NULL,
+    meal       VARCHAR(16)  NOT NULL CHECK (meal IN ('breakfast', 'lunch', 'dinner', 'snack')),
+    name       VARCHAR(100) NOT NULL,
+    calories   INTEGER      NOT NULL CHECK (calories >= 0),
+    protein_g  NUMERIC(5,1) NOT NULL DEFAULT 0 CHECK (protein_g >= 0),
+    carbs_g    NUMERIC(5,1) NOT NULL DEFAULT 0 CHECK (carbs_g >= 0),
+    fat_g      NUMERIC(5,1) NOT NULL DEFAULT 0 CHECK (fat_g >= 0),
+    created_at TIMESTAMPTZ  NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ  NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_food_entries_user_date ON food_entries (user_id, date);
+
+COMMENT ON TABLE food_entries IS 'Записи дневника питания';
+COMMENT ON COLUMN food_entries.date IS 'День дневника в часовом поясе пользователя';
+COMMENT ON COLUMN food_entries.calories IS 'Калорийность порции, ккал';
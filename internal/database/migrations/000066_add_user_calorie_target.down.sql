@@ -0,0 +1,5 @@
+-- 000066_add_user_calorie_target.down.sql
+-- Откат дневной цели по калориям
+
+ALTER TABLE users
+    DROP COLUMN IF EXISTS calorie_target;
@@ -0,0 +1,7 @@
+-- 000066_add_user_calorie_target.up.sql
+-- Дневная цель по калориям в профиле пользователя.
+
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS calorie_target INTEGER CHECK (calorie_target IS NULL OR calorie_target > 0);
+
+COMMENT ON COLUMN users.calorie_target IS 'Дневная цель по калориям, ккал; NULL — цель не задана';
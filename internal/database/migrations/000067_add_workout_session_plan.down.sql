@@ -0,0 +1,5 @@
+-- 000067_add_workout_session_plan.down.sql
+-- Откат плана тренировки
+
+ALTER TABLE workout_sessions
+    DROP COLUMN IF EXISTS plan;
@@ -0,0 +1,7 @@
+-- 000067_add_workout_session_plan.up.sql
+-- План тренировки программы (упражнения, темп и отдых), зафиксированный при старте тренировки.
+
+ALTER TABLE workout_sessions
+    ADD COLUMN IF NOT EXISTS plan JSONB;
+
+COMMENT ON COLUMN workout_sessions.plan IS 'Упражнения тренировки программы с предписаниями темпа и отдыха на момент старта; NULL — свободная тренировка';
@@ -0,0 +1,4 @@
+-- 000068_create_habit_days.down.sql
+-- Откат ежедневных привычек
+
+DROP TABLE IF EXISTS habit_days;
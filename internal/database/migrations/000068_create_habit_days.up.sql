@@ -0,0 +1,18 @@
+-- 000068_create_habit_days.up.sql
+-- Ежедневные привычки: выпитая вода, сон и пульс покоя — одна запись на день.
+
+CREATE TABLE IF NOT EXISTS habit_days (
+    user_id     UUID         NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    date        DATE         NOT NULL,
+    water_ml    INTEGER      CHECK (water_ml IS NULL OR water_ml >= 0),
+    sleep_hours NUMERIC(3,1) CHECK (sleep_hours IS NULL OR sleep_hours >= 0),
+    resting_hr  SMALLINT     CHECK (resting_hr IS NULL OR resting_hr > 0),
+    updated_at  TIMESTAMPTZ  NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (user_id, date)
+);
+
+COMMENT ON TABLE habit_days IS 'Ежедневные привычки пользователя';
+COMMENT ON COLUMN habit_days.date IS 'День в часовом поясе пользователя';
+COMMENT ON COLUMN habit_days.water_ml IS 'Выпитая вода за день, мл';
+COMMENT ON COLUMN habit_days.sleep_hours IS 'Сон в ночь перед днём, часы';
+COMMENT ON COLUMN habit_days.resting_hr IS 'Пульс покоя, уд/мин';
@@ -0,0 +1,8 @@
+-- 000069_create_health_sync.down.sql
+-- Откат импорта из Apple Health и Google Fit
+
+DROP TABLE IF EXISTS health_links;
+DROP TABLE IF EXISTS health_connections;
+
+ALTER TABLE habit_days
+    DROP COLUMN IF EXISTS steps;
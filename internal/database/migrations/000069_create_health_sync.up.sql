@@ -0,0 +1,35 @@
+-- 000069_create_health_sync.up.sql
+-- Импорт из Apple Health и Google Fit: шаги в привычках дня, связи внешних записей
+-- с импортированными данными и состояние синхронизации по каждому источнику.
+
+ALTER TABLE habit_days
+    ADD COLUMN IF NOT EXISTS steps INTEGER CHECK (steps IS NULL OR steps >= 0);
+
+COMMENT ON COLUMN habit_days.steps IS 'Шаги за день';
+
+CREATE TABLE IF NOT EXISTS health_connections (
+    user_id        UUID        NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    provider       VARCHAR(32) NOT NULL,
+    items          INTEGER     NOT NULL DEFAULT 0,
+    synced_until   TIMESTAMPTZ,
+    last_synced_at TIMESTAMPTZ,
+    created_at     TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (user_id, provider)
+);
+
+COMMENT ON TABLE health_connections IS 'Состояние синхронизации с Apple Health и Google Fit';
+COMMENT ON COLUMN health_connections.items IS 'Всего импортированных записей источника';
+COMMENT ON COLUMN health_connections.synced_until IS 'Конец самой поздней записи из выгрузок — курсор инкрементальной синхронизации';
+
+CREATE TABLE IF NOT EXISTS health_links (
+    user_id     UUID         NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    provider    VARCHAR(32)  NOT NULL,
+    external_id VARCHAR(255) NOT NULL,
+    kind        VARCHAR(16)  NOT NULL,
+    entity_id   UUID,
+    created_at  TIMESTAMPTZ  NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (user_id, provider, external_id)
+);
+
+COMMENT ON TABLE health_links IS 'Записи Apple Health и Google Fit, уже импортированные пользователю';
+COMMENT ON COLUMN health_links.entity_id IS 'Созданная тренировка или замер; NULL — запись учтена в привычках дня';
@@ -1,9 +1,41 @@
 package migrations
 
-import "embed"
+import (
+	"embed"
+	"io/fs"
+	"regexp"
+	"strconv"
+)
 
 // Migrations содержит все SQL файлы миграций, встроенные в бинарник.
 // Используется для загрузки миграций через golang-migrate.
 //
 //go:embed *.sql
 var Migrations embed.FS
+
+// upPattern выделяет номер версии из имени файла up-миграции.
+var upPattern = regexp.MustCompile(`^(\d+)_.+\.up\.sql$`)
+
+// LatestVersion возвращает номер последней встроенной миграции.
+// Сравнивается с версией схемы в БД, чтобы обнаружить непримененные миграции.
+func LatestVersion() (uint, error) {
+	entries, err := fs.ReadDir(Migrations, ".")
+	if err != nil {
+		return 0, err
+	}
+	var latest uint
+	for _, e := range entries {
+		m := upPattern.FindStringSubmatch(e.Name())
+		if m == nil {
+			continue
+		}
+		v, err := strconv.ParseUint(m[1], 10, 64)
+		if err != nil {
+			return 0, err
+		}
+		if uint(v) > latest {
+			latest = uint(v)
+		}
+	}
+	return latest, nil
+}
@@ -1,6 +1,7 @@
 package database
 
 import (
+	"context"
 	"database/sql"
 	"errors"
 	"fmt"
@@ -25,6 +26,9 @@ var (
 	// ErrDirtyState возвращается, когда миграции находятся в "грязном" состоянии.
 	// Это означает, что миграция была прервана и требует ручного вмешательства.
 	ErrDirtyState = errors.New("database is in dirty state")
+
+	// ErrPendingMigrations возвращается, когда схема БД отстаёт от встроенных миграций.
+	ErrPendingMigrations = errors.New("database has pending migrations")
 )
 
 // Migrator предоставляет функционал для управления миграциями базы данных.
@@ -205,3 +209,32 @@ func (m *Migrator) CheckDirty() (bool, error) {
 	}
 	return false, nil
 }
+
+// CheckSchema проверяет, что схема БД в актуальном состоянии: миграции не в "грязном"
+// состоянии и применены все встроенные миграции. Читает schema_migrations напрямую,
+// не создавая мигратор, поэтому подходит для частых readiness-проверок.
+func (db *DB) CheckSchema(ctx context.Context) error {
+	latest, err := migrations.LatestVersion()
+	if err != nil {
+		return fmt.Errorf("ошибка чтения встроенных миграций: %w", err)
+	}
+
+	var row struct {
+		Version uint
+		Dirty   bool
+	}
+	result := db.DB.WithContext(ctx).Raw("SELECT version, dirty FROM schema_migrations LIMIT 1").Scan(&row)
+	if result.Error != nil {
+		return fmt.Errorf("ошибка чтения версии схемы: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("%w: схема не инициализирована, ожидается версия %d", ErrPendingMigrations, latest)
+	}
+	if row.Dirty {
+		return fmt.Errorf("%w: версия %d", ErrDirtyState, row.Version)
+	}
+	if row.Version < latest {
+		return fmt.Errorf("%w: версия %d, ожидается %d", ErrPendingMigrations, row.Version, latest)
+	}
+	return nil
+}
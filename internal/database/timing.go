@@ -0,0 +1,45 @@
+package database
+
+import (
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+
+	"workout-app/pkg/servertiming"
+)
+
+// timingStartKey — ключ времени начала запроса в экземпляре gorm.Statement.
+const timingStartKey = "servertiming:start"
+
+// registerServerTiming добавляет в GORM callbacks, учитывающие каждый SQL-запрос
+// в метрике db заголовка Server-Timing. Если в контексте запроса нет накопителя
+// метрик (фоновые задачи, миграции), учёт не выполняется.
+func registerServerTiming(db *gorm.DB) error {
+	before := func(tx *gorm.DB) {
+		tx.InstanceSet(timingStartKey, time.Now())
+	}
+	after := func(tx *gorm.DB) {
+		start, ok := tx.InstanceGet(timingStartKey)
+		if !ok {
+			return
+		}
+		servertiming.Track(tx.Statement.Context, servertiming.DB, start.(time.Time))
+	}
+
+	cb := db.Callback()
+	return errors.Join(
+		cb.Create().Before("gorm:create").Register("servertiming:before_create", before),
+		cb.Create().After("gorm:create").Register("servertiming:after_create", after),
+		cb.Query().Before("gorm:query").Register("servertiming:before_query", before),
+		cb.Query().After("gorm:query").Register("servertiming:after_query", after),
+		cb.Update().Before("gorm:update").Register("servertiming:before_update", before),
+		cb.Update().After("gorm:update").Register("servertiming:after_update", after),
+		cb.Delete().Before("gorm:delete").Register("servertiming:before_delete", before),
+		cb.Delete().After("gorm:delete").Register("servertiming:after_delete", after),
+		cb.Row().Before("gorm:row").Register("servertiming:before_row", before),
+		cb.Row().After("gorm:row").Register("servertiming:after_row", after),
+		cb.Raw().Before("gorm:raw").Register("servertiming:before_raw", before),
+		cb.Raw().After("gorm:raw").Register("servertiming:after_raw", after),
+	)
+}
@@ -0,0 +1,140 @@
+// Package doctor проверяет готовность окружения к запуску сервера: конфигурацию, базу данных,
+// миграции, SMTP и стойкость ключей JWT. Используется командой `server doctor` (`server -check`)
+// в пайплайнах деплоя до переключения трафика.
+package doctor
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"workout-app/internal/config"
+	"workout-app/internal/database"
+	"workout-app/internal/mailer"
+	"workout-app/pkg/logger"
+)
+
+// Status — итог одной проверки.
+type Status string
+
+const (
+	StatusOK   Status = "ok"
+	StatusWarn Status = "warn"
+	StatusFail Status = "fail"
+	StatusSkip Status = "skip"
+)
+
+// Result описывает итог одной проверки.
+type Result struct {
+	Name     string
+	Status   Status
+	Message  string
+	Duration time.Duration
+}
+
+// Report — итоги всех проверок в порядке выполнения.
+type Report struct {
+	Results []Result
+}
+
+// Failed сообщает, провалилась ли хотя бы одна проверка. Предупреждения запуск не блокируют.
+func (r *Report) Failed() bool {
+	for _, res := range r.Results {
+		if res.Status == StatusFail {
+			return true
+		}
+	}
+	return false
+}
+
+// Write печатает отчёт в читаемом виде: строка на проверку и итог.
+func (r *Report) Write(w io.Writer) {
+	for _, res := range r.Results {
+		line := fmt.Sprintf("[%-4s] %-10s %s", res.Status, res.Name, res.Message)
+		if res.Duration > 0 {
+			line += fmt.Sprintf(" (%s)", res.Duration.Round(time.Millisecond))
+		}
+		fmt.Fprintln(w, line)
+	}
+	if r.Failed() {
+		fmt.Fprintln(w, "FAIL: окружение не готово к запуску")
+	} else {
+		fmt.Fprintln(w, "OK: окружение готово к запуску")
+	}
+}
+
+func (r *Report) add(res Result) {
+	r.Results = append(r.Results, res)
+}
+
+// checkTimeout ограничивает время каждой сетевой проверки.
+const checkTimeout = 5 * time.Second
+
+// Run выполняет все проверки. Без корректной конфигурации остальные проверки не выполняются,
+// без подключения к БД пропускается проверка миграций.
+func Run(ctx context.Context) *Report {
+	report := &Report{}
+
+	cfg, err := config.Load()
+	if err != nil {
+		report.add(Result{Name: "config", Status: StatusFail, Message: err.Error()})
+		return report
+	}
+	report.add(Result{Name: "config", Status: StatusOK, Message: fmt.Sprintf("APP_ENV=%s", cfg.AppEnv)})
+
+	report.add(CheckJWT(&cfg.JWT, cfg.AppEnv))
+
+	db, res := timed("database", func() (Status, string, *database.DB) {
+		db, err := database.NewConnection(&cfg.Database, cfg.AppEnv)
+		if err != nil {
+			return StatusFail, err.Error(), nil
+		}
+		return StatusOK, fmt.Sprintf("%s@%s:%s/%s", cfg.Database.User, cfg.Database.Host, cfg.Database.Port, cfg.Database.DBName), db
+	})
+	report.add(res)
+	if db != nil {
+		defer db.Close()
+		_, res = timed("migrations", func() (Status, string, any) {
+			ctx, cancel := context.WithTimeout(ctx, checkTimeout)
+			defer cancel()
+			if err := db.CheckSchema(ctx); err != nil {
+				return StatusFail, err.Error(), nil
+			}
+			return StatusOK, "схема актуальна", nil
+		})
+		report.add(res)
+	} else {
+		report.add(Result{Name: "migrations", Status: StatusSkip, Message: "нет подключения к базе данных"})
+	}
+
+	report.add(checkSMTP(ctx, &cfg.Email))
+	return report
+}
+
+// checkSMTP проверяет доступность SMTP-сервера, если письма отправляются через SMTP.
+func checkSMTP(ctx context.Context, cfg *config.EmailConfig) Result {
+	if cfg.Provider != "smtp" {
+		return Result{Name: "smtp", Status: StatusSkip, Message: "письма отправляются через API провайдера " + cfg.Provider}
+	}
+	if cfg.SMTPHost == "" {
+		return Result{Name: "smtp", Status: StatusWarn, Message: "EMAIL_SMTP_HOST не задан: письма не отправляются"}
+	}
+	_, res := timed("smtp", func() (Status, string, any) {
+		ctx, cancel := context.WithTimeout(ctx, checkTimeout)
+		defer cancel()
+		sender := mailer.NewSMTPSender(cfg, nil, logger.Default())
+		if err := sender.Ping(ctx); err != nil {
+			return StatusFail, err.Error(), nil
+		}
+		return StatusOK, fmt.Sprintf("%s:%d доступен", cfg.SMTPHost, cfg.SMTPPort), nil
+	})
+	return res
+}
+
+// timed выполняет проверку и замеряет её длительность.
+func timed[T any](name string, fn func() (Status, string, T)) (T, Result) {
+	start := time.Now()
+	status, message, value := fn()
+	return value, Result{Name: name, Status: status, Message: message, Duration: time.Since(start)}
+}
@@ -0,0 +1,86 @@
+package doctor
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"workout-app/internal/config"
+	jwtsvc "workout-app/pkg/jwt"
+)
+
+// minSecretLength — минимальная длина секрета HS256 в байтах (256 бит, RFC 7518 §3.2).
+const minSecretLength = 32
+
+// weakSecretMarkers — фрагменты секретов-заглушек из env.example и документации.
+var weakSecretMarkers = []string{"change_me", "changeme", "secret", "example"}
+
+// CheckJWT проверяет стойкость секретов подписи токенов и загружаемость асимметричных ключей.
+// В production слабые секреты — ошибка, в остальных окружениях — предупреждение.
+func CheckJWT(cfg *config.JWTConfig, appEnv string) Result {
+	res := Result{Name: "jwt"}
+
+	secrets := map[string]string{
+		"JWT_ACCESS_SECRET":  cfg.AccessSecret,
+		"JWT_REFRESH_SECRET": cfg.RefreshSecret,
+	}
+	for kid, secret := range cfg.AccessKeys {
+		secrets["JWT_ACCESS_KEYS["+kid+"]"] = secret
+	}
+	for kid, secret := range cfg.RefreshKeys {
+		secrets["JWT_REFRESH_KEYS["+kid+"]"] = secret
+	}
+
+	var problems []string
+	for _, name := range slices.Sorted(maps.Keys(secrets)) {
+		if reason := weakness(secrets[name]); reason != "" {
+			problems = append(problems, name+": "+reason)
+		}
+	}
+	if cfg.AccessSecret == cfg.RefreshSecret {
+		problems = append(problems, "JWT_ACCESS_SECRET и JWT_REFRESH_SECRET совпадают")
+	}
+
+	if cfg.SigningAlgorithm != "" && cfg.SigningAlgorithm != jwtsvc.AlgorithmHS256 {
+		if _, err := jwtsvc.NewService(cfg); err != nil {
+			res.Status = StatusFail
+			res.Message = err.Error()
+			return res
+		}
+	}
+
+	switch {
+	case len(problems) == 0:
+		res.Status = StatusOK
+		res.Message = fmt.Sprintf("подпись access-токенов %s, секреты не короче %d байт", algorithm(cfg), minSecretLength)
+	case strings.EqualFold(appEnv, "production"):
+		res.Status = StatusFail
+		res.Message = strings.Join(problems, "; ")
+	default:
+		res.Status = StatusWarn
+		res.Message = strings.Join(problems, "; ")
+	}
+	return res
+}
+
+// weakness возвращает причину, по которой секрет считается слабым, или пустую строку.
+func weakness(secret string) string {
+	if len(secret) < minSecretLength {
+		return fmt.Sprintf("короче %d байт", minSecretLength)
+	}
+	lower := strings.ToLower(secret)
+	for _, marker := range weakSecretMarkers {
+		if strings.Contains(lower, marker) {
+			return "похож на заглушку из примера конфигурации"
+		}
+	}
+	return ""
+}
+
+func algorithm(cfg *config.JWTConfig) string {
+	if cfg.SigningAlgorithm == "" {
+		return jwtsvc.AlgorithmHS256
+	}
+	return cfg.SigningAlgorithm
+}
@@ -0,0 +1,30 @@
+package audit
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Change описывает изменение одного поля цели действия.
+// Old не заполняется, если прежнее значение неизвестно.
+type Change struct {
+	Old any `json:"old,omitempty"`
+	New any `json:"new,omitempty"`
+}
+
+// Diff — изменения, внесённые действием, по именам полей.
+type Diff map[string]Change
+
+// Entry — запись журнала действий администраторов. Журнал только дополняется.
+type Entry struct {
+	ID       uuid.UUID
+	ActorID  uuid.UUID
+	Action   string // Метод и маршрут, например "PATCH /api/v1/admin/users/:id/role"
+	TargetID string // Параметр :id маршрута: пользователь, организация, ключ эксперимента; пусто для списков
+	Query    string // Строка запроса — фильтры списков
+	Diff     Diff   // Изменения цели; nil, если действие их не сообщает
+	Status   int    // HTTP-статус ответа
+	IP       string
+	At       time.Time
+}
@@ -0,0 +1,58 @@
+package backfill
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Status описывает состояние задачи пересчёта.
+type Status string
+
+const (
+	StatusPending   Status = "pending"   // создана, ещё не взята в работу
+	StatusRunning   Status = "running"   // выполняется (или прервана рестартом и будет продолжена)
+	StatusCompleted Status = "completed" // все элементы обработаны
+	StatusFailed    Status = "failed"    // остановлена из-за ошибки
+	StatusCancelled Status = "cancelled" // отменена администратором
+)
+
+// IsFinal возвращает true для состояний, из которых задача больше не продолжается.
+func (s Status) IsFinal() bool {
+	return s == StatusCompleted || s == StatusFailed || s == StatusCancelled
+}
+
+// Backfill представляет задачу пересчёта исторических данных.
+// Задача обрабатывает элементы пачками по возрастанию курсора и сохраняет прогресс после каждой пачки,
+// поэтому после рестарта продолжает работу с места остановки.
+type Backfill struct {
+	ID              uuid.UUID
+	Job             string     // Имя зарегистрированного типа задачи
+	Status          Status     // Текущее состояние
+	BatchSize       int        // Размер пачки
+	Cursor          string     // Курсор последнего обработанного элемента ("" — с начала)
+	Processed       int64      // Сколько элементов обработано
+	Total           int64      // Оценка общего количества элементов на момент запуска
+	LastError       string     // Текст ошибки, если задача упала
+	CancelRequested bool       // Администратор запросил отмену
+	CreatedBy       uuid.UUID  // Кто запустил задачу
+	StartedAt       *time.Time // Когда задача впервые взята в работу
+	FinishedAt      *time.Time // Когда задача завершилась
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+}
+
+// New — фабрика для создания новой задачи пересчёта.
+func New(job string, batchSize int, total int64, createdBy uuid.UUID) *Backfill {
+	now := time.Now().UTC()
+	return &Backfill{
+		ID:        uuid.New(),
+		Job:       job,
+		Status:    StatusPending,
+		BatchSize: batchSize,
+		Total:     total,
+		CreatedBy: createdBy,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+}
@@ -0,0 +1,33 @@
+package backup
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Backup описывает версию резервной копии локальных данных мобильного приложения.
+// Копия шифруется на устройстве; сервер хранит её как непрозрачный blob и проверяет
+// только размер и контрольную сумму, поэтому ключи шифрования ему неизвестны.
+type Backup struct {
+	ID         uuid.UUID
+	UserID     uuid.UUID
+	Version    int    // Порядковый номер версии у пользователя, начиная с 1
+	SizeBytes  int64  // Размер зашифрованного содержимого
+	SHA256     string // Контрольная сумма зашифрованного содержимого в hex
+	StorageKey string // Ключ объекта в хранилище файлов
+	CreatedAt  time.Time
+}
+
+// New — фабрика для создания записи о только что загруженной версии резервной копии.
+func New(userID uuid.UUID, version int, size int64, sha256, storageKey string) *Backup {
+	return &Backup{
+		ID:         uuid.New(),
+		UserID:     userID,
+		Version:    version,
+		SizeBytes:  size,
+		SHA256:     sha256,
+		StorageKey: storageKey,
+		CreatedAt:  time.Now().UTC(),
+	}
+}
@@ -0,0 +1,93 @@
+package challenge
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Metric — показатель, по которому считается прогресс участника челленджа.
+type Metric string
+
+const (
+	MetricSessions   Metric = "sessions"    // число завершённых тренировок
+	MetricActiveDays Metric = "active_days" // число дней (UTC) хотя бы с одной завершённой тренировкой
+)
+
+// IsValid проверяет, что показатель поддерживается.
+func (m Metric) IsValid() bool {
+	return m == MetricSessions || m == MetricActiveDays
+}
+
+// Status описывает этап челленджа относительно текущего момента.
+type Status string
+
+const (
+	StatusUpcoming Status = "upcoming" // ещё не начался, вступить уже можно
+	StatusActive   Status = "active"   // идёт
+	StatusFinished Status = "finished" // завершён, состав и результаты зафиксированы
+)
+
+// Challenge описывает челлендж, например «30 тренировок за 30 дней».
+// Прогресс участников считается автоматически по завершённым тренировкам за период [StartsAt, EndsAt),
+// независимо от того, когда участник вступил.
+type Challenge struct {
+	ID          uuid.UUID
+	CreatorID   uuid.UUID
+	Title       string
+	Description string
+	Metric      Metric
+	Target      int // Значение показателя, при котором челлендж считается выполненным
+	StartsAt    time.Time
+	EndsAt      time.Time
+	CreatedAt   time.Time
+}
+
+// New — фабрика для создания челленджа.
+func New(creatorID uuid.UUID, title, description string, metric Metric, target int, startsAt, endsAt time.Time) *Challenge {
+	return &Challenge{
+		ID:          uuid.New(),
+		CreatorID:   creatorID,
+		Title:       title,
+		Description: description,
+		Metric:      metric,
+		Target:      target,
+		StartsAt:    startsAt,
+		EndsAt:      endsAt,
+		CreatedAt:   time.Now().UTC(),
+	}
+}
+
+// Status возвращает этап челленджа на момент now.
+func (c *Challenge) Status(now time.Time) Status {
+	switch {
+	case now.Before(c.StartsAt):
+		return StatusUpcoming
+	case now.Before(c.EndsAt):
+		return StatusActive
+	default:
+		return StatusFinished
+	}
+}
+
+// Participant описывает участие пользователя в челлендже.
+type Participant struct {
+	ChallengeID uuid.UUID
+	UserID      uuid.UUID
+	JoinedAt    time.Time
+}
+
+// Listing — челлендж с числом участников и признаком участия текущего пользователя.
+type Listing struct {
+	Challenge    *Challenge
+	Participants int
+	Joined       bool
+}
+
+// Progress — значение показателя участника за период челленджа.
+type Progress struct {
+	UserID   uuid.UUID
+	Username string
+	Value    int
+	JoinedAt time.Time
+}
@@ -0,0 +1,168 @@
+package checkin
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Шкала оценок самочувствия (слайдеры в приложении).
+const (
+	MinRating = 1
+	MaxRating = 10
+)
+
+// CheckIn описывает ежедневную анкету готовности к тренировке: одна запись на пользователя в день.
+type CheckIn struct {
+	ID         uuid.UUID
+	UserID     uuid.UUID
+	Date       time.Time // День анкеты (полночь UTC)
+	Soreness   int       // Мышечная боль: 1 — нет, 10 — сильная
+	Energy     int       // Уровень энергии: 1 — низкий, 10 — высокий
+	Motivation int       // Желание тренироваться: 1 — нет, 10 — высокое
+	Note       string    // Комментарий пользователя (опционально)
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+// Recommendation описывает рекомендуемую нагрузку по готовности за последние дни.
+type Recommendation string
+
+const (
+	RecommendationTrain  Recommendation = "train"            // тренироваться по плану
+	RecommendationReduce Recommendation = "reduce_intensity" // снизить интенсивность
+	RecommendationDeload Recommendation = "deload"           // разгрузочная неделя
+	RecommendationNone   Recommendation = "insufficient_data"
+)
+
+// Readiness описывает готовность пользователя по анкетам за окно ReadinessWindow.
+type Readiness struct {
+	CheckIns        int     // Анкет в окне
+	AverageScore    float64 // Средняя оценка готовности (0–100)
+	AverageSoreness float64
+	Recommendation  Recommendation
+}
+
+// Streak описывает серии ежедневных анкет.
+type Streak struct {
+	Current      int  // Дней подряд, включая сегодня или вчера
+	Longest      int  // Самая длинная серия
+	CheckedToday bool // Заполнена ли анкета сегодня
+}
+
+// Пороги рекомендаций: готовность оценивается по анкетам за последнюю неделю.
+const (
+	ReadinessWindow      = 7 * 24 * time.Hour
+	minCheckInsForAdvice = 3
+	deloadScore          = 40
+	reduceScore          = 60
+	deloadSoreness       = 8
+)
+
+// New — фабрика для создания анкеты за день date.
+func New(userID uuid.UUID, date time.Time, soreness, energy, motivation int, note string) *CheckIn {
+	now := time.Now().UTC()
+	return &CheckIn{
+		ID:         uuid.New(),
+		UserID:     userID,
+		Date:       Day(date),
+		Soreness:   soreness,
+		Energy:     energy,
+		Motivation: motivation,
+		Note:       note,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+}
+
+// Day приводит момент времени к дню анкеты (полночь UTC).
+func Day(t time.Time) time.Time {
+	y, m, d := t.UTC().Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+// ValidRating возвращает true для оценки в пределах шкалы.
+func ValidRating(v int) bool {
+	return v >= MinRating && v <= MaxRating
+}
+
+// Score возвращает оценку готовности 0–100: высокие энергия и мотивация и низкая боль повышают её.
+func (c *CheckIn) Score() int {
+	span := float64(MaxRating - MinRating)
+	sum := float64(MaxRating-c.Soreness) + float64(c.Energy-MinRating) + float64(c.Motivation-MinRating)
+	return int(math.Round(sum / (3 * span) * 100))
+}
+
+// AssessReadiness считает готовность по анкетам, заполненным в окне ReadinessWindow до now.
+func AssessReadiness(checkIns []*CheckIn, now time.Time) Readiness {
+	since := Day(now).Add(-ReadinessWindow + 24*time.Hour)
+	var r Readiness
+	var scoreSum, sorenessSum int
+	for _, c := range checkIns {
+		if c.Date.Before(since) || c.Date.After(now) {
+			continue
+		}
+		r.CheckIns++
+		scoreSum += c.Score()
+		sorenessSum += c.Soreness
+	}
+	if r.CheckIns == 0 {
+		r.Recommendation = RecommendationNone
+		return r
+	}
+	r.AverageScore = math.Round(float64(scoreSum)/float64(r.CheckIns)*10) / 10
+	r.AverageSoreness = math.Round(float64(sorenessSum)/float64(r.CheckIns)*10) / 10
+
+	switch {
+	case r.CheckIns < minCheckInsForAdvice:
+		r.Recommendation = RecommendationNone
+	case r.AverageScore < deloadScore || r.AverageSoreness >= deloadSoreness:
+		r.Recommendation = RecommendationDeload
+	case r.AverageScore < reduceScore:
+		r.Recommendation = RecommendationReduce
+	default:
+		r.Recommendation = RecommendationTrain
+	}
+	return r
+}
+
+// ComputeStreak считает серии по дням заполненных анкет. Текущая серия не прерывается,
+// пока не закончился день после последней анкеты: её можно продолжить сегодня.
+func ComputeStreak(days []time.Time, now time.Time) Streak {
+	sorted := make([]time.Time, 0, len(days))
+	for _, d := range days {
+		sorted = append(sorted, Day(d))
+	}
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Before(sorted[j]) })
+
+	var s Streak
+	run := 0
+	var prev time.Time
+	for i, d := range sorted {
+		switch {
+		case i > 0 && d.Equal(prev):
+			continue
+		case i > 0 && d.Sub(prev) == 24*time.Hour:
+			run++
+		default:
+			run = 1
+		}
+		prev = d
+		if run > s.Longest {
+			s.Longest = run
+		}
+	}
+
+	today := Day(now)
+	if len(sorted) > 0 {
+		last := sorted[len(sorted)-1]
+		// Анкета «на завтра» возможна у пользователей восточнее UTC.
+		s.CheckedToday = !last.Before(today)
+		if !last.Before(today.Add(-24 * time.Hour)) {
+			s.Current = run
+		}
+	}
+	return s
+}
@@ -0,0 +1,28 @@
+package clientversion
+
+import "time"
+
+// HeaderPlatform — заголовок, в котором мобильный клиент сообщает свою платформу.
+const HeaderPlatform = "X-App-Platform"
+
+// Platform описывает платформу клиентского приложения.
+type Platform string
+
+const (
+	PlatformIOS     Platform = "ios"
+	PlatformAndroid Platform = "android"
+)
+
+// IsValid возвращает true для известных платформ.
+func (p Platform) IsValid() bool {
+	return p == PlatformIOS || p == PlatformAndroid
+}
+
+// Policy задаёт минимальную поддерживаемую версию клиента для платформы.
+// Клиенты ниже MinVersion получают 426 Upgrade Required со ссылкой на магазин приложений.
+type Policy struct {
+	Platform   Platform  // Платформа клиента
+	MinVersion string    // Минимальная поддерживаемая версия (major.minor.patch)
+	StoreURL   string    // Ссылка на приложение в магазине для обновления
+	UpdatedAt  time.Time // Время последнего изменения
+}
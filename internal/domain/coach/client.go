@@ -0,0 +1,44 @@
+package coach
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Invitation — приглашение зарегистрированного пользователя стать клиентом тренера.
+// Приглашение приходит письмом; пользователь принимает или отклоняет его в приложении.
+// Принятое и отклонённое приглашения удаляются; до ExpiresAt повторно пригласить пользователя нельзя.
+type Invitation struct {
+	ID        uuid.UUID
+	CoachID   uuid.UUID
+	ClientID  uuid.UUID
+	Email     string // Адрес, на который отправлено приглашение
+	CreatedAt time.Time
+	ExpiresAt time.Time
+}
+
+// NewInvitation — фабрика для приглашения со сроком действия ttl.
+func NewInvitation(coachID, clientID uuid.UUID, email string, at time.Time, ttl time.Duration) *Invitation {
+	return &Invitation{
+		ID:        uuid.New(),
+		CoachID:   coachID,
+		ClientID:  clientID,
+		Email:     email,
+		CreatedAt: at,
+		ExpiresAt: at.Add(ttl),
+	}
+}
+
+// IsExpired сообщает, истёк ли срок приглашения к моменту now.
+func (i *Invitation) IsExpired(now time.Time) bool {
+	return !now.Before(i.ExpiresAt)
+}
+
+// Client — связь «тренер — клиент», установленная принятым приглашением.
+// Только она даёт тренеру право назначать программы и запрашивать данные клиента.
+type Client struct {
+	CoachID   uuid.UUID
+	ClientID  uuid.UUID
+	CreatedAt time.Time
+}
@@ -0,0 +1,56 @@
+package coach
+
+import (
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+// Ограничения заметок и тегов тренера о клиенте.
+const (
+	MaxNoteLength = 5000 // Символов в тексте заметки
+	MaxTagLength  = 32   // Символов в теге
+	MaxClientTags = 20   // Тегов на одного клиента
+)
+
+// Note — личная заметка тренера о клиенте. Заметки видит только тренер, который их написал;
+// клиенту они не показываются и удаляются, когда связь «тренер — клиент» заканчивается.
+type Note struct {
+	ID        uuid.UUID
+	CoachID   uuid.UUID
+	ClientID  uuid.UUID
+	Body      string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// NewNote — фабрика для создания заметки.
+func NewNote(coachID, clientID uuid.UUID, body string, at time.Time) *Note {
+	return &Note{
+		ID:        uuid.New(),
+		CoachID:   coachID,
+		ClientID:  clientID,
+		Body:      body,
+		CreatedAt: at,
+		UpdatedAt: at,
+	}
+}
+
+// NormalizeTag приводит тег к нижнему регистру и схлопывает пробелы.
+// Возвращает false для пустого тега, слишком длинного тега и тега с символами,
+// кроме букв, цифр, пробела, дефиса и подчёркивания.
+func NormalizeTag(tag string) (string, bool) {
+	tag = strings.Join(strings.Fields(strings.ToLower(tag)), " ")
+	if tag == "" || utf8.RuneCountInString(tag) > MaxTagLength {
+		return "", false
+	}
+	for _, r := range tag {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != ' ' && r != '-' && r != '_' {
+			return "", false
+		}
+	}
+	return tag, true
+}
@@ -0,0 +1,82 @@
+package coach
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Specialty описывает направление, в котором работает тренер.
+type Specialty string
+
+const (
+	SpecialtyStrength       Specialty = "strength"        // силовая подготовка
+	SpecialtyPowerlifting   Specialty = "powerlifting"    // пауэрлифтинг
+	SpecialtyWeightlifting  Specialty = "weightlifting"   // тяжёлая атлетика
+	SpecialtyBodybuilding   Specialty = "bodybuilding"    // бодибилдинг
+	SpecialtyFunctional     Specialty = "functional"      // функциональный тренинг
+	SpecialtyEndurance      Specialty = "endurance"       // бег и выносливость
+	SpecialtyMobility       Specialty = "mobility"        // мобильность и растяжка
+	SpecialtyRehabilitation Specialty = "rehabilitation"  // восстановление после травм
+	SpecialtyWeightLoss     Specialty = "weight_loss"     // снижение веса
+	SpecialtyNutrition      Specialty = "nutrition"       // питание
+	SpecialtyGeneralFitness Specialty = "general_fitness" // общая физическая подготовка
+)
+
+// Specialties перечисляет все направления в порядке отображения.
+var Specialties = []Specialty{
+	SpecialtyStrength, SpecialtyPowerlifting, SpecialtyWeightlifting, SpecialtyBodybuilding,
+	SpecialtyFunctional, SpecialtyEndurance, SpecialtyMobility, SpecialtyRehabilitation,
+	SpecialtyWeightLoss, SpecialtyNutrition, SpecialtyGeneralFitness,
+}
+
+// IsValid возвращает true для известных направлений.
+func (s Specialty) IsValid() bool {
+	for _, known := range Specialties {
+		if s == known {
+			return true
+		}
+	}
+	return false
+}
+
+// Verification — отметка «проверенный тренер», которую ставит администратор
+// после проверки квалификации.
+type Verification struct {
+	VerifiedAt time.Time
+	VerifiedBy *uuid.UUID // Администратор, поставивший отметку (nil — аккаунт администратора удалён)
+	Note       string     // Комментарий администратора (не показывается в каталоге)
+}
+
+// Profile — анкета тренера в публичном каталоге.
+// Тренер попадает в каталог, только если сам включил Listed.
+type Profile struct {
+	UserID      uuid.UUID
+	Listed      bool        // Анкета опубликована в каталоге
+	Headline    string      // Краткое описание в одну строку
+	Bio         string      // Рассказ о себе
+	Specialties []Specialty // Направления работы
+	Languages   []string    // Языки занятий (ISO 639-1, нижний регистр)
+	Country     string      // Страна (ISO 3166-1 alpha-2, пустая строка — не указана)
+	City        string      // Город (пустая строка — не указан)
+	Online      bool        // Проводит занятия онлайн
+
+	Verification *Verification // Отметка «проверенный тренер» (nil — нет отметки)
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// IsVerified возвращает true, если у тренера есть отметка «проверенный тренер».
+func (p *Profile) IsVerified() bool {
+	return p.Verification != nil
+}
+
+// DirectoryEntry — анкета тренера вместе с публичными данными его профиля.
+type DirectoryEntry struct {
+	Profile
+	Username  string
+	FirstName string
+	LastName  string
+	AvatarURL string
+}
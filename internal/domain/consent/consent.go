@@ -0,0 +1,45 @@
+package consent
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Scope описывает класс данных, которым клиент делится с тренером.
+type Scope string
+
+const (
+	ScopeWorkouts     Scope = "workouts"     // тренировки и назначенные программы
+	ScopeMeasurements Scope = "measurements" // замеры параметров тела
+	ScopeNutrition    Scope = "nutrition"    // дневник питания
+	ScopeWellbeing    Scope = "wellbeing"    // ежедневные анкеты самочувствия и готовности
+)
+
+// IsValid возвращает true для известных классов данных.
+func (s Scope) IsValid() bool {
+	switch s {
+	case ScopeWorkouts, ScopeMeasurements, ScopeNutrition, ScopeWellbeing:
+		return true
+	}
+	return false
+}
+
+// Consent описывает согласие клиента на доступ тренера к его данным.
+// Отсутствие согласия означает, что тренер не видит ни одного класса данных клиента.
+type Consent struct {
+	ClientID  uuid.UUID // Пользователь, который делится данными
+	CoachID   uuid.UUID // Тренер, которому открыт доступ
+	Scopes    []Scope   // Разрешённые классы данных
+	UpdatedAt time.Time // Время последнего изменения
+}
+
+// Allows сообщает, разрешён ли тренеру доступ к классу данных scope.
+func (c *Consent) Allows(scope Scope) bool {
+	for _, s := range c.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
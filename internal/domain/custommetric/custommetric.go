@@ -0,0 +1,162 @@
+package custommetric
+
+import (
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Definition описывает пользовательскую метрику (например, сила хвата или высота прыжка),
+// которую пользователь отслеживает без изменения схемы БД.
+type Definition struct {
+	ID             uuid.UUID
+	UserID         uuid.UUID
+	Name           string // Название, уникальное для пользователя без учёта регистра
+	Unit           string // Единица измерения (кг, см, с и т.п.)
+	HigherIsBetter bool   // Направление улучшения: больше — лучше или меньше — лучше
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+// Entry описывает значение метрики в момент времени.
+type Entry struct {
+	ID           uuid.UUID
+	DefinitionID uuid.UUID
+	UserID       uuid.UUID
+	Value        float64
+	RecordedAt   time.Time
+	Note         string
+	CreatedAt    time.Time
+}
+
+// Bucket задаёт шаг агрегации значений для графиков.
+type Bucket string
+
+const (
+	BucketDay   Bucket = "day"
+	BucketWeek  Bucket = "week"
+	BucketMonth Bucket = "month"
+)
+
+// IsValid возвращает true для поддерживаемых шагов агрегации.
+func (b Bucket) IsValid() bool {
+	switch b {
+	case BucketDay, BucketWeek, BucketMonth:
+		return true
+	}
+	return false
+}
+
+// Start возвращает начало интервала, которому принадлежит t, в часовом поясе loc
+// (полночь по местному времени; неделя начинается с понедельника).
+func (b Bucket) Start(t time.Time, loc *time.Location) time.Time {
+	y, m, d := t.In(loc).Date()
+	day := time.Date(y, m, d, 0, 0, 0, 0, loc)
+	switch b {
+	case BucketWeek:
+		offset := (int(day.Weekday()) + 6) % 7
+		return day.AddDate(0, 0, -offset)
+	case BucketMonth:
+		return time.Date(y, m, 1, 0, 0, 0, 0, loc)
+	default:
+		return day
+	}
+}
+
+// Point описывает агрегированные значения метрики за интервал.
+type Point struct {
+	Start time.Time
+	Count int
+	Min   float64
+	Max   float64
+	Avg   float64
+	Last  float64 // Последнее значение в интервале
+}
+
+// Summary описывает итоги метрики за период.
+type Summary struct {
+	Count  int
+	First  float64
+	Last   float64
+	Min    float64
+	Max    float64
+	Best   float64 // Лучшее значение с учётом направления улучшения
+	Change float64 // Last - First
+}
+
+// New — фабрика для создания определения метрики.
+func New(userID uuid.UUID, name, unit string, higherIsBetter bool) *Definition {
+	now := time.Now().UTC()
+	return &Definition{
+		ID:             uuid.New(),
+		UserID:         userID,
+		Name:           name,
+		Unit:           unit,
+		HigherIsBetter: higherIsBetter,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+}
+
+// NewEntry — фабрика для создания значения метрики.
+func NewEntry(def *Definition, value float64, recordedAt time.Time, note string) *Entry {
+	return &Entry{
+		ID:           uuid.New(),
+		DefinitionID: def.ID,
+		UserID:       def.UserID,
+		Value:        value,
+		RecordedAt:   recordedAt,
+		Note:         note,
+		CreatedAt:    time.Now().UTC(),
+	}
+}
+
+// Aggregate группирует значения по интервалам bucket в часовом поясе loc и считает итоги за период.
+// Порядок entries не важен; точки возвращаются по возрастанию времени.
+func Aggregate(def *Definition, entries []*Entry, bucket Bucket, loc *time.Location) ([]Point, Summary) {
+	sorted := make([]*Entry, len(entries))
+	copy(sorted, entries)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].RecordedAt.Before(sorted[j].RecordedAt) })
+
+	var points []Point
+	var summary Summary
+	var sum float64
+	for _, e := range sorted {
+		start := bucket.Start(e.RecordedAt, loc)
+		if len(points) == 0 || !points[len(points)-1].Start.Equal(start) {
+			if len(points) > 0 {
+				p := &points[len(points)-1]
+				p.Avg = sum / float64(p.Count)
+			}
+			points = append(points, Point{Start: start, Min: e.Value, Max: e.Value})
+			sum = 0
+		}
+		p := &points[len(points)-1]
+		p.Count++
+		p.Min = min(p.Min, e.Value)
+		p.Max = max(p.Max, e.Value)
+		p.Last = e.Value
+		sum += e.Value
+
+		if summary.Count == 0 {
+			summary.First, summary.Min, summary.Max = e.Value, e.Value, e.Value
+		}
+		summary.Count++
+		summary.Last = e.Value
+		summary.Min = min(summary.Min, e.Value)
+		summary.Max = max(summary.Max, e.Value)
+	}
+	if len(points) > 0 {
+		p := &points[len(points)-1]
+		p.Avg = sum / float64(p.Count)
+	}
+	if summary.Count > 0 {
+		summary.Change = summary.Last - summary.First
+		summary.Best = summary.Min
+		if def.HigherIsBetter {
+			summary.Best = summary.Max
+		}
+	}
+	return points, summary
+}
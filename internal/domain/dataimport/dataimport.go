@@ -0,0 +1,112 @@
+package dataimport
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Status описывает состояние импорта.
+type Status string
+
+const (
+	StatusReceiving Status = "receiving" // файл загружается, записи ещё не переданы в обработку
+	StatusPending   Status = "pending"   // файл принят, обработка ещё не начата
+	StatusRunning   Status = "running"   // записи обрабатываются (или обработка прервана рестартом и будет продолжена)
+	StatusCompleted Status = "completed" // все записи обработаны
+	StatusFailed    Status = "failed"    // обработка остановлена из-за ошибки
+)
+
+// IsFinal возвращает true для состояний, из которых импорт больше не продолжается.
+func (s Status) IsFinal() bool {
+	return s == StatusCompleted || s == StatusFailed
+}
+
+// Kind описывает тип импортируемой записи.
+type Kind string
+
+const (
+	KindWorkout     Kind = "workout"     // завершённая тренировка с подходами
+	KindMeasurement Kind = "measurement" // замер параметров тела
+)
+
+// IsValid возвращает true для поддерживаемых типов записей.
+func (k Kind) IsValid() bool {
+	return k == KindWorkout || k == KindMeasurement
+}
+
+// RecordStatus описывает результат обработки записи.
+type RecordStatus string
+
+const (
+	RecordPending   RecordStatus = "pending"   // ещё не обработана
+	RecordImported  RecordStatus = "imported"  // сохранена в данных пользователя
+	RecordInvalid   RecordStatus = "invalid"   // не прошла проверку, причина в Error
+	RecordDuplicate RecordStatus = "duplicate" // запись с тем же внешним ID уже импортирована
+)
+
+// IsValid возвращает true для известных статусов записей.
+func (s RecordStatus) IsValid() bool {
+	switch s {
+	case RecordPending, RecordImported, RecordInvalid, RecordDuplicate:
+		return true
+	}
+	return false
+}
+
+// Import описывает разовую загрузку исторических данных пользователя из другого приложения.
+// Файл NDJSON сохраняется построчно при загрузке, а записи обрабатываются фоновым воркером
+// пачками с сохранением прогресса, поэтому большие импорты не упираются в таймауты запросов
+// и продолжаются после рестарта.
+type Import struct {
+	ID         uuid.UUID
+	UserID     uuid.UUID
+	Source     string // Приложение, из которого перенесены данные (например, strong), для отображения
+	Status     Status
+	Total      int // Записей в файле
+	Processed  int // Обработано записей
+	Imported   int
+	Invalid    int
+	Duplicates int
+	LastError  string // Причина остановки для статуса failed
+	CreatedAt  time.Time
+	StartedAt  *time.Time
+	FinishedAt *time.Time
+}
+
+// New — фабрика для создания импорта, файл которого ещё загружается.
+func New(userID uuid.UUID, source string, at time.Time) *Import {
+	return &Import{
+		ID:        uuid.New(),
+		UserID:    userID,
+		Source:    source,
+		Status:    StatusReceiving,
+		CreatedAt: at,
+	}
+}
+
+// Count учитывает результат обработки записи в счётчиках импорта.
+func (i *Import) Count(status RecordStatus) {
+	i.Processed++
+	switch status {
+	case RecordImported:
+		i.Imported++
+	case RecordInvalid:
+		i.Invalid++
+	case RecordDuplicate:
+		i.Duplicates++
+	}
+}
+
+// Record описывает строку файла импорта и результат её обработки.
+type Record struct {
+	ImportID   uuid.UUID
+	UserID     uuid.UUID
+	Line       int    // Номер строки в файле, начиная с 1
+	Kind       Kind   // Тип записи; пусто, если строка не разобрана
+	ExternalID string // ID записи в исходном приложении; по нему отсекаются повторы
+	Payload    []byte // Исходная строка JSON
+	Status     RecordStatus
+	Error      string     // Причина отклонения для статуса invalid
+	EntityID   *uuid.UUID // Созданная тренировка или замер
+}
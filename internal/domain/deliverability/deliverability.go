@@ -0,0 +1,114 @@
+package deliverability
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Outcome описывает результат доставки письма, о котором сообщил почтовый провайдер.
+type Outcome string
+
+const (
+	OutcomeAccepted  Outcome = "accepted"  // письмо принято сервером получателя
+	OutcomeBounced   Outcome = "bounced"   // письмо не доставлено
+	OutcomeComplaint Outcome = "complaint" // получатель пометил письмо как спам
+)
+
+// IsValid возвращает true для известных исходов доставки.
+func (o Outcome) IsValid() bool {
+	return o == OutcomeAccepted || o == OutcomeBounced || o == OutcomeComplaint
+}
+
+// BounceType уточняет причину недоставки.
+type BounceType string
+
+const (
+	BounceHard BounceType = "hard" // постоянная ошибка: адрес не существует или отклонён
+	BounceSoft BounceType = "soft" // временная ошибка: переполнен ящик, недоступен сервер и т.п.
+)
+
+// Event — одно событие доставки письма от провайдера.
+type Event struct {
+	ID         int64
+	Provider   string     // Почтовый провайдер: smtp, sendgrid, mailgun и т.п.
+	MessageID  string     // Идентификатор письма у провайдера (может быть пустым)
+	Email      string     // Адрес получателя в нижнем регистре
+	Outcome    Outcome    // Исход доставки
+	BounceType BounceType // Тип недоставки (только для OutcomeBounced)
+	Reason     string     // Диагностика провайдера
+	OccurredAt time.Time  // Когда событие произошло у провайдера
+	CreatedAt  time.Time  // Когда событие получено сервисом
+}
+
+// SuppressionSource описывает, почему адрес попал в список подавления.
+type SuppressionSource string
+
+const (
+	SourceBounce    SuppressionSource = "bounce"    // постоянная недоставка
+	SourceComplaint SuppressionSource = "complaint" // жалоба на спам
+	SourceManual    SuppressionSource = "manual"    // добавлен администратором
+)
+
+// IsValid возвращает true для известных источников.
+func (s SuppressionSource) IsValid() bool {
+	return s == SourceBounce || s == SourceComplaint || s == SourceManual
+}
+
+// Suppression — запись глобального списка подавления: письма на адрес не отправляются.
+type Suppression struct {
+	Email     string
+	Source    SuppressionSource
+	Provider  string     // Провайдер, сообщивший о проблеме или из выгрузки которого импортирован адрес
+	Reason    string     // Диагностика провайдера или комментарий администратора
+	CreatedBy *uuid.UUID // Администратор, добавивший запись (nil для автоматических записей)
+	CreatedAt time.Time
+}
+
+// SuppressionSource возвращает источник записи подавления для события,
+// после которого на адрес больше нельзя отправлять письма.
+func (e *Event) SuppressionSource() (SuppressionSource, bool) {
+	switch {
+	case e.Outcome == OutcomeBounced && e.BounceType == BounceHard:
+		return SourceBounce, true
+	case e.Outcome == OutcomeComplaint:
+		return SourceComplaint, true
+	}
+	return "", false
+}
+
+// ProviderStats — агрегированные исходы доставки одного провайдера за период.
+type ProviderStats struct {
+	Provider    string
+	Accepted    int64
+	Bounced     int64
+	HardBounced int64
+	Complaints  int64
+}
+
+// Total возвращает общее количество событий провайдера.
+func (s ProviderStats) Total() int64 {
+	return s.Accepted + s.Bounced + s.Complaints
+}
+
+// BounceRate возвращает долю недоставленных писем (0, если событий не было).
+func (s ProviderStats) BounceRate() float64 {
+	if s.Total() == 0 {
+		return 0
+	}
+	return float64(s.Bounced) / float64(s.Total())
+}
+
+// ComplaintRate возвращает долю жалоб на спам (0, если событий не было).
+func (s ProviderStats) ComplaintRate() float64 {
+	if s.Total() == 0 {
+		return 0
+	}
+	return float64(s.Complaints) / float64(s.Total())
+}
+
+// NormalizeEmail приводит адрес к виду, в котором он хранится в журнале доставки.
+func NormalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
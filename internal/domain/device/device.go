@@ -0,0 +1,46 @@
+package device
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Platform — платформа устройства; определяет сервис доставки push-уведомлений.
+type Platform string
+
+const (
+	PlatformAndroid Platform = "android" // токен FCM
+	PlatformIOS     Platform = "ios"     // токен APNs
+)
+
+// MaxTokenLength — максимальная длина токена устройства.
+const MaxTokenLength = 4096
+
+// IsValid сообщает, поддерживается ли платформа.
+func (p Platform) IsValid() bool {
+	return p == PlatformAndroid || p == PlatformIOS
+}
+
+// Device — устройство пользователя, на которое доставляются push-уведомления.
+// Токен уникален: при входе другим аккаунтом на том же устройстве токен переходит к новому пользователю.
+type Device struct {
+	ID         uuid.UUID
+	UserID     uuid.UUID
+	Platform   Platform
+	Token      string
+	CreatedAt  time.Time
+	LastSeenAt time.Time // Когда приложение последний раз регистрировало токен
+}
+
+// NewDevice — фабрика для создания устройства.
+func NewDevice(userID uuid.UUID, platform Platform, token string, at time.Time) *Device {
+	return &Device{
+		ID:         uuid.New(),
+		UserID:     userID,
+		Platform:   platform,
+		Token:      token,
+		CreatedAt:  at,
+		LastSeenAt: at,
+	}
+}
@@ -0,0 +1,67 @@
+package draft
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Kind описывает, что редактируется в черновике.
+type Kind string
+
+const (
+	KindProgram Kind = "program" // Тренировочная программа (новая или существующая)
+	KindWorkout Kind = "workout" // Тренировка пользователя
+)
+
+// IsValid сообщает, известен ли вид черновика.
+func (k Kind) IsValid() bool {
+	return k == KindProgram || k == KindWorkout
+}
+
+// Ограничения черновиков.
+const (
+	MaxContentBytes   = 256 << 10 // Размер содержимого черновика в JSON
+	MaxDeviceIDLength = 128       // Символов в идентификаторе устройства
+	MaxDraftsPerUser  = 50        // Действующих черновиков у одного пользователя
+)
+
+// Draft — автосохранённое состояние редактора программы или тренировки на устройстве пользователя.
+// Черновик определяется устройством, видом и редактируемым объектом (TargetID; nil — новый объект):
+// на каждом устройстве свой черновик, чтобы правки с разных устройств не затирали друг друга.
+// Content сервер не разбирает: это состояние редактора в формате клиента.
+type Draft struct {
+	ID       uuid.UUID
+	UserID   uuid.UUID
+	DeviceID string
+	Kind     Kind
+	TargetID *uuid.UUID
+	// BaseVersion — версия опубликованного объекта, с которой началось редактирование
+	// (updated_at программы, время последнего изменения тренировки); nil для нового объекта.
+	BaseVersion *time.Time
+	Content     json.RawMessage
+	Version     int // Номер сохранения черновика, начиная с 1
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	ExpiresAt   time.Time // Черновик удаляется, если его не сохраняли до этого времени
+}
+
+// NewDraft — фабрика для создания черновика; черновик действует ttl с момента at.
+func NewDraft(userID uuid.UUID, deviceID string, kind Kind, targetID *uuid.UUID, at time.Time, ttl time.Duration) *Draft {
+	return &Draft{
+		ID:        uuid.New(),
+		UserID:    userID,
+		DeviceID:  deviceID,
+		Kind:      kind,
+		TargetID:  targetID,
+		CreatedAt: at,
+		UpdatedAt: at,
+		ExpiresAt: at.Add(ttl),
+	}
+}
+
+// IsExpired сообщает, истёк ли срок черновика к моменту now.
+func (d *Draft) IsExpired(now time.Time) bool {
+	return !now.Before(d.ExpiresAt)
+}
@@ -0,0 +1,124 @@
+package emailoutbox
+
+import (
+	"time"
+
+	"workout-app/pkg/mailer"
+)
+
+// Kind описывает тип письма; совпадает с названием шаблона письма.
+type Kind string
+
+const (
+	KindVerificationCode   Kind = "verification_code"    // код подтверждения email
+	KindPasswordChangeCode Kind = "password_change_code" // код подтверждения смены пароля
+	KindPasswordResetCode  Kind = "password_reset_code"  // код сброса пароля
+	KindDataExportReady    Kind = "data_export_ready"    // ссылка на готовую выгрузку данных
+	KindWelcome            Kind = "welcome"              // приветствие после подтверждения email
+	KindPasswordChanged    Kind = "password_changed"     // уведомление о смене пароля
+	KindAccountDeleted     Kind = "account_deleted"      // подтверждение удаления аккаунта
+	KindNotification       Kind = "notification"         // копия уведомления из приложения
+	KindWeeklySummary      Kind = "weekly_summary"       // еженедельная сводка тренировок
+	KindCoachInvitation    Kind = "coach_invitation"     // приглашение стать клиентом тренера
+)
+
+// Status описывает состояние письма в очереди.
+type Status string
+
+const (
+	StatusPending Status = "pending" // ожидает отправки или повторной попытки
+	StatusSent    Status = "sent"    // отправлено
+	StatusDead    Status = "dead"    // попытки исчерпаны или адрес недоставляем; ждёт решения администратора
+)
+
+// IsValid возвращает true для известных состояний.
+func (s Status) IsValid() bool {
+	return s == StatusPending || s == StatusSent || s == StatusDead
+}
+
+// Payload содержит данные письма; набор полей зависит от Kind.
+type Payload struct {
+	Code        string     `json:"code,omitempty"`
+	DownloadURL string     `json:"download_url,omitempty"`
+	ExpiresAt   *time.Time `json:"expires_at,omitempty"`
+	Username    string     `json:"username,omitempty"`    // Имя пользователя в приветствии или имя тренера в приглашении
+	OccurredAt  *time.Time `json:"occurred_at,omitempty"` // Момент смены пароля, удаления аккаунта или события уведомления
+	// NotificationType — тип уведомления для KindNotification.
+	NotificationType string `json:"notification_type,omitempty"`
+	// WeeklySummary — итоги недели для KindWeeklySummary.
+	WeeklySummary *mailer.WeeklySummary `json:"weekly_summary,omitempty"`
+}
+
+// Message — письмо в очереди исходящих писем (outbox). Письмо ставится в очередь в транзакции
+// бизнес-операции, а отправляется фоновым воркером с повторными попытками.
+type Message struct {
+	ID            int64
+	Kind          Kind
+	Recipient     string
+	Locale        string // Язык письма (пусто — язык по умолчанию)
+	Timezone      string // Часовой пояс IANA для дат в письме (пусто — UTC)
+	Payload       Payload
+	Status        Status
+	Attempts      int       // Неудачных попыток отправки
+	NextAttemptAt time.Time // Не раньше этого момента письмо будет отправлено
+	LastError     string    // Ошибка последней неудачной попытки
+	TraceParent   string    // traceparent запроса, поставившего письмо в очередь (пусто — вне запроса)
+	CreatedAt     time.Time
+	FinishedAt    *time.Time // Момент отправки или перевода в dead letter
+}
+
+// New — фабрика для письма, готового к немедленной отправке.
+func New(kind Kind, recipient, locale string, payload Payload, at time.Time) *Message {
+	return &Message{
+		Kind:          kind,
+		Recipient:     recipient,
+		Locale:        locale,
+		Payload:       payload,
+		Status:        StatusPending,
+		NextAttemptAt: at,
+		CreatedAt:     at,
+	}
+}
+
+// MarkSent отмечает письмо отправленным. Данные письма (коды подтверждения) после отправки не хранятся.
+func (m *Message) MarkSent(at time.Time) {
+	m.Status = StatusSent
+	m.Payload = Payload{}
+	m.LastError = ""
+	m.FinishedAt = &at
+}
+
+// MarkFailed учитывает неудачную попытку и назначает следующую через retryAfter.
+func (m *Message) MarkFailed(at time.Time, err string, retryAfter time.Duration) {
+	m.Attempts++
+	m.LastError = err
+	m.NextAttemptAt = at.Add(retryAfter)
+}
+
+// MarkDead переводит письмо в dead letter: автоматических попыток больше не будет.
+func (m *Message) MarkDead(at time.Time, err string) {
+	m.Status = StatusDead
+	m.LastError = err
+	m.FinishedAt = &at
+}
+
+// Requeue возвращает письмо из dead letter в очередь со сброшенным счётчиком попыток.
+func (m *Message) Requeue(at time.Time) {
+	m.Status = StatusPending
+	m.Attempts = 0
+	m.NextAttemptAt = at
+	m.FinishedAt = nil
+}
+
+// RetryDelay возвращает задержку перед попыткой после attempts неудачных:
+// base, 2·base, 4·base и т.д., но не больше max.
+func RetryDelay(attempts int, base, max time.Duration) time.Duration {
+	delay := base
+	for i := 1; i < attempts && delay < max; i++ {
+		delay *= 2
+	}
+	if delay > max {
+		return max
+	}
+	return delay
+}
@@ -0,0 +1,133 @@
+package event
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Типы доменных событий.
+const (
+	TypeMetricRecorded  = "metric.recorded"  // пользователь сохранил замер параметров тела
+	TypeProgramAssigned = "program.assigned" // программа назначена пользователю
+	TypeWorkoutFinished = "workout.finished" // пользователь завершил тренировку
+	TypeCheckInRecorded = "checkin.recorded" // пользователь заполнил ежедневную анкету готовности
+	TypeWorkoutReminder = "workout.reminder" // наступило время напоминания о тренировках, запланированных на день
+
+	TypeClassBooked           = "class.booked"            // участник записался на групповое занятие (или встал в лист ожидания)
+	TypeClassWaitlistPromoted = "class.waitlist_promoted" // участник из листа ожидания получил место на занятии
+	TypeGymCheckedIn          = "gym.checked_in"          // участник отметился в зале по QR-коду
+
+	TypeUserRegistered  = "user.registered"       // пользователь зарегистрировался (по паролю или через OAuth)
+	TypeEmailVerified   = "user.email_verified"   // пользователь подтвердил email после регистрации
+	TypePasswordChanged = "user.password_changed" // пользователь сменил пароль
+	TypeAccountDeleted  = "user.account_deleted"  // пользователь удалил аккаунт
+)
+
+// Event представляет доменное событие, сохранённое в журнале событий.
+// Журнал только дополняется, поэтому события можно повторно доставить подписчикам.
+type Event struct {
+	ID          int64           // Монотонно возрастающий номер события в журнале
+	Type        string          // Тип события (см. константы Type*)
+	AggregateID string          // Идентификатор сущности, к которой относится событие
+	Payload     json.RawMessage // Данные события в JSON
+	OccurredAt  time.Time       // Когда произошло событие
+}
+
+// MetricRecorded — данные события TypeMetricRecorded.
+type MetricRecorded struct {
+	MetricID   string    `json:"metric_id"`
+	UserID     string    `json:"user_id"`
+	MeasuredAt time.Time `json:"measured_at"`
+}
+
+// ProgramAssigned — данные события TypeProgramAssigned.
+type ProgramAssigned struct {
+	AssignmentID string    `json:"assignment_id"`
+	ProgramID    string    `json:"program_id"`
+	UserID       string    `json:"user_id"`
+	AssignedBy   string    `json:"assigned_by"`
+	StartDate    time.Time `json:"start_date"`
+}
+
+// WorkoutFinished — данные события TypeWorkoutFinished.
+type WorkoutFinished struct {
+	SessionID     string    `json:"session_id"`
+	UserID        string    `json:"user_id"`
+	StartedAt     time.Time `json:"started_at"`
+	FinishedAt    time.Time `json:"finished_at"`
+	ActiveSeconds int64     `json:"active_seconds"`
+	Sets          int       `json:"sets"`
+	VolumeKg      float64   `json:"volume_kg"`
+}
+
+// CheckInRecorded — данные события TypeCheckInRecorded. Recommendation — рекомендация
+// по нагрузке с учётом новой анкеты (train, reduce_intensity, deload, insufficient_data).
+type CheckInRecorded struct {
+	CheckInID      string    `json:"checkin_id"`
+	UserID         string    `json:"user_id"`
+	Date           time.Time `json:"date"`
+	Score          int       `json:"score"`
+	Recommendation string    `json:"recommendation"`
+}
+
+// WorkoutReminder — данные события TypeWorkoutReminder. Date — день в часовом поясе пользователя
+// (полночь UTC), Workouts — названия тренировок назначенных программ на этот день.
+type WorkoutReminder struct {
+	UserID   string    `json:"user_id"`
+	Date     time.Time `json:"date"`
+	Workouts []string  `json:"workouts"`
+}
+
+// ClassBooking — данные событий TypeClassBooked и TypeClassWaitlistPromoted.
+// Status — состояние записи после события (booked, waitlisted).
+type ClassBooking struct {
+	BookingID      string    `json:"booking_id"`
+	ClassID        string    `json:"class_id"`
+	OrganizationID string    `json:"organization_id"`
+	UserID         string    `json:"user_id"`
+	StartsAt       time.Time `json:"starts_at"`
+	Status         string    `json:"status"`
+}
+
+// GymCheckIn — данные события TypeGymCheckedIn.
+type GymCheckIn struct {
+	VisitID        string    `json:"visit_id"`
+	LocationID     string    `json:"location_id"`
+	OrganizationID string    `json:"organization_id"`
+	UserID         string    `json:"user_id"`
+	CheckedInAt    time.Time `json:"checked_in_at"`
+}
+
+// События аккаунта не содержат email и других персональных данных: журнал только дополняется
+// и не обезличивается, а подписчики получают актуальные данные пользователя по UserID.
+
+// RegistrationPassword — UserRegistered.Method при регистрации по паролю; при регистрации через OAuth
+// в Method записывается имя провайдера.
+const RegistrationPassword = "password"
+
+// UserRegistered — данные события TypeUserRegistered. EmailVerified — email подтверждён
+// при регистрации (провайдером OAuth); иначе позже последует TypeEmailVerified.
+type UserRegistered struct {
+	UserID        string    `json:"user_id"`
+	Method        string    `json:"method"`
+	EmailVerified bool      `json:"email_verified"`
+	RegisteredAt  time.Time `json:"registered_at"`
+}
+
+// EmailVerified — данные события TypeEmailVerified.
+type EmailVerified struct {
+	UserID     string    `json:"user_id"`
+	VerifiedAt time.Time `json:"verified_at"`
+}
+
+// PasswordChanged — данные события TypePasswordChanged.
+type PasswordChanged struct {
+	UserID    string    `json:"user_id"`
+	ChangedAt time.Time `json:"changed_at"`
+}
+
+// AccountDeleted — данные события TypeAccountDeleted.
+type AccountDeleted struct {
+	UserID    string    `json:"user_id"`
+	DeletedAt time.Time `json:"deleted_at"`
+}
@@ -0,0 +1,95 @@
+package experiment
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"time"
+
+	"github.com/google/uuid"
+
+	"workout-app/internal/domain/region"
+)
+
+// EventType описывает тип аналитического события эксперимента.
+type EventType string
+
+const (
+	EventExposure   EventType = "exposure"   // пользователь увидел вариант
+	EventConversion EventType = "conversion" // пользователь выполнил целевое действие
+)
+
+// IsValid возвращает true для известных типов событий.
+func (t EventType) IsValid() bool {
+	return t == EventExposure || t == EventConversion
+}
+
+// Variant описывает вариант эксперимента и его относительный вес при распределении.
+type Variant struct {
+	Name   string
+	Weight int
+}
+
+// Experiment представляет A/B-эксперимент.
+type Experiment struct {
+	Key         string    // Уникальный ключ эксперимента (например, "onboarding_v2")
+	Description string    // Описание гипотезы
+	Variants    []Variant // Варианты с весами
+	IsActive    bool      // Участвует ли эксперимент в распределении
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// TotalWeight возвращает сумму весов всех вариантов с положительным весом.
+func (e *Experiment) TotalWeight() int {
+	total := 0
+	for _, v := range e.Variants {
+		if v.Weight > 0 {
+			total += v.Weight
+		}
+	}
+	return total
+}
+
+// Assign детерминированно выбирает вариант для пользователя.
+// Выбор зависит только от ID пользователя и ключа эксперимента, поэтому пользователь
+// всегда попадает в один и тот же вариант без хранения назначений в БД,
+// а разные эксперименты распределяют пользователей независимо друг от друга.
+// Возвращает false, если у эксперимента нет вариантов с положительным весом.
+func (e *Experiment) Assign(userID uuid.UUID) (Variant, bool) {
+	total := e.TotalWeight()
+	if total == 0 {
+		return Variant{}, false
+	}
+
+	sum := sha256.Sum256([]byte(e.Key + ":" + userID.String()))
+	bucket := int(binary.BigEndian.Uint64(sum[:8]) % uint64(total))
+
+	for _, v := range e.Variants {
+		if v.Weight <= 0 {
+			continue
+		}
+		if bucket < v.Weight {
+			return v, true
+		}
+		bucket -= v.Weight
+	}
+	// Недостижимо при корректном TotalWeight.
+	return Variant{}, false
+}
+
+// Assignment описывает вариант, назначенный пользователю в эксперименте.
+type Assignment struct {
+	ExperimentKey string
+	Variant       string
+}
+
+// Event представляет аналитическое событие (показ или конверсия) в эксперименте.
+type Event struct {
+	ID            int64
+	ExperimentKey string
+	UserID        uuid.UUID
+	Variant       string
+	Type          EventType
+	Region        region.Region // Регион пользователя на момент события
+	CreatedAt     time.Time
+}
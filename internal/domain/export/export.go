@@ -0,0 +1,44 @@
+package export
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Status описывает состояние выгрузки данных аккаунта.
+type Status string
+
+const (
+	StatusPending Status = "pending" // выгрузка поставлена в очередь, архив собирается
+	StatusReady   Status = "ready"   // архив собран и доступен по подписанной ссылке
+	StatusFailed  Status = "failed"  // сборка архива завершилась ошибкой
+)
+
+// Export описывает выгрузку всех данных пользователя (GDPR, право на переносимость данных).
+type Export struct {
+	ID          uuid.UUID
+	UserID      uuid.UUID
+	Status      Status
+	StorageKey  string // Ключ ZIP-архива в хранилище (пусто, пока архив не собран)
+	SizeBytes   int64
+	Error       string // Причина ошибки сборки (для статуса failed)
+	RequestedAt time.Time
+	CompletedAt *time.Time
+	ExpiresAt   *time.Time // После этого момента архив удаляется, ссылка перестаёт действовать
+}
+
+// New — фабрика для создания выгрузки, поставленной в очередь.
+func New(userID uuid.UUID, at time.Time) *Export {
+	return &Export{
+		ID:          uuid.New(),
+		UserID:      userID,
+		Status:      StatusPending,
+		RequestedAt: at,
+	}
+}
+
+// IsAvailable возвращает true, если архив собран и ещё не истёк.
+func (e *Export) IsAvailable(now time.Time) bool {
+	return e.Status == StatusReady && e.ExpiresAt != nil && now.Before(*e.ExpiresAt)
+}
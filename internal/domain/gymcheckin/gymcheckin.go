@@ -0,0 +1,94 @@
+package gymcheckin
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// CodeRotation — период смены QR-кода локации. Код предыдущего периода тоже принимается,
+	// чтобы отметка не срывалась на границе периода.
+	CodeRotation = 30 * time.Second
+
+	// RepeatWindow — в течение этого времени повторная отметка в той же локации возвращает уже записанный визит.
+	RepeatWindow = 2 * time.Hour
+
+	codeSignatureLen = 16
+)
+
+// ErrMalformedCode возвращается, если строка не похожа на QR-код локации.
+var ErrMalformedCode = errors.New("malformed check-in code")
+
+// Location описывает зал (точку) организации, в которой участники отмечаются по QR-коду.
+type Location struct {
+	ID             uuid.UUID
+	OrganizationID uuid.UUID
+	Name           string
+	Address        string
+	Secret         []byte // Ключ подписи QR-кодов; не покидает сервер
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+// Visit описывает отметку участника в зале.
+type Visit struct {
+	ID             uuid.UUID
+	LocationID     uuid.UUID
+	OrganizationID uuid.UUID
+	UserID         uuid.UUID
+	CheckedInAt    time.Time
+}
+
+// Code возвращает QR-код локации, действующий в момент at, и время его смены.
+// Формат: "<id локации>.<номер периода>.<подпись>".
+func (l *Location) Code(at time.Time) (string, time.Time) {
+	period := codePeriod(at)
+	code := l.ID.String() + "." + strconv.FormatInt(period, 10) + "." + l.sign(period)
+	return code, time.Unix((period+1)*int64(CodeRotation/time.Second), 0).UTC()
+}
+
+// VerifyCode проверяет подпись кода, разобранного ParseCode, для момента now.
+// Принимаются коды текущего и предыдущего периода.
+func (l *Location) VerifyCode(period int64, signature string, now time.Time) bool {
+	current := codePeriod(now)
+	if period != current && period != current-1 {
+		return false
+	}
+	return hmac.Equal([]byte(l.sign(period)), []byte(signature))
+}
+
+func (l *Location) sign(period int64) string {
+	mac := hmac.New(sha256.New, l.Secret)
+	mac.Write([]byte(l.ID.String()))
+	mac.Write([]byte{'.'})
+	mac.Write([]byte(strconv.FormatInt(period, 10)))
+	return hex.EncodeToString(mac.Sum(nil))[:codeSignatureLen]
+}
+
+// ParseCode разбирает QR-код на ID локации, номер периода и подпись.
+func ParseCode(code string) (uuid.UUID, int64, string, error) {
+	parts := strings.Split(strings.TrimSpace(code), ".")
+	if len(parts) != 3 || len(parts[2]) != codeSignatureLen {
+		return uuid.Nil, 0, "", ErrMalformedCode
+	}
+	locationID, err := uuid.Parse(parts[0])
+	if err != nil {
+		return uuid.Nil, 0, "", ErrMalformedCode
+	}
+	period, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return uuid.Nil, 0, "", ErrMalformedCode
+	}
+	return locationID, period, parts[2], nil
+}
+
+func codePeriod(t time.Time) int64 {
+	return t.Unix() / int64(CodeRotation/time.Second)
+}
@@ -0,0 +1,107 @@
+package gymclass
+
+import (
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Class описывает групповое занятие организации (зала): название, тренер, вместимость и длительность.
+// Расписание занятия задаётся еженедельными слотами (Slot).
+type Class struct {
+	ID              uuid.UUID
+	OrganizationID  uuid.UUID
+	Title           string
+	Description     string
+	CoachID         *uuid.UUID // Тренер, отмечающий посещаемость (опционально)
+	Capacity        int        // Мест на одно занятие; сверх них записи попадают в лист ожидания
+	DurationMinutes int
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+}
+
+// Slot описывает еженедельное время проведения занятия (в UTC).
+type Slot struct {
+	ID          uuid.UUID
+	ClassID     uuid.UUID
+	Weekday     int // 1 (понедельник) ... 7 (воскресенье), как дни программ
+	StartMinute int // Минуты от полуночи UTC
+	CreatedAt   time.Time
+}
+
+// Occurrence — конкретное занятие в расписании.
+type Occurrence struct {
+	ClassID  uuid.UUID
+	SlotID   uuid.UUID
+	StartsAt time.Time
+	EndsAt   time.Time
+}
+
+// Occurrences разворачивает еженедельные слоты занятия в конкретные занятия с началом в [from, to),
+// отсортированные по времени начала.
+func (c *Class) Occurrences(slots []*Slot, from, to time.Time) []Occurrence {
+	y, m, d := from.UTC().Date()
+	day := time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+	duration := time.Duration(c.DurationMinutes) * time.Minute
+
+	var result []Occurrence
+	for ; day.Before(to); day = day.AddDate(0, 0, 1) {
+		for _, s := range slots {
+			if s.ClassID != c.ID || s.Weekday != Weekday(day) {
+				continue
+			}
+			start := day.Add(time.Duration(s.StartMinute) * time.Minute)
+			if start.Before(from) || !start.Before(to) {
+				continue
+			}
+			result = append(result, Occurrence{ClassID: c.ID, SlotID: s.ID, StartsAt: start, EndsAt: start.Add(duration)})
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].StartsAt.Before(result[j].StartsAt) })
+	return result
+}
+
+// Occurrence возвращает занятие, начинающееся в startsAt, если оно есть в расписании.
+func (c *Class) Occurrence(slots []*Slot, startsAt time.Time) (Occurrence, bool) {
+	occurrences := c.Occurrences(slots, startsAt, startsAt.Add(time.Minute))
+	if len(occurrences) == 0 || !occurrences[0].StartsAt.Equal(startsAt) {
+		return Occurrence{}, false
+	}
+	return occurrences[0], true
+}
+
+// Weekday возвращает день недели t в UTC: 1 (понедельник) ... 7 (воскресенье).
+func Weekday(t time.Time) int {
+	wd := int(t.UTC().Weekday())
+	if wd == 0 {
+		return 7
+	}
+	return wd
+}
+
+// BookingStatus описывает состояние записи на занятие.
+type BookingStatus string
+
+const (
+	StatusBooked     BookingStatus = "booked"     // место подтверждено
+	StatusWaitlisted BookingStatus = "waitlisted" // в листе ожидания
+	StatusCancelled  BookingStatus = "cancelled"  // запись отменена
+)
+
+// Booking описывает запись участника на конкретное занятие.
+type Booking struct {
+	ID        uuid.UUID
+	ClassID   uuid.UUID
+	UserID    uuid.UUID
+	StartsAt  time.Time
+	Status    BookingStatus
+	Attended  *bool // Отметка тренера о посещении; nil — ещё не отмечено
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// IsActive возвращает true для записей, занимающих место или очередь.
+func (b *Booking) IsActive() bool {
+	return b.Status == StatusBooked || b.Status == StatusWaitlisted
+}
@@ -0,0 +1,32 @@
+package habit
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Day — ежедневные привычки пользователя за один день. Незаполненные показатели — nil.
+type Day struct {
+	UserID     uuid.UUID
+	Date       time.Time // День в часовом поясе пользователя (полночь UTC)
+	WaterMl    *int      // Выпитая вода, мл
+	SleepHours *float64  // Сон в ночь перед днём, часы
+	RestingHR  *int      // Пульс покоя, уд/мин
+	Steps      *int      // Шаги за день
+	UpdatedAt  time.Time
+}
+
+// Границы значений привычек.
+const (
+	MaxWaterMl    = 20000
+	MaxSleepHours = 24
+	MinRestingHR  = 20
+	MaxRestingHR  = 250
+	MaxSteps      = 100000
+)
+
+// IsEmpty возвращает true, если за день не заполнен ни один показатель.
+func (d *Day) IsEmpty() bool {
+	return d.WaterMl == nil && d.SleepHours == nil && d.RestingHR == nil && d.Steps == nil
+}
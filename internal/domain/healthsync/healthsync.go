@@ -0,0 +1,61 @@
+package healthsync
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Provider описывает источник данных о здоровье.
+type Provider string
+
+const (
+	ProviderAppleHealth Provider = "apple_health" // выгрузка HealthKit из iOS-приложения
+	ProviderGoogleFit   Provider = "google_fit"   // ответы Google Fit REST API (sessions и dataset)
+)
+
+// IsValid возвращает true для поддерживаемых источников.
+func (p Provider) IsValid() bool {
+	return p == ProviderAppleHealth || p == ProviderGoogleFit
+}
+
+// Kind описывает тип записи выгрузки.
+type Kind string
+
+const (
+	KindWorkout   Kind = "workout"    // тренировка — сохраняется завершённой тренировкой без подходов
+	KindSteps     Kind = "steps"      // шаги за интервал — суммируются в привычках дня
+	KindHeartRate Kind = "heart_rate" // замер пульса — минимальный за день становится пульсом покоя
+	KindWeight    Kind = "weight"     // вес тела — сохраняется замером параметров тела
+)
+
+// Item — запись выгрузки, приведённая к общему для источников виду.
+type Item struct {
+	ExternalID string
+	Kind       Kind
+	Start      time.Time
+	End        time.Time
+	Value      float64 // Шаги, уд/мин или кг; для тренировки не используется
+	Title      string  // Название тренировки
+}
+
+// Link связывает внешнюю запись с импортированными данными. По связям повторная
+// синхронизация пропускает уже импортированные записи, даже если выгрузки пересекаются.
+type Link struct {
+	UserID     uuid.UUID
+	Provider   Provider
+	ExternalID string
+	Kind       Kind
+	EntityID   *uuid.UUID // Тренировка или замер; nil — запись учтена в привычках дня
+	CreatedAt  time.Time
+}
+
+// Connection — состояние синхронизации пользователя с источником.
+type Connection struct {
+	UserID       uuid.UUID
+	Provider     Provider
+	Items        int        // Всего импортированных записей
+	SyncedUntil  *time.Time // Конец самой поздней записи из выгрузок: следующую выгрузку можно начинать с него
+	LastSyncedAt *time.Time
+	CreatedAt    time.Time
+}
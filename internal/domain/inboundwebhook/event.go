@@ -0,0 +1,65 @@
+package inboundwebhook
+
+import "time"
+
+// Status описывает состояние обработки входящего события.
+type Status string
+
+const (
+	StatusPending   Status = "pending"   // ожидает обработки или повторной попытки
+	StatusProcessed Status = "processed" // обработано
+	StatusDead      Status = "dead"      // попытки исчерпаны; событие сохранено для разбора
+)
+
+// Event — событие, полученное webhook'ом от внешнего провайдера (платежи, Strava, push-провайдеры).
+// Подпись запроса проверяется при получении, после чего событие сохраняется и провайдер сразу получает ответ;
+// обработку выполняет фоновый воркер с повторными попытками. Пара (Provider, ExternalID) уникальна:
+// повторная доставка того же события провайдером не создаёт второй записи.
+type Event struct {
+	ID            int64
+	Provider      string    // Имя провайдера из адреса webhook
+	ExternalID    string    // ID события у провайдера
+	Type          string    // Тип события у провайдера (пусто — провайдер не передаёт тип)
+	Payload       []byte    // Тело запроса без изменений: по нему проверялась подпись
+	Status        Status    // Состояние обработки
+	Attempts      int       // Неудачных попыток обработки
+	NextAttemptAt time.Time // Не раньше этого момента событие будет обработано
+	LastError     string    // Ошибка последней неудачной попытки
+	TraceParent   string    // traceparent запроса провайдера
+	ReceivedAt    time.Time
+	FinishedAt    *time.Time // Момент обработки или перевода в dead letter
+}
+
+// NewEvent — фабрика события, готового к немедленной обработке.
+func NewEvent(provider, externalID, eventType string, payload []byte, at time.Time) *Event {
+	return &Event{
+		Provider:      provider,
+		ExternalID:    externalID,
+		Type:          eventType,
+		Payload:       payload,
+		Status:        StatusPending,
+		NextAttemptAt: at,
+		ReceivedAt:    at,
+	}
+}
+
+// MarkProcessed отмечает событие обработанным.
+func (e *Event) MarkProcessed(at time.Time) {
+	e.Status = StatusProcessed
+	e.LastError = ""
+	e.FinishedAt = &at
+}
+
+// MarkFailed учитывает неудачную попытку и назначает следующую через retryAfter.
+func (e *Event) MarkFailed(at time.Time, err string, retryAfter time.Duration) {
+	e.Attempts++
+	e.LastError = err
+	e.NextAttemptAt = at.Add(retryAfter)
+}
+
+// MarkDead переводит событие в dead letter: автоматических попыток больше не будет.
+func (e *Event) MarkDead(at time.Time, err string) {
+	e.Status = StatusDead
+	e.LastError = err
+	e.FinishedAt = &at
+}
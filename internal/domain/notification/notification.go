@@ -0,0 +1,79 @@
+package notification
+
+import (
+	"encoding/json"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Типы уведомлений. Совпадают с типами событий журнала, кроме TypeClassWaitlisted:
+// запись в лист ожидания публикуется событием class.booked со статусом waitlisted,
+// и TypeWeeklySummary: сводка отправляется только письмом, без уведомления в приложении и push.
+const (
+	TypeProgramAssigned       = "program.assigned"        // тренер назначил программу
+	TypeClassBooked           = "class.booked"            // пользователь записан на групповое занятие
+	TypeClassWaitlisted       = "class.waitlisted"        // пользователь встал в лист ожидания занятия
+	TypeClassWaitlistPromoted = "class.waitlist_promoted" // пользователь получил место из листа ожидания
+	TypeWorkoutReminder       = "workout.reminder"        // напоминание о тренировках, запланированных на день
+	TypeWeeklySummary         = "workout.weekly_summary"  // еженедельная сводка тренировок
+)
+
+// Types — все типы уведомлений в порядке показа в настройках.
+var Types = []string{
+	TypeProgramAssigned, TypeClassBooked, TypeClassWaitlisted, TypeClassWaitlistPromoted, TypeWorkoutReminder, TypeWeeklySummary,
+}
+
+// IsKnownType сообщает, есть ли такой тип уведомлений.
+func IsKnownType(t string) bool {
+	return slices.Contains(Types, t)
+}
+
+// Notification — уведомление пользователя в приложении, созданное по событию журнала.
+// Data — данные события без изменений; клиент показывает текст по Type.
+type Notification struct {
+	ID        uuid.UUID
+	UserID    uuid.UUID
+	Type      string
+	Data      json.RawMessage
+	EventID   int64 // Событие журнала, по которому создано уведомление
+	CreatedAt time.Time
+	ReadAt    *time.Time // nil — уведомление не прочитано
+
+	PushPending bool // Копия ждёт отправки на устройства пользователя
+}
+
+// NewNotification — фабрика для создания непрочитанного уведомления.
+func NewNotification(userID uuid.UUID, notificationType string, data json.RawMessage, eventID int64, at time.Time) *Notification {
+	return &Notification{
+		ID:        uuid.New(),
+		UserID:    userID,
+		Type:      notificationType,
+		Data:      data,
+		EventID:   eventID,
+		CreatedAt: at,
+	}
+}
+
+// IsRead сообщает, прочитано ли уведомление.
+func (n *Notification) IsRead() bool {
+	return n.ReadAt != nil
+}
+
+// Preference — настройка уведомлений одного типа. В приложении уведомления показываются всегда,
+// настройка управляет копиями письмом и push-уведомлением.
+type Preference struct {
+	Type  string
+	Email bool
+	Push  bool
+}
+
+// DefaultPreferences возвращает настройки по умолчанию: письма и push включены для всех типов.
+func DefaultPreferences() []Preference {
+	prefs := make([]Preference, 0, len(Types))
+	for _, t := range Types {
+		prefs = append(prefs, Preference{Type: t, Email: true, Push: true})
+	}
+	return prefs
+}
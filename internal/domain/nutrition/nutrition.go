@@ -0,0 +1,69 @@
+package nutrition
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Meal — приём пищи, к которому относится запись дневника.
+type Meal string
+
+const (
+	MealBreakfast Meal = "breakfast" // завтрак
+	MealLunch     Meal = "lunch"     // обед
+	MealDinner    Meal = "dinner"    // ужин
+	MealSnack     Meal = "snack"     // перекус
+)
+
+// IsValid возвращает true для известных приёмов пищи.
+func (m Meal) IsValid() bool {
+	switch m {
+	case MealBreakfast, MealLunch, MealDinner, MealSnack:
+		return true
+	}
+	return false
+}
+
+// Entry — запись дневника питания: продукт или блюдо с калорийностью и БЖУ порции.
+type Entry struct {
+	ID        uuid.UUID
+	UserID    uuid.UUID
+	Date      time.Time // День дневника в часовом поясе пользователя (полночь UTC)
+	Meal      Meal
+	Name      string
+	Calories  int     // ккал
+	ProteinG  float64 // Белки, г
+	CarbsG    float64 // Углеводы, г
+	FatG      float64 // Жиры, г
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// NewEntry — фабрика для создания записи дневника.
+func NewEntry(userID uuid.UUID, date time.Time, meal Meal, name string, calories int, protein, carbs, fat float64) *Entry {
+	now := time.Now().UTC()
+	return &Entry{
+		ID:        uuid.New(),
+		UserID:    userID,
+		Date:      date,
+		Meal:      meal,
+		Name:      name,
+		Calories:  calories,
+		ProteinG:  protein,
+		CarbsG:    carbs,
+		FatG:      fat,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+}
+
+// DayTotals — итоги дня дневника питания.
+type DayTotals struct {
+	Date     time.Time
+	Entries  int
+	Calories int
+	ProteinG float64
+	CarbsG   float64
+	FatG     float64
+}
@@ -0,0 +1,104 @@
+package organization
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Organization описывает клиента платформы (зал, студию), объединяющего своих сотрудников и участников.
+type Organization struct {
+	ID        uuid.UUID
+	Name      string
+	CreatedAt time.Time
+}
+
+// Role описывает роль пользователя внутри организации.
+type Role string
+
+const (
+	RoleOwner   Role = "owner"   // владелец: управляет настройками организации и командой
+	RoleManager Role = "manager" // менеджер: управляет персоналом
+	RoleStaff   Role = "staff"   // сотрудник зала (тренер, администратор ресепшена)
+	RoleMember  Role = "member"  // участник (клиент зала)
+)
+
+// IsValid возвращает true для известных ролей.
+func (r Role) IsValid() bool {
+	switch r {
+	case RoleOwner, RoleManager, RoleStaff, RoleMember:
+		return true
+	}
+	return false
+}
+
+// IsTeam возвращает true для ролей команды организации: владельца, менеджера и сотрудника.
+func (r Role) IsTeam() bool {
+	return r == RoleOwner || r == RoleManager || r == RoleStaff
+}
+
+// CanInvite сообщает, может ли пользователь с ролью r пригласить в команду сотрудника с ролью role:
+// владелец приглашает менеджеров и сотрудников, менеджер — только сотрудников.
+func (r Role) CanInvite(role Role) bool {
+	switch role {
+	case RoleManager:
+		return r == RoleOwner
+	case RoleStaff:
+		return r == RoleOwner || r == RoleManager
+	}
+	return false
+}
+
+// Member описывает членство пользователя в организации.
+type Member struct {
+	OrganizationID uuid.UUID
+	UserID         uuid.UUID
+	Role           Role
+	CreatedAt      time.Time
+}
+
+// Invitation описывает приглашение зарегистрированного пользователя в команду организации.
+// Пользователь получает роль, приняв приглашение; до ExpiresAt повторно пригласить его нельзя.
+type Invitation struct {
+	ID             uuid.UUID
+	OrganizationID uuid.UUID
+	UserID         uuid.UUID
+	Role           Role // manager или staff
+	InvitedBy      uuid.UUID
+	CreatedAt      time.Time
+	ExpiresAt      time.Time
+}
+
+// IsExpired сообщает, истёк ли срок приглашения к моменту now.
+func (i *Invitation) IsExpired(now time.Time) bool {
+	return !now.Before(i.ExpiresAt)
+}
+
+// EmailProvider описывает способ отправки писем организации.
+type EmailProvider string
+
+const (
+	EmailProviderSMTP EmailProvider = "smtp" // собственный SMTP-сервер организации
+)
+
+// IsValid возвращает true для поддерживаемых провайдеров.
+func (p EmailProvider) IsValid() bool {
+	return p == EmailProviderSMTP
+}
+
+// EmailSettings описывает собственные настройки отправки писем организации.
+// Письма участникам организации отправляются с её адреса и через её провайдера;
+// без настроек используется платформенная отправка.
+type EmailSettings struct {
+	OrganizationID   uuid.UUID
+	FromEmail        string
+	FromName         string
+	Provider         EmailProvider
+	SMTPHost         string
+	SMTPPort         int
+	SMTPUsername     string
+	SMTPPassword     string // В БД хранится только в зашифрованном виде
+	RateLimitPerHour int    // Лимит писем организации в час
+	UpdatedBy        uuid.UUID
+	UpdatedAt        time.Time
+}
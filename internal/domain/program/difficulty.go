@@ -0,0 +1,41 @@
+package program
+
+import "math"
+
+// DifficultyStats описывает оценки сложности тренировок, выполненных по программе (шкала RPE 1–10).
+type DifficultyStats struct {
+	Ratings int     // Количество оценённых тренировок
+	Average float64 // Средняя оценка; 0, если оценок нет
+}
+
+// MinRatingsForAdjustment — сколько оценок нужно, чтобы предлагать изменение нагрузки:
+// по одной-двум тренировкам не отличить неудачный день от неподходящих весов.
+const MinRatingsForAdjustment = 3
+
+// LoadAdjustmentPercent предлагает изменение рабочих весов в процентах по средней сложности
+// последних тренировок: рабочая зона — RPE 6–8, тяжелее снижаем нагрузку, легче — повышаем.
+// Возвращает 0, если оценок меньше MinRatingsForAdjustment или сложность в рабочей зоне.
+func (d DifficultyStats) LoadAdjustmentPercent() float64 {
+	if d.Ratings < MinRatingsForAdjustment {
+		return 0
+	}
+	switch {
+	case d.Average >= 9:
+		return -5
+	case d.Average > 8:
+		return -2.5
+	case d.Average <= 5:
+		return 5
+	case d.Average < 6:
+		return 2.5
+	}
+	return 0
+}
+
+// Adjust применяет изменение нагрузки adjustPercent к весу и округляет результат по правилам.
+func (r LoadRules) Adjust(weightKg, adjustPercent float64) float64 {
+	if adjustPercent == 0 {
+		return weightKg
+	}
+	return math.Max(r.Round(weightKg*(1+adjustPercent/100)), 0)
+}
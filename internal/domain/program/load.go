@@ -0,0 +1,164 @@
+package program
+
+import (
+	"math"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TrainingMax описывает тренировочный максимум пользователя в упражнении —
+// вес, от которого считаются процентные назначения программ (например, 75% от максимума в приседе).
+type TrainingMax struct {
+	UserID    uuid.UUID
+	Exercise  string // Название упражнения в том виде, в каком его ввёл пользователь
+	WeightKg  float64
+	UpdatedAt time.Time
+}
+
+// ExerciseKey приводит название упражнения к ключу сравнения: без регистра и лишних пробелов.
+// По этому ключу процентное назначение программы находит тренировочный максимум.
+func ExerciseKey(name string) string {
+	return strings.Join(strings.Fields(strings.ToLower(name)), " ")
+}
+
+// RoundingMode задаёт направление округления вычисленного веса.
+type RoundingMode string
+
+const (
+	RoundNearest RoundingMode = "nearest"
+	RoundDown    RoundingMode = "down"
+	RoundUp      RoundingMode = "up"
+)
+
+// IsValid возвращает true для поддерживаемых режимов округления.
+func (m RoundingMode) IsValid() bool {
+	switch m {
+	case RoundNearest, RoundDown, RoundUp:
+		return true
+	}
+	return false
+}
+
+// LoadRules описывает, как процент превращается в вес на штанге.
+type LoadRules struct {
+	IncrementKg float64      // Шаг округления веса (например, 2.5 кг)
+	Mode        RoundingMode // Направление округления
+	BarKg       float64      // Вес грифа; 0 — раскладка блинов не считается
+	PlatesKg    []float64    // Доступные блины (на одну сторону), по убыванию веса
+}
+
+// DefaultPlatesKg — стандартный набор блинов зала.
+var DefaultPlatesKg = []float64{25, 20, 15, 10, 5, 2.5, 1.25}
+
+// DefaultLoadRules — правила по умолчанию: округление до 2.5 кг к ближайшему, олимпийский гриф 20 кг.
+func DefaultLoadRules() LoadRules {
+	return LoadRules{IncrementKg: 2.5, Mode: RoundNearest, BarKg: 20, PlatesKg: DefaultPlatesKg}
+}
+
+// Round округляет вес по правилам.
+func (r LoadRules) Round(weightKg float64) float64 {
+	if r.IncrementKg <= 0 {
+		return weightKg
+	}
+	steps := weightKg / r.IncrementKg
+	// Погрешность деления не должна сдвигать вес на целый шаг вниз или вверх.
+	const eps = 1e-9
+	switch r.Mode {
+	case RoundDown:
+		steps = math.Floor(steps + eps)
+	case RoundUp:
+		steps = math.Ceil(steps - eps)
+	default:
+		steps = math.Round(steps)
+	}
+	return math.Round(steps*r.IncrementKg*1000) / 1000
+}
+
+// ResolvePercent вычисляет рабочий вес как percent% от trainingMaxKg с округлением.
+func (r LoadRules) ResolvePercent(percent, trainingMaxKg float64) float64 {
+	return r.Round(trainingMaxKg * percent / 100)
+}
+
+// Plates раскладывает вес на блины для одной стороны грифа.
+// Жадная раскладка точна для стандартного набора блинов; remainderKg — вес, который не удалось набрать
+// (например, если вес меньше грифа или не кратен наименьшему блину).
+func (r LoadRules) Plates(weightKg float64) (perSide []float64, remainderKg float64) {
+	if r.BarKg <= 0 || weightKg < r.BarKg {
+		return nil, math.Max(weightKg-r.BarKg, 0)
+	}
+	side := (weightKg - r.BarKg) / 2
+	for _, plate := range r.PlatesKg {
+		for plate > 0 && side+1e-9 >= plate {
+			perSide = append(perSide, plate)
+			side -= plate
+		}
+	}
+	return perSide, math.Round(side*2*1000) / 1000
+}
+
+// ScheduledWorkout описывает тренировку программы, привязанную к дате назначения.
+type ScheduledWorkout struct {
+	Date    time.Time // Дата тренировки (UTC, полночь)
+	Week    int
+	Day     int
+	Workout Workout
+}
+
+// Schedule раскладывает тренировки программы по датам, начиная со startDate:
+// неделя N начинается через (N-1)*7 дней, день D — через D-1 дней от начала недели.
+// Возвращаются тренировки с датами в [from, to).
+func (p *Program) Schedule(startDate, from, to time.Time) []ScheduledWorkout {
+	y, m, d := startDate.UTC().Date()
+	start := time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+
+	var result []ScheduledWorkout
+	for _, w := range p.Weeks {
+		for _, day := range w.Days {
+			date := start.AddDate(0, 0, (w.Number-1)*7+day.Number-1)
+			if date.Before(from) || !date.Before(to) {
+				continue
+			}
+			for _, wo := range day.Workouts {
+				result = append(result, ScheduledWorkout{Date: date, Week: w.Number, Day: day.Number, Workout: wo})
+			}
+		}
+	}
+	return result
+}
+
+// EndDate возвращает день, следующий за последним днём программы, начатой в startDate.
+func (p *Program) EndDate(startDate time.Time) time.Time {
+	y, m, d := startDate.UTC().Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC).AddDate(0, 0, len(p.Weeks)*7)
+}
+
+// LoadReferences возвращает упражнения, от максимумов которых считаются процентные назначения программы,
+// без повторов и в порядке первого упоминания.
+func (p *Program) LoadReferences() []string {
+	var refs []string
+	seen := make(map[string]struct{})
+	for _, w := range p.Weeks {
+		for _, d := range w.Days {
+			for _, wo := range d.Workouts {
+				for _, e := range wo.Exercises {
+					if e.LoadPercent == nil {
+						continue
+					}
+					ref := e.LoadReference
+					if ref == "" {
+						ref = e.Name
+					}
+					key := ExerciseKey(ref)
+					if _, ok := seen[key]; ok {
+						continue
+					}
+					seen[key] = struct{}{}
+					refs = append(refs, strings.Join(strings.Fields(ref), " "))
+				}
+			}
+		}
+	}
+	return refs
+}
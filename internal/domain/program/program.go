@@ -0,0 +1,171 @@
+package program
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Program представляет многонедельную тренировочную программу.
+// Структура: программа -> недели -> дни -> тренировки -> упражнения.
+type Program struct {
+	ID              uuid.UUID  // Уникальный идентификатор программы
+	OwnerID         uuid.UUID  // Автор программы (тренер или пользователь)
+	Title           string     // Название программы
+	Description     string     // Описание программы
+	Weeks           []Week     // Недели программы по порядку (номер недели = индекс + 1)
+	SourceProgramID *uuid.UUID // Программа, из которой сделана копия (nil для оригинала)
+	// Difficulty — оценки сложности тренировок по назначениям программы;
+	// не хранится в программе, а вычисляется при чтении.
+	Difficulty DifficultyStats
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+// Week описывает неделю программы.
+type Week struct {
+	Number int   // Номер недели, начиная с 1
+	Days   []Day // Тренировочные дни недели; дни отдыха не хранятся
+}
+
+// Day описывает тренировочный день недели.
+type Day struct {
+	Number   int       // День недели: 1 (понедельник) ... 7 (воскресенье)
+	Workouts []Workout // Тренировки дня по порядку выполнения
+}
+
+// Workout описывает одну тренировку в рамках дня программы.
+type Workout struct {
+	Title     string
+	Notes     string
+	Exercises []Exercise
+}
+
+// Exercise описывает упражнение тренировки с целевым объёмом.
+// Вес задаётся либо абсолютно (WeightKg), либо процентом от тренировочного максимума
+// упражнения LoadReference (LoadPercent) — тогда он вычисляется для каждого пользователя при построении расписания.
+type Exercise struct {
+	Name          string   // Название упражнения
+	Sets          int      // Количество подходов
+	Reps          int      // Количество повторений в подходе
+	WeightKg      *float64 // Рабочий вес в килограммах (опционально)
+	LoadPercent   *float64 // Процент от тренировочного максимума (опционально, взаимоисключающе с WeightKg)
+	LoadReference string   // Упражнение, от максимума которого считается процент (например, «Присед»)
+	Notes         string
+	VideoID       *uuid.UUID // Видео с техникой выполнения (опционально), см. domain/video
+	// Tempo — темп повторения в нотации «эксцентрика-пауза-концентрика-пауза» в секундах,
+	// например «3-1-1-0»; X — взрывная фаза. Пустой — темп не задан.
+	Tempo string
+	// RestSeconds — отдых после подходов в секундах: одно значение на все подходы
+	// или по значению на каждый подход (длина Sets). Пустой — отдых не задан.
+	RestSeconds []int
+}
+
+// Ограничения предписаний темпа и отдыха.
+const (
+	MaxTempoPhaseSeconds = 30
+	MaxRestSeconds       = 3600
+)
+
+// NormalizeTempo проверяет темп и приводит его к каноничному виду «3-1-X-0».
+// Возвращает false, если темп не из четырёх фаз от 0 до MaxTempoPhaseSeconds секунд или X.
+func NormalizeTempo(tempo string) (string, bool) {
+	phases := strings.Split(strings.ToUpper(strings.TrimSpace(tempo)), "-")
+	if len(phases) != 4 {
+		return "", false
+	}
+	for i, phase := range phases {
+		phase = strings.TrimSpace(phase)
+		if phase != "X" {
+			n, err := strconv.Atoi(phase)
+			if err != nil || n < 0 || n > MaxTempoPhaseSeconds {
+				return "", false
+			}
+			phase = strconv.Itoa(n)
+		}
+		phases[i] = phase
+	}
+	return strings.Join(phases, "-"), true
+}
+
+// RestAfterSet возвращает отдых после подхода с номером set (с 1) или nil, если отдых не задан.
+func (e Exercise) RestAfterSet(set int) *int {
+	switch {
+	case len(e.RestSeconds) == 0 || set < 1:
+		return nil
+	case len(e.RestSeconds) == 1:
+		rest := e.RestSeconds[0]
+		return &rest
+	case set <= len(e.RestSeconds):
+		rest := e.RestSeconds[set-1]
+		return &rest
+	}
+	return nil
+}
+
+// New — фабрика для создания новой программы.
+func New(ownerID uuid.UUID, title, description string, weeks []Week) *Program {
+	now := time.Now().UTC()
+	return &Program{
+		ID:          uuid.New(),
+		OwnerID:     ownerID,
+		Title:       title,
+		Description: description,
+		Weeks:       weeks,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+}
+
+// Clone создаёт независимую копию программы для нового владельца.
+// Копия ссылается на исходную программу через SourceProgramID.
+func (p *Program) Clone(ownerID uuid.UUID) *Program {
+	weeks := make([]Week, len(p.Weeks))
+	for i, w := range p.Weeks {
+		days := make([]Day, len(w.Days))
+		for j, d := range w.Days {
+			workouts := make([]Workout, len(d.Workouts))
+			for k, wo := range d.Workouts {
+				exercises := make([]Exercise, len(wo.Exercises))
+				for n, e := range wo.Exercises {
+					if e.WeightKg != nil {
+						weight := *e.WeightKg
+						e.WeightKg = &weight
+					}
+					if e.LoadPercent != nil {
+						percent := *e.LoadPercent
+						e.LoadPercent = &percent
+					}
+					if e.VideoID != nil {
+						videoID := *e.VideoID
+						e.VideoID = &videoID
+					}
+					if e.RestSeconds != nil {
+						e.RestSeconds = append([]int(nil), e.RestSeconds...)
+					}
+					exercises[n] = e
+				}
+				workouts[k] = Workout{Title: wo.Title, Notes: wo.Notes, Exercises: exercises}
+			}
+			days[j] = Day{Number: d.Number, Workouts: workouts}
+		}
+		weeks[i] = Week{Number: w.Number, Days: days}
+	}
+
+	clone := New(ownerID, p.Title, p.Description, weeks)
+	sourceID := p.ID
+	clone.SourceProgramID = &sourceID
+	return clone
+}
+
+// Assignment описывает назначение программы пользователю.
+type Assignment struct {
+	ID         uuid.UUID
+	ProgramID  uuid.UUID
+	UserID     uuid.UUID // Пользователь, который выполняет программу
+	AssignedBy uuid.UUID // Кто назначил программу (сам пользователь, тренер или админ)
+	StartDate  time.Time // Дата начала первой недели
+	CreatedAt  time.Time
+}
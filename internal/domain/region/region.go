@@ -0,0 +1,92 @@
+package region
+
+import "strings"
+
+// Region описывает зону хранения данных пользователя (data residency).
+// Определяется по стране регистрации и используется для ограничения функций
+// и, в дальнейшем, для размещения данных в отдельном хранилище.
+type Region string
+
+const (
+	RegionEU     Region = "eu"     // страны ЕЭЗ: данные подпадают под GDPR
+	RegionGlobal Region = "global" // остальные страны и неизвестная страна
+)
+
+// IsValid возвращает true для известных регионов.
+func (r Region) IsValid() bool {
+	return r == RegionEU || r == RegionGlobal
+}
+
+// eeaCountries — страны Европейской экономической зоны (ISO 3166-1 alpha-2).
+var eeaCountries = map[string]bool{
+	"AT": true, "BE": true, "BG": true, "HR": true, "CY": true, "CZ": true, "DK": true,
+	"EE": true, "FI": true, "FR": true, "DE": true, "GR": true, "HU": true, "IE": true,
+	"IT": true, "LV": true, "LT": true, "LU": true, "MT": true, "NL": true, "PL": true,
+	"PT": true, "RO": true, "SK": true, "SI": true, "ES": true, "SE": true,
+	"IS": true, "LI": true, "NO": true,
+}
+
+// NormalizeCountry приводит код страны к формату ISO 3166-1 alpha-2 в верхнем регистре.
+// Служебные коды CDN ("XX" — неизвестно, "T1" — Tor) и некорректные значения дают пустую строку.
+func NormalizeCountry(raw string) string {
+	code := strings.ToUpper(strings.TrimSpace(raw))
+	if len(code) != 2 || code == "XX" || code == "T1" {
+		return ""
+	}
+	for _, ch := range code {
+		if ch < 'A' || ch > 'Z' {
+			return ""
+		}
+	}
+	return code
+}
+
+// FromCountry возвращает регион для нормализованного кода страны.
+func FromCountry(country string) Region {
+	if eeaCountries[country] {
+		return RegionEU
+	}
+	return RegionGlobal
+}
+
+// Feature — функция приложения, которую можно отключить для региона.
+type Feature string
+
+const (
+	FeatureExperiments  Feature = "experiments"   // участие в A/B-экспериментах
+	FeatureAvatarUpload Feature = "avatar_upload" // загрузка аватара
+	FeaturePresence     Feature = "presence"      // статус «сейчас тренируется»
+)
+
+// IsValid возвращает true для известных функций.
+func (f Feature) IsValid() bool {
+	switch f {
+	case FeatureExperiments, FeatureAvatarUpload, FeaturePresence:
+		return true
+	}
+	return false
+}
+
+// Policy описывает функции, отключённые в отдельных регионах.
+// Нулевое значение разрешает всё.
+type Policy struct {
+	disabled map[Region]map[Feature]bool
+}
+
+// NewPolicy создаёт политику из списка отключённых функций по регионам.
+func NewPolicy(disabled map[Region][]Feature) Policy {
+	p := Policy{disabled: make(map[Region]map[Feature]bool, len(disabled))}
+	for r, features := range disabled {
+		set := make(map[Feature]bool, len(features))
+		for _, f := range features {
+			set[f] = true
+		}
+		p.disabled[r] = set
+	}
+	return p
+}
+
+// Allows сообщает, доступна ли функция пользователям региона.
+func (p Policy) Allows(r Region, f Feature) bool {
+	return !p.disabled[r][f]
+}
@@ -0,0 +1,27 @@
+package reminder
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Reminder — отметка, что напоминание о тренировках пользователя за день обработано.
+// Создаётся и тогда, когда на день ничего не запланировано: повторная проверка в тот же день не нужна.
+type Reminder struct {
+	UserID    uuid.UUID
+	Date      time.Time // День в часовом поясе пользователя (полночь UTC)
+	CreatedAt time.Time
+}
+
+// New — фабрика отметки напоминания за день date.
+func New(userID uuid.UUID, date, at time.Time) *Reminder {
+	return &Reminder{UserID: userID, Date: date, CreatedAt: at}
+}
+
+// LocalDate возвращает день, который идёт в момент at в часовом поясе loc, как полночь UTC —
+// в том же виде, в каком даты тренировок возвращает program.Schedule.
+func LocalDate(at time.Time, loc *time.Location) time.Time {
+	y, m, d := at.In(loc).Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
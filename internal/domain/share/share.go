@@ -0,0 +1,57 @@
+package share
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ResourceType — вид данных, которыми делятся по ссылке.
+type ResourceType string
+
+const (
+	ResourceWorkout ResourceType = "workout" // Тренировка с подходами и итогами
+	ResourceProgram ResourceType = "program" // Программа с полной структурой
+)
+
+// IsValid возвращает true для известных видов данных.
+func (t ResourceType) IsValid() bool {
+	return t == ResourceWorkout || t == ResourceProgram
+}
+
+// Share — публичная ссылка на тренировку или программу. Ссылку открывают без аутентификации
+// и только для чтения; владелец может отозвать её в любой момент.
+type Share struct {
+	ID           uuid.UUID
+	OwnerID      uuid.UUID
+	ResourceType ResourceType
+	ResourceID   uuid.UUID
+	TokenHash    string // SHA-256 токена ссылки; сам токен не хранится
+	CreatedAt    time.Time
+	RevokedAt    *time.Time
+}
+
+// New — фабрика для создания ссылки с токеном token.
+func New(ownerID uuid.UUID, resourceType ResourceType, resourceID uuid.UUID, token string, at time.Time) *Share {
+	return &Share{
+		ID:           uuid.New(),
+		OwnerID:      ownerID,
+		ResourceType: resourceType,
+		ResourceID:   resourceID,
+		TokenHash:    HashToken(token),
+		CreatedAt:    at,
+	}
+}
+
+// IsRevoked возвращает true, если ссылка отозвана.
+func (s *Share) IsRevoked() bool {
+	return s.RevokedAt != nil
+}
+
+// HashToken возвращает SHA-256 токена в hex: по нему ссылка ищется в БД.
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,37 @@
+package social
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"workout-app/internal/domain/workout"
+)
+
+// Author — публичные данные профиля автора записи ленты.
+type Author struct {
+	UserID    uuid.UUID
+	Username  string
+	FirstName string
+	LastName  string
+	AvatarURL string
+}
+
+// FeedEntry — запись ленты активности: завершённая тренировка пользователя, на которого подписан читатель,
+// и личные рекорды, поставленные в этой тренировке.
+type FeedEntry struct {
+	Author  Author
+	Session *workout.Session
+	Records []Record
+}
+
+// Record — личный рекорд в упражнении: оценка разового максимума подхода превысила
+// лучшую оценку во всех предыдущих подходах этого упражнения.
+type Record struct {
+	Exercise            string
+	WeightKg            float64
+	Reps                int
+	OneRepMaxKg         float64 // Оценка разового максимума по формуле Эпли
+	PreviousOneRepMaxKg float64 // Предыдущий лучший результат
+	AchievedAt          time.Time
+}
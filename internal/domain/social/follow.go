@@ -0,0 +1,31 @@
+package social
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Follow — подписка пользователя FollowerID на пользователя FolloweeID.
+// Подписка односторонняя и не требует подтверждения; что видит подписчик,
+// определяет настройка видимости тренировок в профиле FolloweeID.
+type Follow struct {
+	FollowerID uuid.UUID
+	FolloweeID uuid.UUID
+	CreatedAt  time.Time
+}
+
+// NewFollow — фабрика для создания подписки.
+func NewFollow(followerID, followeeID uuid.UUID, at time.Time) *Follow {
+	return &Follow{FollowerID: followerID, FolloweeID: followeeID, CreatedAt: at}
+}
+
+// Connection — пользователь в списке подписчиков или подписок вместе с публичными данными профиля.
+type Connection struct {
+	UserID     uuid.UUID
+	Username   string
+	FirstName  string
+	LastName   string
+	AvatarURL  string
+	FollowedAt time.Time // Когда оформлена подписка
+}
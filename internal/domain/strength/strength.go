@@ -0,0 +1,187 @@
+package strength
+
+import (
+	"math"
+	"sort"
+	"strings"
+
+	"workout-app/internal/domain/program"
+)
+
+// Level — уровень силы в упражнении относительно веса тела.
+type Level string
+
+const (
+	LevelBeginner     Level = "beginner"
+	LevelNovice       Level = "novice"
+	LevelIntermediate Level = "intermediate"
+	LevelAdvanced     Level = "advanced"
+	LevelElite        Level = "elite"
+)
+
+// Levels — уровни по возрастанию; порядок совпадает с Row.ThresholdsKg.
+var Levels = [5]Level{LevelBeginner, LevelNovice, LevelIntermediate, LevelAdvanced, LevelElite}
+
+// levelPercentiles — процентиль атлетов, соответствующий порогу каждого уровня.
+var levelPercentiles = [5]float64{5, 20, 50, 80, 95}
+
+// maxPercentile ограничивает процентиль результатов выше порога elite.
+const maxPercentile = 99.9
+
+// Gender — пол, для которого задан норматив.
+type Gender string
+
+const (
+	GenderMale   Gender = "male"
+	GenderFemale Gender = "female"
+)
+
+// ParseGender приводит значение пола из профиля (свободная строка) к Gender.
+func ParseGender(s string) (Gender, bool) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "male", "m", "man", "мужской", "муж", "м":
+		return GenderMale, true
+	case "female", "f", "woman", "женский", "жен", "ж":
+		return GenderFemale, true
+	default:
+		return "", false
+	}
+}
+
+// Row — пороги уровней (разовый максимум, кг) для атлета с весом BodyWeightKg.
+type Row struct {
+	BodyWeightKg float64
+	ThresholdsKg [5]float64 // По Levels
+}
+
+// Standard — нормативы упражнения для пола: строки по весу тела, между которыми пороги интерполируются.
+type Standard struct {
+	Exercise string // Каноническое название (ключ) упражнения, например "squat"
+	Gender   Gender
+	Aliases  []string // Другие названия упражнения, под которыми оно записывается в подходах
+	Rows     []Row    // По возрастанию веса тела
+}
+
+// Catalog ищет норматив по названию упражнения из подхода с учётом синонимов.
+type Catalog struct {
+	byKey map[string]map[Gender]*Standard
+}
+
+// NewCatalog строит каталог нормативов; названия сравниваются по program.ExerciseKey.
+func NewCatalog(standards []*Standard) *Catalog {
+	c := &Catalog{byKey: make(map[string]map[Gender]*Standard)}
+	for _, s := range standards {
+		for _, name := range append([]string{s.Exercise}, s.Aliases...) {
+			key := program.ExerciseKey(name)
+			if c.byKey[key] == nil {
+				c.byKey[key] = make(map[Gender]*Standard)
+			}
+			c.byKey[key][s.Gender] = s
+		}
+	}
+	return c
+}
+
+// Find возвращает норматив упражнения для пола или nil, если норматива нет.
+func (c *Catalog) Find(exercise string, gender Gender) *Standard {
+	return c.byKey[program.ExerciseKey(exercise)][gender]
+}
+
+// Classification — оценка разового максимума по нормативу.
+type Classification struct {
+	Level        Level      // Достигнутый уровень; пусто, если результат ниже beginner
+	Percentile   float64    // Доля атлетов того же пола и веса со слабее результатом, %
+	ThresholdsKg [5]float64 // Пороги уровней для веса тела атлета
+	NextLevel    Level      // Следующий уровень; пусто для elite
+	ToNextKg     float64    // Сколько добавить к максимуму до следующего уровня
+}
+
+// ThresholdsAt возвращает пороги уровней для веса тела, линейно интерполируя между строками норматива.
+// Вне диапазона строк используются крайние строки.
+func (s *Standard) ThresholdsAt(bodyWeightKg float64) [5]float64 {
+	rows := s.Rows
+	if len(rows) == 0 {
+		return [5]float64{}
+	}
+	if bodyWeightKg <= rows[0].BodyWeightKg {
+		return rows[0].ThresholdsKg
+	}
+	last := rows[len(rows)-1]
+	if bodyWeightKg >= last.BodyWeightKg {
+		return last.ThresholdsKg
+	}
+	i := sort.Search(len(rows), func(i int) bool { return rows[i].BodyWeightKg >= bodyWeightKg })
+	lo, hi := rows[i-1], rows[i]
+	t := (bodyWeightKg - lo.BodyWeightKg) / (hi.BodyWeightKg - lo.BodyWeightKg)
+	var result [5]float64
+	for j := range result {
+		result[j] = round1(lo.ThresholdsKg[j] + t*(hi.ThresholdsKg[j]-lo.ThresholdsKg[j]))
+	}
+	return result
+}
+
+// Classify оценивает разовый максимум oneRepMaxKg атлета с весом bodyWeightKg.
+// Процентиль интерполируется между процентилями порогов уровней.
+func (s *Standard) Classify(bodyWeightKg, oneRepMaxKg float64) Classification {
+	c := Classification{ThresholdsKg: s.ThresholdsAt(bodyWeightKg)}
+	th := c.ThresholdsKg
+
+	level := -1
+	for i := range th {
+		if oneRepMaxKg >= th[i] {
+			level = i
+		}
+	}
+	if level >= 0 {
+		c.Level = Levels[level]
+	}
+	if level < len(Levels)-1 {
+		c.NextLevel = Levels[level+1]
+		c.ToNextKg = round1(th[level+1] - oneRepMaxKg)
+	}
+
+	switch {
+	case level < 0:
+		c.Percentile = levelPercentiles[0] * math.Max(oneRepMaxKg, 0) / th[0]
+	case level == len(Levels)-1:
+		// Выше elite процентиль растёт медленно: +1 пункт на каждые 5% сверх порога.
+		extra := (oneRepMaxKg/th[level] - 1) * 100 / 5
+		c.Percentile = math.Min(levelPercentiles[level]+extra, maxPercentile)
+	default:
+		t := (oneRepMaxKg - th[level]) / (th[level+1] - th[level])
+		c.Percentile = levelPercentiles[level] + t*(levelPercentiles[level+1]-levelPercentiles[level])
+	}
+	c.Percentile = round1(c.Percentile)
+	return c
+}
+
+// Validate проверяет строки норматива: веса тела по возрастанию без повторов,
+// пороги положительные и строго растут от beginner к elite.
+func (s *Standard) Validate() bool {
+	if len(s.Rows) == 0 {
+		return false
+	}
+	for i, r := range s.Rows {
+		if r.BodyWeightKg <= 0 || (i > 0 && r.BodyWeightKg <= s.Rows[i-1].BodyWeightKg) {
+			return false
+		}
+		for j, v := range r.ThresholdsKg {
+			if v <= 0 || (j > 0 && v <= r.ThresholdsKg[j-1]) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// EstimateOneRepMax оценивает разовый максимум по весу и числу повторений (формула Эпли).
+func EstimateOneRepMax(weightKg float64, reps int) float64 {
+	if reps <= 1 {
+		return weightKg
+	}
+	return round1(weightKg * (1 + float64(reps)/30))
+}
+
+func round1(v float64) float64 {
+	return math.Round(v*10) / 10
+}
@@ -0,0 +1,53 @@
+package user
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// BodyMetric представляет один замер параметров тела пользователя на определённый момент времени.
+// Любое из значений может отсутствовать, но хотя бы одно должно быть задано.
+type BodyMetric struct {
+	ID             uuid.UUID          // Уникальный идентификатор замера
+	UserID         uuid.UUID          // Пользователь, которому принадлежит замер
+	MeasuredAt     time.Time          // Момент замера
+	WeightKg       *float64           // Вес в килограммах (опционально)
+	BodyFatPercent *float64           // Процент жира (опционально)
+	Measurements   map[string]float64 // Произвольные замеры (например, "waist_cm": 80)
+	CreatedAt      time.Time          // Время создания записи
+}
+
+// NewBodyMetric — фабрика для создания нового замера.
+// Если measuredAt нулевой, используется текущее время.
+func NewBodyMetric(userID uuid.UUID, measuredAt time.Time) *BodyMetric {
+	now := time.Now().UTC()
+	if measuredAt.IsZero() {
+		measuredAt = now
+	}
+	return &BodyMetric{
+		ID:           uuid.New(),
+		UserID:       userID,
+		MeasuredAt:   measuredAt.UTC(),
+		Measurements: map[string]float64{},
+		CreatedAt:    now,
+	}
+}
+
+// IsEmpty возвращает true, если в замере не задано ни одного значения.
+func (m *BodyMetric) IsEmpty() bool {
+	return m.WeightKg == nil && m.BodyFatPercent == nil && len(m.Measurements) == 0
+}
+
+// MetricValue описывает значение метрики вместе с моментом замера.
+type MetricValue struct {
+	Value      float64
+	MeasuredAt time.Time
+}
+
+// BodyMetricsSummary содержит последние известные значения каждой метрики пользователя.
+type BodyMetricsSummary struct {
+	WeightKg       *MetricValue
+	BodyFatPercent *MetricValue
+	Measurements   map[string]MetricValue
+}
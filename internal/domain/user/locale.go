@@ -0,0 +1,67 @@
+package user
+
+import (
+	"time"
+
+	"golang.org/x/text/language"
+)
+
+// MaxTimezoneLength — максимальная длина названия часового пояса.
+const MaxTimezoneLength = 64
+
+// MaxLocaleLength — максимальная длина тега локали (рекомендация BCP 47).
+const MaxLocaleLength = 35
+
+// IsValidTimezone сообщает, известен ли часовой пояс IANA; пустая строка означает UTC.
+// Local не принимается: он зависит от настроек сервера.
+func IsValidTimezone(tz string) bool {
+	if tz == "" {
+		return true
+	}
+	if tz == "Local" || len(tz) > MaxTimezoneLength {
+		return false
+	}
+	_, err := time.LoadLocation(tz)
+	return err == nil
+}
+
+// Location возвращает часовой пояс пользователя; пустой или неизвестный — UTC.
+func (u *User) Location() *time.Location {
+	if u.Timezone == "" {
+		return time.UTC
+	}
+	loc, err := time.LoadLocation(u.Timezone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+// NormalizeLocale проверяет тег локали BCP 47 (например, en-GB) и приводит его к каноническому виду.
+// Пустая строка допустима и означает форматы языка пользователя.
+func NormalizeLocale(s string) (string, bool) {
+	if s == "" {
+		return "", true
+	}
+	if len(s) > MaxLocaleLength {
+		return "", false
+	}
+	tag, err := language.Parse(s)
+	if err != nil {
+		return "", false
+	}
+	return tag.String(), true
+}
+
+// MailLocale возвращает локаль писем: язык писем с региональными форматами дат из Locale,
+// если Locale относится к тому же языку (en-GB для языка en), иначе — только язык писем.
+func (u *User) MailLocale() string {
+	if u.Locale == "" || u.Language == "" {
+		return string(u.Language)
+	}
+	base, _ := language.Make(u.Locale).Base()
+	if base.String() != string(u.Language) {
+		return string(u.Language)
+	}
+	return u.Locale
+}
@@ -0,0 +1,41 @@
+package user
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// OAuthProvider описывает внешнего провайдера входа.
+type OAuthProvider string
+
+const (
+	OAuthProviderGoogle OAuthProvider = "google"
+	OAuthProviderApple  OAuthProvider = "apple"
+)
+
+// OAuthAccount связывает аккаунт у внешнего провайдера с пользователем.
+// Один аккаунт провайдера привязан к одному пользователю, у пользователя — не больше одного аккаунта каждого провайдера.
+type OAuthAccount struct {
+	ID          uuid.UUID
+	UserID      uuid.UUID
+	Provider    OAuthProvider
+	Subject     string // Постоянный ID пользователя у провайдера (sub ID-токена)
+	Email       string // Email из ID-токена на момент привязки
+	CreatedAt   time.Time
+	LastLoginAt time.Time
+}
+
+// NewOAuthAccount — фабрика для создания привязки аккаунта провайдера.
+func NewOAuthAccount(userID uuid.UUID, provider OAuthProvider, subject, email string) *OAuthAccount {
+	now := time.Now().UTC()
+	return &OAuthAccount{
+		ID:          uuid.New(),
+		UserID:      userID,
+		Provider:    provider,
+		Subject:     subject,
+		Email:       email,
+		CreatedAt:   now,
+		LastLoginAt: now,
+	}
+}
@@ -0,0 +1,117 @@
+package user
+
+import (
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Поля профиля, изменения которых попадают в историю.
+const (
+	FieldUsername           = "username"
+	FieldEmail              = "email"
+	FieldEmailVerified      = "email_verified"
+	FieldFirstName          = "first_name"
+	FieldLastName           = "last_name"
+	FieldBirthDate          = "birth_date"
+	FieldGender             = "gender"
+	FieldAvatarURL          = "avatar_url"
+	FieldRole               = "role"
+	FieldTrainingLevel      = "training_level"
+	FieldLanguage           = "language"
+	FieldWorkoutsVisibility = "workouts_visibility"
+	FieldTimezone           = "timezone"
+	FieldLocale             = "locale"
+	FieldUnits              = "units"
+	FieldReminderTime       = "reminder_time"
+	FieldCalorieTarget      = "calorie_target"
+)
+
+// FieldChange — изменение одного поля профиля. Значения приведены к строке; пустая строка — поле не заполнено.
+type FieldChange struct {
+	Field string
+	Old   string
+	New   string
+}
+
+// ProfileChange — запись истории изменений профиля: какие поля изменились, кто и когда их изменил.
+// ActorID совпадает с UserID, если профиль изменил сам пользователь, иначе это администратор.
+type ProfileChange struct {
+	ID        uuid.UUID
+	UserID    uuid.UUID
+	ActorID   uuid.UUID
+	Changes   []FieldChange
+	ChangedAt time.Time
+}
+
+// NewProfileChange — фабрика записи истории изменений профиля.
+func NewProfileChange(userID, actorID uuid.UUID, changes []FieldChange, at time.Time) *ProfileChange {
+	return &ProfileChange{
+		ID:        uuid.New(),
+		UserID:    userID,
+		ActorID:   actorID,
+		Changes:   changes,
+		ChangedAt: at,
+	}
+}
+
+// BySelf сообщает, изменил ли профиль сам пользователь.
+func (c *ProfileChange) BySelf() bool {
+	return c.ActorID == c.UserID
+}
+
+// ProfileFields — значения отслеживаемых полей профиля на момент снимка.
+type ProfileFields map[string]string
+
+// ProfileFields снимает значения отслеживаемых полей профиля для последующего сравнения через Diff.
+func (u *User) ProfileFields() ProfileFields {
+	fields := ProfileFields{
+		FieldUsername:           u.Username,
+		FieldEmail:              u.Email,
+		FieldEmailVerified:      strconv.FormatBool(u.IsEmailVerified),
+		FieldFirstName:          u.FirstName,
+		FieldLastName:           u.LastName,
+		FieldGender:             u.Gender,
+		FieldAvatarURL:          u.AvatarURL,
+		FieldRole:               string(u.Role),
+		FieldTrainingLevel:      string(u.TrainingLevel),
+		FieldLanguage:           string(u.Language),
+		FieldWorkoutsVisibility: string(u.WorkoutsVisibility),
+		FieldTimezone:           u.Timezone,
+		FieldLocale:             u.Locale,
+		FieldUnits:              string(u.Units),
+		FieldBirthDate:          "",
+		FieldReminderTime:       "",
+		FieldCalorieTarget:      "",
+	}
+	if u.BirthDate != nil {
+		fields[FieldBirthDate] = u.BirthDate.Format(time.DateOnly)
+	}
+	if u.ReminderTime != nil {
+		fields[FieldReminderTime] = u.ReminderTime.String()
+	}
+	if u.CalorieTarget != nil {
+		fields[FieldCalorieTarget] = strconv.Itoa(*u.CalorieTarget)
+	}
+	// Ссылки профиля записываются как instagram, instagram_visibility и т.д.
+	for _, kind := range []LinkKind{LinkInstagram, LinkYouTube, LinkWebsite} {
+		link := u.Links.Get(kind)
+		fields[string(kind)] = link.Value
+		fields[string(kind)+"_visibility"] = string(link.Visibility)
+	}
+	return fields
+}
+
+// Diff возвращает поля, значения которых отличаются в after, упорядоченные по имени поля.
+func (f ProfileFields) Diff(after ProfileFields) []FieldChange {
+	var changes []FieldChange
+	for field, old := range f {
+		if updated := after[field]; updated != old {
+			changes = append(changes, FieldChange{Field: field, Old: old, New: updated})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Field < changes[j].Field })
+	return changes
+}
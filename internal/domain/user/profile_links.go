@@ -0,0 +1,215 @@
+package user
+
+import (
+	"net"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// LinkKind описывает вид ссылки в профиле пользователя.
+type LinkKind string
+
+const (
+	LinkInstagram LinkKind = "instagram" // аккаунт Instagram
+	LinkYouTube   LinkKind = "youtube"   // канал YouTube
+	LinkWebsite   LinkKind = "website"   // личный сайт
+)
+
+// LinkVisibility определяет, кому видна ссылка профиля.
+type LinkVisibility string
+
+const (
+	LinkPublic  LinkVisibility = "public"  // видна в публичном профиле
+	LinkPrivate LinkVisibility = "private" // видна только владельцу
+)
+
+// IsValid возвращает true для известных вариантов видимости.
+func (v LinkVisibility) IsValid() bool {
+	return v == LinkPublic || v == LinkPrivate
+}
+
+// ProfileLink — ссылка профиля в нормализованной форме (см. LinkKind.Normalize).
+type ProfileLink struct {
+	Value      string         // Handle Instagram без @, handle YouTube с @ или ID канала, адрес сайта; пусто — не указана
+	Visibility LinkVisibility // Кому видна ссылка
+}
+
+// IsPublic возвращает true, если ссылка указана и видна в публичном профиле.
+func (l ProfileLink) IsPublic() bool {
+	return l.Value != "" && l.Visibility != LinkPrivate
+}
+
+// ProfileLinks — ссылки на соцсети и сайт пользователя. Видимость задаётся для каждой ссылки отдельно.
+type ProfileLinks struct {
+	Instagram ProfileLink
+	YouTube   ProfileLink
+	Website   ProfileLink
+}
+
+// Get возвращает ссылку указанного вида.
+func (l *ProfileLinks) Get(kind LinkKind) *ProfileLink {
+	switch kind {
+	case LinkInstagram:
+		return &l.Instagram
+	case LinkYouTube:
+		return &l.YouTube
+	case LinkWebsite:
+		return &l.Website
+	default:
+		return nil
+	}
+}
+
+// MaxWebsiteLength — максимальная длина адреса сайта после нормализации.
+const MaxWebsiteLength = 255
+
+var (
+	// Правила Instagram: латиница, цифры, точка и подчёркивание, до 30 символов.
+	instagramHandleRe = regexp.MustCompile(`^[a-z0-9._]{1,30}$`)
+	// Handle YouTube: от 3 до 30 символов; регистр не различается.
+	youtubeHandleRe = regexp.MustCompile(`^[a-z0-9._-]{3,30}$`)
+	// ID канала YouTube: UC и ещё 22 символа base64url.
+	youtubeChannelRe = regexp.MustCompile(`^UC[A-Za-z0-9_-]{22}$`)
+	// Имя хоста сайта: только ASCII (IDN — в punycode), не меньше двух меток.
+	websiteHostRe = regexp.MustCompile(`^[a-z0-9]([a-z0-9-]*[a-z0-9])?(\.[a-z0-9]([a-z0-9-]*[a-z0-9])?)+$`)
+)
+
+var (
+	instagramHosts = []string{"instagram.com", "www.instagram.com", "m.instagram.com"}
+	youtubeHosts   = []string{"youtube.com", "www.youtube.com", "m.youtube.com"}
+)
+
+// Normalize приводит введённое пользователем значение к нормализованной форме.
+// Instagram принимает handle (с @ или без) и ссылку на профиль, YouTube — handle, ID канала
+// и ссылки вида youtube.com/@handle или youtube.com/channel/ID, сайт — http(s)-адрес
+// (без схемы подставляется https). Параметры запроса ссылок на соцсети и фрагменты адресов отбрасываются.
+// Возвращает false, если значение некорректно.
+func (k LinkKind) Normalize(raw string) (string, bool) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" || strings.ContainsFunc(raw, isSpaceOrControl) {
+		return "", false
+	}
+	switch k {
+	case LinkInstagram:
+		return normalizeInstagram(raw)
+	case LinkYouTube:
+		return normalizeYouTube(raw)
+	case LinkWebsite:
+		return normalizeWebsite(raw)
+	default:
+		return "", false
+	}
+}
+
+// URL возвращает адрес страницы для нормализованного значения.
+func (k LinkKind) URL(value string) string {
+	switch k {
+	case LinkInstagram:
+		return "https://www.instagram.com/" + value + "/"
+	case LinkYouTube:
+		if strings.HasPrefix(value, "@") {
+			return "https://www.youtube.com/" + value
+		}
+		return "https://www.youtube.com/channel/" + value
+	default:
+		return value
+	}
+}
+
+func normalizeInstagram(raw string) (string, bool) {
+	handle := strings.TrimPrefix(raw, "@")
+	if path, ok := socialPath(raw, instagramHosts); ok {
+		handle = path
+	}
+	handle = strings.ToLower(handle)
+	if !instagramHandleRe.MatchString(handle) ||
+		strings.HasPrefix(handle, ".") || strings.HasSuffix(handle, ".") || strings.Contains(handle, "..") {
+		return "", false
+	}
+	return handle, true
+}
+
+func normalizeYouTube(raw string) (string, bool) {
+	value := raw
+	if path, ok := socialPath(raw, youtubeHosts); ok {
+		if id, found := strings.CutPrefix(path, "channel/"); found {
+			value = id
+		} else if strings.HasPrefix(path, "@") {
+			value = path
+		} else {
+			return "", false
+		}
+	}
+	if youtubeChannelRe.MatchString(value) {
+		return value, true
+	}
+	handle := strings.ToLower(strings.TrimPrefix(value, "@"))
+	if !youtubeHandleRe.MatchString(handle) {
+		return "", false
+	}
+	return "@" + handle, true
+}
+
+func normalizeWebsite(raw string) (string, bool) {
+	if !strings.Contains(raw, "://") {
+		raw = "https://" + raw
+	}
+	u, err := url.Parse(raw)
+	if err != nil || u.Opaque != "" || u.User != nil || u.Port() != "" {
+		return "", false
+	}
+	u.Scheme = strings.ToLower(u.Scheme)
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return "", false
+	}
+	host := strings.ToLower(u.Hostname())
+	if net.ParseIP(host) != nil || !websiteHostRe.MatchString(host) {
+		return "", false
+	}
+	u.Host = host
+	u.Fragment = ""
+	u.RawFragment = ""
+	if u.Path == "/" && u.RawQuery == "" {
+		u.Path = ""
+	}
+	value := u.String()
+	if len(value) > MaxWebsiteLength {
+		return "", false
+	}
+	return value, true
+}
+
+// socialPath возвращает путь ссылки на страницу соцсети без завершающего слэша.
+// false — значение не является ссылкой на один из hosts.
+func socialPath(raw string, hosts []string) (string, bool) {
+	lower := strings.ToLower(raw)
+	if !strings.HasPrefix(lower, "http://") && !strings.HasPrefix(lower, "https://") {
+		isHost := false
+		for _, h := range hosts {
+			if strings.HasPrefix(lower, h+"/") {
+				isHost = true
+				break
+			}
+		}
+		if !isHost {
+			return "", false
+		}
+		raw = "https://" + raw
+	}
+	u, err := url.Parse(raw)
+	if err != nil || u.User != nil {
+		return "", true
+	}
+	host := strings.ToLower(u.Host)
+	for _, h := range hosts {
+		if host == h {
+			return strings.Trim(u.Path, "/"), true
+		}
+	}
+	return "", true
+}
+
+func isSpaceOrControl(r rune) bool {
+	return r <= ' ' || r == 0x7f
+}
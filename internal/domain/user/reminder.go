@@ -0,0 +1,24 @@
+package user
+
+import (
+	"fmt"
+	"time"
+)
+
+// ReminderTime — время суток, в которое пользователь получает напоминание о тренировках дня:
+// минуты от полуночи в часовом поясе пользователя (0–1439).
+type ReminderTime int
+
+// ParseReminderTime разбирает время в формате HH:MM.
+func ParseReminderTime(s string) (ReminderTime, bool) {
+	t, err := time.Parse("15:04", s)
+	if err != nil {
+		return 0, false
+	}
+	return ReminderTime(t.Hour()*60 + t.Minute()), true
+}
+
+// String возвращает время в формате HH:MM.
+func (t ReminderTime) String() string {
+	return fmt.Sprintf("%02d:%02d", int(t)/60, int(t)%60)
+}
@@ -0,0 +1,111 @@
+package user
+
+import (
+	"math"
+	"strings"
+)
+
+// Units описывает систему единиц, в которой пользователь вводит и видит вес, длину и расстояние.
+// Хранятся значения всегда в СИ (кг, см, км); перевод выполняется на границе API.
+type Units string
+
+const (
+	UnitsMetric   Units = "metric"   // кг, см, км
+	UnitsImperial Units = "imperial" // фунты, дюймы, мили
+)
+
+// Коэффициенты перевода из имперских единиц в метрические.
+const (
+	kgPerPound = 0.45359237
+	cmPerInch  = 2.54
+	kmPerMile  = 1.609344
+)
+
+// Суффиксы названий полей с единицами: weight_kg / weight_lb, waist_cm / waist_in.
+const (
+	suffixKg = "_kg"
+	suffixLb = "_lb"
+	suffixCm = "_cm"
+	suffixIn = "_in"
+	suffixKm = "_km"
+	suffixMi = "_mi"
+)
+
+// IsValid возвращает true для поддерживаемых систем единиц.
+func (u Units) IsValid() bool {
+	return u == UnitsMetric || u == UnitsImperial
+}
+
+// OrDefault возвращает систему единиц, подставляя метрическую для пустого значения.
+func (u Units) OrDefault() Units {
+	if u == UnitsImperial {
+		return UnitsImperial
+	}
+	return UnitsMetric
+}
+
+// Weight переводит вес из килограммов в единицы u.
+func (u Units) Weight(kg float64) float64 {
+	if u == UnitsImperial {
+		return kg / kgPerPound
+	}
+	return kg
+}
+
+// Length переводит длину из сантиметров в единицы u.
+func (u Units) Length(cm float64) float64 {
+	if u == UnitsImperial {
+		return cm / cmPerInch
+	}
+	return cm
+}
+
+// Distance переводит расстояние из километров в единицы u.
+func (u Units) Distance(km float64) float64 {
+	if u == UnitsImperial {
+		return km / kmPerMile
+	}
+	return km
+}
+
+// PoundsToKg переводит фунты в килограммы.
+func PoundsToKg(lb float64) float64 {
+	return lb * kgPerPound
+}
+
+// Measurement возвращает название произвольного замера и его значение в единицах u.
+// Для имперской системы замеры с суффиксами _cm, _km и _kg получают суффиксы _in, _mi и _lb,
+// пересчитанное значение округляется до сотых.
+func (u Units) Measurement(name string, value float64) (string, float64) {
+	if u != UnitsImperial {
+		return name, value
+	}
+	switch {
+	case strings.HasSuffix(name, suffixCm):
+		return strings.TrimSuffix(name, suffixCm) + suffixIn, RoundUnit(u.Length(value))
+	case strings.HasSuffix(name, suffixKm):
+		return strings.TrimSuffix(name, suffixKm) + suffixMi, RoundUnit(u.Distance(value))
+	case strings.HasSuffix(name, suffixKg):
+		return strings.TrimSuffix(name, suffixKg) + suffixLb, RoundUnit(u.Weight(value))
+	}
+	return name, value
+}
+
+// CanonicalMeasurement переводит произвольный замер в СИ: имперские суффиксы _in, _mi и _lb
+// заменяются на _cm, _km и _kg, значение пересчитывается. Остальные замеры не меняются.
+func CanonicalMeasurement(name string, value float64) (string, float64) {
+	switch {
+	case strings.HasSuffix(name, suffixIn):
+		return strings.TrimSuffix(name, suffixIn) + suffixCm, value * cmPerInch
+	case strings.HasSuffix(name, suffixMi):
+		return strings.TrimSuffix(name, suffixMi) + suffixKm, value * kmPerMile
+	case strings.HasSuffix(name, suffixLb):
+		return strings.TrimSuffix(name, suffixLb) + suffixKg, PoundsToKg(value)
+	}
+	return name, value
+}
+
+// RoundUnit округляет пересчитанное значение до сотых, чтобы в ответах не было хвостов вида 176.36981.
+func RoundUnit(v float64) float64 {
+	return math.Round(v*100) / 100
+}
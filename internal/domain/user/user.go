@@ -1,9 +1,12 @@
 package user
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/google/uuid"
+
+	"workout-app/internal/domain/region"
 )
 
 // TrainingLevel описывает уровень подготовки пользователя.
@@ -24,6 +27,53 @@ const (
 	RoleAdmin Role = "admin"
 )
 
+// IsValid возвращает true для известных ролей.
+func (r Role) IsValid() bool {
+	return r == RoleUser || r == RoleCoach || r == RoleAdmin
+}
+
+// roleRanks упорядочивает роли по объёму прав.
+var roleRanks = map[Role]int{RoleUser: 1, RoleCoach: 2, RoleAdmin: 3}
+
+// Outranks возвращает true, если у роли r больше прав, чем у other:
+// смена r на other — понижение, после которого токены с прежней ролью должны быть отозваны.
+func (r Role) Outranks(other Role) bool {
+	return roleRanks[r] > roleRanks[other]
+}
+
+// Language описывает язык, на котором пользователь получает письма и уведомления.
+type Language string
+
+const (
+	LanguageRussian Language = "ru"
+	LanguageEnglish Language = "en"
+)
+
+// IsValid возвращает true для поддерживаемых языков.
+func (l Language) IsValid() bool {
+	return l == LanguageRussian || l == LanguageEnglish
+}
+
+// Допустимые значения дневной цели по калориям, ккал.
+const (
+	MinCalorieTarget = 500
+	MaxCalorieTarget = 10000
+)
+
+// WorkoutsVisibility определяет, кто видит тренировки пользователя.
+type WorkoutsVisibility string
+
+const (
+	WorkoutsPublic    WorkoutsVisibility = "public"    // все пользователи
+	WorkoutsFollowers WorkoutsVisibility = "followers" // только подписчики
+	WorkoutsPrivate   WorkoutsVisibility = "private"   // только владелец
+)
+
+// IsValid возвращает true для известных вариантов видимости.
+func (v WorkoutsVisibility) IsValid() bool {
+	return v == WorkoutsPublic || v == WorkoutsFollowers || v == WorkoutsPrivate
+}
+
 // User представляет доменную модель пользователя фитнес‑приложения.
 //
 // Важно: эта модель описывает бизнес‑сущность и не зависит от деталей транспорта (HTTP, gRPC)
@@ -41,12 +91,34 @@ type User struct {
 	AvatarURL string     // URL аватара (опционально)
 	Role      Role       // Роль (user/coach/admin и т.п.)
 
+	Links ProfileLinks // Ссылки на соцсети и сайт (опционально, видимость — для каждой ссылки)
+
+	WorkoutsVisibility WorkoutsVisibility // Кто видит тренировки (пусто — только владелец)
+
 	TrainingLevel   TrainingLevel // Уровень подготовки
 	IsEmailVerified bool          // Подтверждён ли email пользователя
+	Language        Language      // Язык писем (пусто — язык по умолчанию)
+
+	Timezone     string        // Часовой пояс IANA, например Europe/Moscow (пусто — UTC)
+	Locale       string        // Локаль BCP 47 для форматов дат, например en-GB (пусто — форматы языка)
+	Units        Units         // Система единиц для веса и замеров в API (пусто — метрическая)
+	ReminderTime *ReminderTime // Время напоминания о тренировках дня (nil — напоминания выключены)
+
+	CalorieTarget *int // Дневная цель по калориям, ккал (nil — не задана)
+
+	Country string        // Страна регистрации (ISO 3166-1 alpha-2, пустая строка — неизвестна)
+	Region  region.Region // Регион хранения данных, определяется по стране регистрации
+
+	Suspension *Suspension // Блокировка аккаунта администратором (nil, если не заблокирован)
+	LegalHold  *LegalHold  // Юридическое удержание данных (nil, если не установлено)
+
+	TokensValidAfter *time.Time // Токены, выданные раньше этого момента, отозваны (nil — ограничений нет)
 
 	CreatedAt time.Time  // Время создания
 	UpdatedAt time.Time  // Время последнего обновления
 	DeletedAt *time.Time // Для мягкого удаления (nil, если активен)
+
+	AnonymizedAt *time.Time // Время обезличивания (nil, если персональные данные не удалялись)
 }
 
 // NewUser — фабрика для создания нового пользователя на доменном уровне.
@@ -65,11 +137,72 @@ func NewUser(
 		Username:      username,
 		Role:          RoleUser,
 		TrainingLevel: TrainingLevelBeginner,
+		Region:        region.RegionGlobal,
 		CreatedAt:     now,
 		UpdatedAt:     now,
 	}
 }
 
+// SetRegistrationCountry сохраняет страну регистрации и вычисляет по ней регион данных.
+// Некорректный или неизвестный код страны оставляет регион global.
+func (u *User) SetRegistrationCountry(country string) {
+	u.Country = region.NormalizeCountry(country)
+	u.Region = region.FromCountry(u.Country)
+}
+
+// Suspension описывает блокировку аккаунта администратором.
+type Suspension struct {
+	At     time.Time  // Когда аккаунт заблокирован
+	Until  *time.Time // До какого момента (nil — бессрочно)
+	Reason string     // Причина блокировки
+}
+
+// LegalHold описывает юридическое удержание данных аккаунта (например, на время спора).
+// Пока удержание установлено, данные пользователя нельзя обезличивать и удалять автоматически.
+type LegalHold struct {
+	At     time.Time // Когда установлено
+	By     uuid.UUID // Администратор, установивший удержание
+	Reason string    // Основание (номер дела, обращения и т.п.)
+}
+
+// LegalHoldAction описывает действие с юридическим удержанием в журнале аудита.
+type LegalHoldAction string
+
+const (
+	LegalHoldPlaced   LegalHoldAction = "placed"   // удержание установлено
+	LegalHoldReleased LegalHoldAction = "released" // удержание снято
+)
+
+// LegalHoldRecord — запись журнала аудита юридических удержаний. Журнал только дополняется.
+type LegalHoldRecord struct {
+	ID        int64
+	UserID    uuid.UUID
+	Action    LegalHoldAction
+	ActorID   uuid.UUID // Администратор, выполнивший действие
+	Reason    string
+	CreatedAt time.Time
+}
+
+// IsOnLegalHold возвращает true, если на данные пользователя установлено юридическое удержание.
+func (u *User) IsOnLegalHold() bool {
+	return u.LegalHold != nil
+}
+
+// IsSuspended возвращает true, если на момент now аккаунт заблокирован.
+// Истёкшая блокировка считается снятой без отдельного действия администратора.
+func (u *User) IsSuspended(now time.Time) bool {
+	if u.Suspension == nil {
+		return false
+	}
+	return u.Suspension.Until == nil || now.Before(*u.Suspension.Until)
+}
+
+// IsTokenRevoked возвращает true, если токен, выданный в issuedAt, отозван
+// (например, выдан до последней смены пароля).
+func (u *User) IsTokenRevoked(issuedAt time.Time) bool {
+	return u.TokensValidAfter != nil && issuedAt.Before(*u.TokensValidAfter)
+}
+
 // IsDeleted возвращает true, если пользователь мягко удалён.
 func (u *User) IsDeleted() bool {
 	return u.DeletedAt != nil
@@ -81,19 +214,77 @@ func (u *User) MarkDeleted(at time.Time) {
 	u.UpdatedAt = at
 }
 
+// DeletedUsername — имя, под которым обезличенный пользователь отображается в чужом контенте.
+const DeletedUsername = "deleted user"
+
+// IsAnonymized возвращает true, если персональные данные пользователя уже удалены.
+func (u *User) IsAnonymized() bool {
+	return u.AnonymizedAt != nil
+}
+
+// Anonymize удаляет персональные данные пользователя, сохраняя саму запись:
+// программы, назначения и прочий контент продолжают ссылаться на неё и отображаются от имени DeletedUsername.
+// Email заменяется на уникальный недоставляемый адрес, пароль — на пустой хэш, с которым вход невозможен.
+// Пользователь одновременно помечается удалённым, а выданные ему токены — отозванными.
+func (u *User) Anonymize(at time.Time) {
+	u.Email = fmt.Sprintf("deleted-%s@anonymized.invalid", u.ID)
+	u.PasswordHash = ""
+	u.Username = DeletedUsername
+	u.FirstName = ""
+	u.LastName = ""
+	u.BirthDate = nil
+	u.Gender = ""
+	u.AvatarURL = ""
+	u.Links = ProfileLinks{}
+	u.Timezone = ""
+	u.Locale = ""
+	u.ReminderTime = nil
+	u.CalorieTarget = nil
+	u.IsEmailVerified = false
+	u.Suspension = nil
+	u.TokensValidAfter = &at
+	if u.DeletedAt == nil {
+		u.DeletedAt = &at
+	}
+	u.AnonymizedAt = &at
+	u.UpdatedAt = at
+}
+
 // Touch обновляет время последнего изменения сущности.
 func (u *User) Touch(at time.Time) {
 	u.UpdatedAt = at
 }
 
+// VerificationPurpose описывает назначение кода подтверждения.
+type VerificationPurpose string
+
+const (
+	PurposeRegistration    VerificationPurpose = "registration"     // подтверждение email при регистрации
+	PurposeEmailChange     VerificationPurpose = "email_change"     // подтверждение нового email
+	PurposePasswordChange  VerificationPurpose = "password_change"  // подтверждение смены пароля
+	PurposePasswordReset   VerificationPurpose = "password_reset"   // сброс забытого пароля
+	PurposeAccountDeletion VerificationPurpose = "account_deletion" // подтверждение удаления аккаунта
+)
+
+// IsValid возвращает true для известных назначений кода.
+func (p VerificationPurpose) IsValid() bool {
+	switch p {
+	case PurposeRegistration, PurposeEmailChange, PurposePasswordChange, PurposePasswordReset, PurposeAccountDeletion:
+		return true
+	default:
+		return false
+	}
+}
+
 // EmailVerification представляет доменную модель кода подтверждения email.
 type EmailVerification struct {
-	ID          int64     // Идентификатор записи (соответствует BIGSERIAL в БД)
-	UserID      uuid.UUID // Пользователь, для которого создан код
-	CodeHash    string    // Хэш одноразового кода подтверждения
-	ExpiresAt   time.Time // Время истечения кода
-	Attempts    int       // Количество использованных попыток
-	MaxAttempts int       // Максимально допустимое количество попыток
-	CreatedAt   time.Time // Время создания записи
-	NewEmail    *string   // Новый email для изменения (nil при обычном подтверждении при регистрации)
+	ID          int64               // Идентификатор записи (соответствует BIGSERIAL в БД)
+	UserID      uuid.UUID           // Пользователь, для которого создан код
+	CodeHash    string              // Хэш одноразового кода подтверждения
+	ExpiresAt   time.Time           // Время истечения кода
+	Attempts    int                 // Количество использованных попыток
+	MaxAttempts int                 // Максимально допустимое количество попыток
+	CreatedAt   time.Time           // Время создания записи
+	Purpose     VerificationPurpose // Назначение кода; определяет, какой сценарий он подтверждает
+	NewEmail    *string             // Новый email (только для PurposeEmailChange)
 }
@@ -0,0 +1,36 @@
+package user
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// UsernameChange — запись истории смены username.
+// Старый username остаётся зарезервированным за пользователем до ReservedUntil: другой пользователь
+// не может его занять и выдать себя за прежнего владельца, а старые ссылки на профиль ведут к новому имени.
+type UsernameChange struct {
+	ID            uuid.UUID
+	UserID        uuid.UUID
+	OldUsername   string
+	NewUsername   string
+	ChangedAt     time.Time
+	ReservedUntil time.Time
+}
+
+// NewUsernameChange — фабрика записи о смене username; reservation — срок резервирования старого имени.
+func NewUsernameChange(userID uuid.UUID, oldUsername, newUsername string, at time.Time, reservation time.Duration) *UsernameChange {
+	return &UsernameChange{
+		ID:            uuid.New(),
+		UserID:        userID,
+		OldUsername:   oldUsername,
+		NewUsername:   newUsername,
+		ChangedAt:     at,
+		ReservedUntil: at.Add(reservation),
+	}
+}
+
+// IsReserved сообщает, зарезервирован ли старый username в момент at.
+func (c *UsernameChange) IsReserved(at time.Time) bool {
+	return at.Before(c.ReservedUntil)
+}
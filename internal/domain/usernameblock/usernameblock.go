@@ -0,0 +1,25 @@
+package usernameblock
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"workout-app/pkg/usernamefilter"
+)
+
+// Entry — запрещённое слово, добавленное администратором в дополнение к встроенным спискам.
+type Entry struct {
+	ID        uuid.UUID
+	Term      string                  // Слово в нормализованной форме (usernamefilter.Normalize)
+	Mode      usernamefilter.Mode     // Совпадение целиком или вхождение
+	Category  usernamefilter.Category // Служебное имя или ненормативная лексика
+	Reason    string                  // Комментарий администратора
+	CreatedBy *uuid.UUID              // Администратор, добавивший запись
+	CreatedAt time.Time
+}
+
+// Rule возвращает правило проверки username для записи.
+func (e *Entry) Rule() usernamefilter.Rule {
+	return usernamefilter.Rule{Term: e.Term, Mode: e.Mode, Category: e.Category}
+}
@@ -0,0 +1,46 @@
+package video
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Status описывает состояние обработки загруженного видео.
+type Status string
+
+const (
+	StatusProcessing Status = "processing" // файл загружен, идёт приведение к формату воспроизведения
+	StatusReady      Status = "ready"      // видео можно воспроизводить
+	StatusFailed     Status = "failed"     // обработка завершилась ошибкой
+)
+
+// Video описывает загруженное видео с техникой выполнения упражнения.
+// Упражнения программ ссылаются на видео по ID (program.Exercise.VideoID).
+type Video struct {
+	ID          uuid.UUID
+	OwnerID     uuid.UUID // Тренер или администратор, загрузивший видео
+	Title       string
+	StorageKey  string // Ключ объекта в хранилище, из которого отдаётся видео
+	ContentType string // MIME-тип воспроизводимого файла
+	SizeBytes   int64
+	Status      Status
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// New — фабрика для создания записи о только что загруженном видео.
+func New(ownerID uuid.UUID, title, storageKey, contentType string, size int64) *Video {
+	now := time.Now().UTC()
+	return &Video{
+		ID:          uuid.New(),
+		OwnerID:     ownerID,
+		Title:       title,
+		StorageKey:  storageKey,
+		ContentType: contentType,
+		SizeBytes:   size,
+		Status:      StatusProcessing,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+}
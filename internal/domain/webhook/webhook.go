@@ -0,0 +1,117 @@
+package webhook
+
+import (
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+
+	"workout-app/internal/domain/event"
+)
+
+// EventTypes — события, на которые можно подписать webhook.
+// Данные событий не содержат персональных данных: только идентификаторы и моменты времени.
+var EventTypes = []string{
+	event.TypeUserRegistered,
+	event.TypeEmailVerified,
+	event.TypeAccountDeleted,
+}
+
+// IsSupportedEventType возвращает true, если на событие можно подписать webhook.
+func IsSupportedEventType(eventType string) bool {
+	return slices.Contains(EventTypes, eventType)
+}
+
+// Endpoint — адрес внешней системы (CRM, аналитика), получающий уведомления о событиях.
+type Endpoint struct {
+	ID          uuid.UUID
+	URL         string
+	Secret      string   // Ключ подписи запросов (HMAC-SHA256); хранится зашифрованным
+	EventTypes  []string // События, о которых уведомляется адрес
+	Active      bool     // Выключенный адрес не получает новых уведомлений
+	Description string
+	CreatedBy   *uuid.UUID // Администратор, добавивший адрес
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// Subscribed возвращает true, если включённый адрес подписан на событие eventType.
+func (e *Endpoint) Subscribed(eventType string) bool {
+	return e.Active && slices.Contains(e.EventTypes, eventType)
+}
+
+// Status описывает состояние доставки уведомления.
+type Status string
+
+const (
+	StatusPending   Status = "pending"   // ожидает отправки или повторной попытки
+	StatusDelivered Status = "delivered" // адрес ответил 2xx
+	StatusDead      Status = "dead"      // попытки исчерпаны; ждёт решения администратора
+)
+
+// IsValid возвращает true для известных состояний.
+func (s Status) IsValid() bool {
+	return s == StatusPending || s == StatusDelivered || s == StatusDead
+}
+
+// Delivery — уведомление о событии для одного адреса. Тело запроса формируется при постановке
+// в очередь и не меняется между попытками; подпись вычисляется заново при каждой попытке.
+type Delivery struct {
+	ID             int64
+	EndpointID     uuid.UUID
+	EventID        int64 // Номер события в журнале; на одно событие адрес получает одно уведомление
+	EventType      string
+	Body           []byte // JSON-тело запроса
+	Status         Status
+	Attempts       int       // Неудачных попыток отправки
+	NextAttemptAt  time.Time // Не раньше этого момента уведомление будет отправлено
+	LastError      string    // Ошибка последней неудачной попытки
+	LastStatusCode int       // HTTP-статус последнего ответа (0 — ответа не было)
+	TraceParent    string    // traceparent запроса, вызвавшего событие (пусто — вне запроса)
+	CreatedAt      time.Time
+	FinishedAt     *time.Time // Момент доставки или перевода в dead letter
+}
+
+// NewDelivery — фабрика для уведомления, готового к немедленной отправке.
+func NewDelivery(endpointID uuid.UUID, eventID int64, eventType string, body []byte, at time.Time) *Delivery {
+	return &Delivery{
+		EndpointID:    endpointID,
+		EventID:       eventID,
+		EventType:     eventType,
+		Body:          body,
+		Status:        StatusPending,
+		NextAttemptAt: at,
+		CreatedAt:     at,
+	}
+}
+
+// MarkDelivered отмечает уведомление доставленным.
+func (d *Delivery) MarkDelivered(at time.Time, statusCode int) {
+	d.Status = StatusDelivered
+	d.LastStatusCode = statusCode
+	d.LastError = ""
+	d.FinishedAt = &at
+}
+
+// MarkFailed учитывает неудачную попытку и назначает следующую через retryAfter.
+func (d *Delivery) MarkFailed(at time.Time, statusCode int, err string, retryAfter time.Duration) {
+	d.Attempts++
+	d.LastStatusCode = statusCode
+	d.LastError = err
+	d.NextAttemptAt = at.Add(retryAfter)
+}
+
+// MarkDead переводит уведомление в dead letter: автоматических попыток больше не будет.
+func (d *Delivery) MarkDead(at time.Time, err string) {
+	d.Status = StatusDead
+	d.LastError = err
+	d.FinishedAt = &at
+}
+
+// Requeue возвращает уведомление из dead letter в очередь со сброшенным счётчиком попыток.
+func (d *Delivery) Requeue(at time.Time) {
+	d.Status = StatusPending
+	d.Attempts = 0
+	d.NextAttemptAt = at
+	d.FinishedAt = nil
+}
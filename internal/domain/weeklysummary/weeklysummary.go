@@ -0,0 +1,30 @@
+package weeklysummary
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Summary — отметка, что еженедельная сводка тренировок пользователя за неделю обработана.
+// Создаётся и тогда, когда письмо не отправлено (тренировок не было или письма выключены):
+// повторная проверка той же недели не нужна.
+type Summary struct {
+	UserID    uuid.UUID
+	WeekStart time.Time // Понедельник недели в часовом поясе пользователя (полночь UTC)
+	CreatedAt time.Time
+}
+
+// New — фабрика отметки сводки за неделю, начинающуюся weekStart.
+func New(userID uuid.UUID, weekStart, at time.Time) *Summary {
+	return &Summary{UserID: userID, WeekStart: weekStart, CreatedAt: at}
+}
+
+// PreviousWeek возвращает понедельник недели, предшествующей текущей в момент at в часовом поясе loc,
+// как полночь UTC — в том же виде, в каком недели возвращает статистика тренировок.
+func PreviousWeek(at time.Time, loc *time.Location) time.Time {
+	y, m, d := at.In(loc).Date()
+	today := time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+	sinceMonday := (int(today.Weekday()) + 6) % 7
+	return today.AddDate(0, 0, -sinceMonday-7)
+}
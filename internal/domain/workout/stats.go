@@ -0,0 +1,56 @@
+package workout
+
+import "time"
+
+// MuscleGroup — основная группа мышц упражнения в справочнике exercise_muscle_groups.
+type MuscleGroup string
+
+const (
+	MuscleChest     MuscleGroup = "chest"
+	MuscleBack      MuscleGroup = "back"
+	MuscleShoulders MuscleGroup = "shoulders"
+	MuscleArms      MuscleGroup = "arms"
+	MuscleLegs      MuscleGroup = "legs"
+	MuscleCore      MuscleGroup = "core"
+)
+
+// WeekStats описывает завершённые тренировки одной недели (с понедельника в часовом поясе пользователя).
+type WeekStats struct {
+	WeekStart    time.Time // Понедельник недели (дата, полночь UTC)
+	Sessions     int
+	Sets         int
+	Reps         int
+	VolumeKg     float64
+	TrainingDays int // Дней с тренировками
+}
+
+// MuscleGroupStats описывает подходы упражнений одной группы мышц.
+type MuscleGroupStats struct {
+	Group    MuscleGroup
+	Sets     int
+	VolumeKg float64
+}
+
+// Runs описывает серии подряд идущих дней или недель с завершёнными тренировками.
+type Runs struct {
+	Longest int       // Самая длинная серия
+	Last    int       // Последняя серия
+	LastEnd time.Time // Последний день (или понедельник последней недели) последней серии
+}
+
+// Current возвращает длину последней серии, если она не прервана: её последний период не раньше previous
+// (вчера для дней, прошлая неделя для недель). Серия не прерывается, пока текущий день или неделя не закончились.
+func (r Runs) Current(previous time.Time) int {
+	if r.Last == 0 || r.LastEnd.Before(previous) {
+		return 0
+	}
+	return r.Last
+}
+
+// PersonalRecord описывает личный рекорд упражнения за период: максимальный вес подхода
+// больше, чем в любой тренировке до начала периода.
+type PersonalRecord struct {
+	Exercise   string  // Название упражнения из последнего подхода с рекордным весом
+	WeightKg   float64 // Рекордный вес за период
+	PreviousKg float64 // Лучший вес до периода
+}
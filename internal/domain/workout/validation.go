@@ -0,0 +1,92 @@
+package workout
+
+import (
+	"time"
+
+	"workout-app/internal/domain/program"
+)
+
+// ValidationMode задаёт, что происходит с данными за пределами правдоподобных границ.
+type ValidationMode string
+
+const (
+	ValidationOff    ValidationMode = "off"    // Границы не проверяются
+	ValidationWarn   ValidationMode = "warn"   // Данные сохраняются с пометкой и предупреждениями, в статистику не попадают
+	ValidationReject ValidationMode = "reject" // Данные отклоняются
+)
+
+// IsValid возвращает true для известных режимов.
+func (m ValidationMode) IsValid() bool {
+	return m == ValidationOff || m == ValidationWarn || m == ValidationReject
+}
+
+// Коды нарушенных правил.
+const (
+	WarningWeight   = "implausible_weight"
+	WarningReps     = "implausible_reps"
+	WarningDuration = "implausible_duration"
+)
+
+// Warning — нарушенное правило: значение поля больше правдоподобной границы.
+type Warning struct {
+	Code  string
+	Field string  // weight_kg, reps или duration_seconds
+	Value float64 // Записанное значение
+	Limit float64 // Граница в тех же единицах
+}
+
+// ExerciseLimits — правдоподобные границы подхода упражнения из справочника exercise_limits.
+// Незаданная граница берётся из общих правил.
+type ExerciseLimits struct {
+	Exercise    string // Ключ упражнения (program.ExerciseKey)
+	MaxWeightKg *float64
+	MaxReps     *int
+}
+
+// Rules — правила проверки правдоподобия записанных тренировок. Границы отсекают опечатки
+// (лишний ноль, вес вместо повторений), которые иначе испортили бы статистику и рекорды.
+type Rules struct {
+	Mode        ValidationMode
+	MaxWeightKg float64       // Вес подхода по умолчанию
+	MaxReps     int           // Повторения подхода по умолчанию
+	MaxDuration time.Duration // Длительность тренировки
+	Exercises   map[string]ExerciseLimits
+}
+
+// CheckSet возвращает нарушенные правила подхода; границы упражнения имеют приоритет над общими.
+func (r Rules) CheckSet(exercise string, reps int, weightKg *float64) []Warning {
+	if r.Mode == ValidationOff {
+		return nil
+	}
+	maxWeight, maxReps := r.MaxWeightKg, r.MaxReps
+	if limits, ok := r.Exercises[program.ExerciseKey(exercise)]; ok {
+		if limits.MaxWeightKg != nil {
+			maxWeight = *limits.MaxWeightKg
+		}
+		if limits.MaxReps != nil {
+			maxReps = *limits.MaxReps
+		}
+	}
+
+	var warnings []Warning
+	if weightKg != nil && maxWeight > 0 && *weightKg > maxWeight {
+		warnings = append(warnings, Warning{Code: WarningWeight, Field: "weight_kg", Value: *weightKg, Limit: maxWeight})
+	}
+	if maxReps > 0 && reps > maxReps {
+		warnings = append(warnings, Warning{Code: WarningReps, Field: "reps", Value: float64(reps), Limit: float64(maxReps)})
+	}
+	return warnings
+}
+
+// CheckDuration возвращает нарушенное правило длительности тренировки.
+func (r Rules) CheckDuration(d time.Duration) []Warning {
+	if r.Mode == ValidationOff || r.MaxDuration <= 0 || d <= r.MaxDuration {
+		return nil
+	}
+	return []Warning{{
+		Code:  WarningDuration,
+		Field: "duration_seconds",
+		Value: d.Seconds(),
+		Limit: r.MaxDuration.Seconds(),
+	}}
+}
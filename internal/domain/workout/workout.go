@@ -0,0 +1,188 @@
+package workout
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Session описывает тренировку пользователя: от старта до завершения с подходами,
+// время которых фиксирует сервер в момент записи.
+type Session struct {
+	ID             uuid.UUID
+	UserID         uuid.UUID
+	AssignmentID   *uuid.UUID    // Назначение программы, по которой идёт тренировка (опционально)
+	Title          string        // Название тренировки
+	AutoPauseAfter time.Duration // Простой дольше этого порога не входит в активное время
+	StartedAt      time.Time
+	FinishedAt     *time.Time // nil, пока тренировка идёт
+	Sets           []Set      // Подходы по времени записи
+	// Difficulty — субъективная сложность завершённой тренировки по шкале RPE
+	// (MinDifficulty..MaxDifficulty); nil, пока пользователь её не оценил.
+	Difficulty        *int
+	DifficultyRatedAt *time.Time
+	// Plan — упражнения тренировки программы, по которой начата тренировка, на момент старта;
+	// пустой для свободной тренировки.
+	Plan []PlannedExercise
+}
+
+// PlannedExercise описывает упражнение плана тренировки с предписаниями программы.
+type PlannedExercise struct {
+	Exercise    string
+	Sets        int
+	Reps        int
+	WeightKg    *float64 // Абсолютный вес из программы; nil для процентных назначений и упражнений без веса
+	Tempo       string   // Темп повторения, например «3-1-1-0»
+	RestSeconds []int    // Отдых после каждого подхода по порядку; пустой — отдых не задан
+}
+
+// Шкала субъективной сложности тренировки (RPE): 1 — очень легко, 10 — на пределе возможностей.
+const (
+	MinDifficulty = 1
+	MaxDifficulty = 10
+)
+
+// Set описывает выполненный подход.
+type Set struct {
+	ID        uuid.UUID
+	SessionID uuid.UUID
+	Exercise  string   // Название упражнения
+	Reps      int      // Выполненные повторения
+	WeightKg  *float64 // Рабочий вес (nil — упражнение с собственным весом)
+	LoggedAt  time.Time
+	// Implausible — значения за пределами правдоподобных границ (режим проверки warn):
+	// подход хранится, но не входит в тоннаж, статистику и рекорды.
+	Implausible bool
+	// PrescribedRest — отдых после подхода по плану тренировки, секунды; вычисляется
+	// по Session.PrescribedRest и не хранится.
+	PrescribedRest *int
+}
+
+// Summary описывает итоги тренировки с учётом автопаузы.
+type Summary struct {
+	TotalDuration   time.Duration // От старта до завершения (для идущей тренировки — до текущего момента)
+	ActiveDuration  time.Duration // Общее время без простоев
+	PausedDuration  time.Duration // Исключённое время простоев
+	Pauses          int           // Количество автопауз
+	Sets            int
+	VolumeKg        float64 // Тоннаж: сумма повторений × вес (без неправдоподобных подходов)
+	VolumePerMinute float64 // Тоннаж на минуту активного времени
+}
+
+// NewSession — фабрика для создания начатой тренировки.
+func NewSession(userID uuid.UUID, title string, assignmentID *uuid.UUID, autoPauseAfter time.Duration, at time.Time) *Session {
+	return &Session{
+		ID:             uuid.New(),
+		UserID:         userID,
+		AssignmentID:   assignmentID,
+		Title:          title,
+		AutoPauseAfter: autoPauseAfter,
+		StartedAt:      at,
+	}
+}
+
+// PrescribedRest возвращает отдых по плану после подхода Sets[i] или nil, если план его не задаёт.
+// Подход сопоставляется с упражнением плана по названию без учёта регистра, номер подхода —
+// по числу предыдущих подходов в том же упражнении; подходы сверх плана упражнения
+// переходят к его следующему вхождению в план.
+func (s *Session) PrescribedRest(i int) *int {
+	if i < 0 || i >= len(s.Sets) || len(s.Plan) == 0 {
+		return nil
+	}
+	exercise := s.Sets[i].Exercise
+	n := 0
+	for _, set := range s.Sets[:i] {
+		if strings.EqualFold(set.Exercise, exercise) {
+			n++
+		}
+	}
+	for _, p := range s.Plan {
+		if !strings.EqualFold(p.Exercise, exercise) {
+			continue
+		}
+		if n >= p.Sets {
+			n -= p.Sets
+			continue
+		}
+		if n < len(p.RestSeconds) {
+			rest := p.RestSeconds[n]
+			return &rest
+		}
+		return nil
+	}
+	return nil
+}
+
+// IsActive возвращает true, пока тренировка не завершена.
+func (s *Session) IsActive() bool {
+	return s.FinishedAt == nil
+}
+
+// LastActivityAt возвращает время последнего подхода или старта, если подходов ещё нет.
+func (s *Session) LastActivityAt() time.Time {
+	last := s.StartedAt
+	for _, set := range s.Sets {
+		if set.LoggedAt.After(last) {
+			last = set.LoggedAt
+		}
+	}
+	return last
+}
+
+// ModifiedAt возвращает время последнего изменения тренировки: подхода, завершения или оценки сложности.
+func (s *Session) ModifiedAt() time.Time {
+	last := s.LastActivityAt()
+	for _, at := range []*time.Time{s.FinishedAt, s.DifficultyRatedAt} {
+		if at != nil && at.After(last) {
+			last = *at
+		}
+	}
+	return last
+}
+
+// Summarize считает итоги тренировки. Для идущей тренировки концом считается now.
+//
+// Активное время — сумма промежутков между стартом, подходами и концом тренировки;
+// из промежутка длиннее AutoPauseAfter учитывается только AutoPauseAfter (отдых между подходами),
+// остальное считается простоем (пользователь отвлёкся или забыл завершить тренировку).
+func (s *Session) Summarize(now time.Time) Summary {
+	end := now
+	if s.FinishedAt != nil {
+		end = *s.FinishedAt
+	}
+
+	var summary Summary
+	logged := make([]time.Time, 0, len(s.Sets))
+	for _, set := range s.Sets {
+		logged = append(logged, set.LoggedAt)
+		summary.Sets++
+		if set.WeightKg != nil && !set.Implausible {
+			summary.VolumeKg += float64(set.Reps) * *set.WeightKg
+		}
+	}
+	sort.Slice(logged, func(i, j int) bool { return logged[i].Before(logged[j]) })
+
+	points := make([]time.Time, 0, len(logged)+2)
+	points = append(points, s.StartedAt)
+	points = append(points, logged...)
+	points = append(points, end)
+
+	for i := 1; i < len(points); i++ {
+		gap := points[i].Sub(points[i-1])
+		if gap <= 0 {
+			continue
+		}
+		summary.TotalDuration += gap
+		if s.AutoPauseAfter > 0 && gap > s.AutoPauseAfter {
+			summary.PausedDuration += gap - s.AutoPauseAfter
+			summary.Pauses++
+		}
+	}
+	summary.ActiveDuration = summary.TotalDuration - summary.PausedDuration
+	if minutes := summary.ActiveDuration.Minutes(); minutes > 0 {
+		summary.VolumePerMinute = summary.VolumeKg / minutes
+	}
+	return summary
+}
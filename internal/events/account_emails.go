@@ -0,0 +1,126 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	domain "workout-app/internal/domain/event"
+	repo "workout-app/internal/repository/interfaces"
+	"workout-app/pkg/mailer"
+)
+
+// AccountEmailsSubscriberName — имя подписчика, отправляющего письма о событиях аккаунта.
+const AccountEmailsSubscriberName = "account_emails"
+
+// accountEmailMaxAge — письма о событиях старше этого срока не отправляются: replay журнала
+// не должен рассылать приветствия и уведомления о давно сменённых паролях.
+const accountEmailMaxAge = 24 * time.Hour
+
+// AccountEmails отправляет транзакционные письма о событиях аккаунта: приветствие после подтверждения
+// email (или регистрации через OAuth с уже подтверждённым email), уведомления о смене пароля
+// и об удалении аккаунта. Usecase-слой только публикует события и не зависит от набора писем.
+type AccountEmails struct {
+	users  repo.UserRepository
+	sender mailer.EmailSender
+	now    func() time.Time
+}
+
+// Убедимся на этапе компиляции, что структура реализует интерфейс.
+var _ Subscriber = (*AccountEmails)(nil)
+
+// NewAccountEmails создаёт подписчика; адрес и язык письма берутся из текущих данных пользователя.
+func NewAccountEmails(users repo.UserRepository, sender mailer.EmailSender) *AccountEmails {
+	return &AccountEmails{
+		users:  users,
+		sender: sender,
+		now:    func() time.Time { return time.Now().UTC() },
+	}
+}
+
+// Name возвращает имя подписчика.
+func (s *AccountEmails) Name() string {
+	return AccountEmailsSubscriberName
+}
+
+// Types возвращает события аккаунта, о которых отправляются письма.
+func (s *AccountEmails) Types() []string {
+	return []string{domain.TypeUserRegistered, domain.TypeEmailVerified, domain.TypePasswordChanged, domain.TypeAccountDeleted}
+}
+
+// accountEvent — общие поля событий аккаунта; остальные поля нужны только отдельным письмам.
+type accountEvent struct {
+	UserID        string    `json:"user_id"`
+	EmailVerified bool      `json:"email_verified"`
+	ChangedAt     time.Time `json:"changed_at"`
+	DeletedAt     time.Time `json:"deleted_at"`
+}
+
+// Handle отправляет письмо о событии. Устаревшие события, регистрации без подтверждённого email
+// и события обезличенных пользователей пропускаются; адрес из списка подавления ошибкой не считается.
+func (s *AccountEmails) Handle(ctx context.Context, ev domain.Event, dryRun bool) ([]string, error) {
+	if s.now().Sub(ev.OccurredAt) > accountEmailMaxAge {
+		return nil, nil
+	}
+
+	var payload accountEvent
+	if err := json.Unmarshal(ev.Payload, &payload); err != nil {
+		return nil, fmt.Errorf("decode %s payload: %w", ev.Type, err)
+	}
+	if ev.Type == domain.TypeUserRegistered && !payload.EmailVerified {
+		return nil, nil
+	}
+	userID, err := uuid.Parse(payload.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("decode %s payload: %w", ev.Type, err)
+	}
+
+	user, err := s.users.GetByIDIncludingDeleted(ctx, userID)
+	if errors.Is(err, repo.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if user.IsAnonymized() {
+		return nil, nil
+	}
+
+	var (
+		email string
+		send  func(ctx context.Context) error
+	)
+	switch ev.Type {
+	case domain.TypeUserRegistered, domain.TypeEmailVerified:
+		email = "welcome"
+		send = func(ctx context.Context) error { return s.sender.SendWelcome(ctx, user.Email, user.Username) }
+	case domain.TypePasswordChanged:
+		email = "password_changed"
+		send = func(ctx context.Context) error {
+			return s.sender.SendPasswordChanged(ctx, user.Email, payload.ChangedAt)
+		}
+	case domain.TypeAccountDeleted:
+		email = "account_deleted"
+		send = func(ctx context.Context) error {
+			return s.sender.SendAccountDeleted(ctx, user.Email, payload.DeletedAt)
+		}
+	default:
+		return nil, nil
+	}
+
+	change := fmt.Sprintf("send %s email to user %s", email, user.ID)
+	if dryRun {
+		return []string{change}, nil
+	}
+	if err := send(mailer.WithRecipient(ctx, user.MailLocale(), user.Timezone)); err != nil {
+		if errors.Is(err, mailer.ErrRecipientUndeliverable) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return []string{change}, nil
+}
@@ -0,0 +1,141 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"time"
+
+	domain "workout-app/internal/domain/event"
+	repo "workout-app/internal/repository/interfaces"
+	"workout-app/pkg/logger"
+)
+
+// Subscriber обрабатывает доменные события.
+// Обработка должна быть идемпотентной: при replay одно и то же событие может быть доставлено повторно.
+type Subscriber interface {
+	// Name возвращает уникальное имя подписчика (используется в контрольных точках и команде replay).
+	Name() string
+
+	// Types возвращает типы событий, на которые подписан подписчик. Пустой список — все типы.
+	Types() []string
+
+	// Handle обрабатывает событие и возвращает человекочитаемое описание изменений.
+	// При dryRun == true подписчик ничего не меняет, а только описывает, что было бы изменено.
+	Handle(ctx context.Context, ev domain.Event, dryRun bool) ([]string, error)
+}
+
+// Publisher публикует доменные события. Используется usecase-слоем.
+type Publisher interface {
+	// Publish сохраняет событие в журнал и доставляет его подписчикам.
+	// Ошибки публикации логируются и не прерывают основную операцию.
+	Publish(ctx context.Context, eventType, aggregateID string, payload any)
+}
+
+// Registry хранит зарегистрированных подписчиков по имени.
+type Registry struct {
+	subscribers map[string]Subscriber
+}
+
+// NewRegistry создает реестр подписчиков.
+func NewRegistry(subscribers ...Subscriber) *Registry {
+	r := &Registry{subscribers: make(map[string]Subscriber, len(subscribers))}
+	for _, s := range subscribers {
+		r.subscribers[s.Name()] = s
+	}
+	return r
+}
+
+// Get возвращает подписчика по имени.
+func (r *Registry) Get(name string) (Subscriber, bool) {
+	s, ok := r.subscribers[name]
+	return s, ok
+}
+
+// Names возвращает отсортированные имена подписчиков.
+func (r *Registry) Names() []string {
+	names := make([]string, 0, len(r.subscribers))
+	for name := range r.subscribers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Bus сохраняет события в журнал и синхронно доставляет их подписчикам.
+// Журнал записывается до доставки, поэтому пропущенные из-за ошибок события можно доставить через replay.
+type Bus struct {
+	store    repo.EventRepository
+	registry *Registry
+	logger   logger.Logger
+}
+
+// Убедимся на этапе компиляции, что Bus реализует Publisher.
+var _ Publisher = (*Bus)(nil)
+
+// NewBus создает шину доменных событий.
+func NewBus(store repo.EventRepository, registry *Registry, log logger.Logger) *Bus {
+	return &Bus{store: store, registry: registry, logger: log}
+}
+
+// Publish сохраняет событие в журнал и доставляет его подписчикам.
+func (b *Bus) Publish(ctx context.Context, eventType, aggregateID string, payload any) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		b.logger.Error("domain_event_marshal_failed", map[string]any{
+			"type":  eventType,
+			"error": err.Error(),
+		})
+		return
+	}
+
+	ev := domain.Event{
+		Type:        eventType,
+		AggregateID: aggregateID,
+		Payload:     data,
+		OccurredAt:  time.Now().UTC(),
+	}
+	if err := b.store.Append(ctx, &ev); err != nil {
+		b.logger.Error("domain_event_append_failed", map[string]any{
+			"type":         eventType,
+			"aggregate_id": aggregateID,
+			"error":        err.Error(),
+		})
+		return
+	}
+
+	for _, name := range b.registry.Names() {
+		sub, _ := b.registry.Get(name)
+		if !Matches(sub, ev.Type) {
+			continue
+		}
+		if _, err := sub.Handle(ctx, ev, false); err != nil {
+			b.logger.Error("domain_event_delivery_failed", map[string]any{
+				"event_id":   ev.ID,
+				"type":       ev.Type,
+				"subscriber": name,
+				"error":      err.Error(),
+			})
+		}
+	}
+}
+
+// Matches сообщает, подписан ли подписчик на события данного типа.
+func Matches(sub Subscriber, eventType string) bool {
+	types := sub.Types()
+	if len(types) == 0 {
+		return true
+	}
+	for _, t := range types {
+		if t == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// NopPublisher ничего не публикует. Используется там, где журнал событий не нужен (например, в тестах).
+type NopPublisher struct{}
+
+// Publish ничего не делает.
+func (NopPublisher) Publish(context.Context, string, string, any) {}
@@ -0,0 +1,152 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	domain "workout-app/internal/domain/event"
+	"workout-app/internal/domain/gymclass"
+	"workout-app/internal/domain/notification"
+	repo "workout-app/internal/repository/interfaces"
+	"workout-app/pkg/mailer"
+)
+
+// NotificationsSubscriberName — имя подписчика, создающего уведомления в приложении.
+const NotificationsSubscriberName = "notifications"
+
+// notificationCopyMaxAge — копии уведомления (письмо, push) не отправляются для событий старше этого срока:
+// replay журнала восстанавливает уведомления в приложении, но не рассылает устаревшие копии.
+const notificationCopyMaxAge = 24 * time.Hour
+
+// Notifications создаёт уведомления в приложении о событиях, касающихся пользователя,
+// и их копии письмом и push-уведомлением, если пользователь не отключил их для этого типа.
+// Повторная доставка события (cmd/replay) не создаёт второго уведомления и не отправляет копии повторно.
+type Notifications struct {
+	notifications repo.NotificationRepository
+	users         repo.UserRepository
+	sender        mailer.EmailSender
+	now           func() time.Time
+}
+
+// Убедимся на этапе компиляции, что структура реализует интерфейс.
+var _ Subscriber = (*Notifications)(nil)
+
+// NewNotifications создаёт подписчика.
+func NewNotifications(notifications repo.NotificationRepository, users repo.UserRepository, sender mailer.EmailSender) *Notifications {
+	return &Notifications{
+		notifications: notifications,
+		users:         users,
+		sender:        sender,
+		now:           func() time.Time { return time.Now().UTC() },
+	}
+}
+
+// Name возвращает имя подписчика.
+func (s *Notifications) Name() string {
+	return NotificationsSubscriberName
+}
+
+// Types возвращает события, о которых пользователь получает уведомления.
+func (s *Notifications) Types() []string {
+	return []string{domain.TypeProgramAssigned, domain.TypeClassBooked, domain.TypeClassWaitlistPromoted, domain.TypeWorkoutReminder}
+}
+
+// notificationEvent — поля событий, нужные для уведомления.
+type notificationEvent struct {
+	UserID     string    `json:"user_id"`
+	AssignedBy string    `json:"assigned_by"`
+	StartsAt   time.Time `json:"starts_at"`
+	Status     string    `json:"status"`
+}
+
+// Handle создаёт уведомление получателю события. Программы, назначенные себе, и события
+// удалённых пользователей пропускаются; адрес из списка подавления ошибкой не считается.
+func (s *Notifications) Handle(ctx context.Context, ev domain.Event, dryRun bool) ([]string, error) {
+	var payload notificationEvent
+	if err := json.Unmarshal(ev.Payload, &payload); err != nil {
+		return nil, fmt.Errorf("decode %s payload: %w", ev.Type, err)
+	}
+
+	notificationType := ev.Type
+	at := ev.OccurredAt
+	switch ev.Type {
+	case domain.TypeProgramAssigned:
+		if payload.AssignedBy == payload.UserID {
+			return nil, nil
+		}
+	case domain.TypeClassBooked, domain.TypeClassWaitlistPromoted:
+		if ev.Type == domain.TypeClassBooked && payload.Status == string(gymclass.StatusWaitlisted) {
+			notificationType = notification.TypeClassWaitlisted
+		}
+		at = payload.StartsAt
+	case domain.TypeWorkoutReminder:
+	default:
+		return nil, nil
+	}
+
+	userID, err := uuid.Parse(payload.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("decode %s payload: %w", ev.Type, err)
+	}
+	user, err := s.users.GetByIDIncludingDeleted(ctx, userID)
+	if errors.Is(err, repo.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if user.IsDeleted() || user.IsAnonymized() {
+		return nil, nil
+	}
+
+	change := fmt.Sprintf("create %s notification for user %s", notificationType, user.ID)
+	if dryRun {
+		return []string{change}, nil
+	}
+	// Для устаревших событий копии не отправляются: настройка остаётся нулевой.
+	var pref notification.Preference
+	if s.now().Sub(ev.OccurredAt) <= notificationCopyMaxAge {
+		if pref, err = s.preference(ctx, user.ID, notificationType); err != nil {
+			return nil, err
+		}
+	}
+
+	n := notification.NewNotification(user.ID, notificationType, ev.Payload, ev.ID, s.now())
+	// Push-уведомление отправит воркер после фиксации транзакции, не задерживая запрос.
+	n.PushPending = pref.Push
+	created, err := s.notifications.Create(ctx, n)
+	if err != nil || !created {
+		return nil, err
+	}
+	changes := []string{change}
+
+	if !pref.Email {
+		return changes, nil
+	}
+	if err := s.sender.SendNotification(mailer.WithRecipient(ctx, user.MailLocale(), user.Timezone), user.Email, notificationType, at); err != nil {
+		if errors.Is(err, mailer.ErrRecipientUndeliverable) {
+			return changes, nil
+		}
+		return changes, err
+	}
+	return append(changes, fmt.Sprintf("send %s notification email to user %s", notificationType, user.ID)), nil
+}
+
+// preference возвращает настройку пользователя для типа уведомлений; без сохранённой настройки — копии включены.
+func (s *Notifications) preference(ctx context.Context, userID uuid.UUID, notificationType string) (notification.Preference, error) {
+	prefs, err := s.notifications.GetPreferences(ctx, userID)
+	if err != nil {
+		return notification.Preference{}, err
+	}
+	for _, p := range prefs {
+		if p.Type == notificationType {
+			return p, nil
+		}
+	}
+	return notification.Preference{Type: notificationType, Email: true, Push: true}, nil
+}
@@ -0,0 +1,132 @@
+package events
+
+import (
+	"context"
+	"fmt"
+
+	domain "workout-app/internal/domain/event"
+	repo "workout-app/internal/repository/interfaces"
+)
+
+// defaultReplayBatchSize — размер пачки событий, читаемых из журнала за один запрос.
+const defaultReplayBatchSize = 500
+
+// ReplayOptions описывает параметры повторной доставки событий.
+type ReplayOptions struct {
+	// FromID — первый ID события (включительно). 0 — продолжить с контрольной точки.
+	FromID int64
+	// ToID — последний ID события (включительно). 0 — до конца журнала.
+	ToID int64
+	// Reset начинает с начала журнала, игнорируя контрольную точку.
+	Reset bool
+	// DryRun только описывает изменения, не применяя их и не сдвигая контрольную точку.
+	DryRun bool
+	// BatchSize — размер пачки; <= 0 означает значение по умолчанию.
+	BatchSize int
+}
+
+// Outcome описывает результат доставки одного события.
+type Outcome struct {
+	Event   domain.Event
+	Changes []string
+}
+
+// ReplayResult содержит итог повторной доставки.
+type ReplayResult struct {
+	Delivered   int   // сколько событий доставлено подписчику
+	Skipped     int   // сколько событий пропущено (тип не интересен подписчику)
+	Changed     int   // сколько событий привели к изменениям
+	LastEventID int64 // ID последнего обработанного события
+}
+
+// Replayer повторно доставляет события из журнала выбранному подписчику.
+type Replayer struct {
+	store repo.EventRepository
+}
+
+// NewReplayer создает Replayer.
+func NewReplayer(store repo.EventRepository) *Replayer {
+	return &Replayer{store: store}
+}
+
+// CheckpointName возвращает имя контрольной точки replay для подписчика.
+func CheckpointName(subscriber string) string {
+	return "replay:" + subscriber
+}
+
+// Replay доставляет события подписчику начиная с контрольной точки (или opts.FromID).
+// После каждой пачки контрольная точка сохраняется, поэтому прерванный replay продолжается с места остановки.
+// report вызывается для каждого доставленного события и может быть nil.
+func (r *Replayer) Replay(ctx context.Context, sub Subscriber, opts ReplayOptions, report func(Outcome)) (*ReplayResult, error) {
+	checkpoint := CheckpointName(sub.Name())
+	batchSize := opts.BatchSize
+	if batchSize <= 0 {
+		batchSize = defaultReplayBatchSize
+	}
+
+	var after int64
+	switch {
+	case opts.FromID > 0:
+		after = opts.FromID - 1
+	case opts.Reset:
+		after = 0
+	default:
+		last, err := r.store.GetCheckpoint(ctx, checkpoint)
+		if err != nil {
+			return nil, fmt.Errorf("load checkpoint: %w", err)
+		}
+		after = last
+	}
+
+	result := &ReplayResult{LastEventID: after}
+	for {
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+
+		batch, err := r.store.ListAfter(ctx, after, opts.ToID, sub.Types(), batchSize)
+		if err != nil {
+			return result, fmt.Errorf("list events: %w", err)
+		}
+		if len(batch) == 0 {
+			return result, nil
+		}
+
+		for _, ev := range batch {
+			if !Matches(sub, ev.Type) {
+				result.Skipped++
+				after = ev.ID
+				continue
+			}
+
+			changes, err := sub.Handle(ctx, ev, opts.DryRun)
+			if err != nil {
+				_ = r.saveCheckpoint(ctx, checkpoint, opts.DryRun, result.LastEventID)
+				return result, fmt.Errorf("handle event %d (%s): %w", ev.ID, ev.Type, err)
+			}
+
+			result.Delivered++
+			if len(changes) > 0 {
+				result.Changed++
+			}
+			result.LastEventID = ev.ID
+			after = ev.ID
+			if report != nil {
+				report(Outcome{Event: ev, Changes: changes})
+			}
+		}
+		result.LastEventID = after
+
+		if err := r.saveCheckpoint(ctx, checkpoint, opts.DryRun, after); err != nil {
+			return result, fmt.Errorf("save checkpoint: %w", err)
+		}
+	}
+}
+
+// saveCheckpoint сохраняет контрольную точку, если это не пробный прогон.
+func (r *Replayer) saveCheckpoint(ctx context.Context, name string, dryRun bool, lastEventID int64) error {
+	if dryRun || lastEventID == 0 {
+		return nil
+	}
+	return r.store.SaveCheckpoint(ctx, name, lastEventID)
+}
@@ -0,0 +1,40 @@
+package events
+
+import (
+	"gorm.io/gorm"
+
+	repo "workout-app/internal/repository/interfaces"
+	"workout-app/pkg/logger"
+	"workout-app/pkg/mailer"
+)
+
+// Dependencies — зависимости подписчиков по умолчанию.
+type Dependencies struct {
+	DB     *gorm.DB
+	Users  repo.UserRepository
+	Email  mailer.EmailSender // Отправитель транзакционных писем; в сервисе — очередь исходящих писем
+	Logger logger.Logger
+
+	// WebhookEndpoints и WebhookDeliveries — адреса и очередь уведомлений webhooks.
+	// WebhookEndpoints == nil (не задан WEBHOOK_SECRET_KEY) отключает подписчика webhooks.
+	WebhookEndpoints  repo.WebhookEndpointRepository
+	WebhookDeliveries repo.WebhookDeliveryRepository
+
+	// Notifications — уведомления в приложении; nil отключает подписчика уведомлений.
+	Notifications repo.NotificationRepository
+}
+
+// DefaultSubscribers возвращает подписчиков, которые получают события в работающем сервисе.
+// Этот же список использует команда cmd/replay, поэтому новый подписчик достаточно добавить здесь.
+func DefaultSubscribers(deps Dependencies) []Subscriber {
+	subscribers := []Subscriber{
+		NewAccountEmails(deps.Users, deps.Email),
+	}
+	if deps.WebhookEndpoints != nil {
+		subscribers = append(subscribers, NewWebhooks(deps.WebhookEndpoints, deps.WebhookDeliveries))
+	}
+	if deps.Notifications != nil {
+		subscribers = append(subscribers, NewNotifications(deps.Notifications, deps.Users, deps.Email))
+	}
+	return subscribers
+}
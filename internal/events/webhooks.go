@@ -0,0 +1,96 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	domain "workout-app/internal/domain/event"
+	"workout-app/internal/domain/webhook"
+	repo "workout-app/internal/repository/interfaces"
+	"workout-app/pkg/tracing"
+)
+
+// WebhooksSubscriberName — имя подписчика, ставящего в очередь уведомления webhooks.
+const WebhooksSubscriberName = "webhooks"
+
+// Webhooks ставит в очередь уведомления о событиях аккаунта для адресов, подписанных на событие.
+// Отправляет уведомления фоновый воркер (usecase webhook) с подписью и повторными попытками.
+// Повторная доставка события (cmd/replay) не создаёт второго уведомления тому же адресу,
+// а адрес, добавленный позже, получает уведомления о прошлых событиях.
+type Webhooks struct {
+	endpoints  repo.WebhookEndpointRepository
+	deliveries repo.WebhookDeliveryRepository
+	now        func() time.Time
+}
+
+// Убедимся на этапе компиляции, что структура реализует интерфейс.
+var _ Subscriber = (*Webhooks)(nil)
+
+// NewWebhooks создаёт подписчика.
+func NewWebhooks(endpoints repo.WebhookEndpointRepository, deliveries repo.WebhookDeliveryRepository) *Webhooks {
+	return &Webhooks{
+		endpoints:  endpoints,
+		deliveries: deliveries,
+		now:        func() time.Time { return time.Now().UTC() },
+	}
+}
+
+// Name возвращает имя подписчика.
+func (s *Webhooks) Name() string {
+	return WebhooksSubscriberName
+}
+
+// Types возвращает события, на которые можно подписать webhook.
+func (s *Webhooks) Types() []string {
+	return webhook.EventTypes
+}
+
+// webhookBody — тело запроса уведомления. Data — данные события из журнала без изменений.
+type webhookBody struct {
+	EventID    int64           `json:"event_id"`
+	Type       string          `json:"type"`
+	OccurredAt time.Time       `json:"occurred_at"`
+	Data       json.RawMessage `json:"data"`
+}
+
+// Handle ставит в очередь по уведомлению для каждого включённого адреса, подписанного на событие.
+func (s *Webhooks) Handle(ctx context.Context, ev domain.Event, dryRun bool) ([]string, error) {
+	endpoints, err := s.endpoints.ListSubscribed(ctx, ev.Type)
+	if err != nil {
+		return nil, err
+	}
+	if len(endpoints) == 0 {
+		return nil, nil
+	}
+
+	body, err := json.Marshal(webhookBody{
+		EventID:    ev.ID,
+		Type:       ev.Type,
+		OccurredAt: ev.OccurredAt.UTC(),
+		Data:       ev.Payload,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("encode %s webhook body: %w", ev.Type, err)
+	}
+
+	var changes []string
+	for _, e := range endpoints {
+		change := fmt.Sprintf("enqueue %s webhook for endpoint %s", ev.Type, e.ID)
+		if dryRun {
+			changes = append(changes, change)
+			continue
+		}
+		d := webhook.NewDelivery(e.ID, ev.ID, ev.Type, body, s.now())
+		d.TraceParent = tracing.Header(ctx)
+		created, err := s.deliveries.Enqueue(ctx, d)
+		if err != nil {
+			return changes, err
+		}
+		if created {
+			changes = append(changes, change)
+		}
+	}
+	return changes, nil
+}
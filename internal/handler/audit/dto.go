@@ -0,0 +1,28 @@
+package audit
+
+import (
+	"time"
+
+	domain "workout-app/internal/domain/audit"
+)
+
+// AuditLogResponse описывает запись журнала действий администраторов.
+type AuditLogResponse struct {
+	ID      string `json:"id"`
+	ActorID string `json:"actor_id"`
+	// Action — метод и маршрут, например "PATCH /api/v1/admin/users/:id/role".
+	Action   string `json:"action"`
+	TargetID string `json:"target_id,omitempty"`
+	Query    string `json:"query,omitempty"`
+	// Diff — изменённые поля цели: {"role": {"old": "user", "new": "coach"}}.
+	Diff      domain.Diff `json:"diff,omitempty" swaggertype:"object"`
+	Status    int         `json:"status"`
+	IP        string      `json:"ip,omitempty"`
+	CreatedAt time.Time   `json:"created_at"`
+}
+
+// AuditLogListResponse описывает страницу журнала и общее количество подходящих записей.
+type AuditLogListResponse struct {
+	Items []AuditLogResponse `json:"items"`
+	Total int64              `json:"total"`
+}
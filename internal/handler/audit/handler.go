@@ -0,0 +1,141 @@
+package audit
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"workout-app/internal/handler/response"
+	repo "workout-app/internal/repository/interfaces"
+	audituc "workout-app/internal/usecase/audit"
+	"workout-app/pkg/logger"
+)
+
+// Handler обрабатывает запросы к журналу действий администраторов.
+type Handler struct {
+	audit  audituc.Service
+	logger logger.Logger
+}
+
+// NewHandler создаёт новый AuditHandler.
+func NewHandler(audit audituc.Service, logger logger.Logger) *Handler {
+	return &Handler{
+		audit:  audit,
+		logger: logger,
+	}
+}
+
+// List godoc
+// @Summary      Журнал действий администраторов (админ)
+// @Description  Возвращает записи журнала, новые первыми: кто из администраторов, когда и с какого IP выполнил действие, над какой целью, с какими фильтрами и изменениями. В журнал попадает каждый запрос к /api/v1/admin, включая отклонённые (поле status).
+// @Tags         admin
+// @Security     BearerAuth
+// @Produce      json
+// @Param        actor_id   query     string  false  "ID администратора"
+// @Param        target_id  query     string  false  "Цель действия (ID пользователя, организации и т.п.)"
+// @Param        action     query     string  false  "Подстрока действия, например users/:id/role"
+// @Param        from       query     string  false  "Начало периода (RFC 3339)"
+// @Param        to         query     string  false  "Конец периода, не включая (RFC 3339)"
+// @Param        limit      query     int     false  "Размер страницы (по умолчанию 50, максимум 200)"
+// @Param        offset     query     int     false  "Смещение"
+// @Success      200        {object}  AuditLogListResponse
+// @Failure      400        {object}  response.ErrorBody
+// @Failure      401        {object}  response.ErrorBody
+// @Failure      403        {object}  response.ErrorBody
+// @Failure      500        {object}  response.ErrorBody
+// @Router       /api/v1/admin/audit-logs [get]
+func (h *Handler) List(c *gin.Context) {
+	filter := repo.AuditLogFilter{
+		TargetID: c.Query("target_id"),
+		Action:   c.Query("action"),
+	}
+	if raw := c.Query("actor_id"); raw != "" {
+		actorID, err := uuid.Parse(raw)
+		if err != nil {
+			response.Error(c, http.StatusBadRequest, "invalid_actor_id", "Некорректный ID администратора", nil)
+			return
+		}
+		filter.ActorID = &actorID
+	}
+	from, err1 := queryTime(c, "from")
+	to, err2 := queryTime(c, "to")
+	if err1 != nil || err2 != nil {
+		response.Error(c, http.StatusBadRequest, "invalid_period", "Параметры from и to должны быть в формате RFC 3339", nil)
+		return
+	}
+	filter.From, filter.To = from, to
+	limit, err1 := queryInt(c, "limit")
+	offset, err2 := queryInt(c, "offset")
+	if err1 != nil || err2 != nil {
+		response.Error(c, http.StatusBadRequest, "invalid_pagination", "Параметры limit и offset должны быть неотрицательными числами", nil)
+		return
+	}
+	filter.Limit, filter.Offset = limit, offset
+
+	entries, total, err := h.audit.List(c.Request.Context(), filter)
+	if err != nil {
+		h.respondError(c, "list_audit_logs", err)
+		return
+	}
+
+	resp := AuditLogListResponse{Items: make([]AuditLogResponse, 0, len(entries)), Total: total}
+	for _, e := range entries {
+		resp.Items = append(resp.Items, AuditLogResponse{
+			ID:        e.ID.String(),
+			ActorID:   e.ActorID.String(),
+			Action:    e.Action,
+			TargetID:  e.TargetID,
+			Query:     e.Query,
+			Diff:      e.Diff,
+			Status:    e.Status,
+			IP:        e.IP,
+			CreatedAt: e.At,
+		})
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// respondError маппит ошибки usecase в HTTP-ответы.
+func (h *Handler) respondError(c *gin.Context, op string, err error) {
+	switch {
+	case errors.Is(err, audituc.ErrInvalidPeriod):
+		response.Error(c, http.StatusBadRequest, "invalid_period", "Начало периода должно быть раньше конца", nil)
+	default:
+		h.logger.Error("internal_error_in_"+op, map[string]any{
+			"path":   c.Request.URL.Path,
+			"method": c.Request.Method,
+			"error":  err.Error(),
+		})
+		response.Error(c, http.StatusInternalServerError, "internal_error", "Внутренняя ошибка сервера", nil)
+	}
+}
+
+// queryInt читает неотрицательный целочисленный параметр запроса (0, если не задан).
+func queryInt(c *gin.Context, name string) (int, error) {
+	raw := c.Query(name)
+	if raw == "" {
+		return 0, nil
+	}
+	n, err := strconv.Atoi(raw)
+	if err != nil || n < 0 {
+		return 0, strconv.ErrSyntax
+	}
+	return n, nil
+}
+
+// queryTime читает параметр запроса в формате RFC 3339 (nil, если не задан).
+func queryTime(c *gin.Context, name string) (*time.Time, error) {
+	raw := c.Query(name)
+	if raw == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339, raw)
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
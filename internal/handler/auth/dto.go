@@ -3,10 +3,13 @@ package auth
 // RegisterRequest описывает тело запроса регистрации пользователя.
 // Контракт намеренно минимальный: только данные, необходимые для аутентификации.
 type RegisterRequest struct {
-	Email    string `json:"email" binding:"required,email"`
-	Password string `json:"password" binding:"required,min=8"`
+	Email string `json:"email" binding:"required,email"`
+	// Password проверяется политикой паролей (длина, классы символов, распространённые пароли).
+	Password string `json:"password" binding:"required"`
 	// Username должен состоять только из букв и цифр (без пробелов и спецсимволов).
 	Username string `json:"username" binding:"required,alphanum,min=3,max=32"`
+	// Language — язык писем (ru или en); по умолчанию письма приходят на английском.
+	Language string `json:"language,omitempty" binding:"omitempty,oneof=ru en"`
 }
 
 // RegisterResponse описывает ответ при успешной регистрации (отправке кода подтверждения).
@@ -17,6 +20,22 @@ type RegisterResponse struct {
 	Message  string `json:"message"`
 }
 
+// CheckUsernameRequest описывает параметры проверки username.
+// Правила совпадают с RegisterRequest, чтобы ответ соответствовал результату регистрации.
+type CheckUsernameRequest struct {
+	Username string `form:"username" binding:"required,alphanum,min=3,max=32"`
+}
+
+// CheckEmailRequest описывает параметры проверки email.
+type CheckEmailRequest struct {
+	Email string `form:"email" binding:"required,email"`
+}
+
+// AvailabilityResponse описывает ответ проверки, свободно ли значение для регистрации.
+type AvailabilityResponse struct {
+	Available bool `json:"available"`
+}
+
 // LoginRequest описывает тело запроса логина.
 type LoginRequest struct {
 	Email    string `json:"email" binding:"required,email"`
@@ -54,6 +73,34 @@ type LoginResponse struct {
 	Tokens   TokenPair `json:"tokens"`
 }
 
+// ChangePasswordRequest описывает тело запроса смены пароля.
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"current_password" binding:"required"`
+	// NewPassword проверяется политикой паролей, как при регистрации.
+	NewPassword string `json:"new_password" binding:"required"`
+	// Code — код подтверждения из email; обязателен для ролей, требующих подтверждения смены пароля.
+	Code string `json:"code,omitempty"`
+}
+
+// ChangePasswordPendingResponse описывает ответ, когда смена пароля ждёт подтверждения кодом из email.
+type ChangePasswordPendingResponse struct {
+	Message              string `json:"message"`
+	ConfirmationRequired bool   `json:"confirmation_required"`
+}
+
+// ResetPasswordRequest описывает тело запроса сброса пароля по коду из email.
+type ResetPasswordRequest struct {
+	Email string `json:"email" binding:"required,email"`
+	Code  string `json:"code" binding:"required,len=6"`
+	// NewPassword проверяется политикой паролей, как при регистрации.
+	NewPassword string `json:"new_password" binding:"required"`
+}
+
+// ResetPasswordResponse описывает ответ на успешный сброс пароля.
+type ResetPasswordResponse struct {
+	Message string `json:"message"`
+}
+
 // RefreshRequest описывает тело запроса обновления токенов.
 type RefreshRequest struct {
 	RefreshToken string `json:"refresh_token" binding:"required"`
@@ -7,20 +7,28 @@ import (
 
 	"github.com/gin-gonic/gin"
 
+	"workout-app/internal/handler/middleware"
 	"workout-app/internal/handler/response"
+	"workout-app/internal/handler/validation"
 	repo "workout-app/internal/repository/interfaces"
 	authuc "workout-app/internal/usecase/auth"
+	"workout-app/pkg/mailer"
+	"workout-app/pkg/usernamefilter"
 )
 
 // Handler обрабатывает HTTP-запросы, связанные с аутентификацией.
 type Handler struct {
-	auth authuc.Service
+	auth          authuc.Service
+	countryHeader string
 }
 
 // NewHandler создаёт новый AuthHandler.
-func NewHandler(authSvc authuc.Service) *Handler {
+// countryHeader — заголовок с кодом страны клиента, который проставляет CDN или балансировщик
+// (например, CF-IPCountry); пустая строка отключает определение страны при регистрации.
+func NewHandler(authSvc authuc.Service, countryHeader string) *Handler {
 	return &Handler{
-		auth: authSvc,
+		auth:          authSvc,
+		countryHeader: countryHeader,
 	}
 }
 
@@ -34,27 +42,45 @@ func NewHandler(authSvc authuc.Service) *Handler {
 // @Success      201      {object}  RegisterResponse
 // @Failure      400      {object}  response.ErrorBody
 // @Failure      409      {object}  response.ErrorBody
+// @Failure      429      {object}  response.ErrorBody
+// @Failure      422      {object}  response.ErrorBody
 // @Failure      500      {object}  response.ErrorBody
 // @Router       /api/v1/auth/register [post]
 func (h *Handler) Register(c *gin.Context) {
 	var req RegisterRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.Error(c, http.StatusBadRequest, "invalid_request", "Invalid request body", err.Error())
+		response.Error(c, http.StatusBadRequest, "invalid_request", "Invalid request body", validation.Details(c, err))
 		return
 	}
 
-	user, err := h.auth.Register(c.Request.Context(), req.Email, req.Password, req.Username)
+	var country string
+	if h.countryHeader != "" {
+		country = c.GetHeader(h.countryHeader)
+	}
+
+	user, err := h.auth.Register(c.Request.Context(), req.Email, req.Password, req.Username, country, req.Language)
 	if err != nil {
 		switch {
+		case errors.Is(err, authuc.ErrWeakPassword):
+			response.Error(c, http.StatusBadRequest, "weak_password", "Password does not meet the password policy", validation.Password(c, "password", err))
 		case errors.Is(err, authuc.ErrEmailUnverifiedExists):
 			log.Printf("unverified email conflict in Register: email=%s err=%v", req.Email, err)
 			response.Error(c, http.StatusConflict, "email_unverified", "Account with this email already exists but is not verified. Please request a new verification code.", nil)
 		case errors.Is(err, repo.ErrEmailExists):
 			log.Printf("email conflict in Register: email=%s err=%v", req.Email, err)
 			response.Error(c, http.StatusConflict, "email_already_exists", "Email is already in use", nil)
+		case errors.Is(err, usernamefilter.ErrNotAllowed):
+			log.Printf("blocked username in Register: username=%s err=%v", req.Username, err)
+			response.Error(c, http.StatusUnprocessableEntity, "username_not_allowed", "This username is not allowed", validation.Username(c, "username", err))
 		case errors.Is(err, repo.ErrUsernameExists):
 			log.Printf("username conflict in Register: username=%s err=%v", req.Username, err)
 			response.Error(c, http.StatusConflict, "username_already_exists", "Username is already in use", nil)
+		case errors.Is(err, mailer.ErrRecipientUndeliverable):
+			log.Printf("undeliverable email in Register: email=%s", req.Email)
+			response.Error(c, http.StatusUnprocessableEntity, "email_undeliverable", "Emails to this address cannot be delivered. Please use another email.", nil)
+		case errors.Is(err, mailer.ErrSendRateLimited):
+			log.Printf("email rate limited in Register: email=%s", req.Email)
+			response.Error(c, http.StatusTooManyRequests, "email_rate_limited", "Too many emails sent. Please try again later.", nil)
 		default:
 			log.Printf("internal error in Register: email=%s username=%s err=%v", req.Email, req.Username, err)
 			response.Error(c, http.StatusInternalServerError, "internal_error", "Internal server error", nil)
@@ -72,6 +98,62 @@ func (h *Handler) Register(c *gin.Context) {
 	c.JSON(http.StatusCreated, resp)
 }
 
+// CheckUsername godoc
+// @Summary      Проверка, свободен ли username
+// @Description  Позволяет форме регистрации проверить username до отправки. Ответ не раскрывает, подтверждён ли аккаунт.
+// @Tags         auth
+// @Produce      json
+// @Param        username  query     string  true  "Username"
+// @Success      200       {object}  AvailabilityResponse
+// @Failure      400       {object}  response.ErrorBody
+// @Failure      429       {object}  response.ErrorBody
+// @Failure      500       {object}  response.ErrorBody
+// @Router       /api/v1/auth/check-username [get]
+func (h *Handler) CheckUsername(c *gin.Context) {
+	var req CheckUsernameRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid_request", "Invalid username", validation.Details(c, err))
+		return
+	}
+
+	available, err := h.auth.IsUsernameAvailable(c.Request.Context(), req.Username)
+	if err != nil {
+		log.Printf("internal error in CheckUsername: username=%s err=%v", req.Username, err)
+		response.Error(c, http.StatusInternalServerError, "internal_error", "Internal server error", nil)
+		return
+	}
+	c.Header("Cache-Control", "no-store")
+	c.JSON(http.StatusOK, AvailabilityResponse{Available: available})
+}
+
+// CheckEmail godoc
+// @Summary      Проверка, свободен ли email
+// @Description  Позволяет форме регистрации проверить email до отправки. Email занят и подтверждённым, и неподтверждённым аккаунтом — эти случаи не различаются.
+// @Tags         auth
+// @Produce      json
+// @Param        email  query     string  true  "Email"
+// @Success      200    {object}  AvailabilityResponse
+// @Failure      400    {object}  response.ErrorBody
+// @Failure      429    {object}  response.ErrorBody
+// @Failure      500    {object}  response.ErrorBody
+// @Router       /api/v1/auth/check-email [get]
+func (h *Handler) CheckEmail(c *gin.Context) {
+	var req CheckEmailRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid_request", "Invalid email", validation.Details(c, err))
+		return
+	}
+
+	available, err := h.auth.IsEmailAvailable(c.Request.Context(), req.Email)
+	if err != nil {
+		log.Printf("internal error in CheckEmail: email=%s err=%v", req.Email, err)
+		response.Error(c, http.StatusInternalServerError, "internal_error", "Internal server error", nil)
+		return
+	}
+	c.Header("Cache-Control", "no-store")
+	c.JSON(http.StatusOK, AvailabilityResponse{Available: available})
+}
+
 // Login godoc
 // @Summary      Вход по email и паролю
 // @Description  Аутентификация пользователя. Возвращает пару access/refresh токенов.
@@ -82,12 +164,13 @@ func (h *Handler) Register(c *gin.Context) {
 // @Success      200      {object}  LoginResponse
 // @Failure      400      {object}  response.ErrorBody
 // @Failure      401      {object}  response.ErrorBody
+// @Failure      429      {object}  response.ErrorBody
 // @Failure      500      {object}  response.ErrorBody
 // @Router       /api/v1/auth/login [post]
 func (h *Handler) Login(c *gin.Context) {
 	var req LoginRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.Error(c, http.StatusBadRequest, "invalid_request", "Invalid request body", err.Error())
+		response.Error(c, http.StatusBadRequest, "invalid_request", "Invalid request body", validation.Details(c, err))
 		return
 	}
 
@@ -98,6 +181,8 @@ func (h *Handler) Login(c *gin.Context) {
 			response.Error(c, http.StatusUnauthorized, "invalid_credentials", "Invalid email or password", nil)
 		case errors.Is(err, authuc.ErrEmailNotVerified):
 			response.Error(c, http.StatusForbidden, "email_not_verified", "Email is not verified", nil)
+		case errors.Is(err, authuc.ErrAccountSuspended):
+			response.Error(c, http.StatusForbidden, "account_suspended", "Account is suspended", nil)
 		default:
 			log.Printf("internal error in Login: email=%s err=%v", req.Email, err)
 			response.Error(c, http.StatusInternalServerError, "internal_error", "Internal server error", nil)
@@ -133,7 +218,7 @@ func (h *Handler) Login(c *gin.Context) {
 func (h *Handler) Refresh(c *gin.Context) {
 	var req RefreshRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.Error(c, http.StatusBadRequest, "invalid_request", "Invalid request body", err.Error())
+		response.Error(c, http.StatusBadRequest, "invalid_request", "Invalid request body", validation.Details(c, err))
 		return
 	}
 
@@ -144,6 +229,8 @@ func (h *Handler) Refresh(c *gin.Context) {
 			response.Error(c, http.StatusUnauthorized, "invalid_refresh_token", "Invalid refresh token", nil)
 		case errors.Is(err, authuc.ErrEmailNotVerified):
 			response.Error(c, http.StatusForbidden, "email_not_verified", "Email is not verified", nil)
+		case errors.Is(err, authuc.ErrAccountSuspended):
+			response.Error(c, http.StatusForbidden, "account_suspended", "Account is suspended", nil)
 		default:
 			log.Printf("internal error in Refresh: err=%v", err)
 			response.Error(c, http.StatusInternalServerError, "internal_error", "Internal server error", nil)
@@ -173,12 +260,14 @@ func (h *Handler) Refresh(c *gin.Context) {
 // @Param        payload  body      ResendVerificationRequest  true  "Email для повторной отправки кода"
 // @Success      200      {object}  ResendVerificationResponse
 // @Failure      400      {object}  response.ErrorBody
+// @Failure      429      {object}  response.ErrorBody
+// @Failure      422      {object}  response.ErrorBody
 // @Failure      500      {object}  response.ErrorBody
 // @Router       /api/v1/auth/resend-verification [post]
 func (h *Handler) ResendVerification(c *gin.Context) {
 	var req ResendVerificationRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.Error(c, http.StatusBadRequest, "invalid_request", "Invalid request body", err.Error())
+		response.Error(c, http.StatusBadRequest, "invalid_request", "Invalid request body", validation.Details(c, err))
 		return
 	}
 
@@ -191,6 +280,12 @@ func (h *Handler) ResendVerification(c *gin.Context) {
 				Message: "Email is already verified",
 			})
 			return
+		case errors.Is(err, mailer.ErrRecipientUndeliverable):
+			response.Error(c, http.StatusUnprocessableEntity, "email_undeliverable", "Emails to this address cannot be delivered", nil)
+			return
+		case errors.Is(err, mailer.ErrSendRateLimited):
+			response.Error(c, http.StatusTooManyRequests, "email_rate_limited", "Too many emails sent. Please try again later.", nil)
+			return
 		default:
 			log.Printf("internal error in ResendVerification: email=%s err=%v", req.Email, err)
 			response.Error(c, http.StatusInternalServerError, "internal_error", "Internal server error", nil)
@@ -214,12 +309,13 @@ func (h *Handler) ResendVerification(c *gin.Context) {
 // @Failure      400      {object}  response.ErrorBody
 // @Failure      401      {object}  response.ErrorBody
 // @Failure      403      {object}  response.ErrorBody
+// @Failure      429      {object}  response.ErrorBody
 // @Failure      500      {object}  response.ErrorBody
 // @Router       /api/v1/auth/verify-email [post]
 func (h *Handler) VerifyEmail(c *gin.Context) {
 	var req VerifyEmailRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.Error(c, http.StatusBadRequest, "invalid_request", "Invalid request body", err.Error())
+		response.Error(c, http.StatusBadRequest, "invalid_request", "Invalid request body", validation.Details(c, err))
 		return
 	}
 
@@ -253,3 +349,121 @@ func (h *Handler) VerifyEmail(c *gin.Context) {
 
 	c.JSON(http.StatusOK, resp)
 }
+
+// ResetPassword godoc
+// @Summary      Сброс пароля по коду
+// @Description  Устанавливает новый пароль по коду сброса, который администратор отправил на email пользователя. Все ранее выданные токены отзываются; после сброса нужно войти заново.
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        payload  body      ResetPasswordRequest  true  "Email, код сброса и новый пароль"
+// @Success      200      {object}  ResetPasswordResponse
+// @Failure      400      {object}  response.ErrorBody
+// @Failure      429      {object}  response.ErrorBody
+// @Failure      500      {object}  response.ErrorBody
+// @Router       /api/v1/auth/reset-password [post]
+func (h *Handler) ResetPassword(c *gin.Context) {
+	var req ResetPasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid_request", "Invalid request body", validation.Details(c, err))
+		return
+	}
+
+	if err := h.auth.ResetPassword(c.Request.Context(), req.Email, req.Code, req.NewPassword); err != nil {
+		switch {
+		case errors.Is(err, authuc.ErrVerificationCodeNotFound):
+			response.Error(c, http.StatusBadRequest, "verification_code_not_found", "Reset code not found or expired. Please contact support for a new code.", nil)
+		case errors.Is(err, authuc.ErrVerificationCodeInvalid):
+			response.Error(c, http.StatusBadRequest, "verification_code_invalid", "Verification code is invalid", nil)
+		case errors.Is(err, authuc.ErrVerificationAttemptsExceeded):
+			response.Error(c, http.StatusBadRequest, "verification_attempts_exceeded", "Verification attempts limit exceeded. Please contact support for a new code.", nil)
+		case errors.Is(err, authuc.ErrWeakPassword):
+			response.Error(c, http.StatusBadRequest, "weak_password", "New password does not meet the password policy", validation.Password(c, "new_password", err))
+		default:
+			log.Printf("internal error in ResetPassword: email=%s err=%v", req.Email, err)
+			response.Error(c, http.StatusInternalServerError, "internal_error", "Internal server error", nil)
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, ResetPasswordResponse{
+		Message: "Password has been reset. Please log in with the new password.",
+	})
+}
+
+// ChangePassword godoc
+// @Summary      Смена пароля
+// @Description  Меняет пароль текущего пользователя после проверки текущего пароля. Все ранее выданные refresh-токены отзываются; в ответе — новая пара токенов для текущей сессии.
+// @Description  Для ролей из EMAIL_PASSWORD_CHANGE_CONFIRM_ROLES запрос без code отправляет код на email и возвращает 202; пароль меняется повторным запросом с кодом.
+// @Tags         auth
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        payload  body      ChangePasswordRequest  true  "Текущий и новый пароль"
+// @Success      200      {object}  LoginResponse
+// @Success      202      {object}  ChangePasswordPendingResponse
+// @Failure      400      {object}  response.ErrorBody
+// @Failure      401      {object}  response.ErrorBody
+// @Failure      422      {object}  response.ErrorBody
+// @Failure      429      {object}  response.ErrorBody
+// @Failure      500      {object}  response.ErrorBody
+// @Router       /api/v1/users/me/change-password [post]
+func (h *Handler) ChangePassword(c *gin.Context) {
+	userID, err := middleware.UserIDFromContext(c)
+	if err != nil {
+		response.Error(c, http.StatusUnauthorized, "unauthorized", "Authentication required", nil)
+		return
+	}
+
+	var req ChangePasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid_request", "Invalid request body", validation.Details(c, err))
+		return
+	}
+
+	user, access, refresh, err := h.auth.ChangePassword(c.Request.Context(), userID, req.CurrentPassword, req.NewPassword, req.Code)
+	if err != nil {
+		switch {
+		case errors.Is(err, authuc.ErrPasswordChangeConfirmationRequired):
+			log.Printf("password change confirmation code sent: user_id=%s", userID)
+			c.JSON(http.StatusAccepted, ChangePasswordPendingResponse{
+				Message:              "Confirmation code sent to your email. Repeat the request with the code to change the password.",
+				ConfirmationRequired: true,
+			})
+		case errors.Is(err, authuc.ErrVerificationCodeNotFound):
+			response.Error(c, http.StatusBadRequest, "verification_code_not_found", "Verification code not found or expired. Please request a new verification code.", nil)
+		case errors.Is(err, authuc.ErrVerificationCodeInvalid):
+			response.Error(c, http.StatusBadRequest, "verification_code_invalid", "Verification code is invalid", nil)
+		case errors.Is(err, authuc.ErrVerificationAttemptsExceeded):
+			response.Error(c, http.StatusBadRequest, "verification_attempts_exceeded", "Verification attempts limit exceeded. Please request a new code.", nil)
+		case errors.Is(err, authuc.ErrInvalidCurrentPassword):
+			response.Error(c, http.StatusBadRequest, "invalid_current_password", "Current password is incorrect", nil)
+		case errors.Is(err, authuc.ErrSamePassword):
+			response.Error(c, http.StatusBadRequest, "password_same_as_current", "New password must differ from the current one", nil)
+		case errors.Is(err, authuc.ErrWeakPassword):
+			response.Error(c, http.StatusBadRequest, "weak_password", "New password does not meet the password policy", validation.Password(c, "new_password", err))
+		case errors.Is(err, repo.ErrNotFound):
+			response.Error(c, http.StatusUnauthorized, "unauthorized", "Authentication required", nil)
+		case errors.Is(err, mailer.ErrRecipientUndeliverable):
+			response.Error(c, http.StatusUnprocessableEntity, "email_undeliverable", "Confirmation code cannot be delivered to your email", nil)
+		case errors.Is(err, mailer.ErrSendRateLimited):
+			response.Error(c, http.StatusTooManyRequests, "email_rate_limited", "Too many emails sent. Please try again later.", nil)
+		default:
+			log.Printf("internal error in ChangePassword: user_id=%s err=%v", userID, err)
+			response.Error(c, http.StatusInternalServerError, "internal_error", "Internal server error", nil)
+		}
+		return
+	}
+
+	log.Printf("password changed: user_id=%s", user.ID)
+
+	c.JSON(http.StatusOK, LoginResponse{
+		UserID:   user.ID.String(),
+		Email:    user.Email,
+		Username: user.Username,
+		Tokens: TokenPair{
+			AccessToken:  access,
+			RefreshToken: refresh,
+		},
+	})
+}
@@ -0,0 +1,6 @@
+package avatar
+
+// AvatarResponse описывает результат загрузки аватара.
+type AvatarResponse struct {
+	AvatarURL string `json:"avatar_url"`
+}
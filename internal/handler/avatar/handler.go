@@ -0,0 +1,100 @@
+package avatar
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"workout-app/internal/handler/middleware"
+	"workout-app/internal/handler/response"
+	repo "workout-app/internal/repository/interfaces"
+	avataruc "workout-app/internal/usecase/avatar"
+	"workout-app/pkg/logger"
+)
+
+// multipartOverhead — запас на заголовки multipart сверх максимального размера файла.
+const multipartOverhead = 64 << 10
+
+// Handler обрабатывает загрузку аватаров пользователей.
+type Handler struct {
+	avatars  avataruc.Service
+	maxBytes int64
+	logger   logger.Logger
+}
+
+// NewHandler создаёт новый AvatarHandler. maxBytes — максимальный размер файла аватара.
+func NewHandler(avatars avataruc.Service, maxBytes int64, logger logger.Logger) *Handler {
+	return &Handler{
+		avatars:  avatars,
+		maxBytes: maxBytes,
+		logger:   logger,
+	}
+}
+
+// Upload godoc
+// @Summary      Загрузить аватар
+// @Description  Принимает изображение (JPEG, PNG, WebP, GIF) в поле file формы multipart/form-data, сохраняет его в хранилище и записывает ссылку в avatar_url профиля. Тип определяется по содержимому файла.
+// @Tags         users
+// @Security     BearerAuth
+// @Accept       multipart/form-data
+// @Produce      json
+// @Param        file  formData  file  true  "Изображение аватара"
+// @Success      200   {object}  AvatarResponse
+// @Failure      400   {object}  response.ErrorBody
+// @Failure      401   {object}  response.ErrorBody
+// @Failure      413   {object}  response.ErrorBody
+// @Failure      415   {object}  response.ErrorBody
+// @Failure      500   {object}  response.ErrorBody
+// @Router       /api/v1/users/me/avatar [post]
+func (h *Handler) Upload(c *gin.Context) {
+	userID, err := middleware.UserIDFromContext(c)
+	if err != nil {
+		response.Error(c, http.StatusUnauthorized, "unauthorized", "Требуется аутентификация", nil)
+		return
+	}
+
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, h.maxBytes+multipartOverhead)
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		var maxErr *http.MaxBytesError
+		if errors.As(err, &maxErr) {
+			response.Error(c, http.StatusRequestEntityTooLarge, "avatar_too_large", "Файл аватара слишком большой", gin.H{"max_bytes": h.maxBytes})
+			return
+		}
+		response.Error(c, http.StatusBadRequest, "invalid_request", "Ожидается файл в поле file формы multipart/form-data", nil)
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid_request", "Не удалось прочитать файл", nil)
+		return
+	}
+	defer file.Close()
+
+	user, err := h.avatars.Upload(c.Request.Context(), userID, file, fileHeader.Size)
+	if err != nil {
+		switch {
+		case errors.Is(err, avataruc.ErrFileTooLarge):
+			response.Error(c, http.StatusRequestEntityTooLarge, "avatar_too_large", "Файл аватара слишком большой", gin.H{"max_bytes": h.maxBytes})
+		case errors.Is(err, avataruc.ErrEmptyFile):
+			response.Error(c, http.StatusBadRequest, "avatar_empty", "Файл аватара пуст", nil)
+		case errors.Is(err, avataruc.ErrUnsupportedFormat):
+			response.Error(c, http.StatusUnsupportedMediaType, "avatar_unsupported_format", "Поддерживаются только изображения JPEG, PNG, WebP и GIF", nil)
+		case errors.Is(err, repo.ErrNotFound):
+			response.Error(c, http.StatusUnauthorized, "unauthorized", "Требуется аутентификация", nil)
+		default:
+			h.logger.Error("internal_error_in_upload_avatar", map[string]any{
+				"user_id": userID.String(),
+				"path":    c.Request.URL.Path,
+				"method":  c.Request.Method,
+				"error":   err.Error(),
+			})
+			response.Error(c, http.StatusInternalServerError, "internal_error", "Внутренняя ошибка сервера", nil)
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, AvatarResponse{AvatarURL: user.AvatarURL})
+}
@@ -0,0 +1,34 @@
+package backfill
+
+import "time"
+
+// LaunchBackfillRequest описывает тело запроса для запуска задачи пересчёта.
+type LaunchBackfillRequest struct {
+	Job       string `json:"job" binding:"required"`
+	BatchSize int    `json:"batch_size,omitempty" binding:"omitempty,min=1,max=10000"`
+}
+
+// BackfillResponse описывает задачу пересчёта и её прогресс.
+type BackfillResponse struct {
+	ID              string     `json:"id"`
+	Job             string     `json:"job"`
+	Status          string     `json:"status"`
+	BatchSize       int        `json:"batch_size"`
+	Cursor          string     `json:"cursor"`
+	Processed       int64      `json:"processed"`
+	Total           int64      `json:"total"`
+	ProgressPercent float64    `json:"progress_percent"`
+	LastError       string     `json:"last_error,omitempty"`
+	CancelRequested bool       `json:"cancel_requested"`
+	CreatedBy       string     `json:"created_by"`
+	StartedAt       *time.Time `json:"started_at,omitempty"`
+	FinishedAt      *time.Time `json:"finished_at,omitempty"`
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+}
+
+// BackfillListResponse описывает список задач и доступные типы задач.
+type BackfillListResponse struct {
+	Jobs      []string           `json:"jobs"`
+	Backfills []BackfillResponse `json:"backfills"`
+}
@@ -0,0 +1,221 @@
+package backfill
+
+import (
+	"errors"
+	"math"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	domain "workout-app/internal/domain/backfill"
+	"workout-app/internal/handler/middleware"
+	"workout-app/internal/handler/response"
+	repo "workout-app/internal/repository/interfaces"
+	backfilluc "workout-app/internal/usecase/backfill"
+	"workout-app/pkg/logger"
+)
+
+// Handler обрабатывает административные запросы к задачам пересчёта.
+type Handler struct {
+	backfills backfilluc.Service
+	logger    logger.Logger
+}
+
+// NewHandler создаёт новый BackfillHandler.
+func NewHandler(backfills backfilluc.Service, logger logger.Logger) *Handler {
+	return &Handler{
+		backfills: backfills,
+		logger:    logger,
+	}
+}
+
+// List godoc
+// @Summary      Список задач пересчёта (админ)
+// @Description  Возвращает последние задачи пересчёта с прогрессом и имена доступных типов задач.
+// @Tags         admin
+// @Security     BearerAuth
+// @Produce      json
+// @Success      200  {object}  BackfillListResponse
+// @Failure      401  {object}  response.ErrorBody
+// @Failure      403  {object}  response.ErrorBody
+// @Failure      500  {object}  response.ErrorBody
+// @Router       /api/v1/admin/backfills [get]
+func (h *Handler) List(c *gin.Context) {
+	backfills, err := h.backfills.List(c.Request.Context())
+	if err != nil {
+		h.internalError(c, "list_backfills", err)
+		return
+	}
+
+	resp := BackfillListResponse{
+		Jobs:      h.backfills.Jobs(),
+		Backfills: make([]BackfillResponse, 0, len(backfills)),
+	}
+	for _, b := range backfills {
+		resp.Backfills = append(resp.Backfills, toBackfillResponse(b))
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// Launch godoc
+// @Summary      Запустить задачу пересчёта (админ)
+// @Description  Создаёт задачу пересчёта исторических данных. Задача выполняется в фоне пачками и продолжается после рестарта.
+// @Tags         admin
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        payload  body      LaunchBackfillRequest  true  "Тип задачи и размер пачки"
+// @Success      202      {object}  BackfillResponse
+// @Failure      400      {object}  response.ErrorBody
+// @Failure      401      {object}  response.ErrorBody
+// @Failure      403      {object}  response.ErrorBody
+// @Failure      409      {object}  response.ErrorBody
+// @Failure      500      {object}  response.ErrorBody
+// @Router       /api/v1/admin/backfills [post]
+func (h *Handler) Launch(c *gin.Context) {
+	actorID, err := middleware.UserIDFromContext(c)
+	if err != nil {
+		response.Error(c, http.StatusUnauthorized, "unauthorized", "Требуется аутентификация", nil)
+		return
+	}
+
+	var req LaunchBackfillRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid_request", "Некорректное тело запроса", err.Error())
+		return
+	}
+
+	b, err := h.backfills.Launch(c.Request.Context(), req.Job, req.BatchSize, actorID)
+	if err != nil {
+		switch {
+		case errors.Is(err, backfilluc.ErrUnknownJob):
+			response.Error(c, http.StatusBadRequest, "unknown_job", "Неизвестный тип задачи", h.backfills.Jobs())
+		case errors.Is(err, backfilluc.ErrInvalidBatchSize):
+			response.Error(c, http.StatusBadRequest, "invalid_batch_size", "Размер пачки должен быть от 1 до 10000", nil)
+		case errors.Is(err, repo.ErrBackfillActive):
+			response.Error(c, http.StatusConflict, "backfill_already_running", "Задача этого типа уже выполняется", nil)
+		default:
+			h.internalError(c, "launch_backfill", err)
+		}
+		return
+	}
+
+	h.logger.Info("backfill_launched", map[string]any{
+		"backfill_id": b.ID.String(),
+		"job":         b.Job,
+		"batch_size":  b.BatchSize,
+		"total":       b.Total,
+		"actor_id":    actorID.String(),
+	})
+	c.JSON(http.StatusAccepted, toBackfillResponse(b))
+}
+
+// Get godoc
+// @Summary      Получить задачу пересчёта (админ)
+// @Description  Возвращает состояние и прогресс задачи пересчёта.
+// @Tags         admin
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id   path      string  true  "ID задачи"
+// @Success      200  {object}  BackfillResponse
+// @Failure      400  {object}  response.ErrorBody
+// @Failure      401  {object}  response.ErrorBody
+// @Failure      403  {object}  response.ErrorBody
+// @Failure      404  {object}  response.ErrorBody
+// @Failure      500  {object}  response.ErrorBody
+// @Router       /api/v1/admin/backfills/{id} [get]
+func (h *Handler) Get(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid_backfill_id", "Некорректный ID задачи", nil)
+		return
+	}
+
+	b, err := h.backfills.Get(c.Request.Context(), id)
+	if err != nil {
+		if errors.Is(err, repo.ErrNotFound) {
+			response.Error(c, http.StatusNotFound, "backfill_not_found", "Задача не найдена", nil)
+			return
+		}
+		h.internalError(c, "get_backfill", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toBackfillResponse(b))
+}
+
+// Cancel godoc
+// @Summary      Отменить задачу пересчёта (админ)
+// @Description  Запрашивает отмену задачи; выполняющаяся задача остановится после текущей пачки.
+// @Tags         admin
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id   path  string  true  "ID задачи"
+// @Success      202
+// @Failure      400  {object}  response.ErrorBody
+// @Failure      401  {object}  response.ErrorBody
+// @Failure      403  {object}  response.ErrorBody
+// @Failure      404  {object}  response.ErrorBody
+// @Failure      500  {object}  response.ErrorBody
+// @Router       /api/v1/admin/backfills/{id}/cancel [post]
+func (h *Handler) Cancel(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid_backfill_id", "Некорректный ID задачи", nil)
+		return
+	}
+
+	if err := h.backfills.Cancel(c.Request.Context(), id); err != nil {
+		if errors.Is(err, repo.ErrNotFound) {
+			response.Error(c, http.StatusNotFound, "backfill_not_found", "Незавершённая задача не найдена", nil)
+			return
+		}
+		h.internalError(c, "cancel_backfill", err)
+		return
+	}
+
+	h.logger.Info("backfill_cancel_requested", map[string]any{
+		"backfill_id": id.String(),
+		"actor_id":    c.GetString(middleware.ContextUserIDKey),
+	})
+	c.Status(http.StatusAccepted)
+}
+
+func (h *Handler) internalError(c *gin.Context, op string, err error) {
+	h.logger.Error("internal_error_in_"+op, map[string]any{
+		"path":   c.Request.URL.Path,
+		"method": c.Request.Method,
+		"error":  err.Error(),
+	})
+	response.Error(c, http.StatusInternalServerError, "internal_error", "Внутренняя ошибка сервера", nil)
+}
+
+// toBackfillResponse маппит доменную модель в DTO.
+func toBackfillResponse(b *domain.Backfill) BackfillResponse {
+	progress := 0.0
+	switch {
+	case b.Status == domain.StatusCompleted:
+		progress = 100
+	case b.Total > 0:
+		progress = math.Min(100, math.Round(float64(b.Processed)/float64(b.Total)*1000)/10)
+	}
+
+	return BackfillResponse{
+		ID:              b.ID.String(),
+		Job:             b.Job,
+		Status:          string(b.Status),
+		BatchSize:       b.BatchSize,
+		Cursor:          b.Cursor,
+		Processed:       b.Processed,
+		Total:           b.Total,
+		ProgressPercent: progress,
+		LastError:       b.LastError,
+		CancelRequested: b.CancelRequested,
+		CreatedBy:       b.CreatedBy.String(),
+		StartedAt:       b.StartedAt,
+		FinishedAt:      b.FinishedAt,
+		CreatedAt:       b.CreatedAt,
+		UpdatedAt:       b.UpdatedAt,
+	}
+}
@@ -0,0 +1,20 @@
+package backup
+
+import "time"
+
+// BackupResponse описывает сохранённую версию резервной копии.
+type BackupResponse struct {
+	Version   int       `json:"version" example:"3"`
+	SizeBytes int64     `json:"size_bytes"`
+	SHA256    string    `json:"sha256" example:"9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// BackupOverviewResponse описывает версии резервной копии пользователя и ограничения хранения.
+type BackupOverviewResponse struct {
+	Items       []BackupResponse `json:"items"` // Новые первыми
+	UsedBytes   int64            `json:"used_bytes"`
+	QuotaBytes  int64            `json:"quota_bytes"`
+	MaxBytes    int64            `json:"max_bytes"`    // Максимальный размер одной версии
+	MaxVersions int              `json:"max_versions"` // Сколько последних версий хранится
+}
@@ -0,0 +1,245 @@
+package backup
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	domain "workout-app/internal/domain/backup"
+	"workout-app/internal/handler/middleware"
+	"workout-app/internal/handler/response"
+	backupuc "workout-app/internal/usecase/backup"
+	"workout-app/pkg/logger"
+)
+
+// Заголовки запросов и ответов с метаданными резервной копии.
+const (
+	HeaderChecksum    = "X-Backup-SHA256"       // SHA-256 содержимого в hex
+	HeaderBaseVersion = "X-Backup-Base-Version" // Последняя версия, которую видел клиент
+	HeaderVersion     = "X-Backup-Version"      // Версия отдаваемой копии
+)
+
+// Handler обрабатывает загрузку и скачивание зашифрованных резервных копий.
+type Handler struct {
+	backups  backupuc.Service
+	maxBytes int64
+	logger   logger.Logger
+}
+
+// NewHandler создаёт новый BackupHandler. maxBytes — максимальный размер одной версии.
+func NewHandler(backups backupuc.Service, maxBytes int64, logger logger.Logger) *Handler {
+	return &Handler{
+		backups:  backups,
+		maxBytes: maxBytes,
+		logger:   logger,
+	}
+}
+
+// Upload godoc
+// @Summary      Загрузить зашифрованную резервную копию
+// @Description  Принимает зашифрованную на устройстве резервную копию локальных данных как тело запроса application/octet-stream. Заголовок X-Backup-SHA256 (hex) обязателен: сервер сверяет его с полученным содержимым. X-Backup-Base-Version — последняя версия, которую видел клиент (0 или отсутствие заголовка — копий ещё нет); если сохранена другая, возвращается 409 с текущей версией. Сервер хранит несколько последних версий в пределах квоты и удаляет старые.
+// @Tags         backup
+// @Security     BearerAuth
+// @Accept       octet-stream
+// @Produce      json
+// @Param        X-Backup-SHA256        header  string  true   "SHA-256 содержимого в hex"
+// @Param        X-Backup-Base-Version  header  int     false  "Последняя известная клиенту версия"
+// @Success      201  {object}  BackupResponse
+// @Failure      400  {object}  response.ErrorBody
+// @Failure      401  {object}  response.ErrorBody
+// @Failure      409  {object}  response.ErrorBody
+// @Failure      411  {object}  response.ErrorBody
+// @Failure      413  {object}  response.ErrorBody
+// @Failure      422  {object}  response.ErrorBody
+// @Failure      500  {object}  response.ErrorBody
+// @Router       /api/v1/users/me/backup [put]
+func (h *Handler) Upload(c *gin.Context) {
+	userID, err := middleware.UserIDFromContext(c)
+	if err != nil {
+		response.Error(c, http.StatusUnauthorized, "unauthorized", "Требуется аутентификация", nil)
+		return
+	}
+
+	if c.Request.ContentLength < 0 {
+		response.Error(c, http.StatusLengthRequired, "length_required", "Укажите размер резервной копии в заголовке Content-Length", nil)
+		return
+	}
+	baseVersion := 0
+	if raw := c.GetHeader(HeaderBaseVersion); raw != "" {
+		baseVersion, err = strconv.Atoi(raw)
+		if err != nil {
+			response.Error(c, http.StatusBadRequest, "invalid_base_version", "Некорректная базовая версия резервной копии", nil)
+			return
+		}
+	}
+
+	// Сервер не читает тело дальше Content-Length, поэтому проверки размера в usecase достаточно.
+	backup, err := h.backups.Upload(c.Request.Context(), userID, backupuc.UploadInput{
+		BaseVersion: baseVersion,
+		SHA256:      c.GetHeader(HeaderChecksum),
+		Body:        c.Request.Body,
+		Size:        c.Request.ContentLength,
+	})
+	if err != nil {
+		h.respondError(c, "upload_backup", err)
+		return
+	}
+
+	h.logger.Info("backup_uploaded", map[string]any{
+		"user_id": userID.String(),
+		"version": backup.Version,
+		"size":    backup.SizeBytes,
+	})
+	c.JSON(http.StatusCreated, toBackupResponse(backup))
+}
+
+// Overview godoc
+// @Summary      Версии резервной копии
+// @Description  Возвращает сохранённые версии зашифрованной резервной копии (новые первыми), занятое место и ограничения хранения.
+// @Tags         backup
+// @Security     BearerAuth
+// @Produce      json
+// @Success      200  {object}  BackupOverviewResponse
+// @Failure      401  {object}  response.ErrorBody
+// @Failure      500  {object}  response.ErrorBody
+// @Router       /api/v1/users/me/backup [get]
+func (h *Handler) Overview(c *gin.Context) {
+	userID, err := middleware.UserIDFromContext(c)
+	if err != nil {
+		response.Error(c, http.StatusUnauthorized, "unauthorized", "Требуется аутентификация", nil)
+		return
+	}
+
+	overview, err := h.backups.Overview(c.Request.Context(), userID)
+	if err != nil {
+		h.respondError(c, "backup_overview", err)
+		return
+	}
+
+	items := make([]BackupResponse, 0, len(overview.Backups))
+	for _, b := range overview.Backups {
+		items = append(items, toBackupResponse(b))
+	}
+	c.JSON(http.StatusOK, BackupOverviewResponse{
+		Items:       items,
+		UsedBytes:   overview.UsedBytes,
+		QuotaBytes:  overview.Config.QuotaBytes,
+		MaxBytes:    overview.Config.MaxBytes,
+		MaxVersions: overview.Config.MaxVersions,
+	})
+}
+
+// Download godoc
+// @Summary      Скачать резервную копию
+// @Description  Отдаёт зашифрованное содержимое версии резервной копии (по умолчанию последней). Заголовки X-Backup-Version и X-Backup-SHA256 описывают версию; клиент сверяет контрольную сумму перед расшифровкой. Для объектного хранилища отвечает редиректом 302 на временную ссылку.
+// @Tags         backup
+// @Security     BearerAuth
+// @Produce      octet-stream
+// @Param        version  query  int  false  "Версия; по умолчанию последняя"
+// @Success      200
+// @Success      302
+// @Failure      400  {object}  response.ErrorBody
+// @Failure      401  {object}  response.ErrorBody
+// @Failure      404  {object}  response.ErrorBody
+// @Failure      500  {object}  response.ErrorBody
+// @Router       /api/v1/users/me/backup/content [get]
+func (h *Handler) Download(c *gin.Context) {
+	userID, err := middleware.UserIDFromContext(c)
+	if err != nil {
+		response.Error(c, http.StatusUnauthorized, "unauthorized", "Требуется аутентификация", nil)
+		return
+	}
+
+	version := 0
+	if raw := c.Query("version"); raw != "" {
+		version, err = strconv.Atoi(raw)
+		if err != nil || version <= 0 {
+			response.Error(c, http.StatusBadRequest, "invalid_version", "Некорректная версия резервной копии", nil)
+			return
+		}
+	}
+
+	download, err := h.backups.Open(c.Request.Context(), userID, version)
+	if err != nil {
+		h.respondError(c, "download_backup", err)
+		return
+	}
+
+	c.Header("Cache-Control", "private, no-store")
+	c.Header(HeaderVersion, strconv.Itoa(download.Backup.Version))
+	c.Header(HeaderChecksum, download.Backup.SHA256)
+	if download.RedirectURL != "" {
+		c.Redirect(http.StatusFound, download.RedirectURL)
+		return
+	}
+
+	defer download.Object.Close()
+	c.Header("Content-Type", "application/octet-stream")
+	c.Header("Content-Disposition", `attachment; filename="backup-v`+strconv.Itoa(download.Backup.Version)+`.bin"`)
+	c.Header("ETag", `"`+download.Backup.SHA256+`"`)
+	http.ServeContent(c.Writer, c.Request, "", download.Backup.CreatedAt, download.Object)
+}
+
+// Delete godoc
+// @Summary      Удалить резервные копии
+// @Description  Удаляет все версии зашифрованной резервной копии текущего пользователя.
+// @Tags         backup
+// @Security     BearerAuth
+// @Success      204
+// @Failure      401  {object}  response.ErrorBody
+// @Failure      404  {object}  response.ErrorBody
+// @Failure      500  {object}  response.ErrorBody
+// @Router       /api/v1/users/me/backup [delete]
+func (h *Handler) Delete(c *gin.Context) {
+	userID, err := middleware.UserIDFromContext(c)
+	if err != nil {
+		response.Error(c, http.StatusUnauthorized, "unauthorized", "Требуется аутентификация", nil)
+		return
+	}
+
+	if err := h.backups.Delete(c.Request.Context(), userID); err != nil {
+		h.respondError(c, "delete_backup", err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// respondError маппит ошибки usecase-слоя в HTTP-ответы.
+func (h *Handler) respondError(c *gin.Context, op string, err error) {
+	var conflictErr *backupuc.VersionConflictError
+	switch {
+	case errors.Is(err, backupuc.ErrBackupTooLarge):
+		response.Error(c, http.StatusRequestEntityTooLarge, "backup_too_large", "Резервная копия слишком большая", gin.H{"max_bytes": h.maxBytes})
+	case errors.Is(err, backupuc.ErrEmptyBackup):
+		response.Error(c, http.StatusBadRequest, "backup_empty", "Резервная копия пуста", nil)
+	case errors.Is(err, backupuc.ErrInvalidChecksum):
+		response.Error(c, http.StatusBadRequest, "invalid_checksum", "Заголовок X-Backup-SHA256 должен содержать SHA-256 в hex", nil)
+	case errors.Is(err, backupuc.ErrInvalidBaseVersion):
+		response.Error(c, http.StatusBadRequest, "invalid_base_version", "Некорректная базовая версия резервной копии", nil)
+	case errors.Is(err, backupuc.ErrChecksumMismatch):
+		response.Error(c, http.StatusUnprocessableEntity, "checksum_mismatch", "Содержимое не совпадает с контрольной суммой; повторите загрузку", nil)
+	case errors.As(err, &conflictErr):
+		response.Error(c, http.StatusConflict, "backup_version_conflict", "Резервная копия сохранена с другой версией",
+			gin.H{"current_version": conflictErr.Current})
+	case errors.Is(err, backupuc.ErrBackupNotFound):
+		response.Error(c, http.StatusNotFound, "backup_not_found", "Резервная копия не найдена", nil)
+	default:
+		h.logger.Error("internal_error_in_"+op, map[string]any{
+			"path":   c.Request.URL.Path,
+			"method": c.Request.Method,
+			"error":  err.Error(),
+		})
+		response.Error(c, http.StatusInternalServerError, "internal_error", "Внутренняя ошибка сервера", nil)
+	}
+}
+
+func toBackupResponse(b *domain.Backup) BackupResponse {
+	return BackupResponse{
+		Version:   b.Version,
+		SizeBytes: b.SizeBytes,
+		SHA256:    b.SHA256,
+		CreatedAt: b.CreatedAt,
+	}
+}
@@ -0,0 +1,60 @@
+package challenge
+
+import "time"
+
+// CreateChallengeRequest описывает тело запроса для создания челленджа.
+type CreateChallengeRequest struct {
+	Title       string `json:"title" binding:"required,max=100" example:"30 тренировок за 30 дней"`
+	Description string `json:"description,omitempty" binding:"max=1000"`
+	// Metric — показатель прогресса: sessions (завершённые тренировки) или active_days (дни с тренировкой).
+	Metric string `json:"metric" binding:"required,oneof=sessions active_days" example:"sessions"`
+	Target int    `json:"target" binding:"required,min=1,max=1000" example:"30"`
+	// StartsAt — начало учёта тренировок; по умолчанию текущий момент.
+	StartsAt *time.Time `json:"starts_at,omitempty"`
+	EndsAt   time.Time  `json:"ends_at" binding:"required"`
+}
+
+// StandingResponse описывает место участника в таблице лидеров.
+type StandingResponse struct {
+	Rank      int       `json:"rank"`
+	UserID    string    `json:"user_id"`
+	Username  string    `json:"username"`
+	Value     int       `json:"value"`
+	Completed bool      `json:"completed"`
+	JoinedAt  time.Time `json:"joined_at"`
+}
+
+// ChallengeResponse описывает челлендж.
+type ChallengeResponse struct {
+	ID           string    `json:"id"`
+	CreatorID    string    `json:"creator_id"`
+	Title        string    `json:"title"`
+	Description  string    `json:"description,omitempty"`
+	Metric       string    `json:"metric" example:"sessions"`
+	Target       int       `json:"target"`
+	StartsAt     time.Time `json:"starts_at"`
+	EndsAt       time.Time `json:"ends_at"`
+	Status       string    `json:"status" example:"active"`
+	Participants int       `json:"participants"`
+	Joined       bool      `json:"joined"`
+	// Progress — место и прогресс текущего пользователя (только в ответе на GET /challenges/:id для участника).
+	Progress  *StandingResponse `json:"progress,omitempty"`
+	CreatedAt time.Time         `json:"created_at"`
+}
+
+// ChallengeListResponse описывает страницу челленджей.
+type ChallengeListResponse struct {
+	Items []ChallengeResponse `json:"items"`
+}
+
+// LeaderboardResponse описывает таблицу лидеров челленджа.
+type LeaderboardResponse struct {
+	ChallengeID  string             `json:"challenge_id"`
+	Metric       string             `json:"metric"`
+	Target       int                `json:"target"`
+	Status       string             `json:"status"`
+	Participants int                `json:"participants"`
+	Items        []StandingResponse `json:"items"`
+	// Me — место текущего пользователя, даже если он не попал в items; отсутствует, если он не участвует.
+	Me *StandingResponse `json:"me,omitempty"`
+}
@@ -0,0 +1,380 @@
+package challenge
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	domain "workout-app/internal/domain/challenge"
+	userdomain "workout-app/internal/domain/user"
+	"workout-app/internal/handler/middleware"
+	"workout-app/internal/handler/response"
+	challengeuc "workout-app/internal/usecase/challenge"
+	"workout-app/pkg/logger"
+)
+
+// Handler обрабатывает HTTP-запросы челленджей.
+type Handler struct {
+	challenges challengeuc.Service
+	logger     logger.Logger
+}
+
+// NewHandler создаёт новый ChallengeHandler.
+func NewHandler(challenges challengeuc.Service, logger logger.Logger) *Handler {
+	return &Handler{
+		challenges: challenges,
+		logger:     logger,
+	}
+}
+
+// Create godoc
+// @Summary      Создать челлендж
+// @Description  Создаёт челлендж, например «30 тренировок за 30 дней»; создатель сразу становится участником. Прогресс считается автоматически по завершённым тренировкам, начатым в период [starts_at, ends_at).
+// @Tags         challenges
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        payload  body      CreateChallengeRequest  true  "Челлендж"
+// @Success      201      {object}  ChallengeResponse
+// @Failure      400      {object}  response.ErrorBody
+// @Failure      401      {object}  response.ErrorBody
+// @Failure      500      {object}  response.ErrorBody
+// @Router       /api/v1/challenges [post]
+func (h *Handler) Create(c *gin.Context) {
+	userID, err := middleware.UserIDFromContext(c)
+	if err != nil {
+		response.Error(c, http.StatusUnauthorized, "unauthorized", "Требуется аутентификация", nil)
+		return
+	}
+	var req CreateChallengeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid_request", "Некорректное тело запроса", err.Error())
+		return
+	}
+	input := challengeuc.Input{
+		Title:       req.Title,
+		Description: req.Description,
+		Metric:      domain.Metric(req.Metric),
+		Target:      req.Target,
+		EndsAt:      req.EndsAt,
+	}
+	if req.StartsAt != nil {
+		input.StartsAt = *req.StartsAt
+	}
+
+	listing, err := h.challenges.Create(c.Request.Context(), userID, input)
+	if err != nil {
+		h.respondError(c, "create_challenge", err)
+		return
+	}
+	c.JSON(http.StatusCreated, toChallengeResponse(listing, nil))
+}
+
+// List godoc
+// @Summary      Челленджи
+// @Description  Возвращает незавершённые челленджи, ближайшие к завершению первыми. С scope=joined — челленджи текущего пользователя, включая завершённые.
+// @Tags         challenges
+// @Security     BearerAuth
+// @Produce      json
+// @Param        scope   query     string  false  "open (по умолчанию) или joined"
+// @Param        limit   query     int     false  "Размер страницы (по умолчанию 20, максимум 100)"
+// @Param        offset  query     int     false  "Смещение"
+// @Success      200     {object}  ChallengeListResponse
+// @Failure      400     {object}  response.ErrorBody
+// @Failure      401     {object}  response.ErrorBody
+// @Failure      500     {object}  response.ErrorBody
+// @Router       /api/v1/challenges [get]
+func (h *Handler) List(c *gin.Context) {
+	userID, err := middleware.UserIDFromContext(c)
+	if err != nil {
+		response.Error(c, http.StatusUnauthorized, "unauthorized", "Требуется аутентификация", nil)
+		return
+	}
+	scope := c.DefaultQuery("scope", "open")
+	if scope != "open" && scope != "joined" {
+		response.Error(c, http.StatusBadRequest, "invalid_scope", "Параметр scope должен быть open или joined", nil)
+		return
+	}
+	limit, err1 := queryInt(c, "limit")
+	offset, err2 := queryInt(c, "offset")
+	if err1 != nil || err2 != nil {
+		response.Error(c, http.StatusBadRequest, "invalid_pagination", "Параметры limit и offset должны быть неотрицательными числами", nil)
+		return
+	}
+
+	listings, err := h.challenges.List(c.Request.Context(), userID, scope == "joined", limit, offset)
+	if err != nil {
+		h.respondError(c, "list_challenges", err)
+		return
+	}
+	items := make([]ChallengeResponse, 0, len(listings))
+	for _, l := range listings {
+		items = append(items, toChallengeResponse(l, nil))
+	}
+	c.JSON(http.StatusOK, ChallengeListResponse{Items: items})
+}
+
+// Get godoc
+// @Summary      Челлендж
+// @Description  Возвращает челлендж с числом участников; для участника — его место и прогресс в поле progress.
+// @Tags         challenges
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id   path      string  true  "ID челленджа"
+// @Success      200  {object}  ChallengeResponse
+// @Failure      400  {object}  response.ErrorBody
+// @Failure      401  {object}  response.ErrorBody
+// @Failure      404  {object}  response.ErrorBody
+// @Failure      500  {object}  response.ErrorBody
+// @Router       /api/v1/challenges/{id} [get]
+func (h *Handler) Get(c *gin.Context) {
+	userID, err := middleware.UserIDFromContext(c)
+	if err != nil {
+		response.Error(c, http.StatusUnauthorized, "unauthorized", "Требуется аутентификация", nil)
+		return
+	}
+	id, ok := parseChallengeID(c)
+	if !ok {
+		return
+	}
+
+	details, err := h.challenges.Get(c.Request.Context(), userID, id)
+	if err != nil {
+		h.respondError(c, "get_challenge", err)
+		return
+	}
+	c.JSON(http.StatusOK, toChallengeResponse(details.Listing, details.Me))
+}
+
+// Join godoc
+// @Summary      Вступить в челлендж
+// @Description  Добавляет текущего пользователя в участники незавершённого челленджа. Повторное вступление не считается ошибкой. Тренировки, начатые в период челленджа до вступления, тоже учитываются.
+// @Tags         challenges
+// @Security     BearerAuth
+// @Param        id   path  string  true  "ID челленджа"
+// @Success      204
+// @Failure      400  {object}  response.ErrorBody
+// @Failure      401  {object}  response.ErrorBody
+// @Failure      404  {object}  response.ErrorBody
+// @Failure      409  {object}  response.ErrorBody
+// @Failure      500  {object}  response.ErrorBody
+// @Router       /api/v1/challenges/{id}/join [post]
+func (h *Handler) Join(c *gin.Context) {
+	userID, err := middleware.UserIDFromContext(c)
+	if err != nil {
+		response.Error(c, http.StatusUnauthorized, "unauthorized", "Требуется аутентификация", nil)
+		return
+	}
+	id, ok := parseChallengeID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.challenges.Join(c.Request.Context(), userID, id); err != nil {
+		h.respondError(c, "join_challenge", err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// Leave godoc
+// @Summary      Выйти из челленджа
+// @Description  Удаляет текущего пользователя из участников незавершённого челленджа. Выход без участия не считается ошибкой.
+// @Tags         challenges
+// @Security     BearerAuth
+// @Param        id   path  string  true  "ID челленджа"
+// @Success      204
+// @Failure      400  {object}  response.ErrorBody
+// @Failure      401  {object}  response.ErrorBody
+// @Failure      404  {object}  response.ErrorBody
+// @Failure      409  {object}  response.ErrorBody
+// @Failure      500  {object}  response.ErrorBody
+// @Router       /api/v1/challenges/{id}/join [delete]
+func (h *Handler) Leave(c *gin.Context) {
+	userID, err := middleware.UserIDFromContext(c)
+	if err != nil {
+		response.Error(c, http.StatusUnauthorized, "unauthorized", "Требуется аутентификация", nil)
+		return
+	}
+	id, ok := parseChallengeID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.challenges.Leave(c.Request.Context(), userID, id); err != nil {
+		h.respondError(c, "leave_challenge", err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// Delete godoc
+// @Summary      Удалить челлендж
+// @Description  Удаляет челлендж вместе с участниками. Доступно создателю и администраторам.
+// @Tags         challenges
+// @Security     BearerAuth
+// @Param        id   path  string  true  "ID челленджа"
+// @Success      204
+// @Failure      400  {object}  response.ErrorBody
+// @Failure      401  {object}  response.ErrorBody
+// @Failure      403  {object}  response.ErrorBody
+// @Failure      404  {object}  response.ErrorBody
+// @Failure      500  {object}  response.ErrorBody
+// @Router       /api/v1/challenges/{id} [delete]
+func (h *Handler) Delete(c *gin.Context) {
+	userID, err := middleware.UserIDFromContext(c)
+	if err != nil {
+		response.Error(c, http.StatusUnauthorized, "unauthorized", "Требуется аутентификация", nil)
+		return
+	}
+	id, ok := parseChallengeID(c)
+	if !ok {
+		return
+	}
+
+	actor := challengeuc.Actor{UserID: userID, Role: userdomain.Role(c.GetString(middleware.ContextUserRoleKey))}
+	if err := h.challenges.Delete(c.Request.Context(), actor, id); err != nil {
+		h.respondError(c, "delete_challenge", err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// Leaderboard godoc
+// @Summary      Таблица лидеров челленджа
+// @Description  Возвращает лучших участников по значению показателя (участники с равным значением делят место) и место текущего пользователя в поле me.
+// @Tags         challenges
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id     path      string  true   "ID челленджа"
+// @Param        limit  query     int     false  "Число участников (по умолчанию 50, максимум 100)"
+// @Success      200    {object}  LeaderboardResponse
+// @Failure      400    {object}  response.ErrorBody
+// @Failure      401    {object}  response.ErrorBody
+// @Failure      404    {object}  response.ErrorBody
+// @Failure      500    {object}  response.ErrorBody
+// @Router       /api/v1/challenges/{id}/leaderboard [get]
+func (h *Handler) Leaderboard(c *gin.Context) {
+	userID, err := middleware.UserIDFromContext(c)
+	if err != nil {
+		response.Error(c, http.StatusUnauthorized, "unauthorized", "Требуется аутентификация", nil)
+		return
+	}
+	id, ok := parseChallengeID(c)
+	if !ok {
+		return
+	}
+	limit, err := queryInt(c, "limit")
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid_pagination", "Параметр limit должен быть неотрицательным числом", nil)
+		return
+	}
+
+	board, err := h.challenges.Leaderboard(c.Request.Context(), userID, id, limit)
+	if err != nil {
+		h.respondError(c, "challenge_leaderboard", err)
+		return
+	}
+	resp := LeaderboardResponse{
+		ChallengeID:  board.Challenge.ID.String(),
+		Metric:       string(board.Challenge.Metric),
+		Target:       board.Challenge.Target,
+		Status:       string(board.Challenge.Status(time.Now())),
+		Participants: board.Participants,
+		Items:        make([]StandingResponse, 0, len(board.Standings)),
+		Me:           toStandingResponse(board.Me),
+	}
+	for i := range board.Standings {
+		resp.Items = append(resp.Items, *toStandingResponse(&board.Standings[i]))
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// respondError маппит ошибки usecase-слоя в HTTP-ответы.
+func (h *Handler) respondError(c *gin.Context, op string, err error) {
+	switch {
+	case errors.Is(err, challengeuc.ErrInvalidTitle):
+		response.Error(c, http.StatusBadRequest, "invalid_title", "Название челленджа должно быть от 1 до 100 символов", nil)
+	case errors.Is(err, challengeuc.ErrInvalidDescription):
+		response.Error(c, http.StatusBadRequest, "invalid_description", "Описание челленджа не должно превышать 1000 символов", nil)
+	case errors.Is(err, challengeuc.ErrInvalidMetric):
+		response.Error(c, http.StatusBadRequest, "invalid_metric", "Показатель должен быть sessions или active_days", nil)
+	case errors.Is(err, challengeuc.ErrInvalidTarget):
+		response.Error(c, http.StatusBadRequest, "invalid_target", "Цель челленджа должна быть от 1 до 1000", nil)
+	case errors.Is(err, challengeuc.ErrInvalidPeriod):
+		response.Error(c, http.StatusBadRequest, "invalid_period", "Челлендж должен начинаться не раньше сегодняшнего дня, заканчиваться в будущем и длиться не больше года", nil)
+	case errors.Is(err, challengeuc.ErrChallengeNotFound):
+		response.Error(c, http.StatusNotFound, "challenge_not_found", "Челлендж не найден", nil)
+	case errors.Is(err, challengeuc.ErrChallengeFinished):
+		response.Error(c, http.StatusConflict, "challenge_finished", "Челлендж завершён, состав участников изменить нельзя", nil)
+	case errors.Is(err, challengeuc.ErrForbidden):
+		response.Error(c, http.StatusForbidden, "forbidden", "Удалить челлендж может только создатель или администратор", nil)
+	default:
+		h.logger.Error("internal_error_in_"+op, map[string]any{
+			"path":   c.Request.URL.Path,
+			"method": c.Request.Method,
+			"error":  err.Error(),
+		})
+		response.Error(c, http.StatusInternalServerError, "internal_error", "Внутренняя ошибка сервера", nil)
+	}
+}
+
+// parseChallengeID разбирает ID челленджа из пути. При ошибке ответ уже отправлен.
+func parseChallengeID(c *gin.Context) (uuid.UUID, bool) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid_challenge_id", "Некорректный ID челленджа", nil)
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+// queryInt разбирает неотрицательный целочисленный параметр запроса; пустое значение — 0.
+func queryInt(c *gin.Context, name string) (int, error) {
+	raw := c.Query(name)
+	if raw == "" {
+		return 0, nil
+	}
+	n, err := strconv.Atoi(raw)
+	if err != nil || n < 0 {
+		return 0, strconv.ErrSyntax
+	}
+	return n, nil
+}
+
+func toChallengeResponse(l *domain.Listing, me *challengeuc.Standing) ChallengeResponse {
+	ch := l.Challenge
+	return ChallengeResponse{
+		ID:           ch.ID.String(),
+		CreatorID:    ch.CreatorID.String(),
+		Title:        ch.Title,
+		Description:  ch.Description,
+		Metric:       string(ch.Metric),
+		Target:       ch.Target,
+		StartsAt:     ch.StartsAt,
+		EndsAt:       ch.EndsAt,
+		Status:       string(ch.Status(time.Now())),
+		Participants: l.Participants,
+		Joined:       l.Joined,
+		Progress:     toStandingResponse(me),
+		CreatedAt:    ch.CreatedAt,
+	}
+}
+
+func toStandingResponse(s *challengeuc.Standing) *StandingResponse {
+	if s == nil {
+		return nil
+	}
+	return &StandingResponse{
+		Rank:      s.Rank,
+		UserID:    s.UserID.String(),
+		Username:  s.Username,
+		Value:     s.Value,
+		Completed: s.Completed,
+		JoinedAt:  s.JoinedAt,
+	}
+}
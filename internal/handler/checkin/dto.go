@@ -0,0 +1,55 @@
+package checkin
+
+import "time"
+
+// RecordCheckInRequest описывает тело запроса на сохранение анкеты.
+// Оценки — слайдеры от 1 до 10.
+type RecordCheckInRequest struct {
+	// Date — день анкеты (YYYY-MM-DD); не задан — сегодня (UTC). Допускаются два прошедших дня и завтрашний.
+	Date       string `json:"date,omitempty"`
+	Soreness   int    `json:"soreness" binding:"required,min=1,max=10"`
+	Energy     int    `json:"energy" binding:"required,min=1,max=10"`
+	Motivation int    `json:"motivation" binding:"required,min=1,max=10"`
+	Note       string `json:"note,omitempty" binding:"max=500"`
+}
+
+// CheckInResponse описывает анкету готовности.
+type CheckInResponse struct {
+	ID         string    `json:"id"`
+	Date       string    `json:"date"`
+	Soreness   int       `json:"soreness"`
+	Energy     int       `json:"energy"`
+	Motivation int       `json:"motivation"`
+	Note       string    `json:"note,omitempty"`
+	Score      int       `json:"score"` // Оценка готовности 0–100
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// ReadinessResponse описывает готовность по анкетам за последние 7 дней.
+type ReadinessResponse struct {
+	CheckIns        int     `json:"checkins"`
+	AverageScore    float64 `json:"average_score"`
+	AverageSoreness float64 `json:"average_soreness"`
+	// Recommendation — train, reduce_intensity, deload или insufficient_data (меньше 3 анкет за неделю).
+	Recommendation string `json:"recommendation"`
+}
+
+// StreakResponse описывает серии ежедневных анкет.
+type StreakResponse struct {
+	Current      int  `json:"current"`
+	Longest      int  `json:"longest"`
+	CheckedToday bool `json:"checked_today"`
+}
+
+// SummaryResponse описывает серии и готовность текущего пользователя.
+type SummaryResponse struct {
+	Streak    StreakResponse    `json:"streak"`
+	Readiness ReadinessResponse `json:"readiness"`
+}
+
+// ClientCheckInsResponse описывает анкеты клиента для тренера.
+type ClientCheckInsResponse struct {
+	Items     []CheckInResponse `json:"items"`
+	Readiness ReadinessResponse `json:"readiness"`
+}
@@ -0,0 +1,278 @@
+package checkin
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	domain "workout-app/internal/domain/checkin"
+	"workout-app/internal/handler/middleware"
+	"workout-app/internal/handler/response"
+	checkinuc "workout-app/internal/usecase/checkin"
+	consentuc "workout-app/internal/usecase/consent"
+	"workout-app/pkg/logger"
+)
+
+const (
+	dateLayout = "2006-01-02"
+	// defaultPeriod — период выборки, если from не задан.
+	defaultPeriod = 30 * 24 * time.Hour
+)
+
+// Handler обрабатывает HTTP-запросы, связанные с ежедневными анкетами готовности.
+type Handler struct {
+	checkIns checkinuc.Service
+	logger   logger.Logger
+}
+
+// NewHandler создаёт новый CheckInHandler.
+func NewHandler(checkIns checkinuc.Service, logger logger.Logger) *Handler {
+	return &Handler{
+		checkIns: checkIns,
+		logger:   logger,
+	}
+}
+
+// Record godoc
+// @Summary      Заполнить ежедневную анкету
+// @Description  Сохраняет оценки мышечной боли, энергии и мотивации (1–10) за день. Повторная анкета за тот же день заменяет предыдущую.
+// @Tags         checkins
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        payload  body      RecordCheckInRequest  true  "Анкета"
+// @Success      200      {object}  CheckInResponse
+// @Failure      400      {object}  response.ErrorBody
+// @Failure      401      {object}  response.ErrorBody
+// @Failure      500      {object}  response.ErrorBody
+// @Router       /api/v1/checkins [post]
+func (h *Handler) Record(c *gin.Context) {
+	userID, err := middleware.UserIDFromContext(c)
+	if err != nil {
+		response.Error(c, http.StatusUnauthorized, "unauthorized", "Требуется аутентификация", nil)
+		return
+	}
+
+	var req RecordCheckInRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid_request", "Некорректное тело запроса", err.Error())
+		return
+	}
+
+	input := checkinuc.RecordInput{
+		Soreness:   req.Soreness,
+		Energy:     req.Energy,
+		Motivation: req.Motivation,
+		Note:       req.Note,
+	}
+	if req.Date != "" {
+		day, err := time.Parse(dateLayout, req.Date)
+		if err != nil {
+			response.Error(c, http.StatusBadRequest, "invalid_request", "Некорректная дата, ожидается YYYY-MM-DD", nil)
+			return
+		}
+		input.Date = &day
+	}
+
+	checkIn, err := h.checkIns.Record(c.Request.Context(), userID, input)
+	if err != nil {
+		h.respondError(c, "record_checkin", userID, err)
+		return
+	}
+	c.JSON(http.StatusOK, toCheckInResponse(checkIn))
+}
+
+// List godoc
+// @Summary      Список анкет
+// @Description  Возвращает анкеты текущего пользователя за дни периода (по умолчанию — последние 30 дней), новые первыми.
+// @Tags         checkins
+// @Security     BearerAuth
+// @Produce      json
+// @Param        from  query     string  false  "Первый день периода (YYYY-MM-DD)"
+// @Param        to    query     string  false  "Последний день периода (YYYY-MM-DD)"
+// @Success      200   {array}   CheckInResponse
+// @Failure      400   {object}  response.ErrorBody
+// @Failure      401   {object}  response.ErrorBody
+// @Failure      500   {object}  response.ErrorBody
+// @Router       /api/v1/checkins [get]
+func (h *Handler) List(c *gin.Context) {
+	userID, err := middleware.UserIDFromContext(c)
+	if err != nil {
+		response.Error(c, http.StatusUnauthorized, "unauthorized", "Требуется аутентификация", nil)
+		return
+	}
+	from, to, ok := parsePeriod(c)
+	if !ok {
+		return
+	}
+
+	checkIns, err := h.checkIns.List(c.Request.Context(), userID, from, to)
+	if err != nil {
+		h.respondError(c, "list_checkins", userID, err)
+		return
+	}
+	c.JSON(http.StatusOK, toCheckInResponses(checkIns))
+}
+
+// Summary godoc
+// @Summary      Серии анкет и готовность
+// @Description  Возвращает текущую и самую длинную серию ежедневных анкет и готовность за последние 7 дней с рекомендацией по нагрузке (train, reduce_intensity, deload).
+// @Tags         checkins
+// @Security     BearerAuth
+// @Produce      json
+// @Success      200  {object}  SummaryResponse
+// @Failure      401  {object}  response.ErrorBody
+// @Failure      500  {object}  response.ErrorBody
+// @Router       /api/v1/checkins/summary [get]
+func (h *Handler) Summary(c *gin.Context) {
+	userID, err := middleware.UserIDFromContext(c)
+	if err != nil {
+		response.Error(c, http.StatusUnauthorized, "unauthorized", "Требуется аутентификация", nil)
+		return
+	}
+
+	summary, err := h.checkIns.Summary(c.Request.Context(), userID)
+	if err != nil {
+		h.respondError(c, "checkin_summary", userID, err)
+		return
+	}
+	c.JSON(http.StatusOK, SummaryResponse{
+		Streak: StreakResponse{
+			Current:      summary.Streak.Current,
+			Longest:      summary.Streak.Longest,
+			CheckedToday: summary.Streak.CheckedToday,
+		},
+		Readiness: toReadinessResponse(summary.Readiness),
+	})
+}
+
+// ListClientCheckIns godoc
+// @Summary      Получить анкеты клиента (тренер)
+// @Description  Возвращает анкеты готовности клиента текущего тренера за период и готовность за последние 7 дней. Клиент должен открыть тренеру класс данных wellbeing.
+// @Tags         checkins
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id    path      string  true   "ID клиента"
+// @Param        from  query     string  false  "Первый день периода (YYYY-MM-DD)"
+// @Param        to    query     string  false  "Последний день периода (YYYY-MM-DD)"
+// @Success      200   {object}  ClientCheckInsResponse
+// @Failure      400   {object}  response.ErrorBody
+// @Failure      401   {object}  response.ErrorBody
+// @Failure      403   {object}  response.ErrorBody
+// @Failure      404   {object}  response.ErrorBody
+// @Failure      500   {object}  response.ErrorBody
+// @Router       /api/v1/coach/clients/{id}/checkins [get]
+func (h *Handler) ListClientCheckIns(c *gin.Context) {
+	coachID, err := middleware.UserIDFromContext(c)
+	if err != nil {
+		response.Error(c, http.StatusUnauthorized, "unauthorized", "Требуется аутентификация", nil)
+		return
+	}
+
+	clientID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid_user_id", "Некорректный ID пользователя", nil)
+		return
+	}
+	from, to, ok := parsePeriod(c)
+	if !ok {
+		return
+	}
+
+	result, err := h.checkIns.ListForCoach(c.Request.Context(), coachID, clientID, from, to)
+	if err != nil {
+		switch {
+		case errors.Is(err, consentuc.ErrNotCoachClient):
+			response.Error(c, http.StatusNotFound, "client_not_found", "Клиент не найден", nil)
+		case errors.Is(err, consentuc.ErrConsentRequired):
+			response.Error(c, http.StatusForbidden, "consent_required", "Клиент не открыл доступ к анкетам самочувствия", nil)
+		default:
+			h.respondError(c, "list_client_checkins", coachID, err)
+		}
+		return
+	}
+	c.JSON(http.StatusOK, ClientCheckInsResponse{
+		Items:     toCheckInResponses(result.CheckIns),
+		Readiness: toReadinessResponse(result.Readiness),
+	})
+}
+
+// respondError маппит ошибки usecase-слоя в HTTP-ответы.
+func (h *Handler) respondError(c *gin.Context, op string, userID uuid.UUID, err error) {
+	switch {
+	case errors.Is(err, checkinuc.ErrInvalidRating):
+		response.Error(c, http.StatusBadRequest, "invalid_rating", "Оценки должны быть от 1 до 10", nil)
+	case errors.Is(err, checkinuc.ErrInvalidNote):
+		response.Error(c, http.StatusBadRequest, "invalid_note", "Комментарий не должен превышать 500 символов", nil)
+	case errors.Is(err, checkinuc.ErrInvalidDate):
+		response.Error(c, http.StatusBadRequest, "invalid_date", "Анкету можно заполнить только за сегодня или два прошедших дня", nil)
+	case errors.Is(err, checkinuc.ErrInvalidPeriod):
+		response.Error(c, http.StatusBadRequest, "invalid_range", "Некорректный период", nil)
+	default:
+		h.logger.Error("internal_error_in_"+op, map[string]any{
+			"user_id": userID.String(),
+			"path":    c.Request.URL.Path,
+			"method":  c.Request.Method,
+			"error":   err.Error(),
+		})
+		response.Error(c, http.StatusInternalServerError, "internal_error", "Внутренняя ошибка сервера", nil)
+	}
+}
+
+// parsePeriod разбирает дни from/to (YYYY-MM-DD); по умолчанию — последние 30 дней.
+func parsePeriod(c *gin.Context) (time.Time, time.Time, bool) {
+	to := time.Now().UTC()
+	if raw := c.Query("to"); raw != "" {
+		t, err := time.Parse(dateLayout, raw)
+		if err != nil {
+			response.Error(c, http.StatusBadRequest, "invalid_request", "Некорректный параметр to", nil)
+			return time.Time{}, time.Time{}, false
+		}
+		to = t
+	}
+	from := to.Add(-defaultPeriod)
+	if raw := c.Query("from"); raw != "" {
+		t, err := time.Parse(dateLayout, raw)
+		if err != nil {
+			response.Error(c, http.StatusBadRequest, "invalid_request", "Некорректный параметр from", nil)
+			return time.Time{}, time.Time{}, false
+		}
+		from = t
+	}
+	return from, to, true
+}
+
+func toCheckInResponses(checkIns []*domain.CheckIn) []CheckInResponse {
+	resp := make([]CheckInResponse, 0, len(checkIns))
+	for _, c := range checkIns {
+		resp = append(resp, toCheckInResponse(c))
+	}
+	return resp
+}
+
+// toCheckInResponse маппит доменную модель в DTO.
+func toCheckInResponse(c *domain.CheckIn) CheckInResponse {
+	return CheckInResponse{
+		ID:         c.ID.String(),
+		Date:       c.Date.Format(dateLayout),
+		Soreness:   c.Soreness,
+		Energy:     c.Energy,
+		Motivation: c.Motivation,
+		Note:       c.Note,
+		Score:      c.Score(),
+		CreatedAt:  c.CreatedAt,
+		UpdatedAt:  c.UpdatedAt,
+	}
+}
+
+func toReadinessResponse(r domain.Readiness) ReadinessResponse {
+	return ReadinessResponse{
+		CheckIns:        r.CheckIns,
+		AverageScore:    r.AverageScore,
+		AverageSoreness: r.AverageSoreness,
+		Recommendation:  string(r.Recommendation),
+	}
+}
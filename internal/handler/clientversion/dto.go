@@ -0,0 +1,19 @@
+package clientversion
+
+import "time"
+
+// PolicyResponse описывает минимальную поддерживаемую версию клиента для платформы.
+type PolicyResponse struct {
+	Platform   string    `json:"platform"`
+	MinVersion string    `json:"min_version"`
+	StoreURL   string    `json:"store_url"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// SetPolicyRequest описывает тело запроса для установки минимальной версии клиента.
+type SetPolicyRequest struct {
+	// MinVersion — минимальная поддерживаемая версия, например "2.3.0".
+	MinVersion string `json:"min_version" binding:"required"`
+	// StoreURL — ссылка на приложение в магазине для обновления.
+	StoreURL string `json:"store_url" binding:"required"`
+}
@@ -0,0 +1,154 @@
+package clientversion
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	domain "workout-app/internal/domain/clientversion"
+	"workout-app/internal/handler/middleware"
+	"workout-app/internal/handler/response"
+	repo "workout-app/internal/repository/interfaces"
+	clientversionuc "workout-app/internal/usecase/clientversion"
+	"workout-app/pkg/logger"
+)
+
+// Handler обрабатывает административные запросы управления минимальными версиями клиента.
+type Handler struct {
+	clientVersions clientversionuc.Service
+	logger         logger.Logger
+}
+
+// NewHandler создаёт новый ClientVersionHandler.
+func NewHandler(clientVersions clientversionuc.Service, logger logger.Logger) *Handler {
+	return &Handler{
+		clientVersions: clientVersions,
+		logger:         logger,
+	}
+}
+
+// ListPolicies godoc
+// @Summary      Минимальные версии клиента (админ)
+// @Description  Возвращает минимальные поддерживаемые версии мобильного приложения по платформам.
+// @Tags         admin
+// @Security     BearerAuth
+// @Produce      json
+// @Success      200  {array}   PolicyResponse
+// @Failure      401  {object}  response.ErrorBody
+// @Failure      403  {object}  response.ErrorBody
+// @Failure      500  {object}  response.ErrorBody
+// @Router       /api/v1/admin/client-versions [get]
+func (h *Handler) ListPolicies(c *gin.Context) {
+	policies, err := h.clientVersions.ListPolicies(c.Request.Context())
+	if err != nil {
+		h.logger.Error("internal_error_in_list_client_versions", map[string]any{
+			"path":   c.Request.URL.Path,
+			"method": c.Request.Method,
+			"error":  err.Error(),
+		})
+		response.Error(c, http.StatusInternalServerError, "internal_error", "Внутренняя ошибка сервера", nil)
+		return
+	}
+
+	resp := make([]PolicyResponse, 0, len(policies))
+	for _, p := range policies {
+		resp = append(resp, toPolicyResponse(p))
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// SetPolicy godoc
+// @Summary      Задать минимальную версию клиента (админ)
+// @Description  Задаёт минимальную версию приложения для платформы. Клиенты ниже неё получают 426 Upgrade Required со ссылкой на магазин. Изменение применяется без перезапуска.
+// @Tags         admin
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        platform  path      string            true  "Платформа (ios, android)"
+// @Param        payload   body      SetPolicyRequest  true  "Минимальная версия и ссылка на магазин"
+// @Success      200       {object}  PolicyResponse
+// @Failure      400       {object}  response.ErrorBody
+// @Failure      401       {object}  response.ErrorBody
+// @Failure      403       {object}  response.ErrorBody
+// @Failure      500       {object}  response.ErrorBody
+// @Router       /api/v1/admin/client-versions/{platform} [put]
+func (h *Handler) SetPolicy(c *gin.Context) {
+	var req SetPolicyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid_request", "Некорректное тело запроса", err.Error())
+		return
+	}
+
+	platform := domain.Platform(c.Param("platform"))
+	p, err := h.clientVersions.SetPolicy(c.Request.Context(), platform, req.MinVersion, req.StoreURL)
+	if err != nil {
+		h.respondError(c, "set_client_version", err)
+		return
+	}
+
+	h.logger.Info("client_version_policy_updated", map[string]any{
+		"platform":    string(p.Platform),
+		"min_version": p.MinVersion,
+		"actor_id":    c.GetString(middleware.ContextUserIDKey),
+	})
+	c.JSON(http.StatusOK, toPolicyResponse(p))
+}
+
+// DeletePolicy godoc
+// @Summary      Снять ограничение версии клиента (админ)
+// @Description  Удаляет минимальную версию для платформы; все версии приложения снова принимаются.
+// @Tags         admin
+// @Security     BearerAuth
+// @Param        platform  path  string  true  "Платформа (ios, android)"
+// @Success      204
+// @Failure      400  {object}  response.ErrorBody
+// @Failure      401  {object}  response.ErrorBody
+// @Failure      403  {object}  response.ErrorBody
+// @Failure      404  {object}  response.ErrorBody
+// @Failure      500  {object}  response.ErrorBody
+// @Router       /api/v1/admin/client-versions/{platform} [delete]
+func (h *Handler) DeletePolicy(c *gin.Context) {
+	platform := domain.Platform(c.Param("platform"))
+	if err := h.clientVersions.DeletePolicy(c.Request.Context(), platform); err != nil {
+		h.respondError(c, "delete_client_version", err)
+		return
+	}
+
+	h.logger.Info("client_version_policy_deleted", map[string]any{
+		"platform": string(platform),
+		"actor_id": c.GetString(middleware.ContextUserIDKey),
+	})
+	c.Status(http.StatusNoContent)
+}
+
+// respondError отправляет ответ об ошибке для эндпоинтов управления версиями клиента.
+func (h *Handler) respondError(c *gin.Context, op string, err error) {
+	switch {
+	case errors.Is(err, clientversionuc.ErrInvalidPlatform):
+		response.Error(c, http.StatusBadRequest, "invalid_platform", "Платформа должна быть ios или android", nil)
+	case errors.Is(err, clientversionuc.ErrInvalidVersion):
+		response.Error(c, http.StatusBadRequest, "invalid_version", "Версия должна быть в формате major.minor.patch", nil)
+	case errors.Is(err, clientversionuc.ErrInvalidStoreURL):
+		response.Error(c, http.StatusBadRequest, "invalid_store_url", "Некорректная ссылка на магазин приложений", nil)
+	case errors.Is(err, repo.ErrNotFound):
+		response.Error(c, http.StatusNotFound, "client_version_not_found", "Ограничение версии для платформы не задано", nil)
+	default:
+		h.logger.Error("internal_error_in_"+op, map[string]any{
+			"path":   c.Request.URL.Path,
+			"method": c.Request.Method,
+			"error":  err.Error(),
+		})
+		response.Error(c, http.StatusInternalServerError, "internal_error", "Внутренняя ошибка сервера", nil)
+	}
+}
+
+// toPolicyResponse маппит доменную модель в DTO.
+func toPolicyResponse(p *domain.Policy) PolicyResponse {
+	return PolicyResponse{
+		Platform:   string(p.Platform),
+		MinVersion: p.MinVersion,
+		StoreURL:   p.StoreURL,
+		UpdatedAt:  p.UpdatedAt,
+	}
+}
@@ -0,0 +1,68 @@
+package coach
+
+import "time"
+
+// UpdateProfileRequest описывает анкету тренера целиком (PUT заменяет все поля).
+type UpdateProfileRequest struct {
+	Listed      bool     `json:"listed"`                // Опубликовать анкету в каталоге
+	Headline    string   `json:"headline,omitempty"`    // До 120 символов; обязателен для публикации
+	Bio         string   `json:"bio,omitempty"`         // До 2000 символов
+	Specialties []string `json:"specialties,omitempty"` // До 5 направлений; хотя бы одно обязательно для публикации
+	Languages   []string `json:"languages,omitempty"`   // До 5 кодов ISO 639-1
+	Country     string   `json:"country,omitempty"`     // ISO 3166-1 alpha-2
+	City        string   `json:"city,omitempty"`
+	Online      bool     `json:"online"` // Проводит занятия онлайн
+}
+
+// ProfileResponse описывает анкету тренера для её владельца.
+type ProfileResponse struct {
+	Listed      bool       `json:"listed"`
+	Headline    string     `json:"headline"`
+	Bio         string     `json:"bio"`
+	Specialties []string   `json:"specialties"`
+	Languages   []string   `json:"languages"`
+	Country     string     `json:"country,omitempty"`
+	City        string     `json:"city,omitempty"`
+	Online      bool       `json:"online"`
+	Verified    bool       `json:"verified"`
+	VerifiedAt  *time.Time `json:"verified_at,omitempty"`
+	UpdatedAt   *time.Time `json:"updated_at,omitempty"`
+}
+
+// DirectoryEntryResponse описывает тренера в публичном каталоге.
+type DirectoryEntryResponse struct {
+	UserID      string     `json:"user_id"`
+	Username    string     `json:"username"`
+	FirstName   string     `json:"first_name,omitempty"`
+	LastName    string     `json:"last_name,omitempty"`
+	AvatarURL   string     `json:"avatar_url,omitempty"`
+	Headline    string     `json:"headline"`
+	Bio         string     `json:"bio,omitempty"`
+	Specialties []string   `json:"specialties"`
+	Languages   []string   `json:"languages"`
+	Country     string     `json:"country,omitempty"`
+	City        string     `json:"city,omitempty"`
+	Online      bool       `json:"online"`
+	Verified    bool       `json:"verified"`
+	VerifiedAt  *time.Time `json:"verified_at,omitempty"`
+}
+
+// DirectoryResponse описывает страницу публичного каталога тренеров.
+type DirectoryResponse struct {
+	Items []DirectoryEntryResponse `json:"items"`
+	Total int64                    `json:"total"`
+}
+
+// VerifyRequest описывает тело запроса отметки «проверенный тренер».
+type VerifyRequest struct {
+	Note string `json:"note,omitempty"` // Комментарий администратора (например, проверенный сертификат)
+}
+
+// VerificationResponse описывает отметку «проверенный тренер» для администратора.
+type VerificationResponse struct {
+	UserID     string    `json:"user_id"`
+	Listed     bool      `json:"listed"`
+	VerifiedAt time.Time `json:"verified_at"`
+	VerifiedBy *string   `json:"verified_by,omitempty"`
+	Note       string    `json:"note,omitempty"`
+}
@@ -0,0 +1,387 @@
+package coach
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	domain "workout-app/internal/domain/coach"
+	"workout-app/internal/handler/middleware"
+	"workout-app/internal/handler/response"
+	repo "workout-app/internal/repository/interfaces"
+	coachuc "workout-app/internal/usecase/coach"
+	"workout-app/pkg/logger"
+)
+
+// Handler обрабатывает запросы к анкетам тренеров и публичному каталогу.
+type Handler struct {
+	coaches coachuc.Service
+	logger  logger.Logger
+}
+
+// NewHandler создаёт новый CoachHandler.
+func NewHandler(coaches coachuc.Service, logger logger.Logger) *Handler {
+	return &Handler{
+		coaches: coaches,
+		logger:  logger,
+	}
+}
+
+// Directory godoc
+// @Summary      Публичный каталог тренеров
+// @Description  Возвращает опубликованные анкеты тренеров: проверенные тренеры первыми, затем по username. Доступен без аутентификации. Заблокированные, удалённые и лишившиеся роли тренера пользователи в каталог не попадают.
+// @Tags         coaches
+// @Produce      json
+// @Param        specialty  query     string  false  "Направление (strength, powerlifting, weightlifting, bodybuilding, functional, endurance, mobility, rehabilitation, weight_loss, nutrition, general_fitness)"
+// @Param        language   query     string  false  "Язык занятий (ISO 639-1)"
+// @Param        country    query     string  false  "Страна (ISO 3166-1 alpha-2)"
+// @Param        city       query     string  false  "Город (без учёта регистра)"
+// @Param        online     query     bool    false  "Только онлайн (true) или только очные (false) занятия"
+// @Param        verified   query     bool    false  "Только проверенные тренеры"
+// @Param        limit      query     int     false  "Размер страницы (по умолчанию 20, максимум 100)"
+// @Param        offset     query     int     false  "Смещение"
+// @Success      200        {object}  DirectoryResponse
+// @Failure      400        {object}  response.ErrorBody
+// @Failure      429        {object}  response.ErrorBody
+// @Failure      500        {object}  response.ErrorBody
+// @Router       /api/v1/coaches [get]
+func (h *Handler) Directory(c *gin.Context) {
+	limit, err1 := queryInt(c, "limit")
+	offset, err2 := queryInt(c, "offset")
+	if err1 != nil || err2 != nil {
+		response.Error(c, http.StatusBadRequest, "invalid_pagination", "Параметры limit и offset должны быть неотрицательными числами", nil)
+		return
+	}
+	online, err := queryBool(c, "online")
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid_filter", "Параметр online должен быть true или false", nil)
+		return
+	}
+	verified, err := queryBool(c, "verified")
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid_filter", "Параметр verified должен быть true или false", nil)
+		return
+	}
+
+	entries, total, err := h.coaches.ListDirectory(c.Request.Context(), coachuc.DirectoryQuery{
+		Specialty:    domain.Specialty(c.Query("specialty")),
+		Language:     c.Query("language"),
+		Country:      c.Query("country"),
+		City:         c.Query("city"),
+		Online:       online,
+		VerifiedOnly: verified != nil && *verified,
+		Limit:        limit,
+		Offset:       offset,
+	})
+	if err != nil {
+		h.respondError(c, "list_coach_directory", err)
+		return
+	}
+
+	resp := DirectoryResponse{Items: make([]DirectoryEntryResponse, 0, len(entries)), Total: total}
+	for _, e := range entries {
+		resp.Items = append(resp.Items, toDirectoryEntryResponse(e))
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// GetProfile godoc
+// @Summary      Анкета текущего тренера
+// @Description  Возвращает анкету тренера для каталога; если анкета не заполнена — пустую неопубликованную анкету.
+// @Tags         coaches
+// @Security     BearerAuth
+// @Produce      json
+// @Success      200  {object}  ProfileResponse
+// @Failure      401  {object}  response.ErrorBody
+// @Failure      403  {object}  response.ErrorBody
+// @Failure      500  {object}  response.ErrorBody
+// @Router       /api/v1/coach/profile [get]
+func (h *Handler) GetProfile(c *gin.Context) {
+	userID, err := middleware.UserIDFromContext(c)
+	if err != nil {
+		response.Error(c, http.StatusUnauthorized, "unauthorized", "Требуется аутентификация", nil)
+		return
+	}
+
+	p, err := h.coaches.GetProfile(c.Request.Context(), userID)
+	if err != nil {
+		h.respondError(c, "get_coach_profile", err)
+		return
+	}
+	c.JSON(http.StatusOK, toProfileResponse(p))
+}
+
+// UpdateProfile godoc
+// @Summary      Изменить анкету текущего тренера
+// @Description  Заменяет анкету целиком. listed=true публикует анкету в каталоге (нужны headline и хотя бы одно направление), listed=false убирает её из каталога. Отметка «проверенный тренер» не меняется.
+// @Tags         coaches
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        payload  body      UpdateProfileRequest  true  "Анкета тренера"
+// @Success      200      {object}  ProfileResponse
+// @Failure      400      {object}  response.ErrorBody
+// @Failure      401      {object}  response.ErrorBody
+// @Failure      403      {object}  response.ErrorBody
+// @Failure      500      {object}  response.ErrorBody
+// @Router       /api/v1/coach/profile [put]
+func (h *Handler) UpdateProfile(c *gin.Context) {
+	userID, err := middleware.UserIDFromContext(c)
+	if err != nil {
+		response.Error(c, http.StatusUnauthorized, "unauthorized", "Требуется аутентификация", nil)
+		return
+	}
+
+	var req UpdateProfileRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid_request", "Некорректное тело запроса", err.Error())
+		return
+	}
+
+	specialties := make([]domain.Specialty, 0, len(req.Specialties))
+	for _, sp := range req.Specialties {
+		specialties = append(specialties, domain.Specialty(sp))
+	}
+	p, err := h.coaches.UpdateProfile(c.Request.Context(), userID, coachuc.ProfileInput{
+		Listed:      req.Listed,
+		Headline:    req.Headline,
+		Bio:         req.Bio,
+		Specialties: specialties,
+		Languages:   req.Languages,
+		Country:     req.Country,
+		City:        req.City,
+		Online:      req.Online,
+	})
+	if err != nil {
+		h.respondError(c, "update_coach_profile", err)
+		return
+	}
+
+	h.logger.Info("coach_profile_updated", map[string]any{
+		"user_id": userID.String(),
+		"listed":  p.Listed,
+	})
+	c.JSON(http.StatusOK, toProfileResponse(p))
+}
+
+// Verify godoc
+// @Summary      Отметить тренера как проверенного (админ)
+// @Description  Ставит отметку «проверенный тренер», которая показывается в каталоге. Доступно только для пользователей с ролью coach; анкета при этом не публикуется. Повторный вызов обновляет комментарий и время отметки.
+// @Tags         admin
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        id       path      string         true   "ID тренера"
+// @Param        payload  body      VerifyRequest  false  "Комментарий администратора"
+// @Success      200      {object}  VerificationResponse
+// @Failure      400      {object}  response.ErrorBody
+// @Failure      401      {object}  response.ErrorBody
+// @Failure      403      {object}  response.ErrorBody
+// @Failure      404      {object}  response.ErrorBody
+// @Failure      409      {object}  response.ErrorBody
+// @Failure      500      {object}  response.ErrorBody
+// @Router       /api/v1/admin/coaches/{id}/verification [put]
+func (h *Handler) Verify(c *gin.Context) {
+	actorID, err := middleware.UserIDFromContext(c)
+	if err != nil {
+		response.Error(c, http.StatusUnauthorized, "unauthorized", "Требуется аутентификация", nil)
+		return
+	}
+	coachID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid_id", "Некорректный ID пользователя", nil)
+		return
+	}
+
+	var req VerifyRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			response.Error(c, http.StatusBadRequest, "invalid_request", "Некорректное тело запроса", err.Error())
+			return
+		}
+	}
+
+	p, err := h.coaches.Verify(c.Request.Context(), actorID, coachID, req.Note)
+	if err != nil {
+		h.respondError(c, "verify_coach", err)
+		return
+	}
+
+	h.logger.Info("coach_verified", map[string]any{
+		"coach_id": coachID.String(),
+		"actor_id": actorID.String(),
+	})
+	c.JSON(http.StatusOK, toVerificationResponse(p))
+}
+
+// Unverify godoc
+// @Summary      Снять отметку «проверенный тренер» (админ)
+// @Description  Снимает отметку; анкета остаётся в каталоге, если тренер её опубликовал.
+// @Tags         admin
+// @Security     BearerAuth
+// @Param        id  path  string  true  "ID тренера"
+// @Success      204
+// @Failure      400  {object}  response.ErrorBody
+// @Failure      401  {object}  response.ErrorBody
+// @Failure      403  {object}  response.ErrorBody
+// @Failure      404  {object}  response.ErrorBody
+// @Failure      500  {object}  response.ErrorBody
+// @Router       /api/v1/admin/coaches/{id}/verification [delete]
+func (h *Handler) Unverify(c *gin.Context) {
+	coachID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid_id", "Некорректный ID пользователя", nil)
+		return
+	}
+
+	if err := h.coaches.Unverify(c.Request.Context(), coachID); err != nil {
+		h.respondError(c, "unverify_coach", err)
+		return
+	}
+
+	h.logger.Info("coach_unverified", map[string]any{
+		"coach_id": coachID.String(),
+		"actor_id": c.GetString(middleware.ContextUserIDKey),
+	})
+	c.Status(http.StatusNoContent)
+}
+
+// respondError переводит ошибки usecase-слоя в HTTP-ответы.
+func (h *Handler) respondError(c *gin.Context, op string, err error) {
+	switch {
+	case errors.Is(err, coachuc.ErrInvalidSpecialty):
+		response.Error(c, http.StatusBadRequest, "invalid_specialty", "Неизвестное направление тренера", nil)
+	case errors.Is(err, coachuc.ErrTooManySpecialties):
+		response.Error(c, http.StatusBadRequest, "too_many_specialties", "Можно указать не больше 5 направлений", nil)
+	case errors.Is(err, coachuc.ErrInvalidLanguage):
+		response.Error(c, http.StatusBadRequest, "invalid_language", "Язык указывается двухбуквенным кодом ISO 639-1", nil)
+	case errors.Is(err, coachuc.ErrTooManyLanguages):
+		response.Error(c, http.StatusBadRequest, "too_many_languages", "Можно указать не больше 5 языков", nil)
+	case errors.Is(err, coachuc.ErrInvalidCountry):
+		response.Error(c, http.StatusBadRequest, "invalid_country", "Страна указывается двухбуквенным кодом ISO 3166-1", nil)
+	case errors.Is(err, coachuc.ErrHeadlineTooLong):
+		response.Error(c, http.StatusBadRequest, "headline_too_long", "Краткое описание не должно превышать 120 символов", nil)
+	case errors.Is(err, coachuc.ErrBioTooLong):
+		response.Error(c, http.StatusBadRequest, "bio_too_long", "Рассказ о себе не должен превышать 2000 символов", nil)
+	case errors.Is(err, coachuc.ErrCityTooLong):
+		response.Error(c, http.StatusBadRequest, "city_too_long", "Название города не должно превышать 100 символов", nil)
+	case errors.Is(err, coachuc.ErrIncompleteProfile):
+		response.Error(c, http.StatusBadRequest, "incomplete_profile", "Для публикации в каталоге укажите краткое описание и хотя бы одно направление", nil)
+	case errors.Is(err, coachuc.ErrNoteTooLong):
+		response.Error(c, http.StatusBadRequest, "note_too_long", "Комментарий не должен превышать 1000 символов", nil)
+	case errors.Is(err, coachuc.ErrNotCoach):
+		response.Error(c, http.StatusConflict, "not_a_coach", "Отметку можно поставить только пользователю с ролью coach", nil)
+	case errors.Is(err, repo.ErrNotFound):
+		response.Error(c, http.StatusNotFound, "user_not_found", "Пользователь не найден", nil)
+	default:
+		h.logger.Error("internal_error_in_"+op, map[string]any{
+			"path":   c.Request.URL.Path,
+			"method": c.Request.Method,
+			"error":  err.Error(),
+		})
+		response.Error(c, http.StatusInternalServerError, "internal_error", "Внутренняя ошибка сервера", nil)
+	}
+}
+
+// queryInt разбирает неотрицательный числовой параметр запроса; пустой параметр — 0.
+func queryInt(c *gin.Context, name string) (int, error) {
+	raw := c.Query(name)
+	if raw == "" {
+		return 0, nil
+	}
+	n, err := strconv.Atoi(raw)
+	if err != nil || n < 0 {
+		return 0, strconv.ErrSyntax
+	}
+	return n, nil
+}
+
+// queryBool разбирает логический параметр запроса; пустой параметр — nil.
+func queryBool(c *gin.Context, name string) (*bool, error) {
+	raw := c.Query(name)
+	if raw == "" {
+		return nil, nil
+	}
+	v, err := strconv.ParseBool(raw)
+	if err != nil {
+		return nil, err
+	}
+	return &v, nil
+}
+
+func specialtyStrings(items []domain.Specialty) []string {
+	result := make([]string, 0, len(items))
+	for _, sp := range items {
+		result = append(result, string(sp))
+	}
+	return result
+}
+
+func nonNilStrings(items []string) []string {
+	if items == nil {
+		return []string{}
+	}
+	return items
+}
+
+func toProfileResponse(p *domain.Profile) ProfileResponse {
+	resp := ProfileResponse{
+		Listed:      p.Listed,
+		Headline:    p.Headline,
+		Bio:         p.Bio,
+		Specialties: specialtyStrings(p.Specialties),
+		Languages:   nonNilStrings(p.Languages),
+		Country:     p.Country,
+		City:        p.City,
+		Online:      p.Online,
+		Verified:    p.IsVerified(),
+	}
+	if p.Verification != nil {
+		resp.VerifiedAt = &p.Verification.VerifiedAt
+	}
+	if !p.UpdatedAt.IsZero() {
+		resp.UpdatedAt = &p.UpdatedAt
+	}
+	return resp
+}
+
+func toDirectoryEntryResponse(e *domain.DirectoryEntry) DirectoryEntryResponse {
+	resp := DirectoryEntryResponse{
+		UserID:      e.UserID.String(),
+		Username:    e.Username,
+		FirstName:   e.FirstName,
+		LastName:    e.LastName,
+		AvatarURL:   e.AvatarURL,
+		Headline:    e.Headline,
+		Bio:         e.Bio,
+		Specialties: specialtyStrings(e.Specialties),
+		Languages:   nonNilStrings(e.Languages),
+		Country:     e.Country,
+		City:        e.City,
+		Online:      e.Online,
+		Verified:    e.IsVerified(),
+	}
+	if e.Verification != nil {
+		resp.VerifiedAt = &e.Verification.VerifiedAt
+	}
+	return resp
+}
+
+func toVerificationResponse(p *domain.Profile) VerificationResponse {
+	resp := VerificationResponse{
+		UserID: p.UserID.String(),
+		Listed: p.Listed,
+	}
+	if v := p.Verification; v != nil {
+		resp.VerifiedAt = v.VerifiedAt
+		resp.Note = v.Note
+		if v.VerifiedBy != nil {
+			by := v.VerifiedBy.String()
+			resp.VerifiedBy = &by
+		}
+	}
+	return resp
+}
@@ -0,0 +1,38 @@
+package coachclient
+
+import "time"
+
+// InvitationRequest описывает тело запроса приглашения пользователя в клиенты.
+type InvitationRequest struct {
+	Email string `json:"email" binding:"required,email"`
+}
+
+// UserSummary описывает публичные данные тренера или клиента в приглашении.
+type UserSummary struct {
+	ID        string `json:"id"`
+	Username  string `json:"username"`
+	FirstName string `json:"first_name,omitempty"`
+	LastName  string `json:"last_name,omitempty"`
+	AvatarURL string `json:"avatar_url,omitempty"`
+}
+
+// InvitationResponse описывает приглашение тренера.
+type InvitationResponse struct {
+	ID       string `json:"id"`
+	CoachID  string `json:"coach_id"`
+	ClientID string `json:"client_id"`
+	// Email — адрес, на который отправлено приглашение; показывается только тренеру.
+	Email string `json:"email,omitempty"`
+	// Coach заполняется в списке приглашений текущего пользователя, Client — в списке приглашений тренера.
+	Coach     *UserSummary `json:"coach,omitempty"`
+	Client    *UserSummary `json:"client,omitempty"`
+	CreatedAt time.Time    `json:"created_at"`
+	ExpiresAt time.Time    `json:"expires_at"`
+}
+
+// ClientResponse описывает связь «тренер — клиент», установленную принятым приглашением.
+type ClientResponse struct {
+	CoachID   string    `json:"coach_id"`
+	ClientID  string    `json:"client_id"`
+	CreatedAt time.Time `json:"created_at"`
+}
@@ -0,0 +1,268 @@
+package coachclient
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	domain "workout-app/internal/domain/coach"
+	userdomain "workout-app/internal/domain/user"
+	"workout-app/internal/handler/middleware"
+	"workout-app/internal/handler/response"
+	coachclientuc "workout-app/internal/usecase/coachclient"
+	"workout-app/pkg/logger"
+)
+
+// Handler обрабатывает HTTP-запросы, связанные с приглашениями тренеров.
+type Handler struct {
+	clients coachclientuc.Service
+	logger  logger.Logger
+}
+
+// NewHandler создаёт новый CoachClientHandler.
+func NewHandler(clients coachclientuc.Service, logger logger.Logger) *Handler {
+	return &Handler{
+		clients: clients,
+		logger:  logger,
+	}
+}
+
+// Invite godoc
+// @Summary      Пригласить клиента
+// @Description  Приглашает зарегистрированного пользователя по email стать клиентом текущего тренера и отправляет ему письмо. Приглашение действует 7 дней; пользователь принимает его сам. У тренера может быть не больше 100 действующих приглашений.
+// @Tags         coach
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        payload  body      InvitationRequest  true  "Email пользователя"
+// @Success      201      {object}  InvitationResponse
+// @Failure      400      {object}  response.ErrorBody
+// @Failure      401      {object}  response.ErrorBody
+// @Failure      403      {object}  response.ErrorBody
+// @Failure      404      {object}  response.ErrorBody
+// @Failure      409      {object}  response.ErrorBody
+// @Failure      422      {object}  response.ErrorBody
+// @Failure      500      {object}  response.ErrorBody
+// @Router       /api/v1/coach/invitations [post]
+func (h *Handler) Invite(c *gin.Context) {
+	coachID, err := middleware.UserIDFromContext(c)
+	if err != nil {
+		response.Error(c, http.StatusUnauthorized, "unauthorized", "Требуется аутентификация", nil)
+		return
+	}
+
+	var req InvitationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid_request", "Некорректное тело запроса", err.Error())
+		return
+	}
+
+	inv, err := h.clients.Invite(c.Request.Context(), coachID, req.Email)
+	if err != nil {
+		h.respondError(c, "invite_coach_client", coachID, err)
+		return
+	}
+
+	h.logger.Info("coach_client_invited", map[string]any{
+		"invitation_id": inv.ID.String(),
+		"coach_id":      coachID.String(),
+		"client_id":     inv.ClientID.String(),
+	})
+	resp := toInvitationResponse(inv)
+	resp.Email = inv.Email
+	c.JSON(http.StatusCreated, resp)
+}
+
+// ListSentInvitations godoc
+// @Summary      Приглашения тренера
+// @Description  Действующие приглашения текущего тренера, новые первыми. Принявшие приглашение пользователи появляются в списке клиентов.
+// @Tags         coach
+// @Security     BearerAuth
+// @Produce      json
+// @Success      200  {array}   InvitationResponse
+// @Failure      401  {object}  response.ErrorBody
+// @Failure      403  {object}  response.ErrorBody
+// @Failure      500  {object}  response.ErrorBody
+// @Router       /api/v1/coach/invitations [get]
+func (h *Handler) ListSentInvitations(c *gin.Context) {
+	coachID, err := middleware.UserIDFromContext(c)
+	if err != nil {
+		response.Error(c, http.StatusUnauthorized, "unauthorized", "Требуется аутентификация", nil)
+		return
+	}
+
+	invitations, err := h.clients.ListSentInvitations(c.Request.Context(), coachID)
+	if err != nil {
+		h.respondError(c, "list_coach_invitations", coachID, err)
+		return
+	}
+
+	resp := make([]InvitationResponse, 0, len(invitations))
+	for _, inv := range invitations {
+		item := toInvitationResponse(inv.Invitation)
+		item.Email = inv.Invitation.Email
+		client := toUserSummary(inv.Client)
+		item.Client = &client
+		resp = append(resp, item)
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// ListMyInvitations godoc
+// @Summary      Мои приглашения от тренеров
+// @Description  Действующие приглашения тренеров текущему пользователю, новые первыми.
+// @Tags         coach
+// @Security     BearerAuth
+// @Produce      json
+// @Success      200  {array}   InvitationResponse
+// @Failure      401  {object}  response.ErrorBody
+// @Failure      500  {object}  response.ErrorBody
+// @Router       /api/v1/users/me/coach-invitations [get]
+func (h *Handler) ListMyInvitations(c *gin.Context) {
+	userID, err := middleware.UserIDFromContext(c)
+	if err != nil {
+		response.Error(c, http.StatusUnauthorized, "unauthorized", "Требуется аутентификация", nil)
+		return
+	}
+
+	invitations, err := h.clients.ListInvitations(c.Request.Context(), userID)
+	if err != nil {
+		h.respondError(c, "list_my_coach_invitations", userID, err)
+		return
+	}
+
+	resp := make([]InvitationResponse, 0, len(invitations))
+	for _, inv := range invitations {
+		item := toInvitationResponse(inv.Invitation)
+		coach := toUserSummary(inv.Coach)
+		item.Coach = &coach
+		resp = append(resp, item)
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// AcceptInvitation godoc
+// @Summary      Принять приглашение тренера
+// @Description  Текущий пользователь становится клиентом тренера: тренер видит его в списке клиентов и может назначать программы. Доступ тренера к данным открывается отдельно согласиями.
+// @Tags         coach
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id   path      string  true  "ID приглашения"
+// @Success      200  {object}  ClientResponse
+// @Failure      400  {object}  response.ErrorBody
+// @Failure      401  {object}  response.ErrorBody
+// @Failure      404  {object}  response.ErrorBody
+// @Failure      500  {object}  response.ErrorBody
+// @Router       /api/v1/users/me/coach-invitations/{id}/accept [post]
+func (h *Handler) AcceptInvitation(c *gin.Context) {
+	userID, invitationID, ok := invitationParams(c)
+	if !ok {
+		return
+	}
+
+	client, err := h.clients.AcceptInvitation(c.Request.Context(), userID, invitationID)
+	if err != nil {
+		h.respondError(c, "accept_coach_invitation", userID, err)
+		return
+	}
+
+	h.logger.Info("coach_invitation_accepted", map[string]any{
+		"invitation_id": invitationID.String(),
+		"coach_id":      client.CoachID.String(),
+		"client_id":     userID.String(),
+	})
+	c.JSON(http.StatusOK, ClientResponse{
+		CoachID:   client.CoachID.String(),
+		ClientID:  client.ClientID.String(),
+		CreatedAt: client.CreatedAt,
+	})
+}
+
+// DeclineInvitation godoc
+// @Summary      Отклонить приглашение тренера
+// @Tags         coach
+// @Security     BearerAuth
+// @Param        id  path  string  true  "ID приглашения"
+// @Success      204
+// @Failure      400  {object}  response.ErrorBody
+// @Failure      401  {object}  response.ErrorBody
+// @Failure      404  {object}  response.ErrorBody
+// @Failure      500  {object}  response.ErrorBody
+// @Router       /api/v1/users/me/coach-invitations/{id} [delete]
+func (h *Handler) DeclineInvitation(c *gin.Context) {
+	userID, invitationID, ok := invitationParams(c)
+	if !ok {
+		return
+	}
+
+	if err := h.clients.DeclineInvitation(c.Request.Context(), userID, invitationID); err != nil {
+		h.respondError(c, "decline_coach_invitation", userID, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// respondError маппит ошибки usecase-слоя в HTTP-ответы.
+func (h *Handler) respondError(c *gin.Context, op string, userID uuid.UUID, err error) {
+	switch {
+	case errors.Is(err, coachclientuc.ErrUserNotFound):
+		response.Error(c, http.StatusNotFound, "user_not_found", "Пользователь не найден", nil)
+	case errors.Is(err, coachclientuc.ErrCannotInviteSelf):
+		response.Error(c, http.StatusBadRequest, "cannot_invite_self", "Нельзя пригласить самого себя", nil)
+	case errors.Is(err, coachclientuc.ErrAlreadyClient):
+		response.Error(c, http.StatusConflict, "already_client", "Пользователь уже ваш клиент", nil)
+	case errors.Is(err, coachclientuc.ErrAlreadyInvited):
+		response.Error(c, http.StatusConflict, "already_invited", "Пользователь уже приглашён", nil)
+	case errors.Is(err, coachclientuc.ErrTooManyInvitations):
+		response.Error(c, http.StatusUnprocessableEntity, "too_many_invitations", "Достигнут лимит действующих приглашений", nil)
+	case errors.Is(err, coachclientuc.ErrInvitationNotFound):
+		response.Error(c, http.StatusNotFound, "invitation_not_found", "Приглашение не найдено или истекло", nil)
+	default:
+		h.logger.Error("internal_error_in_"+op, map[string]any{
+			"user_id": userID.String(),
+			"path":    c.Request.URL.Path,
+			"method":  c.Request.Method,
+			"error":   err.Error(),
+		})
+		response.Error(c, http.StatusInternalServerError, "internal_error", "Внутренняя ошибка сервера", nil)
+	}
+}
+
+// invitationParams извлекает текущего пользователя и ID приглашения из пути.
+func invitationParams(c *gin.Context) (uuid.UUID, uuid.UUID, bool) {
+	userID, err := middleware.UserIDFromContext(c)
+	if err != nil {
+		response.Error(c, http.StatusUnauthorized, "unauthorized", "Требуется аутентификация", nil)
+		return uuid.Nil, uuid.Nil, false
+	}
+	invitationID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid_invitation_id", "Некорректный ID приглашения", nil)
+		return uuid.Nil, uuid.Nil, false
+	}
+	return userID, invitationID, true
+}
+
+// toInvitationResponse маппит приглашение в DTO без адреса: его видит только тренер.
+func toInvitationResponse(inv *domain.Invitation) InvitationResponse {
+	return InvitationResponse{
+		ID:        inv.ID.String(),
+		CoachID:   inv.CoachID.String(),
+		ClientID:  inv.ClientID.String(),
+		CreatedAt: inv.CreatedAt,
+		ExpiresAt: inv.ExpiresAt,
+	}
+}
+
+// toUserSummary маппит пользователя в публичные данные.
+func toUserSummary(u *userdomain.User) UserSummary {
+	return UserSummary{
+		ID:        u.ID.String(),
+		Username:  u.Username,
+		FirstName: u.FirstName,
+		LastName:  u.LastName,
+		AvatarURL: u.AvatarURL,
+	}
+}
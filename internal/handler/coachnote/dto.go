@@ -0,0 +1,26 @@
+package coachnote
+
+import "time"
+
+// NoteRequest описывает тело запроса на создание или изменение заметки.
+type NoteRequest struct {
+	Body string `json:"body" binding:"required"`
+}
+
+// NoteResponse описывает заметку тренера о клиенте.
+type NoteResponse struct {
+	ID        string    `json:"id"`
+	Body      string    `json:"body"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// TagsRequest описывает тело запроса на замену тегов клиента.
+type TagsRequest struct {
+	Tags []string `json:"tags" binding:"required"`
+}
+
+// TagsResponse описывает теги клиента.
+type TagsResponse struct {
+	Tags []string `json:"tags"`
+}
@@ -0,0 +1,289 @@
+package coachnote
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	domain "workout-app/internal/domain/coach"
+	"workout-app/internal/handler/middleware"
+	"workout-app/internal/handler/response"
+	coachnoteuc "workout-app/internal/usecase/coachnote"
+	"workout-app/pkg/logger"
+)
+
+// Handler обрабатывает HTTP-запросы, связанные с заметками и тегами тренера о клиентах.
+type Handler struct {
+	notes  coachnoteuc.Service
+	logger logger.Logger
+}
+
+// NewHandler создаёт новый CoachNoteHandler.
+func NewHandler(notes coachnoteuc.Service, logger logger.Logger) *Handler {
+	return &Handler{
+		notes:  notes,
+		logger: logger,
+	}
+}
+
+// ListNotes godoc
+// @Summary      Заметки о клиенте
+// @Description  Возвращает личные заметки текущего тренера о клиенте, новые первыми. Клиенту заметки не показываются.
+// @Tags         coach
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id   path      string  true  "ID клиента"
+// @Success      200  {array}   NoteResponse
+// @Failure      400  {object}  response.ErrorBody
+// @Failure      401  {object}  response.ErrorBody
+// @Failure      403  {object}  response.ErrorBody
+// @Failure      404  {object}  response.ErrorBody
+// @Failure      500  {object}  response.ErrorBody
+// @Router       /api/v1/coach/clients/{id}/notes [get]
+func (h *Handler) ListNotes(c *gin.Context) {
+	coachID, clientID, ok := h.pair(c)
+	if !ok {
+		return
+	}
+
+	notes, err := h.notes.ListNotes(c.Request.Context(), coachID, clientID)
+	if err != nil {
+		h.respondError(c, "list_coach_notes", coachID, err)
+		return
+	}
+
+	resp := make([]NoteResponse, 0, len(notes))
+	for _, n := range notes {
+		resp = append(resp, toNoteResponse(n))
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// CreateNote godoc
+// @Summary      Добавить заметку о клиенте
+// @Description  Сохраняет личную заметку текущего тренера о клиенте (до 5000 символов).
+// @Tags         coach
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        id       path      string       true  "ID клиента"
+// @Param        request  body      NoteRequest  true  "Текст заметки"
+// @Success      201      {object}  NoteResponse
+// @Failure      400      {object}  response.ErrorBody
+// @Failure      401      {object}  response.ErrorBody
+// @Failure      403      {object}  response.ErrorBody
+// @Failure      404      {object}  response.ErrorBody
+// @Failure      500      {object}  response.ErrorBody
+// @Router       /api/v1/coach/clients/{id}/notes [post]
+func (h *Handler) CreateNote(c *gin.Context) {
+	coachID, clientID, ok := h.pair(c)
+	if !ok {
+		return
+	}
+	var req NoteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid_request", "Некорректное тело запроса", err.Error())
+		return
+	}
+
+	n, err := h.notes.CreateNote(c.Request.Context(), coachID, clientID, req.Body)
+	if err != nil {
+		h.respondError(c, "create_coach_note", coachID, err)
+		return
+	}
+	c.JSON(http.StatusCreated, toNoteResponse(n))
+}
+
+// UpdateNote godoc
+// @Summary      Изменить заметку о клиенте
+// @Description  Заменяет текст заметки текущего тренера о клиенте.
+// @Tags         coach
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        id       path      string       true  "ID клиента"
+// @Param        noteId   path      string       true  "ID заметки"
+// @Param        request  body      NoteRequest  true  "Текст заметки"
+// @Success      200      {object}  NoteResponse
+// @Failure      400      {object}  response.ErrorBody
+// @Failure      401      {object}  response.ErrorBody
+// @Failure      403      {object}  response.ErrorBody
+// @Failure      404      {object}  response.ErrorBody
+// @Failure      500      {object}  response.ErrorBody
+// @Router       /api/v1/coach/clients/{id}/notes/{noteId} [put]
+func (h *Handler) UpdateNote(c *gin.Context) {
+	coachID, clientID, ok := h.pair(c)
+	if !ok {
+		return
+	}
+	noteID, ok := h.noteID(c)
+	if !ok {
+		return
+	}
+	var req NoteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid_request", "Некорректное тело запроса", err.Error())
+		return
+	}
+
+	n, err := h.notes.UpdateNote(c.Request.Context(), coachID, clientID, noteID, req.Body)
+	if err != nil {
+		h.respondError(c, "update_coach_note", coachID, err)
+		return
+	}
+	c.JSON(http.StatusOK, toNoteResponse(n))
+}
+
+// DeleteNote godoc
+// @Summary      Удалить заметку о клиенте
+// @Tags         coach
+// @Security     BearerAuth
+// @Param        id      path  string  true  "ID клиента"
+// @Param        noteId  path  string  true  "ID заметки"
+// @Success      204
+// @Failure      400  {object}  response.ErrorBody
+// @Failure      401  {object}  response.ErrorBody
+// @Failure      403  {object}  response.ErrorBody
+// @Failure      404  {object}  response.ErrorBody
+// @Failure      500  {object}  response.ErrorBody
+// @Router       /api/v1/coach/clients/{id}/notes/{noteId} [delete]
+func (h *Handler) DeleteNote(c *gin.Context) {
+	coachID, clientID, ok := h.pair(c)
+	if !ok {
+		return
+	}
+	noteID, ok := h.noteID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.notes.DeleteNote(c.Request.Context(), coachID, clientID, noteID); err != nil {
+		h.respondError(c, "delete_coach_note", coachID, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// ListTags godoc
+// @Summary      Теги клиента
+// @Description  Возвращает теги, которыми текущий тренер отметил клиента. Клиенту теги не показываются.
+// @Tags         coach
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id   path      string  true  "ID клиента"
+// @Success      200  {object}  TagsResponse
+// @Failure      400  {object}  response.ErrorBody
+// @Failure      401  {object}  response.ErrorBody
+// @Failure      403  {object}  response.ErrorBody
+// @Failure      404  {object}  response.ErrorBody
+// @Failure      500  {object}  response.ErrorBody
+// @Router       /api/v1/coach/clients/{id}/tags [get]
+func (h *Handler) ListTags(c *gin.Context) {
+	coachID, clientID, ok := h.pair(c)
+	if !ok {
+		return
+	}
+
+	tags, err := h.notes.ListTags(c.Request.Context(), coachID, clientID)
+	if err != nil {
+		h.respondError(c, "list_coach_tags", coachID, err)
+		return
+	}
+	c.JSON(http.StatusOK, TagsResponse{Tags: tags})
+}
+
+// SetTags godoc
+// @Summary      Заменить теги клиента
+// @Description  Заменяет теги клиента (до 20 тегов по 32 символа). Теги приводятся к нижнему регистру; пустой список снимает все теги.
+// @Tags         coach
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        id       path      string       true  "ID клиента"
+// @Param        request  body      TagsRequest  true  "Теги"
+// @Success      200      {object}  TagsResponse
+// @Failure      400      {object}  response.ErrorBody
+// @Failure      401      {object}  response.ErrorBody
+// @Failure      403      {object}  response.ErrorBody
+// @Failure      404      {object}  response.ErrorBody
+// @Failure      500      {object}  response.ErrorBody
+// @Router       /api/v1/coach/clients/{id}/tags [put]
+func (h *Handler) SetTags(c *gin.Context) {
+	coachID, clientID, ok := h.pair(c)
+	if !ok {
+		return
+	}
+	var req TagsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid_request", "Некорректное тело запроса", err.Error())
+		return
+	}
+
+	tags, err := h.notes.SetTags(c.Request.Context(), coachID, clientID, req.Tags)
+	if err != nil {
+		h.respondError(c, "set_coach_tags", coachID, err)
+		return
+	}
+	c.JSON(http.StatusOK, TagsResponse{Tags: tags})
+}
+
+// pair извлекает текущего тренера и клиента из пути.
+func (h *Handler) pair(c *gin.Context) (uuid.UUID, uuid.UUID, bool) {
+	coachID, err := middleware.UserIDFromContext(c)
+	if err != nil {
+		response.Error(c, http.StatusUnauthorized, "unauthorized", "Требуется аутентификация", nil)
+		return uuid.Nil, uuid.Nil, false
+	}
+	clientID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid_user_id", "Некорректный ID пользователя", nil)
+		return uuid.Nil, uuid.Nil, false
+	}
+	return coachID, clientID, true
+}
+
+// noteID извлекает ID заметки из пути.
+func (h *Handler) noteID(c *gin.Context) (uuid.UUID, bool) {
+	id, err := uuid.Parse(c.Param("noteId"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid_note_id", "Некорректный ID заметки", nil)
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+// respondError маппит ошибки usecase-слоя в HTTP-ответы.
+func (h *Handler) respondError(c *gin.Context, op string, coachID uuid.UUID, err error) {
+	switch {
+	case errors.Is(err, coachnoteuc.ErrNotCoachClient):
+		response.Error(c, http.StatusNotFound, "client_not_found", "Клиент не найден", nil)
+	case errors.Is(err, coachnoteuc.ErrNoteNotFound):
+		response.Error(c, http.StatusNotFound, "note_not_found", "Заметка не найдена", nil)
+	case errors.Is(err, coachnoteuc.ErrInvalidNote):
+		response.Error(c, http.StatusBadRequest, "invalid_note", "Заметка должна содержать от 1 до 5000 символов", nil)
+	case errors.Is(err, coachnoteuc.ErrInvalidTag):
+		response.Error(c, http.StatusBadRequest, "invalid_tag", "Тег должен содержать от 1 до 32 букв, цифр, пробелов, '-' или '_'", nil)
+	case errors.Is(err, coachnoteuc.ErrTooManyTags):
+		response.Error(c, http.StatusBadRequest, "too_many_tags", "У клиента может быть не больше 20 тегов", nil)
+	default:
+		h.logger.Error("internal_error_in_"+op, map[string]any{
+			"coach_id": coachID.String(),
+			"path":     c.Request.URL.Path,
+			"method":   c.Request.Method,
+			"error":    err.Error(),
+		})
+		response.Error(c, http.StatusInternalServerError, "internal_error", "Внутренняя ошибка сервера", nil)
+	}
+}
+
+// toNoteResponse маппит заметку в DTO.
+func toNoteResponse(n *domain.Note) NoteResponse {
+	return NoteResponse{
+		ID:        n.ID.String(),
+		Body:      n.Body,
+		CreatedAt: n.CreatedAt,
+		UpdatedAt: n.UpdatedAt,
+	}
+}
@@ -0,0 +1,17 @@
+package consent
+
+import "time"
+
+// ConsentResponse описывает классы данных, открытые клиентом тренеру.
+type ConsentResponse struct {
+	CoachID   string    `json:"coach_id"`
+	Scopes    []string  `json:"scopes"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// SetConsentRequest описывает тело запроса для изменения согласия.
+type SetConsentRequest struct {
+	// Scopes — открытые тренеру классы данных: workouts, measurements, nutrition, wellbeing.
+	// Пустой список запрещает тренеру доступ ко всем данным.
+	Scopes []string `json:"scopes" binding:"required"`
+}
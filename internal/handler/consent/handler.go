@@ -0,0 +1,194 @@
+package consent
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	domain "workout-app/internal/domain/consent"
+	"workout-app/internal/handler/middleware"
+	"workout-app/internal/handler/response"
+	repo "workout-app/internal/repository/interfaces"
+	consentuc "workout-app/internal/usecase/consent"
+	"workout-app/pkg/logger"
+)
+
+// Handler обрабатывает HTTP-запросы настроек доступа тренеров к данным клиента.
+type Handler struct {
+	consents consentuc.Service
+	logger   logger.Logger
+}
+
+// NewHandler создаёт новый ConsentHandler.
+func NewHandler(consents consentuc.Service, logger logger.Logger) *Handler {
+	return &Handler{
+		consents: consents,
+		logger:   logger,
+	}
+}
+
+// List godoc
+// @Summary      Получить согласия на доступ тренеров
+// @Description  Возвращает тренеров, которым текущий пользователь открыл доступ, и классы данных (workouts, measurements, nutrition, wellbeing) для каждого.
+// @Tags         consents
+// @Security     BearerAuth
+// @Produce      json
+// @Success      200  {array}   ConsentResponse
+// @Failure      401  {object}  response.ErrorBody
+// @Failure      500  {object}  response.ErrorBody
+// @Router       /api/v1/users/me/coach-consents [get]
+func (h *Handler) List(c *gin.Context) {
+	userID, err := middleware.UserIDFromContext(c)
+	if err != nil {
+		response.Error(c, http.StatusUnauthorized, "unauthorized", "Требуется аутентификация", nil)
+		return
+	}
+
+	consents, err := h.consents.List(c.Request.Context(), userID)
+	if err != nil {
+		h.logger.Error("internal_error_in_list_consents", map[string]any{
+			"user_id": userID.String(),
+			"path":    c.Request.URL.Path,
+			"method":  c.Request.Method,
+			"error":   err.Error(),
+		})
+		response.Error(c, http.StatusInternalServerError, "internal_error", "Внутренняя ошибка сервера", nil)
+		return
+	}
+
+	resp := make([]ConsentResponse, 0, len(consents))
+	for _, cn := range consents {
+		resp = append(resp, toConsentResponse(cn))
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// Set godoc
+// @Summary      Изменить согласие на доступ тренера
+// @Description  Заменяет набор классов данных, открытых тренеру. Тренер должен быть связан с пользователем (пользователь принял его приглашение).
+// @Tags         consents
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        coachId  path      string             true  "ID тренера"
+// @Param        payload  body      SetConsentRequest  true  "Открытые классы данных"
+// @Success      200      {object}  ConsentResponse
+// @Failure      400      {object}  response.ErrorBody
+// @Failure      401      {object}  response.ErrorBody
+// @Failure      404      {object}  response.ErrorBody
+// @Failure      500      {object}  response.ErrorBody
+// @Router       /api/v1/users/me/coach-consents/{coachId} [put]
+func (h *Handler) Set(c *gin.Context) {
+	userID, err := middleware.UserIDFromContext(c)
+	if err != nil {
+		response.Error(c, http.StatusUnauthorized, "unauthorized", "Требуется аутентификация", nil)
+		return
+	}
+
+	coachID, err := uuid.Parse(c.Param("coachId"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid_coach_id", "Некорректный ID тренера", nil)
+		return
+	}
+
+	var req SetConsentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid_request", "Некорректное тело запроса", err.Error())
+		return
+	}
+
+	scopes := make([]domain.Scope, 0, len(req.Scopes))
+	for _, s := range req.Scopes {
+		scopes = append(scopes, domain.Scope(s))
+	}
+
+	cn, err := h.consents.Set(c.Request.Context(), userID, coachID, scopes)
+	if err != nil {
+		switch {
+		case errors.Is(err, consentuc.ErrInvalidScope):
+			response.Error(c, http.StatusBadRequest, "invalid_scope", "Допустимые классы данных: workouts, measurements, nutrition, wellbeing", nil)
+		case errors.Is(err, consentuc.ErrNotCoachClient):
+			response.Error(c, http.StatusNotFound, "coach_not_found", "Тренер не найден среди ваших тренеров", nil)
+		default:
+			h.logger.Error("internal_error_in_set_consent", map[string]any{
+				"user_id":  userID.String(),
+				"coach_id": coachID.String(),
+				"path":     c.Request.URL.Path,
+				"method":   c.Request.Method,
+				"error":    err.Error(),
+			})
+			response.Error(c, http.StatusInternalServerError, "internal_error", "Внутренняя ошибка сервера", nil)
+		}
+		return
+	}
+
+	h.logger.Info("coach_consent_updated", map[string]any{
+		"user_id":  userID.String(),
+		"coach_id": coachID.String(),
+		"scopes":   req.Scopes,
+	})
+	c.JSON(http.StatusOK, toConsentResponse(cn))
+}
+
+// Revoke godoc
+// @Summary      Отозвать согласие на доступ тренера
+// @Description  Закрывает тренеру доступ ко всем данным текущего пользователя.
+// @Tags         consents
+// @Security     BearerAuth
+// @Param        coachId  path  string  true  "ID тренера"
+// @Success      204
+// @Failure      400  {object}  response.ErrorBody
+// @Failure      401  {object}  response.ErrorBody
+// @Failure      404  {object}  response.ErrorBody
+// @Failure      500  {object}  response.ErrorBody
+// @Router       /api/v1/users/me/coach-consents/{coachId} [delete]
+func (h *Handler) Revoke(c *gin.Context) {
+	userID, err := middleware.UserIDFromContext(c)
+	if err != nil {
+		response.Error(c, http.StatusUnauthorized, "unauthorized", "Требуется аутентификация", nil)
+		return
+	}
+
+	coachID, err := uuid.Parse(c.Param("coachId"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid_coach_id", "Некорректный ID тренера", nil)
+		return
+	}
+
+	if err := h.consents.Revoke(c.Request.Context(), userID, coachID); err != nil {
+		if errors.Is(err, repo.ErrNotFound) {
+			response.Error(c, http.StatusNotFound, "consent_not_found", "Согласие не найдено", nil)
+			return
+		}
+		h.logger.Error("internal_error_in_revoke_consent", map[string]any{
+			"user_id":  userID.String(),
+			"coach_id": coachID.String(),
+			"path":     c.Request.URL.Path,
+			"method":   c.Request.Method,
+			"error":    err.Error(),
+		})
+		response.Error(c, http.StatusInternalServerError, "internal_error", "Внутренняя ошибка сервера", nil)
+		return
+	}
+
+	h.logger.Info("coach_consent_revoked", map[string]any{
+		"user_id":  userID.String(),
+		"coach_id": coachID.String(),
+	})
+	c.Status(http.StatusNoContent)
+}
+
+// toConsentResponse маппит доменную модель в DTO.
+func toConsentResponse(cn *domain.Consent) ConsentResponse {
+	scopes := make([]string, 0, len(cn.Scopes))
+	for _, s := range cn.Scopes {
+		scopes = append(scopes, string(s))
+	}
+	return ConsentResponse{
+		CoachID:   cn.CoachID.String(),
+		Scopes:    scopes,
+		UpdatedAt: cn.UpdatedAt,
+	}
+}
@@ -0,0 +1,71 @@
+package custommetric
+
+import "time"
+
+// MetricRequest описывает тело запроса на создание или изменение пользовательской метрики.
+type MetricRequest struct {
+	Name string `json:"name" binding:"required,max=64"`
+	Unit string `json:"unit" binding:"required,max=16"`
+	// HigherIsBetter — направление улучшения; не задано — больше значит лучше.
+	HigherIsBetter *bool `json:"higher_is_better,omitempty"`
+}
+
+// EntryRequest описывает тело запроса на запись значения метрики.
+type EntryRequest struct {
+	Value *float64 `json:"value" binding:"required"`
+	// RecordedAt — момент измерения (RFC3339); не задан — текущий момент.
+	RecordedAt *time.Time `json:"recorded_at,omitempty"`
+	Note       string     `json:"note,omitempty" binding:"max=500"`
+}
+
+// MetricResponse описывает пользовательскую метрику.
+type MetricResponse struct {
+	ID             string         `json:"id"`
+	Name           string         `json:"name"`
+	Unit           string         `json:"unit"`
+	HigherIsBetter bool           `json:"higher_is_better"`
+	Latest         *EntryResponse `json:"latest,omitempty"` // Последнее значение (только в списке)
+	CreatedAt      time.Time      `json:"created_at"`
+	UpdatedAt      time.Time      `json:"updated_at"`
+}
+
+// EntryResponse описывает значение метрики.
+type EntryResponse struct {
+	ID         string    `json:"id"`
+	Value      float64   `json:"value"`
+	RecordedAt time.Time `json:"recorded_at"`
+	Note       string    `json:"note,omitempty"`
+}
+
+// PointResponse описывает агрегированные значения метрики за интервал.
+type PointResponse struct {
+	Start time.Time `json:"start"`
+	Count int       `json:"count"`
+	Min   float64   `json:"min"`
+	Max   float64   `json:"max"`
+	Avg   float64   `json:"avg"`
+	Last  float64   `json:"last"`
+}
+
+// SummaryResponse описывает итоги метрики за период.
+type SummaryResponse struct {
+	Count  int     `json:"count"`
+	First  float64 `json:"first"`
+	Last   float64 `json:"last"`
+	Min    float64 `json:"min"`
+	Max    float64 `json:"max"`
+	Best   float64 `json:"best"`   // Лучшее значение с учётом higher_is_better
+	Change float64 `json:"change"` // last - first
+}
+
+// StatsResponse описывает данные для графика метрики.
+type StatsResponse struct {
+	Metric MetricResponse `json:"metric"`
+	From   time.Time      `json:"from"`
+	To     time.Time      `json:"to"`
+	Bucket string         `json:"bucket"`
+	// Timezone — часовой пояс пользователя, в котором считаются интервалы (UTC, если не задан в профиле).
+	Timezone string          `json:"timezone"`
+	Points   []PointResponse `json:"points"`
+	Summary  SummaryResponse `json:"summary"`
+}
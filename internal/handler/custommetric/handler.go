@@ -0,0 +1,484 @@
+package custommetric
+
+import (
+	"errors"
+	"math"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	domain "workout-app/internal/domain/custommetric"
+	"workout-app/internal/handler/middleware"
+	"workout-app/internal/handler/response"
+	custommetricuc "workout-app/internal/usecase/custommetric"
+	"workout-app/pkg/logger"
+)
+
+// defaultPeriod — период выборки, если from не задан.
+const defaultPeriod = 90 * 24 * time.Hour
+
+// Handler обрабатывает HTTP-запросы, связанные с пользовательскими метриками.
+type Handler struct {
+	metrics custommetricuc.Service
+	logger  logger.Logger
+}
+
+// NewHandler создаёт новый CustomMetricHandler.
+func NewHandler(metrics custommetricuc.Service, logger logger.Logger) *Handler {
+	return &Handler{
+		metrics: metrics,
+		logger:  logger,
+	}
+}
+
+// Create godoc
+// @Summary      Создать пользовательскую метрику
+// @Description  Заводит метрику с единицей измерения (например, «Сила хвата», «кг»). Название уникально для пользователя без учёта регистра; не больше 50 метрик.
+// @Tags         custom-metrics
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        payload  body      MetricRequest  true  "Метрика"
+// @Success      201      {object}  MetricResponse
+// @Failure      400      {object}  response.ErrorBody
+// @Failure      401      {object}  response.ErrorBody
+// @Failure      409      {object}  response.ErrorBody
+// @Failure      500      {object}  response.ErrorBody
+// @Router       /api/v1/custom-metrics [post]
+func (h *Handler) Create(c *gin.Context) {
+	userID, ok := h.userID(c)
+	if !ok {
+		return
+	}
+	var req MetricRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid_request", "Некорректное тело запроса", err.Error())
+		return
+	}
+
+	def, err := h.metrics.Create(c.Request.Context(), userID, toDefinitionInput(req))
+	if err != nil {
+		h.respondError(c, "create_custom_metric", userID, err)
+		return
+	}
+	c.JSON(http.StatusCreated, toMetricResponse(def, nil))
+}
+
+// List godoc
+// @Summary      Список пользовательских метрик
+// @Description  Возвращает метрики текущего пользователя по названию с последним значением каждой.
+// @Tags         custom-metrics
+// @Security     BearerAuth
+// @Produce      json
+// @Success      200  {array}   MetricResponse
+// @Failure      401  {object}  response.ErrorBody
+// @Failure      500  {object}  response.ErrorBody
+// @Router       /api/v1/custom-metrics [get]
+func (h *Handler) List(c *gin.Context) {
+	userID, ok := h.userID(c)
+	if !ok {
+		return
+	}
+
+	metrics, err := h.metrics.List(c.Request.Context(), userID)
+	if err != nil {
+		h.respondError(c, "list_custom_metrics", userID, err)
+		return
+	}
+	resp := make([]MetricResponse, 0, len(metrics))
+	for _, m := range metrics {
+		resp = append(resp, toMetricResponse(m.Definition, m.Latest))
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// Update godoc
+// @Summary      Изменить пользовательскую метрику
+// @Description  Изменяет название, единицу измерения и направление улучшения метрики. Сохранённые значения не пересчитываются.
+// @Tags         custom-metrics
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        id       path      string         true  "ID метрики"
+// @Param        payload  body      MetricRequest  true  "Метрика"
+// @Success      200      {object}  MetricResponse
+// @Failure      400      {object}  response.ErrorBody
+// @Failure      401      {object}  response.ErrorBody
+// @Failure      404      {object}  response.ErrorBody
+// @Failure      409      {object}  response.ErrorBody
+// @Failure      500      {object}  response.ErrorBody
+// @Router       /api/v1/custom-metrics/{id} [put]
+func (h *Handler) Update(c *gin.Context) {
+	userID, ok := h.userID(c)
+	if !ok {
+		return
+	}
+	metricID, ok := parseID(c, "id", "Некорректный ID метрики")
+	if !ok {
+		return
+	}
+	var req MetricRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid_request", "Некорректное тело запроса", err.Error())
+		return
+	}
+
+	def, err := h.metrics.Update(c.Request.Context(), userID, metricID, toDefinitionInput(req))
+	if err != nil {
+		h.respondError(c, "update_custom_metric", userID, err)
+		return
+	}
+	c.JSON(http.StatusOK, toMetricResponse(def, nil))
+}
+
+// Delete godoc
+// @Summary      Удалить пользовательскую метрику
+// @Description  Удаляет метрику вместе со всеми её значениями.
+// @Tags         custom-metrics
+// @Security     BearerAuth
+// @Param        id   path  string  true  "ID метрики"
+// @Success      204
+// @Failure      400  {object}  response.ErrorBody
+// @Failure      401  {object}  response.ErrorBody
+// @Failure      404  {object}  response.ErrorBody
+// @Failure      500  {object}  response.ErrorBody
+// @Router       /api/v1/custom-metrics/{id} [delete]
+func (h *Handler) Delete(c *gin.Context) {
+	userID, ok := h.userID(c)
+	if !ok {
+		return
+	}
+	metricID, ok := parseID(c, "id", "Некорректный ID метрики")
+	if !ok {
+		return
+	}
+
+	if err := h.metrics.Delete(c.Request.Context(), userID, metricID); err != nil {
+		h.respondError(c, "delete_custom_metric", userID, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// AddEntry godoc
+// @Summary      Записать значение метрики
+// @Description  Сохраняет значение метрики на момент recorded_at (по умолчанию — сейчас). Момент измерения не может быть в будущем.
+// @Tags         custom-metrics
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        id       path      string        true  "ID метрики"
+// @Param        payload  body      EntryRequest  true  "Значение"
+// @Success      201      {object}  EntryResponse
+// @Failure      400      {object}  response.ErrorBody
+// @Failure      401      {object}  response.ErrorBody
+// @Failure      404      {object}  response.ErrorBody
+// @Failure      500      {object}  response.ErrorBody
+// @Router       /api/v1/custom-metrics/{id}/entries [post]
+func (h *Handler) AddEntry(c *gin.Context) {
+	userID, ok := h.userID(c)
+	if !ok {
+		return
+	}
+	metricID, ok := parseID(c, "id", "Некорректный ID метрики")
+	if !ok {
+		return
+	}
+	var req EntryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid_request", "Некорректное тело запроса", err.Error())
+		return
+	}
+
+	entry, err := h.metrics.AddEntry(c.Request.Context(), userID, metricID, custommetricuc.EntryInput{
+		Value:      *req.Value,
+		RecordedAt: req.RecordedAt,
+		Note:       req.Note,
+	})
+	if err != nil {
+		h.respondError(c, "add_custom_metric_entry", userID, err)
+		return
+	}
+	c.JSON(http.StatusCreated, toEntryResponse(entry))
+}
+
+// ListEntries godoc
+// @Summary      Значения метрики
+// @Description  Возвращает значения метрики за период [from, to) (по умолчанию — последние 90 дней), старые первыми. Не больше 5000 значений.
+// @Tags         custom-metrics
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id    path      string  true   "ID метрики"
+// @Param        from  query     string  false  "Начало периода (RFC3339 или YYYY-MM-DD)"
+// @Param        to    query     string  false  "Конец периода (RFC3339 или YYYY-MM-DD включительно)"
+// @Success      200   {array}   EntryResponse
+// @Failure      400   {object}  response.ErrorBody
+// @Failure      401   {object}  response.ErrorBody
+// @Failure      404   {object}  response.ErrorBody
+// @Failure      500   {object}  response.ErrorBody
+// @Router       /api/v1/custom-metrics/{id}/entries [get]
+func (h *Handler) ListEntries(c *gin.Context) {
+	userID, ok := h.userID(c)
+	if !ok {
+		return
+	}
+	metricID, ok := parseID(c, "id", "Некорректный ID метрики")
+	if !ok {
+		return
+	}
+	from, to, ok := parsePeriod(c)
+	if !ok {
+		return
+	}
+
+	entries, err := h.metrics.ListEntries(c.Request.Context(), userID, metricID, from, to)
+	if err != nil {
+		h.respondError(c, "list_custom_metric_entries", userID, err)
+		return
+	}
+	resp := make([]EntryResponse, 0, len(entries))
+	for _, e := range entries {
+		resp = append(resp, toEntryResponse(e))
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// DeleteEntry godoc
+// @Summary      Удалить значение метрики
+// @Tags         custom-metrics
+// @Security     BearerAuth
+// @Param        id        path  string  true  "ID метрики"
+// @Param        entry_id  path  string  true  "ID значения"
+// @Success      204
+// @Failure      400  {object}  response.ErrorBody
+// @Failure      401  {object}  response.ErrorBody
+// @Failure      404  {object}  response.ErrorBody
+// @Failure      500  {object}  response.ErrorBody
+// @Router       /api/v1/custom-metrics/{id}/entries/{entry_id} [delete]
+func (h *Handler) DeleteEntry(c *gin.Context) {
+	userID, ok := h.userID(c)
+	if !ok {
+		return
+	}
+	metricID, ok := parseID(c, "id", "Некорректный ID метрики")
+	if !ok {
+		return
+	}
+	entryID, ok := parseID(c, "entry_id", "Некорректный ID значения")
+	if !ok {
+		return
+	}
+
+	if err := h.metrics.DeleteEntry(c.Request.Context(), userID, metricID, entryID); err != nil {
+		h.respondError(c, "delete_custom_metric_entry", userID, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// Stats godoc
+// @Summary      Данные для графика метрики
+// @Description  Агрегирует значения метрики за период [from, to) (по умолчанию — последние 90 дней) по дням, неделям (с понедельника) или месяцам в часовом поясе из профиля пользователя и возвращает итоги: первое, последнее, минимальное, максимальное и лучшее значение.
+// @Tags         custom-metrics
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id      path      string  true   "ID метрики"
+// @Param        from    query     string  false  "Начало периода (RFC3339 или YYYY-MM-DD)"
+// @Param        to      query     string  false  "Конец периода (RFC3339 или YYYY-MM-DD включительно)"
+// @Param        bucket  query     string  false  "Шаг агрегации: day (по умолчанию), week или month"
+// @Success      200     {object}  StatsResponse
+// @Failure      400     {object}  response.ErrorBody
+// @Failure      401     {object}  response.ErrorBody
+// @Failure      404     {object}  response.ErrorBody
+// @Failure      500     {object}  response.ErrorBody
+// @Router       /api/v1/custom-metrics/{id}/stats [get]
+func (h *Handler) Stats(c *gin.Context) {
+	userID, ok := h.userID(c)
+	if !ok {
+		return
+	}
+	metricID, ok := parseID(c, "id", "Некорректный ID метрики")
+	if !ok {
+		return
+	}
+	from, to, ok := parsePeriod(c)
+	if !ok {
+		return
+	}
+	bucket := domain.Bucket(c.DefaultQuery("bucket", string(domain.BucketDay)))
+
+	stats, err := h.metrics.Stats(c.Request.Context(), userID, metricID, from, to, bucket)
+	if err != nil {
+		h.respondError(c, "custom_metric_stats", userID, err)
+		return
+	}
+
+	points := make([]PointResponse, 0, len(stats.Points))
+	for _, p := range stats.Points {
+		points = append(points, PointResponse{
+			Start: p.Start,
+			Count: p.Count,
+			Min:   p.Min,
+			Max:   p.Max,
+			Avg:   round2(p.Avg),
+			Last:  p.Last,
+		})
+	}
+	c.JSON(http.StatusOK, StatsResponse{
+		Metric:   toMetricResponse(stats.Definition, nil),
+		From:     stats.From,
+		To:       stats.To,
+		Bucket:   string(stats.Bucket),
+		Timezone: stats.Timezone,
+		Points:   points,
+		Summary: SummaryResponse{
+			Count:  stats.Summary.Count,
+			First:  stats.Summary.First,
+			Last:   stats.Summary.Last,
+			Min:    stats.Summary.Min,
+			Max:    stats.Summary.Max,
+			Best:   stats.Summary.Best,
+			Change: round2(stats.Summary.Change),
+		},
+	})
+}
+
+// userID извлекает ID текущего пользователя и отвечает 401, если его нет.
+func (h *Handler) userID(c *gin.Context) (uuid.UUID, bool) {
+	userID, err := middleware.UserIDFromContext(c)
+	if err != nil {
+		response.Error(c, http.StatusUnauthorized, "unauthorized", "Требуется аутентификация", nil)
+		return uuid.Nil, false
+	}
+	return userID, true
+}
+
+// respondError маппит ошибки usecase-слоя в HTTP-ответы.
+func (h *Handler) respondError(c *gin.Context, op string, userID uuid.UUID, err error) {
+	switch {
+	case errors.Is(err, custommetricuc.ErrInvalidName):
+		response.Error(c, http.StatusBadRequest, "invalid_name", "Название метрики должно содержать от 1 до 64 символов", nil)
+	case errors.Is(err, custommetricuc.ErrInvalidUnit):
+		response.Error(c, http.StatusBadRequest, "invalid_unit", "Единица измерения должна содержать от 1 до 16 символов", nil)
+	case errors.Is(err, custommetricuc.ErrInvalidValue):
+		response.Error(c, http.StatusBadRequest, "invalid_value", "Некорректное значение метрики", nil)
+	case errors.Is(err, custommetricuc.ErrInvalidNote):
+		response.Error(c, http.StatusBadRequest, "invalid_note", "Комментарий не должен превышать 500 символов", nil)
+	case errors.Is(err, custommetricuc.ErrInvalidRecordedAt):
+		response.Error(c, http.StatusBadRequest, "invalid_recorded_at", "Момент измерения не может быть в будущем", nil)
+	case errors.Is(err, custommetricuc.ErrInvalidPeriod):
+		response.Error(c, http.StatusBadRequest, "invalid_range", "Некорректный период", nil)
+	case errors.Is(err, custommetricuc.ErrInvalidBucket):
+		response.Error(c, http.StatusBadRequest, "invalid_bucket", "Шаг агрегации должен быть day, week или month", nil)
+	case errors.Is(err, custommetricuc.ErrTooManyStatsPoints):
+		response.Error(c, http.StatusBadRequest, "too_many_entries", "Слишком много значений за период, сократите период", nil)
+	case errors.Is(err, custommetricuc.ErrTooManyMetrics):
+		response.Error(c, http.StatusBadRequest, "too_many_metrics", "Превышено количество пользовательских метрик", nil)
+	case errors.Is(err, custommetricuc.ErrMetricExists):
+		response.Error(c, http.StatusConflict, "metric_exists", "Метрика с таким названием уже существует", nil)
+	case errors.Is(err, custommetricuc.ErrMetricNotFound):
+		response.Error(c, http.StatusNotFound, "metric_not_found", "Метрика не найдена", nil)
+	case errors.Is(err, custommetricuc.ErrEntryNotFound):
+		response.Error(c, http.StatusNotFound, "entry_not_found", "Значение метрики не найдено", nil)
+	default:
+		h.logger.Error("internal_error_in_"+op, map[string]any{
+			"user_id": userID.String(),
+			"path":    c.Request.URL.Path,
+			"method":  c.Request.Method,
+			"error":   err.Error(),
+		})
+		response.Error(c, http.StatusInternalServerError, "internal_error", "Внутренняя ошибка сервера", nil)
+	}
+}
+
+// parseID извлекает UUID из параметра пути и отвечает 400, если он некорректен.
+func parseID(c *gin.Context, param, message string) (uuid.UUID, bool) {
+	id, err := uuid.Parse(c.Param(param))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid_request", message, nil)
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+// parsePeriod разбирает параметры from/to как полуинтервал [from, to).
+func parsePeriod(c *gin.Context) (time.Time, time.Time, bool) {
+	to := time.Now().UTC()
+	if raw := c.Query("to"); raw != "" {
+		t, err := parseTimeParam(raw, true)
+		if err != nil {
+			response.Error(c, http.StatusBadRequest, "invalid_request", "Некорректный параметр to", nil)
+			return time.Time{}, time.Time{}, false
+		}
+		to = t
+	}
+	from := to.Add(-defaultPeriod)
+	if raw := c.Query("from"); raw != "" {
+		t, err := parseTimeParam(raw, false)
+		if err != nil {
+			response.Error(c, http.StatusBadRequest, "invalid_request", "Некорректный параметр from", nil)
+			return time.Time{}, time.Time{}, false
+		}
+		from = t
+	}
+	return from, to, true
+}
+
+// parseTimeParam разбирает RFC3339 или YYYY-MM-DD; для конца периода дата сдвигается на начало следующего дня.
+func parseTimeParam(raw string, nextDay bool) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, raw); err == nil {
+		return t, nil
+	}
+	t, err := time.Parse("2006-01-02", raw)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if nextDay {
+		t = t.Add(24 * time.Hour)
+	}
+	return t, nil
+}
+
+func toDefinitionInput(req MetricRequest) custommetricuc.DefinitionInput {
+	higherIsBetter := true
+	if req.HigherIsBetter != nil {
+		higherIsBetter = *req.HigherIsBetter
+	}
+	return custommetricuc.DefinitionInput{
+		Name:           req.Name,
+		Unit:           req.Unit,
+		HigherIsBetter: higherIsBetter,
+	}
+}
+
+// toMetricResponse маппит доменную модель в DTO.
+func toMetricResponse(d *domain.Definition, latest *domain.Entry) MetricResponse {
+	resp := MetricResponse{
+		ID:             d.ID.String(),
+		Name:           d.Name,
+		Unit:           d.Unit,
+		HigherIsBetter: d.HigherIsBetter,
+		CreatedAt:      d.CreatedAt,
+		UpdatedAt:      d.UpdatedAt,
+	}
+	if latest != nil {
+		e := toEntryResponse(latest)
+		resp.Latest = &e
+	}
+	return resp
+}
+
+func toEntryResponse(e *domain.Entry) EntryResponse {
+	return EntryResponse{
+		ID:         e.ID.String(),
+		Value:      e.Value,
+		RecordedAt: e.RecordedAt,
+		Note:       e.Note,
+	}
+}
+
+func round2(v float64) float64 {
+	return math.Round(v*100) / 100
+}
@@ -0,0 +1,42 @@
+package dataimport
+
+import "time"
+
+// ImportResponse описывает импорт исторических данных и ход его обработки.
+type ImportResponse struct {
+	ID     string `json:"id"`
+	Source string `json:"source,omitempty"`
+	// Status — pending (в очереди), running (обрабатывается), completed или failed.
+	Status     string     `json:"status"`
+	Total      int        `json:"total"`
+	Processed  int        `json:"processed"`
+	Imported   int        `json:"imported"`
+	Invalid    int        `json:"invalid"`
+	Duplicates int        `json:"duplicates"`
+	Error      string     `json:"error,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+	StartedAt  *time.Time `json:"started_at,omitempty"`
+	FinishedAt *time.Time `json:"finished_at,omitempty"`
+}
+
+// ImportListResponse описывает последние импорты пользователя.
+type ImportListResponse struct {
+	Items []ImportResponse `json:"items"`
+}
+
+// RecordResponse описывает результат обработки строки файла.
+type RecordResponse struct {
+	Line       int    `json:"line"`
+	Type       string `json:"type,omitempty"`
+	ExternalID string `json:"external_id,omitempty"`
+	// Status — pending, imported, invalid (причина в error) или duplicate.
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+	// EntityID — созданная тренировка или замер.
+	EntityID *string `json:"entity_id,omitempty"`
+}
+
+// RecordListResponse описывает страницу результатов по строкам файла.
+type RecordListResponse struct {
+	Items []RecordResponse `json:"items"`
+}
@@ -0,0 +1,267 @@
+package dataimport
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	domain "workout-app/internal/domain/dataimport"
+	"workout-app/internal/handler/middleware"
+	"workout-app/internal/handler/response"
+	importuc "workout-app/internal/usecase/dataimport"
+	"workout-app/pkg/logger"
+)
+
+// Handler обрабатывает запросы импорта исторических данных.
+type Handler struct {
+	imports       importuc.Service
+	maxBytes      int64
+	uploadTimeout time.Duration
+	logger        logger.Logger
+}
+
+// NewHandler создаёт новый ImportHandler.
+// uploadTimeout заменяет таймауты чтения и записи сервера на время загрузки файла.
+func NewHandler(imports importuc.Service, maxBytes int64, uploadTimeout time.Duration, logger logger.Logger) *Handler {
+	return &Handler{
+		imports:       imports,
+		maxBytes:      maxBytes,
+		uploadTimeout: uploadTimeout,
+		logger:        logger,
+	}
+}
+
+// Upload godoc
+// @Summary      Импорт истории из другого приложения
+// @Description  Принимает файл NDJSON (одна запись JSON на строку) с завершёнными тренировками ({"type":"workout","id","title","started_at","finished_at","sets":[{"exercise","reps","weight_kg","logged_at"}]}) и замерами ({"type":"measurement","id","measured_at","weight_kg","body_fat_percent","measurements"}). Файл читается потоком, записи обрабатываются в фоне; отвечает 202 с импортом в очереди. Записи с уже импортированным id пропускаются как дубликаты. Результат по каждой строке — в GET /imports/{id}/records. Одновременно обрабатывается один импорт пользователя.
+// @Tags         imports
+// @Security     BearerAuth
+// @Accept       application/x-ndjson
+// @Produce      json
+// @Param        source  query     string  false  "Приложение, из которого перенесены данные (до 64 символов)"
+// @Success      202     {object}  ImportResponse
+// @Failure      400     {object}  response.ErrorBody
+// @Failure      401     {object}  response.ErrorBody
+// @Failure      409     {object}  response.ErrorBody
+// @Failure      413     {object}  response.ErrorBody
+// @Failure      500     {object}  response.ErrorBody
+// @Router       /api/v1/imports [post]
+func (h *Handler) Upload(c *gin.Context) {
+	userID, err := middleware.UserIDFromContext(c)
+	if err != nil {
+		response.Error(c, http.StatusUnauthorized, "unauthorized", "Требуется аутентификация", nil)
+		return
+	}
+
+	// Большой файл загружается дольше общих таймаутов сервера.
+	rc := http.NewResponseController(c.Writer)
+	deadline := time.Now().Add(h.uploadTimeout)
+	if err := errors.Join(rc.SetReadDeadline(deadline), rc.SetWriteDeadline(deadline)); err != nil {
+		h.logger.Warn("import_upload_deadline_not_extended", map[string]any{"error": err.Error()})
+	}
+
+	body := http.MaxBytesReader(c.Writer, c.Request.Body, h.maxBytes)
+	imp, err := h.imports.Upload(c.Request.Context(), userID, c.Query("source"), body)
+	if err != nil {
+		var maxErr *http.MaxBytesError
+		if errors.As(err, &maxErr) {
+			response.Error(c, http.StatusRequestEntityTooLarge, "import_too_large", "Файл импорта слишком большой", gin.H{"max_bytes": h.maxBytes})
+			return
+		}
+		h.respondError(c, "upload_import", err)
+		return
+	}
+
+	h.logger.Info("data_import_uploaded", map[string]any{
+		"import_id": imp.ID.String(),
+		"user_id":   userID.String(),
+		"source":    imp.Source,
+		"total":     imp.Total,
+	})
+	c.JSON(http.StatusAccepted, toImportResponse(imp))
+}
+
+// List godoc
+// @Summary      Мои импорты
+// @Description  Возвращает последние импорты текущего пользователя со счётчиками обработки, новые первыми.
+// @Tags         imports
+// @Security     BearerAuth
+// @Produce      json
+// @Success      200  {object}  ImportListResponse
+// @Failure      401  {object}  response.ErrorBody
+// @Failure      500  {object}  response.ErrorBody
+// @Router       /api/v1/imports [get]
+func (h *Handler) List(c *gin.Context) {
+	userID, err := middleware.UserIDFromContext(c)
+	if err != nil {
+		response.Error(c, http.StatusUnauthorized, "unauthorized", "Требуется аутентификация", nil)
+		return
+	}
+
+	imports, err := h.imports.List(c.Request.Context(), userID)
+	if err != nil {
+		h.respondError(c, "list_imports", err)
+		return
+	}
+
+	resp := ImportListResponse{Items: make([]ImportResponse, 0, len(imports))}
+	for _, imp := range imports {
+		resp.Items = append(resp.Items, toImportResponse(imp))
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// Get godoc
+// @Summary      Состояние импорта
+// @Description  Возвращает статус импорта и счётчики: всего записей, обработано, импортировано, отклонено и дубликатов.
+// @Tags         imports
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id   path      string  true  "ID импорта"
+// @Success      200  {object}  ImportResponse
+// @Failure      400  {object}  response.ErrorBody
+// @Failure      401  {object}  response.ErrorBody
+// @Failure      404  {object}  response.ErrorBody
+// @Failure      500  {object}  response.ErrorBody
+// @Router       /api/v1/imports/{id} [get]
+func (h *Handler) Get(c *gin.Context) {
+	userID, err := middleware.UserIDFromContext(c)
+	if err != nil {
+		response.Error(c, http.StatusUnauthorized, "unauthorized", "Требуется аутентификация", nil)
+		return
+	}
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid_id", "Некорректный ID импорта", nil)
+		return
+	}
+
+	imp, err := h.imports.Get(c.Request.Context(), userID, id)
+	if err != nil {
+		h.respondError(c, "get_import", err)
+		return
+	}
+	c.JSON(http.StatusOK, toImportResponse(imp))
+}
+
+// Records godoc
+// @Summary      Результаты по строкам импорта
+// @Description  Возвращает результат обработки строк файла в порядке строк: imported с ID созданной записи, invalid с причиной, duplicate или pending.
+// @Tags         imports
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id      path      string  true   "ID импорта"
+// @Param        status  query     string  false  "Статус: pending, imported, invalid или duplicate"
+// @Param        limit   query     int     false  "Размер страницы (по умолчанию 100, максимум 1000)"
+// @Param        offset  query     int     false  "Смещение"
+// @Success      200     {object}  RecordListResponse
+// @Failure      400     {object}  response.ErrorBody
+// @Failure      401     {object}  response.ErrorBody
+// @Failure      404     {object}  response.ErrorBody
+// @Failure      500     {object}  response.ErrorBody
+// @Router       /api/v1/imports/{id}/records [get]
+func (h *Handler) Records(c *gin.Context) {
+	userID, err := middleware.UserIDFromContext(c)
+	if err != nil {
+		response.Error(c, http.StatusUnauthorized, "unauthorized", "Требуется аутентификация", nil)
+		return
+	}
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid_id", "Некорректный ID импорта", nil)
+		return
+	}
+	limit, err1 := queryInt(c, "limit")
+	offset, err2 := queryInt(c, "offset")
+	if err1 != nil || err2 != nil {
+		response.Error(c, http.StatusBadRequest, "invalid_pagination", "Параметры limit и offset должны быть неотрицательными числами", nil)
+		return
+	}
+
+	records, err := h.imports.Records(c.Request.Context(), userID, id, domain.RecordStatus(c.Query("status")), limit, offset)
+	if err != nil {
+		h.respondError(c, "list_import_records", err)
+		return
+	}
+
+	resp := RecordListResponse{Items: make([]RecordResponse, 0, len(records))}
+	for _, r := range records {
+		item := RecordResponse{
+			Line:       r.Line,
+			Type:       string(r.Kind),
+			ExternalID: r.ExternalID,
+			Status:     string(r.Status),
+			Error:      r.Error,
+		}
+		if r.EntityID != nil {
+			entityID := r.EntityID.String()
+			item.EntityID = &entityID
+		}
+		resp.Items = append(resp.Items, item)
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// respondError маппит ошибки usecase в HTTP-ответы.
+func (h *Handler) respondError(c *gin.Context, op string, err error) {
+	switch {
+	case errors.Is(err, importuc.ErrImportNotFound):
+		response.Error(c, http.StatusNotFound, "import_not_found", "Импорт не найден", nil)
+	case errors.Is(err, importuc.ErrImportInProgress):
+		response.Error(c, http.StatusConflict, "import_in_progress", "Предыдущий импорт ещё обрабатывается", nil)
+	case errors.Is(err, importuc.ErrInvalidSource):
+		response.Error(c, http.StatusBadRequest, "invalid_source", "Источник должен быть не длиннее 64 символов", nil)
+	case errors.Is(err, importuc.ErrEmptyFile):
+		response.Error(c, http.StatusBadRequest, "empty_import", "Файл импорта не содержит записей", nil)
+	case errors.Is(err, importuc.ErrLineTooLong):
+		response.Error(c, http.StatusBadRequest, "import_line_too_long", "Строка файла импорта слишком длинная", nil)
+	case errors.Is(err, importuc.ErrTooManyRecords):
+		response.Error(c, http.StatusRequestEntityTooLarge, "too_many_records", "Слишком много записей в файле импорта, разделите его на части", nil)
+	case errors.Is(err, importuc.ErrInvalidStatus):
+		response.Error(c, http.StatusBadRequest, "invalid_status", "Статус должен быть pending, imported, invalid или duplicate", nil)
+	case errors.Is(err, importuc.ErrInvalidPagination):
+		response.Error(c, http.StatusBadRequest, "invalid_pagination", "Размер страницы должен быть не больше 1000", nil)
+	default:
+		h.logger.Error("internal_error_in_"+op, map[string]any{
+			"path":   c.Request.URL.Path,
+			"method": c.Request.Method,
+			"error":  err.Error(),
+		})
+		response.Error(c, http.StatusInternalServerError, "internal_error", "Внутренняя ошибка сервера", nil)
+	}
+}
+
+// queryInt читает неотрицательный целочисленный параметр запроса (0, если не задан).
+func queryInt(c *gin.Context, name string) (int, error) {
+	raw := c.Query(name)
+	if raw == "" {
+		return 0, nil
+	}
+	n, err := strconv.Atoi(raw)
+	if err != nil || n < 0 {
+		return 0, strconv.ErrSyntax
+	}
+	return n, nil
+}
+
+// toImportResponse маппит доменную модель в DTO.
+func toImportResponse(imp *domain.Import) ImportResponse {
+	return ImportResponse{
+		ID:         imp.ID.String(),
+		Source:     imp.Source,
+		Status:     string(imp.Status),
+		Total:      imp.Total,
+		Processed:  imp.Processed,
+		Imported:   imp.Imported,
+		Invalid:    imp.Invalid,
+		Duplicates: imp.Duplicates,
+		Error:      imp.LastError,
+		CreatedAt:  imp.CreatedAt,
+		StartedAt:  imp.StartedAt,
+		FinishedAt: imp.FinishedAt,
+	}
+}
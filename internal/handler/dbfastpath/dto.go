@@ -0,0 +1,26 @@
+package dbfastpath
+
+// FastPathResponse описывает чтения пользователей через GORM и pgx с момента старта инстанса.
+type FastPathResponse struct {
+	Mode   string           `json:"mode"`
+	Reads  []ReadResponse   `json:"reads"`
+	Shadow []ShadowResponse `json:"shadow"`
+}
+
+// ReadResponse описывает чтения одним методом через один путь.
+type ReadResponse struct {
+	Method        string  `json:"method"`
+	Path          string  `json:"path"`
+	Reads         int64   `json:"reads"`
+	Errors        int64   `json:"errors"`
+	AvgDurationMs float64 `json:"avg_duration_ms"`
+	MaxDurationMs float64 `json:"max_duration_ms"`
+}
+
+// ShadowResponse описывает сверку теневых чтений pgx с GORM по методу.
+type ShadowResponse struct {
+	Method     string `json:"method"`
+	Compared   int64  `json:"compared"`
+	Mismatches int64  `json:"mismatches"`
+	Skipped    int64  `json:"skipped"`
+}
@@ -0,0 +1,68 @@
+package dbfastpath
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	pgrepo "workout-app/internal/repository/postgres"
+)
+
+// Handler обрабатывает административный запрос метрик быстрого пути чтения пользователей.
+type Handler struct {
+	mode    string
+	metrics *pgrepo.FastPathMetrics
+}
+
+// NewHandler создаёт новый обработчик. mode — значение DB_USER_FAST_PATH.
+func NewHandler(mode string, metrics *pgrepo.FastPathMetrics) *Handler {
+	return &Handler{mode: mode, metrics: metrics}
+}
+
+// Stats godoc
+// @Summary      Метрики быстрого пути чтения пользователей (админ)
+// @Description  Возвращает режим DB_USER_FAST_PATH, длительность и ошибки чтений пользователей по ID и email через GORM и pgx и результаты сверки теневых чтений pgx с GORM с момента старта инстанса.
+// @Tags         admin
+// @Security     BearerAuth
+// @Produce      json
+// @Success      200  {object}  FastPathResponse
+// @Failure      401  {object}  response.ErrorBody
+// @Failure      403  {object}  response.ErrorBody
+// @Router       /api/v1/admin/db-fast-path [get]
+func (h *Handler) Stats(c *gin.Context) {
+	reads := h.metrics.Reads()
+	shadow := h.metrics.Shadow()
+	resp := FastPathResponse{
+		Mode:   h.mode,
+		Reads:  make([]ReadResponse, 0, len(reads)),
+		Shadow: make([]ShadowResponse, 0, len(shadow)),
+	}
+	for _, r := range reads {
+		item := ReadResponse{
+			Method:        r.Method,
+			Path:          r.Path,
+			Reads:         r.Reads,
+			Errors:        r.Errors,
+			MaxDurationMs: durationMs(r.MaxDuration),
+		}
+		if r.Reads > 0 {
+			item.AvgDurationMs = durationMs(r.TotalDuration) / float64(r.Reads)
+		}
+		resp.Reads = append(resp.Reads, item)
+	}
+	for _, s := range shadow {
+		resp.Shadow = append(resp.Shadow, ShadowResponse{
+			Method:     s.Method,
+			Compared:   s.Compared,
+			Mismatches: s.Mismatches,
+			Skipped:    s.Skipped,
+		})
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// durationMs переводит длительность в миллисекунды с долями: чтение по индексу занимает меньше миллисекунды.
+func durationMs(d time.Duration) float64 {
+	return float64(d.Microseconds()) / 1000
+}
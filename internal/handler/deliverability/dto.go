@@ -0,0 +1,42 @@
+package deliverability
+
+import "time"
+
+// GenericWebhookRequest описывает webhook в нормализованном формате сервиса.
+// Используется для провайдеров без отдельного адаптера и для ретрансляции событий.
+type GenericWebhookRequest struct {
+	Events []GenericEventDTO `json:"events"`
+}
+
+// GenericEventDTO описывает одно событие доставки в нормализованном формате.
+type GenericEventDTO struct {
+	Email      string     `json:"email"`
+	Outcome    string     `json:"outcome"`               // accepted, bounced или complaint
+	BounceType string     `json:"bounce_type,omitempty"` // hard или soft (для bounced)
+	Reason     string     `json:"reason,omitempty"`
+	MessageID  string     `json:"message_id,omitempty"`
+	OccurredAt *time.Time `json:"occurred_at,omitempty"`
+}
+
+// WebhookResponse описывает результат приёма webhook.
+type WebhookResponse struct {
+	Recorded int `json:"recorded"`
+}
+
+// ProviderReportResponse описывает статистику доставки одного провайдера.
+type ProviderReportResponse struct {
+	Provider      string  `json:"provider"`
+	Accepted      int64   `json:"accepted"`
+	Bounced       int64   `json:"bounced"`
+	HardBounced   int64   `json:"hard_bounced"`
+	Complaints    int64   `json:"complaints"`
+	BounceRate    float64 `json:"bounce_rate"`
+	ComplaintRate float64 `json:"complaint_rate"`
+}
+
+// ReportResponse описывает отчёт о доставляемости писем.
+type ReportResponse struct {
+	Since           time.Time                `json:"since"`
+	Providers       []ProviderReportResponse `json:"providers"`
+	SuppressedTotal int64                    `json:"suppressed_total"`
+}
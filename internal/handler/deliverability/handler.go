@@ -0,0 +1,155 @@
+package deliverability
+
+import (
+	"crypto/subtle"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"workout-app/internal/handler/response"
+	deliverabilityuc "workout-app/internal/usecase/deliverability"
+	"workout-app/pkg/logger"
+)
+
+// HeaderWebhookToken — заголовок с общим секретом webhook провайдера.
+// Секрет принимается только в заголовке: параметры URL попадают в логи прокси и провайдера.
+const HeaderWebhookToken = "X-Webhook-Token"
+
+// maxWebhookBodyBytes ограничивает размер тела webhook.
+const maxWebhookBodyBytes = 1 << 20
+
+// defaultReportDays — период отчёта по умолчанию.
+const defaultReportDays = 30
+
+// Handler обрабатывает webhooks почтовых провайдеров и отчёт о доставляемости писем.
+type Handler struct {
+	deliverability deliverabilityuc.Service
+	webhookSecret  string
+	logger         logger.Logger
+}
+
+// NewHandler создаёт новый DeliverabilityHandler.
+// webhookSecret — общий секрет, который провайдеры передают в каждом webhook.
+func NewHandler(deliverability deliverabilityuc.Service, webhookSecret string, logger logger.Logger) *Handler {
+	return &Handler{
+		deliverability: deliverability,
+		webhookSecret:  webhookSecret,
+		logger:         logger,
+	}
+}
+
+// Webhook godoc
+// @Summary      Принять события доставки от почтового провайдера
+// @Description  Принимает события доставки (доставлено, недоставка, жалоба). Поддерживаются форматы SendGrid и Mailgun; для остальных провайдеров — нормализованный формат. Адреса с постоянной недоставкой или жалобой попадают в список подавления.
+// @Tags         email
+// @Accept       json
+// @Produce      json
+// @Param        provider         path      string                 true   "Провайдер (sendgrid, mailgun или произвольное имя)"
+// @Param        X-Webhook-Token  header    string                 false  "Общий секрет webhook"
+// @Param        payload          body      GenericWebhookRequest  true   "События доставки"
+// @Success      200              {object}  WebhookResponse
+// @Failure      400              {object}  response.ErrorBody
+// @Failure      401              {object}  response.ErrorBody
+// @Failure      500              {object}  response.ErrorBody
+// @Router       /api/v1/webhooks/email/{provider} [post]
+func (h *Handler) Webhook(c *gin.Context) {
+	token := c.GetHeader(HeaderWebhookToken)
+	if subtle.ConstantTimeCompare([]byte(token), []byte(h.webhookSecret)) != 1 {
+		response.Error(c, http.StatusUnauthorized, "invalid_webhook_token", "Некорректный секрет webhook", nil)
+		return
+	}
+
+	provider := c.Param("provider")
+	body, err := io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, maxWebhookBodyBytes))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid_request", "Некорректное тело запроса", err.Error())
+		return
+	}
+
+	events, err := parseEvents(provider, body)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid_request", "Некорректное тело запроса", err.Error())
+		return
+	}
+
+	recorded, err := h.deliverability.RecordEvents(c.Request.Context(), provider, events)
+	if err != nil {
+		switch {
+		case errors.Is(err, deliverabilityuc.ErrInvalidProvider):
+			response.Error(c, http.StatusBadRequest, "invalid_provider", "Некорректное имя провайдера", nil)
+		case errors.Is(err, deliverabilityuc.ErrInvalidEvent):
+			response.Error(c, http.StatusBadRequest, "invalid_event", "Событие должно содержать email и исход accepted, bounced или complaint", nil)
+		default:
+			h.logger.Error("internal_error_in_email_webhook", map[string]any{
+				"provider": provider,
+				"recorded": recorded,
+				"path":     c.Request.URL.Path,
+				"method":   c.Request.Method,
+				"error":    err.Error(),
+			})
+			response.Error(c, http.StatusInternalServerError, "internal_error", "Внутренняя ошибка сервера", nil)
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, WebhookResponse{Recorded: recorded})
+}
+
+// Report godoc
+// @Summary      Отчёт о доставляемости писем (админ)
+// @Description  Возвращает количество доставленных, недоставленных писем и жалоб по провайдерам за последние days дней и размер списка подавления.
+// @Tags         email
+// @Security     BearerAuth
+// @Produce      json
+// @Param        days  query     int  false  "Период в днях (1-365, по умолчанию 30)"
+// @Success      200   {object}  ReportResponse
+// @Failure      400   {object}  response.ErrorBody
+// @Failure      401   {object}  response.ErrorBody
+// @Failure      403   {object}  response.ErrorBody
+// @Failure      500   {object}  response.ErrorBody
+// @Router       /api/v1/admin/email/deliverability [get]
+func (h *Handler) Report(c *gin.Context) {
+	days := defaultReportDays
+	if raw := c.Query("days"); raw != "" {
+		n, err := strconv.Atoi(raw)
+		if err != nil || n < 1 || n > 365 {
+			response.Error(c, http.StatusBadRequest, "invalid_days", "Параметр days должен быть числом от 1 до 365", nil)
+			return
+		}
+		days = n
+	}
+
+	since := time.Now().UTC().AddDate(0, 0, -days)
+	report, err := h.deliverability.Report(c.Request.Context(), since)
+	if err != nil {
+		h.logger.Error("internal_error_in_deliverability_report", map[string]any{
+			"path":   c.Request.URL.Path,
+			"method": c.Request.Method,
+			"error":  err.Error(),
+		})
+		response.Error(c, http.StatusInternalServerError, "internal_error", "Внутренняя ошибка сервера", nil)
+		return
+	}
+
+	providers := make([]ProviderReportResponse, 0, len(report.Providers))
+	for _, p := range report.Providers {
+		providers = append(providers, ProviderReportResponse{
+			Provider:      p.Provider,
+			Accepted:      p.Accepted,
+			Bounced:       p.Bounced,
+			HardBounced:   p.HardBounced,
+			Complaints:    p.Complaints,
+			BounceRate:    p.BounceRate(),
+			ComplaintRate: p.ComplaintRate(),
+		})
+	}
+	c.JSON(http.StatusOK, ReportResponse{
+		Since:           report.Since,
+		Providers:       providers,
+		SuppressedTotal: report.SuppressedTotal,
+	})
+}
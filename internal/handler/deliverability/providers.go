@@ -0,0 +1,147 @@
+package deliverability
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"time"
+
+	domain "workout-app/internal/domain/deliverability"
+)
+
+// parseEvents разбирает тело webhook в формате провайдера.
+// Для провайдеров без адаптера ожидается нормализованный формат GenericWebhookRequest.
+// События, не влияющие на доставляемость (открытия, клики и т.п.), пропускаются.
+func parseEvents(provider string, body []byte) ([]domain.Event, error) {
+	switch provider {
+	case "sendgrid":
+		return parseSendGrid(body)
+	case "mailgun":
+		return parseMailgun(body)
+	default:
+		return parseGeneric(body)
+	}
+}
+
+// parseGeneric разбирает нормализованный формат сервиса.
+func parseGeneric(body []byte) ([]domain.Event, error) {
+	var req GenericWebhookRequest
+	if err := json.Unmarshal(body, &req); err != nil {
+		return nil, err
+	}
+	events := make([]domain.Event, 0, len(req.Events))
+	for _, e := range req.Events {
+		ev := domain.Event{
+			Email:      e.Email,
+			Outcome:    domain.Outcome(e.Outcome),
+			BounceType: domain.BounceType(e.BounceType),
+			Reason:     e.Reason,
+			MessageID:  e.MessageID,
+		}
+		if e.OccurredAt != nil {
+			ev.OccurredAt = e.OccurredAt.UTC()
+		}
+		events = append(events, ev)
+	}
+	return events, nil
+}
+
+// sendGridEvent — элемент массива событий SendGrid Event Webhook.
+type sendGridEvent struct {
+	Email       string `json:"email"`
+	Timestamp   int64  `json:"timestamp"`
+	Event       string `json:"event"`
+	Type        string `json:"type"` // bounce (постоянная) или blocked (временная)
+	Reason      string `json:"reason"`
+	SGMessageID string `json:"sg_message_id"`
+}
+
+// parseSendGrid разбирает SendGrid Event Webhook.
+func parseSendGrid(body []byte) ([]domain.Event, error) {
+	var raw []sendGridEvent
+	if err := json.Unmarshal(body, &raw); err != nil {
+		return nil, err
+	}
+	events := make([]domain.Event, 0, len(raw))
+	for _, e := range raw {
+		ev := domain.Event{
+			Email:      e.Email,
+			Reason:     e.Reason,
+			MessageID:  e.SGMessageID,
+			OccurredAt: time.Unix(e.Timestamp, 0).UTC(),
+		}
+		switch e.Event {
+		case "delivered":
+			ev.Outcome = domain.OutcomeAccepted
+		case "bounce":
+			ev.Outcome = domain.OutcomeBounced
+			ev.BounceType = domain.BounceHard
+			if e.Type == "blocked" {
+				ev.BounceType = domain.BounceSoft
+			}
+		case "spamreport":
+			ev.Outcome = domain.OutcomeComplaint
+		default:
+			continue
+		}
+		events = append(events, ev)
+	}
+	return events, nil
+}
+
+// mailgunWebhook — тело Mailgun webhook (формат event-data).
+type mailgunWebhook struct {
+	EventData struct {
+		Event     string  `json:"event"`
+		Severity  string  `json:"severity"` // permanent или temporary (для failed)
+		Recipient string  `json:"recipient"`
+		Timestamp float64 `json:"timestamp"`
+		Message   struct {
+			Headers struct {
+				MessageID string `json:"message-id"`
+			} `json:"headers"`
+		} `json:"message"`
+		DeliveryStatus struct {
+			Description string `json:"description"`
+			Message     string `json:"message"`
+		} `json:"delivery-status"`
+	} `json:"event-data"`
+}
+
+// parseMailgun разбирает Mailgun webhook; одно событие на запрос.
+func parseMailgun(body []byte) ([]domain.Event, error) {
+	var raw mailgunWebhook
+	if err := json.Unmarshal(body, &raw); err != nil {
+		return nil, err
+	}
+	data := raw.EventData
+
+	sec, frac := math.Modf(data.Timestamp)
+	ev := domain.Event{
+		Email:      data.Recipient,
+		MessageID:  data.Message.Headers.MessageID,
+		Reason:     data.DeliveryStatus.Description,
+		OccurredAt: time.Unix(int64(sec), int64(frac*1e9)).UTC(),
+	}
+	if ev.Reason == "" {
+		ev.Reason = data.DeliveryStatus.Message
+	}
+
+	switch data.Event {
+	case "delivered":
+		ev.Outcome = domain.OutcomeAccepted
+	case "failed":
+		ev.Outcome = domain.OutcomeBounced
+		ev.BounceType = domain.BounceSoft
+		if data.Severity == "permanent" {
+			ev.BounceType = domain.BounceHard
+		}
+	case "complained":
+		ev.Outcome = domain.OutcomeComplaint
+	case "":
+		return nil, fmt.Errorf("mailgun webhook without event-data")
+	default:
+		return nil, nil
+	}
+	return []domain.Event{ev}, nil
+}
@@ -0,0 +1,40 @@
+package draft
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SaveDraftRequest описывает тело запроса на автосохранение черновика.
+type SaveDraftRequest struct {
+	DeviceID string     `json:"device_id" binding:"required" example:"a1b2c3"` // Постоянный идентификатор установки приложения
+	Kind     string     `json:"kind" binding:"required" example:"program"`     // program или workout
+	TargetID *uuid.UUID `json:"target_id,omitempty" swaggertype:"string" format:"uuid"`
+	// BaseVersion — updated_at программы или время последнего изменения тренировки на момент начала редактирования.
+	BaseVersion *time.Time      `json:"base_version,omitempty"`
+	Version     int             `json:"version"` // Версия черновика из предыдущего ответа; 0 — первое сохранение
+	Content     json.RawMessage `json:"content" binding:"required" swaggertype:"object"`
+}
+
+// DraftResponse описывает черновик без содержимого.
+type DraftResponse struct {
+	ID          string     `json:"id"`
+	DeviceID    string     `json:"device_id"`
+	Kind        string     `json:"kind"`
+	TargetID    *string    `json:"target_id"`
+	BaseVersion *time.Time `json:"base_version"`
+	Version     int        `json:"version"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+	ExpiresAt   time.Time  `json:"expires_at"`
+}
+
+// DraftDetailResponse описывает черновик с содержимым и состоянием опубликованного объекта.
+type DraftDetailResponse struct {
+	DraftResponse
+	Content          json.RawMessage `json:"content" swaggertype:"object"`
+	PublishedVersion *time.Time      `json:"published_version"`
+	Conflict         bool            `json:"conflict"` // Объект изменён или удалён после начала редактирования
+}
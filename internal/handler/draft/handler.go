@@ -0,0 +1,243 @@
+package draft
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	domain "workout-app/internal/domain/draft"
+	"workout-app/internal/handler/middleware"
+	"workout-app/internal/handler/response"
+	draftuc "workout-app/internal/usecase/draft"
+	"workout-app/pkg/logger"
+)
+
+// Handler обрабатывает HTTP-запросы, связанные с черновиками редактора программ и тренировок.
+type Handler struct {
+	drafts draftuc.Service
+	logger logger.Logger
+}
+
+// NewHandler создаёт новый DraftHandler.
+func NewHandler(drafts draftuc.Service, logger logger.Logger) *Handler {
+	return &Handler{
+		drafts: drafts,
+		logger: logger,
+	}
+}
+
+// Save godoc
+// @Summary      Автосохранить черновик
+// @Description  Сохраняет состояние редактора программы или тренировки для устройства. Черновик определяется device_id, kind и target_id (без target_id — новый объект). version — версия из предыдущего ответа (0 при первом сохранении); если сохранена другая, возвращается 409 с текущим черновиком в details. conflict=true — программу или тренировку изменили после base_version. Черновик удаляется, если его не сохраняли DRAFT_TTL.
+// @Tags         drafts
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        request  body      SaveDraftRequest  true  "Черновик"
+// @Success      200      {object}  DraftDetailResponse
+// @Failure      400      {object}  response.ErrorBody
+// @Failure      401      {object}  response.ErrorBody
+// @Failure      404      {object}  response.ErrorBody
+// @Failure      409      {object}  response.ErrorBody
+// @Failure      500      {object}  response.ErrorBody
+// @Router       /api/v1/drafts [put]
+func (h *Handler) Save(c *gin.Context) {
+	userID, ok := h.currentUser(c)
+	if !ok {
+		return
+	}
+	var req SaveDraftRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid_request", "Некорректное тело запроса", err.Error())
+		return
+	}
+
+	view, err := h.drafts.Save(c.Request.Context(), userID, draftuc.SaveInput{
+		DeviceID:    req.DeviceID,
+		Kind:        domain.Kind(req.Kind),
+		TargetID:    req.TargetID,
+		BaseVersion: req.BaseVersion,
+		Version:     req.Version,
+		Content:     req.Content,
+	})
+	if err != nil {
+		h.respondError(c, "save_draft", userID, err)
+		return
+	}
+	c.JSON(http.StatusOK, toDraftDetailResponse(view))
+}
+
+// List godoc
+// @Summary      Черновики
+// @Description  Возвращает действующие черновики текущего пользователя без содержимого, недавно сохранённые первыми. device_id ограничивает список одним устройством.
+// @Tags         drafts
+// @Security     BearerAuth
+// @Produce      json
+// @Param        device_id  query     string  false  "Идентификатор устройства"
+// @Success      200        {array}   DraftResponse
+// @Failure      401        {object}  response.ErrorBody
+// @Failure      500        {object}  response.ErrorBody
+// @Router       /api/v1/drafts [get]
+func (h *Handler) List(c *gin.Context) {
+	userID, ok := h.currentUser(c)
+	if !ok {
+		return
+	}
+
+	drafts, err := h.drafts.List(c.Request.Context(), userID, c.Query("device_id"))
+	if err != nil {
+		h.respondError(c, "list_drafts", userID, err)
+		return
+	}
+
+	resp := make([]DraftResponse, 0, len(drafts))
+	for _, d := range drafts {
+		resp = append(resp, toDraftResponse(d))
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// Get godoc
+// @Summary      Черновик
+// @Description  Возвращает черновик с содержимым. published_version — текущая версия программы или тренировки; conflict=true — объект изменён или удалён после начала редактирования.
+// @Tags         drafts
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id   path      string  true  "ID черновика"
+// @Success      200  {object}  DraftDetailResponse
+// @Failure      400  {object}  response.ErrorBody
+// @Failure      401  {object}  response.ErrorBody
+// @Failure      404  {object}  response.ErrorBody
+// @Failure      500  {object}  response.ErrorBody
+// @Router       /api/v1/drafts/{id} [get]
+func (h *Handler) Get(c *gin.Context) {
+	userID, ok := h.currentUser(c)
+	if !ok {
+		return
+	}
+	id, ok := h.draftID(c)
+	if !ok {
+		return
+	}
+
+	view, err := h.drafts.Get(c.Request.Context(), userID, id)
+	if err != nil {
+		h.respondError(c, "get_draft", userID, err)
+		return
+	}
+	c.JSON(http.StatusOK, toDraftDetailResponse(view))
+}
+
+// Delete godoc
+// @Summary      Удалить черновик
+// @Description  Удаляет черновик после публикации изменений или отказа от них.
+// @Tags         drafts
+// @Security     BearerAuth
+// @Param        id   path  string  true  "ID черновика"
+// @Success      204
+// @Failure      400  {object}  response.ErrorBody
+// @Failure      401  {object}  response.ErrorBody
+// @Failure      404  {object}  response.ErrorBody
+// @Failure      500  {object}  response.ErrorBody
+// @Router       /api/v1/drafts/{id} [delete]
+func (h *Handler) Delete(c *gin.Context) {
+	userID, ok := h.currentUser(c)
+	if !ok {
+		return
+	}
+	id, ok := h.draftID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.drafts.Delete(c.Request.Context(), userID, id); err != nil {
+		h.respondError(c, "delete_draft", userID, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// currentUser извлекает текущего пользователя из контекста.
+func (h *Handler) currentUser(c *gin.Context) (uuid.UUID, bool) {
+	userID, err := middleware.UserIDFromContext(c)
+	if err != nil {
+		response.Error(c, http.StatusUnauthorized, "unauthorized", "Требуется аутентификация", nil)
+		return uuid.Nil, false
+	}
+	return userID, true
+}
+
+// draftID разбирает ID черновика из пути.
+func (h *Handler) draftID(c *gin.Context) (uuid.UUID, bool) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid_draft_id", "Некорректный ID черновика", nil)
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+// respondError маппит ошибки usecase-слоя в HTTP-ответы.
+func (h *Handler) respondError(c *gin.Context, op string, userID uuid.UUID, err error) {
+	var conflictErr *draftuc.VersionConflictError
+	switch {
+	case errors.Is(err, draftuc.ErrInvalidKind):
+		response.Error(c, http.StatusBadRequest, "invalid_draft_kind", "Вид черновика должен быть program или workout", nil)
+	case errors.Is(err, draftuc.ErrInvalidDeviceID):
+		response.Error(c, http.StatusBadRequest, "invalid_device_id", "Некорректный идентификатор устройства", nil)
+	case errors.Is(err, draftuc.ErrInvalidContent):
+		response.Error(c, http.StatusBadRequest, "invalid_draft_content", "Содержимое черновика должно быть JSON-объектом до 256 КБ", nil)
+	case errors.Is(err, draftuc.ErrBaseVersionRequired):
+		response.Error(c, http.StatusBadRequest, "base_version_required", "Для существующего объекта нужна base_version", nil)
+	case errors.Is(err, draftuc.ErrTooManyDrafts):
+		response.Error(c, http.StatusBadRequest, "too_many_drafts", "Слишком много черновиков; удалите ненужные", nil)
+	case errors.Is(err, draftuc.ErrTargetNotFound):
+		response.Error(c, http.StatusNotFound, "draft_target_not_found", "Программа или тренировка не найдена", nil)
+	case errors.Is(err, draftuc.ErrDraftNotFound):
+		response.Error(c, http.StatusNotFound, "draft_not_found", "Черновик не найден", nil)
+	case errors.As(err, &conflictErr):
+		response.Error(c, http.StatusConflict, "draft_version_conflict", "Черновик сохранён с другой версией",
+			toDraftDetailResponse(&draftuc.View{Draft: conflictErr.Current}))
+	case errors.Is(err, draftuc.ErrVersionConflict):
+		response.Error(c, http.StatusConflict, "draft_version_conflict", "Черновик сохранён с другой версией", nil)
+	default:
+		h.logger.Error("internal_error_in_"+op, map[string]any{
+			"user_id": userID.String(),
+			"path":    c.Request.URL.Path,
+			"method":  c.Request.Method,
+			"error":   err.Error(),
+		})
+		response.Error(c, http.StatusInternalServerError, "internal_error", "Внутренняя ошибка сервера", nil)
+	}
+}
+
+// toDraftResponse маппит черновик в DTO без содержимого.
+func toDraftResponse(d *domain.Draft) DraftResponse {
+	resp := DraftResponse{
+		ID:          d.ID.String(),
+		DeviceID:    d.DeviceID,
+		Kind:        string(d.Kind),
+		BaseVersion: d.BaseVersion,
+		Version:     d.Version,
+		CreatedAt:   d.CreatedAt,
+		UpdatedAt:   d.UpdatedAt,
+		ExpiresAt:   d.ExpiresAt,
+	}
+	if d.TargetID != nil {
+		targetID := d.TargetID.String()
+		resp.TargetID = &targetID
+	}
+	return resp
+}
+
+// toDraftDetailResponse маппит черновик с состоянием опубликованного объекта в DTO.
+func toDraftDetailResponse(v *draftuc.View) DraftDetailResponse {
+	return DraftDetailResponse{
+		DraftResponse:    toDraftResponse(v.Draft),
+		Content:          v.Draft.Content,
+		PublishedVersion: v.PublishedVersion,
+		Conflict:         v.Conflict,
+	}
+}
@@ -0,0 +1,24 @@
+package emailoutbox
+
+import "time"
+
+// MessageResponse описывает письмо в очереди исходящих писем. Данные письма (коды) не возвращаются.
+type MessageResponse struct {
+	ID            int64      `json:"id"`
+	Kind          string     `json:"kind"`
+	Recipient     string     `json:"recipient"`
+	Locale        string     `json:"locale,omitempty"`
+	Status        string     `json:"status"`
+	Attempts      int        `json:"attempts"`
+	NextAttemptAt time.Time  `json:"next_attempt_at"`
+	LastError     string     `json:"last_error,omitempty"`
+	CreatedAt     time.Time  `json:"created_at"`
+	FinishedAt    *time.Time `json:"finished_at,omitempty"`
+}
+
+// OutboxListResponse описывает количество писем по статусам и страницу писем.
+type OutboxListResponse struct {
+	Counts map[string]int64  `json:"counts"`
+	Items  []MessageResponse `json:"items"`
+	Total  int64             `json:"total"`
+}
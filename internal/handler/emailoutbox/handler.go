@@ -0,0 +1,163 @@
+package emailoutbox
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	domain "workout-app/internal/domain/emailoutbox"
+	"workout-app/internal/handler/middleware"
+	"workout-app/internal/handler/response"
+	repo "workout-app/internal/repository/interfaces"
+	emailoutboxuc "workout-app/internal/usecase/emailoutbox"
+	"workout-app/pkg/logger"
+)
+
+// Handler обрабатывает административные запросы к очереди исходящих писем.
+type Handler struct {
+	outbox emailoutboxuc.Service
+	logger logger.Logger
+}
+
+// NewHandler создаёт новый EmailOutboxHandler.
+func NewHandler(outbox emailoutboxuc.Service, logger logger.Logger) *Handler {
+	return &Handler{
+		outbox: outbox,
+		logger: logger,
+	}
+}
+
+// List godoc
+// @Summary      Очередь исходящих писем (админ)
+// @Description  Возвращает количество писем по статусам и страницу писем, новые первыми. Письма в статусе dead исчерпали попытки отправки или адресованы недоставляемому адресу.
+// @Tags         email
+// @Security     BearerAuth
+// @Produce      json
+// @Param        status  query     string  false  "Статус: pending, sent или dead"
+// @Param        limit   query     int     false  "Размер страницы (по умолчанию 50, максимум 200)"
+// @Param        offset  query     int     false  "Смещение"
+// @Success      200     {object}  OutboxListResponse
+// @Failure      400     {object}  response.ErrorBody
+// @Failure      401     {object}  response.ErrorBody
+// @Failure      403     {object}  response.ErrorBody
+// @Failure      500     {object}  response.ErrorBody
+// @Router       /api/v1/admin/email/outbox [get]
+func (h *Handler) List(c *gin.Context) {
+	limit, err1 := queryInt(c, "limit")
+	offset, err2 := queryInt(c, "offset")
+	if err1 != nil || err2 != nil {
+		response.Error(c, http.StatusBadRequest, "invalid_pagination", "Параметры limit и offset должны быть неотрицательными числами", nil)
+		return
+	}
+
+	ctx := c.Request.Context()
+	items, total, err := h.outbox.List(ctx, domain.Status(c.Query("status")), limit, offset)
+	if err != nil {
+		h.respondError(c, "list_email_outbox", err)
+		return
+	}
+	counts, err := h.outbox.Stats(ctx)
+	if err != nil {
+		h.respondError(c, "list_email_outbox", err)
+		return
+	}
+
+	resp := OutboxListResponse{
+		Counts: make(map[string]int64, len(counts)),
+		Items:  make([]MessageResponse, 0, len(items)),
+		Total:  total,
+	}
+	for status, n := range counts {
+		resp.Counts[string(status)] = n
+	}
+	for _, m := range items {
+		resp.Items = append(resp.Items, toMessageResponse(m))
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// Retry godoc
+// @Summary      Повторить отправку письма из dead letter (админ)
+// @Description  Возвращает письмо в очередь со сброшенным счётчиком попыток; воркер отправит его при следующем запуске.
+// @Tags         email
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id   path      int  true  "ID письма"
+// @Success      200  {object}  MessageResponse
+// @Failure      400  {object}  response.ErrorBody
+// @Failure      401  {object}  response.ErrorBody
+// @Failure      403  {object}  response.ErrorBody
+// @Failure      404  {object}  response.ErrorBody
+// @Failure      409  {object}  response.ErrorBody
+// @Failure      500  {object}  response.ErrorBody
+// @Router       /api/v1/admin/email/outbox/{id}/retry [post]
+func (h *Handler) Retry(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || id <= 0 {
+		response.Error(c, http.StatusBadRequest, "invalid_id", "Некорректный ID письма", nil)
+		return
+	}
+
+	m, err := h.outbox.Retry(c.Request.Context(), id)
+	if err != nil {
+		h.respondError(c, "retry_email_outbox", err)
+		return
+	}
+
+	h.logger.Info("email_outbox_requeued", map[string]any{
+		"email_id": m.ID,
+		"kind":     string(m.Kind),
+		"actor_id": c.GetString(middleware.ContextUserIDKey),
+	})
+	c.JSON(http.StatusOK, toMessageResponse(m))
+}
+
+// respondError отправляет ответ об ошибке для эндпоинтов очереди исходящих писем.
+func (h *Handler) respondError(c *gin.Context, op string, err error) {
+	switch {
+	case errors.Is(err, emailoutboxuc.ErrInvalidStatus):
+		response.Error(c, http.StatusBadRequest, "invalid_status", "Статус должен быть pending, sent или dead", nil)
+	case errors.Is(err, emailoutboxuc.ErrNotDead):
+		response.Error(c, http.StatusConflict, "email_not_dead", "Повторить можно только письмо из dead letter", nil)
+	case errors.Is(err, repo.ErrNotFound):
+		response.Error(c, http.StatusNotFound, "email_not_found", "Письмо не найдено", nil)
+	default:
+		h.logger.Error("internal_error_in_"+op, map[string]any{
+			"path":   c.Request.URL.Path,
+			"method": c.Request.Method,
+			"error":  err.Error(),
+		})
+		response.Error(c, http.StatusInternalServerError, "internal_error", "Внутренняя ошибка сервера", nil)
+	}
+}
+
+// queryInt читает неотрицательный целочисленный параметр запроса (0, если не задан).
+func queryInt(c *gin.Context, name string) (int, error) {
+	raw := c.Query(name)
+	if raw == "" {
+		return 0, nil
+	}
+	n, err := strconv.Atoi(raw)
+	if err != nil || n < 0 {
+		return 0, strconv.ErrSyntax
+	}
+	return n, nil
+}
+
+// toMessageResponse маппит доменную модель в DTO.
+func toMessageResponse(m *domain.Message) MessageResponse {
+	return MessageResponse{
+		ID:            m.ID,
+		Kind:          string(m.Kind),
+		Recipient:     m.Recipient,
+		Locale:        m.Locale,
+		Status:        string(m.Status),
+		Attempts:      m.Attempts,
+		NextAttemptAt: m.NextAttemptAt,
+		LastError:     m.LastError,
+		CreatedAt:     m.CreatedAt,
+		FinishedAt:    m.FinishedAt,
+	}
+}
@@ -0,0 +1,54 @@
+package experiment
+
+import "time"
+
+// AssignmentResponse описывает вариант пользователя в эксперименте.
+type AssignmentResponse struct {
+	ExperimentKey string `json:"experiment_key"`
+	Variant       string `json:"variant"`
+}
+
+// RecordEventRequest описывает тело запроса для записи события эксперимента.
+type RecordEventRequest struct {
+	// Type — тип события: exposure (показ варианта) или conversion (целевое действие).
+	Type string `json:"type" binding:"required,oneof=exposure conversion"`
+}
+
+// EventResponse описывает записанное событие эксперимента.
+type EventResponse struct {
+	ExperimentKey string    `json:"experiment_key"`
+	Variant       string    `json:"variant"`
+	Type          string    `json:"type"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// VariantDTO описывает вариант эксперимента и его вес.
+type VariantDTO struct {
+	Name   string `json:"name" binding:"required,max=64"`
+	Weight int    `json:"weight" binding:"gte=0"`
+}
+
+// CreateExperimentRequest описывает тело запроса для создания эксперимента.
+type CreateExperimentRequest struct {
+	Key         string       `json:"key" binding:"required"`
+	Description string       `json:"description"`
+	Variants    []VariantDTO `json:"variants" binding:"required,min=2,dive"`
+	IsActive    *bool        `json:"is_active,omitempty"`
+}
+
+// UpdateExperimentRequest описывает тело запроса для обновления эксперимента.
+type UpdateExperimentRequest struct {
+	Description string       `json:"description"`
+	Variants    []VariantDTO `json:"variants" binding:"required,min=2,dive"`
+	IsActive    bool         `json:"is_active"`
+}
+
+// ExperimentResponse описывает эксперимент для административных эндпоинтов.
+type ExperimentResponse struct {
+	Key         string       `json:"key"`
+	Description string       `json:"description,omitempty"`
+	Variants    []VariantDTO `json:"variants"`
+	IsActive    bool         `json:"is_active"`
+	CreatedAt   time.Time    `json:"created_at"`
+	UpdatedAt   time.Time    `json:"updated_at"`
+}
@@ -0,0 +1,287 @@
+package experiment
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	domain "workout-app/internal/domain/experiment"
+	"workout-app/internal/handler/middleware"
+	"workout-app/internal/handler/response"
+	repo "workout-app/internal/repository/interfaces"
+	experimentuc "workout-app/internal/usecase/experiment"
+	"workout-app/pkg/logger"
+)
+
+// Handler обрабатывает HTTP-запросы, связанные с A/B-экспериментами.
+type Handler struct {
+	experiments experimentuc.Service
+	logger      logger.Logger
+}
+
+// NewHandler создаёт новый ExperimentHandler.
+func NewHandler(experiments experimentuc.Service, logger logger.Logger) *Handler {
+	return &Handler{
+		experiments: experiments,
+		logger:      logger,
+	}
+}
+
+// GetMyAssignments godoc
+// @Summary      Получить варианты текущего пользователя в экспериментах
+// @Description  Возвращает назначенный вариант в каждом активном эксперименте. Назначение детерминировано и не меняется между запросами.
+// @Tags         experiments
+// @Security     BearerAuth
+// @Produce      json
+// @Success      200  {array}   AssignmentResponse
+// @Failure      401  {object}  response.ErrorBody
+// @Failure      500  {object}  response.ErrorBody
+// @Router       /api/v1/users/me/experiments [get]
+func (h *Handler) GetMyAssignments(c *gin.Context) {
+	userID, err := middleware.UserIDFromContext(c)
+	if err != nil {
+		response.Error(c, http.StatusUnauthorized, "unauthorized", "Требуется аутентификация", nil)
+		return
+	}
+
+	assignments, err := h.experiments.GetAssignments(c.Request.Context(), userID)
+	if err != nil {
+		h.logger.Error("internal_error_in_get_assignments", map[string]any{
+			"user_id": userID.String(),
+			"path":    c.Request.URL.Path,
+			"method":  c.Request.Method,
+			"error":   err.Error(),
+		})
+		response.Error(c, http.StatusInternalServerError, "internal_error", "Внутренняя ошибка сервера", nil)
+		return
+	}
+
+	resp := make([]AssignmentResponse, 0, len(assignments))
+	for _, a := range assignments {
+		resp = append(resp, AssignmentResponse{ExperimentKey: a.ExperimentKey, Variant: a.Variant})
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// RecordEvent godoc
+// @Summary      Записать событие эксперимента
+// @Description  Записывает показ (exposure) или конверсию (conversion) для варианта текущего пользователя.
+// @Tags         experiments
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        key      path      string              true  "Ключ эксперимента"
+// @Param        payload  body      RecordEventRequest  true  "Тип события"
+// @Success      201      {object}  EventResponse
+// @Failure      400      {object}  response.ErrorBody
+// @Failure      401      {object}  response.ErrorBody
+// @Failure      404      {object}  response.ErrorBody
+// @Failure      409      {object}  response.ErrorBody
+// @Failure      500      {object}  response.ErrorBody
+// @Router       /api/v1/users/me/experiments/{key}/events [post]
+func (h *Handler) RecordEvent(c *gin.Context) {
+	userID, err := middleware.UserIDFromContext(c)
+	if err != nil {
+		response.Error(c, http.StatusUnauthorized, "unauthorized", "Требуется аутентификация", nil)
+		return
+	}
+
+	var req RecordEventRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid_request", "Некорректное тело запроса", err.Error())
+		return
+	}
+
+	key := c.Param("key")
+	ev, err := h.experiments.RecordEvent(c.Request.Context(), userID, key, domain.EventType(req.Type))
+	if err != nil {
+		switch {
+		case errors.Is(err, repo.ErrNotFound):
+			response.Error(c, http.StatusNotFound, "experiment_not_found", "Эксперимент не найден", nil)
+		case errors.Is(err, experimentuc.ErrExperimentInactive):
+			response.Error(c, http.StatusConflict, "experiment_inactive", "Эксперимент не активен", nil)
+		case errors.Is(err, experimentuc.ErrInvalidEventType):
+			response.Error(c, http.StatusBadRequest, "invalid_event_type", "Некорректный тип события", nil)
+		default:
+			h.logger.Error("internal_error_in_record_experiment_event", map[string]any{
+				"user_id":        userID.String(),
+				"experiment_key": key,
+				"path":           c.Request.URL.Path,
+				"method":         c.Request.Method,
+				"error":          err.Error(),
+			})
+			response.Error(c, http.StatusInternalServerError, "internal_error", "Внутренняя ошибка сервера", nil)
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, EventResponse{
+		ExperimentKey: ev.ExperimentKey,
+		Variant:       ev.Variant,
+		Type:          string(ev.Type),
+		CreatedAt:     ev.CreatedAt,
+	})
+}
+
+// ListExperiments godoc
+// @Summary      Получить список экспериментов (админ)
+// @Description  Возвращает все эксперименты, включая неактивные. Доступно только для роли admin.
+// @Tags         experiments
+// @Security     BearerAuth
+// @Produce      json
+// @Success      200  {array}   ExperimentResponse
+// @Failure      401  {object}  response.ErrorBody
+// @Failure      403  {object}  response.ErrorBody
+// @Failure      500  {object}  response.ErrorBody
+// @Router       /api/v1/admin/experiments [get]
+func (h *Handler) ListExperiments(c *gin.Context) {
+	experiments, err := h.experiments.ListExperiments(c.Request.Context())
+	if err != nil {
+		h.logger.Error("internal_error_in_list_experiments", map[string]any{
+			"path":   c.Request.URL.Path,
+			"method": c.Request.Method,
+			"error":  err.Error(),
+		})
+		response.Error(c, http.StatusInternalServerError, "internal_error", "Внутренняя ошибка сервера", nil)
+		return
+	}
+
+	resp := make([]ExperimentResponse, 0, len(experiments))
+	for _, e := range experiments {
+		resp = append(resp, toExperimentResponse(e))
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// CreateExperiment godoc
+// @Summary      Создать эксперимент (админ)
+// @Description  Создаёт новый A/B-эксперимент с вариантами и весами. По умолчанию эксперимент активен.
+// @Tags         experiments
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        payload  body      CreateExperimentRequest  true  "Данные эксперимента"
+// @Success      201      {object}  ExperimentResponse
+// @Failure      400      {object}  response.ErrorBody
+// @Failure      401      {object}  response.ErrorBody
+// @Failure      403      {object}  response.ErrorBody
+// @Failure      409      {object}  response.ErrorBody
+// @Failure      500      {object}  response.ErrorBody
+// @Router       /api/v1/admin/experiments [post]
+func (h *Handler) CreateExperiment(c *gin.Context) {
+	var req CreateExperimentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid_request", "Некорректное тело запроса", err.Error())
+		return
+	}
+
+	isActive := true
+	if req.IsActive != nil {
+		isActive = *req.IsActive
+	}
+
+	e, err := h.experiments.CreateExperiment(c.Request.Context(), experimentuc.ExperimentInput{
+		Key:         req.Key,
+		Description: req.Description,
+		Variants:    toDomainVariants(req.Variants),
+		IsActive:    isActive,
+	})
+	if err != nil {
+		h.respondAdminError(c, "create_experiment", err)
+		return
+	}
+
+	h.logger.Info("experiment_created", map[string]any{
+		"experiment_key": e.Key,
+		"actor_id":       c.GetString(middleware.ContextUserIDKey),
+	})
+	c.JSON(http.StatusCreated, toExperimentResponse(e))
+}
+
+// UpdateExperiment godoc
+// @Summary      Обновить эксперимент (админ)
+// @Description  Обновляет описание, варианты и признак активности эксперимента.
+// @Tags         experiments
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        key      path      string                   true  "Ключ эксперимента"
+// @Param        payload  body      UpdateExperimentRequest  true  "Данные эксперимента"
+// @Success      200      {object}  ExperimentResponse
+// @Failure      400      {object}  response.ErrorBody
+// @Failure      401      {object}  response.ErrorBody
+// @Failure      403      {object}  response.ErrorBody
+// @Failure      404      {object}  response.ErrorBody
+// @Failure      500      {object}  response.ErrorBody
+// @Router       /api/v1/admin/experiments/{key} [put]
+func (h *Handler) UpdateExperiment(c *gin.Context) {
+	var req UpdateExperimentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid_request", "Некорректное тело запроса", err.Error())
+		return
+	}
+
+	e, err := h.experiments.UpdateExperiment(c.Request.Context(), c.Param("key"), experimentuc.ExperimentInput{
+		Description: req.Description,
+		Variants:    toDomainVariants(req.Variants),
+		IsActive:    req.IsActive,
+	})
+	if err != nil {
+		h.respondAdminError(c, "update_experiment", err)
+		return
+	}
+
+	h.logger.Info("experiment_updated", map[string]any{
+		"experiment_key": e.Key,
+		"actor_id":       c.GetString(middleware.ContextUserIDKey),
+		"is_active":      e.IsActive,
+	})
+	c.JSON(http.StatusOK, toExperimentResponse(e))
+}
+
+// respondAdminError отправляет ответ об ошибке для административных эндпоинтов экспериментов.
+func (h *Handler) respondAdminError(c *gin.Context, op string, err error) {
+	switch {
+	case errors.Is(err, experimentuc.ErrInvalidExperimentKey):
+		response.Error(c, http.StatusBadRequest, "invalid_experiment_key", "Ключ должен состоять из строчных латинских букв, цифр, '_' и '-' (2-64 символа)", nil)
+	case errors.Is(err, experimentuc.ErrInvalidVariants):
+		response.Error(c, http.StatusBadRequest, "invalid_variants", "Нужно минимум два варианта с уникальными именами и положительным суммарным весом", nil)
+	case errors.Is(err, repo.ErrExperimentExists):
+		response.Error(c, http.StatusConflict, "experiment_already_exists", "Эксперимент с таким ключом уже существует", nil)
+	case errors.Is(err, repo.ErrNotFound):
+		response.Error(c, http.StatusNotFound, "experiment_not_found", "Эксперимент не найден", nil)
+	default:
+		h.logger.Error("internal_error_in_"+op, map[string]any{
+			"path":   c.Request.URL.Path,
+			"method": c.Request.Method,
+			"error":  err.Error(),
+		})
+		response.Error(c, http.StatusInternalServerError, "internal_error", "Внутренняя ошибка сервера", nil)
+	}
+}
+
+// toDomainVariants маппит DTO вариантов в доменные модели.
+func toDomainVariants(variants []VariantDTO) []domain.Variant {
+	result := make([]domain.Variant, 0, len(variants))
+	for _, v := range variants {
+		result = append(result, domain.Variant{Name: v.Name, Weight: v.Weight})
+	}
+	return result
+}
+
+// toExperimentResponse маппит доменную модель в DTO.
+func toExperimentResponse(e *domain.Experiment) ExperimentResponse {
+	variants := make([]VariantDTO, 0, len(e.Variants))
+	for _, v := range e.Variants {
+		variants = append(variants, VariantDTO{Name: v.Name, Weight: v.Weight})
+	}
+	return ExperimentResponse{
+		Key:         e.Key,
+		Description: e.Description,
+		Variants:    variants,
+		IsActive:    e.IsActive,
+		CreatedAt:   e.CreatedAt,
+		UpdatedAt:   e.UpdatedAt,
+	}
+}
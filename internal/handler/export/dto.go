@@ -0,0 +1,15 @@
+package export
+
+import "time"
+
+// ExportResponse описывает состояние выгрузки данных аккаунта.
+type ExportResponse struct {
+	ID          string     `json:"id"`
+	Status      string     `json:"status"`
+	RequestedAt time.Time  `json:"requested_at"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+	// DownloadURL — подписанная ссылка на ZIP-архив (только для статуса ready); не требует Authorization.
+	DownloadURL string     `json:"download_url,omitempty"`
+	ExpiresAt   *time.Time `json:"expires_at,omitempty"`
+	SizeBytes   int64      `json:"size_bytes,omitempty"`
+}
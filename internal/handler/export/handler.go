@@ -0,0 +1,138 @@
+package export
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	domain "workout-app/internal/domain/export"
+	"workout-app/internal/handler/middleware"
+	"workout-app/internal/handler/response"
+	exportuc "workout-app/internal/usecase/export"
+	"workout-app/pkg/logger"
+)
+
+// Handler обрабатывает запросы выгрузки данных аккаунта.
+type Handler struct {
+	exports exportuc.Service
+	logger  logger.Logger
+}
+
+// NewHandler создаёт новый ExportHandler.
+func NewHandler(exports exportuc.Service, logger logger.Logger) *Handler {
+	return &Handler{
+		exports: exports,
+		logger:  logger,
+	}
+}
+
+// Request godoc
+// @Summary      Выгрузка данных аккаунта (GDPR)
+// @Description  Возвращает состояние выгрузки всех данных текущего пользователя (профиль, тренировки, замеры, назначения программ, доступы тренеров). Если действующей выгрузки нет, ставит новую в очередь и отвечает 202. Когда ZIP-архив собран, пользователь получает письмо со ссылкой, а ответ содержит download_url.
+// @Tags         users
+// @Security     BearerAuth
+// @Produce      json
+// @Success      200  {object}  ExportResponse
+// @Success      202  {object}  ExportResponse
+// @Failure      401  {object}  response.ErrorBody
+// @Failure      500  {object}  response.ErrorBody
+// @Router       /api/v1/users/me/export [get]
+func (h *Handler) Request(c *gin.Context) {
+	userID, err := middleware.UserIDFromContext(c)
+	if err != nil {
+		response.Error(c, http.StatusUnauthorized, "unauthorized", "Требуется аутентификация", nil)
+		return
+	}
+
+	e, err := h.exports.Request(c.Request.Context(), userID)
+	if err != nil {
+		h.logger.Error("internal_error_in_request_export", map[string]any{
+			"user_id": userID.String(),
+			"path":    c.Request.URL.Path,
+			"method":  c.Request.Method,
+			"error":   err.Error(),
+		})
+		response.Error(c, http.StatusInternalServerError, "internal_error", "Внутренняя ошибка сервера", nil)
+		return
+	}
+
+	resp := ExportResponse{
+		ID:          e.ID.String(),
+		Status:      string(e.Status),
+		RequestedAt: e.RequestedAt,
+		CompletedAt: e.CompletedAt,
+	}
+	if e.Status != domain.StatusReady {
+		c.JSON(http.StatusAccepted, resp)
+		return
+	}
+	link, err := h.exports.DownloadURL(e)
+	if err != nil {
+		h.logger.Error("data_export_link_failed", map[string]any{"export_id": e.ID.String(), "error": err.Error()})
+		response.Error(c, http.StatusInternalServerError, "internal_error", "Внутренняя ошибка сервера", nil)
+		return
+	}
+	resp.DownloadURL = link
+	resp.ExpiresAt = e.ExpiresAt
+	resp.SizeBytes = e.SizeBytes
+	c.JSON(http.StatusOK, resp)
+}
+
+// Download godoc
+// @Summary      Скачать архив с данными аккаунта
+// @Description  Отдаёт ZIP-архив по подписанной ссылке из письма или из GET /users/me/export. Заголовок Authorization не требуется. Если хранилище выдаёт временные ссылки (S3), отвечает редиректом.
+// @Tags         users
+// @Produce      application/zip
+// @Param        id         path      string  true  "ID выгрузки"
+// @Param        expires    query     string  true  "Срок действия ссылки (Unix time)"
+// @Param        signature  query     string  true  "Подпись ссылки"
+// @Success      200
+// @Success      302
+// @Failure      400        {object}  response.ErrorBody
+// @Failure      403        {object}  response.ErrorBody
+// @Failure      404        {object}  response.ErrorBody
+// @Failure      410        {object}  response.ErrorBody
+// @Failure      500        {object}  response.ErrorBody
+// @Router       /api/v1/exports/{id}/download [get]
+func (h *Handler) Download(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid_request", "Некорректный ID выгрузки", nil)
+		return
+	}
+
+	download, err := h.exports.Open(c.Request.Context(), id, c.Query("expires"), c.Query("signature"))
+	if err != nil {
+		switch {
+		case errors.Is(err, exportuc.ErrInvalidSignature):
+			response.Error(c, http.StatusForbidden, "invalid_signature", "Некорректная ссылка на архив", nil)
+		case errors.Is(err, exportuc.ErrLinkExpired):
+			response.Error(c, http.StatusGone, "link_expired", "Срок действия ссылки истёк, запросите выгрузку заново", nil)
+		case errors.Is(err, exportuc.ErrExportNotFound):
+			response.Error(c, http.StatusNotFound, "export_not_found", "Выгрузка не найдена", nil)
+		default:
+			h.logger.Error("internal_error_in_download_export", map[string]any{
+				"export_id": id.String(),
+				"path":      c.Request.URL.Path,
+				"method":    c.Request.Method,
+				"error":     err.Error(),
+			})
+			response.Error(c, http.StatusInternalServerError, "internal_error", "Внутренняя ошибка сервера", nil)
+		}
+		return
+	}
+
+	c.Header("Cache-Control", "private, no-store")
+	if download.RedirectURL != "" {
+		c.Redirect(http.StatusFound, download.RedirectURL)
+		return
+	}
+
+	defer download.Object.Close()
+	filename := "account-data-" + download.Export.RequestedAt.Format("2006-01-02") + ".zip"
+	c.Header("Content-Type", "application/zip")
+	c.Header("Content-Disposition", `attachment; filename="`+filename+`"`)
+	http.ServeContent(c.Writer, c.Request, "", download.Object.ModTime, download.Object)
+}
@@ -0,0 +1,63 @@
+package gymcheckin
+
+import "time"
+
+// LocationRequest описывает тело запроса для создания зала.
+type LocationRequest struct {
+	Name    string `json:"name" binding:"required,max=200" example:"Зал на Ленина"`
+	Address string `json:"address,omitempty" binding:"max=500" example:"ул. Ленина, 1"`
+}
+
+// LocationResponse описывает зал организации.
+type LocationResponse struct {
+	ID             string    `json:"id"`
+	OrganizationID string    `json:"organization_id"`
+	Name           string    `json:"name"`
+	Address        string    `json:"address,omitempty"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+// CodeResponse описывает действующий QR-код зала.
+type CodeResponse struct {
+	// Code — содержимое QR-кода; экран на входе отображает его и запрашивает новый к expires_at.
+	Code      string    `json:"code" example:"5f0c…e1.58874512.9b1f0d3c6a2e4b7f"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// CheckInRequest описывает тело запроса отметки в зале.
+type CheckInRequest struct {
+	// Code — содержимое отсканированного QR-кода.
+	Code string `json:"code" binding:"required,max=200"`
+}
+
+// VisitResponse описывает отметку в зале.
+type VisitResponse struct {
+	ID             string    `json:"id"`
+	LocationID     string    `json:"location_id"`
+	OrganizationID string    `json:"organization_id"`
+	UserID         string    `json:"user_id"`
+	CheckedInAt    time.Time `json:"checked_in_at"`
+}
+
+// AttendanceReportResponse описывает посещаемость залов организации за период.
+type AttendanceReportResponse struct {
+	From          time.Time                  `json:"from"`
+	To            time.Time                  `json:"to"`
+	TotalVisits   int                        `json:"total_visits"`
+	UniqueMembers int                        `json:"unique_members"`
+	Days          []DayAttendanceResponse    `json:"days"`
+	Members       []MemberAttendanceResponse `json:"members"`
+}
+
+// DayAttendanceResponse описывает число отметок за день (UTC).
+type DayAttendanceResponse struct {
+	Date   string `json:"date" example:"2026-10-15"`
+	Visits int    `json:"visits"`
+}
+
+// MemberAttendanceResponse описывает посещаемость участника за период.
+type MemberAttendanceResponse struct {
+	UserID      string    `json:"user_id"`
+	Visits      int       `json:"visits"`
+	LastVisitAt time.Time `json:"last_visit_at"`
+}
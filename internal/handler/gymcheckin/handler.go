@@ -0,0 +1,438 @@
+package gymcheckin
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	domain "workout-app/internal/domain/gymcheckin"
+	userdomain "workout-app/internal/domain/user"
+	"workout-app/internal/handler/middleware"
+	"workout-app/internal/handler/response"
+	gymcheckinuc "workout-app/internal/usecase/gymcheckin"
+	"workout-app/pkg/logger"
+)
+
+const (
+	dateLayout = "2006-01-02"
+	// defaultPeriod — период истории и отчёта по умолчанию (до текущего момента).
+	defaultPeriod = 30 * 24 * time.Hour
+)
+
+// Handler обрабатывает HTTP-запросы отметок в залах организаций.
+type Handler struct {
+	checkIns gymcheckinuc.Service
+	logger   logger.Logger
+}
+
+// NewHandler создаёт новый GymCheckInHandler.
+func NewHandler(checkIns gymcheckinuc.Service, logger logger.Logger) *Handler {
+	return &Handler{
+		checkIns: checkIns,
+		logger:   logger,
+	}
+}
+
+// CreateLocation godoc
+// @Summary      Создать зал организации
+// @Description  Создаёт зал, в котором участники отмечаются по QR-коду. Доступно владельцу организации и администраторам.
+// @Tags         gym-checkins
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        id       path      string           true  "ID организации"
+// @Param        payload  body      LocationRequest  true  "Зал"
+// @Success      201      {object}  LocationResponse
+// @Failure      400      {object}  response.ErrorBody
+// @Failure      401      {object}  response.ErrorBody
+// @Failure      403      {object}  response.ErrorBody
+// @Failure      404      {object}  response.ErrorBody
+// @Failure      500      {object}  response.ErrorBody
+// @Router       /api/v1/organizations/{id}/locations [post]
+func (h *Handler) CreateLocation(c *gin.Context) {
+	actor, ok := actorFromContext(c)
+	if !ok {
+		response.Error(c, http.StatusUnauthorized, "unauthorized", "Требуется аутентификация", nil)
+		return
+	}
+	orgID, ok := parseID(c, "invalid_organization_id", "Некорректный ID организации")
+	if !ok {
+		return
+	}
+
+	var req LocationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid_request", "Некорректное тело запроса", err.Error())
+		return
+	}
+
+	l, err := h.checkIns.CreateLocation(c.Request.Context(), actor, orgID, gymcheckinuc.LocationInput{
+		Name:    req.Name,
+		Address: req.Address,
+	})
+	if err != nil {
+		h.respondError(c, "create_gym_location", err)
+		return
+	}
+	c.JSON(http.StatusCreated, toLocationResponse(l))
+}
+
+// ListLocations godoc
+// @Summary      Залы организации
+// @Description  Возвращает залы организации. Доступно участникам организации.
+// @Tags         gym-checkins
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id   path      string  true  "ID организации"
+// @Success      200  {array}   LocationResponse
+// @Failure      400  {object}  response.ErrorBody
+// @Failure      401  {object}  response.ErrorBody
+// @Failure      404  {object}  response.ErrorBody
+// @Failure      500  {object}  response.ErrorBody
+// @Router       /api/v1/organizations/{id}/locations [get]
+func (h *Handler) ListLocations(c *gin.Context) {
+	actor, ok := actorFromContext(c)
+	if !ok {
+		response.Error(c, http.StatusUnauthorized, "unauthorized", "Требуется аутентификация", nil)
+		return
+	}
+	orgID, ok := parseID(c, "invalid_organization_id", "Некорректный ID организации")
+	if !ok {
+		return
+	}
+
+	locations, err := h.checkIns.ListLocations(c.Request.Context(), actor, orgID)
+	if err != nil {
+		h.respondError(c, "list_gym_locations", err)
+		return
+	}
+	resp := make([]LocationResponse, 0, len(locations))
+	for _, l := range locations {
+		resp = append(resp, toLocationResponse(l))
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// DeleteLocation godoc
+// @Summary      Удалить зал
+// @Description  Удаляет зал вместе с историей отметок в нём. Доступно владельцу организации и администраторам.
+// @Tags         gym-checkins
+// @Security     BearerAuth
+// @Param        id  path  string  true  "ID зала"
+// @Success      204
+// @Failure      400  {object}  response.ErrorBody
+// @Failure      401  {object}  response.ErrorBody
+// @Failure      403  {object}  response.ErrorBody
+// @Failure      404  {object}  response.ErrorBody
+// @Failure      500  {object}  response.ErrorBody
+// @Router       /api/v1/locations/{id} [delete]
+func (h *Handler) DeleteLocation(c *gin.Context) {
+	actor, ok := actorFromContext(c)
+	if !ok {
+		response.Error(c, http.StatusUnauthorized, "unauthorized", "Требуется аутентификация", nil)
+		return
+	}
+	locationID, ok := parseID(c, "invalid_location_id", "Некорректный ID зала")
+	if !ok {
+		return
+	}
+
+	if err := h.checkIns.DeleteLocation(c.Request.Context(), actor, locationID); err != nil {
+		h.respondError(c, "delete_gym_location", err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// Code godoc
+// @Summary      Текущий QR-код зала
+// @Description  Возвращает содержимое QR-кода для экрана на входе. Код меняется каждые 30 секунд; код предыдущего периода
+// @Description  ещё принимается, поэтому экрану достаточно запрашивать новый к expires_at. Доступно владельцу организации и администраторам.
+// @Tags         gym-checkins
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id   path      string  true  "ID зала"
+// @Success      200  {object}  CodeResponse
+// @Failure      400  {object}  response.ErrorBody
+// @Failure      401  {object}  response.ErrorBody
+// @Failure      403  {object}  response.ErrorBody
+// @Failure      404  {object}  response.ErrorBody
+// @Failure      500  {object}  response.ErrorBody
+// @Router       /api/v1/locations/{id}/code [get]
+func (h *Handler) Code(c *gin.Context) {
+	actor, ok := actorFromContext(c)
+	if !ok {
+		response.Error(c, http.StatusUnauthorized, "unauthorized", "Требуется аутентификация", nil)
+		return
+	}
+	locationID, ok := parseID(c, "invalid_location_id", "Некорректный ID зала")
+	if !ok {
+		return
+	}
+
+	code, err := h.checkIns.CurrentCode(c.Request.Context(), actor, locationID)
+	if err != nil {
+		h.respondError(c, "gym_location_code", err)
+		return
+	}
+	c.Header("Cache-Control", "no-store")
+	c.JSON(http.StatusOK, CodeResponse{Code: code.Code, ExpiresAt: code.ExpiresAt})
+}
+
+// CheckIn godoc
+// @Summary      Отметиться в зале по QR-коду
+// @Description  Проверяет отсканированный QR-код и записывает посещение. Отметиться может участник организации, которой принадлежит зал.
+// @Description  Повторная отметка в том же зале в течение 2 часов возвращает уже записанное посещение с кодом 200.
+// @Tags         gym-checkins
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        payload  body      CheckInRequest  true  "QR-код"
+// @Success      201      {object}  VisitResponse
+// @Success      200      {object}  VisitResponse
+// @Failure      400      {object}  response.ErrorBody
+// @Failure      401      {object}  response.ErrorBody
+// @Failure      403      {object}  response.ErrorBody
+// @Failure      422      {object}  response.ErrorBody
+// @Failure      500      {object}  response.ErrorBody
+// @Router       /api/v1/checkins/gym [post]
+func (h *Handler) CheckIn(c *gin.Context) {
+	actor, ok := actorFromContext(c)
+	if !ok {
+		response.Error(c, http.StatusUnauthorized, "unauthorized", "Требуется аутентификация", nil)
+		return
+	}
+
+	var req CheckInRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid_request", "Некорректное тело запроса", err.Error())
+		return
+	}
+
+	visit, created, err := h.checkIns.CheckIn(c.Request.Context(), actor, req.Code)
+	if err != nil {
+		h.respondError(c, "gym_check_in", err)
+		return
+	}
+	status := http.StatusOK
+	if created {
+		status = http.StatusCreated
+	}
+	c.JSON(status, toVisitResponse(visit))
+}
+
+// ListMine godoc
+// @Summary      История посещений залов
+// @Description  Возвращает отметки текущего пользователя в залах за [from, to) (RFC3339 или YYYY-MM-DD), новые первыми;
+// @Description  по умолчанию — последние 30 дней, не больше 366 дней.
+// @Tags         gym-checkins
+// @Security     BearerAuth
+// @Produce      json
+// @Param        from  query     string  false  "Начало периода"
+// @Param        to    query     string  false  "Конец периода"
+// @Success      200   {array}   VisitResponse
+// @Failure      400   {object}  response.ErrorBody
+// @Failure      401   {object}  response.ErrorBody
+// @Failure      500   {object}  response.ErrorBody
+// @Router       /api/v1/checkins/gym [get]
+func (h *Handler) ListMine(c *gin.Context) {
+	actor, ok := actorFromContext(c)
+	if !ok {
+		response.Error(c, http.StatusUnauthorized, "unauthorized", "Требуется аутентификация", nil)
+		return
+	}
+	from, to, ok := parsePeriod(c)
+	if !ok {
+		return
+	}
+
+	visits, err := h.checkIns.ListMyVisits(c.Request.Context(), actor.UserID, from, to)
+	if err != nil {
+		h.respondError(c, "list_gym_visits", err)
+		return
+	}
+	resp := make([]VisitResponse, 0, len(visits))
+	for _, v := range visits {
+		resp = append(resp, toVisitResponse(v))
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// Attendance godoc
+// @Summary      Отчёт о посещаемости залов организации
+// @Description  Возвращает число посещений и уникальных участников за [from, to) (RFC3339 или YYYY-MM-DD; по умолчанию — последние 30 дней,
+// @Description  не больше 366 дней) с разбивкой по дням (UTC) и участникам. Доступно владельцу организации и администраторам.
+// @Tags         gym-checkins
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id           path      string  true   "ID организации"
+// @Param        from         query     string  false  "Начало периода"
+// @Param        to           query     string  false  "Конец периода"
+// @Param        location_id  query     string  false  "Только один зал"
+// @Success      200          {object}  AttendanceReportResponse
+// @Failure      400          {object}  response.ErrorBody
+// @Failure      401          {object}  response.ErrorBody
+// @Failure      403          {object}  response.ErrorBody
+// @Failure      404          {object}  response.ErrorBody
+// @Failure      500          {object}  response.ErrorBody
+// @Router       /api/v1/organizations/{id}/attendance [get]
+func (h *Handler) Attendance(c *gin.Context) {
+	actor, ok := actorFromContext(c)
+	if !ok {
+		response.Error(c, http.StatusUnauthorized, "unauthorized", "Требуется аутентификация", nil)
+		return
+	}
+	orgID, ok := parseID(c, "invalid_organization_id", "Некорректный ID организации")
+	if !ok {
+		return
+	}
+	from, to, ok := parsePeriod(c)
+	if !ok {
+		return
+	}
+	filter := gymcheckinuc.ReportFilter{From: from, To: to}
+	if raw := c.Query("location_id"); raw != "" {
+		locationID, err := uuid.Parse(raw)
+		if err != nil {
+			response.Error(c, http.StatusBadRequest, "invalid_location_id", "Некорректный ID зала", nil)
+			return
+		}
+		filter.LocationID = &locationID
+	}
+
+	report, err := h.checkIns.AttendanceReport(c.Request.Context(), actor, orgID, filter)
+	if err != nil {
+		h.respondError(c, "gym_attendance_report", err)
+		return
+	}
+
+	resp := AttendanceReportResponse{
+		From:          report.From,
+		To:            report.To,
+		TotalVisits:   report.TotalVisits,
+		UniqueMembers: report.UniqueMembers,
+		Days:          make([]DayAttendanceResponse, 0, len(report.Days)),
+		Members:       make([]MemberAttendanceResponse, 0, len(report.Members)),
+	}
+	for _, d := range report.Days {
+		resp.Days = append(resp.Days, DayAttendanceResponse{Date: d.Date.Format(dateLayout), Visits: d.Visits})
+	}
+	for _, m := range report.Members {
+		resp.Members = append(resp.Members, MemberAttendanceResponse{
+			UserID:      m.UserID.String(),
+			Visits:      m.Visits,
+			LastVisitAt: m.LastVisitAt,
+		})
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// respondError отправляет ответ об ошибке для эндпоинтов отметок в залах.
+func (h *Handler) respondError(c *gin.Context, op string, err error) {
+	switch {
+	case errors.Is(err, gymcheckinuc.ErrInvalidLocation):
+		response.Error(c, http.StatusBadRequest, "invalid_location", "Некорректные параметры зала", err.Error())
+	case errors.Is(err, gymcheckinuc.ErrInvalidPeriod):
+		response.Error(c, http.StatusBadRequest, "invalid_range", "Некорректный период", err.Error())
+	case errors.Is(err, gymcheckinuc.ErrForbidden):
+		response.Error(c, http.StatusForbidden, "forbidden", "Недостаточно прав в организации", nil)
+	case errors.Is(err, gymcheckinuc.ErrNotMember):
+		response.Error(c, http.StatusForbidden, "not_member", "Вы не состоите в организации этого зала", nil)
+	case errors.Is(err, gymcheckinuc.ErrLocationNotFound):
+		response.Error(c, http.StatusNotFound, "location_not_found", "Зал не найден", nil)
+	case errors.Is(err, gymcheckinuc.ErrInvalidCode):
+		response.Error(c, http.StatusUnprocessableEntity, "invalid_code", "QR-код недействителен или устарел, отсканируйте его ещё раз", nil)
+	default:
+		h.logger.Error("internal_error_in_"+op, map[string]any{
+			"user_id": c.GetString(middleware.ContextUserIDKey),
+			"path":    c.Request.URL.Path,
+			"method":  c.Request.Method,
+			"error":   err.Error(),
+		})
+		response.Error(c, http.StatusInternalServerError, "internal_error", "Внутренняя ошибка сервера", nil)
+	}
+}
+
+// actorFromContext извлекает ID и роль текущего пользователя из контекста запроса.
+func actorFromContext(c *gin.Context) (gymcheckinuc.Actor, bool) {
+	userID, err := middleware.UserIDFromContext(c)
+	if err != nil {
+		return gymcheckinuc.Actor{}, false
+	}
+	return gymcheckinuc.Actor{
+		UserID: userID,
+		Role:   userdomain.Role(c.GetString(middleware.ContextUserRoleKey)),
+	}, true
+}
+
+// parseID разбирает UUID из параметра пути :id. При ошибке ответ уже отправлен.
+func parseID(c *gin.Context, code, message string) (uuid.UUID, bool) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, code, message, nil)
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+// parsePeriod разбирает период ?from=&to= (RFC3339 или YYYY-MM-DD; дата в to включается целиком).
+// По умолчанию период заканчивается текущим моментом и длится defaultPeriod. При ошибке ответ уже отправлен.
+func parsePeriod(c *gin.Context) (time.Time, time.Time, bool) {
+	to := time.Now().UTC()
+	if raw := c.Query("to"); raw != "" {
+		t, err := parseTimeParam(raw, true)
+		if err != nil {
+			response.Error(c, http.StatusBadRequest, "invalid_request", "Некорректный параметр to", nil)
+			return time.Time{}, time.Time{}, false
+		}
+		to = t
+	}
+	from := to.Add(-defaultPeriod)
+	if raw := c.Query("from"); raw != "" {
+		t, err := parseTimeParam(raw, false)
+		if err != nil {
+			response.Error(c, http.StatusBadRequest, "invalid_request", "Некорректный параметр from", nil)
+			return time.Time{}, time.Time{}, false
+		}
+		from = t
+	}
+	return from, to, true
+}
+
+// parseTimeParam разбирает RFC3339 или YYYY-MM-DD; для конца периода дата сдвигается на начало следующего дня.
+func parseTimeParam(raw string, nextDay bool) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, raw); err == nil {
+		return t, nil
+	}
+	t, err := time.Parse(dateLayout, raw)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if nextDay {
+		t = t.Add(24 * time.Hour)
+	}
+	return t, nil
+}
+
+func toLocationResponse(l *domain.Location) LocationResponse {
+	return LocationResponse{
+		ID:             l.ID.String(),
+		OrganizationID: l.OrganizationID.String(),
+		Name:           l.Name,
+		Address:        l.Address,
+		CreatedAt:      l.CreatedAt,
+	}
+}
+
+func toVisitResponse(v *domain.Visit) VisitResponse {
+	return VisitResponse{
+		ID:             v.ID.String(),
+		LocationID:     v.LocationID.String(),
+		OrganizationID: v.OrganizationID.String(),
+		UserID:         v.UserID.String(),
+		CheckedInAt:    v.CheckedInAt,
+	}
+}
@@ -0,0 +1,79 @@
+package gymclass
+
+import "time"
+
+// ClassRequest описывает тело запроса для создания и изменения группового занятия.
+type ClassRequest struct {
+	Title       string `json:"title" binding:"required,max=200" example:"Функциональный тренинг"`
+	Description string `json:"description,omitempty" binding:"max=2000"`
+	// CoachID — тренер занятия (участник организации), отмечает посещаемость.
+	CoachID         string `json:"coach_id,omitempty" binding:"omitempty,uuid"`
+	Capacity        int    `json:"capacity" binding:"required,min=1,max=500" example:"12"`
+	DurationMinutes int    `json:"duration_minutes" binding:"required,min=1,max=1440" example:"55"`
+}
+
+// SlotRequest описывает тело запроса для добавления еженедельного слота занятия.
+type SlotRequest struct {
+	// Weekday — день недели: 1 (понедельник) ... 7 (воскресенье).
+	Weekday int `json:"weekday" binding:"required,min=1,max=7" example:"2"`
+	// StartTime — время начала в UTC в формате HH:MM.
+	StartTime string `json:"start_time" binding:"required" example:"18:30"`
+}
+
+// SlotResponse описывает еженедельный слот занятия.
+type SlotResponse struct {
+	ID        string `json:"id"`
+	Weekday   int    `json:"weekday"`
+	StartTime string `json:"start_time"`
+}
+
+// ClassResponse описывает групповое занятие с еженедельным расписанием.
+type ClassResponse struct {
+	ID              string         `json:"id"`
+	OrganizationID  string         `json:"organization_id"`
+	Title           string         `json:"title"`
+	Description     string         `json:"description,omitempty"`
+	CoachID         *string        `json:"coach_id,omitempty"`
+	Capacity        int            `json:"capacity"`
+	DurationMinutes int            `json:"duration_minutes"`
+	Slots           []SlotResponse `json:"slots"`
+	CreatedAt       time.Time      `json:"created_at"`
+	UpdatedAt       time.Time      `json:"updated_at"`
+}
+
+// ScheduledClassResponse описывает конкретное занятие расписания организации.
+type ScheduledClassResponse struct {
+	ClassID    string           `json:"class_id"`
+	Title      string           `json:"title"`
+	CoachID    *string          `json:"coach_id,omitempty"`
+	StartsAt   time.Time        `json:"starts_at"`
+	EndsAt     time.Time        `json:"ends_at"`
+	Capacity   int              `json:"capacity"`
+	Booked     int              `json:"booked"`
+	Waitlisted int              `json:"waitlisted"`
+	SpotsLeft  int              `json:"spots_left"`
+	MyBooking  *BookingResponse `json:"my_booking,omitempty"`
+}
+
+// BookRequest описывает тело запроса для записи на занятие.
+type BookRequest struct {
+	// StartsAt — начало занятия из расписания организации (RFC3339).
+	StartsAt time.Time `json:"starts_at" binding:"required" example:"2026-10-20T18:30:00Z"`
+}
+
+// AttendanceRequest описывает тело запроса для отметки посещения.
+type AttendanceRequest struct {
+	Attended *bool `json:"attended" binding:"required"`
+}
+
+// BookingResponse описывает запись на занятие.
+type BookingResponse struct {
+	ID        string    `json:"id"`
+	ClassID   string    `json:"class_id"`
+	UserID    string    `json:"user_id"`
+	StartsAt  time.Time `json:"starts_at"`
+	Status    string    `json:"status" enums:"booked,waitlisted,cancelled"`
+	Attended  *bool     `json:"attended,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
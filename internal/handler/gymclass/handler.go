@@ -0,0 +1,697 @@
+package gymclass
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	domain "workout-app/internal/domain/gymclass"
+	userdomain "workout-app/internal/domain/user"
+	"workout-app/internal/handler/middleware"
+	"workout-app/internal/handler/response"
+	gymclassuc "workout-app/internal/usecase/gymclass"
+	"workout-app/pkg/logger"
+)
+
+// defaultBookingsPeriod — период списка своих записей по умолчанию (от текущего момента).
+const defaultBookingsPeriod = 30 * 24 * time.Hour
+
+// Handler обрабатывает HTTP-запросы групповых занятий организаций.
+type Handler struct {
+	classes gymclassuc.Service
+	logger  logger.Logger
+}
+
+// NewHandler создаёт новый GymClassHandler.
+func NewHandler(classes gymclassuc.Service, logger logger.Logger) *Handler {
+	return &Handler{
+		classes: classes,
+		logger:  logger,
+	}
+}
+
+// Create godoc
+// @Summary      Создать групповое занятие
+// @Description  Создаёт занятие организации. Расписание задаётся еженедельными слотами. Доступно владельцу организации и администраторам.
+// @Tags         classes
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        id       path      string        true  "ID организации"
+// @Param        payload  body      ClassRequest  true  "Занятие"
+// @Success      201      {object}  ClassResponse
+// @Failure      400      {object}  response.ErrorBody
+// @Failure      401      {object}  response.ErrorBody
+// @Failure      403      {object}  response.ErrorBody
+// @Failure      404      {object}  response.ErrorBody
+// @Failure      500      {object}  response.ErrorBody
+// @Router       /api/v1/organizations/{id}/classes [post]
+func (h *Handler) Create(c *gin.Context) {
+	actor, ok := actorFromContext(c)
+	if !ok {
+		response.Error(c, http.StatusUnauthorized, "unauthorized", "Требуется аутентификация", nil)
+		return
+	}
+	orgID, ok := parseID(c, "id", "invalid_organization_id", "Некорректный ID организации")
+	if !ok {
+		return
+	}
+	input, ok := bindClassInput(c)
+	if !ok {
+		return
+	}
+
+	class, err := h.classes.CreateClass(c.Request.Context(), actor, orgID, input)
+	if err != nil {
+		h.respondError(c, "create_class", err)
+		return
+	}
+	c.JSON(http.StatusCreated, toClassResponse(class, nil))
+}
+
+// List godoc
+// @Summary      Групповые занятия организации
+// @Description  Возвращает занятия организации с еженедельным расписанием. Доступно участникам организации.
+// @Tags         classes
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id   path      string  true  "ID организации"
+// @Success      200  {array}   ClassResponse
+// @Failure      400  {object}  response.ErrorBody
+// @Failure      401  {object}  response.ErrorBody
+// @Failure      404  {object}  response.ErrorBody
+// @Failure      500  {object}  response.ErrorBody
+// @Router       /api/v1/organizations/{id}/classes [get]
+func (h *Handler) List(c *gin.Context) {
+	actor, ok := actorFromContext(c)
+	if !ok {
+		response.Error(c, http.StatusUnauthorized, "unauthorized", "Требуется аутентификация", nil)
+		return
+	}
+	orgID, ok := parseID(c, "id", "invalid_organization_id", "Некорректный ID организации")
+	if !ok {
+		return
+	}
+
+	classes, err := h.classes.ListClasses(c.Request.Context(), actor, orgID)
+	if err != nil {
+		h.respondError(c, "list_classes", err)
+		return
+	}
+	resp := make([]ClassResponse, 0, len(classes))
+	for _, cs := range classes {
+		resp = append(resp, toClassResponse(cs.Class, cs.Slots))
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// Schedule godoc
+// @Summary      Расписание групповых занятий организации
+// @Description  Возвращает занятия организации с началом в [from, to) (RFC3339 или YYYY-MM-DD, по умолчанию — 7 дней от текущего момента, не больше 31 дня)
+// @Description  с числом записей, свободными местами и записью текущего пользователя. Доступно участникам организации.
+// @Tags         classes
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id    path      string  true   "ID организации"
+// @Param        from  query     string  false  "Начало периода"
+// @Param        to    query     string  false  "Конец периода"
+// @Success      200   {array}   ScheduledClassResponse
+// @Failure      400   {object}  response.ErrorBody
+// @Failure      401   {object}  response.ErrorBody
+// @Failure      404   {object}  response.ErrorBody
+// @Failure      500   {object}  response.ErrorBody
+// @Router       /api/v1/organizations/{id}/class-schedule [get]
+func (h *Handler) Schedule(c *gin.Context) {
+	actor, ok := actorFromContext(c)
+	if !ok {
+		response.Error(c, http.StatusUnauthorized, "unauthorized", "Требуется аутентификация", nil)
+		return
+	}
+	orgID, ok := parseID(c, "id", "invalid_organization_id", "Некорректный ID организации")
+	if !ok {
+		return
+	}
+	from, to, ok := parsePeriod(c, 7*24*time.Hour)
+	if !ok {
+		return
+	}
+
+	scheduled, err := h.classes.Schedule(c.Request.Context(), actor, orgID, from, to)
+	if err != nil {
+		h.respondError(c, "class_schedule", err)
+		return
+	}
+	resp := make([]ScheduledClassResponse, 0, len(scheduled))
+	for _, sc := range scheduled {
+		item := ScheduledClassResponse{
+			ClassID:    sc.Class.ID.String(),
+			Title:      sc.Class.Title,
+			CoachID:    uuidString(sc.Class.CoachID),
+			StartsAt:   sc.Occurrence.StartsAt,
+			EndsAt:     sc.Occurrence.EndsAt,
+			Capacity:   sc.Class.Capacity,
+			Booked:     sc.Booked,
+			Waitlisted: sc.Waitlisted,
+			SpotsLeft:  max(sc.Class.Capacity-sc.Booked, 0),
+		}
+		if sc.MyBooking != nil {
+			b := toBookingResponse(sc.MyBooking)
+			item.MyBooking = &b
+		}
+		resp = append(resp, item)
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// Update godoc
+// @Summary      Изменить групповое занятие
+// @Description  Изменяет занятие. При увеличении вместимости участники из листа ожидания предстоящих занятий получают места. Доступно владельцу организации и администраторам.
+// @Tags         classes
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        id       path      string        true  "ID занятия"
+// @Param        payload  body      ClassRequest  true  "Занятие"
+// @Success      200      {object}  ClassResponse
+// @Failure      400      {object}  response.ErrorBody
+// @Failure      401      {object}  response.ErrorBody
+// @Failure      403      {object}  response.ErrorBody
+// @Failure      404      {object}  response.ErrorBody
+// @Failure      500      {object}  response.ErrorBody
+// @Router       /api/v1/classes/{id} [put]
+func (h *Handler) Update(c *gin.Context) {
+	actor, ok := actorFromContext(c)
+	if !ok {
+		response.Error(c, http.StatusUnauthorized, "unauthorized", "Требуется аутентификация", nil)
+		return
+	}
+	classID, ok := parseID(c, "id", "invalid_class_id", "Некорректный ID занятия")
+	if !ok {
+		return
+	}
+	input, ok := bindClassInput(c)
+	if !ok {
+		return
+	}
+
+	class, err := h.classes.UpdateClass(c.Request.Context(), actor, classID, input)
+	if err != nil {
+		h.respondError(c, "update_class", err)
+		return
+	}
+	c.JSON(http.StatusOK, toClassResponse(class, nil))
+}
+
+// Delete godoc
+// @Summary      Удалить групповое занятие
+// @Description  Удаляет занятие вместе с расписанием и записями. Доступно владельцу организации и администраторам.
+// @Tags         classes
+// @Security     BearerAuth
+// @Param        id  path  string  true  "ID занятия"
+// @Success      204
+// @Failure      400  {object}  response.ErrorBody
+// @Failure      401  {object}  response.ErrorBody
+// @Failure      403  {object}  response.ErrorBody
+// @Failure      404  {object}  response.ErrorBody
+// @Failure      500  {object}  response.ErrorBody
+// @Router       /api/v1/classes/{id} [delete]
+func (h *Handler) Delete(c *gin.Context) {
+	actor, ok := actorFromContext(c)
+	if !ok {
+		response.Error(c, http.StatusUnauthorized, "unauthorized", "Требуется аутентификация", nil)
+		return
+	}
+	classID, ok := parseID(c, "id", "invalid_class_id", "Некорректный ID занятия")
+	if !ok {
+		return
+	}
+
+	if err := h.classes.DeleteClass(c.Request.Context(), actor, classID); err != nil {
+		h.respondError(c, "delete_class", err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// AddSlot godoc
+// @Summary      Добавить слот расписания занятия
+// @Description  Добавляет еженедельное время проведения занятия (день недели и время начала в UTC). Доступно владельцу организации и администраторам.
+// @Tags         classes
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        id       path      string       true  "ID занятия"
+// @Param        payload  body      SlotRequest  true  "Слот"
+// @Success      201      {object}  SlotResponse
+// @Failure      400      {object}  response.ErrorBody
+// @Failure      401      {object}  response.ErrorBody
+// @Failure      403      {object}  response.ErrorBody
+// @Failure      404      {object}  response.ErrorBody
+// @Failure      409      {object}  response.ErrorBody
+// @Failure      500      {object}  response.ErrorBody
+// @Router       /api/v1/classes/{id}/slots [post]
+func (h *Handler) AddSlot(c *gin.Context) {
+	actor, ok := actorFromContext(c)
+	if !ok {
+		response.Error(c, http.StatusUnauthorized, "unauthorized", "Требуется аутентификация", nil)
+		return
+	}
+	classID, ok := parseID(c, "id", "invalid_class_id", "Некорректный ID занятия")
+	if !ok {
+		return
+	}
+
+	var req SlotRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid_request", "Некорректное тело запроса", err.Error())
+		return
+	}
+	start, err := time.Parse("15:04", req.StartTime)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid_slot", "Время начала должно быть в формате HH:MM", nil)
+		return
+	}
+
+	slot, err := h.classes.AddSlot(c.Request.Context(), actor, classID, req.Weekday, start.Hour()*60+start.Minute())
+	if err != nil {
+		h.respondError(c, "add_class_slot", err)
+		return
+	}
+	c.JSON(http.StatusCreated, toSlotResponse(slot))
+}
+
+// DeleteSlot godoc
+// @Summary      Удалить слот расписания занятия
+// @Description  Удаляет еженедельное время проведения занятия. Доступно владельцу организации и администраторам.
+// @Tags         classes
+// @Security     BearerAuth
+// @Param        id       path  string  true  "ID занятия"
+// @Param        slot_id  path  string  true  "ID слота"
+// @Success      204
+// @Failure      400  {object}  response.ErrorBody
+// @Failure      401  {object}  response.ErrorBody
+// @Failure      403  {object}  response.ErrorBody
+// @Failure      404  {object}  response.ErrorBody
+// @Failure      500  {object}  response.ErrorBody
+// @Router       /api/v1/classes/{id}/slots/{slot_id} [delete]
+func (h *Handler) DeleteSlot(c *gin.Context) {
+	actor, ok := actorFromContext(c)
+	if !ok {
+		response.Error(c, http.StatusUnauthorized, "unauthorized", "Требуется аутентификация", nil)
+		return
+	}
+	classID, ok := parseID(c, "id", "invalid_class_id", "Некорректный ID занятия")
+	if !ok {
+		return
+	}
+	slotID, ok := parseID(c, "slot_id", "invalid_slot_id", "Некорректный ID слота")
+	if !ok {
+		return
+	}
+
+	if err := h.classes.DeleteSlot(c.Request.Context(), actor, classID, slotID); err != nil {
+		h.respondError(c, "delete_class_slot", err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// Roster godoc
+// @Summary      Список записавшихся на занятие
+// @Description  Возвращает записи на занятие: сначала подтверждённые, затем лист ожидания в порядке записи. Доступно тренеру занятия, владельцу организации и администраторам.
+// @Tags         classes
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id         path      string  true  "ID занятия"
+// @Param        starts_at  query     string  true  "Начало занятия (RFC3339)"
+// @Success      200        {array}   BookingResponse
+// @Failure      400        {object}  response.ErrorBody
+// @Failure      401        {object}  response.ErrorBody
+// @Failure      403        {object}  response.ErrorBody
+// @Failure      404        {object}  response.ErrorBody
+// @Failure      500        {object}  response.ErrorBody
+// @Router       /api/v1/classes/{id}/roster [get]
+func (h *Handler) Roster(c *gin.Context) {
+	actor, ok := actorFromContext(c)
+	if !ok {
+		response.Error(c, http.StatusUnauthorized, "unauthorized", "Требуется аутентификация", nil)
+		return
+	}
+	classID, ok := parseID(c, "id", "invalid_class_id", "Некорректный ID занятия")
+	if !ok {
+		return
+	}
+	startsAt, err := time.Parse(time.RFC3339, c.Query("starts_at"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid_request", "Параметр starts_at должен быть в формате RFC3339", nil)
+		return
+	}
+
+	bookings, err := h.classes.Roster(c.Request.Context(), actor, classID, startsAt)
+	if err != nil {
+		h.respondError(c, "class_roster", err)
+		return
+	}
+	c.JSON(http.StatusOK, toBookingResponses(bookings))
+}
+
+// Book godoc
+// @Summary      Записаться на занятие
+// @Description  Записывает текущего пользователя на занятие из расписания организации. Если мест нет, запись попадает в лист ожидания (status=waitlisted)
+// @Description  и подтверждается автоматически, когда место освободится. Запись открыта до начала занятия и не раньше чем за 60 дней. Доступно участникам организации.
+// @Tags         classes
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        id       path      string       true  "ID занятия"
+// @Param        payload  body      BookRequest  true  "Начало занятия"
+// @Success      201      {object}  BookingResponse
+// @Failure      400      {object}  response.ErrorBody
+// @Failure      401      {object}  response.ErrorBody
+// @Failure      404      {object}  response.ErrorBody
+// @Failure      409      {object}  response.ErrorBody
+// @Failure      422      {object}  response.ErrorBody
+// @Failure      500      {object}  response.ErrorBody
+// @Router       /api/v1/classes/{id}/bookings [post]
+func (h *Handler) Book(c *gin.Context) {
+	actor, ok := actorFromContext(c)
+	if !ok {
+		response.Error(c, http.StatusUnauthorized, "unauthorized", "Требуется аутентификация", nil)
+		return
+	}
+	classID, ok := parseID(c, "id", "invalid_class_id", "Некорректный ID занятия")
+	if !ok {
+		return
+	}
+
+	var req BookRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid_request", "Некорректное тело запроса", err.Error())
+		return
+	}
+
+	booking, err := h.classes.Book(c.Request.Context(), actor, classID, req.StartsAt)
+	if err != nil {
+		h.respondError(c, "book_class", err)
+		return
+	}
+	c.JSON(http.StatusCreated, toBookingResponse(booking))
+}
+
+// ListMine godoc
+// @Summary      Мои записи на занятия
+// @Description  Возвращает записи текущего пользователя (включая отменённые) на занятия с началом в [from, to); по умолчанию — 30 дней от текущего момента.
+// @Tags         classes
+// @Security     BearerAuth
+// @Produce      json
+// @Param        from  query     string  false  "Начало периода (RFC3339 или YYYY-MM-DD)"
+// @Param        to    query     string  false  "Конец периода (RFC3339 или YYYY-MM-DD)"
+// @Success      200   {array}   BookingResponse
+// @Failure      400   {object}  response.ErrorBody
+// @Failure      401   {object}  response.ErrorBody
+// @Failure      500   {object}  response.ErrorBody
+// @Router       /api/v1/class-bookings [get]
+func (h *Handler) ListMine(c *gin.Context) {
+	actor, ok := actorFromContext(c)
+	if !ok {
+		response.Error(c, http.StatusUnauthorized, "unauthorized", "Требуется аутентификация", nil)
+		return
+	}
+	from, to, ok := parsePeriod(c, defaultBookingsPeriod)
+	if !ok {
+		return
+	}
+
+	bookings, err := h.classes.ListMyBookings(c.Request.Context(), actor.UserID, from, to)
+	if err != nil {
+		h.respondError(c, "list_class_bookings", err)
+		return
+	}
+	c.JSON(http.StatusOK, toBookingResponses(bookings))
+}
+
+// Cancel godoc
+// @Summary      Отменить запись на занятие
+// @Description  Отменяет запись до начала занятия; освободившееся место получает первый из листа ожидания. Доступно участнику, владельцу организации и администраторам.
+// @Tags         classes
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id   path      string  true  "ID записи"
+// @Success      200  {object}  BookingResponse
+// @Failure      400  {object}  response.ErrorBody
+// @Failure      401  {object}  response.ErrorBody
+// @Failure      404  {object}  response.ErrorBody
+// @Failure      409  {object}  response.ErrorBody
+// @Failure      500  {object}  response.ErrorBody
+// @Router       /api/v1/class-bookings/{id} [delete]
+func (h *Handler) Cancel(c *gin.Context) {
+	actor, ok := actorFromContext(c)
+	if !ok {
+		response.Error(c, http.StatusUnauthorized, "unauthorized", "Требуется аутентификация", nil)
+		return
+	}
+	bookingID, ok := parseID(c, "id", "invalid_booking_id", "Некорректный ID записи")
+	if !ok {
+		return
+	}
+
+	booking, err := h.classes.Cancel(c.Request.Context(), actor, bookingID)
+	if err != nil {
+		h.respondError(c, "cancel_class_booking", err)
+		return
+	}
+	c.JSON(http.StatusOK, toBookingResponse(booking))
+}
+
+// MarkAttendance godoc
+// @Summary      Отметить посещение занятия
+// @Description  Отмечает, пришёл ли участник с подтверждённой записью. Доступно после начала занятия тренеру занятия, владельцу организации и администраторам.
+// @Tags         classes
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        id       path      string             true  "ID записи"
+// @Param        payload  body      AttendanceRequest  true  "Отметка"
+// @Success      200      {object}  BookingResponse
+// @Failure      400      {object}  response.ErrorBody
+// @Failure      401      {object}  response.ErrorBody
+// @Failure      403      {object}  response.ErrorBody
+// @Failure      404      {object}  response.ErrorBody
+// @Failure      409      {object}  response.ErrorBody
+// @Failure      500      {object}  response.ErrorBody
+// @Router       /api/v1/class-bookings/{id}/attendance [put]
+func (h *Handler) MarkAttendance(c *gin.Context) {
+	actor, ok := actorFromContext(c)
+	if !ok {
+		response.Error(c, http.StatusUnauthorized, "unauthorized", "Требуется аутентификация", nil)
+		return
+	}
+	bookingID, ok := parseID(c, "id", "invalid_booking_id", "Некорректный ID записи")
+	if !ok {
+		return
+	}
+
+	var req AttendanceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid_request", "Некорректное тело запроса", err.Error())
+		return
+	}
+
+	booking, err := h.classes.MarkAttendance(c.Request.Context(), actor, bookingID, *req.Attended)
+	if err != nil {
+		h.respondError(c, "mark_class_attendance", err)
+		return
+	}
+	c.JSON(http.StatusOK, toBookingResponse(booking))
+}
+
+// respondError отправляет ответ об ошибке для эндпоинтов групповых занятий.
+func (h *Handler) respondError(c *gin.Context, op string, err error) {
+	switch {
+	case errors.Is(err, gymclassuc.ErrInvalidClass):
+		response.Error(c, http.StatusBadRequest, "invalid_class", "Некорректные параметры занятия", err.Error())
+	case errors.Is(err, gymclassuc.ErrInvalidSlot):
+		response.Error(c, http.StatusBadRequest, "invalid_slot", "Некорректный слот расписания", err.Error())
+	case errors.Is(err, gymclassuc.ErrInvalidPeriod):
+		response.Error(c, http.StatusBadRequest, "invalid_range", "Некорректный период", err.Error())
+	case errors.Is(err, gymclassuc.ErrCoachNotMember):
+		response.Error(c, http.StatusBadRequest, "coach_not_member", "Тренер не состоит в организации", nil)
+	case errors.Is(err, gymclassuc.ErrForbidden):
+		response.Error(c, http.StatusForbidden, "forbidden", "Недостаточно прав в организации", nil)
+	case errors.Is(err, gymclassuc.ErrClassNotFound):
+		response.Error(c, http.StatusNotFound, "class_not_found", "Занятие не найдено", nil)
+	case errors.Is(err, gymclassuc.ErrSlotNotFound):
+		response.Error(c, http.StatusNotFound, "slot_not_found", "Слот расписания не найден", nil)
+	case errors.Is(err, gymclassuc.ErrBookingNotFound):
+		response.Error(c, http.StatusNotFound, "booking_not_found", "Запись не найдена", nil)
+	case errors.Is(err, gymclassuc.ErrNotScheduled):
+		response.Error(c, http.StatusNotFound, "class_not_scheduled", "В это время занятия нет в расписании", nil)
+	case errors.Is(err, gymclassuc.ErrSlotExists):
+		response.Error(c, http.StatusConflict, "slot_exists", "У занятия уже есть слот на это время", nil)
+	case errors.Is(err, gymclassuc.ErrAlreadyBooked):
+		response.Error(c, http.StatusConflict, "already_booked", "Вы уже записаны на это занятие", nil)
+	case errors.Is(err, gymclassuc.ErrCancelNotAllowed):
+		response.Error(c, http.StatusConflict, "cancel_not_allowed", "Запись уже отменена или занятие началось", nil)
+	case errors.Is(err, gymclassuc.ErrNotBooked):
+		response.Error(c, http.StatusConflict, "not_booked", "У записи нет подтверждённого места", nil)
+	case errors.Is(err, gymclassuc.ErrAttendanceTooSoon):
+		response.Error(c, http.StatusConflict, "class_not_started", "Посещение отмечается после начала занятия", nil)
+	case errors.Is(err, gymclassuc.ErrBookingClosed):
+		response.Error(c, http.StatusUnprocessableEntity, "booking_closed", "Запись на это занятие закрыта", nil)
+	case errors.Is(err, gymclassuc.ErrWaitlistFull):
+		response.Error(c, http.StatusUnprocessableEntity, "waitlist_full", "Лист ожидания заполнен", nil)
+	default:
+		h.logger.Error("internal_error_in_"+op, map[string]any{
+			"user_id": c.GetString(middleware.ContextUserIDKey),
+			"path":    c.Request.URL.Path,
+			"method":  c.Request.Method,
+			"error":   err.Error(),
+		})
+		response.Error(c, http.StatusInternalServerError, "internal_error", "Внутренняя ошибка сервера", nil)
+	}
+}
+
+// actorFromContext извлекает ID и роль текущего пользователя из контекста запроса.
+func actorFromContext(c *gin.Context) (gymclassuc.Actor, bool) {
+	userID, err := middleware.UserIDFromContext(c)
+	if err != nil {
+		return gymclassuc.Actor{}, false
+	}
+	return gymclassuc.Actor{
+		UserID: userID,
+		Role:   userdomain.Role(c.GetString(middleware.ContextUserRoleKey)),
+	}, true
+}
+
+// parseID разбирает UUID из параметра пути. При ошибке ответ уже отправлен.
+func parseID(c *gin.Context, param, code, message string) (uuid.UUID, bool) {
+	id, err := uuid.Parse(c.Param(param))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, code, message, nil)
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+// bindClassInput разбирает тело запроса занятия. При ошибке ответ уже отправлен.
+func bindClassInput(c *gin.Context) (gymclassuc.ClassInput, bool) {
+	var req ClassRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid_request", "Некорректное тело запроса", err.Error())
+		return gymclassuc.ClassInput{}, false
+	}
+	input := gymclassuc.ClassInput{
+		Title:           req.Title,
+		Description:     req.Description,
+		Capacity:        req.Capacity,
+		DurationMinutes: req.DurationMinutes,
+	}
+	if req.CoachID != "" {
+		// Формат уже проверен binding-тегом uuid
+		coachID := uuid.MustParse(req.CoachID)
+		input.CoachID = &coachID
+	}
+	return input, true
+}
+
+// parsePeriod разбирает период ?from=&to= (RFC3339 или YYYY-MM-DD; дата в to включается целиком).
+// По умолчанию период начинается с текущего момента и длится defaultPeriod. При ошибке ответ уже отправлен.
+func parsePeriod(c *gin.Context, defaultPeriod time.Duration) (time.Time, time.Time, bool) {
+	from := time.Now().UTC()
+	if raw := c.Query("from"); raw != "" {
+		t, err := parseTimeParam(raw, false)
+		if err != nil {
+			response.Error(c, http.StatusBadRequest, "invalid_request", "Некорректный параметр from", nil)
+			return time.Time{}, time.Time{}, false
+		}
+		from = t
+	}
+	to := from.Add(defaultPeriod)
+	if raw := c.Query("to"); raw != "" {
+		t, err := parseTimeParam(raw, true)
+		if err != nil {
+			response.Error(c, http.StatusBadRequest, "invalid_request", "Некорректный параметр to", nil)
+			return time.Time{}, time.Time{}, false
+		}
+		to = t
+	}
+	return from, to, true
+}
+
+// parseTimeParam разбирает RFC3339 или YYYY-MM-DD; для конца периода дата сдвигается на начало следующего дня.
+func parseTimeParam(raw string, nextDay bool) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, raw); err == nil {
+		return t, nil
+	}
+	t, err := time.Parse("2006-01-02", raw)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if nextDay {
+		t = t.Add(24 * time.Hour)
+	}
+	return t, nil
+}
+
+// toClassResponse маппит занятие и его слоты в DTO.
+func toClassResponse(class *domain.Class, slots []*domain.Slot) ClassResponse {
+	resp := ClassResponse{
+		ID:              class.ID.String(),
+		OrganizationID:  class.OrganizationID.String(),
+		Title:           class.Title,
+		Description:     class.Description,
+		CoachID:         uuidString(class.CoachID),
+		Capacity:        class.Capacity,
+		DurationMinutes: class.DurationMinutes,
+		Slots:           make([]SlotResponse, 0, len(slots)),
+		CreatedAt:       class.CreatedAt,
+		UpdatedAt:       class.UpdatedAt,
+	}
+	for _, s := range slots {
+		resp.Slots = append(resp.Slots, toSlotResponse(s))
+	}
+	return resp
+}
+
+// toSlotResponse маппит слот расписания в DTO.
+func toSlotResponse(s *domain.Slot) SlotResponse {
+	return SlotResponse{
+		ID:        s.ID.String(),
+		Weekday:   s.Weekday,
+		StartTime: fmt.Sprintf("%02d:%02d", s.StartMinute/60, s.StartMinute%60),
+	}
+}
+
+// toBookingResponse маппит запись на занятие в DTO.
+func toBookingResponse(b *domain.Booking) BookingResponse {
+	return BookingResponse{
+		ID:        b.ID.String(),
+		ClassID:   b.ClassID.String(),
+		UserID:    b.UserID.String(),
+		StartsAt:  b.StartsAt,
+		Status:    string(b.Status),
+		Attended:  b.Attended,
+		CreatedAt: b.CreatedAt,
+		UpdatedAt: b.UpdatedAt,
+	}
+}
+
+func toBookingResponses(bookings []*domain.Booking) []BookingResponse {
+	resp := make([]BookingResponse, 0, len(bookings))
+	for _, b := range bookings {
+		resp = append(resp, toBookingResponse(b))
+	}
+	return resp
+}
+
+func uuidString(id *uuid.UUID) *string {
+	if id == nil {
+		return nil
+	}
+	s := id.String()
+	return &s
+}
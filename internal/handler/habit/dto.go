@@ -0,0 +1,38 @@
+package habit
+
+import "time"
+
+// DayRequest описывает привычки дня. Запись дня заменяется целиком: не переданный показатель
+// становится незаполненным. Нужен хотя бы один показатель.
+type DayRequest struct {
+	WaterMl    *int     `json:"water_ml,omitempty" example:"2200"`
+	SleepHours *float64 `json:"sleep_hours,omitempty" example:"7.5"`
+	RestingHR  *int     `json:"resting_hr,omitempty" example:"58"`
+	Steps      *int     `json:"steps,omitempty" example:"9500"`
+}
+
+// DayResponse описывает привычки дня.
+type DayResponse struct {
+	Date       string    `json:"date" example:"2026-10-15"`
+	WaterMl    *int      `json:"water_ml,omitempty"`
+	SleepHours *float64  `json:"sleep_hours,omitempty"`
+	RestingHR  *int      `json:"resting_hr,omitempty"`
+	Steps      *int      `json:"steps,omitempty"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// AveragesResponse описывает средние значения по заполненным дням периода.
+type AveragesResponse struct {
+	WaterMl    *float64 `json:"water_ml,omitempty"`
+	SleepHours *float64 `json:"sleep_hours,omitempty"`
+	RestingHR  *float64 `json:"resting_hr,omitempty"`
+	Steps      *float64 `json:"steps,omitempty"`
+}
+
+// RangeResponse описывает привычки за период: только дни с записями и средние значения.
+type RangeResponse struct {
+	From     string           `json:"from" example:"2026-09-16"`
+	To       string           `json:"to" example:"2026-10-15"`
+	Items    []DayResponse    `json:"items"`
+	Averages AveragesResponse `json:"averages"`
+}
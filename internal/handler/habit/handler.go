@@ -0,0 +1,226 @@
+package habit
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	domain "workout-app/internal/domain/habit"
+	"workout-app/internal/handler/middleware"
+	"workout-app/internal/handler/response"
+	habituc "workout-app/internal/usecase/habit"
+	"workout-app/pkg/logger"
+)
+
+const dateLayout = "2006-01-02"
+
+// Handler обрабатывает HTTP-запросы ежедневных привычек.
+type Handler struct {
+	habits habituc.Service
+	logger logger.Logger
+}
+
+// NewHandler создаёт новый HabitHandler.
+func NewHandler(habits habituc.Service, logger logger.Logger) *Handler {
+	return &Handler{
+		habits: habits,
+		logger: logger,
+	}
+}
+
+// Upsert godoc
+// @Summary      Сохранить привычки дня
+// @Description  Сохраняет выпитую воду, сон, пульс покоя и шаги за день, заменяя прежнюю запись этого дня. Будущие дни (в часовом поясе пользователя) недоступны.
+// @Tags         habits
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        date     path      string      true  "День (YYYY-MM-DD)"
+// @Param        payload  body      DayRequest  true  "Привычки дня"
+// @Success      200      {object}  DayResponse
+// @Failure      400      {object}  response.ErrorBody
+// @Failure      401      {object}  response.ErrorBody
+// @Failure      500      {object}  response.ErrorBody
+// @Router       /api/v1/habits/{date} [put]
+func (h *Handler) Upsert(c *gin.Context) {
+	userID, ok := h.userID(c)
+	if !ok {
+		return
+	}
+	date, ok := parseDate(c)
+	if !ok {
+		return
+	}
+	var req DayRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid_request", "Некорректное тело запроса", err.Error())
+		return
+	}
+
+	day, err := h.habits.Upsert(c.Request.Context(), userID, date, habituc.DayInput{
+		WaterMl:    req.WaterMl,
+		SleepHours: req.SleepHours,
+		RestingHR:  req.RestingHR,
+		Steps:      req.Steps,
+	})
+	if err != nil {
+		h.respondError(c, "upsert_habit_day", userID, err)
+		return
+	}
+	c.JSON(http.StatusOK, toDayResponse(day))
+}
+
+// Range godoc
+// @Summary      Привычки за период
+// @Description  Возвращает записи дней [from, to] (не больше 366 дней) и средние значения по заполненным дням. По умолчанию — 30 дней по сегодня в часовом поясе пользователя.
+// @Tags         habits
+// @Security     BearerAuth
+// @Produce      json
+// @Param        from  query     string  false  "Первый день (YYYY-MM-DD)"
+// @Param        to    query     string  false  "Последний день (YYYY-MM-DD)"
+// @Success      200   {object}  RangeResponse
+// @Failure      400   {object}  response.ErrorBody
+// @Failure      401   {object}  response.ErrorBody
+// @Failure      500   {object}  response.ErrorBody
+// @Router       /api/v1/habits [get]
+func (h *Handler) Range(c *gin.Context) {
+	userID, ok := h.userID(c)
+	if !ok {
+		return
+	}
+	from, ok := queryDate(c, "from")
+	if !ok {
+		return
+	}
+	to, ok := queryDate(c, "to")
+	if !ok {
+		return
+	}
+
+	r, err := h.habits.Range(c.Request.Context(), userID, from, to)
+	if err != nil {
+		h.respondError(c, "habit_range", userID, err)
+		return
+	}
+	resp := RangeResponse{
+		From:  r.From.Format(dateLayout),
+		To:    r.To.Format(dateLayout),
+		Items: make([]DayResponse, 0, len(r.Days)),
+		Averages: AveragesResponse{
+			WaterMl:    r.Averages.WaterMl,
+			SleepHours: r.Averages.SleepHours,
+			RestingHR:  r.Averages.RestingHR,
+			Steps:      r.Averages.Steps,
+		},
+	}
+	for _, d := range r.Days {
+		resp.Items = append(resp.Items, toDayResponse(d))
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// Delete godoc
+// @Summary      Удалить привычки дня
+// @Tags         habits
+// @Security     BearerAuth
+// @Param        date  path  string  true  "День (YYYY-MM-DD)"
+// @Success      204
+// @Failure      400   {object}  response.ErrorBody
+// @Failure      401   {object}  response.ErrorBody
+// @Failure      404   {object}  response.ErrorBody
+// @Failure      500   {object}  response.ErrorBody
+// @Router       /api/v1/habits/{date} [delete]
+func (h *Handler) Delete(c *gin.Context) {
+	userID, ok := h.userID(c)
+	if !ok {
+		return
+	}
+	date, ok := parseDate(c)
+	if !ok {
+		return
+	}
+
+	if err := h.habits.Delete(c.Request.Context(), userID, date); err != nil {
+		h.respondError(c, "delete_habit_day", userID, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// userID извлекает ID текущего пользователя и отвечает 401, если его нет.
+func (h *Handler) userID(c *gin.Context) (uuid.UUID, bool) {
+	userID, err := middleware.UserIDFromContext(c)
+	if err != nil {
+		response.Error(c, http.StatusUnauthorized, "unauthorized", "Требуется аутентификация", nil)
+		return uuid.Nil, false
+	}
+	return userID, true
+}
+
+// respondError маппит ошибки usecase-слоя в HTTP-ответы.
+func (h *Handler) respondError(c *gin.Context, op string, userID uuid.UUID, err error) {
+	switch {
+	case errors.Is(err, habituc.ErrInvalidWater):
+		response.Error(c, http.StatusBadRequest, "invalid_water", "Вода должна быть от 0 до 20000 мл", nil)
+	case errors.Is(err, habituc.ErrInvalidSleep):
+		response.Error(c, http.StatusBadRequest, "invalid_sleep", "Сон должен быть от 0 до 24 часов", nil)
+	case errors.Is(err, habituc.ErrInvalidRestingHR):
+		response.Error(c, http.StatusBadRequest, "invalid_resting_hr", "Пульс покоя должен быть от 20 до 250 уд/мин", nil)
+	case errors.Is(err, habituc.ErrInvalidSteps):
+		response.Error(c, http.StatusBadRequest, "invalid_steps", "Шаги должны быть от 0 до 100000", nil)
+	case errors.Is(err, habituc.ErrEmptyDay):
+		response.Error(c, http.StatusBadRequest, "empty_day", "Укажите хотя бы один показатель", nil)
+	case errors.Is(err, habituc.ErrInvalidDate):
+		response.Error(c, http.StatusBadRequest, "invalid_date", "Нельзя заполнить будущий день", nil)
+	case errors.Is(err, habituc.ErrInvalidPeriod):
+		response.Error(c, http.StatusBadRequest, "invalid_range", "Некорректный период: from не позже to, не больше 366 дней", nil)
+	case errors.Is(err, habituc.ErrDayNotFound):
+		response.Error(c, http.StatusNotFound, "day_not_found", "Запись дня не найдена", nil)
+	default:
+		h.logger.Error("internal_error_in_"+op, map[string]any{
+			"user_id": userID.String(),
+			"path":    c.Request.URL.Path,
+			"method":  c.Request.Method,
+			"error":   err.Error(),
+		})
+		response.Error(c, http.StatusInternalServerError, "internal_error", "Внутренняя ошибка сервера", nil)
+	}
+}
+
+// parseDate разбирает день (YYYY-MM-DD) из пути. При ошибке ответ уже отправлен.
+func parseDate(c *gin.Context) (time.Time, bool) {
+	date, err := time.Parse(dateLayout, c.Param("date"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid_request", "Дата должна быть в формате YYYY-MM-DD", nil)
+		return time.Time{}, false
+	}
+	return date, true
+}
+
+// queryDate разбирает день (YYYY-MM-DD) из параметра запроса; пустой параметр — nil.
+func queryDate(c *gin.Context, name string) (*time.Time, bool) {
+	raw := c.Query(name)
+	if raw == "" {
+		return nil, true
+	}
+	t, err := time.Parse(dateLayout, raw)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid_request", "Некорректный параметр "+name, nil)
+		return nil, false
+	}
+	return &t, true
+}
+
+func toDayResponse(d *domain.Day) DayResponse {
+	return DayResponse{
+		Date:       d.Date.Format(dateLayout),
+		WaterMl:    d.WaterMl,
+		SleepHours: d.SleepHours,
+		RestingHR:  d.RestingHR,
+		Steps:      d.Steps,
+		UpdatedAt:  d.UpdatedAt,
+	}
+}
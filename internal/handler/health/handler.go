@@ -8,19 +8,29 @@ import (
 	"github.com/gin-gonic/gin"
 
 	"workout-app/internal/database"
+	"workout-app/internal/handler/response"
 )
 
 // Handler обрабатывает health check запросы
 type Handler struct {
-	db     *database.DB
-	appEnv string
+	db        *database.DB
+	appEnv    string
+	startedAt time.Time
+
+	// readiness — зависимости, проверяемые в /health/ready.
+	readiness    []DependencyCheck
+	readyTimeout time.Duration
 }
 
-// NewHandler создает новый экземпляр health handler
-func NewHandler(db *database.DB, appEnv string) *Handler {
+// NewHandler создает новый экземпляр health handler.
+// readiness — зависимости для readiness-проверки; некритичные влияют только на детали ответа.
+func NewHandler(db *database.DB, appEnv string, startedAt time.Time, readiness []DependencyCheck) *Handler {
 	return &Handler{
-		db:     db,
-		appEnv: appEnv,
+		db:           db,
+		appEnv:       appEnv,
+		startedAt:    startedAt,
+		readiness:    readiness,
+		readyTimeout: 3 * time.Second,
 	}
 }
 
@@ -41,6 +51,7 @@ func (h *Handler) Health(c *gin.Context) {
 // HealthDB проверяет подключение к базе данных
 func (h *Handler) HealthDB(c *gin.Context) {
 	if h.db == nil {
+		response.SetRetryAfter(c, response.DefaultUnavailableRetryAfter, response.RetryExponential)
 		c.JSON(http.StatusServiceUnavailable, HealthResponse{
 			Status:  "error",
 			Message: "База данных не инициализирована",
@@ -76,6 +87,7 @@ func (h *Handler) HealthDB(c *gin.Context) {
 			errorMessage = "База данных недоступна: " + err.Error()
 		}
 
+		response.SetRetryAfter(c, response.DefaultUnavailableRetryAfter, response.RetryExponential)
 		c.JSON(http.StatusServiceUnavailable, HealthResponse{
 			Status:  "error",
 			Message: errorMessage,
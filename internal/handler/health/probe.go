@@ -0,0 +1,111 @@
+package health
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"workout-app/internal/handler/response"
+)
+
+// Статусы readiness-проверки в целом.
+const (
+	StatusReady    = "ready"
+	StatusNotReady = "not_ready"
+)
+
+// LivenessResponse представляет ответ liveness-проверки.
+type LivenessResponse struct {
+	Status        string `json:"status"`
+	UptimeSeconds int64  `json:"uptime_seconds"`
+}
+
+// DependencyResult описывает результат проверки одной зависимости.
+type DependencyResult struct {
+	Status    string  `json:"status"` // ok, down или not_configured
+	Critical  bool    `json:"critical"`
+	LatencyMS float64 `json:"latency_ms"`
+	Error     string  `json:"error,omitempty"` // только вне production
+}
+
+// ReadinessResponse представляет ответ readiness-проверки.
+type ReadinessResponse struct {
+	Status       string                      `json:"status"`
+	Dependencies map[string]DependencyResult `json:"dependencies"`
+	CheckedAt    time.Time                   `json:"checked_at"`
+}
+
+// Live godoc
+// @Summary      Liveness-проверка
+// @Description  Отвечает 200, пока процесс обрабатывает запросы. Зависимости не проверяются, чтобы сбой БД не приводил к перезапуску подов.
+// @Tags         health
+// @Produce      json
+// @Success      200  {object}  LivenessResponse
+// @Router       /health/live [get]
+func (h *Handler) Live(c *gin.Context) {
+	c.JSON(http.StatusOK, LivenessResponse{
+		Status:        StatusOK,
+		UptimeSeconds: int64(time.Since(h.startedAt).Seconds()),
+	})
+}
+
+// Ready godoc
+// @Summary      Readiness-проверка
+// @Description  Проверяет завершение запуска и зависимости (БД, актуальность миграций, Redis, SMTP) и возвращает статус и задержку каждой. 503, если недоступна критичная зависимость.
+// @Tags         health
+// @Produce      json
+// @Success      200  {object}  ReadinessResponse
+// @Failure      503  {object}  ReadinessResponse
+// @Router       /health/ready [get]
+func (h *Handler) Ready(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), h.readyTimeout)
+	defer cancel()
+
+	results := make([]DependencyResult, len(h.readiness))
+	var wg sync.WaitGroup
+	for i, check := range h.readiness {
+		results[i] = DependencyResult{Status: StatusNotConfigured, Critical: check.Critical}
+		if check.Check == nil {
+			continue
+		}
+		wg.Add(1)
+		go func(i int, check DependencyCheck) {
+			defer wg.Done()
+			started := time.Now()
+			err := check.Check(ctx)
+			results[i].LatencyMS = float64(time.Since(started).Microseconds()) / 1000
+			if err != nil {
+				results[i].Status = StatusDown
+				if h.appEnv != "production" {
+					results[i].Error = err.Error()
+				}
+				return
+			}
+			results[i].Status = StatusOK
+		}(i, check)
+	}
+	wg.Wait()
+
+	resp := ReadinessResponse{
+		Status:       StatusReady,
+		Dependencies: make(map[string]DependencyResult, len(h.readiness)),
+		CheckedAt:    time.Now().UTC(),
+	}
+	for i, check := range h.readiness {
+		resp.Dependencies[check.Name] = results[i]
+		if check.Critical && results[i].Status == StatusDown {
+			resp.Status = StatusNotReady
+		}
+	}
+
+	code := http.StatusOK
+	if resp.Status != StatusReady {
+		code = http.StatusServiceUnavailable
+		response.SetRetryAfter(c, response.DefaultUnavailableRetryAfter, response.RetryExponential)
+	}
+	c.Header("Cache-Control", "no-store")
+	c.JSON(code, resp)
+}
@@ -0,0 +1,142 @@
+package health
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Статусы зависимостей и сервиса в целом для публичной страницы статуса.
+const (
+	StatusOK            = "ok"
+	StatusDegraded      = "degraded"
+	StatusDown          = "down"
+	StatusNotConfigured = "not_configured"
+)
+
+// DependencyCheck описывает проверку одной внешней зависимости.
+// Check == nil означает, что зависимость не настроена в текущем окружении.
+// Critical-зависимость при недоступности переводит сервис в статус down, остальные — в degraded.
+type DependencyCheck struct {
+	Name     string
+	Critical bool
+	Check    func(ctx context.Context) error
+}
+
+// StatusResponse представляет ответ публичного эндпоинта статуса.
+type StatusResponse struct {
+	Status        string            `json:"status"`
+	Version       string            `json:"version"`
+	UptimeSeconds int64             `json:"uptime_seconds"`
+	Dependencies  map[string]string `json:"dependencies"`
+	CheckedAt     time.Time         `json:"checked_at"`
+}
+
+// StatusHandler отдаёт сводку состояния сервиса для публичной страницы статуса.
+// Результат проверок кешируется на cacheTTL, чтобы частые запросы не нагружали зависимости.
+// Детали ошибок наружу не отдаются — только грубый статус каждой зависимости.
+type StatusHandler struct {
+	version   string
+	startedAt time.Time
+	checks    []DependencyCheck
+	timeout   time.Duration
+	cacheTTL  time.Duration
+
+	mu     sync.Mutex
+	cached *StatusResponse
+}
+
+// NewStatusHandler создает новый обработчик публичного статуса.
+func NewStatusHandler(version string, startedAt time.Time, checks []DependencyCheck) *StatusHandler {
+	return &StatusHandler{
+		version:   version,
+		startedAt: startedAt,
+		checks:    checks,
+		timeout:   3 * time.Second,
+		cacheTTL:  10 * time.Second,
+	}
+}
+
+// Status godoc
+// @Summary      Публичный статус сервиса
+// @Description  Возвращает версию, аптайм и грубое состояние зависимостей (db, cache, mail, storage) без внутренних деталей ошибок.
+// @Tags         health
+// @Produce      json
+// @Success      200  {object}  StatusResponse
+// @Failure      429  {object}  response.ErrorBody
+// @Router       /status [get]
+func (h *StatusHandler) Status(c *gin.Context) {
+	snapshot := h.snapshot(c.Request.Context())
+
+	resp := *snapshot
+	resp.UptimeSeconds = int64(time.Since(h.startedAt).Seconds())
+
+	c.Header("Cache-Control", "public, max-age=10")
+	c.JSON(http.StatusOK, resp)
+}
+
+// Invalidate сбрасывает закешированный результат проверок.
+// Следующий запрос к /status выполнит проверки зависимостей заново.
+func (h *StatusHandler) Invalidate(_ context.Context) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.cached = nil
+	return nil
+}
+
+// snapshot возвращает закешированный результат проверок или выполняет их заново.
+func (h *StatusHandler) snapshot(ctx context.Context) *StatusResponse {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.cached != nil && time.Since(h.cached.CheckedAt) < h.cacheTTL {
+		return h.cached
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, h.timeout)
+	defer cancel()
+
+	results := make([]string, len(h.checks))
+	var wg sync.WaitGroup
+	for i, check := range h.checks {
+		if check.Check == nil {
+			results[i] = StatusNotConfigured
+			continue
+		}
+		wg.Add(1)
+		go func(i int, check DependencyCheck) {
+			defer wg.Done()
+			if err := check.Check(ctx); err != nil {
+				results[i] = StatusDown
+				return
+			}
+			results[i] = StatusOK
+		}(i, check)
+	}
+	wg.Wait()
+
+	overall := StatusOK
+	deps := make(map[string]string, len(h.checks))
+	for i, check := range h.checks {
+		deps[check.Name] = results[i]
+		if results[i] != StatusDown {
+			continue
+		}
+		if check.Critical {
+			overall = StatusDown
+		} else if overall == StatusOK {
+			overall = StatusDegraded
+		}
+	}
+
+	h.cached = &StatusResponse{
+		Status:       overall,
+		Version:      h.version,
+		Dependencies: deps,
+		CheckedAt:    time.Now().UTC(),
+	}
+	return h.cached
+}
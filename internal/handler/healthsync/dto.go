@@ -0,0 +1,41 @@
+package healthsync
+
+import "time"
+
+// ImportedResponse описывает число импортированных записей по типам.
+type ImportedResponse struct {
+	Workouts  int `json:"workouts" example:"2"`
+	Steps     int `json:"steps" example:"96"`
+	HeartRate int `json:"heart_rate" example:"40"`
+	Weight    int `json:"weight" example:"1"`
+}
+
+// ItemErrorResponse описывает причину отклонения записи выгрузки.
+type ItemErrorResponse struct {
+	ID    string `json:"id" example:"5C1A0E0F-7D3B-4C8E-9E61-3F2A1B9C0D11"`
+	Error string `json:"error" example:"record is in the future"`
+}
+
+// ConnectionResponse описывает состояние синхронизации с источником.
+type ConnectionResponse struct {
+	Provider     string     `json:"provider" example:"apple_health"`
+	Items        int        `json:"items" example:"1250"`
+	SyncedUntil  *time.Time `json:"synced_until,omitempty"`
+	LastSyncedAt *time.Time `json:"last_synced_at,omitempty"`
+	CreatedAt    time.Time  `json:"created_at"`
+}
+
+// SyncResponse описывает итог синхронизации.
+type SyncResponse struct {
+	Imported   ImportedResponse    `json:"imported"`
+	Duplicates int                 `json:"duplicates" example:"12"`
+	Skipped    int                 `json:"skipped" example:"3"`
+	Invalid    int                 `json:"invalid" example:"0"`
+	Errors     []ItemErrorResponse `json:"errors"`
+	Connection ConnectionResponse  `json:"connection"`
+}
+
+// ConnectionsResponse описывает источники, с которыми синхронизировался пользователь.
+type ConnectionsResponse struct {
+	Items []ConnectionResponse `json:"items"`
+}
@@ -0,0 +1,164 @@
+package healthsync
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	domain "workout-app/internal/domain/healthsync"
+	"workout-app/internal/handler/middleware"
+	"workout-app/internal/handler/response"
+	healthsyncuc "workout-app/internal/usecase/healthsync"
+	"workout-app/pkg/logger"
+)
+
+// Handler обрабатывает HTTP-запросы импорта из Apple Health и Google Fit.
+type Handler struct {
+	sync     healthsyncuc.Service
+	maxBytes int64
+	logger   logger.Logger
+}
+
+// NewHandler создаёт новый HealthSyncHandler.
+func NewHandler(sync healthsyncuc.Service, maxBytes int64, logger logger.Logger) *Handler {
+	return &Handler{
+		sync:     sync,
+		maxBytes: maxBytes,
+		logger:   logger,
+	}
+}
+
+// Sync godoc
+// @Summary      Импортировать данные Apple Health или Google Fit
+// @Description  Принимает выгрузку источника в JSON и сразу импортирует её: тренировки — завершёнными тренировками, вес — замерами, шаги — суммой за день, пульс — минимальным за день пульсом покоя в привычках. Записи, импортированные раньше, пропускаются по внешнему ID, поэтому выгрузки могут пересекаться; synced_until в ответе — начало следующей выгрузки.
+// @Tags         integrations
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        provider  path      string  true  "Источник: apple_health или google_fit"
+// @Success      200       {object}  SyncResponse
+// @Failure      400       {object}  response.ErrorBody
+// @Failure      401       {object}  response.ErrorBody
+// @Failure      404       {object}  response.ErrorBody
+// @Failure      413       {object}  response.ErrorBody
+// @Failure      500       {object}  response.ErrorBody
+// @Router       /api/v1/integrations/health/{provider} [post]
+func (h *Handler) Sync(c *gin.Context) {
+	userID, ok := h.userID(c)
+	if !ok {
+		return
+	}
+
+	body := http.MaxBytesReader(c.Writer, c.Request.Body, h.maxBytes)
+	result, err := h.sync.Sync(c.Request.Context(), userID, domain.Provider(c.Param("provider")), body)
+	if err != nil {
+		var maxErr *http.MaxBytesError
+		if errors.As(err, &maxErr) {
+			response.Error(c, http.StatusRequestEntityTooLarge, "health_export_too_large", "Выгрузка слишком большая", gin.H{"max_bytes": h.maxBytes})
+			return
+		}
+		h.respondError(c, "health_sync", userID, err)
+		return
+	}
+
+	imported := 0
+	for _, n := range result.Imported {
+		imported += n
+	}
+	h.logger.Info("health_data_synced", map[string]any{
+		"user_id":    userID.String(),
+		"provider":   string(result.Connection.Provider),
+		"imported":   imported,
+		"duplicates": result.Duplicates,
+		"invalid":    result.Invalid,
+	})
+	resp := SyncResponse{
+		Imported: ImportedResponse{
+			Workouts:  result.Imported[domain.KindWorkout],
+			Steps:     result.Imported[domain.KindSteps],
+			HeartRate: result.Imported[domain.KindHeartRate],
+			Weight:    result.Imported[domain.KindWeight],
+		},
+		Duplicates: result.Duplicates,
+		Skipped:    result.Skipped,
+		Invalid:    result.Invalid,
+		Errors:     make([]ItemErrorResponse, 0, len(result.Errors)),
+		Connection: toConnectionResponse(result.Connection),
+	}
+	for _, e := range result.Errors {
+		resp.Errors = append(resp.Errors, ItemErrorResponse{ID: e.ExternalID, Error: e.Error})
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// Connections godoc
+// @Summary      Состояние синхронизации с Apple Health и Google Fit
+// @Description  Возвращает источники, с которыми синхронизировался пользователь: число импортированных записей, время последней синхронизации и synced_until — конец самой поздней записи из выгрузок, с которого клиент начинает следующую выгрузку.
+// @Tags         integrations
+// @Security     BearerAuth
+// @Produce      json
+// @Success      200  {object}  ConnectionsResponse
+// @Failure      401  {object}  response.ErrorBody
+// @Failure      500  {object}  response.ErrorBody
+// @Router       /api/v1/integrations/health [get]
+func (h *Handler) Connections(c *gin.Context) {
+	userID, ok := h.userID(c)
+	if !ok {
+		return
+	}
+
+	connections, err := h.sync.Connections(c.Request.Context(), userID)
+	if err != nil {
+		h.respondError(c, "list_health_connections", userID, err)
+		return
+	}
+	resp := ConnectionsResponse{Items: make([]ConnectionResponse, 0, len(connections))}
+	for _, conn := range connections {
+		resp.Items = append(resp.Items, toConnectionResponse(conn))
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// userID извлекает ID текущего пользователя и отвечает 401, если его нет.
+func (h *Handler) userID(c *gin.Context) (uuid.UUID, bool) {
+	userID, err := middleware.UserIDFromContext(c)
+	if err != nil {
+		response.Error(c, http.StatusUnauthorized, "unauthorized", "Требуется аутентификация", nil)
+		return uuid.Nil, false
+	}
+	return userID, true
+}
+
+// respondError маппит ошибки usecase-слоя в HTTP-ответы.
+func (h *Handler) respondError(c *gin.Context, op string, userID uuid.UUID, err error) {
+	switch {
+	case errors.Is(err, healthsyncuc.ErrInvalidProvider):
+		response.Error(c, http.StatusNotFound, "provider_not_found", "Источник должен быть apple_health или google_fit", nil)
+	case errors.Is(err, healthsyncuc.ErrInvalidPayload):
+		response.Error(c, http.StatusBadRequest, "invalid_health_export", "Некорректный формат выгрузки", err.Error())
+	case errors.Is(err, healthsyncuc.ErrEmptyPayload):
+		response.Error(c, http.StatusBadRequest, "empty_health_export", "Выгрузка не содержит записей", nil)
+	case errors.Is(err, healthsyncuc.ErrTooManyItems):
+		response.Error(c, http.StatusBadRequest, "too_many_records", "Слишком много записей в выгрузке: разбейте её на части", nil)
+	default:
+		h.logger.Error("internal_error_in_"+op, map[string]any{
+			"user_id": userID.String(),
+			"path":    c.Request.URL.Path,
+			"method":  c.Request.Method,
+			"error":   err.Error(),
+		})
+		response.Error(c, http.StatusInternalServerError, "internal_error", "Внутренняя ошибка сервера", nil)
+	}
+}
+
+func toConnectionResponse(c *domain.Connection) ConnectionResponse {
+	return ConnectionResponse{
+		Provider:     string(c.Provider),
+		Items:        c.Items,
+		SyncedUntil:  c.SyncedUntil,
+		LastSyncedAt: c.LastSyncedAt,
+		CreatedAt:    c.CreatedAt,
+	}
+}
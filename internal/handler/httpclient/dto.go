@@ -0,0 +1,17 @@
+package httpclient
+
+// HTTPClientsResponse описывает обращения к внешним сервисам с момента старта инстанса.
+type HTTPClientsResponse struct {
+	Hosts []HostResponse `json:"hosts"`
+}
+
+// HostResponse описывает обращения клиента интеграции к одному хосту.
+type HostResponse struct {
+	Client        string  `json:"client"`
+	Host          string  `json:"host"`
+	Requests      int64   `json:"requests"`
+	Retries       int64   `json:"retries"`
+	Failures      int64   `json:"failures"`
+	AvgDurationMs float64 `json:"avg_duration_ms"`
+	MaxDurationMs int64   `json:"max_duration_ms"`
+}
@@ -0,0 +1,49 @@
+package httpclient
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"workout-app/pkg/httpclient"
+)
+
+// Handler обрабатывает административный запрос метрик HTTP-клиентов внешних сервисов.
+type Handler struct {
+	metrics *httpclient.Metrics
+}
+
+// NewHandler создаёт новый HTTPClientHandler.
+func NewHandler(metrics *httpclient.Metrics) *Handler {
+	return &Handler{metrics: metrics}
+}
+
+// Stats godoc
+// @Summary      Метрики обращений к внешним сервисам (админ)
+// @Description  Возвращает по каждой интеграции и хосту число попыток запросов, повторов, ошибок (сетевые и 5xx) и длительность с момента старта инстанса.
+// @Tags         admin
+// @Security     BearerAuth
+// @Produce      json
+// @Success      200  {object}  HTTPClientsResponse
+// @Failure      401  {object}  response.ErrorBody
+// @Failure      403  {object}  response.ErrorBody
+// @Router       /api/v1/admin/http-clients [get]
+func (h *Handler) Stats(c *gin.Context) {
+	hosts := h.metrics.Hosts()
+	resp := HTTPClientsResponse{Hosts: make([]HostResponse, 0, len(hosts))}
+	for _, host := range hosts {
+		item := HostResponse{
+			Client:        host.Client,
+			Host:          host.Host,
+			Requests:      host.Requests,
+			Retries:       host.Retries,
+			Failures:      host.Failures,
+			MaxDurationMs: host.MaxDuration.Milliseconds(),
+		}
+		if host.Requests > 0 {
+			item.AvgDurationMs = float64(host.TotalDuration.Milliseconds()) / float64(host.Requests)
+		}
+		resp.Hosts = append(resp.Hosts, item)
+	}
+	c.JSON(http.StatusOK, resp)
+}
@@ -0,0 +1,7 @@
+package inboundwebhook
+
+// ReceiveResponse описывает результат приёма события провайдера.
+type ReceiveResponse struct {
+	// Duplicate — событие с тем же ID уже было получено; повторно оно не обрабатывается.
+	Duplicate bool `json:"duplicate"`
+}
@@ -0,0 +1,84 @@
+package inboundwebhook
+
+import (
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"workout-app/internal/handler/response"
+	inboundwebhookuc "workout-app/internal/usecase/inboundwebhook"
+	"workout-app/pkg/logger"
+)
+
+// maxBodyBytes ограничивает размер тела webhook.
+const maxBodyBytes = 1 << 20
+
+// Handler принимает webhooks внешних провайдеров. Тело читается без разбора: подпись проверяется
+// по сырым байтам, а обработка события выполняется в фоне.
+type Handler struct {
+	webhooks inboundwebhookuc.Service
+	logger   logger.Logger
+}
+
+// NewHandler создаёт новый InboundWebhookHandler.
+func NewHandler(webhooks inboundwebhookuc.Service, logger logger.Logger) *Handler {
+	return &Handler{
+		webhooks: webhooks,
+		logger:   logger,
+	}
+}
+
+// Receive godoc
+// @Summary      Принять событие от внешнего провайдера
+// @Description  Проверяет подпись запроса провайдера по сырому телу и сохраняет событие для фоновой обработки. Повторная доставка события с тем же ID не обрабатывается повторно; запросы со временем подписи вне допустимого окна отклоняются.
+// @Tags         webhooks
+// @Accept       json
+// @Produce      json
+// @Param        provider  path      string  true  "Провайдер"
+// @Success      200       {object}  ReceiveResponse
+// @Failure      400       {object}  response.ErrorBody
+// @Failure      401       {object}  response.ErrorBody
+// @Failure      404       {object}  response.ErrorBody
+// @Failure      413       {object}  response.ErrorBody
+// @Failure      500       {object}  response.ErrorBody
+// @Router       /api/v1/webhooks/inbound/{provider} [post]
+func (h *Handler) Receive(c *gin.Context) {
+	provider := c.Param("provider")
+	body, err := io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, maxBodyBytes))
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			response.Error(c, http.StatusRequestEntityTooLarge, "payload_too_large", "Тело запроса слишком большое", nil)
+			return
+		}
+		response.Error(c, http.StatusBadRequest, "invalid_request", "Некорректное тело запроса", err.Error())
+		return
+	}
+
+	created, err := h.webhooks.Receive(c.Request.Context(), provider, c.Request.Header, body)
+	if err != nil {
+		switch {
+		case errors.Is(err, inboundwebhookuc.ErrUnknownProvider):
+			response.Error(c, http.StatusNotFound, "unknown_provider", "Провайдер не подключён", nil)
+		case errors.Is(err, inboundwebhookuc.ErrInvalidSignature):
+			response.Error(c, http.StatusUnauthorized, "invalid_signature", "Некорректная подпись webhook", nil)
+		case errors.Is(err, inboundwebhookuc.ErrSignatureExpired):
+			response.Error(c, http.StatusUnauthorized, "signature_expired", "Время подписи webhook вне допустимого окна", nil)
+		case errors.Is(err, inboundwebhookuc.ErrInvalidPayload):
+			response.Error(c, http.StatusBadRequest, "invalid_payload", "Не удалось определить ID события", nil)
+		default:
+			h.logger.Error("internal_error_in_inbound_webhook", map[string]any{
+				"provider": provider,
+				"path":     c.Request.URL.Path,
+				"method":   c.Request.Method,
+				"error":    err.Error(),
+			})
+			response.Error(c, http.StatusInternalServerError, "internal_error", "Внутренняя ошибка сервера", nil)
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, ReceiveResponse{Duplicate: !created})
+}
@@ -0,0 +1,55 @@
+package jobs
+
+import "time"
+
+// JobsResponse описывает состояние фоновых задач инстанса.
+type JobsResponse struct {
+	Periodic       []PeriodicResponse `json:"periodic"`
+	Queues         []QueueResponse    `json:"queues"`
+	Metrics        []MetricsResponse  `json:"metrics"`
+	RecentFailures []FailureResponse  `json:"recent_failures"`
+}
+
+// PeriodicResponse описывает статистику запусков периодической задачи с момента старта инстанса.
+type PeriodicResponse struct {
+	Name           string     `json:"name"`
+	Runs           int64      `json:"runs"`
+	Failures       int64      `json:"failures"`
+	Skipped        int64      `json:"skipped"` // Запуски, пропущенные из-за блокировки другой реплики
+	LastRunAt      *time.Time `json:"last_run_at,omitempty"`
+	LastDurationMs int64      `json:"last_duration_ms"`
+	LastError      string     `json:"last_error,omitempty"`
+}
+
+// QueueResponse описывает глубину очереди: число задач, ожидающих выполнения или повторной попытки.
+// Error заполняется, если очередь не удалось опросить.
+type QueueResponse struct {
+	Name  string `json:"name"`
+	Depth int64  `json:"depth"`
+	Error string `json:"error,omitempty"`
+}
+
+// MetricsResponse описывает длительность и ошибки выполнений задач одного типа с момента старта инстанса.
+type MetricsResponse struct {
+	Type          string           `json:"type"`
+	Runs          int64            `json:"runs"`
+	Failures      int64            `json:"failures"`
+	AvgDurationMs float64          `json:"avg_duration_ms"`
+	MaxDurationMs int64            `json:"max_duration_ms"`
+	Histogram     []BucketResponse `json:"histogram"`
+}
+
+// BucketResponse — корзина гистограммы длительности; LeMs = null для выполнений дольше всех границ.
+type BucketResponse struct {
+	LeMs  *int64 `json:"le_ms"`
+	Count int64  `json:"count"`
+}
+
+// FailureResponse описывает неудачное выполнение задачи.
+type FailureResponse struct {
+	Type       string    `json:"type"`
+	At         time.Time `json:"at"`
+	DurationMs int64     `json:"duration_ms"`
+	Error      string    `json:"error"`
+	TraceID    string    `json:"trace_id,omitempty"`
+}
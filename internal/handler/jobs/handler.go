@@ -0,0 +1,129 @@
+package jobs
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"workout-app/internal/handler/middleware"
+	"workout-app/internal/worker"
+	"workout-app/pkg/logger"
+)
+
+// PeriodicJob — периодическая задача, статистику которой показывает эндпоинт.
+type PeriodicJob interface {
+	Name() string
+	Stats() worker.Stats
+}
+
+// Queue описывает очередь фоновых задач.
+type Queue struct {
+	Name  string
+	Depth func(ctx context.Context) (int64, error) // Количество задач, ожидающих выполнения
+}
+
+// Handler обрабатывает административный запрос состояния фоновых задач.
+type Handler struct {
+	metrics  *worker.Metrics
+	periodic []PeriodicJob
+	queues   []Queue
+	logger   logger.Logger
+}
+
+// NewHandler создаёт новый JobsHandler.
+func NewHandler(metrics *worker.Metrics, periodic []PeriodicJob, queues []Queue, logger logger.Logger) *Handler {
+	return &Handler{
+		metrics:  metrics,
+		periodic: periodic,
+		queues:   queues,
+		logger:   logger,
+	}
+}
+
+// Status godoc
+// @Summary      Состояние фоновых задач (админ)
+// @Description  Возвращает статистику периодических задач, глубину очередей, длительность и ошибки выполнений по типам задач и последние ошибки с trace_id исходного запроса. Статистика и метрики считаются с момента старта инстанса.
+// @Tags         admin
+// @Security     BearerAuth
+// @Produce      json
+// @Success      200  {object}  JobsResponse
+// @Failure      401  {object}  response.ErrorBody
+// @Failure      403  {object}  response.ErrorBody
+// @Router       /api/v1/admin/jobs [get]
+func (h *Handler) Status(c *gin.Context) {
+	resp := JobsResponse{
+		Periodic:       make([]PeriodicResponse, 0, len(h.periodic)),
+		Queues:         make([]QueueResponse, 0, len(h.queues)),
+		Metrics:        []MetricsResponse{},
+		RecentFailures: []FailureResponse{},
+	}
+
+	for _, job := range h.periodic {
+		stats := job.Stats()
+		item := PeriodicResponse{
+			Name:      job.Name(),
+			Runs:      stats.Runs,
+			Failures:  stats.Failures,
+			Skipped:   stats.Skipped,
+			LastError: stats.LastError,
+		}
+		if !stats.LastRunAt.IsZero() {
+			item.LastRunAt = &stats.LastRunAt
+			item.LastDurationMs = stats.LastDuration.Milliseconds()
+		}
+		resp.Periodic = append(resp.Periodic, item)
+	}
+
+	// Недоступная очередь не скрывает остальные: ошибка возвращается в её элементе.
+	ctx := c.Request.Context()
+	for _, q := range h.queues {
+		item := QueueResponse{Name: q.Name}
+		depth, err := q.Depth(ctx)
+		if err != nil {
+			middleware.LoggerFromContext(c, h.logger).Error("job_queue_depth_failed", map[string]any{
+				"queue": q.Name,
+				"error": err.Error(),
+			})
+			item.Error = "unavailable"
+		}
+		item.Depth = depth
+		resp.Queues = append(resp.Queues, item)
+	}
+
+	for _, job := range h.metrics.Jobs() {
+		resp.Metrics = append(resp.Metrics, toMetricsResponse(job))
+	}
+	for _, f := range h.metrics.RecentFailures() {
+		resp.RecentFailures = append(resp.RecentFailures, FailureResponse{
+			Type:       f.Type,
+			At:         f.At,
+			DurationMs: f.Duration.Milliseconds(),
+			Error:      f.Error,
+			TraceID:    f.TraceID,
+		})
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+func toMetricsResponse(job worker.JobMetrics) MetricsResponse {
+	resp := MetricsResponse{
+		Type:          job.Type,
+		Runs:          job.Runs,
+		Failures:      job.Failures,
+		MaxDurationMs: job.MaxDuration.Milliseconds(),
+		Histogram:     make([]BucketResponse, 0, len(job.Buckets)),
+	}
+	if job.Runs > 0 {
+		resp.AvgDurationMs = float64(job.TotalDuration.Milliseconds()) / float64(job.Runs)
+	}
+	for i, count := range job.Buckets {
+		bucket := BucketResponse{Count: count}
+		if i < len(worker.DurationBuckets) {
+			le := worker.DurationBuckets[i].Milliseconds()
+			bucket.LeMs = &le
+		}
+		resp.Histogram = append(resp.Histogram, bucket)
+	}
+	return resp
+}
@@ -0,0 +1,32 @@
+package jwks
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	jwtsvc "workout-app/pkg/jwt"
+)
+
+// Handler публикует открытые ключи проверки access-токенов.
+type Handler struct {
+	jwt jwtsvc.Service
+}
+
+// NewHandler создаёт новый JWKS-обработчик.
+func NewHandler(jwt jwtsvc.Service) *Handler {
+	return &Handler{jwt: jwt}
+}
+
+// JWKS godoc
+// @Summary      Открытые ключи проверки access-токенов
+// @Description  JSON Web Key Set (RFC 7517) для проверки access-токенов другими сервисами без общего секрета: ключ выбирается по kid из заголовка токена. Содержит текущий ключ и ключи прежних пар, токены которых ещё не истекли. При подписи HS256 набор пуст.
+// @Tags         auth
+// @Produce      json
+// @Success      200  {object}  jwt.JWKSet
+// @Router       /.well-known/jwks.json [get]
+func (h *Handler) JWKS(c *gin.Context) {
+	// Ключи меняются только при перезапуске с новой конфигурацией; кеш короче времени жизни токена.
+	c.Header("Cache-Control", "public, max-age=300")
+	c.JSON(http.StatusOK, h.jwt.JWKS())
+}
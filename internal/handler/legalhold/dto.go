@@ -0,0 +1,32 @@
+package legalhold
+
+import "time"
+
+// LegalHoldRequest описывает тело запроса установки или снятия удержания.
+type LegalHoldRequest struct {
+	// Reason — основание (номер дела, обращения и т.п.), попадает в журнал аудита.
+	Reason string `json:"reason" binding:"required"`
+}
+
+// HoldResponse описывает текущее юридическое удержание.
+type HoldResponse struct {
+	At     time.Time `json:"at"`
+	By     string    `json:"by"`
+	Reason string    `json:"reason"`
+}
+
+// AuditRecordResponse описывает запись журнала аудита удержаний.
+type AuditRecordResponse struct {
+	ID        int64     `json:"id"`
+	Action    string    `json:"action"`
+	ActorID   string    `json:"actor_id"`
+	Reason    string    `json:"reason"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// LegalHoldResponse описывает состояние удержания пользователя и журнал аудита.
+type LegalHoldResponse struct {
+	UserID  string                `json:"user_id"`
+	Hold    *HoldResponse         `json:"hold"` // null, если удержания нет
+	History []AuditRecordResponse `json:"history"`
+}
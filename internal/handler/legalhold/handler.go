@@ -0,0 +1,202 @@
+package legalhold
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	domain "workout-app/internal/domain/user"
+	"workout-app/internal/handler/middleware"
+	"workout-app/internal/handler/response"
+	repo "workout-app/internal/repository/interfaces"
+	legalholduc "workout-app/internal/usecase/legalhold"
+	"workout-app/pkg/logger"
+)
+
+// Handler обрабатывает административные HTTP-запросы юридических удержаний.
+type Handler struct {
+	holds  legalholduc.Service
+	logger logger.Logger
+}
+
+// NewHandler создаёт новый LegalHoldHandler.
+func NewHandler(holds legalholduc.Service, logger logger.Logger) *Handler {
+	return &Handler{
+		holds:  holds,
+		logger: logger,
+	}
+}
+
+// Get godoc
+// @Summary      Получить юридическое удержание пользователя (админ)
+// @Description  Возвращает текущее удержание (или null) и полный журнал установок и снятий.
+// @Tags         legal-hold
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id   path      string  true  "ID пользователя"
+// @Success      200  {object}  LegalHoldResponse
+// @Failure      400  {object}  response.ErrorBody
+// @Failure      401  {object}  response.ErrorBody
+// @Failure      403  {object}  response.ErrorBody
+// @Failure      404  {object}  response.ErrorBody
+// @Failure      500  {object}  response.ErrorBody
+// @Router       /api/v1/admin/users/{id}/legal-hold [get]
+func (h *Handler) Get(c *gin.Context) {
+	userID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid_user_id", "Некорректный ID пользователя", nil)
+		return
+	}
+
+	hold, history, err := h.holds.Get(c.Request.Context(), userID)
+	if err != nil {
+		h.respondError(c, "get_legal_hold", userID, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toLegalHoldResponse(userID, hold, history))
+}
+
+// Place godoc
+// @Summary      Установить юридическое удержание (админ)
+// @Description  Запрещает окончательное удаление (обезличивание) аккаунта и автоматическую очистку его данных до снятия удержания. Действие записывается в журнал аудита.
+// @Tags         legal-hold
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        id       path      string            true  "ID пользователя"
+// @Param        payload  body      LegalHoldRequest  true  "Основание удержания"
+// @Success      200      {object}  HoldResponse
+// @Failure      400      {object}  response.ErrorBody
+// @Failure      401      {object}  response.ErrorBody
+// @Failure      403      {object}  response.ErrorBody
+// @Failure      404      {object}  response.ErrorBody
+// @Failure      409      {object}  response.ErrorBody
+// @Failure      500      {object}  response.ErrorBody
+// @Router       /api/v1/admin/users/{id}/legal-hold [put]
+func (h *Handler) Place(c *gin.Context) {
+	actorID, userID, req, ok := h.parseRequest(c)
+	if !ok {
+		return
+	}
+
+	hold, err := h.holds.Place(c.Request.Context(), actorID, userID, req.Reason)
+	if err != nil {
+		h.respondError(c, "place_legal_hold", userID, err)
+		return
+	}
+
+	h.logger.Info("legal_hold_placed", map[string]any{
+		"actor_id":       actorID.String(),
+		"target_user_id": userID.String(),
+	})
+	c.JSON(http.StatusOK, toHoldResponse(hold))
+}
+
+// Release godoc
+// @Summary      Снять юридическое удержание (админ)
+// @Description  Снимает удержание; основание снятия записывается в журнал аудита.
+// @Tags         legal-hold
+// @Security     BearerAuth
+// @Accept       json
+// @Param        id       path  string            true  "ID пользователя"
+// @Param        payload  body  LegalHoldRequest  true  "Основание снятия"
+// @Success      204
+// @Failure      400  {object}  response.ErrorBody
+// @Failure      401  {object}  response.ErrorBody
+// @Failure      403  {object}  response.ErrorBody
+// @Failure      404  {object}  response.ErrorBody
+// @Failure      409  {object}  response.ErrorBody
+// @Failure      500  {object}  response.ErrorBody
+// @Router       /api/v1/admin/users/{id}/legal-hold [delete]
+func (h *Handler) Release(c *gin.Context) {
+	actorID, userID, req, ok := h.parseRequest(c)
+	if !ok {
+		return
+	}
+
+	if err := h.holds.Release(c.Request.Context(), actorID, userID, req.Reason); err != nil {
+		h.respondError(c, "release_legal_hold", userID, err)
+		return
+	}
+
+	h.logger.Info("legal_hold_released", map[string]any{
+		"actor_id":       actorID.String(),
+		"target_user_id": userID.String(),
+	})
+	c.Status(http.StatusNoContent)
+}
+
+// parseRequest извлекает администратора, пользователя и тело запроса; при ошибке отправляет ответ.
+func (h *Handler) parseRequest(c *gin.Context) (uuid.UUID, uuid.UUID, LegalHoldRequest, bool) {
+	var req LegalHoldRequest
+
+	actorID, err := middleware.UserIDFromContext(c)
+	if err != nil {
+		response.Error(c, http.StatusUnauthorized, "unauthorized", "Требуется аутентификация", nil)
+		return uuid.Nil, uuid.Nil, req, false
+	}
+
+	userID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid_user_id", "Некорректный ID пользователя", nil)
+		return uuid.Nil, uuid.Nil, req, false
+	}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid_request", "Некорректное тело запроса", err.Error())
+		return uuid.Nil, uuid.Nil, req, false
+	}
+	return actorID, userID, req, true
+}
+
+// respondError отправляет ответ об ошибке для эндпоинтов удержаний.
+func (h *Handler) respondError(c *gin.Context, op string, userID uuid.UUID, err error) {
+	switch {
+	case errors.Is(err, legalholduc.ErrReasonRequired):
+		response.Error(c, http.StatusBadRequest, "reason_required", "Укажите основание (до 500 символов)", nil)
+	case errors.Is(err, legalholduc.ErrAlreadyOnHold):
+		response.Error(c, http.StatusConflict, "legal_hold_exists", "Удержание уже установлено", nil)
+	case errors.Is(err, legalholduc.ErrNotOnHold):
+		response.Error(c, http.StatusConflict, "legal_hold_not_found", "Удержание не установлено", nil)
+	case errors.Is(err, repo.ErrNotFound):
+		response.Error(c, http.StatusNotFound, "user_not_found", "Пользователь не найден", nil)
+	default:
+		h.logger.Error("internal_error_in_"+op, map[string]any{
+			"target_user_id": userID.String(),
+			"path":           c.Request.URL.Path,
+			"method":         c.Request.Method,
+			"error":          err.Error(),
+		})
+		response.Error(c, http.StatusInternalServerError, "internal_error", "Внутренняя ошибка сервера", nil)
+	}
+}
+
+// toHoldResponse маппит удержание в DTO.
+func toHoldResponse(hold *domain.LegalHold) HoldResponse {
+	return HoldResponse{At: hold.At, By: hold.By.String(), Reason: hold.Reason}
+}
+
+// toLegalHoldResponse маппит состояние удержания и журнал в DTO.
+func toLegalHoldResponse(userID uuid.UUID, hold *domain.LegalHold, history []*domain.LegalHoldRecord) LegalHoldResponse {
+	resp := LegalHoldResponse{
+		UserID:  userID.String(),
+		History: make([]AuditRecordResponse, 0, len(history)),
+	}
+	if hold != nil {
+		h := toHoldResponse(hold)
+		resp.Hold = &h
+	}
+	for _, rec := range history {
+		resp.History = append(resp.History, AuditRecordResponse{
+			ID:        rec.ID,
+			Action:    string(rec.Action),
+			ActorID:   rec.ActorID.String(),
+			Reason:    rec.Reason,
+			CreatedAt: rec.CreatedAt,
+		})
+	}
+	return resp
+}
@@ -0,0 +1,27 @@
+package maintenance
+
+import "time"
+
+// MaintenanceInfoResponse описывает доступные служебные операции.
+type MaintenanceInfoResponse struct {
+	Caches []string `json:"caches"`
+}
+
+// MaintenanceResultResponse описывает результат выполненной служебной операции.
+type MaintenanceResultResponse struct {
+	Action     string `json:"action"`
+	Target     string `json:"target"`
+	DurationMs int64  `json:"duration_ms"`
+	Affected   *int   `json:"affected,omitempty"`
+}
+
+// CleanupStatusResponse описывает статистику фоновой очистки устаревших записей на инстансе.
+type CleanupStatusResponse struct {
+	Enabled        bool             `json:"enabled"`
+	Runs           int64            `json:"runs"`
+	Failures       int64            `json:"failures"`
+	LastRunAt      *time.Time       `json:"last_run_at,omitempty"`
+	LastDurationMs int64            `json:"last_duration_ms"`
+	LastError      string           `json:"last_error,omitempty"`
+	Removed        map[string]int64 `json:"removed"`
+}
@@ -0,0 +1,172 @@
+package maintenance
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"workout-app/internal/handler/middleware"
+	"workout-app/internal/handler/response"
+	cleanupuc "workout-app/internal/usecase/cleanup"
+	maintenanceuc "workout-app/internal/usecase/maintenance"
+	"workout-app/internal/worker"
+	"workout-app/pkg/logger"
+)
+
+// Handler обрабатывает административные служебные операции.
+// Каждая операция пишет запись аудита в лог (кто, что, над чем, с каким результатом).
+type Handler struct {
+	maintenance maintenanceuc.Service
+	cleanup     cleanupuc.Service
+	cleanupJob  JobStats
+	logger      logger.Logger
+}
+
+// JobStats возвращает статистику запусков фоновой задачи.
+type JobStats interface {
+	Stats() worker.Stats
+}
+
+// NewHandler создаёт новый MaintenanceHandler.
+// cleanupJob может быть nil, если фоновая очистка отключена (CLEANUP_INTERVAL=0).
+func NewHandler(maintenance maintenanceuc.Service, cleanup cleanupuc.Service, cleanupJob JobStats, logger logger.Logger) *Handler {
+	return &Handler{
+		maintenance: maintenance,
+		cleanup:     cleanup,
+		cleanupJob:  cleanupJob,
+		logger:      logger,
+	}
+}
+
+// Info godoc
+// @Summary      Список служебных операций (админ)
+// @Description  Возвращает имена кешей, доступных для сброса.
+// @Tags         admin
+// @Security     BearerAuth
+// @Produce      json
+// @Success      200  {object}  MaintenanceInfoResponse
+// @Failure      401  {object}  response.ErrorBody
+// @Failure      403  {object}  response.ErrorBody
+// @Router       /api/v1/admin/maintenance [get]
+func (h *Handler) Info(c *gin.Context) {
+	c.JSON(http.StatusOK, MaintenanceInfoResponse{
+		Caches: h.maintenance.ListCaches(),
+	})
+}
+
+// InvalidateCache godoc
+// @Summary      Сбросить кеш (админ)
+// @Description  Сбрасывает указанный кеш; следующий запрос заполнит его актуальными данными.
+// @Tags         admin
+// @Security     BearerAuth
+// @Produce      json
+// @Param        name  path      string  true  "Имя кеша"
+// @Success      200   {object}  MaintenanceResultResponse
+// @Failure      401   {object}  response.ErrorBody
+// @Failure      403   {object}  response.ErrorBody
+// @Failure      404   {object}  response.ErrorBody
+// @Failure      500   {object}  response.ErrorBody
+// @Router       /api/v1/admin/maintenance/caches/{name}/invalidate [post]
+func (h *Handler) InvalidateCache(c *gin.Context) {
+	name := c.Param("name")
+	started := time.Now()
+	err := h.maintenance.InvalidateCache(c.Request.Context(), name)
+	h.audit(c, "invalidate_cache", name, started, err)
+
+	if err != nil {
+		if errors.Is(err, maintenanceuc.ErrUnknownCache) {
+			response.Error(c, http.StatusNotFound, "cache_not_found", "Кеш не найден", nil)
+			return
+		}
+		response.Error(c, http.StatusInternalServerError, "internal_error", "Внутренняя ошибка сервера", nil)
+		return
+	}
+
+	c.JSON(http.StatusOK, MaintenanceResultResponse{
+		Action:     "invalidate_cache",
+		Target:     name,
+		DurationMs: time.Since(started).Milliseconds(),
+	})
+}
+
+// CleanupStatus godoc
+// @Summary      Статистика фоновой очистки (админ)
+// @Description  Возвращает статистику запусков фоновой очистки устаревших записей и количество удалённых строк по таблицам с момента старта инстанса.
+// @Tags         admin
+// @Security     BearerAuth
+// @Produce      json
+// @Success      200  {object}  CleanupStatusResponse
+// @Failure      401  {object}  response.ErrorBody
+// @Failure      403  {object}  response.ErrorBody
+// @Router       /api/v1/admin/maintenance/cleanup [get]
+func (h *Handler) CleanupStatus(c *gin.Context) {
+	resp := CleanupStatusResponse{
+		Enabled: h.cleanupJob != nil,
+		Removed: h.cleanup.Removed(),
+	}
+	if h.cleanupJob != nil {
+		stats := h.cleanupJob.Stats()
+		resp.Runs = stats.Runs
+		resp.Failures = stats.Failures
+		resp.LastError = stats.LastError
+		if !stats.LastRunAt.IsZero() {
+			resp.LastRunAt = &stats.LastRunAt
+			resp.LastDurationMs = stats.LastDuration.Milliseconds()
+		}
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// RunCleanup godoc
+// @Summary      Запустить очистку устаревших записей (админ)
+// @Description  Немедленно удаляет истёкшие коды подтверждения и другие устаревшие записи, не дожидаясь фонового запуска.
+// @Tags         admin
+// @Security     BearerAuth
+// @Produce      json
+// @Success      200  {object}  MaintenanceResultResponse
+// @Failure      401  {object}  response.ErrorBody
+// @Failure      403  {object}  response.ErrorBody
+// @Failure      500  {object}  response.ErrorBody
+// @Router       /api/v1/admin/maintenance/cleanup/run [post]
+func (h *Handler) RunCleanup(c *gin.Context) {
+	started := time.Now()
+	removed, err := h.cleanup.Run(c.Request.Context())
+	h.audit(c, "run_cleanup", "all", started, err)
+
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, "internal_error", "Внутренняя ошибка сервера", nil)
+		return
+	}
+
+	var affected int
+	for _, n := range removed {
+		affected += int(n)
+	}
+	c.JSON(http.StatusOK, MaintenanceResultResponse{
+		Action:     "run_cleanup",
+		Target:     "all",
+		DurationMs: time.Since(started).Milliseconds(),
+		Affected:   &affected,
+	})
+}
+
+// audit пишет запись аудита служебной операции.
+func (h *Handler) audit(c *gin.Context, action, target string, started time.Time, err error) {
+	fields := map[string]any{
+		"action":      action,
+		"target":      target,
+		"actor_id":    c.GetString(middleware.ContextUserIDKey),
+		"client_ip":   c.ClientIP(),
+		"duration_ms": time.Since(started).Milliseconds(),
+		"result":      "ok",
+	}
+	if err != nil {
+		fields["result"] = "failed"
+		fields["error"] = err.Error()
+		h.logger.Error("admin_maintenance", fields)
+		return
+	}
+	h.logger.Info("admin_maintenance", fields)
+}
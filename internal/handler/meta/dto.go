@@ -0,0 +1,28 @@
+package meta
+
+// ChangelogResponse описывает журнал изменений публичного API.
+type ChangelogResponse struct {
+	// Version — версия сервера, отдавшего журнал.
+	Version string                   `json:"version" example:"1.0.0"`
+	Entries []ChangelogEntryResponse `json:"entries"`
+}
+
+// ChangelogEntryResponse описывает запись журнала изменений.
+type ChangelogEntryResponse struct {
+	ID string `json:"id" example:"2026-10-15-coach-invitations"`
+	// Type — вид изменения: added, changed, deprecated или removed.
+	Type        string             `json:"type" example:"added"`
+	Date        string             `json:"date" example:"2026-10-15"`
+	Title       string             `json:"title"`
+	Description string             `json:"description,omitempty"`
+	Endpoints   []EndpointResponse `json:"endpoints"`
+	// Sunset — дата, после которой устаревший эндпоинт может быть удалён (только для deprecated).
+	Sunset      string `json:"sunset,omitempty" example:"2027-04-01"`
+	Replacement string `json:"replacement,omitempty"`
+}
+
+// EndpointResponse описывает маршрут API, которого касается изменение.
+type EndpointResponse struct {
+	Method string `json:"method" example:"GET"`
+	Route  string `json:"route" example:"/api/v1/users/me"`
+}
@@ -0,0 +1,76 @@
+package meta
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"workout-app/internal/compat"
+	"workout-app/internal/handler/response"
+	"workout-app/internal/version"
+)
+
+const dateLayout = "2006-01-02"
+
+// Handler отдаёт метаданные публичного API.
+type Handler struct {
+	changelog *compat.Changelog
+}
+
+// NewHandler создаёт новый MetaHandler.
+func NewHandler(changelog *compat.Changelog) *Handler {
+	return &Handler{changelog: changelog}
+}
+
+// Changelog godoc
+// @Summary      Журнал изменений API
+// @Description  Машиночитаемый журнал изменений публичного API, новые записи первыми. Записи deprecated перечисляют устаревшие эндпоинты: они отвечают с заголовками Deprecation, Sunset (дата возможного удаления) и Link на этот журнал.
+// @Tags         meta
+// @Produce      json
+// @Param        since  query     string  false  "Только записи с этой даты (YYYY-MM-DD)"
+// @Success      200    {object}  ChangelogResponse
+// @Failure      400    {object}  response.ErrorBody
+// @Router       /api/v1/meta/changelog [get]
+func (h *Handler) Changelog(c *gin.Context) {
+	var since time.Time
+	if raw := c.Query("since"); raw != "" {
+		var err error
+		since, err = time.Parse(dateLayout, raw)
+		if err != nil {
+			response.Error(c, http.StatusBadRequest, "invalid_since", "Параметр since должен быть в формате YYYY-MM-DD", nil)
+			return
+		}
+	}
+
+	entries := h.changelog.Entries(since)
+	resp := ChangelogResponse{
+		Version: version.Version,
+		Entries: make([]ChangelogEntryResponse, 0, len(entries)),
+	}
+	for _, e := range entries {
+		resp.Entries = append(resp.Entries, toEntryResponse(e))
+	}
+	// Журнал меняется только с новой сборкой.
+	c.Header("Cache-Control", "public, max-age=300")
+	c.JSON(http.StatusOK, resp)
+}
+
+func toEntryResponse(e compat.ChangelogEntry) ChangelogEntryResponse {
+	resp := ChangelogEntryResponse{
+		ID:          e.ID,
+		Type:        string(e.Type),
+		Date:        e.Date.Format(dateLayout),
+		Title:       e.Title,
+		Description: e.Description,
+		Endpoints:   make([]EndpointResponse, 0, len(e.Endpoints)),
+		Replacement: e.Replacement,
+	}
+	for _, ep := range e.Endpoints {
+		resp.Endpoints = append(resp.Endpoints, EndpointResponse{Method: ep.Method, Route: ep.Route})
+	}
+	if e.Sunset != nil {
+		resp.Sunset = e.Sunset.Format(dateLayout)
+	}
+	return resp
+}
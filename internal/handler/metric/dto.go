@@ -0,0 +1,41 @@
+package metric
+
+import "time"
+
+// RecordMetricRequest описывает тело запроса для сохранения нового замера.
+// Все поля опциональны, но хотя бы одно значение должно быть задано.
+// Вес передаётся в килограммах (weight_kg) или фунтах (weight_lb), но не в обеих единицах сразу;
+// замеры с суффиксами _in, _mi и _lb пересчитываются в _cm, _km и _kg.
+type RecordMetricRequest struct {
+	MeasuredAt     *time.Time         `json:"measured_at,omitempty"`
+	WeightKg       *float64           `json:"weight_kg,omitempty" binding:"omitempty,gt=0,lte=500"`
+	WeightLb       *float64           `json:"weight_lb,omitempty" binding:"omitempty,gt=0,lte=1100"`
+	BodyFatPercent *float64           `json:"body_fat_percent,omitempty" binding:"omitempty,gte=0,lte=100"`
+	Measurements   map[string]float64 `json:"measurements,omitempty" binding:"omitempty,max=50"`
+}
+
+// MetricResponse описывает один замер параметров тела в единицах пользователя:
+// для имперской системы вес отдаётся в weight_lb, а замеры _cm, _km и _kg — как _in, _mi и _lb.
+type MetricResponse struct {
+	ID             string             `json:"id"`
+	MeasuredAt     time.Time          `json:"measured_at"`
+	WeightKg       *float64           `json:"weight_kg,omitempty"`
+	WeightLb       *float64           `json:"weight_lb,omitempty"`
+	BodyFatPercent *float64           `json:"body_fat_percent,omitempty"`
+	Measurements   map[string]float64 `json:"measurements,omitempty"`
+	CreatedAt      time.Time          `json:"created_at"`
+}
+
+// MetricValueResponse описывает последнее значение метрики и момент его замера.
+type MetricValueResponse struct {
+	Value      float64   `json:"value"`
+	MeasuredAt time.Time `json:"measured_at"`
+}
+
+// MetricsSummaryResponse описывает сводку последних значений всех метрик пользователя в его единицах.
+type MetricsSummaryResponse struct {
+	WeightKg       *MetricValueResponse           `json:"weight_kg,omitempty"`
+	WeightLb       *MetricValueResponse           `json:"weight_lb,omitempty"`
+	BodyFatPercent *MetricValueResponse           `json:"body_fat_percent,omitempty"`
+	Measurements   map[string]MetricValueResponse `json:"measurements"`
+}
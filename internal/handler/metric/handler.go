@@ -0,0 +1,396 @@
+package metric
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	domain "workout-app/internal/domain/user"
+	"workout-app/internal/handler/middleware"
+	"workout-app/internal/handler/response"
+	consentuc "workout-app/internal/usecase/consent"
+	metricuc "workout-app/internal/usecase/metric"
+	useruc "workout-app/internal/usecase/user"
+	"workout-app/pkg/logger"
+)
+
+// Handler обрабатывает HTTP-запросы, связанные с замерами параметров тела.
+// Значения принимаются и отдаются в системе единиц текущего пользователя, хранятся в СИ.
+type Handler struct {
+	metrics metricuc.Service
+	units   useruc.UnitsProvider
+	logger  logger.Logger
+}
+
+// NewHandler создаёт новый MetricHandler.
+func NewHandler(metrics metricuc.Service, units useruc.UnitsProvider, logger logger.Logger) *Handler {
+	return &Handler{
+		metrics: metrics,
+		units:   units,
+		logger:  logger,
+	}
+}
+
+// Record godoc
+// @Summary      Сохранить замер параметров тела
+// @Description  Сохраняет вес, процент жира и/или произвольные замеры (например, обхват талии).
+// @Description  Вес принимается в килограммах (weight_kg) или фунтах (weight_lb), замеры с суффиксами _in, _mi и _lb пересчитываются в _cm, _km и _kg. Ответ — в системе единиц пользователя.
+// @Tags         metrics
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        payload  body      RecordMetricRequest  true  "Данные замера"
+// @Success      201      {object}  MetricResponse
+// @Failure      400      {object}  response.ErrorBody
+// @Failure      401      {object}  response.ErrorBody
+// @Failure      500      {object}  response.ErrorBody
+// @Router       /api/v1/metrics [post]
+func (h *Handler) Record(c *gin.Context) {
+	userID, err := middleware.UserIDFromContext(c)
+	if err != nil {
+		response.Error(c, http.StatusUnauthorized, "unauthorized", "Требуется аутентификация", nil)
+		return
+	}
+
+	var req RecordMetricRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid_request", "Некорректное тело запроса", err.Error())
+		return
+	}
+	weightKg := req.WeightKg
+	if req.WeightLb != nil {
+		if weightKg != nil {
+			response.Error(c, http.StatusBadRequest, "invalid_request", "Вес указывается либо в weight_kg, либо в weight_lb", nil)
+			return
+		}
+		kg := domain.PoundsToKg(*req.WeightLb)
+		weightKg = &kg
+	}
+
+	m, err := h.metrics.Record(c.Request.Context(), userID, metricuc.RecordInput{
+		MeasuredAt:     req.MeasuredAt,
+		WeightKg:       weightKg,
+		BodyFatPercent: req.BodyFatPercent,
+		Measurements:   req.Measurements,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, metricuc.ErrEmptyMeasurement):
+			response.Error(c, http.StatusBadRequest, "empty_measurement", "Необходимо указать хотя бы одно значение", nil)
+		case errors.Is(err, metricuc.ErrInvalidMeasurement):
+			response.Error(c, http.StatusBadRequest, "invalid_measurement", "Некорректное название или значение замера", nil)
+		case errors.Is(err, metricuc.ErrMeasuredInFuture):
+			response.Error(c, http.StatusBadRequest, "measured_in_future", "Дата замера не может быть в будущем", nil)
+		default:
+			h.logger.Error("internal_error_in_record_metric", map[string]any{
+				"user_id": userID.String(),
+				"path":    c.Request.URL.Path,
+				"method":  c.Request.Method,
+				"error":   err.Error(),
+			})
+			response.Error(c, http.StatusInternalServerError, "internal_error", "Внутренняя ошибка сервера", nil)
+		}
+		return
+	}
+
+	units, ok := h.userUnits(c, userID)
+	if !ok {
+		return
+	}
+	c.JSON(http.StatusCreated, toMetricResponse(m, units))
+}
+
+// List godoc
+// @Summary      Получить историю замеров
+// @Description  Возвращает страницу замеров текущего пользователя за период (по убыванию даты) в его системе единиц. Границы задаются в формате RFC3339 или YYYY-MM-DD.
+// @Tags         metrics
+// @Security     BearerAuth
+// @Produce      json
+// @Param        from    query     string  false  "Начало периода (включительно)"
+// @Param        to      query     string  false  "Конец периода (включительно)"
+// @Param        limit   query     int     false  "Размер страницы (по умолчанию 100, максимум 500)"
+// @Param        offset  query     int     false  "Смещение"
+// @Success      200   {array}   MetricResponse
+// @Failure      400   {object}  response.ErrorBody
+// @Failure      401   {object}  response.ErrorBody
+// @Failure      500   {object}  response.ErrorBody
+// @Router       /api/v1/metrics [get]
+func (h *Handler) List(c *gin.Context) {
+	userID, err := middleware.UserIDFromContext(c)
+	if err != nil {
+		response.Error(c, http.StatusUnauthorized, "unauthorized", "Требуется аутентификация", nil)
+		return
+	}
+
+	from, err := parseTimeParam(c.Query("from"), false)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid_request", "Некорректный параметр from", nil)
+		return
+	}
+	to, err := parseTimeParam(c.Query("to"), true)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid_request", "Некорректный параметр to", nil)
+		return
+	}
+
+	limit, offset, ok := parsePage(c)
+	if !ok {
+		return
+	}
+
+	metrics, err := h.metrics.List(c.Request.Context(), userID, from, to, limit, offset)
+	if err != nil {
+		if errors.Is(err, metricuc.ErrInvalidRange) {
+			response.Error(c, http.StatusBadRequest, "invalid_range", "Начало периода позже его конца", nil)
+			return
+		}
+		h.logger.Error("internal_error_in_list_metrics", map[string]any{
+			"user_id": userID.String(),
+			"path":    c.Request.URL.Path,
+			"method":  c.Request.Method,
+			"error":   err.Error(),
+		})
+		response.Error(c, http.StatusInternalServerError, "internal_error", "Внутренняя ошибка сервера", nil)
+		return
+	}
+
+	units, ok := h.userUnits(c, userID)
+	if !ok {
+		return
+	}
+	resp := make([]MetricResponse, 0, len(metrics))
+	for _, m := range metrics {
+		resp = append(resp, toMetricResponse(m, units))
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// Latest godoc
+// @Summary      Получить последние значения метрик
+// @Description  Возвращает последнее известное значение веса, процента жира и каждого произвольного замера в системе единиц пользователя.
+// @Tags         metrics
+// @Security     BearerAuth
+// @Produce      json
+// @Success      200  {object}  MetricsSummaryResponse
+// @Failure      401  {object}  response.ErrorBody
+// @Failure      500  {object}  response.ErrorBody
+// @Router       /api/v1/metrics/latest [get]
+func (h *Handler) Latest(c *gin.Context) {
+	userID, err := middleware.UserIDFromContext(c)
+	if err != nil {
+		response.Error(c, http.StatusUnauthorized, "unauthorized", "Требуется аутентификация", nil)
+		return
+	}
+
+	summary, err := h.metrics.LatestSummary(c.Request.Context(), userID)
+	if err != nil {
+		h.logger.Error("internal_error_in_latest_metrics", map[string]any{
+			"user_id": userID.String(),
+			"path":    c.Request.URL.Path,
+			"method":  c.Request.Method,
+			"error":   err.Error(),
+		})
+		response.Error(c, http.StatusInternalServerError, "internal_error", "Внутренняя ошибка сервера", nil)
+		return
+	}
+
+	units, ok := h.userUnits(c, userID)
+	if !ok {
+		return
+	}
+	c.JSON(http.StatusOK, toSummaryResponse(summary, units))
+}
+
+// ListClientMetrics godoc
+// @Summary      Получить замеры клиента (тренер)
+// @Description  Возвращает замеры клиента текущего тренера за период в системе единиц тренера. Клиент должен открыть тренеру класс данных measurements.
+// @Tags         metrics
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id      path      string  true   "ID клиента"
+// @Param        from    query     string  false  "Начало периода (включительно)"
+// @Param        to      query     string  false  "Конец периода (включительно)"
+// @Param        limit   query     int     false  "Размер страницы (по умолчанию 100, максимум 500)"
+// @Param        offset  query     int     false  "Смещение"
+// @Success      200   {array}   MetricResponse
+// @Failure      400   {object}  response.ErrorBody
+// @Failure      401   {object}  response.ErrorBody
+// @Failure      403   {object}  response.ErrorBody
+// @Failure      404   {object}  response.ErrorBody
+// @Failure      500   {object}  response.ErrorBody
+// @Router       /api/v1/coach/clients/{id}/metrics [get]
+func (h *Handler) ListClientMetrics(c *gin.Context) {
+	coachID, err := middleware.UserIDFromContext(c)
+	if err != nil {
+		response.Error(c, http.StatusUnauthorized, "unauthorized", "Требуется аутентификация", nil)
+		return
+	}
+
+	clientID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid_user_id", "Некорректный ID пользователя", nil)
+		return
+	}
+
+	from, err := parseTimeParam(c.Query("from"), false)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid_request", "Некорректный параметр from", nil)
+		return
+	}
+	to, err := parseTimeParam(c.Query("to"), true)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid_request", "Некорректный параметр to", nil)
+		return
+	}
+
+	limit, offset, ok := parsePage(c)
+	if !ok {
+		return
+	}
+
+	metrics, err := h.metrics.ListForCoach(c.Request.Context(), coachID, clientID, from, to, limit, offset)
+	if err != nil {
+		switch {
+		case errors.Is(err, metricuc.ErrInvalidRange):
+			response.Error(c, http.StatusBadRequest, "invalid_range", "Начало периода позже его конца", nil)
+		case errors.Is(err, consentuc.ErrNotCoachClient):
+			response.Error(c, http.StatusNotFound, "client_not_found", "Клиент не найден", nil)
+		case errors.Is(err, consentuc.ErrConsentRequired):
+			response.Error(c, http.StatusForbidden, "consent_required", "Клиент не открыл доступ к замерам", nil)
+		default:
+			h.logger.Error("internal_error_in_list_client_metrics", map[string]any{
+				"coach_id":  coachID.String(),
+				"client_id": clientID.String(),
+				"path":      c.Request.URL.Path,
+				"method":    c.Request.Method,
+				"error":     err.Error(),
+			})
+			response.Error(c, http.StatusInternalServerError, "internal_error", "Внутренняя ошибка сервера", nil)
+		}
+		return
+	}
+
+	units, ok := h.userUnits(c, coachID)
+	if !ok {
+		return
+	}
+	resp := make([]MetricResponse, 0, len(metrics))
+	for _, m := range metrics {
+		resp = append(resp, toMetricResponse(m, units))
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// userUnits возвращает систему единиц пользователя и отвечает 500, если её не удалось получить.
+func (h *Handler) userUnits(c *gin.Context, userID uuid.UUID) (domain.Units, bool) {
+	units, err := h.units.Units(c.Request.Context(), userID)
+	if err != nil {
+		h.logger.Error("internal_error_in_metric_units", map[string]any{
+			"user_id": userID.String(),
+			"path":    c.Request.URL.Path,
+			"method":  c.Request.Method,
+			"error":   err.Error(),
+		})
+		response.Error(c, http.StatusInternalServerError, "internal_error", "Внутренняя ошибка сервера", nil)
+		return "", false
+	}
+	return units, true
+}
+
+// parsePage разбирает параметры страницы limit и offset; при ошибке отвечает 400.
+func parsePage(c *gin.Context) (int, int, bool) {
+	limit, err1 := queryInt(c, "limit")
+	offset, err2 := queryInt(c, "offset")
+	if err1 != nil || err2 != nil {
+		response.Error(c, http.StatusBadRequest, "invalid_pagination", "Параметры limit и offset должны быть неотрицательными числами", nil)
+		return 0, 0, false
+	}
+	return limit, offset, true
+}
+
+func queryInt(c *gin.Context, name string) (int, error) {
+	raw := c.Query(name)
+	if raw == "" {
+		return 0, nil
+	}
+	n, err := strconv.Atoi(raw)
+	if err != nil || n < 0 {
+		return 0, strconv.ErrSyntax
+	}
+	return n, nil
+}
+
+// parseTimeParam разбирает границу периода в формате RFC3339 или YYYY-MM-DD.
+// Для верхней границы, заданной датой, берётся конец дня.
+func parseTimeParam(raw string, endOfDay bool) (time.Time, error) {
+	if raw == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse(time.RFC3339, raw); err == nil {
+		return t, nil
+	}
+	t, err := time.Parse("2006-01-02", raw)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if endOfDay {
+		t = t.Add(24*time.Hour - time.Nanosecond)
+	}
+	return t, nil
+}
+
+// toMetricResponse маппит доменную модель в DTO в системе единиц units.
+func toMetricResponse(m *domain.BodyMetric, units domain.Units) MetricResponse {
+	resp := MetricResponse{
+		ID:             m.ID.String(),
+		MeasuredAt:     m.MeasuredAt,
+		BodyFatPercent: m.BodyFatPercent,
+		Measurements:   make(map[string]float64, len(m.Measurements)),
+		CreatedAt:      m.CreatedAt,
+	}
+	if m.WeightKg != nil {
+		resp.WeightKg, resp.WeightLb = weightFields(*m.WeightKg, units)
+	}
+	for name, value := range m.Measurements {
+		name, value = units.Measurement(name, value)
+		resp.Measurements[name] = value
+	}
+	return resp
+}
+
+// toSummaryResponse маппит сводку последних значений в DTO в системе единиц units.
+func toSummaryResponse(s *domain.BodyMetricsSummary, units domain.Units) MetricsSummaryResponse {
+	resp := MetricsSummaryResponse{
+		Measurements: make(map[string]MetricValueResponse, len(s.Measurements)),
+	}
+	if s.WeightKg != nil {
+		kg, lb := weightFields(s.WeightKg.Value, units)
+		if kg != nil {
+			resp.WeightKg = &MetricValueResponse{Value: *kg, MeasuredAt: s.WeightKg.MeasuredAt}
+		} else {
+			resp.WeightLb = &MetricValueResponse{Value: *lb, MeasuredAt: s.WeightKg.MeasuredAt}
+		}
+	}
+	if s.BodyFatPercent != nil {
+		resp.BodyFatPercent = &MetricValueResponse{Value: s.BodyFatPercent.Value, MeasuredAt: s.BodyFatPercent.MeasuredAt}
+	}
+	for name, v := range s.Measurements {
+		name, value := units.Measurement(name, v.Value)
+		resp.Measurements[name] = MetricValueResponse{Value: value, MeasuredAt: v.MeasuredAt}
+	}
+	return resp
+}
+
+// weightFields возвращает вес для поля weight_kg или weight_lb в зависимости от системы единиц;
+// другое поле остаётся nil.
+func weightFields(kg float64, units domain.Units) (*float64, *float64) {
+	if units == domain.UnitsImperial {
+		lb := domain.RoundUnit(units.Weight(kg))
+		return nil, &lb
+	}
+	return &kg, nil
+}
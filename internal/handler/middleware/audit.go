@@ -0,0 +1,59 @@
+package middleware
+
+import (
+	"context"
+
+	"github.com/gin-gonic/gin"
+
+	domain "workout-app/internal/domain/audit"
+	"workout-app/pkg/logger"
+)
+
+// contextAuditDiffKey — ключ изменений цели действия, которые обработчик передаёт в журнал.
+const contextAuditDiffKey = "auditDiff"
+
+// AuditRecorder записывает действия администраторов в журнал.
+type AuditRecorder interface {
+	Record(ctx context.Context, e *domain.Entry) error
+}
+
+// SetAuditDiff передаёт в журнал действий изменения, внесённые обработчиком.
+func SetAuditDiff(c *gin.Context, diff domain.Diff) {
+	c.Set(contextAuditDiffKey, diff)
+}
+
+// AdminAudit возвращает middleware, записывающее каждый запрос в журнал действий администраторов:
+// инициатора, маршрут, цель (последний параметр пути), строку запроса, изменения, статус ответа и IP.
+// Используется поверх Auth и RequireRole. Ошибка записи не меняет уже отправленный ответ и только логируется.
+func AdminAudit(recorder AuditRecorder, log logger.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+
+		actorID, err := UserIDFromContext(c)
+		if err != nil {
+			return
+		}
+		entry := &domain.Entry{
+			ActorID: actorID,
+			Action:  c.Request.Method + " " + c.FullPath(),
+			Query:   c.Request.URL.RawQuery,
+			Status:  c.Writer.Status(),
+			IP:      c.ClientIP(),
+		}
+		if n := len(c.Params); n > 0 {
+			entry.TargetID = c.Params[n-1].Value
+		}
+		if diff, ok := c.Get(contextAuditDiffKey); ok {
+			entry.Diff, _ = diff.(domain.Diff)
+		}
+
+		// Ответ уже отправлен: запись не должна прерываться отменой запроса клиентом.
+		if err := recorder.Record(context.WithoutCancel(c.Request.Context()), entry); err != nil {
+			log.Error("admin_audit_record_failed", map[string]any{
+				"actor_id": actorID.String(),
+				"action":   entry.Action,
+				"error":    err.Error(),
+			})
+		}
+	}
+}
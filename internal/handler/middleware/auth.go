@@ -1,10 +1,13 @@
 package middleware
 
 import (
+	"context"
+	"errors"
 	"net/http"
 	"strings"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 
 	domain "workout-app/internal/domain/user"
 	"workout-app/internal/handler/response"
@@ -18,9 +21,17 @@ const (
 	ContextUserRoleKey  = "userRole"
 )
 
+// AccountStatusChecker проверяет состояние аккаунта, которое не отражено в самом токене.
+type AccountStatusChecker interface {
+	// IsSuspended сообщает, заблокирован ли аккаунт пользователя в данный момент.
+	IsSuspended(ctx context.Context, userID uuid.UUID) (bool, error)
+}
+
 // Auth возвращает middleware для аутентификации по JWT access-токену.
 // Ожидает заголовок Authorization: Bearer <token>.
-func Auth(jwtService jwtsvc.Service, log logger.Logger) gin.HandlerFunc {
+// Если задан accounts, запросы заблокированных пользователей отклоняются с кодом account_suspended
+// даже при действующем токене.
+func Auth(jwtService jwtsvc.Service, accounts AccountStatusChecker, log logger.Logger) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		authHeader := c.GetHeader("Authorization")
 		if authHeader == "" {
@@ -29,6 +40,7 @@ func Auth(jwtService jwtsvc.Service, log logger.Logger) gin.HandlerFunc {
 				"method": c.Request.Method,
 			})
 			response.Error(c, http.StatusUnauthorized, "missing_authorization_header", "Missing Authorization header", nil)
+			c.Abort()
 			return
 		}
 
@@ -40,6 +52,7 @@ func Auth(jwtService jwtsvc.Service, log logger.Logger) gin.HandlerFunc {
 				"value":  authHeader,
 			})
 			response.Error(c, http.StatusUnauthorized, "invalid_authorization_header", "Invalid Authorization header format", nil)
+			c.Abort()
 			return
 		}
 
@@ -50,6 +63,7 @@ func Auth(jwtService jwtsvc.Service, log logger.Logger) gin.HandlerFunc {
 				"method": c.Request.Method,
 			})
 			response.Error(c, http.StatusUnauthorized, "invalid_authorization_header", "Invalid Authorization header format", nil)
+			c.Abort()
 			return
 		}
 
@@ -61,9 +75,41 @@ func Auth(jwtService jwtsvc.Service, log logger.Logger) gin.HandlerFunc {
 				"error":  err.Error(),
 			})
 			response.Error(c, http.StatusUnauthorized, "invalid_token", "Invalid access token", nil)
+			c.Abort()
 			return
 		}
 
+		if accounts != nil {
+			userID, err := uuid.Parse(claims.UserID)
+			if err != nil {
+				response.Error(c, http.StatusUnauthorized, "invalid_token", "Invalid access token", nil)
+				c.Abort()
+				return
+			}
+			suspended, err := accounts.IsSuspended(c.Request.Context(), userID)
+			if err != nil {
+				log.Error("account_status_check_failed", map[string]any{
+					"user_id": claims.UserID,
+					"path":    c.Request.URL.Path,
+					"method":  c.Request.Method,
+					"error":   err.Error(),
+				})
+				response.Error(c, http.StatusInternalServerError, "internal_error", "Internal server error", nil)
+				c.Abort()
+				return
+			}
+			if suspended {
+				log.Info("suspended_account_request", map[string]any{
+					"user_id": claims.UserID,
+					"path":    c.Request.URL.Path,
+					"method":  c.Request.Method,
+				})
+				response.Error(c, http.StatusForbidden, "account_suspended", "Account is suspended", nil)
+				c.Abort()
+				return
+			}
+		}
+
 		// Сохраняем данные пользователя в контексте Gin
 		c.Set(ContextUserIDKey, claims.UserID)
 		c.Set(ContextUserEmailKey, claims.Email)
@@ -73,6 +119,22 @@ func Auth(jwtService jwtsvc.Service, log logger.Logger) gin.HandlerFunc {
 	}
 }
 
+// UserIDFromContext извлекает идентификатор аутентифицированного пользователя из контекста Gin.
+// Возвращает ошибку, если Auth middleware не отработал или значение некорректно.
+func UserIDFromContext(c *gin.Context) (uuid.UUID, error) {
+	idStr := c.GetString(ContextUserIDKey)
+	if idStr == "" {
+		return uuid.Nil, errors.New("missing_user_id_in_context")
+	}
+
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		return uuid.Nil, errors.New("invalid_user_id_in_context")
+	}
+
+	return id, nil
+}
+
 // RequireRole возвращает middleware, которое проверяет, что роль пользователя входит
 // в список разрешённых ролей. Используется поверх Auth или в группах с Auth.
 func RequireRole(log logger.Logger, allowedRoles ...domain.Role) gin.HandlerFunc {
@@ -0,0 +1,70 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"workout-app/internal/compat"
+	domain "workout-app/internal/domain/clientversion"
+	"workout-app/internal/handler/response"
+	"workout-app/pkg/logger"
+)
+
+// ClientVersionChecker проверяет версию клиента по минимальной поддерживаемой для платформы.
+type ClientVersionChecker interface {
+	// Check возвращает политику платформы, если версия клиента ниже минимальной, иначе nil.
+	Check(ctx context.Context, platform domain.Platform, v compat.Version) (*domain.Policy, error)
+}
+
+// UpgradeRequiredDetails описывает детали ответа 426 для устаревшего клиента.
+type UpgradeRequiredDetails struct {
+	Platform   string `json:"platform"`
+	MinVersion string `json:"min_version"`
+	StoreURL   string `json:"store_url"`
+}
+
+// MinClientVersion возвращает middleware, отклоняющий запросы устаревших мобильных клиентов
+// с кодом 426 Upgrade Required и ссылкой на магазин приложений.
+// Проверяются только запросы с заголовками X-App-Platform и X-App-Version; остальные
+// (веб, служебные проверки) проходят без изменений. При ошибке чтения политик запрос
+// пропускается, чтобы недоступность БД не блокировала клиентов сильнее, чем сами эндпоинты.
+func MinClientVersion(checker ClientVersionChecker, log logger.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		platform := domain.Platform(strings.ToLower(strings.TrimSpace(c.GetHeader(domain.HeaderPlatform))))
+		raw := c.GetHeader(compat.HeaderAppVersion)
+		if platform == "" || raw == "" {
+			c.Next()
+			return
+		}
+		version, ok := compat.ParseVersion(raw)
+		if !ok {
+			c.Next()
+			return
+		}
+
+		policy, err := checker.Check(c.Request.Context(), platform, version)
+		if err != nil {
+			log.Error("client_version_check_failed", map[string]any{
+				"platform": string(platform),
+				"version":  version.String(),
+				"error":    err.Error(),
+			})
+			c.Next()
+			return
+		}
+		if policy == nil {
+			c.Next()
+			return
+		}
+
+		response.Error(c, http.StatusUpgradeRequired, "upgrade_required", "This app version is no longer supported. Please update the app.", UpgradeRequiredDetails{
+			Platform:   string(policy.Platform),
+			MinVersion: policy.MinVersion,
+			StoreURL:   policy.StoreURL,
+		})
+		c.Abort()
+	}
+}
@@ -0,0 +1,129 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"workout-app/internal/compat"
+	"workout-app/pkg/logger"
+)
+
+// Compat возвращает middleware слоя совместимости для старых версий мобильного приложения.
+// По заголовку X-App-Version выбираются правила из реестра: устаревшие поля JSON-запроса
+// переименовываются в актуальные до handler'а, а поля ответа — обратно в устаревшие.
+// Каждое применение правила логируется, чтобы планировать удаление правил.
+// Запросы без заголовка версии или с нераспознанной версией проходят без изменений.
+func Compat(registry *compat.Registry, log logger.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if registry.Empty() {
+			c.Next()
+			return
+		}
+
+		raw := c.GetHeader(compat.HeaderAppVersion)
+		if raw == "" {
+			c.Next()
+			return
+		}
+		version, ok := compat.ParseVersion(raw)
+		if !ok {
+			c.Next()
+			return
+		}
+
+		rules := registry.Match(c.Request.Method, c.FullPath(), version)
+		if len(rules) == 0 {
+			c.Next()
+			return
+		}
+
+		rewriteRequest(c, rules, version, log)
+
+		var responseRenames []compat.FieldRename
+		for _, rule := range rules {
+			responseRenames = append(responseRenames, rule.Response...)
+		}
+		if len(responseRenames) == 0 {
+			c.Next()
+			return
+		}
+
+		original := c.Writer
+		buffered := &bufferedWriter{ResponseWriter: original, status: http.StatusOK}
+		c.Writer = buffered
+		func() {
+			defer func() { c.Writer = original }()
+			c.Next()
+		}()
+
+		if strings.HasPrefix(original.Header().Get("Content-Type"), "application/json") && buffered.body.Len() > 0 {
+			var doc any
+			if err := json.Unmarshal(buffered.body.Bytes(), &doc); err == nil {
+				changed := false
+				for _, f := range responseRenames {
+					if compat.Rename(doc, f) {
+						changed = true
+					}
+				}
+				if changed {
+					if data, err := json.Marshal(doc); err == nil {
+						buffered.body.Reset()
+						buffered.body.Write(data)
+					}
+				}
+			}
+		}
+		buffered.flush()
+	}
+}
+
+// rewriteRequest переименовывает устаревшие поля JSON-тела запроса.
+func rewriteRequest(c *gin.Context, rules []compat.Rule, version compat.Version, log logger.Logger) {
+	if c.Request.Body == nil || !strings.HasPrefix(c.ContentType(), "application/json") {
+		return
+	}
+
+	body, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		return
+	}
+	c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+	var doc any
+	if err := json.Unmarshal(body, &doc); err != nil {
+		return
+	}
+
+	changed := false
+	for _, rule := range rules {
+		for _, f := range rule.Request {
+			if !compat.Rename(doc, f) {
+				continue
+			}
+			changed = true
+			log.Info("compat_legacy_request_field", map[string]any{
+				"method":       c.Request.Method,
+				"route":        c.FullPath(),
+				"field":        f.Path,
+				"renamed_to":   f.To,
+				"app_version":  version.String(),
+				"remove_after": rule.RemoveAfter,
+			})
+		}
+	}
+	if !changed {
+		return
+	}
+
+	data, err := json.Marshal(doc)
+	if err != nil {
+		return
+	}
+	c.Request.Body = io.NopCloser(bytes.NewReader(data))
+	c.Request.ContentLength = int64(len(data))
+}
@@ -0,0 +1,37 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"workout-app/internal/compat"
+	"workout-app/pkg/logger"
+)
+
+// Deprecation возвращает middleware, который добавляет к ответам устаревших маршрутов заголовки
+// Deprecation (RFC 9745), Sunset (RFC 8594) и Link на журнал изменений API.
+// Маршруты объявляются устаревшими записями deprecated в журнале; каждый вызов логируется,
+// чтобы видеть, какие клиенты ещё не перешли на замену.
+func Deprecation(changelog *compat.Changelog, log logger.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		entry, ok := changelog.Deprecation(c.Request.Method, c.FullPath())
+		if !ok {
+			c.Next()
+			return
+		}
+
+		header := c.Writer.Header()
+		header.Set("Deprecation", entry.DeprecationHeader())
+		if sunset := entry.SunsetHeader(); sunset != "" {
+			header.Set("Sunset", sunset)
+		}
+		header.Add("Link", "<"+compat.ChangelogPath+`>; rel="deprecation"; type="application/json"`)
+
+		log.Info("deprecated_route_called", map[string]any{
+			"method":       c.Request.Method,
+			"route":        c.FullPath(),
+			"changelog_id": entry.ID,
+			"app_version":  c.GetHeader(compat.HeaderAppVersion),
+		})
+		c.Next()
+	}
+}
@@ -1,61 +1,53 @@
 package middleware
 
 import (
-	"fmt"
-	"log"
 	"time"
 
 	"github.com/gin-gonic/gin"
-)
 
-// Logger middleware для логирования HTTP запросов
-func Logger() gin.HandlerFunc {
-	return gin.LoggerWithFormatter(func(param gin.LogFormatterParams) string {
-		return fmt.Sprintf("%s - [%s] \"%s %s %s %d %s \"%s\" %s\"\n",
-			param.ClientIP,
-			param.TimeStamp.Format(time.RFC1123),
-			param.Method,
-			param.Path,
-			param.Request.Proto,
-			param.StatusCode,
-			param.Latency,
-			param.Request.UserAgent(),
-			param.ErrorMessage,
-		)
-	})
-}
+	"workout-app/pkg/logger"
+)
 
-// Улучшенная версия с более структурированным логированием
-func LoggerStructured() gin.HandlerFunc {
+// Logger логирует каждый HTTP-запрос одной структурированной записью http_request.
+// Уровень зависит от статуса: 5xx — error, 4xx — warn, остальные — info.
+// Должен подключаться после RequestID, чтобы запись содержала request_id.
+func Logger(log logger.Logger) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		// Начало запроса
 		start := time.Now()
 		path := c.Request.URL.Path
 		raw := c.Request.URL.RawQuery
 
-		// Обрабатываем запрос
 		c.Next()
 
-		// Вычисляем время выполнения
-		latency := time.Since(start)
-		clientIP := c.ClientIP()
-		method := c.Request.Method
-		statusCode := c.Writer.Status()
-		errorMessage := c.Errors.ByType(gin.ErrorTypePrivate).String()
-
 		if raw != "" {
 			path = path + "?" + raw
 		}
 
-		// Логируем информацию о запросе
-		log.Printf("[%s] %s %s %d %v %s %s",
-			method,
-			path,
-			c.Request.Proto,
-			statusCode,
-			latency,
-			clientIP,
-			errorMessage,
-		)
+		status := c.Writer.Status()
+		fields := map[string]any{
+			"method":     c.Request.Method,
+			"path":       path,
+			"proto":      c.Request.Proto,
+			"status":     status,
+			"latency_ms": float64(time.Since(start).Microseconds()) / 1000,
+			"client_ip":  c.ClientIP(),
+			"bytes":      c.Writer.Size(),
+		}
+		if userID := c.GetString(ContextUserIDKey); userID != "" {
+			fields["user_id"] = userID
+		}
+		if errs := c.Errors.ByType(gin.ErrorTypePrivate).String(); errs != "" {
+			fields["errors"] = errs
+		}
+
+		reqLogger := LoggerFromContext(c, log)
+		switch {
+		case status >= 500:
+			reqLogger.Error("http_request", fields)
+		case status >= 400:
+			reqLogger.Warn("http_request", fields)
+		default:
+			reqLogger.Info("http_request", fields)
+		}
 	}
 }
@@ -0,0 +1,25 @@
+package middleware
+
+import (
+	"net"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"workout-app/internal/handler/response"
+)
+
+// LoopbackOnly возвращает middleware, пропускающее только запросы с loopback-адреса.
+// Проверяется адрес TCP-соединения, а не X-Forwarded-For: заголовок подделывается клиентом,
+// а запрос через прокси приходит с адреса прокси и отклоняется. Остальным отвечает 404.
+func LoopbackOnly() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ip := net.ParseIP(c.RemoteIP())
+		if ip == nil || !ip.IsLoopback() {
+			response.Error(c, http.StatusNotFound, "not_found", "Не найдено", nil)
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
@@ -0,0 +1,91 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"workout-app/internal/domain/organization"
+	domain "workout-app/internal/domain/user"
+	"workout-app/internal/handler/response"
+	"workout-app/pkg/logger"
+)
+
+// ContextOrgRoleKey — роль текущего пользователя в организации из пути запроса; её задаёт RequireOrgRole.
+const ContextOrgRoleKey = "orgRole"
+
+// OrgRoleResolver определяет роль пользователя в организации.
+type OrgRoleResolver interface {
+	// MemberRole возвращает роль пользователя в организации; false — пользователь в ней не состоит.
+	MemberRole(ctx context.Context, orgID, userID uuid.UUID) (organization.Role, bool, error)
+}
+
+// RequireOrgRole возвращает middleware, которое проверяет роль текущего пользователя в организации
+// из параметра пути :id. Дополняет RequireRole: глобальная роль admin проходит проверку с правами
+// владельца. Должно подключаться после Auth. Роль сохраняется в контексте под ContextOrgRoleKey.
+// Не состоящему в организации отвечает 404, чтобы не раскрывать её существование.
+func RequireOrgRole(resolver OrgRoleResolver, log logger.Logger, allowedRoles ...organization.Role) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		orgID, err := uuid.Parse(c.Param("id"))
+		if err != nil {
+			response.Error(c, http.StatusBadRequest, "invalid_organization_id", "Некорректный ID организации", nil)
+			c.Abort()
+			return
+		}
+		if domain.Role(c.GetString(ContextUserRoleKey)) == domain.RoleAdmin {
+			c.Set(ContextOrgRoleKey, string(organization.RoleOwner))
+			c.Next()
+			return
+		}
+
+		userID, err := UserIDFromContext(c)
+		if err != nil {
+			response.Error(c, http.StatusUnauthorized, "unauthorized", "Требуется аутентификация", nil)
+			c.Abort()
+			return
+		}
+
+		role, member, err := resolver.MemberRole(c.Request.Context(), orgID, userID)
+		if err != nil {
+			log.Error("org_role_resolve_failed", map[string]any{
+				"user_id":         userID.String(),
+				"organization_id": orgID.String(),
+				"path":            c.Request.URL.Path,
+				"method":          c.Request.Method,
+				"error":           err.Error(),
+			})
+			response.Error(c, http.StatusInternalServerError, "internal_error", "Внутренняя ошибка сервера", nil)
+			c.Abort()
+			return
+		}
+		if !member {
+			response.Error(c, http.StatusNotFound, "organization_not_found", "Организация не найдена", nil)
+			c.Abort()
+			return
+		}
+
+		for _, allowed := range allowedRoles {
+			if role == allowed {
+				c.Set(ContextOrgRoleKey, string(role))
+				c.Next()
+				return
+			}
+		}
+		log.Info("access_denied_by_org_role", map[string]any{
+			"user_id":         userID.String(),
+			"organization_id": orgID.String(),
+			"path":            c.Request.URL.Path,
+			"method":          c.Request.Method,
+			"org_role":        role,
+		})
+		response.Error(c, http.StatusForbidden, "forbidden", "Недостаточно прав в организации", nil)
+		c.Abort()
+	}
+}
+
+// OrgRoleFromContext возвращает роль текущего пользователя в организации, сохранённую RequireOrgRole.
+func OrgRoleFromContext(c *gin.Context) organization.Role {
+	return organization.Role(c.GetString(ContextOrgRoleKey))
+}
@@ -0,0 +1,113 @@
+package middleware
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"workout-app/internal/handler/response"
+	"workout-app/pkg/logger"
+	"workout-app/pkg/ratelimit"
+)
+
+// KeyFunc извлекает из запроса ключ, по которому считается лимит.
+// Пустой ключ означает, что запрос не ограничивается.
+type KeyFunc func(c *gin.Context) string
+
+// ByClientIP возвращает KeyFunc, ограничивающий запросы по IP клиента.
+func ByClientIP(prefix string) KeyFunc {
+	return func(c *gin.Context) string {
+		return prefix + ":ip:" + c.ClientIP()
+	}
+}
+
+// ByUserID возвращает KeyFunc, ограничивающий запросы по ID аутентифицированного пользователя.
+// Должно подключаться после Auth; без пользователя в контексте запрос не ограничивается.
+func ByUserID(prefix string) KeyFunc {
+	return func(c *gin.Context) string {
+		userID := c.GetString(ContextUserIDKey)
+		if userID == "" {
+			return ""
+		}
+		return prefix + ":user:" + userID
+	}
+}
+
+// maxKeyBodyBytes ограничивает объём тела запроса, читаемого для извлечения ключа.
+const maxKeyBodyBytes = 64 << 10
+
+// ByJSONField возвращает KeyFunc, ограничивающий запросы по строковому полю JSON-тела
+// (например, email). Значение приводится normalize к форме, в которой разные написания одного
+// значения совпадают (для email — emailaddr.Policy.Canonical), и хешируется, чтобы не хранить
+// персональные данные в ключах хранилища. Тело запроса восстанавливается для handler'а.
+// Если поле отсутствует или тело не разбирается, запрос по этому ключу не ограничивается.
+func ByJSONField(prefix, field string, normalize func(string) string) KeyFunc {
+	return func(c *gin.Context) string {
+		if c.Request.Body == nil {
+			return ""
+		}
+		body, err := io.ReadAll(io.LimitReader(c.Request.Body, maxKeyBodyBytes))
+		if err != nil {
+			return ""
+		}
+		c.Request.Body = io.NopCloser(io.MultiReader(bytes.NewReader(body), c.Request.Body))
+
+		var payload map[string]any
+		if err := json.Unmarshal(body, &payload); err != nil {
+			return ""
+		}
+		value, _ := payload[field].(string)
+		value = normalize(value)
+		if value == "" {
+			return ""
+		}
+		sum := sha256.Sum256([]byte(value))
+		return prefix + ":" + field + ":" + hex.EncodeToString(sum[:16])
+	}
+}
+
+// RateLimit возвращает middleware, ограничивающее частоту запросов по ключу из keyFn.
+// При превышении лимита отвечает 429 rate_limited с заголовком Retry-After.
+// Ошибки хранилища лимитов не блокируют запрос (fail-open), а только логируются.
+func RateLimit(limiter ratelimit.Limiter, keyFn KeyFunc, log logger.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := keyFn(c)
+		if key == "" {
+			c.Next()
+			return
+		}
+
+		res, err := limiter.Allow(c.Request.Context(), key)
+		if err != nil {
+			log.Error("rate_limiter_error", map[string]any{
+				"path":   c.Request.URL.Path,
+				"method": c.Request.Method,
+				"error":  err.Error(),
+			})
+			c.Next()
+			return
+		}
+
+		c.Header("X-RateLimit-Limit", strconv.Itoa(res.Limit))
+		c.Header("X-RateLimit-Remaining", strconv.Itoa(res.Remaining))
+
+		if !res.Allowed {
+			log.Info("rate_limited", map[string]any{
+				"path":      c.Request.URL.Path,
+				"method":    c.Request.Method,
+				"client_ip": c.ClientIP(),
+			})
+			response.RetryLater(c, http.StatusTooManyRequests, "rate_limited", "Too many requests, please try again later", res.RetryAfter, nil)
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
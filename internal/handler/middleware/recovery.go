@@ -2,24 +2,26 @@ package middleware
 
 import (
 	"fmt"
-	"log"
 	"net/http"
+	"runtime/debug"
 
 	"github.com/gin-gonic/gin"
-)
 
-// Recovery middleware для обработки паник и предотвращения краша приложения
-func Recovery() gin.HandlerFunc {
-	return gin.CustomRecovery(func(c *gin.Context, recovered interface{}) {
-		// Логируем панику с подробной информацией
-		log.Printf("Паника перехвачена: %v\n", recovered)
+	"workout-app/pkg/logger"
+)
 
-		// Логируем стек вызовов (если доступен)
-		if err, ok := recovered.(string); ok {
-			log.Printf("Ошибка: %s\n", err)
-		}
+// Recovery middleware для обработки паник и предотвращения краша приложения.
+// Паника логируется со стеком вызовов и request_id; клиенту возвращается 500 без деталей.
+func Recovery(log logger.Logger) gin.HandlerFunc {
+	return gin.CustomRecoveryWithWriter(nil, func(c *gin.Context, recovered interface{}) {
+		LoggerFromContext(c, log).Error("panic_recovered", map[string]any{
+			"method":    c.Request.Method,
+			"path":      c.Request.URL.Path,
+			"client_ip": c.ClientIP(),
+			"panic":     fmt.Sprintf("%v", recovered),
+			"stack":     string(debug.Stack()),
+		})
 
-		// Возвращаем 500 ошибку клиенту
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error":   "Внутренняя ошибка сервера",
 			"message": "Произошла непредвиденная ошибка. Пожалуйста, попробуйте позже.",
@@ -28,30 +30,3 @@ func Recovery() gin.HandlerFunc {
 		c.Abort()
 	})
 }
-
-// RecoveryWithLogger - расширенная версия с дополнительным логированием
-func RecoveryWithLogger() gin.HandlerFunc {
-	return gin.CustomRecoveryWithWriter(nil, func(c *gin.Context, recovered interface{}) {
-		// Получаем информацию о запросе
-		method := c.Request.Method
-		path := c.Request.URL.Path
-		clientIP := c.ClientIP()
-
-		// Логируем панику с контекстом
-		log.Printf("[PANIC] %s %s from %s: %v\n", method, path, clientIP, recovered)
-
-		// В production режиме не показываем детали ошибки
-		errorMessage := "Внутренняя ошибка сервера"
-		if gin.Mode() == gin.DebugMode {
-			errorMessage = fmt.Sprintf("%v", recovered)
-		}
-
-		// Возвращаем ошибку
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error":   "Internal Server Error",
-			"message": errorMessage,
-		})
-
-		c.Abort()
-	})
-}
@@ -0,0 +1,62 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"workout-app/internal/domain/region"
+	"workout-app/internal/handler/response"
+	"workout-app/pkg/logger"
+)
+
+// RegionResolver определяет регион хранения данных пользователя.
+type RegionResolver interface {
+	// GetRegion возвращает регион пользователя.
+	GetRegion(ctx context.Context, userID uuid.UUID) (region.Region, error)
+}
+
+// RegionFeature возвращает middleware, отклоняющее запрос, если функция отключена
+// в регионе текущего пользователя. Должно подключаться после Auth.
+// Если политика разрешает функцию во всех регионах, обращения к resolver не происходит.
+func RegionFeature(feature region.Feature, resolver RegionResolver, policy region.Policy, log logger.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if policy.Allows(region.RegionEU, feature) && policy.Allows(region.RegionGlobal, feature) {
+			c.Next()
+			return
+		}
+
+		userID, err := UserIDFromContext(c)
+		if err != nil {
+			response.Error(c, http.StatusUnauthorized, "unauthorized", "Требуется аутентификация", nil)
+			c.Abort()
+			return
+		}
+
+		r, err := resolver.GetRegion(c.Request.Context(), userID)
+		if err != nil {
+			log.Error("region_resolve_failed", map[string]any{
+				"user_id": userID.String(),
+				"path":    c.Request.URL.Path,
+				"method":  c.Request.Method,
+				"error":   err.Error(),
+			})
+			response.Error(c, http.StatusInternalServerError, "internal_error", "Внутренняя ошибка сервера", nil)
+			c.Abort()
+			return
+		}
+
+		if !policy.Allows(r, feature) {
+			response.Error(c, http.StatusForbidden, "feature_unavailable_in_region", "Функция недоступна в вашем регионе", map[string]string{
+				"feature": string(feature),
+				"region":  string(r),
+			})
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -0,0 +1,44 @@
+package middleware
+
+import (
+	"regexp"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"workout-app/pkg/logger"
+)
+
+const (
+	// HeaderRequestID — заголовок с идентификатором запроса (принимается от клиента/балансировщика и возвращается в ответе).
+	HeaderRequestID = "X-Request-ID"
+	// ContextRequestIDKey — ключ gin-контекста с идентификатором запроса.
+	ContextRequestIDKey = "requestID"
+)
+
+// requestIDPattern ограничивает принимаемые от клиента идентификаторы, чтобы они не ломали формат логов.
+var requestIDPattern = regexp.MustCompile(`^[A-Za-z0-9._-]{1,64}$`)
+
+// RequestID присваивает запросу идентификатор и кладёт в контекст логгер с полем request_id.
+// Корректный X-Request-ID из запроса переиспользуется, иначе генерируется новый UUID.
+func RequestID(log logger.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.GetHeader(HeaderRequestID)
+		if !requestIDPattern.MatchString(id) {
+			id = uuid.NewString()
+		}
+
+		c.Set(ContextRequestIDKey, id)
+		c.Header(HeaderRequestID, id)
+
+		reqLogger := log.With(map[string]any{"request_id": id})
+		c.Request = c.Request.WithContext(logger.NewContext(c.Request.Context(), reqLogger))
+
+		c.Next()
+	}
+}
+
+// LoggerFromContext возвращает логгер текущего запроса (с полем request_id) или fallback.
+func LoggerFromContext(c *gin.Context, fallback logger.Logger) logger.Logger {
+	return logger.FromContext(c.Request.Context(), fallback)
+}
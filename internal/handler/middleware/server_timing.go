@@ -0,0 +1,60 @@
+package middleware
+
+import (
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"workout-app/pkg/servertiming"
+)
+
+// ServerTiming возвращает middleware, добавляющее к ответу заголовок Server-Timing
+// с суммарным временем обращений к БД (db), кешу (cache) и внешним сервисам (external)
+// за время запроса, а также полным временем обработки (total).
+// Метрики собираются инструментированными клиентами через контекст запроса,
+// поэтому middleware должно стоять до middleware, подменяющих контекст или writer.
+func ServerTiming() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx, timings := servertiming.NewContext(c.Request.Context())
+		c.Request = c.Request.WithContext(ctx)
+
+		w := &timingWriter{ResponseWriter: c.Writer, timings: timings, start: time.Now()}
+		c.Writer = w
+
+		c.Next()
+
+		// Ответ без тела (например, 204) ещё не отправлен — заголовок можно добавить здесь.
+		w.setHeader()
+	}
+}
+
+// timingWriter добавляет заголовок Server-Timing непосредственно перед отправкой заголовков ответа.
+type timingWriter struct {
+	gin.ResponseWriter
+	timings *servertiming.Timings
+	start   time.Time
+	done    bool
+}
+
+func (w *timingWriter) setHeader() {
+	if w.done || w.ResponseWriter.Written() {
+		return
+	}
+	w.done = true
+	w.Header().Set(servertiming.HeaderName, w.timings.Header(time.Since(w.start)))
+}
+
+func (w *timingWriter) WriteHeaderNow() {
+	w.setHeader()
+	w.ResponseWriter.WriteHeaderNow()
+}
+
+func (w *timingWriter) Write(data []byte) (int, error) {
+	w.setHeader()
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *timingWriter) WriteString(s string) (int, error) {
+	w.setHeader()
+	return w.ResponseWriter.WriteString(s)
+}
@@ -0,0 +1,28 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"workout-app/pkg/logger"
+	"workout-app/pkg/tracing"
+)
+
+// Trace начинает span запроса, продолжая трассу из заголовка traceparent, если он корректен,
+// и возвращает traceparent span'а в ответе. В логгер запроса добавляется поле trace_id.
+// Фоновые задачи, поставленные запросом, сохраняют его traceparent и продолжают ту же трассу.
+// Должно стоять после RequestID, чтобы дополнить его логгер.
+func Trace() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		parent, _ := tracing.Parse(c.GetHeader(tracing.HeaderName))
+		ctx, span := tracing.Start(c.Request.Context(), "http_request", parent)
+
+		reqLogger := logger.FromContext(ctx, nil)
+		if reqLogger != nil {
+			ctx = logger.NewContext(ctx, reqLogger.With(map[string]any{"trace_id": span.Context.TraceID}))
+		}
+		c.Request = c.Request.WithContext(ctx)
+		c.Header(tracing.HeaderName, span.Context.String())
+
+		c.Next()
+	}
+}
@@ -0,0 +1,124 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"workout-app/internal/handler/response"
+	repo "workout-app/internal/repository/interfaces"
+	"workout-app/pkg/logger"
+)
+
+// errRollback сигнализирует Transactor'у откатить транзакцию без ошибки для клиента.
+var errRollback = errors.New("rollback requested by handler outcome")
+
+// Transaction возвращает opt-in middleware, выполняющее изменяющий запрос (POST/PUT/PATCH/DELETE)
+// в одной транзакции БД. Все вызовы usecase'ов внутри handler'а получают контекст с транзакцией.
+//
+// Транзакция фиксируется, если handler ответил статусом < 400 и не добавил ошибок в c.Errors,
+// иначе откатывается. Ответ буферизуется и отправляется клиенту только после фиксации:
+// если COMMIT не удался, клиент получает 500, а не успешный ответ об изменениях, которых нет.
+//
+// Не подключайте к маршрутам, выполняющим операции, недопустимые в транзакции
+// (например, REFRESH MATERIALIZED VIEW CONCURRENTLY), и к долгим запросам.
+func Transaction(tx repo.Transactor, log logger.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		switch c.Request.Method {
+		case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		default:
+			c.Next()
+			return
+		}
+
+		original := c.Writer
+		originalReq := c.Request
+		buffered := &bufferedWriter{ResponseWriter: original, status: http.StatusOK}
+		c.Writer = buffered
+
+		err := func() error {
+			defer func() {
+				c.Writer = original
+				c.Request = originalReq
+			}()
+			return tx.WithinTransaction(originalReq.Context(), func(ctx context.Context) error {
+				c.Request = originalReq.WithContext(ctx)
+				c.Next()
+				if buffered.status >= http.StatusBadRequest || len(c.Errors) > 0 {
+					return errRollback
+				}
+				return nil
+			})
+		}()
+
+		if err != nil && !errors.Is(err, errRollback) {
+			log.Error("transaction_commit_failed", map[string]any{
+				"path":   c.Request.URL.Path,
+				"method": c.Request.Method,
+				"status": buffered.status,
+				"error":  err.Error(),
+			})
+			response.Error(c, http.StatusInternalServerError, "internal_error", "Внутренняя ошибка сервера", nil)
+			return
+		}
+
+		buffered.flush()
+	}
+}
+
+// bufferedWriter накапливает статус и тело ответа до завершения транзакции.
+// Заголовки пишутся сразу в исходный writer и отправляются вместе с буфером.
+type bufferedWriter struct {
+	gin.ResponseWriter
+	status  int
+	written bool
+	body    bytes.Buffer
+}
+
+func (w *bufferedWriter) WriteHeader(code int) {
+	if code > 0 && !w.written {
+		w.status = code
+	}
+}
+
+func (w *bufferedWriter) WriteHeaderNow() {
+	w.written = true
+}
+
+func (w *bufferedWriter) Write(data []byte) (int, error) {
+	w.written = true
+	return w.body.Write(data)
+}
+
+func (w *bufferedWriter) WriteString(s string) (int, error) {
+	w.written = true
+	return w.body.WriteString(s)
+}
+
+func (w *bufferedWriter) Status() int {
+	return w.status
+}
+
+func (w *bufferedWriter) Size() int {
+	if !w.written {
+		return -1
+	}
+	return w.body.Len()
+}
+
+func (w *bufferedWriter) Written() bool {
+	return w.written
+}
+
+// flush отправляет накопленный ответ в исходный writer.
+func (w *bufferedWriter) flush() {
+	w.ResponseWriter.WriteHeader(w.status)
+	if w.body.Len() == 0 {
+		w.ResponseWriter.WriteHeaderNow()
+		return
+	}
+	_, _ = w.ResponseWriter.Write(w.body.Bytes())
+}
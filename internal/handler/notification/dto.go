@@ -0,0 +1,47 @@
+package notification
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// NotificationResponse описывает уведомление в приложении.
+// Data — данные события, по которому создано уведомление; набор полей зависит от type.
+type NotificationResponse struct {
+	ID        string          `json:"id"`
+	Type      string          `json:"type"`
+	Data      json.RawMessage `json:"data" swaggertype:"object"`
+	CreatedAt time.Time       `json:"created_at"`
+	ReadAt    *time.Time      `json:"read_at"`
+}
+
+// NotificationsResponse описывает страницу уведомлений.
+type NotificationsResponse struct {
+	Items  []NotificationResponse `json:"items"`
+	Total  int64                  `json:"total"`
+	Unread int64                  `json:"unread"` // Все непрочитанные уведомления пользователя
+}
+
+// PreferenceItem описывает настройку копий уведомлений одного типа.
+type PreferenceItem struct {
+	Type  string `json:"type"`
+	Email bool   `json:"email"`
+	Push  bool   `json:"push"`
+}
+
+// PreferenceUpdateItem описывает изменение настройки одного типа; отсутствующие поля не меняются.
+type PreferenceUpdateItem struct {
+	Type  string `json:"type" binding:"required"`
+	Email *bool  `json:"email"`
+	Push  *bool  `json:"push"`
+}
+
+// PreferencesRequest описывает тело запроса на изменение настроек; типы, которых нет в запросе, не меняются.
+type PreferencesRequest struct {
+	Items []PreferenceUpdateItem `json:"items" binding:"required,dive"`
+}
+
+// PreferencesResponse описывает настройки по всем типам уведомлений.
+type PreferencesResponse struct {
+	Items []PreferenceItem `json:"items"`
+}
@@ -0,0 +1,255 @@
+package notification
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	domain "workout-app/internal/domain/notification"
+	"workout-app/internal/handler/middleware"
+	"workout-app/internal/handler/response"
+	notificationuc "workout-app/internal/usecase/notification"
+	"workout-app/pkg/logger"
+)
+
+// Handler обрабатывает HTTP-запросы, связанные с уведомлениями в приложении и их настройками.
+type Handler struct {
+	notifications notificationuc.Service
+	logger        logger.Logger
+}
+
+// NewHandler создаёт новый NotificationHandler.
+func NewHandler(notifications notificationuc.Service, logger logger.Logger) *Handler {
+	return &Handler{
+		notifications: notifications,
+		logger:        logger,
+	}
+}
+
+// List godoc
+// @Summary      Уведомления
+// @Description  Возвращает уведомления текущего пользователя, новые первыми, и количество непрочитанных. Уведомления создаются по событиям: назначение программы тренером, запись на групповое занятие, лист ожидания, получение места из листа ожидания.
+// @Tags         notifications
+// @Security     BearerAuth
+// @Produce      json
+// @Param        unread  query     bool    false  "Только непрочитанные"
+// @Param        limit   query     int     false  "Размер страницы (по умолчанию 20, максимум 100)"
+// @Param        offset  query     int     false  "Смещение"
+// @Success      200     {object}  NotificationsResponse
+// @Failure      400     {object}  response.ErrorBody
+// @Failure      401     {object}  response.ErrorBody
+// @Failure      500     {object}  response.ErrorBody
+// @Router       /api/v1/users/me/notifications [get]
+func (h *Handler) List(c *gin.Context) {
+	userID, ok := h.currentUser(c)
+	if !ok {
+		return
+	}
+	limit, err1 := queryInt(c, "limit")
+	offset, err2 := queryInt(c, "offset")
+	if err1 != nil || err2 != nil {
+		response.Error(c, http.StatusBadRequest, "invalid_pagination", "Параметры limit и offset должны быть неотрицательными числами", nil)
+		return
+	}
+	unreadOnly := false
+	if raw := c.Query("unread"); raw != "" {
+		v, err := strconv.ParseBool(raw)
+		if err != nil {
+			response.Error(c, http.StatusBadRequest, "invalid_unread", "Параметр unread должен быть true или false", nil)
+			return
+		}
+		unreadOnly = v
+	}
+
+	page, err := h.notifications.List(c.Request.Context(), userID, unreadOnly, limit, offset)
+	if err != nil {
+		h.respondError(c, "list_notifications", userID, err)
+		return
+	}
+
+	resp := NotificationsResponse{
+		Items:  make([]NotificationResponse, 0, len(page.Items)),
+		Total:  page.Total,
+		Unread: page.Unread,
+	}
+	for _, n := range page.Items {
+		resp.Items = append(resp.Items, toNotificationResponse(n))
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// MarkRead godoc
+// @Summary      Отметить уведомление прочитанным
+// @Description  Отмечает уведомление текущего пользователя прочитанным. Повторная отметка не меняет время прочтения.
+// @Tags         notifications
+// @Security     BearerAuth
+// @Param        id   path  string  true  "ID уведомления"
+// @Success      204
+// @Failure      400  {object}  response.ErrorBody
+// @Failure      401  {object}  response.ErrorBody
+// @Failure      404  {object}  response.ErrorBody
+// @Failure      500  {object}  response.ErrorBody
+// @Router       /api/v1/users/me/notifications/{id}/read [post]
+func (h *Handler) MarkRead(c *gin.Context) {
+	userID, ok := h.currentUser(c)
+	if !ok {
+		return
+	}
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid_notification_id", "Некорректный ID уведомления", nil)
+		return
+	}
+
+	if err := h.notifications.MarkRead(c.Request.Context(), userID, id); err != nil {
+		h.respondError(c, "mark_notification_read", userID, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// MarkAllRead godoc
+// @Summary      Отметить все уведомления прочитанными
+// @Description  Отмечает прочитанными все уведомления текущего пользователя.
+// @Tags         notifications
+// @Security     BearerAuth
+// @Success      204
+// @Failure      401  {object}  response.ErrorBody
+// @Failure      500  {object}  response.ErrorBody
+// @Router       /api/v1/users/me/notifications/read-all [post]
+func (h *Handler) MarkAllRead(c *gin.Context) {
+	userID, ok := h.currentUser(c)
+	if !ok {
+		return
+	}
+
+	if err := h.notifications.MarkAllRead(c.Request.Context(), userID); err != nil {
+		h.respondError(c, "mark_all_notifications_read", userID, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// GetPreferences godoc
+// @Summary      Настройки уведомлений
+// @Description  Возвращает для каждого типа уведомлений, отправляются ли его копии письмом и push-уведомлением. По умолчанию копии включены.
+// @Tags         notifications
+// @Security     BearerAuth
+// @Produce      json
+// @Success      200  {object}  PreferencesResponse
+// @Failure      401  {object}  response.ErrorBody
+// @Failure      500  {object}  response.ErrorBody
+// @Router       /api/v1/users/me/notification-preferences [get]
+func (h *Handler) GetPreferences(c *gin.Context) {
+	userID, ok := h.currentUser(c)
+	if !ok {
+		return
+	}
+
+	prefs, err := h.notifications.GetPreferences(c.Request.Context(), userID)
+	if err != nil {
+		h.respondError(c, "get_notification_preferences", userID, err)
+		return
+	}
+	c.JSON(http.StatusOK, toPreferencesResponse(prefs))
+}
+
+// UpdatePreferences godoc
+// @Summary      Изменить настройки уведомлений
+// @Description  Включает или отключает письма и push-уведомления для переданных типов уведомлений; остальные типы и поля не меняются. Уведомления в приложении создаются независимо от настроек.
+// @Tags         notifications
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        request  body      PreferencesRequest  true  "Настройки"
+// @Success      200      {object}  PreferencesResponse
+// @Failure      400      {object}  response.ErrorBody
+// @Failure      401      {object}  response.ErrorBody
+// @Failure      500      {object}  response.ErrorBody
+// @Router       /api/v1/users/me/notification-preferences [put]
+func (h *Handler) UpdatePreferences(c *gin.Context) {
+	userID, ok := h.currentUser(c)
+	if !ok {
+		return
+	}
+	var req PreferencesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid_request", "Некорректное тело запроса", err.Error())
+		return
+	}
+
+	updates := make([]notificationuc.PreferenceUpdate, 0, len(req.Items))
+	for _, item := range req.Items {
+		updates = append(updates, notificationuc.PreferenceUpdate{Type: item.Type, Email: item.Email, Push: item.Push})
+	}
+	updated, err := h.notifications.UpdatePreferences(c.Request.Context(), userID, updates)
+	if err != nil {
+		h.respondError(c, "update_notification_preferences", userID, err)
+		return
+	}
+	c.JSON(http.StatusOK, toPreferencesResponse(updated))
+}
+
+// currentUser извлекает текущего пользователя из контекста.
+func (h *Handler) currentUser(c *gin.Context) (uuid.UUID, bool) {
+	userID, err := middleware.UserIDFromContext(c)
+	if err != nil {
+		response.Error(c, http.StatusUnauthorized, "unauthorized", "Требуется аутентификация", nil)
+		return uuid.Nil, false
+	}
+	return userID, true
+}
+
+// respondError маппит ошибки usecase-слоя в HTTP-ответы.
+func (h *Handler) respondError(c *gin.Context, op string, userID uuid.UUID, err error) {
+	switch {
+	case errors.Is(err, notificationuc.ErrNotificationNotFound):
+		response.Error(c, http.StatusNotFound, "notification_not_found", "Уведомление не найдено", nil)
+	case errors.Is(err, notificationuc.ErrInvalidType):
+		response.Error(c, http.StatusBadRequest, "invalid_notification_type", "Неизвестный тип уведомлений", err.Error())
+	default:
+		h.logger.Error("internal_error_in_"+op, map[string]any{
+			"user_id": userID.String(),
+			"path":    c.Request.URL.Path,
+			"method":  c.Request.Method,
+			"error":   err.Error(),
+		})
+		response.Error(c, http.StatusInternalServerError, "internal_error", "Внутренняя ошибка сервера", nil)
+	}
+}
+
+// queryInt разбирает неотрицательный числовой параметр запроса; пустой параметр — 0.
+func queryInt(c *gin.Context, name string) (int, error) {
+	raw := c.Query(name)
+	if raw == "" {
+		return 0, nil
+	}
+	n, err := strconv.Atoi(raw)
+	if err != nil || n < 0 {
+		return 0, strconv.ErrSyntax
+	}
+	return n, nil
+}
+
+// toNotificationResponse маппит уведомление в DTO.
+func toNotificationResponse(n *domain.Notification) NotificationResponse {
+	return NotificationResponse{
+		ID:        n.ID.String(),
+		Type:      n.Type,
+		Data:      n.Data,
+		CreatedAt: n.CreatedAt,
+		ReadAt:    n.ReadAt,
+	}
+}
+
+// toPreferencesResponse маппит настройки в DTO.
+func toPreferencesResponse(prefs []domain.Preference) PreferencesResponse {
+	resp := PreferencesResponse{Items: make([]PreferenceItem, 0, len(prefs))}
+	for _, p := range prefs {
+		resp.Items = append(resp.Items, PreferenceItem{Type: p.Type, Email: p.Email, Push: p.Push})
+	}
+	return resp
+}
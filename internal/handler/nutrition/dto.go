@@ -0,0 +1,60 @@
+package nutrition
+
+import "time"
+
+// EntryRequest описывает тело запроса на добавление или изменение записи дневника питания.
+type EntryRequest struct {
+	// Date — день дневника (YYYY-MM-DD); не задан — сегодня в часовом поясе пользователя
+	// (при изменении — прежний день записи).
+	Date string `json:"date,omitempty" example:"2026-10-15"`
+	// Meal — приём пищи: breakfast, lunch, dinner или snack.
+	Meal     string  `json:"meal" binding:"required" example:"breakfast"`
+	Name     string  `json:"name" binding:"required" example:"Овсянка на молоке"`
+	Calories *int    `json:"calories" binding:"required" example:"320"`
+	ProteinG float64 `json:"protein_g" example:"11.5"`
+	CarbsG   float64 `json:"carbs_g" example:"48"`
+	FatG     float64 `json:"fat_g" example:"8.2"`
+}
+
+// EntryResponse описывает запись дневника питания.
+type EntryResponse struct {
+	ID        string    `json:"id"`
+	Date      string    `json:"date"`
+	Meal      string    `json:"meal"`
+	Name      string    `json:"name"`
+	Calories  int       `json:"calories"`
+	ProteinG  float64   `json:"protein_g"`
+	CarbsG    float64   `json:"carbs_g"`
+	FatG      float64   `json:"fat_g"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// DayTotalsResponse описывает итоги дня.
+type DayTotalsResponse struct {
+	Date     string  `json:"date"`
+	Entries  int     `json:"entries"`
+	Calories int     `json:"calories"`
+	ProteinG float64 `json:"protein_g"`
+	CarbsG   float64 `json:"carbs_g"`
+	FatG     float64 `json:"fat_g"`
+	// RemainingCalories — сколько ккал осталось до цели (отрицательное — цель превышена); отсутствует без цели.
+	RemainingCalories *int `json:"remaining_calories,omitempty"`
+}
+
+// DiaryResponse описывает дневник питания за день.
+type DiaryResponse struct {
+	Date string `json:"date"`
+	// CalorieTarget — дневная цель по калориям из профиля; отсутствует, если не задана.
+	CalorieTarget *int              `json:"calorie_target,omitempty"`
+	Items         []EntryResponse   `json:"items"`
+	Totals        DayTotalsResponse `json:"totals"`
+}
+
+// TotalsResponse описывает итоги дней периода.
+type TotalsResponse struct {
+	From          string              `json:"from"`
+	To            string              `json:"to"`
+	CalorieTarget *int                `json:"calorie_target,omitempty"`
+	Items         []DayTotalsResponse `json:"items"`
+}
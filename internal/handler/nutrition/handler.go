@@ -0,0 +1,326 @@
+package nutrition
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	domain "workout-app/internal/domain/nutrition"
+	"workout-app/internal/handler/middleware"
+	"workout-app/internal/handler/response"
+	nutritionuc "workout-app/internal/usecase/nutrition"
+	"workout-app/pkg/logger"
+)
+
+const dateLayout = "2006-01-02"
+
+// Handler обрабатывает HTTP-запросы дневника питания.
+type Handler struct {
+	nutrition nutritionuc.Service
+	logger    logger.Logger
+}
+
+// NewHandler создаёт новый NutritionHandler.
+func NewHandler(nutrition nutritionuc.Service, logger logger.Logger) *Handler {
+	return &Handler{
+		nutrition: nutrition,
+		logger:    logger,
+	}
+}
+
+// AddEntry godoc
+// @Summary      Добавить запись в дневник питания
+// @Description  Сохраняет продукт или блюдо с калорийностью и БЖУ порции. День по умолчанию — сегодня в часовом поясе пользователя; будущие дни недоступны.
+// @Tags         nutrition
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        payload  body      EntryRequest  true  "Запись дневника"
+// @Success      201      {object}  EntryResponse
+// @Failure      400      {object}  response.ErrorBody
+// @Failure      401      {object}  response.ErrorBody
+// @Failure      500      {object}  response.ErrorBody
+// @Router       /api/v1/nutrition/entries [post]
+func (h *Handler) AddEntry(c *gin.Context) {
+	userID, ok := h.userID(c)
+	if !ok {
+		return
+	}
+	input, ok := bindEntry(c)
+	if !ok {
+		return
+	}
+
+	entry, err := h.nutrition.AddEntry(c.Request.Context(), userID, input)
+	if err != nil {
+		h.respondError(c, "add_food_entry", userID, err)
+		return
+	}
+	c.JSON(http.StatusCreated, toEntryResponse(entry))
+}
+
+// Diary godoc
+// @Summary      Дневник питания за день
+// @Description  Возвращает записи дня по приёмам пищи (завтрак, обед, ужин, перекусы), итоги дня и дневную цель по калориям из профиля.
+// @Tags         nutrition
+// @Security     BearerAuth
+// @Produce      json
+// @Param        date  query     string  false  "День (YYYY-MM-DD), по умолчанию — сегодня в часовом поясе пользователя"
+// @Success      200   {object}  DiaryResponse
+// @Failure      400   {object}  response.ErrorBody
+// @Failure      401   {object}  response.ErrorBody
+// @Failure      500   {object}  response.ErrorBody
+// @Router       /api/v1/nutrition/entries [get]
+func (h *Handler) Diary(c *gin.Context) {
+	userID, ok := h.userID(c)
+	if !ok {
+		return
+	}
+	date, ok := queryDate(c, "date")
+	if !ok {
+		return
+	}
+
+	diary, err := h.nutrition.Diary(c.Request.Context(), userID, date)
+	if err != nil {
+		h.respondError(c, "food_diary", userID, err)
+		return
+	}
+	resp := DiaryResponse{
+		Date:          diary.Date.Format(dateLayout),
+		CalorieTarget: diary.CalorieTarget,
+		Items:         make([]EntryResponse, 0, len(diary.Entries)),
+		Totals:        toDayTotalsResponse(diary.Totals),
+	}
+	for _, e := range diary.Entries {
+		resp.Items = append(resp.Items, toEntryResponse(e))
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// UpdateEntry godoc
+// @Summary      Изменить запись дневника питания
+// @Description  Заменяет данные записи. Без date запись остаётся в прежнем дне.
+// @Tags         nutrition
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        id       path      string        true  "ID записи"
+// @Param        payload  body      EntryRequest  true  "Запись дневника"
+// @Success      200      {object}  EntryResponse
+// @Failure      400      {object}  response.ErrorBody
+// @Failure      401      {object}  response.ErrorBody
+// @Failure      404      {object}  response.ErrorBody
+// @Failure      500      {object}  response.ErrorBody
+// @Router       /api/v1/nutrition/entries/{id} [put]
+func (h *Handler) UpdateEntry(c *gin.Context) {
+	userID, ok := h.userID(c)
+	if !ok {
+		return
+	}
+	entryID, ok := parseEntryID(c)
+	if !ok {
+		return
+	}
+	input, ok := bindEntry(c)
+	if !ok {
+		return
+	}
+
+	entry, err := h.nutrition.UpdateEntry(c.Request.Context(), userID, entryID, input)
+	if err != nil {
+		h.respondError(c, "update_food_entry", userID, err)
+		return
+	}
+	c.JSON(http.StatusOK, toEntryResponse(entry))
+}
+
+// DeleteEntry godoc
+// @Summary      Удалить запись дневника питания
+// @Tags         nutrition
+// @Security     BearerAuth
+// @Param        id   path  string  true  "ID записи"
+// @Success      204
+// @Failure      400  {object}  response.ErrorBody
+// @Failure      401  {object}  response.ErrorBody
+// @Failure      404  {object}  response.ErrorBody
+// @Failure      500  {object}  response.ErrorBody
+// @Router       /api/v1/nutrition/entries/{id} [delete]
+func (h *Handler) DeleteEntry(c *gin.Context) {
+	userID, ok := h.userID(c)
+	if !ok {
+		return
+	}
+	entryID, ok := parseEntryID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.nutrition.DeleteEntry(c.Request.Context(), userID, entryID); err != nil {
+		h.respondError(c, "delete_food_entry", userID, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// Totals godoc
+// @Summary      Итоги питания по дням
+// @Description  Возвращает калории и БЖУ каждого дня периода [from, to] (не больше 92 дней), включая дни без записей, и остаток до дневной цели. По умолчанию — сегодня в часовом поясе пользователя; без from — только день to.
+// @Tags         nutrition
+// @Security     BearerAuth
+// @Produce      json
+// @Param        from  query     string  false  "Первый день (YYYY-MM-DD)"
+// @Param        to    query     string  false  "Последний день (YYYY-MM-DD)"
+// @Success      200   {object}  TotalsResponse
+// @Failure      400   {object}  response.ErrorBody
+// @Failure      401   {object}  response.ErrorBody
+// @Failure      500   {object}  response.ErrorBody
+// @Router       /api/v1/nutrition/totals [get]
+func (h *Handler) Totals(c *gin.Context) {
+	userID, ok := h.userID(c)
+	if !ok {
+		return
+	}
+	from, ok := queryDate(c, "from")
+	if !ok {
+		return
+	}
+	to, ok := queryDate(c, "to")
+	if !ok {
+		return
+	}
+
+	totals, err := h.nutrition.Totals(c.Request.Context(), userID, from, to)
+	if err != nil {
+		h.respondError(c, "food_totals", userID, err)
+		return
+	}
+	resp := TotalsResponse{
+		From:          totals.From.Format(dateLayout),
+		To:            totals.To.Format(dateLayout),
+		CalorieTarget: totals.CalorieTarget,
+		Items:         make([]DayTotalsResponse, 0, len(totals.Days)),
+	}
+	for _, day := range totals.Days {
+		resp.Items = append(resp.Items, toDayTotalsResponse(day))
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// userID извлекает ID текущего пользователя и отвечает 401, если его нет.
+func (h *Handler) userID(c *gin.Context) (uuid.UUID, bool) {
+	userID, err := middleware.UserIDFromContext(c)
+	if err != nil {
+		response.Error(c, http.StatusUnauthorized, "unauthorized", "Требуется аутентификация", nil)
+		return uuid.Nil, false
+	}
+	return userID, true
+}
+
+// respondError маппит ошибки usecase-слоя в HTTP-ответы.
+func (h *Handler) respondError(c *gin.Context, op string, userID uuid.UUID, err error) {
+	switch {
+	case errors.Is(err, nutritionuc.ErrInvalidMeal):
+		response.Error(c, http.StatusBadRequest, "invalid_meal", "Приём пищи должен быть breakfast, lunch, dinner или snack", nil)
+	case errors.Is(err, nutritionuc.ErrInvalidName):
+		response.Error(c, http.StatusBadRequest, "invalid_name", "Название должно содержать от 1 до 100 символов", nil)
+	case errors.Is(err, nutritionuc.ErrInvalidCalories):
+		response.Error(c, http.StatusBadRequest, "invalid_calories", "Калорийность должна быть от 0 до 10000 ккал", nil)
+	case errors.Is(err, nutritionuc.ErrInvalidMacros):
+		response.Error(c, http.StatusBadRequest, "invalid_macros", "Белки, жиры и углеводы должны быть от 0 до 1000 г", nil)
+	case errors.Is(err, nutritionuc.ErrInvalidDate):
+		response.Error(c, http.StatusBadRequest, "invalid_date", "Нельзя добавить запись в будущий день", nil)
+	case errors.Is(err, nutritionuc.ErrInvalidPeriod):
+		response.Error(c, http.StatusBadRequest, "invalid_range", "Некорректный период: from не позже to, не больше 92 дней", nil)
+	case errors.Is(err, nutritionuc.ErrEntryNotFound):
+		response.Error(c, http.StatusNotFound, "entry_not_found", "Запись дневника не найдена", nil)
+	default:
+		h.logger.Error("internal_error_in_"+op, map[string]any{
+			"user_id": userID.String(),
+			"path":    c.Request.URL.Path,
+			"method":  c.Request.Method,
+			"error":   err.Error(),
+		})
+		response.Error(c, http.StatusInternalServerError, "internal_error", "Внутренняя ошибка сервера", nil)
+	}
+}
+
+// bindEntry разбирает тело запроса с записью дневника. При ошибке ответ уже отправлен.
+func bindEntry(c *gin.Context) (nutritionuc.EntryInput, bool) {
+	var req EntryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid_request", "Некорректное тело запроса", err.Error())
+		return nutritionuc.EntryInput{}, false
+	}
+	input := nutritionuc.EntryInput{
+		Meal:     domain.Meal(req.Meal),
+		Name:     req.Name,
+		Calories: *req.Calories,
+		ProteinG: req.ProteinG,
+		CarbsG:   req.CarbsG,
+		FatG:     req.FatG,
+	}
+	if req.Date != "" {
+		date, err := time.Parse(dateLayout, req.Date)
+		if err != nil {
+			response.Error(c, http.StatusBadRequest, "invalid_request", "Дата должна быть в формате YYYY-MM-DD", nil)
+			return nutritionuc.EntryInput{}, false
+		}
+		input.Date = &date
+	}
+	return input, true
+}
+
+// parseEntryID разбирает ID записи из пути. При ошибке ответ уже отправлен.
+func parseEntryID(c *gin.Context) (uuid.UUID, bool) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid_request", "Некорректный ID записи", nil)
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+// queryDate разбирает день (YYYY-MM-DD) из параметра запроса; пустой параметр — nil.
+func queryDate(c *gin.Context, name string) (*time.Time, bool) {
+	raw := c.Query(name)
+	if raw == "" {
+		return nil, true
+	}
+	t, err := time.Parse(dateLayout, raw)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid_request", "Некорректный параметр "+name, nil)
+		return nil, false
+	}
+	return &t, true
+}
+
+func toEntryResponse(e *domain.Entry) EntryResponse {
+	return EntryResponse{
+		ID:        e.ID.String(),
+		Date:      e.Date.Format(dateLayout),
+		Meal:      string(e.Meal),
+		Name:      e.Name,
+		Calories:  e.Calories,
+		ProteinG:  e.ProteinG,
+		CarbsG:    e.CarbsG,
+		FatG:      e.FatG,
+		CreatedAt: e.CreatedAt,
+		UpdatedAt: e.UpdatedAt,
+	}
+}
+
+func toDayTotalsResponse(day nutritionuc.Day) DayTotalsResponse {
+	return DayTotalsResponse{
+		Date:              day.Date.Format(dateLayout),
+		Entries:           day.Entries,
+		Calories:          day.Calories,
+		ProteinG:          day.ProteinG,
+		CarbsG:            day.CarbsG,
+		FatG:              day.FatG,
+		RemainingCalories: day.Remaining,
+	}
+}
@@ -0,0 +1,25 @@
+package oauth
+
+// LoginRequest описывает тело запроса входа через провайдера.
+type LoginRequest struct {
+	// IDToken — ID-токен (JWT), полученный клиентом от Google или Apple.
+	IDToken string `json:"id_token" binding:"required"`
+	// Nonce — значение, переданное провайдеру при входе; если задано, сверяется с nonce в ID-токене.
+	Nonce string `json:"nonce,omitempty"`
+}
+
+// TokenPair описывает пару access/refresh токенов.
+type TokenPair struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+}
+
+// LoginResponse — ответ при успешном входе через провайдера; совпадает с ответом /auth/login
+// и дополнительно сообщает, создан ли пользователь.
+type LoginResponse struct {
+	UserID   string    `json:"user_id"`
+	Email    string    `json:"email"`
+	Username string    `json:"username"`
+	Created  bool      `json:"created"`
+	Tokens   TokenPair `json:"tokens"`
+}
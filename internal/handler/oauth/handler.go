@@ -0,0 +1,105 @@
+package oauth
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"workout-app/internal/handler/response"
+	"workout-app/internal/handler/validation"
+	oauthuc "workout-app/internal/usecase/oauth"
+	"workout-app/pkg/logger"
+)
+
+// Handler обрабатывает вход через внешних провайдеров (Google, Apple).
+type Handler struct {
+	oauth         oauthuc.Service
+	countryHeader string
+	logger        logger.Logger
+}
+
+// NewHandler создаёт новый OAuthHandler.
+// countryHeader — заголовок с кодом страны клиента для новых пользователей (как при регистрации).
+func NewHandler(oauthSvc oauthuc.Service, countryHeader string, logger logger.Logger) *Handler {
+	return &Handler{
+		oauth:         oauthSvc,
+		countryHeader: countryHeader,
+		logger:        logger,
+	}
+}
+
+// Login godoc
+// @Summary      Вход через Google или Apple
+// @Description  Проверяет ID-токен провайдера. Если аккаунт провайдера не привязан, привязывает его к пользователю с тем же email (email отмечается подтверждённым) или создаёт нового пользователя без пароля. Возвращает пару access/refresh токенов.
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        provider  path      string        true  "Провайдер: google или apple"
+// @Param        payload   body      LoginRequest  true  "ID-токен провайдера"
+// @Success      200       {object}  LoginResponse
+// @Failure      400       {object}  response.ErrorBody
+// @Failure      401       {object}  response.ErrorBody
+// @Failure      403       {object}  response.ErrorBody
+// @Failure      404       {object}  response.ErrorBody
+// @Failure      409       {object}  response.ErrorBody
+// @Failure      500       {object}  response.ErrorBody
+// @Failure      502       {object}  response.ErrorBody
+// @Router       /api/v1/auth/oauth/{provider} [post]
+func (h *Handler) Login(c *gin.Context) {
+	var req LoginRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid_request", "Invalid request body", validation.Details(c, err))
+		return
+	}
+
+	var country string
+	if h.countryHeader != "" {
+		country = c.GetHeader(h.countryHeader)
+	}
+
+	provider := c.Param("provider")
+	result, err := h.oauth.Login(c.Request.Context(), provider, req.IDToken, req.Nonce, country)
+	if err != nil {
+		switch {
+		case errors.Is(err, oauthuc.ErrProviderNotSupported):
+			response.Error(c, http.StatusNotFound, "provider_not_supported", "OAuth provider is not supported", nil)
+		case errors.Is(err, oauthuc.ErrInvalidIDToken):
+			response.Error(c, http.StatusUnauthorized, "invalid_id_token", "Invalid ID token", nil)
+		case errors.Is(err, oauthuc.ErrEmailNotVerified):
+			response.Error(c, http.StatusForbidden, "email_not_verified", "Provider did not confirm the email address", nil)
+		case errors.Is(err, oauthuc.ErrAccountSuspended):
+			response.Error(c, http.StatusForbidden, "account_suspended", "Account is suspended", nil)
+		case errors.Is(err, oauthuc.ErrAccountDeleted):
+			response.Error(c, http.StatusForbidden, "account_deleted", "Account is deleted", nil)
+		case errors.Is(err, oauthuc.ErrProviderLinked):
+			response.Error(c, http.StatusConflict, "provider_already_linked", "Another account of this provider is already linked to the user", nil)
+		case errors.Is(err, oauthuc.ErrProviderUnavailable):
+			h.logger.Warn("oauth_provider_unavailable", map[string]any{
+				"provider": provider,
+				"error":    err.Error(),
+			})
+			response.Error(c, http.StatusBadGateway, "provider_unavailable", "Could not verify the token with the provider", nil)
+		default:
+			h.logger.Error("internal_error_in_oauth_login", map[string]any{
+				"provider": provider,
+				"path":     c.Request.URL.Path,
+				"method":   c.Request.Method,
+				"error":    err.Error(),
+			})
+			response.Error(c, http.StatusInternalServerError, "internal_error", "Internal server error", nil)
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, LoginResponse{
+		UserID:   result.User.ID.String(),
+		Email:    result.User.Email,
+		Username: result.User.Username,
+		Created:  result.Created,
+		Tokens: TokenPair{
+			AccessToken:  result.AccessToken,
+			RefreshToken: result.RefreshToken,
+		},
+	})
+}
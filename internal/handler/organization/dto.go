@@ -0,0 +1,97 @@
+package organization
+
+import "time"
+
+// CreateOrganizationRequest описывает тело запроса создания организации.
+type CreateOrganizationRequest struct {
+	Name    string `json:"name" binding:"required"`
+	OwnerID string `json:"owner_id" binding:"required"`
+}
+
+// AddMemberRequest описывает тело запроса добавления участника.
+type AddMemberRequest struct {
+	UserID string `json:"user_id" binding:"required"`
+	// Role — owner, manager, staff или member (по умолчанию member).
+	Role string `json:"role,omitempty"`
+}
+
+// OrganizationResponse описывает организацию.
+type OrganizationResponse struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// MemberResponse описывает членство пользователя в организации.
+type MemberResponse struct {
+	OrganizationID string    `json:"organization_id"`
+	UserID         string    `json:"user_id"`
+	Role           string    `json:"role"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+// MembersResponse описывает страницу участников организации.
+type MembersResponse struct {
+	Items []MemberResponse `json:"items"`
+	Total int64            `json:"total"`
+}
+
+// InvitationRequest описывает тело запроса приглашения в команду организации.
+type InvitationRequest struct {
+	Email string `json:"email" binding:"required,email"`
+	// Role — manager или staff.
+	Role string `json:"role" binding:"required"`
+}
+
+// TransferOwnershipRequest описывает тело запроса передачи владения организацией.
+type TransferOwnershipRequest struct {
+	UserID string `json:"user_id" binding:"required"`
+}
+
+// InvitationResponse описывает приглашение в команду организации.
+type InvitationResponse struct {
+	ID             string `json:"id"`
+	OrganizationID string `json:"organization_id"`
+	// Organization заполняется в списке приглашений текущего пользователя.
+	Organization *OrganizationResponse `json:"organization,omitempty"`
+	UserID       string                `json:"user_id"`
+	Role         string                `json:"role"`
+	InvitedBy    string                `json:"invited_by"`
+	CreatedAt    time.Time             `json:"created_at"`
+	ExpiresAt    time.Time             `json:"expires_at"`
+}
+
+// MembershipResponse описывает организацию текущего пользователя и его роль в ней.
+type MembershipResponse struct {
+	Organization OrganizationResponse `json:"organization"`
+	Role         string               `json:"role"`
+	JoinedAt     time.Time            `json:"joined_at"`
+}
+
+// EmailSettingsRequest описывает тело запроса изменения настроек отправки писем организации.
+type EmailSettingsRequest struct {
+	FromEmail    string `json:"from_email" binding:"required"`
+	FromName     string `json:"from_name,omitempty"`
+	Provider     string `json:"provider" binding:"required"`
+	SMTPHost     string `json:"smtp_host" binding:"required"`
+	SMTPPort     int    `json:"smtp_port" binding:"required"`
+	SMTPUsername string `json:"smtp_username" binding:"required"`
+	// SMTPPassword обязателен при первой настройке; при изменении пустое значение оставляет прежний пароль.
+	SMTPPassword string `json:"smtp_password,omitempty"`
+	// RateLimitPerHour — лимит писем в час (0 — лимит платформы по умолчанию).
+	RateLimitPerHour int `json:"rate_limit_per_hour,omitempty"`
+}
+
+// EmailSettingsResponse описывает настройки отправки писем организации. Пароль не возвращается.
+type EmailSettingsResponse struct {
+	FromEmail        string    `json:"from_email"`
+	FromName         string    `json:"from_name"`
+	Provider         string    `json:"provider"`
+	SMTPHost         string    `json:"smtp_host"`
+	SMTPPort         int       `json:"smtp_port"`
+	SMTPUsername     string    `json:"smtp_username"`
+	HasPassword      bool      `json:"has_password"`
+	RateLimitPerHour int       `json:"rate_limit_per_hour"`
+	UpdatedBy        string    `json:"updated_by"`
+	UpdatedAt        time.Time `json:"updated_at"`
+}
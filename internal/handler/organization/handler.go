@@ -0,0 +1,664 @@
+package organization
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	domain "workout-app/internal/domain/organization"
+	"workout-app/internal/handler/middleware"
+	"workout-app/internal/handler/response"
+	organizationuc "workout-app/internal/usecase/organization"
+	"workout-app/internal/usecase/tenantemail"
+	"workout-app/pkg/logger"
+)
+
+// Handler обрабатывает HTTP-запросы организаций (залов) и их настроек.
+type Handler struct {
+	orgs   organizationuc.Service
+	emails tenantemail.Service
+	logger logger.Logger
+}
+
+// NewHandler создаёт новый OrganizationHandler.
+func NewHandler(orgs organizationuc.Service, emails tenantemail.Service, logger logger.Logger) *Handler {
+	return &Handler{
+		orgs:   orgs,
+		emails: emails,
+		logger: logger,
+	}
+}
+
+// Create godoc
+// @Summary      Создать организацию (админ)
+// @Description  Создаёт организацию (зал) и делает указанного пользователя её владельцем.
+// @Tags         organizations
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        payload  body      CreateOrganizationRequest  true  "Название и владелец"
+// @Success      201      {object}  OrganizationResponse
+// @Failure      400      {object}  response.ErrorBody
+// @Failure      401      {object}  response.ErrorBody
+// @Failure      403      {object}  response.ErrorBody
+// @Failure      404      {object}  response.ErrorBody
+// @Failure      500      {object}  response.ErrorBody
+// @Router       /api/v1/admin/organizations [post]
+func (h *Handler) Create(c *gin.Context) {
+	var req CreateOrganizationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid_request", "Некорректное тело запроса", err.Error())
+		return
+	}
+	ownerID, err := uuid.Parse(req.OwnerID)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid_user_id", "Некорректный ID пользователя", nil)
+		return
+	}
+
+	org, err := h.orgs.Create(c.Request.Context(), req.Name, ownerID)
+	if err != nil {
+		h.respondError(c, "create_organization", err)
+		return
+	}
+
+	h.logger.Info("organization_created", map[string]any{
+		"organization_id": org.ID.String(),
+		"owner_id":        ownerID.String(),
+		"actor_id":        c.GetString(middleware.ContextUserIDKey),
+	})
+	c.JSON(http.StatusCreated, toOrganizationResponse(org))
+}
+
+// AddMember godoc
+// @Summary      Добавить участника организации (админ)
+// @Tags         organizations
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        id       path      string            true  "ID организации"
+// @Param        payload  body      AddMemberRequest  true  "Пользователь и роль"
+// @Success      201      {object}  MemberResponse
+// @Failure      400      {object}  response.ErrorBody
+// @Failure      401      {object}  response.ErrorBody
+// @Failure      403      {object}  response.ErrorBody
+// @Failure      404      {object}  response.ErrorBody
+// @Failure      409      {object}  response.ErrorBody
+// @Failure      500      {object}  response.ErrorBody
+// @Router       /api/v1/admin/organizations/{id}/members [post]
+func (h *Handler) AddMember(c *gin.Context) {
+	orgID, ok := parseOrganizationID(c)
+	if !ok {
+		return
+	}
+
+	var req AddMemberRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid_request", "Некорректное тело запроса", err.Error())
+		return
+	}
+	userID, err := uuid.Parse(req.UserID)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid_user_id", "Некорректный ID пользователя", nil)
+		return
+	}
+	role := domain.Role(req.Role)
+	if role == "" {
+		role = domain.RoleMember
+	}
+
+	m, err := h.orgs.AddMember(c.Request.Context(), orgID, userID, role)
+	if err != nil {
+		h.respondError(c, "add_organization_member", err)
+		return
+	}
+	c.JSON(http.StatusCreated, toMemberResponse(m))
+}
+
+// RemoveMember godoc
+// @Summary      Исключить участника организации (админ)
+// @Description  Владельца организации исключить нельзя.
+// @Tags         organizations
+// @Security     BearerAuth
+// @Param        id      path  string  true  "ID организации"
+// @Param        userId  path  string  true  "ID пользователя"
+// @Success      204
+// @Failure      400  {object}  response.ErrorBody
+// @Failure      401  {object}  response.ErrorBody
+// @Failure      403  {object}  response.ErrorBody
+// @Failure      404  {object}  response.ErrorBody
+// @Failure      409  {object}  response.ErrorBody
+// @Failure      500  {object}  response.ErrorBody
+// @Router       /api/v1/admin/organizations/{id}/members/{userId} [delete]
+func (h *Handler) RemoveMember(c *gin.Context) {
+	orgID, ok := parseOrganizationID(c)
+	if !ok {
+		return
+	}
+	userID, err := uuid.Parse(c.Param("userId"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid_user_id", "Некорректный ID пользователя", nil)
+		return
+	}
+
+	if err := h.orgs.RemoveMember(c.Request.Context(), orgID, userID); err != nil {
+		h.respondError(c, "remove_organization_member", err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// ListMine godoc
+// @Summary      Мои организации
+// @Description  Возвращает организации текущего пользователя и его роль в каждой.
+// @Tags         organizations
+// @Security     BearerAuth
+// @Produce      json
+// @Success      200  {array}   MembershipResponse
+// @Failure      401  {object}  response.ErrorBody
+// @Failure      500  {object}  response.ErrorBody
+// @Router       /api/v1/users/me/organizations [get]
+func (h *Handler) ListMine(c *gin.Context) {
+	userID, err := middleware.UserIDFromContext(c)
+	if err != nil {
+		response.Error(c, http.StatusUnauthorized, "unauthorized", "Требуется аутентификация", nil)
+		return
+	}
+
+	memberships, err := h.orgs.ListForUser(c.Request.Context(), userID)
+	if err != nil {
+		h.respondError(c, "list_my_organizations", err)
+		return
+	}
+
+	resp := make([]MembershipResponse, 0, len(memberships))
+	for _, m := range memberships {
+		resp = append(resp, MembershipResponse{
+			Organization: toOrganizationResponse(m.Organization),
+			Role:         string(m.Role),
+			JoinedAt:     m.JoinedAt,
+		})
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// GetEmailSettings godoc
+// @Summary      Настройки отправки писем организации
+// @Description  Доступно владельцу организации и администраторам. Пароль SMTP не возвращается.
+// @Tags         organizations
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id   path      string  true  "ID организации"
+// @Success      200  {object}  EmailSettingsResponse
+// @Failure      401  {object}  response.ErrorBody
+// @Failure      403  {object}  response.ErrorBody
+// @Failure      404  {object}  response.ErrorBody
+// @Failure      503  {object}  response.ErrorBody
+// @Failure      500  {object}  response.ErrorBody
+// @Router       /api/v1/organizations/{id}/email-settings [get]
+func (h *Handler) GetEmailSettings(c *gin.Context) {
+	orgID, ok := parseOrganizationID(c)
+	if !ok {
+		return
+	}
+
+	settings, err := h.emails.Get(c.Request.Context(), orgID)
+	if err != nil {
+		h.respondError(c, "get_organization_email_settings", err)
+		return
+	}
+	c.JSON(http.StatusOK, toEmailSettingsResponse(settings))
+}
+
+// SetEmailSettings godoc
+// @Summary      Изменить настройки отправки писем организации
+// @Description  Письма участникам организации будут отправляться с её адреса через её SMTP-сервер с лимитом в час. Доступно владельцу организации и администраторам. Пароль хранится зашифрованным.
+// @Tags         organizations
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        id       path      string                true  "ID организации"
+// @Param        payload  body      EmailSettingsRequest  true  "Отправитель и провайдер"
+// @Success      200      {object}  EmailSettingsResponse
+// @Failure      400      {object}  response.ErrorBody
+// @Failure      401      {object}  response.ErrorBody
+// @Failure      403      {object}  response.ErrorBody
+// @Failure      404      {object}  response.ErrorBody
+// @Failure      503      {object}  response.ErrorBody
+// @Failure      500      {object}  response.ErrorBody
+// @Router       /api/v1/organizations/{id}/email-settings [put]
+func (h *Handler) SetEmailSettings(c *gin.Context) {
+	orgID, ok := parseOrganizationID(c)
+	if !ok {
+		return
+	}
+	actorID, err := middleware.UserIDFromContext(c)
+	if err != nil {
+		response.Error(c, http.StatusUnauthorized, "unauthorized", "Требуется аутентификация", nil)
+		return
+	}
+
+	var req EmailSettingsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid_request", "Некорректное тело запроса", err.Error())
+		return
+	}
+
+	settings, err := h.emails.Set(c.Request.Context(), actorID, orgID, tenantemail.SettingsInput{
+		FromEmail:        req.FromEmail,
+		FromName:         req.FromName,
+		Provider:         domain.EmailProvider(req.Provider),
+		SMTPHost:         req.SMTPHost,
+		SMTPPort:         req.SMTPPort,
+		SMTPUsername:     req.SMTPUsername,
+		SMTPPassword:     req.SMTPPassword,
+		RateLimitPerHour: req.RateLimitPerHour,
+	})
+	if err != nil {
+		h.respondError(c, "set_organization_email_settings", err)
+		return
+	}
+
+	h.logger.Info("organization_email_settings_updated", map[string]any{
+		"organization_id": orgID.String(),
+		"actor_id":        actorID.String(),
+		"from_email":      settings.FromEmail,
+		"smtp_host":       settings.SMTPHost,
+	})
+	c.JSON(http.StatusOK, toEmailSettingsResponse(settings))
+}
+
+// DeleteEmailSettings godoc
+// @Summary      Сбросить настройки отправки писем организации
+// @Description  Возвращает организацию к платформенной отправке писем. Доступно владельцу организации и администраторам.
+// @Tags         organizations
+// @Security     BearerAuth
+// @Param        id  path  string  true  "ID организации"
+// @Success      204
+// @Failure      401  {object}  response.ErrorBody
+// @Failure      403  {object}  response.ErrorBody
+// @Failure      404  {object}  response.ErrorBody
+// @Failure      503  {object}  response.ErrorBody
+// @Failure      500  {object}  response.ErrorBody
+// @Router       /api/v1/organizations/{id}/email-settings [delete]
+func (h *Handler) DeleteEmailSettings(c *gin.Context) {
+	orgID, ok := parseOrganizationID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.emails.Delete(c.Request.Context(), orgID); err != nil {
+		h.respondError(c, "delete_organization_email_settings", err)
+		return
+	}
+
+	h.logger.Info("organization_email_settings_deleted", map[string]any{
+		"organization_id": orgID.String(),
+		"actor_id":        c.GetString(middleware.ContextUserIDKey),
+	})
+	c.Status(http.StatusNoContent)
+}
+
+// ListMembers godoc
+// @Summary      Участники организации
+// @Description  Страница участников организации: сначала команда (владелец, менеджеры, сотрудники), затем участники, внутри роли — в порядке вступления. Доступно команде организации и администраторам.
+// @Tags         organizations
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id      path      string  true   "ID организации"
+// @Param        limit   query     int     false  "Размер страницы (по умолчанию 50, максимум 100)"
+// @Param        offset  query     int     false  "Смещение"
+// @Success      200     {object}  MembersResponse
+// @Failure      400     {object}  response.ErrorBody
+// @Failure      401     {object}  response.ErrorBody
+// @Failure      403     {object}  response.ErrorBody
+// @Failure      404     {object}  response.ErrorBody
+// @Failure      500     {object}  response.ErrorBody
+// @Router       /api/v1/organizations/{id}/members [get]
+func (h *Handler) ListMembers(c *gin.Context) {
+	orgID, ok := parseOrganizationID(c)
+	if !ok {
+		return
+	}
+	limit, err1 := queryInt(c, "limit")
+	offset, err2 := queryInt(c, "offset")
+	if err1 != nil || err2 != nil {
+		response.Error(c, http.StatusBadRequest, "invalid_pagination", "Параметры limit и offset должны быть неотрицательными числами", nil)
+		return
+	}
+
+	page, err := h.orgs.ListMembers(c.Request.Context(), orgID, limit, offset)
+	if err != nil {
+		h.respondError(c, "list_organization_members", err)
+		return
+	}
+
+	items := make([]MemberResponse, 0, len(page.Items))
+	for _, m := range page.Items {
+		items = append(items, toMemberResponse(m))
+	}
+	c.JSON(http.StatusOK, MembersResponse{Items: items, Total: page.Total})
+}
+
+// Invite godoc
+// @Summary      Пригласить в команду организации
+// @Description  Приглашает зарегистрированного пользователя по email в команду с ролью manager или staff. Владелец приглашает менеджеров и сотрудников, менеджер — только сотрудников. Приглашение действует 7 дней; пользователь принимает его сам.
+// @Tags         organizations
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        id       path      string             true  "ID организации"
+// @Param        payload  body      InvitationRequest  true  "Email и роль"
+// @Success      201      {object}  InvitationResponse
+// @Failure      400      {object}  response.ErrorBody
+// @Failure      401      {object}  response.ErrorBody
+// @Failure      403      {object}  response.ErrorBody
+// @Failure      404      {object}  response.ErrorBody
+// @Failure      409      {object}  response.ErrorBody
+// @Failure      500      {object}  response.ErrorBody
+// @Router       /api/v1/organizations/{id}/invitations [post]
+func (h *Handler) Invite(c *gin.Context) {
+	orgID, ok := parseOrganizationID(c)
+	if !ok {
+		return
+	}
+	actorID, err := middleware.UserIDFromContext(c)
+	if err != nil {
+		response.Error(c, http.StatusUnauthorized, "unauthorized", "Требуется аутентификация", nil)
+		return
+	}
+
+	var req InvitationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid_request", "Некорректное тело запроса", err.Error())
+		return
+	}
+
+	inv, err := h.orgs.Invite(c.Request.Context(), orgID, actorID, middleware.OrgRoleFromContext(c), req.Email, domain.Role(req.Role))
+	if err != nil {
+		h.respondError(c, "invite_organization_member", err)
+		return
+	}
+
+	h.logger.Info("organization_member_invited", map[string]any{
+		"organization_id": orgID.String(),
+		"invitation_id":   inv.ID.String(),
+		"user_id":         inv.UserID.String(),
+		"role":            string(inv.Role),
+		"actor_id":        actorID.String(),
+	})
+	c.JSON(http.StatusCreated, toInvitationResponse(inv))
+}
+
+// TransferOwnership godoc
+// @Summary      Передать владение организацией
+// @Description  Делает участника организации владельцем; прежний владелец становится менеджером. Доступно владельцу организации и администраторам.
+// @Tags         organizations
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        id       path      string                    true  "ID организации"
+// @Param        payload  body      TransferOwnershipRequest  true  "Новый владелец"
+// @Success      200      {object}  MemberResponse
+// @Failure      400      {object}  response.ErrorBody
+// @Failure      401      {object}  response.ErrorBody
+// @Failure      403      {object}  response.ErrorBody
+// @Failure      404      {object}  response.ErrorBody
+// @Failure      409      {object}  response.ErrorBody
+// @Failure      500      {object}  response.ErrorBody
+// @Router       /api/v1/organizations/{id}/transfer-ownership [post]
+func (h *Handler) TransferOwnership(c *gin.Context) {
+	orgID, ok := parseOrganizationID(c)
+	if !ok {
+		return
+	}
+
+	var req TransferOwnershipRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid_request", "Некорректное тело запроса", err.Error())
+		return
+	}
+	newOwnerID, err := uuid.Parse(req.UserID)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid_user_id", "Некорректный ID пользователя", nil)
+		return
+	}
+
+	m, err := h.orgs.TransferOwnership(c.Request.Context(), orgID, newOwnerID)
+	if err != nil {
+		h.respondError(c, "transfer_organization_ownership", err)
+		return
+	}
+
+	h.logger.Info("organization_ownership_transferred", map[string]any{
+		"organization_id": orgID.String(),
+		"owner_id":        newOwnerID.String(),
+		"actor_id":        c.GetString(middleware.ContextUserIDKey),
+	})
+	c.JSON(http.StatusOK, toMemberResponse(m))
+}
+
+// ListMyInvitations godoc
+// @Summary      Мои приглашения в организации
+// @Description  Действующие приглашения текущего пользователя в команды организаций, новые первыми.
+// @Tags         organizations
+// @Security     BearerAuth
+// @Produce      json
+// @Success      200  {array}   InvitationResponse
+// @Failure      401  {object}  response.ErrorBody
+// @Failure      500  {object}  response.ErrorBody
+// @Router       /api/v1/users/me/organization-invitations [get]
+func (h *Handler) ListMyInvitations(c *gin.Context) {
+	userID, err := middleware.UserIDFromContext(c)
+	if err != nil {
+		response.Error(c, http.StatusUnauthorized, "unauthorized", "Требуется аутентификация", nil)
+		return
+	}
+
+	invitations, err := h.orgs.ListInvitations(c.Request.Context(), userID)
+	if err != nil {
+		h.respondError(c, "list_my_organization_invitations", err)
+		return
+	}
+
+	resp := make([]InvitationResponse, 0, len(invitations))
+	for _, inv := range invitations {
+		item := toInvitationResponse(inv.Invitation)
+		org := toOrganizationResponse(inv.Organization)
+		item.Organization = &org
+		resp = append(resp, item)
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// AcceptInvitation godoc
+// @Summary      Принять приглашение в организацию
+// @Description  Текущий пользователь вступает в организацию с ролью из приглашения; участник организации получает эту роль.
+// @Tags         organizations
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id   path      string  true  "ID приглашения"
+// @Success      200  {object}  MemberResponse
+// @Failure      400  {object}  response.ErrorBody
+// @Failure      401  {object}  response.ErrorBody
+// @Failure      404  {object}  response.ErrorBody
+// @Failure      409  {object}  response.ErrorBody
+// @Failure      500  {object}  response.ErrorBody
+// @Router       /api/v1/users/me/organization-invitations/{id}/accept [post]
+func (h *Handler) AcceptInvitation(c *gin.Context) {
+	userID, invitationID, ok := invitationParams(c)
+	if !ok {
+		return
+	}
+
+	m, err := h.orgs.AcceptInvitation(c.Request.Context(), userID, invitationID)
+	if err != nil {
+		h.respondError(c, "accept_organization_invitation", err)
+		return
+	}
+
+	h.logger.Info("organization_invitation_accepted", map[string]any{
+		"organization_id": m.OrganizationID.String(),
+		"invitation_id":   invitationID.String(),
+		"user_id":         userID.String(),
+		"role":            string(m.Role),
+	})
+	c.JSON(http.StatusOK, toMemberResponse(m))
+}
+
+// DeclineInvitation godoc
+// @Summary      Отклонить приглашение в организацию
+// @Tags         organizations
+// @Security     BearerAuth
+// @Param        id  path  string  true  "ID приглашения"
+// @Success      204
+// @Failure      400  {object}  response.ErrorBody
+// @Failure      401  {object}  response.ErrorBody
+// @Failure      404  {object}  response.ErrorBody
+// @Failure      500  {object}  response.ErrorBody
+// @Router       /api/v1/users/me/organization-invitations/{id} [delete]
+func (h *Handler) DeclineInvitation(c *gin.Context) {
+	userID, invitationID, ok := invitationParams(c)
+	if !ok {
+		return
+	}
+
+	if err := h.orgs.DeclineInvitation(c.Request.Context(), userID, invitationID); err != nil {
+		h.respondError(c, "decline_organization_invitation", err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// respondError отправляет ответ об ошибке для эндпоинтов организаций.
+func (h *Handler) respondError(c *gin.Context, op string, err error) {
+	switch {
+	case errors.Is(err, organizationuc.ErrInvalidName):
+		response.Error(c, http.StatusBadRequest, "invalid_name", "Название организации должно быть от 1 до 200 символов", nil)
+	case errors.Is(err, organizationuc.ErrInvalidRole):
+		response.Error(c, http.StatusBadRequest, "invalid_role", "Роль должна быть owner, manager, staff или member", nil)
+	case errors.Is(err, organizationuc.ErrInvalidInviteRole):
+		response.Error(c, http.StatusBadRequest, "invalid_role", "В команду приглашают с ролью manager или staff", nil)
+	case errors.Is(err, organizationuc.ErrOrganizationNotFound):
+		response.Error(c, http.StatusNotFound, "organization_not_found", "Организация не найдена", nil)
+	case errors.Is(err, organizationuc.ErrUserNotFound):
+		response.Error(c, http.StatusNotFound, "user_not_found", "Пользователь не найден", nil)
+	case errors.Is(err, organizationuc.ErrMemberNotFound):
+		response.Error(c, http.StatusNotFound, "member_not_found", "Пользователь не состоит в организации", nil)
+	case errors.Is(err, organizationuc.ErrAlreadyMember):
+		response.Error(c, http.StatusConflict, "already_member", "Пользователь уже состоит в организации или её команде", nil)
+	case errors.Is(err, organizationuc.ErrCannotRemoveOwner):
+		response.Error(c, http.StatusConflict, "cannot_remove_owner", "Владельца организации нельзя исключить", nil)
+	case errors.Is(err, organizationuc.ErrAlreadyInvited):
+		response.Error(c, http.StatusConflict, "already_invited", "Пользователь уже приглашён в организацию", nil)
+	case errors.Is(err, organizationuc.ErrAlreadyOwner):
+		response.Error(c, http.StatusConflict, "already_owner", "Пользователь уже владелец организации", nil)
+	case errors.Is(err, organizationuc.ErrInvitationNotFound):
+		response.Error(c, http.StatusNotFound, "invitation_not_found", "Приглашение не найдено или истекло", nil)
+	case errors.Is(err, organizationuc.ErrForbidden):
+		response.Error(c, http.StatusForbidden, "forbidden", "Недостаточно прав в организации", nil)
+	case errors.Is(err, tenantemail.ErrDisabled):
+		response.Error(c, http.StatusServiceUnavailable, "tenant_email_disabled", "Собственные настройки отправки писем отключены", nil)
+	case errors.Is(err, tenantemail.ErrNotConfigured):
+		response.Error(c, http.StatusNotFound, "email_settings_not_found", "Организация использует платформенную отправку писем", nil)
+	case errors.Is(err, tenantemail.ErrInvalidFromEmail):
+		response.Error(c, http.StatusBadRequest, "invalid_from_email", "Некорректный адрес отправителя", nil)
+	case errors.Is(err, tenantemail.ErrInvalidFromName):
+		response.Error(c, http.StatusBadRequest, "invalid_from_name", "Имя отправителя должно быть не длиннее 100 символов и в одну строку", nil)
+	case errors.Is(err, tenantemail.ErrInvalidProvider):
+		response.Error(c, http.StatusBadRequest, "invalid_provider", "Поддерживается только провайдер smtp", nil)
+	case errors.Is(err, tenantemail.ErrInvalidSMTP):
+		response.Error(c, http.StatusBadRequest, "invalid_smtp_settings", "Некорректные хост, порт или пользователь SMTP", nil)
+	case errors.Is(err, tenantemail.ErrPasswordRequired):
+		response.Error(c, http.StatusBadRequest, "smtp_password_required", "Укажите пароль SMTP", nil)
+	case errors.Is(err, tenantemail.ErrInvalidRateLimit):
+		response.Error(c, http.StatusBadRequest, "invalid_rate_limit", "Лимит писем в час превышает допустимый", nil)
+	default:
+		h.logger.Error("internal_error_in_"+op, map[string]any{
+			"path":   c.Request.URL.Path,
+			"method": c.Request.Method,
+			"error":  err.Error(),
+		})
+		response.Error(c, http.StatusInternalServerError, "internal_error", "Внутренняя ошибка сервера", nil)
+	}
+}
+
+// parseOrganizationID разбирает ID организации из пути. При ошибке ответ уже отправлен.
+func parseOrganizationID(c *gin.Context) (uuid.UUID, bool) {
+	orgID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid_organization_id", "Некорректный ID организации", nil)
+		return uuid.Nil, false
+	}
+	return orgID, true
+}
+
+// invitationParams извлекает ID текущего пользователя и приглашения. При ошибке ответ уже отправлен.
+func invitationParams(c *gin.Context) (uuid.UUID, uuid.UUID, bool) {
+	userID, err := middleware.UserIDFromContext(c)
+	if err != nil {
+		response.Error(c, http.StatusUnauthorized, "unauthorized", "Требуется аутентификация", nil)
+		return uuid.Nil, uuid.Nil, false
+	}
+	invitationID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid_invitation_id", "Некорректный ID приглашения", nil)
+		return uuid.Nil, uuid.Nil, false
+	}
+	return userID, invitationID, true
+}
+
+func queryInt(c *gin.Context, name string) (int, error) {
+	raw := c.Query(name)
+	if raw == "" {
+		return 0, nil
+	}
+	n, err := strconv.Atoi(raw)
+	if err != nil || n < 0 {
+		return 0, strconv.ErrSyntax
+	}
+	return n, nil
+}
+
+func toMemberResponse(m *domain.Member) MemberResponse {
+	return MemberResponse{
+		OrganizationID: m.OrganizationID.String(),
+		UserID:         m.UserID.String(),
+		Role:           string(m.Role),
+		CreatedAt:      m.CreatedAt,
+	}
+}
+
+func toInvitationResponse(inv *domain.Invitation) InvitationResponse {
+	return InvitationResponse{
+		ID:             inv.ID.String(),
+		OrganizationID: inv.OrganizationID.String(),
+		UserID:         inv.UserID.String(),
+		Role:           string(inv.Role),
+		InvitedBy:      inv.InvitedBy.String(),
+		CreatedAt:      inv.CreatedAt,
+		ExpiresAt:      inv.ExpiresAt,
+	}
+}
+
+func toOrganizationResponse(org *domain.Organization) OrganizationResponse {
+	return OrganizationResponse{ID: org.ID.String(), Name: org.Name, CreatedAt: org.CreatedAt}
+}
+
+func toEmailSettingsResponse(s *domain.EmailSettings) EmailSettingsResponse {
+	return EmailSettingsResponse{
+		FromEmail:        s.FromEmail,
+		FromName:         s.FromName,
+		Provider:         string(s.Provider),
+		SMTPHost:         s.SMTPHost,
+		SMTPPort:         s.SMTPPort,
+		SMTPUsername:     s.SMTPUsername,
+		HasPassword:      s.SMTPPassword != "",
+		RateLimitPerHour: s.RateLimitPerHour,
+		UpdatedBy:        s.UpdatedBy.String(),
+		UpdatedAt:        s.UpdatedAt,
+	}
+}
@@ -0,0 +1,31 @@
+package playground
+
+// UserResponse описывает тестового пользователя песочницы.
+type UserResponse struct {
+	UserID   string `json:"user_id"`
+	Email    string `json:"email"`
+	Username string `json:"username"`
+	Role     string `json:"role"`
+}
+
+// UsersResponse описывает список тестовых пользователей.
+type UsersResponse struct {
+	Users []UserResponse `json:"users"`
+}
+
+// TokenRequest описывает тело запроса выпуска токенов.
+type TokenRequest struct {
+	Role string `json:"role" binding:"required,oneof=user coach admin"`
+}
+
+// TokenPair описывает пару access/refresh токенов.
+type TokenPair struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+}
+
+// TokenResponse описывает ответ выпуска токенов: тестового пользователя и его токены.
+type TokenResponse struct {
+	UserResponse
+	Tokens TokenPair `json:"tokens"`
+}
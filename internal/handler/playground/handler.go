@@ -0,0 +1,85 @@
+package playground
+
+import (
+	_ "embed"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	domain "workout-app/internal/domain/user"
+	"workout-app/internal/handler/response"
+	"workout-app/internal/handler/validation"
+	playgrounduc "workout-app/internal/usecase/playground"
+)
+
+// page — страница песочницы: выбор тестового пользователя и форма запроса к API с подставленным токеном.
+//
+//go:embed playground.html
+var page []byte
+
+// Handler обрабатывает HTTP-запросы песочницы API. Маршруты регистрируются только вне production.
+type Handler struct {
+	playground playgrounduc.Service
+}
+
+// NewHandler создаёт новый обработчик песочницы.
+func NewHandler(playground playgrounduc.Service) *Handler {
+	return &Handler{playground: playground}
+}
+
+// Page отдаёт HTML-страницу песочницы.
+func (h *Handler) Page(c *gin.Context) {
+	c.Header("Cache-Control", "no-store")
+	c.Data(http.StatusOK, "text/html; charset=utf-8", page)
+}
+
+// Users возвращает тестовых пользователей.
+func (h *Handler) Users(c *gin.Context) {
+	users, err := h.playground.Users(c.Request.Context())
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, "internal_error", "Failed to list playground users", nil)
+		return
+	}
+
+	resp := UsersResponse{Users: make([]UserResponse, 0, len(users))}
+	for _, u := range users {
+		resp.Users = append(resp.Users, toUserResponse(u))
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// Token выпускает пару токенов тестовому пользователю с указанной ролью.
+func (h *Handler) Token(c *gin.Context) {
+	var req TokenRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid_request", "Invalid request body", validation.Details(c, err))
+		return
+	}
+
+	user, access, refresh, err := h.playground.IssueTokens(c.Request.Context(), domain.Role(req.Role))
+	if err != nil {
+		switch {
+		case errors.Is(err, playgrounduc.ErrUnknownRole), errors.Is(err, playgrounduc.ErrNotSeeded):
+			response.Error(c, http.StatusNotFound, "playground_user_not_found", "Playground user not found", nil)
+		default:
+			response.Error(c, http.StatusInternalServerError, "internal_error", "Failed to issue tokens", nil)
+		}
+		return
+	}
+
+	c.Header("Cache-Control", "no-store")
+	c.JSON(http.StatusOK, TokenResponse{
+		UserResponse: toUserResponse(user),
+		Tokens:       TokenPair{AccessToken: access, RefreshToken: refresh},
+	})
+}
+
+func toUserResponse(u *domain.User) UserResponse {
+	return UserResponse{
+		UserID:   u.ID.String(),
+		Email:    u.Email,
+		Username: u.Username,
+		Role:     string(u.Role),
+	}
+}
@@ -0,0 +1,24 @@
+package presence
+
+import "time"
+
+// HeartbeatRequest описывает тело heartbeat активной тренировки. Тело опционально.
+type HeartbeatRequest struct {
+	SessionID string `json:"session_id,omitempty" binding:"max=64"`
+}
+
+// PresenceResponse описывает присутствие пользователя.
+type PresenceResponse struct {
+	Status     string     `json:"status"`
+	SessionID  string     `json:"session_id,omitempty"`
+	Since      *time.Time `json:"since,omitempty"`
+	LastSeenAt *time.Time `json:"last_seen_at,omitempty"`
+}
+
+// ClientPresenceResponse описывает присутствие клиента тренера.
+type ClientPresenceResponse struct {
+	UserID   string   `json:"user_id"`
+	Username string   `json:"username"`
+	Tags     []string `json:"tags"` // Теги тренера
+	PresenceResponse
+}
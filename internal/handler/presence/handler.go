@@ -0,0 +1,177 @@
+package presence
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"workout-app/internal/handler/middleware"
+	"workout-app/internal/handler/response"
+	presenceuc "workout-app/internal/usecase/presence"
+	"workout-app/pkg/logger"
+	"workout-app/pkg/presence"
+)
+
+// Handler обрабатывает HTTP-запросы, связанные с присутствием пользователей.
+type Handler struct {
+	presence presenceuc.Service
+	logger   logger.Logger
+}
+
+// NewHandler создаёт новый PresenceHandler.
+func NewHandler(presence presenceuc.Service, logger logger.Logger) *Handler {
+	return &Handler{
+		presence: presence,
+		logger:   logger,
+	}
+}
+
+// Heartbeat godoc
+// @Summary      Heartbeat активной тренировки
+// @Description  Отмечает, что пользователь сейчас тренируется. Клиент должен отправлять heartbeat чаще, чем истекает присутствие (TTL); без heartbeat пользователь становится offline.
+// @Tags         presence
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        payload  body      HeartbeatRequest  false  "Идентификатор сессии тренировки"
+// @Success      200      {object}  PresenceResponse
+// @Failure      400      {object}  response.ErrorBody
+// @Failure      401      {object}  response.ErrorBody
+// @Failure      500      {object}  response.ErrorBody
+// @Router       /api/v1/users/me/presence/heartbeat [post]
+func (h *Handler) Heartbeat(c *gin.Context) {
+	userID, err := middleware.UserIDFromContext(c)
+	if err != nil {
+		response.Error(c, http.StatusUnauthorized, "unauthorized", "Требуется аутентификация", nil)
+		return
+	}
+
+	var req HeartbeatRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			response.Error(c, http.StatusBadRequest, "invalid_request", "Некорректное тело запроса", err.Error())
+			return
+		}
+	}
+
+	state, err := h.presence.Heartbeat(c.Request.Context(), userID, req.SessionID)
+	if err != nil {
+		if errors.Is(err, presenceuc.ErrInvalidSessionID) {
+			response.Error(c, http.StatusBadRequest, "invalid_session_id", "Некорректный идентификатор сессии", nil)
+			return
+		}
+		h.logger.Error("internal_error_in_presence_heartbeat", map[string]any{
+			"user_id": userID.String(),
+			"path":    c.Request.URL.Path,
+			"method":  c.Request.Method,
+			"error":   err.Error(),
+		})
+		response.Error(c, http.StatusInternalServerError, "internal_error", "Внутренняя ошибка сервера", nil)
+		return
+	}
+
+	c.JSON(http.StatusOK, toPresenceResponse(state.Status, state))
+}
+
+// Stop godoc
+// @Summary      Завершить присутствие
+// @Description  Сбрасывает статус «тренируется» текущего пользователя, не дожидаясь истечения TTL.
+// @Tags         presence
+// @Security     BearerAuth
+// @Success      204
+// @Failure      401  {object}  response.ErrorBody
+// @Failure      500  {object}  response.ErrorBody
+// @Router       /api/v1/users/me/presence [delete]
+func (h *Handler) Stop(c *gin.Context) {
+	userID, err := middleware.UserIDFromContext(c)
+	if err != nil {
+		response.Error(c, http.StatusUnauthorized, "unauthorized", "Требуется аутентификация", nil)
+		return
+	}
+
+	if err := h.presence.Stop(c.Request.Context(), userID); err != nil {
+		h.logger.Error("internal_error_in_presence_stop", map[string]any{
+			"user_id": userID.String(),
+			"path":    c.Request.URL.Path,
+			"method":  c.Request.Method,
+			"error":   err.Error(),
+		})
+		response.Error(c, http.StatusInternalServerError, "internal_error", "Внутренняя ошибка сервера", nil)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// ListClients godoc
+// @Summary      Присутствие клиентов тренера
+// @Description  Возвращает клиентов текущего тренера (принявших его приглашение), их присутствие и теги тренера. Фильтр status=training оставляет только тех, кто сейчас тренируется; q ищет по username, тегам и заметкам тренера; tag оставляет клиентов с тегом.
+// @Tags         presence
+// @Security     BearerAuth
+// @Produce      json
+// @Param        status  query     string  false  "Фильтр по статусу (training, offline)"
+// @Param        q       query     string  false  "Поиск по username, тегам и заметкам"
+// @Param        tag     query     string  false  "Фильтр по тегу"
+// @Success      200     {array}   ClientPresenceResponse
+// @Failure      400     {object}  response.ErrorBody
+// @Failure      401     {object}  response.ErrorBody
+// @Failure      403     {object}  response.ErrorBody
+// @Failure      500     {object}  response.ErrorBody
+// @Router       /api/v1/coach/clients [get]
+func (h *Handler) ListClients(c *gin.Context) {
+	coachID, err := middleware.UserIDFromContext(c)
+	if err != nil {
+		response.Error(c, http.StatusUnauthorized, "unauthorized", "Требуется аутентификация", nil)
+		return
+	}
+
+	clients, err := h.presence.ListClients(c.Request.Context(), coachID, presenceuc.ClientFilter{
+		Status: c.Query("status"),
+		Query:  c.Query("q"),
+		Tag:    c.Query("tag"),
+	})
+	if err != nil {
+		if errors.Is(err, presenceuc.ErrInvalidStatus) {
+			response.Error(c, http.StatusBadRequest, "invalid_status", "Параметр status должен быть training или offline", nil)
+			return
+		}
+		if errors.Is(err, presenceuc.ErrInvalidTag) {
+			response.Error(c, http.StatusBadRequest, "invalid_tag", "Некорректный тег", nil)
+			return
+		}
+		h.logger.Error("internal_error_in_list_coach_clients", map[string]any{
+			"coach_id": coachID.String(),
+			"path":     c.Request.URL.Path,
+			"method":   c.Request.Method,
+			"error":    err.Error(),
+		})
+		response.Error(c, http.StatusInternalServerError, "internal_error", "Внутренняя ошибка сервера", nil)
+		return
+	}
+
+	resp := make([]ClientPresenceResponse, 0, len(clients))
+	for _, cl := range clients {
+		if cl.Tags == nil {
+			cl.Tags = []string{}
+		}
+		resp = append(resp, ClientPresenceResponse{
+			UserID:           cl.UserID.String(),
+			Username:         cl.Username,
+			Tags:             cl.Tags,
+			PresenceResponse: toPresenceResponse(cl.Status, cl.State),
+		})
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// toPresenceResponse маппит присутствие в DTO.
+func toPresenceResponse(status string, state *presence.State) PresenceResponse {
+	resp := PresenceResponse{Status: status}
+	if state != nil {
+		resp.SessionID = state.SessionID
+		resp.Since = &state.Since
+		resp.LastSeenAt = &state.LastSeenAt
+	}
+	return resp
+}
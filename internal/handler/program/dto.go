@@ -0,0 +1,193 @@
+package program
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ExerciseDTO описывает упражнение тренировки программы.
+// Вес задаётся либо weight_kg, либо load_percent — процентом от тренировочного максимума
+// упражнения load_reference (по умолчанию — самого упражнения).
+type ExerciseDTO struct {
+	Name          string   `json:"name" binding:"required,max=100"`
+	Sets          int      `json:"sets" binding:"required,min=1,max=50"`
+	Reps          int      `json:"reps" binding:"required,min=1,max=1000"`
+	WeightKg      *float64 `json:"weight_kg,omitempty" binding:"omitempty,gte=0,lte=1000"`
+	LoadPercent   *float64 `json:"load_percent,omitempty" binding:"omitempty,gt=0,lte=150" example:"75"`
+	LoadReference string   `json:"load_reference,omitempty" binding:"max=100" example:"Присед"`
+	Notes         string   `json:"notes,omitempty" binding:"max=1000"`
+	// VideoID — видео с техникой выполнения, загруженное через /api/v1/videos.
+	VideoID *uuid.UUID `json:"video_id,omitempty" swaggertype:"string" format:"uuid"`
+	// Tempo — темп повторения «эксцентрика-пауза-концентрика-пауза» в секундах; X — взрывная фаза.
+	Tempo string `json:"tempo,omitempty" binding:"max=20" example:"3-1-1-0"`
+	// RestSeconds — отдых после подходов: одно значение на все подходы или по значению на каждый подход.
+	RestSeconds []int `json:"rest_seconds,omitempty" binding:"max=50,dive,min=0,max=3600" example:"120,120,180"`
+}
+
+// WorkoutDTO описывает тренировку в рамках дня программы.
+type WorkoutDTO struct {
+	Title     string        `json:"title" binding:"required,max=200"`
+	Notes     string        `json:"notes,omitempty" binding:"max=2000"`
+	Exercises []ExerciseDTO `json:"exercises" binding:"required,min=1,max=30,dive"`
+}
+
+// DayDTO описывает тренировочный день недели (1 — понедельник, 7 — воскресенье).
+type DayDTO struct {
+	Day      int          `json:"day" binding:"required,min=1,max=7"`
+	Workouts []WorkoutDTO `json:"workouts" binding:"required,min=1,max=5,dive"`
+}
+
+// WeekDTO описывает неделю программы. Номер недели определяется порядком в массиве weeks.
+type WeekDTO struct {
+	Number int      `json:"number"`
+	Days   []DayDTO `json:"days" binding:"max=7,dive"`
+}
+
+// CreateProgramRequest описывает тело запроса для создания программы.
+type CreateProgramRequest struct {
+	Title       string    `json:"title" binding:"required,max=200"`
+	Description string    `json:"description,omitempty" binding:"max=5000"`
+	Weeks       []WeekDTO `json:"weeks" binding:"required,min=1,max=52,dive"`
+}
+
+// AssignProgramRequest описывает тело запроса для назначения программы.
+// Если user_id не указан, программа назначается текущему пользователю.
+type AssignProgramRequest struct {
+	UserID    string `json:"user_id,omitempty" binding:"omitempty,uuid"`
+	StartDate string `json:"start_date,omitempty" example:"2025-01-06"`
+}
+
+// AssignToClientRequest описывает тело запроса назначения программы клиенту тренера.
+type AssignToClientRequest struct {
+	ProgramID string `json:"program_id" binding:"required,uuid"`
+	StartDate string `json:"start_date,omitempty" example:"2025-01-06"`
+}
+
+// BulkAssignClientDTO описывает клиента группового назначения.
+type BulkAssignClientDTO struct {
+	UserID string `json:"user_id" binding:"required,uuid"`
+	// StartDate — дата начала клиента; по умолчанию — общая start_date запроса.
+	StartDate string `json:"start_date,omitempty" example:"2025-01-13"`
+	// TrainingMaxes — максимумы клиента (упражнение -> кг), по которым вычисляются процентные веса программы.
+	TrainingMaxes map[string]float64 `json:"training_maxes,omitempty"`
+}
+
+// BulkAssignRequest описывает тело запроса для группового назначения программы.
+type BulkAssignRequest struct {
+	StartDate string                `json:"start_date,omitempty" example:"2025-01-06"`
+	Clients   []BulkAssignClientDTO `json:"clients" binding:"required,min=1,max=100,dive"`
+}
+
+// BulkAssignResultDTO описывает результат назначения одному клиенту.
+// При ошибке заполнены error и message, при успехе — assignment.
+type BulkAssignResultDTO struct {
+	UserID     string              `json:"user_id"`
+	Status     string              `json:"status" enums:"assigned,failed"`
+	Assignment *AssignmentResponse `json:"assignment,omitempty"`
+	// MissingTrainingMaxes — упражнения процентных назначений, для которых у клиента нет максимума.
+	MissingTrainingMaxes []string `json:"missing_training_maxes,omitempty"`
+	Error                string   `json:"error,omitempty"`
+	Message              string   `json:"message,omitempty"`
+}
+
+// BulkAssignResponse описывает результат группового назначения.
+type BulkAssignResponse struct {
+	Assigned int                   `json:"assigned"`
+	Failed   int                   `json:"failed"`
+	Results  []BulkAssignResultDTO `json:"results"`
+}
+
+// ProgramResponse описывает программу с полной структурой.
+type ProgramResponse struct {
+	ID              string    `json:"id"`
+	OwnerID         string    `json:"owner_id"`
+	Title           string    `json:"title"`
+	Description     string    `json:"description"`
+	Weeks           []WeekDTO `json:"weeks"`
+	SourceProgramID *string   `json:"source_program_id,omitempty"`
+	// Difficulty — средняя сложность по оценкам тренировок назначений программы; нет оценок — поле не задано.
+	Difficulty *DifficultyDTO `json:"difficulty,omitempty"`
+	CreatedAt  time.Time      `json:"created_at"`
+	UpdatedAt  time.Time      `json:"updated_at"`
+}
+
+// DifficultyDTO описывает оценки сложности тренировок (шкала RPE 1–10).
+type DifficultyDTO struct {
+	Ratings int     `json:"ratings"`
+	Average float64 `json:"average" example:"7.4"`
+}
+
+// AssignmentResponse описывает назначение программы пользователю.
+type AssignmentResponse struct {
+	ID         string    `json:"id"`
+	ProgramID  string    `json:"program_id"`
+	UserID     string    `json:"user_id"`
+	AssignedBy string    `json:"assigned_by"`
+	StartDate  string    `json:"start_date"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// AdherenceResponse описывает выполнение клиентом назначенной программы к текущему дню.
+type AdherenceResponse struct {
+	Assignment   AssignmentResponse `json:"assignment"`
+	ProgramTitle string             `json:"program_title"`
+	EndDate      string             `json:"end_date"` // День, следующий за последним днём программы
+	// TotalWorkouts — тренировок в программе, ScheduledWorkouts — из них по расписанию по сегодняшний день (UTC).
+	TotalWorkouts     int `json:"total_workouts"`
+	ScheduledWorkouts int `json:"scheduled_workouts"`
+	// CompletedWorkouts — завершённых тренировок, начатых по назначению.
+	CompletedWorkouts int `json:"completed_workouts"`
+	// Rate — доля выполненных тренировок расписания (0..1); нет, пока по расписанию не было тренировок.
+	Rate *float64 `json:"rate,omitempty" example:"0.83"`
+}
+
+// ScheduledExerciseDTO описывает упражнение расписания с вычисленным весом.
+type ScheduledExerciseDTO struct {
+	ExerciseDTO
+	// ResolvedWeightKg — итоговый вес: из weight_kg или вычисленный из load_percent с округлением.
+	ResolvedWeightKg *float64 `json:"resolved_weight_kg,omitempty"`
+	// TrainingMaxKg — тренировочный максимум, от которого посчитан процент.
+	TrainingMaxKg *float64 `json:"training_max_kg,omitempty"`
+	// MissingTrainingMax — у пользователя нет максимума для процентного назначения.
+	MissingTrainingMax bool `json:"missing_training_max,omitempty"`
+	// PlatesPerSideKg — блины на одну сторону грифа.
+	PlatesPerSideKg  []float64 `json:"plates_per_side_kg,omitempty"`
+	PlateRemainderKg float64   `json:"plate_remainder_kg,omitempty"`
+}
+
+// ScheduledWorkoutDTO описывает тренировку расписания на конкретную дату.
+type ScheduledWorkoutDTO struct {
+	Date      string                 `json:"date" example:"2025-01-06"`
+	Week      int                    `json:"week"`
+	Day       int                    `json:"day"`
+	Title     string                 `json:"title"`
+	Notes     string                 `json:"notes,omitempty"`
+	Exercises []ScheduledExerciseDTO `json:"exercises"`
+}
+
+// ScheduleResponse описывает назначенную программу, разложенную по датам.
+type ScheduleResponse struct {
+	Assignment   AssignmentResponse    `json:"assignment"`
+	ProgramTitle string                `json:"program_title"`
+	Workouts     []ScheduledWorkoutDTO `json:"workouts"`
+	// Difficulty — оценки сложности последних тренировок назначения (до 5).
+	Difficulty DifficultyDTO `json:"difficulty"`
+	// LoadAdjustmentPercent — предлагаемое по оценкам изменение рабочих весов, %.
+	LoadAdjustmentPercent float64 `json:"load_adjustment_percent"`
+	// FeedbackApplied — изменение нагрузки применено к resolved_weight_kg (apply_feedback=true).
+	FeedbackApplied bool `json:"feedback_applied"`
+}
+
+// SetTrainingMaxRequest описывает тело запроса для сохранения тренировочного максимума.
+type SetTrainingMaxRequest struct {
+	Exercise string  `json:"exercise" binding:"required,max=100" example:"Присед"`
+	WeightKg float64 `json:"weight_kg" binding:"required,gt=0,lte=1000" example:"140"`
+}
+
+// TrainingMaxResponse описывает тренировочный максимум пользователя.
+type TrainingMaxResponse struct {
+	Exercise  string    `json:"exercise"`
+	WeightKg  float64   `json:"weight_kg"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
@@ -0,0 +1,915 @@
+package program
+
+import (
+	"errors"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	domain "workout-app/internal/domain/program"
+	userdomain "workout-app/internal/domain/user"
+	"workout-app/internal/handler/middleware"
+	"workout-app/internal/handler/response"
+	repo "workout-app/internal/repository/interfaces"
+	consentuc "workout-app/internal/usecase/consent"
+	programuc "workout-app/internal/usecase/program"
+	"workout-app/pkg/logger"
+)
+
+// dateLayout — формат даты начала программы в запросах и ответах.
+const dateLayout = "2006-01-02"
+
+// Handler обрабатывает HTTP-запросы, связанные с тренировочными программами.
+type Handler struct {
+	programs programuc.Service
+	logger   logger.Logger
+}
+
+// NewHandler создаёт новый ProgramHandler.
+func NewHandler(programs programuc.Service, logger logger.Logger) *Handler {
+	return &Handler{
+		programs: programs,
+		logger:   logger,
+	}
+}
+
+// Create godoc
+// @Summary      Создать тренировочную программу
+// @Description  Создаёт многонедельную программу: недели -> дни -> тренировки -> упражнения. Автором становится текущий пользователь.
+// @Tags         programs
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        payload  body      CreateProgramRequest  true  "Структура программы"
+// @Success      201      {object}  ProgramResponse
+// @Failure      400      {object}  response.ErrorBody
+// @Failure      401      {object}  response.ErrorBody
+// @Failure      500      {object}  response.ErrorBody
+// @Router       /api/v1/programs [post]
+func (h *Handler) Create(c *gin.Context) {
+	actor, ok := actorFromContext(c)
+	if !ok {
+		response.Error(c, http.StatusUnauthorized, "unauthorized", "Требуется аутентификация", nil)
+		return
+	}
+
+	var req CreateProgramRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid_request", "Некорректное тело запроса", err.Error())
+		return
+	}
+
+	p, err := h.programs.Create(c.Request.Context(), actor, programuc.ProgramInput{
+		Title:       req.Title,
+		Description: req.Description,
+		Weeks:       toDomainWeeks(req.Weeks),
+	})
+	if err != nil {
+		h.respondError(c, "create_program", actor, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, toProgramResponse(p))
+}
+
+// ListMine godoc
+// @Summary      Получить свои программы
+// @Description  Возвращает программы, автором которых является текущий пользователь.
+// @Tags         programs
+// @Security     BearerAuth
+// @Produce      json
+// @Success      200  {array}   ProgramResponse
+// @Failure      401  {object}  response.ErrorBody
+// @Failure      500  {object}  response.ErrorBody
+// @Router       /api/v1/programs [get]
+func (h *Handler) ListMine(c *gin.Context) {
+	actor, ok := actorFromContext(c)
+	if !ok {
+		response.Error(c, http.StatusUnauthorized, "unauthorized", "Требуется аутентификация", nil)
+		return
+	}
+
+	programs, err := h.programs.ListOwn(c.Request.Context(), actor.UserID)
+	if err != nil {
+		h.respondError(c, "list_programs", actor, err)
+		return
+	}
+
+	resp := make([]ProgramResponse, 0, len(programs))
+	for _, p := range programs {
+		resp = append(resp, toProgramResponse(p))
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// ListAssigned godoc
+// @Summary      Получить назначенные программы
+// @Description  Возвращает программы, назначенные текущему пользователю (им самим или тренером).
+// @Tags         programs
+// @Security     BearerAuth
+// @Produce      json
+// @Success      200  {array}   AssignmentResponse
+// @Failure      401  {object}  response.ErrorBody
+// @Failure      500  {object}  response.ErrorBody
+// @Router       /api/v1/programs/assigned [get]
+func (h *Handler) ListAssigned(c *gin.Context) {
+	actor, ok := actorFromContext(c)
+	if !ok {
+		response.Error(c, http.StatusUnauthorized, "unauthorized", "Требуется аутентификация", nil)
+		return
+	}
+
+	assignments, err := h.programs.ListAssigned(c.Request.Context(), actor.UserID)
+	if err != nil {
+		h.respondError(c, "list_assigned_programs", actor, err)
+		return
+	}
+
+	resp := make([]AssignmentResponse, 0, len(assignments))
+	for _, a := range assignments {
+		resp = append(resp, toAssignmentResponse(a))
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// ListClientAssignments godoc
+// @Summary      Получить назначенные программы клиента (тренер)
+// @Description  Возвращает программы, назначенные клиенту текущего тренера. Клиент должен открыть тренеру класс данных workouts.
+// @Tags         programs
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id   path      string  true  "ID клиента"
+// @Success      200  {array}   AssignmentResponse
+// @Failure      400  {object}  response.ErrorBody
+// @Failure      401  {object}  response.ErrorBody
+// @Failure      403  {object}  response.ErrorBody
+// @Failure      404  {object}  response.ErrorBody
+// @Failure      500  {object}  response.ErrorBody
+// @Router       /api/v1/coach/clients/{id}/assignments [get]
+func (h *Handler) ListClientAssignments(c *gin.Context) {
+	actor, ok := actorFromContext(c)
+	if !ok {
+		response.Error(c, http.StatusUnauthorized, "unauthorized", "Требуется аутентификация", nil)
+		return
+	}
+
+	clientID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid_user_id", "Некорректный ID пользователя", nil)
+		return
+	}
+
+	assignments, err := h.programs.ListAssignedForCoach(c.Request.Context(), actor.UserID, clientID)
+	if err != nil {
+		h.respondError(c, "list_client_assignments", actor, err)
+		return
+	}
+
+	resp := make([]AssignmentResponse, 0, len(assignments))
+	for _, a := range assignments {
+		resp = append(resp, toAssignmentResponse(a))
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// ClientAdherence godoc
+// @Summary      Выполнение программ клиентом (тренер)
+// @Description  Для программ, назначенных клиенту текущим тренером, возвращает число тренировок по расписанию по сегодняшний день и завершённых тренировок по назначению. Клиент должен открыть тренеру класс данных workouts.
+// @Tags         programs
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id   path      string  true  "ID клиента"
+// @Success      200  {array}   AdherenceResponse
+// @Failure      400  {object}  response.ErrorBody
+// @Failure      401  {object}  response.ErrorBody
+// @Failure      403  {object}  response.ErrorBody
+// @Failure      404  {object}  response.ErrorBody
+// @Failure      500  {object}  response.ErrorBody
+// @Router       /api/v1/coach/clients/{id}/adherence [get]
+func (h *Handler) ClientAdherence(c *gin.Context) {
+	actor, ok := actorFromContext(c)
+	if !ok {
+		response.Error(c, http.StatusUnauthorized, "unauthorized", "Требуется аутентификация", nil)
+		return
+	}
+
+	clientID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid_user_id", "Некорректный ID пользователя", nil)
+		return
+	}
+
+	adherence, err := h.programs.AdherenceForCoach(c.Request.Context(), actor.UserID, clientID)
+	if err != nil {
+		h.respondError(c, "client_adherence", actor, err)
+		return
+	}
+
+	resp := make([]AdherenceResponse, 0, len(adherence))
+	for _, a := range adherence {
+		item := AdherenceResponse{
+			Assignment:        toAssignmentResponse(a.Assignment),
+			ProgramTitle:      a.Program.Title,
+			EndDate:           a.Program.EndDate(a.Assignment.StartDate).Format(dateLayout),
+			TotalWorkouts:     a.Total,
+			ScheduledWorkouts: a.Scheduled,
+			CompletedWorkouts: a.Completed,
+		}
+		if rate, ok := a.Rate(); ok {
+			rate = math.Round(rate*100) / 100
+			item.Rate = &rate
+		}
+		resp = append(resp, item)
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// Get godoc
+// @Summary      Получить программу
+// @Description  Возвращает программу, если текущий пользователь — её автор, она ему назначена или он админ.
+// @Tags         programs
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id   path      string  true  "ID программы"
+// @Success      200  {object}  ProgramResponse
+// @Failure      400  {object}  response.ErrorBody
+// @Failure      401  {object}  response.ErrorBody
+// @Failure      403  {object}  response.ErrorBody
+// @Failure      404  {object}  response.ErrorBody
+// @Failure      500  {object}  response.ErrorBody
+// @Router       /api/v1/programs/{id} [get]
+func (h *Handler) Get(c *gin.Context) {
+	actor, ok := actorFromContext(c)
+	if !ok {
+		response.Error(c, http.StatusUnauthorized, "unauthorized", "Требуется аутентификация", nil)
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid_program_id", "Некорректный ID программы", nil)
+		return
+	}
+
+	p, err := h.programs.Get(c.Request.Context(), actor, id)
+	if err != nil {
+		h.respondError(c, "get_program", actor, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toProgramResponse(p))
+}
+
+// Clone godoc
+// @Summary      Скопировать программу
+// @Description  Создаёт независимую копию доступной программы; автором копии становится текущий пользователь.
+// @Tags         programs
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id   path      string  true  "ID исходной программы"
+// @Success      201  {object}  ProgramResponse
+// @Failure      400  {object}  response.ErrorBody
+// @Failure      401  {object}  response.ErrorBody
+// @Failure      403  {object}  response.ErrorBody
+// @Failure      404  {object}  response.ErrorBody
+// @Failure      500  {object}  response.ErrorBody
+// @Router       /api/v1/programs/{id}/clone [post]
+func (h *Handler) Clone(c *gin.Context) {
+	actor, ok := actorFromContext(c)
+	if !ok {
+		response.Error(c, http.StatusUnauthorized, "unauthorized", "Требуется аутентификация", nil)
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid_program_id", "Некорректный ID программы", nil)
+		return
+	}
+
+	p, err := h.programs.Clone(c.Request.Context(), actor, id)
+	if err != nil {
+		h.respondError(c, "clone_program", actor, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, toProgramResponse(p))
+}
+
+// Assign godoc
+// @Summary      Назначить программу
+// @Description  Назначает программу текущему пользователю или, для тренеров и админов, другому пользователю.
+// @Description  Тренер назначает программы только своим клиентам — принявшим его приглашение; админ — любому пользователю.
+// @Tags         programs
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        id       path      string                true  "ID программы"
+// @Param        payload  body      AssignProgramRequest  true  "Параметры назначения"
+// @Success      201      {object}  AssignmentResponse
+// @Failure      400      {object}  response.ErrorBody
+// @Failure      401      {object}  response.ErrorBody
+// @Failure      403      {object}  response.ErrorBody
+// @Failure      404      {object}  response.ErrorBody
+// @Failure      409      {object}  response.ErrorBody
+// @Failure      500      {object}  response.ErrorBody
+// @Router       /api/v1/programs/{id}/assign [post]
+func (h *Handler) Assign(c *gin.Context) {
+	actor, ok := actorFromContext(c)
+	if !ok {
+		response.Error(c, http.StatusUnauthorized, "unauthorized", "Требуется аутентификация", nil)
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid_program_id", "Некорректный ID программы", nil)
+		return
+	}
+
+	var req AssignProgramRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid_request", "Некорректное тело запроса", err.Error())
+		return
+	}
+
+	var input programuc.AssignInput
+	if req.UserID != "" {
+		// Формат уже проверен binding-тегом uuid
+		input.UserID = uuid.MustParse(req.UserID)
+	}
+	if req.StartDate != "" {
+		startDate, err := time.Parse(dateLayout, req.StartDate)
+		if err != nil {
+			response.Error(c, http.StatusBadRequest, "invalid_start_date", "Дата начала должна быть в формате YYYY-MM-DD", nil)
+			return
+		}
+		input.StartDate = startDate
+	}
+
+	a, err := h.programs.Assign(c.Request.Context(), actor, id, input)
+	if err != nil {
+		h.respondError(c, "assign_program", actor, err)
+		return
+	}
+
+	h.logger.Info("program_assigned", map[string]any{
+		"program_id":  a.ProgramID.String(),
+		"user_id":     a.UserID.String(),
+		"assigned_by": a.AssignedBy.String(),
+	})
+	c.JSON(http.StatusCreated, toAssignmentResponse(a))
+}
+
+// AssignToClient godoc
+// @Summary      Назначить программу клиенту (тренер)
+// @Description  Назначает программу текущего тренера его клиенту: пользователю, принявшему приглашение тренера или уже получавшему от него программы.
+// @Tags         programs
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        id       path      string                 true  "ID клиента"
+// @Param        payload  body      AssignToClientRequest  true  "Программа и дата начала"
+// @Success      201      {object}  AssignmentResponse
+// @Failure      400      {object}  response.ErrorBody
+// @Failure      401      {object}  response.ErrorBody
+// @Failure      403      {object}  response.ErrorBody
+// @Failure      404      {object}  response.ErrorBody
+// @Failure      409      {object}  response.ErrorBody
+// @Failure      500      {object}  response.ErrorBody
+// @Router       /api/v1/coach/clients/{id}/programs [post]
+func (h *Handler) AssignToClient(c *gin.Context) {
+	actor, ok := actorFromContext(c)
+	if !ok {
+		response.Error(c, http.StatusUnauthorized, "unauthorized", "Требуется аутентификация", nil)
+		return
+	}
+
+	clientID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid_user_id", "Некорректный ID пользователя", nil)
+		return
+	}
+
+	var req AssignToClientRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid_request", "Некорректное тело запроса", err.Error())
+		return
+	}
+
+	var startDate time.Time
+	if req.StartDate != "" {
+		startDate, err = time.Parse(dateLayout, req.StartDate)
+		if err != nil {
+			response.Error(c, http.StatusBadRequest, "invalid_start_date", "Дата начала должна быть в формате YYYY-MM-DD", nil)
+			return
+		}
+	}
+
+	// Формат уже проверен binding-тегом uuid
+	a, err := h.programs.AssignToClient(c.Request.Context(), actor, clientID, uuid.MustParse(req.ProgramID), startDate)
+	if err != nil {
+		h.respondError(c, "assign_program_to_client", actor, err)
+		return
+	}
+
+	h.logger.Info("program_assigned", map[string]any{
+		"program_id":  a.ProgramID.String(),
+		"user_id":     a.UserID.String(),
+		"assigned_by": a.AssignedBy.String(),
+	})
+	c.JSON(http.StatusCreated, toAssignmentResponse(a))
+}
+
+// BulkAssign godoc
+// @Summary      Назначить программу группе клиентов
+// @Description  Назначает программу нескольким клиентам (до 100) с общей или индивидуальной датой начала.
+// @Description  Переданные training_maxes сохраняются клиенту и используются для вычисления процентных весов программы.
+// @Description  Клиенты обрабатываются независимо: ошибка одного не отменяет остальные назначения и возвращается в его результате.
+// @Description  Доступно автору программы с ролью coach (только его клиентам, принявшим приглашение) и админу.
+// @Tags         programs
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        id       path      string             true  "ID программы"
+// @Param        payload  body      BulkAssignRequest  true  "Клиенты и даты начала"
+// @Success      200      {object}  BulkAssignResponse
+// @Failure      400      {object}  response.ErrorBody
+// @Failure      401      {object}  response.ErrorBody
+// @Failure      403      {object}  response.ErrorBody
+// @Failure      404      {object}  response.ErrorBody
+// @Failure      500      {object}  response.ErrorBody
+// @Router       /api/v1/programs/{id}/assign/bulk [post]
+func (h *Handler) BulkAssign(c *gin.Context) {
+	actor, ok := actorFromContext(c)
+	if !ok {
+		response.Error(c, http.StatusUnauthorized, "unauthorized", "Требуется аутентификация", nil)
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid_program_id", "Некорректный ID программы", nil)
+		return
+	}
+
+	var req BulkAssignRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid_request", "Некорректное тело запроса", err.Error())
+		return
+	}
+
+	var input programuc.BulkAssignInput
+	if req.StartDate != "" {
+		if input.StartDate, err = time.Parse(dateLayout, req.StartDate); err != nil {
+			response.Error(c, http.StatusBadRequest, "invalid_start_date", "Дата начала должна быть в формате YYYY-MM-DD", nil)
+			return
+		}
+	}
+	for _, client := range req.Clients {
+		// Формат user_id уже проверен binding-тегом uuid
+		item := programuc.BulkAssignClient{UserID: uuid.MustParse(client.UserID), TrainingMaxes: client.TrainingMaxes}
+		if client.StartDate != "" {
+			if item.StartDate, err = time.Parse(dateLayout, client.StartDate); err != nil {
+				response.Error(c, http.StatusBadRequest, "invalid_start_date", "Дата начала должна быть в формате YYYY-MM-DD", client.UserID)
+				return
+			}
+		}
+		input.Clients = append(input.Clients, item)
+	}
+
+	results, err := h.programs.BulkAssign(c.Request.Context(), actor, id, input)
+	if err != nil {
+		h.respondError(c, "bulk_assign_program", actor, err)
+		return
+	}
+
+	resp := BulkAssignResponse{Results: make([]BulkAssignResultDTO, 0, len(results))}
+	for _, r := range results {
+		item := BulkAssignResultDTO{UserID: r.UserID.String()}
+		if r.Err != nil {
+			resp.Failed++
+			item.Status = "failed"
+			item.Error, item.Message = h.bulkAssignError(c, actor, r)
+		} else {
+			resp.Assigned++
+			a := toAssignmentResponse(r.Assignment)
+			item.Status = "assigned"
+			item.Assignment = &a
+			item.MissingTrainingMaxes = r.MissingTrainingMaxes
+		}
+		resp.Results = append(resp.Results, item)
+	}
+
+	h.logger.Info("program_bulk_assigned", map[string]any{
+		"program_id":  id.String(),
+		"assigned_by": actor.UserID.String(),
+		"assigned":    resp.Assigned,
+		"failed":      resp.Failed,
+	})
+	c.JSON(http.StatusOK, resp)
+}
+
+// bulkAssignError возвращает код и сообщение ошибки назначения клиенту при групповом назначении.
+func (h *Handler) bulkAssignError(c *gin.Context, actor programuc.Actor, r programuc.BulkAssignResult) (string, string) {
+	switch {
+	case errors.Is(r.Err, programuc.ErrAssigneeNotFound):
+		return "user_not_found", "Пользователь не найден"
+	case errors.Is(r.Err, consentuc.ErrNotCoachClient):
+		return "client_not_found", "Пользователь не является вашим клиентом"
+	case errors.Is(r.Err, repo.ErrProgramAlreadyAssigned):
+		return "program_already_assigned", "Программа уже назначена этому пользователю"
+	case errors.Is(r.Err, programuc.ErrInvalidTrainingMax):
+		return "invalid_training_max", r.Err.Error()
+	case errors.Is(r.Err, programuc.ErrTooManyTrainingMax):
+		return "too_many_training_maxes", "Достигнут лимит тренировочных максимумов"
+	default:
+		h.logger.Error("internal_error_in_bulk_assign_program", map[string]any{
+			"user_id":   actor.UserID.String(),
+			"client_id": r.UserID.String(),
+			"path":      c.Request.URL.Path,
+			"error":     r.Err.Error(),
+		})
+		return "internal_error", "Внутренняя ошибка сервера"
+	}
+}
+
+// Schedule godoc
+// @Summary      Расписание назначенной программы
+// @Description  Раскладывает назначенную программу по датам и вычисляет процентные веса по тренировочным максимумам пользователя
+// @Description  с округлением и раскладкой блинов. Доступно пользователю, которому назначена программа, админу и назначившему тренеру
+// @Description  (с согласием клиента на класс данных workouts). По умолчанию — весь срок программы, округление до 2.5 кг к ближайшему, гриф 20 кг.
+// @Description  По оценкам сложности последних 5 тренировок назначения предлагается изменение нагрузки (load_adjustment_percent); apply_feedback=true применяет его к весам.
+// @Tags         programs
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id              path      string  true   "ID назначения"
+// @Param        from            query     string  false  "Начало периода (YYYY-MM-DD)"
+// @Param        to              query     string  false  "Конец периода, не включительно (YYYY-MM-DD)"
+// @Param        round_to        query     number  false  "Шаг округления веса, кг (0 — без округления)"
+// @Param        rounding        query     string  false  "Направление округления: nearest, down, up"
+// @Param        bar_kg          query     number  false  "Вес грифа, кг (0 — не считать блины)"
+// @Param        apply_feedback  query     bool    false  "Применить к весам изменение нагрузки, предложенное по оценкам сложности"
+// @Success      200             {object}  ScheduleResponse
+// @Failure      400             {object}  response.ErrorBody
+// @Failure      401             {object}  response.ErrorBody
+// @Failure      403             {object}  response.ErrorBody
+// @Failure      404             {object}  response.ErrorBody
+// @Failure      500             {object}  response.ErrorBody
+// @Router       /api/v1/programs/assignments/{id}/schedule [get]
+func (h *Handler) Schedule(c *gin.Context) {
+	actor, ok := actorFromContext(c)
+	if !ok {
+		response.Error(c, http.StatusUnauthorized, "unauthorized", "Требуется аутентификация", nil)
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid_assignment_id", "Некорректный ID назначения", nil)
+		return
+	}
+
+	input := programuc.ScheduleInput{Rules: domain.DefaultLoadRules()}
+	for param, dst := range map[string]*time.Time{"from": &input.From, "to": &input.To} {
+		if raw := c.Query(param); raw != "" {
+			t, err := time.Parse(dateLayout, raw)
+			if err != nil {
+				response.Error(c, http.StatusBadRequest, "invalid_period", "Даты периода должны быть в формате YYYY-MM-DD", nil)
+				return
+			}
+			*dst = t
+		}
+	}
+	for param, dst := range map[string]*float64{"round_to": &input.Rules.IncrementKg, "bar_kg": &input.Rules.BarKg} {
+		if raw := c.Query(param); raw != "" {
+			v, err := strconv.ParseFloat(raw, 64)
+			if err != nil {
+				response.Error(c, http.StatusBadRequest, "invalid_schedule_params", "Параметр "+param+" должен быть числом", nil)
+				return
+			}
+			*dst = v
+		}
+	}
+	if raw := c.Query("rounding"); raw != "" {
+		input.Rules.Mode = domain.RoundingMode(raw)
+	}
+	if raw := c.Query("apply_feedback"); raw != "" {
+		apply, err := strconv.ParseBool(raw)
+		if err != nil {
+			response.Error(c, http.StatusBadRequest, "invalid_schedule_params", "Параметр apply_feedback должен быть true или false", nil)
+			return
+		}
+		input.ApplyFeedback = apply
+	}
+
+	schedule, err := h.programs.Schedule(c.Request.Context(), actor, id, input)
+	if err != nil {
+		h.respondError(c, "program_schedule", actor, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toScheduleResponse(schedule))
+}
+
+// ListTrainingMaxes godoc
+// @Summary      Получить тренировочные максимумы
+// @Description  Возвращает тренировочные максимумы текущего пользователя, от которых считаются процентные назначения программ.
+// @Tags         training-maxes
+// @Security     BearerAuth
+// @Produce      json
+// @Success      200  {array}   TrainingMaxResponse
+// @Failure      401  {object}  response.ErrorBody
+// @Failure      500  {object}  response.ErrorBody
+// @Router       /api/v1/training-maxes [get]
+func (h *Handler) ListTrainingMaxes(c *gin.Context) {
+	actor, ok := actorFromContext(c)
+	if !ok {
+		response.Error(c, http.StatusUnauthorized, "unauthorized", "Требуется аутентификация", nil)
+		return
+	}
+
+	maxes, err := h.programs.ListTrainingMaxes(c.Request.Context(), actor.UserID)
+	if err != nil {
+		h.respondError(c, "list_training_maxes", actor, err)
+		return
+	}
+
+	resp := make([]TrainingMaxResponse, 0, len(maxes))
+	for _, tm := range maxes {
+		resp = append(resp, toTrainingMaxResponse(tm))
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// SetTrainingMax godoc
+// @Summary      Сохранить тренировочный максимум
+// @Description  Сохраняет максимум текущего пользователя в упражнении; прежнее значение заменяется.
+// @Description  Упражнения сравниваются без учёта регистра и лишних пробелов.
+// @Tags         training-maxes
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        payload  body      SetTrainingMaxRequest  true  "Упражнение и вес"
+// @Success      200      {object}  TrainingMaxResponse
+// @Failure      400      {object}  response.ErrorBody
+// @Failure      401      {object}  response.ErrorBody
+// @Failure      422      {object}  response.ErrorBody
+// @Failure      500      {object}  response.ErrorBody
+// @Router       /api/v1/training-maxes [put]
+func (h *Handler) SetTrainingMax(c *gin.Context) {
+	actor, ok := actorFromContext(c)
+	if !ok {
+		response.Error(c, http.StatusUnauthorized, "unauthorized", "Требуется аутентификация", nil)
+		return
+	}
+
+	var req SetTrainingMaxRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid_request", "Некорректное тело запроса", err.Error())
+		return
+	}
+
+	tm, err := h.programs.SetTrainingMax(c.Request.Context(), actor.UserID, req.Exercise, req.WeightKg)
+	if err != nil {
+		h.respondError(c, "set_training_max", actor, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toTrainingMaxResponse(tm))
+}
+
+// DeleteTrainingMax godoc
+// @Summary      Удалить тренировочный максимум
+// @Tags         training-maxes
+// @Security     BearerAuth
+// @Param        exercise  query  string  true  "Упражнение"
+// @Success      204
+// @Failure      400  {object}  response.ErrorBody
+// @Failure      401  {object}  response.ErrorBody
+// @Failure      404  {object}  response.ErrorBody
+// @Failure      500  {object}  response.ErrorBody
+// @Router       /api/v1/training-maxes [delete]
+func (h *Handler) DeleteTrainingMax(c *gin.Context) {
+	actor, ok := actorFromContext(c)
+	if !ok {
+		response.Error(c, http.StatusUnauthorized, "unauthorized", "Требуется аутентификация", nil)
+		return
+	}
+
+	exercise := c.Query("exercise")
+	if exercise == "" {
+		response.Error(c, http.StatusBadRequest, "invalid_request", "Не указано упражнение", nil)
+		return
+	}
+
+	if err := h.programs.DeleteTrainingMax(c.Request.Context(), actor.UserID, exercise); err != nil {
+		h.respondError(c, "delete_training_max", actor, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// respondError отправляет ответ об ошибке для эндпоинтов программ.
+func (h *Handler) respondError(c *gin.Context, op string, actor programuc.Actor, err error) {
+	switch {
+	case errors.Is(err, programuc.ErrInvalidProgram):
+		response.Error(c, http.StatusBadRequest, "invalid_program", "Некорректная структура программы", err.Error())
+	case errors.Is(err, programuc.ErrProgramAccessDenied):
+		response.Error(c, http.StatusForbidden, "forbidden", "Недостаточно прав для доступа к программе", nil)
+	case errors.Is(err, programuc.ErrInvalidBulkAssign):
+		response.Error(c, http.StatusBadRequest, "invalid_request", "Некорректный список клиентов", err.Error())
+	case errors.Is(err, programuc.ErrInvalidSchedule):
+		response.Error(c, http.StatusBadRequest, "invalid_schedule_params", "Некорректные параметры расписания", err.Error())
+	case errors.Is(err, programuc.ErrInvalidTrainingMax):
+		response.Error(c, http.StatusBadRequest, "invalid_training_max", "Некорректный тренировочный максимум", err.Error())
+	case errors.Is(err, programuc.ErrTooManyTrainingMax):
+		response.Error(c, http.StatusUnprocessableEntity, "too_many_training_maxes", "Достигнут лимит тренировочных максимумов", nil)
+	case errors.Is(err, programuc.ErrTrainingMaxNotFound):
+		response.Error(c, http.StatusNotFound, "training_max_not_found", "Тренировочный максимум не найден", nil)
+	case errors.Is(err, programuc.ErrAssignmentNotFound):
+		response.Error(c, http.StatusNotFound, "assignment_not_found", "Назначение не найдено", nil)
+	case errors.Is(err, programuc.ErrAssigneeNotFound):
+		response.Error(c, http.StatusNotFound, "user_not_found", "Пользователь не найден", nil)
+	case errors.Is(err, consentuc.ErrNotCoachClient):
+		response.Error(c, http.StatusNotFound, "client_not_found", "Клиент не найден", nil)
+	case errors.Is(err, consentuc.ErrConsentRequired):
+		response.Error(c, http.StatusForbidden, "consent_required", "Клиент не открыл доступ к тренировкам", nil)
+	case errors.Is(err, repo.ErrProgramAlreadyAssigned):
+		response.Error(c, http.StatusConflict, "program_already_assigned", "Программа уже назначена этому пользователю", nil)
+	case errors.Is(err, repo.ErrNotFound):
+		response.Error(c, http.StatusNotFound, "program_not_found", "Программа не найдена", nil)
+	default:
+		h.logger.Error("internal_error_in_"+op, map[string]any{
+			"user_id": actor.UserID.String(),
+			"path":    c.Request.URL.Path,
+			"method":  c.Request.Method,
+			"error":   err.Error(),
+		})
+		response.Error(c, http.StatusInternalServerError, "internal_error", "Внутренняя ошибка сервера", nil)
+	}
+}
+
+// actorFromContext извлекает ID и роль текущего пользователя из контекста запроса.
+func actorFromContext(c *gin.Context) (programuc.Actor, bool) {
+	userID, err := middleware.UserIDFromContext(c)
+	if err != nil {
+		return programuc.Actor{}, false
+	}
+	return programuc.Actor{
+		UserID: userID,
+		Role:   userdomain.Role(c.GetString(middleware.ContextUserRoleKey)),
+	}, true
+}
+
+// toDomainWeeks маппит DTO недель в доменные модели.
+func toDomainWeeks(weeks []WeekDTO) []domain.Week {
+	result := make([]domain.Week, 0, len(weeks))
+	for _, w := range weeks {
+		days := make([]domain.Day, 0, len(w.Days))
+		for _, d := range w.Days {
+			workouts := make([]domain.Workout, 0, len(d.Workouts))
+			for _, wo := range d.Workouts {
+				exercises := make([]domain.Exercise, 0, len(wo.Exercises))
+				for _, e := range wo.Exercises {
+					exercises = append(exercises, domain.Exercise{
+						Name:          e.Name,
+						Sets:          e.Sets,
+						Reps:          e.Reps,
+						WeightKg:      e.WeightKg,
+						LoadPercent:   e.LoadPercent,
+						LoadReference: e.LoadReference,
+						Notes:         e.Notes,
+						VideoID:       e.VideoID,
+						Tempo:         e.Tempo,
+						RestSeconds:   e.RestSeconds,
+					})
+				}
+				workouts = append(workouts, domain.Workout{Title: wo.Title, Notes: wo.Notes, Exercises: exercises})
+			}
+			days = append(days, domain.Day{Number: d.Day, Workouts: workouts})
+		}
+		result = append(result, domain.Week{Days: days})
+	}
+	return result
+}
+
+// toProgramResponse маппит доменную модель программы в DTO.
+func toProgramResponse(p *domain.Program) ProgramResponse {
+	weeks := make([]WeekDTO, 0, len(p.Weeks))
+	for _, w := range p.Weeks {
+		days := make([]DayDTO, 0, len(w.Days))
+		for _, d := range w.Days {
+			workouts := make([]WorkoutDTO, 0, len(d.Workouts))
+			for _, wo := range d.Workouts {
+				exercises := make([]ExerciseDTO, 0, len(wo.Exercises))
+				for _, e := range wo.Exercises {
+					exercises = append(exercises, toExerciseDTO(e))
+				}
+				workouts = append(workouts, WorkoutDTO{Title: wo.Title, Notes: wo.Notes, Exercises: exercises})
+			}
+			days = append(days, DayDTO{Day: d.Number, Workouts: workouts})
+		}
+		weeks = append(weeks, WeekDTO{Number: w.Number, Days: days})
+	}
+
+	resp := ProgramResponse{
+		ID:          p.ID.String(),
+		OwnerID:     p.OwnerID.String(),
+		Title:       p.Title,
+		Description: p.Description,
+		Weeks:       weeks,
+		CreatedAt:   p.CreatedAt,
+		UpdatedAt:   p.UpdatedAt,
+	}
+	if p.SourceProgramID != nil {
+		s := p.SourceProgramID.String()
+		resp.SourceProgramID = &s
+	}
+	if p.Difficulty.Ratings > 0 {
+		d := toDifficultyDTO(p.Difficulty)
+		resp.Difficulty = &d
+	}
+	return resp
+}
+
+// toDifficultyDTO маппит оценки сложности в DTO; среднее округляется до десятых.
+func toDifficultyDTO(d domain.DifficultyStats) DifficultyDTO {
+	return DifficultyDTO{Ratings: d.Ratings, Average: math.Round(d.Average*10) / 10}
+}
+
+// toAssignmentResponse маппит назначение программы в DTO.
+func toAssignmentResponse(a *domain.Assignment) AssignmentResponse {
+	return AssignmentResponse{
+		ID:         a.ID.String(),
+		ProgramID:  a.ProgramID.String(),
+		UserID:     a.UserID.String(),
+		AssignedBy: a.AssignedBy.String(),
+		StartDate:  a.StartDate.Format(dateLayout),
+		CreatedAt:  a.CreatedAt,
+	}
+}
+
+// toExerciseDTO маппит упражнение программы в DTO.
+func toExerciseDTO(e domain.Exercise) ExerciseDTO {
+	return ExerciseDTO{
+		Name:          e.Name,
+		Sets:          e.Sets,
+		Reps:          e.Reps,
+		WeightKg:      e.WeightKg,
+		LoadPercent:   e.LoadPercent,
+		LoadReference: e.LoadReference,
+		Notes:         e.Notes,
+		VideoID:       e.VideoID,
+		Tempo:         e.Tempo,
+		RestSeconds:   e.RestSeconds,
+	}
+}
+
+// toScheduleResponse маппит расписание назначения в DTO.
+func toScheduleResponse(s *programuc.AssignmentSchedule) ScheduleResponse {
+	workouts := make([]ScheduledWorkoutDTO, 0, len(s.Workouts))
+	for _, wo := range s.Workouts {
+		exercises := make([]ScheduledExerciseDTO, 0, len(wo.Exercises))
+		for _, e := range wo.Exercises {
+			exercises = append(exercises, ScheduledExerciseDTO{
+				ExerciseDTO:        toExerciseDTO(e.Exercise),
+				ResolvedWeightKg:   e.ResolvedWeightKg,
+				TrainingMaxKg:      e.TrainingMaxKg,
+				MissingTrainingMax: e.MissingTrainingMax,
+				PlatesPerSideKg:    e.PlatesPerSideKg,
+				PlateRemainderKg:   e.PlateRemainderKg,
+			})
+		}
+		workouts = append(workouts, ScheduledWorkoutDTO{
+			Date:      wo.Date.Format(dateLayout),
+			Week:      wo.Week,
+			Day:       wo.Day,
+			Title:     wo.Title,
+			Notes:     wo.Notes,
+			Exercises: exercises,
+		})
+	}
+	return ScheduleResponse{
+		Assignment:            toAssignmentResponse(s.Assignment),
+		ProgramTitle:          s.Program.Title,
+		Workouts:              workouts,
+		Difficulty:            toDifficultyDTO(s.Difficulty),
+		LoadAdjustmentPercent: s.LoadAdjustmentPercent,
+		FeedbackApplied:       s.FeedbackApplied,
+	}
+}
+
+// toTrainingMaxResponse маппит тренировочный максимум в DTO.
+func toTrainingMaxResponse(tm *domain.TrainingMax) TrainingMaxResponse {
+	return TrainingMaxResponse{
+		Exercise:  tm.Exercise,
+		WeightKg:  tm.WeightKg,
+		UpdatedAt: tm.UpdatedAt,
+	}
+}
@@ -0,0 +1,57 @@
+package progress
+
+import "time"
+
+// StatsResponse описывает статистику тренировок за диапазон.
+// Тоннаж отдаётся в системе единиц пользователя: volume_kg или volume_lb.
+type StatsResponse struct {
+	Range string    `json:"range"`
+	From  time.Time `json:"from"`
+	To    time.Time `json:"to"`
+	// Timezone — часовой пояс пользователя, в котором считаются дни и недели (UTC, если не задан в профиле).
+	Timezone     string                `json:"timezone"`
+	Totals       TotalsResponse        `json:"totals"`
+	Weeks        []WeekResponse        `json:"weeks"`
+	MuscleGroups []MuscleGroupResponse `json:"muscle_groups"`
+	// UnclassifiedSets — подходы упражнений, для которых группа мышц неизвестна.
+	UnclassifiedSets int             `json:"unclassified_sets"`
+	Streaks          StreaksResponse `json:"streaks"`
+}
+
+// TotalsResponse описывает итоги диапазона.
+type TotalsResponse struct {
+	Sessions        int      `json:"sessions"`
+	Sets            int      `json:"sets"`
+	Reps            int      `json:"reps"`
+	VolumeKg        *float64 `json:"volume_kg,omitempty"`
+	VolumeLb        *float64 `json:"volume_lb,omitempty"`
+	TrainingDays    int      `json:"training_days"`
+	SessionsPerWeek float64  `json:"sessions_per_week"`
+}
+
+// WeekResponse описывает одну неделю диапазона.
+type WeekResponse struct {
+	WeekStart    string   `json:"week_start"` // Понедельник, YYYY-MM-DD
+	Sessions     int      `json:"sessions"`
+	Sets         int      `json:"sets"`
+	Reps         int      `json:"reps"`
+	VolumeKg     *float64 `json:"volume_kg,omitempty"`
+	VolumeLb     *float64 `json:"volume_lb,omitempty"`
+	TrainingDays int      `json:"training_days"`
+}
+
+// MuscleGroupResponse описывает подходы одной группы мышц.
+type MuscleGroupResponse struct {
+	Group    string   `json:"group"`
+	Sets     int      `json:"sets"`
+	VolumeKg *float64 `json:"volume_kg,omitempty"`
+	VolumeLb *float64 `json:"volume_lb,omitempty"`
+}
+
+// StreaksResponse описывает серии дней и недель с тренировками за всё время.
+type StreaksResponse struct {
+	CurrentDays  int `json:"current_days"`
+	LongestDays  int `json:"longest_days"`
+	CurrentWeeks int `json:"current_weeks"`
+	LongestWeeks int `json:"longest_weeks"`
+}
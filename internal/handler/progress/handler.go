@@ -0,0 +1,140 @@
+package progress
+
+import (
+	"errors"
+	"math"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	userdomain "workout-app/internal/domain/user"
+	"workout-app/internal/handler/middleware"
+	"workout-app/internal/handler/response"
+	progressuc "workout-app/internal/usecase/progress"
+	useruc "workout-app/internal/usecase/user"
+	"workout-app/pkg/logger"
+)
+
+// Handler обрабатывает HTTP-запросы статистики тренировок.
+type Handler struct {
+	progress progressuc.Service
+	units    useruc.UnitsProvider
+	logger   logger.Logger
+}
+
+// NewHandler создаёт новый ProgressHandler.
+func NewHandler(progress progressuc.Service, units useruc.UnitsProvider, logger logger.Logger) *Handler {
+	return &Handler{
+		progress: progress,
+		units:    units,
+		logger:   logger,
+	}
+}
+
+// Stats godoc
+// @Summary      Статистика тренировок
+// @Description  Сводка завершённых тренировок текущего пользователя за диапазон: тренировки, подходы и тоннаж по неделям (с понедельника, недели без тренировок — нулями), итоги, самые нагружаемые группы мышц и серии дней и недель подряд с тренировками. Дни и недели считаются в часовом поясе из профиля, тоннаж — в системе единиц пользователя.
+// @Tags         users
+// @Security     BearerAuth
+// @Produce      json
+// @Param        range  query     string  false  "Диапазон: 7d, 30d, 90d (по умолчанию), 365d или all"
+// @Success      200    {object}  StatsResponse
+// @Failure      400    {object}  response.ErrorBody
+// @Failure      401    {object}  response.ErrorBody
+// @Failure      500    {object}  response.ErrorBody
+// @Router       /api/v1/users/me/stats [get]
+func (h *Handler) Stats(c *gin.Context) {
+	userID, err := middleware.UserIDFromContext(c)
+	if err != nil {
+		response.Error(c, http.StatusUnauthorized, "unauthorized", "Требуется аутентификация", nil)
+		return
+	}
+	rng := progressuc.Range(c.DefaultQuery("range", string(progressuc.DefaultRange)))
+
+	stats, err := h.progress.Stats(c.Request.Context(), userID, rng)
+	if err != nil {
+		h.respondError(c, "user_stats", userID, err)
+		return
+	}
+	units, err := h.units.Units(c.Request.Context(), userID)
+	if err != nil {
+		h.respondError(c, "user_stats_units", userID, err)
+		return
+	}
+	c.JSON(http.StatusOK, toStatsResponse(stats, units))
+}
+
+func toStatsResponse(stats *progressuc.Stats, units userdomain.Units) StatsResponse {
+	resp := StatsResponse{
+		Range:    string(stats.Range),
+		From:     stats.From,
+		To:       stats.To,
+		Timezone: stats.Timezone,
+		Totals: TotalsResponse{
+			Sessions:        stats.Totals.Sessions,
+			Sets:            stats.Totals.Sets,
+			Reps:            stats.Totals.Reps,
+			TrainingDays:    stats.Totals.TrainingDays,
+			SessionsPerWeek: round2(stats.Totals.SessionsPerWeek),
+		},
+		Weeks:            make([]WeekResponse, 0, len(stats.Weeks)),
+		MuscleGroups:     make([]MuscleGroupResponse, 0, len(stats.MuscleGroups)),
+		UnclassifiedSets: stats.UnclassifiedSets,
+		Streaks: StreaksResponse{
+			CurrentDays:  stats.Streaks.CurrentDays,
+			LongestDays:  stats.Streaks.LongestDays,
+			CurrentWeeks: stats.Streaks.CurrentWeeks,
+			LongestWeeks: stats.Streaks.LongestWeeks,
+		},
+	}
+	resp.Totals.VolumeKg, resp.Totals.VolumeLb = volumeFields(stats.Totals.VolumeKg, units)
+	for _, w := range stats.Weeks {
+		week := WeekResponse{
+			WeekStart:    w.WeekStart.Format("2006-01-02"),
+			Sessions:     w.Sessions,
+			Sets:         w.Sets,
+			Reps:         w.Reps,
+			TrainingDays: w.TrainingDays,
+		}
+		week.VolumeKg, week.VolumeLb = volumeFields(w.VolumeKg, units)
+		resp.Weeks = append(resp.Weeks, week)
+	}
+	for _, g := range stats.MuscleGroups {
+		group := MuscleGroupResponse{Group: string(g.Group), Sets: g.Sets}
+		group.VolumeKg, group.VolumeLb = volumeFields(g.VolumeKg, units)
+		resp.MuscleGroups = append(resp.MuscleGroups, group)
+	}
+	return resp
+}
+
+// respondError маппит ошибки usecase-слоя в HTTP-ответы.
+func (h *Handler) respondError(c *gin.Context, op string, userID uuid.UUID, err error) {
+	switch {
+	case errors.Is(err, progressuc.ErrInvalidRange):
+		response.Error(c, http.StatusBadRequest, "invalid_range", "Диапазон должен быть 7d, 30d, 90d, 365d или all", nil)
+	default:
+		h.logger.Error("internal_error_in_"+op, map[string]any{
+			"user_id": userID.String(),
+			"path":    c.Request.URL.Path,
+			"method":  c.Request.Method,
+			"error":   err.Error(),
+		})
+		response.Error(c, http.StatusInternalServerError, "internal_error", "Внутренняя ошибка сервера", nil)
+	}
+}
+
+// volumeFields возвращает тоннаж для поля в килограммах или фунтах в зависимости от системы единиц;
+// другое поле остаётся nil.
+func volumeFields(kg float64, units userdomain.Units) (*float64, *float64) {
+	value := round2(units.Weight(kg))
+	if units == userdomain.UnitsImperial {
+		return nil, &value
+	}
+	return &value, nil
+}
+
+// round2 округляет значение до сотых для ответа.
+func round2(v float64) float64 {
+	return math.Round(v*100) / 100
+}
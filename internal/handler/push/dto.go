@@ -0,0 +1,17 @@
+package push
+
+import "time"
+
+// RegisterDeviceRequest описывает тело запроса на регистрацию устройства.
+type RegisterDeviceRequest struct {
+	Platform string `json:"platform" binding:"required" example:"ios"` // android (токен FCM) или ios (токен APNs)
+	Token    string `json:"token" binding:"required"`
+}
+
+// DeviceResponse описывает устройство, получающее push-уведомления. Токен в ответ не попадает.
+type DeviceResponse struct {
+	ID         string    `json:"id"`
+	Platform   string    `json:"platform"`
+	CreatedAt  time.Time `json:"created_at"`
+	LastSeenAt time.Time `json:"last_seen_at"`
+}
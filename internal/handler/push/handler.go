@@ -0,0 +1,160 @@
+package push
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	domain "workout-app/internal/domain/device"
+	"workout-app/internal/handler/middleware"
+	"workout-app/internal/handler/response"
+	pushuc "workout-app/internal/usecase/push"
+	"workout-app/pkg/logger"
+)
+
+// Handler обрабатывает HTTP-запросы, связанные с устройствами для push-уведомлений.
+type Handler struct {
+	push   pushuc.Service
+	logger logger.Logger
+}
+
+// NewHandler создаёт новый PushHandler.
+func NewHandler(push pushuc.Service, logger logger.Logger) *Handler {
+	return &Handler{
+		push:   push,
+		logger: logger,
+	}
+}
+
+// RegisterDevice godoc
+// @Summary      Зарегистрировать устройство
+// @Description  Сохраняет токен FCM (android) или APNs (ios), на который отправляются push-копии уведомлений. Приложение вызывает метод после входа и при каждой смене токена; повторная регистрация того же токена обновляет last_seen_at, а токен другого пользователя переходит к текущему.
+// @Tags         notifications
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        request  body      RegisterDeviceRequest  true  "Устройство"
+// @Success      201      {object}  DeviceResponse
+// @Failure      400      {object}  response.ErrorBody
+// @Failure      401      {object}  response.ErrorBody
+// @Failure      500      {object}  response.ErrorBody
+// @Router       /api/v1/users/me/devices [post]
+func (h *Handler) RegisterDevice(c *gin.Context) {
+	userID, ok := h.currentUser(c)
+	if !ok {
+		return
+	}
+	var req RegisterDeviceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid_request", "Некорректное тело запроса", err.Error())
+		return
+	}
+
+	d, err := h.push.RegisterDevice(c.Request.Context(), userID, domain.Platform(req.Platform), req.Token)
+	if err != nil {
+		h.respondError(c, "register_device", userID, err)
+		return
+	}
+	c.JSON(http.StatusCreated, toDeviceResponse(d))
+}
+
+// ListDevices godoc
+// @Summary      Устройства
+// @Description  Возвращает устройства текущего пользователя, получающие push-уведомления, недавно активные первыми.
+// @Tags         notifications
+// @Security     BearerAuth
+// @Produce      json
+// @Success      200  {array}   DeviceResponse
+// @Failure      401  {object}  response.ErrorBody
+// @Failure      500  {object}  response.ErrorBody
+// @Router       /api/v1/users/me/devices [get]
+func (h *Handler) ListDevices(c *gin.Context) {
+	userID, ok := h.currentUser(c)
+	if !ok {
+		return
+	}
+
+	devices, err := h.push.ListDevices(c.Request.Context(), userID)
+	if err != nil {
+		h.respondError(c, "list_devices", userID, err)
+		return
+	}
+
+	resp := make([]DeviceResponse, 0, len(devices))
+	for _, d := range devices {
+		resp = append(resp, toDeviceResponse(d))
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// DeleteDevice godoc
+// @Summary      Удалить устройство
+// @Description  Отключает push-уведомления на устройстве. Приложение вызывает метод при выходе из аккаунта.
+// @Tags         notifications
+// @Security     BearerAuth
+// @Param        id   path  string  true  "ID устройства"
+// @Success      204
+// @Failure      400  {object}  response.ErrorBody
+// @Failure      401  {object}  response.ErrorBody
+// @Failure      404  {object}  response.ErrorBody
+// @Failure      500  {object}  response.ErrorBody
+// @Router       /api/v1/users/me/devices/{id} [delete]
+func (h *Handler) DeleteDevice(c *gin.Context) {
+	userID, ok := h.currentUser(c)
+	if !ok {
+		return
+	}
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid_device_id", "Некорректный ID устройства", nil)
+		return
+	}
+
+	if err := h.push.DeleteDevice(c.Request.Context(), userID, id); err != nil {
+		h.respondError(c, "delete_device", userID, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// currentUser извлекает текущего пользователя из контекста.
+func (h *Handler) currentUser(c *gin.Context) (uuid.UUID, bool) {
+	userID, err := middleware.UserIDFromContext(c)
+	if err != nil {
+		response.Error(c, http.StatusUnauthorized, "unauthorized", "Требуется аутентификация", nil)
+		return uuid.Nil, false
+	}
+	return userID, true
+}
+
+// respondError маппит ошибки usecase-слоя в HTTP-ответы.
+func (h *Handler) respondError(c *gin.Context, op string, userID uuid.UUID, err error) {
+	switch {
+	case errors.Is(err, pushuc.ErrInvalidPlatform):
+		response.Error(c, http.StatusBadRequest, "invalid_platform", "Платформа должна быть android или ios", nil)
+	case errors.Is(err, pushuc.ErrInvalidToken):
+		response.Error(c, http.StatusBadRequest, "invalid_device_token", "Некорректный токен устройства", nil)
+	case errors.Is(err, pushuc.ErrDeviceNotFound):
+		response.Error(c, http.StatusNotFound, "device_not_found", "Устройство не найдено", nil)
+	default:
+		h.logger.Error("internal_error_in_"+op, map[string]any{
+			"user_id": userID.String(),
+			"path":    c.Request.URL.Path,
+			"method":  c.Request.Method,
+			"error":   err.Error(),
+		})
+		response.Error(c, http.StatusInternalServerError, "internal_error", "Внутренняя ошибка сервера", nil)
+	}
+}
+
+// toDeviceResponse маппит устройство в DTO.
+func toDeviceResponse(d *domain.Device) DeviceResponse {
+	return DeviceResponse{
+		ID:         d.ID.String(),
+		Platform:   string(d.Platform),
+		CreatedAt:  d.CreatedAt,
+		LastSeenAt: d.LastSeenAt,
+	}
+}
@@ -1,21 +1,82 @@
 package response
 
-import "github.com/gin-gonic/gin"
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Стратегии повтора в подсказке RetryHint.
+const (
+	// RetryFixed — сервер точно знает, когда запрос пройдёт: повторять через after_seconds.
+	RetryFixed = "fixed"
+	// RetryExponential — время восстановления неизвестно: after_seconds — первая пауза,
+	// дальше клиент удваивает её с джиттером.
+	RetryExponential = "exponential"
+)
+
+// Паузы по умолчанию для 429/503, когда источник ошибки не знает точного времени.
+const (
+	DefaultRateLimitRetryAfter   = time.Minute
+	DefaultUnavailableRetryAfter = 30 * time.Second
+)
 
 // ErrorBody описывает стандартный формат ошибки API.
 type ErrorBody struct {
 	Code    string      `json:"code"`
 	Message string      `json:"message,omitempty"`
 	Details interface{} `json:"details,omitempty"`
+	// Retry присутствует в ответах 429 и 503 и дублирует заголовок Retry-After.
+	Retry *RetryHint `json:"retry,omitempty"`
+}
+
+// RetryHint — машиночитаемая подсказка, когда и как повторить запрос.
+type RetryHint struct {
+	AfterSeconds int    `json:"after_seconds"`
+	Strategy     string `json:"strategy"`
 }
 
 // Error отправляет JSON-ответ с ошибкой в едином формате.
+// Ответы 429 и 503 всегда получают Retry-After и подсказку retry с паузой по умолчанию;
+// если пауза известна, используйте RetryLater.
 func Error(c *gin.Context, status int, code, message string, details interface{}) {
+	body := ErrorBody{
+		Code:    code,
+		Message: message,
+		Details: details,
+	}
+	switch status {
+	case http.StatusTooManyRequests:
+		body.Retry = SetRetryAfter(c, DefaultRateLimitRetryAfter, RetryExponential)
+	case http.StatusServiceUnavailable:
+		body.Retry = SetRetryAfter(c, DefaultUnavailableRetryAfter, RetryExponential)
+	}
+	c.JSON(status, gin.H{"error": body})
+}
+
+// RetryLater отправляет ошибку (обычно 429 или 503) с точной паузой до повтора.
+func RetryLater(c *gin.Context, status int, code, message string, retryAfter time.Duration, details interface{}) {
 	c.JSON(status, gin.H{
 		"error": ErrorBody{
 			Code:    code,
 			Message: message,
 			Details: details,
+			Retry:   SetRetryAfter(c, retryAfter, RetryFixed),
 		},
 	})
 }
+
+// SetRetryAfter выставляет заголовок Retry-After (целые секунды, не меньше 1)
+// и возвращает соответствующую подсказку для тела ответа.
+// Подходит и для ответов не в формате ErrorBody (например, health-проверок).
+func SetRetryAfter(c *gin.Context, retryAfter time.Duration, strategy string) *RetryHint {
+	seconds := int(math.Ceil(retryAfter.Seconds()))
+	if seconds < 1 {
+		seconds = 1
+	}
+	c.Header("Retry-After", strconv.Itoa(seconds))
+	return &RetryHint{AfterSeconds: seconds, Strategy: strategy}
+}
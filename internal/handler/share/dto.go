@@ -0,0 +1,79 @@
+package share
+
+import "time"
+
+// ShareResponse описывает публичную ссылку. Token заполняется только в ответе на создание ссылки:
+// в БД хранится его хэш, поэтому показать токен повторно нельзя.
+type ShareResponse struct {
+	ID           string    `json:"id"`
+	ResourceType string    `json:"resource_type" example:"workout"`
+	ResourceID   string    `json:"resource_id"`
+	Token        string    `json:"token,omitempty"`
+	Path         string    `json:"path,omitempty" example:"/api/v1/shared/3q2-7wWx..."`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// SharedSetResponse описывает подход тренировки, открытой по ссылке.
+type SharedSetResponse struct {
+	Exercise string    `json:"exercise"`
+	Reps     int       `json:"reps"`
+	WeightKg *float64  `json:"weight_kg,omitempty"`
+	LoggedAt time.Time `json:"logged_at"`
+}
+
+// SharedWorkoutResponse описывает тренировку, открытую по ссылке; итоги — с учётом автопаузы.
+type SharedWorkoutResponse struct {
+	Title         string              `json:"title"`
+	StartedAt     time.Time           `json:"started_at"`
+	FinishedAt    *time.Time          `json:"finished_at,omitempty"`
+	Active        bool                `json:"active"`
+	ActiveSeconds int64               `json:"active_seconds"`
+	VolumeKg      float64             `json:"volume_kg"`
+	Sets          []SharedSetResponse `json:"sets"`
+}
+
+// SharedExerciseResponse описывает упражнение программы, открытой по ссылке.
+type SharedExerciseResponse struct {
+	Name          string   `json:"name"`
+	Sets          int      `json:"sets"`
+	Reps          int      `json:"reps"`
+	WeightKg      *float64 `json:"weight_kg,omitempty"`
+	LoadPercent   *float64 `json:"load_percent,omitempty"`
+	LoadReference string   `json:"load_reference,omitempty"`
+	Notes         string   `json:"notes,omitempty"`
+}
+
+// SharedProgramWorkoutResponse описывает тренировку дня программы.
+type SharedProgramWorkoutResponse struct {
+	Title     string                   `json:"title"`
+	Notes     string                   `json:"notes,omitempty"`
+	Exercises []SharedExerciseResponse `json:"exercises"`
+}
+
+// SharedDayResponse описывает тренировочный день недели (1 — понедельник, 7 — воскресенье).
+type SharedDayResponse struct {
+	Day      int                            `json:"day"`
+	Workouts []SharedProgramWorkoutResponse `json:"workouts"`
+}
+
+// SharedWeekResponse описывает неделю программы.
+type SharedWeekResponse struct {
+	Number int                 `json:"number"`
+	Days   []SharedDayResponse `json:"days"`
+}
+
+// SharedProgramResponse описывает программу, открытую по ссылке.
+type SharedProgramResponse struct {
+	Title       string               `json:"title"`
+	Description string               `json:"description"`
+	Weeks       []SharedWeekResponse `json:"weeks"`
+}
+
+// SharedResponse описывает данные, открытые по ссылке: задано одно из полей workout и program.
+// Идентификаторы владельца и данных не раскрываются.
+type SharedResponse struct {
+	ResourceType string                 `json:"resource_type" example:"workout"`
+	SharedAt     time.Time              `json:"shared_at"`
+	Workout      *SharedWorkoutResponse `json:"workout,omitempty"`
+	Program      *SharedProgramResponse `json:"program,omitempty"`
+}
@@ -0,0 +1,282 @@
+package share
+
+import (
+	"errors"
+	"math"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	programdomain "workout-app/internal/domain/program"
+	domain "workout-app/internal/domain/share"
+	workoutdomain "workout-app/internal/domain/workout"
+	"workout-app/internal/handler/middleware"
+	"workout-app/internal/handler/response"
+	shareuc "workout-app/internal/usecase/share"
+	"workout-app/pkg/logger"
+)
+
+// sharedPathPrefix — путь, по которому открывается ссылка; к нему добавляется токен.
+const sharedPathPrefix = "/api/v1/shared/"
+
+// Handler обрабатывает HTTP-запросы, связанные с публичными ссылками на тренировки и программы.
+type Handler struct {
+	shares shareuc.Service
+	logger logger.Logger
+}
+
+// NewHandler создаёт новый ShareHandler.
+func NewHandler(shares shareuc.Service, logger logger.Logger) *Handler {
+	return &Handler{
+		shares: shares,
+		logger: logger,
+	}
+}
+
+// ShareWorkout godoc
+// @Summary      Поделиться тренировкой
+// @Description  Создаёт ссылку, по которой тренировку можно посмотреть без аутентификации. Токен показывается только в этом ответе.
+// @Tags         shares
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id   path      string  true  "ID тренировки"
+// @Success      201  {object}  ShareResponse
+// @Failure      400  {object}  response.ErrorBody
+// @Failure      401  {object}  response.ErrorBody
+// @Failure      404  {object}  response.ErrorBody
+// @Failure      409  {object}  response.ErrorBody
+// @Failure      500  {object}  response.ErrorBody
+// @Router       /api/v1/workouts/{id}/share [post]
+func (h *Handler) ShareWorkout(c *gin.Context) {
+	h.create(c, domain.ResourceWorkout, "share_workout")
+}
+
+// ShareProgram godoc
+// @Summary      Поделиться программой
+// @Description  Создаёт ссылку, по которой программу можно посмотреть без аутентификации. Делиться можно только своими программами. Токен показывается только в этом ответе.
+// @Tags         shares
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id   path      string  true  "ID программы"
+// @Success      201  {object}  ShareResponse
+// @Failure      400  {object}  response.ErrorBody
+// @Failure      401  {object}  response.ErrorBody
+// @Failure      404  {object}  response.ErrorBody
+// @Failure      409  {object}  response.ErrorBody
+// @Failure      500  {object}  response.ErrorBody
+// @Router       /api/v1/programs/{id}/share [post]
+func (h *Handler) ShareProgram(c *gin.Context) {
+	h.create(c, domain.ResourceProgram, "share_program")
+}
+
+func (h *Handler) create(c *gin.Context, resourceType domain.ResourceType, op string) {
+	userID, ok := h.userID(c)
+	if !ok {
+		return
+	}
+	resourceID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid_id", "Некорректный ID", nil)
+		return
+	}
+
+	created, err := h.shares.Create(c.Request.Context(), userID, resourceType, resourceID)
+	if err != nil {
+		h.respondError(c, op, userID, err)
+		return
+	}
+	resp := toShareResponse(created.Share)
+	resp.Token = created.Token
+	resp.Path = sharedPathPrefix + created.Token
+	c.JSON(http.StatusCreated, resp)
+}
+
+// List godoc
+// @Summary      Мои публичные ссылки
+// @Description  Возвращает неотозванные ссылки текущего пользователя, новые первыми. Токены не возвращаются.
+// @Tags         shares
+// @Security     BearerAuth
+// @Produce      json
+// @Success      200  {array}   ShareResponse
+// @Failure      401  {object}  response.ErrorBody
+// @Failure      500  {object}  response.ErrorBody
+// @Router       /api/v1/shares [get]
+func (h *Handler) List(c *gin.Context) {
+	userID, ok := h.userID(c)
+	if !ok {
+		return
+	}
+
+	shares, err := h.shares.List(c.Request.Context(), userID)
+	if err != nil {
+		h.respondError(c, "list_shares", userID, err)
+		return
+	}
+	resp := make([]ShareResponse, 0, len(shares))
+	for _, s := range shares {
+		resp = append(resp, toShareResponse(s))
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// Revoke godoc
+// @Summary      Отозвать публичную ссылку
+// @Description  Ссылка перестаёт открываться сразу после отзыва.
+// @Tags         shares
+// @Security     BearerAuth
+// @Param        id  path  string  true  "ID ссылки"
+// @Success      204
+// @Failure      400  {object}  response.ErrorBody
+// @Failure      401  {object}  response.ErrorBody
+// @Failure      404  {object}  response.ErrorBody
+// @Failure      500  {object}  response.ErrorBody
+// @Router       /api/v1/shares/{id} [delete]
+func (h *Handler) Revoke(c *gin.Context) {
+	userID, ok := h.userID(c)
+	if !ok {
+		return
+	}
+	shareID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid_id", "Некорректный ID ссылки", nil)
+		return
+	}
+
+	if err := h.shares.Revoke(c.Request.Context(), userID, shareID); err != nil {
+		h.respondError(c, "revoke_share", userID, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// View godoc
+// @Summary      Открыть публичную ссылку
+// @Description  Возвращает тренировку или программу по токену ссылки без аутентификации, только для чтения. Вес — в килограммах.
+// @Tags         shares
+// @Produce      json
+// @Param        token  path      string  true  "Токен ссылки"
+// @Success      200    {object}  SharedResponse
+// @Failure      404    {object}  response.ErrorBody
+// @Failure      429    {object}  response.ErrorBody
+// @Failure      500    {object}  response.ErrorBody
+// @Router       /api/v1/shared/{token} [get]
+func (h *Handler) View(c *gin.Context) {
+	view, err := h.shares.View(c.Request.Context(), c.Param("token"))
+	if err != nil {
+		h.respondError(c, "view_share", uuid.Nil, err)
+		return
+	}
+
+	resp := SharedResponse{
+		ResourceType: string(view.Share.ResourceType),
+		SharedAt:     view.Share.CreatedAt,
+	}
+	if view.Workout != nil {
+		workout := toSharedWorkoutResponse(view.Workout, time.Now())
+		resp.Workout = &workout
+	}
+	if view.Program != nil {
+		program := toSharedProgramResponse(view.Program)
+		resp.Program = &program
+	}
+	// Ссылку можно отозвать в любой момент, поэтому ответ не кешируется.
+	c.Header("Cache-Control", "no-store")
+	c.JSON(http.StatusOK, resp)
+}
+
+// userID извлекает текущего пользователя и отвечает 401, если его нет.
+func (h *Handler) userID(c *gin.Context) (uuid.UUID, bool) {
+	userID, err := middleware.UserIDFromContext(c)
+	if err != nil {
+		response.Error(c, http.StatusUnauthorized, "unauthorized", "Требуется аутентификация", nil)
+		return uuid.Nil, false
+	}
+	return userID, true
+}
+
+// respondError маппит ошибки usecase-слоя в HTTP-ответы.
+func (h *Handler) respondError(c *gin.Context, op string, userID uuid.UUID, err error) {
+	switch {
+	case errors.Is(err, shareuc.ErrResourceNotFound):
+		response.Error(c, http.StatusNotFound, "not_found", "Тренировка или программа не найдена", nil)
+	case errors.Is(err, shareuc.ErrShareNotFound):
+		response.Error(c, http.StatusNotFound, "share_not_found", "Ссылка не найдена или отозвана", nil)
+	case errors.Is(err, shareuc.ErrTooManyShares):
+		response.Error(c, http.StatusConflict, "too_many_shares", "Слишком много активных ссылок: отзовите ненужные", nil)
+	default:
+		// Шаблон маршрута вместо пути: токен ссылки не должен попадать в лог.
+		log := map[string]any{
+			"path":   c.FullPath(),
+			"method": c.Request.Method,
+			"error":  err.Error(),
+		}
+		if userID != uuid.Nil {
+			log["user_id"] = userID.String()
+		}
+		h.logger.Error("internal_error_in_"+op, log)
+		response.Error(c, http.StatusInternalServerError, "internal_error", "Внутренняя ошибка сервера", nil)
+	}
+}
+
+func toShareResponse(s *domain.Share) ShareResponse {
+	return ShareResponse{
+		ID:           s.ID.String(),
+		ResourceType: string(s.ResourceType),
+		ResourceID:   s.ResourceID.String(),
+		CreatedAt:    s.CreatedAt,
+	}
+}
+
+// toSharedWorkoutResponse маппит тренировку в публичное представление; для идущей тренировки итоги — на момент now.
+func toSharedWorkoutResponse(s *workoutdomain.Session, now time.Time) SharedWorkoutResponse {
+	summary := s.Summarize(now.UTC())
+	resp := SharedWorkoutResponse{
+		Title:         s.Title,
+		StartedAt:     s.StartedAt,
+		FinishedAt:    s.FinishedAt,
+		Active:        s.IsActive(),
+		ActiveSeconds: int64(summary.ActiveDuration / time.Second),
+		VolumeKg:      math.Round(summary.VolumeKg*100) / 100,
+		Sets:          make([]SharedSetResponse, 0, len(s.Sets)),
+	}
+	for _, set := range s.Sets {
+		resp.Sets = append(resp.Sets, SharedSetResponse{
+			Exercise: set.Exercise,
+			Reps:     set.Reps,
+			WeightKg: set.WeightKg,
+			LoggedAt: set.LoggedAt,
+		})
+	}
+	return resp
+}
+
+// toSharedProgramResponse маппит программу в публичное представление без ссылок на видео.
+func toSharedProgramResponse(p *programdomain.Program) SharedProgramResponse {
+	weeks := make([]SharedWeekResponse, 0, len(p.Weeks))
+	for _, w := range p.Weeks {
+		days := make([]SharedDayResponse, 0, len(w.Days))
+		for _, d := range w.Days {
+			workouts := make([]SharedProgramWorkoutResponse, 0, len(d.Workouts))
+			for _, wo := range d.Workouts {
+				exercises := make([]SharedExerciseResponse, 0, len(wo.Exercises))
+				for _, e := range wo.Exercises {
+					exercises = append(exercises, SharedExerciseResponse{
+						Name:          e.Name,
+						Sets:          e.Sets,
+						Reps:          e.Reps,
+						WeightKg:      e.WeightKg,
+						LoadPercent:   e.LoadPercent,
+						LoadReference: e.LoadReference,
+						Notes:         e.Notes,
+					})
+				}
+				workouts = append(workouts, SharedProgramWorkoutResponse{Title: wo.Title, Notes: wo.Notes, Exercises: exercises})
+			}
+			days = append(days, SharedDayResponse{Day: d.Number, Workouts: workouts})
+		}
+		weeks = append(weeks, SharedWeekResponse{Number: w.Number, Days: days})
+	}
+	return SharedProgramResponse{Title: p.Title, Description: p.Description, Weeks: weeks}
+}
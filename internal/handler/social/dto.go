@@ -0,0 +1,63 @@
+package social
+
+import "time"
+
+// ConnectionResponse описывает пользователя в списке подписчиков или подписок.
+type ConnectionResponse struct {
+	UserID     string    `json:"user_id"`
+	Username   string    `json:"username"`
+	FirstName  string    `json:"first_name,omitempty"`
+	LastName   string    `json:"last_name,omitempty"`
+	AvatarURL  string    `json:"avatar_url,omitempty"`
+	FollowedAt time.Time `json:"followed_at"`
+}
+
+// ConnectionsResponse описывает страницу подписчиков или подписок.
+type ConnectionsResponse struct {
+	Items []ConnectionResponse `json:"items"`
+	Total int64                `json:"total"`
+}
+
+// AuthorResponse описывает автора записи ленты.
+type AuthorResponse struct {
+	UserID    string `json:"user_id"`
+	Username  string `json:"username"`
+	FirstName string `json:"first_name,omitempty"`
+	LastName  string `json:"last_name,omitempty"`
+	AvatarURL string `json:"avatar_url,omitempty"`
+}
+
+// FeedWorkoutResponse описывает завершённую тренировку в ленте.
+type FeedWorkoutResponse struct {
+	ID            string    `json:"id"`
+	Title         string    `json:"title"`
+	StartedAt     time.Time `json:"started_at"`
+	FinishedAt    time.Time `json:"finished_at"`
+	ActiveSeconds int64     `json:"active_seconds"`
+	Sets          int       `json:"sets"`
+	VolumeKg      float64   `json:"volume_kg"`
+	Exercises     []string  `json:"exercises"`
+}
+
+// RecordResponse описывает личный рекорд, поставленный в тренировке.
+type RecordResponse struct {
+	Exercise            string    `json:"exercise"`
+	WeightKg            float64   `json:"weight_kg"`
+	Reps                int       `json:"reps"`
+	OneRepMaxKg         float64   `json:"one_rep_max_kg"`
+	PreviousOneRepMaxKg float64   `json:"previous_one_rep_max_kg"`
+	AchievedAt          time.Time `json:"achieved_at"`
+}
+
+// FeedEntryResponse описывает запись ленты активности.
+type FeedEntryResponse struct {
+	Author  AuthorResponse      `json:"author"`
+	Workout FeedWorkoutResponse `json:"workout"`
+	Records []RecordResponse    `json:"records"`
+}
+
+// FeedResponse описывает страницу ленты активности.
+type FeedResponse struct {
+	Items []FeedEntryResponse `json:"items"`
+	Total int64               `json:"total"`
+}
@@ -0,0 +1,312 @@
+package social
+
+import (
+	"context"
+	"errors"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	domain "workout-app/internal/domain/social"
+	"workout-app/internal/handler/middleware"
+	"workout-app/internal/handler/response"
+	repo "workout-app/internal/repository/interfaces"
+	socialuc "workout-app/internal/usecase/social"
+	"workout-app/pkg/logger"
+)
+
+// Handler обрабатывает HTTP-запросы, связанные с подписками пользователей.
+type Handler struct {
+	social socialuc.Service
+	logger logger.Logger
+}
+
+// NewHandler создаёт новый SocialHandler.
+func NewHandler(social socialuc.Service, logger logger.Logger) *Handler {
+	return &Handler{
+		social: social,
+		logger: logger,
+	}
+}
+
+// Follow godoc
+// @Summary      Подписаться на пользователя
+// @Description  Подписывает текущего пользователя на тренировки другого пользователя. Повторная подписка не считается ошибкой.
+// @Tags         social
+// @Security     BearerAuth
+// @Param        id  path  string  true  "ID пользователя"
+// @Success      204
+// @Failure      400  {object}  response.ErrorBody
+// @Failure      401  {object}  response.ErrorBody
+// @Failure      404  {object}  response.ErrorBody
+// @Failure      500  {object}  response.ErrorBody
+// @Router       /api/v1/users/{id}/follow [post]
+func (h *Handler) Follow(c *gin.Context) {
+	followerID, followeeID, ok := h.pair(c)
+	if !ok {
+		return
+	}
+
+	if err := h.social.Follow(c.Request.Context(), followerID, followeeID); err != nil {
+		h.respondError(c, "follow_user", err)
+		return
+	}
+
+	h.logger.Info("user_followed", map[string]any{
+		"follower_id": followerID.String(),
+		"followee_id": followeeID.String(),
+	})
+	c.Status(http.StatusNoContent)
+}
+
+// Unfollow godoc
+// @Summary      Отписаться от пользователя
+// @Description  Отменяет подписку текущего пользователя. Отсутствие подписки не считается ошибкой.
+// @Tags         social
+// @Security     BearerAuth
+// @Param        id  path  string  true  "ID пользователя"
+// @Success      204
+// @Failure      400  {object}  response.ErrorBody
+// @Failure      401  {object}  response.ErrorBody
+// @Failure      500  {object}  response.ErrorBody
+// @Router       /api/v1/users/{id}/follow [delete]
+func (h *Handler) Unfollow(c *gin.Context) {
+	followerID, followeeID, ok := h.pair(c)
+	if !ok {
+		return
+	}
+
+	if err := h.social.Unfollow(c.Request.Context(), followerID, followeeID); err != nil {
+		h.respondError(c, "unfollow_user", err)
+		return
+	}
+
+	h.logger.Info("user_unfollowed", map[string]any{
+		"follower_id": followerID.String(),
+		"followee_id": followeeID.String(),
+	})
+	c.Status(http.StatusNoContent)
+}
+
+// Followers godoc
+// @Summary      Подписчики пользователя
+// @Description  Возвращает подписчиков пользователя, новые подписки первыми. Вместо ID можно указать me. Удалённые пользователи в список не попадают.
+// @Tags         social
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id      path      string  true   "ID пользователя или me"
+// @Param        limit   query     int     false  "Размер страницы (по умолчанию 20, максимум 100)"
+// @Param        offset  query     int     false  "Смещение"
+// @Success      200     {object}  ConnectionsResponse
+// @Failure      400     {object}  response.ErrorBody
+// @Failure      401     {object}  response.ErrorBody
+// @Failure      404     {object}  response.ErrorBody
+// @Failure      500     {object}  response.ErrorBody
+// @Router       /api/v1/users/{id}/followers [get]
+func (h *Handler) Followers(c *gin.Context) {
+	h.list(c, "list_followers", h.social.ListFollowers)
+}
+
+// Following godoc
+// @Summary      Подписки пользователя
+// @Description  Возвращает пользователей, на которых подписан пользователь, новые подписки первыми. Вместо ID можно указать me. Удалённые пользователи в список не попадают.
+// @Tags         social
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id      path      string  true   "ID пользователя или me"
+// @Param        limit   query     int     false  "Размер страницы (по умолчанию 20, максимум 100)"
+// @Param        offset  query     int     false  "Смещение"
+// @Success      200     {object}  ConnectionsResponse
+// @Failure      400     {object}  response.ErrorBody
+// @Failure      401     {object}  response.ErrorBody
+// @Failure      404     {object}  response.ErrorBody
+// @Failure      500     {object}  response.ErrorBody
+// @Router       /api/v1/users/{id}/following [get]
+func (h *Handler) Following(c *gin.Context) {
+	h.list(c, "list_following", h.social.ListFollowing)
+}
+
+// Feed godoc
+// @Summary      Лента активности
+// @Description  Возвращает завершённые тренировки пользователей, на которых подписан текущий пользователь, вместе с поставленными в них личными рекордами, новые первыми. В ленту попадают только пользователи, открывшие тренировки всем или подписчикам. Страницы ленты кешируются на CACHE_FEED_TTL.
+// @Tags         social
+// @Security     BearerAuth
+// @Produce      json
+// @Param        limit   query     int     false  "Размер страницы (по умолчанию 20, максимум 100)"
+// @Param        offset  query     int     false  "Смещение"
+// @Success      200     {object}  FeedResponse
+// @Failure      400     {object}  response.ErrorBody
+// @Failure      401     {object}  response.ErrorBody
+// @Failure      500     {object}  response.ErrorBody
+// @Router       /api/v1/feed [get]
+func (h *Handler) Feed(c *gin.Context) {
+	viewerID, err := middleware.UserIDFromContext(c)
+	if err != nil {
+		response.Error(c, http.StatusUnauthorized, "unauthorized", "Требуется аутентификация", nil)
+		return
+	}
+	limit, err1 := queryInt(c, "limit")
+	offset, err2 := queryInt(c, "offset")
+	if err1 != nil || err2 != nil {
+		response.Error(c, http.StatusBadRequest, "invalid_pagination", "Параметры limit и offset должны быть неотрицательными числами", nil)
+		return
+	}
+
+	entries, total, err := h.social.Feed(c.Request.Context(), viewerID, limit, offset)
+	if err != nil {
+		h.respondError(c, "feed", err)
+		return
+	}
+
+	resp := FeedResponse{Items: make([]FeedEntryResponse, 0, len(entries)), Total: total}
+	for _, entry := range entries {
+		resp.Items = append(resp.Items, toFeedEntryResponse(entry))
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+type listFunc func(ctx context.Context, userID uuid.UUID, limit, offset int) ([]*domain.Connection, int64, error)
+
+// list отдаёт страницу подписчиков или подписок пользователя из пути.
+func (h *Handler) list(c *gin.Context, op string, fetch listFunc) {
+	currentID, err := middleware.UserIDFromContext(c)
+	if err != nil {
+		response.Error(c, http.StatusUnauthorized, "unauthorized", "Требуется аутентификация", nil)
+		return
+	}
+	userID := currentID
+	// Маршруты /users/me/... регистрируются отдельно и приходят без параметра id.
+	if raw := c.Param("id"); raw != "" && raw != "me" {
+		if userID, err = uuid.Parse(raw); err != nil {
+			response.Error(c, http.StatusBadRequest, "invalid_user_id", "Некорректный ID пользователя", nil)
+			return
+		}
+	}
+	limit, err1 := queryInt(c, "limit")
+	offset, err2 := queryInt(c, "offset")
+	if err1 != nil || err2 != nil {
+		response.Error(c, http.StatusBadRequest, "invalid_pagination", "Параметры limit и offset должны быть неотрицательными числами", nil)
+		return
+	}
+
+	connections, total, err := fetch(c.Request.Context(), userID, limit, offset)
+	if err != nil {
+		h.respondError(c, op, err)
+		return
+	}
+
+	resp := ConnectionsResponse{Items: make([]ConnectionResponse, 0, len(connections)), Total: total}
+	for _, conn := range connections {
+		resp.Items = append(resp.Items, ConnectionResponse{
+			UserID:     conn.UserID.String(),
+			Username:   conn.Username,
+			FirstName:  conn.FirstName,
+			LastName:   conn.LastName,
+			AvatarURL:  conn.AvatarURL,
+			FollowedAt: conn.FollowedAt,
+		})
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// toFeedEntryResponse маппит запись ленты в DTO.
+func toFeedEntryResponse(entry *domain.FeedEntry) FeedEntryResponse {
+	s := entry.Session
+	summary := s.Summarize(time.Now().UTC())
+	workout := FeedWorkoutResponse{
+		ID:            s.ID.String(),
+		Title:         s.Title,
+		StartedAt:     s.StartedAt,
+		ActiveSeconds: int64(summary.ActiveDuration / time.Second),
+		Sets:          summary.Sets,
+		VolumeKg:      round2(summary.VolumeKg),
+		Exercises:     []string{},
+	}
+	if s.FinishedAt != nil {
+		workout.FinishedAt = *s.FinishedAt
+	}
+	seen := make(map[string]bool)
+	for _, set := range s.Sets {
+		if !seen[set.Exercise] {
+			seen[set.Exercise] = true
+			workout.Exercises = append(workout.Exercises, set.Exercise)
+		}
+	}
+
+	resp := FeedEntryResponse{
+		Author: AuthorResponse{
+			UserID:    entry.Author.UserID.String(),
+			Username:  entry.Author.Username,
+			FirstName: entry.Author.FirstName,
+			LastName:  entry.Author.LastName,
+			AvatarURL: entry.Author.AvatarURL,
+		},
+		Workout: workout,
+		Records: make([]RecordResponse, 0, len(entry.Records)),
+	}
+	for _, r := range entry.Records {
+		resp.Records = append(resp.Records, RecordResponse{
+			Exercise:            r.Exercise,
+			WeightKg:            r.WeightKg,
+			Reps:                r.Reps,
+			OneRepMaxKg:         round2(r.OneRepMaxKg),
+			PreviousOneRepMaxKg: round2(r.PreviousOneRepMaxKg),
+			AchievedAt:          r.AchievedAt,
+		})
+	}
+	return resp
+}
+
+// pair извлекает текущего пользователя и пользователя из пути.
+func (h *Handler) pair(c *gin.Context) (uuid.UUID, uuid.UUID, bool) {
+	currentID, err := middleware.UserIDFromContext(c)
+	if err != nil {
+		response.Error(c, http.StatusUnauthorized, "unauthorized", "Требуется аутентификация", nil)
+		return uuid.Nil, uuid.Nil, false
+	}
+	targetID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid_user_id", "Некорректный ID пользователя", nil)
+		return uuid.Nil, uuid.Nil, false
+	}
+	return currentID, targetID, true
+}
+
+// respondError переводит ошибки usecase-слоя в HTTP-ответы.
+func (h *Handler) respondError(c *gin.Context, op string, err error) {
+	switch {
+	case errors.Is(err, socialuc.ErrCannotFollowSelf):
+		response.Error(c, http.StatusBadRequest, "cannot_follow_self", "Нельзя подписаться на себя", nil)
+	case errors.Is(err, repo.ErrNotFound):
+		response.Error(c, http.StatusNotFound, "user_not_found", "Пользователь не найден", nil)
+	default:
+		h.logger.Error("internal_error_in_"+op, map[string]any{
+			"path":   c.Request.URL.Path,
+			"method": c.Request.Method,
+			"error":  err.Error(),
+		})
+		response.Error(c, http.StatusInternalServerError, "internal_error", "Внутренняя ошибка сервера", nil)
+	}
+}
+
+// queryInt разбирает неотрицательный числовой параметр запроса; пустой параметр — 0.
+func queryInt(c *gin.Context, name string) (int, error) {
+	raw := c.Query(name)
+	if raw == "" {
+		return 0, nil
+	}
+	n, err := strconv.Atoi(raw)
+	if err != nil || n < 0 {
+		return 0, strconv.ErrSyntax
+	}
+	return n, nil
+}
+
+// round2 округляет значение до сотых.
+func round2(v float64) float64 {
+	return math.Round(v*100) / 100
+}
@@ -0,0 +1,67 @@
+package strength
+
+import "time"
+
+// RecordsResponse описывает личные рекорды пользователя с оценкой по нормативам.
+type RecordsResponse struct {
+	// Gender — пол, по которому оценены рекорды (male, female); пусто, если неизвестен.
+	Gender string `json:"gender,omitempty" example:"male"`
+	// BodyWeightKg — вес тела для оценки; не задан, если неизвестен.
+	BodyWeightKg *float64         `json:"body_weight_kg,omitempty" example:"82.5"`
+	Records      []RecordResponse `json:"records"`
+}
+
+// RecordResponse описывает лучший подход в упражнении.
+type RecordResponse struct {
+	Exercise    string    `json:"exercise" example:"Приседания"`
+	WeightKg    float64   `json:"weight_kg" example:"120"`
+	Reps        int       `json:"reps" example:"3"`
+	OneRepMaxKg float64   `json:"one_rep_max_kg" example:"132"`
+	SessionID   string    `json:"session_id"`
+	AchievedAt  time.Time `json:"achieved_at"`
+	// Standard — упражнение норматива, по которому оценён рекорд; пусто, если норматива нет.
+	Standard       string                  `json:"standard,omitempty" example:"squat"`
+	Classification *ClassificationResponse `json:"classification,omitempty"`
+}
+
+// ClassificationResponse описывает уровень и процентиль результата.
+type ClassificationResponse struct {
+	// Level — beginner, novice, intermediate, advanced, elite; пусто, если результат ниже beginner.
+	Level string `json:"level,omitempty" example:"intermediate"`
+	// Percentile — доля атлетов того же пола и веса со слабее результатом, %.
+	Percentile float64            `json:"percentile" example:"61.3"`
+	Thresholds ThresholdsResponse `json:"thresholds"`
+	NextLevel  string             `json:"next_level,omitempty" example:"advanced"`
+	ToNextKg   float64            `json:"to_next_kg,omitempty" example:"18"`
+}
+
+// ThresholdsResponse описывает пороги разового максимума (кг) для уровней.
+type ThresholdsResponse struct {
+	BeginnerKg     float64 `json:"beginner_kg" binding:"gt=0" example:"55"`
+	NoviceKg       float64 `json:"novice_kg" binding:"gt=0" example:"80"`
+	IntermediateKg float64 `json:"intermediate_kg" binding:"gt=0" example:"110"`
+	AdvancedKg     float64 `json:"advanced_kg" binding:"gt=0" example:"150"`
+	EliteKg        float64 `json:"elite_kg" binding:"gt=0" example:"190"`
+}
+
+// StandardResponse описывает норматив упражнения для пола.
+type StandardResponse struct {
+	Exercise string               `json:"exercise" example:"squat"`
+	Gender   string               `json:"gender" example:"male"`
+	Aliases  []string             `json:"aliases"`
+	Rows     []StandardRowPayload `json:"rows"`
+}
+
+// StandardRowPayload описывает пороги уровней для веса тела.
+type StandardRowPayload struct {
+	BodyWeightKg float64 `json:"body_weight_kg" binding:"gt=0" example:"80"`
+	ThresholdsResponse
+}
+
+// SetStandardRequest описывает тело запроса замены норматива.
+type SetStandardRequest struct {
+	Gender string `json:"gender" binding:"required,oneof=male female" example:"male"`
+	// Aliases — синонимы упражнения для сопоставления с подходами; не передан — прежние сохраняются.
+	Aliases []string             `json:"aliases,omitempty" binding:"omitempty,max=20,dive,required,max=100"`
+	Rows    []StandardRowPayload `json:"rows" binding:"required,min=1,max=50,dive"`
+}
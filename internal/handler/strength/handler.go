@@ -0,0 +1,216 @@
+package strength
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	domain "workout-app/internal/domain/strength"
+	"workout-app/internal/handler/middleware"
+	"workout-app/internal/handler/response"
+	"workout-app/internal/handler/validation"
+	strengthuc "workout-app/internal/usecase/strength"
+	"workout-app/pkg/logger"
+)
+
+// Handler обрабатывает HTTP-запросы личных рекордов и нормативов силы.
+type Handler struct {
+	strength strengthuc.Service
+	logger   logger.Logger
+}
+
+// NewHandler создаёт новый StrengthHandler.
+func NewHandler(strength strengthuc.Service, logger logger.Logger) *Handler {
+	return &Handler{
+		strength: strength,
+		logger:   logger,
+	}
+}
+
+// Records godoc
+// @Summary      Личные рекорды с уровнем силы
+// @Description  Возвращает лучший подход (по оценке разового максимума, до 10 повторений) в каждом упражнении.
+// @Description  Для упражнений с нормативом добавляет уровень (beginner…elite) и процентиль относительно атлетов того же пола и веса.
+// @Description  Пол и вес тела берутся из профиля и последнего замера, если не заданы в запросе.
+// @Tags         workouts
+// @Security     BearerAuth
+// @Produce      json
+// @Param        gender          query     string  false  "Пол для оценки: male, female"
+// @Param        body_weight_kg  query     number  false  "Вес тела для оценки, кг"
+// @Success      200             {object}  RecordsResponse
+// @Failure      400             {object}  response.ErrorBody
+// @Failure      401             {object}  response.ErrorBody
+// @Failure      500             {object}  response.ErrorBody
+// @Router       /api/v1/workouts/records [get]
+func (h *Handler) Records(c *gin.Context) {
+	userID, err := middleware.UserIDFromContext(c)
+	if err != nil {
+		response.Error(c, http.StatusUnauthorized, "unauthorized", "Требуется аутентификация", nil)
+		return
+	}
+
+	opts := strengthuc.RecordsOptions{Gender: c.Query("gender")}
+	if raw := c.Query("body_weight_kg"); raw != "" {
+		bw, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			response.Error(c, http.StatusBadRequest, "invalid_body_weight", "Некорректный вес тела", nil)
+			return
+		}
+		opts.BodyWeightKg = &bw
+	}
+
+	report, err := h.strength.Records(c.Request.Context(), userID, opts)
+	if err != nil {
+		h.respondError(c, "workout_records", err)
+		return
+	}
+
+	resp := RecordsResponse{
+		Gender:       string(report.Gender),
+		BodyWeightKg: report.BodyWeightKg,
+		Records:      make([]RecordResponse, 0, len(report.Records)),
+	}
+	for _, r := range report.Records {
+		rec := RecordResponse{
+			Exercise:    r.Exercise,
+			WeightKg:    r.WeightKg,
+			Reps:        r.Reps,
+			OneRepMaxKg: r.OneRepMaxKg,
+			SessionID:   r.SessionID.String(),
+			AchievedAt:  r.AchievedAt,
+			Standard:    r.Standard,
+		}
+		if cl := r.Classification; cl != nil {
+			rec.Classification = &ClassificationResponse{
+				Level:      string(cl.Level),
+				Percentile: cl.Percentile,
+				Thresholds: toThresholds(cl.ThresholdsKg),
+				NextLevel:  string(cl.NextLevel),
+				ToNextKg:   cl.ToNextKg,
+			}
+		}
+		resp.Records = append(resp.Records, rec)
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// ListStandards godoc
+// @Summary      Нормативы силы
+// @Description  Возвращает пороги разового максимума для уровней beginner…elite по упражнениям, полу и весу тела.
+// @Description  Между строками пороги интерполируются линейно.
+// @Tags         workouts
+// @Security     BearerAuth
+// @Produce      json
+// @Success      200  {array}   StandardResponse
+// @Failure      401  {object}  response.ErrorBody
+// @Failure      500  {object}  response.ErrorBody
+// @Router       /api/v1/strength-standards [get]
+func (h *Handler) ListStandards(c *gin.Context) {
+	standards, err := h.strength.ListStandards(c.Request.Context())
+	if err != nil {
+		h.respondError(c, "list_strength_standards", err)
+		return
+	}
+	resp := make([]StandardResponse, 0, len(standards))
+	for _, s := range standards {
+		resp = append(resp, toStandardResponse(s))
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// SetStandard godoc
+// @Summary      Заменить норматив силы (админ)
+// @Description  Заменяет строки норматива упражнения для пола. Пороги в каждой строке должны строго расти от beginner к elite.
+// @Description  Если передан aliases, синонимы упражнения заменяются.
+// @Tags         admin
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        exercise  path      string              true  "Упражнение норматива"
+// @Param        payload   body      SetStandardRequest  true  "Норматив"
+// @Success      200       {object}  StandardResponse
+// @Failure      400       {object}  response.ErrorBody
+// @Failure      401       {object}  response.ErrorBody
+// @Failure      403       {object}  response.ErrorBody
+// @Failure      409       {object}  response.ErrorBody
+// @Failure      500       {object}  response.ErrorBody
+// @Router       /api/v1/admin/strength-standards/{exercise} [put]
+func (h *Handler) SetStandard(c *gin.Context) {
+	var req SetStandardRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid_request", "Некорректное тело запроса", validation.Details(c, err))
+		return
+	}
+
+	input := strengthuc.StandardInput{
+		Exercise: c.Param("exercise"),
+		Gender:   req.Gender,
+		Aliases:  req.Aliases,
+		Rows:     make([]domain.Row, 0, len(req.Rows)),
+	}
+	for _, r := range req.Rows {
+		input.Rows = append(input.Rows, domain.Row{
+			BodyWeightKg: r.BodyWeightKg,
+			ThresholdsKg: [5]float64{r.BeginnerKg, r.NoviceKg, r.IntermediateKg, r.AdvancedKg, r.EliteKg},
+		})
+	}
+
+	s, err := h.strength.SetStandard(c.Request.Context(), input)
+	if err != nil {
+		h.respondError(c, "set_strength_standard", err)
+		return
+	}
+	c.JSON(http.StatusOK, toStandardResponse(s))
+}
+
+// respondError отправляет ответ об ошибке для эндпоинтов рекордов и нормативов.
+func (h *Handler) respondError(c *gin.Context, op string, err error) {
+	switch {
+	case errors.Is(err, strengthuc.ErrInvalidGender):
+		response.Error(c, http.StatusBadRequest, "invalid_gender", "Некорректный пол: допустимы male и female", nil)
+	case errors.Is(err, strengthuc.ErrInvalidBodyWeight):
+		response.Error(c, http.StatusBadRequest, "invalid_body_weight", "Некорректный вес тела", err.Error())
+	case errors.Is(err, strengthuc.ErrInvalidStandard):
+		response.Error(c, http.StatusBadRequest, "invalid_standard", "Некорректный норматив: пороги должны быть положительными и расти от beginner к elite", nil)
+	case errors.Is(err, strengthuc.ErrAliasTaken):
+		response.Error(c, http.StatusConflict, "alias_taken", "Синоним уже закреплён за другим упражнением", nil)
+	case errors.Is(err, strengthuc.ErrUserNotFound):
+		response.Error(c, http.StatusNotFound, "user_not_found", "Пользователь не найден", nil)
+	default:
+		h.logger.Error("internal_error_in_"+op, map[string]any{
+			"user_id": c.GetString(middleware.ContextUserIDKey),
+			"path":    c.Request.URL.Path,
+			"method":  c.Request.Method,
+			"error":   err.Error(),
+		})
+		response.Error(c, http.StatusInternalServerError, "internal_error", "Внутренняя ошибка сервера", nil)
+	}
+}
+
+func toThresholds(th [5]float64) ThresholdsResponse {
+	return ThresholdsResponse{
+		BeginnerKg:     th[0],
+		NoviceKg:       th[1],
+		IntermediateKg: th[2],
+		AdvancedKg:     th[3],
+		EliteKg:        th[4],
+	}
+}
+
+func toStandardResponse(s *domain.Standard) StandardResponse {
+	resp := StandardResponse{
+		Exercise: s.Exercise,
+		Gender:   string(s.Gender),
+		Aliases:  s.Aliases,
+		Rows:     make([]StandardRowPayload, 0, len(s.Rows)),
+	}
+	if resp.Aliases == nil {
+		resp.Aliases = []string{}
+	}
+	for _, r := range s.Rows {
+		resp.Rows = append(resp.Rows, StandardRowPayload{BodyWeightKg: r.BodyWeightKg, ThresholdsResponse: toThresholds(r.ThresholdsKg)})
+	}
+	return resp
+}
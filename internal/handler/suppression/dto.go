@@ -0,0 +1,32 @@
+package suppression
+
+import "time"
+
+// AddSuppressionRequest описывает тело запроса ручного добавления адреса в список подавления.
+type AddSuppressionRequest struct {
+	Email  string `json:"email" binding:"required"`
+	Reason string `json:"reason,omitempty"`
+}
+
+// SuppressionResponse описывает запись списка подавления.
+type SuppressionResponse struct {
+	Email     string    `json:"email"`
+	Source    string    `json:"source"`
+	Provider  string    `json:"provider"`
+	Reason    string    `json:"reason,omitempty"`
+	CreatedBy *string   `json:"created_by,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// SuppressionListResponse описывает страницу списка подавления.
+type SuppressionListResponse struct {
+	Items []SuppressionResponse `json:"items"`
+	Total int64                 `json:"total"`
+}
+
+// ImportResponse описывает итог импорта выгрузки провайдера.
+type ImportResponse struct {
+	Added   int `json:"added"`
+	Skipped int `json:"skipped"`
+	Invalid int `json:"invalid"`
+}
@@ -0,0 +1,258 @@
+package suppression
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	domain "workout-app/internal/domain/deliverability"
+	"workout-app/internal/handler/middleware"
+	"workout-app/internal/handler/response"
+	repo "workout-app/internal/repository/interfaces"
+	suppressionuc "workout-app/internal/usecase/suppression"
+	"workout-app/pkg/logger"
+)
+
+// maxImportBytes ограничивает размер загружаемой выгрузки провайдера.
+const maxImportBytes = 20 << 20
+
+// Handler обрабатывает административные запросы к списку подавления писем.
+type Handler struct {
+	suppressions suppressionuc.Service
+	logger       logger.Logger
+}
+
+// NewHandler создаёт новый SuppressionHandler.
+func NewHandler(suppressions suppressionuc.Service, logger logger.Logger) *Handler {
+	return &Handler{
+		suppressions: suppressions,
+		logger:       logger,
+	}
+}
+
+// List godoc
+// @Summary      Список подавления писем (админ)
+// @Description  Возвращает адреса, на которые не отправляются письма, новые первыми.
+// @Tags         email
+// @Security     BearerAuth
+// @Produce      json
+// @Param        source  query     string  false  "Источник: bounce, complaint или manual"
+// @Param        limit   query     int     false  "Размер страницы (по умолчанию 50, максимум 200)"
+// @Param        offset  query     int     false  "Смещение"
+// @Success      200     {object}  SuppressionListResponse
+// @Failure      400     {object}  response.ErrorBody
+// @Failure      401     {object}  response.ErrorBody
+// @Failure      403     {object}  response.ErrorBody
+// @Failure      500     {object}  response.ErrorBody
+// @Router       /api/v1/admin/email/suppressions [get]
+func (h *Handler) List(c *gin.Context) {
+	limit, err1 := queryInt(c, "limit")
+	offset, err2 := queryInt(c, "offset")
+	if err1 != nil || err2 != nil {
+		response.Error(c, http.StatusBadRequest, "invalid_pagination", "Параметры limit и offset должны быть неотрицательными числами", nil)
+		return
+	}
+
+	items, total, err := h.suppressions.List(c.Request.Context(), domain.SuppressionSource(c.Query("source")), limit, offset)
+	if err != nil {
+		h.respondError(c, "list_suppressions", err)
+		return
+	}
+
+	resp := SuppressionListResponse{Items: make([]SuppressionResponse, 0, len(items)), Total: total}
+	for _, s := range items {
+		resp.Items = append(resp.Items, toSuppressionResponse(s))
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// Add godoc
+// @Summary      Добавить адрес в список подавления (админ)
+// @Description  Запрещает отправку писем на адрес. Запись получает источник manual.
+// @Tags         email
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        payload  body      AddSuppressionRequest  true  "Адрес и комментарий"
+// @Success      201      {object}  SuppressionResponse
+// @Failure      400      {object}  response.ErrorBody
+// @Failure      401      {object}  response.ErrorBody
+// @Failure      403      {object}  response.ErrorBody
+// @Failure      409      {object}  response.ErrorBody
+// @Failure      500      {object}  response.ErrorBody
+// @Router       /api/v1/admin/email/suppressions [post]
+func (h *Handler) Add(c *gin.Context) {
+	actorID, err := middleware.UserIDFromContext(c)
+	if err != nil {
+		response.Error(c, http.StatusUnauthorized, "unauthorized", "Требуется аутентификация", nil)
+		return
+	}
+
+	var req AddSuppressionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid_request", "Некорректное тело запроса", err.Error())
+		return
+	}
+
+	entry, err := h.suppressions.Add(c.Request.Context(), actorID, req.Email, req.Reason)
+	if err != nil {
+		h.respondError(c, "add_suppression", err)
+		return
+	}
+
+	h.logger.Info("email_suppression_added", map[string]any{
+		"email":    entry.Email,
+		"actor_id": actorID.String(),
+	})
+	c.JSON(http.StatusCreated, toSuppressionResponse(entry))
+}
+
+// Remove godoc
+// @Summary      Удалить адрес из списка подавления (админ)
+// @Description  Снова разрешает отправку писем на адрес, независимо от источника записи.
+// @Tags         email
+// @Security     BearerAuth
+// @Param        email  path  string  true  "Email"
+// @Success      204
+// @Failure      401  {object}  response.ErrorBody
+// @Failure      403  {object}  response.ErrorBody
+// @Failure      404  {object}  response.ErrorBody
+// @Failure      500  {object}  response.ErrorBody
+// @Router       /api/v1/admin/email/suppressions/{email} [delete]
+func (h *Handler) Remove(c *gin.Context) {
+	email := c.Param("email")
+	if err := h.suppressions.Remove(c.Request.Context(), email); err != nil {
+		h.respondError(c, "remove_suppression", err)
+		return
+	}
+
+	h.logger.Info("email_suppression_removed", map[string]any{
+		"email":    email,
+		"actor_id": c.GetString(middleware.ContextUserIDKey),
+	})
+	c.Status(http.StatusNoContent)
+}
+
+// Import godoc
+// @Summary      Импортировать выгрузку провайдера в список подавления (админ)
+// @Description  Принимает CSV-выгрузку (bounces, spam reports и т.п.) в поле file формы multipart/form-data. Колонка с адресом определяется по заголовку (email, address, recipient), иначе используется первая колонка. Уже присутствующие адреса не меняются.
+// @Tags         email
+// @Security     BearerAuth
+// @Accept       multipart/form-data
+// @Produce      json
+// @Param        file      formData  file    true  "CSV-выгрузка"
+// @Param        provider  formData  string  true  "Провайдер (sendgrid, mailgun, ses и т.п.)"
+// @Param        source    formData  string  true  "Тип выгрузки: bounce, complaint или manual"
+// @Success      200       {object}  ImportResponse
+// @Failure      400       {object}  response.ErrorBody
+// @Failure      401       {object}  response.ErrorBody
+// @Failure      403       {object}  response.ErrorBody
+// @Failure      413       {object}  response.ErrorBody
+// @Failure      500       {object}  response.ErrorBody
+// @Router       /api/v1/admin/email/suppressions/import [post]
+func (h *Handler) Import(c *gin.Context) {
+	actorID, err := middleware.UserIDFromContext(c)
+	if err != nil {
+		response.Error(c, http.StatusUnauthorized, "unauthorized", "Требуется аутентификация", nil)
+		return
+	}
+
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxImportBytes)
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		var maxErr *http.MaxBytesError
+		if errors.As(err, &maxErr) {
+			response.Error(c, http.StatusRequestEntityTooLarge, "import_too_large", "Файл выгрузки слишком большой", gin.H{"max_bytes": maxImportBytes})
+			return
+		}
+		response.Error(c, http.StatusBadRequest, "invalid_request", "Ожидается файл в поле file формы multipart/form-data", nil)
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid_request", "Не удалось прочитать файл", nil)
+		return
+	}
+	defer file.Close()
+
+	result, err := h.suppressions.Import(c.Request.Context(), actorID, suppressionuc.ImportInput{
+		Provider: c.PostForm("provider"),
+		Source:   domain.SuppressionSource(c.PostForm("source")),
+		Data:     file,
+	})
+	if err != nil {
+		h.respondError(c, "import_suppressions", err)
+		return
+	}
+
+	h.logger.Info("email_suppressions_imported", map[string]any{
+		"provider": c.PostForm("provider"),
+		"source":   c.PostForm("source"),
+		"added":    result.Added,
+		"skipped":  result.Skipped,
+		"invalid":  result.Invalid,
+		"actor_id": actorID.String(),
+	})
+	c.JSON(http.StatusOK, ImportResponse{Added: result.Added, Skipped: result.Skipped, Invalid: result.Invalid})
+}
+
+// respondError отправляет ответ об ошибке для эндпоинтов списка подавления.
+func (h *Handler) respondError(c *gin.Context, op string, err error) {
+	switch {
+	case errors.Is(err, suppressionuc.ErrInvalidEmail):
+		response.Error(c, http.StatusBadRequest, "invalid_email", "Некорректный email", nil)
+	case errors.Is(err, suppressionuc.ErrReasonTooLong):
+		response.Error(c, http.StatusBadRequest, "reason_too_long", "Комментарий не должен превышать 1000 символов", nil)
+	case errors.Is(err, suppressionuc.ErrInvalidSource):
+		response.Error(c, http.StatusBadRequest, "invalid_source", "Источник должен быть bounce, complaint или manual", nil)
+	case errors.Is(err, suppressionuc.ErrInvalidProvider):
+		response.Error(c, http.StatusBadRequest, "invalid_provider", "Укажите провайдера (до 32 символов)", nil)
+	case errors.Is(err, suppressionuc.ErrInvalidImportFile):
+		response.Error(c, http.StatusBadRequest, "invalid_import_file", "Файл не является CSV-выгрузкой с адресами", err.Error())
+	case errors.Is(err, suppressionuc.ErrImportTooLarge):
+		response.Error(c, http.StatusRequestEntityTooLarge, "import_too_large", "Слишком много строк в выгрузке", nil)
+	case errors.Is(err, suppressionuc.ErrAlreadySuppressed):
+		response.Error(c, http.StatusConflict, "already_suppressed", "Адрес уже в списке подавления", nil)
+	case errors.Is(err, repo.ErrNotFound):
+		response.Error(c, http.StatusNotFound, "suppression_not_found", "Адреса нет в списке подавления", nil)
+	default:
+		h.logger.Error("internal_error_in_"+op, map[string]any{
+			"path":   c.Request.URL.Path,
+			"method": c.Request.Method,
+			"error":  err.Error(),
+		})
+		response.Error(c, http.StatusInternalServerError, "internal_error", "Внутренняя ошибка сервера", nil)
+	}
+}
+
+// queryInt читает неотрицательный целочисленный параметр запроса (0, если не задан).
+func queryInt(c *gin.Context, name string) (int, error) {
+	raw := c.Query(name)
+	if raw == "" {
+		return 0, nil
+	}
+	n, err := strconv.Atoi(raw)
+	if err != nil || n < 0 {
+		return 0, strconv.ErrSyntax
+	}
+	return n, nil
+}
+
+// toSuppressionResponse маппит доменную модель в DTO.
+func toSuppressionResponse(s *domain.Suppression) SuppressionResponse {
+	resp := SuppressionResponse{
+		Email:     s.Email,
+		Source:    string(s.Source),
+		Provider:  s.Provider,
+		Reason:    s.Reason,
+		CreatedAt: s.CreatedAt,
+	}
+	if s.CreatedBy != nil {
+		by := s.CreatedBy.String()
+		resp.CreatedBy = &by
+	}
+	return resp
+}
@@ -15,8 +15,34 @@ type ProfileResponse struct {
 	AvatarURL     string     `json:"avatar_url,omitempty"`
 	Role          string     `json:"role,omitempty"`
 	TrainingLevel string     `json:"training_level,omitempty"`
-	CreatedAt     time.Time  `json:"created_at"`
-	UpdatedAt     time.Time  `json:"updated_at"`
+	Language      string     `json:"language,omitempty"`
+	Country       string     `json:"country,omitempty"`
+	Region        string     `json:"region,omitempty"`
+	// Links — ссылки на соцсети и сайт вместе с их видимостью.
+	Links *ProfileLinksResponse `json:"links,omitempty"`
+	// WorkoutsVisibility — кто видит тренировки: public, followers или private.
+	WorkoutsVisibility string `json:"workouts_visibility"`
+	// Timezone — часовой пояс IANA; пусто — UTC.
+	Timezone string `json:"timezone,omitempty"`
+	// Locale — локаль BCP 47 для форматов дат; пусто — форматы языка.
+	Locale string `json:"locale,omitempty"`
+	// Units — система единиц веса и замеров в API: metric или imperial.
+	Units string `json:"units"`
+	// ReminderTime — время напоминания о тренировках дня (HH:MM); отсутствует, если напоминания выключены.
+	ReminderTime string `json:"reminder_time,omitempty"`
+	// CalorieTarget — дневная цель по калориям, ккал; отсутствует, если не задана.
+	CalorieTarget *int `json:"calorie_target,omitempty"`
+	// Suspension присутствует, только если аккаунт заблокирован в данный момент.
+	Suspension *SuspensionResponse `json:"suspension,omitempty"`
+	CreatedAt  time.Time           `json:"created_at"`
+	UpdatedAt  time.Time           `json:"updated_at"`
+}
+
+// SuspensionResponse описывает действующую блокировку аккаунта.
+type SuspensionResponse struct {
+	SuspendedAt    time.Time  `json:"suspended_at"`
+	SuspendedUntil *time.Time `json:"suspended_until,omitempty"`
+	Reason         string     `json:"reason,omitempty"`
 }
 
 // ProfileUpdateRequest описывает тело запроса для отдельного эндпоинта
@@ -30,6 +56,47 @@ type ProfileUpdateRequest struct {
 	Gender        *string    `json:"gender,omitempty"`
 	AvatarURL     *string    `json:"avatar_url,omitempty"`
 	TrainingLevel *string    `json:"training_level,omitempty"`
+	// Language — язык писем: ru или en.
+	Language *string `json:"language,omitempty" binding:"omitempty,oneof=ru en"`
+	// Units — система единиц веса и замеров: metric (кг, см) или imperial (фунты, дюймы).
+	Units *string `json:"units,omitempty" binding:"omitempty,oneof=metric imperial" example:"imperial"`
+	// Instagram — имя пользователя (с @ или без) или ссылка на профиль; пустая строка удаляет ссылку.
+	Instagram *string `json:"instagram,omitempty" binding:"omitempty,max=200"`
+	// YouTube — handle (@name), ID канала или ссылка на канал; пустая строка удаляет ссылку.
+	YouTube *string `json:"youtube,omitempty" binding:"omitempty,max=200"`
+	// Website — адрес сайта (http или https; без схемы подставляется https); пустая строка удаляет ссылку.
+	Website *string `json:"website,omitempty" binding:"omitempty,max=255"`
+	// Видимость ссылок: public — в публичном профиле, private — только владельцу.
+	InstagramVisibility *string `json:"instagram_visibility,omitempty" binding:"omitempty,oneof=public private"`
+	YouTubeVisibility   *string `json:"youtube_visibility,omitempty" binding:"omitempty,oneof=public private"`
+	WebsiteVisibility   *string `json:"website_visibility,omitempty" binding:"omitempty,oneof=public private"`
+	// WorkoutsVisibility — кто видит тренировки: public — все пользователи, followers — подписчики, private — только владелец.
+	WorkoutsVisibility *string `json:"workouts_visibility,omitempty" binding:"omitempty,oneof=public followers private"`
+	// Timezone — часовой пояс IANA (например, Europe/Moscow); пустая строка — UTC.
+	Timezone *string `json:"timezone,omitempty" binding:"omitempty,max=64"`
+	// Locale — локаль BCP 47 для форматов дат в письмах (например, en-GB); пустая строка — форматы языка.
+	Locale *string `json:"locale,omitempty" binding:"omitempty,max=35" example:"en-GB"`
+	// ReminderTime — время напоминания о тренировках дня (HH:MM) в часовом поясе пользователя; пустая строка выключает напоминания.
+	ReminderTime *string `json:"reminder_time,omitempty" example:"08:00"`
+	// CalorieTarget — дневная цель по калориям (500–10000 ккал); 0 удаляет цель.
+	CalorieTarget *int `json:"calorie_target,omitempty" example:"2200"`
+}
+
+// ProfileLinkResponse описывает ссылку профиля.
+type ProfileLinkResponse struct {
+	// Value — нормализованное значение: handle Instagram, handle YouTube (@name) или ID канала, адрес сайта.
+	Value string `json:"value" example:"jane.doe"`
+	// URL — адрес страницы для отображения.
+	URL string `json:"url" example:"https://www.instagram.com/jane.doe/"`
+	// Visibility — public или private; возвращается только владельцу профиля.
+	Visibility string `json:"visibility,omitempty" example:"public"`
+}
+
+// ProfileLinksResponse описывает указанные ссылки профиля; отсутствующие ссылки не возвращаются.
+type ProfileLinksResponse struct {
+	Instagram *ProfileLinkResponse `json:"instagram,omitempty"`
+	YouTube   *ProfileLinkResponse `json:"youtube,omitempty"`
+	Website   *ProfileLinkResponse `json:"website,omitempty"`
 }
 
 // PublicProfileResponse описывает публичный профиль пользователя.
@@ -44,8 +111,48 @@ type PublicProfileResponse struct {
 	AvatarURL     string     `json:"avatar_url,omitempty"`
 	Role          string     `json:"role,omitempty"`
 	TrainingLevel string     `json:"training_level,omitempty"`
-	CreatedAt     time.Time  `json:"created_at"`
-	UpdatedAt     time.Time  `json:"updated_at"`
+	// Links — только ссылки, которые пользователь сделал публичными.
+	Links     *ProfileLinksResponse `json:"links,omitempty"`
+	CreatedAt time.Time             `json:"created_at"`
+	UpdatedAt time.Time             `json:"updated_at"`
+}
+
+// UsernameChangeResponse описывает запись истории смены username.
+type UsernameChangeResponse struct {
+	OldUsername string    `json:"old_username"`
+	NewUsername string    `json:"new_username"`
+	ChangedAt   time.Time `json:"changed_at"`
+	// ReservedUntil — до этого момента старое имя не может занять другой пользователь.
+	ReservedUntil time.Time `json:"reserved_until"`
+}
+
+// UsernameHistoryResponse описывает историю смены username текущего пользователя.
+type UsernameHistoryResponse struct {
+	Items []UsernameChangeResponse `json:"items"`
+}
+
+// FieldChangeResponse описывает изменение одного поля профиля; пустая строка — поле не было заполнено.
+type FieldChangeResponse struct {
+	Field string `json:"field"`
+	Old   string `json:"old"`
+	New   string `json:"new"`
+}
+
+// ProfileChangeResponse описывает запись истории изменений профиля.
+type ProfileChangeResponse struct {
+	ID string `json:"id"`
+	// Actor — кто изменил профиль: self (сам пользователь) или admin.
+	Actor string `json:"actor" enums:"self,admin"`
+	// ActorID — идентификатор изменившего; возвращается только администратору.
+	ActorID   string                `json:"actor_id,omitempty"`
+	Changes   []FieldChangeResponse `json:"changes"`
+	ChangedAt time.Time             `json:"changed_at"`
+}
+
+// ProfileHistoryResponse описывает страницу истории изменений профиля.
+type ProfileHistoryResponse struct {
+	Items []ProfileChangeResponse `json:"items"`
+	Total int64                   `json:"total"`
 }
 
 // ChangeEmailRequest описывает тело запроса для изменения email.
@@ -62,3 +169,40 @@ type ChangeEmailResponse struct {
 type VerifyEmailChangeRequest struct {
 	Code string `json:"code" binding:"required,len=6,numeric"`
 }
+
+// ChangeRoleRequest описывает тело запроса для изменения роли пользователя администратором.
+type ChangeRoleRequest struct {
+	Role string `json:"role" binding:"required,oneof=user coach admin"`
+}
+
+// SuspendUserRequest описывает тело запроса для блокировки аккаунта.
+// Если until не указан, блокировка бессрочная.
+type SuspendUserRequest struct {
+	Until  *time.Time `json:"until,omitempty"`
+	Reason string     `json:"reason,omitempty" binding:"max=500"`
+}
+
+// AdminUserResponse описывает пользователя для администратора: профиль вместе
+// с состоянием подтверждения email, удаления и юридического удержания.
+type AdminUserResponse struct {
+	ProfileResponse
+	EmailVerified bool `json:"email_verified"`
+	// DeletedAt — момент мягкого удаления аккаунта; отсутствует у активных пользователей.
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
+	// AnonymizedAt — момент обезличивания; после него персональные данные профиля недоступны.
+	AnonymizedAt *time.Time `json:"anonymized_at,omitempty"`
+	// LegalHold присутствует, пока на данные установлено юридическое удержание.
+	LegalHold *AdminLegalHoldResponse `json:"legal_hold,omitempty"`
+}
+
+// AdminLegalHoldResponse описывает действующее юридическое удержание.
+type AdminLegalHoldResponse struct {
+	PlacedAt time.Time `json:"placed_at"`
+	PlacedBy string    `json:"placed_by"`
+	Reason   string    `json:"reason,omitempty"`
+}
+
+// AdminActionResponse описывает результат административного действия без изменения профиля.
+type AdminActionResponse struct {
+	Message string `json:"message"`
+}
@@ -3,46 +3,50 @@ package user
 import (
 	"errors"
 	"net/http"
+	"net/url"
+	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 
+	"workout-app/internal/domain/audit"
 	domain "workout-app/internal/domain/user"
 	"workout-app/internal/handler/middleware"
 	"workout-app/internal/handler/response"
+	"workout-app/internal/handler/validation"
 	repo "workout-app/internal/repository/interfaces"
+	anonymizationuc "workout-app/internal/usecase/anonymization"
+	authuc "workout-app/internal/usecase/auth"
 	useruc "workout-app/internal/usecase/user"
 	"workout-app/pkg/logger"
+	"workout-app/pkg/mailer"
+	"workout-app/pkg/usernamefilter"
 )
 
 // Handler обрабатывает HTTP-запросы, связанные с профилем пользователя.
 type Handler struct {
-	users  useruc.Service
-	logger logger.Logger
+	users      useruc.Service
+	auth       authuc.Service
+	anonymizer anonymizationuc.Service
+	logger     logger.Logger
 }
 
 // NewHandler создаёт новый UserHandler.
-func NewHandler(users useruc.Service, logger logger.Logger) *Handler {
+// auth отправляет код сброса пароля по запросу администратора.
+func NewHandler(users useruc.Service, auth authuc.Service, anonymizer anonymizationuc.Service, logger logger.Logger) *Handler {
 	return &Handler{
-		users:  users,
-		logger: logger,
+		users:      users,
+		auth:       auth,
+		anonymizer: anonymizer,
+		logger:     logger,
 	}
 }
 
 // getUserIDFromContext извлекает идентификатор пользователя из контекста запроса.
 // Возвращает ошибку unauthorized в случае отсутствия или некорректного значения.
 func getUserIDFromContext(c *gin.Context) (uuid.UUID, error) {
-	idStr := c.GetString(middleware.ContextUserIDKey)
-	if idStr == "" {
-		return uuid.Nil, errors.New("missing_user_id_in_context")
-	}
-
-	id, err := uuid.Parse(idStr)
-	if err != nil {
-		return uuid.Nil, errors.New("invalid_user_id_in_context")
-	}
-
-	return id, nil
+	return middleware.UserIDFromContext(c)
 }
 
 // getRequestContext возвращает базовые поля контекста запроса для логирования.
@@ -98,7 +102,7 @@ func (h *Handler) GetMe(c *gin.Context) {
 
 // UpdateMe godoc
 // @Summary      Обновить профиль текущего пользователя
-// @Description  Частичное обновление профиля (username, имя, уровень подготовки и т.п.).
+// @Description  Частичное обновление профиля (username, имя, уровень подготовки, ссылки на соцсети и сайт, часовой пояс, локаль, система единиц, время напоминания о тренировках, цель по калориям и т.п.). Ссылки нормализуются: для Instagram и YouTube сохраняется handle или ID канала, для сайта — адрес без фрагмента.
 // @Tags         user
 // @Security     BearerAuth
 // @Accept       json
@@ -109,6 +113,7 @@ func (h *Handler) GetMe(c *gin.Context) {
 // @Failure      401      {object}  response.ErrorBody
 // @Failure      404      {object}  response.ErrorBody
 // @Failure      409      {object}  response.ErrorBody
+// @Failure      422      {object}  response.ErrorBody
 // @Failure      500      {object}  response.ErrorBody
 // @Router       /api/v1/users/me [put]
 func (h *Handler) UpdateMe(c *gin.Context) {
@@ -120,7 +125,7 @@ func (h *Handler) UpdateMe(c *gin.Context) {
 
 	var req ProfileUpdateRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.Error(c, http.StatusBadRequest, "invalid_request", "Некорректное тело запроса", err.Error())
+		response.Error(c, http.StatusBadRequest, "invalid_request", "Некорректное тело запроса", validation.Details(c, err))
 		return
 	}
 
@@ -148,6 +153,28 @@ func (h *Handler) UpdateMe(c *gin.Context) {
 		level := domain.TrainingLevel(*req.TrainingLevel)
 		input.TrainingLevel = &level
 	}
+	if req.Language != nil {
+		language := domain.Language(*req.Language)
+		input.Language = &language
+	}
+	if req.Units != nil {
+		units := domain.Units(*req.Units)
+		input.Units = &units
+	}
+	input.Instagram = req.Instagram
+	input.YouTube = req.YouTube
+	input.Website = req.Website
+	input.InstagramVisibility = linkVisibility(req.InstagramVisibility)
+	input.YouTubeVisibility = linkVisibility(req.YouTubeVisibility)
+	input.WebsiteVisibility = linkVisibility(req.WebsiteVisibility)
+	if req.WorkoutsVisibility != nil {
+		visibility := domain.WorkoutsVisibility(*req.WorkoutsVisibility)
+		input.WorkoutsVisibility = &visibility
+	}
+	input.Timezone = req.Timezone
+	input.Locale = req.Locale
+	input.ReminderTime = req.ReminderTime
+	input.CalorieTarget = req.CalorieTarget
 
 	user, err := h.users.UpdateProfile(c.Request.Context(), userID, input)
 	if err != nil {
@@ -170,6 +197,41 @@ func (h *Handler) UpdateMe(c *gin.Context) {
 			})
 			response.Error(c, http.StatusConflict, "username_already_exists", "Указанный никнейм уже используется", nil)
 			return
+		case errors.Is(err, usernamefilter.ErrNotAllowed):
+			h.logger.Info("username_not_allowed_in_update_me", map[string]any{
+				"user_id": userID.String(),
+				"path":    c.Request.URL.Path,
+				"method":  c.Request.Method,
+				"error":   err.Error(),
+			})
+			response.Error(c, http.StatusUnprocessableEntity, "username_not_allowed", "Этот никнейм недоступен", validation.Username(c, "username", err))
+			return
+		case errors.Is(err, useruc.ErrInvalidProfileLink):
+			var linkErr *useruc.ProfileLinkError
+			var details []validation.FieldError
+			if errors.As(err, &linkErr) {
+				details = validation.Rule(c, string(linkErr.Kind), "profile_link_"+string(linkErr.Kind))
+			}
+			response.Error(c, http.StatusBadRequest, "invalid_profile_link", "Некорректная ссылка профиля", details)
+			return
+		case errors.Is(err, useruc.ErrInvalidLinkVisibility):
+			response.Error(c, http.StatusBadRequest, "invalid_request", "Видимость ссылки должна быть public или private", nil)
+			return
+		case errors.Is(err, useruc.ErrInvalidWorkoutsVisibility):
+			response.Error(c, http.StatusBadRequest, "invalid_request", "Видимость тренировок должна быть public, followers или private", nil)
+			return
+		case errors.Is(err, useruc.ErrInvalidTimezone):
+			response.Error(c, http.StatusBadRequest, "invalid_timezone", "Неизвестный часовой пояс", nil)
+			return
+		case errors.Is(err, useruc.ErrInvalidLocale):
+			response.Error(c, http.StatusBadRequest, "invalid_locale", "Локаль должна быть тегом BCP 47, например en-GB", nil)
+			return
+		case errors.Is(err, useruc.ErrInvalidReminderTime):
+			response.Error(c, http.StatusBadRequest, "invalid_reminder_time", "Время напоминания должно быть в формате HH:MM", nil)
+			return
+		case errors.Is(err, useruc.ErrInvalidCalorieTarget):
+			response.Error(c, http.StatusBadRequest, "invalid_calorie_target", "Цель по калориям должна быть от 500 до 10000 ккал", nil)
+			return
 		case errors.Is(err, repo.ErrNotFound):
 			h.logger.Info("user_not_found_in_update_me", map[string]any{
 				"user_id": userID.String(),
@@ -289,6 +351,183 @@ func (h *Handler) GetByID(c *gin.Context) {
 	c.JSON(http.StatusOK, toPublicProfileResponse(user))
 }
 
+// GetByUsername godoc
+// @Summary      Получить публичный профиль пользователя по username
+// @Description  Возвращает публичный профиль по текущему username. Если username — прежнее имя пользователя, сменившего его, отвечает 302 с Location на профиль по новому имени.
+// @Tags         user
+// @Security     BearerAuth
+// @Produce      json
+// @Param        username  path      string  true  "Username"
+// @Success      200       {object}  PublicProfileResponse
+// @Success      302       "Username сменён; Location — адрес профиля по новому имени"
+// @Failure      401       {object}  response.ErrorBody
+// @Failure      404       {object}  response.ErrorBody
+// @Failure      500       {object}  response.ErrorBody
+// @Router       /api/v1/users/by-username/{username} [get]
+func (h *Handler) GetByUsername(c *gin.Context) {
+	username := c.Param("username")
+
+	user, renamed, err := h.users.ResolveUsername(c.Request.Context(), username)
+	if err != nil {
+		if errors.Is(err, repo.ErrNotFound) {
+			response.Error(c, http.StatusNotFound, "user_not_found", "Пользователь не найден", nil)
+			return
+		}
+		h.logger.Error("internal_error_in_get_by_username", map[string]any{
+			"username": username,
+			"path":     c.Request.URL.Path,
+			"method":   c.Request.Method,
+			"error":    err.Error(),
+		})
+		response.Error(c, http.StatusInternalServerError, "internal_error", "Внутренняя ошибка сервера", nil)
+		return
+	}
+
+	if renamed {
+		// 302, а не 301: после окончания резерва старое имя может занять другой пользователь,
+		// поэтому перенаправление не должно кэшироваться навсегда.
+		c.Redirect(http.StatusFound, "/api/v1/users/by-username/"+url.PathEscape(user.Username))
+		return
+	}
+	c.JSON(http.StatusOK, toPublicProfileResponse(user))
+}
+
+// GetUsernameHistory godoc
+// @Summary      История смены username
+// @Description  Возвращает последние смены username текущего пользователя, новые первыми.
+// @Tags         user
+// @Security     BearerAuth
+// @Produce      json
+// @Success      200  {object}  UsernameHistoryResponse
+// @Failure      401  {object}  response.ErrorBody
+// @Failure      500  {object}  response.ErrorBody
+// @Router       /api/v1/users/me/username-history [get]
+func (h *Handler) GetUsernameHistory(c *gin.Context) {
+	userID, err := getUserIDFromContext(c)
+	if err != nil {
+		response.Error(c, http.StatusUnauthorized, "unauthorized", "Требуется аутентификация", nil)
+		return
+	}
+
+	changes, err := h.users.ListUsernameHistory(c.Request.Context(), userID)
+	if err != nil {
+		fields := getRequestContext(c, userID)
+		fields["error"] = err.Error()
+		h.logger.Error("internal_error_in_get_username_history", fields)
+		response.Error(c, http.StatusInternalServerError, "internal_error", "Внутренняя ошибка сервера", nil)
+		return
+	}
+
+	resp := UsernameHistoryResponse{Items: make([]UsernameChangeResponse, 0, len(changes))}
+	for _, change := range changes {
+		resp.Items = append(resp.Items, UsernameChangeResponse{
+			OldUsername:   change.OldUsername,
+			NewUsername:   change.NewUsername,
+			ChangedAt:     change.ChangedAt,
+			ReservedUntil: change.ReservedUntil,
+		})
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// GetProfileHistory godoc
+// @Summary      История изменений профиля
+// @Description  Возвращает изменения профиля текущего пользователя, новые первыми: изменённые поля со старыми и новыми значениями, кто (self или admin) и когда их изменил.
+// @Tags         user
+// @Security     BearerAuth
+// @Produce      json
+// @Param        limit   query     int  false  "Размер страницы (по умолчанию 20, максимум 100)"
+// @Param        offset  query     int  false  "Смещение"
+// @Success      200     {object}  ProfileHistoryResponse
+// @Failure      400     {object}  response.ErrorBody
+// @Failure      401     {object}  response.ErrorBody
+// @Failure      500     {object}  response.ErrorBody
+// @Router       /api/v1/users/me/profile-history [get]
+func (h *Handler) GetProfileHistory(c *gin.Context) {
+	userID, err := getUserIDFromContext(c)
+	if err != nil {
+		response.Error(c, http.StatusUnauthorized, "unauthorized", "Требуется аутентификация", nil)
+		return
+	}
+	limit, err1 := queryInt(c, "limit")
+	offset, err2 := queryInt(c, "offset")
+	if err1 != nil || err2 != nil {
+		response.Error(c, http.StatusBadRequest, "invalid_pagination", "Параметры limit и offset должны быть неотрицательными числами", nil)
+		return
+	}
+
+	changes, total, err := h.users.ListProfileHistory(c.Request.Context(), userID, limit, offset)
+	if err != nil {
+		fields := getRequestContext(c, userID)
+		fields["error"] = err.Error()
+		h.logger.Error("internal_error_in_get_profile_history", fields)
+		response.Error(c, http.StatusInternalServerError, "internal_error", "Внутренняя ошибка сервера", nil)
+		return
+	}
+	c.JSON(http.StatusOK, toProfileHistoryResponse(changes, total, false))
+}
+
+// GetUserHistory godoc
+// @Summary      История изменений профиля пользователя (админ)
+// @Description  Возвращает изменения профиля пользователя, новые первыми, с идентификатором изменившего. Доступно только для роли admin.
+// @Tags         user
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id      path      string  true   "ID пользователя"
+// @Param        limit   query     int     false  "Размер страницы (по умолчанию 20, максимум 100)"
+// @Param        offset  query     int     false  "Смещение"
+// @Success      200     {object}  ProfileHistoryResponse
+// @Failure      400     {object}  response.ErrorBody
+// @Failure      401     {object}  response.ErrorBody
+// @Failure      403     {object}  response.ErrorBody
+// @Failure      404     {object}  response.ErrorBody
+// @Failure      500     {object}  response.ErrorBody
+// @Router       /api/v1/admin/users/{id}/history [get]
+func (h *Handler) GetUserHistory(c *gin.Context) {
+	actorID, err := getUserIDFromContext(c)
+	if err != nil {
+		response.Error(c, http.StatusUnauthorized, "unauthorized", "Требуется аутентификация", nil)
+		return
+	}
+
+	userID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid_user_id", "Некорректный ID пользователя", nil)
+		return
+	}
+	limit, err1 := queryInt(c, "limit")
+	offset, err2 := queryInt(c, "offset")
+	if err1 != nil || err2 != nil {
+		response.Error(c, http.StatusBadRequest, "invalid_pagination", "Параметры limit и offset должны быть неотрицательными числами", nil)
+		return
+	}
+
+	// Несуществующий пользователь отличается от пользователя без изменений профиля.
+	if _, err := h.users.GetForAdmin(c.Request.Context(), userID); err != nil {
+		if errors.Is(err, repo.ErrNotFound) {
+			response.Error(c, http.StatusNotFound, "user_not_found", "Пользователь не найден", nil)
+			return
+		}
+		h.respondUserHistoryError(c, actorID, userID, err)
+		return
+	}
+	changes, total, err := h.users.ListProfileHistory(c.Request.Context(), userID, limit, offset)
+	if err != nil {
+		h.respondUserHistoryError(c, actorID, userID, err)
+		return
+	}
+	c.JSON(http.StatusOK, toProfileHistoryResponse(changes, total, true))
+}
+
+// respondUserHistoryError логирует внутреннюю ошибку чтения истории профиля администратором.
+func (h *Handler) respondUserHistoryError(c *gin.Context, actorID, userID uuid.UUID, err error) {
+	fields := getRequestContext(c, actorID)
+	fields["target_user_id"] = userID.String()
+	fields["error"] = err.Error()
+	h.logger.Error("internal_error_in_get_user_history", fields)
+	response.Error(c, http.StatusInternalServerError, "internal_error", "Внутренняя ошибка сервера", nil)
+}
+
 // ListUsers godoc
 // @Summary      Получить список всех пользователей (админ)
 // @Description  Возвращает список всех активных пользователей. Доступно только для роли admin.
@@ -320,6 +559,479 @@ func (h *Handler) ListUsers(c *gin.Context) {
 	c.JSON(http.StatusOK, resp)
 }
 
+// ChangeRole godoc
+// @Summary      Изменить роль пользователя (админ)
+// @Description  Назначает пользователю роль user, coach или admin. Нельзя снять роль admin с последнего администратора. Новая роль попадает в токены пользователя при следующем refresh.
+// @Tags         user
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        id       path      string             true  "ID пользователя"
+// @Param        payload  body      ChangeRoleRequest  true  "Новая роль"
+// @Success      200      {object}  ProfileResponse
+// @Failure      400      {object}  response.ErrorBody
+// @Failure      401      {object}  response.ErrorBody
+// @Failure      403      {object}  response.ErrorBody
+// @Failure      404      {object}  response.ErrorBody
+// @Failure      409      {object}  response.ErrorBody
+// @Failure      500      {object}  response.ErrorBody
+// @Router       /api/v1/admin/users/{id}/role [patch]
+func (h *Handler) ChangeRole(c *gin.Context) {
+	actorID, err := getUserIDFromContext(c)
+	if err != nil {
+		response.Error(c, http.StatusUnauthorized, "unauthorized", "Требуется аутентификация", nil)
+		return
+	}
+
+	userID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid_user_id", "Некорректный ID пользователя", nil)
+		return
+	}
+
+	var req ChangeRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid_request", "Некорректное тело запроса", validation.Details(c, err))
+		return
+	}
+
+	user, previous, err := h.users.ChangeRole(c.Request.Context(), actorID, userID, domain.Role(req.Role))
+	if err != nil {
+		switch {
+		case errors.Is(err, useruc.ErrInvalidRole):
+			response.Error(c, http.StatusBadRequest, "invalid_role", "Некорректная роль", nil)
+		case errors.Is(err, repo.ErrNotFound):
+			response.Error(c, http.StatusNotFound, "user_not_found", "Пользователь не найден", nil)
+		case errors.Is(err, repo.ErrLastAdmin):
+			h.logger.Info("last_admin_demotion_rejected", map[string]any{
+				"actor_id":       actorID.String(),
+				"target_user_id": userID.String(),
+			})
+			response.Error(c, http.StatusConflict, "last_admin", "Нельзя снять роль с последнего администратора", nil)
+		default:
+			h.logger.Error("internal_error_in_change_role", map[string]any{
+				"actor_id":       actorID.String(),
+				"target_user_id": userID.String(),
+				"path":           c.Request.URL.Path,
+				"method":         c.Request.Method,
+				"error":          err.Error(),
+			})
+			response.Error(c, http.StatusInternalServerError, "internal_error", "Внутренняя ошибка сервера", nil)
+		}
+		return
+	}
+
+	if previous != user.Role {
+		middleware.SetAuditDiff(c, audit.Diff{"role": {Old: string(previous), New: string(user.Role)}})
+		h.logger.Info("user_role_changed", map[string]any{
+			"actor_id":       actorID.String(),
+			"target_user_id": userID.String(),
+			"old_role":       string(previous),
+			"new_role":       string(user.Role),
+			"client_ip":      c.ClientIP(),
+		})
+	}
+
+	c.JSON(http.StatusOK, toProfileResponse(user))
+}
+
+// Suspend godoc
+// @Summary      Заблокировать пользователя (админ)
+// @Description  Блокирует аккаунт до указанного момента или бессрочно. Заблокированный пользователь не может войти, обновить токены и обращаться к защищённым эндпоинтам (код account_suspended).
+// @Tags         user
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        id       path      string              true  "ID пользователя"
+// @Param        payload  body      SuspendUserRequest  true  "Срок и причина блокировки"
+// @Success      200      {object}  ProfileResponse
+// @Failure      400      {object}  response.ErrorBody
+// @Failure      401      {object}  response.ErrorBody
+// @Failure      403      {object}  response.ErrorBody
+// @Failure      404      {object}  response.ErrorBody
+// @Failure      409      {object}  response.ErrorBody
+// @Failure      500      {object}  response.ErrorBody
+// @Router       /api/v1/admin/users/{id}/suspend [post]
+func (h *Handler) Suspend(c *gin.Context) {
+	actorID, err := getUserIDFromContext(c)
+	if err != nil {
+		response.Error(c, http.StatusUnauthorized, "unauthorized", "Требуется аутентификация", nil)
+		return
+	}
+
+	userID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid_user_id", "Некорректный ID пользователя", nil)
+		return
+	}
+
+	var req SuspendUserRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid_request", "Некорректное тело запроса", validation.Details(c, err))
+		return
+	}
+
+	user, err := h.users.Suspend(c.Request.Context(), actorID, userID, req.Until, req.Reason)
+	if err != nil {
+		switch {
+		case errors.Is(err, useruc.ErrCannotSuspendSelf):
+			response.Error(c, http.StatusBadRequest, "cannot_suspend_self", "Нельзя заблокировать собственный аккаунт", nil)
+		case errors.Is(err, useruc.ErrSuspensionInPast):
+			response.Error(c, http.StatusBadRequest, "invalid_suspension_period", "Окончание блокировки должно быть в будущем", nil)
+		case errors.Is(err, useruc.ErrCannotSuspendAdmin):
+			response.Error(c, http.StatusConflict, "cannot_suspend_admin", "Нельзя заблокировать администратора", nil)
+		case errors.Is(err, repo.ErrNotFound):
+			response.Error(c, http.StatusNotFound, "user_not_found", "Пользователь не найден", nil)
+		default:
+			ctx := getRequestContext(c, actorID)
+			ctx["target_user_id"] = userID.String()
+			ctx["error"] = err.Error()
+			h.logger.Error("internal_error_in_suspend_user", ctx)
+			response.Error(c, http.StatusInternalServerError, "internal_error", "Внутренняя ошибка сервера", nil)
+		}
+		return
+	}
+
+	fields := map[string]any{
+		"actor_id":       actorID.String(),
+		"target_user_id": userID.String(),
+		"reason":         req.Reason,
+		"client_ip":      c.ClientIP(),
+	}
+	diff := audit.Diff{"suspended": {New: true}, "suspension_reason": {New: req.Reason}}
+	if req.Until != nil {
+		fields["until"] = req.Until.UTC().Format(time.RFC3339)
+		diff["suspended_until"] = audit.Change{New: req.Until.UTC()}
+	}
+	middleware.SetAuditDiff(c, diff)
+	h.logger.Info("user_suspended", fields)
+
+	c.JSON(http.StatusOK, toProfileResponse(user))
+}
+
+// Unsuspend godoc
+// @Summary      Разблокировать пользователя (админ)
+// @Description  Снимает блокировку аккаунта пользователя.
+// @Tags         user
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id   path      string  true  "ID пользователя"
+// @Success      200  {object}  ProfileResponse
+// @Failure      400  {object}  response.ErrorBody
+// @Failure      401  {object}  response.ErrorBody
+// @Failure      403  {object}  response.ErrorBody
+// @Failure      404  {object}  response.ErrorBody
+// @Failure      500  {object}  response.ErrorBody
+// @Router       /api/v1/admin/users/{id}/unsuspend [post]
+func (h *Handler) Unsuspend(c *gin.Context) {
+	actorID, err := getUserIDFromContext(c)
+	if err != nil {
+		response.Error(c, http.StatusUnauthorized, "unauthorized", "Требуется аутентификация", nil)
+		return
+	}
+
+	userID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid_user_id", "Некорректный ID пользователя", nil)
+		return
+	}
+
+	user, err := h.users.Unsuspend(c.Request.Context(), userID)
+	if err != nil {
+		if errors.Is(err, repo.ErrNotFound) {
+			response.Error(c, http.StatusNotFound, "user_not_found", "Пользователь не найден", nil)
+			return
+		}
+		ctx := getRequestContext(c, actorID)
+		ctx["target_user_id"] = userID.String()
+		ctx["error"] = err.Error()
+		h.logger.Error("internal_error_in_unsuspend_user", ctx)
+		response.Error(c, http.StatusInternalServerError, "internal_error", "Внутренняя ошибка сервера", nil)
+		return
+	}
+
+	middleware.SetAuditDiff(c, audit.Diff{"suspended": {New: false}})
+	h.logger.Info("user_unsuspended", map[string]any{
+		"actor_id":       actorID.String(),
+		"target_user_id": userID.String(),
+		"client_ip":      c.ClientIP(),
+	})
+
+	c.JSON(http.StatusOK, toProfileResponse(user))
+}
+
+// Purge godoc
+// @Summary      Окончательно удалить пользователя (админ)
+// @Description  Обезличивает аккаунт: удаляет персональные данные профиля, замеры и коды подтверждения, а имя заменяет на «deleted user». Запись пользователя сохраняется, чтобы программы и назначения других пользователей остались согласованными. Действие необратимо.
+// @Tags         user
+// @Security     BearerAuth
+// @Param        id   path  string  true  "ID пользователя"
+// @Success      204
+// @Failure      400  {object}  response.ErrorBody
+// @Failure      401  {object}  response.ErrorBody
+// @Failure      403  {object}  response.ErrorBody
+// @Failure      404  {object}  response.ErrorBody
+// @Failure      409  {object}  response.ErrorBody
+// @Failure      500  {object}  response.ErrorBody
+// @Router       /api/v1/admin/users/{id}/purge [post]
+func (h *Handler) Purge(c *gin.Context) {
+	actorID, err := getUserIDFromContext(c)
+	if err != nil {
+		response.Error(c, http.StatusUnauthorized, "unauthorized", "Требуется аутентификация", nil)
+		return
+	}
+
+	userID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid_user_id", "Некорректный ID пользователя", nil)
+		return
+	}
+	if userID == actorID {
+		response.Error(c, http.StatusBadRequest, "cannot_purge_self", "Нельзя удалить собственный аккаунт администратора", nil)
+		return
+	}
+
+	if err := h.anonymizer.Anonymize(c.Request.Context(), userID); err != nil {
+		switch {
+		case errors.Is(err, repo.ErrNotFound):
+			response.Error(c, http.StatusNotFound, "user_not_found", "Пользователь не найден", nil)
+		case errors.Is(err, anonymizationuc.ErrAlreadyAnonymized):
+			response.Error(c, http.StatusConflict, "user_already_purged", "Пользователь уже удалён окончательно", nil)
+		case errors.Is(err, anonymizationuc.ErrUnderLegalHold):
+			response.Error(c, http.StatusConflict, "legal_hold", "На данные пользователя установлено юридическое удержание", nil)
+		default:
+			ctx := getRequestContext(c, actorID)
+			ctx["target_user_id"] = userID.String()
+			ctx["error"] = err.Error()
+			h.logger.Error("internal_error_in_purge_user", ctx)
+			response.Error(c, http.StatusInternalServerError, "internal_error", "Внутренняя ошибка сервера", nil)
+		}
+		return
+	}
+
+	middleware.SetAuditDiff(c, audit.Diff{"anonymized": {Old: false, New: true}})
+	h.logger.Info("user_purged", map[string]any{
+		"actor_id":       actorID.String(),
+		"target_user_id": userID.String(),
+		"client_ip":      c.ClientIP(),
+	})
+
+	c.Status(http.StatusNoContent)
+}
+
+// GetUserForAdmin godoc
+// @Summary      Получить пользователя (админ)
+// @Description  Возвращает полный профиль пользователя вместе с состоянием подтверждения email, мягкого удаления, обезличивания и юридического удержания. Мягко удалённые и обезличенные пользователи тоже возвращаются.
+// @Tags         user
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id   path      string  true  "ID пользователя"
+// @Success      200  {object}  AdminUserResponse
+// @Failure      400  {object}  response.ErrorBody
+// @Failure      401  {object}  response.ErrorBody
+// @Failure      403  {object}  response.ErrorBody
+// @Failure      404  {object}  response.ErrorBody
+// @Failure      500  {object}  response.ErrorBody
+// @Router       /api/v1/admin/users/{id} [get]
+func (h *Handler) GetUserForAdmin(c *gin.Context) {
+	userID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid_user_id", "Некорректный ID пользователя", nil)
+		return
+	}
+
+	user, err := h.users.GetForAdmin(c.Request.Context(), userID)
+	if err != nil {
+		if errors.Is(err, repo.ErrNotFound) {
+			response.Error(c, http.StatusNotFound, "user_not_found", "Пользователь не найден", nil)
+			return
+		}
+		h.logger.Error("internal_error_in_admin_get_user", map[string]any{
+			"target_user_id": userID.String(),
+			"path":           c.Request.URL.Path,
+			"method":         c.Request.Method,
+			"error":          err.Error(),
+		})
+		response.Error(c, http.StatusInternalServerError, "internal_error", "Внутренняя ошибка сервера", nil)
+		return
+	}
+
+	c.JSON(http.StatusOK, toAdminUserResponse(user))
+}
+
+// ForceVerifyEmail godoc
+// @Summary      Подтвердить email пользователя (админ)
+// @Description  Отмечает email пользователя подтверждённым без кода (например, после проверки через поддержку). Неиспользованные коды подтверждения регистрации удаляются.
+// @Tags         user
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id   path      string  true  "ID пользователя"
+// @Success      200  {object}  AdminUserResponse
+// @Failure      400  {object}  response.ErrorBody
+// @Failure      401  {object}  response.ErrorBody
+// @Failure      403  {object}  response.ErrorBody
+// @Failure      404  {object}  response.ErrorBody
+// @Failure      409  {object}  response.ErrorBody
+// @Failure      500  {object}  response.ErrorBody
+// @Router       /api/v1/admin/users/{id}/verify-email [post]
+func (h *Handler) ForceVerifyEmail(c *gin.Context) {
+	actorID, err := getUserIDFromContext(c)
+	if err != nil {
+		response.Error(c, http.StatusUnauthorized, "unauthorized", "Требуется аутентификация", nil)
+		return
+	}
+
+	userID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid_user_id", "Некорректный ID пользователя", nil)
+		return
+	}
+
+	user, err := h.users.ForceVerifyEmail(c.Request.Context(), actorID, userID)
+	if err != nil {
+		switch {
+		case errors.Is(err, repo.ErrNotFound):
+			response.Error(c, http.StatusNotFound, "user_not_found", "Пользователь не найден", nil)
+		case errors.Is(err, useruc.ErrEmailAlreadyVerified):
+			response.Error(c, http.StatusConflict, "email_already_verified", "Email пользователя уже подтверждён", nil)
+		default:
+			ctx := getRequestContext(c, actorID)
+			ctx["target_user_id"] = userID.String()
+			ctx["error"] = err.Error()
+			h.logger.Error("internal_error_in_force_verify_email", ctx)
+			response.Error(c, http.StatusInternalServerError, "internal_error", "Внутренняя ошибка сервера", nil)
+		}
+		return
+	}
+
+	middleware.SetAuditDiff(c, audit.Diff{"email_verified": {Old: false, New: true}})
+	h.logger.Info("user_email_force_verified", map[string]any{
+		"actor_id":       actorID.String(),
+		"target_user_id": userID.String(),
+		"client_ip":      c.ClientIP(),
+	})
+
+	c.JSON(http.StatusOK, toAdminUserResponse(user))
+}
+
+// SendPasswordReset godoc
+// @Summary      Отправить пользователю сброс пароля (админ)
+// @Description  Отправляет на email пользователя код сброса пароля; пользователь задаёт новый пароль через POST /api/v1/auth/reset-password. Прежние коды сброса перестают действовать. Текущий пароль и сессии остаются действительными до сброса.
+// @Tags         user
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id   path      string  true  "ID пользователя"
+// @Success      202  {object}  AdminActionResponse
+// @Failure      400  {object}  response.ErrorBody
+// @Failure      401  {object}  response.ErrorBody
+// @Failure      403  {object}  response.ErrorBody
+// @Failure      404  {object}  response.ErrorBody
+// @Failure      422  {object}  response.ErrorBody
+// @Failure      429  {object}  response.ErrorBody
+// @Failure      500  {object}  response.ErrorBody
+// @Router       /api/v1/admin/users/{id}/password-reset [post]
+func (h *Handler) SendPasswordReset(c *gin.Context) {
+	actorID, err := getUserIDFromContext(c)
+	if err != nil {
+		response.Error(c, http.StatusUnauthorized, "unauthorized", "Требуется аутентификация", nil)
+		return
+	}
+
+	userID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid_user_id", "Некорректный ID пользователя", nil)
+		return
+	}
+
+	if err := h.auth.SendPasswordReset(c.Request.Context(), userID); err != nil {
+		switch {
+		case errors.Is(err, repo.ErrNotFound):
+			response.Error(c, http.StatusNotFound, "user_not_found", "Пользователь не найден", nil)
+		case errors.Is(err, mailer.ErrRecipientUndeliverable):
+			response.Error(c, http.StatusUnprocessableEntity, "email_undeliverable", "Письма на email пользователя не доставляются", nil)
+		case errors.Is(err, mailer.ErrSendRateLimited):
+			response.Error(c, http.StatusTooManyRequests, "email_rate_limited", "Превышен лимит отправки писем, попробуйте позже", nil)
+		default:
+			ctx := getRequestContext(c, actorID)
+			ctx["target_user_id"] = userID.String()
+			ctx["error"] = err.Error()
+			h.logger.Error("internal_error_in_send_password_reset", ctx)
+			response.Error(c, http.StatusInternalServerError, "internal_error", "Внутренняя ошибка сервера", nil)
+		}
+		return
+	}
+
+	middleware.SetAuditDiff(c, audit.Diff{"password_reset_sent": {New: true}})
+	h.logger.Info("user_password_reset_sent", map[string]any{
+		"actor_id":       actorID.String(),
+		"target_user_id": userID.String(),
+		"client_ip":      c.ClientIP(),
+	})
+
+	c.JSON(http.StatusAccepted, AdminActionResponse{Message: "Код сброса пароля отправлен на email пользователя"})
+}
+
+// HardDelete godoc
+// @Summary      Удалить запись пользователя (админ)
+// @Description  Обезличивает пользователя (если это ещё не сделано) и удаляет его запись вместе с оставшимися личными данными. В отличие от purge, запись не сохраняется. Недоступно для администраторов, пользователей под юридическим удержанием и авторов программ или владельцев организаций — для них используйте purge. Действие необратимо.
+// @Tags         user
+// @Security     BearerAuth
+// @Param        id   path  string  true  "ID пользователя"
+// @Success      204
+// @Failure      400  {object}  response.ErrorBody
+// @Failure      401  {object}  response.ErrorBody
+// @Failure      403  {object}  response.ErrorBody
+// @Failure      404  {object}  response.ErrorBody
+// @Failure      409  {object}  response.ErrorBody
+// @Failure      500  {object}  response.ErrorBody
+// @Router       /api/v1/admin/users/{id} [delete]
+func (h *Handler) HardDelete(c *gin.Context) {
+	actorID, err := getUserIDFromContext(c)
+	if err != nil {
+		response.Error(c, http.StatusUnauthorized, "unauthorized", "Требуется аутентификация", nil)
+		return
+	}
+
+	userID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid_user_id", "Некорректный ID пользователя", nil)
+		return
+	}
+	if userID == actorID {
+		response.Error(c, http.StatusBadRequest, "cannot_delete_self", "Нельзя удалить собственный аккаунт администратора", nil)
+		return
+	}
+
+	if err := h.anonymizer.Delete(c.Request.Context(), userID); err != nil {
+		switch {
+		case errors.Is(err, repo.ErrNotFound):
+			response.Error(c, http.StatusNotFound, "user_not_found", "Пользователь не найден", nil)
+		case errors.Is(err, anonymizationuc.ErrUnderLegalHold):
+			response.Error(c, http.StatusConflict, "legal_hold", "На данные пользователя установлено юридическое удержание", nil)
+		case errors.Is(err, anonymizationuc.ErrCannotDeleteAdmin):
+			response.Error(c, http.StatusConflict, "cannot_delete_admin", "Нельзя удалить администратора; сначала смените роль", nil)
+		case errors.Is(err, anonymizationuc.ErrOwnsSharedContent):
+			response.Error(c, http.StatusConflict, "user_owns_shared_content", "Пользователь — автор программ или владелец организации; используйте purge", nil)
+		default:
+			ctx := getRequestContext(c, actorID)
+			ctx["target_user_id"] = userID.String()
+			ctx["error"] = err.Error()
+			h.logger.Error("internal_error_in_hard_delete_user", ctx)
+			response.Error(c, http.StatusInternalServerError, "internal_error", "Внутренняя ошибка сервера", nil)
+		}
+		return
+	}
+
+	middleware.SetAuditDiff(c, audit.Diff{"deleted": {Old: false, New: true}})
+	h.logger.Info("user_hard_deleted", map[string]any{
+		"actor_id":       actorID.String(),
+		"target_user_id": userID.String(),
+		"client_ip":      c.ClientIP(),
+	})
+
+	c.Status(http.StatusNoContent)
+}
+
 // RequestEmailChange godoc
 // @Summary      Запросить изменение email
 // @Description  Отправляет код подтверждения на новый email для изменения email пользователя.
@@ -333,6 +1045,8 @@ func (h *Handler) ListUsers(c *gin.Context) {
 // @Failure      401      {object}  response.ErrorBody
 // @Failure      404      {object}  response.ErrorBody
 // @Failure      409      {object}  response.ErrorBody
+// @Failure      422      {object}  response.ErrorBody
+// @Failure      429      {object}  response.ErrorBody
 // @Failure      500      {object}  response.ErrorBody
 // @Router       /api/v1/users/me/change-email [post]
 func (h *Handler) RequestEmailChange(c *gin.Context) {
@@ -344,7 +1058,7 @@ func (h *Handler) RequestEmailChange(c *gin.Context) {
 
 	var req ChangeEmailRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.Error(c, http.StatusBadRequest, "invalid_request", "Некорректное тело запроса", err.Error())
+		response.Error(c, http.StatusBadRequest, "invalid_request", "Некорректное тело запроса", validation.Details(c, err))
 		return
 	}
 
@@ -363,6 +1077,16 @@ func (h *Handler) RequestEmailChange(c *gin.Context) {
 			h.logger.Info("email_already_exists", ctx)
 			response.Error(c, http.StatusConflict, "email_already_exists", "Указанный email уже используется", nil)
 			return
+		case errors.Is(err, mailer.ErrRecipientUndeliverable):
+			ctx := getRequestContext(c, userID)
+			ctx["new_email"] = req.NewEmail
+			h.logger.Info("email_undeliverable", ctx)
+			response.Error(c, http.StatusUnprocessableEntity, "email_undeliverable", "Письма на указанный email не доставляются", nil)
+			return
+		case errors.Is(err, mailer.ErrSendRateLimited):
+			h.logger.Info("email_rate_limited", getRequestContext(c, userID))
+			response.Error(c, http.StatusTooManyRequests, "email_rate_limited", "Превышен лимит отправки писем, попробуйте позже", nil)
+			return
 		case errors.Is(err, repo.ErrNotFound):
 			h.logger.Info("user_not_found", getRequestContext(c, userID))
 			response.Error(c, http.StatusNotFound, "user_not_found", "Пользователь не найден", nil)
@@ -405,7 +1129,7 @@ func (h *Handler) VerifyEmailChange(c *gin.Context) {
 
 	var req VerifyEmailChangeRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.Error(c, http.StatusBadRequest, "invalid_request", "Некорректное тело запроса", err.Error())
+		response.Error(c, http.StatusBadRequest, "invalid_request", "Некорректное тело запроса", validation.Details(c, err))
 		return
 	}
 
@@ -448,20 +1172,66 @@ func (h *Handler) VerifyEmailChange(c *gin.Context) {
 
 // toProfileResponse маппит доменную модель в DTO.
 func toProfileResponse(u *domain.User) ProfileResponse {
-	return ProfileResponse{
-		ID:            u.ID.String(),
-		Email:         u.Email,
-		Username:      u.Username,
-		FirstName:     u.FirstName,
-		LastName:      u.LastName,
-		BirthDate:     u.BirthDate,
-		Gender:        u.Gender,
-		AvatarURL:     u.AvatarURL,
-		Role:          string(u.Role),
-		TrainingLevel: string(u.TrainingLevel),
-		CreatedAt:     u.CreatedAt,
-		UpdatedAt:     u.UpdatedAt,
+	resp := ProfileResponse{
+		ID:                 u.ID.String(),
+		Email:              u.Email,
+		Username:           u.Username,
+		FirstName:          u.FirstName,
+		LastName:           u.LastName,
+		BirthDate:          u.BirthDate,
+		Gender:             u.Gender,
+		AvatarURL:          u.AvatarURL,
+		Role:               string(u.Role),
+		TrainingLevel:      string(u.TrainingLevel),
+		Language:           string(u.Language),
+		Country:            u.Country,
+		Region:             string(u.Region),
+		Links:              toProfileLinksResponse(u.Links, false),
+		CreatedAt:          u.CreatedAt,
+		WorkoutsVisibility: workoutsVisibility(u.WorkoutsVisibility),
+		Timezone:           u.Timezone,
+		Locale:             u.Locale,
+		Units:              string(u.Units.OrDefault()),
+		CalorieTarget:      u.CalorieTarget,
+		UpdatedAt:          u.UpdatedAt,
+	}
+	if u.ReminderTime != nil {
+		resp.ReminderTime = u.ReminderTime.String()
+	}
+	if u.IsSuspended(time.Now()) {
+		resp.Suspension = &SuspensionResponse{
+			SuspendedAt:    u.Suspension.At,
+			SuspendedUntil: u.Suspension.Until,
+			Reason:         u.Suspension.Reason,
+		}
+	}
+	return resp
+}
+
+// workoutsVisibility возвращает видимость тренировок для ответа; пустое значение — private.
+func workoutsVisibility(v domain.WorkoutsVisibility) string {
+	if v == "" {
+		return string(domain.WorkoutsPrivate)
+	}
+	return string(v)
+}
+
+// toAdminUserResponse маппит доменную модель в DTO для администратора.
+func toAdminUserResponse(u *domain.User) AdminUserResponse {
+	resp := AdminUserResponse{
+		ProfileResponse: toProfileResponse(u),
+		EmailVerified:   u.IsEmailVerified,
+		DeletedAt:       u.DeletedAt,
+		AnonymizedAt:    u.AnonymizedAt,
+	}
+	if u.LegalHold != nil {
+		resp.LegalHold = &AdminLegalHoldResponse{
+			PlacedAt: u.LegalHold.At,
+			PlacedBy: u.LegalHold.By.String(),
+			Reason:   u.LegalHold.Reason,
+		}
 	}
+	return resp
 }
 
 // toPublicProfileResponse маппит доменную модель в публичный DTO (без email).
@@ -476,7 +1246,83 @@ func toPublicProfileResponse(u *domain.User) PublicProfileResponse {
 		AvatarURL:     u.AvatarURL,
 		Role:          string(u.Role),
 		TrainingLevel: string(u.TrainingLevel),
+		Links:         toProfileLinksResponse(u.Links, true),
 		CreatedAt:     u.CreatedAt,
 		UpdatedAt:     u.UpdatedAt,
 	}
 }
+
+// toProfileLinksResponse маппит ссылки профиля в DTO. Для публичного профиля (publicOnly) возвращает
+// только публичные ссылки и не раскрывает настройки видимости. nil — ни одной ссылки для показа.
+func toProfileLinksResponse(links domain.ProfileLinks, publicOnly bool) *ProfileLinksResponse {
+	link := func(kind domain.LinkKind) *ProfileLinkResponse {
+		l := *links.Get(kind)
+		if l.Value == "" || (publicOnly && !l.IsPublic()) {
+			return nil
+		}
+		resp := &ProfileLinkResponse{Value: l.Value, URL: kind.URL(l.Value)}
+		if !publicOnly {
+			resp.Visibility = string(domain.LinkPublic)
+			if l.Visibility != "" {
+				resp.Visibility = string(l.Visibility)
+			}
+		}
+		return resp
+	}
+
+	resp := &ProfileLinksResponse{
+		Instagram: link(domain.LinkInstagram),
+		YouTube:   link(domain.LinkYouTube),
+		Website:   link(domain.LinkWebsite),
+	}
+	if resp.Instagram == nil && resp.YouTube == nil && resp.Website == nil {
+		return nil
+	}
+	return resp
+}
+
+// linkVisibility переводит видимость ссылки из запроса в доменный тип.
+func linkVisibility(v *string) *domain.LinkVisibility {
+	if v == nil {
+		return nil
+	}
+	visibility := domain.LinkVisibility(*v)
+	return &visibility
+}
+
+// toProfileHistoryResponse маппит страницу истории изменений профиля в DTO; withActorID — ответ администратору.
+func toProfileHistoryResponse(changes []*domain.ProfileChange, total int64, withActorID bool) ProfileHistoryResponse {
+	resp := ProfileHistoryResponse{Items: make([]ProfileChangeResponse, 0, len(changes)), Total: total}
+	for _, change := range changes {
+		item := ProfileChangeResponse{
+			ID:        change.ID.String(),
+			Actor:     "admin",
+			Changes:   make([]FieldChangeResponse, 0, len(change.Changes)),
+			ChangedAt: change.ChangedAt,
+		}
+		if change.BySelf() {
+			item.Actor = "self"
+		}
+		if withActorID {
+			item.ActorID = change.ActorID.String()
+		}
+		for _, f := range change.Changes {
+			item.Changes = append(item.Changes, FieldChangeResponse{Field: f.Field, Old: f.Old, New: f.New})
+		}
+		resp.Items = append(resp.Items, item)
+	}
+	return resp
+}
+
+// queryInt разбирает неотрицательный числовой параметр запроса; пустой параметр — 0.
+func queryInt(c *gin.Context, name string) (int, error) {
+	raw := c.Query(name)
+	if raw == "" {
+		return 0, nil
+	}
+	n, err := strconv.Atoi(raw)
+	if err != nil || n < 0 {
+		return 0, strconv.ErrSyntax
+	}
+	return n, nil
+}
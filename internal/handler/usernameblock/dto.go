@@ -0,0 +1,27 @@
+package usernameblock
+
+import "time"
+
+// AddEntryRequest описывает тело запроса добавления запрещённого username.
+type AddEntryRequest struct {
+	Term     string `json:"term" binding:"required"`
+	Mode     string `json:"mode,omitempty"`     // exact (по умолчанию) или contains
+	Category string `json:"category,omitempty"` // reserved (по умолчанию) или profanity
+	Reason   string `json:"reason,omitempty"`
+}
+
+// EntryResponse описывает запрещённый username, добавленный администратором.
+type EntryResponse struct {
+	ID        string    `json:"id"`
+	Term      string    `json:"term"`
+	Mode      string    `json:"mode"`
+	Category  string    `json:"category"`
+	Reason    string    `json:"reason,omitempty"`
+	CreatedBy *string   `json:"created_by,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// EntryListResponse описывает список запрещённых username, добавленных администраторами.
+type EntryListResponse struct {
+	Items []EntryResponse `json:"items"`
+}
@@ -0,0 +1,177 @@
+package usernameblock
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	domain "workout-app/internal/domain/usernameblock"
+	"workout-app/internal/handler/middleware"
+	"workout-app/internal/handler/response"
+	repo "workout-app/internal/repository/interfaces"
+	usernameblockuc "workout-app/internal/usecase/usernameblock"
+	"workout-app/pkg/logger"
+	"workout-app/pkg/usernamefilter"
+)
+
+// Handler обрабатывает административные запросы к списку запрещённых username.
+type Handler struct {
+	blocklist usernameblockuc.Service
+	logger    logger.Logger
+}
+
+// NewHandler создаёт новый UsernameBlockHandler.
+func NewHandler(blocklist usernameblockuc.Service, logger logger.Logger) *Handler {
+	return &Handler{
+		blocklist: blocklist,
+		logger:    logger,
+	}
+}
+
+// List godoc
+// @Summary      Запрещённые username, добавленные администраторами (админ)
+// @Description  Возвращает записи, дополняющие встроенные списки служебных имён и ненормативной лексики.
+// @Tags         admin
+// @Security     BearerAuth
+// @Produce      json
+// @Success      200  {object}  EntryListResponse
+// @Failure      401  {object}  response.ErrorBody
+// @Failure      403  {object}  response.ErrorBody
+// @Failure      500  {object}  response.ErrorBody
+// @Router       /api/v1/admin/username-blocklist [get]
+func (h *Handler) List(c *gin.Context) {
+	entries, err := h.blocklist.List(c.Request.Context())
+	if err != nil {
+		h.respondError(c, "list_username_blocklist", err)
+		return
+	}
+
+	resp := EntryListResponse{Items: make([]EntryResponse, 0, len(entries))}
+	for _, e := range entries {
+		resp.Items = append(resp.Items, toEntryResponse(e))
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// Add godoc
+// @Summary      Запретить username (админ)
+// @Description  Добавляет слово в список запрещённых username. Слово нормализуется (нижний регистр, без цифрового суффикса и цифр-подстановок); mode=exact запрещает username, совпадающий со словом целиком, mode=contains — содержащий его. Действует для новых регистраций и смены username; существующие username не меняются.
+// @Tags         admin
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        payload  body      AddEntryRequest  true  "Слово, способ сопоставления и категория"
+// @Success      201      {object}  EntryResponse
+// @Failure      400      {object}  response.ErrorBody
+// @Failure      401      {object}  response.ErrorBody
+// @Failure      403      {object}  response.ErrorBody
+// @Failure      409      {object}  response.ErrorBody
+// @Failure      500      {object}  response.ErrorBody
+// @Router       /api/v1/admin/username-blocklist [post]
+func (h *Handler) Add(c *gin.Context) {
+	actorID, err := middleware.UserIDFromContext(c)
+	if err != nil {
+		response.Error(c, http.StatusUnauthorized, "unauthorized", "Требуется аутентификация", nil)
+		return
+	}
+
+	var req AddEntryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid_request", "Некорректное тело запроса", err.Error())
+		return
+	}
+
+	entry, err := h.blocklist.Add(c.Request.Context(), actorID, usernameblockuc.AddInput{
+		Term:     req.Term,
+		Mode:     usernamefilter.Mode(req.Mode),
+		Category: usernamefilter.Category(req.Category),
+		Reason:   req.Reason,
+	})
+	if err != nil {
+		h.respondError(c, "add_username_block", err)
+		return
+	}
+
+	h.logger.Info("username_block_added", map[string]any{
+		"entry_id": entry.ID.String(),
+		"term":     entry.Term,
+		"mode":     string(entry.Mode),
+		"actor_id": actorID.String(),
+	})
+	c.JSON(http.StatusCreated, toEntryResponse(entry))
+}
+
+// Remove godoc
+// @Summary      Удалить запрещённый username (админ)
+// @Description  Удаляет запись, добавленную администратором. Встроенные списки меняются только конфигурацией.
+// @Tags         admin
+// @Security     BearerAuth
+// @Param        id  path  string  true  "ID записи"
+// @Success      204
+// @Failure      400  {object}  response.ErrorBody
+// @Failure      401  {object}  response.ErrorBody
+// @Failure      403  {object}  response.ErrorBody
+// @Failure      404  {object}  response.ErrorBody
+// @Failure      500  {object}  response.ErrorBody
+// @Router       /api/v1/admin/username-blocklist/{id} [delete]
+func (h *Handler) Remove(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid_id", "Некорректный ID записи", nil)
+		return
+	}
+
+	if err := h.blocklist.Remove(c.Request.Context(), id); err != nil {
+		h.respondError(c, "remove_username_block", err)
+		return
+	}
+
+	h.logger.Info("username_block_removed", map[string]any{
+		"entry_id": id.String(),
+		"actor_id": c.GetString(middleware.ContextUserIDKey),
+	})
+	c.Status(http.StatusNoContent)
+}
+
+// respondError переводит ошибки usecase-слоя в HTTP-ответы.
+func (h *Handler) respondError(c *gin.Context, op string, err error) {
+	switch {
+	case errors.Is(err, usernameblockuc.ErrInvalidTerm):
+		response.Error(c, http.StatusBadRequest, "invalid_term", "Слово должно содержать латинские буквы и быть не длиннее 32 символов", nil)
+	case errors.Is(err, usernameblockuc.ErrInvalidMode):
+		response.Error(c, http.StatusBadRequest, "invalid_mode", "Способ сопоставления должен быть exact или contains", nil)
+	case errors.Is(err, usernameblockuc.ErrInvalidCategory):
+		response.Error(c, http.StatusBadRequest, "invalid_category", "Категория должна быть reserved или profanity", nil)
+	case errors.Is(err, usernameblockuc.ErrReasonTooLong):
+		response.Error(c, http.StatusBadRequest, "reason_too_long", "Комментарий не должен превышать 1000 символов", nil)
+	case errors.Is(err, usernameblockuc.ErrAlreadyBlocked):
+		response.Error(c, http.StatusConflict, "already_blocked", "Слово уже в списке запрещённых", nil)
+	case errors.Is(err, repo.ErrNotFound):
+		response.Error(c, http.StatusNotFound, "username_block_not_found", "Запись не найдена", nil)
+	default:
+		h.logger.Error("internal_error_in_"+op, map[string]any{
+			"path":   c.Request.URL.Path,
+			"method": c.Request.Method,
+			"error":  err.Error(),
+		})
+		response.Error(c, http.StatusInternalServerError, "internal_error", "Внутренняя ошибка сервера", nil)
+	}
+}
+
+func toEntryResponse(e *domain.Entry) EntryResponse {
+	resp := EntryResponse{
+		ID:        e.ID.String(),
+		Term:      e.Term,
+		Mode:      string(e.Mode),
+		Category:  string(e.Category),
+		Reason:    e.Reason,
+		CreatedAt: e.CreatedAt,
+	}
+	if e.CreatedBy != nil {
+		by := e.CreatedBy.String()
+		resp.CreatedBy = &by
+	}
+	return resp
+}
@@ -0,0 +1,270 @@
+// Package validation переводит ошибки разбора и проверки запросов (gin binding) в структурированные
+// детали ответа: для каждого поля — машиночитаемое правило и локализованное сообщение.
+package validation
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+
+	"workout-app/pkg/password"
+	"workout-app/pkg/usernamefilter"
+)
+
+// FieldError описывает нарушение правила проверки одного поля запроса.
+type FieldError struct {
+	// Field — имя поля, как в JSON или query (вложенные — через точку, элементы массивов — [i]).
+	// Пусто, если запрос не удалось разобрать целиком.
+	Field string `json:"field,omitempty" example:"password"`
+	// Rule — нарушенное правило: required, min, max, email, alphanum, uuid, oneof, type, malformed,
+	// для паролей — коды политики паролей (password_too_short, password_common и т.п.),
+	// для запрещённых username — username_reserved и username_profanity,
+	// для ссылок профиля — profile_link_instagram, profile_link_youtube и profile_link_website.
+	Rule string `json:"rule" example:"min"`
+	// Param — параметр правила (например, минимальная длина), если есть.
+	Param   string `json:"param,omitempty" example:"8"`
+	Message string `json:"message"`
+}
+
+func init() {
+	// Ошибки валидатора называют поля так же, как их видит клиент: по тегам json/form.
+	if v, ok := binding.Validator.Engine().(*validator.Validate); ok {
+		v.RegisterTagNameFunc(fieldName)
+	}
+}
+
+func fieldName(f reflect.StructField) string {
+	for _, tag := range []string{"json", "form", "uri"} {
+		name, _, _ := strings.Cut(f.Tag.Get(tag), ",")
+		if name == "-" {
+			return ""
+		}
+		if name != "" {
+			return name
+		}
+	}
+	return f.Name
+}
+
+// Details переводит ошибку ShouldBind* в список нарушений. Язык сообщений выбирается
+// по заголовку Accept-Language (ru по умолчанию, en).
+func Details(c *gin.Context, err error) []FieldError {
+	lang := language(c.GetHeader("Accept-Language"))
+
+	var verrs validator.ValidationErrors
+	if errors.As(err, &verrs) {
+		result := make([]FieldError, 0, len(verrs))
+		for _, fe := range verrs {
+			result = append(result, FieldError{
+				Field:   namespace(fe),
+				Rule:    fe.Tag(),
+				Param:   fe.Param(),
+				Message: message(lang, fe),
+			})
+		}
+		return result
+	}
+
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) {
+		return []FieldError{{
+			Field:   typeErr.Field,
+			Rule:    "type",
+			Param:   typeName(typeErr.Type),
+			Message: fmt.Sprintf(messages[lang]["type"], typeName(typeErr.Type)),
+		}}
+	}
+
+	return []FieldError{{Rule: "malformed", Message: messages[lang]["malformed"]}}
+}
+
+// Password переводит нарушения политики паролей (*password.PolicyError в цепочке err) в детали поля field.
+// Возвращает nil, если err не содержит нарушений политики.
+func Password(c *gin.Context, field string, err error) []FieldError {
+	var policyErr *password.PolicyError
+	if !errors.As(err, &policyErr) {
+		return nil
+	}
+	texts := messages[language(c.GetHeader("Accept-Language"))]
+	result := make([]FieldError, 0, len(policyErr.Violations))
+	for _, v := range policyErr.Violations {
+		text := texts[v.Code]
+		if strings.Contains(text, "%s") {
+			text = fmt.Sprintf(text, v.Param)
+		}
+		result = append(result, FieldError{Field: field, Rule: v.Code, Param: v.Param, Message: text})
+	}
+	return result
+}
+
+// Username переводит запрет username (*usernamefilter.Error в цепочке err) в детали поля field.
+// Само запрещённое слово в ответ не попадает. Возвращает nil, если err не содержит запрета.
+func Username(c *gin.Context, field string, err error) []FieldError {
+	var filterErr *usernamefilter.Error
+	if !errors.As(err, &filterErr) {
+		return nil
+	}
+	rule := "username_" + string(filterErr.Category)
+	return []FieldError{{Field: field, Rule: rule, Message: messages[language(c.GetHeader("Accept-Language"))][rule]}}
+}
+
+// Rule возвращает детали нарушения правила rule полем field, проверенным вне binding (в usecase-слое).
+func Rule(c *gin.Context, field, rule string) []FieldError {
+	texts := messages[language(c.GetHeader("Accept-Language"))]
+	text, ok := texts[rule]
+	if !ok {
+		text = texts["invalid"]
+	}
+	return []FieldError{{Field: field, Rule: rule, Message: text}}
+}
+
+// namespace возвращает путь к полю без имени корневой структуры запроса.
+func namespace(fe validator.FieldError) string {
+	ns := fe.Namespace()
+	if _, rest, ok := strings.Cut(ns, "."); ok {
+		return rest
+	}
+	return ns
+}
+
+func language(acceptLanguage string) string {
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		tag, _, _ := strings.Cut(strings.TrimSpace(part), ";")
+		base, _, _ := strings.Cut(strings.ToLower(tag), "-")
+		if _, ok := messages[base]; ok {
+			return base
+		}
+	}
+	return "ru"
+}
+
+func message(lang string, fe validator.FieldError) string {
+	texts := messages[lang]
+	key := fe.Tag()
+	switch key {
+	case "min", "max", "len":
+		// Для строк и списков ограничение — на длину, для чисел — на значение.
+		switch fe.Kind() {
+		case reflect.String:
+			key += "_string"
+		case reflect.Slice, reflect.Array, reflect.Map:
+			key += "_items"
+		}
+	}
+	text, ok := texts[key]
+	if !ok {
+		return texts["invalid"]
+	}
+	if strings.Contains(text, "%s") {
+		return fmt.Sprintf(text, fe.Param())
+	}
+	return text
+}
+
+func typeName(t reflect.Type) string {
+	switch t.Kind() {
+	case reflect.String:
+		return "string"
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "integer"
+	case reflect.Float32, reflect.Float64:
+		return "number"
+	case reflect.Slice, reflect.Array:
+		return "array"
+	default:
+		return "object"
+	}
+}
+
+// messages — тексты сообщений по языкам и правилам; %s заменяется параметром правила.
+var messages = map[string]map[string]string{
+	"ru": {
+		"required":   "Обязательное поле",
+		"email":      "Некорректный email",
+		"alphanum":   "Допустимы только буквы и цифры",
+		"uuid":       "Некорректный идентификатор",
+		"url":        "Некорректный URL",
+		"oneof":      "Допустимые значения: %s",
+		"datetime":   "Ожидается дата в формате %s",
+		"min":        "Значение должно быть не меньше %s",
+		"max":        "Значение должно быть не больше %s",
+		"len":        "Значение должно быть равно %s",
+		"min_string": "Минимальная длина — %s символов",
+		"max_string": "Максимальная длина — %s символов",
+		"len_string": "Длина должна быть %s символов",
+		"min_items":  "Минимум элементов: %s",
+		"max_items":  "Максимум элементов: %s",
+		"len_items":  "Должно быть элементов: %s",
+		"gte":        "Значение должно быть не меньше %s",
+		"lte":        "Значение должно быть не больше %s",
+		"gt":         "Значение должно быть больше %s",
+		"lt":         "Значение должно быть меньше %s",
+		"type":       "Ожидается значение типа %s",
+		"malformed":  "Тело запроса не удалось разобрать",
+		"invalid":    "Некорректное значение",
+
+		password.ViolationTooShort:      "Пароль должен быть не короче %s символов",
+		password.ViolationTooLong:       "Пароль должен быть не длиннее %s байт",
+		password.ViolationMissingLower:  "Пароль должен содержать строчную букву",
+		password.ViolationMissingUpper:  "Пароль должен содержать заглавную букву",
+		password.ViolationMissingDigit:  "Пароль должен содержать цифру",
+		password.ViolationMissingSymbol: "Пароль должен содержать символ, отличный от букв и цифр",
+		password.ViolationCommon:        "Пароль слишком распространён, выберите другой",
+
+		"username_reserved":  "Это имя зарезервировано, выберите другое",
+		"username_profanity": "Имя содержит недопустимые слова, выберите другое",
+
+		"profile_link_instagram": "Укажите имя пользователя Instagram или ссылку на профиль",
+		"profile_link_youtube":   "Укажите handle YouTube (@name), ID канала или ссылку на канал",
+		"profile_link_website":   "Укажите адрес сайта, начинающийся с http:// или https://",
+	},
+	"en": {
+		"required":   "This field is required",
+		"email":      "Invalid email address",
+		"alphanum":   "Only letters and digits are allowed",
+		"uuid":       "Invalid identifier",
+		"url":        "Invalid URL",
+		"oneof":      "Allowed values: %s",
+		"datetime":   "Expected a date in format %s",
+		"min":        "Must be at least %s",
+		"max":        "Must be at most %s",
+		"len":        "Must be equal to %s",
+		"min_string": "Must be at least %s characters long",
+		"max_string": "Must be at most %s characters long",
+		"len_string": "Must be exactly %s characters long",
+		"min_items":  "Must contain at least %s items",
+		"max_items":  "Must contain at most %s items",
+		"len_items":  "Must contain exactly %s items",
+		"gte":        "Must be at least %s",
+		"lte":        "Must be at most %s",
+		"gt":         "Must be greater than %s",
+		"lt":         "Must be less than %s",
+		"type":       "Expected a value of type %s",
+		"malformed":  "Request body could not be parsed",
+		"invalid":    "Invalid value",
+
+		password.ViolationTooShort:      "Password must be at least %s characters long",
+		password.ViolationTooLong:       "Password must be at most %s bytes long",
+		password.ViolationMissingLower:  "Password must contain a lowercase letter",
+		password.ViolationMissingUpper:  "Password must contain an uppercase letter",
+		password.ViolationMissingDigit:  "Password must contain a digit",
+		password.ViolationMissingSymbol: "Password must contain a character other than letters and digits",
+		password.ViolationCommon:        "Password is too common, choose another one",
+
+		"username_reserved":  "This username is reserved, choose another one",
+		"username_profanity": "This username contains inappropriate words, choose another one",
+
+		"profile_link_instagram": "Enter an Instagram username or profile link",
+		"profile_link_youtube":   "Enter a YouTube handle (@name), channel ID or channel link",
+		"profile_link_website":   "Enter a website address starting with http:// or https://",
+	},
+}
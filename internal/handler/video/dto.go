@@ -0,0 +1,23 @@
+package video
+
+import "time"
+
+// VideoResponse описывает видео упражнения.
+type VideoResponse struct {
+	ID          string    `json:"id"`
+	OwnerID     string    `json:"owner_id"`
+	Title       string    `json:"title"`
+	ContentType string    `json:"content_type"`
+	SizeBytes   int64     `json:"size_bytes"`
+	Status      string    `json:"status" example:"ready"`
+	CreatedAt   time.Time `json:"created_at"`
+	// StreamURL — подписанная ссылка на воспроизведение (только для status=ready).
+	// Не требует заголовка Authorization и поддерживает Range-запросы.
+	StreamURL          string     `json:"stream_url,omitempty"`
+	StreamURLExpiresAt *time.Time `json:"stream_url_expires_at,omitempty"`
+}
+
+// VideoListResponse описывает список видео пользователя.
+type VideoListResponse struct {
+	Items []VideoResponse `json:"items"`
+}
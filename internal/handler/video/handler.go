@@ -0,0 +1,299 @@
+package video
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	userdomain "workout-app/internal/domain/user"
+	domain "workout-app/internal/domain/video"
+	"workout-app/internal/handler/middleware"
+	"workout-app/internal/handler/response"
+	videouc "workout-app/internal/usecase/video"
+	"workout-app/pkg/logger"
+)
+
+// multipartOverhead — запас на заголовки и текстовые поля multipart сверх максимального размера файла.
+const multipartOverhead = 64 << 10
+
+// Handler обрабатывает загрузку и воспроизведение видео упражнений.
+type Handler struct {
+	videos   videouc.Service
+	maxBytes int64
+	logger   logger.Logger
+}
+
+// NewHandler создаёт новый VideoHandler. maxBytes — максимальный размер файла видео.
+func NewHandler(videos videouc.Service, maxBytes int64, logger logger.Logger) *Handler {
+	return &Handler{
+		videos:   videos,
+		maxBytes: maxBytes,
+		logger:   logger,
+	}
+}
+
+// Upload godoc
+// @Summary      Загрузить видео упражнения (тренер/админ)
+// @Description  Принимает видео (MP4, WebM, QuickTime) в поле file и название в поле title формы multipart/form-data. Тип определяется по содержимому файла. После загрузки видео проходит перекодирование в формат воспроизведения; статус ready означает, что его можно смотреть. ID видео указывается в поле video_id упражнения программы.
+// @Tags         videos
+// @Security     BearerAuth
+// @Accept       multipart/form-data
+// @Produce      json
+// @Param        file   formData  file    true  "Видеофайл"
+// @Param        title  formData  string  true  "Название видео"
+// @Success      201    {object}  VideoResponse
+// @Failure      400    {object}  response.ErrorBody
+// @Failure      401    {object}  response.ErrorBody
+// @Failure      403    {object}  response.ErrorBody
+// @Failure      413    {object}  response.ErrorBody
+// @Failure      415    {object}  response.ErrorBody
+// @Failure      500    {object}  response.ErrorBody
+// @Router       /api/v1/videos [post]
+func (h *Handler) Upload(c *gin.Context) {
+	userID, err := middleware.UserIDFromContext(c)
+	if err != nil {
+		response.Error(c, http.StatusUnauthorized, "unauthorized", "Требуется аутентификация", nil)
+		return
+	}
+
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, h.maxBytes+multipartOverhead)
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		var maxErr *http.MaxBytesError
+		if errors.As(err, &maxErr) {
+			response.Error(c, http.StatusRequestEntityTooLarge, "video_too_large", "Видеофайл слишком большой", gin.H{"max_bytes": h.maxBytes})
+			return
+		}
+		response.Error(c, http.StatusBadRequest, "invalid_request", "Ожидается файл в поле file формы multipart/form-data", nil)
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid_request", "Не удалось прочитать файл", nil)
+		return
+	}
+	defer file.Close()
+
+	video, err := h.videos.Upload(c.Request.Context(), userID, c.PostForm("title"), file, fileHeader.Size)
+	if err != nil {
+		h.respondError(c, "upload_video", err)
+		return
+	}
+
+	h.logger.Info("video_uploaded", map[string]any{
+		"video_id": video.ID.String(),
+		"owner_id": userID.String(),
+		"size":     video.SizeBytes,
+		"status":   string(video.Status),
+	})
+	c.JSON(http.StatusCreated, h.toResponse(c, video))
+}
+
+// ListMine godoc
+// @Summary      Мои видео упражнений (тренер/админ)
+// @Description  Возвращает видео, загруженные текущим пользователем, новые первыми.
+// @Tags         videos
+// @Security     BearerAuth
+// @Produce      json
+// @Success      200  {object}  VideoListResponse
+// @Failure      401  {object}  response.ErrorBody
+// @Failure      403  {object}  response.ErrorBody
+// @Failure      500  {object}  response.ErrorBody
+// @Router       /api/v1/videos [get]
+func (h *Handler) ListMine(c *gin.Context) {
+	userID, err := middleware.UserIDFromContext(c)
+	if err != nil {
+		response.Error(c, http.StatusUnauthorized, "unauthorized", "Требуется аутентификация", nil)
+		return
+	}
+
+	videos, err := h.videos.ListMine(c.Request.Context(), userID)
+	if err != nil {
+		h.respondError(c, "list_videos", err)
+		return
+	}
+
+	items := make([]VideoResponse, 0, len(videos))
+	for _, v := range videos {
+		items = append(items, toVideoResponse(v))
+	}
+	c.JSON(http.StatusOK, VideoListResponse{Items: items})
+}
+
+// Get godoc
+// @Summary      Получить видео упражнения
+// @Description  Возвращает видео и, если оно готово, подписанную ссылку stream_url для встроенного плеера. Ссылка действует ограниченное время (stream_url_expires_at); после истечения получите новую.
+// @Tags         videos
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id   path      string  true  "ID видео"
+// @Success      200  {object}  VideoResponse
+// @Failure      400  {object}  response.ErrorBody
+// @Failure      401  {object}  response.ErrorBody
+// @Failure      404  {object}  response.ErrorBody
+// @Failure      500  {object}  response.ErrorBody
+// @Router       /api/v1/videos/{id} [get]
+func (h *Handler) Get(c *gin.Context) {
+	id, ok := parseVideoID(c)
+	if !ok {
+		return
+	}
+
+	video, err := h.videos.Get(c.Request.Context(), id)
+	if err != nil {
+		h.respondError(c, "get_video", err)
+		return
+	}
+	c.JSON(http.StatusOK, h.toResponse(c, video))
+}
+
+// Delete godoc
+// @Summary      Удалить видео упражнения
+// @Description  Удаляет видео и его файл. Доступно автору видео и администратору. Упражнения, ссылающиеся на видео, остаются без него.
+// @Tags         videos
+// @Security     BearerAuth
+// @Param        id  path  string  true  "ID видео"
+// @Success      204
+// @Failure      400  {object}  response.ErrorBody
+// @Failure      401  {object}  response.ErrorBody
+// @Failure      403  {object}  response.ErrorBody
+// @Failure      404  {object}  response.ErrorBody
+// @Failure      500  {object}  response.ErrorBody
+// @Router       /api/v1/videos/{id} [delete]
+func (h *Handler) Delete(c *gin.Context) {
+	userID, err := middleware.UserIDFromContext(c)
+	if err != nil {
+		response.Error(c, http.StatusUnauthorized, "unauthorized", "Требуется аутентификация", nil)
+		return
+	}
+	id, ok := parseVideoID(c)
+	if !ok {
+		return
+	}
+
+	isAdmin := userdomain.Role(c.GetString(middleware.ContextUserRoleKey)) == userdomain.RoleAdmin
+	if err := h.videos.Delete(c.Request.Context(), userID, isAdmin, id); err != nil {
+		h.respondError(c, "delete_video", err)
+		return
+	}
+
+	h.logger.Info("video_deleted", map[string]any{"video_id": id.String(), "actor_id": userID.String()})
+	c.Status(http.StatusNoContent)
+}
+
+// Stream godoc
+// @Summary      Воспроизвести видео по подписанной ссылке
+// @Description  Отдаёт видео по ссылке stream_url без заголовка Authorization. Поддерживает Range-запросы (206 Partial Content) для перемотки. При хранении в S3 отвечает 302 на временную ссылку хранилища, которое само обрабатывает Range.
+// @Tags         videos
+// @Produce      video/mp4
+// @Param        id         path    string  true   "ID видео"
+// @Param        expires    query   int     true   "Срок действия ссылки (Unix-время)"
+// @Param        signature  query   string  true   "Подпись ссылки"
+// @Param        Range      header  string  false  "Диапазон байтов, например bytes=0-1048575"
+// @Success      200
+// @Success      206
+// @Success      302
+// @Failure      400  {object}  response.ErrorBody
+// @Failure      403  {object}  response.ErrorBody
+// @Failure      404  {object}  response.ErrorBody
+// @Failure      409  {object}  response.ErrorBody
+// @Failure      410  {object}  response.ErrorBody
+// @Failure      416
+// @Failure      500  {object}  response.ErrorBody
+// @Router       /api/v1/videos/{id}/stream [get]
+func (h *Handler) Stream(c *gin.Context) {
+	id, ok := parseVideoID(c)
+	if !ok {
+		return
+	}
+
+	stream, err := h.videos.OpenStream(c.Request.Context(), id, c.Query("expires"), c.Query("signature"))
+	if err != nil {
+		h.respondError(c, "stream_video", err)
+		return
+	}
+
+	if stream.RedirectURL != "" {
+		c.Header("Cache-Control", "private, no-store")
+		c.Redirect(http.StatusFound, stream.RedirectURL)
+		return
+	}
+
+	defer stream.Object.Close()
+	c.Header("Content-Type", stream.Video.ContentType)
+	c.Header("Cache-Control", "private, max-age=3600")
+	// ServeContent обрабатывает Range, If-Range и условные запросы.
+	http.ServeContent(c.Writer, c.Request, "", stream.Object.ModTime, stream.Object)
+}
+
+// toResponse преобразует видео в ответ и добавляет ссылку на воспроизведение для готовых видео.
+func (h *Handler) toResponse(c *gin.Context, v *domain.Video) VideoResponse {
+	resp := toVideoResponse(v)
+	if v.Status != domain.StatusReady {
+		return resp
+	}
+	link, err := h.videos.StreamLink(c.Request.Context(), v.ID)
+	if err != nil {
+		h.logger.Error("video_stream_link_failed", map[string]any{"video_id": v.ID.String(), "error": err.Error()})
+		return resp
+	}
+	resp.StreamURL = link.URL
+	resp.StreamURLExpiresAt = &link.ExpiresAt
+	return resp
+}
+
+// respondError отправляет ответ об ошибке для эндпоинтов видео.
+func (h *Handler) respondError(c *gin.Context, op string, err error) {
+	switch {
+	case errors.Is(err, videouc.ErrFileTooLarge):
+		response.Error(c, http.StatusRequestEntityTooLarge, "video_too_large", "Видеофайл слишком большой", gin.H{"max_bytes": h.maxBytes})
+	case errors.Is(err, videouc.ErrEmptyFile):
+		response.Error(c, http.StatusBadRequest, "video_empty", "Видеофайл пуст", nil)
+	case errors.Is(err, videouc.ErrUnsupportedFormat):
+		response.Error(c, http.StatusUnsupportedMediaType, "video_unsupported_format", "Поддерживаются только видео MP4, WebM и QuickTime", nil)
+	case errors.Is(err, videouc.ErrInvalidTitle):
+		response.Error(c, http.StatusBadRequest, "invalid_title", "Название видео должно быть от 1 до 200 символов", nil)
+	case errors.Is(err, videouc.ErrVideoNotFound):
+		response.Error(c, http.StatusNotFound, "video_not_found", "Видео не найдено", nil)
+	case errors.Is(err, videouc.ErrForbidden):
+		response.Error(c, http.StatusForbidden, "forbidden", "Удалить видео может только автор или администратор", nil)
+	case errors.Is(err, videouc.ErrNotReady):
+		response.Error(c, http.StatusConflict, "video_not_ready", "Видео ещё обрабатывается или не прошло обработку", nil)
+	case errors.Is(err, videouc.ErrInvalidSignature):
+		response.Error(c, http.StatusForbidden, "invalid_signature", "Некорректная ссылка на видео", nil)
+	case errors.Is(err, videouc.ErrLinkExpired):
+		response.Error(c, http.StatusGone, "link_expired", "Срок действия ссылки на видео истёк", nil)
+	default:
+		h.logger.Error("internal_error_in_"+op, map[string]any{
+			"path":   c.Request.URL.Path,
+			"method": c.Request.Method,
+			"error":  err.Error(),
+		})
+		response.Error(c, http.StatusInternalServerError, "internal_error", "Внутренняя ошибка сервера", nil)
+	}
+}
+
+// parseVideoID разбирает ID видео из пути. При ошибке ответ уже отправлен.
+func parseVideoID(c *gin.Context) (uuid.UUID, bool) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid_video_id", "Некорректный ID видео", nil)
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+func toVideoResponse(v *domain.Video) VideoResponse {
+	return VideoResponse{
+		ID:          v.ID.String(),
+		OwnerID:     v.OwnerID.String(),
+		Title:       v.Title,
+		ContentType: v.ContentType,
+		SizeBytes:   v.SizeBytes,
+		Status:      string(v.Status),
+		CreatedAt:   v.CreatedAt,
+	}
+}
@@ -0,0 +1,59 @@
+package webhook
+
+import "time"
+
+// CreateEndpointRequest описывает новый адрес webhook.
+type CreateEndpointRequest struct {
+	URL         string   `json:"url" binding:"required"`         // https:// (http:// — только вне production)
+	EventTypes  []string `json:"event_types" binding:"required"` // user.registered, user.email_verified, user.account_deleted
+	Secret      string   `json:"secret,omitempty"`               // Ключ подписи не короче 16 символов; пусто — сгенерировать
+	Description string   `json:"description,omitempty"`          // До 255 символов
+}
+
+// UpdateEndpointRequest описывает изменение адреса; отсутствующие поля не меняются.
+type UpdateEndpointRequest struct {
+	URL         *string  `json:"url,omitempty"`
+	EventTypes  []string `json:"event_types,omitempty"`
+	Active      *bool    `json:"active,omitempty"`
+	Description *string  `json:"description,omitempty"`
+}
+
+// EndpointResponse описывает адрес webhook. Ключ подписи возвращается только при создании
+// и замене ключа.
+type EndpointResponse struct {
+	ID          string    `json:"id"`
+	URL         string    `json:"url"`
+	EventTypes  []string  `json:"event_types"`
+	Active      bool      `json:"active"`
+	Description string    `json:"description,omitempty"`
+	Secret      string    `json:"secret,omitempty"`
+	CreatedBy   *string   `json:"created_by,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// EndpointListResponse описывает список адресов webhooks.
+type EndpointListResponse struct {
+	Items []EndpointResponse `json:"items"`
+}
+
+// DeliveryResponse описывает уведомление webhook.
+type DeliveryResponse struct {
+	ID             int64      `json:"id"`
+	EndpointID     string     `json:"endpoint_id"`
+	EventID        int64      `json:"event_id"`
+	EventType      string     `json:"event_type"`
+	Status         string     `json:"status"`
+	Attempts       int        `json:"attempts"`
+	NextAttemptAt  time.Time  `json:"next_attempt_at"`
+	LastError      string     `json:"last_error,omitempty"`
+	LastStatusCode int        `json:"last_status_code,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+	FinishedAt     *time.Time `json:"finished_at,omitempty"`
+}
+
+// DeliveryListResponse описывает страницу уведомлений адреса.
+type DeliveryListResponse struct {
+	Items []DeliveryResponse `json:"items"`
+	Total int64              `json:"total"`
+}
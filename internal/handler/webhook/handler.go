@@ -0,0 +1,386 @@
+package webhook
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	domain "workout-app/internal/domain/webhook"
+	"workout-app/internal/handler/middleware"
+	"workout-app/internal/handler/response"
+	repo "workout-app/internal/repository/interfaces"
+	webhookuc "workout-app/internal/usecase/webhook"
+	"workout-app/pkg/logger"
+)
+
+// Handler обрабатывает административные запросы к webhooks.
+type Handler struct {
+	webhooks webhookuc.Service
+	logger   logger.Logger
+}
+
+// NewHandler создаёт новый WebhookHandler.
+func NewHandler(webhooks webhookuc.Service, logger logger.Logger) *Handler {
+	return &Handler{
+		webhooks: webhooks,
+		logger:   logger,
+	}
+}
+
+// List godoc
+// @Summary      Адреса webhooks (админ)
+// @Description  Возвращает адреса внешних систем, получающих уведомления о событиях аккаунта. Ключи подписи не возвращаются.
+// @Tags         admin
+// @Security     BearerAuth
+// @Produce      json
+// @Success      200  {object}  EndpointListResponse
+// @Failure      401  {object}  response.ErrorBody
+// @Failure      403  {object}  response.ErrorBody
+// @Failure      500  {object}  response.ErrorBody
+// @Failure      503  {object}  response.ErrorBody
+// @Router       /api/v1/admin/webhooks [get]
+func (h *Handler) List(c *gin.Context) {
+	endpoints, err := h.webhooks.ListEndpoints(c.Request.Context())
+	if err != nil {
+		h.respondError(c, "list_webhooks", err)
+		return
+	}
+
+	resp := EndpointListResponse{Items: make([]EndpointResponse, 0, len(endpoints))}
+	for _, e := range endpoints {
+		resp.Items = append(resp.Items, toEndpointResponse(e, false))
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// Create godoc
+// @Summary      Добавить адрес webhook (админ)
+// @Description  Добавляет адрес и подписывает его на события. Если ключ подписи не задан, он генерируется; ключ возвращается только в этом ответе.
+// @Tags         admin
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        request  body      CreateEndpointRequest  true  "Адрес"
+// @Success      201      {object}  EndpointResponse
+// @Failure      400      {object}  response.ErrorBody
+// @Failure      401      {object}  response.ErrorBody
+// @Failure      403      {object}  response.ErrorBody
+// @Failure      500      {object}  response.ErrorBody
+// @Failure      503      {object}  response.ErrorBody
+// @Router       /api/v1/admin/webhooks [post]
+func (h *Handler) Create(c *gin.Context) {
+	actorID, err := middleware.UserIDFromContext(c)
+	if err != nil {
+		response.Error(c, http.StatusUnauthorized, "unauthorized", "Требуется аутентификация", nil)
+		return
+	}
+
+	var req CreateEndpointRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid_request", "Некорректное тело запроса", err.Error())
+		return
+	}
+
+	e, err := h.webhooks.CreateEndpoint(c.Request.Context(), actorID, webhookuc.EndpointInput{
+		URL:         req.URL,
+		Secret:      req.Secret,
+		EventTypes:  req.EventTypes,
+		Description: req.Description,
+	})
+	if err != nil {
+		h.respondError(c, "create_webhook", err)
+		return
+	}
+
+	h.logger.Info("webhook_created", map[string]any{
+		"webhook_id":  e.ID.String(),
+		"event_types": e.EventTypes,
+		"actor_id":    actorID.String(),
+	})
+	c.JSON(http.StatusCreated, toEndpointResponse(e, true))
+}
+
+// Update godoc
+// @Summary      Изменить адрес webhook (админ)
+// @Description  Меняет переданные поля: адрес, события, описание; active=false выключает адрес — новые уведомления для него не создаются, а ожидающие уходят в dead letter.
+// @Tags         admin
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        id       path      string                 true  "ID адреса"
+// @Param        request  body      UpdateEndpointRequest  true  "Изменения"
+// @Success      200      {object}  EndpointResponse
+// @Failure      400      {object}  response.ErrorBody
+// @Failure      401      {object}  response.ErrorBody
+// @Failure      403      {object}  response.ErrorBody
+// @Failure      404      {object}  response.ErrorBody
+// @Failure      500      {object}  response.ErrorBody
+// @Failure      503      {object}  response.ErrorBody
+// @Router       /api/v1/admin/webhooks/{id} [patch]
+func (h *Handler) Update(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid_id", "Некорректный ID адреса", nil)
+		return
+	}
+
+	var req UpdateEndpointRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid_request", "Некорректное тело запроса", err.Error())
+		return
+	}
+
+	e, err := h.webhooks.UpdateEndpoint(c.Request.Context(), id, webhookuc.EndpointUpdate{
+		URL:         req.URL,
+		EventTypes:  req.EventTypes,
+		Active:      req.Active,
+		Description: req.Description,
+	})
+	if err != nil {
+		h.respondError(c, "update_webhook", err)
+		return
+	}
+
+	h.logger.Info("webhook_updated", map[string]any{
+		"webhook_id": e.ID.String(),
+		"active":     e.Active,
+		"actor_id":   c.GetString(middleware.ContextUserIDKey),
+	})
+	c.JSON(http.StatusOK, toEndpointResponse(e, false))
+}
+
+// RotateSecret godoc
+// @Summary      Заменить ключ подписи webhook (админ)
+// @Description  Генерирует новый ключ подписи; все следующие попытки, включая ожидающие в очереди, подписываются им. Ключ возвращается только в этом ответе.
+// @Tags         admin
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id   path      string  true  "ID адреса"
+// @Success      200  {object}  EndpointResponse
+// @Failure      400  {object}  response.ErrorBody
+// @Failure      401  {object}  response.ErrorBody
+// @Failure      403  {object}  response.ErrorBody
+// @Failure      404  {object}  response.ErrorBody
+// @Failure      500  {object}  response.ErrorBody
+// @Failure      503  {object}  response.ErrorBody
+// @Router       /api/v1/admin/webhooks/{id}/rotate-secret [post]
+func (h *Handler) RotateSecret(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid_id", "Некорректный ID адреса", nil)
+		return
+	}
+
+	e, err := h.webhooks.RotateSecret(c.Request.Context(), id)
+	if err != nil {
+		h.respondError(c, "rotate_webhook_secret", err)
+		return
+	}
+
+	h.logger.Info("webhook_secret_rotated", map[string]any{
+		"webhook_id": e.ID.String(),
+		"actor_id":   c.GetString(middleware.ContextUserIDKey),
+	})
+	c.JSON(http.StatusOK, toEndpointResponse(e, true))
+}
+
+// Delete godoc
+// @Summary      Удалить адрес webhook (админ)
+// @Description  Удаляет адрес вместе с историей и очередью его уведомлений.
+// @Tags         admin
+// @Security     BearerAuth
+// @Param        id  path  string  true  "ID адреса"
+// @Success      204
+// @Failure      400  {object}  response.ErrorBody
+// @Failure      401  {object}  response.ErrorBody
+// @Failure      403  {object}  response.ErrorBody
+// @Failure      404  {object}  response.ErrorBody
+// @Failure      500  {object}  response.ErrorBody
+// @Failure      503  {object}  response.ErrorBody
+// @Router       /api/v1/admin/webhooks/{id} [delete]
+func (h *Handler) Delete(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid_id", "Некорректный ID адреса", nil)
+		return
+	}
+
+	if err := h.webhooks.DeleteEndpoint(c.Request.Context(), id); err != nil {
+		h.respondError(c, "delete_webhook", err)
+		return
+	}
+
+	h.logger.Info("webhook_deleted", map[string]any{
+		"webhook_id": id.String(),
+		"actor_id":   c.GetString(middleware.ContextUserIDKey),
+	})
+	c.Status(http.StatusNoContent)
+}
+
+// ListDeliveries godoc
+// @Summary      Уведомления адреса webhook (админ)
+// @Description  Возвращает страницу уведомлений адреса, новые первыми: статус, число попыток, HTTP-статус и ошибку последней попытки.
+// @Tags         admin
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id      path      string  true   "ID адреса"
+// @Param        status  query     string  false  "Статус: pending, delivered или dead"
+// @Param        limit   query     int     false  "Размер страницы (по умолчанию 50, максимум 200)"
+// @Param        offset  query     int     false  "Смещение"
+// @Success      200     {object}  DeliveryListResponse
+// @Failure      400     {object}  response.ErrorBody
+// @Failure      401     {object}  response.ErrorBody
+// @Failure      403     {object}  response.ErrorBody
+// @Failure      404     {object}  response.ErrorBody
+// @Failure      500     {object}  response.ErrorBody
+// @Failure      503     {object}  response.ErrorBody
+// @Router       /api/v1/admin/webhooks/{id}/deliveries [get]
+func (h *Handler) ListDeliveries(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid_id", "Некорректный ID адреса", nil)
+		return
+	}
+	limit, err1 := queryInt(c, "limit")
+	offset, err2 := queryInt(c, "offset")
+	if err1 != nil || err2 != nil {
+		response.Error(c, http.StatusBadRequest, "invalid_pagination", "Параметры limit и offset должны быть неотрицательными числами", nil)
+		return
+	}
+
+	items, total, err := h.webhooks.ListDeliveries(c.Request.Context(), id, domain.Status(c.Query("status")), limit, offset)
+	if err != nil {
+		h.respondError(c, "list_webhook_deliveries", err)
+		return
+	}
+
+	resp := DeliveryListResponse{Items: make([]DeliveryResponse, 0, len(items)), Total: total}
+	for _, d := range items {
+		resp.Items = append(resp.Items, toDeliveryResponse(d))
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// RetryDelivery godoc
+// @Summary      Повторить уведомление из dead letter (админ)
+// @Description  Возвращает уведомление в очередь со сброшенным счётчиком попыток; воркер отправит его при следующем запуске.
+// @Tags         admin
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id   path      int  true  "ID уведомления"
+// @Success      200  {object}  DeliveryResponse
+// @Failure      400  {object}  response.ErrorBody
+// @Failure      401  {object}  response.ErrorBody
+// @Failure      403  {object}  response.ErrorBody
+// @Failure      404  {object}  response.ErrorBody
+// @Failure      409  {object}  response.ErrorBody
+// @Failure      500  {object}  response.ErrorBody
+// @Failure      503  {object}  response.ErrorBody
+// @Router       /api/v1/admin/webhooks/deliveries/{id}/retry [post]
+func (h *Handler) RetryDelivery(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || id <= 0 {
+		response.Error(c, http.StatusBadRequest, "invalid_id", "Некорректный ID уведомления", nil)
+		return
+	}
+
+	d, err := h.webhooks.RetryDelivery(c.Request.Context(), id)
+	if err != nil {
+		h.respondError(c, "retry_webhook_delivery", err)
+		return
+	}
+
+	h.logger.Info("webhook_delivery_requeued", map[string]any{
+		"delivery_id": d.ID,
+		"webhook_id":  d.EndpointID.String(),
+		"actor_id":    c.GetString(middleware.ContextUserIDKey),
+	})
+	c.JSON(http.StatusOK, toDeliveryResponse(d))
+}
+
+// respondError отправляет ответ об ошибке для эндпоинтов webhooks.
+func (h *Handler) respondError(c *gin.Context, op string, err error) {
+	switch {
+	case errors.Is(err, webhookuc.ErrDisabled):
+		response.Error(c, http.StatusServiceUnavailable, "webhooks_disabled", "Webhooks отключены: не задан WEBHOOK_SECRET_KEY", nil)
+	case errors.Is(err, webhookuc.ErrInvalidURL):
+		response.Error(c, http.StatusBadRequest, "invalid_url", "Адрес должен быть абсолютным URL http(s) без учётных данных", nil)
+	case errors.Is(err, webhookuc.ErrInsecureURL):
+		response.Error(c, http.StatusBadRequest, "insecure_url", "Адрес должен использовать https", nil)
+	case errors.Is(err, webhookuc.ErrInvalidEventType):
+		response.Error(c, http.StatusBadRequest, "invalid_event_type", "Неподдерживаемый тип события", domain.EventTypes)
+	case errors.Is(err, webhookuc.ErrNoEventTypes):
+		response.Error(c, http.StatusBadRequest, "no_event_types", "Укажите хотя бы один тип события", domain.EventTypes)
+	case errors.Is(err, webhookuc.ErrSecretTooShort):
+		response.Error(c, http.StatusBadRequest, "secret_too_short", "Ключ подписи должен быть не короче 16 символов", nil)
+	case errors.Is(err, webhookuc.ErrDescriptionTooLong):
+		response.Error(c, http.StatusBadRequest, "description_too_long", "Описание должно быть не длиннее 255 символов", nil)
+	case errors.Is(err, webhookuc.ErrInvalidStatus):
+		response.Error(c, http.StatusBadRequest, "invalid_status", "Статус должен быть pending, delivered или dead", nil)
+	case errors.Is(err, webhookuc.ErrNotDead):
+		response.Error(c, http.StatusConflict, "delivery_not_dead", "Повторить можно только уведомление из dead letter", nil)
+	case errors.Is(err, repo.ErrNotFound):
+		response.Error(c, http.StatusNotFound, "webhook_not_found", "Адрес или уведомление не найдены", nil)
+	default:
+		h.logger.Error("internal_error_in_"+op, map[string]any{
+			"path":   c.Request.URL.Path,
+			"method": c.Request.Method,
+			"error":  err.Error(),
+		})
+		response.Error(c, http.StatusInternalServerError, "internal_error", "Внутренняя ошибка сервера", nil)
+	}
+}
+
+// queryInt читает неотрицательный целочисленный параметр запроса (0, если не задан).
+func queryInt(c *gin.Context, name string) (int, error) {
+	raw := c.Query(name)
+	if raw == "" {
+		return 0, nil
+	}
+	n, err := strconv.Atoi(raw)
+	if err != nil || n < 0 {
+		return 0, strconv.ErrSyntax
+	}
+	return n, nil
+}
+
+// toEndpointResponse маппит доменную модель в DTO; withSecret — вернуть ключ подписи.
+func toEndpointResponse(e *domain.Endpoint, withSecret bool) EndpointResponse {
+	resp := EndpointResponse{
+		ID:          e.ID.String(),
+		URL:         e.URL,
+		EventTypes:  e.EventTypes,
+		Active:      e.Active,
+		Description: e.Description,
+		CreatedAt:   e.CreatedAt,
+		UpdatedAt:   e.UpdatedAt,
+	}
+	if withSecret {
+		resp.Secret = e.Secret
+	}
+	if e.CreatedBy != nil {
+		by := e.CreatedBy.String()
+		resp.CreatedBy = &by
+	}
+	return resp
+}
+
+// toDeliveryResponse маппит доменную модель в DTO. Тело уведомления не возвращается.
+func toDeliveryResponse(d *domain.Delivery) DeliveryResponse {
+	return DeliveryResponse{
+		ID:             d.ID,
+		EndpointID:     d.EndpointID.String(),
+		EventID:        d.EventID,
+		EventType:      d.EventType,
+		Status:         string(d.Status),
+		Attempts:       d.Attempts,
+		NextAttemptAt:  d.NextAttemptAt,
+		LastError:      d.LastError,
+		LastStatusCode: d.LastStatusCode,
+		CreatedAt:      d.CreatedAt,
+		FinishedAt:     d.FinishedAt,
+	}
+}
@@ -0,0 +1,119 @@
+package workout
+
+import "time"
+
+// StartSessionRequest описывает тело запроса на старт тренировки.
+type StartSessionRequest struct {
+	Title        string  `json:"title" binding:"required,max=200"`
+	AssignmentID *string `json:"assignment_id,omitempty" binding:"omitempty,uuid"`
+	// AutoPauseAfterSeconds — порог автопаузы этой тренировки (30..3600); не задан — значение сервера.
+	AutoPauseAfterSeconds *int `json:"auto_pause_after_seconds,omitempty" binding:"omitempty,min=30,max=3600"`
+	// ProgramWorkout — тренировка программы назначения: её упражнения с темпом и отдыхом
+	// становятся планом тренировки. Требует assignment_id.
+	ProgramWorkout *ProgramWorkoutRequest `json:"program_workout,omitempty"`
+}
+
+// ProgramWorkoutRequest указывает тренировку программы: неделя, день недели (1 — понедельник)
+// и порядковый номер тренировки в дне (с 0).
+type ProgramWorkoutRequest struct {
+	Week  int `json:"week" binding:"required,min=1,max=52"`
+	Day   int `json:"day" binding:"required,min=1,max=7"`
+	Index int `json:"index" binding:"min=0,max=4"`
+}
+
+// LogSetRequest описывает тело запроса на запись подхода. Время подхода задаёт сервер.
+// Вес передаётся в килограммах (weight_kg) или фунтах (weight_lb), но не в обеих единицах сразу.
+type LogSetRequest struct {
+	Exercise string   `json:"exercise" binding:"required,max=100"`
+	Reps     int      `json:"reps" binding:"required,min=1,max=1000"`
+	WeightKg *float64 `json:"weight_kg,omitempty" binding:"omitempty,gte=0,lte=1000"`
+	WeightLb *float64 `json:"weight_lb,omitempty" binding:"omitempty,gte=0,lte=2200"`
+}
+
+// RateDifficultyRequest описывает оценку сложности завершённой тренировки.
+type RateDifficultyRequest struct {
+	// Difficulty — субъективная сложность по шкале RPE: 1 — очень легко, 10 — на пределе.
+	Difficulty int `json:"difficulty" binding:"required,min=1,max=10"`
+}
+
+// SetResponse описывает выполненный подход. Вес — в weight_kg или, для имперской системы, в weight_lb.
+// Implausible — значения за пределами правдоподобных границ: подход не входит в тоннаж и статистику.
+// Warnings заполняется только в ответе на запись подхода.
+type SetResponse struct {
+	ID          string    `json:"id"`
+	Exercise    string    `json:"exercise"`
+	Reps        int       `json:"reps"`
+	WeightKg    *float64  `json:"weight_kg,omitempty"`
+	WeightLb    *float64  `json:"weight_lb,omitempty"`
+	LoggedAt    time.Time `json:"logged_at"`
+	Implausible bool      `json:"implausible,omitempty"`
+	// PrescribedRestSeconds — отдых после подхода по плану тренировки, по нему клиент запускает таймер отдыха.
+	PrescribedRestSeconds *int              `json:"prescribed_rest_seconds,omitempty"`
+	Warnings              []WarningResponse `json:"warnings,omitempty"`
+}
+
+// WarningResponse описывает нарушенную правдоподобную границу. Вес — в единицах пользователя
+// (field weight_kg или weight_lb).
+type WarningResponse struct {
+	Code  string  `json:"code"`
+	Field string  `json:"field"`
+	Value float64 `json:"value"`
+	Limit float64 `json:"limit"`
+}
+
+// PlannedExerciseResponse описывает упражнение плана тренировки с предписаниями программы.
+// Вес — в weight_kg или, для имперской системы, в weight_lb.
+type PlannedExerciseResponse struct {
+	Exercise    string   `json:"exercise"`
+	Sets        int      `json:"sets"`
+	Reps        int      `json:"reps"`
+	WeightKg    *float64 `json:"weight_kg,omitempty"`
+	WeightLb    *float64 `json:"weight_lb,omitempty"`
+	Tempo       string   `json:"tempo,omitempty" example:"3-1-1-0"`
+	RestSeconds []int    `json:"rest_seconds,omitempty"`
+}
+
+// SummaryResponse описывает итоги тренировки с учётом автопаузы.
+// Тоннаж — в volume_kg или, для имперской системы, в volume_lb; volume_per_minute — в тех же единицах.
+type SummaryResponse struct {
+	TotalSeconds    int64    `json:"total_seconds"`
+	ActiveSeconds   int64    `json:"active_seconds"`
+	PausedSeconds   int64    `json:"paused_seconds"`
+	Pauses          int      `json:"pauses"`
+	Sets            int      `json:"sets"`
+	VolumeKg        *float64 `json:"volume_kg,omitempty"`
+	VolumeLb        *float64 `json:"volume_lb,omitempty"`
+	VolumePerMinute float64  `json:"volume_per_minute"`
+}
+
+// SessionResponse описывает тренировку с подходами и итогами.
+// Для идущей тренировки итоги считаются на момент ответа.
+type SessionResponse struct {
+	ID                    string     `json:"id"`
+	Title                 string     `json:"title"`
+	AssignmentID          *string    `json:"assignment_id,omitempty"`
+	AutoPauseAfterSeconds int        `json:"auto_pause_after_seconds"`
+	StartedAt             time.Time  `json:"started_at"`
+	FinishedAt            *time.Time `json:"finished_at,omitempty"`
+	Active                bool       `json:"active"`
+	Difficulty            *int       `json:"difficulty,omitempty"`
+	DifficultyRatedAt     *time.Time `json:"difficulty_rated_at,omitempty"`
+	// Plan — план тренировки программы на момент старта; нет у свободной тренировки.
+	Plan    []PlannedExerciseResponse `json:"plan,omitempty"`
+	Sets    []SetResponse             `json:"sets"`
+	Summary SummaryResponse           `json:"summary"`
+}
+
+// StatsResponse описывает суммарные итоги завершённых тренировок за период.
+// Тоннаж — в volume_kg или volume_lb в зависимости от системы единиц пользователя.
+type StatsResponse struct {
+	From            time.Time `json:"from"`
+	To              time.Time `json:"to"`
+	Sessions        int       `json:"sessions"`
+	Sets            int       `json:"sets"`
+	VolumeKg        *float64  `json:"volume_kg,omitempty"`
+	VolumeLb        *float64  `json:"volume_lb,omitempty"`
+	ActiveSeconds   int64     `json:"active_seconds"`
+	PausedSeconds   int64     `json:"paused_seconds"`
+	VolumePerMinute float64   `json:"volume_per_minute"`
+}
@@ -0,0 +1,751 @@
+package workout
+
+import (
+	"errors"
+	"math"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	userdomain "workout-app/internal/domain/user"
+	domain "workout-app/internal/domain/workout"
+	"workout-app/internal/handler/middleware"
+	"workout-app/internal/handler/response"
+	repo "workout-app/internal/repository/interfaces"
+	consentuc "workout-app/internal/usecase/consent"
+	socialuc "workout-app/internal/usecase/social"
+	useruc "workout-app/internal/usecase/user"
+	workoutuc "workout-app/internal/usecase/workout"
+	"workout-app/pkg/logger"
+)
+
+// defaultPeriod — период выборки, если from не задан.
+const defaultPeriod = 30 * 24 * time.Hour
+
+// Handler обрабатывает HTTP-запросы, связанные с тренировками.
+// Вес подходов и тоннаж отдаются в системе единиц текущего пользователя, хранятся в килограммах.
+type Handler struct {
+	workouts workoutuc.Service
+	units    useruc.UnitsProvider
+	logger   logger.Logger
+}
+
+// NewHandler создаёт новый WorkoutHandler.
+func NewHandler(workouts workoutuc.Service, units useruc.UnitsProvider, logger logger.Logger) *Handler {
+	return &Handler{
+		workouts: workouts,
+		units:    units,
+		logger:   logger,
+	}
+}
+
+// Start godoc
+// @Summary      Начать тренировку
+// @Description  Начинает тренировку. Время старта и подходов фиксирует сервер. Незавершённая тренировка без активности дольше 12 часов завершается автоматически.
+// @Description  С assignment_id и program_workout в тренировку записывается план тренировки программы: упражнения с темпом и отдыхом после каждого подхода.
+// @Tags         workouts
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        payload  body      StartSessionRequest  true  "Параметры тренировки"
+// @Success      201      {object}  SessionResponse
+// @Failure      400      {object}  response.ErrorBody
+// @Failure      401      {object}  response.ErrorBody
+// @Failure      404      {object}  response.ErrorBody
+// @Failure      409      {object}  response.ErrorBody
+// @Failure      500      {object}  response.ErrorBody
+// @Router       /api/v1/workouts [post]
+func (h *Handler) Start(c *gin.Context) {
+	userID, ok := h.userID(c)
+	if !ok {
+		return
+	}
+
+	var req StartSessionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid_request", "Некорректное тело запроса", err.Error())
+		return
+	}
+
+	input := workoutuc.StartInput{Title: req.Title}
+	if req.AssignmentID != nil {
+		id, err := uuid.Parse(*req.AssignmentID)
+		if err != nil {
+			response.Error(c, http.StatusBadRequest, "invalid_request", "Некорректный assignment_id", nil)
+			return
+		}
+		input.AssignmentID = &id
+	}
+	if req.AutoPauseAfterSeconds != nil {
+		input.AutoPauseAfter = time.Duration(*req.AutoPauseAfterSeconds) * time.Second
+	}
+	if req.ProgramWorkout != nil {
+		input.ProgramWorkout = &workoutuc.ProgramWorkoutRef{
+			Week:  req.ProgramWorkout.Week,
+			Day:   req.ProgramWorkout.Day,
+			Index: req.ProgramWorkout.Index,
+		}
+	}
+
+	session, err := h.workouts.Start(c.Request.Context(), userID, input)
+	if err != nil {
+		h.respondError(c, "start_workout", userID, err)
+		return
+	}
+	h.respondSession(c, http.StatusCreated, userID, session)
+}
+
+// LogSet godoc
+// @Summary      Записать подход
+// @Description  Записывает подход идущей тренировки. Время подхода фиксирует сервер: промежутки между подходами дольше порога автопаузы не входят в активное время.
+// @Description  Вес принимается в килограммах (weight_kg) или фунтах (weight_lb), ответ — в системе единиц пользователя.
+// @Tags         workouts
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        id       path      string         true  "ID тренировки"
+// @Param        payload  body      LogSetRequest  true  "Подход"
+// @Success      201      {object}  SetResponse
+// @Failure      400      {object}  response.ErrorBody
+// @Failure      401      {object}  response.ErrorBody
+// @Failure      404      {object}  response.ErrorBody
+// @Failure      409      {object}  response.ErrorBody
+// @Failure      422      {object}  response.ErrorBody
+// @Failure      500      {object}  response.ErrorBody
+// @Router       /api/v1/workouts/{id}/sets [post]
+func (h *Handler) LogSet(c *gin.Context) {
+	userID, ok := h.userID(c)
+	if !ok {
+		return
+	}
+	sessionID, ok := parseSessionID(c)
+	if !ok {
+		return
+	}
+
+	var req LogSetRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid_request", "Некорректное тело запроса", err.Error())
+		return
+	}
+	weightKg := req.WeightKg
+	if req.WeightLb != nil {
+		if weightKg != nil {
+			response.Error(c, http.StatusBadRequest, "invalid_request", "Вес указывается либо в weight_kg, либо в weight_lb", nil)
+			return
+		}
+		kg := userdomain.PoundsToKg(*req.WeightLb)
+		weightKg = &kg
+	}
+
+	set, warnings, err := h.workouts.LogSet(c.Request.Context(), userID, sessionID, workoutuc.SetInput{
+		Exercise: req.Exercise,
+		Reps:     req.Reps,
+		WeightKg: weightKg,
+	})
+	var implausibleErr *workoutuc.ImplausibleError
+	if errors.As(err, &implausibleErr) {
+		warnings = implausibleErr.Warnings
+	} else if err != nil {
+		h.respondError(c, "log_workout_set", userID, err)
+		return
+	}
+	units, ok := h.userUnits(c, userID)
+	if !ok {
+		return
+	}
+	if implausibleErr != nil {
+		response.Error(c, http.StatusUnprocessableEntity, "implausible_set",
+			"Вес или повторения за пределами правдоподобных значений", toWarningResponses(warnings, units))
+		return
+	}
+	resp := toSetResponse(*set, units)
+	resp.Warnings = toWarningResponses(warnings, units)
+	c.JSON(http.StatusCreated, resp)
+}
+
+// Finish godoc
+// @Summary      Завершить тренировку
+// @Description  Завершает тренировку и возвращает итоги с учётом автопаузы.
+// @Tags         workouts
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id   path      string  true  "ID тренировки"
+// @Success      200  {object}  SessionResponse
+// @Failure      400  {object}  response.ErrorBody
+// @Failure      401  {object}  response.ErrorBody
+// @Failure      404  {object}  response.ErrorBody
+// @Failure      409  {object}  response.ErrorBody
+// @Failure      500  {object}  response.ErrorBody
+// @Router       /api/v1/workouts/{id}/finish [post]
+func (h *Handler) Finish(c *gin.Context) {
+	userID, ok := h.userID(c)
+	if !ok {
+		return
+	}
+	sessionID, ok := parseSessionID(c)
+	if !ok {
+		return
+	}
+
+	session, err := h.workouts.Finish(c.Request.Context(), userID, sessionID)
+	if err != nil {
+		h.respondError(c, "finish_workout", userID, err)
+		return
+	}
+	h.respondSession(c, http.StatusOK, userID, session)
+}
+
+// RateDifficulty godoc
+// @Summary      Оценить сложность тренировки
+// @Description  Сохраняет субъективную сложность завершённой тренировки по шкале RPE (1 — очень легко, 10 — на пределе). Повторная оценка заменяет прежнюю.
+// @Description  Оценки тренировок по назначенной программе формируют среднюю сложность программы и предлагаемое изменение нагрузки в расписании.
+// @Tags         workouts
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        id       path      string                 true  "ID тренировки"
+// @Param        payload  body      RateDifficultyRequest  true  "Оценка сложности"
+// @Success      200      {object}  SessionResponse
+// @Failure      400      {object}  response.ErrorBody
+// @Failure      401      {object}  response.ErrorBody
+// @Failure      404      {object}  response.ErrorBody
+// @Failure      409      {object}  response.ErrorBody
+// @Failure      500      {object}  response.ErrorBody
+// @Router       /api/v1/workouts/{id}/difficulty [put]
+func (h *Handler) RateDifficulty(c *gin.Context) {
+	userID, ok := h.userID(c)
+	if !ok {
+		return
+	}
+	sessionID, ok := parseSessionID(c)
+	if !ok {
+		return
+	}
+
+	var req RateDifficultyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid_request", "Некорректное тело запроса", err.Error())
+		return
+	}
+
+	session, err := h.workouts.RateDifficulty(c.Request.Context(), userID, sessionID, req.Difficulty)
+	if err != nil {
+		h.respondError(c, "rate_workout_difficulty", userID, err)
+		return
+	}
+	h.respondSession(c, http.StatusOK, userID, session)
+}
+
+// Get godoc
+// @Summary      Получить тренировку
+// @Description  Возвращает тренировку с подходами и итогами. Для идущей тренировки итоги считаются на момент запроса.
+// @Tags         workouts
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id   path      string  true  "ID тренировки"
+// @Success      200  {object}  SessionResponse
+// @Failure      400  {object}  response.ErrorBody
+// @Failure      401  {object}  response.ErrorBody
+// @Failure      404  {object}  response.ErrorBody
+// @Failure      500  {object}  response.ErrorBody
+// @Router       /api/v1/workouts/{id} [get]
+func (h *Handler) Get(c *gin.Context) {
+	userID, ok := h.userID(c)
+	if !ok {
+		return
+	}
+	sessionID, ok := parseSessionID(c)
+	if !ok {
+		return
+	}
+
+	session, err := h.workouts.Get(c.Request.Context(), userID, sessionID)
+	if err != nil {
+		h.respondError(c, "get_workout", userID, err)
+		return
+	}
+	h.respondSession(c, http.StatusOK, userID, session)
+}
+
+// GetActive godoc
+// @Summary      Получить идущую тренировку
+// @Description  Возвращает незавершённую тренировку текущего пользователя.
+// @Tags         workouts
+// @Security     BearerAuth
+// @Produce      json
+// @Success      200  {object}  SessionResponse
+// @Failure      401  {object}  response.ErrorBody
+// @Failure      404  {object}  response.ErrorBody
+// @Failure      500  {object}  response.ErrorBody
+// @Router       /api/v1/workouts/active [get]
+func (h *Handler) GetActive(c *gin.Context) {
+	userID, ok := h.userID(c)
+	if !ok {
+		return
+	}
+
+	session, err := h.workouts.GetActive(c.Request.Context(), userID)
+	if err != nil {
+		h.respondError(c, "get_active_workout", userID, err)
+		return
+	}
+	h.respondSession(c, http.StatusOK, userID, session)
+}
+
+// List godoc
+// @Summary      Список тренировок
+// @Description  Возвращает до 100 тренировок, начатых в периоде (по умолчанию — последние 30 дней), новые первыми.
+// @Tags         workouts
+// @Security     BearerAuth
+// @Produce      json
+// @Param        from  query     string  false  "Начало периода (RFC3339 или YYYY-MM-DD)"
+// @Param        to    query     string  false  "Конец периода (RFC3339 или YYYY-MM-DD включительно)"
+// @Success      200   {array}   SessionResponse
+// @Failure      400   {object}  response.ErrorBody
+// @Failure      401   {object}  response.ErrorBody
+// @Failure      500   {object}  response.ErrorBody
+// @Router       /api/v1/workouts [get]
+func (h *Handler) List(c *gin.Context) {
+	userID, ok := h.userID(c)
+	if !ok {
+		return
+	}
+	from, to, ok := parsePeriod(c)
+	if !ok {
+		return
+	}
+
+	sessions, err := h.workouts.List(c.Request.Context(), userID, from, to)
+	if err != nil {
+		h.respondError(c, "list_workouts", userID, err)
+		return
+	}
+
+	units, ok := h.userUnits(c, userID)
+	if !ok {
+		return
+	}
+	now := time.Now()
+	resp := make([]SessionResponse, 0, len(sessions))
+	for _, session := range sessions {
+		resp = append(resp, toSessionResponse(session, now, units))
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// ListUserWorkouts godoc
+// @Summary      Тренировки другого пользователя
+// @Description  Возвращает до 100 тренировок пользователя, начатых в периоде (по умолчанию — последние 30 дней), новые первыми. Доступно, если пользователь открыл тренировки всем (public) или подписчикам (followers) и запрашивающий на него подписан.
+// @Tags         workouts
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id    path      string  true   "ID пользователя"
+// @Param        from  query     string  false  "Начало периода (RFC3339 или YYYY-MM-DD)"
+// @Param        to    query     string  false  "Конец периода (RFC3339 или YYYY-MM-DD включительно)"
+// @Success      200   {array}   SessionResponse
+// @Failure      400   {object}  response.ErrorBody
+// @Failure      401   {object}  response.ErrorBody
+// @Failure      403   {object}  response.ErrorBody
+// @Failure      404   {object}  response.ErrorBody
+// @Failure      500   {object}  response.ErrorBody
+// @Router       /api/v1/users/{id}/workouts [get]
+func (h *Handler) ListUserWorkouts(c *gin.Context) {
+	viewerID, ok := h.userID(c)
+	if !ok {
+		return
+	}
+	ownerID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid_user_id", "Некорректный ID пользователя", nil)
+		return
+	}
+	from, to, ok := parsePeriod(c)
+	if !ok {
+		return
+	}
+
+	sessions, err := h.workouts.ListForViewer(c.Request.Context(), viewerID, ownerID, from, to)
+	if err != nil {
+		h.respondError(c, "list_user_workouts", viewerID, err)
+		return
+	}
+
+	// Тренировки другого пользователя отдаются в единицах того, кто их смотрит.
+	units, ok := h.userUnits(c, viewerID)
+	if !ok {
+		return
+	}
+	now := time.Now()
+	resp := make([]SessionResponse, 0, len(sessions))
+	for _, session := range sessions {
+		resp = append(resp, toSessionResponse(session, now, units))
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// Stats godoc
+// @Summary      Итоги тренировок за период
+// @Description  Суммирует активное время, простои и тоннаж завершённых тренировок, начатых в периоде (по умолчанию — последние 30 дней, не больше 366 дней).
+// @Tags         workouts
+// @Security     BearerAuth
+// @Produce      json
+// @Param        from  query     string  false  "Начало периода (RFC3339 или YYYY-MM-DD)"
+// @Param        to    query     string  false  "Конец периода (RFC3339 или YYYY-MM-DD включительно)"
+// @Success      200   {object}  StatsResponse
+// @Failure      400   {object}  response.ErrorBody
+// @Failure      401   {object}  response.ErrorBody
+// @Failure      500   {object}  response.ErrorBody
+// @Router       /api/v1/workouts/stats [get]
+func (h *Handler) Stats(c *gin.Context) {
+	userID, ok := h.userID(c)
+	if !ok {
+		return
+	}
+	from, to, ok := parsePeriod(c)
+	if !ok {
+		return
+	}
+
+	stats, err := h.workouts.Stats(c.Request.Context(), userID, from, to)
+	if err != nil {
+		h.respondError(c, "workout_stats", userID, err)
+		return
+	}
+	units, ok := h.userUnits(c, userID)
+	if !ok {
+		return
+	}
+	c.JSON(http.StatusOK, toStatsResponse(from, to, stats, units))
+}
+
+// ListClientWorkouts godoc
+// @Summary      Тренировки клиента (тренер)
+// @Description  Возвращает до 100 тренировок клиента текущего тренера, начатых в периоде (по умолчанию — последние 30 дней), новые первыми, в системе единиц тренера. Клиент должен открыть тренеру класс данных workouts.
+// @Tags         workouts
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id    path      string  true   "ID клиента"
+// @Param        from  query     string  false  "Начало периода (RFC3339 или YYYY-MM-DD)"
+// @Param        to    query     string  false  "Конец периода (RFC3339 или YYYY-MM-DD включительно)"
+// @Success      200   {array}   SessionResponse
+// @Failure      400   {object}  response.ErrorBody
+// @Failure      401   {object}  response.ErrorBody
+// @Failure      403   {object}  response.ErrorBody
+// @Failure      404   {object}  response.ErrorBody
+// @Failure      500   {object}  response.ErrorBody
+// @Router       /api/v1/coach/clients/{id}/workouts [get]
+func (h *Handler) ListClientWorkouts(c *gin.Context) {
+	coachID, clientID, from, to, ok := h.clientPeriod(c)
+	if !ok {
+		return
+	}
+
+	sessions, err := h.workouts.ListForCoach(c.Request.Context(), coachID, clientID, from, to)
+	if err != nil {
+		h.respondError(c, "list_client_workouts", coachID, err)
+		return
+	}
+
+	units, ok := h.userUnits(c, coachID)
+	if !ok {
+		return
+	}
+	now := time.Now()
+	resp := make([]SessionResponse, 0, len(sessions))
+	for _, session := range sessions {
+		resp = append(resp, toSessionResponse(session, now, units))
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// ClientStats godoc
+// @Summary      Итоги тренировок клиента (тренер)
+// @Description  Итоги завершённых тренировок клиента текущего тренера за период (по умолчанию — последние 30 дней, не больше 366 дней) в системе единиц тренера. Клиент должен открыть тренеру класс данных workouts.
+// @Tags         workouts
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id    path      string  true   "ID клиента"
+// @Param        from  query     string  false  "Начало периода (RFC3339 или YYYY-MM-DD)"
+// @Param        to    query     string  false  "Конец периода (RFC3339 или YYYY-MM-DD включительно)"
+// @Success      200   {object}  StatsResponse
+// @Failure      400   {object}  response.ErrorBody
+// @Failure      401   {object}  response.ErrorBody
+// @Failure      403   {object}  response.ErrorBody
+// @Failure      404   {object}  response.ErrorBody
+// @Failure      500   {object}  response.ErrorBody
+// @Router       /api/v1/coach/clients/{id}/workouts/stats [get]
+func (h *Handler) ClientStats(c *gin.Context) {
+	coachID, clientID, from, to, ok := h.clientPeriod(c)
+	if !ok {
+		return
+	}
+
+	stats, err := h.workouts.StatsForCoach(c.Request.Context(), coachID, clientID, from, to)
+	if err != nil {
+		h.respondError(c, "client_workout_stats", coachID, err)
+		return
+	}
+	units, ok := h.userUnits(c, coachID)
+	if !ok {
+		return
+	}
+	c.JSON(http.StatusOK, toStatsResponse(from, to, stats, units))
+}
+
+// clientPeriod извлекает текущего тренера, ID клиента из пути и период выборки.
+func (h *Handler) clientPeriod(c *gin.Context) (uuid.UUID, uuid.UUID, time.Time, time.Time, bool) {
+	coachID, ok := h.userID(c)
+	if !ok {
+		return uuid.Nil, uuid.Nil, time.Time{}, time.Time{}, false
+	}
+	clientID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid_user_id", "Некорректный ID пользователя", nil)
+		return uuid.Nil, uuid.Nil, time.Time{}, time.Time{}, false
+	}
+	from, to, ok := parsePeriod(c)
+	return coachID, clientID, from, to, ok
+}
+
+// toStatsResponse маппит итоги тренировок в DTO в системе единиц units.
+func toStatsResponse(from, to time.Time, stats *workoutuc.Stats, units userdomain.Units) StatsResponse {
+	resp := StatsResponse{
+		From:            from,
+		To:              to,
+		Sessions:        stats.Sessions,
+		Sets:            stats.Sets,
+		ActiveSeconds:   int64(stats.ActiveDuration / time.Second),
+		PausedSeconds:   int64(stats.PausedDuration / time.Second),
+		VolumePerMinute: round2(units.Weight(stats.VolumePerMinute)),
+	}
+	resp.VolumeKg, resp.VolumeLb = volumeFields(stats.VolumeKg, units)
+	return resp
+}
+
+// userID извлекает ID текущего пользователя и отвечает 401, если его нет.
+func (h *Handler) userID(c *gin.Context) (uuid.UUID, bool) {
+	userID, err := middleware.UserIDFromContext(c)
+	if err != nil {
+		response.Error(c, http.StatusUnauthorized, "unauthorized", "Требуется аутентификация", nil)
+		return uuid.Nil, false
+	}
+	return userID, true
+}
+
+// userUnits возвращает систему единиц пользователя и отвечает 500, если её не удалось получить.
+func (h *Handler) userUnits(c *gin.Context, userID uuid.UUID) (userdomain.Units, bool) {
+	units, err := h.units.Units(c.Request.Context(), userID)
+	if err != nil {
+		h.respondError(c, "workout_units", userID, err)
+		return "", false
+	}
+	return units, true
+}
+
+// respondSession отвечает тренировкой в системе единиц пользователя userID.
+func (h *Handler) respondSession(c *gin.Context, status int, userID uuid.UUID, session *domain.Session) {
+	units, ok := h.userUnits(c, userID)
+	if !ok {
+		return
+	}
+	c.JSON(status, toSessionResponse(session, time.Now(), units))
+}
+
+// respondError маппит ошибки usecase-слоя в HTTP-ответы.
+func (h *Handler) respondError(c *gin.Context, op string, userID uuid.UUID, err error) {
+	switch {
+	case errors.Is(err, workoutuc.ErrInvalidTitle):
+		response.Error(c, http.StatusBadRequest, "invalid_title", "Название тренировки должно содержать от 1 до 200 символов", nil)
+	case errors.Is(err, workoutuc.ErrInvalidAutoPause):
+		response.Error(c, http.StatusBadRequest, "invalid_auto_pause", "Порог автопаузы должен быть от 30 секунд до 1 часа", nil)
+	case errors.Is(err, workoutuc.ErrInvalidSet):
+		response.Error(c, http.StatusBadRequest, "invalid_set", "Некорректное упражнение, повторения или вес", nil)
+	case errors.Is(err, workoutuc.ErrInvalidPeriod):
+		response.Error(c, http.StatusBadRequest, "invalid_range", "Некорректный период", nil)
+	case errors.Is(err, workoutuc.ErrInvalidDifficulty):
+		response.Error(c, http.StatusBadRequest, "invalid_difficulty", "Сложность тренировки оценивается от 1 до 10", nil)
+	case errors.Is(err, workoutuc.ErrTooManySets):
+		response.Error(c, http.StatusBadRequest, "too_many_sets", "Превышено количество подходов в тренировке", nil)
+	case errors.Is(err, workoutuc.ErrSessionNotFound):
+		response.Error(c, http.StatusNotFound, "workout_not_found", "Тренировка не найдена", nil)
+	case errors.Is(err, workoutuc.ErrPlanNeedsAssignment):
+		response.Error(c, http.StatusBadRequest, "invalid_request", "program_workout требует assignment_id", nil)
+	case errors.Is(err, workoutuc.ErrAssignmentNotFound):
+		response.Error(c, http.StatusNotFound, "assignment_not_found", "Назначение программы не найдено", nil)
+	case errors.Is(err, workoutuc.ErrPlanNotFound):
+		response.Error(c, http.StatusNotFound, "program_workout_not_found", "В программе нет такой тренировки", nil)
+	case errors.Is(err, workoutuc.ErrSessionActive):
+		response.Error(c, http.StatusConflict, "workout_in_progress", "Уже есть незавершённая тренировка", nil)
+	case errors.Is(err, workoutuc.ErrSessionFinished):
+		response.Error(c, http.StatusConflict, "workout_finished", "Тренировка уже завершена", nil)
+	case errors.Is(err, workoutuc.ErrSessionNotFinished):
+		response.Error(c, http.StatusConflict, "workout_not_finished", "Оценить можно только завершённую тренировку", nil)
+	case errors.Is(err, consentuc.ErrNotCoachClient):
+		response.Error(c, http.StatusNotFound, "client_not_found", "Клиент не найден", nil)
+	case errors.Is(err, consentuc.ErrConsentRequired):
+		response.Error(c, http.StatusForbidden, "consent_required", "Клиент не открыл доступ к тренировкам", nil)
+	case errors.Is(err, socialuc.ErrWorkoutsHidden):
+		response.Error(c, http.StatusForbidden, "workouts_hidden", "Пользователь скрыл свои тренировки", nil)
+	case errors.Is(err, repo.ErrNotFound):
+		response.Error(c, http.StatusNotFound, "user_not_found", "Пользователь не найден", nil)
+	default:
+		h.logger.Error("internal_error_in_"+op, map[string]any{
+			"user_id": userID.String(),
+			"path":    c.Request.URL.Path,
+			"method":  c.Request.Method,
+			"error":   err.Error(),
+		})
+		response.Error(c, http.StatusInternalServerError, "internal_error", "Внутренняя ошибка сервера", nil)
+	}
+}
+
+// parseSessionID извлекает ID тренировки из пути и отвечает 400, если он некорректен.
+func parseSessionID(c *gin.Context) (uuid.UUID, bool) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid_request", "Некорректный ID тренировки", nil)
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+// parsePeriod разбирает параметры from/to как полуинтервал [from, to).
+// Дата без времени в to включает весь день; по умолчанию — последние 30 дней.
+func parsePeriod(c *gin.Context) (time.Time, time.Time, bool) {
+	to := time.Now().UTC()
+	if raw := c.Query("to"); raw != "" {
+		t, err := parseTimeParam(raw, true)
+		if err != nil {
+			response.Error(c, http.StatusBadRequest, "invalid_request", "Некорректный параметр to", nil)
+			return time.Time{}, time.Time{}, false
+		}
+		to = t
+	}
+	from := to.Add(-defaultPeriod)
+	if raw := c.Query("from"); raw != "" {
+		t, err := parseTimeParam(raw, false)
+		if err != nil {
+			response.Error(c, http.StatusBadRequest, "invalid_request", "Некорректный параметр from", nil)
+			return time.Time{}, time.Time{}, false
+		}
+		from = t
+	}
+	return from, to, true
+}
+
+// parseTimeParam разбирает RFC3339 или YYYY-MM-DD; для конца периода дата сдвигается на начало следующего дня.
+func parseTimeParam(raw string, nextDay bool) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, raw); err == nil {
+		return t, nil
+	}
+	t, err := time.Parse("2006-01-02", raw)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if nextDay {
+		t = t.Add(24 * time.Hour)
+	}
+	return t, nil
+}
+
+// toSessionResponse маппит доменную модель в DTO в системе единиц units;
+// итоги идущей тренировки считаются на момент now.
+func toSessionResponse(s *domain.Session, now time.Time, units userdomain.Units) SessionResponse {
+	resp := SessionResponse{
+		ID:                    s.ID.String(),
+		Title:                 s.Title,
+		AutoPauseAfterSeconds: int(s.AutoPauseAfter / time.Second),
+		StartedAt:             s.StartedAt,
+		FinishedAt:            s.FinishedAt,
+		Active:                s.IsActive(),
+		Difficulty:            s.Difficulty,
+		DifficultyRatedAt:     s.DifficultyRatedAt,
+		Sets:                  make([]SetResponse, 0, len(s.Sets)),
+	}
+	if s.AssignmentID != nil {
+		id := s.AssignmentID.String()
+		resp.AssignmentID = &id
+	}
+	for _, e := range s.Plan {
+		planned := PlannedExerciseResponse{
+			Exercise:    e.Exercise,
+			Sets:        e.Sets,
+			Reps:        e.Reps,
+			Tempo:       e.Tempo,
+			RestSeconds: e.RestSeconds,
+		}
+		if e.WeightKg != nil {
+			planned.WeightKg, planned.WeightLb = volumeFields(*e.WeightKg, units)
+		}
+		resp.Plan = append(resp.Plan, planned)
+	}
+	for i, set := range s.Sets {
+		set.PrescribedRest = s.PrescribedRest(i)
+		resp.Sets = append(resp.Sets, toSetResponse(set, units))
+	}
+
+	summary := s.Summarize(now.UTC())
+	resp.Summary = SummaryResponse{
+		TotalSeconds:    int64(summary.TotalDuration / time.Second),
+		ActiveSeconds:   int64(summary.ActiveDuration / time.Second),
+		PausedSeconds:   int64(summary.PausedDuration / time.Second),
+		Pauses:          summary.Pauses,
+		Sets:            summary.Sets,
+		VolumePerMinute: round2(units.Weight(summary.VolumePerMinute)),
+	}
+	resp.Summary.VolumeKg, resp.Summary.VolumeLb = volumeFields(summary.VolumeKg, units)
+	return resp
+}
+
+// toSetResponse маппит подход в DTO в системе единиц units.
+func toSetResponse(set domain.Set, units userdomain.Units) SetResponse {
+	resp := SetResponse{
+		ID:                    set.ID.String(),
+		Exercise:              set.Exercise,
+		Reps:                  set.Reps,
+		LoggedAt:              set.LoggedAt,
+		Implausible:           set.Implausible,
+		PrescribedRestSeconds: set.PrescribedRest,
+	}
+	if set.WeightKg != nil {
+		resp.WeightKg, resp.WeightLb = volumeFields(*set.WeightKg, units)
+	}
+	return resp
+}
+
+// toWarningResponses переводит нарушенные границы веса в систему единиц пользователя.
+func toWarningResponses(warnings []domain.Warning, units userdomain.Units) []WarningResponse {
+	if len(warnings) == 0 {
+		return nil
+	}
+	resp := make([]WarningResponse, 0, len(warnings))
+	for _, w := range warnings {
+		item := WarningResponse{Code: w.Code, Field: w.Field, Value: w.Value, Limit: w.Limit}
+		if w.Code == domain.WarningWeight {
+			item.Value, item.Limit = round2(units.Weight(w.Value)), round2(units.Weight(w.Limit))
+			if units == userdomain.UnitsImperial {
+				item.Field = "weight_lb"
+			}
+		}
+		resp = append(resp, item)
+	}
+	return resp
+}
+
+// volumeFields возвращает вес или тоннаж для поля в килограммах или фунтах в зависимости от системы единиц;
+// другое поле остаётся nil.
+func volumeFields(kg float64, units userdomain.Units) (*float64, *float64) {
+	value := round2(units.Weight(kg))
+	if units == userdomain.UnitsImperial {
+		return nil, &value
+	}
+	return &value, nil
+}
+
+// round2 округляет значение до сотых для ответа.
+func round2(v float64) float64 {
+	return math.Round(v*100) / 100
+}
@@ -0,0 +1,212 @@
+// Package lifecycle управляет запуском и остановкой фоновых компонентов процесса
+// (очереди писем, задачи очистки, пересчёты, внешние клиенты) и сообщает, готов ли процесс принимать трафик.
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"workout-app/pkg/logger"
+)
+
+// StartFunc запускает компонент. Переданный контекст отменяется в начале остановки менеджера,
+// поэтому фоновые горутины компонента должны завершаться по ctx.Done().
+type StartFunc func(ctx context.Context) error
+
+// StopFunc останавливает компонент и дожидается завершения его горутин.
+// Контекст ограничивает время остановки и общий для всех компонентов.
+type StopFunc func(ctx context.Context) error
+
+// ErrAlreadyStarted возвращается при повторном запуске менеджера.
+var ErrAlreadyStarted = errors.New("lifecycle: already started")
+
+// ErrNotReady возвращается Ready, пока компоненты запускаются, и после начала остановки.
+var ErrNotReady = errors.New("lifecycle: not ready")
+
+type component struct {
+	name  string
+	start StartFunc
+	stop  StopFunc
+}
+
+// Manager запускает зарегистрированные компоненты в порядке регистрации
+// и останавливает их в обратном порядке: компонент, зарегистрированный первым
+// (например, подключение к Redis), останавливается последним.
+type Manager struct {
+	logger logger.Logger
+
+	mu         sync.Mutex
+	components []component
+	started    []component
+	runCancel  context.CancelFunc
+	isStarted  bool
+	isReady    bool
+	isStopped  bool
+}
+
+// NewManager создаёт менеджер жизненного цикла.
+func NewManager(logger logger.Logger) *Manager {
+	return &Manager{logger: logger}
+}
+
+// Register добавляет компонент. start и stop могут быть nil (например, у клиента, которого нужно только закрыть).
+// Компоненты, зарегистрированные после Start, не запускаются, но останавливаются при Shutdown.
+func (m *Manager) Register(name string, start StartFunc, stop StopFunc) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	c := component{name: name, start: start, stop: stop}
+	m.components = append(m.components, c)
+	if m.isStarted {
+		m.started = append(m.started, c)
+	}
+}
+
+// Start запускает компоненты в порядке регистрации.
+// Если компонент не запустился, уже запущенные останавливаются с тем же ctx, а ошибка возвращается.
+// Компоненты, которые должны дождаться зависимостей или прогреться до приёма трафика (см. Gate),
+// регистрируются первыми: менеджер становится готовым (Ready) только после запуска всех компонентов.
+func (m *Manager) Start(ctx context.Context) error {
+	m.mu.Lock()
+	if m.isStarted {
+		m.mu.Unlock()
+		return ErrAlreadyStarted
+	}
+	m.isStarted = true
+	runCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+	m.runCancel = cancel
+	components := append([]component(nil), m.components...)
+	m.mu.Unlock()
+
+	for _, c := range components {
+		if c.start != nil {
+			if err := c.start(runCtx); err != nil {
+				m.logger.Error("lifecycle_component_start_failed", map[string]any{
+					"component": c.name,
+					"error":     err.Error(),
+				})
+				_ = m.Shutdown(ctx)
+				return fmt.Errorf("start %s: %w", c.name, err)
+			}
+		}
+
+		m.mu.Lock()
+		m.started = append(m.started, c)
+		m.mu.Unlock()
+	}
+
+	m.mu.Lock()
+	m.isReady = !m.isStopped
+	m.mu.Unlock()
+	m.logger.Info("lifecycle_ready", map[string]any{"components": len(components)})
+	return nil
+}
+
+// Ready возвращает ErrNotReady, пока запускаются компоненты или после начала остановки.
+// Сигнатура совпадает с проверкой зависимости readiness probe.
+func (m *Manager) Ready(context.Context) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if !m.isReady {
+		return ErrNotReady
+	}
+	return nil
+}
+
+// Shutdown отменяет контекст запуска и останавливает запущенные компоненты в обратном порядке с одним ctx.
+// Ошибки остановки не прерывают остановку остальных компонентов и возвращаются вместе.
+// Повторные вызовы ничего не делают.
+func (m *Manager) Shutdown(ctx context.Context) error {
+	m.mu.Lock()
+	if m.isStopped {
+		m.mu.Unlock()
+		return nil
+	}
+	m.isStopped = true
+	m.isReady = false
+	if m.runCancel != nil {
+		m.runCancel()
+	}
+	started := m.started
+	if !m.isStarted {
+		// Менеджер не запускался: закрываем всё зарегистрированное (например, клиентов).
+		started = m.components
+	}
+	m.mu.Unlock()
+
+	var errs []error
+	for i := len(started) - 1; i >= 0; i-- {
+		c := started[i]
+		if c.stop == nil {
+			continue
+		}
+
+		begin := time.Now()
+		if err := c.stop(ctx); err != nil {
+			m.logger.Error("lifecycle_component_stop_failed", map[string]any{
+				"component": c.name,
+				"error":     err.Error(),
+			})
+			errs = append(errs, fmt.Errorf("stop %s: %w", c.name, err))
+			continue
+		}
+		m.logger.Info("lifecycle_component_stopped", map[string]any{
+			"component":   c.name,
+			"duration_ms": time.Since(begin).Milliseconds(),
+		})
+	}
+	return errors.Join(errs...)
+}
+
+// Gate превращает проверку зависимости в StartFunc, который повторяет её каждые interval, пока она не пройдёт.
+// Если проверка не прошла за timeout, возвращается её последняя ошибка и запуск менеджера прерывается.
+// Так сервер дожидается, например, применения миграций отдельной командой, а не падает при первой неудаче.
+func Gate(check func(ctx context.Context) error, timeout, interval time.Duration) StartFunc {
+	return func(ctx context.Context) error {
+		ctx, cancel := context.WithTimeout(ctx, timeout)
+		defer cancel()
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			err := check(ctx)
+			if err == nil {
+				return nil
+			}
+			select {
+			case <-ctx.Done():
+				return fmt.Errorf("not ready after %s: %w", timeout, err)
+			case <-ticker.C:
+			}
+		}
+	}
+}
+
+// WaitFunc превращает блокирующее ожидание (например, sync.WaitGroup.Wait) в StopFunc,
+// который возвращает ошибку контекста, если компонент не успел остановиться.
+func WaitFunc(wait func()) StopFunc {
+	return func(ctx context.Context) error {
+		done := make(chan struct{})
+		go func() {
+			wait()
+			close(done)
+		}()
+
+		select {
+		case <-done:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// CloseFunc превращает Close() error в StopFunc.
+func CloseFunc(closeFn func() error) StopFunc {
+	return func(context.Context) error {
+		return closeFn()
+	}
+}
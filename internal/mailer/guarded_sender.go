@@ -0,0 +1,130 @@
+package mailer
+
+import (
+	"context"
+	"time"
+
+	"workout-app/pkg/logger"
+	mailerpkg "workout-app/pkg/mailer"
+)
+
+// DeliveryGuard сообщает, можно ли отправлять письма на адрес.
+type DeliveryGuard interface {
+	IsSuppressed(ctx context.Context, email string) (bool, error)
+}
+
+// GuardedSender не отправляет письма на адреса из списка подавления
+// и возвращает для них mailer.ErrRecipientUndeliverable.
+// Если проверку выполнить не удалось, письмо отправляется: доставка кода важнее точности блокировки.
+type GuardedSender struct {
+	next   mailerpkg.EmailSender
+	guard  DeliveryGuard
+	logger logger.Logger
+}
+
+// Убедимся на этапе компиляции, что структура реализует интерфейс.
+var _ mailerpkg.EmailSender = (*GuardedSender)(nil)
+
+// NewGuardedSender оборачивает отправителя проверкой списка подавления.
+func NewGuardedSender(next mailerpkg.EmailSender, guard DeliveryGuard, logger logger.Logger) *GuardedSender {
+	return &GuardedSender{next: next, guard: guard, logger: logger}
+}
+
+// SendEmailVerificationCode отправляет код подтверждения email, если адрес не заблокирован.
+func (s *GuardedSender) SendEmailVerificationCode(ctx context.Context, email, code string) error {
+	if err := s.check(ctx, email); err != nil {
+		return err
+	}
+	return s.next.SendEmailVerificationCode(ctx, email, code)
+}
+
+// SendPasswordChangeCode отправляет код подтверждения смены пароля, если адрес не заблокирован.
+func (s *GuardedSender) SendPasswordChangeCode(ctx context.Context, email, code string) error {
+	if err := s.check(ctx, email); err != nil {
+		return err
+	}
+	return s.next.SendPasswordChangeCode(ctx, email, code)
+}
+
+// SendPasswordResetCode отправляет код сброса пароля, если адрес не заблокирован.
+func (s *GuardedSender) SendPasswordResetCode(ctx context.Context, email, code string) error {
+	if err := s.check(ctx, email); err != nil {
+		return err
+	}
+	return s.next.SendPasswordResetCode(ctx, email, code)
+}
+
+// SendDataExportReady отправляет ссылку на выгрузку данных, если адрес не заблокирован.
+func (s *GuardedSender) SendDataExportReady(ctx context.Context, email, downloadURL string, expiresAt time.Time) error {
+	if err := s.check(ctx, email); err != nil {
+		return err
+	}
+	return s.next.SendDataExportReady(ctx, email, downloadURL, expiresAt)
+}
+
+// SendWelcome отправляет приветственное письмо, если адрес не заблокирован.
+func (s *GuardedSender) SendWelcome(ctx context.Context, email, username string) error {
+	if err := s.check(ctx, email); err != nil {
+		return err
+	}
+	return s.next.SendWelcome(ctx, email, username)
+}
+
+// SendPasswordChanged отправляет уведомление о смене пароля, если адрес не заблокирован.
+func (s *GuardedSender) SendPasswordChanged(ctx context.Context, email string, changedAt time.Time) error {
+	if err := s.check(ctx, email); err != nil {
+		return err
+	}
+	return s.next.SendPasswordChanged(ctx, email, changedAt)
+}
+
+// SendAccountDeleted отправляет подтверждение удаления аккаунта, если адрес не заблокирован.
+func (s *GuardedSender) SendAccountDeleted(ctx context.Context, email string, deletedAt time.Time) error {
+	if err := s.check(ctx, email); err != nil {
+		return err
+	}
+	return s.next.SendAccountDeleted(ctx, email, deletedAt)
+}
+
+// SendNotification отправляет копию уведомления, если адрес не заблокирован.
+func (s *GuardedSender) SendNotification(ctx context.Context, email, notificationType string, at time.Time) error {
+	if err := s.check(ctx, email); err != nil {
+		return err
+	}
+	return s.next.SendNotification(ctx, email, notificationType, at)
+}
+
+// SendWeeklySummary отправляет еженедельную сводку тренировок, если адрес не заблокирован.
+func (s *GuardedSender) SendWeeklySummary(ctx context.Context, email string, summary mailerpkg.WeeklySummary) error {
+	if err := s.check(ctx, email); err != nil {
+		return err
+	}
+	return s.next.SendWeeklySummary(ctx, email, summary)
+}
+
+// SendCoachInvitation отправляет приглашение от тренера, если адрес не заблокирован.
+func (s *GuardedSender) SendCoachInvitation(ctx context.Context, email, coachName string, expiresAt time.Time) error {
+	if err := s.check(ctx, email); err != nil {
+		return err
+	}
+	return s.next.SendCoachInvitation(ctx, email, coachName, expiresAt)
+}
+
+// check возвращает ErrRecipientUndeliverable для заблокированных адресов.
+func (s *GuardedSender) check(ctx context.Context, email string) error {
+	blocked, err := s.guard.IsSuppressed(ctx, email)
+	if err != nil {
+		s.logger.Warn("deliverability_check_failed", map[string]any{
+			"email": email,
+			"error": err.Error(),
+		})
+		return nil
+	}
+	if blocked {
+		s.logger.Info("email_send_suppressed", map[string]any{
+			"email": email,
+		})
+		return mailerpkg.ErrRecipientUndeliverable
+	}
+	return nil
+}
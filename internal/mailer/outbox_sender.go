@@ -0,0 +1,89 @@
+package mailer
+
+import (
+	"context"
+	"time"
+
+	domain "workout-app/internal/domain/emailoutbox"
+	mailerpkg "workout-app/pkg/mailer"
+	"workout-app/pkg/tracing"
+)
+
+// OutboxQueue ставит письма в очередь исходящих писем.
+type OutboxQueue interface {
+	Enqueue(ctx context.Context, m *domain.Message) error
+}
+
+// OutboxSender не отправляет письма сам, а ставит их в очередь (outbox): запрос не ждёт SMTP-сервер
+// и не падает из-за его недоступности, а фоновый воркер отправляет письма с повторными попытками.
+// Внутри транзакции (Transactor) письмо попадает в очередь только вместе с изменениями, ради которых отправляется.
+type OutboxSender struct {
+	queue OutboxQueue
+}
+
+// Убедимся на этапе компиляции, что структура реализует интерфейс.
+var _ mailerpkg.EmailSender = (*OutboxSender)(nil)
+
+// NewOutboxSender создаёт отправителя, ставящего письма в очередь.
+func NewOutboxSender(queue OutboxQueue) *OutboxSender {
+	return &OutboxSender{queue: queue}
+}
+
+// SendEmailVerificationCode ставит в очередь письмо с кодом подтверждения email.
+func (s *OutboxSender) SendEmailVerificationCode(ctx context.Context, email, code string) error {
+	return s.enqueue(ctx, domain.KindVerificationCode, email, domain.Payload{Code: code})
+}
+
+// SendPasswordChangeCode ставит в очередь письмо с кодом подтверждения смены пароля.
+func (s *OutboxSender) SendPasswordChangeCode(ctx context.Context, email, code string) error {
+	return s.enqueue(ctx, domain.KindPasswordChangeCode, email, domain.Payload{Code: code})
+}
+
+// SendPasswordResetCode ставит в очередь письмо с кодом сброса пароля.
+func (s *OutboxSender) SendPasswordResetCode(ctx context.Context, email, code string) error {
+	return s.enqueue(ctx, domain.KindPasswordResetCode, email, domain.Payload{Code: code})
+}
+
+// SendDataExportReady ставит в очередь письмо со ссылкой на архив с данными аккаунта.
+func (s *OutboxSender) SendDataExportReady(ctx context.Context, email, downloadURL string, expiresAt time.Time) error {
+	return s.enqueue(ctx, domain.KindDataExportReady, email, domain.Payload{DownloadURL: downloadURL, ExpiresAt: &expiresAt})
+}
+
+// SendWelcome ставит в очередь приветственное письмо.
+func (s *OutboxSender) SendWelcome(ctx context.Context, email, username string) error {
+	return s.enqueue(ctx, domain.KindWelcome, email, domain.Payload{Username: username})
+}
+
+// SendPasswordChanged ставит в очередь уведомление о смене пароля.
+func (s *OutboxSender) SendPasswordChanged(ctx context.Context, email string, changedAt time.Time) error {
+	return s.enqueue(ctx, domain.KindPasswordChanged, email, domain.Payload{OccurredAt: &changedAt})
+}
+
+// SendAccountDeleted ставит в очередь подтверждение удаления аккаунта.
+func (s *OutboxSender) SendAccountDeleted(ctx context.Context, email string, deletedAt time.Time) error {
+	return s.enqueue(ctx, domain.KindAccountDeleted, email, domain.Payload{OccurredAt: &deletedAt})
+}
+
+// SendNotification ставит в очередь копию уведомления.
+func (s *OutboxSender) SendNotification(ctx context.Context, email, notificationType string, at time.Time) error {
+	return s.enqueue(ctx, domain.KindNotification, email, domain.Payload{NotificationType: notificationType, OccurredAt: &at})
+}
+
+// SendWeeklySummary ставит в очередь еженедельную сводку тренировок.
+func (s *OutboxSender) SendWeeklySummary(ctx context.Context, email string, summary mailerpkg.WeeklySummary) error {
+	return s.enqueue(ctx, domain.KindWeeklySummary, email, domain.Payload{WeeklySummary: &summary})
+}
+
+// SendCoachInvitation ставит в очередь приглашение от тренера.
+func (s *OutboxSender) SendCoachInvitation(ctx context.Context, email, coachName string, expiresAt time.Time) error {
+	return s.enqueue(ctx, domain.KindCoachInvitation, email, domain.Payload{Username: coachName, ExpiresAt: &expiresAt})
+}
+
+// enqueue ставит письмо в очередь на языке и в часовом поясе из контекста; вместе с письмом
+// сохраняется контекст трассировки запроса.
+func (s *OutboxSender) enqueue(ctx context.Context, kind domain.Kind, email string, payload domain.Payload) error {
+	m := domain.New(kind, email, mailerpkg.LocaleFromContext(ctx), payload, time.Now().UTC())
+	m.Timezone = mailerpkg.TimezoneFromContext(ctx)
+	m.TraceParent = tracing.Header(ctx)
+	return s.queue.Enqueue(ctx, m)
+}
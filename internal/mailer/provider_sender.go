@@ -0,0 +1,115 @@
+package mailer
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"workout-app/pkg/logger"
+	mailerpkg "workout-app/pkg/mailer"
+	"workout-app/pkg/servertiming"
+)
+
+// ProviderSender отправляет письма через API почтового провайдера (SendGrid, SES, Mailgun).
+// Письма рендерятся из тех же шаблонов, что и в SMTPSender, на языке из контекста.
+type ProviderSender struct {
+	provider  mailerpkg.Provider
+	fromEmail string
+	templates *Templates
+	logger    logger.Logger
+}
+
+// Убедимся на этапе компиляции, что структура реализует интерфейс.
+var _ mailerpkg.EmailSender = (*ProviderSender)(nil)
+
+// NewProviderSender создаёт отправителя писем с адреса fromEmail через provider.
+func NewProviderSender(provider mailerpkg.Provider, fromEmail string, templates *Templates, logger logger.Logger) *ProviderSender {
+	return &ProviderSender{
+		provider:  provider,
+		fromEmail: fromEmail,
+		templates: templates,
+		logger:    logger.With(map[string]any{"email_provider": provider.Name()}),
+	}
+}
+
+// SendEmailVerificationCode отправляет письмо с кодом подтверждения email.
+func (s *ProviderSender) SendEmailVerificationCode(ctx context.Context, email, code string) error {
+	return s.send(ctx, email, TemplateVerificationCode, codeData{Code: code})
+}
+
+// SendPasswordChangeCode отправляет письмо с кодом подтверждения смены пароля.
+func (s *ProviderSender) SendPasswordChangeCode(ctx context.Context, email, code string) error {
+	return s.send(ctx, email, TemplatePasswordChangeCode, codeData{Code: code})
+}
+
+// SendPasswordResetCode отправляет письмо с кодом сброса пароля.
+func (s *ProviderSender) SendPasswordResetCode(ctx context.Context, email, code string) error {
+	return s.send(ctx, email, TemplatePasswordResetCode, codeData{Code: code})
+}
+
+// SendDataExportReady отправляет письмо со ссылкой на архив с данными аккаунта.
+func (s *ProviderSender) SendDataExportReady(ctx context.Context, email, downloadURL string, expiresAt time.Time) error {
+	return s.send(ctx, email, TemplateDataExportReady, dataExportData{DownloadURL: downloadURL, ExpiresAt: expiresAt})
+}
+
+// SendWelcome отправляет приветственное письмо.
+func (s *ProviderSender) SendWelcome(ctx context.Context, email, username string) error {
+	return s.send(ctx, email, TemplateWelcome, welcomeData{Username: username})
+}
+
+// SendPasswordChanged отправляет уведомление о смене пароля.
+func (s *ProviderSender) SendPasswordChanged(ctx context.Context, email string, changedAt time.Time) error {
+	return s.send(ctx, email, TemplatePasswordChanged, passwordChangedData{ChangedAt: changedAt})
+}
+
+// SendAccountDeleted отправляет подтверждение удаления аккаунта.
+func (s *ProviderSender) SendAccountDeleted(ctx context.Context, email string, deletedAt time.Time) error {
+	return s.send(ctx, email, TemplateAccountDeleted, accountDeletedData{DeletedAt: deletedAt})
+}
+
+// SendNotification отправляет копию уведомления.
+func (s *ProviderSender) SendNotification(ctx context.Context, email, notificationType string, at time.Time) error {
+	return s.send(ctx, email, TemplateNotification, notificationData{Type: notificationType, At: at})
+}
+
+// SendWeeklySummary отправляет еженедельную сводку тренировок.
+func (s *ProviderSender) SendWeeklySummary(ctx context.Context, email string, summary mailerpkg.WeeklySummary) error {
+	return s.send(ctx, email, TemplateWeeklySummary, newWeeklySummaryData(summary))
+}
+
+// SendCoachInvitation отправляет приглашение от тренера.
+func (s *ProviderSender) SendCoachInvitation(ctx context.Context, email, coachName string, expiresAt time.Time) error {
+	return s.send(ctx, email, TemplateCoachInvitation, coachInvitationData{CoachName: coachName, ExpiresAt: expiresAt})
+}
+
+// send рендерит письмо из шаблона и передаёт его провайдеру.
+func (s *ProviderSender) send(ctx context.Context, email, template string, data any) error {
+	defer servertiming.Track(ctx, servertiming.External, time.Now())
+
+	message, err := s.templates.Render(mailerpkg.LocaleFromContext(ctx), mailerpkg.TimezoneFromContext(ctx), template, data)
+	if err != nil {
+		return fmt.Errorf("failed to render email: %w", err)
+	}
+
+	err = s.provider.Send(ctx, &mailerpkg.Email{
+		From:    s.fromEmail,
+		To:      email,
+		Subject: message.Subject,
+		Text:    message.Text,
+		HTML:    message.HTML,
+	})
+	if err != nil {
+		s.logger.Error("failed to send email", map[string]any{
+			"email":    email,
+			"template": template,
+			"err":      err.Error(),
+		})
+		return err
+	}
+
+	s.logger.Info("email sent", map[string]any{
+		"email":    email,
+		"template": template,
+	})
+	return nil
+}
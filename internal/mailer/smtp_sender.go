@@ -1,44 +1,163 @@
 package mailer
 
 import (
+	"bytes"
 	"context"
 	"fmt"
-	"net/smtp"
-	"strings"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"net/textproto"
+	"time"
 
 	"workout-app/internal/config"
 	"workout-app/pkg/logger"
+	mailerpkg "workout-app/pkg/mailer"
+	"workout-app/pkg/servertiming"
 )
 
-// SMTPSender реализует отправку писем через стандартную библиотеку net/smtp.
-// Используется для отправки кода подтверждения email.
+// SMTPSender реализует отправку писем через SMTP (net/smtp) с обязательным TLS, таймаутами
+// и пулом соединений. Письма рендерятся из шаблонов на языке из контекста (mailer.WithLocale)
+// и отправляются в виде multipart/alternative с текстовой и HTML-версиями.
 type SMTPSender struct {
-	cfg    *config.EmailConfig
-	logger logger.Logger
+	cfg       *config.EmailConfig
+	fromName  string // Отображаемое имя отправителя (пусто — только адрес)
+	templates *Templates
+	transport *smtpTransport
+	logger    logger.Logger
 }
 
 // NewSMTPSender создаёт новый SMTP-отправитель на основе EmailConfig.
-func NewSMTPSender(cfg *config.EmailConfig, logger logger.Logger) *SMTPSender {
+// Простаивающие соединения пула закрывает Close.
+func NewSMTPSender(cfg *config.EmailConfig, templates *Templates, logger logger.Logger) *SMTPSender {
 	return &SMTPSender{
-		cfg:    cfg,
-		logger: logger,
+		cfg:       cfg,
+		templates: templates,
+		transport: newSMTPTransport(cfg),
+		logger:    logger,
 	}
 }
 
 // SendEmailVerificationCode отправляет письмо с кодом подтверждения email.
 // Используется как для подтверждения email при регистрации, так и для подтверждения изменения email.
 func (s *SMTPSender) SendEmailVerificationCode(ctx context.Context, email, code string) error {
-	subject := "Your verification code"
-	body := fmt.Sprintf("Your verification code is: %s\n\nThis code will expire in a few minutes.", code)
+	return s.send(ctx, email, TemplateVerificationCode, codeData{Code: code})
+}
 
-	msg := buildMessage(s.cfg.FromEmail, email, subject, body)
+// SendPasswordChangeCode отправляет письмо с кодом подтверждения смены пароля.
+func (s *SMTPSender) SendPasswordChangeCode(ctx context.Context, email, code string) error {
+	return s.send(ctx, email, TemplatePasswordChangeCode, codeData{Code: code})
+}
 
-	addr := fmt.Sprintf("%s:%d", s.cfg.SMTPHost, s.cfg.SMTPPort)
-	auth := smtp.PlainAuth("", s.cfg.SMTPUsername, s.cfg.SMTPPassword, s.cfg.SMTPHost)
+// SendPasswordResetCode отправляет письмо с кодом сброса пароля.
+func (s *SMTPSender) SendPasswordResetCode(ctx context.Context, email, code string) error {
+	return s.send(ctx, email, TemplatePasswordResetCode, codeData{Code: code})
+}
+
+// SendDataExportReady отправляет письмо со ссылкой на архив с данными аккаунта.
+func (s *SMTPSender) SendDataExportReady(ctx context.Context, email, downloadURL string, expiresAt time.Time) error {
+	return s.send(ctx, email, TemplateDataExportReady, dataExportData{DownloadURL: downloadURL, ExpiresAt: expiresAt})
+}
+
+// SendWelcome отправляет приветственное письмо.
+func (s *SMTPSender) SendWelcome(ctx context.Context, email, username string) error {
+	return s.send(ctx, email, TemplateWelcome, welcomeData{Username: username})
+}
+
+// SendPasswordChanged отправляет уведомление о смене пароля.
+func (s *SMTPSender) SendPasswordChanged(ctx context.Context, email string, changedAt time.Time) error {
+	return s.send(ctx, email, TemplatePasswordChanged, passwordChangedData{ChangedAt: changedAt})
+}
+
+// SendAccountDeleted отправляет подтверждение удаления аккаунта.
+func (s *SMTPSender) SendAccountDeleted(ctx context.Context, email string, deletedAt time.Time) error {
+	return s.send(ctx, email, TemplateAccountDeleted, accountDeletedData{DeletedAt: deletedAt})
+}
+
+// SendNotification отправляет копию уведомления.
+func (s *SMTPSender) SendNotification(ctx context.Context, email, notificationType string, at time.Time) error {
+	return s.send(ctx, email, TemplateNotification, notificationData{Type: notificationType, At: at})
+}
 
-	// В данной реализации контекст используется только для логгирования;
-	// net/smtp не поддерживает контекст из коробки.
-	if err := smtp.SendMail(addr, auth, s.cfg.FromEmail, []string{email}, []byte(msg)); err != nil {
+// SendWeeklySummary отправляет еженедельную сводку тренировок.
+func (s *SMTPSender) SendWeeklySummary(ctx context.Context, email string, summary mailerpkg.WeeklySummary) error {
+	return s.send(ctx, email, TemplateWeeklySummary, newWeeklySummaryData(summary))
+}
+
+// SendCoachInvitation отправляет приглашение от тренера.
+func (s *SMTPSender) SendCoachInvitation(ctx context.Context, email, coachName string, expiresAt time.Time) error {
+	return s.send(ctx, email, TemplateCoachInvitation, coachInvitationData{CoachName: coachName, ExpiresAt: expiresAt})
+}
+
+// codeData — данные шаблонов писем с кодом подтверждения.
+type codeData struct {
+	Code string
+}
+
+// dataExportData — данные шаблона письма о готовой выгрузке данных.
+type dataExportData struct {
+	DownloadURL string
+	ExpiresAt   time.Time
+}
+
+// welcomeData — данные приветственного письма.
+type welcomeData struct {
+	Username string
+}
+
+// passwordChangedData — данные уведомления о смене пароля.
+type passwordChangedData struct {
+	ChangedAt time.Time
+}
+
+// accountDeletedData — данные подтверждения удаления аккаунта.
+type accountDeletedData struct {
+	DeletedAt time.Time
+}
+
+// notificationData — данные копии уведомления; текст письма выбирается по Type.
+type notificationData struct {
+	Type string
+	At   time.Time
+}
+
+// weeklySummaryData — данные еженедельной сводки; WeekEnd — воскресенье недели.
+type weeklySummaryData struct {
+	mailerpkg.WeeklySummary
+	WeekEnd time.Time
+}
+
+func newWeeklySummaryData(summary mailerpkg.WeeklySummary) weeklySummaryData {
+	return weeklySummaryData{WeeklySummary: summary, WeekEnd: summary.WeekStart.AddDate(0, 0, 6)}
+}
+
+// coachInvitationData — данные приглашения от тренера.
+type coachInvitationData struct {
+	CoachName string
+	ExpiresAt time.Time
+}
+
+// send рендерит письмо из шаблона и отправляет его одному получателю.
+func (s *SMTPSender) send(ctx context.Context, email, template string, data any) error {
+	defer servertiming.Track(ctx, servertiming.External, time.Now())
+
+	message, err := s.templates.Render(mailerpkg.LocaleFromContext(ctx), mailerpkg.TimezoneFromContext(ctx), template, data)
+	if err != nil {
+		return fmt.Errorf("failed to render email: %w", err)
+	}
+
+	from := s.cfg.FromEmail
+	if s.fromName != "" {
+		from = (&mail.Address{Name: s.fromName, Address: s.cfg.FromEmail}).String()
+	}
+	msg, err := buildMessage(from, email, message)
+	if err != nil {
+		return fmt.Errorf("failed to build email: %w", err)
+	}
+
+	if err := s.transport.Send(ctx, s.cfg.FromEmail, email, msg); err != nil {
 		s.logger.Error("failed to send verification email", map[string]any{
 			"email": email,
 			"err":   err.Error(),
@@ -47,19 +166,64 @@ func (s *SMTPSender) SendEmailVerificationCode(ctx context.Context, email, code
 	}
 
 	s.logger.Info("verification email sent", map[string]any{
-		"email": email,
+		"email":    email,
+		"template": template,
 	})
 	return nil
 }
 
-func buildMessage(from, to, subject, body string) string {
-	var b strings.Builder
+// Close закрывает простаивающие SMTP-соединения.
+func (s *SMTPSender) Close() error {
+	return s.transport.Close()
+}
+
+// Ping проверяет, что SMTP-сервер принимает TCP-соединения.
+// Используется в проверках состояния сервиса; письма при этом не отправляются.
+func (s *SMTPSender) Ping(ctx context.Context) error {
+	addr := fmt.Sprintf("%s:%d", s.cfg.SMTPHost, s.cfg.SMTPPort)
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return err
+	}
+	return conn.Close()
+}
+
+// buildMessage собирает письмо multipart/alternative: клиенты без поддержки HTML показывают текстовую версию.
+// Тема кодируется по RFC 2047, части — quoted-printable, поэтому письмо можно писать не только латиницей.
+func buildMessage(from, to string, message *Message) ([]byte, error) {
+	var body bytes.Buffer
+	parts := multipart.NewWriter(&body)
+	for _, part := range []struct{ contentType, content string }{
+		{"text/plain; charset=\"utf-8\"", message.Text},
+		{"text/html; charset=\"utf-8\"", message.HTML},
+	} {
+		w, err := parts.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {part.contentType},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		})
+		if err != nil {
+			return nil, err
+		}
+		qp := quotedprintable.NewWriter(w)
+		if _, err := qp.Write([]byte(part.content)); err != nil {
+			return nil, err
+		}
+		if err := qp.Close(); err != nil {
+			return nil, err
+		}
+	}
+	if err := parts.Close(); err != nil {
+		return nil, err
+	}
+
+	var b bytes.Buffer
 	b.WriteString(fmt.Sprintf("From: %s\r\n", from))
 	b.WriteString(fmt.Sprintf("To: %s\r\n", to))
-	b.WriteString(fmt.Sprintf("Subject: %s\r\n", subject))
+	b.WriteString(fmt.Sprintf("Subject: %s\r\n", mime.QEncoding.Encode("utf-8", message.Subject)))
 	b.WriteString("MIME-Version: 1.0\r\n")
-	b.WriteString("Content-Type: text/plain; charset=\"utf-8\"\r\n")
+	b.WriteString(fmt.Sprintf("Content-Type: multipart/alternative; boundary=\"%s\"\r\n", parts.Boundary()))
 	b.WriteString("\r\n")
-	b.WriteString(body)
-	return b.String()
+	b.Write(body.Bytes())
+	return b.Bytes(), nil
 }
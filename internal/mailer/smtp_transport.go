@@ -0,0 +1,277 @@
+package mailer
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/smtp"
+	"strconv"
+	"sync"
+	"time"
+
+	"workout-app/internal/config"
+)
+
+// Способы защиты соединения с SMTP-сервером (EMAIL_SMTP_TLS).
+const (
+	SMTPTLSStartTLS = "starttls" // Обычное подключение с обязательным переходом на TLS командой STARTTLS
+	SMTPTLSImplicit = "implicit" // TLS с момента подключения (SMTPS)
+	SMTPTLSNone     = "none"     // Без шифрования, только для локальных серверов разработки
+)
+
+// defaultSMTPTimeout — таймаут SMTP, если он не задан (например, в настройках организаций).
+const defaultSMTPTimeout = 10 * time.Second
+
+// ErrSMTPStartTLSUnsupported — сервер не предлагает STARTTLS, а отправка без шифрования запрещена.
+var ErrSMTPStartTLSUnsupported = errors.New("smtp server does not support STARTTLS")
+
+// smtpTransport отправляет письма через SMTP-сервер и переиспользует аутентифицированные соединения:
+// пачка писем с кодами подтверждения не тратит время на TCP, TLS и AUTH для каждого письма.
+// Каждая операция ограничена таймаутом и прерывается при отмене контекста.
+type smtpTransport struct {
+	addr      string
+	host      string
+	username  string
+	password  string
+	tlsMode   string
+	tlsConfig *tls.Config
+	timeout   time.Duration
+
+	maxIdle     int           // Сколько простаивающих соединений хранить; 0 — соединение закрывается после письма
+	idleTimeout time.Duration // Дольше простаивающее соединение закрывается вместо повторного использования
+	maxMessages int           // Писем через одно соединение; 0 — без ограничения
+
+	mu     sync.Mutex
+	idle   []*smtpConn
+	closed bool
+}
+
+// smtpConn — открытое соединение с SMTP-сервером после приветствия, TLS и AUTH.
+type smtpConn struct {
+	conn     net.Conn
+	client   *smtp.Client
+	sent     int
+	lastUsed time.Time
+}
+
+// newSMTPTransport создаёт транспорт по настройкам SMTP. Без явного способа защиты
+// порт 465 означает SMTPS, остальные — обязательный STARTTLS.
+func newSMTPTransport(cfg *config.EmailConfig) *smtpTransport {
+	mode := cfg.SMTPTLS
+	if mode == "" {
+		mode = SMTPTLSStartTLS
+		if cfg.SMTPPort == 465 {
+			mode = SMTPTLSImplicit
+		}
+	}
+	timeout := cfg.SMTPTimeout
+	if timeout <= 0 {
+		timeout = defaultSMTPTimeout
+	}
+	return &smtpTransport{
+		addr:        net.JoinHostPort(cfg.SMTPHost, strconv.Itoa(cfg.SMTPPort)),
+		host:        cfg.SMTPHost,
+		username:    cfg.SMTPUsername,
+		password:    cfg.SMTPPassword,
+		tlsMode:     mode,
+		tlsConfig:   &tls.Config{ServerName: cfg.SMTPHost, MinVersion: tls.VersionTLS12},
+		timeout:     timeout,
+		maxIdle:     cfg.SMTPPoolSize,
+		idleTimeout: cfg.SMTPIdleTimeout,
+		maxMessages: cfg.SMTPMaxMessagesPerConn,
+	}
+}
+
+// Send отправляет готовое письмо одному получателю.
+func (t *smtpTransport) Send(ctx context.Context, from, to string, msg []byte) error {
+	c, err := t.acquire(ctx)
+	if err != nil {
+		return err
+	}
+
+	// Отмена контекста обрывает ожидание ответа сервера: net/smtp контекст не принимает.
+	stop := context.AfterFunc(ctx, func() { _ = c.conn.SetDeadline(time.Now()) })
+	err = c.deliver(from, to, msg, t.deadline(ctx))
+	interrupted := !stop()
+	if err != nil || interrupted {
+		// Соединение с оборванным или неудачным обменом нельзя переиспользовать.
+		_ = c.client.Close()
+		return contextError(ctx, err)
+	}
+	t.release(c)
+	return nil
+}
+
+// contextError дополняет ошибку обмена причиной отмены контекста, чтобы вызывающий отличал
+// истёкший дедлайн запроса от проблем SMTP-сервера.
+func contextError(ctx context.Context, err error) error {
+	if err == nil {
+		return nil
+	}
+	ctxErr := ctx.Err()
+	if d, ok := ctx.Deadline(); ctxErr == nil && ok && !time.Now().Before(d) {
+		// Дедлайн соединения совпадает с дедлайном контекста и может сработать раньше таймера контекста.
+		ctxErr = context.DeadlineExceeded
+	}
+	if ctxErr == nil {
+		return err
+	}
+	return fmt.Errorf("%w: %w", ctxErr, err)
+}
+
+// Close закрывает простаивающие соединения; занятые закроются после отправки письма.
+func (t *smtpTransport) Close() error {
+	t.mu.Lock()
+	idle := t.idle
+	t.idle = nil
+	t.closed = true
+	t.mu.Unlock()
+
+	for _, c := range idle {
+		c.quit(t.timeout)
+	}
+	return nil
+}
+
+// acquire возвращает проверенное соединение из пула или открывает новое.
+func (t *smtpTransport) acquire(ctx context.Context) (*smtpConn, error) {
+	for {
+		c := t.popIdle()
+		if c == nil {
+			break
+		}
+		if time.Since(c.lastUsed) > t.idleTimeout {
+			c.quit(t.timeout)
+			continue
+		}
+		// Сервер мог закрыть простаивающее соединение: RSET проверяет его и сбрасывает состояние.
+		_ = c.conn.SetDeadline(t.deadline(ctx))
+		if err := c.client.Reset(); err != nil {
+			_ = c.client.Close()
+			continue
+		}
+		return c, nil
+	}
+	return t.dial(ctx)
+}
+
+// popIdle забирает последнее возвращённое соединение: оно с наибольшей вероятностью ещё открыто.
+func (t *smtpTransport) popIdle() *smtpConn {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	n := len(t.idle)
+	if n == 0 {
+		return nil
+	}
+	c := t.idle[n-1]
+	t.idle = t.idle[:n-1]
+	return c
+}
+
+// release возвращает соединение в пул или закрывает его, если пул полон или лимит писем исчерпан.
+func (t *smtpTransport) release(c *smtpConn) {
+	c.sent++
+	c.lastUsed = time.Now()
+
+	t.mu.Lock()
+	keep := !t.closed && len(t.idle) < t.maxIdle && (t.maxMessages == 0 || c.sent < t.maxMessages)
+	if keep {
+		t.idle = append(t.idle, c)
+	}
+	t.mu.Unlock()
+
+	if !keep {
+		c.quit(t.timeout)
+	}
+}
+
+// dial подключается к серверу, включает TLS и проходит аутентификацию.
+func (t *smtpTransport) dial(ctx context.Context) (*smtpConn, error) {
+	dialer := &net.Dialer{Timeout: t.timeout}
+	var (
+		conn net.Conn
+		err  error
+	)
+	if t.tlsMode == SMTPTLSImplicit {
+		conn, err = (&tls.Dialer{NetDialer: dialer, Config: t.tlsConfig}).DialContext(ctx, "tcp", t.addr)
+	} else {
+		conn, err = dialer.DialContext(ctx, "tcp", t.addr)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	stop := context.AfterFunc(ctx, func() { _ = conn.SetDeadline(time.Now()) })
+	defer stop()
+	_ = conn.SetDeadline(t.deadline(ctx))
+
+	client, err := smtp.NewClient(conn, t.host)
+	if err != nil {
+		_ = conn.Close()
+		return nil, contextError(ctx, err)
+	}
+	if err := t.handshake(client); err != nil {
+		_ = client.Close()
+		return nil, contextError(ctx, err)
+	}
+	if !stop() {
+		_ = client.Close()
+		return nil, ctx.Err()
+	}
+	return &smtpConn{conn: conn, client: client}, nil
+}
+
+// handshake включает STARTTLS, если он обязателен, и проходит аутентификацию.
+func (t *smtpTransport) handshake(client *smtp.Client) error {
+	if t.tlsMode == SMTPTLSStartTLS {
+		if ok, _ := client.Extension("STARTTLS"); !ok {
+			return ErrSMTPStartTLSUnsupported
+		}
+		if err := client.StartTLS(t.tlsConfig); err != nil {
+			return err
+		}
+	}
+	if t.username != "" {
+		// PlainAuth сам отказывается передавать пароль по незашифрованному соединению к удалённому серверу.
+		return client.Auth(smtp.PlainAuth("", t.username, t.password, t.host))
+	}
+	return nil
+}
+
+// deadline — срок текущей операции: таймаут транспорта или дедлайн контекста, если он раньше.
+func (t *smtpTransport) deadline(ctx context.Context) time.Time {
+	deadline := time.Now().Add(t.timeout)
+	if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+		return d
+	}
+	return deadline
+}
+
+// deliver передаёт письмо по открытому соединению.
+func (c *smtpConn) deliver(from, to string, msg []byte, deadline time.Time) error {
+	_ = c.conn.SetDeadline(deadline)
+	if err := c.client.Mail(from); err != nil {
+		return err
+	}
+	if err := c.client.Rcpt(to); err != nil {
+		return err
+	}
+	w, err := c.client.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(msg); err != nil {
+		return err
+	}
+	return w.Close()
+}
+
+// quit вежливо завершает сессию; ошибки не важны — соединение закрывается в любом случае.
+func (c *smtpConn) quit(timeout time.Duration) {
+	_ = c.conn.SetDeadline(time.Now().Add(timeout))
+	if err := c.client.Quit(); err != nil {
+		_ = c.client.Close()
+	}
+}
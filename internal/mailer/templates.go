@@ -0,0 +1,232 @@
+package mailer
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	htmltemplate "html/template"
+	"io/fs"
+	"strings"
+	texttemplate "text/template"
+	"time"
+)
+
+// templateFS содержит шаблоны писем: общий HTML-макет layout.html и по каталогу на язык,
+// в котором у каждого письма есть <name>.txt (шаблоны "subject" и "text") и <name>.html (шаблон "content").
+//
+//go:embed templates
+var templateFS embed.FS
+
+// Названия шаблонов писем (аргумент name в Templates.Render).
+const (
+	TemplateVerificationCode   = "verification_code"
+	TemplatePasswordChangeCode = "password_change_code"
+	TemplatePasswordResetCode  = "password_reset_code"
+	TemplateDataExportReady    = "data_export_ready"
+	TemplateWelcome            = "welcome"
+	TemplatePasswordChanged    = "password_changed"
+	TemplateAccountDeleted     = "account_deleted"
+	TemplateNotification       = "notification"
+	TemplateWeeklySummary      = "weekly_summary"
+	TemplateCoachInvitation    = "coach_invitation"
+)
+
+// templateNames — все шаблоны писем; каждый обязан быть переведён на язык по умолчанию.
+var templateNames = []string{
+	TemplateVerificationCode, TemplatePasswordChangeCode, TemplatePasswordResetCode, TemplateDataExportReady,
+	TemplateWelcome, TemplatePasswordChanged, TemplateAccountDeleted, TemplateNotification, TemplateWeeklySummary,
+	TemplateCoachInvitation,
+}
+
+// dateTimeLayouts — формат даты и времени в письмах по языкам; для региональных локалей
+// с другим порядком даты или 12-часовым временем задан отдельный формат.
+var dateTimeLayouts = map[string]string{
+	"en":    "2006-01-02 15:04 MST",
+	"en-us": "Jan 2, 2006 3:04 PM MST",
+	"en-gb": "02/01/2006 15:04 MST",
+	"ru":    "02.01.2006 15:04 MST",
+}
+
+// dateLayouts — формат календарной даты (без времени) в письмах по языкам.
+var dateLayouts = map[string]string{
+	"en": "Jan 2, 2006",
+	"ru": "02.01.2006",
+}
+
+// Message — письмо, готовое к отправке: тема, текстовая и HTML-версии тела.
+type Message struct {
+	Subject string
+	Text    string
+	HTML    string
+}
+
+// Templates рендерит письма из встроенных шаблонов на языке получателя.
+type Templates struct {
+	defaultLocale string
+	text          map[string]*texttemplate.Template // Ключ — язык/название шаблона
+	html          map[string]*htmltemplate.Template
+}
+
+// LoadTemplates разбирает встроенные шаблоны писем. Письма на неподдерживаемом языке
+// и письма, для которых нет перевода, рендерятся на языке defaultLocale.
+func LoadTemplates(defaultLocale string) (*Templates, error) {
+	t := &Templates{
+		defaultLocale: defaultLocale,
+		text:          make(map[string]*texttemplate.Template),
+		html:          make(map[string]*htmltemplate.Template),
+	}
+
+	layout, err := fs.ReadFile(templateFS, "templates/layout.html")
+	if err != nil {
+		return nil, err
+	}
+	dirs, err := fs.ReadDir(templateFS, "templates")
+	if err != nil {
+		return nil, err
+	}
+	for _, dir := range dirs {
+		if !dir.IsDir() {
+			continue
+		}
+		locale := dir.Name()
+		funcs := templateFuncs(locale)
+		for _, name := range templateNames {
+			text, err := fs.ReadFile(templateFS, "templates/"+locale+"/"+name+".txt")
+			if err != nil {
+				continue // Перевода нет — используется язык по умолчанию
+			}
+			html, err := fs.ReadFile(templateFS, "templates/"+locale+"/"+name+".html")
+			if err != nil {
+				return nil, fmt.Errorf("email template %s/%s: missing html version", locale, name)
+			}
+
+			key := locale + "/" + name
+			textTmpl, err := texttemplate.New(key).Funcs(funcs).Parse(string(text))
+			if err != nil {
+				return nil, fmt.Errorf("email template %s: %w", key, err)
+			}
+			htmlTmpl, err := htmltemplate.New(key).Funcs(funcs).Parse(string(layout))
+			if err == nil {
+				_, err = htmlTmpl.Parse(string(text))
+			}
+			if err == nil {
+				_, err = htmlTmpl.Parse(string(html))
+			}
+			if err != nil {
+				return nil, fmt.Errorf("email template %s: %w", key, err)
+			}
+			t.text[key] = textTmpl
+			t.html[key] = htmlTmpl
+		}
+	}
+
+	for _, name := range templateNames {
+		if _, ok := t.text[defaultLocale+"/"+name]; !ok {
+			return nil, fmt.Errorf("email template %s is not available in default locale %q", name, defaultLocale)
+		}
+	}
+	return t, nil
+}
+
+// MustLoadTemplates — как LoadTemplates, но паникует при ошибке: шаблоны встроены в бинарник,
+// поэтому ошибка разбора — дефект сборки, а не окружения.
+func MustLoadTemplates(defaultLocale string) *Templates {
+	t, err := LoadTemplates(defaultLocale)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+// Render рендерит письмо name на языке locale ("ru", "en-US" и т.п.; пусто — язык по умолчанию).
+// Даты выводятся в формате локали в часовом поясе IANA timezone (пусто или неизвестный — UTC).
+func (t *Templates) Render(locale, timezone, name string, data any) (*Message, error) {
+	lang := t.resolve(locale, name)
+	key := lang + "/" + name
+	if _, ok := t.text[key]; !ok {
+		return nil, fmt.Errorf("unknown email template %q", name)
+	}
+	// Разобранные шаблоны не исполняются: html/template нельзя клонировать после исполнения.
+	datetime := map[string]any{"datetime": dateTimeFunc(locale, lang, timezone)}
+	textTmpl, err := t.text[key].Clone()
+	if err != nil {
+		return nil, err
+	}
+	htmlTmpl, err := t.html[key].Clone()
+	if err != nil {
+		return nil, err
+	}
+	textTmpl.Funcs(datetime)
+	htmlTmpl.Funcs(datetime)
+
+	var subject, text, html bytes.Buffer
+	if err := textTmpl.ExecuteTemplate(&subject, "subject", data); err != nil {
+		return nil, err
+	}
+	if err := textTmpl.ExecuteTemplate(&text, "text", data); err != nil {
+		return nil, err
+	}
+	if err := htmlTmpl.ExecuteTemplate(&html, "layout", data); err != nil {
+		return nil, err
+	}
+	return &Message{
+		Subject: strings.TrimSpace(subject.String()),
+		Text:    strings.TrimSpace(text.String()),
+		HTML:    html.String(),
+	}, nil
+}
+
+// resolve возвращает язык, на котором есть перевод письма name: язык locale или язык по умолчанию.
+func (t *Templates) resolve(locale, name string) string {
+	lang := normalizeLocale(locale)
+	if i := strings.Index(lang, "-"); i > 0 {
+		lang = lang[:i]
+	}
+	if lang != "" {
+		if _, ok := t.text[lang+"/"+name]; ok {
+			return lang
+		}
+	}
+	return t.defaultLocale
+}
+
+// normalizeLocale приводит локаль к виду ключей dateTimeLayouts: en_GB → en-gb.
+func normalizeLocale(locale string) string {
+	return strings.ReplaceAll(strings.ToLower(strings.TrimSpace(locale)), "_", "-")
+}
+
+// templateFuncs возвращает функции шаблонов для языка locale. datetime заменяется при каждом Render;
+// date выводит календарную дату (полночь UTC) без перевода в часовой пояс получателя.
+func templateFuncs(locale string) map[string]any {
+	dateLayout, ok := dateLayouts[locale]
+	if !ok {
+		dateLayout = dateLayouts["en"]
+	}
+	return map[string]any{
+		"locale":   func() string { return locale },
+		"datetime": dateTimeFunc(locale, locale, ""),
+		"date":     func(t time.Time) string { return t.UTC().Format(dateLayout) },
+	}
+}
+
+// dateTimeFunc возвращает функцию форматирования дат письма: формат региональной локали locale,
+// если он задан для языка шаблона lang, иначе формат языка; время — в часовом поясе timezone.
+func dateTimeFunc(locale, lang, timezone string) func(time.Time) string {
+	layout, ok := "", false
+	if tag := normalizeLocale(locale); strings.HasPrefix(tag, lang+"-") {
+		layout, ok = dateTimeLayouts[tag]
+	}
+	if !ok {
+		layout, ok = dateTimeLayouts[lang]
+	}
+	if !ok {
+		layout = dateTimeLayouts["en"]
+	}
+	loc := time.UTC
+	if timezone != "" {
+		if l, err := time.LoadLocation(timezone); err == nil {
+			loc = l
+		}
+	}
+	return func(t time.Time) string { return t.In(loc).Format(layout) }
+}
@@ -0,0 +1,201 @@
+package mailer
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"workout-app/internal/config"
+	domain "workout-app/internal/domain/organization"
+	"workout-app/pkg/logger"
+	mailerpkg "workout-app/pkg/mailer"
+	"workout-app/pkg/ratelimit"
+)
+
+// TenantSettingsSource возвращает настройки отправки писем организации получателя.
+type TenantSettingsSource interface {
+	// SettingsFor возвращает nil, если письмо отправляет платформа.
+	SettingsFor(ctx context.Context, email string) (*domain.EmailSettings, error)
+}
+
+// TenantSender отправляет письма участникам организаций с адреса и через SMTP организации
+// с её лимитом писем в час. Остальные письма отправляет платформенный отправитель.
+// Если настройки получить не удалось, письмо отправляет платформа: доставка кода важнее отправителя.
+type TenantSender struct {
+	platform   mailerpkg.EmailSender
+	templates  *Templates
+	settings   TenantSettingsSource
+	newLimiter func(limit int) ratelimit.Limiter
+	logger     logger.Logger
+
+	mu       sync.Mutex
+	limiters map[int]ratelimit.Limiter // Ограничители по значению лимита; ключ счётчика — организация
+}
+
+// Убедимся на этапе компиляции, что структура реализует интерфейс.
+var _ mailerpkg.EmailSender = (*TenantSender)(nil)
+
+// NewTenantSender оборачивает платформенного отправителя маршрутизацией по организациям.
+// templates — шаблоны писем, отправляемых через SMTP организаций; newLimiter создаёт ограничитель на limit писем в час.
+func NewTenantSender(
+	platform mailerpkg.EmailSender,
+	templates *Templates,
+	settings TenantSettingsSource,
+	newLimiter func(limit int) ratelimit.Limiter,
+	logger logger.Logger,
+) *TenantSender {
+	return &TenantSender{
+		platform:   platform,
+		templates:  templates,
+		settings:   settings,
+		newLimiter: newLimiter,
+		logger:     logger,
+		limiters:   make(map[int]ratelimit.Limiter),
+	}
+}
+
+// SendEmailVerificationCode отправляет код подтверждения email.
+func (s *TenantSender) SendEmailVerificationCode(ctx context.Context, email, code string) error {
+	sender, err := s.senderFor(ctx, email)
+	if err != nil {
+		return err
+	}
+	return sender.SendEmailVerificationCode(ctx, email, code)
+}
+
+// SendPasswordChangeCode отправляет код подтверждения смены пароля.
+func (s *TenantSender) SendPasswordChangeCode(ctx context.Context, email, code string) error {
+	sender, err := s.senderFor(ctx, email)
+	if err != nil {
+		return err
+	}
+	return sender.SendPasswordChangeCode(ctx, email, code)
+}
+
+// SendPasswordResetCode отправляет код сброса пароля.
+func (s *TenantSender) SendPasswordResetCode(ctx context.Context, email, code string) error {
+	sender, err := s.senderFor(ctx, email)
+	if err != nil {
+		return err
+	}
+	return sender.SendPasswordResetCode(ctx, email, code)
+}
+
+// SendDataExportReady отправляет ссылку на выгрузку данных аккаунта.
+func (s *TenantSender) SendDataExportReady(ctx context.Context, email, downloadURL string, expiresAt time.Time) error {
+	sender, err := s.senderFor(ctx, email)
+	if err != nil {
+		return err
+	}
+	return sender.SendDataExportReady(ctx, email, downloadURL, expiresAt)
+}
+
+// SendWelcome отправляет приветственное письмо.
+func (s *TenantSender) SendWelcome(ctx context.Context, email, username string) error {
+	sender, err := s.senderFor(ctx, email)
+	if err != nil {
+		return err
+	}
+	return sender.SendWelcome(ctx, email, username)
+}
+
+// SendPasswordChanged отправляет уведомление о смене пароля.
+func (s *TenantSender) SendPasswordChanged(ctx context.Context, email string, changedAt time.Time) error {
+	sender, err := s.senderFor(ctx, email)
+	if err != nil {
+		return err
+	}
+	return sender.SendPasswordChanged(ctx, email, changedAt)
+}
+
+// SendAccountDeleted отправляет подтверждение удаления аккаунта.
+func (s *TenantSender) SendAccountDeleted(ctx context.Context, email string, deletedAt time.Time) error {
+	sender, err := s.senderFor(ctx, email)
+	if err != nil {
+		return err
+	}
+	return sender.SendAccountDeleted(ctx, email, deletedAt)
+}
+
+// SendNotification отправляет копию уведомления.
+func (s *TenantSender) SendNotification(ctx context.Context, email, notificationType string, at time.Time) error {
+	sender, err := s.senderFor(ctx, email)
+	if err != nil {
+		return err
+	}
+	return sender.SendNotification(ctx, email, notificationType, at)
+}
+
+// SendWeeklySummary отправляет еженедельную сводку тренировок.
+func (s *TenantSender) SendWeeklySummary(ctx context.Context, email string, summary mailerpkg.WeeklySummary) error {
+	sender, err := s.senderFor(ctx, email)
+	if err != nil {
+		return err
+	}
+	return sender.SendWeeklySummary(ctx, email, summary)
+}
+
+// SendCoachInvitation отправляет приглашение от тренера.
+func (s *TenantSender) SendCoachInvitation(ctx context.Context, email, coachName string, expiresAt time.Time) error {
+	sender, err := s.senderFor(ctx, email)
+	if err != nil {
+		return err
+	}
+	return sender.SendCoachInvitation(ctx, email, coachName, expiresAt)
+}
+
+// senderFor выбирает отправителя для получателя и учитывает письмо в лимите организации.
+func (s *TenantSender) senderFor(ctx context.Context, email string) (mailerpkg.EmailSender, error) {
+	settings, err := s.settings.SettingsFor(ctx, email)
+	if err != nil {
+		s.logger.Warn("tenant_email_settings_lookup_failed", map[string]any{
+			"email": email,
+			"error": err.Error(),
+		})
+		return s.platform, nil
+	}
+	if settings == nil {
+		return s.platform, nil
+	}
+
+	orgID := settings.OrganizationID.String()
+	res, err := s.limiter(settings.RateLimitPerHour).Allow(ctx, orgID)
+	if err != nil {
+		s.logger.Warn("tenant_email_rate_limit_check_failed", map[string]any{
+			"organization_id": orgID,
+			"error":           err.Error(),
+		})
+	} else if !res.Allowed {
+		s.logger.Warn("tenant_email_rate_limited", map[string]any{
+			"organization_id": orgID,
+			"email":           email,
+			"limit":           res.Limit,
+		})
+		return nil, mailerpkg.ErrSendRateLimited
+	}
+
+	// Письма организаций редки, поэтому соединение открывается на каждое письмо (без пула);
+	// способ защиты выбирается по порту: 465 — SMTPS, остальные — обязательный STARTTLS.
+	sender := NewSMTPSender(&config.EmailConfig{
+		SMTPHost:     settings.SMTPHost,
+		SMTPPort:     settings.SMTPPort,
+		SMTPUsername: settings.SMTPUsername,
+		SMTPPassword: settings.SMTPPassword,
+		FromEmail:    settings.FromEmail,
+	}, s.templates, s.logger.With(map[string]any{"organization_id": orgID}))
+	sender.fromName = settings.FromName
+	return sender, nil
+}
+
+// limiter возвращает ограничитель на limit писем в час.
+func (s *TenantSender) limiter(limit int) ratelimit.Limiter {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	l, ok := s.limiters[limit]
+	if !ok {
+		l = s.newLimiter(limit)
+		s.limiters[limit] = l
+	}
+	return l
+}
@@ -0,0 +1,39 @@
+package push
+
+import (
+	"context"
+
+	"workout-app/pkg/logger"
+	pushpkg "workout-app/pkg/push"
+)
+
+// LogSender записывает push-уведомления в лог вместо отправки.
+// Используется для платформ, для которых не настроен FCM или APNs (локальная разработка).
+type LogSender struct {
+	platform string
+	logger   logger.Logger
+}
+
+// Убедимся на этапе компиляции, что структура реализует интерфейс.
+var _ pushpkg.Sender = (*LogSender)(nil)
+
+// NewLogSender создаёт отправителя, логирующего уведомления устройств platform.
+func NewLogSender(platform string, logger logger.Logger) *LogSender {
+	return &LogSender{platform: platform, logger: logger}
+}
+
+// Name возвращает имя отправителя.
+func (s *LogSender) Name() string {
+	return "log"
+}
+
+// Send логирует уведомление; токен устройства в лог не попадает.
+func (s *LogSender) Send(_ context.Context, msg *pushpkg.Message) error {
+	s.logger.Info("Push notification sent", map[string]any{
+		"platform": s.platform,
+		"title":    msg.Title,
+		"body":     msg.Body,
+		"data":     msg.Data,
+	})
+	return nil
+}
@@ -0,0 +1,38 @@
+package push
+
+import "workout-app/internal/domain/notification"
+
+// notificationText — заголовок и текст push-уведомления.
+type notificationText struct {
+	title string
+	body  string
+}
+
+// notificationTexts — тексты push-уведомлений по языку и типу уведомления. Подробности (время занятия,
+// название программы) приложение показывает по данным уведомления из GET /users/me/notifications.
+var notificationTexts = map[string]map[string]notificationText{
+	"ru": {
+		notification.TypeProgramAssigned:       {"Новая программа тренировок", "Тренер назначил вам программу. Откройте приложение, чтобы посмотреть расписание."},
+		notification.TypeClassBooked:           {"Вы записаны на занятие", "Место на групповом занятии подтверждено."},
+		notification.TypeClassWaitlisted:       {"Вы в листе ожидания", "Мест на занятии нет. Мы сообщим, если место освободится."},
+		notification.TypeClassWaitlistPromoted: {"Место освободилось", "Вы записаны на занятие из листа ожидания."},
+		notification.TypeWorkoutReminder:       {"Тренировка сегодня", "По вашей программе на сегодня запланирована тренировка."},
+	},
+	"en": {
+		notification.TypeProgramAssigned:       {"New training program", "Your coach assigned you a program. Open the app to see the schedule."},
+		notification.TypeClassBooked:           {"You are booked for a class", "Your spot in the group class is confirmed."},
+		notification.TypeClassWaitlisted:       {"You are on the waitlist", "The class is full. We will let you know if a spot opens up."},
+		notification.TypeClassWaitlistPromoted: {"A spot opened up", "You are now booked for the class from the waitlist."},
+		notification.TypeWorkoutReminder:       {"Workout today", "Your program has a workout planned for today."},
+	},
+}
+
+// textFor возвращает текст уведомления на языке locale, иначе на языке defaultLocale, иначе на английском.
+func textFor(locale, defaultLocale, notificationType string) (notificationText, bool) {
+	for _, l := range []string{locale, defaultLocale, "en"} {
+		if text, ok := notificationTexts[l][notificationType]; ok {
+			return text, true
+		}
+	}
+	return notificationText{}, false
+}
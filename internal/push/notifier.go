@@ -0,0 +1,86 @@
+package push
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+
+	"workout-app/internal/domain/device"
+	"workout-app/internal/domain/notification"
+	repo "workout-app/internal/repository/interfaces"
+	"workout-app/pkg/logger"
+	pushpkg "workout-app/pkg/push"
+)
+
+// Notifier доставляет push-уведомления на все устройства пользователя через сервис платформы устройства.
+// Доставка без гарантий: ошибки сервиса логируются и не повторяются, а устройства с недействительными
+// токенами удаляются.
+type Notifier struct {
+	devices       repo.DeviceRepository
+	senders       map[device.Platform]pushpkg.Sender
+	defaultLocale string
+	logger        logger.Logger
+}
+
+// NewNotifier создаёт отправителя; устройства платформ без отправителя в senders пропускаются.
+// defaultLocale — язык уведомлений пользователям, не выбравшим язык.
+func NewNotifier(devices repo.DeviceRepository, senders map[device.Platform]pushpkg.Sender, defaultLocale string, logger logger.Logger) *Notifier {
+	return &Notifier{devices: devices, senders: senders, defaultLocale: defaultLocale, logger: logger}
+}
+
+// Notify отправляет копию уведомления в приложении на языке locale. В данных push-уведомления
+// передаются тип и ID уведомления, чтобы приложение открыло его.
+func (n *Notifier) Notify(ctx context.Context, userID uuid.UUID, locale string, item *notification.Notification) (int, error) {
+	text, ok := textFor(locale, n.defaultLocale, item.Type)
+	if !ok {
+		return 0, nil
+	}
+	return n.Send(ctx, userID, pushpkg.Message{
+		Title: text.title,
+		Body:  text.body,
+		Data:  map[string]string{"notification_id": item.ID.String(), "type": item.Type},
+	})
+}
+
+// Send отправляет сообщение на устройства пользователя и возвращает количество доставленных.
+// Ошибка возвращается, только если не удалось прочитать или удалить устройства.
+func (n *Notifier) Send(ctx context.Context, userID uuid.UUID, msg pushpkg.Message) (int, error) {
+	devices, err := n.devices.ListByUser(ctx, userID)
+	if err != nil {
+		return 0, err
+	}
+
+	delivered := 0
+	for _, d := range devices {
+		sender, ok := n.senders[d.Platform]
+		if !ok {
+			continue
+		}
+		m := msg
+		m.Token = d.Token
+		err := sender.Send(ctx, &m)
+		switch {
+		case err == nil:
+			delivered++
+		case errors.Is(err, pushpkg.ErrTokenInvalid):
+			if err := n.devices.DeleteByToken(ctx, d.Token); err != nil {
+				return delivered, err
+			}
+			n.logger.Info("push_device_removed", map[string]any{
+				"user_id":   userID.String(),
+				"device_id": d.ID.String(),
+				"service":   sender.Name(),
+				"error":     err.Error(),
+			})
+		default:
+			n.logger.Warn("push_send_failed", map[string]any{
+				"user_id":   userID.String(),
+				"device_id": d.ID.String(),
+				"service":   sender.Name(),
+				"error":     err.Error(),
+			})
+		}
+	}
+	return delivered, nil
+}
@@ -0,0 +1,31 @@
+package interfaces
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	domain "workout-app/internal/domain/audit"
+)
+
+// AuditLogFilter задаёт выборку записей журнала действий администраторов.
+type AuditLogFilter struct {
+	ActorID  *uuid.UUID
+	TargetID string
+	Action   string // Подстрока метода и маршрута, например "users/:id/role"
+	From     *time.Time
+	To       *time.Time
+	Limit    int
+	Offset   int
+}
+
+// AuditLogRepository определяет контракт журнала действий администраторов.
+// Журнал только дополняется: записи не изменяются и не удаляются.
+type AuditLogRepository interface {
+	// Create добавляет запись в журнал.
+	Create(ctx context.Context, e *domain.Entry) error
+
+	// List возвращает записи по фильтру (новые первыми) и общее количество подходящих записей.
+	List(ctx context.Context, filter AuditLogFilter) ([]*domain.Entry, int64, error)
+}
@@ -0,0 +1,50 @@
+package interfaces
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+
+	domain "workout-app/internal/domain/backfill"
+)
+
+// ErrBackfillActive возвращается, когда задача этого типа уже выполняется.
+var ErrBackfillActive = errors.New("backfill of this job is already active")
+
+// ErrBackfillLeaseLost возвращается, когда задачу перехватил другой процесс или она уже завершена.
+var ErrBackfillLeaseLost = errors.New("backfill lease lost")
+
+// BackfillRepository определяет контракт для хранения задач пересчёта и их прогресса.
+type BackfillRepository interface {
+	// Create сохраняет новую задачу.
+	// Возвращает ErrBackfillActive, если незавершённая задача того же типа уже есть.
+	Create(ctx context.Context, b *domain.Backfill) error
+
+	// GetByID возвращает задачу по идентификатору.
+	// Возвращает (nil, ErrNotFound), если задачи нет.
+	GetByID(ctx context.Context, id uuid.UUID) (*domain.Backfill, error)
+
+	// List возвращает последние задачи, новые первыми.
+	List(ctx context.Context, limit int) ([]*domain.Backfill, error)
+
+	// ListResumable возвращает незавершённые задачи (pending и running).
+	ListResumable(ctx context.Context) ([]*domain.Backfill, error)
+
+	// Claim захватывает аренду задачи для процесса owner на срок ttl.
+	// Успешен, если задача не завершена и аренда свободна, истекла или уже принадлежит owner.
+	Claim(ctx context.Context, id uuid.UUID, owner string, ttl time.Duration) (bool, error)
+
+	// SaveProgress сохраняет курсор и счётчик обработанных элементов и продлевает аренду.
+	// Возвращает ErrBackfillLeaseLost, если аренда принадлежит другому процессу.
+	SaveProgress(ctx context.Context, id uuid.UUID, owner, cursor string, processed int64, ttl time.Duration) error
+
+	// Finish переводит задачу в финальное состояние и освобождает аренду.
+	Finish(ctx context.Context, id uuid.UUID, owner string, status domain.Status, lastError string) error
+
+	// RequestCancel помечает задачу на отмену; выполняющий её процесс остановится после текущей пачки.
+	// Незапущенная задача отменяется сразу.
+	// Возвращает ErrNotFound, если незавершённой задачи с таким ID нет.
+	RequestCancel(ctx context.Context, id uuid.UUID) error
+}
@@ -0,0 +1,30 @@
+package interfaces
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+
+	domain "workout-app/internal/domain/backup"
+)
+
+// ErrBackupVersionExists возвращается, если версия резервной копии уже сохранена параллельной загрузкой.
+var ErrBackupVersionExists = errors.New("backup version already exists")
+
+// BackupRepository определяет контракт хранения метаданных зашифрованных резервных копий.
+type BackupRepository interface {
+	// Create сохраняет новую версию резервной копии.
+	// Возвращает ErrBackupVersionExists, если у пользователя уже есть такая версия.
+	Create(ctx context.Context, b *domain.Backup) error
+
+	// ListByUser возвращает версии резервной копии пользователя, новые первыми.
+	ListByUser(ctx context.Context, userID uuid.UUID) ([]*domain.Backup, error)
+
+	// Delete удаляет версию резервной копии.
+	// Возвращает ErrNotFound, если версии нет.
+	Delete(ctx context.Context, id uuid.UUID) error
+
+	// DeleteByUserID удаляет все версии резервной копии пользователя.
+	DeleteByUserID(ctx context.Context, userID uuid.UUID) error
+}
@@ -0,0 +1,27 @@
+package interfaces
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	domain "workout-app/internal/domain/user"
+)
+
+// BodyMetricRepository определяет контракт для работы с замерами параметров тела.
+type BodyMetricRepository interface {
+	// Create сохраняет новый замер.
+	Create(ctx context.Context, m *domain.BodyMetric) error
+
+	// ListByUser возвращает страницу замеров пользователя в диапазоне [from, to], отсортированных по убыванию даты.
+	// Нулевые from/to означают отсутствие соответствующей границы.
+	ListByUser(ctx context.Context, userID uuid.UUID, from, to time.Time, limit, offset int) ([]*domain.BodyMetric, error)
+
+	// GetLatestSummary возвращает последние известные значения каждой метрики пользователя.
+	// Если замеров нет, возвращает пустую сводку без ошибки.
+	GetLatestSummary(ctx context.Context, userID uuid.UUID) (*domain.BodyMetricsSummary, error)
+
+	// DeleteByUserID удаляет все замеры пользователя.
+	DeleteByUserID(ctx context.Context, userID uuid.UUID) error
+}
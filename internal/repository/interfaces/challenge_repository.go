@@ -0,0 +1,53 @@
+package interfaces
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	domain "workout-app/internal/domain/challenge"
+)
+
+// ChallengeFilter задаёт выборку списка челленджей.
+type ChallengeFilter struct {
+	UserID     uuid.UUID // Пользователь, для которого считается признак участия
+	JoinedOnly bool      // Только челленджи, в которых участвует UserID
+	EndsAfter  time.Time // Только челленджи, заканчивающиеся позже (нулевое значение — без ограничения)
+	Limit      int
+	Offset     int
+}
+
+// ChallengeRepository определяет контракт для челленджей, их участников и прогресса.
+type ChallengeRepository interface {
+	// Create сохраняет челлендж.
+	Create(ctx context.Context, c *domain.Challenge) error
+
+	// GetByID возвращает челлендж.
+	// Возвращает (nil, ErrNotFound), если челленджа нет.
+	GetByID(ctx context.Context, id uuid.UUID) (*domain.Challenge, error)
+
+	// GetListing возвращает челлендж с числом участников и участием userID.
+	// Возвращает (nil, ErrNotFound), если челленджа нет.
+	GetListing(ctx context.Context, id, userID uuid.UUID) (*domain.Listing, error)
+
+	// List возвращает челленджи по фильтру, ближайшие к завершению первыми.
+	List(ctx context.Context, filter ChallengeFilter) ([]*domain.Listing, error)
+
+	// Delete удаляет челлендж вместе с участниками.
+	// Возвращает ErrNotFound, если челленджа нет.
+	Delete(ctx context.Context, id uuid.UUID) error
+
+	// AddParticipant добавляет участника; повторное вступление ничего не меняет.
+	AddParticipant(ctx context.Context, p *domain.Participant) error
+
+	// RemoveParticipant удаляет участника; отсутствие участия ошибкой не считается.
+	RemoveParticipant(ctx context.Context, challengeID, userID uuid.UUID) error
+
+	// Progress считает показатель челленджа для всех его активных участников
+	// по завершённым тренировкам за период челленджа.
+	Progress(ctx context.Context, c *domain.Challenge) ([]*domain.Progress, error)
+
+	// DeleteParticipantsByUserID удаляет участие пользователя во всех челленджах (при обезличивании).
+	DeleteParticipantsByUserID(ctx context.Context, userID uuid.UUID) error
+}
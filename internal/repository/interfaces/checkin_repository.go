@@ -0,0 +1,26 @@
+package interfaces
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	domain "workout-app/internal/domain/checkin"
+)
+
+// CheckInRepository определяет контракт хранения ежедневных анкет готовности.
+type CheckInRepository interface {
+	// Upsert сохраняет анкету; анкета за тот же день заменяет предыдущую.
+	// После сохранения ID и CreatedAt соответствуют сохранённой записи.
+	Upsert(ctx context.Context, c *domain.CheckIn) error
+
+	// ListByUser возвращает анкеты пользователя за дни [from, to], новые первыми.
+	ListByUser(ctx context.Context, userID uuid.UUID, from, to time.Time) ([]*domain.CheckIn, error)
+
+	// ListDays возвращает все дни, за которые пользователь заполнил анкету (для подсчёта серий).
+	ListDays(ctx context.Context, userID uuid.UUID) ([]time.Time, error)
+
+	// DeleteByUserID удаляет все анкеты пользователя (при обезличивании).
+	DeleteByUserID(ctx context.Context, userID uuid.UUID) error
+}
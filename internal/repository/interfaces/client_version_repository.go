@@ -0,0 +1,20 @@
+package interfaces
+
+import (
+	"context"
+
+	domain "workout-app/internal/domain/clientversion"
+)
+
+// ClientVersionRepository определяет контракт для хранения минимальных версий клиента.
+type ClientVersionRepository interface {
+	// List возвращает политики всех платформ.
+	List(ctx context.Context) ([]*domain.Policy, error)
+
+	// Upsert создаёт или обновляет политику платформы.
+	Upsert(ctx context.Context, p *domain.Policy) error
+
+	// Delete удаляет политику платформы.
+	// Возвращает ErrNotFound, если политики нет.
+	Delete(ctx context.Context, platform domain.Platform) error
+}
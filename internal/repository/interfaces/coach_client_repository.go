@@ -0,0 +1,48 @@
+package interfaces
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+
+	domain "workout-app/internal/domain/coach"
+)
+
+// ErrCoachInvitationExists возвращается, если у пользователя уже есть действующее приглашение тренера.
+var ErrCoachInvitationExists = errors.New("user already has a pending invitation from the coach")
+
+// CoachClientRepository определяет контракт для приглашений тренеров и связей «тренер — клиент».
+type CoachClientRepository interface {
+	// CreateInvitation сохраняет приглашение; истёкшее приглашение той же пары заменяется.
+	// Возвращает ErrCoachInvitationExists, если действующее приглашение уже есть.
+	CreateInvitation(ctx context.Context, inv *domain.Invitation) error
+
+	// GetInvitation возвращает приглашение по ID или ErrNotFound.
+	GetInvitation(ctx context.Context, id uuid.UUID) (*domain.Invitation, error)
+
+	// ListInvitationsByCoach возвращает действующие на момент now приглашения тренера, новые первыми.
+	ListInvitationsByCoach(ctx context.Context, coachID uuid.UUID, now time.Time) ([]*domain.Invitation, error)
+
+	// ListInvitationsByClient возвращает действующие на момент now приглашения пользователя, новые первыми.
+	ListInvitationsByClient(ctx context.Context, clientID uuid.UUID, now time.Time) ([]*domain.Invitation, error)
+
+	// DeleteInvitation удаляет приглашение. Возвращает ErrNotFound, если приглашения нет.
+	DeleteInvitation(ctx context.Context, id uuid.UUID) error
+
+	// AddClient сохраняет связь «тренер — клиент»; повторное добавление не считается ошибкой.
+	AddClient(ctx context.Context, c *domain.Client) error
+
+	// IsClient сообщает, есть ли связь «тренер — клиент» между coachID и clientID.
+	IsClient(ctx context.Context, coachID, clientID uuid.UUID) (bool, error)
+
+	// ListClientIDs возвращает клиентов тренера, упорядоченных по ID.
+	ListClientIDs(ctx context.Context, coachID uuid.UUID) ([]uuid.UUID, error)
+
+	// DeleteClient удаляет связь «тренер — клиент», если она есть.
+	DeleteClient(ctx context.Context, coachID, clientID uuid.UUID) error
+
+	// DeleteByUserID удаляет приглашения и связи, где пользователь выступает тренером или клиентом.
+	DeleteByUserID(ctx context.Context, userID uuid.UUID) error
+}
@@ -0,0 +1,46 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	domain "workout-app/internal/domain/coach"
+)
+
+// CoachNoteRepository определяет контракт для заметок и тегов тренера о клиентах.
+// Все выборки ограничены тренером: заметки одного тренера не видны другим.
+type CoachNoteRepository interface {
+	// CreateNote сохраняет новую заметку.
+	CreateNote(ctx context.Context, n *domain.Note) error
+
+	// GetNote возвращает заметку тренера о клиенте или ErrNotFound.
+	GetNote(ctx context.Context, coachID, clientID, id uuid.UUID) (*domain.Note, error)
+
+	// UpdateNote сохраняет текст и время изменения заметки. Возвращает ErrNotFound, если заметки нет.
+	UpdateNote(ctx context.Context, n *domain.Note) error
+
+	// DeleteNote удаляет заметку тренера о клиенте. Возвращает ErrNotFound, если заметки нет.
+	DeleteNote(ctx context.Context, coachID, clientID, id uuid.UUID) error
+
+	// ListNotes возвращает заметки тренера о клиенте, новые первыми.
+	ListNotes(ctx context.Context, coachID, clientID uuid.UUID) ([]*domain.Note, error)
+
+	// ReplaceTags заменяет теги тренера для клиента; пустой список удаляет все теги.
+	ReplaceTags(ctx context.Context, coachID, clientID uuid.UUID, tags []string) error
+
+	// ListTags возвращает теги тренера для клиента по алфавиту.
+	ListTags(ctx context.Context, coachID, clientID uuid.UUID) ([]string, error)
+
+	// ListTagsByClient возвращает теги тренера для всех его клиентов.
+	ListTagsByClient(ctx context.Context, coachID uuid.UUID) (map[uuid.UUID][]string, error)
+
+	// SearchClients возвращает клиентов, в заметках тренера о которых встречается query (без учёта регистра).
+	SearchClients(ctx context.Context, coachID uuid.UUID, query string) ([]uuid.UUID, error)
+
+	// DeleteByRelationship удаляет заметки и теги тренера о клиенте.
+	DeleteByRelationship(ctx context.Context, coachID, clientID uuid.UUID) error
+
+	// DeleteByUserID удаляет заметки и теги, где пользователь выступает тренером или клиентом.
+	DeleteByUserID(ctx context.Context, userID uuid.UUID) error
+}
@@ -0,0 +1,43 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	domain "workout-app/internal/domain/coach"
+)
+
+// CoachDirectoryFilter задаёт выборку анкет публичного каталога тренеров.
+// Пустые поля не ограничивают выборку.
+type CoachDirectoryFilter struct {
+	Specialty    domain.Specialty
+	Language     string // Код языка ISO 639-1
+	Country      string // Код страны ISO 3166-1 alpha-2
+	City         string // Сравнивается без учёта регистра
+	Online       *bool
+	VerifiedOnly bool
+	Limit        int
+	Offset       int
+}
+
+// CoachProfileRepository определяет контракт для анкет тренеров.
+type CoachProfileRepository interface {
+	// Get возвращает анкету тренера.
+	// Возвращает ErrNotFound, если тренер не заполнял анкету и не получал отметку о проверке.
+	Get(ctx context.Context, userID uuid.UUID) (*domain.Profile, error)
+
+	// Save создаёт или обновляет анкету. Отметка о проверке не меняется.
+	Save(ctx context.Context, p *domain.Profile) error
+
+	// SetVerification ставит (v != nil) или снимает отметку «проверенный тренер».
+	// Если анкеты ещё нет, создаётся неопубликованная анкета с отметкой.
+	SetVerification(ctx context.Context, userID uuid.UUID, v *domain.Verification) error
+
+	// ListDirectory возвращает опубликованные анкеты активных тренеров по фильтру
+	// (проверенные первыми, затем по username) и общее количество подходящих анкет.
+	ListDirectory(ctx context.Context, filter CoachDirectoryFilter) ([]*domain.DirectoryEntry, int64, error)
+
+	// DeleteByUserID удаляет анкету тренера.
+	DeleteByUserID(ctx context.Context, userID uuid.UUID) error
+}
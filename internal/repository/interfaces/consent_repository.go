@@ -0,0 +1,29 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	domain "workout-app/internal/domain/consent"
+)
+
+// ConsentRepository определяет контракт для хранения согласий клиентов на доступ тренеров к данным.
+type ConsentRepository interface {
+	// Get возвращает согласие клиента для тренера.
+	// Возвращает ErrNotFound, если клиент ничего не открывал этому тренеру.
+	Get(ctx context.Context, clientID, coachID uuid.UUID) (*domain.Consent, error)
+
+	// ListByClient возвращает все согласия клиента.
+	ListByClient(ctx context.Context, clientID uuid.UUID) ([]*domain.Consent, error)
+
+	// Upsert создаёт или заменяет согласие клиента для тренера.
+	Upsert(ctx context.Context, c *domain.Consent) error
+
+	// Delete отзывает согласие клиента для тренера.
+	// Возвращает ErrNotFound, если согласия нет.
+	Delete(ctx context.Context, clientID, coachID uuid.UUID) error
+
+	// DeleteByUserID удаляет согласия, где пользователь выступает клиентом или тренером.
+	DeleteByUserID(ctx context.Context, userID uuid.UUID) error
+}
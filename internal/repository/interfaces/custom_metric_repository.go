@@ -0,0 +1,58 @@
+package interfaces
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+
+	domain "workout-app/internal/domain/custommetric"
+)
+
+// ErrCustomMetricExists возвращается, если у пользователя уже есть метрика с таким названием.
+var ErrCustomMetricExists = errors.New("custom metric already exists")
+
+// CustomMetricRepository определяет контракт хранения пользовательских метрик и их значений.
+type CustomMetricRepository interface {
+	// Create сохраняет новую метрику.
+	// Возвращает ErrCustomMetricExists, если название уже занято.
+	Create(ctx context.Context, d *domain.Definition) error
+
+	// Update сохраняет изменения названия, единицы и направления улучшения.
+	// Возвращает ErrNotFound, если метрики нет, и ErrCustomMetricExists, если название занято.
+	Update(ctx context.Context, d *domain.Definition) error
+
+	// GetByID возвращает метрику пользователя.
+	// Возвращает ErrNotFound, если метрики нет или она принадлежит другому пользователю.
+	GetByID(ctx context.Context, userID, id uuid.UUID) (*domain.Definition, error)
+
+	// ListByUser возвращает метрики пользователя по названию.
+	ListByUser(ctx context.Context, userID uuid.UUID) ([]*domain.Definition, error)
+
+	// CountByUser возвращает количество метрик пользователя.
+	CountByUser(ctx context.Context, userID uuid.UUID) (int64, error)
+
+	// Delete удаляет метрику вместе со всеми значениями.
+	// Возвращает ErrNotFound, если метрики нет.
+	Delete(ctx context.Context, userID, id uuid.UUID) error
+
+	// AddEntry сохраняет значение метрики.
+	AddEntry(ctx context.Context, e *domain.Entry) error
+
+	// ListEntries возвращает значения метрики за [from, to), старые первыми, не более limit.
+	ListEntries(ctx context.Context, metricID uuid.UUID, from, to time.Time, limit int) ([]*domain.Entry, error)
+
+	// LatestEntries возвращает последнее значение каждой метрики пользователя по ID метрики.
+	LatestEntries(ctx context.Context, userID uuid.UUID) (map[uuid.UUID]*domain.Entry, error)
+
+	// DeleteEntry удаляет значение метрики.
+	// Возвращает ErrNotFound, если значения нет.
+	DeleteEntry(ctx context.Context, metricID, entryID uuid.UUID) error
+
+	// ListEntriesByUser возвращает все значения всех метрик пользователя (для выгрузки данных).
+	ListEntriesByUser(ctx context.Context, userID uuid.UUID) ([]*domain.Entry, error)
+
+	// DeleteByUserID удаляет все метрики и значения пользователя (при обезличивании).
+	DeleteByUserID(ctx context.Context, userID uuid.UUID) error
+}
@@ -0,0 +1,49 @@
+package interfaces
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+
+	domain "workout-app/internal/domain/export"
+)
+
+// ErrExportPending возвращается при попытке поставить в очередь вторую выгрузку пользователя.
+var ErrExportPending = errors.New("data export already pending")
+
+// DataExportRepository определяет контракт хранения выгрузок данных аккаунта.
+type DataExportRepository interface {
+	// Create сохраняет выгрузку, поставленную в очередь.
+	// Возвращает ErrExportPending, если у пользователя уже есть выгрузка в очереди.
+	Create(ctx context.Context, e *domain.Export) error
+
+	// GetByID возвращает выгрузку по идентификатору.
+	// Возвращает ErrNotFound, если выгрузки нет.
+	GetByID(ctx context.Context, id uuid.UUID) (*domain.Export, error)
+
+	// GetLatestByUser возвращает последнюю запрошенную выгрузку пользователя.
+	// Возвращает ErrNotFound, если пользователь ничего не выгружал.
+	GetLatestByUser(ctx context.Context, userID uuid.UUID) (*domain.Export, error)
+
+	// ListPending возвращает до limit выгрузок в очереди, старые первыми.
+	ListPending(ctx context.Context, limit int) ([]*domain.Export, error)
+
+	// Claim захватывает сборку выгрузки до момента until.
+	// Успешен, если выгрузка в очереди, а предыдущая аренда отсутствует или истекла.
+	Claim(ctx context.Context, id uuid.UUID, until time.Time) (bool, error)
+
+	// Complete сохраняет результат сборки: статус, архив, ошибку и сроки.
+	Complete(ctx context.Context, e *domain.Export) error
+
+	// ListExpired возвращает до limit выгрузок с истёкшим к моменту now сроком хранения.
+	ListExpired(ctx context.Context, now time.Time, limit int) ([]*domain.Export, error)
+
+	// Delete удаляет запись о выгрузке.
+	Delete(ctx context.Context, id uuid.UUID) error
+
+	// ExpireByUserID сокращает срок хранения всех выгрузок пользователя до at, а выгрузки
+	// в очереди помечает ошибкой (при обезличивании). Архивы удаляет фоновая очистка.
+	ExpireByUserID(ctx context.Context, userID uuid.UUID, at time.Time) error
+}
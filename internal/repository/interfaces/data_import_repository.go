@@ -0,0 +1,74 @@
+package interfaces
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+
+	domain "workout-app/internal/domain/dataimport"
+)
+
+// ErrImportInProgress возвращается при попытке начать второй импорт, пока предыдущий не завершён.
+var ErrImportInProgress = errors.New("data import already in progress")
+
+// DataImportRepository определяет контракт хранения импортов исторических данных и их записей.
+type DataImportRepository interface {
+	// Create сохраняет импорт, файл которого начал загружаться.
+	// Возвращает ErrImportInProgress, если у пользователя есть незавершённый импорт.
+	Create(ctx context.Context, i *domain.Import) error
+
+	// AddRecords сохраняет пачку строк загружаемого файла.
+	AddRecords(ctx context.Context, records []*domain.Record) error
+
+	// Enqueue фиксирует загруженный файл из total записей и передаёт импорт в обработку.
+	// Возвращает ErrNotFound, если импорта нет или он уже в обработке.
+	Enqueue(ctx context.Context, id uuid.UUID, total int) error
+
+	// GetByID возвращает импорт по идентификатору.
+	// Возвращает ErrNotFound, если импорта нет.
+	GetByID(ctx context.Context, id uuid.UUID) (*domain.Import, error)
+
+	// GetActiveByUser возвращает незавершённый импорт пользователя.
+	// Возвращает ErrNotFound, если такого нет.
+	GetActiveByUser(ctx context.Context, userID uuid.UUID) (*domain.Import, error)
+
+	// ListByUser возвращает до limit последних импортов пользователя, новые первыми.
+	ListByUser(ctx context.Context, userID uuid.UUID, limit int) ([]*domain.Import, error)
+
+	// ListRunnable возвращает до limit импортов в очереди или в обработке, старые первыми.
+	ListRunnable(ctx context.Context, limit int) ([]*domain.Import, error)
+
+	// Claim захватывает обработку импорта до момента until и переводит его в статус running.
+	// Успешен, если импорт в очереди или в обработке, а предыдущая аренда отсутствует или истекла.
+	Claim(ctx context.Context, id uuid.UUID, until time.Time) (bool, error)
+
+	// ListPendingRecords возвращает до limit необработанных записей импорта в порядке строк файла.
+	ListPendingRecords(ctx context.Context, importID uuid.UUID, limit int) ([]*domain.Record, error)
+
+	// IsImported возвращает true, если запись с таким внешним ID уже импортирована пользователем.
+	IsImported(ctx context.Context, userID uuid.UUID, kind domain.Kind, externalID string) (bool, error)
+
+	// SaveRecord сохраняет результат обработки записи: тип, внешний ID, статус, ошибку и созданную сущность.
+	SaveRecord(ctx context.Context, r *domain.Record) error
+
+	// SaveProgress сохраняет счётчики импорта и продлевает аренду обработки до until.
+	SaveProgress(ctx context.Context, i *domain.Import, until time.Time) error
+
+	// Finish сохраняет итоговые счётчики, статус, ошибку и время завершения, снимая аренду.
+	Finish(ctx context.Context, i *domain.Import) error
+
+	// ListRecords возвращает записи импорта в порядке строк файла, при непустом status — только с этим статусом.
+	ListRecords(ctx context.Context, importID uuid.UUID, status domain.RecordStatus, limit, offset int) ([]*domain.Record, error)
+
+	// Delete удаляет импорт вместе с его записями.
+	Delete(ctx context.Context, id uuid.UUID) error
+
+	// DeleteFinished удаляет не более limit импортов, завершённых раньше before, и брошенных
+	// загрузок, начатых раньше before, вместе с записями. Возвращает количество удалённых импортов.
+	DeleteFinished(ctx context.Context, before time.Time, limit int) (int64, error)
+
+	// DeleteByUserID удаляет все импорты пользователя (при обезличивании).
+	DeleteByUserID(ctx context.Context, userID uuid.UUID) error
+}
@@ -0,0 +1,17 @@
+package interfaces
+
+import (
+	"context"
+	"time"
+
+	domain "workout-app/internal/domain/deliverability"
+)
+
+// DeliverabilityRepository определяет контракт для журнала доставки писем.
+type DeliverabilityRepository interface {
+	// CreateEvent сохраняет событие доставки и заполняет его ID.
+	CreateEvent(ctx context.Context, ev *domain.Event) error
+
+	// StatsSince возвращает агрегаты исходов доставки по провайдерам с момента since.
+	StatsSince(ctx context.Context, since time.Time) ([]domain.ProviderStats, error)
+}
@@ -0,0 +1,29 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	domain "workout-app/internal/domain/device"
+)
+
+// DeviceRepository определяет контракт для устройств, получающих push-уведомления.
+type DeviceRepository interface {
+	// Upsert регистрирует устройство. Если токен уже зарегистрирован (в том числе другим пользователем),
+	// запись переходит к d.UserID с новой платформой и временем LastSeenAt; d.ID и d.CreatedAt
+	// заполняются значениями сохранённой записи.
+	Upsert(ctx context.Context, d *domain.Device) error
+
+	// ListByUser возвращает устройства пользователя, недавно активные первыми.
+	ListByUser(ctx context.Context, userID uuid.UUID) ([]*domain.Device, error)
+
+	// Delete удаляет устройство пользователя. Возвращает ErrNotFound, если устройства нет.
+	Delete(ctx context.Context, userID, id uuid.UUID) error
+
+	// DeleteByToken удаляет устройство с недействительным токеном.
+	DeleteByToken(ctx context.Context, token string) error
+
+	// DeleteByUserID удаляет все устройства пользователя.
+	DeleteByUserID(ctx context.Context, userID uuid.UUID) error
+}
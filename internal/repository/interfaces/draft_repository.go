@@ -0,0 +1,47 @@
+package interfaces
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+
+	domain "workout-app/internal/domain/draft"
+)
+
+// ErrDraftExists возвращается, когда черновик для этого устройства и объекта уже создан.
+var ErrDraftExists = errors.New("draft already exists")
+
+// DraftRepository определяет контракт хранения черновиков редактора программ и тренировок.
+type DraftRepository interface {
+	// Create сохраняет новый черновик.
+	// Возвращает ErrDraftExists, если черновик с тем же устройством, видом и объектом уже есть (в том числе истёкший).
+	Create(ctx context.Context, d *domain.Draft) error
+
+	// Update сохраняет содержимое, версию и сроки черновика, если в базе хранится версия expectedVersion.
+	// Возвращает ErrNotFound, если черновика нет или его версия другая.
+	Update(ctx context.Context, d *domain.Draft, expectedVersion int) error
+
+	// GetByKey возвращает черновик устройства для объекта targetID (nil — новый объект) или ErrNotFound.
+	GetByKey(ctx context.Context, userID uuid.UUID, deviceID string, kind domain.Kind, targetID *uuid.UUID) (*domain.Draft, error)
+
+	// GetByID возвращает черновик пользователя или ErrNotFound.
+	GetByID(ctx context.Context, userID, id uuid.UUID) (*domain.Draft, error)
+
+	// ListByUser возвращает черновики пользователя, не истёкшие к now, недавно сохранённые первыми.
+	// deviceID ограничивает список одним устройством (пусто — все устройства). Content не загружается.
+	ListByUser(ctx context.Context, userID uuid.UUID, deviceID string, now time.Time) ([]*domain.Draft, error)
+
+	// CountByUser возвращает количество черновиков пользователя, не истёкших к now.
+	CountByUser(ctx context.Context, userID uuid.UUID, now time.Time) (int64, error)
+
+	// Delete удаляет черновик пользователя. Возвращает ErrNotFound, если черновика нет.
+	Delete(ctx context.Context, userID, id uuid.UUID) error
+
+	// DeleteExpired удаляет не более limit черновиков, истёкших до before, и возвращает количество удалённых.
+	DeleteExpired(ctx context.Context, before time.Time, limit int) (int64, error)
+
+	// DeleteByUserID удаляет все черновики пользователя.
+	DeleteByUserID(ctx context.Context, userID uuid.UUID) error
+}
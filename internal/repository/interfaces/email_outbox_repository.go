@@ -0,0 +1,41 @@
+package interfaces
+
+import (
+	"context"
+	"time"
+
+	domain "workout-app/internal/domain/emailoutbox"
+)
+
+// EmailOutboxRepository определяет контракт хранения очереди исходящих писем.
+type EmailOutboxRepository interface {
+	// Enqueue сохраняет письмо в очереди и проставляет ему ID.
+	// Внутри транзакции (Transactor) письмо попадает в очередь только при её фиксации.
+	Enqueue(ctx context.Context, m *domain.Message) error
+
+	// ListDue возвращает до limit писем в очереди, время попытки которых наступило к now, старые первыми.
+	ListDue(ctx context.Context, now time.Time, limit int) ([]*domain.Message, error)
+
+	// Claim захватывает отправку письма до момента until, сдвигая время попытки.
+	// Успешен, если письмо в очереди и время его попытки наступило к now: так письмо не отправят
+	// два процесса одновременно, а если процесс упал, письмо подхватит другой по истечении аренды.
+	Claim(ctx context.Context, id int64, now, until time.Time) (bool, error)
+
+	// Save сохраняет результат попытки: статус, счётчик попыток, время следующей попытки, ошибку и данные письма.
+	// Возвращает ErrNotFound, если письма нет.
+	Save(ctx context.Context, m *domain.Message) error
+
+	// GetByID возвращает письмо по ID.
+	// Возвращает ErrNotFound, если письма нет.
+	GetByID(ctx context.Context, id int64) (*domain.Message, error)
+
+	// List возвращает страницу писем в статусе status (пусто — любом), новые первыми, и их общее количество.
+	List(ctx context.Context, status domain.Status, limit, offset int) ([]*domain.Message, int64, error)
+
+	// CountByStatus возвращает количество писем по статусам.
+	CountByStatus(ctx context.Context) (map[domain.Status]int64, error)
+
+	// DeleteFinished удаляет не более limit отправленных и dead-letter писем, завершённых до before,
+	// и возвращает количество удалённых.
+	DeleteFinished(ctx context.Context, before time.Time, limit int) (int64, error)
+}
@@ -2,6 +2,7 @@ package interfaces
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 
@@ -11,21 +12,12 @@ import (
 // EmailVerificationRepository определяет контракт для работы с кодами подтверждения email.
 type EmailVerificationRepository interface {
 	// Create создает новую запись с кодом подтверждения email.
+	// Назначение кода (Purpose) обязательно.
 	Create(ctx context.Context, v *domain.EmailVerification) error
 
-	// GetActiveByUserID возвращает активную (не истекшую) запись по user_id.
+	// GetActiveByPurpose возвращает активную (не истекшую) запись пользователя с указанным назначением.
 	// Возвращает (nil, ErrNotFound), если активного кода нет.
-	GetActiveByUserID(ctx context.Context, userID uuid.UUID) (*domain.EmailVerification, error)
-
-	// GetActiveByUserIDAndNewEmail возвращает активную (не истекшую) запись по user_id и new_email.
-	// Используется для поиска кода подтверждения изменения email.
-	// Возвращает (nil, ErrNotFound), если активного кода нет.
-	GetActiveByUserIDAndNewEmail(ctx context.Context, userID uuid.UUID, newEmail string) (*domain.EmailVerification, error)
-
-	// GetActiveEmailChangeByUserID возвращает активную (не истекшую) запись изменения email по user_id.
-	// Используется для поиска кода подтверждения изменения email без знания нового email.
-	// Возвращает (nil, ErrNotFound), если активного кода нет.
-	GetActiveEmailChangeByUserID(ctx context.Context, userID uuid.UUID) (*domain.EmailVerification, error)
+	GetActiveByPurpose(ctx context.Context, userID uuid.UUID, purpose domain.VerificationPurpose) (*domain.EmailVerification, error)
 
 	// GetByID возвращает запись верификации по её ID.
 	// Используется для получения обновленного значения попыток после IncrementAttempts.
@@ -34,9 +26,12 @@ type EmailVerificationRepository interface {
 	// IncrementAttempts увеличивает счетчик попыток для записи по её ID.
 	IncrementAttempts(ctx context.Context, id int64) error
 
-	// DeleteByUserID удаляет все записи кодов для указанного пользователя.
+	// DeleteByUserID удаляет все записи кодов для указанного пользователя независимо от назначения.
 	DeleteByUserID(ctx context.Context, userID uuid.UUID) error
 
-	// DeleteEmailChangeByUserID удаляет все записи кодов изменения email для указанного пользователя.
-	DeleteEmailChangeByUserID(ctx context.Context, userID uuid.UUID) error
+	// DeleteByPurpose удаляет записи кодов пользователя с указанным назначением.
+	DeleteByPurpose(ctx context.Context, userID uuid.UUID, purpose domain.VerificationPurpose) error
+
+	// DeleteExpired удаляет не более limit записей, истёкших до before, и возвращает количество удалённых.
+	DeleteExpired(ctx context.Context, before time.Time, limit int) (int64, error)
 }
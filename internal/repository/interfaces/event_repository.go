@@ -0,0 +1,23 @@
+package interfaces
+
+import (
+	"context"
+
+	domain "workout-app/internal/domain/event"
+)
+
+// EventRepository определяет контракт журнала доменных событий и контрольных точек доставки.
+type EventRepository interface {
+	// Append добавляет событие в журнал и заполняет его ID.
+	Append(ctx context.Context, ev *domain.Event) error
+
+	// ListAfter возвращает до limit событий с ID больше afterID (и не больше toID, если toID > 0)
+	// по возрастанию ID. Пустой types означает все типы.
+	ListAfter(ctx context.Context, afterID, toID int64, types []string, limit int) ([]domain.Event, error)
+
+	// GetCheckpoint возвращает ID последнего обработанного события для контрольной точки (0, если её нет).
+	GetCheckpoint(ctx context.Context, name string) (int64, error)
+
+	// SaveCheckpoint сохраняет ID последнего обработанного события для контрольной точки.
+	SaveCheckpoint(ctx context.Context, name string, lastEventID int64) error
+}
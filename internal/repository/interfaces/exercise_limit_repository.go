@@ -0,0 +1,13 @@
+package interfaces
+
+import (
+	"context"
+
+	domain "workout-app/internal/domain/workout"
+)
+
+// ExerciseLimitRepository определяет контракт чтения справочника правдоподобных границ упражнений.
+type ExerciseLimitRepository interface {
+	// List возвращает границы всех упражнений справочника.
+	List(ctx context.Context) ([]domain.ExerciseLimits, error)
+}
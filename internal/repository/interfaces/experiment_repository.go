@@ -0,0 +1,32 @@
+package interfaces
+
+import (
+	"context"
+	"errors"
+
+	domain "workout-app/internal/domain/experiment"
+)
+
+// ErrExperimentExists возвращается, когда эксперимент с таким ключом уже существует.
+var ErrExperimentExists = errors.New("experiment already exists")
+
+// ExperimentRepository определяет контракт для работы с экспериментами и их событиями.
+type ExperimentRepository interface {
+	// Create создает новый эксперимент.
+	// Возвращает ErrExperimentExists, если ключ уже занят.
+	Create(ctx context.Context, e *domain.Experiment) error
+
+	// Update обновляет описание, варианты и признак активности эксперимента.
+	// Возвращает ErrNotFound, если эксперимента нет.
+	Update(ctx context.Context, e *domain.Experiment) error
+
+	// GetByKey возвращает эксперимент по ключу.
+	// Возвращает (nil, ErrNotFound), если эксперимента нет.
+	GetByKey(ctx context.Context, key string) (*domain.Experiment, error)
+
+	// List возвращает все эксперименты; при activeOnly=true — только активные.
+	List(ctx context.Context, activeOnly bool) ([]*domain.Experiment, error)
+
+	// CreateEvent сохраняет событие показа или конверсии.
+	CreateEvent(ctx context.Context, ev *domain.Event) error
+}
@@ -0,0 +1,18 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	domain "workout-app/internal/domain/social"
+)
+
+// FeedRepository определяет контракт для ленты активности. Лента собирается при чтении
+// (fan-out-on-read): тренировки выбираются по текущим подпискам читателя, без раскладки по лентам при записи.
+type FeedRepository interface {
+	// ListWorkouts возвращает завершённые тренировки с подходами пользователей, на которых подписан viewerID
+	// и которые открыли тренировки всем или подписчикам, вместе с авторами (новые по времени завершения первыми),
+	// и их общее количество. Удалённые и обезличенные пользователи в ленту не попадают. Рекорды не заполняются.
+	ListWorkouts(ctx context.Context, viewerID uuid.UUID, limit, offset int) ([]*domain.FeedEntry, int64, error)
+}
@@ -0,0 +1,32 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	domain "workout-app/internal/domain/social"
+)
+
+// FollowRepository определяет контракт для подписок пользователей друг на друга.
+type FollowRepository interface {
+	// Create сохраняет подписку. Повторная подписка не считается ошибкой и не меняет время подписки.
+	Create(ctx context.Context, f *domain.Follow) error
+
+	// Delete удаляет подписку followerID на followeeID. Отсутствие подписки не считается ошибкой.
+	Delete(ctx context.Context, followerID, followeeID uuid.UUID) error
+
+	// Exists возвращает true, если followerID подписан на followeeID.
+	Exists(ctx context.Context, followerID, followeeID uuid.UUID) (bool, error)
+
+	// ListFollowers возвращает подписчиков пользователя (новые подписки первыми) и их общее количество.
+	// Удалённые и обезличенные пользователи в список не попадают.
+	ListFollowers(ctx context.Context, userID uuid.UUID, limit, offset int) ([]*domain.Connection, int64, error)
+
+	// ListFollowing возвращает пользователей, на которых подписан userID (новые подписки первыми),
+	// и их общее количество. Удалённые и обезличенные пользователи в список не попадают.
+	ListFollowing(ctx context.Context, userID uuid.UUID, limit, offset int) ([]*domain.Connection, int64, error)
+
+	// DeleteByUserID удаляет подписки пользователя и подписки на него.
+	DeleteByUserID(ctx context.Context, userID uuid.UUID) error
+}
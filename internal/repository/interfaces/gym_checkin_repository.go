@@ -0,0 +1,44 @@
+package interfaces
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	domain "workout-app/internal/domain/gymcheckin"
+)
+
+// GymCheckInRepository определяет контракт для залов организаций и отметок участников в них.
+type GymCheckInRepository interface {
+	// CreateLocation сохраняет зал.
+	CreateLocation(ctx context.Context, l *domain.Location) error
+
+	// GetLocation возвращает зал.
+	// Возвращает (nil, ErrNotFound), если зала нет.
+	GetLocation(ctx context.Context, id uuid.UUID) (*domain.Location, error)
+
+	// ListLocations возвращает залы организации, отсортированные по названию.
+	ListLocations(ctx context.Context, orgID uuid.UUID) ([]*domain.Location, error)
+
+	// DeleteLocation удаляет зал вместе с отметками.
+	// Возвращает ErrNotFound, если зала нет.
+	DeleteLocation(ctx context.Context, id uuid.UUID) error
+
+	// CreateVisit сохраняет отметку в зале.
+	CreateVisit(ctx context.Context, v *domain.Visit) error
+
+	// LastVisit возвращает последнюю отметку пользователя в зале.
+	// Возвращает (nil, ErrNotFound), если отметок нет.
+	LastVisit(ctx context.Context, userID, locationID uuid.UUID) (*domain.Visit, error)
+
+	// ListVisitsByUser возвращает отметки пользователя за [from, to), новые первыми.
+	ListVisitsByUser(ctx context.Context, userID uuid.UUID, from, to time.Time) ([]*domain.Visit, error)
+
+	// ListVisitsByOrganization возвращает отметки в залах организации за [from, to) по времени;
+	// locationID != nil ограничивает выборку одним залом.
+	ListVisitsByOrganization(ctx context.Context, orgID uuid.UUID, locationID *uuid.UUID, from, to time.Time) ([]*domain.Visit, error)
+
+	// DeleteVisitsByUserID удаляет отметки пользователя (при обезличивании).
+	DeleteVisitsByUserID(ctx context.Context, userID uuid.UUID) error
+}
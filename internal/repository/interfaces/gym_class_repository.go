@@ -0,0 +1,78 @@
+package interfaces
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+
+	domain "workout-app/internal/domain/gymclass"
+)
+
+// Ошибки репозитория групповых занятий.
+var (
+	// ErrSlotExists возвращается при добавлении слота на время, которое у занятия уже есть.
+	ErrSlotExists = errors.New("class slot already exists")
+	// ErrAlreadyBooked возвращается, если у пользователя уже есть активная запись на занятие.
+	ErrAlreadyBooked = errors.New("user already booked the class")
+)
+
+// GymClassRepository определяет контракт для групповых занятий организаций, их расписания и записей.
+type GymClassRepository interface {
+	// Create сохраняет занятие.
+	Create(ctx context.Context, c *domain.Class) error
+
+	// Update сохраняет изменения занятия.
+	// Возвращает ErrNotFound, если занятия нет.
+	Update(ctx context.Context, c *domain.Class) error
+
+	// GetByID возвращает занятие.
+	// Возвращает (nil, ErrNotFound), если занятия нет.
+	GetByID(ctx context.Context, id uuid.UUID) (*domain.Class, error)
+
+	// GetByIDForUpdate возвращает занятие, блокируя его строку до конца транзакции.
+	// Записи на занятие выполняются под этой блокировкой, чтобы не превысить вместимость.
+	GetByIDForUpdate(ctx context.Context, id uuid.UUID) (*domain.Class, error)
+
+	// ListByOrganization возвращает занятия организации, отсортированные по названию.
+	ListByOrganization(ctx context.Context, orgID uuid.UUID) ([]*domain.Class, error)
+
+	// Delete удаляет занятие вместе со слотами и записями.
+	// Возвращает ErrNotFound, если занятия нет.
+	Delete(ctx context.Context, id uuid.UUID) error
+
+	// AddSlot добавляет еженедельный слот занятия.
+	// Возвращает ErrSlotExists, если у занятия уже есть слот на это время.
+	AddSlot(ctx context.Context, s *domain.Slot) error
+
+	// ListSlots возвращает слоты занятий по дню недели и времени.
+	ListSlots(ctx context.Context, classIDs []uuid.UUID) ([]*domain.Slot, error)
+
+	// DeleteSlot удаляет слот занятия. Записи на уже прошедшие занятия остаются.
+	// Возвращает ErrNotFound, если слота нет.
+	DeleteSlot(ctx context.Context, classID, slotID uuid.UUID) error
+
+	// CreateBooking сохраняет запись на занятие.
+	// Возвращает ErrAlreadyBooked, если у пользователя уже есть активная запись на это занятие.
+	CreateBooking(ctx context.Context, b *domain.Booking) error
+
+	// UpdateBooking сохраняет статус и отметку о посещении записи.
+	UpdateBooking(ctx context.Context, b *domain.Booking) error
+
+	// GetBooking возвращает запись.
+	// Возвращает (nil, ErrNotFound), если записи нет.
+	GetBooking(ctx context.Context, id uuid.UUID) (*domain.Booking, error)
+
+	// ListOccurrenceBookings возвращает активные записи на занятие в порядке записи.
+	ListOccurrenceBookings(ctx context.Context, classID uuid.UUID, startsAt time.Time) ([]*domain.Booking, error)
+
+	// ListActiveBookings возвращает активные записи на занятия classIDs, начинающиеся в [from, to).
+	ListActiveBookings(ctx context.Context, classIDs []uuid.UUID, from, to time.Time) ([]*domain.Booking, error)
+
+	// ListBookingsByUser возвращает записи пользователя на занятия, начинающиеся в [from, to), по времени начала.
+	ListBookingsByUser(ctx context.Context, userID uuid.UUID, from, to time.Time) ([]*domain.Booking, error)
+
+	// DeleteBookingsByUserID удаляет записи пользователя (при обезличивании).
+	DeleteBookingsByUserID(ctx context.Context, userID uuid.UUID) error
+}
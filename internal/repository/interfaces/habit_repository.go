@@ -0,0 +1,33 @@
+package interfaces
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	domain "workout-app/internal/domain/habit"
+)
+
+// HabitRepository определяет контракт хранения ежедневных привычек.
+type HabitRepository interface {
+	// Upsert сохраняет привычки дня, заменяя прежнюю запись того же дня.
+	Upsert(ctx context.Context, d *domain.Day) error
+
+	// AddSteps прибавляет шаги к записи дня, создавая её при отсутствии.
+	AddSteps(ctx context.Context, userID uuid.UUID, date time.Time, steps int, at time.Time) error
+
+	// LowerRestingHR записывает пульс покоя дня, если он ниже сохранённого или тот не заполнен;
+	// создаёт запись дня при отсутствии.
+	LowerRestingHR(ctx context.Context, userID uuid.UUID, date time.Time, bpm int, at time.Time) error
+
+	// ListByPeriod возвращает записи пользователя за дни [from, to] по возрастанию даты.
+	ListByPeriod(ctx context.Context, userID uuid.UUID, from, to time.Time) ([]*domain.Day, error)
+
+	// Delete удаляет запись дня.
+	// Возвращает ErrNotFound, если записи нет.
+	Delete(ctx context.Context, userID uuid.UUID, date time.Time) error
+
+	// DeleteByUserID удаляет привычки пользователя (при обезличивании).
+	DeleteByUserID(ctx context.Context, userID uuid.UUID) error
+}
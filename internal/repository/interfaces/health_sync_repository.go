@@ -0,0 +1,32 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	domain "workout-app/internal/domain/healthsync"
+)
+
+// HealthSyncRepository определяет контракт хранения состояния синхронизации
+// с Apple Health и Google Fit и связей импортированных записей.
+type HealthSyncRepository interface {
+	// LockConnection возвращает состояние синхронизации с источником, создавая его при отсутствии,
+	// и блокирует его до конца транзакции: синхронизации одного источника не выполняются параллельно.
+	LockConnection(ctx context.Context, c *domain.Connection) (*domain.Connection, error)
+
+	// UpdateConnection сохраняет счётчик, курсор и время синхронизации.
+	UpdateConnection(ctx context.Context, c *domain.Connection) error
+
+	// ListConnections возвращает источники, с которыми синхронизировался пользователь.
+	ListConnections(ctx context.Context, userID uuid.UUID) ([]*domain.Connection, error)
+
+	// LinkedIDs возвращает внешние ID из externalIDs, уже импортированные из источника.
+	LinkedIDs(ctx context.Context, userID uuid.UUID, provider domain.Provider, externalIDs []string) ([]string, error)
+
+	// CreateLinks сохраняет связи импортированных записей.
+	CreateLinks(ctx context.Context, links []*domain.Link) error
+
+	// DeleteByUserID удаляет состояние синхронизации и связи пользователя (при обезличивании).
+	DeleteByUserID(ctx context.Context, userID uuid.UUID) error
+}
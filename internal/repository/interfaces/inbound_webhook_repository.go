@@ -0,0 +1,33 @@
+package interfaces
+
+import (
+	"context"
+	"time"
+
+	domain "workout-app/internal/domain/inboundwebhook"
+)
+
+// InboundWebhookRepository определяет контракт хранения входящих событий webhooks провайдеров.
+type InboundWebhookRepository interface {
+	// Create сохраняет событие и проставляет ему ID.
+	// Если событие провайдера с тем же внешним ID уже есть, ничего не делает и возвращает false.
+	Create(ctx context.Context, e *domain.Event) (bool, error)
+
+	// ListDue возвращает до limit событий в очереди, время попытки которых наступило к now, старые первыми.
+	ListDue(ctx context.Context, now time.Time, limit int) ([]*domain.Event, error)
+
+	// Claim захватывает обработку события до момента until, сдвигая время попытки.
+	// Успешен, если событие в очереди и время его попытки наступило к now.
+	Claim(ctx context.Context, id int64, now, until time.Time) (bool, error)
+
+	// Save сохраняет результат попытки: статус, счётчик попыток, время следующей попытки и ошибку.
+	// Возвращает ErrNotFound, если события нет.
+	Save(ctx context.Context, e *domain.Event) error
+
+	// CountPending возвращает количество событий в очереди (ожидающих обработки или повторной попытки).
+	CountPending(ctx context.Context) (int64, error)
+
+	// DeleteFinished удаляет не более limit обработанных и dead-letter событий, завершённых до before,
+	// и возвращает количество удалённых.
+	DeleteFinished(ctx context.Context, before time.Time, limit int) (int64, error)
+}
@@ -0,0 +1,19 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	domain "workout-app/internal/domain/user"
+)
+
+// LegalHoldAuditRepository определяет контракт журнала аудита юридических удержаний.
+// Журнал только дополняется: записи не изменяются и не удаляются.
+type LegalHoldAuditRepository interface {
+	// Create добавляет запись в журнал и заполняет её ID и CreatedAt.
+	Create(ctx context.Context, rec *domain.LegalHoldRecord) error
+
+	// ListByUser возвращает записи журнала по пользователю в хронологическом порядке.
+	ListByUser(ctx context.Context, userID uuid.UUID) ([]*domain.LegalHoldRecord, error)
+}
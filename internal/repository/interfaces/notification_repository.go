@@ -0,0 +1,50 @@
+package interfaces
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	domain "workout-app/internal/domain/notification"
+)
+
+// NotificationRepository определяет контракт для уведомлений в приложении и настроек их копий.
+type NotificationRepository interface {
+	// Create сохраняет уведомление. Возвращает false без ошибки, если уведомление
+	// по этому событию для пользователя уже есть (повторная доставка события).
+	Create(ctx context.Context, n *domain.Notification) (bool, error)
+
+	// List возвращает уведомления пользователя, новые первыми, и их общее количество.
+	// unreadOnly ограничивает выборку непрочитанными.
+	List(ctx context.Context, userID uuid.UUID, unreadOnly bool, limit, offset int) ([]*domain.Notification, int64, error)
+
+	// CountUnread возвращает количество непрочитанных уведомлений пользователя.
+	CountUnread(ctx context.Context, userID uuid.UUID) (int64, error)
+
+	// MarkRead отмечает уведомление пользователя прочитанным; уже прочитанное не меняется.
+	// Возвращает ErrNotFound, если уведомления нет.
+	MarkRead(ctx context.Context, userID, id uuid.UUID, at time.Time) error
+
+	// MarkAllRead отмечает прочитанными все уведомления пользователя.
+	MarkAllRead(ctx context.Context, userID uuid.UUID, at time.Time) error
+
+	// ListPushPending возвращает уведомления, копии которых ждут отправки на устройства, старые первыми.
+	ListPushPending(ctx context.Context, limit int) ([]*domain.Notification, error)
+
+	// ClaimPush снимает отметку ожидания push. Возвращает false, если её уже снял другой инстанс:
+	// копия отправляется не более одного раза.
+	ClaimPush(ctx context.Context, id uuid.UUID) (bool, error)
+
+	// ExpirePush снимает отметку ожидания push с уведомлений, созданных раньше before, и возвращает их количество.
+	ExpirePush(ctx context.Context, before time.Time) (int64, error)
+
+	// GetPreferences возвращает сохранённые настройки пользователя; типов без настройки в ответе нет.
+	GetPreferences(ctx context.Context, userID uuid.UUID) ([]domain.Preference, error)
+
+	// SetPreferences сохраняет настройки пользователя для переданных типов.
+	SetPreferences(ctx context.Context, userID uuid.UUID, prefs []domain.Preference) error
+
+	// DeleteByUserID удаляет уведомления и настройки пользователя.
+	DeleteByUserID(ctx context.Context, userID uuid.UUID) error
+}
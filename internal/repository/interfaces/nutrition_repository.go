@@ -0,0 +1,37 @@
+package interfaces
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	domain "workout-app/internal/domain/nutrition"
+)
+
+// NutritionRepository определяет контракт хранения дневника питания.
+type NutritionRepository interface {
+	// Create сохраняет запись дневника.
+	Create(ctx context.Context, e *domain.Entry) error
+
+	// Update сохраняет изменения записи.
+	// Возвращает ErrNotFound, если записи нет.
+	Update(ctx context.Context, e *domain.Entry) error
+
+	// GetByID возвращает запись пользователя.
+	// Возвращает ErrNotFound, если записи нет или она принадлежит другому пользователю.
+	GetByID(ctx context.Context, userID, id uuid.UUID) (*domain.Entry, error)
+
+	// ListByDate возвращает записи пользователя за день по приёмам пищи, в порядке добавления.
+	ListByDate(ctx context.Context, userID uuid.UUID, date time.Time) ([]*domain.Entry, error)
+
+	// DailyTotals возвращает итоги дней [from, to] по возрастанию даты; дни без записей пропускаются.
+	DailyTotals(ctx context.Context, userID uuid.UUID, from, to time.Time) ([]*domain.DayTotals, error)
+
+	// Delete удаляет запись пользователя.
+	// Возвращает ErrNotFound, если записи нет.
+	Delete(ctx context.Context, userID, id uuid.UUID) error
+
+	// DeleteByUserID удаляет дневник пользователя (при обезличивании).
+	DeleteByUserID(ctx context.Context, userID uuid.UUID) error
+}
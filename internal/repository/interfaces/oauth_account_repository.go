@@ -0,0 +1,32 @@
+package interfaces
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+
+	domain "workout-app/internal/domain/user"
+)
+
+// ErrOAuthAccountLinked возвращается, если аккаунт провайдера уже привязан
+// или у пользователя уже есть привязка этого провайдера.
+var ErrOAuthAccountLinked = errors.New("oauth account already linked")
+
+// OAuthAccountRepository определяет контракт хранения привязок аккаунтов внешних провайдеров.
+type OAuthAccountRepository interface {
+	// Create сохраняет привязку.
+	// Возвращает ErrOAuthAccountLinked, если привязка с тем же провайдером уже существует.
+	Create(ctx context.Context, a *domain.OAuthAccount) error
+
+	// GetByProviderSubject возвращает привязку по провайдеру и ID пользователя у провайдера.
+	// Возвращает ErrNotFound, если привязки нет.
+	GetByProviderSubject(ctx context.Context, provider domain.OAuthProvider, subject string) (*domain.OAuthAccount, error)
+
+	// TouchLogin обновляет время последнего входа через привязку.
+	TouchLogin(ctx context.Context, id uuid.UUID, at time.Time) error
+
+	// DeleteByUserID удаляет все привязки пользователя (при обезличивании).
+	DeleteByUserID(ctx context.Context, userID uuid.UUID) error
+}
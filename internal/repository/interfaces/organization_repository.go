@@ -0,0 +1,86 @@
+package interfaces
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+
+	domain "workout-app/internal/domain/organization"
+)
+
+// ErrMemberExists возвращается при повторном добавлении пользователя в организацию.
+var ErrMemberExists = errors.New("user is already a member of the organization")
+
+// ErrInvitationExists возвращается, если у пользователя уже есть действующее приглашение в организацию.
+var ErrInvitationExists = errors.New("user already has a pending invitation to the organization")
+
+// OrganizationRepository определяет контракт для хранения организаций и их участников.
+type OrganizationRepository interface {
+	// Create создаёт организацию.
+	Create(ctx context.Context, org *domain.Organization) error
+
+	// GetByID возвращает организацию.
+	// Возвращает ErrNotFound, если организации нет.
+	GetByID(ctx context.Context, id uuid.UUID) (*domain.Organization, error)
+
+	// AddMember добавляет пользователя в организацию.
+	// Возвращает ErrMemberExists, если пользователь уже состоит в ней.
+	AddMember(ctx context.Context, m *domain.Member) error
+
+	// GetMember возвращает членство пользователя в организации.
+	// Возвращает ErrNotFound, если пользователь в ней не состоит.
+	GetMember(ctx context.Context, orgID, userID uuid.UUID) (*domain.Member, error)
+
+	// RemoveMember исключает пользователя из организации.
+	// Возвращает ErrNotFound, если пользователь в ней не состоит.
+	RemoveMember(ctx context.Context, orgID, userID uuid.UUID) error
+
+	// ListByUser возвращает организации пользователя с его ролью в каждой (в порядке вступления).
+	ListByUser(ctx context.Context, userID uuid.UUID) ([]*domain.Organization, []*domain.Member, error)
+
+	// UpdateMemberRole меняет роль участника организации.
+	// Возвращает ErrNotFound, если пользователь в ней не состоит.
+	UpdateMemberRole(ctx context.Context, orgID, userID uuid.UUID, role domain.Role) error
+
+	// ListMembers возвращает страницу участников организации: сначала команда (владелец, менеджеры,
+	// сотрудники), затем участники, внутри роли — в порядке вступления; и общее количество участников.
+	ListMembers(ctx context.Context, orgID uuid.UUID, limit, offset int) ([]*domain.Member, int64, error)
+
+	// CreateInvitation сохраняет приглашение, заменяя истёкшее приглашение того же пользователя.
+	// Возвращает ErrInvitationExists, если действующее приглашение уже есть.
+	CreateInvitation(ctx context.Context, inv *domain.Invitation) error
+
+	// GetInvitation возвращает приглашение по ID.
+	// Возвращает ErrNotFound, если приглашения нет.
+	GetInvitation(ctx context.Context, id uuid.UUID) (*domain.Invitation, error)
+
+	// ListInvitationsByUser возвращает действующие на момент now приглашения пользователя, новые первыми,
+	// вместе с организациями.
+	ListInvitationsByUser(ctx context.Context, userID uuid.UUID, now time.Time) ([]*domain.Invitation, []*domain.Organization, error)
+
+	// DeleteInvitation удаляет приглашение.
+	// Возвращает ErrNotFound, если приглашения нет.
+	DeleteInvitation(ctx context.Context, id uuid.UUID) error
+}
+
+// OrganizationEmailSettingsRepository определяет контракт для настроек отправки писем организаций.
+// Реализация отвечает за шифрование секретов при хранении.
+type OrganizationEmailSettingsRepository interface {
+	// Get возвращает настройки организации.
+	// Возвращает ErrNotFound, если организация использует платформенную отправку.
+	Get(ctx context.Context, orgID uuid.UUID) (*domain.EmailSettings, error)
+
+	// GetForRecipient возвращает настройки организации, в которой состоит пользователь с этим email.
+	// Если пользователь состоит в нескольких организациях с настройками, выбирается та, куда он вступил раньше.
+	// Возвращает ErrNotFound, если письмо отправляется платформой.
+	GetForRecipient(ctx context.Context, email string) (*domain.EmailSettings, error)
+
+	// Upsert создаёт или заменяет настройки организации.
+	Upsert(ctx context.Context, s *domain.EmailSettings) error
+
+	// Delete удаляет настройки, возвращая организацию к платформенной отправке.
+	// Возвращает ErrNotFound, если настроек нет.
+	Delete(ctx context.Context, orgID uuid.UUID) error
+}
@@ -0,0 +1,21 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	domain "workout-app/internal/domain/user"
+)
+
+// ProfileHistoryRepository определяет контракт хранения истории изменений профиля.
+type ProfileHistoryRepository interface {
+	// Create сохраняет запись об изменении профиля.
+	Create(ctx context.Context, c *domain.ProfileChange) error
+
+	// ListByUser возвращает изменения профиля пользователя, новые первыми, и их общее количество.
+	ListByUser(ctx context.Context, userID uuid.UUID, limit, offset int) ([]*domain.ProfileChange, int64, error)
+
+	// DeleteByUserID удаляет историю пользователя (при обезличивании).
+	DeleteByUserID(ctx context.Context, userID uuid.UUID) error
+}
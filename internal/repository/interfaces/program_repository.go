@@ -0,0 +1,55 @@
+package interfaces
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+
+	domain "workout-app/internal/domain/program"
+)
+
+// ErrProgramAlreadyAssigned возвращается, когда программа уже назначена пользователю.
+var ErrProgramAlreadyAssigned = errors.New("program already assigned to user")
+
+// ProgramRepository определяет контракт для работы с тренировочными программами и их назначениями.
+type ProgramRepository interface {
+	// Create сохраняет программу вместе со всеми неделями, днями и тренировками в одной транзакции.
+	Create(ctx context.Context, p *domain.Program) error
+
+	// GetByID возвращает программу с полной структурой.
+	// Возвращает (nil, ErrNotFound), если программы нет.
+	GetByID(ctx context.Context, id uuid.UUID) (*domain.Program, error)
+
+	// ListByOwner возвращает программы автора, новые первыми.
+	ListByOwner(ctx context.Context, ownerID uuid.UUID) ([]*domain.Program, error)
+
+	// CreateAssignment назначает программу пользователю.
+	// Возвращает ErrProgramAlreadyAssigned, если программа уже назначена этому пользователю.
+	CreateAssignment(ctx context.Context, a *domain.Assignment) error
+
+	// IsAssigned сообщает, назначена ли программа пользователю.
+	IsAssigned(ctx context.Context, programID, userID uuid.UUID) (bool, error)
+
+	// GetAssignmentByID возвращает назначение по ID.
+	// Возвращает (nil, ErrNotFound), если назначения нет.
+	GetAssignmentByID(ctx context.Context, id uuid.UUID) (*domain.Assignment, error)
+
+	// ListAssignmentsByUser возвращает назначения пользователя, начиная с самых поздних.
+	ListAssignmentsByUser(ctx context.Context, userID uuid.UUID) ([]*domain.Assignment, error)
+
+	// DeleteAssignmentsByUserID удаляет назначения программ пользователю (при обезличивании).
+	// Программы, автором которых он является, и назначения, сделанные им клиентам, не затрагиваются.
+	DeleteAssignmentsByUserID(ctx context.Context, userID uuid.UUID) error
+
+	// DifficultyStats возвращает оценки сложности завершённых тренировок по назначениям программ.
+	// Программы без оценок в результат не попадают.
+	DifficultyStats(ctx context.Context, programIDs []uuid.UUID) (map[uuid.UUID]domain.DifficultyStats, error)
+
+	// RecentDifficulty возвращает оценки сложности последних limit оценённых тренировок назначения.
+	RecentDifficulty(ctx context.Context, assignmentID uuid.UUID, limit int) (domain.DifficultyStats, error)
+
+	// CompletedSessions возвращает число завершённых тренировок по назначениям.
+	// Назначения без завершённых тренировок в результат не попадают.
+	CompletedSessions(ctx context.Context, assignmentIDs []uuid.UUID) (map[uuid.UUID]int, error)
+}
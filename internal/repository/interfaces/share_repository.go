@@ -0,0 +1,30 @@
+package interfaces
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	domain "workout-app/internal/domain/share"
+)
+
+// ShareRepository определяет контракт хранения публичных ссылок на тренировки и программы.
+type ShareRepository interface {
+	// Create сохраняет ссылку.
+	Create(ctx context.Context, s *domain.Share) error
+
+	// GetByTokenHash возвращает ссылку по хэшу токена, в том числе отозванную.
+	// Возвращает (nil, ErrNotFound), если ссылки нет.
+	GetByTokenHash(ctx context.Context, tokenHash string) (*domain.Share, error)
+
+	// ListActiveByOwner возвращает неотозванные ссылки владельца, новые первыми.
+	ListActiveByOwner(ctx context.Context, ownerID uuid.UUID) ([]*domain.Share, error)
+
+	// CountActiveByOwner возвращает количество неотозванных ссылок владельца.
+	CountActiveByOwner(ctx context.Context, ownerID uuid.UUID) (int, error)
+
+	// Revoke отзывает неотозванную ссылку владельца.
+	// Возвращает ErrNotFound, если такой ссылки нет или она уже отозвана.
+	Revoke(ctx context.Context, id, ownerID uuid.UUID, at time.Time) error
+}
@@ -0,0 +1,22 @@
+package interfaces
+
+import (
+	"context"
+	"errors"
+
+	domain "workout-app/internal/domain/strength"
+)
+
+// ErrAliasTaken возвращается, если синоним уже закреплён за другим упражнением.
+var ErrAliasTaken = errors.New("exercise alias already belongs to another exercise")
+
+// StrengthStandardRepository определяет контракт хранения нормативов силы.
+type StrengthStandardRepository interface {
+	// List возвращает все нормативы со строками по возрастанию веса тела и синонимами упражнений.
+	List(ctx context.Context) ([]*domain.Standard, error)
+
+	// Replace заменяет строки норматива упражнения для пола; синонимы упражнения заменяются,
+	// если s.Aliases не nil.
+	// Возвращает ErrAliasTaken, если синоним закреплён за другим упражнением.
+	Replace(ctx context.Context, s *domain.Standard) error
+}
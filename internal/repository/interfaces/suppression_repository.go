@@ -0,0 +1,33 @@
+package interfaces
+
+import (
+	"context"
+
+	domain "workout-app/internal/domain/deliverability"
+)
+
+// SuppressionFilter задаёт выборку записей списка подавления.
+type SuppressionFilter struct {
+	Source domain.SuppressionSource // Пустое значение — все источники
+	Limit  int
+	Offset int
+}
+
+// SuppressionRepository определяет контракт для глобального списка подавления писем.
+type SuppressionRepository interface {
+	// Add добавляет адрес в список. Если адрес уже в списке, запись не меняется и возвращается false.
+	Add(ctx context.Context, s *domain.Suppression) (bool, error)
+
+	// Remove удаляет адрес из списка.
+	// Возвращает ErrNotFound, если адреса в списке нет.
+	Remove(ctx context.Context, email string) error
+
+	// Exists сообщает, находится ли адрес в списке.
+	Exists(ctx context.Context, email string) (bool, error)
+
+	// List возвращает записи по фильтру (новые первыми) и общее количество подходящих записей.
+	List(ctx context.Context, filter SuppressionFilter) ([]*domain.Suppression, int64, error)
+
+	// Count возвращает размер списка.
+	Count(ctx context.Context) (int64, error)
+}
@@ -0,0 +1,25 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	domain "workout-app/internal/domain/program"
+)
+
+// TrainingMaxRepository определяет контракт для работы с тренировочными максимумами пользователей.
+type TrainingMaxRepository interface {
+	// Upsert сохраняет максимум пользователя в упражнении, заменяя прежнее значение.
+	Upsert(ctx context.Context, tm *domain.TrainingMax) error
+
+	// ListByUser возвращает максимумы пользователя, отсортированные по упражнению.
+	ListByUser(ctx context.Context, userID uuid.UUID) ([]*domain.TrainingMax, error)
+
+	// Delete удаляет максимум пользователя в упражнении.
+	// Возвращает ErrNotFound, если максимума нет.
+	Delete(ctx context.Context, userID uuid.UUID, exercise string) error
+
+	// DeleteByUserID удаляет все максимумы пользователя (при обезличивании).
+	DeleteByUserID(ctx context.Context, userID uuid.UUID) error
+}
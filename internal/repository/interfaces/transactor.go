@@ -0,0 +1,49 @@
+package interfaces
+
+import "context"
+
+// Transactor выполняет функцию в рамках одной транзакции БД.
+// Репозитории, вызванные с контекстом из fn, работают внутри этой транзакции.
+type Transactor interface {
+	// WithinTransaction открывает транзакцию, вызывает fn и фиксирует её,
+	// если fn вернула nil, иначе откатывает. Вложенные вызовы используют точки сохранения.
+	// Действия, отложенные через AfterCommit, выполняются после фиксации внешней транзакции.
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+// afterCommitKey — ключ контекста, под которым хранятся действия, отложенные до фиксации транзакции.
+type afterCommitKey struct{}
+
+// afterCommitHooks собирает действия одной транзакции; parent — действия внешней транзакции.
+type afterCommitHooks struct {
+	parent *afterCommitHooks
+	fns    []func()
+}
+
+// AfterCommit откладывает fn до фиксации транзакции из контекста (например, сброс кеша, который
+// иначе успел бы заполниться данными до COMMIT). При откате fn не вызывается.
+// Вне транзакции fn выполняется сразу.
+func AfterCommit(ctx context.Context, fn func()) {
+	if hooks, ok := ctx.Value(afterCommitKey{}).(*afterCommitHooks); ok {
+		hooks.fns = append(hooks.fns, fn)
+		return
+	}
+	fn()
+}
+
+// WithAfterCommit возвращает контекст для fn транзакции и функцию, которую реализация Transactor
+// вызывает после успешной фиксации. Для вложенной транзакции отложенные действия переходят
+// во внешнюю и выполняются после её фиксации.
+func WithAfterCommit(ctx context.Context) (context.Context, func()) {
+	parent, _ := ctx.Value(afterCommitKey{}).(*afterCommitHooks)
+	hooks := &afterCommitHooks{parent: parent}
+	return context.WithValue(ctx, afterCommitKey{}, hooks), func() {
+		if hooks.parent != nil {
+			hooks.parent.fns = append(hooks.parent.fns, hooks.fns...)
+			return
+		}
+		for _, fn := range hooks.fns {
+			fn()
+		}
+	}
+}
@@ -3,6 +3,7 @@ package interfaces
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/google/uuid"
 
@@ -18,6 +19,9 @@ var ErrEmailExists = errors.New("email already exists")
 // ErrUsernameExists возвращается, когда пользователь с таким username уже существует.
 var ErrUsernameExists = errors.New("username already exists")
 
+// ErrLastAdmin возвращается при попытке снять роль admin с последнего администратора.
+var ErrLastAdmin = errors.New("cannot demote the last admin")
+
 // UserRepository определяет контракт для работы с пользователями на уровне хранилища.
 //
 // Интерфейс оперирует доменной моделью User и не раскрывает деталей реализации (GORM, SQL и т.п.).
@@ -31,6 +35,10 @@ type UserRepository interface {
 	// Возвращает (nil, ErrNotFound), если пользователь не найден или мягко удалён.
 	GetByID(ctx context.Context, id uuid.UUID) (*domain.User, error)
 
+	// GetByIDIncludingDeleted возвращает пользователя по идентификатору, в том числе мягко удалённого.
+	// Возвращает (nil, ErrNotFound), если пользователя нет.
+	GetByIDIncludingDeleted(ctx context.Context, id uuid.UUID) (*domain.User, error)
+
 	// GetByEmail возвращает пользователя по email.
 	// Возвращает (nil, ErrNotFound), если пользователь не найден или мягко удалён.
 	GetByEmail(ctx context.Context, email string) (*domain.User, error)
@@ -39,14 +47,66 @@ type UserRepository interface {
 	// Возвращает (nil, ErrNotFound), если пользователь не найден или мягко удалён.
 	GetByUsername(ctx context.Context, username string) (*domain.User, error)
 
-	// Update обновляет данные пользователя.
-	// Не обновляет защищенные поля: id, created_at, password_hash.
+	// Update обновляет данные профиля пользователя.
+	// Не обновляет защищенные поля: id, created_at, password_hash, role и is_email_verified —
+	// у них отдельные методы (UpdatePassword, UpdateRole, MarkEmailVerified).
 	Update(ctx context.Context, user *domain.User) error
 
+	// MarkEmailVerified отмечает email пользователя подтверждённым.
+	// Возвращает ErrNotFound, если пользователь не найден или мягко удалён.
+	MarkEmailVerified(ctx context.Context, id uuid.UUID) error
+
+	// UpdatePassword обновляет хэш пароля и отзывает токены, выданные раньше tokensValidAfter.
+	// Возвращает ErrNotFound, если пользователь не найден или мягко удалён.
+	UpdatePassword(ctx context.Context, id uuid.UUID, passwordHash string, tokensValidAfter time.Time) error
+
+	// UpdateRole атомарно меняет роль пользователя и возвращает предыдущую роль.
+	// Это единственный способ изменить роль; при понижении выданные токены отзываются.
+	// Возвращает ErrLastAdmin, если изменение оставит систему без администраторов.
+	// Возвращает ErrNotFound, если пользователь не найден или мягко удалён.
+	UpdateRole(ctx context.Context, id uuid.UUID, role domain.Role) (domain.Role, error)
+
+	// SetSuspension устанавливает блокировку пользователя; s == nil снимает блокировку.
+	// Возвращает ErrNotFound, если пользователь не найден или мягко удалён.
+	SetSuspension(ctx context.Context, id uuid.UUID, s *domain.Suspension) error
+
+	// SetLegalHold устанавливает юридическое удержание; h == nil снимает его.
+	// Применимо и к мягко удалённым пользователям.
+	// Возвращает ErrNotFound, если пользователь не найден или уже обезличен.
+	SetLegalHold(ctx context.Context, id uuid.UUID, h *domain.LegalHold) error
+
 	// SoftDelete помечает пользователя как удалённого (soft delete).
 	SoftDelete(ctx context.Context, id uuid.UUID) error
 
+	// Anonymize сохраняет обезличенные данные пользователя, подготовленные domain.User.Anonymize.
+	// Возвращает ErrNotFound, если пользователя нет, он уже обезличен или находится под юридическим удержанием.
+	Anonymize(ctx context.Context, u *domain.User) error
+
+	// Delete окончательно удаляет запись пользователя; связанные записи удаляются каскадно
+	// или теряют ссылку на пользователя согласно внешним ключам.
+	// Возвращает ErrNotFound, если пользователя нет или он находится под юридическим удержанием.
+	Delete(ctx context.Context, id uuid.UUID) error
+
 	// List возвращает всех активных (не удалённых) пользователей.
 	// В первой версии без пагинации; при необходимости можно расширить фильтрами.
 	List(ctx context.Context) ([]*domain.User, error)
+
+	// ListIDsAfter возвращает до limit идентификаторов активных пользователей, больших after, по возрастанию.
+	// Используется для обхода всех пользователей пачками (uuid.Nil — с начала).
+	ListIDsAfter(ctx context.Context, after uuid.UUID, limit int) ([]uuid.UUID, error)
+
+	// ListReminderRecipients возвращает до limit активных пользователей с подтверждённым email
+	// и включёнными напоминаниями о тренировках, с ID больше after, по возрастанию ID.
+	ListReminderRecipients(ctx context.Context, after uuid.UUID, limit int) ([]*domain.User, error)
+
+	// ListWeeklySummaryRecipients возвращает до limit активных пользователей с подтверждённым email,
+	// у которых есть завершённые тренировки, начатые не раньше since, с ID больше after, по возрастанию ID.
+	ListWeeklySummaryRecipients(ctx context.Context, since time.Time, after uuid.UUID, limit int) ([]*domain.User, error)
+
+	// ListPurgeCandidates возвращает до limit идентификаторов пользователей, мягко удалённых раньше before,
+	// ещё не обезличенных и не находящихся под юридическим удержанием, начиная с самых давних.
+	ListPurgeCandidates(ctx context.Context, before time.Time, limit int) ([]uuid.UUID, error)
+
+	// Count возвращает количество активных пользователей.
+	Count(ctx context.Context) (int64, error)
 }
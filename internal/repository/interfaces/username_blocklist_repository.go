@@ -0,0 +1,22 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	domain "workout-app/internal/domain/usernameblock"
+)
+
+// UsernameBlocklistRepository определяет контракт для запрещённых username, управляемых администраторами.
+type UsernameBlocklistRepository interface {
+	// Create добавляет запись. Если слово с тем же способом сопоставления уже есть, запись не меняется и возвращается false.
+	Create(ctx context.Context, e *domain.Entry) (bool, error)
+
+	// Delete удаляет запись.
+	// Возвращает ErrNotFound, если записи нет.
+	Delete(ctx context.Context, id uuid.UUID) error
+
+	// List возвращает все записи, отсортированные по слову.
+	List(ctx context.Context) ([]*domain.Entry, error)
+}
@@ -0,0 +1,30 @@
+package interfaces
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	domain "workout-app/internal/domain/user"
+)
+
+// UsernameHistoryRepository определяет контракт хранения истории смены username.
+type UsernameHistoryRepository interface {
+	// Create сохраняет запись о смене username.
+	Create(ctx context.Context, c *domain.UsernameChange) error
+
+	// ListByUser возвращает до limit последних смен username пользователя, новые первыми.
+	ListByUser(ctx context.Context, userID uuid.UUID, limit int) ([]*domain.UsernameChange, error)
+
+	// IsReserved сообщает, зарезервирован ли username в момент now за пользователем, отличным от exceptUserID
+	// (uuid.Nil — за любым пользователем).
+	IsReserved(ctx context.Context, username string, exceptUserID uuid.UUID, now time.Time) (bool, error)
+
+	// GetLatestByOldUsername возвращает последнюю смену, в которой username был старым именем
+	// активного пользователя. Возвращает ErrNotFound, если такой смены нет.
+	GetLatestByOldUsername(ctx context.Context, username string) (*domain.UsernameChange, error)
+
+	// DeleteByUserID удаляет историю пользователя (при обезличивании).
+	DeleteByUserID(ctx context.Context, userID uuid.UUID) error
+}
@@ -0,0 +1,29 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	domain "workout-app/internal/domain/video"
+)
+
+// VideoRepository определяет контракт хранения метаданных видео упражнений.
+type VideoRepository interface {
+	// Create сохраняет новое видео.
+	Create(ctx context.Context, v *domain.Video) error
+
+	// GetByID возвращает видео по ID.
+	// Возвращает ErrNotFound, если видео нет.
+	GetByID(ctx context.Context, id uuid.UUID) (*domain.Video, error)
+
+	// Update сохраняет изменения видео (ключ, тип, размер, статус).
+	Update(ctx context.Context, v *domain.Video) error
+
+	// Delete удаляет видео.
+	// Возвращает ErrNotFound, если видео нет.
+	Delete(ctx context.Context, id uuid.UUID) error
+
+	// ListByOwner возвращает видео, загруженные пользователем, новые первыми.
+	ListByOwner(ctx context.Context, ownerID uuid.UUID) ([]*domain.Video, error)
+}
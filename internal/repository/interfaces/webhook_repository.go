@@ -0,0 +1,68 @@
+package interfaces
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	domain "workout-app/internal/domain/webhook"
+)
+
+// WebhookEndpointRepository определяет контракт хранения адресов webhooks.
+// Ключи подписи хранятся зашифрованными; репозиторий возвращает их расшифрованными.
+type WebhookEndpointRepository interface {
+	// Create сохраняет новый адрес.
+	Create(ctx context.Context, e *domain.Endpoint) error
+
+	// Update сохраняет изменения адреса.
+	// Возвращает ErrNotFound, если адреса нет.
+	Update(ctx context.Context, e *domain.Endpoint) error
+
+	// Delete удаляет адрес вместе с его уведомлениями.
+	// Возвращает ErrNotFound, если адреса нет.
+	Delete(ctx context.Context, id uuid.UUID) error
+
+	// GetByID возвращает адрес по ID.
+	// Возвращает ErrNotFound, если адреса нет.
+	GetByID(ctx context.Context, id uuid.UUID) (*domain.Endpoint, error)
+
+	// List возвращает все адреса, старые первыми.
+	List(ctx context.Context) ([]*domain.Endpoint, error)
+
+	// ListSubscribed возвращает включённые адреса, подписанные на событие eventType.
+	ListSubscribed(ctx context.Context, eventType string) ([]*domain.Endpoint, error)
+}
+
+// WebhookDeliveryRepository определяет контракт хранения очереди уведомлений webhooks.
+type WebhookDeliveryRepository interface {
+	// Enqueue сохраняет уведомление в очереди и проставляет ему ID.
+	// Если уведомление о событии для адреса уже есть, ничего не делает и возвращает false.
+	Enqueue(ctx context.Context, d *domain.Delivery) (bool, error)
+
+	// ListDue возвращает до limit уведомлений в очереди, время попытки которых наступило к now, старые первыми.
+	ListDue(ctx context.Context, now time.Time, limit int) ([]*domain.Delivery, error)
+
+	// Claim захватывает отправку уведомления до момента until, сдвигая время попытки.
+	// Успешен, если уведомление в очереди и время его попытки наступило к now.
+	Claim(ctx context.Context, id int64, now, until time.Time) (bool, error)
+
+	// Save сохраняет результат попытки: статус, счётчик попыток, время следующей попытки, ошибку и HTTP-статус.
+	// Возвращает ErrNotFound, если уведомления нет.
+	Save(ctx context.Context, d *domain.Delivery) error
+
+	// GetByID возвращает уведомление по ID.
+	// Возвращает ErrNotFound, если уведомления нет.
+	GetByID(ctx context.Context, id int64) (*domain.Delivery, error)
+
+	// List возвращает страницу уведомлений адреса в статусе status (пусто — любом), новые первыми,
+	// и их общее количество.
+	List(ctx context.Context, endpointID uuid.UUID, status domain.Status, limit, offset int) ([]*domain.Delivery, int64, error)
+
+	// CountPending возвращает количество уведомлений в очереди (ожидающих отправки или повторной попытки).
+	CountPending(ctx context.Context) (int64, error)
+
+	// DeleteFinished удаляет не более limit доставленных и dead-letter уведомлений, завершённых до before,
+	// и возвращает количество удалённых.
+	DeleteFinished(ctx context.Context, before time.Time, limit int) (int64, error)
+}
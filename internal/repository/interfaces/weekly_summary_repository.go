@@ -0,0 +1,19 @@
+package interfaces
+
+import (
+	"context"
+	"time"
+
+	domain "workout-app/internal/domain/weeklysummary"
+)
+
+// WeeklySummaryRepository определяет контракт хранения отметок обработанных еженедельных сводок.
+type WeeklySummaryRepository interface {
+	// Claim сохраняет отметку. Возвращает false без ошибки, если за эту неделю отметка уже есть
+	// (сводку обработал другой запуск воркера).
+	Claim(ctx context.Context, s *domain.Summary) (bool, error)
+
+	// DeleteBefore удаляет не более limit отметок за недели, начавшиеся раньше before,
+	// и возвращает количество удалённых.
+	DeleteBefore(ctx context.Context, before time.Time, limit int) (int64, error)
+}
@@ -0,0 +1,18 @@
+package interfaces
+
+import (
+	"context"
+	"time"
+
+	domain "workout-app/internal/domain/reminder"
+)
+
+// WorkoutReminderRepository определяет контракт хранения отметок обработанных напоминаний о тренировках.
+type WorkoutReminderRepository interface {
+	// Claim сохраняет отметку. Возвращает false без ошибки, если за этот день отметка уже есть
+	// (напоминание обработал другой запуск воркера).
+	Claim(ctx context.Context, r *domain.Reminder) (bool, error)
+
+	// DeleteBefore удаляет не более limit отметок за дни раньше before и возвращает количество удалённых.
+	DeleteBefore(ctx context.Context, before time.Time, limit int) (int64, error)
+}
@@ -0,0 +1,50 @@
+package interfaces
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+
+	domain "workout-app/internal/domain/workout"
+)
+
+// ErrActiveSessionExists возвращается при попытке начать тренировку, когда предыдущая не завершена.
+var ErrActiveSessionExists = errors.New("active workout session already exists")
+
+// WorkoutSessionRepository определяет контракт хранения тренировок и подходов.
+type WorkoutSessionRepository interface {
+	// Create сохраняет начатую тренировку.
+	// Возвращает ErrActiveSessionExists, если у пользователя уже идёт тренировка.
+	Create(ctx context.Context, s *domain.Session) error
+
+	// GetByID возвращает тренировку с подходами.
+	// Возвращает ErrNotFound, если тренировки нет.
+	GetByID(ctx context.Context, id uuid.UUID) (*domain.Session, error)
+
+	// GetActive возвращает идущую тренировку пользователя с подходами.
+	// Возвращает ErrNotFound, если активной тренировки нет.
+	GetActive(ctx context.Context, userID uuid.UUID) (*domain.Session, error)
+
+	// AddSet сохраняет подход тренировки.
+	AddSet(ctx context.Context, set *domain.Set) error
+
+	// Finish завершает тренировку в момент at.
+	// Возвращает ErrNotFound, если тренировки нет или она уже завершена.
+	Finish(ctx context.Context, id uuid.UUID, at time.Time) error
+
+	// SetDifficulty сохраняет оценку сложности завершённой тренировки; повторная оценка заменяет прежнюю.
+	// Возвращает ErrNotFound, если тренировки нет или она ещё не завершена.
+	SetDifficulty(ctx context.Context, id uuid.UUID, difficulty int, at time.Time) error
+
+	// ListByUser возвращает тренировки пользователя с подходами, начатые в [from, to), новые первыми.
+	ListByUser(ctx context.Context, userID uuid.UUID, from, to time.Time, limit int) ([]*domain.Session, error)
+
+	// ListWeightedSets возвращает подходы пользователя с рабочим весом и числом повторений не больше maxReps
+	// по всем тренировкам, в порядке записи.
+	ListWeightedSets(ctx context.Context, userID uuid.UUID, maxReps int) ([]domain.Set, error)
+
+	// DeleteByUserID удаляет все тренировки пользователя (при обезличивании).
+	DeleteByUserID(ctx context.Context, userID uuid.UUID) error
+}
@@ -0,0 +1,31 @@
+package interfaces
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	domain "workout-app/internal/domain/workout"
+)
+
+// WorkoutStatsRepository определяет агрегирующие запросы статистики тренировок.
+// Учитываются только завершённые тренировки; периоды — по времени начала тренировки.
+// tz — часовой пояс IANA, в котором считаются дни и недели.
+type WorkoutStatsRepository interface {
+	// Weekly возвращает итоги по неделям тренировок, начатых в [from, to), по возрастанию недели.
+	// Недели без тренировок не возвращаются.
+	Weekly(ctx context.Context, userID uuid.UUID, from, to time.Time, tz string) ([]domain.WeekStats, error)
+
+	// MuscleGroups возвращает подходы тренировок, начатых в [from, to), по группам мышц упражнений:
+	// больше подходов — раньше. Упражнения, которых нет в справочнике, не учитываются.
+	MuscleGroups(ctx context.Context, userID uuid.UUID, from, to time.Time) ([]domain.MuscleGroupStats, error)
+
+	// Streaks возвращает серии дней и недель с тренировками за всё время.
+	Streaks(ctx context.Context, userID uuid.UUID, tz string) (days, weeks domain.Runs, err error)
+
+	// PersonalRecords возвращает упражнения, максимальный вес которых в тренировках, начатых в [from, to),
+	// превысил лучший вес до from; больше прирост — раньше. Упражнения, которых раньше не было, рекордами
+	// не считаются: первый подход ещё не с чем сравнить.
+	PersonalRecords(ctx context.Context, userID uuid.UUID, from, to time.Time) ([]domain.PersonalRecord, error)
+}
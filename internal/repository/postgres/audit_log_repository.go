@@ -0,0 +1,142 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	domain "workout-app/internal/domain/audit"
+	repo "workout-app/internal/repository/interfaces"
+)
+
+// pgAuditLog представляет ORM-модель для таблицы audit_logs.
+type pgAuditLog struct {
+	ID        string    `gorm:"column:id;type:uuid;primaryKey"`
+	ActorID   string    `gorm:"column:actor_id;type:uuid;not null"`
+	Action    string    `gorm:"column:action;type:varchar(255);not null"`
+	TargetID  string    `gorm:"column:target_id;type:varchar(255);not null"`
+	Query     string    `gorm:"column:query;type:text;not null"`
+	Diff      *string   `gorm:"column:diff;type:jsonb"`
+	Status    int       `gorm:"column:status;type:smallint;not null"`
+	IP        string    `gorm:"column:ip;type:varchar(64);not null"`
+	CreatedAt time.Time `gorm:"column:created_at;type:timestamptz;not null"`
+}
+
+func (pgAuditLog) TableName() string {
+	return "audit_logs"
+}
+
+func (m *pgAuditLog) toDomain() (*domain.Entry, error) {
+	id, err := uuid.Parse(m.ID)
+	if err != nil {
+		return nil, err
+	}
+	actorID, err := uuid.Parse(m.ActorID)
+	if err != nil {
+		return nil, err
+	}
+	e := &domain.Entry{
+		ID:       id,
+		ActorID:  actorID,
+		Action:   m.Action,
+		TargetID: m.TargetID,
+		Query:    m.Query,
+		Status:   m.Status,
+		IP:       m.IP,
+		At:       m.CreatedAt,
+	}
+	if m.Diff != nil {
+		if err := json.Unmarshal([]byte(*m.Diff), &e.Diff); err != nil {
+			return nil, err
+		}
+	}
+	return e, nil
+}
+
+// AuditLogRepository реализует repo.AuditLogRepository на GORM/Postgres.
+type AuditLogRepository struct {
+	db *gorm.DB
+}
+
+// Убедимся на этапе компиляции, что структура реализует интерфейс.
+var _ repo.AuditLogRepository = (*AuditLogRepository)(nil)
+
+// NewAuditLogRepository создает новый репозиторий журнала действий администраторов.
+func NewAuditLogRepository(db *gorm.DB) *AuditLogRepository {
+	return &AuditLogRepository{db: db}
+}
+
+// Create добавляет запись в журнал.
+func (r *AuditLogRepository) Create(ctx context.Context, e *domain.Entry) error {
+	model := &pgAuditLog{
+		ID:        e.ID.String(),
+		ActorID:   e.ActorID.String(),
+		Action:    e.Action,
+		TargetID:  e.TargetID,
+		Query:     e.Query,
+		Status:    e.Status,
+		IP:        e.IP,
+		CreatedAt: e.At,
+	}
+	if len(e.Diff) > 0 {
+		raw, err := json.Marshal(e.Diff)
+		if err != nil {
+			return err
+		}
+		diff := string(raw)
+		model.Diff = &diff
+	}
+	return dbFromContext(ctx, r.db).Create(model).Error
+}
+
+// List возвращает записи по фильтру (новые первыми) и общее количество подходящих записей.
+func (r *AuditLogRepository) List(ctx context.Context, filter repo.AuditLogFilter) ([]*domain.Entry, int64, error) {
+	// Отдельные цепочки для подсчёта и выборки: GORM не переиспользует запрос после Count.
+	filtered := func() *gorm.DB {
+		query := dbFromContext(ctx, r.db).Model(&pgAuditLog{})
+		if filter.ActorID != nil {
+			query = query.Where("actor_id = ?", filter.ActorID.String())
+		}
+		if filter.TargetID != "" {
+			query = query.Where("target_id = ?", filter.TargetID)
+		}
+		if filter.Action != "" {
+			query = query.Where("strpos(action, ?) > 0", filter.Action)
+		}
+		if filter.From != nil {
+			query = query.Where("created_at >= ?", *filter.From)
+		}
+		if filter.To != nil {
+			query = query.Where("created_at < ?", *filter.To)
+		}
+		return query
+	}
+
+	var total int64
+	if err := filtered().Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var models []pgAuditLog
+	err := filtered().
+		Order("created_at DESC, id").
+		Limit(filter.Limit).
+		Offset(filter.Offset).
+		Find(&models).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	items := make([]*domain.Entry, 0, len(models))
+	for i := range models {
+		e, err := models[i].toDomain()
+		if err != nil {
+			return nil, 0, err
+		}
+		items = append(items, e)
+	}
+	return items, total, nil
+}
@@ -0,0 +1,242 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	domain "workout-app/internal/domain/backfill"
+	repo "workout-app/internal/repository/interfaces"
+)
+
+// pgBackfill представляет ORM-модель для таблицы backfills.
+type pgBackfill struct {
+	ID              string     `gorm:"column:id;type:uuid;primaryKey"`
+	Job             string     `gorm:"column:job;type:varchar(64);not null"`
+	Status          string     `gorm:"column:status;type:text;not null"`
+	BatchSize       int        `gorm:"column:batch_size;type:integer;not null"`
+	Cursor          string     `gorm:"column:cursor_value;type:text;not null"`
+	Processed       int64      `gorm:"column:processed;type:bigint;not null"`
+	Total           int64      `gorm:"column:total;type:bigint;not null"`
+	LastError       string     `gorm:"column:last_error;type:text;not null"`
+	CancelRequested bool       `gorm:"column:cancel_requested;type:boolean;not null"`
+	LeaseOwner      string     `gorm:"column:lease_owner;type:text;not null"`
+	LeaseExpiresAt  *time.Time `gorm:"column:lease_expires_at;type:timestamptz"`
+	CreatedBy       *string    `gorm:"column:created_by;type:uuid"`
+	StartedAt       *time.Time `gorm:"column:started_at;type:timestamptz"`
+	FinishedAt      *time.Time `gorm:"column:finished_at;type:timestamptz"`
+	CreatedAt       time.Time  `gorm:"column:created_at;type:timestamptz;not null"`
+	UpdatedAt       time.Time  `gorm:"column:updated_at;type:timestamptz;not null"`
+}
+
+func (pgBackfill) TableName() string {
+	return "backfills"
+}
+
+func (m *pgBackfill) toDomain() (*domain.Backfill, error) {
+	id, err := uuid.Parse(m.ID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid backfill id: %w", err)
+	}
+	var createdBy uuid.UUID
+	if m.CreatedBy != nil {
+		if createdBy, err = uuid.Parse(*m.CreatedBy); err != nil {
+			return nil, fmt.Errorf("invalid created_by: %w", err)
+		}
+	}
+
+	return &domain.Backfill{
+		ID:              id,
+		Job:             m.Job,
+		Status:          domain.Status(m.Status),
+		BatchSize:       m.BatchSize,
+		Cursor:          m.Cursor,
+		Processed:       m.Processed,
+		Total:           m.Total,
+		LastError:       m.LastError,
+		CancelRequested: m.CancelRequested,
+		CreatedBy:       createdBy,
+		StartedAt:       m.StartedAt,
+		FinishedAt:      m.FinishedAt,
+		CreatedAt:       m.CreatedAt,
+		UpdatedAt:       m.UpdatedAt,
+	}, nil
+}
+
+// BackfillRepository реализует repo.BackfillRepository на GORM/Postgres.
+type BackfillRepository struct {
+	db *gorm.DB
+}
+
+// Убедимся на этапе компиляции, что структура реализует интерфейс.
+var _ repo.BackfillRepository = (*BackfillRepository)(nil)
+
+// NewBackfillRepository создает новый репозиторий задач пересчёта.
+func NewBackfillRepository(db *gorm.DB) *BackfillRepository {
+	return &BackfillRepository{db: db}
+}
+
+// Create сохраняет новую задачу.
+func (r *BackfillRepository) Create(ctx context.Context, b *domain.Backfill) error {
+	createdBy := b.CreatedBy.String()
+	model := &pgBackfill{
+		ID:        b.ID.String(),
+		Job:       b.Job,
+		Status:    string(b.Status),
+		BatchSize: b.BatchSize,
+		Cursor:    b.Cursor,
+		Processed: b.Processed,
+		Total:     b.Total,
+		CreatedBy: &createdBy,
+		CreatedAt: b.CreatedAt,
+		UpdatedAt: b.UpdatedAt,
+	}
+	if err := dbFromContext(ctx, r.db).Create(model).Error; err != nil {
+		if isUniqueViolation(err, "idx_backfills_active_job") {
+			return repo.ErrBackfillActive
+		}
+		return err
+	}
+	return nil
+}
+
+// GetByID возвращает задачу по идентификатору.
+func (r *BackfillRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Backfill, error) {
+	var model pgBackfill
+	err := dbFromContext(ctx, r.db).Where("id = ?", id.String()).Take(&model).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, repo.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return model.toDomain()
+}
+
+// List возвращает последние задачи, новые первыми.
+func (r *BackfillRepository) List(ctx context.Context, limit int) ([]*domain.Backfill, error) {
+	var models []pgBackfill
+	if err := dbFromContext(ctx, r.db).Order("created_at DESC").Limit(limit).Find(&models).Error; err != nil {
+		return nil, err
+	}
+	return toDomainBackfills(models)
+}
+
+// ListResumable возвращает незавершённые задачи в порядке создания.
+func (r *BackfillRepository) ListResumable(ctx context.Context) ([]*domain.Backfill, error) {
+	var models []pgBackfill
+	err := dbFromContext(ctx, r.db).
+		Where("status IN ?", []string{string(domain.StatusPending), string(domain.StatusRunning)}).
+		Order("created_at").
+		Find(&models).Error
+	if err != nil {
+		return nil, err
+	}
+	return toDomainBackfills(models)
+}
+
+// Claim захватывает аренду задачи для процесса owner.
+func (r *BackfillRepository) Claim(ctx context.Context, id uuid.UUID, owner string, ttl time.Duration) (bool, error) {
+	now := time.Now().UTC()
+	result := dbFromContext(ctx, r.db).
+		Model(&pgBackfill{}).
+		Where("id = ? AND status IN ?", id.String(), []string{string(domain.StatusPending), string(domain.StatusRunning)}).
+		Where("lease_owner = '' OR lease_owner = ? OR lease_expires_at IS NULL OR lease_expires_at < ?", owner, now).
+		Updates(map[string]interface{}{
+			"status":           string(domain.StatusRunning),
+			"lease_owner":      owner,
+			"lease_expires_at": now.Add(ttl),
+			"started_at":       gorm.Expr("COALESCE(started_at, ?)", now),
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+// SaveProgress сохраняет курсор и счётчик обработанных элементов и продлевает аренду.
+func (r *BackfillRepository) SaveProgress(ctx context.Context, id uuid.UUID, owner, cursor string, processed int64, ttl time.Duration) error {
+	result := dbFromContext(ctx, r.db).
+		Model(&pgBackfill{}).
+		Where("id = ? AND lease_owner = ? AND status = ?", id.String(), owner, string(domain.StatusRunning)).
+		Updates(map[string]interface{}{
+			"cursor_value":     cursor,
+			"processed":        processed,
+			"lease_expires_at": time.Now().UTC().Add(ttl),
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return repo.ErrBackfillLeaseLost
+	}
+	return nil
+}
+
+// Finish переводит задачу в финальное состояние и освобождает аренду.
+func (r *BackfillRepository) Finish(ctx context.Context, id uuid.UUID, owner string, status domain.Status, lastError string) error {
+	result := dbFromContext(ctx, r.db).
+		Model(&pgBackfill{}).
+		Where("id = ? AND lease_owner = ?", id.String(), owner).
+		Updates(map[string]interface{}{
+			"status":           string(status),
+			"last_error":       lastError,
+			"lease_owner":      "",
+			"lease_expires_at": nil,
+			"finished_at":      time.Now().UTC(),
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return repo.ErrBackfillLeaseLost
+	}
+	return nil
+}
+
+// RequestCancel помечает задачу на отмену.
+func (r *BackfillRepository) RequestCancel(ctx context.Context, id uuid.UUID) error {
+	return dbFromContext(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		// Незапущенную задачу отменяем сразу: выполняющего её процесса нет.
+		pending := tx.Model(&pgBackfill{}).
+			Where("id = ? AND status = ?", id.String(), string(domain.StatusPending)).
+			Updates(map[string]interface{}{
+				"status":           string(domain.StatusCancelled),
+				"cancel_requested": true,
+				"finished_at":      time.Now().UTC(),
+			})
+		if pending.Error != nil {
+			return pending.Error
+		}
+		if pending.RowsAffected > 0 {
+			return nil
+		}
+
+		running := tx.Model(&pgBackfill{}).
+			Where("id = ? AND status = ?", id.String(), string(domain.StatusRunning)).
+			Update("cancel_requested", true)
+		if running.Error != nil {
+			return running.Error
+		}
+		if running.RowsAffected == 0 {
+			return repo.ErrNotFound
+		}
+		return nil
+	})
+}
+
+func toDomainBackfills(models []pgBackfill) ([]*domain.Backfill, error) {
+	backfills := make([]*domain.Backfill, 0, len(models))
+	for i := range models {
+		b, err := models[i].toDomain()
+		if err != nil {
+			return nil, err
+		}
+		backfills = append(backfills, b)
+	}
+	return backfills, nil
+}
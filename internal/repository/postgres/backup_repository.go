@@ -0,0 +1,127 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	domain "workout-app/internal/domain/backup"
+	repo "workout-app/internal/repository/interfaces"
+)
+
+// pgBackup представляет ORM-модель для таблицы user_backups.
+type pgBackup struct {
+	ID         string    `gorm:"column:id;type:uuid;primaryKey"`
+	UserID     string    `gorm:"column:user_id;type:uuid;not null"`
+	Version    int       `gorm:"column:version;not null"`
+	SizeBytes  int64     `gorm:"column:size_bytes;not null"`
+	SHA256     string    `gorm:"column:sha256;type:char(64);not null"`
+	StorageKey string    `gorm:"column:storage_key;type:varchar(512);not null"`
+	CreatedAt  time.Time `gorm:"column:created_at;type:timestamptz;not null"`
+}
+
+func (pgBackup) TableName() string {
+	return "user_backups"
+}
+
+func (m *pgBackup) toDomain() (*domain.Backup, error) {
+	id, err := uuid.Parse(m.ID)
+	if err != nil {
+		return nil, err
+	}
+	userID, err := uuid.Parse(m.UserID)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.Backup{
+		ID:         id,
+		UserID:     userID,
+		Version:    m.Version,
+		SizeBytes:  m.SizeBytes,
+		SHA256:     m.SHA256,
+		StorageKey: m.StorageKey,
+		CreatedAt:  m.CreatedAt,
+	}, nil
+}
+
+// BackupRepository реализует repo.BackupRepository на GORM/Postgres.
+type BackupRepository struct {
+	db *gorm.DB
+}
+
+// Убедимся на этапе компиляции, что структура реализует интерфейс.
+var _ repo.BackupRepository = (*BackupRepository)(nil)
+
+// NewBackupRepository создает новый репозиторий резервных копий.
+func NewBackupRepository(db *gorm.DB) *BackupRepository {
+	return &BackupRepository{db: db}
+}
+
+// Create сохраняет новую версию; занятая версия означает параллельную загрузку.
+func (r *BackupRepository) Create(ctx context.Context, b *domain.Backup) error {
+	result := dbFromContext(ctx, r.db).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "user_id"}, {Name: "version"}},
+			DoNothing: true,
+		}).
+		Create(&pgBackup{
+			ID:         b.ID.String(),
+			UserID:     b.UserID.String(),
+			Version:    b.Version,
+			SizeBytes:  b.SizeBytes,
+			SHA256:     b.SHA256,
+			StorageKey: b.StorageKey,
+			CreatedAt:  b.CreatedAt,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return repo.ErrBackupVersionExists
+	}
+	return nil
+}
+
+// ListByUser возвращает версии резервной копии пользователя, новые первыми.
+func (r *BackupRepository) ListByUser(ctx context.Context, userID uuid.UUID) ([]*domain.Backup, error) {
+	var models []pgBackup
+	err := dbFromContext(ctx, r.db).
+		Where("user_id = ?", userID.String()).
+		Order("version DESC").
+		Find(&models).Error
+	if err != nil {
+		return nil, err
+	}
+
+	backups := make([]*domain.Backup, 0, len(models))
+	for i := range models {
+		b, err := models[i].toDomain()
+		if err != nil {
+			return nil, err
+		}
+		backups = append(backups, b)
+	}
+	return backups, nil
+}
+
+// Delete удаляет версию резервной копии.
+func (r *BackupRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	result := dbFromContext(ctx, r.db).Where("id = ?", id.String()).Delete(&pgBackup{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return repo.ErrNotFound
+	}
+	return nil
+}
+
+// DeleteByUserID удаляет все версии резервной копии пользователя.
+func (r *BackupRepository) DeleteByUserID(ctx context.Context, userID uuid.UUID) error {
+	return dbFromContext(ctx, r.db).
+		Where("user_id = ?", userID.String()).
+		Delete(&pgBackup{}).Error
+}
@@ -0,0 +1,186 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	domain "workout-app/internal/domain/user"
+	repo "workout-app/internal/repository/interfaces"
+)
+
+// pgBodyMetric представляет ORM-модель для таблицы body_metrics.
+type pgBodyMetric struct {
+	ID             string    `gorm:"column:id;type:uuid;primaryKey"`
+	UserID         string    `gorm:"column:user_id;type:uuid;not null"`
+	MeasuredAt     time.Time `gorm:"column:measured_at;type:timestamptz;not null"`
+	WeightKg       *float64  `gorm:"column:weight_kg;type:numeric(6,2)"`
+	BodyFatPercent *float64  `gorm:"column:body_fat_percent;type:numeric(5,2)"`
+	Measurements   string    `gorm:"column:measurements;type:jsonb;not null"`
+	CreatedAt      time.Time `gorm:"column:created_at;type:timestamptz;not null"`
+}
+
+func (pgBodyMetric) TableName() string {
+	return "body_metrics"
+}
+
+func (m *pgBodyMetric) toDomain() (*domain.BodyMetric, error) {
+	id, err := uuid.Parse(m.ID)
+	if err != nil {
+		return nil, err
+	}
+	userID, err := uuid.Parse(m.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	measurements := map[string]float64{}
+	if m.Measurements != "" {
+		if err := json.Unmarshal([]byte(m.Measurements), &measurements); err != nil {
+			return nil, fmt.Errorf("failed to decode measurements: %w", err)
+		}
+	}
+
+	return &domain.BodyMetric{
+		ID:             id,
+		UserID:         userID,
+		MeasuredAt:     m.MeasuredAt,
+		WeightKg:       m.WeightKg,
+		BodyFatPercent: m.BodyFatPercent,
+		Measurements:   measurements,
+		CreatedAt:      m.CreatedAt,
+	}, nil
+}
+
+func fromDomainBodyMetric(m *domain.BodyMetric) (*pgBodyMetric, error) {
+	measurements := m.Measurements
+	if measurements == nil {
+		measurements = map[string]float64{}
+	}
+	raw, err := json.Marshal(measurements)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode measurements: %w", err)
+	}
+
+	return &pgBodyMetric{
+		ID:             m.ID.String(),
+		UserID:         m.UserID.String(),
+		MeasuredAt:     m.MeasuredAt,
+		WeightKg:       m.WeightKg,
+		BodyFatPercent: m.BodyFatPercent,
+		Measurements:   string(raw),
+		CreatedAt:      m.CreatedAt,
+	}, nil
+}
+
+// BodyMetricRepository реализует repo.BodyMetricRepository на GORM/Postgres.
+type BodyMetricRepository struct {
+	db *gorm.DB
+}
+
+// Убедимся на этапе компиляции, что структура реализует интерфейс.
+var _ repo.BodyMetricRepository = (*BodyMetricRepository)(nil)
+
+// NewBodyMetricRepository создает новый репозиторий замеров параметров тела.
+func NewBodyMetricRepository(db *gorm.DB) *BodyMetricRepository {
+	return &BodyMetricRepository{db: db}
+}
+
+// Create сохраняет новый замер.
+func (r *BodyMetricRepository) Create(ctx context.Context, m *domain.BodyMetric) error {
+	model, err := fromDomainBodyMetric(m)
+	if err != nil {
+		return err
+	}
+	return dbFromContext(ctx, r.db).Create(model).Error
+}
+
+// ListByUser возвращает страницу замеров пользователя в диапазоне [from, to].
+func (r *BodyMetricRepository) ListByUser(ctx context.Context, userID uuid.UUID, from, to time.Time, limit, offset int) ([]*domain.BodyMetric, error) {
+	query := dbFromContext(ctx, r.db).Where("user_id = ?", userID.String())
+	if !from.IsZero() {
+		query = query.Where("measured_at >= ?", from)
+	}
+	if !to.IsZero() {
+		query = query.Where("measured_at <= ?", to)
+	}
+
+	var models []pgBodyMetric
+	err := query.Order("measured_at DESC").Order("id").Limit(limit).Offset(offset).Find(&models).Error
+	if err != nil {
+		return nil, err
+	}
+
+	metrics := make([]*domain.BodyMetric, 0, len(models))
+	for i := range models {
+		m, err := models[i].toDomain()
+		if err != nil {
+			return nil, err
+		}
+		metrics = append(metrics, m)
+	}
+	return metrics, nil
+}
+
+// latestMetricRow — строка результата агрегирующего запроса последних значений.
+type latestMetricRow struct {
+	Name       string
+	Value      float64
+	MeasuredAt time.Time
+}
+
+// GetLatestSummary возвращает последние известные значения каждой метрики пользователя.
+// Вес, процент жира и каждый произвольный замер выбираются независимо: последний замер
+// веса может быть сделан в другой день, чем последний замер талии.
+func (r *BodyMetricRepository) GetLatestSummary(ctx context.Context, userID uuid.UUID) (*domain.BodyMetricsSummary, error) {
+	const query = `
+		(SELECT 'weight_kg' AS name, weight_kg::float8 AS value, measured_at
+		   FROM body_metrics
+		  WHERE user_id = @user_id AND weight_kg IS NOT NULL
+		  ORDER BY measured_at DESC
+		  LIMIT 1)
+		UNION ALL
+		(SELECT 'body_fat_percent' AS name, body_fat_percent::float8 AS value, measured_at
+		   FROM body_metrics
+		  WHERE user_id = @user_id AND body_fat_percent IS NOT NULL
+		  ORDER BY measured_at DESC
+		  LIMIT 1)
+		UNION ALL
+		(SELECT DISTINCT ON (kv.key) 'm:' || kv.key AS name, (kv.value #>> '{}')::float8 AS value, bm.measured_at
+		   FROM body_metrics bm, jsonb_each(bm.measurements) kv
+		  WHERE bm.user_id = @user_id
+		  ORDER BY kv.key, bm.measured_at DESC)`
+
+	var rows []latestMetricRow
+	if err := dbFromContext(ctx, r.db).
+		Raw(query, map[string]interface{}{"user_id": userID.String()}).
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	summary := &domain.BodyMetricsSummary{Measurements: map[string]domain.MetricValue{}}
+	for _, row := range rows {
+		value := domain.MetricValue{Value: row.Value, MeasuredAt: row.MeasuredAt}
+		switch {
+		case row.Name == "weight_kg":
+			summary.WeightKg = &value
+		case row.Name == "body_fat_percent":
+			summary.BodyFatPercent = &value
+		case strings.HasPrefix(row.Name, "m:"):
+			summary.Measurements[strings.TrimPrefix(row.Name, "m:")] = value
+		}
+	}
+	return summary, nil
+}
+
+// DeleteByUserID удаляет все замеры пользователя.
+func (r *BodyMetricRepository) DeleteByUserID(ctx context.Context, userID uuid.UUID) error {
+	return dbFromContext(ctx, r.db).
+		Where("user_id = ?", userID.String()).
+		Delete(&pgBodyMetric{}).Error
+}
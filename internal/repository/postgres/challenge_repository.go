@@ -0,0 +1,258 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	domain "workout-app/internal/domain/challenge"
+	repo "workout-app/internal/repository/interfaces"
+)
+
+// pgChallenge представляет ORM-модель для таблицы challenges.
+type pgChallenge struct {
+	ID          string    `gorm:"column:id;type:uuid;primaryKey"`
+	CreatorID   string    `gorm:"column:creator_id;type:uuid;not null"`
+	Title       string    `gorm:"column:title;type:varchar(100);not null"`
+	Description string    `gorm:"column:description;type:varchar(1000);not null"`
+	Metric      string    `gorm:"column:metric;type:varchar(16);not null"`
+	Target      int       `gorm:"column:target;not null"`
+	StartsAt    time.Time `gorm:"column:starts_at;type:timestamptz;not null"`
+	EndsAt      time.Time `gorm:"column:ends_at;type:timestamptz;not null"`
+	CreatedAt   time.Time `gorm:"column:created_at;type:timestamptz;not null"`
+}
+
+func (pgChallenge) TableName() string {
+	return "challenges"
+}
+
+func (m *pgChallenge) toDomain() (*domain.Challenge, error) {
+	id, err := uuid.Parse(m.ID)
+	if err != nil {
+		return nil, err
+	}
+	creatorID, err := uuid.Parse(m.CreatorID)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.Challenge{
+		ID:          id,
+		CreatorID:   creatorID,
+		Title:       m.Title,
+		Description: m.Description,
+		Metric:      domain.Metric(m.Metric),
+		Target:      m.Target,
+		StartsAt:    m.StartsAt,
+		EndsAt:      m.EndsAt,
+		CreatedAt:   m.CreatedAt,
+	}, nil
+}
+
+// pgChallengeParticipant представляет ORM-модель для таблицы challenge_participants.
+type pgChallengeParticipant struct {
+	ChallengeID string    `gorm:"column:challenge_id;type:uuid;primaryKey"`
+	UserID      string    `gorm:"column:user_id;type:uuid;primaryKey"`
+	JoinedAt    time.Time `gorm:"column:joined_at;type:timestamptz;not null"`
+}
+
+func (pgChallengeParticipant) TableName() string {
+	return "challenge_participants"
+}
+
+// pgChallengeListing — строка выборки челленджа с числом участников и участием пользователя.
+type pgChallengeListing struct {
+	pgChallenge
+	Participants int
+	Joined       bool
+}
+
+func (m *pgChallengeListing) toDomain() (*domain.Listing, error) {
+	c, err := m.pgChallenge.toDomain()
+	if err != nil {
+		return nil, err
+	}
+	return &domain.Listing{Challenge: c, Participants: m.Participants, Joined: m.Joined}, nil
+}
+
+// ChallengeRepository реализует repo.ChallengeRepository на GORM/Postgres.
+type ChallengeRepository struct {
+	db *gorm.DB
+}
+
+// Убедимся на этапе компиляции, что структура реализует интерфейс.
+var _ repo.ChallengeRepository = (*ChallengeRepository)(nil)
+
+// NewChallengeRepository создает новый репозиторий челленджей.
+func NewChallengeRepository(db *gorm.DB) *ChallengeRepository {
+	return &ChallengeRepository{db: db}
+}
+
+// Create сохраняет челлендж.
+func (r *ChallengeRepository) Create(ctx context.Context, c *domain.Challenge) error {
+	return dbFromContext(ctx, r.db).Create(&pgChallenge{
+		ID:          c.ID.String(),
+		CreatorID:   c.CreatorID.String(),
+		Title:       c.Title,
+		Description: c.Description,
+		Metric:      string(c.Metric),
+		Target:      c.Target,
+		StartsAt:    c.StartsAt,
+		EndsAt:      c.EndsAt,
+		CreatedAt:   c.CreatedAt,
+	}).Error
+}
+
+// GetByID возвращает челлендж по ID.
+func (r *ChallengeRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Challenge, error) {
+	var model pgChallenge
+	if err := dbFromContext(ctx, r.db).Where("id = ?", id.String()).First(&model).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, repo.ErrNotFound
+		}
+		return nil, err
+	}
+	return model.toDomain()
+}
+
+// listingQuery выбирает челленджи с числом участников и участием пользователя.
+func (r *ChallengeRepository) listingQuery(ctx context.Context, userID uuid.UUID) *gorm.DB {
+	return dbFromContext(ctx, r.db).
+		Table("challenges c").
+		Select(`c.*,
+			(SELECT COUNT(*) FROM challenge_participants p WHERE p.challenge_id = c.id) AS participants,
+			EXISTS (SELECT 1 FROM challenge_participants p WHERE p.challenge_id = c.id AND p.user_id = ?) AS joined`,
+			userID.String())
+}
+
+// GetListing возвращает челлендж с числом участников и участием пользователя.
+func (r *ChallengeRepository) GetListing(ctx context.Context, id, userID uuid.UUID) (*domain.Listing, error) {
+	var rows []pgChallengeListing
+	if err := r.listingQuery(ctx, userID).Where("c.id = ?", id.String()).Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, repo.ErrNotFound
+	}
+	return rows[0].toDomain()
+}
+
+// List возвращает челленджи по фильтру, ближайшие к завершению первыми.
+func (r *ChallengeRepository) List(ctx context.Context, filter repo.ChallengeFilter) ([]*domain.Listing, error) {
+	query := r.listingQuery(ctx, filter.UserID)
+	if filter.JoinedOnly {
+		query = query.Where("EXISTS (SELECT 1 FROM challenge_participants p WHERE p.challenge_id = c.id AND p.user_id = ?)", filter.UserID.String())
+	}
+	if !filter.EndsAfter.IsZero() {
+		query = query.Where("c.ends_at > ?", filter.EndsAfter)
+	}
+
+	var rows []pgChallengeListing
+	err := query.
+		Order("c.ends_at ASC, c.id").
+		Limit(filter.Limit).
+		Offset(filter.Offset).
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	listings := make([]*domain.Listing, 0, len(rows))
+	for i := range rows {
+		l, err := rows[i].toDomain()
+		if err != nil {
+			return nil, err
+		}
+		listings = append(listings, l)
+	}
+	return listings, nil
+}
+
+// Delete удаляет челлендж; участники удаляются каскадно.
+func (r *ChallengeRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	result := dbFromContext(ctx, r.db).Where("id = ?", id.String()).Delete(&pgChallenge{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return repo.ErrNotFound
+	}
+	return nil
+}
+
+// AddParticipant добавляет участника; повторное вступление сохраняет прежнюю дату.
+func (r *ChallengeRepository) AddParticipant(ctx context.Context, p *domain.Participant) error {
+	return dbFromContext(ctx, r.db).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&pgChallengeParticipant{
+			ChallengeID: p.ChallengeID.String(),
+			UserID:      p.UserID.String(),
+			JoinedAt:    p.JoinedAt,
+		}).Error
+}
+
+// RemoveParticipant удаляет участника.
+func (r *ChallengeRepository) RemoveParticipant(ctx context.Context, challengeID, userID uuid.UUID) error {
+	return dbFromContext(ctx, r.db).
+		Where("challenge_id = ? AND user_id = ?", challengeID.String(), userID.String()).
+		Delete(&pgChallengeParticipant{}).Error
+}
+
+// Progress считает показатель челленджа для участников одним запросом.
+// Удалённые пользователи в таблицу лидеров не попадают.
+func (r *ChallengeRepository) Progress(ctx context.Context, c *domain.Challenge) ([]*domain.Progress, error) {
+	value := "COUNT(ws.id)"
+	if c.Metric == domain.MetricActiveDays {
+		value = "COUNT(DISTINCT (ws.started_at AT TIME ZONE 'UTC')::date)"
+	}
+	query := `
+		SELECT p.user_id, u.username, p.joined_at, ` + value + ` AS value
+		  FROM challenge_participants p
+		  JOIN users u ON u.id = p.user_id AND u.deleted_at IS NULL
+		  LEFT JOIN workout_sessions ws ON ws.user_id = p.user_id AND ws.finished_at IS NOT NULL
+		   AND ws.started_at >= @starts_at AND ws.started_at < @ends_at
+		 WHERE p.challenge_id = @challenge_id
+		 GROUP BY p.user_id, u.username, p.joined_at`
+
+	var rows []struct {
+		UserID   string
+		Username string
+		JoinedAt time.Time
+		Value    int
+	}
+	err := dbFromContext(ctx, r.db).
+		Raw(query, map[string]interface{}{
+			"challenge_id": c.ID.String(),
+			"starts_at":    c.StartsAt,
+			"ends_at":      c.EndsAt,
+		}).
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	progress := make([]*domain.Progress, 0, len(rows))
+	for _, row := range rows {
+		userID, err := uuid.Parse(row.UserID)
+		if err != nil {
+			return nil, err
+		}
+		progress = append(progress, &domain.Progress{
+			UserID:   userID,
+			Username: row.Username,
+			Value:    row.Value,
+			JoinedAt: row.JoinedAt,
+		})
+	}
+	return progress, nil
+}
+
+// DeleteParticipantsByUserID удаляет участие пользователя во всех челленджах.
+func (r *ChallengeRepository) DeleteParticipantsByUserID(ctx context.Context, userID uuid.UUID) error {
+	return dbFromContext(ctx, r.db).
+		Where("user_id = ?", userID.String()).
+		Delete(&pgChallengeParticipant{}).Error
+}
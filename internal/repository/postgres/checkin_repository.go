@@ -0,0 +1,144 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	domain "workout-app/internal/domain/checkin"
+	repo "workout-app/internal/repository/interfaces"
+)
+
+// pgCheckIn представляет ORM-модель для таблицы daily_checkins.
+type pgCheckIn struct {
+	ID         string    `gorm:"column:id;type:uuid;primaryKey"`
+	UserID     string    `gorm:"column:user_id;type:uuid;not null"`
+	Day        time.Time `gorm:"column:day;type:date;not null"`
+	Soreness   int       `gorm:"column:soreness;not null"`
+	Energy     int       `gorm:"column:energy;not null"`
+	Motivation int       `gorm:"column:motivation;not null"`
+	Note       string    `gorm:"column:note;type:varchar(500);not null"`
+	CreatedAt  time.Time `gorm:"column:created_at;type:timestamptz;not null"`
+	UpdatedAt  time.Time `gorm:"column:updated_at;type:timestamptz;not null"`
+}
+
+func (pgCheckIn) TableName() string {
+	return "daily_checkins"
+}
+
+func newPgCheckIn(c *domain.CheckIn) *pgCheckIn {
+	return &pgCheckIn{
+		ID:         c.ID.String(),
+		UserID:     c.UserID.String(),
+		Day:        c.Date,
+		Soreness:   c.Soreness,
+		Energy:     c.Energy,
+		Motivation: c.Motivation,
+		Note:       c.Note,
+		CreatedAt:  c.CreatedAt,
+		UpdatedAt:  c.UpdatedAt,
+	}
+}
+
+func (m *pgCheckIn) toDomain() (*domain.CheckIn, error) {
+	id, err := uuid.Parse(m.ID)
+	if err != nil {
+		return nil, err
+	}
+	userID, err := uuid.Parse(m.UserID)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.CheckIn{
+		ID:         id,
+		UserID:     userID,
+		Date:       domain.Day(m.Day),
+		Soreness:   m.Soreness,
+		Energy:     m.Energy,
+		Motivation: m.Motivation,
+		Note:       m.Note,
+		CreatedAt:  m.CreatedAt,
+		UpdatedAt:  m.UpdatedAt,
+	}, nil
+}
+
+// CheckInRepository реализует repo.CheckInRepository на GORM/Postgres.
+type CheckInRepository struct {
+	db *gorm.DB
+}
+
+// Убедимся на этапе компиляции, что структура реализует интерфейс.
+var _ repo.CheckInRepository = (*CheckInRepository)(nil)
+
+// NewCheckInRepository создает новый репозиторий ежедневных анкет.
+func NewCheckInRepository(db *gorm.DB) *CheckInRepository {
+	return &CheckInRepository{db: db}
+}
+
+// Upsert сохраняет анкету, заменяя анкету за тот же день.
+func (r *CheckInRepository) Upsert(ctx context.Context, c *domain.CheckIn) error {
+	db := dbFromContext(ctx, r.db)
+	err := db.
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "user_id"}, {Name: "day"}},
+			DoUpdates: clause.AssignmentColumns([]string{"soreness", "energy", "motivation", "note", "updated_at"}),
+		}).
+		Create(newPgCheckIn(c)).Error
+	if err != nil {
+		return err
+	}
+
+	// При замене сохраняются ID и время создания исходной анкеты.
+	var stored pgCheckIn
+	if err := db.Where("user_id = ? AND day = ?", c.UserID.String(), c.Date).First(&stored).Error; err != nil {
+		return err
+	}
+	saved, err := stored.toDomain()
+	if err != nil {
+		return err
+	}
+	c.ID = saved.ID
+	c.CreatedAt = saved.CreatedAt
+	return nil
+}
+
+// ListByUser возвращает анкеты пользователя за период, новые первыми.
+func (r *CheckInRepository) ListByUser(ctx context.Context, userID uuid.UUID, from, to time.Time) ([]*domain.CheckIn, error) {
+	var models []pgCheckIn
+	err := dbFromContext(ctx, r.db).
+		Where("user_id = ? AND day >= ? AND day <= ?", userID.String(), from, to).
+		Order("day DESC").
+		Find(&models).Error
+	if err != nil {
+		return nil, err
+	}
+
+	checkIns := make([]*domain.CheckIn, 0, len(models))
+	for i := range models {
+		c, err := models[i].toDomain()
+		if err != nil {
+			return nil, err
+		}
+		checkIns = append(checkIns, c)
+	}
+	return checkIns, nil
+}
+
+// ListDays возвращает дни заполненных анкет по возрастанию.
+func (r *CheckInRepository) ListDays(ctx context.Context, userID uuid.UUID) ([]time.Time, error) {
+	var days []time.Time
+	err := dbFromContext(ctx, r.db).
+		Model(&pgCheckIn{}).
+		Where("user_id = ?", userID.String()).
+		Order("day").
+		Pluck("day", &days).Error
+	return days, err
+}
+
+// DeleteByUserID удаляет все анкеты пользователя.
+func (r *CheckInRepository) DeleteByUserID(ctx context.Context, userID uuid.UUID) error {
+	return dbFromContext(ctx, r.db).Where("user_id = ?", userID.String()).Delete(&pgCheckIn{}).Error
+}
@@ -0,0 +1,90 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	domain "workout-app/internal/domain/clientversion"
+	repo "workout-app/internal/repository/interfaces"
+)
+
+// pgClientVersionPolicy представляет ORM-модель для таблицы client_version_policies.
+type pgClientVersionPolicy struct {
+	Platform   string    `gorm:"column:platform;type:varchar(16);primaryKey"`
+	MinVersion string    `gorm:"column:min_version;type:varchar(32);not null"`
+	StoreURL   string    `gorm:"column:store_url;type:text;not null"`
+	UpdatedAt  time.Time `gorm:"column:updated_at;type:timestamptz;not null"`
+}
+
+func (pgClientVersionPolicy) TableName() string {
+	return "client_version_policies"
+}
+
+func (m *pgClientVersionPolicy) toDomain() *domain.Policy {
+	return &domain.Policy{
+		Platform:   domain.Platform(m.Platform),
+		MinVersion: m.MinVersion,
+		StoreURL:   m.StoreURL,
+		UpdatedAt:  m.UpdatedAt,
+	}
+}
+
+// ClientVersionRepository реализует repo.ClientVersionRepository на GORM/Postgres.
+type ClientVersionRepository struct {
+	db *gorm.DB
+}
+
+// Убедимся на этапе компиляции, что структура реализует интерфейс.
+var _ repo.ClientVersionRepository = (*ClientVersionRepository)(nil)
+
+// NewClientVersionRepository создает новый репозиторий минимальных версий клиента.
+func NewClientVersionRepository(db *gorm.DB) *ClientVersionRepository {
+	return &ClientVersionRepository{db: db}
+}
+
+// List возвращает политики, отсортированные по платформе.
+func (r *ClientVersionRepository) List(ctx context.Context) ([]*domain.Policy, error) {
+	var models []pgClientVersionPolicy
+	if err := dbFromContext(ctx, r.db).Order("platform").Find(&models).Error; err != nil {
+		return nil, err
+	}
+
+	policies := make([]*domain.Policy, 0, len(models))
+	for i := range models {
+		policies = append(policies, models[i].toDomain())
+	}
+	return policies, nil
+}
+
+// Upsert создаёт или обновляет политику платформы.
+func (r *ClientVersionRepository) Upsert(ctx context.Context, p *domain.Policy) error {
+	model := &pgClientVersionPolicy{
+		Platform:   string(p.Platform),
+		MinVersion: p.MinVersion,
+		StoreURL:   p.StoreURL,
+		UpdatedAt:  p.UpdatedAt,
+	}
+	return dbFromContext(ctx, r.db).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "platform"}},
+			DoUpdates: clause.AssignmentColumns([]string{"min_version", "store_url", "updated_at"}),
+		}).
+		Create(model).Error
+}
+
+// Delete удаляет политику платформы.
+func (r *ClientVersionRepository) Delete(ctx context.Context, platform domain.Platform) error {
+	result := dbFromContext(ctx, r.db).
+		Where("platform = ?", string(platform)).
+		Delete(&pgClientVersionPolicy{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return repo.ErrNotFound
+	}
+	return nil
+}
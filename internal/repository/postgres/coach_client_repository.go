@@ -0,0 +1,218 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	domain "workout-app/internal/domain/coach"
+	repo "workout-app/internal/repository/interfaces"
+)
+
+// pgCoachInvitation представляет ORM-модель для таблицы coach_invitations.
+type pgCoachInvitation struct {
+	ID        string    `gorm:"column:id;type:uuid;primaryKey"`
+	CoachID   string    `gorm:"column:coach_id;type:uuid;not null"`
+	ClientID  string    `gorm:"column:client_id;type:uuid;not null"`
+	Email     string    `gorm:"column:email;type:varchar(255);not null"`
+	CreatedAt time.Time `gorm:"column:created_at;type:timestamptz;not null"`
+	ExpiresAt time.Time `gorm:"column:expires_at;type:timestamptz;not null"`
+}
+
+func (pgCoachInvitation) TableName() string {
+	return "coach_invitations"
+}
+
+func (m *pgCoachInvitation) toDomain() (*domain.Invitation, error) {
+	id, err := uuid.Parse(m.ID)
+	if err != nil {
+		return nil, err
+	}
+	coachID, err := uuid.Parse(m.CoachID)
+	if err != nil {
+		return nil, err
+	}
+	clientID, err := uuid.Parse(m.ClientID)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.Invitation{
+		ID:        id,
+		CoachID:   coachID,
+		ClientID:  clientID,
+		Email:     m.Email,
+		CreatedAt: m.CreatedAt,
+		ExpiresAt: m.ExpiresAt,
+	}, nil
+}
+
+// pgCoachClient представляет ORM-модель для таблицы coach_clients.
+type pgCoachClient struct {
+	CoachID   string    `gorm:"column:coach_id;type:uuid;primaryKey"`
+	ClientID  string    `gorm:"column:client_id;type:uuid;primaryKey"`
+	CreatedAt time.Time `gorm:"column:created_at;type:timestamptz;not null"`
+}
+
+func (pgCoachClient) TableName() string {
+	return "coach_clients"
+}
+
+// CoachClientRepository реализует repo.CoachClientRepository на GORM/Postgres.
+type CoachClientRepository struct {
+	db *gorm.DB
+}
+
+// Убедимся на этапе компиляции, что структура реализует интерфейс.
+var _ repo.CoachClientRepository = (*CoachClientRepository)(nil)
+
+// NewCoachClientRepository создает новый репозиторий приглашений тренеров и их клиентов.
+func NewCoachClientRepository(db *gorm.DB) *CoachClientRepository {
+	return &CoachClientRepository{db: db}
+}
+
+// CreateInvitation сохраняет приглашение. Истёкшее приглашение той же пары заменяется одной командой:
+// конфликт по (coach_id, client_id) обновляет строку, только если её срок истёк.
+func (r *CoachClientRepository) CreateInvitation(ctx context.Context, inv *domain.Invitation) error {
+	result := dbFromContext(ctx, r.db).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "coach_id"}, {Name: "client_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"id", "email", "created_at", "expires_at"}),
+			Where: clause.Where{Exprs: []clause.Expression{
+				clause.Expr{SQL: "coach_invitations.expires_at <= EXCLUDED.created_at"},
+			}},
+		}).
+		Create(&pgCoachInvitation{
+			ID:        inv.ID.String(),
+			CoachID:   inv.CoachID.String(),
+			ClientID:  inv.ClientID.String(),
+			Email:     inv.Email,
+			CreatedAt: inv.CreatedAt,
+			ExpiresAt: inv.ExpiresAt,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return repo.ErrCoachInvitationExists
+	}
+	return nil
+}
+
+// GetInvitation возвращает приглашение по ID.
+func (r *CoachClientRepository) GetInvitation(ctx context.Context, id uuid.UUID) (*domain.Invitation, error) {
+	var model pgCoachInvitation
+	if err := dbFromContext(ctx, r.db).Where("id = ?", id.String()).First(&model).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, repo.ErrNotFound
+		}
+		return nil, err
+	}
+	return model.toDomain()
+}
+
+// ListInvitationsByCoach возвращает действующие приглашения тренера, новые первыми.
+func (r *CoachClientRepository) ListInvitationsByCoach(ctx context.Context, coachID uuid.UUID, now time.Time) ([]*domain.Invitation, error) {
+	return r.listInvitations(ctx, "coach_id = ? AND expires_at > ?", coachID, now)
+}
+
+// ListInvitationsByClient возвращает действующие приглашения пользователя, новые первыми.
+func (r *CoachClientRepository) ListInvitationsByClient(ctx context.Context, clientID uuid.UUID, now time.Time) ([]*domain.Invitation, error) {
+	return r.listInvitations(ctx, "client_id = ? AND expires_at > ?", clientID, now)
+}
+
+func (r *CoachClientRepository) listInvitations(ctx context.Context, where string, userID uuid.UUID, now time.Time) ([]*domain.Invitation, error) {
+	var models []pgCoachInvitation
+	err := dbFromContext(ctx, r.db).
+		Where(where, userID.String(), now).
+		Order("created_at DESC, id").
+		Find(&models).Error
+	if err != nil {
+		return nil, err
+	}
+
+	invitations := make([]*domain.Invitation, 0, len(models))
+	for i := range models {
+		inv, err := models[i].toDomain()
+		if err != nil {
+			return nil, err
+		}
+		invitations = append(invitations, inv)
+	}
+	return invitations, nil
+}
+
+// DeleteInvitation удаляет приглашение.
+func (r *CoachClientRepository) DeleteInvitation(ctx context.Context, id uuid.UUID) error {
+	result := dbFromContext(ctx, r.db).Where("id = ?", id.String()).Delete(&pgCoachInvitation{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return repo.ErrNotFound
+	}
+	return nil
+}
+
+// AddClient сохраняет связь «тренер — клиент»; существующая связь не меняется.
+func (r *CoachClientRepository) AddClient(ctx context.Context, c *domain.Client) error {
+	return dbFromContext(ctx, r.db).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&pgCoachClient{
+			CoachID:   c.CoachID.String(),
+			ClientID:  c.ClientID.String(),
+			CreatedAt: c.CreatedAt,
+		}).Error
+}
+
+// IsClient сообщает, есть ли связь «тренер — клиент».
+func (r *CoachClientRepository) IsClient(ctx context.Context, coachID, clientID uuid.UUID) (bool, error) {
+	var count int64
+	err := dbFromContext(ctx, r.db).
+		Model(&pgCoachClient{}).
+		Where("coach_id = ? AND client_id = ?", coachID.String(), clientID.String()).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// ListClientIDs возвращает клиентов тренера, упорядоченных по ID.
+func (r *CoachClientRepository) ListClientIDs(ctx context.Context, coachID uuid.UUID) ([]uuid.UUID, error) {
+	var raw []string
+	err := dbFromContext(ctx, r.db).
+		Model(&pgCoachClient{}).
+		Where("coach_id = ?", coachID.String()).
+		Order("client_id").
+		Pluck("client_id", &raw).Error
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]uuid.UUID, 0, len(raw))
+	for _, v := range raw {
+		id, err := uuid.Parse(v)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// DeleteClient удаляет связь «тренер — клиент».
+func (r *CoachClientRepository) DeleteClient(ctx context.Context, coachID, clientID uuid.UUID) error {
+	return dbFromContext(ctx, r.db).
+		Where("coach_id = ? AND client_id = ?", coachID.String(), clientID.String()).
+		Delete(&pgCoachClient{}).Error
+}
+
+// DeleteByUserID удаляет приглашения и связи, где пользователь выступает тренером или клиентом.
+func (r *CoachClientRepository) DeleteByUserID(ctx context.Context, userID uuid.UUID) error {
+	db := dbFromContext(ctx, r.db)
+	if err := db.Where("coach_id = ? OR client_id = ?", userID.String(), userID.String()).Delete(&pgCoachInvitation{}).Error; err != nil {
+		return err
+	}
+	return db.Where("coach_id = ? OR client_id = ?", userID.String(), userID.String()).Delete(&pgCoachClient{}).Error
+}
@@ -0,0 +1,251 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	domain "workout-app/internal/domain/coach"
+	repo "workout-app/internal/repository/interfaces"
+)
+
+// pgCoachNote представляет ORM-модель для таблицы coach_client_notes.
+type pgCoachNote struct {
+	ID        string    `gorm:"column:id;type:uuid;primaryKey"`
+	CoachID   string    `gorm:"column:coach_id;type:uuid;not null"`
+	ClientID  string    `gorm:"column:client_id;type:uuid;not null"`
+	Body      string    `gorm:"column:body;type:text;not null"`
+	CreatedAt time.Time `gorm:"column:created_at;type:timestamptz;not null"`
+	UpdatedAt time.Time `gorm:"column:updated_at;type:timestamptz;not null"`
+}
+
+func (pgCoachNote) TableName() string {
+	return "coach_client_notes"
+}
+
+func (m *pgCoachNote) toDomain() (*domain.Note, error) {
+	id, err := uuid.Parse(m.ID)
+	if err != nil {
+		return nil, err
+	}
+	coachID, err := uuid.Parse(m.CoachID)
+	if err != nil {
+		return nil, err
+	}
+	clientID, err := uuid.Parse(m.ClientID)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.Note{
+		ID:        id,
+		CoachID:   coachID,
+		ClientID:  clientID,
+		Body:      m.Body,
+		CreatedAt: m.CreatedAt,
+		UpdatedAt: m.UpdatedAt,
+	}, nil
+}
+
+// pgCoachTag представляет ORM-модель для таблицы coach_client_tags.
+type pgCoachTag struct {
+	CoachID   string    `gorm:"column:coach_id;type:uuid;primaryKey"`
+	ClientID  string    `gorm:"column:client_id;type:uuid;primaryKey"`
+	Tag       string    `gorm:"column:tag;type:varchar(32);primaryKey"`
+	CreatedAt time.Time `gorm:"column:created_at;type:timestamptz;not null"`
+}
+
+func (pgCoachTag) TableName() string {
+	return "coach_client_tags"
+}
+
+// CoachNoteRepository реализует repo.CoachNoteRepository на GORM/Postgres.
+type CoachNoteRepository struct {
+	db *gorm.DB
+}
+
+// Убедимся на этапе компиляции, что структура реализует интерфейс.
+var _ repo.CoachNoteRepository = (*CoachNoteRepository)(nil)
+
+// NewCoachNoteRepository создает новый репозиторий заметок тренера о клиентах.
+func NewCoachNoteRepository(db *gorm.DB) *CoachNoteRepository {
+	return &CoachNoteRepository{db: db}
+}
+
+// CreateNote сохраняет новую заметку.
+func (r *CoachNoteRepository) CreateNote(ctx context.Context, n *domain.Note) error {
+	return dbFromContext(ctx, r.db).Create(&pgCoachNote{
+		ID:        n.ID.String(),
+		CoachID:   n.CoachID.String(),
+		ClientID:  n.ClientID.String(),
+		Body:      n.Body,
+		CreatedAt: n.CreatedAt,
+		UpdatedAt: n.UpdatedAt,
+	}).Error
+}
+
+// GetNote возвращает заметку тренера о клиенте.
+func (r *CoachNoteRepository) GetNote(ctx context.Context, coachID, clientID, id uuid.UUID) (*domain.Note, error) {
+	var model pgCoachNote
+	err := dbFromContext(ctx, r.db).
+		Where("id = ? AND coach_id = ? AND client_id = ?", id.String(), coachID.String(), clientID.String()).
+		First(&model).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, repo.ErrNotFound
+		}
+		return nil, err
+	}
+	return model.toDomain()
+}
+
+// UpdateNote сохраняет текст и время изменения заметки.
+func (r *CoachNoteRepository) UpdateNote(ctx context.Context, n *domain.Note) error {
+	result := dbFromContext(ctx, r.db).
+		Model(&pgCoachNote{}).
+		Where("id = ? AND coach_id = ? AND client_id = ?", n.ID.String(), n.CoachID.String(), n.ClientID.String()).
+		Updates(map[string]any{"body": n.Body, "updated_at": n.UpdatedAt})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return repo.ErrNotFound
+	}
+	return nil
+}
+
+// DeleteNote удаляет заметку тренера о клиенте.
+func (r *CoachNoteRepository) DeleteNote(ctx context.Context, coachID, clientID, id uuid.UUID) error {
+	result := dbFromContext(ctx, r.db).
+		Where("id = ? AND coach_id = ? AND client_id = ?", id.String(), coachID.String(), clientID.String()).
+		Delete(&pgCoachNote{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return repo.ErrNotFound
+	}
+	return nil
+}
+
+// ListNotes возвращает заметки тренера о клиенте, новые первыми.
+func (r *CoachNoteRepository) ListNotes(ctx context.Context, coachID, clientID uuid.UUID) ([]*domain.Note, error) {
+	var models []pgCoachNote
+	err := dbFromContext(ctx, r.db).
+		Where("coach_id = ? AND client_id = ?", coachID.String(), clientID.String()).
+		Order("created_at DESC, id").
+		Find(&models).Error
+	if err != nil {
+		return nil, err
+	}
+
+	notes := make([]*domain.Note, 0, len(models))
+	for i := range models {
+		n, err := models[i].toDomain()
+		if err != nil {
+			return nil, err
+		}
+		notes = append(notes, n)
+	}
+	return notes, nil
+}
+
+// ReplaceTags заменяет теги тренера для клиента в одной транзакции.
+func (r *CoachNoteRepository) ReplaceTags(ctx context.Context, coachID, clientID uuid.UUID, tags []string) error {
+	return dbFromContext(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		err := tx.
+			Where("coach_id = ? AND client_id = ?", coachID.String(), clientID.String()).
+			Delete(&pgCoachTag{}).Error
+		if err != nil || len(tags) == 0 {
+			return err
+		}
+
+		now := time.Now().UTC()
+		models := make([]pgCoachTag, 0, len(tags))
+		for _, tag := range tags {
+			models = append(models, pgCoachTag{
+				CoachID:   coachID.String(),
+				ClientID:  clientID.String(),
+				Tag:       tag,
+				CreatedAt: now,
+			})
+		}
+		return tx.Create(&models).Error
+	})
+}
+
+// ListTags возвращает теги тренера для клиента по алфавиту.
+func (r *CoachNoteRepository) ListTags(ctx context.Context, coachID, clientID uuid.UUID) ([]string, error) {
+	tags := []string{}
+	err := dbFromContext(ctx, r.db).
+		Model(&pgCoachTag{}).
+		Where("coach_id = ? AND client_id = ?", coachID.String(), clientID.String()).
+		Order("tag").
+		Pluck("tag", &tags).Error
+	return tags, err
+}
+
+// ListTagsByClient возвращает теги тренера для всех его клиентов.
+func (r *CoachNoteRepository) ListTagsByClient(ctx context.Context, coachID uuid.UUID) (map[uuid.UUID][]string, error) {
+	var models []pgCoachTag
+	err := dbFromContext(ctx, r.db).
+		Where("coach_id = ?", coachID.String()).
+		Order("client_id, tag").
+		Find(&models).Error
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[uuid.UUID][]string)
+	for _, m := range models {
+		clientID, err := uuid.Parse(m.ClientID)
+		if err != nil {
+			return nil, err
+		}
+		result[clientID] = append(result[clientID], m.Tag)
+	}
+	return result, nil
+}
+
+// SearchClients ищет подстроку в заметках тренера. strpos вместо LIKE: запрос не требует экранирования % и _.
+func (r *CoachNoteRepository) SearchClients(ctx context.Context, coachID uuid.UUID, query string) ([]uuid.UUID, error) {
+	var raw []string
+	err := dbFromContext(ctx, r.db).
+		Model(&pgCoachNote{}).
+		Distinct("client_id").
+		Where("coach_id = ? AND strpos(lower(body), lower(?)) > 0", coachID.String(), query).
+		Pluck("client_id", &raw).Error
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]uuid.UUID, 0, len(raw))
+	for _, v := range raw {
+		id, err := uuid.Parse(v)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// DeleteByRelationship удаляет заметки и теги тренера о клиенте.
+func (r *CoachNoteRepository) DeleteByRelationship(ctx context.Context, coachID, clientID uuid.UUID) error {
+	db := dbFromContext(ctx, r.db)
+	if err := db.Where("coach_id = ? AND client_id = ?", coachID.String(), clientID.String()).Delete(&pgCoachNote{}).Error; err != nil {
+		return err
+	}
+	return db.Where("coach_id = ? AND client_id = ?", coachID.String(), clientID.String()).Delete(&pgCoachTag{}).Error
+}
+
+// DeleteByUserID удаляет заметки и теги, где пользователь выступает тренером или клиентом.
+func (r *CoachNoteRepository) DeleteByUserID(ctx context.Context, userID uuid.UUID) error {
+	db := dbFromContext(ctx, r.db)
+	if err := db.Where("coach_id = ? OR client_id = ?", userID.String(), userID.String()).Delete(&pgCoachNote{}).Error; err != nil {
+		return err
+	}
+	return db.Where("coach_id = ? OR client_id = ?", userID.String(), userID.String()).Delete(&pgCoachTag{}).Error
+}
@@ -0,0 +1,267 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	domain "workout-app/internal/domain/coach"
+	userdomain "workout-app/internal/domain/user"
+	repo "workout-app/internal/repository/interfaces"
+)
+
+// pgCoachProfile представляет ORM-модель для таблицы coach_profiles.
+type pgCoachProfile struct {
+	UserID           string     `gorm:"column:user_id;type:uuid;primaryKey"`
+	Listed           bool       `gorm:"column:listed;type:boolean;not null"`
+	Headline         string     `gorm:"column:headline;type:varchar(120);not null"`
+	Bio              string     `gorm:"column:bio;type:text;not null"`
+	Specialties      string     `gorm:"column:specialties;type:jsonb;not null"`
+	Languages        string     `gorm:"column:languages;type:jsonb;not null"`
+	Country          string     `gorm:"column:country;type:varchar(2);not null"`
+	City             string     `gorm:"column:city;type:varchar(100);not null"`
+	Online           bool       `gorm:"column:online;type:boolean;not null"`
+	VerifiedAt       *time.Time `gorm:"column:verified_at;type:timestamptz"`
+	VerifiedBy       *string    `gorm:"column:verified_by;type:uuid"`
+	VerificationNote string     `gorm:"column:verification_note;type:text;not null"`
+	CreatedAt        time.Time  `gorm:"column:created_at;type:timestamptz;not null"`
+	UpdatedAt        time.Time  `gorm:"column:updated_at;type:timestamptz;not null"`
+}
+
+func (pgCoachProfile) TableName() string {
+	return "coach_profiles"
+}
+
+func (m *pgCoachProfile) toDomain() (*domain.Profile, error) {
+	userID, err := uuid.Parse(m.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &domain.Profile{
+		UserID:    userID,
+		Listed:    m.Listed,
+		Headline:  m.Headline,
+		Bio:       m.Bio,
+		Country:   m.Country,
+		City:      m.City,
+		Online:    m.Online,
+		CreatedAt: m.CreatedAt,
+		UpdatedAt: m.UpdatedAt,
+	}
+	if err := json.Unmarshal([]byte(m.Specialties), &p.Specialties); err != nil {
+		return nil, fmt.Errorf("failed to decode specialties: %w", err)
+	}
+	if err := json.Unmarshal([]byte(m.Languages), &p.Languages); err != nil {
+		return nil, fmt.Errorf("failed to decode languages: %w", err)
+	}
+	if m.VerifiedAt != nil {
+		p.Verification = &domain.Verification{VerifiedAt: *m.VerifiedAt, Note: m.VerificationNote}
+		if m.VerifiedBy != nil {
+			by, err := uuid.Parse(*m.VerifiedBy)
+			if err != nil {
+				return nil, err
+			}
+			p.Verification.VerifiedBy = &by
+		}
+	}
+	return p, nil
+}
+
+// pgCoachDirectoryRow — строка каталога: анкета и публичные поля пользователя.
+type pgCoachDirectoryRow struct {
+	pgCoachProfile
+	Username  string `gorm:"column:username"`
+	FirstName string `gorm:"column:first_name"`
+	LastName  string `gorm:"column:last_name"`
+	AvatarURL string `gorm:"column:avatar_url"`
+}
+
+// CoachProfileRepository реализует repo.CoachProfileRepository на GORM/Postgres.
+type CoachProfileRepository struct {
+	db *gorm.DB
+}
+
+// Убедимся на этапе компиляции, что структура реализует интерфейс.
+var _ repo.CoachProfileRepository = (*CoachProfileRepository)(nil)
+
+// NewCoachProfileRepository создает новый репозиторий анкет тренеров.
+func NewCoachProfileRepository(db *gorm.DB) *CoachProfileRepository {
+	return &CoachProfileRepository{db: db}
+}
+
+// Get возвращает анкету тренера.
+func (r *CoachProfileRepository) Get(ctx context.Context, userID uuid.UUID) (*domain.Profile, error) {
+	var model pgCoachProfile
+	err := dbFromContext(ctx, r.db).
+		Where("user_id = ?", userID.String()).
+		First(&model).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, repo.ErrNotFound
+		}
+		return nil, err
+	}
+	return model.toDomain()
+}
+
+// Save создаёт или обновляет анкету, не трогая отметку о проверке.
+func (r *CoachProfileRepository) Save(ctx context.Context, p *domain.Profile) error {
+	specialties, err := json.Marshal(nonNil(p.Specialties))
+	if err != nil {
+		return fmt.Errorf("failed to encode specialties: %w", err)
+	}
+	languages, err := json.Marshal(nonNil(p.Languages))
+	if err != nil {
+		return fmt.Errorf("failed to encode languages: %w", err)
+	}
+
+	model := &pgCoachProfile{
+		UserID:      p.UserID.String(),
+		Listed:      p.Listed,
+		Headline:    p.Headline,
+		Bio:         p.Bio,
+		Specialties: string(specialties),
+		Languages:   string(languages),
+		Country:     p.Country,
+		City:        p.City,
+		Online:      p.Online,
+		CreatedAt:   p.CreatedAt,
+		UpdatedAt:   p.UpdatedAt,
+	}
+	return dbFromContext(ctx, r.db).
+		Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "user_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{
+				"listed", "headline", "bio", "specialties", "languages", "country", "city", "online", "updated_at",
+			}),
+		}).
+		Create(model).Error
+}
+
+// SetVerification ставит или снимает отметку «проверенный тренер».
+func (r *CoachProfileRepository) SetVerification(ctx context.Context, userID uuid.UUID, v *domain.Verification) error {
+	db := dbFromContext(ctx, r.db)
+	if v == nil {
+		return db.Model(&pgCoachProfile{}).
+			Where("user_id = ?", userID.String()).
+			Updates(map[string]any{
+				"verified_at":       nil,
+				"verified_by":       nil,
+				"verification_note": "",
+			}).Error
+	}
+
+	now := time.Now().UTC()
+	model := &pgCoachProfile{
+		UserID:           userID.String(),
+		Specialties:      "[]",
+		Languages:        "[]",
+		VerifiedAt:       &v.VerifiedAt,
+		VerificationNote: v.Note,
+		CreatedAt:        now,
+		UpdatedAt:        now,
+	}
+	if v.VerifiedBy != nil {
+		by := v.VerifiedBy.String()
+		model.VerifiedBy = &by
+	}
+	return db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"verified_at", "verified_by", "verification_note"}),
+	}).Create(model).Error
+}
+
+// ListDirectory возвращает опубликованные анкеты активных тренеров по фильтру.
+// Удалённые, обезличенные и заблокированные пользователи, а также пользователи,
+// лишившиеся роли тренера, в каталог не попадают.
+func (r *CoachProfileRepository) ListDirectory(ctx context.Context, filter repo.CoachDirectoryFilter) ([]*domain.DirectoryEntry, int64, error) {
+	// Отдельные цепочки для подсчёта и выборки: GORM не переиспользует запрос после Count.
+	filtered := func() *gorm.DB {
+		query := dbFromContext(ctx, r.db).
+			Table("coach_profiles cp").
+			Joins("JOIN users u ON u.id = cp.user_id").
+			Where("cp.listed").
+			Where("u.role = ?", string(userdomain.RoleCoach)).
+			Where("u.deleted_at IS NULL AND u.anonymized_at IS NULL").
+			Where("(u.suspended_at IS NULL OR u.suspended_until <= ?)", time.Now().UTC())
+		if filter.Specialty != "" {
+			query = query.Where("cp.specialties @> ?::jsonb", jsonArray(string(filter.Specialty)))
+		}
+		if filter.Language != "" {
+			query = query.Where("cp.languages @> ?::jsonb", jsonArray(filter.Language))
+		}
+		if filter.Country != "" {
+			query = query.Where("cp.country = ?", filter.Country)
+		}
+		if filter.City != "" {
+			query = query.Where("lower(cp.city) = lower(?)", filter.City)
+		}
+		if filter.Online != nil {
+			query = query.Where("cp.online = ?", *filter.Online)
+		}
+		if filter.VerifiedOnly {
+			query = query.Where("cp.verified_at IS NOT NULL")
+		}
+		return query
+	}
+
+	var total int64
+	if err := filtered().Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var rows []pgCoachDirectoryRow
+	err := filtered().
+		Select("cp.*, u.username, u.first_name, u.last_name, u.avatar_url").
+		Order("cp.verified_at IS NULL, u.username").
+		Limit(filter.Limit).
+		Offset(filter.Offset).
+		Find(&rows).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	entries := make([]*domain.DirectoryEntry, 0, len(rows))
+	for i := range rows {
+		p, err := rows[i].toDomain()
+		if err != nil {
+			return nil, 0, err
+		}
+		entries = append(entries, &domain.DirectoryEntry{
+			Profile:   *p,
+			Username:  rows[i].Username,
+			FirstName: rows[i].FirstName,
+			LastName:  rows[i].LastName,
+			AvatarURL: rows[i].AvatarURL,
+		})
+	}
+	return entries, total, nil
+}
+
+// DeleteByUserID удаляет анкету тренера.
+func (r *CoachProfileRepository) DeleteByUserID(ctx context.Context, userID uuid.UUID) error {
+	return dbFromContext(ctx, r.db).
+		Where("user_id = ?", userID.String()).
+		Delete(&pgCoachProfile{}).Error
+}
+
+// jsonArray кодирует значение как JSON-массив из одного элемента для сравнения через @>.
+func jsonArray(value string) string {
+	raw, _ := json.Marshal([]string{value})
+	return string(raw)
+}
+
+// nonNil заменяет nil-срез пустым, чтобы в JSONB записывался [] вместо null.
+func nonNil[T any](items []T) []T {
+	if items == nil {
+		return []T{}
+	}
+	return items
+}
@@ -0,0 +1,147 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	domain "workout-app/internal/domain/consent"
+	repo "workout-app/internal/repository/interfaces"
+)
+
+// pgConsent представляет ORM-модель для таблицы coach_consents.
+type pgConsent struct {
+	ClientID  string    `gorm:"column:client_id;type:uuid;primaryKey"`
+	CoachID   string    `gorm:"column:coach_id;type:uuid;primaryKey"`
+	Scopes    string    `gorm:"column:scopes;type:jsonb;not null"`
+	UpdatedAt time.Time `gorm:"column:updated_at;type:timestamptz;not null"`
+}
+
+func (pgConsent) TableName() string {
+	return "coach_consents"
+}
+
+func (m *pgConsent) toDomain() (*domain.Consent, error) {
+	clientID, err := uuid.Parse(m.ClientID)
+	if err != nil {
+		return nil, err
+	}
+	coachID, err := uuid.Parse(m.CoachID)
+	if err != nil {
+		return nil, err
+	}
+
+	var scopes []domain.Scope
+	if err := json.Unmarshal([]byte(m.Scopes), &scopes); err != nil {
+		return nil, fmt.Errorf("failed to decode scopes: %w", err)
+	}
+
+	return &domain.Consent{
+		ClientID:  clientID,
+		CoachID:   coachID,
+		Scopes:    scopes,
+		UpdatedAt: m.UpdatedAt,
+	}, nil
+}
+
+// ConsentRepository реализует repo.ConsentRepository на GORM/Postgres.
+type ConsentRepository struct {
+	db *gorm.DB
+}
+
+// Убедимся на этапе компиляции, что структура реализует интерфейс.
+var _ repo.ConsentRepository = (*ConsentRepository)(nil)
+
+// NewConsentRepository создает новый репозиторий согласий на доступ тренеров.
+func NewConsentRepository(db *gorm.DB) *ConsentRepository {
+	return &ConsentRepository{db: db}
+}
+
+// Get возвращает согласие клиента для тренера.
+func (r *ConsentRepository) Get(ctx context.Context, clientID, coachID uuid.UUID) (*domain.Consent, error) {
+	var model pgConsent
+	err := dbFromContext(ctx, r.db).
+		Where("client_id = ? AND coach_id = ?", clientID.String(), coachID.String()).
+		First(&model).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, repo.ErrNotFound
+		}
+		return nil, err
+	}
+	return model.toDomain()
+}
+
+// ListByClient возвращает все согласия клиента, начиная с последних изменённых.
+func (r *ConsentRepository) ListByClient(ctx context.Context, clientID uuid.UUID) ([]*domain.Consent, error) {
+	var models []pgConsent
+	err := dbFromContext(ctx, r.db).
+		Where("client_id = ?", clientID.String()).
+		Order("updated_at DESC").
+		Find(&models).Error
+	if err != nil {
+		return nil, err
+	}
+
+	consents := make([]*domain.Consent, 0, len(models))
+	for i := range models {
+		c, err := models[i].toDomain()
+		if err != nil {
+			return nil, err
+		}
+		consents = append(consents, c)
+	}
+	return consents, nil
+}
+
+// Upsert создаёт или заменяет согласие клиента для тренера.
+func (r *ConsentRepository) Upsert(ctx context.Context, c *domain.Consent) error {
+	scopes := c.Scopes
+	if scopes == nil {
+		scopes = []domain.Scope{}
+	}
+	raw, err := json.Marshal(scopes)
+	if err != nil {
+		return fmt.Errorf("failed to encode scopes: %w", err)
+	}
+
+	model := &pgConsent{
+		ClientID:  c.ClientID.String(),
+		CoachID:   c.CoachID.String(),
+		Scopes:    string(raw),
+		UpdatedAt: c.UpdatedAt,
+	}
+	return dbFromContext(ctx, r.db).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "client_id"}, {Name: "coach_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"scopes", "updated_at"}),
+		}).
+		Create(model).Error
+}
+
+// Delete отзывает согласие клиента для тренера.
+func (r *ConsentRepository) Delete(ctx context.Context, clientID, coachID uuid.UUID) error {
+	result := dbFromContext(ctx, r.db).
+		Where("client_id = ? AND coach_id = ?", clientID.String(), coachID.String()).
+		Delete(&pgConsent{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return repo.ErrNotFound
+	}
+	return nil
+}
+
+// DeleteByUserID удаляет согласия, где пользователь выступает клиентом или тренером.
+func (r *ConsentRepository) DeleteByUserID(ctx context.Context, userID uuid.UUID) error {
+	return dbFromContext(ctx, r.db).
+		Where("client_id = ? OR coach_id = ?", userID.String(), userID.String()).
+		Delete(&pgConsent{}).Error
+}
@@ -0,0 +1,296 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	domain "workout-app/internal/domain/custommetric"
+	repo "workout-app/internal/repository/interfaces"
+)
+
+// pgCustomMetric представляет ORM-модель для таблицы custom_metrics.
+type pgCustomMetric struct {
+	ID             string    `gorm:"column:id;type:uuid;primaryKey"`
+	UserID         string    `gorm:"column:user_id;type:uuid;not null"`
+	Name           string    `gorm:"column:name;type:varchar(64);not null"`
+	Unit           string    `gorm:"column:unit;type:varchar(16);not null"`
+	HigherIsBetter bool      `gorm:"column:higher_is_better;not null"`
+	CreatedAt      time.Time `gorm:"column:created_at;type:timestamptz;not null"`
+	UpdatedAt      time.Time `gorm:"column:updated_at;type:timestamptz;not null"`
+}
+
+func (pgCustomMetric) TableName() string {
+	return "custom_metrics"
+}
+
+func newPgCustomMetric(d *domain.Definition) *pgCustomMetric {
+	return &pgCustomMetric{
+		ID:             d.ID.String(),
+		UserID:         d.UserID.String(),
+		Name:           d.Name,
+		Unit:           d.Unit,
+		HigherIsBetter: d.HigherIsBetter,
+		CreatedAt:      d.CreatedAt,
+		UpdatedAt:      d.UpdatedAt,
+	}
+}
+
+func (m *pgCustomMetric) toDomain() (*domain.Definition, error) {
+	id, err := uuid.Parse(m.ID)
+	if err != nil {
+		return nil, err
+	}
+	userID, err := uuid.Parse(m.UserID)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.Definition{
+		ID:             id,
+		UserID:         userID,
+		Name:           m.Name,
+		Unit:           m.Unit,
+		HigherIsBetter: m.HigherIsBetter,
+		CreatedAt:      m.CreatedAt,
+		UpdatedAt:      m.UpdatedAt,
+	}, nil
+}
+
+// pgCustomMetricEntry представляет ORM-модель для таблицы custom_metric_entries.
+type pgCustomMetricEntry struct {
+	ID         string    `gorm:"column:id;type:uuid;primaryKey"`
+	MetricID   string    `gorm:"column:metric_id;type:uuid;not null"`
+	UserID     string    `gorm:"column:user_id;type:uuid;not null"`
+	Value      float64   `gorm:"column:value;not null"`
+	RecordedAt time.Time `gorm:"column:recorded_at;type:timestamptz;not null"`
+	Note       string    `gorm:"column:note;type:varchar(500);not null"`
+	CreatedAt  time.Time `gorm:"column:created_at;type:timestamptz;not null"`
+}
+
+func (pgCustomMetricEntry) TableName() string {
+	return "custom_metric_entries"
+}
+
+func newPgCustomMetricEntry(e *domain.Entry) *pgCustomMetricEntry {
+	return &pgCustomMetricEntry{
+		ID:         e.ID.String(),
+		MetricID:   e.DefinitionID.String(),
+		UserID:     e.UserID.String(),
+		Value:      e.Value,
+		RecordedAt: e.RecordedAt,
+		Note:       e.Note,
+		CreatedAt:  e.CreatedAt,
+	}
+}
+
+func (m *pgCustomMetricEntry) toDomain() (*domain.Entry, error) {
+	id, err := uuid.Parse(m.ID)
+	if err != nil {
+		return nil, err
+	}
+	metricID, err := uuid.Parse(m.MetricID)
+	if err != nil {
+		return nil, err
+	}
+	userID, err := uuid.Parse(m.UserID)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.Entry{
+		ID:           id,
+		DefinitionID: metricID,
+		UserID:       userID,
+		Value:        m.Value,
+		RecordedAt:   m.RecordedAt,
+		Note:         m.Note,
+		CreatedAt:    m.CreatedAt,
+	}, nil
+}
+
+// CustomMetricRepository реализует repo.CustomMetricRepository на GORM/Postgres.
+type CustomMetricRepository struct {
+	db *gorm.DB
+}
+
+// Убедимся на этапе компиляции, что структура реализует интерфейс.
+var _ repo.CustomMetricRepository = (*CustomMetricRepository)(nil)
+
+// NewCustomMetricRepository создает новый репозиторий пользовательских метрик.
+func NewCustomMetricRepository(db *gorm.DB) *CustomMetricRepository {
+	return &CustomMetricRepository{db: db}
+}
+
+// Create сохраняет новую метрику.
+func (r *CustomMetricRepository) Create(ctx context.Context, d *domain.Definition) error {
+	if err := dbFromContext(ctx, r.db).Create(newPgCustomMetric(d)).Error; err != nil {
+		if isUniqueViolation(err, "idx_custom_metrics_user_name") {
+			return repo.ErrCustomMetricExists
+		}
+		return err
+	}
+	return nil
+}
+
+// Update сохраняет изменения метрики.
+func (r *CustomMetricRepository) Update(ctx context.Context, d *domain.Definition) error {
+	result := dbFromContext(ctx, r.db).
+		Model(&pgCustomMetric{}).
+		Where("id = ? AND user_id = ?", d.ID.String(), d.UserID.String()).
+		Updates(map[string]any{
+			"name":             d.Name,
+			"unit":             d.Unit,
+			"higher_is_better": d.HigherIsBetter,
+			"updated_at":       d.UpdatedAt,
+		})
+	if result.Error != nil {
+		if isUniqueViolation(result.Error, "idx_custom_metrics_user_name") {
+			return repo.ErrCustomMetricExists
+		}
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return repo.ErrNotFound
+	}
+	return nil
+}
+
+// GetByID возвращает метрику пользователя по ID.
+func (r *CustomMetricRepository) GetByID(ctx context.Context, userID, id uuid.UUID) (*domain.Definition, error) {
+	var model pgCustomMetric
+	err := dbFromContext(ctx, r.db).
+		Where("id = ? AND user_id = ?", id.String(), userID.String()).
+		First(&model).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, repo.ErrNotFound
+		}
+		return nil, err
+	}
+	return model.toDomain()
+}
+
+// ListByUser возвращает метрики пользователя, отсортированные по названию.
+func (r *CustomMetricRepository) ListByUser(ctx context.Context, userID uuid.UUID) ([]*domain.Definition, error) {
+	var models []pgCustomMetric
+	err := dbFromContext(ctx, r.db).
+		Where("user_id = ?", userID.String()).
+		Order("LOWER(name)").
+		Find(&models).Error
+	if err != nil {
+		return nil, err
+	}
+
+	defs := make([]*domain.Definition, 0, len(models))
+	for i := range models {
+		d, err := models[i].toDomain()
+		if err != nil {
+			return nil, err
+		}
+		defs = append(defs, d)
+	}
+	return defs, nil
+}
+
+// CountByUser возвращает количество метрик пользователя.
+func (r *CustomMetricRepository) CountByUser(ctx context.Context, userID uuid.UUID) (int64, error) {
+	var count int64
+	err := dbFromContext(ctx, r.db).
+		Model(&pgCustomMetric{}).
+		Where("user_id = ?", userID.String()).
+		Count(&count).Error
+	return count, err
+}
+
+// Delete удаляет метрику; значения удаляются каскадно.
+func (r *CustomMetricRepository) Delete(ctx context.Context, userID, id uuid.UUID) error {
+	result := dbFromContext(ctx, r.db).
+		Where("id = ? AND user_id = ?", id.String(), userID.String()).
+		Delete(&pgCustomMetric{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return repo.ErrNotFound
+	}
+	return nil
+}
+
+// AddEntry сохраняет значение метрики.
+func (r *CustomMetricRepository) AddEntry(ctx context.Context, e *domain.Entry) error {
+	return dbFromContext(ctx, r.db).Create(newPgCustomMetricEntry(e)).Error
+}
+
+// ListEntries возвращает значения метрики за период, старые первыми.
+func (r *CustomMetricRepository) ListEntries(ctx context.Context, metricID uuid.UUID, from, to time.Time, limit int) ([]*domain.Entry, error) {
+	return r.listEntries(dbFromContext(ctx, r.db).
+		Where("metric_id = ? AND recorded_at >= ? AND recorded_at < ?", metricID.String(), from, to).
+		Order("recorded_at").
+		Limit(limit))
+}
+
+// LatestEntries возвращает последнее значение каждой метрики пользователя.
+func (r *CustomMetricRepository) LatestEntries(ctx context.Context, userID uuid.UUID) (map[uuid.UUID]*domain.Entry, error) {
+	entries, err := r.listEntries(dbFromContext(ctx, r.db).
+		Select("DISTINCT ON (metric_id) *").
+		Where("user_id = ?", userID.String()).
+		Order("metric_id, recorded_at DESC"))
+	if err != nil {
+		return nil, err
+	}
+
+	latest := make(map[uuid.UUID]*domain.Entry, len(entries))
+	for _, e := range entries {
+		latest[e.DefinitionID] = e
+	}
+	return latest, nil
+}
+
+// DeleteEntry удаляет значение метрики.
+func (r *CustomMetricRepository) DeleteEntry(ctx context.Context, metricID, entryID uuid.UUID) error {
+	result := dbFromContext(ctx, r.db).
+		Where("id = ? AND metric_id = ?", entryID.String(), metricID.String()).
+		Delete(&pgCustomMetricEntry{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return repo.ErrNotFound
+	}
+	return nil
+}
+
+// ListEntriesByUser возвращает все значения метрик пользователя.
+func (r *CustomMetricRepository) ListEntriesByUser(ctx context.Context, userID uuid.UUID) ([]*domain.Entry, error) {
+	return r.listEntries(dbFromContext(ctx, r.db).
+		Where("user_id = ?", userID.String()).
+		Order("recorded_at"))
+}
+
+// DeleteByUserID удаляет все метрики и значения пользователя.
+func (r *CustomMetricRepository) DeleteByUserID(ctx context.Context, userID uuid.UUID) error {
+	db := dbFromContext(ctx, r.db)
+	if err := db.Where("user_id = ?", userID.String()).Delete(&pgCustomMetricEntry{}).Error; err != nil {
+		return err
+	}
+	return db.Where("user_id = ?", userID.String()).Delete(&pgCustomMetric{}).Error
+}
+
+func (r *CustomMetricRepository) listEntries(query *gorm.DB) ([]*domain.Entry, error) {
+	var models []pgCustomMetricEntry
+	if err := query.Find(&models).Error; err != nil {
+		return nil, err
+	}
+
+	entries := make([]*domain.Entry, 0, len(models))
+	for i := range models {
+		e, err := models[i].toDomain()
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, e)
+	}
+	return entries, nil
+}
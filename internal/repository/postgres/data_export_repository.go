@@ -0,0 +1,198 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	domain "workout-app/internal/domain/export"
+	repo "workout-app/internal/repository/interfaces"
+)
+
+// pgDataExport представляет ORM-модель для таблицы data_exports.
+type pgDataExport struct {
+	ID           string     `gorm:"column:id;type:uuid;primaryKey"`
+	UserID       string     `gorm:"column:user_id;type:uuid;not null"`
+	Status       string     `gorm:"column:status;type:varchar(16);not null"`
+	StorageKey   string     `gorm:"column:storage_key;type:varchar(512);not null"`
+	SizeBytes    int64      `gorm:"column:size_bytes;not null"`
+	Error        string     `gorm:"column:error;type:text;not null"`
+	RequestedAt  time.Time  `gorm:"column:requested_at;type:timestamptz;not null"`
+	CompletedAt  *time.Time `gorm:"column:completed_at;type:timestamptz"`
+	ExpiresAt    *time.Time `gorm:"column:expires_at;type:timestamptz"`
+	ClaimedUntil *time.Time `gorm:"column:claimed_until;type:timestamptz"`
+}
+
+func (pgDataExport) TableName() string {
+	return "data_exports"
+}
+
+func newPgDataExport(e *domain.Export) *pgDataExport {
+	return &pgDataExport{
+		ID:          e.ID.String(),
+		UserID:      e.UserID.String(),
+		Status:      string(e.Status),
+		StorageKey:  e.StorageKey,
+		SizeBytes:   e.SizeBytes,
+		Error:       e.Error,
+		RequestedAt: e.RequestedAt,
+		CompletedAt: e.CompletedAt,
+		ExpiresAt:   e.ExpiresAt,
+	}
+}
+
+func (m *pgDataExport) toDomain() (*domain.Export, error) {
+	id, err := uuid.Parse(m.ID)
+	if err != nil {
+		return nil, err
+	}
+	userID, err := uuid.Parse(m.UserID)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.Export{
+		ID:          id,
+		UserID:      userID,
+		Status:      domain.Status(m.Status),
+		StorageKey:  m.StorageKey,
+		SizeBytes:   m.SizeBytes,
+		Error:       m.Error,
+		RequestedAt: m.RequestedAt,
+		CompletedAt: m.CompletedAt,
+		ExpiresAt:   m.ExpiresAt,
+	}, nil
+}
+
+// DataExportRepository реализует repo.DataExportRepository на GORM/Postgres.
+type DataExportRepository struct {
+	db *gorm.DB
+}
+
+// Убедимся на этапе компиляции, что структура реализует интерфейс.
+var _ repo.DataExportRepository = (*DataExportRepository)(nil)
+
+// NewDataExportRepository создает новый репозиторий выгрузок данных аккаунта.
+func NewDataExportRepository(db *gorm.DB) *DataExportRepository {
+	return &DataExportRepository{db: db}
+}
+
+// Create сохраняет выгрузку, поставленную в очередь.
+func (r *DataExportRepository) Create(ctx context.Context, e *domain.Export) error {
+	if err := dbFromContext(ctx, r.db).Create(newPgDataExport(e)).Error; err != nil {
+		if isUniqueViolation(err, "idx_data_exports_one_pending") {
+			return repo.ErrExportPending
+		}
+		return err
+	}
+	return nil
+}
+
+// GetByID возвращает выгрузку по ID.
+func (r *DataExportRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Export, error) {
+	return r.getOne(dbFromContext(ctx, r.db).Where("id = ?", id.String()))
+}
+
+// GetLatestByUser возвращает последнюю выгрузку пользователя.
+func (r *DataExportRepository) GetLatestByUser(ctx context.Context, userID uuid.UUID) (*domain.Export, error) {
+	return r.getOne(dbFromContext(ctx, r.db).Where("user_id = ?", userID.String()).Order("requested_at DESC"))
+}
+
+func (r *DataExportRepository) getOne(query *gorm.DB) (*domain.Export, error) {
+	var model pgDataExport
+	if err := query.First(&model).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, repo.ErrNotFound
+		}
+		return nil, err
+	}
+	return model.toDomain()
+}
+
+// ListPending возвращает выгрузки в очереди, старые первыми.
+func (r *DataExportRepository) ListPending(ctx context.Context, limit int) ([]*domain.Export, error) {
+	return r.list(dbFromContext(ctx, r.db).
+		Where("status = ?", string(domain.StatusPending)).
+		Order("requested_at").
+		Limit(limit))
+}
+
+// Claim захватывает сборку выгрузки до момента until.
+func (r *DataExportRepository) Claim(ctx context.Context, id uuid.UUID, until time.Time) (bool, error) {
+	result := dbFromContext(ctx, r.db).
+		Model(&pgDataExport{}).
+		Where("id = ? AND status = ?", id.String(), string(domain.StatusPending)).
+		Where("claimed_until IS NULL OR claimed_until < ?", time.Now().UTC()).
+		Update("claimed_until", until)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+// Complete сохраняет результат сборки выгрузки.
+func (r *DataExportRepository) Complete(ctx context.Context, e *domain.Export) error {
+	result := dbFromContext(ctx, r.db).
+		Model(&pgDataExport{}).
+		Where("id = ?", e.ID.String()).
+		Updates(map[string]any{
+			"status":        string(e.Status),
+			"storage_key":   e.StorageKey,
+			"size_bytes":    e.SizeBytes,
+			"error":         e.Error,
+			"completed_at":  e.CompletedAt,
+			"expires_at":    e.ExpiresAt,
+			"claimed_until": nil,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return repo.ErrNotFound
+	}
+	return nil
+}
+
+// ListExpired возвращает выгрузки с истёкшим сроком хранения.
+func (r *DataExportRepository) ListExpired(ctx context.Context, now time.Time, limit int) ([]*domain.Export, error) {
+	return r.list(dbFromContext(ctx, r.db).
+		Where("expires_at IS NOT NULL AND expires_at <= ?", now).
+		Order("expires_at").
+		Limit(limit))
+}
+
+func (r *DataExportRepository) list(query *gorm.DB) ([]*domain.Export, error) {
+	var models []pgDataExport
+	if err := query.Find(&models).Error; err != nil {
+		return nil, err
+	}
+
+	exports := make([]*domain.Export, 0, len(models))
+	for i := range models {
+		e, err := models[i].toDomain()
+		if err != nil {
+			return nil, err
+		}
+		exports = append(exports, e)
+	}
+	return exports, nil
+}
+
+// Delete удаляет запись о выгрузке.
+func (r *DataExportRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	return dbFromContext(ctx, r.db).Where("id = ?", id.String()).Delete(&pgDataExport{}).Error
+}
+
+// ExpireByUserID сокращает срок хранения выгрузок пользователя и снимает их с очереди.
+func (r *DataExportRepository) ExpireByUserID(ctx context.Context, userID uuid.UUID, at time.Time) error {
+	return dbFromContext(ctx, r.db).
+		Model(&pgDataExport{}).
+		Where("user_id = ?", userID.String()).
+		Updates(map[string]any{
+			"status":     gorm.Expr("CASE WHEN status = ? THEN ? ELSE status END", string(domain.StatusPending), string(domain.StatusFailed)),
+			"error":      gorm.Expr("CASE WHEN status = ? THEN ? ELSE error END", string(domain.StatusPending), "account anonymized"),
+			"expires_at": at,
+		}).Error
+}
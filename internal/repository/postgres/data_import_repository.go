@@ -0,0 +1,391 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	domain "workout-app/internal/domain/dataimport"
+	repo "workout-app/internal/repository/interfaces"
+)
+
+// pgDataImport представляет ORM-модель для таблицы data_imports.
+type pgDataImport struct {
+	ID           string     `gorm:"column:id;type:uuid;primaryKey"`
+	UserID       string     `gorm:"column:user_id;type:uuid;not null"`
+	Source       string     `gorm:"column:source;type:varchar(64);not null"`
+	Status       string     `gorm:"column:status;type:varchar(16);not null"`
+	Total        int        `gorm:"column:total;not null"`
+	Processed    int        `gorm:"column:processed;not null"`
+	Imported     int        `gorm:"column:imported;not null"`
+	Invalid      int        `gorm:"column:invalid;not null"`
+	Duplicates   int        `gorm:"column:duplicates;not null"`
+	LastError    string     `gorm:"column:last_error;type:text;not null"`
+	CreatedAt    time.Time  `gorm:"column:created_at;type:timestamptz;not null"`
+	StartedAt    *time.Time `gorm:"column:started_at;type:timestamptz"`
+	FinishedAt   *time.Time `gorm:"column:finished_at;type:timestamptz"`
+	ClaimedUntil *time.Time `gorm:"column:claimed_until;type:timestamptz"`
+}
+
+func (pgDataImport) TableName() string {
+	return "data_imports"
+}
+
+// pgDataImportRecord представляет ORM-модель для таблицы data_import_records.
+type pgDataImportRecord struct {
+	ImportID   string  `gorm:"column:import_id;type:uuid;primaryKey"`
+	Line       int     `gorm:"column:line;primaryKey"`
+	UserID     string  `gorm:"column:user_id;type:uuid;not null"`
+	Kind       string  `gorm:"column:kind;type:varchar(16);not null"`
+	ExternalID string  `gorm:"column:external_id;type:varchar(128);not null"`
+	Payload    string  `gorm:"column:payload;type:text;not null"`
+	Status     string  `gorm:"column:status;type:varchar(16);not null"`
+	Error      string  `gorm:"column:error;type:text;not null"`
+	EntityID   *string `gorm:"column:entity_id;type:uuid"`
+}
+
+func (pgDataImportRecord) TableName() string {
+	return "data_import_records"
+}
+
+func (m *pgDataImport) toDomain() (*domain.Import, error) {
+	id, err := uuid.Parse(m.ID)
+	if err != nil {
+		return nil, err
+	}
+	userID, err := uuid.Parse(m.UserID)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.Import{
+		ID:         id,
+		UserID:     userID,
+		Source:     m.Source,
+		Status:     domain.Status(m.Status),
+		Total:      m.Total,
+		Processed:  m.Processed,
+		Imported:   m.Imported,
+		Invalid:    m.Invalid,
+		Duplicates: m.Duplicates,
+		LastError:  m.LastError,
+		CreatedAt:  m.CreatedAt,
+		StartedAt:  m.StartedAt,
+		FinishedAt: m.FinishedAt,
+	}, nil
+}
+
+func (m *pgDataImportRecord) toDomain() (*domain.Record, error) {
+	importID, err := uuid.Parse(m.ImportID)
+	if err != nil {
+		return nil, err
+	}
+	userID, err := uuid.Parse(m.UserID)
+	if err != nil {
+		return nil, err
+	}
+	r := &domain.Record{
+		ImportID:   importID,
+		UserID:     userID,
+		Line:       m.Line,
+		Kind:       domain.Kind(m.Kind),
+		ExternalID: m.ExternalID,
+		Payload:    []byte(m.Payload),
+		Status:     domain.RecordStatus(m.Status),
+		Error:      m.Error,
+	}
+	if m.EntityID != nil {
+		entityID, err := uuid.Parse(*m.EntityID)
+		if err != nil {
+			return nil, err
+		}
+		r.EntityID = &entityID
+	}
+	return r, nil
+}
+
+// DataImportRepository реализует repo.DataImportRepository на GORM/Postgres.
+type DataImportRepository struct {
+	db *gorm.DB
+}
+
+// Убедимся на этапе компиляции, что структура реализует интерфейс.
+var _ repo.DataImportRepository = (*DataImportRepository)(nil)
+
+// NewDataImportRepository создает новый репозиторий импортов исторических данных.
+func NewDataImportRepository(db *gorm.DB) *DataImportRepository {
+	return &DataImportRepository{db: db}
+}
+
+// Create сохраняет импорт, файл которого начал загружаться.
+func (r *DataImportRepository) Create(ctx context.Context, i *domain.Import) error {
+	model := &pgDataImport{
+		ID:        i.ID.String(),
+		UserID:    i.UserID.String(),
+		Source:    i.Source,
+		Status:    string(i.Status),
+		CreatedAt: i.CreatedAt,
+	}
+	if err := dbFromContext(ctx, r.db).Create(model).Error; err != nil {
+		if isUniqueViolation(err, "idx_data_imports_one_active") {
+			return repo.ErrImportInProgress
+		}
+		return err
+	}
+	return nil
+}
+
+// AddRecords сохраняет пачку строк загружаемого файла одним запросом.
+func (r *DataImportRepository) AddRecords(ctx context.Context, records []*domain.Record) error {
+	if len(records) == 0 {
+		return nil
+	}
+	models := make([]pgDataImportRecord, 0, len(records))
+	for _, rec := range records {
+		models = append(models, pgDataImportRecord{
+			ImportID:   rec.ImportID.String(),
+			Line:       rec.Line,
+			UserID:     rec.UserID.String(),
+			Kind:       string(rec.Kind),
+			ExternalID: rec.ExternalID,
+			Payload:    string(rec.Payload),
+			Status:     string(rec.Status),
+			Error:      rec.Error,
+		})
+	}
+	return dbFromContext(ctx, r.db).Create(&models).Error
+}
+
+// Enqueue передаёт загруженный импорт в обработку.
+func (r *DataImportRepository) Enqueue(ctx context.Context, id uuid.UUID, total int) error {
+	result := dbFromContext(ctx, r.db).
+		Model(&pgDataImport{}).
+		Where("id = ? AND status = ?", id.String(), string(domain.StatusReceiving)).
+		Updates(map[string]any{
+			"status": string(domain.StatusPending),
+			"total":  total,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return repo.ErrNotFound
+	}
+	return nil
+}
+
+// GetByID возвращает импорт по ID.
+func (r *DataImportRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Import, error) {
+	return r.getOne(dbFromContext(ctx, r.db).Where("id = ?", id.String()))
+}
+
+// GetActiveByUser возвращает незавершённый импорт пользователя.
+func (r *DataImportRepository) GetActiveByUser(ctx context.Context, userID uuid.UUID) (*domain.Import, error) {
+	return r.getOne(dbFromContext(ctx, r.db).
+		Where("user_id = ?", userID.String()).
+		Where("status IN ?", activeImportStatuses()))
+}
+
+func (r *DataImportRepository) getOne(query *gorm.DB) (*domain.Import, error) {
+	var model pgDataImport
+	if err := query.First(&model).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, repo.ErrNotFound
+		}
+		return nil, err
+	}
+	return model.toDomain()
+}
+
+// ListByUser возвращает последние импорты пользователя.
+func (r *DataImportRepository) ListByUser(ctx context.Context, userID uuid.UUID, limit int) ([]*domain.Import, error) {
+	return r.list(dbFromContext(ctx, r.db).
+		Where("user_id = ?", userID.String()).
+		Order("created_at DESC").
+		Limit(limit))
+}
+
+// ListRunnable возвращает импорты в очереди или в обработке, старые первыми.
+func (r *DataImportRepository) ListRunnable(ctx context.Context, limit int) ([]*domain.Import, error) {
+	return r.list(dbFromContext(ctx, r.db).
+		Where("status IN ?", []string{string(domain.StatusPending), string(domain.StatusRunning)}).
+		Order("created_at").
+		Limit(limit))
+}
+
+func (r *DataImportRepository) list(query *gorm.DB) ([]*domain.Import, error) {
+	var models []pgDataImport
+	if err := query.Find(&models).Error; err != nil {
+		return nil, err
+	}
+
+	imports := make([]*domain.Import, 0, len(models))
+	for i := range models {
+		imp, err := models[i].toDomain()
+		if err != nil {
+			return nil, err
+		}
+		imports = append(imports, imp)
+	}
+	return imports, nil
+}
+
+// Claim захватывает обработку импорта до момента until.
+func (r *DataImportRepository) Claim(ctx context.Context, id uuid.UUID, until time.Time) (bool, error) {
+	now := time.Now().UTC()
+	result := dbFromContext(ctx, r.db).
+		Model(&pgDataImport{}).
+		Where("id = ?", id.String()).
+		Where("status IN ?", []string{string(domain.StatusPending), string(domain.StatusRunning)}).
+		Where("claimed_until IS NULL OR claimed_until < ?", now).
+		Updates(map[string]any{
+			"status":        string(domain.StatusRunning),
+			"started_at":    gorm.Expr("COALESCE(started_at, ?)", now),
+			"claimed_until": until,
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+// ListPendingRecords возвращает необработанные записи импорта в порядке строк файла.
+func (r *DataImportRepository) ListPendingRecords(ctx context.Context, importID uuid.UUID, limit int) ([]*domain.Record, error) {
+	return r.listRecords(dbFromContext(ctx, r.db).
+		Where("import_id = ? AND status = ?", importID.String(), string(domain.RecordPending)).
+		Order("line").
+		Limit(limit))
+}
+
+// IsImported проверяет, импортирована ли запись с таким внешним ID.
+func (r *DataImportRepository) IsImported(ctx context.Context, userID uuid.UUID, kind domain.Kind, externalID string) (bool, error) {
+	var count int64
+	err := dbFromContext(ctx, r.db).
+		Model(&pgDataImportRecord{}).
+		Where("user_id = ? AND kind = ? AND external_id = ? AND status = ?",
+			userID.String(), string(kind), externalID, string(domain.RecordImported)).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// SaveRecord сохраняет результат обработки записи.
+func (r *DataImportRepository) SaveRecord(ctx context.Context, rec *domain.Record) error {
+	var entityID *string
+	if rec.EntityID != nil {
+		id := rec.EntityID.String()
+		entityID = &id
+	}
+	result := dbFromContext(ctx, r.db).
+		Model(&pgDataImportRecord{}).
+		Where("import_id = ? AND line = ?", rec.ImportID.String(), rec.Line).
+		Updates(map[string]any{
+			"kind":        string(rec.Kind),
+			"external_id": rec.ExternalID,
+			"status":      string(rec.Status),
+			"error":       rec.Error,
+			"entity_id":   entityID,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return repo.ErrNotFound
+	}
+	return nil
+}
+
+// SaveProgress сохраняет счётчики импорта и продлевает аренду.
+func (r *DataImportRepository) SaveProgress(ctx context.Context, i *domain.Import, until time.Time) error {
+	updates := importCounters(i)
+	updates["claimed_until"] = until
+	return r.update(ctx, i.ID, updates)
+}
+
+// Finish сохраняет итог импорта и снимает аренду.
+func (r *DataImportRepository) Finish(ctx context.Context, i *domain.Import) error {
+	updates := importCounters(i)
+	updates["status"] = string(i.Status)
+	updates["last_error"] = i.LastError
+	updates["finished_at"] = i.FinishedAt
+	updates["claimed_until"] = nil
+	return r.update(ctx, i.ID, updates)
+}
+
+func (r *DataImportRepository) update(ctx context.Context, id uuid.UUID, updates map[string]any) error {
+	result := dbFromContext(ctx, r.db).
+		Model(&pgDataImport{}).
+		Where("id = ?", id.String()).
+		Updates(updates)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return repo.ErrNotFound
+	}
+	return nil
+}
+
+// ListRecords возвращает записи импорта в порядке строк файла.
+func (r *DataImportRepository) ListRecords(ctx context.Context, importID uuid.UUID, status domain.RecordStatus, limit, offset int) ([]*domain.Record, error) {
+	query := dbFromContext(ctx, r.db).Where("import_id = ?", importID.String())
+	if status != "" {
+		query = query.Where("status = ?", string(status))
+	}
+	return r.listRecords(query.Order("line").Limit(limit).Offset(offset))
+}
+
+func (r *DataImportRepository) listRecords(query *gorm.DB) ([]*domain.Record, error) {
+	var models []pgDataImportRecord
+	if err := query.Find(&models).Error; err != nil {
+		return nil, err
+	}
+
+	records := make([]*domain.Record, 0, len(models))
+	for i := range models {
+		rec, err := models[i].toDomain()
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, rec)
+	}
+	return records, nil
+}
+
+// Delete удаляет импорт; записи удаляются каскадно.
+func (r *DataImportRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	return dbFromContext(ctx, r.db).Where("id = ?", id.String()).Delete(&pgDataImport{}).Error
+}
+
+// DeleteFinished удаляет завершённые раньше before импорты и брошенные загрузки.
+// Удаление пачками ограничивает время блокировок при накопившемся объёме.
+func (r *DataImportRepository) DeleteFinished(ctx context.Context, before time.Time, limit int) (int64, error) {
+	result := dbFromContext(ctx, r.db).Exec(
+		`DELETE FROM data_imports
+		 WHERE id IN (
+		     SELECT id FROM data_imports
+		     WHERE finished_at < ? OR (status = ? AND created_at < ?)
+		     ORDER BY id LIMIT ?)`,
+		before, string(domain.StatusReceiving), before, limit,
+	)
+	return result.RowsAffected, result.Error
+}
+
+// DeleteByUserID удаляет все импорты пользователя.
+func (r *DataImportRepository) DeleteByUserID(ctx context.Context, userID uuid.UUID) error {
+	return dbFromContext(ctx, r.db).Where("user_id = ?", userID.String()).Delete(&pgDataImport{}).Error
+}
+
+func importCounters(i *domain.Import) map[string]any {
+	return map[string]any{
+		"processed":  i.Processed,
+		"imported":   i.Imported,
+		"invalid":    i.Invalid,
+		"duplicates": i.Duplicates,
+	}
+}
+
+func activeImportStatuses() []string {
+	return []string{string(domain.StatusReceiving), string(domain.StatusPending), string(domain.StatusRunning)}
+}
@@ -0,0 +1,97 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+
+	domain "workout-app/internal/domain/deliverability"
+	repo "workout-app/internal/repository/interfaces"
+)
+
+// pgDeliveryEvent представляет ORM-модель для таблицы email_delivery_events.
+type pgDeliveryEvent struct {
+	ID         int64     `gorm:"column:id;type:bigserial;primaryKey"`
+	Provider   string    `gorm:"column:provider;type:varchar(32);not null"`
+	MessageID  string    `gorm:"column:message_id;type:varchar(255);not null"`
+	Email      string    `gorm:"column:email;type:varchar(255);not null"`
+	Outcome    string    `gorm:"column:outcome;type:varchar(16);not null"`
+	BounceType string    `gorm:"column:bounce_type;type:varchar(8);not null"`
+	Reason     string    `gorm:"column:reason;type:text;not null"`
+	OccurredAt time.Time `gorm:"column:occurred_at;type:timestamptz;not null"`
+	CreatedAt  time.Time `gorm:"column:created_at;type:timestamptz;not null"`
+}
+
+func (pgDeliveryEvent) TableName() string {
+	return "email_delivery_events"
+}
+
+// DeliverabilityRepository реализует repo.DeliverabilityRepository на GORM/Postgres.
+type DeliverabilityRepository struct {
+	db *gorm.DB
+}
+
+// Убедимся на этапе компиляции, что структура реализует интерфейс.
+var _ repo.DeliverabilityRepository = (*DeliverabilityRepository)(nil)
+
+// NewDeliverabilityRepository создает новый репозиторий журнала доставки писем.
+func NewDeliverabilityRepository(db *gorm.DB) *DeliverabilityRepository {
+	return &DeliverabilityRepository{db: db}
+}
+
+// CreateEvent сохраняет событие доставки.
+func (r *DeliverabilityRepository) CreateEvent(ctx context.Context, ev *domain.Event) error {
+	model := &pgDeliveryEvent{
+		Provider:   ev.Provider,
+		MessageID:  ev.MessageID,
+		Email:      ev.Email,
+		Outcome:    string(ev.Outcome),
+		BounceType: string(ev.BounceType),
+		Reason:     ev.Reason,
+		OccurredAt: ev.OccurredAt,
+		CreatedAt:  ev.CreatedAt,
+	}
+	if err := dbFromContext(ctx, r.db).Create(model).Error; err != nil {
+		return err
+	}
+	ev.ID = model.ID
+	return nil
+}
+
+// StatsSince агрегирует исходы доставки по провайдерам.
+func (r *DeliverabilityRepository) StatsSince(ctx context.Context, since time.Time) ([]domain.ProviderStats, error) {
+	var rows []struct {
+		Provider    string
+		Accepted    int64
+		Bounced     int64
+		HardBounced int64
+		Complaints  int64
+	}
+	err := dbFromContext(ctx, r.db).
+		Model(&pgDeliveryEvent{}).
+		Select(`provider,
+			COUNT(*) FILTER (WHERE outcome = 'accepted') AS accepted,
+			COUNT(*) FILTER (WHERE outcome = 'bounced') AS bounced,
+			COUNT(*) FILTER (WHERE outcome = 'bounced' AND bounce_type = 'hard') AS hard_bounced,
+			COUNT(*) FILTER (WHERE outcome = 'complaint') AS complaints`).
+		Where("occurred_at >= ?", since).
+		Group("provider").
+		Order("provider").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	stats := make([]domain.ProviderStats, 0, len(rows))
+	for _, row := range rows {
+		stats = append(stats, domain.ProviderStats{
+			Provider:    row.Provider,
+			Accepted:    row.Accepted,
+			Bounced:     row.Bounced,
+			HardBounced: row.HardBounced,
+			Complaints:  row.Complaints,
+		})
+	}
+	return stats, nil
+}
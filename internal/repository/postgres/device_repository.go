@@ -0,0 +1,136 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	domain "workout-app/internal/domain/device"
+	repo "workout-app/internal/repository/interfaces"
+)
+
+// pgDevice представляет ORM-модель для таблицы push_devices.
+type pgDevice struct {
+	ID         string    `gorm:"column:id;type:uuid;primaryKey"`
+	UserID     string    `gorm:"column:user_id;type:uuid;not null"`
+	Platform   string    `gorm:"column:platform;type:varchar(16);not null"`
+	Token      string    `gorm:"column:token;type:text;not null"`
+	CreatedAt  time.Time `gorm:"column:created_at;type:timestamptz;not null"`
+	LastSeenAt time.Time `gorm:"column:last_seen_at;type:timestamptz;not null"`
+}
+
+func (pgDevice) TableName() string {
+	return "push_devices"
+}
+
+func (m *pgDevice) toDomain() (*domain.Device, error) {
+	id, err := uuid.Parse(m.ID)
+	if err != nil {
+		return nil, err
+	}
+	userID, err := uuid.Parse(m.UserID)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.Device{
+		ID:         id,
+		UserID:     userID,
+		Platform:   domain.Platform(m.Platform),
+		Token:      m.Token,
+		CreatedAt:  m.CreatedAt,
+		LastSeenAt: m.LastSeenAt,
+	}, nil
+}
+
+// DeviceRepository реализует repo.DeviceRepository на GORM/Postgres.
+type DeviceRepository struct {
+	db *gorm.DB
+}
+
+// Убедимся на этапе компиляции, что структура реализует интерфейс.
+var _ repo.DeviceRepository = (*DeviceRepository)(nil)
+
+// NewDeviceRepository создает новый репозиторий устройств.
+func NewDeviceRepository(db *gorm.DB) *DeviceRepository {
+	return &DeviceRepository{db: db}
+}
+
+// Upsert регистрирует устройство одним запросом INSERT ... ON CONFLICT (token) DO UPDATE ... RETURNING.
+func (r *DeviceRepository) Upsert(ctx context.Context, d *domain.Device) error {
+	model := &pgDevice{
+		ID:         d.ID.String(),
+		UserID:     d.UserID.String(),
+		Platform:   string(d.Platform),
+		Token:      d.Token,
+		CreatedAt:  d.CreatedAt,
+		LastSeenAt: d.LastSeenAt,
+	}
+	err := dbFromContext(ctx, r.db).
+		Clauses(
+			clause.OnConflict{
+				Columns:   []clause.Column{{Name: "token"}},
+				DoUpdates: clause.AssignmentColumns([]string{"user_id", "platform", "last_seen_at"}),
+			},
+			clause.Returning{Columns: []clause.Column{{Name: "id"}, {Name: "created_at"}}},
+		).
+		Create(model).Error
+	if err != nil {
+		return err
+	}
+	id, err := uuid.Parse(model.ID)
+	if err != nil {
+		return err
+	}
+	d.ID = id
+	d.CreatedAt = model.CreatedAt
+	return nil
+}
+
+// ListByUser возвращает устройства пользователя, недавно активные первыми.
+func (r *DeviceRepository) ListByUser(ctx context.Context, userID uuid.UUID) ([]*domain.Device, error) {
+	var models []pgDevice
+	err := dbFromContext(ctx, r.db).
+		Where("user_id = ?", userID.String()).
+		Order("last_seen_at DESC, id").
+		Find(&models).Error
+	if err != nil {
+		return nil, err
+	}
+
+	devices := make([]*domain.Device, 0, len(models))
+	for i := range models {
+		d, err := models[i].toDomain()
+		if err != nil {
+			return nil, err
+		}
+		devices = append(devices, d)
+	}
+	return devices, nil
+}
+
+// Delete удаляет устройство пользователя.
+func (r *DeviceRepository) Delete(ctx context.Context, userID, id uuid.UUID) error {
+	result := dbFromContext(ctx, r.db).
+		Where("id = ? AND user_id = ?", id.String(), userID.String()).
+		Delete(&pgDevice{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return repo.ErrNotFound
+	}
+	return nil
+}
+
+// DeleteByToken удаляет устройство с недействительным токеном.
+func (r *DeviceRepository) DeleteByToken(ctx context.Context, token string) error {
+	return dbFromContext(ctx, r.db).Where("token = ?", token).Delete(&pgDevice{}).Error
+}
+
+// DeleteByUserID удаляет все устройства пользователя.
+func (r *DeviceRepository) DeleteByUserID(ctx context.Context, userID uuid.UUID) error {
+	return dbFromContext(ctx, r.db).Where("user_id = ?", userID.String()).Delete(&pgDevice{}).Error
+}
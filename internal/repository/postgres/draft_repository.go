@@ -0,0 +1,225 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	domain "workout-app/internal/domain/draft"
+	repo "workout-app/internal/repository/interfaces"
+)
+
+// pgDraft представляет ORM-модель для таблицы drafts.
+type pgDraft struct {
+	ID          string     `gorm:"column:id;type:uuid;primaryKey"`
+	UserID      string     `gorm:"column:user_id;type:uuid;not null"`
+	DeviceID    string     `gorm:"column:device_id;type:varchar(128);not null"`
+	Kind        string     `gorm:"column:kind;type:varchar(16);not null"`
+	TargetID    *string    `gorm:"column:target_id;type:uuid"`
+	BaseVersion *time.Time `gorm:"column:base_version;type:timestamptz"`
+	Content     string     `gorm:"column:content;type:jsonb;not null"`
+	Version     int        `gorm:"column:version;not null"`
+	CreatedAt   time.Time  `gorm:"column:created_at;type:timestamptz;not null"`
+	UpdatedAt   time.Time  `gorm:"column:updated_at;type:timestamptz;not null"`
+	ExpiresAt   time.Time  `gorm:"column:expires_at;type:timestamptz;not null"`
+}
+
+func (pgDraft) TableName() string {
+	return "drafts"
+}
+
+func newPgDraft(d *domain.Draft) *pgDraft {
+	m := &pgDraft{
+		ID:          d.ID.String(),
+		UserID:      d.UserID.String(),
+		DeviceID:    d.DeviceID,
+		Kind:        string(d.Kind),
+		BaseVersion: d.BaseVersion,
+		Content:     string(d.Content),
+		Version:     d.Version,
+		CreatedAt:   d.CreatedAt,
+		UpdatedAt:   d.UpdatedAt,
+		ExpiresAt:   d.ExpiresAt,
+	}
+	if d.TargetID != nil {
+		targetID := d.TargetID.String()
+		m.TargetID = &targetID
+	}
+	return m
+}
+
+func (m *pgDraft) toDomain() (*domain.Draft, error) {
+	id, err := uuid.Parse(m.ID)
+	if err != nil {
+		return nil, err
+	}
+	userID, err := uuid.Parse(m.UserID)
+	if err != nil {
+		return nil, err
+	}
+	d := &domain.Draft{
+		ID:          id,
+		UserID:      userID,
+		DeviceID:    m.DeviceID,
+		Kind:        domain.Kind(m.Kind),
+		BaseVersion: m.BaseVersion,
+		Version:     m.Version,
+		CreatedAt:   m.CreatedAt,
+		UpdatedAt:   m.UpdatedAt,
+		ExpiresAt:   m.ExpiresAt,
+	}
+	if m.Content != "" {
+		d.Content = []byte(m.Content)
+	}
+	if m.TargetID != nil {
+		targetID, err := uuid.Parse(*m.TargetID)
+		if err != nil {
+			return nil, err
+		}
+		d.TargetID = &targetID
+	}
+	return d, nil
+}
+
+// DraftRepository реализует repo.DraftRepository на GORM/Postgres.
+type DraftRepository struct {
+	db *gorm.DB
+}
+
+// Убедимся на этапе компиляции, что структура реализует интерфейс.
+var _ repo.DraftRepository = (*DraftRepository)(nil)
+
+// NewDraftRepository создает новый репозиторий черновиков.
+func NewDraftRepository(db *gorm.DB) *DraftRepository {
+	return &DraftRepository{db: db}
+}
+
+// Create сохраняет новый черновик.
+func (r *DraftRepository) Create(ctx context.Context, d *domain.Draft) error {
+	if err := dbFromContext(ctx, r.db).Create(newPgDraft(d)).Error; err != nil {
+		if isUniqueViolation(err, "idx_drafts_target") || isUniqueViolation(err, "idx_drafts_new") {
+			return repo.ErrDraftExists
+		}
+		return err
+	}
+	return nil
+}
+
+// Update сохраняет черновик с проверкой версии: из двух одновременных сохранений одной версии
+// проходит только первое.
+func (r *DraftRepository) Update(ctx context.Context, d *domain.Draft, expectedVersion int) error {
+	result := dbFromContext(ctx, r.db).
+		Model(&pgDraft{}).
+		Where("id = ? AND user_id = ? AND version = ?", d.ID.String(), d.UserID.String(), expectedVersion).
+		Updates(map[string]any{
+			"base_version": d.BaseVersion,
+			"content":      string(d.Content),
+			"version":      d.Version,
+			"updated_at":   d.UpdatedAt,
+			"expires_at":   d.ExpiresAt,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return repo.ErrNotFound
+	}
+	return nil
+}
+
+// GetByKey возвращает черновик устройства для объекта.
+func (r *DraftRepository) GetByKey(ctx context.Context, userID uuid.UUID, deviceID string, kind domain.Kind, targetID *uuid.UUID) (*domain.Draft, error) {
+	query := dbFromContext(ctx, r.db).
+		Where("user_id = ? AND device_id = ? AND kind = ?", userID.String(), deviceID, string(kind))
+	if targetID != nil {
+		query = query.Where("target_id = ?", targetID.String())
+	} else {
+		query = query.Where("target_id IS NULL")
+	}
+	return r.first(query)
+}
+
+// GetByID возвращает черновик пользователя по ID.
+func (r *DraftRepository) GetByID(ctx context.Context, userID, id uuid.UUID) (*domain.Draft, error) {
+	return r.first(dbFromContext(ctx, r.db).Where("id = ? AND user_id = ?", id.String(), userID.String()))
+}
+
+func (r *DraftRepository) first(query *gorm.DB) (*domain.Draft, error) {
+	var model pgDraft
+	if err := query.First(&model).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, repo.ErrNotFound
+		}
+		return nil, err
+	}
+	return model.toDomain()
+}
+
+// ListByUser возвращает действующие черновики пользователя без содержимого.
+func (r *DraftRepository) ListByUser(ctx context.Context, userID uuid.UUID, deviceID string, now time.Time) ([]*domain.Draft, error) {
+	query := dbFromContext(ctx, r.db).
+		Omit("content").
+		Where("user_id = ? AND expires_at > ?", userID.String(), now)
+	if deviceID != "" {
+		query = query.Where("device_id = ?", deviceID)
+	}
+
+	var models []pgDraft
+	if err := query.Order("updated_at DESC").Find(&models).Error; err != nil {
+		return nil, err
+	}
+
+	drafts := make([]*domain.Draft, 0, len(models))
+	for i := range models {
+		d, err := models[i].toDomain()
+		if err != nil {
+			return nil, err
+		}
+		drafts = append(drafts, d)
+	}
+	return drafts, nil
+}
+
+// CountByUser возвращает количество действующих черновиков пользователя.
+func (r *DraftRepository) CountByUser(ctx context.Context, userID uuid.UUID, now time.Time) (int64, error) {
+	var count int64
+	err := dbFromContext(ctx, r.db).
+		Model(&pgDraft{}).
+		Where("user_id = ? AND expires_at > ?", userID.String(), now).
+		Count(&count).Error
+	return count, err
+}
+
+// Delete удаляет черновик пользователя.
+func (r *DraftRepository) Delete(ctx context.Context, userID, id uuid.UUID) error {
+	result := dbFromContext(ctx, r.db).
+		Where("id = ? AND user_id = ?", id.String(), userID.String()).
+		Delete(&pgDraft{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return repo.ErrNotFound
+	}
+	return nil
+}
+
+// DeleteExpired удаляет не более limit черновиков, истёкших до before.
+func (r *DraftRepository) DeleteExpired(ctx context.Context, before time.Time, limit int) (int64, error) {
+	result := dbFromContext(ctx, r.db).Exec(
+		`DELETE FROM drafts
+		 WHERE id IN (SELECT id FROM drafts WHERE expires_at < ? ORDER BY id LIMIT ?)`,
+		before, limit,
+	)
+	return result.RowsAffected, result.Error
+}
+
+// DeleteByUserID удаляет все черновики пользователя.
+func (r *DraftRepository) DeleteByUserID(ctx context.Context, userID uuid.UUID) error {
+	return dbFromContext(ctx, r.db).
+		Where("user_id = ?", userID.String()).
+		Delete(&pgDraft{}).Error
+}
@@ -0,0 +1,234 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+
+	domain "workout-app/internal/domain/emailoutbox"
+	repo "workout-app/internal/repository/interfaces"
+)
+
+// pgEmailOutboxMessage представляет ORM-модель для таблицы email_outbox.
+type pgEmailOutboxMessage struct {
+	ID            int64      `gorm:"column:id;primaryKey;autoIncrement"`
+	Kind          string     `gorm:"column:kind;type:varchar(32);not null"`
+	Recipient     string     `gorm:"column:recipient;type:varchar(255);not null"`
+	Locale        string     `gorm:"column:locale;type:varchar(35);not null"`
+	Timezone      string     `gorm:"column:timezone;type:varchar(64);not null"`
+	Payload       string     `gorm:"column:payload;type:jsonb;not null"`
+	Status        string     `gorm:"column:status;type:varchar(16);not null"`
+	Attempts      int        `gorm:"column:attempts;not null"`
+	NextAttemptAt time.Time  `gorm:"column:next_attempt_at;type:timestamptz;not null"`
+	LastError     string     `gorm:"column:last_error;type:text;not null"`
+	TraceParent   string     `gorm:"column:trace_parent;type:varchar(55);not null"`
+	CreatedAt     time.Time  `gorm:"column:created_at;type:timestamptz;not null"`
+	FinishedAt    *time.Time `gorm:"column:finished_at;type:timestamptz"`
+}
+
+func (pgEmailOutboxMessage) TableName() string {
+	return "email_outbox"
+}
+
+func newPgEmailOutboxMessage(m *domain.Message) (*pgEmailOutboxMessage, error) {
+	payload, err := json.Marshal(m.Payload)
+	if err != nil {
+		return nil, err
+	}
+	return &pgEmailOutboxMessage{
+		ID:            m.ID,
+		Kind:          string(m.Kind),
+		Recipient:     m.Recipient,
+		Locale:        m.Locale,
+		Timezone:      m.Timezone,
+		Payload:       string(payload),
+		Status:        string(m.Status),
+		Attempts:      m.Attempts,
+		NextAttemptAt: m.NextAttemptAt,
+		LastError:     m.LastError,
+		TraceParent:   m.TraceParent,
+		CreatedAt:     m.CreatedAt,
+		FinishedAt:    m.FinishedAt,
+	}, nil
+}
+
+func (m *pgEmailOutboxMessage) toDomain() (*domain.Message, error) {
+	var payload domain.Payload
+	if err := json.Unmarshal([]byte(m.Payload), &payload); err != nil {
+		return nil, err
+	}
+	return &domain.Message{
+		ID:            m.ID,
+		Kind:          domain.Kind(m.Kind),
+		Recipient:     m.Recipient,
+		Locale:        m.Locale,
+		Timezone:      m.Timezone,
+		Payload:       payload,
+		Status:        domain.Status(m.Status),
+		Attempts:      m.Attempts,
+		NextAttemptAt: m.NextAttemptAt,
+		LastError:     m.LastError,
+		TraceParent:   m.TraceParent,
+		CreatedAt:     m.CreatedAt,
+		FinishedAt:    m.FinishedAt,
+	}, nil
+}
+
+// EmailOutboxRepository реализует repo.EmailOutboxRepository на GORM/Postgres.
+type EmailOutboxRepository struct {
+	db *gorm.DB
+}
+
+// Убедимся на этапе компиляции, что структура реализует интерфейс.
+var _ repo.EmailOutboxRepository = (*EmailOutboxRepository)(nil)
+
+// NewEmailOutboxRepository создает новый репозиторий очереди исходящих писем.
+func NewEmailOutboxRepository(db *gorm.DB) *EmailOutboxRepository {
+	return &EmailOutboxRepository{db: db}
+}
+
+// Enqueue сохраняет письмо в очереди.
+func (r *EmailOutboxRepository) Enqueue(ctx context.Context, m *domain.Message) error {
+	model, err := newPgEmailOutboxMessage(m)
+	if err != nil {
+		return err
+	}
+	model.ID = 0
+	if err := dbFromContext(ctx, r.db).Create(model).Error; err != nil {
+		return err
+	}
+	m.ID = model.ID
+	return nil
+}
+
+// ListDue возвращает письма, время попытки которых наступило, старые первыми.
+func (r *EmailOutboxRepository) ListDue(ctx context.Context, now time.Time, limit int) ([]*domain.Message, error) {
+	return r.list(dbFromContext(ctx, r.db).
+		Where("status = ? AND next_attempt_at <= ?", string(domain.StatusPending), now).
+		Order("next_attempt_at, id").
+		Limit(limit))
+}
+
+// Claim захватывает отправку письма до момента until.
+func (r *EmailOutboxRepository) Claim(ctx context.Context, id int64, now, until time.Time) (bool, error) {
+	result := dbFromContext(ctx, r.db).
+		Model(&pgEmailOutboxMessage{}).
+		Where("id = ? AND status = ? AND next_attempt_at <= ?", id, string(domain.StatusPending), now).
+		Update("next_attempt_at", until)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+// Save сохраняет результат попытки отправки.
+func (r *EmailOutboxRepository) Save(ctx context.Context, m *domain.Message) error {
+	model, err := newPgEmailOutboxMessage(m)
+	if err != nil {
+		return err
+	}
+	result := dbFromContext(ctx, r.db).
+		Model(&pgEmailOutboxMessage{}).
+		Where("id = ?", m.ID).
+		Updates(map[string]any{
+			"payload":         model.Payload,
+			"status":          model.Status,
+			"attempts":        model.Attempts,
+			"next_attempt_at": model.NextAttemptAt,
+			"last_error":      model.LastError,
+			"finished_at":     model.FinishedAt,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return repo.ErrNotFound
+	}
+	return nil
+}
+
+// GetByID возвращает письмо по ID.
+func (r *EmailOutboxRepository) GetByID(ctx context.Context, id int64) (*domain.Message, error) {
+	var model pgEmailOutboxMessage
+	if err := dbFromContext(ctx, r.db).Where("id = ?", id).First(&model).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, repo.ErrNotFound
+		}
+		return nil, err
+	}
+	return model.toDomain()
+}
+
+// List возвращает страницу писем, новые первыми.
+func (r *EmailOutboxRepository) List(ctx context.Context, status domain.Status, limit, offset int) ([]*domain.Message, int64, error) {
+	// Отдельные цепочки для подсчёта и выборки: GORM не переиспользует запрос после Count.
+	filtered := func() *gorm.DB {
+		query := dbFromContext(ctx, r.db).Model(&pgEmailOutboxMessage{})
+		if status != "" {
+			query = query.Where("status = ?", string(status))
+		}
+		return query
+	}
+
+	var total int64
+	if err := filtered().Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	items, err := r.list(filtered().Order("id DESC").Limit(limit).Offset(offset))
+	if err != nil {
+		return nil, 0, err
+	}
+	return items, total, nil
+}
+
+// CountByStatus возвращает количество писем по статусам.
+func (r *EmailOutboxRepository) CountByStatus(ctx context.Context) (map[domain.Status]int64, error) {
+	var rows []struct {
+		Status string
+		Count  int64
+	}
+	err := dbFromContext(ctx, r.db).
+		Model(&pgEmailOutboxMessage{}).
+		Select("status, COUNT(*) AS count").
+		Group("status").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[domain.Status]int64, len(rows))
+	for _, row := range rows {
+		counts[domain.Status(row.Status)] = row.Count
+	}
+	return counts, nil
+}
+
+// DeleteFinished удаляет завершённые письма пачкой не больше limit.
+func (r *EmailOutboxRepository) DeleteFinished(ctx context.Context, before time.Time, limit int) (int64, error) {
+	result := dbFromContext(ctx, r.db).Exec(
+		`DELETE FROM email_outbox
+		 WHERE id IN (SELECT id FROM email_outbox WHERE finished_at < ? ORDER BY id LIMIT ?)`,
+		before, limit,
+	)
+	return result.RowsAffected, result.Error
+}
+
+func (r *EmailOutboxRepository) list(query *gorm.DB) ([]*domain.Message, error) {
+	var models []pgEmailOutboxMessage
+	if err := query.Find(&models).Error; err != nil {
+		return nil, err
+	}
+
+	messages := make([]*domain.Message, 0, len(models))
+	for i := range models {
+		m, err := models[i].toDomain()
+		if err != nil {
+			return nil, err
+		}
+		messages = append(messages, m)
+	}
+	return messages, nil
+}
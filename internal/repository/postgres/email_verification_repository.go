@@ -22,6 +22,7 @@ type pgEmailVerification struct {
 	MaxAttempts int       `gorm:"column:max_attempts;type:int;not null"`
 	CreatedAt   time.Time `gorm:"column:created_at;type:timestamptz;not null"`
 	NewEmail    *string   `gorm:"column:new_email;type:varchar(255)"`
+	Purpose     string    `gorm:"column:purpose;type:varchar(32);not null"`
 }
 
 func (pgEmailVerification) TableName() string {
@@ -43,6 +44,7 @@ func (m *pgEmailVerification) toDomain() (*domain.EmailVerification, error) {
 		MaxAttempts: m.MaxAttempts,
 		CreatedAt:   m.CreatedAt,
 		NewEmail:    m.NewEmail,
+		Purpose:     domain.VerificationPurpose(m.Purpose),
 	}, nil
 }
 
@@ -56,6 +58,7 @@ func fromDomainEmailVerification(v *domain.EmailVerification) *pgEmailVerificati
 		MaxAttempts: v.MaxAttempts,
 		CreatedAt:   v.CreatedAt,
 		NewEmail:    v.NewEmail,
+		Purpose:     string(v.Purpose),
 	}
 }
 
@@ -74,56 +77,19 @@ func NewEmailVerificationRepository(db *gorm.DB) *EmailVerificationRepository {
 
 // Create создает новую запись с кодом подтверждения email.
 func (r *EmailVerificationRepository) Create(ctx context.Context, v *domain.EmailVerification) error {
-	model := fromDomainEmailVerification(v)
-	return r.db.WithContext(ctx).Create(model).Error
-}
-
-// GetActiveByUserID возвращает активную (не истекшую) запись по user_id.
-func (r *EmailVerificationRepository) GetActiveByUserID(ctx context.Context, userID uuid.UUID) (*domain.EmailVerification, error) {
-	var model pgEmailVerification
-
-	err := r.db.WithContext(ctx).
-		Where("user_id = ? AND expires_at > NOW() AND new_email IS NULL", userID.String()).
-		Order("created_at DESC").
-		Take(&model).Error
-	if err != nil {
-		if err == gorm.ErrRecordNotFound {
-			return nil, repo.ErrNotFound
-		}
-		return nil, err
-	}
-
-	return model.toDomain()
-}
-
-// GetActiveByUserIDAndNewEmail возвращает активную (не истекшую) запись по user_id и new_email.
-func (r *EmailVerificationRepository) GetActiveByUserIDAndNewEmail(ctx context.Context, userID uuid.UUID, newEmail string) (*domain.EmailVerification, error) {
-	if newEmail == "" {
-		return nil, fmt.Errorf("newEmail cannot be empty")
+	if !v.Purpose.IsValid() {
+		return fmt.Errorf("invalid verification purpose %q", v.Purpose)
 	}
-
-	var model pgEmailVerification
-
-	err := r.db.WithContext(ctx).
-		Where("user_id = ? AND new_email = ? AND expires_at > NOW()", userID.String(), newEmail).
-		Order("created_at DESC").
-		Take(&model).Error
-	if err != nil {
-		if err == gorm.ErrRecordNotFound {
-			return nil, repo.ErrNotFound
-		}
-		return nil, err
-	}
-
-	return model.toDomain()
+	model := fromDomainEmailVerification(v)
+	return dbFromContext(ctx, r.db).Create(model).Error
 }
 
-// GetActiveEmailChangeByUserID возвращает активную (не истекшую) запись изменения email по user_id.
-func (r *EmailVerificationRepository) GetActiveEmailChangeByUserID(ctx context.Context, userID uuid.UUID) (*domain.EmailVerification, error) {
+// GetActiveByPurpose возвращает активную (не истекшую) запись пользователя с указанным назначением.
+func (r *EmailVerificationRepository) GetActiveByPurpose(ctx context.Context, userID uuid.UUID, purpose domain.VerificationPurpose) (*domain.EmailVerification, error) {
 	var model pgEmailVerification
 
-	err := r.db.WithContext(ctx).
-		Where("user_id = ? AND new_email IS NOT NULL AND expires_at > NOW()", userID.String()).
+	err := dbFromContext(ctx, r.db).
+		Where("user_id = ? AND purpose = ? AND expires_at > NOW()", userID.String(), string(purpose)).
 		Order("created_at DESC").
 		Take(&model).Error
 	if err != nil {
@@ -140,7 +106,7 @@ func (r *EmailVerificationRepository) GetActiveEmailChangeByUserID(ctx context.C
 func (r *EmailVerificationRepository) GetByID(ctx context.Context, id int64) (*domain.EmailVerification, error) {
 	var model pgEmailVerification
 
-	err := r.db.WithContext(ctx).
+	err := dbFromContext(ctx, r.db).
 		Where("id = ?", id).
 		Take(&model).Error
 	if err != nil {
@@ -155,7 +121,7 @@ func (r *EmailVerificationRepository) GetByID(ctx context.Context, id int64) (*d
 
 // IncrementAttempts увеличивает счетчик попыток для записи по её ID.
 func (r *EmailVerificationRepository) IncrementAttempts(ctx context.Context, id int64) error {
-	result := r.db.WithContext(ctx).
+	result := dbFromContext(ctx, r.db).
 		Model(&pgEmailVerification{}).
 		Where("id = ?", id).
 		UpdateColumn("attempts", gorm.Expr("attempts + 1"))
@@ -171,7 +137,7 @@ func (r *EmailVerificationRepository) IncrementAttempts(ctx context.Context, id
 
 // DeleteByUserID удаляет все записи кодов для указанного пользователя.
 func (r *EmailVerificationRepository) DeleteByUserID(ctx context.Context, userID uuid.UUID) error {
-	result := r.db.WithContext(ctx).
+	result := dbFromContext(ctx, r.db).
 		Where("user_id = ?", userID.String()).
 		Delete(&pgEmailVerification{})
 
@@ -181,10 +147,10 @@ func (r *EmailVerificationRepository) DeleteByUserID(ctx context.Context, userID
 	return nil
 }
 
-// DeleteEmailChangeByUserID удаляет все записи кодов изменения email для указанного пользователя.
-func (r *EmailVerificationRepository) DeleteEmailChangeByUserID(ctx context.Context, userID uuid.UUID) error {
-	result := r.db.WithContext(ctx).
-		Where("user_id = ? AND new_email IS NOT NULL", userID.String()).
+// DeleteByPurpose удаляет записи кодов пользователя с указанным назначением.
+func (r *EmailVerificationRepository) DeleteByPurpose(ctx context.Context, userID uuid.UUID, purpose domain.VerificationPurpose) error {
+	result := dbFromContext(ctx, r.db).
+		Where("user_id = ? AND purpose = ?", userID.String(), string(purpose)).
 		Delete(&pgEmailVerification{})
 
 	if result.Error != nil {
@@ -192,3 +158,14 @@ func (r *EmailVerificationRepository) DeleteEmailChangeByUserID(ctx context.Cont
 	}
 	return nil
 }
+
+// DeleteExpired удаляет не более limit записей, истёкших до before.
+// Удаление пачками ограничивает время блокировок при накопившемся объёме.
+func (r *EmailVerificationRepository) DeleteExpired(ctx context.Context, before time.Time, limit int) (int64, error) {
+	result := dbFromContext(ctx, r.db).Exec(
+		`DELETE FROM email_verifications
+		 WHERE id IN (SELECT id FROM email_verifications WHERE expires_at < ? ORDER BY id LIMIT ?)`,
+		before, limit,
+	)
+	return result.RowsAffected, result.Error
+}
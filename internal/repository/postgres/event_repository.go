@@ -0,0 +1,124 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	domain "workout-app/internal/domain/event"
+	repo "workout-app/internal/repository/interfaces"
+)
+
+// pgDomainEvent представляет ORM-модель для таблицы domain_events.
+type pgDomainEvent struct {
+	ID          int64     `gorm:"column:id;type:bigserial;primaryKey"`
+	Type        string    `gorm:"column:type;type:varchar(100);not null"`
+	AggregateID string    `gorm:"column:aggregate_id;type:text;not null"`
+	Payload     string    `gorm:"column:payload;type:jsonb;not null"`
+	OccurredAt  time.Time `gorm:"column:occurred_at;type:timestamptz;not null"`
+}
+
+func (pgDomainEvent) TableName() string {
+	return "domain_events"
+}
+
+// pgEventCheckpoint представляет ORM-модель для таблицы event_checkpoints.
+type pgEventCheckpoint struct {
+	Name        string    `gorm:"column:name;type:varchar(150);primaryKey"`
+	LastEventID int64     `gorm:"column:last_event_id;type:bigint;not null"`
+	UpdatedAt   time.Time `gorm:"column:updated_at;type:timestamptz;not null"`
+}
+
+func (pgEventCheckpoint) TableName() string {
+	return "event_checkpoints"
+}
+
+// EventRepository реализует repo.EventRepository на GORM/Postgres.
+type EventRepository struct {
+	db *gorm.DB
+}
+
+// Убедимся на этапе компиляции, что структура реализует интерфейс.
+var _ repo.EventRepository = (*EventRepository)(nil)
+
+// NewEventRepository создает новый репозиторий журнала событий.
+func NewEventRepository(db *gorm.DB) *EventRepository {
+	return &EventRepository{db: db}
+}
+
+// Append добавляет событие в журнал.
+func (r *EventRepository) Append(ctx context.Context, ev *domain.Event) error {
+	payload := string(ev.Payload)
+	if payload == "" {
+		payload = "{}"
+	}
+	model := &pgDomainEvent{
+		Type:        ev.Type,
+		AggregateID: ev.AggregateID,
+		Payload:     payload,
+		OccurredAt:  ev.OccurredAt,
+	}
+	if err := dbFromContext(ctx, r.db).Create(model).Error; err != nil {
+		return err
+	}
+	ev.ID = model.ID
+	return nil
+}
+
+// ListAfter возвращает события после afterID по возрастанию ID.
+func (r *EventRepository) ListAfter(ctx context.Context, afterID, toID int64, types []string, limit int) ([]domain.Event, error) {
+	query := dbFromContext(ctx, r.db).Where("id > ?", afterID)
+	if toID > 0 {
+		query = query.Where("id <= ?", toID)
+	}
+	if len(types) > 0 {
+		query = query.Where("type IN ?", types)
+	}
+
+	var models []pgDomainEvent
+	if err := query.Order("id").Limit(limit).Find(&models).Error; err != nil {
+		return nil, err
+	}
+
+	events := make([]domain.Event, 0, len(models))
+	for _, m := range models {
+		events = append(events, domain.Event{
+			ID:          m.ID,
+			Type:        m.Type,
+			AggregateID: m.AggregateID,
+			Payload:     []byte(m.Payload),
+			OccurredAt:  m.OccurredAt,
+		})
+	}
+	return events, nil
+}
+
+// GetCheckpoint возвращает ID последнего обработанного события для контрольной точки.
+func (r *EventRepository) GetCheckpoint(ctx context.Context, name string) (int64, error) {
+	var model pgEventCheckpoint
+	result := dbFromContext(ctx, r.db).Where("name = ?", name).Limit(1).Find(&model)
+	if result.Error != nil {
+		return 0, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return 0, nil
+	}
+	return model.LastEventID, nil
+}
+
+// SaveCheckpoint сохраняет ID последнего обработанного события для контрольной точки.
+func (r *EventRepository) SaveCheckpoint(ctx context.Context, name string, lastEventID int64) error {
+	model := &pgEventCheckpoint{
+		Name:        name,
+		LastEventID: lastEventID,
+		UpdatedAt:   time.Now().UTC(),
+	}
+	return dbFromContext(ctx, r.db).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "name"}},
+			DoUpdates: clause.AssignmentColumns([]string{"last_event_id", "updated_at"}),
+		}).
+		Create(model).Error
+}
@@ -0,0 +1,52 @@
+package postgres
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+
+	domain "workout-app/internal/domain/workout"
+	repo "workout-app/internal/repository/interfaces"
+)
+
+// pgExerciseLimit представляет ORM-модель для таблицы exercise_limits.
+type pgExerciseLimit struct {
+	ExerciseKey string   `gorm:"column:exercise_key;type:varchar(100);primaryKey"`
+	MaxWeightKg *float64 `gorm:"column:max_weight_kg;type:numeric(6,2)"`
+	MaxReps     *int     `gorm:"column:max_reps"`
+}
+
+func (pgExerciseLimit) TableName() string {
+	return "exercise_limits"
+}
+
+// ExerciseLimitRepository реализует repo.ExerciseLimitRepository на GORM/Postgres.
+type ExerciseLimitRepository struct {
+	db *gorm.DB
+}
+
+// Убедимся на этапе компиляции, что структура реализует интерфейс.
+var _ repo.ExerciseLimitRepository = (*ExerciseLimitRepository)(nil)
+
+// NewExerciseLimitRepository создает новый репозиторий границ упражнений.
+func NewExerciseLimitRepository(db *gorm.DB) *ExerciseLimitRepository {
+	return &ExerciseLimitRepository{db: db}
+}
+
+// List возвращает границы всех упражнений, отсортированные по ключу.
+func (r *ExerciseLimitRepository) List(ctx context.Context) ([]domain.ExerciseLimits, error) {
+	var models []pgExerciseLimit
+	if err := dbFromContext(ctx, r.db).Order("exercise_key").Find(&models).Error; err != nil {
+		return nil, err
+	}
+
+	limits := make([]domain.ExerciseLimits, 0, len(models))
+	for _, m := range models {
+		limits = append(limits, domain.ExerciseLimits{
+			Exercise:    m.ExerciseKey,
+			MaxWeightKg: m.MaxWeightKg,
+			MaxReps:     m.MaxReps,
+		})
+	}
+	return limits, nil
+}
@@ -0,0 +1,195 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+
+	domain "workout-app/internal/domain/experiment"
+	repo "workout-app/internal/repository/interfaces"
+)
+
+// pgExperiment представляет ORM-модель для таблицы experiments.
+type pgExperiment struct {
+	Key         string    `gorm:"column:key;type:varchar(64);primaryKey"`
+	Description string    `gorm:"column:description;type:text;not null"`
+	Variants    string    `gorm:"column:variants;type:jsonb;not null"`
+	IsActive    bool      `gorm:"column:is_active;type:boolean;not null"`
+	CreatedAt   time.Time `gorm:"column:created_at;type:timestamptz;not null"`
+	UpdatedAt   time.Time `gorm:"column:updated_at;type:timestamptz;not null"`
+}
+
+func (pgExperiment) TableName() string {
+	return "experiments"
+}
+
+// pgVariant — JSON-представление варианта в колонке variants.
+type pgVariant struct {
+	Name   string `json:"name"`
+	Weight int    `json:"weight"`
+}
+
+func (m *pgExperiment) toDomain() (*domain.Experiment, error) {
+	var raw []pgVariant
+	if err := json.Unmarshal([]byte(m.Variants), &raw); err != nil {
+		return nil, fmt.Errorf("failed to decode variants: %w", err)
+	}
+	variants := make([]domain.Variant, 0, len(raw))
+	for _, v := range raw {
+		variants = append(variants, domain.Variant{Name: v.Name, Weight: v.Weight})
+	}
+
+	return &domain.Experiment{
+		Key:         m.Key,
+		Description: m.Description,
+		Variants:    variants,
+		IsActive:    m.IsActive,
+		CreatedAt:   m.CreatedAt,
+		UpdatedAt:   m.UpdatedAt,
+	}, nil
+}
+
+func fromDomainExperiment(e *domain.Experiment) (*pgExperiment, error) {
+	raw := make([]pgVariant, 0, len(e.Variants))
+	for _, v := range e.Variants {
+		raw = append(raw, pgVariant{Name: v.Name, Weight: v.Weight})
+	}
+	variants, err := json.Marshal(raw)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode variants: %w", err)
+	}
+
+	return &pgExperiment{
+		Key:         e.Key,
+		Description: e.Description,
+		Variants:    string(variants),
+		IsActive:    e.IsActive,
+		CreatedAt:   e.CreatedAt,
+		UpdatedAt:   e.UpdatedAt,
+	}, nil
+}
+
+// pgExperimentEvent представляет ORM-модель для таблицы experiment_events.
+type pgExperimentEvent struct {
+	ID            int64     `gorm:"column:id;type:bigserial;primaryKey"`
+	ExperimentKey string    `gorm:"column:experiment_key;type:varchar(64);not null"`
+	UserID        string    `gorm:"column:user_id;type:uuid;not null"`
+	Variant       string    `gorm:"column:variant;type:varchar(64);not null"`
+	EventType     string    `gorm:"column:event_type;type:text;not null"`
+	Region        string    `gorm:"column:region;type:varchar(16);not null"`
+	CreatedAt     time.Time `gorm:"column:created_at;type:timestamptz;not null"`
+}
+
+func (pgExperimentEvent) TableName() string {
+	return "experiment_events"
+}
+
+// ExperimentRepository реализует repo.ExperimentRepository на GORM/Postgres.
+type ExperimentRepository struct {
+	db *gorm.DB
+}
+
+// Убедимся на этапе компиляции, что структура реализует интерфейс.
+var _ repo.ExperimentRepository = (*ExperimentRepository)(nil)
+
+// NewExperimentRepository создает новый репозиторий экспериментов.
+func NewExperimentRepository(db *gorm.DB) *ExperimentRepository {
+	return &ExperimentRepository{db: db}
+}
+
+// Create создает новый эксперимент.
+func (r *ExperimentRepository) Create(ctx context.Context, e *domain.Experiment) error {
+	model, err := fromDomainExperiment(e)
+	if err != nil {
+		return err
+	}
+	if err := dbFromContext(ctx, r.db).Create(model).Error; err != nil {
+		if isUniqueViolation(err, "experiments_pkey") {
+			return repo.ErrExperimentExists
+		}
+		return err
+	}
+	return nil
+}
+
+// Update обновляет описание, варианты и признак активности эксперимента.
+func (r *ExperimentRepository) Update(ctx context.Context, e *domain.Experiment) error {
+	model, err := fromDomainExperiment(e)
+	if err != nil {
+		return err
+	}
+
+	result := dbFromContext(ctx, r.db).
+		Model(&pgExperiment{}).
+		Where("key = ?", model.Key).
+		Updates(map[string]interface{}{
+			"description": model.Description,
+			"variants":    model.Variants,
+			"is_active":   model.IsActive,
+			// updated_at обновляется на стороне БД триггером update_experiments_updated_at
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return repo.ErrNotFound
+	}
+	return nil
+}
+
+// GetByKey возвращает эксперимент по ключу.
+func (r *ExperimentRepository) GetByKey(ctx context.Context, key string) (*domain.Experiment, error) {
+	var model pgExperiment
+	err := dbFromContext(ctx, r.db).Where("key = ?", key).Take(&model).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, repo.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return model.toDomain()
+}
+
+// List возвращает эксперименты, отсортированные по ключу.
+func (r *ExperimentRepository) List(ctx context.Context, activeOnly bool) ([]*domain.Experiment, error) {
+	query := dbFromContext(ctx, r.db)
+	if activeOnly {
+		query = query.Where("is_active = TRUE")
+	}
+
+	var models []pgExperiment
+	if err := query.Order("key").Find(&models).Error; err != nil {
+		return nil, err
+	}
+
+	experiments := make([]*domain.Experiment, 0, len(models))
+	for i := range models {
+		e, err := models[i].toDomain()
+		if err != nil {
+			return nil, err
+		}
+		experiments = append(experiments, e)
+	}
+	return experiments, nil
+}
+
+// CreateEvent сохраняет событие показа или конверсии.
+func (r *ExperimentRepository) CreateEvent(ctx context.Context, ev *domain.Event) error {
+	model := &pgExperimentEvent{
+		ExperimentKey: ev.ExperimentKey,
+		UserID:        ev.UserID.String(),
+		Variant:       ev.Variant,
+		EventType:     string(ev.Type),
+		Region:        string(ev.Region),
+		CreatedAt:     ev.CreatedAt,
+	}
+	if err := dbFromContext(ctx, r.db).Create(model).Error; err != nil {
+		return err
+	}
+	ev.ID = model.ID
+	return nil
+}
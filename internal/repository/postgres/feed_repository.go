@@ -0,0 +1,91 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	domain "workout-app/internal/domain/social"
+	userdomain "workout-app/internal/domain/user"
+	repo "workout-app/internal/repository/interfaces"
+)
+
+// pgFeedRow — строка ленты: тренировка и публичные данные её автора.
+type pgFeedRow struct {
+	pgWorkoutSession `gorm:"embedded"`
+	Username         string `gorm:"column:author_username"`
+	FirstName        string `gorm:"column:author_first_name"`
+	LastName         string `gorm:"column:author_last_name"`
+	AvatarURL        string `gorm:"column:author_avatar_url"`
+}
+
+// FeedRepository реализует repo.FeedRepository на GORM/Postgres.
+type FeedRepository struct {
+	db       *gorm.DB
+	sessions *WorkoutSessionRepository
+}
+
+// Убедимся на этапе компиляции, что структура реализует интерфейс.
+var _ repo.FeedRepository = (*FeedRepository)(nil)
+
+// NewFeedRepository создает новый репозиторий ленты активности.
+func NewFeedRepository(db *gorm.DB) *FeedRepository {
+	return &FeedRepository{db: db, sessions: NewWorkoutSessionRepository(db)}
+}
+
+// ListWorkouts выбирает тренировки из подписок читателя одним запросом с JOIN по follows
+// и подгружает подходы так же, как WorkoutSessionRepository.
+func (r *FeedRepository) ListWorkouts(ctx context.Context, viewerID uuid.UUID, limit, offset int) ([]*domain.FeedEntry, int64, error) {
+	// Отдельные цепочки для подсчёта и выборки: GORM не переиспользует запрос после Count.
+	filtered := func() *gorm.DB {
+		return dbFromContext(ctx, r.db).
+			Table("workout_sessions ws").
+			Joins("JOIN follows f ON f.followee_id = ws.user_id AND f.follower_id = ?", viewerID.String()).
+			Joins("JOIN users u ON u.id = ws.user_id").
+			Where("ws.finished_at IS NOT NULL").
+			Where("u.deleted_at IS NULL AND u.anonymized_at IS NULL").
+			Where("u.workouts_visibility IN ?", []string{string(userdomain.WorkoutsPublic), string(userdomain.WorkoutsFollowers)})
+	}
+
+	var total int64
+	if err := filtered().Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var rows []pgFeedRow
+	err := filtered().
+		Select("ws.*, u.username AS author_username, u.first_name AS author_first_name, " +
+			"u.last_name AS author_last_name, u.avatar_url AS author_avatar_url").
+		Order("ws.finished_at DESC, ws.id DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&rows).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	models := make([]pgWorkoutSession, 0, len(rows))
+	for _, row := range rows {
+		models = append(models, row.pgWorkoutSession)
+	}
+	sessions, err := r.sessions.withSets(ctx, models)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	entries := make([]*domain.FeedEntry, 0, len(rows))
+	for i, row := range rows {
+		entries = append(entries, &domain.FeedEntry{
+			Author: domain.Author{
+				UserID:    sessions[i].UserID,
+				Username:  row.Username,
+				FirstName: row.FirstName,
+				LastName:  row.LastName,
+				AvatarURL: row.AvatarURL,
+			},
+			Session: sessions[i],
+		})
+	}
+	return entries, total, nil
+}
@@ -0,0 +1,137 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	domain "workout-app/internal/domain/social"
+	repo "workout-app/internal/repository/interfaces"
+)
+
+// pgFollow представляет ORM-модель для таблицы follows.
+type pgFollow struct {
+	FollowerID string    `gorm:"column:follower_id;type:uuid;primaryKey"`
+	FolloweeID string    `gorm:"column:followee_id;type:uuid;primaryKey"`
+	CreatedAt  time.Time `gorm:"column:created_at;type:timestamptz;not null"`
+}
+
+func (pgFollow) TableName() string {
+	return "follows"
+}
+
+// pgConnectionRow — строка списка подписчиков или подписок: пользователь и время подписки.
+type pgConnectionRow struct {
+	UserID     string    `gorm:"column:user_id"`
+	Username   string    `gorm:"column:username"`
+	FirstName  string    `gorm:"column:first_name"`
+	LastName   string    `gorm:"column:last_name"`
+	AvatarURL  string    `gorm:"column:avatar_url"`
+	FollowedAt time.Time `gorm:"column:followed_at"`
+}
+
+// FollowRepository реализует repo.FollowRepository на GORM/Postgres.
+type FollowRepository struct {
+	db *gorm.DB
+}
+
+// Убедимся на этапе компиляции, что структура реализует интерфейс.
+var _ repo.FollowRepository = (*FollowRepository)(nil)
+
+// NewFollowRepository создает новый репозиторий подписок.
+func NewFollowRepository(db *gorm.DB) *FollowRepository {
+	return &FollowRepository{db: db}
+}
+
+// Create сохраняет подписку; повторная подписка игнорируется.
+func (r *FollowRepository) Create(ctx context.Context, f *domain.Follow) error {
+	return dbFromContext(ctx, r.db).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&pgFollow{
+			FollowerID: f.FollowerID.String(),
+			FolloweeID: f.FolloweeID.String(),
+			CreatedAt:  f.CreatedAt,
+		}).Error
+}
+
+// Delete удаляет подписку.
+func (r *FollowRepository) Delete(ctx context.Context, followerID, followeeID uuid.UUID) error {
+	return dbFromContext(ctx, r.db).
+		Where("follower_id = ? AND followee_id = ?", followerID.String(), followeeID.String()).
+		Delete(&pgFollow{}).Error
+}
+
+// Exists проверяет наличие подписки.
+func (r *FollowRepository) Exists(ctx context.Context, followerID, followeeID uuid.UUID) (bool, error) {
+	var count int64
+	err := dbFromContext(ctx, r.db).
+		Model(&pgFollow{}).
+		Where("follower_id = ? AND followee_id = ?", followerID.String(), followeeID.String()).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// ListFollowers возвращает подписчиков пользователя.
+func (r *FollowRepository) ListFollowers(ctx context.Context, userID uuid.UUID, limit, offset int) ([]*domain.Connection, int64, error) {
+	return r.list(ctx, "f.followee_id", "f.follower_id", userID, limit, offset)
+}
+
+// ListFollowing возвращает пользователей, на которых подписан userID.
+func (r *FollowRepository) ListFollowing(ctx context.Context, userID uuid.UUID, limit, offset int) ([]*domain.Connection, int64, error) {
+	return r.list(ctx, "f.follower_id", "f.followee_id", userID, limit, offset)
+}
+
+// list выбирает подписки, где колонка ownerColumn равна userID, вместе с пользователями из колонки otherColumn.
+func (r *FollowRepository) list(ctx context.Context, ownerColumn, otherColumn string, userID uuid.UUID, limit, offset int) ([]*domain.Connection, int64, error) {
+	// Отдельные цепочки для подсчёта и выборки: GORM не переиспользует запрос после Count.
+	filtered := func() *gorm.DB {
+		return dbFromContext(ctx, r.db).
+			Table("follows f").
+			Joins("JOIN users u ON u.id = "+otherColumn).
+			Where(ownerColumn+" = ?", userID.String()).
+			Where("u.deleted_at IS NULL AND u.anonymized_at IS NULL")
+	}
+
+	var total int64
+	if err := filtered().Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var rows []pgConnectionRow
+	err := filtered().
+		Select("u.id AS user_id, u.username, u.first_name, u.last_name, u.avatar_url, f.created_at AS followed_at").
+		Order("f.created_at DESC, u.username").
+		Limit(limit).
+		Offset(offset).
+		Find(&rows).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	connections := make([]*domain.Connection, 0, len(rows))
+	for _, row := range rows {
+		id, err := uuid.Parse(row.UserID)
+		if err != nil {
+			return nil, 0, err
+		}
+		connections = append(connections, &domain.Connection{
+			UserID:     id,
+			Username:   row.Username,
+			FirstName:  row.FirstName,
+			LastName:   row.LastName,
+			AvatarURL:  row.AvatarURL,
+			FollowedAt: row.FollowedAt,
+		})
+	}
+	return connections, total, nil
+}
+
+// DeleteByUserID удаляет подписки пользователя и подписки на него.
+func (r *FollowRepository) DeleteByUserID(ctx context.Context, userID uuid.UUID) error {
+	return dbFromContext(ctx, r.db).
+		Where("follower_id = ? OR followee_id = ?", userID.String(), userID.String()).
+		Delete(&pgFollow{}).Error
+}
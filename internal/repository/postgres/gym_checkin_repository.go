@@ -0,0 +1,228 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	domain "workout-app/internal/domain/gymcheckin"
+	repo "workout-app/internal/repository/interfaces"
+)
+
+// pgGymLocation представляет ORM-модель для таблицы gym_locations.
+type pgGymLocation struct {
+	ID             string    `gorm:"column:id;type:uuid;primaryKey"`
+	OrganizationID string    `gorm:"column:organization_id;type:uuid;not null"`
+	Name           string    `gorm:"column:name;type:varchar(200);not null"`
+	Address        string    `gorm:"column:address;type:varchar(500);not null"`
+	Secret         []byte    `gorm:"column:secret;type:bytea;not null"`
+	CreatedAt      time.Time `gorm:"column:created_at;type:timestamptz;not null"`
+	UpdatedAt      time.Time `gorm:"column:updated_at;type:timestamptz;not null"`
+}
+
+func (pgGymLocation) TableName() string {
+	return "gym_locations"
+}
+
+func (m *pgGymLocation) toDomain() (*domain.Location, error) {
+	id, err := uuid.Parse(m.ID)
+	if err != nil {
+		return nil, err
+	}
+	orgID, err := uuid.Parse(m.OrganizationID)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.Location{
+		ID:             id,
+		OrganizationID: orgID,
+		Name:           m.Name,
+		Address:        m.Address,
+		Secret:         m.Secret,
+		CreatedAt:      m.CreatedAt,
+		UpdatedAt:      m.UpdatedAt,
+	}, nil
+}
+
+// pgGymVisit представляет ORM-модель для таблицы gym_visits.
+type pgGymVisit struct {
+	ID             string    `gorm:"column:id;type:uuid;primaryKey"`
+	LocationID     string    `gorm:"column:location_id;type:uuid;not null"`
+	OrganizationID string    `gorm:"column:organization_id;type:uuid;not null"`
+	UserID         string    `gorm:"column:user_id;type:uuid;not null"`
+	CheckedInAt    time.Time `gorm:"column:checked_in_at;type:timestamptz;not null"`
+}
+
+func (pgGymVisit) TableName() string {
+	return "gym_visits"
+}
+
+func (m *pgGymVisit) toDomain() (*domain.Visit, error) {
+	id, err := uuid.Parse(m.ID)
+	if err != nil {
+		return nil, err
+	}
+	locationID, err := uuid.Parse(m.LocationID)
+	if err != nil {
+		return nil, err
+	}
+	orgID, err := uuid.Parse(m.OrganizationID)
+	if err != nil {
+		return nil, err
+	}
+	userID, err := uuid.Parse(m.UserID)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.Visit{
+		ID:             id,
+		LocationID:     locationID,
+		OrganizationID: orgID,
+		UserID:         userID,
+		CheckedInAt:    m.CheckedInAt,
+	}, nil
+}
+
+// GymCheckInRepository реализует repo.GymCheckInRepository на GORM/Postgres.
+type GymCheckInRepository struct {
+	db *gorm.DB
+}
+
+// Убедимся на этапе компиляции, что структура реализует интерфейс.
+var _ repo.GymCheckInRepository = (*GymCheckInRepository)(nil)
+
+// NewGymCheckInRepository создает новый репозиторий залов и отметок посещения.
+func NewGymCheckInRepository(db *gorm.DB) *GymCheckInRepository {
+	return &GymCheckInRepository{db: db}
+}
+
+// CreateLocation сохраняет зал.
+func (r *GymCheckInRepository) CreateLocation(ctx context.Context, l *domain.Location) error {
+	model := &pgGymLocation{
+		ID:             l.ID.String(),
+		OrganizationID: l.OrganizationID.String(),
+		Name:           l.Name,
+		Address:        l.Address,
+		Secret:         l.Secret,
+		CreatedAt:      l.CreatedAt,
+		UpdatedAt:      l.UpdatedAt,
+	}
+	return dbFromContext(ctx, r.db).Create(model).Error
+}
+
+// GetLocation возвращает зал.
+func (r *GymCheckInRepository) GetLocation(ctx context.Context, id uuid.UUID) (*domain.Location, error) {
+	var model pgGymLocation
+	err := dbFromContext(ctx, r.db).Where("id = ?", id.String()).Take(&model).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, repo.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return model.toDomain()
+}
+
+// ListLocations возвращает залы организации, отсортированные по названию.
+func (r *GymCheckInRepository) ListLocations(ctx context.Context, orgID uuid.UUID) ([]*domain.Location, error) {
+	var models []pgGymLocation
+	err := dbFromContext(ctx, r.db).
+		Where("organization_id = ?", orgID.String()).
+		Order("LOWER(name), created_at").
+		Find(&models).Error
+	if err != nil {
+		return nil, err
+	}
+
+	locations := make([]*domain.Location, 0, len(models))
+	for i := range models {
+		l, err := models[i].toDomain()
+		if err != nil {
+			return nil, err
+		}
+		locations = append(locations, l)
+	}
+	return locations, nil
+}
+
+// DeleteLocation удаляет зал; отметки удаляются каскадно.
+func (r *GymCheckInRepository) DeleteLocation(ctx context.Context, id uuid.UUID) error {
+	result := dbFromContext(ctx, r.db).Where("id = ?", id.String()).Delete(&pgGymLocation{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return repo.ErrNotFound
+	}
+	return nil
+}
+
+// CreateVisit сохраняет отметку в зале.
+func (r *GymCheckInRepository) CreateVisit(ctx context.Context, v *domain.Visit) error {
+	model := &pgGymVisit{
+		ID:             v.ID.String(),
+		LocationID:     v.LocationID.String(),
+		OrganizationID: v.OrganizationID.String(),
+		UserID:         v.UserID.String(),
+		CheckedInAt:    v.CheckedInAt,
+	}
+	return dbFromContext(ctx, r.db).Create(model).Error
+}
+
+// LastVisit возвращает последнюю отметку пользователя в зале.
+func (r *GymCheckInRepository) LastVisit(ctx context.Context, userID, locationID uuid.UUID) (*domain.Visit, error) {
+	var model pgGymVisit
+	err := dbFromContext(ctx, r.db).
+		Where("user_id = ? AND location_id = ?", userID.String(), locationID.String()).
+		Order("checked_in_at DESC").
+		Take(&model).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, repo.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return model.toDomain()
+}
+
+// ListVisitsByUser возвращает отметки пользователя за [from, to), новые первыми.
+func (r *GymCheckInRepository) ListVisitsByUser(ctx context.Context, userID uuid.UUID, from, to time.Time) ([]*domain.Visit, error) {
+	return r.listVisits(dbFromContext(ctx, r.db).
+		Where("user_id = ? AND checked_in_at >= ? AND checked_in_at < ?", userID.String(), from, to).
+		Order("checked_in_at DESC"))
+}
+
+// ListVisitsByOrganization возвращает отметки в залах организации за [from, to) по времени.
+func (r *GymCheckInRepository) ListVisitsByOrganization(ctx context.Context, orgID uuid.UUID, locationID *uuid.UUID, from, to time.Time) ([]*domain.Visit, error) {
+	query := dbFromContext(ctx, r.db).
+		Where("organization_id = ? AND checked_in_at >= ? AND checked_in_at < ?", orgID.String(), from, to)
+	if locationID != nil {
+		query = query.Where("location_id = ?", locationID.String())
+	}
+	return r.listVisits(query.Order("checked_in_at"))
+}
+
+// DeleteVisitsByUserID удаляет отметки пользователя.
+func (r *GymCheckInRepository) DeleteVisitsByUserID(ctx context.Context, userID uuid.UUID) error {
+	return dbFromContext(ctx, r.db).Where("user_id = ?", userID.String()).Delete(&pgGymVisit{}).Error
+}
+
+func (r *GymCheckInRepository) listVisits(query *gorm.DB) ([]*domain.Visit, error) {
+	var models []pgGymVisit
+	if err := query.Find(&models).Error; err != nil {
+		return nil, err
+	}
+
+	visits := make([]*domain.Visit, 0, len(models))
+	for i := range models {
+		v, err := models[i].toDomain()
+		if err != nil {
+			return nil, err
+		}
+		visits = append(visits, v)
+	}
+	return visits, nil
+}
@@ -0,0 +1,413 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	domain "workout-app/internal/domain/gymclass"
+	repo "workout-app/internal/repository/interfaces"
+)
+
+// pgGymClass представляет ORM-модель для таблицы gym_classes.
+type pgGymClass struct {
+	ID              string    `gorm:"column:id;type:uuid;primaryKey"`
+	OrganizationID  string    `gorm:"column:organization_id;type:uuid;not null"`
+	Title           string    `gorm:"column:title;type:varchar(200);not null"`
+	Description     string    `gorm:"column:description;type:varchar(2000);not null"`
+	CoachID         *string   `gorm:"column:coach_id;type:uuid"`
+	Capacity        int       `gorm:"column:capacity;not null"`
+	DurationMinutes int       `gorm:"column:duration_minutes;not null"`
+	CreatedAt       time.Time `gorm:"column:created_at;type:timestamptz;not null"`
+	UpdatedAt       time.Time `gorm:"column:updated_at;type:timestamptz;not null"`
+}
+
+func (pgGymClass) TableName() string {
+	return "gym_classes"
+}
+
+func newPgGymClass(c *domain.Class) *pgGymClass {
+	m := &pgGymClass{
+		ID:              c.ID.String(),
+		OrganizationID:  c.OrganizationID.String(),
+		Title:           c.Title,
+		Description:     c.Description,
+		Capacity:        c.Capacity,
+		DurationMinutes: c.DurationMinutes,
+		CreatedAt:       c.CreatedAt,
+		UpdatedAt:       c.UpdatedAt,
+	}
+	if c.CoachID != nil {
+		s := c.CoachID.String()
+		m.CoachID = &s
+	}
+	return m
+}
+
+func (m *pgGymClass) toDomain() (*domain.Class, error) {
+	id, err := uuid.Parse(m.ID)
+	if err != nil {
+		return nil, err
+	}
+	orgID, err := uuid.Parse(m.OrganizationID)
+	if err != nil {
+		return nil, err
+	}
+	c := &domain.Class{
+		ID:              id,
+		OrganizationID:  orgID,
+		Title:           m.Title,
+		Description:     m.Description,
+		Capacity:        m.Capacity,
+		DurationMinutes: m.DurationMinutes,
+		CreatedAt:       m.CreatedAt,
+		UpdatedAt:       m.UpdatedAt,
+	}
+	if m.CoachID != nil {
+		coachID, err := uuid.Parse(*m.CoachID)
+		if err != nil {
+			return nil, err
+		}
+		c.CoachID = &coachID
+	}
+	return c, nil
+}
+
+// pgGymClassSlot представляет ORM-модель для таблицы gym_class_slots.
+type pgGymClassSlot struct {
+	ID          string    `gorm:"column:id;type:uuid;primaryKey"`
+	ClassID     string    `gorm:"column:class_id;type:uuid;not null"`
+	Weekday     int       `gorm:"column:weekday;not null"`
+	StartMinute int       `gorm:"column:start_minute;not null"`
+	CreatedAt   time.Time `gorm:"column:created_at;type:timestamptz;not null"`
+}
+
+func (pgGymClassSlot) TableName() string {
+	return "gym_class_slots"
+}
+
+func (m *pgGymClassSlot) toDomain() (*domain.Slot, error) {
+	id, err := uuid.Parse(m.ID)
+	if err != nil {
+		return nil, err
+	}
+	classID, err := uuid.Parse(m.ClassID)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.Slot{
+		ID:          id,
+		ClassID:     classID,
+		Weekday:     m.Weekday,
+		StartMinute: m.StartMinute,
+		CreatedAt:   m.CreatedAt,
+	}, nil
+}
+
+// pgGymClassBooking представляет ORM-модель для таблицы gym_class_bookings.
+type pgGymClassBooking struct {
+	ID        string    `gorm:"column:id;type:uuid;primaryKey"`
+	ClassID   string    `gorm:"column:class_id;type:uuid;not null"`
+	UserID    string    `gorm:"column:user_id;type:uuid;not null"`
+	StartsAt  time.Time `gorm:"column:starts_at;type:timestamptz;not null"`
+	Status    string    `gorm:"column:status;type:varchar(16);not null"`
+	Attended  *bool     `gorm:"column:attended"`
+	CreatedAt time.Time `gorm:"column:created_at;type:timestamptz;not null"`
+	UpdatedAt time.Time `gorm:"column:updated_at;type:timestamptz;not null"`
+}
+
+func (pgGymClassBooking) TableName() string {
+	return "gym_class_bookings"
+}
+
+func (m *pgGymClassBooking) toDomain() (*domain.Booking, error) {
+	id, err := uuid.Parse(m.ID)
+	if err != nil {
+		return nil, err
+	}
+	classID, err := uuid.Parse(m.ClassID)
+	if err != nil {
+		return nil, err
+	}
+	userID, err := uuid.Parse(m.UserID)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.Booking{
+		ID:        id,
+		ClassID:   classID,
+		UserID:    userID,
+		StartsAt:  m.StartsAt,
+		Status:    domain.BookingStatus(m.Status),
+		Attended:  m.Attended,
+		CreatedAt: m.CreatedAt,
+		UpdatedAt: m.UpdatedAt,
+	}, nil
+}
+
+// GymClassRepository реализует repo.GymClassRepository на GORM/Postgres.
+type GymClassRepository struct {
+	db *gorm.DB
+}
+
+// Убедимся на этапе компиляции, что структура реализует интерфейс.
+var _ repo.GymClassRepository = (*GymClassRepository)(nil)
+
+// NewGymClassRepository создает новый репозиторий групповых занятий.
+func NewGymClassRepository(db *gorm.DB) *GymClassRepository {
+	return &GymClassRepository{db: db}
+}
+
+// Create сохраняет занятие.
+func (r *GymClassRepository) Create(ctx context.Context, c *domain.Class) error {
+	return dbFromContext(ctx, r.db).Create(newPgGymClass(c)).Error
+}
+
+// Update сохраняет изменения занятия.
+func (r *GymClassRepository) Update(ctx context.Context, c *domain.Class) error {
+	m := newPgGymClass(c)
+	result := dbFromContext(ctx, r.db).
+		Model(&pgGymClass{}).
+		Where("id = ?", m.ID).
+		Updates(map[string]any{
+			"title":            m.Title,
+			"description":      m.Description,
+			"coach_id":         m.CoachID,
+			"capacity":         m.Capacity,
+			"duration_minutes": m.DurationMinutes,
+			"updated_at":       m.UpdatedAt,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return repo.ErrNotFound
+	}
+	return nil
+}
+
+// GetByID возвращает занятие.
+func (r *GymClassRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Class, error) {
+	return r.getByID(dbFromContext(ctx, r.db), id)
+}
+
+// GetByIDForUpdate возвращает занятие, блокируя его строку до конца транзакции.
+func (r *GymClassRepository) GetByIDForUpdate(ctx context.Context, id uuid.UUID) (*domain.Class, error) {
+	return r.getByID(dbFromContext(ctx, r.db).Clauses(clause.Locking{Strength: "UPDATE"}), id)
+}
+
+func (r *GymClassRepository) getByID(db *gorm.DB, id uuid.UUID) (*domain.Class, error) {
+	var model pgGymClass
+	err := db.Where("id = ?", id.String()).Take(&model).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, repo.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return model.toDomain()
+}
+
+// ListByOrganization возвращает занятия организации, отсортированные по названию.
+func (r *GymClassRepository) ListByOrganization(ctx context.Context, orgID uuid.UUID) ([]*domain.Class, error) {
+	var models []pgGymClass
+	err := dbFromContext(ctx, r.db).
+		Where("organization_id = ?", orgID.String()).
+		Order("LOWER(title), created_at").
+		Find(&models).Error
+	if err != nil {
+		return nil, err
+	}
+
+	classes := make([]*domain.Class, 0, len(models))
+	for i := range models {
+		c, err := models[i].toDomain()
+		if err != nil {
+			return nil, err
+		}
+		classes = append(classes, c)
+	}
+	return classes, nil
+}
+
+// Delete удаляет занятие; слоты и записи удаляются каскадно.
+func (r *GymClassRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	result := dbFromContext(ctx, r.db).Where("id = ?", id.String()).Delete(&pgGymClass{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return repo.ErrNotFound
+	}
+	return nil
+}
+
+// AddSlot добавляет еженедельный слот занятия.
+func (r *GymClassRepository) AddSlot(ctx context.Context, s *domain.Slot) error {
+	model := &pgGymClassSlot{
+		ID:          s.ID.String(),
+		ClassID:     s.ClassID.String(),
+		Weekday:     s.Weekday,
+		StartMinute: s.StartMinute,
+		CreatedAt:   s.CreatedAt,
+	}
+	if err := dbFromContext(ctx, r.db).Create(model).Error; err != nil {
+		if isUniqueViolation(err, "uq_gym_class_slots_time") {
+			return repo.ErrSlotExists
+		}
+		return err
+	}
+	return nil
+}
+
+// ListSlots возвращает слоты занятий по дню недели и времени.
+func (r *GymClassRepository) ListSlots(ctx context.Context, classIDs []uuid.UUID) ([]*domain.Slot, error) {
+	if len(classIDs) == 0 {
+		return []*domain.Slot{}, nil
+	}
+	var models []pgGymClassSlot
+	err := dbFromContext(ctx, r.db).
+		Where("class_id IN ?", classIDStrings(classIDs)).
+		Order("weekday, start_minute").
+		Find(&models).Error
+	if err != nil {
+		return nil, err
+	}
+
+	slots := make([]*domain.Slot, 0, len(models))
+	for i := range models {
+		s, err := models[i].toDomain()
+		if err != nil {
+			return nil, err
+		}
+		slots = append(slots, s)
+	}
+	return slots, nil
+}
+
+// DeleteSlot удаляет слот занятия.
+func (r *GymClassRepository) DeleteSlot(ctx context.Context, classID, slotID uuid.UUID) error {
+	result := dbFromContext(ctx, r.db).
+		Where("id = ? AND class_id = ?", slotID.String(), classID.String()).
+		Delete(&pgGymClassSlot{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return repo.ErrNotFound
+	}
+	return nil
+}
+
+// CreateBooking сохраняет запись на занятие.
+func (r *GymClassRepository) CreateBooking(ctx context.Context, b *domain.Booking) error {
+	model := &pgGymClassBooking{
+		ID:        b.ID.String(),
+		ClassID:   b.ClassID.String(),
+		UserID:    b.UserID.String(),
+		StartsAt:  b.StartsAt,
+		Status:    string(b.Status),
+		Attended:  b.Attended,
+		CreatedAt: b.CreatedAt,
+		UpdatedAt: b.UpdatedAt,
+	}
+	if err := dbFromContext(ctx, r.db).Create(model).Error; err != nil {
+		if isUniqueViolation(err, "idx_gym_class_bookings_active") {
+			return repo.ErrAlreadyBooked
+		}
+		return err
+	}
+	return nil
+}
+
+// UpdateBooking сохраняет статус и отметку о посещении записи.
+func (r *GymClassRepository) UpdateBooking(ctx context.Context, b *domain.Booking) error {
+	result := dbFromContext(ctx, r.db).
+		Model(&pgGymClassBooking{}).
+		Where("id = ?", b.ID.String()).
+		Updates(map[string]any{
+			"status":     string(b.Status),
+			"attended":   b.Attended,
+			"updated_at": b.UpdatedAt,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return repo.ErrNotFound
+	}
+	return nil
+}
+
+// GetBooking возвращает запись.
+func (r *GymClassRepository) GetBooking(ctx context.Context, id uuid.UUID) (*domain.Booking, error) {
+	var model pgGymClassBooking
+	err := dbFromContext(ctx, r.db).Where("id = ?", id.String()).Take(&model).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, repo.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return model.toDomain()
+}
+
+// ListOccurrenceBookings возвращает активные записи на занятие в порядке записи.
+func (r *GymClassRepository) ListOccurrenceBookings(ctx context.Context, classID uuid.UUID, startsAt time.Time) ([]*domain.Booking, error) {
+	return r.listBookings(dbFromContext(ctx, r.db).
+		Where("class_id = ? AND starts_at = ? AND status <> ?", classID.String(), startsAt, string(domain.StatusCancelled)).
+		Order("created_at, id"))
+}
+
+// ListActiveBookings возвращает активные записи на занятия classIDs, начинающиеся в [from, to).
+func (r *GymClassRepository) ListActiveBookings(ctx context.Context, classIDs []uuid.UUID, from, to time.Time) ([]*domain.Booking, error) {
+	if len(classIDs) == 0 {
+		return []*domain.Booking{}, nil
+	}
+	return r.listBookings(dbFromContext(ctx, r.db).
+		Where("class_id IN ? AND starts_at >= ? AND starts_at < ? AND status <> ?",
+			classIDStrings(classIDs), from, to, string(domain.StatusCancelled)).
+		Order("starts_at, created_at"))
+}
+
+// ListBookingsByUser возвращает записи пользователя на занятия, начинающиеся в [from, to).
+func (r *GymClassRepository) ListBookingsByUser(ctx context.Context, userID uuid.UUID, from, to time.Time) ([]*domain.Booking, error) {
+	return r.listBookings(dbFromContext(ctx, r.db).
+		Where("user_id = ? AND starts_at >= ? AND starts_at < ?", userID.String(), from, to).
+		Order("starts_at, created_at"))
+}
+
+// DeleteBookingsByUserID удаляет записи пользователя.
+func (r *GymClassRepository) DeleteBookingsByUserID(ctx context.Context, userID uuid.UUID) error {
+	return dbFromContext(ctx, r.db).Where("user_id = ?", userID.String()).Delete(&pgGymClassBooking{}).Error
+}
+
+func (r *GymClassRepository) listBookings(query *gorm.DB) ([]*domain.Booking, error) {
+	var models []pgGymClassBooking
+	if err := query.Find(&models).Error; err != nil {
+		return nil, err
+	}
+
+	bookings := make([]*domain.Booking, 0, len(models))
+	for i := range models {
+		b, err := models[i].toDomain()
+		if err != nil {
+			return nil, err
+		}
+		bookings = append(bookings, b)
+	}
+	return bookings, nil
+}
+
+// classIDStrings приводит ID занятий к строкам для условия IN.
+func classIDStrings(ids []uuid.UUID) []string {
+	result := make([]string, 0, len(ids))
+	for _, id := range ids {
+		result = append(result, id.String())
+	}
+	return result
+}
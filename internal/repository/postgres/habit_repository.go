@@ -0,0 +1,149 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	domain "workout-app/internal/domain/habit"
+	repo "workout-app/internal/repository/interfaces"
+)
+
+// pgHabitDay представляет ORM-модель для таблицы habit_days.
+type pgHabitDay struct {
+	UserID     string    `gorm:"column:user_id;type:uuid;primaryKey"`
+	Date       time.Time `gorm:"column:date;type:date;primaryKey"`
+	WaterMl    *int      `gorm:"column:water_ml"`
+	SleepHours *float64  `gorm:"column:sleep_hours;type:numeric(3,1)"`
+	RestingHR  *int      `gorm:"column:resting_hr;type:smallint"`
+	Steps      *int      `gorm:"column:steps"`
+	UpdatedAt  time.Time `gorm:"column:updated_at;type:timestamptz;not null"`
+}
+
+func (pgHabitDay) TableName() string {
+	return "habit_days"
+}
+
+func (m *pgHabitDay) toDomain() (*domain.Day, error) {
+	userID, err := uuid.Parse(m.UserID)
+	if err != nil {
+		return nil, err
+	}
+	y, mo, d := m.Date.Date()
+	return &domain.Day{
+		UserID:     userID,
+		Date:       time.Date(y, mo, d, 0, 0, 0, 0, time.UTC),
+		WaterMl:    m.WaterMl,
+		SleepHours: m.SleepHours,
+		RestingHR:  m.RestingHR,
+		Steps:      m.Steps,
+		UpdatedAt:  m.UpdatedAt,
+	}, nil
+}
+
+// HabitRepository реализует repo.HabitRepository на GORM/Postgres.
+type HabitRepository struct {
+	db *gorm.DB
+}
+
+// Убедимся на этапе компиляции, что структура реализует интерфейс.
+var _ repo.HabitRepository = (*HabitRepository)(nil)
+
+// NewHabitRepository создает новый репозиторий ежедневных привычек.
+func NewHabitRepository(db *gorm.DB) *HabitRepository {
+	return &HabitRepository{db: db}
+}
+
+// Upsert сохраняет привычки дня, заменяя прежнюю запись.
+func (r *HabitRepository) Upsert(ctx context.Context, d *domain.Day) error {
+	model := &pgHabitDay{
+		UserID:     d.UserID.String(),
+		Date:       d.Date,
+		WaterMl:    d.WaterMl,
+		SleepHours: d.SleepHours,
+		RestingHR:  d.RestingHR,
+		Steps:      d.Steps,
+		UpdatedAt:  d.UpdatedAt,
+	}
+	return dbFromContext(ctx, r.db).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "user_id"}, {Name: "date"}},
+			DoUpdates: clause.AssignmentColumns([]string{"water_ml", "sleep_hours", "resting_hr", "steps", "updated_at"}),
+		}).
+		Create(model).Error
+}
+
+// AddSteps прибавляет шаги к записи дня.
+func (r *HabitRepository) AddSteps(ctx context.Context, userID uuid.UUID, date time.Time, steps int, at time.Time) error {
+	model := &pgHabitDay{UserID: userID.String(), Date: date, Steps: &steps, UpdatedAt: at}
+	return dbFromContext(ctx, r.db).
+		Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "user_id"}, {Name: "date"}},
+			DoUpdates: clause.Assignments(map[string]any{
+				"steps":      gorm.Expr("COALESCE(habit_days.steps, 0) + EXCLUDED.steps"),
+				"updated_at": gorm.Expr("EXCLUDED.updated_at"),
+			}),
+		}).
+		Create(model).Error
+}
+
+// LowerRestingHR записывает пульс покоя дня, если он ниже сохранённого.
+func (r *HabitRepository) LowerRestingHR(ctx context.Context, userID uuid.UUID, date time.Time, bpm int, at time.Time) error {
+	model := &pgHabitDay{UserID: userID.String(), Date: date, RestingHR: &bpm, UpdatedAt: at}
+	return dbFromContext(ctx, r.db).
+		Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "user_id"}, {Name: "date"}},
+			DoUpdates: clause.Assignments(map[string]any{
+				// LEAST игнорирует NULL, поэтому незаполненный пульс просто заменяется.
+				"resting_hr": gorm.Expr("LEAST(habit_days.resting_hr, EXCLUDED.resting_hr)"),
+				"updated_at": gorm.Expr("EXCLUDED.updated_at"),
+			}),
+		}).
+		Create(model).Error
+}
+
+// ListByPeriod возвращает записи пользователя за дни [from, to].
+func (r *HabitRepository) ListByPeriod(ctx context.Context, userID uuid.UUID, from, to time.Time) ([]*domain.Day, error) {
+	var models []pgHabitDay
+	err := dbFromContext(ctx, r.db).
+		Where("user_id = ? AND date >= ? AND date <= ?", userID.String(), from, to).
+		Order("date").
+		Find(&models).Error
+	if err != nil {
+		return nil, err
+	}
+
+	days := make([]*domain.Day, 0, len(models))
+	for i := range models {
+		d, err := models[i].toDomain()
+		if err != nil {
+			return nil, err
+		}
+		days = append(days, d)
+	}
+	return days, nil
+}
+
+// Delete удаляет запись дня.
+func (r *HabitRepository) Delete(ctx context.Context, userID uuid.UUID, date time.Time) error {
+	result := dbFromContext(ctx, r.db).
+		Where("user_id = ? AND date = ?", userID.String(), date).
+		Delete(&pgHabitDay{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return repo.ErrNotFound
+	}
+	return nil
+}
+
+// DeleteByUserID удаляет привычки пользователя.
+func (r *HabitRepository) DeleteByUserID(ctx context.Context, userID uuid.UUID) error {
+	return dbFromContext(ctx, r.db).
+		Where("user_id = ?", userID.String()).
+		Delete(&pgHabitDay{}).Error
+}
@@ -0,0 +1,186 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	domain "workout-app/internal/domain/healthsync"
+	repo "workout-app/internal/repository/interfaces"
+)
+
+// linkedIDsChunk ограничивает число внешних ID в одном запросе: у Postgres есть предел параметров.
+const linkedIDsChunk = 5000
+
+// pgHealthConnection представляет ORM-модель для таблицы health_connections.
+type pgHealthConnection struct {
+	UserID       string     `gorm:"column:user_id;type:uuid;primaryKey"`
+	Provider     string     `gorm:"column:provider;primaryKey"`
+	Items        int        `gorm:"column:items;not null"`
+	SyncedUntil  *time.Time `gorm:"column:synced_until;type:timestamptz"`
+	LastSyncedAt *time.Time `gorm:"column:last_synced_at;type:timestamptz"`
+	CreatedAt    time.Time  `gorm:"column:created_at;type:timestamptz;not null"`
+}
+
+func (pgHealthConnection) TableName() string {
+	return "health_connections"
+}
+
+func (m *pgHealthConnection) toDomain() (*domain.Connection, error) {
+	userID, err := uuid.Parse(m.UserID)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.Connection{
+		UserID:       userID,
+		Provider:     domain.Provider(m.Provider),
+		Items:        m.Items,
+		SyncedUntil:  m.SyncedUntil,
+		LastSyncedAt: m.LastSyncedAt,
+		CreatedAt:    m.CreatedAt,
+	}, nil
+}
+
+// pgHealthLink представляет ORM-модель для таблицы health_links.
+type pgHealthLink struct {
+	UserID     string    `gorm:"column:user_id;type:uuid;primaryKey"`
+	Provider   string    `gorm:"column:provider;primaryKey"`
+	ExternalID string    `gorm:"column:external_id;primaryKey"`
+	Kind       string    `gorm:"column:kind;not null"`
+	EntityID   *string   `gorm:"column:entity_id;type:uuid"`
+	CreatedAt  time.Time `gorm:"column:created_at;type:timestamptz;not null"`
+}
+
+func (pgHealthLink) TableName() string {
+	return "health_links"
+}
+
+// HealthSyncRepository реализует repo.HealthSyncRepository на GORM/Postgres.
+type HealthSyncRepository struct {
+	db *gorm.DB
+}
+
+// Убедимся на этапе компиляции, что структура реализует интерфейс.
+var _ repo.HealthSyncRepository = (*HealthSyncRepository)(nil)
+
+// NewHealthSyncRepository создает новый репозиторий синхронизации с Apple Health и Google Fit.
+func NewHealthSyncRepository(db *gorm.DB) *HealthSyncRepository {
+	return &HealthSyncRepository{db: db}
+}
+
+// LockConnection возвращает состояние синхронизации, создавая его при отсутствии, и блокирует строку.
+func (r *HealthSyncRepository) LockConnection(ctx context.Context, c *domain.Connection) (*domain.Connection, error) {
+	db := dbFromContext(ctx, r.db)
+	model := &pgHealthConnection{
+		UserID:    c.UserID.String(),
+		Provider:  string(c.Provider),
+		CreatedAt: c.CreatedAt,
+	}
+	if err := db.Clauses(clause.OnConflict{DoNothing: true}).Create(model).Error; err != nil {
+		return nil, err
+	}
+
+	var locked pgHealthConnection
+	err := db.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("user_id = ? AND provider = ?", model.UserID, model.Provider).
+		Take(&locked).Error
+	if err != nil {
+		return nil, err
+	}
+	return locked.toDomain()
+}
+
+// UpdateConnection сохраняет счётчик, курсор и время синхронизации.
+func (r *HealthSyncRepository) UpdateConnection(ctx context.Context, c *domain.Connection) error {
+	result := dbFromContext(ctx, r.db).
+		Model(&pgHealthConnection{}).
+		Where("user_id = ? AND provider = ?", c.UserID.String(), string(c.Provider)).
+		Updates(map[string]any{
+			"items":          c.Items,
+			"synced_until":   c.SyncedUntil,
+			"last_synced_at": c.LastSyncedAt,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return repo.ErrNotFound
+	}
+	return nil
+}
+
+// ListConnections возвращает источники, с которыми синхронизировался пользователь.
+func (r *HealthSyncRepository) ListConnections(ctx context.Context, userID uuid.UUID) ([]*domain.Connection, error) {
+	var models []pgHealthConnection
+	err := dbFromContext(ctx, r.db).
+		Where("user_id = ?", userID.String()).
+		Order("provider").
+		Find(&models).Error
+	if err != nil {
+		return nil, err
+	}
+
+	connections := make([]*domain.Connection, 0, len(models))
+	for i := range models {
+		c, err := models[i].toDomain()
+		if err != nil {
+			return nil, err
+		}
+		connections = append(connections, c)
+	}
+	return connections, nil
+}
+
+// LinkedIDs возвращает уже импортированные внешние ID.
+func (r *HealthSyncRepository) LinkedIDs(ctx context.Context, userID uuid.UUID, provider domain.Provider, externalIDs []string) ([]string, error) {
+	var linked []string
+	for start := 0; start < len(externalIDs); start += linkedIDsChunk {
+		end := min(start+linkedIDsChunk, len(externalIDs))
+		var chunk []string
+		err := dbFromContext(ctx, r.db).
+			Model(&pgHealthLink{}).
+			Where("user_id = ? AND provider = ? AND external_id IN ?", userID.String(), string(provider), externalIDs[start:end]).
+			Pluck("external_id", &chunk).Error
+		if err != nil {
+			return nil, err
+		}
+		linked = append(linked, chunk...)
+	}
+	return linked, nil
+}
+
+// CreateLinks сохраняет связи импортированных записей.
+func (r *HealthSyncRepository) CreateLinks(ctx context.Context, links []*domain.Link) error {
+	if len(links) == 0 {
+		return nil
+	}
+	models := make([]pgHealthLink, 0, len(links))
+	for _, l := range links {
+		var entityID *string
+		if l.EntityID != nil {
+			id := l.EntityID.String()
+			entityID = &id
+		}
+		models = append(models, pgHealthLink{
+			UserID:     l.UserID.String(),
+			Provider:   string(l.Provider),
+			ExternalID: l.ExternalID,
+			Kind:       string(l.Kind),
+			EntityID:   entityID,
+			CreatedAt:  l.CreatedAt,
+		})
+	}
+	return dbFromContext(ctx, r.db).CreateInBatches(models, 1000).Error
+}
+
+// DeleteByUserID удаляет состояние синхронизации и связи пользователя.
+func (r *HealthSyncRepository) DeleteByUserID(ctx context.Context, userID uuid.UUID) error {
+	db := dbFromContext(ctx, r.db)
+	if err := db.Where("user_id = ?", userID.String()).Delete(&pgHealthLink{}).Error; err != nil {
+		return err
+	}
+	return db.Where("user_id = ?", userID.String()).Delete(&pgHealthConnection{}).Error
+}
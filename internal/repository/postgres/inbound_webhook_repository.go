@@ -0,0 +1,164 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	domain "workout-app/internal/domain/inboundwebhook"
+	repo "workout-app/internal/repository/interfaces"
+)
+
+// pgInboundWebhookEvent представляет ORM-модель для таблицы inbound_webhook_events.
+type pgInboundWebhookEvent struct {
+	ID            int64      `gorm:"column:id;primaryKey;autoIncrement"`
+	Provider      string     `gorm:"column:provider;type:varchar(32);not null"`
+	ExternalID    string     `gorm:"column:external_id;type:varchar(255);not null"`
+	EventType     string     `gorm:"column:event_type;type:varchar(128);not null"`
+	Payload       []byte     `gorm:"column:payload;type:bytea;not null"`
+	Status        string     `gorm:"column:status;type:varchar(16);not null"`
+	Attempts      int        `gorm:"column:attempts;not null"`
+	NextAttemptAt time.Time  `gorm:"column:next_attempt_at;type:timestamptz;not null"`
+	LastError     string     `gorm:"column:last_error;type:text;not null"`
+	TraceParent   string     `gorm:"column:trace_parent;type:varchar(55);not null"`
+	ReceivedAt    time.Time  `gorm:"column:received_at;type:timestamptz;not null"`
+	FinishedAt    *time.Time `gorm:"column:finished_at;type:timestamptz"`
+}
+
+func (pgInboundWebhookEvent) TableName() string {
+	return "inbound_webhook_events"
+}
+
+func (m *pgInboundWebhookEvent) toDomain() *domain.Event {
+	return &domain.Event{
+		ID:            m.ID,
+		Provider:      m.Provider,
+		ExternalID:    m.ExternalID,
+		Type:          m.EventType,
+		Payload:       m.Payload,
+		Status:        domain.Status(m.Status),
+		Attempts:      m.Attempts,
+		NextAttemptAt: m.NextAttemptAt,
+		LastError:     m.LastError,
+		TraceParent:   m.TraceParent,
+		ReceivedAt:    m.ReceivedAt,
+		FinishedAt:    m.FinishedAt,
+	}
+}
+
+// InboundWebhookRepository реализует repo.InboundWebhookRepository на GORM/Postgres.
+type InboundWebhookRepository struct {
+	db *gorm.DB
+}
+
+// Убедимся на этапе компиляции, что структура реализует интерфейс.
+var _ repo.InboundWebhookRepository = (*InboundWebhookRepository)(nil)
+
+// NewInboundWebhookRepository создает новый репозиторий входящих событий webhooks.
+func NewInboundWebhookRepository(db *gorm.DB) *InboundWebhookRepository {
+	return &InboundWebhookRepository{db: db}
+}
+
+// Create сохраняет событие; повтор события провайдера игнорируется.
+func (r *InboundWebhookRepository) Create(ctx context.Context, e *domain.Event) (bool, error) {
+	model := &pgInboundWebhookEvent{
+		Provider:      e.Provider,
+		ExternalID:    e.ExternalID,
+		EventType:     e.Type,
+		Payload:       e.Payload,
+		Status:        string(e.Status),
+		Attempts:      e.Attempts,
+		NextAttemptAt: e.NextAttemptAt,
+		LastError:     e.LastError,
+		TraceParent:   e.TraceParent,
+		ReceivedAt:    e.ReceivedAt,
+	}
+	result := dbFromContext(ctx, r.db).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "provider"}, {Name: "external_id"}},
+			DoNothing: true,
+		}).
+		Create(model)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return false, nil
+	}
+	e.ID = model.ID
+	return true, nil
+}
+
+// ListDue возвращает события, время попытки которых наступило, старые первыми.
+func (r *InboundWebhookRepository) ListDue(ctx context.Context, now time.Time, limit int) ([]*domain.Event, error) {
+	var models []pgInboundWebhookEvent
+	err := dbFromContext(ctx, r.db).
+		Where("status = ? AND next_attempt_at <= ?", string(domain.StatusPending), now).
+		Order("next_attempt_at, id").
+		Limit(limit).
+		Find(&models).Error
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([]*domain.Event, 0, len(models))
+	for i := range models {
+		events = append(events, models[i].toDomain())
+	}
+	return events, nil
+}
+
+// Claim захватывает обработку события до момента until.
+func (r *InboundWebhookRepository) Claim(ctx context.Context, id int64, now, until time.Time) (bool, error) {
+	result := dbFromContext(ctx, r.db).
+		Model(&pgInboundWebhookEvent{}).
+		Where("id = ? AND status = ? AND next_attempt_at <= ?", id, string(domain.StatusPending), now).
+		Update("next_attempt_at", until)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+// Save сохраняет результат попытки обработки.
+func (r *InboundWebhookRepository) Save(ctx context.Context, e *domain.Event) error {
+	result := dbFromContext(ctx, r.db).
+		Model(&pgInboundWebhookEvent{}).
+		Where("id = ?", e.ID).
+		Updates(map[string]any{
+			"status":          string(e.Status),
+			"attempts":        e.Attempts,
+			"next_attempt_at": e.NextAttemptAt,
+			"last_error":      e.LastError,
+			"finished_at":     e.FinishedAt,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return repo.ErrNotFound
+	}
+	return nil
+}
+
+// CountPending возвращает количество событий в очереди.
+func (r *InboundWebhookRepository) CountPending(ctx context.Context) (int64, error) {
+	var count int64
+	err := dbFromContext(ctx, r.db).
+		Model(&pgInboundWebhookEvent{}).
+		Where("status = ?", string(domain.StatusPending)).
+		Count(&count).Error
+	return count, err
+}
+
+// DeleteFinished удаляет завершённые события пачкой не больше limit.
+func (r *InboundWebhookRepository) DeleteFinished(ctx context.Context, before time.Time, limit int) (int64, error) {
+	result := dbFromContext(ctx, r.db).Exec(
+		`DELETE FROM inbound_webhook_events
+		 WHERE id IN (SELECT id FROM inbound_webhook_events WHERE finished_at < ? ORDER BY id LIMIT ?)`,
+		before, limit,
+	)
+	return result.RowsAffected, result.Error
+}
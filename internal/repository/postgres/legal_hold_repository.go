@@ -0,0 +1,99 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	domain "workout-app/internal/domain/user"
+	repo "workout-app/internal/repository/interfaces"
+)
+
+// pgLegalHoldRecord представляет ORM-модель для таблицы legal_hold_audit.
+type pgLegalHoldRecord struct {
+	ID        int64     `gorm:"column:id;primaryKey;autoIncrement"`
+	UserID    string    `gorm:"column:user_id;type:uuid;not null"`
+	Action    string    `gorm:"column:action;type:varchar(16);not null"`
+	ActorID   string    `gorm:"column:actor_id;type:uuid;not null"`
+	Reason    string    `gorm:"column:reason;type:text;not null"`
+	CreatedAt time.Time `gorm:"column:created_at;type:timestamptz;not null"`
+}
+
+func (pgLegalHoldRecord) TableName() string {
+	return "legal_hold_audit"
+}
+
+func (m *pgLegalHoldRecord) toDomain() (*domain.LegalHoldRecord, error) {
+	userID, err := uuid.Parse(m.UserID)
+	if err != nil {
+		return nil, err
+	}
+	actorID, err := uuid.Parse(m.ActorID)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.LegalHoldRecord{
+		ID:        m.ID,
+		UserID:    userID,
+		Action:    domain.LegalHoldAction(m.Action),
+		ActorID:   actorID,
+		Reason:    m.Reason,
+		CreatedAt: m.CreatedAt,
+	}, nil
+}
+
+// LegalHoldAuditRepository реализует repo.LegalHoldAuditRepository на GORM/Postgres.
+type LegalHoldAuditRepository struct {
+	db *gorm.DB
+}
+
+// Убедимся на этапе компиляции, что структура реализует интерфейс.
+var _ repo.LegalHoldAuditRepository = (*LegalHoldAuditRepository)(nil)
+
+// NewLegalHoldAuditRepository создает новый репозиторий журнала юридических удержаний.
+func NewLegalHoldAuditRepository(db *gorm.DB) *LegalHoldAuditRepository {
+	return &LegalHoldAuditRepository{db: db}
+}
+
+// Create добавляет запись в журнал.
+func (r *LegalHoldAuditRepository) Create(ctx context.Context, rec *domain.LegalHoldRecord) error {
+	if rec.CreatedAt.IsZero() {
+		rec.CreatedAt = time.Now().UTC()
+	}
+	model := &pgLegalHoldRecord{
+		UserID:    rec.UserID.String(),
+		Action:    string(rec.Action),
+		ActorID:   rec.ActorID.String(),
+		Reason:    rec.Reason,
+		CreatedAt: rec.CreatedAt,
+	}
+	if err := dbFromContext(ctx, r.db).Create(model).Error; err != nil {
+		return err
+	}
+	rec.ID = model.ID
+	return nil
+}
+
+// ListByUser возвращает записи журнала по пользователю в хронологическом порядке.
+func (r *LegalHoldAuditRepository) ListByUser(ctx context.Context, userID uuid.UUID) ([]*domain.LegalHoldRecord, error) {
+	var models []pgLegalHoldRecord
+	err := dbFromContext(ctx, r.db).
+		Where("user_id = ?", userID.String()).
+		Order("id").
+		Find(&models).Error
+	if err != nil {
+		return nil, err
+	}
+
+	records := make([]*domain.LegalHoldRecord, 0, len(models))
+	for i := range models {
+		rec, err := models[i].toDomain()
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, rec)
+	}
+	return records, nil
+}
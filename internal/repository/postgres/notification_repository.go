@@ -0,0 +1,272 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	domain "workout-app/internal/domain/notification"
+	repo "workout-app/internal/repository/interfaces"
+)
+
+// pgNotification представляет ORM-модель для таблицы notifications.
+type pgNotification struct {
+	ID        string     `gorm:"column:id;type:uuid;primaryKey"`
+	UserID    string     `gorm:"column:user_id;type:uuid;not null"`
+	Type      string     `gorm:"column:type;type:varchar(64);not null"`
+	Data      string     `gorm:"column:data;type:jsonb;not null"`
+	EventID   int64      `gorm:"column:event_id;not null"`
+	CreatedAt time.Time  `gorm:"column:created_at;type:timestamptz;not null"`
+	ReadAt    *time.Time `gorm:"column:read_at;type:timestamptz"`
+
+	PushPending bool `gorm:"column:push_pending;not null"`
+}
+
+func (pgNotification) TableName() string {
+	return "notifications"
+}
+
+func (m *pgNotification) toDomain() (*domain.Notification, error) {
+	id, err := uuid.Parse(m.ID)
+	if err != nil {
+		return nil, err
+	}
+	userID, err := uuid.Parse(m.UserID)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.Notification{
+		ID:        id,
+		UserID:    userID,
+		Type:      m.Type,
+		Data:      []byte(m.Data),
+		EventID:   m.EventID,
+		CreatedAt: m.CreatedAt,
+		ReadAt:    m.ReadAt,
+
+		PushPending: m.PushPending,
+	}, nil
+}
+
+// pgNotificationPreference представляет ORM-модель для таблицы notification_preferences.
+type pgNotificationPreference struct {
+	UserID       string    `gorm:"column:user_id;type:uuid;primaryKey"`
+	Type         string    `gorm:"column:type;type:varchar(64);primaryKey"`
+	EmailEnabled bool      `gorm:"column:email_enabled;not null"`
+	PushEnabled  bool      `gorm:"column:push_enabled;not null"`
+	UpdatedAt    time.Time `gorm:"column:updated_at;type:timestamptz;not null"`
+}
+
+func (pgNotificationPreference) TableName() string {
+	return "notification_preferences"
+}
+
+// NotificationRepository реализует repo.NotificationRepository на GORM/Postgres.
+type NotificationRepository struct {
+	db *gorm.DB
+}
+
+// Убедимся на этапе компиляции, что структура реализует интерфейс.
+var _ repo.NotificationRepository = (*NotificationRepository)(nil)
+
+// NewNotificationRepository создает новый репозиторий уведомлений.
+func NewNotificationRepository(db *gorm.DB) *NotificationRepository {
+	return &NotificationRepository{db: db}
+}
+
+// Create сохраняет уведомление; конфликт по (event_id, user_id) не считается ошибкой.
+func (r *NotificationRepository) Create(ctx context.Context, n *domain.Notification) (bool, error) {
+	data := string(n.Data)
+	if data == "" {
+		data = "{}"
+	}
+	result := dbFromContext(ctx, r.db).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "event_id"}, {Name: "user_id"}},
+			DoNothing: true,
+		}).
+		Create(&pgNotification{
+			ID:        n.ID.String(),
+			UserID:    n.UserID.String(),
+			Type:      n.Type,
+			Data:      data,
+			EventID:   n.EventID,
+			CreatedAt: n.CreatedAt,
+			ReadAt:    n.ReadAt,
+
+			PushPending: n.PushPending,
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// List возвращает уведомления пользователя, новые первыми, и их общее количество.
+func (r *NotificationRepository) List(ctx context.Context, userID uuid.UUID, unreadOnly bool, limit, offset int) ([]*domain.Notification, int64, error) {
+	filtered := func() *gorm.DB {
+		query := dbFromContext(ctx, r.db).Model(&pgNotification{}).Where("user_id = ?", userID.String())
+		if unreadOnly {
+			query = query.Where("read_at IS NULL")
+		}
+		return query
+	}
+
+	var total int64
+	if err := filtered().Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var models []pgNotification
+	if err := filtered().Order("created_at DESC, id").Limit(limit).Offset(offset).Find(&models).Error; err != nil {
+		return nil, 0, err
+	}
+
+	items := make([]*domain.Notification, 0, len(models))
+	for i := range models {
+		n, err := models[i].toDomain()
+		if err != nil {
+			return nil, 0, err
+		}
+		items = append(items, n)
+	}
+	return items, total, nil
+}
+
+// CountUnread возвращает количество непрочитанных уведомлений пользователя.
+func (r *NotificationRepository) CountUnread(ctx context.Context, userID uuid.UUID) (int64, error) {
+	var count int64
+	err := dbFromContext(ctx, r.db).
+		Model(&pgNotification{}).
+		Where("user_id = ? AND read_at IS NULL", userID.String()).
+		Count(&count).Error
+	return count, err
+}
+
+// MarkRead отмечает уведомление прочитанным; время первого прочтения сохраняется.
+func (r *NotificationRepository) MarkRead(ctx context.Context, userID, id uuid.UUID, at time.Time) error {
+	var model pgNotification
+	err := dbFromContext(ctx, r.db).
+		Where("id = ? AND user_id = ?", id.String(), userID.String()).
+		First(&model).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return repo.ErrNotFound
+		}
+		return err
+	}
+	if model.ReadAt != nil {
+		return nil
+	}
+	return dbFromContext(ctx, r.db).
+		Model(&pgNotification{}).
+		Where("id = ? AND read_at IS NULL", id.String()).
+		Update("read_at", at).Error
+}
+
+// MarkAllRead отмечает прочитанными все уведомления пользователя.
+func (r *NotificationRepository) MarkAllRead(ctx context.Context, userID uuid.UUID, at time.Time) error {
+	return dbFromContext(ctx, r.db).
+		Model(&pgNotification{}).
+		Where("user_id = ? AND read_at IS NULL", userID.String()).
+		Update("read_at", at).Error
+}
+
+// ListPushPending возвращает уведомления, копии которых ждут отправки на устройства, старые первыми.
+func (r *NotificationRepository) ListPushPending(ctx context.Context, limit int) ([]*domain.Notification, error) {
+	var models []pgNotification
+	err := dbFromContext(ctx, r.db).
+		Where("push_pending").
+		Order("created_at, id").
+		Limit(limit).
+		Find(&models).Error
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]*domain.Notification, 0, len(models))
+	for i := range models {
+		n, err := models[i].toDomain()
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, n)
+	}
+	return items, nil
+}
+
+// ClaimPush снимает отметку ожидания push; false — копию уже забрал другой инстанс.
+func (r *NotificationRepository) ClaimPush(ctx context.Context, id uuid.UUID) (bool, error) {
+	result := dbFromContext(ctx, r.db).
+		Model(&pgNotification{}).
+		Where("id = ? AND push_pending", id.String()).
+		Update("push_pending", false)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+// ExpirePush снимает отметку ожидания push с уведомлений, созданных раньше before.
+func (r *NotificationRepository) ExpirePush(ctx context.Context, before time.Time) (int64, error) {
+	result := dbFromContext(ctx, r.db).
+		Model(&pgNotification{}).
+		Where("push_pending AND created_at < ?", before).
+		Update("push_pending", false)
+	return result.RowsAffected, result.Error
+}
+
+// GetPreferences возвращает сохранённые настройки пользователя.
+func (r *NotificationRepository) GetPreferences(ctx context.Context, userID uuid.UUID) ([]domain.Preference, error) {
+	var models []pgNotificationPreference
+	err := dbFromContext(ctx, r.db).
+		Where("user_id = ?", userID.String()).
+		Order("type").
+		Find(&models).Error
+	if err != nil {
+		return nil, err
+	}
+
+	prefs := make([]domain.Preference, 0, len(models))
+	for _, m := range models {
+		prefs = append(prefs, domain.Preference{Type: m.Type, Email: m.EmailEnabled, Push: m.PushEnabled})
+	}
+	return prefs, nil
+}
+
+// SetPreferences сохраняет настройки пользователя (upsert по типу).
+func (r *NotificationRepository) SetPreferences(ctx context.Context, userID uuid.UUID, prefs []domain.Preference) error {
+	if len(prefs) == 0 {
+		return nil
+	}
+	now := time.Now().UTC()
+	models := make([]pgNotificationPreference, 0, len(prefs))
+	for _, p := range prefs {
+		models = append(models, pgNotificationPreference{
+			UserID:       userID.String(),
+			Type:         p.Type,
+			EmailEnabled: p.Email,
+			PushEnabled:  p.Push,
+			UpdatedAt:    now,
+		})
+	}
+	return dbFromContext(ctx, r.db).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "user_id"}, {Name: "type"}},
+			DoUpdates: clause.AssignmentColumns([]string{"email_enabled", "push_enabled", "updated_at"}),
+		}).
+		Create(&models).Error
+}
+
+// DeleteByUserID удаляет уведомления и настройки пользователя.
+func (r *NotificationRepository) DeleteByUserID(ctx context.Context, userID uuid.UUID) error {
+	db := dbFromContext(ctx, r.db)
+	if err := db.Where("user_id = ?", userID.String()).Delete(&pgNotification{}).Error; err != nil {
+		return err
+	}
+	return db.Where("user_id = ?", userID.String()).Delete(&pgNotificationPreference{}).Error
+}
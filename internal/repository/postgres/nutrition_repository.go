@@ -0,0 +1,210 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	domain "workout-app/internal/domain/nutrition"
+	repo "workout-app/internal/repository/interfaces"
+)
+
+// pgFoodEntry представляет ORM-модель для таблицы food_entries.
+type pgFoodEntry struct {
+	ID        string    `gorm:"column:id;type:uuid;primaryKey"`
+	UserID    string    `gorm:"column:user_id;type:uuid;not null"`
+	Date      time.Time `gorm:"column:date;type:date;not null"`
+	Meal      string    `gorm:"column:meal;type:varchar(16);not null"`
+	Name      string    `gorm:"column:name;type:varchar(100);not null"`
+	Calories  int       `gorm:"column:calories;not null"`
+	ProteinG  float64   `gorm:"column:protein_g;type:numeric(5,1);not null"`
+	CarbsG    float64   `gorm:"column:carbs_g;type:numeric(5,1);not null"`
+	FatG      float64   `gorm:"column:fat_g;type:numeric(5,1);not null"`
+	CreatedAt time.Time `gorm:"column:created_at;type:timestamptz;not null"`
+	UpdatedAt time.Time `gorm:"column:updated_at;type:timestamptz;not null"`
+}
+
+func (pgFoodEntry) TableName() string {
+	return "food_entries"
+}
+
+func newPgFoodEntry(e *domain.Entry) *pgFoodEntry {
+	return &pgFoodEntry{
+		ID:        e.ID.String(),
+		UserID:    e.UserID.String(),
+		Date:      e.Date,
+		Meal:      string(e.Meal),
+		Name:      e.Name,
+		Calories:  e.Calories,
+		ProteinG:  e.ProteinG,
+		CarbsG:    e.CarbsG,
+		FatG:      e.FatG,
+		CreatedAt: e.CreatedAt,
+		UpdatedAt: e.UpdatedAt,
+	}
+}
+
+func (m *pgFoodEntry) toDomain() (*domain.Entry, error) {
+	id, err := uuid.Parse(m.ID)
+	if err != nil {
+		return nil, err
+	}
+	userID, err := uuid.Parse(m.UserID)
+	if err != nil {
+		return nil, err
+	}
+	y, mo, d := m.Date.Date()
+	return &domain.Entry{
+		ID:        id,
+		UserID:    userID,
+		Date:      time.Date(y, mo, d, 0, 0, 0, 0, time.UTC),
+		Meal:      domain.Meal(m.Meal),
+		Name:      m.Name,
+		Calories:  m.Calories,
+		ProteinG:  m.ProteinG,
+		CarbsG:    m.CarbsG,
+		FatG:      m.FatG,
+		CreatedAt: m.CreatedAt,
+		UpdatedAt: m.UpdatedAt,
+	}, nil
+}
+
+// NutritionRepository реализует repo.NutritionRepository на GORM/Postgres.
+type NutritionRepository struct {
+	db *gorm.DB
+}
+
+// Убедимся на этапе компиляции, что структура реализует интерфейс.
+var _ repo.NutritionRepository = (*NutritionRepository)(nil)
+
+// NewNutritionRepository создает новый репозиторий дневника питания.
+func NewNutritionRepository(db *gorm.DB) *NutritionRepository {
+	return &NutritionRepository{db: db}
+}
+
+// Create сохраняет запись дневника.
+func (r *NutritionRepository) Create(ctx context.Context, e *domain.Entry) error {
+	return dbFromContext(ctx, r.db).Create(newPgFoodEntry(e)).Error
+}
+
+// Update сохраняет изменения записи.
+func (r *NutritionRepository) Update(ctx context.Context, e *domain.Entry) error {
+	result := dbFromContext(ctx, r.db).
+		Model(&pgFoodEntry{}).
+		Where("id = ? AND user_id = ?", e.ID.String(), e.UserID.String()).
+		Updates(map[string]interface{}{
+			"date":       e.Date,
+			"meal":       string(e.Meal),
+			"name":       e.Name,
+			"calories":   e.Calories,
+			"protein_g":  e.ProteinG,
+			"carbs_g":    e.CarbsG,
+			"fat_g":      e.FatG,
+			"updated_at": e.UpdatedAt,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return repo.ErrNotFound
+	}
+	return nil
+}
+
+// GetByID возвращает запись пользователя.
+func (r *NutritionRepository) GetByID(ctx context.Context, userID, id uuid.UUID) (*domain.Entry, error) {
+	var model pgFoodEntry
+	err := dbFromContext(ctx, r.db).
+		Where("id = ? AND user_id = ?", id.String(), userID.String()).
+		First(&model).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, repo.ErrNotFound
+		}
+		return nil, err
+	}
+	return model.toDomain()
+}
+
+// ListByDate возвращает записи пользователя за день: завтрак, обед, ужин, перекусы.
+func (r *NutritionRepository) ListByDate(ctx context.Context, userID uuid.UUID, date time.Time) ([]*domain.Entry, error) {
+	var models []pgFoodEntry
+	err := dbFromContext(ctx, r.db).
+		Where("user_id = ? AND date = ?", userID.String(), date).
+		Order("CASE meal WHEN 'breakfast' THEN 1 WHEN 'lunch' THEN 2 WHEN 'dinner' THEN 3 ELSE 4 END, created_at, id").
+		Find(&models).Error
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]*domain.Entry, 0, len(models))
+	for i := range models {
+		e, err := models[i].toDomain()
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, e)
+	}
+	return entries, nil
+}
+
+// DailyTotals суммирует записи пользователя по дням [from, to].
+func (r *NutritionRepository) DailyTotals(ctx context.Context, userID uuid.UUID, from, to time.Time) ([]*domain.DayTotals, error) {
+	var rows []struct {
+		Date     time.Time
+		Entries  int
+		Calories int
+		ProteinG float64
+		CarbsG   float64
+		FatG     float64
+	}
+	err := dbFromContext(ctx, r.db).
+		Model(&pgFoodEntry{}).
+		Select(`date, COUNT(*) AS entries, SUM(calories) AS calories,
+			SUM(protein_g) AS protein_g, SUM(carbs_g) AS carbs_g, SUM(fat_g) AS fat_g`).
+		Where("user_id = ? AND date >= ? AND date <= ?", userID.String(), from, to).
+		Group("date").
+		Order("date").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	totals := make([]*domain.DayTotals, 0, len(rows))
+	for _, row := range rows {
+		y, m, d := row.Date.Date()
+		totals = append(totals, &domain.DayTotals{
+			Date:     time.Date(y, m, d, 0, 0, 0, 0, time.UTC),
+			Entries:  row.Entries,
+			Calories: row.Calories,
+			ProteinG: row.ProteinG,
+			CarbsG:   row.CarbsG,
+			FatG:     row.FatG,
+		})
+	}
+	return totals, nil
+}
+
+// Delete удаляет запись пользователя.
+func (r *NutritionRepository) Delete(ctx context.Context, userID, id uuid.UUID) error {
+	result := dbFromContext(ctx, r.db).
+		Where("id = ? AND user_id = ?", id.String(), userID.String()).
+		Delete(&pgFoodEntry{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return repo.ErrNotFound
+	}
+	return nil
+}
+
+// DeleteByUserID удаляет дневник пользователя.
+func (r *NutritionRepository) DeleteByUserID(ctx context.Context, userID uuid.UUID) error {
+	return dbFromContext(ctx, r.db).
+		Where("user_id = ?", userID.String()).
+		Delete(&pgFoodEntry{}).Error
+}
@@ -0,0 +1,109 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	domain "workout-app/internal/domain/user"
+	repo "workout-app/internal/repository/interfaces"
+)
+
+// pgOAuthAccount представляет ORM-модель для таблицы oauth_accounts.
+type pgOAuthAccount struct {
+	ID          string    `gorm:"column:id;type:uuid;primaryKey"`
+	UserID      string    `gorm:"column:user_id;type:uuid;not null"`
+	Provider    string    `gorm:"column:provider;type:varchar(16);not null"`
+	Subject     string    `gorm:"column:subject;type:varchar(255);not null"`
+	Email       string    `gorm:"column:email;type:varchar(255);not null"`
+	CreatedAt   time.Time `gorm:"column:created_at;type:timestamptz;not null"`
+	LastLoginAt time.Time `gorm:"column:last_login_at;type:timestamptz;not null"`
+}
+
+func (pgOAuthAccount) TableName() string {
+	return "oauth_accounts"
+}
+
+func (m *pgOAuthAccount) toDomain() (*domain.OAuthAccount, error) {
+	id, err := uuid.Parse(m.ID)
+	if err != nil {
+		return nil, err
+	}
+	userID, err := uuid.Parse(m.UserID)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.OAuthAccount{
+		ID:          id,
+		UserID:      userID,
+		Provider:    domain.OAuthProvider(m.Provider),
+		Subject:     m.Subject,
+		Email:       m.Email,
+		CreatedAt:   m.CreatedAt,
+		LastLoginAt: m.LastLoginAt,
+	}, nil
+}
+
+// OAuthAccountRepository реализует repo.OAuthAccountRepository на GORM/Postgres.
+type OAuthAccountRepository struct {
+	db *gorm.DB
+}
+
+// Убедимся на этапе компиляции, что структура реализует интерфейс.
+var _ repo.OAuthAccountRepository = (*OAuthAccountRepository)(nil)
+
+// NewOAuthAccountRepository создает новый репозиторий привязок внешних аккаунтов.
+func NewOAuthAccountRepository(db *gorm.DB) *OAuthAccountRepository {
+	return &OAuthAccountRepository{db: db}
+}
+
+// Create сохраняет привязку аккаунта провайдера.
+func (r *OAuthAccountRepository) Create(ctx context.Context, a *domain.OAuthAccount) error {
+	model := &pgOAuthAccount{
+		ID:          a.ID.String(),
+		UserID:      a.UserID.String(),
+		Provider:    string(a.Provider),
+		Subject:     a.Subject,
+		Email:       a.Email,
+		CreatedAt:   a.CreatedAt,
+		LastLoginAt: a.LastLoginAt,
+	}
+	if err := dbFromContext(ctx, r.db).Create(model).Error; err != nil {
+		if isUniqueViolation(err, "uq_oauth_accounts_provider_subject", "uq_oauth_accounts_user_provider") {
+			return repo.ErrOAuthAccountLinked
+		}
+		return err
+	}
+	return nil
+}
+
+// GetByProviderSubject возвращает привязку по провайдеру и ID пользователя у провайдера.
+func (r *OAuthAccountRepository) GetByProviderSubject(ctx context.Context, provider domain.OAuthProvider, subject string) (*domain.OAuthAccount, error) {
+	var model pgOAuthAccount
+	err := dbFromContext(ctx, r.db).
+		Where("provider = ? AND subject = ?", string(provider), subject).
+		First(&model).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, repo.ErrNotFound
+		}
+		return nil, err
+	}
+	return model.toDomain()
+}
+
+// TouchLogin обновляет время последнего входа через привязку.
+func (r *OAuthAccountRepository) TouchLogin(ctx context.Context, id uuid.UUID, at time.Time) error {
+	return dbFromContext(ctx, r.db).
+		Model(&pgOAuthAccount{}).
+		Where("id = ?", id.String()).
+		Update("last_login_at", at).Error
+}
+
+// DeleteByUserID удаляет все привязки пользователя.
+func (r *OAuthAccountRepository) DeleteByUserID(ctx context.Context, userID uuid.UUID) error {
+	return dbFromContext(ctx, r.db).Where("user_id = ?", userID.String()).Delete(&pgOAuthAccount{}).Error
+}
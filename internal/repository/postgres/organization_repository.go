@@ -0,0 +1,518 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	domain "workout-app/internal/domain/organization"
+	repo "workout-app/internal/repository/interfaces"
+	"workout-app/pkg/secretbox"
+)
+
+// pgOrganization представляет ORM-модель для таблицы organizations.
+type pgOrganization struct {
+	ID        string    `gorm:"column:id;type:uuid;primaryKey"`
+	Name      string    `gorm:"column:name;type:varchar(200);not null"`
+	CreatedAt time.Time `gorm:"column:created_at;type:timestamptz;not null"`
+}
+
+func (pgOrganization) TableName() string {
+	return "organizations"
+}
+
+func (m *pgOrganization) toDomain() (*domain.Organization, error) {
+	id, err := uuid.Parse(m.ID)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.Organization{ID: id, Name: m.Name, CreatedAt: m.CreatedAt}, nil
+}
+
+// pgOrganizationMember представляет ORM-модель для таблицы organization_members.
+type pgOrganizationMember struct {
+	OrganizationID string    `gorm:"column:organization_id;type:uuid;primaryKey"`
+	UserID         string    `gorm:"column:user_id;type:uuid;primaryKey"`
+	Role           string    `gorm:"column:role;type:varchar(16);not null"`
+	CreatedAt      time.Time `gorm:"column:created_at;type:timestamptz;not null"`
+}
+
+func (pgOrganizationMember) TableName() string {
+	return "organization_members"
+}
+
+func (m *pgOrganizationMember) toDomain() (*domain.Member, error) {
+	orgID, err := uuid.Parse(m.OrganizationID)
+	if err != nil {
+		return nil, err
+	}
+	userID, err := uuid.Parse(m.UserID)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.Member{
+		OrganizationID: orgID,
+		UserID:         userID,
+		Role:           domain.Role(m.Role),
+		CreatedAt:      m.CreatedAt,
+	}, nil
+}
+
+// OrganizationRepository реализует repo.OrganizationRepository на GORM/Postgres.
+type OrganizationRepository struct {
+	db *gorm.DB
+}
+
+// Убедимся на этапе компиляции, что структура реализует интерфейс.
+var _ repo.OrganizationRepository = (*OrganizationRepository)(nil)
+
+// NewOrganizationRepository создает новый репозиторий организаций.
+func NewOrganizationRepository(db *gorm.DB) *OrganizationRepository {
+	return &OrganizationRepository{db: db}
+}
+
+// Create создаёт организацию.
+func (r *OrganizationRepository) Create(ctx context.Context, org *domain.Organization) error {
+	return dbFromContext(ctx, r.db).Create(&pgOrganization{
+		ID:        org.ID.String(),
+		Name:      org.Name,
+		CreatedAt: org.CreatedAt,
+	}).Error
+}
+
+// GetByID возвращает организацию.
+func (r *OrganizationRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Organization, error) {
+	var model pgOrganization
+	if err := dbFromContext(ctx, r.db).Where("id = ?", id.String()).First(&model).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, repo.ErrNotFound
+		}
+		return nil, err
+	}
+	return model.toDomain()
+}
+
+// AddMember добавляет пользователя в организацию.
+func (r *OrganizationRepository) AddMember(ctx context.Context, m *domain.Member) error {
+	err := dbFromContext(ctx, r.db).Create(&pgOrganizationMember{
+		OrganizationID: m.OrganizationID.String(),
+		UserID:         m.UserID.String(),
+		Role:           string(m.Role),
+		CreatedAt:      m.CreatedAt,
+	}).Error
+	if isUniqueViolation(err, "organization_members_pkey") {
+		return repo.ErrMemberExists
+	}
+	return err
+}
+
+// GetMember возвращает членство пользователя в организации.
+func (r *OrganizationRepository) GetMember(ctx context.Context, orgID, userID uuid.UUID) (*domain.Member, error) {
+	var model pgOrganizationMember
+	err := dbFromContext(ctx, r.db).
+		Where("organization_id = ? AND user_id = ?", orgID.String(), userID.String()).
+		First(&model).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, repo.ErrNotFound
+		}
+		return nil, err
+	}
+	return model.toDomain()
+}
+
+// RemoveMember исключает пользователя из организации.
+func (r *OrganizationRepository) RemoveMember(ctx context.Context, orgID, userID uuid.UUID) error {
+	result := dbFromContext(ctx, r.db).
+		Where("organization_id = ? AND user_id = ?", orgID.String(), userID.String()).
+		Delete(&pgOrganizationMember{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return repo.ErrNotFound
+	}
+	return nil
+}
+
+// ListByUser возвращает организации пользователя с его ролью в каждой.
+func (r *OrganizationRepository) ListByUser(ctx context.Context, userID uuid.UUID) ([]*domain.Organization, []*domain.Member, error) {
+	var members []pgOrganizationMember
+	err := dbFromContext(ctx, r.db).
+		Where("user_id = ?", userID.String()).
+		Order("created_at, organization_id").
+		Find(&members).Error
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(members) == 0 {
+		return []*domain.Organization{}, []*domain.Member{}, nil
+	}
+
+	ids := make([]string, 0, len(members))
+	for _, m := range members {
+		ids = append(ids, m.OrganizationID)
+	}
+	var orgModels []pgOrganization
+	if err := dbFromContext(ctx, r.db).Where("id IN ?", ids).Find(&orgModels).Error; err != nil {
+		return nil, nil, err
+	}
+	byID := make(map[string]*pgOrganization, len(orgModels))
+	for i := range orgModels {
+		byID[orgModels[i].ID] = &orgModels[i]
+	}
+
+	orgs := make([]*domain.Organization, 0, len(members))
+	result := make([]*domain.Member, 0, len(members))
+	for i := range members {
+		orgModel, ok := byID[members[i].OrganizationID]
+		if !ok {
+			continue
+		}
+		org, err := orgModel.toDomain()
+		if err != nil {
+			return nil, nil, err
+		}
+		m, err := members[i].toDomain()
+		if err != nil {
+			return nil, nil, err
+		}
+		orgs = append(orgs, org)
+		result = append(result, m)
+	}
+	return orgs, result, nil
+}
+
+// UpdateMemberRole меняет роль участника организации.
+func (r *OrganizationRepository) UpdateMemberRole(ctx context.Context, orgID, userID uuid.UUID, role domain.Role) error {
+	result := dbFromContext(ctx, r.db).
+		Model(&pgOrganizationMember{}).
+		Where("organization_id = ? AND user_id = ?", orgID.String(), userID.String()).
+		Update("role", string(role))
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return repo.ErrNotFound
+	}
+	return nil
+}
+
+// ListMembers возвращает страницу участников организации, команду первой, и их общее количество.
+func (r *OrganizationRepository) ListMembers(ctx context.Context, orgID uuid.UUID, limit, offset int) ([]*domain.Member, int64, error) {
+	filtered := func() *gorm.DB {
+		return dbFromContext(ctx, r.db).Model(&pgOrganizationMember{}).Where("organization_id = ?", orgID.String())
+	}
+
+	var total int64
+	if err := filtered().Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var models []pgOrganizationMember
+	err := filtered().
+		Order("CASE role WHEN 'owner' THEN 0 WHEN 'manager' THEN 1 WHEN 'staff' THEN 2 ELSE 3 END, created_at, user_id").
+		Limit(limit).
+		Offset(offset).
+		Find(&models).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	members := make([]*domain.Member, 0, len(models))
+	for i := range models {
+		m, err := models[i].toDomain()
+		if err != nil {
+			return nil, 0, err
+		}
+		members = append(members, m)
+	}
+	return members, total, nil
+}
+
+// pgOrganizationInvitation представляет ORM-модель для таблицы organization_invitations.
+type pgOrganizationInvitation struct {
+	ID             string    `gorm:"column:id;type:uuid;primaryKey"`
+	OrganizationID string    `gorm:"column:organization_id;type:uuid;not null"`
+	UserID         string    `gorm:"column:user_id;type:uuid;not null"`
+	Role           string    `gorm:"column:role;type:varchar(16);not null"`
+	InvitedBy      string    `gorm:"column:invited_by;type:uuid;not null"`
+	CreatedAt      time.Time `gorm:"column:created_at;type:timestamptz;not null"`
+	ExpiresAt      time.Time `gorm:"column:expires_at;type:timestamptz;not null"`
+}
+
+func (pgOrganizationInvitation) TableName() string {
+	return "organization_invitations"
+}
+
+func (m *pgOrganizationInvitation) toDomain() (*domain.Invitation, error) {
+	id, err := uuid.Parse(m.ID)
+	if err != nil {
+		return nil, err
+	}
+	orgID, err := uuid.Parse(m.OrganizationID)
+	if err != nil {
+		return nil, err
+	}
+	userID, err := uuid.Parse(m.UserID)
+	if err != nil {
+		return nil, err
+	}
+	invitedBy, err := uuid.Parse(m.InvitedBy)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.Invitation{
+		ID:             id,
+		OrganizationID: orgID,
+		UserID:         userID,
+		Role:           domain.Role(m.Role),
+		InvitedBy:      invitedBy,
+		CreatedAt:      m.CreatedAt,
+		ExpiresAt:      m.ExpiresAt,
+	}, nil
+}
+
+// CreateInvitation сохраняет приглашение. Истёкшее приглашение того же пользователя заменяется
+// одной командой: конфликт по (organization_id, user_id) обновляет строку, только если её срок истёк.
+func (r *OrganizationRepository) CreateInvitation(ctx context.Context, inv *domain.Invitation) error {
+	result := dbFromContext(ctx, r.db).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "organization_id"}, {Name: "user_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"id", "role", "invited_by", "created_at", "expires_at"}),
+			Where: clause.Where{Exprs: []clause.Expression{
+				clause.Expr{SQL: "organization_invitations.expires_at <= EXCLUDED.created_at"},
+			}},
+		}).
+		Create(&pgOrganizationInvitation{
+			ID:             inv.ID.String(),
+			OrganizationID: inv.OrganizationID.String(),
+			UserID:         inv.UserID.String(),
+			Role:           string(inv.Role),
+			InvitedBy:      inv.InvitedBy.String(),
+			CreatedAt:      inv.CreatedAt,
+			ExpiresAt:      inv.ExpiresAt,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return repo.ErrInvitationExists
+	}
+	return nil
+}
+
+// GetInvitation возвращает приглашение по ID.
+func (r *OrganizationRepository) GetInvitation(ctx context.Context, id uuid.UUID) (*domain.Invitation, error) {
+	var model pgOrganizationInvitation
+	if err := dbFromContext(ctx, r.db).Where("id = ?", id.String()).First(&model).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, repo.ErrNotFound
+		}
+		return nil, err
+	}
+	return model.toDomain()
+}
+
+// ListInvitationsByUser возвращает действующие приглашения пользователя с организациями, новые первыми.
+func (r *OrganizationRepository) ListInvitationsByUser(ctx context.Context, userID uuid.UUID, now time.Time) ([]*domain.Invitation, []*domain.Organization, error) {
+	var models []pgOrganizationInvitation
+	err := dbFromContext(ctx, r.db).
+		Where("user_id = ? AND expires_at > ?", userID.String(), now).
+		Order("created_at DESC, id").
+		Find(&models).Error
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(models) == 0 {
+		return []*domain.Invitation{}, []*domain.Organization{}, nil
+	}
+
+	ids := make([]string, 0, len(models))
+	for _, m := range models {
+		ids = append(ids, m.OrganizationID)
+	}
+	var orgModels []pgOrganization
+	if err := dbFromContext(ctx, r.db).Where("id IN ?", ids).Find(&orgModels).Error; err != nil {
+		return nil, nil, err
+	}
+	byID := make(map[string]*pgOrganization, len(orgModels))
+	for i := range orgModels {
+		byID[orgModels[i].ID] = &orgModels[i]
+	}
+
+	invitations := make([]*domain.Invitation, 0, len(models))
+	orgs := make([]*domain.Organization, 0, len(models))
+	for i := range models {
+		orgModel, ok := byID[models[i].OrganizationID]
+		if !ok {
+			continue
+		}
+		org, err := orgModel.toDomain()
+		if err != nil {
+			return nil, nil, err
+		}
+		inv, err := models[i].toDomain()
+		if err != nil {
+			return nil, nil, err
+		}
+		invitations = append(invitations, inv)
+		orgs = append(orgs, org)
+	}
+	return invitations, orgs, nil
+}
+
+// DeleteInvitation удаляет приглашение.
+func (r *OrganizationRepository) DeleteInvitation(ctx context.Context, id uuid.UUID) error {
+	result := dbFromContext(ctx, r.db).Where("id = ?", id.String()).Delete(&pgOrganizationInvitation{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return repo.ErrNotFound
+	}
+	return nil
+}
+
+// pgOrganizationEmailSettings представляет ORM-модель для таблицы organization_email_settings.
+type pgOrganizationEmailSettings struct {
+	OrganizationID        string    `gorm:"column:organization_id;type:uuid;primaryKey"`
+	FromEmail             string    `gorm:"column:from_email;type:varchar(255);not null"`
+	FromName              string    `gorm:"column:from_name;type:varchar(100);not null"`
+	Provider              string    `gorm:"column:provider;type:varchar(32);not null"`
+	SMTPHost              string    `gorm:"column:smtp_host;type:varchar(255);not null"`
+	SMTPPort              int       `gorm:"column:smtp_port;type:integer;not null"`
+	SMTPUsername          string    `gorm:"column:smtp_username;type:varchar(255);not null"`
+	SMTPPasswordEncrypted []byte    `gorm:"column:smtp_password_encrypted;type:bytea;not null"`
+	RateLimitPerHour      int       `gorm:"column:rate_limit_per_hour;type:integer;not null"`
+	UpdatedBy             string    `gorm:"column:updated_by;type:uuid;not null"`
+	UpdatedAt             time.Time `gorm:"column:updated_at;type:timestamptz;not null"`
+}
+
+func (pgOrganizationEmailSettings) TableName() string {
+	return "organization_email_settings"
+}
+
+// OrganizationEmailSettingsRepository реализует repo.OrganizationEmailSettingsRepository на GORM/Postgres.
+// Пароль SMTP шифруется при записи и расшифровывается при чтении.
+type OrganizationEmailSettingsRepository struct {
+	db  *gorm.DB
+	box *secretbox.Box
+}
+
+// Убедимся на этапе компиляции, что структура реализует интерфейс.
+var _ repo.OrganizationEmailSettingsRepository = (*OrganizationEmailSettingsRepository)(nil)
+
+// NewOrganizationEmailSettingsRepository создает новый репозиторий настроек отправки писем организаций.
+func NewOrganizationEmailSettingsRepository(db *gorm.DB, box *secretbox.Box) *OrganizationEmailSettingsRepository {
+	return &OrganizationEmailSettingsRepository{db: db, box: box}
+}
+
+func (r *OrganizationEmailSettingsRepository) toDomain(m *pgOrganizationEmailSettings) (*domain.EmailSettings, error) {
+	orgID, err := uuid.Parse(m.OrganizationID)
+	if err != nil {
+		return nil, err
+	}
+	updatedBy, err := uuid.Parse(m.UpdatedBy)
+	if err != nil {
+		return nil, err
+	}
+	password, err := r.box.Open(m.SMTPPasswordEncrypted)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt smtp password of organization %s: %w", m.OrganizationID, err)
+	}
+	return &domain.EmailSettings{
+		OrganizationID:   orgID,
+		FromEmail:        m.FromEmail,
+		FromName:         m.FromName,
+		Provider:         domain.EmailProvider(m.Provider),
+		SMTPHost:         m.SMTPHost,
+		SMTPPort:         m.SMTPPort,
+		SMTPUsername:     m.SMTPUsername,
+		SMTPPassword:     string(password),
+		RateLimitPerHour: m.RateLimitPerHour,
+		UpdatedBy:        updatedBy,
+		UpdatedAt:        m.UpdatedAt,
+	}, nil
+}
+
+// Get возвращает настройки организации.
+func (r *OrganizationEmailSettingsRepository) Get(ctx context.Context, orgID uuid.UUID) (*domain.EmailSettings, error) {
+	var model pgOrganizationEmailSettings
+	err := dbFromContext(ctx, r.db).Where("organization_id = ?", orgID.String()).First(&model).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, repo.ErrNotFound
+		}
+		return nil, err
+	}
+	return r.toDomain(&model)
+}
+
+// GetForRecipient возвращает настройки организации, в которой состоит пользователь с этим email.
+func (r *OrganizationEmailSettingsRepository) GetForRecipient(ctx context.Context, email string) (*domain.EmailSettings, error) {
+	var model pgOrganizationEmailSettings
+	err := dbFromContext(ctx, r.db).
+		Joins("JOIN organization_members m ON m.organization_id = organization_email_settings.organization_id").
+		Joins("JOIN users u ON u.id = m.user_id").
+		Where("u.email = ?", email).
+		Order("m.created_at, m.organization_id").
+		First(&model).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, repo.ErrNotFound
+		}
+		return nil, err
+	}
+	return r.toDomain(&model)
+}
+
+// Upsert создаёт или заменяет настройки организации.
+func (r *OrganizationEmailSettingsRepository) Upsert(ctx context.Context, s *domain.EmailSettings) error {
+	sealed, err := r.box.Seal([]byte(s.SMTPPassword))
+	if err != nil {
+		return fmt.Errorf("failed to encrypt smtp password: %w", err)
+	}
+
+	model := &pgOrganizationEmailSettings{
+		OrganizationID:        s.OrganizationID.String(),
+		FromEmail:             s.FromEmail,
+		FromName:              s.FromName,
+		Provider:              string(s.Provider),
+		SMTPHost:              s.SMTPHost,
+		SMTPPort:              s.SMTPPort,
+		SMTPUsername:          s.SMTPUsername,
+		SMTPPasswordEncrypted: sealed,
+		RateLimitPerHour:      s.RateLimitPerHour,
+		UpdatedBy:             s.UpdatedBy.String(),
+		UpdatedAt:             s.UpdatedAt,
+	}
+	return dbFromContext(ctx, r.db).
+		Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "organization_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{
+				"from_email", "from_name", "provider", "smtp_host", "smtp_port", "smtp_username",
+				"smtp_password_encrypted", "rate_limit_per_hour", "updated_by", "updated_at",
+			}),
+		}).
+		Create(model).Error
+}
+
+// Delete удаляет настройки организации.
+func (r *OrganizationEmailSettingsRepository) Delete(ctx context.Context, orgID uuid.UUID) error {
+	result := dbFromContext(ctx, r.db).
+		Where("organization_id = ?", orgID.String()).
+		Delete(&pgOrganizationEmailSettings{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return repo.ErrNotFound
+	}
+	return nil
+}
@@ -0,0 +1,127 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	domain "workout-app/internal/domain/user"
+	repo "workout-app/internal/repository/interfaces"
+)
+
+// pgProfileChange представляет ORM-модель для таблицы profile_changes.
+type pgProfileChange struct {
+	ID        string    `gorm:"column:id;type:uuid;primaryKey"`
+	UserID    string    `gorm:"column:user_id;type:uuid;not null"`
+	ActorID   string    `gorm:"column:actor_id;type:uuid;not null"`
+	Changes   string    `gorm:"column:changes;type:jsonb;not null"`
+	ChangedAt time.Time `gorm:"column:changed_at;type:timestamptz;not null"`
+}
+
+func (pgProfileChange) TableName() string {
+	return "profile_changes"
+}
+
+// pgFieldChange — JSON-представление изменения поля в колонке changes.
+type pgFieldChange struct {
+	Field string `json:"field"`
+	Old   string `json:"old"`
+	New   string `json:"new"`
+}
+
+func (m *pgProfileChange) toDomain() (*domain.ProfileChange, error) {
+	id, err := uuid.Parse(m.ID)
+	if err != nil {
+		return nil, err
+	}
+	userID, err := uuid.Parse(m.UserID)
+	if err != nil {
+		return nil, err
+	}
+	actorID, err := uuid.Parse(m.ActorID)
+	if err != nil {
+		return nil, err
+	}
+	var fields []pgFieldChange
+	if err := json.Unmarshal([]byte(m.Changes), &fields); err != nil {
+		return nil, err
+	}
+	changes := make([]domain.FieldChange, 0, len(fields))
+	for _, f := range fields {
+		changes = append(changes, domain.FieldChange{Field: f.Field, Old: f.Old, New: f.New})
+	}
+	return &domain.ProfileChange{
+		ID:        id,
+		UserID:    userID,
+		ActorID:   actorID,
+		Changes:   changes,
+		ChangedAt: m.ChangedAt,
+	}, nil
+}
+
+// ProfileHistoryRepository реализует repo.ProfileHistoryRepository на GORM/Postgres.
+type ProfileHistoryRepository struct {
+	db *gorm.DB
+}
+
+// Убедимся на этапе компиляции, что структура реализует интерфейс.
+var _ repo.ProfileHistoryRepository = (*ProfileHistoryRepository)(nil)
+
+// NewProfileHistoryRepository создает новый репозиторий истории изменений профиля.
+func NewProfileHistoryRepository(db *gorm.DB) *ProfileHistoryRepository {
+	return &ProfileHistoryRepository{db: db}
+}
+
+// Create сохраняет запись об изменении профиля.
+func (r *ProfileHistoryRepository) Create(ctx context.Context, c *domain.ProfileChange) error {
+	fields := make([]pgFieldChange, 0, len(c.Changes))
+	for _, f := range c.Changes {
+		fields = append(fields, pgFieldChange{Field: f.Field, Old: f.Old, New: f.New})
+	}
+	raw, err := json.Marshal(fields)
+	if err != nil {
+		return err
+	}
+	return dbFromContext(ctx, r.db).Create(&pgProfileChange{
+		ID:        c.ID.String(),
+		UserID:    c.UserID.String(),
+		ActorID:   c.ActorID.String(),
+		Changes:   string(raw),
+		ChangedAt: c.ChangedAt,
+	}).Error
+}
+
+// ListByUser возвращает изменения профиля пользователя, новые первыми, и их общее количество.
+func (r *ProfileHistoryRepository) ListByUser(ctx context.Context, userID uuid.UUID, limit, offset int) ([]*domain.ProfileChange, int64, error) {
+	filtered := func() *gorm.DB {
+		return dbFromContext(ctx, r.db).Model(&pgProfileChange{}).Where("user_id = ?", userID.String())
+	}
+
+	var total int64
+	if err := filtered().Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var models []pgProfileChange
+	if err := filtered().Order("changed_at DESC, id").Limit(limit).Offset(offset).Find(&models).Error; err != nil {
+		return nil, 0, err
+	}
+
+	items := make([]*domain.ProfileChange, 0, len(models))
+	for i := range models {
+		c, err := models[i].toDomain()
+		if err != nil {
+			return nil, 0, err
+		}
+		items = append(items, c)
+	}
+	return items, total, nil
+}
+
+// DeleteByUserID удаляет историю пользователя.
+func (r *ProfileHistoryRepository) DeleteByUserID(ctx context.Context, userID uuid.UUID) error {
+	return dbFromContext(ctx, r.db).Where("user_id = ?", userID.String()).Delete(&pgProfileChange{}).Error
+}
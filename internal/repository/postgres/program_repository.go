@@ -0,0 +1,505 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	domain "workout-app/internal/domain/program"
+	repo "workout-app/internal/repository/interfaces"
+)
+
+// pgProgram представляет ORM-модель для таблицы programs.
+type pgProgram struct {
+	ID              string    `gorm:"column:id;type:uuid;primaryKey"`
+	OwnerID         string    `gorm:"column:owner_id;type:uuid;not null"`
+	Title           string    `gorm:"column:title;type:varchar(200);not null"`
+	Description     string    `gorm:"column:description;type:text;not null"`
+	WeeksCount      int       `gorm:"column:weeks_count;type:integer;not null"`
+	SourceProgramID *string   `gorm:"column:source_program_id;type:uuid"`
+	CreatedAt       time.Time `gorm:"column:created_at;type:timestamptz;not null"`
+	UpdatedAt       time.Time `gorm:"column:updated_at;type:timestamptz;not null"`
+}
+
+func (pgProgram) TableName() string {
+	return "programs"
+}
+
+// pgProgramWorkout представляет ORM-модель для таблицы program_workouts.
+type pgProgramWorkout struct {
+	ID         string `gorm:"column:id;type:uuid;primaryKey"`
+	ProgramID  string `gorm:"column:program_id;type:uuid;not null"`
+	WeekNumber int    `gorm:"column:week_number;type:integer;not null"`
+	DayNumber  int    `gorm:"column:day_number;type:integer;not null"`
+	Position   int    `gorm:"column:position;type:integer;not null"`
+	Title      string `gorm:"column:title;type:varchar(200);not null"`
+	Notes      string `gorm:"column:notes;type:text;not null"`
+	Exercises  string `gorm:"column:exercises;type:jsonb;not null"`
+}
+
+func (pgProgramWorkout) TableName() string {
+	return "program_workouts"
+}
+
+// pgProgramExercise — JSON-представление упражнения в колонке exercises.
+type pgProgramExercise struct {
+	Name          string     `json:"name"`
+	Sets          int        `json:"sets"`
+	Reps          int        `json:"reps"`
+	WeightKg      *float64   `json:"weight_kg,omitempty"`
+	LoadPercent   *float64   `json:"load_percent,omitempty"`
+	LoadReference string     `json:"load_reference,omitempty"`
+	Notes         string     `json:"notes,omitempty"`
+	VideoID       *uuid.UUID `json:"video_id,omitempty"`
+	Tempo         string     `json:"tempo,omitempty"`
+	RestSeconds   []int      `json:"rest_seconds,omitempty"`
+}
+
+// pgProgramAssignment представляет ORM-модель для таблицы program_assignments.
+type pgProgramAssignment struct {
+	ID         string    `gorm:"column:id;type:uuid;primaryKey"`
+	ProgramID  string    `gorm:"column:program_id;type:uuid;not null"`
+	UserID     string    `gorm:"column:user_id;type:uuid;not null"`
+	AssignedBy *string   `gorm:"column:assigned_by;type:uuid"`
+	StartDate  time.Time `gorm:"column:start_date;type:date;not null"`
+	CreatedAt  time.Time `gorm:"column:created_at;type:timestamptz;not null"`
+}
+
+func (pgProgramAssignment) TableName() string {
+	return "program_assignments"
+}
+
+func (m *pgProgramAssignment) toDomain() (*domain.Assignment, error) {
+	id, err := uuid.Parse(m.ID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid assignment id: %w", err)
+	}
+	programID, err := uuid.Parse(m.ProgramID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid program id: %w", err)
+	}
+	userID, err := uuid.Parse(m.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user id: %w", err)
+	}
+	// assigned_by обнуляется при удалении назначившего пользователя
+	var assignedBy uuid.UUID
+	if m.AssignedBy != nil {
+		if assignedBy, err = uuid.Parse(*m.AssignedBy); err != nil {
+			return nil, fmt.Errorf("invalid assigned_by: %w", err)
+		}
+	}
+
+	return &domain.Assignment{
+		ID:         id,
+		ProgramID:  programID,
+		UserID:     userID,
+		AssignedBy: assignedBy,
+		StartDate:  m.StartDate,
+		CreatedAt:  m.CreatedAt,
+	}, nil
+}
+
+// fromDomainProgram раскладывает программу на строку programs и строки program_workouts.
+func fromDomainProgram(p *domain.Program) (*pgProgram, []pgProgramWorkout, error) {
+	model := &pgProgram{
+		ID:          p.ID.String(),
+		OwnerID:     p.OwnerID.String(),
+		Title:       p.Title,
+		Description: p.Description,
+		WeeksCount:  len(p.Weeks),
+		CreatedAt:   p.CreatedAt,
+		UpdatedAt:   p.UpdatedAt,
+	}
+	if p.SourceProgramID != nil {
+		s := p.SourceProgramID.String()
+		model.SourceProgramID = &s
+	}
+
+	var workouts []pgProgramWorkout
+	for _, w := range p.Weeks {
+		for _, d := range w.Days {
+			for pos, wo := range d.Workouts {
+				raw := make([]pgProgramExercise, 0, len(wo.Exercises))
+				for _, e := range wo.Exercises {
+					raw = append(raw, pgProgramExercise{
+						Name:          e.Name,
+						Sets:          e.Sets,
+						Reps:          e.Reps,
+						WeightKg:      e.WeightKg,
+						LoadPercent:   e.LoadPercent,
+						LoadReference: e.LoadReference,
+						Notes:         e.Notes,
+						VideoID:       e.VideoID,
+						Tempo:         e.Tempo,
+						RestSeconds:   e.RestSeconds,
+					})
+				}
+				exercises, err := json.Marshal(raw)
+				if err != nil {
+					return nil, nil, fmt.Errorf("failed to encode exercises: %w", err)
+				}
+				workouts = append(workouts, pgProgramWorkout{
+					ID:         uuid.New().String(),
+					ProgramID:  model.ID,
+					WeekNumber: w.Number,
+					DayNumber:  d.Number,
+					Position:   pos,
+					Title:      wo.Title,
+					Notes:      wo.Notes,
+					Exercises:  string(exercises),
+				})
+			}
+		}
+	}
+	return model, workouts, nil
+}
+
+// toDomainProgram собирает программу из строки programs и её тренировок.
+// Тренировки должны быть отсортированы по (week_number, day_number, position).
+func toDomainProgram(m *pgProgram, workouts []pgProgramWorkout) (*domain.Program, error) {
+	id, err := uuid.Parse(m.ID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid program id: %w", err)
+	}
+	ownerID, err := uuid.Parse(m.OwnerID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid owner id: %w", err)
+	}
+
+	weeks := make([]domain.Week, m.WeeksCount)
+	for i := range weeks {
+		weeks[i].Number = i + 1
+	}
+	for _, wo := range workouts {
+		if wo.WeekNumber < 1 || wo.WeekNumber > len(weeks) {
+			continue
+		}
+		var raw []pgProgramExercise
+		if err := json.Unmarshal([]byte(wo.Exercises), &raw); err != nil {
+			return nil, fmt.Errorf("failed to decode exercises: %w", err)
+		}
+		exercises := make([]domain.Exercise, 0, len(raw))
+		for _, e := range raw {
+			exercises = append(exercises, domain.Exercise{
+				Name:          e.Name,
+				Sets:          e.Sets,
+				Reps:          e.Reps,
+				WeightKg:      e.WeightKg,
+				LoadPercent:   e.LoadPercent,
+				LoadReference: e.LoadReference,
+				Notes:         e.Notes,
+				VideoID:       e.VideoID,
+				Tempo:         e.Tempo,
+				RestSeconds:   e.RestSeconds,
+			})
+		}
+
+		week := &weeks[wo.WeekNumber-1]
+		if n := len(week.Days); n == 0 || week.Days[n-1].Number != wo.DayNumber {
+			week.Days = append(week.Days, domain.Day{Number: wo.DayNumber})
+		}
+		day := &week.Days[len(week.Days)-1]
+		day.Workouts = append(day.Workouts, domain.Workout{
+			Title:     wo.Title,
+			Notes:     wo.Notes,
+			Exercises: exercises,
+		})
+	}
+
+	p := &domain.Program{
+		ID:          id,
+		OwnerID:     ownerID,
+		Title:       m.Title,
+		Description: m.Description,
+		Weeks:       weeks,
+		CreatedAt:   m.CreatedAt,
+		UpdatedAt:   m.UpdatedAt,
+	}
+	if m.SourceProgramID != nil {
+		sourceID, err := uuid.Parse(*m.SourceProgramID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid source program id: %w", err)
+		}
+		p.SourceProgramID = &sourceID
+	}
+	return p, nil
+}
+
+// ProgramRepository реализует repo.ProgramRepository на GORM/Postgres.
+type ProgramRepository struct {
+	db *gorm.DB
+}
+
+// Убедимся на этапе компиляции, что структура реализует интерфейс.
+var _ repo.ProgramRepository = (*ProgramRepository)(nil)
+
+// NewProgramRepository создает новый репозиторий тренировочных программ.
+func NewProgramRepository(db *gorm.DB) *ProgramRepository {
+	return &ProgramRepository{db: db}
+}
+
+// Create сохраняет программу вместе с тренировками в одной транзакции.
+func (r *ProgramRepository) Create(ctx context.Context, p *domain.Program) error {
+	model, workouts, err := fromDomainProgram(p)
+	if err != nil {
+		return err
+	}
+
+	return dbFromContext(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(model).Error; err != nil {
+			return err
+		}
+		if len(workouts) == 0 {
+			return nil
+		}
+		return tx.CreateInBatches(workouts, 100).Error
+	})
+}
+
+// GetByID возвращает программу с полной структурой.
+func (r *ProgramRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Program, error) {
+	var model pgProgram
+	err := dbFromContext(ctx, r.db).Where("id = ?", id.String()).Take(&model).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, repo.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	programs, err := r.withWorkouts(ctx, []pgProgram{model})
+	if err != nil {
+		return nil, err
+	}
+	return programs[0], nil
+}
+
+// ListByOwner возвращает программы автора, новые первыми.
+func (r *ProgramRepository) ListByOwner(ctx context.Context, ownerID uuid.UUID) ([]*domain.Program, error) {
+	var models []pgProgram
+	err := dbFromContext(ctx, r.db).
+		Where("owner_id = ?", ownerID.String()).
+		Order("created_at DESC").
+		Find(&models).Error
+	if err != nil {
+		return nil, err
+	}
+	return r.withWorkouts(ctx, models)
+}
+
+// withWorkouts загружает тренировки для набора программ одним запросом и собирает доменные модели.
+func (r *ProgramRepository) withWorkouts(ctx context.Context, models []pgProgram) ([]*domain.Program, error) {
+	if len(models) == 0 {
+		return []*domain.Program{}, nil
+	}
+
+	ids := make([]string, 0, len(models))
+	for _, m := range models {
+		ids = append(ids, m.ID)
+	}
+
+	var workouts []pgProgramWorkout
+	err := dbFromContext(ctx, r.db).
+		Where("program_id IN ?", ids).
+		Order("program_id, week_number, day_number, position").
+		Find(&workouts).Error
+	if err != nil {
+		return nil, err
+	}
+
+	byProgram := make(map[string][]pgProgramWorkout, len(models))
+	for _, wo := range workouts {
+		byProgram[wo.ProgramID] = append(byProgram[wo.ProgramID], wo)
+	}
+
+	programs := make([]*domain.Program, 0, len(models))
+	for i := range models {
+		p, err := toDomainProgram(&models[i], byProgram[models[i].ID])
+		if err != nil {
+			return nil, err
+		}
+		programs = append(programs, p)
+	}
+	return programs, nil
+}
+
+// CreateAssignment назначает программу пользователю.
+func (r *ProgramRepository) CreateAssignment(ctx context.Context, a *domain.Assignment) error {
+	assignedBy := a.AssignedBy.String()
+	model := &pgProgramAssignment{
+		ID:         a.ID.String(),
+		ProgramID:  a.ProgramID.String(),
+		UserID:     a.UserID.String(),
+		AssignedBy: &assignedBy,
+		StartDate:  a.StartDate,
+		CreatedAt:  a.CreatedAt,
+	}
+	if err := dbFromContext(ctx, r.db).Create(model).Error; err != nil {
+		if isUniqueViolation(err, "program_assignments_program_user_key") {
+			return repo.ErrProgramAlreadyAssigned
+		}
+		return err
+	}
+	return nil
+}
+
+// IsAssigned сообщает, назначена ли программа пользователю.
+func (r *ProgramRepository) IsAssigned(ctx context.Context, programID, userID uuid.UUID) (bool, error) {
+	var count int64
+	err := dbFromContext(ctx, r.db).
+		Model(&pgProgramAssignment{}).
+		Where("program_id = ? AND user_id = ?", programID.String(), userID.String()).
+		Count(&count).Error
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// GetAssignmentByID возвращает назначение по ID.
+func (r *ProgramRepository) GetAssignmentByID(ctx context.Context, id uuid.UUID) (*domain.Assignment, error) {
+	var model pgProgramAssignment
+	err := dbFromContext(ctx, r.db).Where("id = ?", id.String()).Take(&model).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, repo.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return model.toDomain()
+}
+
+// ListAssignmentsByUser возвращает назначения пользователя, начиная с самых поздних.
+func (r *ProgramRepository) ListAssignmentsByUser(ctx context.Context, userID uuid.UUID) ([]*domain.Assignment, error) {
+	var models []pgProgramAssignment
+	err := dbFromContext(ctx, r.db).
+		Where("user_id = ?", userID.String()).
+		Order("start_date DESC, created_at DESC").
+		Find(&models).Error
+	if err != nil {
+		return nil, err
+	}
+
+	assignments := make([]*domain.Assignment, 0, len(models))
+	for i := range models {
+		a, err := models[i].toDomain()
+		if err != nil {
+			return nil, err
+		}
+		assignments = append(assignments, a)
+	}
+	return assignments, nil
+}
+
+// DeleteAssignmentsByUserID удаляет назначения программ пользователю.
+func (r *ProgramRepository) DeleteAssignmentsByUserID(ctx context.Context, userID uuid.UUID) error {
+	return dbFromContext(ctx, r.db).
+		Where("user_id = ?", userID.String()).
+		Delete(&pgProgramAssignment{}).Error
+}
+
+// DifficultyStats агрегирует оценки сложности завершённых тренировок по назначениям программ.
+func (r *ProgramRepository) DifficultyStats(ctx context.Context, programIDs []uuid.UUID) (map[uuid.UUID]domain.DifficultyStats, error) {
+	stats := make(map[uuid.UUID]domain.DifficultyStats, len(programIDs))
+	if len(programIDs) == 0 {
+		return stats, nil
+	}
+	ids := make([]string, len(programIDs))
+	for i, id := range programIDs {
+		ids[i] = id.String()
+	}
+
+	var rows []struct {
+		ProgramID string
+		Ratings   int
+		Average   float64
+	}
+	err := dbFromContext(ctx, r.db).
+		Table("workout_sessions").
+		Select("program_assignments.program_id AS program_id, COUNT(*) AS ratings, AVG(workout_sessions.difficulty) AS average").
+		Joins("JOIN program_assignments ON program_assignments.id = workout_sessions.assignment_id").
+		Where("program_assignments.program_id IN ? AND workout_sessions.difficulty IS NOT NULL", ids).
+		Group("program_assignments.program_id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	for _, row := range rows {
+		id, err := uuid.Parse(row.ProgramID)
+		if err != nil {
+			return nil, err
+		}
+		stats[id] = domain.DifficultyStats{Ratings: row.Ratings, Average: row.Average}
+	}
+	return stats, nil
+}
+
+// CompletedSessions считает завершённые тренировки по назначениям одним запросом.
+func (r *ProgramRepository) CompletedSessions(ctx context.Context, assignmentIDs []uuid.UUID) (map[uuid.UUID]int, error) {
+	completed := make(map[uuid.UUID]int, len(assignmentIDs))
+	if len(assignmentIDs) == 0 {
+		return completed, nil
+	}
+	ids := make([]string, len(assignmentIDs))
+	for i, id := range assignmentIDs {
+		ids[i] = id.String()
+	}
+
+	var rows []struct {
+		AssignmentID string
+		Sessions     int
+	}
+	err := dbFromContext(ctx, r.db).
+		Table("workout_sessions").
+		Select("assignment_id, COUNT(*) AS sessions").
+		Where("assignment_id IN ? AND finished_at IS NOT NULL", ids).
+		Group("assignment_id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	for _, row := range rows {
+		id, err := uuid.Parse(row.AssignmentID)
+		if err != nil {
+			return nil, err
+		}
+		completed[id] = row.Sessions
+	}
+	return completed, nil
+}
+
+// RecentDifficulty агрегирует оценки последних limit оценённых тренировок назначения.
+func (r *ProgramRepository) RecentDifficulty(ctx context.Context, assignmentID uuid.UUID, limit int) (domain.DifficultyStats, error) {
+	recent := dbFromContext(ctx, r.db).
+		Table("workout_sessions").
+		Select("difficulty").
+		Where("assignment_id = ? AND difficulty IS NOT NULL", assignmentID.String()).
+		Order("finished_at DESC").
+		Limit(limit)
+
+	var row struct {
+		Ratings int
+		Average *float64
+	}
+	err := dbFromContext(ctx, r.db).
+		Table("(?) AS recent", recent).
+		Select("COUNT(*) AS ratings, AVG(difficulty) AS average").
+		Scan(&row).Error
+	if err != nil {
+		return domain.DifficultyStats{}, err
+	}
+
+	stats := domain.DifficultyStats{Ratings: row.Ratings}
+	if row.Average != nil {
+		stats.Average = *row.Average
+	}
+	return stats, nil
+}
@@ -0,0 +1,138 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	domain "workout-app/internal/domain/share"
+	repo "workout-app/internal/repository/interfaces"
+)
+
+// pgShare представляет ORM-модель для таблицы shares.
+type pgShare struct {
+	ID           string     `gorm:"column:id;type:uuid;primaryKey"`
+	OwnerID      string     `gorm:"column:owner_id;type:uuid;not null"`
+	ResourceType string     `gorm:"column:resource_type;type:varchar(16);not null"`
+	ResourceID   string     `gorm:"column:resource_id;type:uuid;not null"`
+	TokenHash    string     `gorm:"column:token_hash;type:char(64);not null"`
+	CreatedAt    time.Time  `gorm:"column:created_at;type:timestamptz;not null"`
+	RevokedAt    *time.Time `gorm:"column:revoked_at;type:timestamptz"`
+}
+
+func (pgShare) TableName() string {
+	return "shares"
+}
+
+func (m *pgShare) toDomain() (*domain.Share, error) {
+	id, err := uuid.Parse(m.ID)
+	if err != nil {
+		return nil, err
+	}
+	ownerID, err := uuid.Parse(m.OwnerID)
+	if err != nil {
+		return nil, err
+	}
+	resourceID, err := uuid.Parse(m.ResourceID)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.Share{
+		ID:           id,
+		OwnerID:      ownerID,
+		ResourceType: domain.ResourceType(m.ResourceType),
+		ResourceID:   resourceID,
+		TokenHash:    m.TokenHash,
+		CreatedAt:    m.CreatedAt,
+		RevokedAt:    m.RevokedAt,
+	}, nil
+}
+
+// ShareRepository реализует repo.ShareRepository на GORM/Postgres.
+type ShareRepository struct {
+	db *gorm.DB
+}
+
+// Убедимся на этапе компиляции, что структура реализует интерфейс.
+var _ repo.ShareRepository = (*ShareRepository)(nil)
+
+// NewShareRepository создает новый репозиторий публичных ссылок.
+func NewShareRepository(db *gorm.DB) *ShareRepository {
+	return &ShareRepository{db: db}
+}
+
+// Create сохраняет ссылку.
+func (r *ShareRepository) Create(ctx context.Context, s *domain.Share) error {
+	return dbFromContext(ctx, r.db).Create(&pgShare{
+		ID:           s.ID.String(),
+		OwnerID:      s.OwnerID.String(),
+		ResourceType: string(s.ResourceType),
+		ResourceID:   s.ResourceID.String(),
+		TokenHash:    s.TokenHash,
+		CreatedAt:    s.CreatedAt,
+		RevokedAt:    s.RevokedAt,
+	}).Error
+}
+
+// GetByTokenHash возвращает ссылку по хэшу токена.
+func (r *ShareRepository) GetByTokenHash(ctx context.Context, tokenHash string) (*domain.Share, error) {
+	var model pgShare
+	err := dbFromContext(ctx, r.db).Where("token_hash = ?", tokenHash).First(&model).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, repo.ErrNotFound
+		}
+		return nil, err
+	}
+	return model.toDomain()
+}
+
+// ListActiveByOwner возвращает неотозванные ссылки владельца, новые первыми.
+func (r *ShareRepository) ListActiveByOwner(ctx context.Context, ownerID uuid.UUID) ([]*domain.Share, error) {
+	var models []pgShare
+	err := dbFromContext(ctx, r.db).
+		Where("owner_id = ? AND revoked_at IS NULL", ownerID.String()).
+		Order("created_at DESC, id").
+		Find(&models).Error
+	if err != nil {
+		return nil, err
+	}
+
+	shares := make([]*domain.Share, 0, len(models))
+	for i := range models {
+		s, err := models[i].toDomain()
+		if err != nil {
+			return nil, err
+		}
+		shares = append(shares, s)
+	}
+	return shares, nil
+}
+
+// CountActiveByOwner возвращает количество неотозванных ссылок владельца.
+func (r *ShareRepository) CountActiveByOwner(ctx context.Context, ownerID uuid.UUID) (int, error) {
+	var count int64
+	err := dbFromContext(ctx, r.db).
+		Model(&pgShare{}).
+		Where("owner_id = ? AND revoked_at IS NULL", ownerID.String()).
+		Count(&count).Error
+	return int(count), err
+}
+
+// Revoke отзывает неотозванную ссылку владельца.
+func (r *ShareRepository) Revoke(ctx context.Context, id, ownerID uuid.UUID, at time.Time) error {
+	result := dbFromContext(ctx, r.db).
+		Model(&pgShare{}).
+		Where("id = ? AND owner_id = ? AND revoked_at IS NULL", id.String(), ownerID.String()).
+		Update("revoked_at", at)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return repo.ErrNotFound
+	}
+	return nil
+}
@@ -0,0 +1,139 @@
+package postgres
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+
+	"workout-app/internal/domain/program"
+	domain "workout-app/internal/domain/strength"
+	repo "workout-app/internal/repository/interfaces"
+)
+
+// pgStrengthStandard представляет ORM-модель для таблицы strength_standards.
+type pgStrengthStandard struct {
+	Exercise       string  `gorm:"column:exercise;type:varchar(100);primaryKey"`
+	Gender         string  `gorm:"column:gender;type:varchar(10);primaryKey"`
+	BodyWeightKg   float64 `gorm:"column:body_weight_kg;type:numeric(5,1);primaryKey"`
+	BeginnerKg     float64 `gorm:"column:beginner_kg;type:numeric(6,1);not null"`
+	NoviceKg       float64 `gorm:"column:novice_kg;type:numeric(6,1);not null"`
+	IntermediateKg float64 `gorm:"column:intermediate_kg;type:numeric(6,1);not null"`
+	AdvancedKg     float64 `gorm:"column:advanced_kg;type:numeric(6,1);not null"`
+	EliteKg        float64 `gorm:"column:elite_kg;type:numeric(6,1);not null"`
+}
+
+func (pgStrengthStandard) TableName() string {
+	return "strength_standards"
+}
+
+func (m *pgStrengthStandard) toDomain() domain.Row {
+	return domain.Row{
+		BodyWeightKg: m.BodyWeightKg,
+		ThresholdsKg: [5]float64{m.BeginnerKg, m.NoviceKg, m.IntermediateKg, m.AdvancedKg, m.EliteKg},
+	}
+}
+
+// pgStrengthStandardAlias представляет ORM-модель для таблицы strength_standard_aliases.
+type pgStrengthStandardAlias struct {
+	AliasKey string `gorm:"column:alias_key;type:varchar(100);primaryKey"`
+	Exercise string `gorm:"column:exercise;type:varchar(100);not null"`
+}
+
+func (pgStrengthStandardAlias) TableName() string {
+	return "strength_standard_aliases"
+}
+
+// StrengthStandardRepository реализует repo.StrengthStandardRepository на GORM/Postgres.
+type StrengthStandardRepository struct {
+	db *gorm.DB
+}
+
+// Убедимся на этапе компиляции, что структура реализует интерфейс.
+var _ repo.StrengthStandardRepository = (*StrengthStandardRepository)(nil)
+
+// NewStrengthStandardRepository создает новый репозиторий нормативов силы.
+func NewStrengthStandardRepository(db *gorm.DB) *StrengthStandardRepository {
+	return &StrengthStandardRepository{db: db}
+}
+
+// List возвращает все нормативы, сгруппированные по упражнению и полу.
+func (r *StrengthStandardRepository) List(ctx context.Context) ([]*domain.Standard, error) {
+	db := dbFromContext(ctx, r.db)
+
+	var models []pgStrengthStandard
+	if err := db.Order("exercise, gender, body_weight_kg").Find(&models).Error; err != nil {
+		return nil, err
+	}
+	var aliases []pgStrengthStandardAlias
+	if err := db.Order("exercise, alias_key").Find(&aliases).Error; err != nil {
+		return nil, err
+	}
+	aliasesByExercise := make(map[string][]string)
+	for _, a := range aliases {
+		aliasesByExercise[a.Exercise] = append(aliasesByExercise[a.Exercise], a.AliasKey)
+	}
+
+	standards := make([]*domain.Standard, 0)
+	var current *domain.Standard
+	for i := range models {
+		m := &models[i]
+		if current == nil || current.Exercise != m.Exercise || string(current.Gender) != m.Gender {
+			current = &domain.Standard{
+				Exercise: m.Exercise,
+				Gender:   domain.Gender(m.Gender),
+				Aliases:  aliasesByExercise[m.Exercise],
+			}
+			standards = append(standards, current)
+		}
+		current.Rows = append(current.Rows, m.toDomain())
+	}
+	return standards, nil
+}
+
+// Replace заменяет строки норматива и, если заданы, синонимы упражнения в одной транзакции.
+func (r *StrengthStandardRepository) Replace(ctx context.Context, s *domain.Standard) error {
+	return dbFromContext(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		err := tx.Where("exercise = ? AND gender = ?", s.Exercise, string(s.Gender)).
+			Delete(&pgStrengthStandard{}).Error
+		if err != nil {
+			return err
+		}
+		models := make([]pgStrengthStandard, 0, len(s.Rows))
+		for _, row := range s.Rows {
+			models = append(models, pgStrengthStandard{
+				Exercise:       s.Exercise,
+				Gender:         string(s.Gender),
+				BodyWeightKg:   row.BodyWeightKg,
+				BeginnerKg:     row.ThresholdsKg[0],
+				NoviceKg:       row.ThresholdsKg[1],
+				IntermediateKg: row.ThresholdsKg[2],
+				AdvancedKg:     row.ThresholdsKg[3],
+				EliteKg:        row.ThresholdsKg[4],
+			})
+		}
+		if err := tx.Create(&models).Error; err != nil {
+			return err
+		}
+
+		if s.Aliases == nil {
+			return nil
+		}
+		if err := tx.Where("exercise = ?", s.Exercise).Delete(&pgStrengthStandardAlias{}).Error; err != nil {
+			return err
+		}
+		if len(s.Aliases) == 0 {
+			return nil
+		}
+		aliases := make([]pgStrengthStandardAlias, 0, len(s.Aliases))
+		for _, alias := range s.Aliases {
+			aliases = append(aliases, pgStrengthStandardAlias{AliasKey: program.ExerciseKey(alias), Exercise: s.Exercise})
+		}
+		if err := tx.Create(&aliases).Error; err != nil {
+			if isUniqueViolation(err) {
+				return repo.ErrAliasTaken
+			}
+			return err
+		}
+		return nil
+	})
+}
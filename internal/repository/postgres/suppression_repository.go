@@ -0,0 +1,151 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	domain "workout-app/internal/domain/deliverability"
+	repo "workout-app/internal/repository/interfaces"
+)
+
+// pgSuppression представляет ORM-модель для таблицы email_suppressions.
+type pgSuppression struct {
+	Email     string    `gorm:"column:email;type:varchar(255);primaryKey"`
+	Source    string    `gorm:"column:source;type:varchar(16);not null"`
+	Provider  string    `gorm:"column:provider;type:varchar(32);not null"`
+	Reason    string    `gorm:"column:reason;type:text;not null"`
+	CreatedBy *string   `gorm:"column:created_by;type:uuid"`
+	CreatedAt time.Time `gorm:"column:created_at;type:timestamptz;not null"`
+}
+
+func (pgSuppression) TableName() string {
+	return "email_suppressions"
+}
+
+func (m *pgSuppression) toDomain() (*domain.Suppression, error) {
+	s := &domain.Suppression{
+		Email:     m.Email,
+		Source:    domain.SuppressionSource(m.Source),
+		Provider:  m.Provider,
+		Reason:    m.Reason,
+		CreatedAt: m.CreatedAt,
+	}
+	if m.CreatedBy != nil {
+		id, err := uuid.Parse(*m.CreatedBy)
+		if err != nil {
+			return nil, err
+		}
+		s.CreatedBy = &id
+	}
+	return s, nil
+}
+
+// SuppressionRepository реализует repo.SuppressionRepository на GORM/Postgres.
+type SuppressionRepository struct {
+	db *gorm.DB
+}
+
+// Убедимся на этапе компиляции, что структура реализует интерфейс.
+var _ repo.SuppressionRepository = (*SuppressionRepository)(nil)
+
+// NewSuppressionRepository создает новый репозиторий списка подавления писем.
+func NewSuppressionRepository(db *gorm.DB) *SuppressionRepository {
+	return &SuppressionRepository{db: db}
+}
+
+// Add добавляет адрес в список, не перезаписывая существующую запись.
+func (r *SuppressionRepository) Add(ctx context.Context, s *domain.Suppression) (bool, error) {
+	model := &pgSuppression{
+		Email:     s.Email,
+		Source:    string(s.Source),
+		Provider:  s.Provider,
+		Reason:    s.Reason,
+		CreatedAt: s.CreatedAt,
+	}
+	if s.CreatedBy != nil {
+		by := s.CreatedBy.String()
+		model.CreatedBy = &by
+	}
+	result := dbFromContext(ctx, r.db).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(model)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// Remove удаляет адрес из списка.
+func (r *SuppressionRepository) Remove(ctx context.Context, email string) error {
+	result := dbFromContext(ctx, r.db).
+		Where("email = ?", email).
+		Delete(&pgSuppression{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return repo.ErrNotFound
+	}
+	return nil
+}
+
+// Exists сообщает, находится ли адрес в списке.
+func (r *SuppressionRepository) Exists(ctx context.Context, email string) (bool, error) {
+	var count int64
+	err := dbFromContext(ctx, r.db).
+		Model(&pgSuppression{}).
+		Where("email = ?", email).
+		Count(&count).Error
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// List возвращает записи по фильтру и общее количество подходящих записей.
+func (r *SuppressionRepository) List(ctx context.Context, filter repo.SuppressionFilter) ([]*domain.Suppression, int64, error) {
+	// Отдельные цепочки для подсчёта и выборки: GORM не переиспользует запрос после Count.
+	filtered := func() *gorm.DB {
+		query := dbFromContext(ctx, r.db).Model(&pgSuppression{})
+		if filter.Source != "" {
+			query = query.Where("source = ?", string(filter.Source))
+		}
+		return query
+	}
+
+	var total int64
+	if err := filtered().Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var models []pgSuppression
+	err := filtered().
+		Order("created_at DESC, email").
+		Limit(filter.Limit).
+		Offset(filter.Offset).
+		Find(&models).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	items := make([]*domain.Suppression, 0, len(models))
+	for i := range models {
+		s, err := models[i].toDomain()
+		if err != nil {
+			return nil, 0, err
+		}
+		items = append(items, s)
+	}
+	return items, total, nil
+}
+
+// Count возвращает размер списка.
+func (r *SuppressionRepository) Count(ctx context.Context) (int64, error) {
+	var count int64
+	err := dbFromContext(ctx, r.db).Model(&pgSuppression{}).Count(&count).Error
+	return count, err
+}
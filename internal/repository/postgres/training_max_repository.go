@@ -0,0 +1,110 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	domain "workout-app/internal/domain/program"
+	repo "workout-app/internal/repository/interfaces"
+)
+
+// pgTrainingMax представляет ORM-модель для таблицы training_maxes.
+type pgTrainingMax struct {
+	UserID      string    `gorm:"column:user_id;type:uuid;primaryKey"`
+	ExerciseKey string    `gorm:"column:exercise_key;type:varchar(100);primaryKey"`
+	Exercise    string    `gorm:"column:exercise;type:varchar(100);not null"`
+	WeightKg    float64   `gorm:"column:weight_kg;not null"`
+	UpdatedAt   time.Time `gorm:"column:updated_at;type:timestamptz;not null"`
+}
+
+func (pgTrainingMax) TableName() string {
+	return "training_maxes"
+}
+
+func (m *pgTrainingMax) toDomain() (*domain.TrainingMax, error) {
+	userID, err := uuid.Parse(m.UserID)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.TrainingMax{
+		UserID:    userID,
+		Exercise:  m.Exercise,
+		WeightKg:  m.WeightKg,
+		UpdatedAt: m.UpdatedAt,
+	}, nil
+}
+
+// TrainingMaxRepository реализует repo.TrainingMaxRepository на GORM/Postgres.
+type TrainingMaxRepository struct {
+	db *gorm.DB
+}
+
+// Убедимся на этапе компиляции, что структура реализует интерфейс.
+var _ repo.TrainingMaxRepository = (*TrainingMaxRepository)(nil)
+
+// NewTrainingMaxRepository создает новый репозиторий тренировочных максимумов.
+func NewTrainingMaxRepository(db *gorm.DB) *TrainingMaxRepository {
+	return &TrainingMaxRepository{db: db}
+}
+
+// Upsert сохраняет максимум пользователя в упражнении, заменяя прежнее значение.
+func (r *TrainingMaxRepository) Upsert(ctx context.Context, tm *domain.TrainingMax) error {
+	model := &pgTrainingMax{
+		UserID:      tm.UserID.String(),
+		ExerciseKey: domain.ExerciseKey(tm.Exercise),
+		Exercise:    tm.Exercise,
+		WeightKg:    tm.WeightKg,
+		UpdatedAt:   tm.UpdatedAt,
+	}
+	return dbFromContext(ctx, r.db).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "user_id"}, {Name: "exercise_key"}},
+			DoUpdates: clause.AssignmentColumns([]string{"exercise", "weight_kg", "updated_at"}),
+		}).
+		Create(model).Error
+}
+
+// ListByUser возвращает максимумы пользователя, отсортированные по упражнению.
+func (r *TrainingMaxRepository) ListByUser(ctx context.Context, userID uuid.UUID) ([]*domain.TrainingMax, error) {
+	var models []pgTrainingMax
+	err := dbFromContext(ctx, r.db).
+		Where("user_id = ?", userID.String()).
+		Order("exercise_key").
+		Find(&models).Error
+	if err != nil {
+		return nil, err
+	}
+
+	maxes := make([]*domain.TrainingMax, 0, len(models))
+	for i := range models {
+		tm, err := models[i].toDomain()
+		if err != nil {
+			return nil, err
+		}
+		maxes = append(maxes, tm)
+	}
+	return maxes, nil
+}
+
+// Delete удаляет максимум пользователя в упражнении.
+func (r *TrainingMaxRepository) Delete(ctx context.Context, userID uuid.UUID, exercise string) error {
+	result := dbFromContext(ctx, r.db).
+		Where("user_id = ? AND exercise_key = ?", userID.String(), domain.ExerciseKey(exercise)).
+		Delete(&pgTrainingMax{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return repo.ErrNotFound
+	}
+	return nil
+}
+
+// DeleteByUserID удаляет все максимумы пользователя.
+func (r *TrainingMaxRepository) DeleteByUserID(ctx context.Context, userID uuid.UUID) error {
+	return dbFromContext(ctx, r.db).Where("user_id = ?", userID.String()).Delete(&pgTrainingMax{}).Error
+}
@@ -0,0 +1,48 @@
+package postgres
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+
+	repo "workout-app/internal/repository/interfaces"
+)
+
+// txContextKey — ключ контекста, под которым хранится открытая транзакция.
+type txContextKey struct{}
+
+// dbFromContext возвращает транзакцию из контекста (если она открыта через Transactor)
+// или исходное подключение. Все репозитории обращаются к БД через эту функцию.
+func dbFromContext(ctx context.Context, db *gorm.DB) *gorm.DB {
+	if tx, ok := ctx.Value(txContextKey{}).(*gorm.DB); ok {
+		return tx.WithContext(ctx)
+	}
+	return db.WithContext(ctx)
+}
+
+// Transactor реализует repo.Transactor на GORM/Postgres.
+type Transactor struct {
+	db *gorm.DB
+}
+
+// Убедимся на этапе компиляции, что структура реализует интерфейс.
+var _ repo.Transactor = (*Transactor)(nil)
+
+// NewTransactor создает новый Transactor.
+func NewTransactor(db *gorm.DB) *Transactor {
+	return &Transactor{db: db}
+}
+
+// WithinTransaction выполняет fn в транзакции; контекст fn содержит транзакцию для репозиториев.
+// Действия repo.AfterCommit из fn выполняются после фиксации.
+func (t *Transactor) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	txCtx, committed := repo.WithAfterCommit(ctx)
+	err := dbFromContext(ctx, t.db).Transaction(func(tx *gorm.DB) error {
+		return fn(context.WithValue(txCtx, txContextKey{}, tx))
+	})
+	if err != nil {
+		return err
+	}
+	committed()
+	return nil
+}
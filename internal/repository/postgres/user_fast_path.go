@@ -0,0 +1,334 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	domain "workout-app/internal/domain/user"
+	repo "workout-app/internal/repository/interfaces"
+	"workout-app/pkg/logger"
+)
+
+// FastPathMode — режим быстрого пути чтения пользователей (DB_USER_FAST_PATH).
+type FastPathMode string
+
+const (
+	FastPathOff    FastPathMode = "off"    // Только GORM
+	FastPathShadow FastPathMode = "shadow" // Ответ из GORM; pgx читает то же в фоне, результаты сравниваются
+	FastPathOn     FastPathMode = "on"     // Ответ из pgx; при ошибке pgx — из GORM
+)
+
+// Методы чтения, которые обслуживает быстрый путь.
+const (
+	methodGetByID    = "get_by_id"
+	methodGetByEmail = "get_by_email"
+)
+
+// Пути чтения в метриках.
+const (
+	pathGORM = "gorm"
+	pathPGX  = "pgx"
+)
+
+// shadowReadTimeout ограничивает теневое чтение: оно не связано с отменой исходного запроса.
+const shadowReadTimeout = 5 * time.Second
+
+// UserReader — чтение пользователя по ID и email, которое обслуживает быстрый путь.
+type UserReader interface {
+	GetByID(ctx context.Context, id uuid.UUID) (*domain.User, error)
+	GetByEmail(ctx context.Context, email string) (*domain.User, error)
+}
+
+// UserFastPath оборачивает репозиторий пользователей: GetByID и GetByEmail (вход, refresh, проверка
+// токена) в зависимости от режима читаются через pgx или сверяются с ним, остальные методы идут в GORM.
+// Внутри транзакции всегда читает GORM: соединение pgx не видит её незафиксированных изменений.
+type UserFastPath struct {
+	repo.UserRepository
+	fast    UserReader
+	mode    FastPathMode
+	metrics *FastPathMetrics
+	logger  logger.Logger
+
+	shadow chan struct{} // Ограничивает одновременные теневые чтения; при заполнении чтение пропускается
+	wg     sync.WaitGroup
+}
+
+// NewUserFastPath создаёт обёртку быстрого пути над primary. maxShadow — сколько теневых чтений
+// выполняется одновременно; лишние пропускаются и учитываются в метриках.
+func NewUserFastPath(primary repo.UserRepository, fast UserReader, mode FastPathMode, maxShadow int, metrics *FastPathMetrics, logger logger.Logger) *UserFastPath {
+	return &UserFastPath{
+		UserRepository: primary,
+		fast:           fast,
+		mode:           mode,
+		metrics:        metrics,
+		logger:         logger,
+		shadow:         make(chan struct{}, maxShadow),
+	}
+}
+
+// GetByID возвращает пользователя по идентификатору через выбранный путь.
+func (r *UserFastPath) GetByID(ctx context.Context, id uuid.UUID) (*domain.User, error) {
+	return r.read(ctx, methodGetByID,
+		func(ctx context.Context) (*domain.User, error) { return r.UserRepository.GetByID(ctx, id) },
+		func(ctx context.Context) (*domain.User, error) { return r.fast.GetByID(ctx, id) },
+	)
+}
+
+// GetByEmail возвращает пользователя по email через выбранный путь.
+func (r *UserFastPath) GetByEmail(ctx context.Context, email string) (*domain.User, error) {
+	return r.read(ctx, methodGetByEmail,
+		func(ctx context.Context) (*domain.User, error) { return r.UserRepository.GetByEmail(ctx, email) },
+		func(ctx context.Context) (*domain.User, error) { return r.fast.GetByEmail(ctx, email) },
+	)
+}
+
+// Stop дожидается завершения теневых чтений; вызывается до закрытия пула pgx.
+func (r *UserFastPath) Stop(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		r.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+type readFunc func(ctx context.Context) (*domain.User, error)
+
+func (r *UserFastPath) read(ctx context.Context, method string, primary, fast readFunc) (*domain.User, error) {
+	if _, inTx := ctx.Value(txContextKey{}).(*gorm.DB); inTx || r.mode == FastPathOff {
+		return primary(ctx)
+	}
+
+	if r.mode == FastPathOn {
+		user, err := r.observe(ctx, method, pathPGX, fast)
+		if err == nil || errors.Is(err, repo.ErrNotFound) {
+			return user, err
+		}
+		r.logger.Warn("user_fast_path_fallback", map[string]any{"method": method, "error": err.Error()})
+		return r.observe(ctx, method, pathGORM, primary)
+	}
+
+	user, err := r.observe(ctx, method, pathGORM, primary)
+	if err != nil && !errors.Is(err, repo.ErrNotFound) {
+		return user, err
+	}
+	select {
+	case r.shadow <- struct{}{}:
+	default:
+		r.metrics.shadowSkipped(method)
+		return user, err
+	}
+	// Сверяется копия: вызывающий код может менять возвращённого пользователя, пока идёт теневое чтение.
+	var expected *domain.User
+	if user != nil {
+		snapshot := *user
+		expected = &snapshot
+	}
+	r.wg.Add(1)
+	go func() {
+		defer r.wg.Done()
+		defer func() { <-r.shadow }()
+		shadowCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), shadowReadTimeout)
+		defer cancel()
+		r.compare(shadowCtx, method, expected, fast)
+	}()
+	return user, err
+}
+
+// observe выполняет чтение и учитывает его длительность; отсутствие пользователя ошибкой не считается.
+func (r *UserFastPath) observe(ctx context.Context, method, path string, fn readFunc) (*domain.User, error) {
+	start := time.Now()
+	user, err := fn(ctx)
+	r.metrics.observe(method, path, time.Since(start), err != nil && !errors.Is(err, repo.ErrNotFound))
+	return user, err
+}
+
+// compare читает пользователя через pgx и сверяет с результатом GORM (nil — пользователь не найден).
+func (r *UserFastPath) compare(ctx context.Context, method string, expected *domain.User, fast readFunc) {
+	got, err := r.observe(ctx, method, pathPGX, fast)
+	if err != nil && !errors.Is(err, repo.ErrNotFound) {
+		r.logger.Warn("user_fast_path_shadow_failed", map[string]any{"method": method, "error": err.Error()})
+		return
+	}
+
+	var fields []string
+	switch {
+	case expected == nil && got == nil:
+	case expected == nil || got == nil:
+		fields = []string{"found"}
+	default:
+		fields = diffUsers(expected, got)
+	}
+	r.metrics.compared(method, len(fields) > 0)
+	if len(fields) > 0 {
+		// Значения полей не пишутся в лог: среди них персональные данные и хэш пароля.
+		log := map[string]any{"method": method, "fields": fields}
+		if expected != nil {
+			log["user_id"] = expected.ID.String()
+		}
+		r.logger.Warn("user_fast_path_mismatch", log)
+	}
+}
+
+// diffUsers возвращает имена полей, значения которых различаются. Моменты времени сравниваются
+// без учёта часового пояса: драйверы могут вернуть их в разных зонах.
+func diffUsers(a, b *domain.User) []string {
+	va, vb := reflect.ValueOf(*a), reflect.ValueOf(*b)
+	var fields []string
+	for i := 0; i < va.NumField(); i++ {
+		if !equalValues(va.Field(i), vb.Field(i)) {
+			fields = append(fields, va.Type().Field(i).Name)
+		}
+	}
+	return fields
+}
+
+func equalValues(a, b reflect.Value) bool {
+	if t, ok := a.Interface().(time.Time); ok {
+		return t.Equal(b.Interface().(time.Time))
+	}
+	switch a.Kind() {
+	case reflect.Pointer:
+		if a.IsNil() || b.IsNil() {
+			return a.IsNil() == b.IsNil()
+		}
+		return equalValues(a.Elem(), b.Elem())
+	case reflect.Struct:
+		for i := 0; i < a.NumField(); i++ {
+			if !equalValues(a.Field(i), b.Field(i)) {
+				return false
+			}
+		}
+		return true
+	default:
+		return reflect.DeepEqual(a.Interface(), b.Interface())
+	}
+}
+
+// ReadPathStats описывает чтения одним методом через один путь с момента старта процесса.
+type ReadPathStats struct {
+	Method        string // get_by_id или get_by_email
+	Path          string // gorm или pgx
+	Reads         int64
+	Errors        int64 // Ошибки, кроме отсутствия пользователя
+	TotalDuration time.Duration
+	MaxDuration   time.Duration
+}
+
+// ShadowStats описывает сверку теневых чтений pgx с GORM по методу.
+type ShadowStats struct {
+	Method     string
+	Compared   int64 // Сверено чтений
+	Mismatches int64 // Из них с расхождением
+	Skipped    int64 // Не выполнено: достигнут предел одновременных теневых чтений
+}
+
+type readPathKey struct {
+	method, path string
+}
+
+// FastPathMetrics накапливает длительность чтений пользователей через GORM и pgx
+// и результаты сверки. Безопасен для конкурентного использования.
+type FastPathMetrics struct {
+	mu     sync.Mutex
+	reads  map[readPathKey]*ReadPathStats
+	shadow map[string]*ShadowStats
+}
+
+// NewFastPathMetrics создаёт пустой набор метрик.
+func NewFastPathMetrics() *FastPathMetrics {
+	return &FastPathMetrics{
+		reads:  make(map[readPathKey]*ReadPathStats),
+		shadow: make(map[string]*ShadowStats),
+	}
+}
+
+func (m *FastPathMetrics) observe(method, path string, d time.Duration, failed bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	key := readPathKey{method: method, path: path}
+	stats, ok := m.reads[key]
+	if !ok {
+		stats = &ReadPathStats{Method: method, Path: path}
+		m.reads[key] = stats
+	}
+	stats.Reads++
+	stats.TotalDuration += d
+	if d > stats.MaxDuration {
+		stats.MaxDuration = d
+	}
+	if failed {
+		stats.Errors++
+	}
+}
+
+func (m *FastPathMetrics) shadowStats(method string) *ShadowStats {
+	stats, ok := m.shadow[method]
+	if !ok {
+		stats = &ShadowStats{Method: method}
+		m.shadow[method] = stats
+	}
+	return stats
+}
+
+func (m *FastPathMetrics) compared(method string, mismatch bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	stats := m.shadowStats(method)
+	stats.Compared++
+	if mismatch {
+		stats.Mismatches++
+	}
+}
+
+func (m *FastPathMetrics) shadowSkipped(method string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.shadowStats(method).Skipped++
+}
+
+// Reads возвращает статистику чтений, упорядоченную по методу и пути.
+func (m *FastPathMetrics) Reads() []ReadPathStats {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	reads := make([]ReadPathStats, 0, len(m.reads))
+	for _, stats := range m.reads {
+		reads = append(reads, *stats)
+	}
+	sort.Slice(reads, func(i, j int) bool {
+		if reads[i].Method != reads[j].Method {
+			return reads[i].Method < reads[j].Method
+		}
+		return reads[i].Path < reads[j].Path
+	})
+	return reads
+}
+
+// Shadow возвращает результаты сверки по методам.
+func (m *FastPathMetrics) Shadow() []ShadowStats {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	shadow := make([]ShadowStats, 0, len(m.shadow))
+	for _, stats := range m.shadow {
+		shadow = append(shadow, *stats)
+	}
+	sort.Slice(shadow, func(i, j int) bool { return shadow[i].Method < shadow[j].Method })
+	return shadow
+}
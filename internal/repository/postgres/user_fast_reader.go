@@ -0,0 +1,65 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	domain "workout-app/internal/domain/user"
+	repo "workout-app/internal/repository/interfaces"
+	"workout-app/pkg/emailaddr"
+)
+
+// userColumns — колонки users в порядке полей pgUser в UserFastReader.one. Колонки без NOT NULL
+// приводятся к пустой строке, как их читает GORM.
+const userColumns = `id, email, email_normalized, password_hash, username, COALESCE(first_name, ''), COALESCE(last_name, ''),
+	birth_date, COALESCE(gender, ''), COALESCE(avatar_url, ''), instagram, instagram_visibility, youtube, youtube_visibility, website, website_visibility,
+	workouts_visibility, role, training_level, is_email_verified, language, timezone, locale, units, reminder_time,
+	calorie_target, country, region, suspended_at, suspended_until, suspension_reason, legal_hold_at, legal_hold_by, legal_hold_reason,
+	tokens_valid_after, created_at, updated_at, deleted_at, anonymized_at`
+
+// UserFastReader читает пользователей по email и ID напрямую через pgx, без GORM:
+// быстрый путь для входа и обновления токенов. Запросы и маппинг совпадают с UserRepository.
+type UserFastReader struct {
+	pool   *pgxpool.Pool
+	emails emailaddr.Policy
+}
+
+// NewUserFastReader создаёт читатель пользователей на пуле pgx.
+// emails должна совпадать с политикой UserRepository, иначе поиск по email разойдётся.
+func NewUserFastReader(pool *pgxpool.Pool, emails emailaddr.Policy) *UserFastReader {
+	return &UserFastReader{pool: pool, emails: emails}
+}
+
+// GetByID возвращает активного пользователя по идентификатору.
+func (r *UserFastReader) GetByID(ctx context.Context, id uuid.UUID) (*domain.User, error) {
+	return r.one(ctx, "id = $1", id.String())
+}
+
+// GetByEmail возвращает активного пользователя по канонической форме email.
+func (r *UserFastReader) GetByEmail(ctx context.Context, email string) (*domain.User, error) {
+	return r.one(ctx, "email_normalized = $1", r.emails.Canonical(email))
+}
+
+func (r *UserFastReader) one(ctx context.Context, condition string, arg any) (*domain.User, error) {
+	row := r.pool.QueryRow(ctx, "SELECT "+userColumns+" FROM users WHERE deleted_at IS NULL AND "+condition+" LIMIT 1", arg)
+
+	var m pgUser
+	err := row.Scan(
+		&m.ID, &m.Email, &m.EmailNormalized, &m.PasswordHash, &m.Username, &m.FirstName, &m.LastName, &m.BirthDate, &m.Gender,
+		&m.AvatarURL, &m.Instagram, &m.InstagramVisible, &m.YouTube, &m.YouTubeVisible, &m.Website, &m.WebsiteVisible,
+		&m.WorkoutsVisible, &m.Role, &m.TrainingLevel, &m.IsEmailVerified, &m.Language, &m.Timezone, &m.Locale, &m.Units, &m.ReminderTime,
+		&m.CalorieTarget, &m.Country, &m.Region, &m.SuspendedAt, &m.SuspendedUntil, &m.SuspensionReason, &m.LegalHoldAt, &m.LegalHoldBy, &m.LegalHoldReason,
+		&m.TokensValidAfter, &m.CreatedAt, &m.UpdatedAt, &m.DeletedAt, &m.AnonymizedAt,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, repo.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return m.toDomain()
+}
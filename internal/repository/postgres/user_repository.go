@@ -9,29 +9,56 @@ import (
 	"github.com/google/uuid"
 	"github.com/jackc/pgconn"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 
+	"workout-app/internal/domain/region"
 	domain "workout-app/internal/domain/user"
 	repo "workout-app/internal/repository/interfaces"
+	"workout-app/pkg/emailaddr"
 )
 
 // pgUser представляет собой ORM-модель для таблицы users.
 // Она максимально близко отражает схему БД и маппится в доменную модель User.
 type pgUser struct {
-	ID              string     `gorm:"column:id;type:uuid;primaryKey"`
-	Email           string     `gorm:"column:email;type:varchar(255);not null"`
-	PasswordHash    string     `gorm:"column:password_hash;type:varchar(255);not null"`
-	Username        string     `gorm:"column:username;type:varchar(50);not null"`
-	FirstName       string     `gorm:"column:first_name;type:varchar(100)"`
-	LastName        string     `gorm:"column:last_name;type:varchar(100)"`
-	BirthDate       *time.Time `gorm:"column:birth_date;type:date"`
-	Gender          string     `gorm:"column:gender;type:text"`
-	AvatarURL       string     `gorm:"column:avatar_url;type:text"`
-	Role            string     `gorm:"column:role;type:text;not null"`
-	TrainingLevel   string     `gorm:"column:training_level;type:text;not null"`
-	IsEmailVerified bool       `gorm:"column:is_email_verified;type:boolean;not null"`
-	CreatedAt       time.Time  `gorm:"column:created_at;type:timestamptz;not null"`
-	UpdatedAt       time.Time  `gorm:"column:updated_at;type:timestamptz;not null"`
-	DeletedAt       *time.Time `gorm:"column:deleted_at;type:timestamptz"`
+	ID               string     `gorm:"column:id;type:uuid;primaryKey"`
+	Email            string     `gorm:"column:email;type:varchar(255);not null"`
+	EmailNormalized  string     `gorm:"column:email_normalized;type:varchar(255);not null"`
+	PasswordHash     string     `gorm:"column:password_hash;type:varchar(255);not null"`
+	Username         string     `gorm:"column:username;type:varchar(50);not null"`
+	FirstName        string     `gorm:"column:first_name;type:varchar(100)"`
+	LastName         string     `gorm:"column:last_name;type:varchar(100)"`
+	BirthDate        *time.Time `gorm:"column:birth_date;type:date"`
+	Gender           string     `gorm:"column:gender;type:text"`
+	AvatarURL        string     `gorm:"column:avatar_url;type:text"`
+	Instagram        string     `gorm:"column:instagram;type:varchar(30);not null"`
+	InstagramVisible string     `gorm:"column:instagram_visibility;type:varchar(16);not null"`
+	YouTube          string     `gorm:"column:youtube;type:varchar(32);not null"`
+	YouTubeVisible   string     `gorm:"column:youtube_visibility;type:varchar(16);not null"`
+	Website          string     `gorm:"column:website;type:varchar(255);not null"`
+	WebsiteVisible   string     `gorm:"column:website_visibility;type:varchar(16);not null"`
+	WorkoutsVisible  string     `gorm:"column:workouts_visibility;type:varchar(16);not null"`
+	Role             string     `gorm:"column:role;type:text;not null"`
+	TrainingLevel    string     `gorm:"column:training_level;type:text;not null"`
+	IsEmailVerified  bool       `gorm:"column:is_email_verified;type:boolean;not null"`
+	Language         string     `gorm:"column:language;type:varchar(8);not null"`
+	Timezone         string     `gorm:"column:timezone;type:varchar(64);not null"`
+	Locale           string     `gorm:"column:locale;type:varchar(35);not null"`
+	Units            string     `gorm:"column:units;type:varchar(16);not null"`
+	ReminderTime     *int16     `gorm:"column:reminder_time;type:smallint"`
+	CalorieTarget    *int       `gorm:"column:calorie_target"`
+	Country          string     `gorm:"column:country;type:varchar(2);not null"`
+	Region           string     `gorm:"column:region;type:varchar(16);not null"`
+	SuspendedAt      *time.Time `gorm:"column:suspended_at;type:timestamptz"`
+	SuspendedUntil   *time.Time `gorm:"column:suspended_until;type:timestamptz"`
+	SuspensionReason string     `gorm:"column:suspension_reason;type:text;not null"`
+	LegalHoldAt      *time.Time `gorm:"column:legal_hold_at;type:timestamptz"`
+	LegalHoldBy      *string    `gorm:"column:legal_hold_by;type:uuid"`
+	LegalHoldReason  string     `gorm:"column:legal_hold_reason;type:text;not null"`
+	TokensValidAfter *time.Time `gorm:"column:tokens_valid_after;type:timestamptz"`
+	CreatedAt        time.Time  `gorm:"column:created_at;type:timestamptz;not null"`
+	UpdatedAt        time.Time  `gorm:"column:updated_at;type:timestamptz;not null"`
+	DeletedAt        *time.Time `gorm:"column:deleted_at;type:timestamptz"`
+	AnonymizedAt     *time.Time `gorm:"column:anonymized_at;type:timestamptz"`
 }
 
 func (pgUser) TableName() string {
@@ -40,15 +67,17 @@ func (pgUser) TableName() string {
 
 // UserRepository реализует repo.UserRepository с использованием GORM и Postgres.
 type UserRepository struct {
-	db *gorm.DB
+	db     *gorm.DB
+	emails emailaddr.Policy // Правила канонической формы email для уникальности и поиска
 }
 
 // Убедимся на этапе компиляции, что структура реализует интерфейс.
 var _ repo.UserRepository = (*UserRepository)(nil)
 
 // NewUserRepository создает новый репозиторий пользователей.
-func NewUserRepository(db *gorm.DB) *UserRepository {
-	return &UserRepository{db: db}
+// emails определяет, какие варианты написания адреса считаются одним email.
+func NewUserRepository(db *gorm.DB, emails emailaddr.Policy) *UserRepository {
+	return &UserRepository{db: db, emails: emails}
 }
 
 // isUniqueViolation проверяет, является ли ошибка нарушением уникального ограничения PostgreSQL.
@@ -100,53 +129,156 @@ func (m *pgUser) toDomain() (*domain.User, error) {
 		return nil, err
 	}
 
+	var suspension *domain.Suspension
+	if m.SuspendedAt != nil {
+		suspension = &domain.Suspension{
+			At:     *m.SuspendedAt,
+			Until:  m.SuspendedUntil,
+			Reason: m.SuspensionReason,
+		}
+	}
+
+	var legalHold *domain.LegalHold
+	if m.LegalHoldAt != nil {
+		legalHold = &domain.LegalHold{At: *m.LegalHoldAt, Reason: m.LegalHoldReason}
+		if m.LegalHoldBy != nil {
+			if legalHold.By, err = uuid.Parse(*m.LegalHoldBy); err != nil {
+				return nil, err
+			}
+		}
+	}
+
 	return &domain.User{
-		ID:              id,
-		Email:           m.Email,
-		PasswordHash:    m.PasswordHash,
-		Username:        m.Username,
-		FirstName:       m.FirstName,
-		LastName:        m.LastName,
-		BirthDate:       m.BirthDate,
-		Gender:          m.Gender,
-		AvatarURL:       m.AvatarURL,
-		Role:            domain.Role(m.Role),
-		TrainingLevel:   domain.TrainingLevel(m.TrainingLevel),
-		IsEmailVerified: m.IsEmailVerified,
-		CreatedAt:       m.CreatedAt,
-		UpdatedAt:       m.UpdatedAt,
-		DeletedAt:       m.DeletedAt,
+		ID:           id,
+		Email:        m.Email,
+		PasswordHash: m.PasswordHash,
+		Username:     m.Username,
+		FirstName:    m.FirstName,
+		LastName:     m.LastName,
+		BirthDate:    m.BirthDate,
+		Gender:       m.Gender,
+		AvatarURL:    m.AvatarURL,
+		Links: domain.ProfileLinks{
+			Instagram: domain.ProfileLink{Value: m.Instagram, Visibility: domain.LinkVisibility(m.InstagramVisible)},
+			YouTube:   domain.ProfileLink{Value: m.YouTube, Visibility: domain.LinkVisibility(m.YouTubeVisible)},
+			Website:   domain.ProfileLink{Value: m.Website, Visibility: domain.LinkVisibility(m.WebsiteVisible)},
+		},
+		WorkoutsVisibility: domain.WorkoutsVisibility(m.WorkoutsVisible),
+		Role:               domain.Role(m.Role),
+		TrainingLevel:      domain.TrainingLevel(m.TrainingLevel),
+		IsEmailVerified:    m.IsEmailVerified,
+		Language:           domain.Language(m.Language),
+		Timezone:           m.Timezone,
+		Locale:             m.Locale,
+		Units:              domain.Units(m.Units),
+		ReminderTime:       reminderTimeToDomain(m.ReminderTime),
+		CalorieTarget:      m.CalorieTarget,
+		Country:            m.Country,
+		Region:             region.Region(m.Region),
+		Suspension:         suspension,
+		LegalHold:          legalHold,
+		TokensValidAfter:   m.TokensValidAfter,
+		CreatedAt:          m.CreatedAt,
+		UpdatedAt:          m.UpdatedAt,
+		DeletedAt:          m.DeletedAt,
+		AnonymizedAt:       m.AnonymizedAt,
 	}, nil
 }
 
 // fromDomain маппит доменную модель в ORM-модель.
 func fromDomain(u *domain.User) *pgUser {
-	return &pgUser{
-		ID:              u.ID.String(),
-		Email:           u.Email,
-		PasswordHash:    u.PasswordHash,
-		Username:        u.Username,
-		FirstName:       u.FirstName,
-		LastName:        u.LastName,
-		BirthDate:       u.BirthDate,
-		Gender:          u.Gender,
-		AvatarURL:       u.AvatarURL,
-		Role:            string(u.Role),
-		TrainingLevel:   string(u.TrainingLevel),
-		IsEmailVerified: u.IsEmailVerified,
-		CreatedAt:       u.CreatedAt,
-		UpdatedAt:       u.UpdatedAt,
-		DeletedAt:       u.DeletedAt,
+	model := &pgUser{
+		ID:               u.ID.String(),
+		Email:            u.Email,
+		PasswordHash:     u.PasswordHash,
+		Username:         u.Username,
+		FirstName:        u.FirstName,
+		LastName:         u.LastName,
+		BirthDate:        u.BirthDate,
+		Gender:           u.Gender,
+		AvatarURL:        u.AvatarURL,
+		Instagram:        u.Links.Instagram.Value,
+		InstagramVisible: linkVisibility(u.Links.Instagram),
+		YouTube:          u.Links.YouTube.Value,
+		YouTubeVisible:   linkVisibility(u.Links.YouTube),
+		Website:          u.Links.Website.Value,
+		WebsiteVisible:   linkVisibility(u.Links.Website),
+		WorkoutsVisible:  workoutsVisibility(u.WorkoutsVisibility),
+		Role:             string(u.Role),
+		TrainingLevel:    string(u.TrainingLevel),
+		IsEmailVerified:  u.IsEmailVerified,
+		Language:         string(u.Language),
+		Timezone:         u.Timezone,
+		Locale:           u.Locale,
+		Units:            string(u.Units),
+		ReminderTime:     reminderTimeFromDomain(u.ReminderTime),
+		CalorieTarget:    u.CalorieTarget,
+		Country:          u.Country,
+		Region:           string(u.Region),
+		TokensValidAfter: u.TokensValidAfter,
+		CreatedAt:        u.CreatedAt,
+		UpdatedAt:        u.UpdatedAt,
+		DeletedAt:        u.DeletedAt,
+		AnonymizedAt:     u.AnonymizedAt,
+	}
+	if u.Suspension != nil {
+		at := u.Suspension.At
+		model.SuspendedAt = &at
+		model.SuspendedUntil = u.Suspension.Until
+		model.SuspensionReason = u.Suspension.Reason
+	}
+	if u.LegalHold != nil {
+		at := u.LegalHold.At
+		by := u.LegalHold.By.String()
+		model.LegalHoldAt = &at
+		model.LegalHoldBy = &by
+		model.LegalHoldReason = u.LegalHold.Reason
+	}
+	return model
+}
+
+// linkVisibility возвращает видимость ссылки для хранения; не заданная видимость означает public.
+func linkVisibility(l domain.ProfileLink) string {
+	if l.Visibility == "" {
+		return string(domain.LinkPublic)
 	}
+	return string(l.Visibility)
+}
+
+// reminderTimeToDomain маппит время напоминания из БД; NULL — напоминания выключены.
+func reminderTimeToDomain(minutes *int16) *domain.ReminderTime {
+	if minutes == nil {
+		return nil
+	}
+	t := domain.ReminderTime(*minutes)
+	return &t
+}
+
+// reminderTimeFromDomain маппит время напоминания для записи в БД.
+func reminderTimeFromDomain(t *domain.ReminderTime) *int16 {
+	if t == nil {
+		return nil
+	}
+	minutes := int16(*t)
+	return &minutes
+}
+
+// workoutsVisibility возвращает видимость тренировок для записи в БД; пустое значение — private.
+func workoutsVisibility(v domain.WorkoutsVisibility) string {
+	if v == "" {
+		return string(domain.WorkoutsPrivate)
+	}
+	return string(v)
 }
 
 // Create создает нового пользователя в БД.
 func (r *UserRepository) Create(ctx context.Context, user *domain.User) error {
 	model := fromDomain(user)
-	err := r.db.WithContext(ctx).Create(model).Error
+	model.EmailNormalized = r.emails.Canonical(model.Email)
+	err := dbFromContext(ctx, r.db).Create(model).Error
 	if err != nil {
 		// Проверка на нарушение уникальности email
-		if isUniqueViolation(err, "idx_users_email_unique") || strings.Contains(err.Error(), "idx_users_email_unique") {
+		if isUniqueViolation(err, "idx_users_email_normalized_unique") || strings.Contains(err.Error(), "idx_users_email_normalized_unique") {
 			return repo.ErrEmailExists
 		}
 		// Проверка на нарушение уникальности username
@@ -161,7 +293,7 @@ func (r *UserRepository) Create(ctx context.Context, user *domain.User) error {
 // oneByCondition возвращает одну запись по условию с учётом soft delete.
 func (r *UserRepository) oneByCondition(ctx context.Context, query string, args ...interface{}) (*domain.User, error) {
 	var model pgUser
-	err := r.db.WithContext(ctx).
+	err := dbFromContext(ctx, r.db).
 		Where("deleted_at IS NULL").
 		Where(query, args...).
 		Take(&model).Error
@@ -180,9 +312,25 @@ func (r *UserRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Use
 	return r.oneByCondition(ctx, "id = ?", id.String())
 }
 
-// GetByEmail возвращает пользователя по email.
+// GetByIDIncludingDeleted возвращает пользователя по идентификатору, в том числе мягко удалённого.
+func (r *UserRepository) GetByIDIncludingDeleted(ctx context.Context, id uuid.UUID) (*domain.User, error) {
+	var model pgUser
+	err := dbFromContext(ctx, r.db).
+		Where("id = ?", id.String()).
+		Take(&model).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, repo.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return model.toDomain()
+}
+
+// GetByEmail возвращает пользователя по email. Сравнение идёт по канонической форме адреса,
+// поэтому находятся и варианты написания, которые политика считает тем же ящиком.
 func (r *UserRepository) GetByEmail(ctx context.Context, email string) (*domain.User, error) {
-	return r.oneByCondition(ctx, "email = ?", email)
+	return r.oneByCondition(ctx, "email_normalized = ?", r.emails.Canonical(email))
 }
 
 // GetByUsername возвращает пользователя по username.
@@ -193,7 +341,7 @@ func (r *UserRepository) GetByUsername(ctx context.Context, username string) (*d
 // List возвращает всех активных (не удалённых) пользователей.
 func (r *UserRepository) List(ctx context.Context) ([]*domain.User, error) {
 	var models []pgUser
-	err := r.db.WithContext(ctx).
+	err := dbFromContext(ctx, r.db).
 		Where("deleted_at IS NULL").
 		Order("created_at DESC").
 		Find(&models).Error
@@ -212,34 +360,272 @@ func (r *UserRepository) List(ctx context.Context) ([]*domain.User, error) {
 	return users, nil
 }
 
-// Update обновляет данные пользователя.
-// Не обновляет защищенные поля: id, created_at, password_hash.
+// SetSuspension устанавливает или снимает (s == nil) блокировку пользователя.
+func (r *UserRepository) SetSuspension(ctx context.Context, id uuid.UUID, s *domain.Suspension) error {
+	updates := map[string]interface{}{
+		"suspended_at":      nil,
+		"suspended_until":   nil,
+		"suspension_reason": "",
+	}
+	if s != nil {
+		updates["suspended_at"] = s.At
+		updates["suspended_until"] = s.Until
+		updates["suspension_reason"] = s.Reason
+	}
+
+	result := dbFromContext(ctx, r.db).
+		Model(&pgUser{}).
+		Where("id = ? AND deleted_at IS NULL", id.String()).
+		Updates(updates)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return repo.ErrNotFound
+	}
+	return nil
+}
+
+// SetLegalHold устанавливает или снимает (h == nil) юридическое удержание.
+// В отличие от блокировки, удержание применимо и к мягко удалённым пользователям.
+func (r *UserRepository) SetLegalHold(ctx context.Context, id uuid.UUID, h *domain.LegalHold) error {
+	updates := map[string]interface{}{
+		"legal_hold_at":     nil,
+		"legal_hold_by":     nil,
+		"legal_hold_reason": "",
+	}
+	if h != nil {
+		updates["legal_hold_at"] = h.At
+		updates["legal_hold_by"] = h.By.String()
+		updates["legal_hold_reason"] = h.Reason
+	}
+
+	result := dbFromContext(ctx, r.db).
+		Model(&pgUser{}).
+		Where("id = ? AND anonymized_at IS NULL", id.String()).
+		Updates(updates)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return repo.ErrNotFound
+	}
+	return nil
+}
+
+// UpdatePassword обновляет хэш пароля и отзывает токены, выданные до tokensValidAfter.
+func (r *UserRepository) UpdatePassword(ctx context.Context, id uuid.UUID, passwordHash string, tokensValidAfter time.Time) error {
+	result := dbFromContext(ctx, r.db).
+		Model(&pgUser{}).
+		Where("id = ? AND deleted_at IS NULL", id.String()).
+		Updates(map[string]interface{}{
+			"password_hash":      passwordHash,
+			"tokens_valid_after": tokensValidAfter,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return repo.ErrNotFound
+	}
+	return nil
+}
+
+// UpdateRole атомарно меняет роль пользователя; Update роль не пишет, поэтому параллельное
+// сохранение профиля не может вернуть прежнюю роль.
+// Строки всех администраторов блокируются на время транзакции, поэтому два параллельных
+// понижения не могут одновременно пройти проверку на последнего администратора.
+// При понижении отзываются выданные токены: в них записана прежняя роль.
+func (r *UserRepository) UpdateRole(ctx context.Context, id uuid.UUID, role domain.Role) (domain.Role, error) {
+	var previous domain.Role
+	err := dbFromContext(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		var admins []string
+		err := tx.Model(&pgUser{}).
+			Where("role = ? AND deleted_at IS NULL", string(domain.RoleAdmin)).
+			Clauses(clause.Locking{Strength: "UPDATE"}).
+			Pluck("id", &admins).Error
+		if err != nil {
+			return err
+		}
+
+		var current pgUser
+		err = tx.Where("id = ? AND deleted_at IS NULL", id.String()).
+			Clauses(clause.Locking{Strength: "UPDATE"}).
+			Take(&current).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return repo.ErrNotFound
+		}
+		if err != nil {
+			return err
+		}
+		previous = domain.Role(current.Role)
+
+		if previous == domain.RoleAdmin && role != domain.RoleAdmin && len(admins) <= 1 {
+			return repo.ErrLastAdmin
+		}
+		if previous == role {
+			return nil
+		}
+
+		updates := map[string]interface{}{"role": string(role)}
+		if previous.Outranks(role) {
+			updates["tokens_valid_after"] = time.Now().UTC()
+		}
+		return tx.Model(&pgUser{}).
+			Where("id = ?", id.String()).
+			Updates(updates).Error
+	})
+	if err != nil {
+		return "", err
+	}
+	return previous, nil
+}
+
+// ListIDsAfter возвращает идентификаторы активных пользователей, больших after, по возрастанию.
+func (r *UserRepository) ListIDsAfter(ctx context.Context, after uuid.UUID, limit int) ([]uuid.UUID, error) {
+	var raw []string
+	err := dbFromContext(ctx, r.db).
+		Model(&pgUser{}).
+		Where("deleted_at IS NULL AND id > ?", after.String()).
+		Order("id").
+		Limit(limit).
+		Pluck("id", &raw).Error
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]uuid.UUID, 0, len(raw))
+	for _, s := range raw {
+		id, err := uuid.Parse(s)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// ListReminderRecipients возвращает пачку пользователей с включёнными напоминаниями о тренировках.
+func (r *UserRepository) ListReminderRecipients(ctx context.Context, after uuid.UUID, limit int) ([]*domain.User, error) {
+	var models []pgUser
+	err := dbFromContext(ctx, r.db).
+		Where("reminder_time IS NOT NULL AND deleted_at IS NULL AND is_email_verified AND id > ?", after.String()).
+		Order("id").
+		Limit(limit).
+		Find(&models).Error
+	if err != nil {
+		return nil, err
+	}
+
+	users := make([]*domain.User, 0, len(models))
+	for i := range models {
+		u, err := models[i].toDomain()
+		if err != nil {
+			return nil, err
+		}
+		users = append(users, u)
+	}
+	return users, nil
+}
+
+// ListWeeklySummaryRecipients возвращает пачку пользователей, тренировавшихся начиная с since.
+func (r *UserRepository) ListWeeklySummaryRecipients(ctx context.Context, since time.Time, after uuid.UUID, limit int) ([]*domain.User, error) {
+	var models []pgUser
+	err := dbFromContext(ctx, r.db).
+		Where("deleted_at IS NULL AND is_email_verified AND id > ?", after.String()).
+		Where(`EXISTS (
+		     SELECT 1 FROM workout_sessions ws
+		      WHERE ws.user_id = users.id AND ws.finished_at IS NOT NULL AND ws.started_at >= ?
+		 )`, since).
+		Order("id").
+		Limit(limit).
+		Find(&models).Error
+	if err != nil {
+		return nil, err
+	}
+
+	users := make([]*domain.User, 0, len(models))
+	for i := range models {
+		u, err := models[i].toDomain()
+		if err != nil {
+			return nil, err
+		}
+		users = append(users, u)
+	}
+	return users, nil
+}
+
+// ListPurgeCandidates возвращает мягко удалённых пользователей, срок хранения которых истёк.
+func (r *UserRepository) ListPurgeCandidates(ctx context.Context, before time.Time, limit int) ([]uuid.UUID, error) {
+	var raw []string
+	err := dbFromContext(ctx, r.db).
+		Model(&pgUser{}).
+		Where("deleted_at < ? AND anonymized_at IS NULL AND legal_hold_at IS NULL", before).
+		Order("deleted_at, id").
+		Limit(limit).
+		Pluck("id", &raw).Error
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]uuid.UUID, 0, len(raw))
+	for _, s := range raw {
+		id, err := uuid.Parse(s)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// Count возвращает количество активных пользователей.
+func (r *UserRepository) Count(ctx context.Context) (int64, error) {
+	var count int64
+	err := dbFromContext(ctx, r.db).Model(&pgUser{}).Where("deleted_at IS NULL").Count(&count).Error
+	return count, err
+}
+
+// Update обновляет данные профиля пользователя.
+// Не обновляет защищенные поля: id, created_at, password_hash, role, is_email_verified.
 func (r *UserRepository) Update(ctx context.Context, user *domain.User) error {
 	model := fromDomain(user)
 
 	// Используем выборочное обновление для защиты критичных полей
 	updates := map[string]interface{}{
-		"email":             model.Email,
-		"username":          model.Username,
-		"first_name":        model.FirstName,
-		"last_name":         model.LastName,
-		"birth_date":        model.BirthDate,
-		"gender":            model.Gender,
-		"avatar_url":        model.AvatarURL,
-		"role":              model.Role,
-		"training_level":    model.TrainingLevel,
-		"is_email_verified": model.IsEmailVerified,
+		"email":                model.Email,
+		"email_normalized":     r.emails.Canonical(model.Email),
+		"username":             model.Username,
+		"first_name":           model.FirstName,
+		"last_name":            model.LastName,
+		"birth_date":           model.BirthDate,
+		"gender":               model.Gender,
+		"avatar_url":           model.AvatarURL,
+		"instagram":            model.Instagram,
+		"instagram_visibility": model.InstagramVisible,
+		"youtube":              model.YouTube,
+		"youtube_visibility":   model.YouTubeVisible,
+		"website":              model.Website,
+		"website_visibility":   model.WebsiteVisible,
+		"workouts_visibility":  model.WorkoutsVisible,
+		"training_level":       model.TrainingLevel,
+		"language":             model.Language,
+		"timezone":             model.Timezone,
+		"locale":               model.Locale,
+		"units":                model.Units,
+		"reminder_time":        model.ReminderTime,
+		"calorie_target":       model.CalorieTarget,
 		// updated_at обновляется на стороне БД триггером update_users_updated_at
 	}
 
-	result := r.db.WithContext(ctx).
+	result := dbFromContext(ctx, r.db).
 		Model(&pgUser{}).
 		Where("id = ? AND deleted_at IS NULL", model.ID).
 		Updates(updates)
 
 	if result.Error != nil {
 		// Проверка на нарушение уникальности при обновлении
-		if isUniqueViolation(result.Error, "idx_users_email_unique") || strings.Contains(result.Error.Error(), "idx_users_email_unique") {
+		if isUniqueViolation(result.Error, "idx_users_email_normalized_unique") || strings.Contains(result.Error.Error(), "idx_users_email_normalized_unique") {
 			return repo.ErrEmailExists
 		}
 		if isUniqueViolation(result.Error, "idx_users_username_unique") || strings.Contains(result.Error.Error(), "idx_users_username_unique") {
@@ -256,13 +642,28 @@ func (r *UserRepository) Update(ctx context.Context, user *domain.User) error {
 	return nil
 }
 
+// MarkEmailVerified отмечает email пользователя подтверждённым.
+func (r *UserRepository) MarkEmailVerified(ctx context.Context, id uuid.UUID) error {
+	result := dbFromContext(ctx, r.db).
+		Model(&pgUser{}).
+		Where("id = ? AND deleted_at IS NULL", id.String()).
+		Update("is_email_verified", true)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return repo.ErrNotFound
+	}
+	return nil
+}
+
 // SoftDelete помечает пользователя как удалённого.
 // Синхронизировано с доменным методом MarkDeleted (также обновляет updated_at).
 func (r *UserRepository) SoftDelete(ctx context.Context, id uuid.UUID) error {
 	now := time.Now().UTC()
 
 	// Обновляем deleted_at и updated_at синхронно с доменной логикой MarkDeleted
-	result := r.db.WithContext(ctx).
+	result := dbFromContext(ctx, r.db).
 		Model(&pgUser{}).
 		Where("id = ? AND deleted_at IS NULL", id.String()).
 		Updates(map[string]interface{}{
@@ -281,3 +682,58 @@ func (r *UserRepository) SoftDelete(ctx context.Context, id uuid.UUID) error {
 
 	return nil
 }
+
+// Anonymize сохраняет обезличенные данные пользователя (см. domain.User.Anonymize).
+// Обновление выполняется только для ещё не обезличенной записи без юридического удержания.
+func (r *UserRepository) Anonymize(ctx context.Context, u *domain.User) error {
+	result := dbFromContext(ctx, r.db).
+		Model(&pgUser{}).
+		Where("id = ? AND anonymized_at IS NULL AND legal_hold_at IS NULL", u.ID.String()).
+		Updates(map[string]interface{}{
+			"email":              u.Email,
+			"email_normalized":   r.emails.Canonical(u.Email),
+			"password_hash":      u.PasswordHash,
+			"username":           u.Username,
+			"first_name":         u.FirstName,
+			"last_name":          u.LastName,
+			"birth_date":         u.BirthDate,
+			"gender":             u.Gender,
+			"avatar_url":         u.AvatarURL,
+			"instagram":          u.Links.Instagram.Value,
+			"youtube":            u.Links.YouTube.Value,
+			"website":            u.Links.Website.Value,
+			"timezone":           u.Timezone,
+			"locale":             u.Locale,
+			"reminder_time":      nil,
+			"calorie_target":     nil,
+			"is_email_verified":  u.IsEmailVerified,
+			"suspended_at":       nil,
+			"suspended_until":    nil,
+			"suspension_reason":  "",
+			"tokens_valid_after": u.TokensValidAfter,
+			"deleted_at":         u.DeletedAt,
+			"anonymized_at":      u.AnonymizedAt,
+			"updated_at":         u.UpdatedAt,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return repo.ErrNotFound
+	}
+	return nil
+}
+
+// Delete окончательно удаляет запись пользователя, если на неё не установлено юридическое удержание.
+func (r *UserRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	result := dbFromContext(ctx, r.db).
+		Where("id = ? AND legal_hold_at IS NULL", id.String()).
+		Delete(&pgUser{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return repo.ErrNotFound
+	}
+	return nil
+}
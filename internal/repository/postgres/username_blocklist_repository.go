@@ -0,0 +1,120 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	domain "workout-app/internal/domain/usernameblock"
+	repo "workout-app/internal/repository/interfaces"
+	"workout-app/pkg/usernamefilter"
+)
+
+// pgUsernameBlock представляет ORM-модель для таблицы username_blocklist.
+type pgUsernameBlock struct {
+	ID        string    `gorm:"column:id;type:uuid;primaryKey"`
+	Term      string    `gorm:"column:term;type:varchar(50);not null"`
+	Mode      string    `gorm:"column:mode;type:varchar(16);not null"`
+	Category  string    `gorm:"column:category;type:varchar(16);not null"`
+	Reason    string    `gorm:"column:reason;type:text;not null"`
+	CreatedBy *string   `gorm:"column:created_by;type:uuid"`
+	CreatedAt time.Time `gorm:"column:created_at;type:timestamptz;not null"`
+}
+
+func (pgUsernameBlock) TableName() string {
+	return "username_blocklist"
+}
+
+func (m *pgUsernameBlock) toDomain() (*domain.Entry, error) {
+	id, err := uuid.Parse(m.ID)
+	if err != nil {
+		return nil, err
+	}
+	e := &domain.Entry{
+		ID:        id,
+		Term:      m.Term,
+		Mode:      usernamefilter.Mode(m.Mode),
+		Category:  usernamefilter.Category(m.Category),
+		Reason:    m.Reason,
+		CreatedAt: m.CreatedAt,
+	}
+	if m.CreatedBy != nil {
+		by, err := uuid.Parse(*m.CreatedBy)
+		if err != nil {
+			return nil, err
+		}
+		e.CreatedBy = &by
+	}
+	return e, nil
+}
+
+// UsernameBlocklistRepository реализует repo.UsernameBlocklistRepository на GORM/Postgres.
+type UsernameBlocklistRepository struct {
+	db *gorm.DB
+}
+
+// Убедимся на этапе компиляции, что структура реализует интерфейс.
+var _ repo.UsernameBlocklistRepository = (*UsernameBlocklistRepository)(nil)
+
+// NewUsernameBlocklistRepository создает новый репозиторий запрещённых username.
+func NewUsernameBlocklistRepository(db *gorm.DB) *UsernameBlocklistRepository {
+	return &UsernameBlocklistRepository{db: db}
+}
+
+// Create добавляет запись, не перезаписывая существующую с тем же словом и способом сопоставления.
+func (r *UsernameBlocklistRepository) Create(ctx context.Context, e *domain.Entry) (bool, error) {
+	model := &pgUsernameBlock{
+		ID:        e.ID.String(),
+		Term:      e.Term,
+		Mode:      string(e.Mode),
+		Category:  string(e.Category),
+		Reason:    e.Reason,
+		CreatedAt: e.CreatedAt,
+	}
+	if e.CreatedBy != nil {
+		by := e.CreatedBy.String()
+		model.CreatedBy = &by
+	}
+	result := dbFromContext(ctx, r.db).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(model)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// Delete удаляет запись.
+func (r *UsernameBlocklistRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	result := dbFromContext(ctx, r.db).
+		Where("id = ?", id.String()).
+		Delete(&pgUsernameBlock{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return repo.ErrNotFound
+	}
+	return nil
+}
+
+// List возвращает все записи, отсортированные по слову.
+func (r *UsernameBlocklistRepository) List(ctx context.Context) ([]*domain.Entry, error) {
+	var models []pgUsernameBlock
+	if err := dbFromContext(ctx, r.db).Order("term, mode").Find(&models).Error; err != nil {
+		return nil, err
+	}
+
+	entries := make([]*domain.Entry, 0, len(models))
+	for i := range models {
+		e, err := models[i].toDomain()
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, e)
+	}
+	return entries, nil
+}
@@ -0,0 +1,126 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	domain "workout-app/internal/domain/user"
+	repo "workout-app/internal/repository/interfaces"
+)
+
+// pgUsernameChange представляет ORM-модель для таблицы username_history.
+type pgUsernameChange struct {
+	ID            string    `gorm:"column:id;type:uuid;primaryKey"`
+	UserID        string    `gorm:"column:user_id;type:uuid;not null"`
+	OldUsername   string    `gorm:"column:old_username;type:varchar(50);not null"`
+	NewUsername   string    `gorm:"column:new_username;type:varchar(50);not null"`
+	ChangedAt     time.Time `gorm:"column:changed_at;type:timestamptz;not null"`
+	ReservedUntil time.Time `gorm:"column:reserved_until;type:timestamptz;not null"`
+}
+
+func (pgUsernameChange) TableName() string {
+	return "username_history"
+}
+
+func (m *pgUsernameChange) toDomain() (*domain.UsernameChange, error) {
+	id, err := uuid.Parse(m.ID)
+	if err != nil {
+		return nil, err
+	}
+	userID, err := uuid.Parse(m.UserID)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.UsernameChange{
+		ID:            id,
+		UserID:        userID,
+		OldUsername:   m.OldUsername,
+		NewUsername:   m.NewUsername,
+		ChangedAt:     m.ChangedAt,
+		ReservedUntil: m.ReservedUntil,
+	}, nil
+}
+
+// UsernameHistoryRepository реализует repo.UsernameHistoryRepository на GORM/Postgres.
+type UsernameHistoryRepository struct {
+	db *gorm.DB
+}
+
+// Убедимся на этапе компиляции, что структура реализует интерфейс.
+var _ repo.UsernameHistoryRepository = (*UsernameHistoryRepository)(nil)
+
+// NewUsernameHistoryRepository создает новый репозиторий истории смены username.
+func NewUsernameHistoryRepository(db *gorm.DB) *UsernameHistoryRepository {
+	return &UsernameHistoryRepository{db: db}
+}
+
+// Create сохраняет запись о смене username.
+func (r *UsernameHistoryRepository) Create(ctx context.Context, c *domain.UsernameChange) error {
+	return dbFromContext(ctx, r.db).Create(&pgUsernameChange{
+		ID:            c.ID.String(),
+		UserID:        c.UserID.String(),
+		OldUsername:   c.OldUsername,
+		NewUsername:   c.NewUsername,
+		ChangedAt:     c.ChangedAt,
+		ReservedUntil: c.ReservedUntil,
+	}).Error
+}
+
+// ListByUser возвращает последние смены username пользователя.
+func (r *UsernameHistoryRepository) ListByUser(ctx context.Context, userID uuid.UUID, limit int) ([]*domain.UsernameChange, error) {
+	var models []pgUsernameChange
+	err := dbFromContext(ctx, r.db).
+		Where("user_id = ?", userID.String()).
+		Order("changed_at DESC").
+		Limit(limit).
+		Find(&models).Error
+	if err != nil {
+		return nil, err
+	}
+
+	changes := make([]*domain.UsernameChange, 0, len(models))
+	for i := range models {
+		c, err := models[i].toDomain()
+		if err != nil {
+			return nil, err
+		}
+		changes = append(changes, c)
+	}
+	return changes, nil
+}
+
+// IsReserved сообщает, зарезервирован ли username за другим пользователем.
+func (r *UsernameHistoryRepository) IsReserved(ctx context.Context, username string, exceptUserID uuid.UUID, now time.Time) (bool, error) {
+	var count int64
+	err := dbFromContext(ctx, r.db).
+		Model(&pgUsernameChange{}).
+		Where("old_username = ? AND reserved_until > ? AND user_id <> ?", username, now, exceptUserID.String()).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// GetLatestByOldUsername возвращает последнюю смену активного пользователя со старым именем username.
+func (r *UsernameHistoryRepository) GetLatestByOldUsername(ctx context.Context, username string) (*domain.UsernameChange, error) {
+	var model pgUsernameChange
+	err := dbFromContext(ctx, r.db).
+		Where("old_username = ?", username).
+		Where("EXISTS (SELECT 1 FROM users u WHERE u.id = username_history.user_id AND u.deleted_at IS NULL)").
+		Order("changed_at DESC").
+		Take(&model).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, repo.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return model.toDomain()
+}
+
+// DeleteByUserID удаляет историю пользователя.
+func (r *UsernameHistoryRepository) DeleteByUserID(ctx context.Context, userID uuid.UUID) error {
+	return dbFromContext(ctx, r.db).Where("user_id = ?", userID.String()).Delete(&pgUsernameChange{}).Error
+}
@@ -0,0 +1,152 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	domain "workout-app/internal/domain/video"
+	repo "workout-app/internal/repository/interfaces"
+)
+
+// pgVideo представляет ORM-модель для таблицы exercise_videos.
+type pgVideo struct {
+	ID          string    `gorm:"column:id;type:uuid;primaryKey"`
+	OwnerID     string    `gorm:"column:owner_id;type:uuid;not null"`
+	Title       string    `gorm:"column:title;type:varchar(200);not null"`
+	StorageKey  string    `gorm:"column:storage_key;type:varchar(512);not null"`
+	ContentType string    `gorm:"column:content_type;type:varchar(64);not null"`
+	SizeBytes   int64     `gorm:"column:size_bytes;not null"`
+	Status      string    `gorm:"column:status;type:varchar(16);not null"`
+	CreatedAt   time.Time `gorm:"column:created_at;type:timestamptz;not null"`
+	UpdatedAt   time.Time `gorm:"column:updated_at;type:timestamptz;not null"`
+}
+
+func (pgVideo) TableName() string {
+	return "exercise_videos"
+}
+
+func newPgVideo(v *domain.Video) *pgVideo {
+	return &pgVideo{
+		ID:          v.ID.String(),
+		OwnerID:     v.OwnerID.String(),
+		Title:       v.Title,
+		StorageKey:  v.StorageKey,
+		ContentType: v.ContentType,
+		SizeBytes:   v.SizeBytes,
+		Status:      string(v.Status),
+		CreatedAt:   v.CreatedAt,
+		UpdatedAt:   v.UpdatedAt,
+	}
+}
+
+func (m *pgVideo) toDomain() (*domain.Video, error) {
+	id, err := uuid.Parse(m.ID)
+	if err != nil {
+		return nil, err
+	}
+	ownerID, err := uuid.Parse(m.OwnerID)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.Video{
+		ID:          id,
+		OwnerID:     ownerID,
+		Title:       m.Title,
+		StorageKey:  m.StorageKey,
+		ContentType: m.ContentType,
+		SizeBytes:   m.SizeBytes,
+		Status:      domain.Status(m.Status),
+		CreatedAt:   m.CreatedAt,
+		UpdatedAt:   m.UpdatedAt,
+	}, nil
+}
+
+// VideoRepository реализует repo.VideoRepository на GORM/Postgres.
+type VideoRepository struct {
+	db *gorm.DB
+}
+
+// Убедимся на этапе компиляции, что структура реализует интерфейс.
+var _ repo.VideoRepository = (*VideoRepository)(nil)
+
+// NewVideoRepository создает новый репозиторий видео упражнений.
+func NewVideoRepository(db *gorm.DB) *VideoRepository {
+	return &VideoRepository{db: db}
+}
+
+// Create сохраняет новое видео.
+func (r *VideoRepository) Create(ctx context.Context, v *domain.Video) error {
+	return dbFromContext(ctx, r.db).Create(newPgVideo(v)).Error
+}
+
+// GetByID возвращает видео по ID.
+func (r *VideoRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Video, error) {
+	var model pgVideo
+	if err := dbFromContext(ctx, r.db).Where("id = ?", id.String()).First(&model).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, repo.ErrNotFound
+		}
+		return nil, err
+	}
+	return model.toDomain()
+}
+
+// Update сохраняет изменения видео.
+func (r *VideoRepository) Update(ctx context.Context, v *domain.Video) error {
+	result := dbFromContext(ctx, r.db).
+		Model(&pgVideo{}).
+		Where("id = ?", v.ID.String()).
+		Updates(map[string]any{
+			"title":        v.Title,
+			"storage_key":  v.StorageKey,
+			"content_type": v.ContentType,
+			"size_bytes":   v.SizeBytes,
+			"status":       string(v.Status),
+			"updated_at":   v.UpdatedAt,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return repo.ErrNotFound
+	}
+	return nil
+}
+
+// Delete удаляет видео.
+func (r *VideoRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	result := dbFromContext(ctx, r.db).Where("id = ?", id.String()).Delete(&pgVideo{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return repo.ErrNotFound
+	}
+	return nil
+}
+
+// ListByOwner возвращает видео пользователя, новые первыми.
+func (r *VideoRepository) ListByOwner(ctx context.Context, ownerID uuid.UUID) ([]*domain.Video, error) {
+	var models []pgVideo
+	err := dbFromContext(ctx, r.db).
+		Where("owner_id = ?", ownerID.String()).
+		Order("created_at DESC").
+		Find(&models).Error
+	if err != nil {
+		return nil, err
+	}
+
+	videos := make([]*domain.Video, 0, len(models))
+	for i := range models {
+		v, err := models[i].toDomain()
+		if err != nil {
+			return nil, err
+		}
+		videos = append(videos, v)
+	}
+	return videos, nil
+}
@@ -0,0 +1,395 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	domain "workout-app/internal/domain/webhook"
+	repo "workout-app/internal/repository/interfaces"
+	"workout-app/pkg/secretbox"
+)
+
+// pgWebhookEndpoint представляет ORM-модель для таблицы webhook_endpoints.
+type pgWebhookEndpoint struct {
+	ID              string    `gorm:"column:id;type:uuid;primaryKey"`
+	URL             string    `gorm:"column:url;type:varchar(2048);not null"`
+	SecretEncrypted []byte    `gorm:"column:secret_encrypted;type:bytea;not null"`
+	EventTypes      string    `gorm:"column:event_types;type:jsonb;not null"`
+	Active          bool      `gorm:"column:active;type:boolean;not null"`
+	Description     string    `gorm:"column:description;type:varchar(255);not null"`
+	CreatedBy       *string   `gorm:"column:created_by;type:uuid"`
+	CreatedAt       time.Time `gorm:"column:created_at;type:timestamptz;not null"`
+	UpdatedAt       time.Time `gorm:"column:updated_at;type:timestamptz;not null"`
+}
+
+func (pgWebhookEndpoint) TableName() string {
+	return "webhook_endpoints"
+}
+
+// WebhookEndpointRepository реализует repo.WebhookEndpointRepository на GORM/Postgres.
+// Ключ подписи шифруется при записи и расшифровывается при чтении.
+type WebhookEndpointRepository struct {
+	db  *gorm.DB
+	box *secretbox.Box
+}
+
+// Убедимся на этапе компиляции, что структура реализует интерфейс.
+var _ repo.WebhookEndpointRepository = (*WebhookEndpointRepository)(nil)
+
+// NewWebhookEndpointRepository создает новый репозиторий адресов webhooks.
+func NewWebhookEndpointRepository(db *gorm.DB, box *secretbox.Box) *WebhookEndpointRepository {
+	return &WebhookEndpointRepository{db: db, box: box}
+}
+
+func (r *WebhookEndpointRepository) toModel(e *domain.Endpoint) (*pgWebhookEndpoint, error) {
+	sealed, err := r.box.Seal([]byte(e.Secret))
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt webhook secret: %w", err)
+	}
+	eventTypes, err := json.Marshal(nonNil(e.EventTypes))
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode event types: %w", err)
+	}
+	model := &pgWebhookEndpoint{
+		ID:              e.ID.String(),
+		URL:             e.URL,
+		SecretEncrypted: sealed,
+		EventTypes:      string(eventTypes),
+		Active:          e.Active,
+		Description:     e.Description,
+		CreatedAt:       e.CreatedAt,
+		UpdatedAt:       e.UpdatedAt,
+	}
+	if e.CreatedBy != nil {
+		by := e.CreatedBy.String()
+		model.CreatedBy = &by
+	}
+	return model, nil
+}
+
+func (r *WebhookEndpointRepository) toDomain(m *pgWebhookEndpoint) (*domain.Endpoint, error) {
+	id, err := uuid.Parse(m.ID)
+	if err != nil {
+		return nil, err
+	}
+	secret, err := r.box.Open(m.SecretEncrypted)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt secret of webhook %s: %w", m.ID, err)
+	}
+	e := &domain.Endpoint{
+		ID:          id,
+		URL:         m.URL,
+		Secret:      string(secret),
+		Active:      m.Active,
+		Description: m.Description,
+		CreatedAt:   m.CreatedAt,
+		UpdatedAt:   m.UpdatedAt,
+	}
+	if err := json.Unmarshal([]byte(m.EventTypes), &e.EventTypes); err != nil {
+		return nil, fmt.Errorf("failed to decode event types: %w", err)
+	}
+	if m.CreatedBy != nil {
+		by, err := uuid.Parse(*m.CreatedBy)
+		if err != nil {
+			return nil, err
+		}
+		e.CreatedBy = &by
+	}
+	return e, nil
+}
+
+// Create сохраняет новый адрес.
+func (r *WebhookEndpointRepository) Create(ctx context.Context, e *domain.Endpoint) error {
+	model, err := r.toModel(e)
+	if err != nil {
+		return err
+	}
+	return dbFromContext(ctx, r.db).Create(model).Error
+}
+
+// Update сохраняет изменения адреса.
+func (r *WebhookEndpointRepository) Update(ctx context.Context, e *domain.Endpoint) error {
+	model, err := r.toModel(e)
+	if err != nil {
+		return err
+	}
+	result := dbFromContext(ctx, r.db).
+		Model(&pgWebhookEndpoint{}).
+		Where("id = ?", model.ID).
+		Updates(map[string]any{
+			"url":              model.URL,
+			"secret_encrypted": model.SecretEncrypted,
+			"event_types":      model.EventTypes,
+			"active":           model.Active,
+			"description":      model.Description,
+			"updated_at":       model.UpdatedAt,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return repo.ErrNotFound
+	}
+	return nil
+}
+
+// Delete удаляет адрес; уведомления удаляются каскадно.
+func (r *WebhookEndpointRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	result := dbFromContext(ctx, r.db).Where("id = ?", id.String()).Delete(&pgWebhookEndpoint{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return repo.ErrNotFound
+	}
+	return nil
+}
+
+// GetByID возвращает адрес по ID.
+func (r *WebhookEndpointRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Endpoint, error) {
+	var model pgWebhookEndpoint
+	if err := dbFromContext(ctx, r.db).Where("id = ?", id.String()).First(&model).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, repo.ErrNotFound
+		}
+		return nil, err
+	}
+	return r.toDomain(&model)
+}
+
+// List возвращает все адреса, старые первыми.
+func (r *WebhookEndpointRepository) List(ctx context.Context) ([]*domain.Endpoint, error) {
+	return r.list(dbFromContext(ctx, r.db).Order("created_at, id"))
+}
+
+// ListSubscribed возвращает включённые адреса, подписанные на событие.
+func (r *WebhookEndpointRepository) ListSubscribed(ctx context.Context, eventType string) ([]*domain.Endpoint, error) {
+	return r.list(dbFromContext(ctx, r.db).
+		Where("active AND event_types @> ?::jsonb", jsonArray(eventType)).
+		Order("created_at, id"))
+}
+
+func (r *WebhookEndpointRepository) list(query *gorm.DB) ([]*domain.Endpoint, error) {
+	var models []pgWebhookEndpoint
+	if err := query.Find(&models).Error; err != nil {
+		return nil, err
+	}
+
+	endpoints := make([]*domain.Endpoint, 0, len(models))
+	for i := range models {
+		e, err := r.toDomain(&models[i])
+		if err != nil {
+			return nil, err
+		}
+		endpoints = append(endpoints, e)
+	}
+	return endpoints, nil
+}
+
+// pgWebhookDelivery представляет ORM-модель для таблицы webhook_deliveries.
+type pgWebhookDelivery struct {
+	ID             int64      `gorm:"column:id;primaryKey;autoIncrement"`
+	EndpointID     string     `gorm:"column:endpoint_id;type:uuid;not null"`
+	EventID        int64      `gorm:"column:event_id;not null"`
+	EventType      string     `gorm:"column:event_type;type:varchar(64);not null"`
+	Body           string     `gorm:"column:body;type:jsonb;not null"`
+	Status         string     `gorm:"column:status;type:varchar(16);not null"`
+	Attempts       int        `gorm:"column:attempts;not null"`
+	NextAttemptAt  time.Time  `gorm:"column:next_attempt_at;type:timestamptz;not null"`
+	LastError      string     `gorm:"column:last_error;type:text;not null"`
+	LastStatusCode int        `gorm:"column:last_status_code;not null"`
+	TraceParent    string     `gorm:"column:trace_parent;type:varchar(55);not null"`
+	CreatedAt      time.Time  `gorm:"column:created_at;type:timestamptz;not null"`
+	FinishedAt     *time.Time `gorm:"column:finished_at;type:timestamptz"`
+}
+
+func (pgWebhookDelivery) TableName() string {
+	return "webhook_deliveries"
+}
+
+func (m *pgWebhookDelivery) toDomain() (*domain.Delivery, error) {
+	endpointID, err := uuid.Parse(m.EndpointID)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.Delivery{
+		ID:             m.ID,
+		EndpointID:     endpointID,
+		EventID:        m.EventID,
+		EventType:      m.EventType,
+		Body:           []byte(m.Body),
+		Status:         domain.Status(m.Status),
+		Attempts:       m.Attempts,
+		NextAttemptAt:  m.NextAttemptAt,
+		LastError:      m.LastError,
+		LastStatusCode: m.LastStatusCode,
+		TraceParent:    m.TraceParent,
+		CreatedAt:      m.CreatedAt,
+		FinishedAt:     m.FinishedAt,
+	}, nil
+}
+
+// WebhookDeliveryRepository реализует repo.WebhookDeliveryRepository на GORM/Postgres.
+type WebhookDeliveryRepository struct {
+	db *gorm.DB
+}
+
+// Убедимся на этапе компиляции, что структура реализует интерфейс.
+var _ repo.WebhookDeliveryRepository = (*WebhookDeliveryRepository)(nil)
+
+// NewWebhookDeliveryRepository создает новый репозиторий очереди уведомлений webhooks.
+func NewWebhookDeliveryRepository(db *gorm.DB) *WebhookDeliveryRepository {
+	return &WebhookDeliveryRepository{db: db}
+}
+
+// Enqueue сохраняет уведомление; повтор события для того же адреса игнорируется.
+func (r *WebhookDeliveryRepository) Enqueue(ctx context.Context, d *domain.Delivery) (bool, error) {
+	model := &pgWebhookDelivery{
+		EndpointID:    d.EndpointID.String(),
+		EventID:       d.EventID,
+		EventType:     d.EventType,
+		Body:          string(d.Body),
+		Status:        string(d.Status),
+		Attempts:      d.Attempts,
+		NextAttemptAt: d.NextAttemptAt,
+		LastError:     d.LastError,
+		TraceParent:   d.TraceParent,
+		CreatedAt:     d.CreatedAt,
+	}
+	result := dbFromContext(ctx, r.db).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "endpoint_id"}, {Name: "event_id"}},
+			DoNothing: true,
+		}).
+		Create(model)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return false, nil
+	}
+	d.ID = model.ID
+	return true, nil
+}
+
+// ListDue возвращает уведомления, время попытки которых наступило, старые первыми.
+func (r *WebhookDeliveryRepository) ListDue(ctx context.Context, now time.Time, limit int) ([]*domain.Delivery, error) {
+	return r.list(dbFromContext(ctx, r.db).
+		Where("status = ? AND next_attempt_at <= ?", string(domain.StatusPending), now).
+		Order("next_attempt_at, id").
+		Limit(limit))
+}
+
+// Claim захватывает отправку уведомления до момента until.
+func (r *WebhookDeliveryRepository) Claim(ctx context.Context, id int64, now, until time.Time) (bool, error) {
+	result := dbFromContext(ctx, r.db).
+		Model(&pgWebhookDelivery{}).
+		Where("id = ? AND status = ? AND next_attempt_at <= ?", id, string(domain.StatusPending), now).
+		Update("next_attempt_at", until)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+// Save сохраняет результат попытки отправки.
+func (r *WebhookDeliveryRepository) Save(ctx context.Context, d *domain.Delivery) error {
+	result := dbFromContext(ctx, r.db).
+		Model(&pgWebhookDelivery{}).
+		Where("id = ?", d.ID).
+		Updates(map[string]any{
+			"status":           string(d.Status),
+			"attempts":         d.Attempts,
+			"next_attempt_at":  d.NextAttemptAt,
+			"last_error":       d.LastError,
+			"last_status_code": d.LastStatusCode,
+			"finished_at":      d.FinishedAt,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return repo.ErrNotFound
+	}
+	return nil
+}
+
+// GetByID возвращает уведомление по ID.
+func (r *WebhookDeliveryRepository) GetByID(ctx context.Context, id int64) (*domain.Delivery, error) {
+	var model pgWebhookDelivery
+	if err := dbFromContext(ctx, r.db).Where("id = ?", id).First(&model).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, repo.ErrNotFound
+		}
+		return nil, err
+	}
+	return model.toDomain()
+}
+
+// List возвращает страницу уведомлений адреса, новые первыми.
+func (r *WebhookDeliveryRepository) List(ctx context.Context, endpointID uuid.UUID, status domain.Status, limit, offset int) ([]*domain.Delivery, int64, error) {
+	// Отдельные цепочки для подсчёта и выборки: GORM не переиспользует запрос после Count.
+	filtered := func() *gorm.DB {
+		query := dbFromContext(ctx, r.db).
+			Model(&pgWebhookDelivery{}).
+			Where("endpoint_id = ?", endpointID.String())
+		if status != "" {
+			query = query.Where("status = ?", string(status))
+		}
+		return query
+	}
+
+	var total int64
+	if err := filtered().Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	items, err := r.list(filtered().Order("id DESC").Limit(limit).Offset(offset))
+	if err != nil {
+		return nil, 0, err
+	}
+	return items, total, nil
+}
+
+// CountPending возвращает количество уведомлений в очереди.
+func (r *WebhookDeliveryRepository) CountPending(ctx context.Context) (int64, error) {
+	var count int64
+	err := dbFromContext(ctx, r.db).
+		Model(&pgWebhookDelivery{}).
+		Where("status = ?", string(domain.StatusPending)).
+		Count(&count).Error
+	return count, err
+}
+
+// DeleteFinished удаляет завершённые уведомления пачкой не больше limit.
+func (r *WebhookDeliveryRepository) DeleteFinished(ctx context.Context, before time.Time, limit int) (int64, error) {
+	result := dbFromContext(ctx, r.db).Exec(
+		`DELETE FROM webhook_deliveries
+		 WHERE id IN (SELECT id FROM webhook_deliveries WHERE finished_at < ? ORDER BY id LIMIT ?)`,
+		before, limit,
+	)
+	return result.RowsAffected, result.Error
+}
+
+func (r *WebhookDeliveryRepository) list(query *gorm.DB) ([]*domain.Delivery, error) {
+	var models []pgWebhookDelivery
+	if err := query.Find(&models).Error; err != nil {
+		return nil, err
+	}
+
+	deliveries := make([]*domain.Delivery, 0, len(models))
+	for i := range models {
+		d, err := models[i].toDomain()
+		if err != nil {
+			return nil, err
+		}
+		deliveries = append(deliveries, d)
+	}
+	return deliveries, nil
+}
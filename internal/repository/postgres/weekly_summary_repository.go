@@ -0,0 +1,63 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	domain "workout-app/internal/domain/weeklysummary"
+	repo "workout-app/internal/repository/interfaces"
+)
+
+// pgWeeklySummary представляет ORM-модель для таблицы weekly_summaries.
+type pgWeeklySummary struct {
+	UserID    string    `gorm:"column:user_id;type:uuid;primaryKey"`
+	WeekStart time.Time `gorm:"column:week_start;type:date;primaryKey"`
+	CreatedAt time.Time `gorm:"column:created_at;type:timestamptz;not null"`
+}
+
+func (pgWeeklySummary) TableName() string {
+	return "weekly_summaries"
+}
+
+// WeeklySummaryRepository реализует repo.WeeklySummaryRepository на GORM/Postgres.
+type WeeklySummaryRepository struct {
+	db *gorm.DB
+}
+
+// Убедимся на этапе компиляции, что структура реализует интерфейс.
+var _ repo.WeeklySummaryRepository = (*WeeklySummaryRepository)(nil)
+
+// NewWeeklySummaryRepository создает новый репозиторий отметок еженедельных сводок.
+func NewWeeklySummaryRepository(db *gorm.DB) *WeeklySummaryRepository {
+	return &WeeklySummaryRepository{db: db}
+}
+
+// Claim сохраняет отметку, если за эту неделю её ещё нет.
+func (r *WeeklySummaryRepository) Claim(ctx context.Context, s *domain.Summary) (bool, error) {
+	result := dbFromContext(ctx, r.db).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&pgWeeklySummary{
+			UserID:    s.UserID.String(),
+			WeekStart: s.WeekStart,
+			CreatedAt: s.CreatedAt,
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// DeleteBefore удаляет не более limit отметок за недели раньше before.
+func (r *WeeklySummaryRepository) DeleteBefore(ctx context.Context, before time.Time, limit int) (int64, error) {
+	result := dbFromContext(ctx, r.db).Exec(
+		`DELETE FROM weekly_summaries
+		 WHERE (user_id, week_start) IN (
+		     SELECT user_id, week_start FROM weekly_summaries WHERE week_start < ? ORDER BY week_start LIMIT ?
+		 )`,
+		before, limit,
+	)
+	return result.RowsAffected, result.Error
+}
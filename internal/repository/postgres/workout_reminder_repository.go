@@ -0,0 +1,63 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	domain "workout-app/internal/domain/reminder"
+	repo "workout-app/internal/repository/interfaces"
+)
+
+// pgWorkoutReminder представляет ORM-модель для таблицы workout_reminders.
+type pgWorkoutReminder struct {
+	UserID    string    `gorm:"column:user_id;type:uuid;primaryKey"`
+	LocalDate time.Time `gorm:"column:local_date;type:date;primaryKey"`
+	CreatedAt time.Time `gorm:"column:created_at;type:timestamptz;not null"`
+}
+
+func (pgWorkoutReminder) TableName() string {
+	return "workout_reminders"
+}
+
+// WorkoutReminderRepository реализует repo.WorkoutReminderRepository на GORM/Postgres.
+type WorkoutReminderRepository struct {
+	db *gorm.DB
+}
+
+// Убедимся на этапе компиляции, что структура реализует интерфейс.
+var _ repo.WorkoutReminderRepository = (*WorkoutReminderRepository)(nil)
+
+// NewWorkoutReminderRepository создает новый репозиторий отметок напоминаний о тренировках.
+func NewWorkoutReminderRepository(db *gorm.DB) *WorkoutReminderRepository {
+	return &WorkoutReminderRepository{db: db}
+}
+
+// Claim сохраняет отметку, если за этот день её ещё нет.
+func (r *WorkoutReminderRepository) Claim(ctx context.Context, rem *domain.Reminder) (bool, error) {
+	result := dbFromContext(ctx, r.db).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&pgWorkoutReminder{
+			UserID:    rem.UserID.String(),
+			LocalDate: rem.Date,
+			CreatedAt: rem.CreatedAt,
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// DeleteBefore удаляет не более limit отметок за дни раньше before.
+func (r *WorkoutReminderRepository) DeleteBefore(ctx context.Context, before time.Time, limit int) (int64, error) {
+	result := dbFromContext(ctx, r.db).Exec(
+		`DELETE FROM workout_reminders
+		 WHERE (user_id, local_date) IN (
+		     SELECT user_id, local_date FROM workout_reminders WHERE local_date < ? ORDER BY local_date LIMIT ?
+		 )`,
+		before, limit,
+	)
+	return result.RowsAffected, result.Error
+}
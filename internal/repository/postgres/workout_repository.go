@@ -0,0 +1,331 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	domain "workout-app/internal/domain/workout"
+	repo "workout-app/internal/repository/interfaces"
+)
+
+// pgWorkoutSession представляет ORM-модель для таблицы workout_sessions.
+type pgWorkoutSession struct {
+	ID                    string     `gorm:"column:id;type:uuid;primaryKey"`
+	UserID                string     `gorm:"column:user_id;type:uuid;not null"`
+	AssignmentID          *string    `gorm:"column:assignment_id;type:uuid"`
+	Title                 string     `gorm:"column:title;type:varchar(200);not null"`
+	AutoPauseAfterSeconds int        `gorm:"column:auto_pause_after_seconds;not null"`
+	StartedAt             time.Time  `gorm:"column:started_at;type:timestamptz;not null"`
+	FinishedAt            *time.Time `gorm:"column:finished_at;type:timestamptz"`
+	Difficulty            *int       `gorm:"column:difficulty;type:smallint"`
+	DifficultyRatedAt     *time.Time `gorm:"column:difficulty_rated_at;type:timestamptz"`
+	Plan                  *string    `gorm:"column:plan;type:jsonb"`
+}
+
+func (pgWorkoutSession) TableName() string {
+	return "workout_sessions"
+}
+
+// pgPlannedExercise — JSON-представление упражнения плана в колонке plan.
+type pgPlannedExercise struct {
+	Exercise    string   `json:"exercise"`
+	Sets        int      `json:"sets"`
+	Reps        int      `json:"reps"`
+	WeightKg    *float64 `json:"weight_kg,omitempty"`
+	Tempo       string   `json:"tempo,omitempty"`
+	RestSeconds []int    `json:"rest_seconds,omitempty"`
+}
+
+// pgWorkoutSet представляет ORM-модель для таблицы workout_sets.
+type pgWorkoutSet struct {
+	ID          string    `gorm:"column:id;type:uuid;primaryKey"`
+	SessionID   string    `gorm:"column:session_id;type:uuid;not null"`
+	Exercise    string    `gorm:"column:exercise;type:varchar(100);not null"`
+	Reps        int       `gorm:"column:reps;not null"`
+	WeightKg    *float64  `gorm:"column:weight_kg;type:numeric(6,2)"`
+	LoggedAt    time.Time `gorm:"column:logged_at;type:timestamptz;not null"`
+	Implausible bool      `gorm:"column:implausible;not null;default:false"`
+}
+
+func (pgWorkoutSet) TableName() string {
+	return "workout_sets"
+}
+
+func (m *pgWorkoutSession) toDomain() (*domain.Session, error) {
+	id, err := uuid.Parse(m.ID)
+	if err != nil {
+		return nil, err
+	}
+	userID, err := uuid.Parse(m.UserID)
+	if err != nil {
+		return nil, err
+	}
+	s := &domain.Session{
+		ID:                id,
+		UserID:            userID,
+		Title:             m.Title,
+		AutoPauseAfter:    time.Duration(m.AutoPauseAfterSeconds) * time.Second,
+		StartedAt:         m.StartedAt,
+		FinishedAt:        m.FinishedAt,
+		Sets:              []domain.Set{},
+		Difficulty:        m.Difficulty,
+		DifficultyRatedAt: m.DifficultyRatedAt,
+	}
+	if m.AssignmentID != nil {
+		assignmentID, err := uuid.Parse(*m.AssignmentID)
+		if err != nil {
+			return nil, err
+		}
+		s.AssignmentID = &assignmentID
+	}
+	if m.Plan != nil {
+		var raw []pgPlannedExercise
+		if err := json.Unmarshal([]byte(*m.Plan), &raw); err != nil {
+			return nil, fmt.Errorf("failed to decode workout plan: %w", err)
+		}
+		for _, e := range raw {
+			s.Plan = append(s.Plan, domain.PlannedExercise{
+				Exercise:    e.Exercise,
+				Sets:        e.Sets,
+				Reps:        e.Reps,
+				WeightKg:    e.WeightKg,
+				Tempo:       e.Tempo,
+				RestSeconds: e.RestSeconds,
+			})
+		}
+	}
+	return s, nil
+}
+
+func (m *pgWorkoutSet) toDomain() (domain.Set, error) {
+	id, err := uuid.Parse(m.ID)
+	if err != nil {
+		return domain.Set{}, err
+	}
+	sessionID, err := uuid.Parse(m.SessionID)
+	if err != nil {
+		return domain.Set{}, err
+	}
+	return domain.Set{
+		ID:          id,
+		SessionID:   sessionID,
+		Exercise:    m.Exercise,
+		Reps:        m.Reps,
+		WeightKg:    m.WeightKg,
+		LoggedAt:    m.LoggedAt,
+		Implausible: m.Implausible,
+	}, nil
+}
+
+// WorkoutSessionRepository реализует repo.WorkoutSessionRepository на GORM/Postgres.
+type WorkoutSessionRepository struct {
+	db *gorm.DB
+}
+
+// Убедимся на этапе компиляции, что структура реализует интерфейс.
+var _ repo.WorkoutSessionRepository = (*WorkoutSessionRepository)(nil)
+
+// NewWorkoutSessionRepository создает новый репозиторий тренировок.
+func NewWorkoutSessionRepository(db *gorm.DB) *WorkoutSessionRepository {
+	return &WorkoutSessionRepository{db: db}
+}
+
+// Create сохраняет начатую тренировку.
+func (r *WorkoutSessionRepository) Create(ctx context.Context, s *domain.Session) error {
+	model := &pgWorkoutSession{
+		ID:                    s.ID.String(),
+		UserID:                s.UserID.String(),
+		Title:                 s.Title,
+		AutoPauseAfterSeconds: int(s.AutoPauseAfter / time.Second),
+		StartedAt:             s.StartedAt,
+		FinishedAt:            s.FinishedAt,
+	}
+	if s.AssignmentID != nil {
+		assignmentID := s.AssignmentID.String()
+		model.AssignmentID = &assignmentID
+	}
+	if len(s.Plan) > 0 {
+		raw := make([]pgPlannedExercise, 0, len(s.Plan))
+		for _, e := range s.Plan {
+			raw = append(raw, pgPlannedExercise{
+				Exercise:    e.Exercise,
+				Sets:        e.Sets,
+				Reps:        e.Reps,
+				WeightKg:    e.WeightKg,
+				Tempo:       e.Tempo,
+				RestSeconds: e.RestSeconds,
+			})
+		}
+		plan, err := json.Marshal(raw)
+		if err != nil {
+			return fmt.Errorf("failed to encode workout plan: %w", err)
+		}
+		encoded := string(plan)
+		model.Plan = &encoded
+	}
+	if err := dbFromContext(ctx, r.db).Create(model).Error; err != nil {
+		if isUniqueViolation(err, "idx_workout_sessions_one_active") {
+			return repo.ErrActiveSessionExists
+		}
+		return err
+	}
+	return nil
+}
+
+// GetByID возвращает тренировку с подходами.
+func (r *WorkoutSessionRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Session, error) {
+	return r.getOne(ctx, dbFromContext(ctx, r.db).Where("id = ?", id.String()))
+}
+
+// GetActive возвращает идущую тренировку пользователя.
+func (r *WorkoutSessionRepository) GetActive(ctx context.Context, userID uuid.UUID) (*domain.Session, error) {
+	return r.getOne(ctx, dbFromContext(ctx, r.db).Where("user_id = ? AND finished_at IS NULL", userID.String()))
+}
+
+// AddSet сохраняет подход тренировки.
+func (r *WorkoutSessionRepository) AddSet(ctx context.Context, set *domain.Set) error {
+	return dbFromContext(ctx, r.db).Create(&pgWorkoutSet{
+		ID:          set.ID.String(),
+		SessionID:   set.SessionID.String(),
+		Exercise:    set.Exercise,
+		Reps:        set.Reps,
+		WeightKg:    set.WeightKg,
+		LoggedAt:    set.LoggedAt,
+		Implausible: set.Implausible,
+	}).Error
+}
+
+// Finish завершает идущую тренировку.
+func (r *WorkoutSessionRepository) Finish(ctx context.Context, id uuid.UUID, at time.Time) error {
+	result := dbFromContext(ctx, r.db).
+		Model(&pgWorkoutSession{}).
+		Where("id = ? AND finished_at IS NULL", id.String()).
+		Update("finished_at", at)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return repo.ErrNotFound
+	}
+	return nil
+}
+
+// SetDifficulty сохраняет оценку сложности завершённой тренировки.
+func (r *WorkoutSessionRepository) SetDifficulty(ctx context.Context, id uuid.UUID, difficulty int, at time.Time) error {
+	result := dbFromContext(ctx, r.db).
+		Model(&pgWorkoutSession{}).
+		Where("id = ? AND finished_at IS NOT NULL", id.String()).
+		Updates(map[string]any{"difficulty": difficulty, "difficulty_rated_at": at})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return repo.ErrNotFound
+	}
+	return nil
+}
+
+// ListByUser возвращает тренировки пользователя за период, новые первыми.
+func (r *WorkoutSessionRepository) ListByUser(ctx context.Context, userID uuid.UUID, from, to time.Time, limit int) ([]*domain.Session, error) {
+	var models []pgWorkoutSession
+	err := dbFromContext(ctx, r.db).
+		Where("user_id = ? AND started_at >= ? AND started_at < ?", userID.String(), from, to).
+		Order("started_at DESC").
+		Limit(limit).
+		Find(&models).Error
+	if err != nil {
+		return nil, err
+	}
+	return r.withSets(ctx, models)
+}
+
+// ListWeightedSets возвращает подходы пользователя с весом и не более maxReps повторений;
+// неправдоподобные подходы не возвращаются.
+func (r *WorkoutSessionRepository) ListWeightedSets(ctx context.Context, userID uuid.UUID, maxReps int) ([]domain.Set, error) {
+	var models []pgWorkoutSet
+	err := dbFromContext(ctx, r.db).
+		Joins("JOIN workout_sessions ON workout_sessions.id = workout_sets.session_id").
+		Where("workout_sessions.user_id = ? AND workout_sets.weight_kg IS NOT NULL AND workout_sets.reps BETWEEN 1 AND ? AND NOT workout_sets.implausible", userID.String(), maxReps).
+		Order("workout_sets.logged_at, workout_sets.id").
+		Find(&models).Error
+	if err != nil {
+		return nil, err
+	}
+
+	sets := make([]domain.Set, 0, len(models))
+	for i := range models {
+		set, err := models[i].toDomain()
+		if err != nil {
+			return nil, err
+		}
+		sets = append(sets, set)
+	}
+	return sets, nil
+}
+
+// DeleteByUserID удаляет тренировки пользователя; подходы удаляются каскадно.
+func (r *WorkoutSessionRepository) DeleteByUserID(ctx context.Context, userID uuid.UUID) error {
+	return dbFromContext(ctx, r.db).
+		Where("user_id = ?", userID.String()).
+		Delete(&pgWorkoutSession{}).Error
+}
+
+// getOne выполняет запрос одной тренировки и подгружает её подходы.
+func (r *WorkoutSessionRepository) getOne(ctx context.Context, query *gorm.DB) (*domain.Session, error) {
+	var model pgWorkoutSession
+	if err := query.Take(&model).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, repo.ErrNotFound
+		}
+		return nil, err
+	}
+	sessions, err := r.withSets(ctx, []pgWorkoutSession{model})
+	if err != nil {
+		return nil, err
+	}
+	return sessions[0], nil
+}
+
+// withSets собирает тренировки вместе с подходами одним дополнительным запросом.
+func (r *WorkoutSessionRepository) withSets(ctx context.Context, models []pgWorkoutSession) ([]*domain.Session, error) {
+	sessions := make([]*domain.Session, 0, len(models))
+	if len(models) == 0 {
+		return sessions, nil
+	}
+
+	ids := make([]string, 0, len(models))
+	byID := make(map[string]*domain.Session, len(models))
+	for i := range models {
+		s, err := models[i].toDomain()
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, models[i].ID)
+		byID[models[i].ID] = s
+		sessions = append(sessions, s)
+	}
+
+	var sets []pgWorkoutSet
+	err := dbFromContext(ctx, r.db).
+		Where("session_id IN ?", ids).
+		Order("session_id, logged_at, id").
+		Find(&sets).Error
+	if err != nil {
+		return nil, err
+	}
+	for i := range sets {
+		set, err := sets[i].toDomain()
+		if err != nil {
+			return nil, err
+		}
+		s := byID[sets[i].SessionID]
+		s.Sets = append(s.Sets, set)
+	}
+	return sessions, nil
+}
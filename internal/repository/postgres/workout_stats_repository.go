@@ -0,0 +1,217 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	domain "workout-app/internal/domain/workout"
+	repo "workout-app/internal/repository/interfaces"
+)
+
+// WorkoutStatsRepository реализует repo.WorkoutStatsRepository агрегирующими SQL-запросами:
+// подходы и тренировки суммируются в Postgres, в приложение попадают только итоги.
+// Неправдоподобные подходы (workout_sets.implausible) в статистику не входят.
+type WorkoutStatsRepository struct {
+	db *gorm.DB
+}
+
+// Убедимся на этапе компиляции, что структура реализует интерфейс.
+var _ repo.WorkoutStatsRepository = (*WorkoutStatsRepository)(nil)
+
+// NewWorkoutStatsRepository создает новый репозиторий статистики тренировок.
+func NewWorkoutStatsRepository(db *gorm.DB) *WorkoutStatsRepository {
+	return &WorkoutStatsRepository{db: db}
+}
+
+// Weekly суммирует тренировки, подходы и тоннаж по неделям.
+func (r *WorkoutStatsRepository) Weekly(ctx context.Context, userID uuid.UUID, from, to time.Time, tz string) ([]domain.WeekStats, error) {
+	// Подходы суммируются по тренировке до группировки по неделям, чтобы тренировка считалась один раз.
+	const query = `
+		SELECT date_trunc('week', ws.started_at AT TIME ZONE @tz)::date AS week_start,
+		       COUNT(*) AS sessions,
+		       COALESCE(SUM(s.sets), 0)::bigint AS sets,
+		       COALESCE(SUM(s.reps), 0)::bigint AS reps,
+		       COALESCE(SUM(s.volume_kg), 0)::float8 AS volume_kg,
+		       COUNT(DISTINCT (ws.started_at AT TIME ZONE @tz)::date) AS training_days
+		  FROM workout_sessions ws
+		  LEFT JOIN LATERAL (
+		       SELECT COUNT(*) AS sets, SUM(reps) AS reps, SUM(reps * weight_kg) AS volume_kg
+		         FROM workout_sets
+		        WHERE session_id = ws.id AND NOT implausible
+		  ) s ON TRUE
+		 WHERE ws.user_id = @user_id AND ws.finished_at IS NOT NULL
+		   AND ws.started_at >= @from AND ws.started_at < @to
+		 GROUP BY week_start
+		 ORDER BY week_start`
+
+	var rows []struct {
+		WeekStart    time.Time
+		Sessions     int
+		Sets         int
+		Reps         int
+		VolumeKg     float64
+		TrainingDays int
+	}
+	err := dbFromContext(ctx, r.db).
+		Raw(query, map[string]interface{}{"user_id": userID.String(), "from": from, "to": to, "tz": tz}).
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	weeks := make([]domain.WeekStats, 0, len(rows))
+	for _, row := range rows {
+		weeks = append(weeks, domain.WeekStats{
+			WeekStart:    row.WeekStart,
+			Sessions:     row.Sessions,
+			Sets:         row.Sets,
+			Reps:         row.Reps,
+			VolumeKg:     row.VolumeKg,
+			TrainingDays: row.TrainingDays,
+		})
+	}
+	return weeks, nil
+}
+
+// MuscleGroups суммирует подходы по группам мышц справочника exercise_muscle_groups.
+// Название упражнения приводится к ключу так же, как program.ExerciseKey.
+func (r *WorkoutStatsRepository) MuscleGroups(ctx context.Context, userID uuid.UUID, from, to time.Time) ([]domain.MuscleGroupStats, error) {
+	const query = `
+		SELECT emg.muscle_group,
+		       COUNT(*) AS sets,
+		       COALESCE(SUM(s.reps * s.weight_kg), 0)::float8 AS volume_kg
+		  FROM workout_sets s
+		  JOIN workout_sessions ws ON ws.id = s.session_id
+		  JOIN exercise_muscle_groups emg
+		    ON emg.exercise_key = lower(btrim(regexp_replace(s.exercise, '\s+', ' ', 'g')))
+		 WHERE ws.user_id = @user_id AND ws.finished_at IS NOT NULL AND NOT s.implausible
+		   AND ws.started_at >= @from AND ws.started_at < @to
+		 GROUP BY emg.muscle_group
+		 ORDER BY sets DESC, volume_kg DESC, emg.muscle_group`
+
+	var rows []struct {
+		MuscleGroup string
+		Sets        int
+		VolumeKg    float64
+	}
+	err := dbFromContext(ctx, r.db).
+		Raw(query, map[string]interface{}{"user_id": userID.String(), "from": from, "to": to}).
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	groups := make([]domain.MuscleGroupStats, 0, len(rows))
+	for _, row := range rows {
+		groups = append(groups, domain.MuscleGroupStats{
+			Group:    domain.MuscleGroup(row.MuscleGroup),
+			Sets:     row.Sets,
+			VolumeKg: row.VolumeKg,
+		})
+	}
+	return groups, nil
+}
+
+// PersonalRecords сравнивает максимальный вес упражнений за период с лучшим весом до него.
+// Название упражнения приводится к ключу так же, как program.ExerciseKey.
+func (r *WorkoutStatsRepository) PersonalRecords(ctx context.Context, userID uuid.UUID, from, to time.Time) ([]domain.PersonalRecord, error) {
+	const query = `
+		WITH sets AS (
+		     SELECT lower(btrim(regexp_replace(s.exercise, '\s+', ' ', 'g'))) AS exercise_key,
+		            s.exercise, s.weight_kg, ws.started_at, s.logged_at
+		       FROM workout_sets s
+		       JOIN workout_sessions ws ON ws.id = s.session_id
+		      WHERE ws.user_id = @user_id AND ws.finished_at IS NOT NULL
+		        AND ws.started_at < @to AND s.weight_kg > 0 AND NOT s.implausible
+		), period_best AS (
+		     SELECT DISTINCT ON (exercise_key) exercise_key, exercise, weight_kg
+		       FROM sets
+		      WHERE started_at >= @from
+		      ORDER BY exercise_key, weight_kg DESC, started_at DESC, logged_at DESC
+		), previous_best AS (
+		     SELECT exercise_key, MAX(weight_kg) AS weight_kg
+		       FROM sets
+		      WHERE started_at < @from
+		      GROUP BY exercise_key
+		)
+		SELECT c.exercise, c.weight_kg::float8 AS weight_kg, p.weight_kg::float8 AS previous_kg
+		  FROM period_best c
+		  JOIN previous_best p ON p.exercise_key = c.exercise_key
+		 WHERE c.weight_kg > p.weight_kg
+		 ORDER BY c.weight_kg - p.weight_kg DESC, c.exercise_key`
+
+	var rows []struct {
+		Exercise   string
+		WeightKg   float64
+		PreviousKg float64
+	}
+	err := dbFromContext(ctx, r.db).
+		Raw(query, map[string]interface{}{"user_id": userID.String(), "from": from, "to": to}).
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	records := make([]domain.PersonalRecord, 0, len(rows))
+	for _, row := range rows {
+		records = append(records, domain.PersonalRecord{
+			Exercise:   row.Exercise,
+			WeightKg:   row.WeightKg,
+			PreviousKg: row.PreviousKg,
+		})
+	}
+	return records, nil
+}
+
+// Streaks находит серии подряд идущих дней и недель с тренировками («острова» в последовательности дат).
+func (r *WorkoutStatsRepository) Streaks(ctx context.Context, userID uuid.UUID, tz string) (domain.Runs, domain.Runs, error) {
+	days, err := r.runs(ctx, userID, tz, "day", 1)
+	if err != nil {
+		return domain.Runs{}, domain.Runs{}, err
+	}
+	weeks, err := r.runs(ctx, userID, tz, "week", 7)
+	if err != nil {
+		return domain.Runs{}, domain.Runs{}, err
+	}
+	return days, weeks, nil
+}
+
+// runs считает серии периодов period (day или week) длиной step дней: у дат одной серии разность
+// даты и её порядкового номера × step одинакова.
+func (r *WorkoutStatsRepository) runs(ctx context.Context, userID uuid.UUID, tz, period string, step int) (domain.Runs, error) {
+	const query = `
+		WITH periods AS (
+		     SELECT DISTINCT date_trunc(@period, started_at AT TIME ZONE @tz)::date AS period
+		       FROM workout_sessions
+		      WHERE user_id = @user_id AND finished_at IS NOT NULL
+		), runs AS (
+		     SELECT MAX(period) AS last_end, COUNT(*) AS length
+		       FROM (SELECT period, period - (ROW_NUMBER() OVER (ORDER BY period) * @step)::int AS grp FROM periods) p
+		      GROUP BY grp
+		)
+		SELECT COALESCE(MAX(length), 0) AS longest,
+		       COALESCE((SELECT length FROM runs ORDER BY last_end DESC LIMIT 1), 0) AS last,
+		       MAX(last_end) AS last_end
+		  FROM runs`
+
+	var row struct {
+		Longest int
+		Last    int
+		LastEnd *time.Time
+	}
+	err := dbFromContext(ctx, r.db).
+		Raw(query, map[string]interface{}{"user_id": userID.String(), "tz": tz, "period": period, "step": step}).
+		Scan(&row).Error
+	if err != nil {
+		return domain.Runs{}, err
+	}
+
+	runs := domain.Runs{Longest: row.Longest, Last: row.Last}
+	if row.LastEnd != nil {
+		runs.LastEnd = *row.LastEnd
+	}
+	return runs, nil
+}
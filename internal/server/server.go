@@ -2,34 +2,163 @@ package server
 
 import (
 	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"errors"
 	"fmt"
 	"log"
 	"net/http"
 	"os"
 	"os/signal"
+	"path/filepath"
+	"strings"
 	"syscall"
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 
 	_ "workout-app/api/swagger" // docs
+	"workout-app/internal/compat"
 	"workout-app/internal/config"
 	"workout-app/internal/database"
+	"workout-app/internal/domain/device"
+	emailoutboxdomain "workout-app/internal/domain/emailoutbox"
+	orgdomain "workout-app/internal/domain/organization"
+	"workout-app/internal/domain/region"
 	domain "workout-app/internal/domain/user"
+	workoutdomain "workout-app/internal/domain/workout"
+	"workout-app/internal/events"
+	audithandler "workout-app/internal/handler/audit"
 	authhandler "workout-app/internal/handler/auth"
+	avatarhandler "workout-app/internal/handler/avatar"
+	backfillhandler "workout-app/internal/handler/backfill"
+	backuphandler "workout-app/internal/handler/backup"
+	challengehandler "workout-app/internal/handler/challenge"
+	checkinhandler "workout-app/internal/handler/checkin"
+	clientversionhandler "workout-app/internal/handler/clientversion"
+	coachhandler "workout-app/internal/handler/coach"
+	coachclienthandler "workout-app/internal/handler/coachclient"
+	coachnotehandler "workout-app/internal/handler/coachnote"
+	consenthandler "workout-app/internal/handler/consent"
+	custommetrichandler "workout-app/internal/handler/custommetric"
+	importhandler "workout-app/internal/handler/dataimport"
+	dbfastpathhandler "workout-app/internal/handler/dbfastpath"
+	deliverabilityhandler "workout-app/internal/handler/deliverability"
+	drafthandler "workout-app/internal/handler/draft"
+	emailoutboxhandler "workout-app/internal/handler/emailoutbox"
+	experimenthandler "workout-app/internal/handler/experiment"
+	exporthandler "workout-app/internal/handler/export"
+	gymcheckinhandler "workout-app/internal/handler/gymcheckin"
+	gymclasshandler "workout-app/internal/handler/gymclass"
+	habithandler "workout-app/internal/handler/habit"
 	"workout-app/internal/handler/health"
+	healthsynchandler "workout-app/internal/handler/healthsync"
+	httpclienthandler "workout-app/internal/handler/httpclient"
+	jobshandler "workout-app/internal/handler/jobs"
+	"workout-app/internal/handler/jwks"
+	legalholdhandler "workout-app/internal/handler/legalhold"
+	maintenancehandler "workout-app/internal/handler/maintenance"
+	metahandler "workout-app/internal/handler/meta"
+	metrichandler "workout-app/internal/handler/metric"
 	"workout-app/internal/handler/middleware"
+	notificationhandler "workout-app/internal/handler/notification"
+	nutritionhandler "workout-app/internal/handler/nutrition"
+	oauthhandler "workout-app/internal/handler/oauth"
+	organizationhandler "workout-app/internal/handler/organization"
+	playgroundhandler "workout-app/internal/handler/playground"
+	presencehandler "workout-app/internal/handler/presence"
+	programhandler "workout-app/internal/handler/program"
+	progresshandler "workout-app/internal/handler/progress"
+	pushhandler "workout-app/internal/handler/push"
+	sharehandler "workout-app/internal/handler/share"
+	socialhandler "workout-app/internal/handler/social"
+	strengthhandler "workout-app/internal/handler/strength"
+	suppressionhandler "workout-app/internal/handler/suppression"
 	userhandler "workout-app/internal/handler/user"
+	usernameblockhandler "workout-app/internal/handler/usernameblock"
+	videohandler "workout-app/internal/handler/video"
+	webhookhandler "workout-app/internal/handler/webhook"
+	workouthandler "workout-app/internal/handler/workout"
+	"workout-app/internal/lifecycle"
 	"workout-app/internal/mailer"
+	"workout-app/internal/push"
+	repo "workout-app/internal/repository/interfaces"
 	pgrepo "workout-app/internal/repository/postgres"
+	anonymizationuc "workout-app/internal/usecase/anonymization"
+	audituc "workout-app/internal/usecase/audit"
 	authuc "workout-app/internal/usecase/auth"
+	avataruc "workout-app/internal/usecase/avatar"
+	backfilluc "workout-app/internal/usecase/backfill"
+	backupuc "workout-app/internal/usecase/backup"
+	challengeuc "workout-app/internal/usecase/challenge"
+	checkinuc "workout-app/internal/usecase/checkin"
+	cleanupuc "workout-app/internal/usecase/cleanup"
+	clientversionuc "workout-app/internal/usecase/clientversion"
+	coachuc "workout-app/internal/usecase/coach"
+	coachclientuc "workout-app/internal/usecase/coachclient"
+	coachnoteuc "workout-app/internal/usecase/coachnote"
+	consentuc "workout-app/internal/usecase/consent"
+	custommetricuc "workout-app/internal/usecase/custommetric"
+	importuc "workout-app/internal/usecase/dataimport"
+	deliverabilityuc "workout-app/internal/usecase/deliverability"
+	draftuc "workout-app/internal/usecase/draft"
+	emailoutboxuc "workout-app/internal/usecase/emailoutbox"
+	experimentuc "workout-app/internal/usecase/experiment"
+	exportuc "workout-app/internal/usecase/export"
+	gymcheckinuc "workout-app/internal/usecase/gymcheckin"
+	gymclassuc "workout-app/internal/usecase/gymclass"
+	habituc "workout-app/internal/usecase/habit"
+	healthsyncuc "workout-app/internal/usecase/healthsync"
+	legalholduc "workout-app/internal/usecase/legalhold"
+	maintenanceuc "workout-app/internal/usecase/maintenance"
+	metricuc "workout-app/internal/usecase/metric"
+	notificationuc "workout-app/internal/usecase/notification"
+	nutritionuc "workout-app/internal/usecase/nutrition"
+	oauthuc "workout-app/internal/usecase/oauth"
+	organizationuc "workout-app/internal/usecase/organization"
+	playgrounduc "workout-app/internal/usecase/playground"
+	presenceuc "workout-app/internal/usecase/presence"
+	programuc "workout-app/internal/usecase/program"
+	progressuc "workout-app/internal/usecase/progress"
+	pushuc "workout-app/internal/usecase/push"
+	reminderuc "workout-app/internal/usecase/reminder"
+	retentionuc "workout-app/internal/usecase/retention"
+	shareuc "workout-app/internal/usecase/share"
+	socialuc "workout-app/internal/usecase/social"
+	strengthuc "workout-app/internal/usecase/strength"
+	suppressionuc "workout-app/internal/usecase/suppression"
+	tenantemailuc "workout-app/internal/usecase/tenantemail"
 	useruc "workout-app/internal/usecase/user"
+	usernameblockuc "workout-app/internal/usecase/usernameblock"
+	videouc "workout-app/internal/usecase/video"
+	webhookuc "workout-app/internal/usecase/webhook"
+	weeklysummaryuc "workout-app/internal/usecase/weeklysummary"
+	workoutuc "workout-app/internal/usecase/workout"
+	"workout-app/internal/version"
+	"workout-app/internal/worker"
+	"workout-app/pkg/cache"
+	"workout-app/pkg/emailaddr"
+	"workout-app/pkg/httpclient"
 	"workout-app/pkg/jwt"
+	"workout-app/pkg/lock"
 	"workout-app/pkg/logger"
 	mailerpkg "workout-app/pkg/mailer"
+	"workout-app/pkg/oidc"
+	"workout-app/pkg/password"
+	"workout-app/pkg/presence"
+	pushpkg "workout-app/pkg/push"
+	"workout-app/pkg/ratelimit"
+	"workout-app/pkg/secretbox"
+	"workout-app/pkg/servertiming"
+	"workout-app/pkg/storage"
+	"workout-app/pkg/usernamefilter"
 
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/redis/go-redis/v9"
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
+	"gorm.io/gorm"
 )
 
 // Server представляет HTTP сервер приложения
@@ -38,11 +167,85 @@ type Server struct {
 	httpServer *http.Server
 	db         *database.DB
 	cfg        *config.Config
+	startedAt  time.Time
 
-	logger      logger.Logger
-	jwtService  jwt.Service
-	authHandler *authhandler.Handler
-	userHandler *userhandler.Handler
+	// lifecycle запускает фоновые компоненты при старте и останавливает их после HTTP сервера.
+	lifecycle *lifecycle.Manager
+	// jobMetrics собирает длительность и ошибки фоновых задач; periodicJobs — зарегистрированные периодические задачи.
+	jobMetrics   *worker.Metrics
+	periodicJobs []jobshandler.PeriodicJob
+	// httpClients создаёт HTTP-клиенты интеграций с общим пулом соединений.
+	httpClients *httpclient.Factory
+	// emailPolicy задаёт, какие варианты написания email считаются одним ящиком (поиск и лимиты по email).
+	emailPolicy emailaddr.Policy
+
+	logger                logger.Logger
+	jwtService            jwt.Service
+	authMiddleware        gin.HandlerFunc
+	txMiddleware          gin.HandlerFunc
+	regionFeature         func(region.Feature) gin.HandlerFunc
+	orgRole               func(...orgdomain.Role) gin.HandlerFunc
+	smtpSender            *mailer.SMTPSender
+	redis                 *redis.Client
+	storage               storage.Storage
+	authHandler           *authhandler.Handler
+	oauthHandler          *oauthhandler.Handler
+	userHandler           *userhandler.Handler
+	metricHandler         *metrichandler.Handler
+	experimentHandler     *experimenthandler.Handler
+	programHandler        *programhandler.Handler
+	statusHandler         *health.StatusHandler
+	maintenanceHandler    *maintenancehandler.Handler
+	backfillHandler       *backfillhandler.Handler
+	presenceHandler       *presencehandler.Handler
+	avatarHandler         *avatarhandler.Handler
+	backupHandler         *backuphandler.Handler
+	consentHandler        *consenthandler.Handler
+	legalHoldHandler      *legalholdhandler.Handler
+	auditService          audituc.Service
+	auditHandler          *audithandler.Handler
+	deliverabilityHandler *deliverabilityhandler.Handler
+	suppressionHandler    *suppressionhandler.Handler
+	usernameBlockHandler  *usernameblockhandler.Handler
+	emailOutboxHandler    *emailoutboxhandler.Handler
+	organizationHandler   *organizationhandler.Handler
+	videoHandler          *videohandler.Handler
+	workoutHandler        *workouthandler.Handler
+	shareHandler          *sharehandler.Handler
+	progressHandler       *progresshandler.Handler
+	exportHandler         *exporthandler.Handler
+	importHandler         *importhandler.Handler
+	checkInHandler        *checkinhandler.Handler
+	customMetricHandler   *custommetrichandler.Handler
+	gymClassHandler       *gymclasshandler.Handler
+	challengeHandler      *challengehandler.Handler
+	nutritionHandler      *nutritionhandler.Handler
+	habitHandler          *habithandler.Handler
+	healthSyncHandler     *healthsynchandler.Handler
+	gymCheckInHandler     *gymcheckinhandler.Handler
+	strengthHandler       *strengthhandler.Handler
+	coachHandler          *coachhandler.Handler
+	coachNoteHandler      *coachnotehandler.Handler
+	coachClientHandler    *coachclienthandler.Handler
+	socialHandler         *socialhandler.Handler
+	notificationHandler   *notificationhandler.Handler
+	pushHandler           *pushhandler.Handler
+	draftHandler          *drafthandler.Handler
+	webhookHandler        *webhookhandler.Handler
+	jobsHandler           *jobshandler.Handler
+	httpClientHandler     *httpclienthandler.Handler
+	backfillService       backfilluc.Service
+
+	clientVersionHandler *clientversionhandler.Handler
+	clientVersionService clientversionuc.Service
+
+	changelog   *compat.Changelog // Журнал изменений API: эндпоинт /meta/changelog и заголовки устаревших маршрутов
+	metaHandler *metahandler.Handler
+
+	playgroundHandler *playgroundhandler.Handler // nil, если песочница API выключена
+
+	userFastPathMetrics *pgrepo.FastPathMetrics
+	dbFastPathHandler   *dbfastpathhandler.Handler
 }
 
 // loggerEmailSender — простая реализация EmailSender, логирующая коды в логгер.
@@ -59,6 +262,82 @@ func (s *loggerEmailSender) SendEmailVerificationCode(ctx context.Context, email
 	return nil
 }
 
+func (s *loggerEmailSender) SendPasswordChangeCode(ctx context.Context, email, code string) error {
+	s.logger.Info("Password change code sent", map[string]any{
+		"email": email,
+		"code":  code,
+	})
+	return nil
+}
+
+func (s *loggerEmailSender) SendPasswordResetCode(ctx context.Context, email, code string) error {
+	s.logger.Info("Password reset code sent", map[string]any{
+		"email": email,
+		"code":  code,
+	})
+	return nil
+}
+
+func (s *loggerEmailSender) SendDataExportReady(ctx context.Context, email, downloadURL string, expiresAt time.Time) error {
+	s.logger.Info("Data export link sent", map[string]any{
+		"email":      email,
+		"url":        downloadURL,
+		"expires_at": expiresAt,
+	})
+	return nil
+}
+
+func (s *loggerEmailSender) SendWelcome(ctx context.Context, email, username string) error {
+	s.logger.Info("Welcome email sent", map[string]any{
+		"email":    email,
+		"username": username,
+	})
+	return nil
+}
+
+func (s *loggerEmailSender) SendPasswordChanged(ctx context.Context, email string, changedAt time.Time) error {
+	s.logger.Info("Password changed notice sent", map[string]any{
+		"email":      email,
+		"changed_at": changedAt,
+	})
+	return nil
+}
+
+func (s *loggerEmailSender) SendAccountDeleted(ctx context.Context, email string, deletedAt time.Time) error {
+	s.logger.Info("Account deleted notice sent", map[string]any{
+		"email":      email,
+		"deleted_at": deletedAt,
+	})
+	return nil
+}
+
+func (s *loggerEmailSender) SendNotification(ctx context.Context, email, notificationType string, at time.Time) error {
+	s.logger.Info("Notification email sent", map[string]any{
+		"email": email,
+		"type":  notificationType,
+		"at":    at,
+	})
+	return nil
+}
+
+func (s *loggerEmailSender) SendWeeklySummary(ctx context.Context, email string, summary mailerpkg.WeeklySummary) error {
+	s.logger.Info("Weekly summary email sent", map[string]any{
+		"email":      email,
+		"week_start": summary.WeekStart,
+		"sessions":   summary.Sessions,
+	})
+	return nil
+}
+
+func (s *loggerEmailSender) SendCoachInvitation(ctx context.Context, email, coachName string, expiresAt time.Time) error {
+	s.logger.Info("Coach invitation email sent", map[string]any{
+		"email":      email,
+		"coach":      coachName,
+		"expires_at": expiresAt,
+	})
+	return nil
+}
+
 // NewServer создает новый экземпляр сервера
 func NewServer(cfg *config.Config, db *database.DB) *Server {
 	// Устанавливаем режим Gin в зависимости от окружения
@@ -69,51 +348,516 @@ func NewServer(cfg *config.Config, db *database.DB) *Server {
 	}
 
 	router := gin.New()
+	// Без доверенных прокси ClientIP — адрес соединения, и подставленный клиентом X-Forwarded-For
+	// не меняет ключи лимитов по IP. Адреса валидированы в config.Validate, ошибка здесь невозможна.
+	_ = router.SetTrustedProxies(cfg.Server.TrustedProxies)
 
 	s := &Server{
-		router: router,
-		db:     db,
-		cfg:    cfg,
+		router:    router,
+		db:        db,
+		cfg:       cfg,
+		startedAt: time.Now(),
 	}
 
-	s.logger = logger.Default()
+	// Уровень валидирован в config.Validate, ошибка здесь невозможна.
+	logLevel, _ := logger.ParseLevel(cfg.Log.Level)
+	s.logger = logger.New(os.Stdout, logLevel, cfg.Log.Format)
+	logger.RedirectStdLog(s.logger)
+	s.lifecycle = lifecycle.NewManager(s.logger)
+	s.jobMetrics = worker.NewMetrics()
+	s.userFastPathMetrics = pgrepo.NewFastPathMetrics()
+	// Все обращения к внешним сервисам идут через общий пул соединений с повторами и метриками.
+	s.httpClients = httpclient.NewFactory(httpclient.Config{
+		Timeout:         cfg.HTTPClient.Timeout,
+		MaxConnsPerHost: cfg.HTTPClient.MaxConnsPerHost,
+		MaxRetries:      cfg.HTTPClient.MaxRetries,
+		RetryBase:       cfg.HTTPClient.RetryBase,
+		RetryMax:        cfg.HTTPClient.RetryMax,
+	}, s.logger)
+	s.lifecycle.Register("http-clients", nil, lifecycle.CloseFunc(s.httpClients.Close))
+
+	if cfg.Redis.URL != "" {
+		opts, err := redis.ParseURL(cfg.Redis.URL)
+		if err != nil {
+			s.logger.Error("redis_config_invalid", map[string]any{"error": err.Error()})
+		} else {
+			s.redis = redis.NewClient(opts)
+			s.redis.AddHook(servertiming.RedisHook{})
+			// Регистрируется первым, чтобы закрыться после всех компонентов, которые его используют.
+			// Запуск ждёт, пока Redis ответит: без него не работают лимиты запросов и кеши.
+			s.lifecycle.Register("redis", lifecycle.Gate(func(ctx context.Context) error {
+				return s.redis.Ping(ctx).Err()
+			}, cfg.Startup.Timeout, startupGateInterval), lifecycle.CloseFunc(s.redis.Close))
+		}
+	}
+	// Миграции применяет отдельная команда; новая версия сервера не начинает работу со старой схемой.
+	s.lifecycle.Register("migrations", lifecycle.Gate(db.CheckSchema, cfg.Startup.Timeout, startupGateInterval), nil)
+
+	// Кеш профилей пользователей нужен до создания репозитория: все изменения пользователей
+	// проходят через обёртку, которая сбрасывает устаревшие профили.
+	var userProfileCache cache.Cache
+	if s.redis != nil && cfg.Redis.UserProfileCacheTTL > 0 {
+		userProfileCache = cache.NewRedisCache(s.redis, "user_profile:")
+	}
+	// Лента активности собирается при чтении; страницы ленты кешируются ненадолго, подписки кеш не сбрасывают.
+	var feedCache cache.Cache
+	if s.redis != nil && cfg.Redis.FeedCacheTTL > 0 {
+		feedCache = cache.NewRedisCache(s.redis, "feed:")
+	}
 
 	// Инициализируем зависимости домена пользователя и аутентификации один раз
 	gormDB := db.DB
-	userRepo := pgrepo.NewUserRepository(gormDB)
+	s.emailPolicy = emailaddr.Policy{FoldGmail: cfg.Email.FoldGmail, FoldPlusTags: cfg.Email.FoldPlusTags}
+	userRepo := useruc.NewCacheInvalidator(
+		s.newUserRepository(gormDB, s.emailPolicy),
+		userProfileCache,
+		s.logger,
+	)
+	usernameHistoryRepo := pgrepo.NewUsernameHistoryRepository(gormDB)
+	profileHistoryRepo := pgrepo.NewProfileHistoryRepository(gormDB)
+	workoutReminderRepo := pgrepo.NewWorkoutReminderRepository(gormDB)
+	weeklySummaryRepo := pgrepo.NewWeeklySummaryRepository(gormDB)
+	workoutStatsRepo := pgrepo.NewWorkoutStatsRepository(gormDB)
 	emailVerifRepo := pgrepo.NewEmailVerificationRepository(gormDB)
-	s.jwtService = jwt.NewService(&cfg.JWT)
+	bodyMetricRepo := pgrepo.NewBodyMetricRepository(gormDB)
+	experimentRepo := pgrepo.NewExperimentRepository(gormDB)
+	programRepo := pgrepo.NewProgramRepository(gormDB)
+	workoutRepo := pgrepo.NewWorkoutSessionRepository(gormDB)
+	exportRepo := pgrepo.NewDataExportRepository(gormDB)
+	importRepo := pgrepo.NewDataImportRepository(gormDB)
+	backupRepo := pgrepo.NewBackupRepository(gormDB)
+	challengeRepo := pgrepo.NewChallengeRepository(gormDB)
+	nutritionRepo := pgrepo.NewNutritionRepository(gormDB)
+	habitRepo := pgrepo.NewHabitRepository(gormDB)
+	healthSyncRepo := pgrepo.NewHealthSyncRepository(gormDB)
+	checkInRepo := pgrepo.NewCheckInRepository(gormDB)
+	customMetricRepo := pgrepo.NewCustomMetricRepository(gormDB)
+	oauthAccountRepo := pgrepo.NewOAuthAccountRepository(gormDB)
+	trainingMaxRepo := pgrepo.NewTrainingMaxRepository(gormDB)
+	organizationRepo := pgrepo.NewOrganizationRepository(gormDB)
+	gymClassRepo := pgrepo.NewGymClassRepository(gormDB)
+	gymCheckInRepo := pgrepo.NewGymCheckInRepository(gormDB)
+	strengthStandardRepo := pgrepo.NewStrengthStandardRepository(gormDB)
+	backfillRepo := pgrepo.NewBackfillRepository(gormDB)
+	eventRepo := pgrepo.NewEventRepository(gormDB)
+	clientVersionRepo := pgrepo.NewClientVersionRepository(gormDB)
+	usernameBlocklistRepo := pgrepo.NewUsernameBlocklistRepository(gormDB)
+	coachProfileRepo := pgrepo.NewCoachProfileRepository(gormDB)
+	coachNoteRepo := pgrepo.NewCoachNoteRepository(gormDB)
+	coachClientRepo := pgrepo.NewCoachClientRepository(gormDB)
+	notificationRepo := pgrepo.NewNotificationRepository(gormDB)
+	deviceRepo := pgrepo.NewDeviceRepository(gormDB)
+	draftRepo := pgrepo.NewDraftRepository(gormDB)
+	followRepo := pgrepo.NewFollowRepository(gormDB)
+	consentRepo := pgrepo.NewConsentRepository(gormDB)
+	legalHoldAuditRepo := pgrepo.NewLegalHoldAuditRepository(gormDB)
+	auditLogRepo := pgrepo.NewAuditLogRepository(gormDB)
+	transactor := pgrepo.NewTransactor(gormDB)
+	jwtService, err := jwt.NewService(&cfg.JWT)
+	if err != nil {
+		// Путь и алгоритм проверены в config.Validate: ошибка здесь — неверный или недоступный файл ключа.
+		panic(err)
+	}
+	s.jwtService = jwtService
+	s.lifecycle.Register("jwt", func(context.Context) error {
+		return jwt.SelfCheck(jwtService)
+	}, nil)
+	s.lifecycle.Register("bcrypt", s.warmUpPasswordHashing, nil)
 
+	s.storage = s.newStorage()
+
+	// Шаблоны встроены в бинарник, язык по умолчанию проверен в config.Validate.
+	emailTemplates := mailer.MustLoadTemplates(cfg.Email.DefaultLocale)
 	var emailSender mailerpkg.EmailSender
-	if cfg.Email.SMTPHost != "" {
-		emailSender = mailer.NewSMTPSender(&cfg.Email, s.logger)
-	} else {
+	switch {
+	case cfg.Email.Provider != "smtp":
+		emailSender = mailer.NewProviderSender(s.newEmailProvider(), cfg.Email.FromEmail, emailTemplates, s.logger)
+	case cfg.Email.SMTPHost != "":
+		s.smtpSender = mailer.NewSMTPSender(&cfg.Email, emailTemplates, s.logger)
+		// Пул SMTP-соединений закрывается после остановки отправки писем из очереди (обратный порядок).
+		s.lifecycle.Register("smtp", nil, lifecycle.CloseFunc(s.smtpSender.Close))
+		emailSender = s.smtpSender
+	default:
 		// Фолбэк: логируем коды в лог вместо реальной отправки писем.
 		emailSender = &loggerEmailSender{logger: s.logger}
 	}
+	// Письма участникам организаций с собственными настройками отправляются через их SMTP;
+	// без EMAIL_TENANT_SECRET_KEY все письма отправляет платформа.
+	tenantEmailService := tenantemailuc.NewService(s.newTenantEmailSettingsRepository(), cfg.Email.TenantMaxPerHour)
+	emailSender = mailer.NewTenantSender(emailSender, emailTemplates, tenantEmailService, s.newTenantEmailLimiter, s.logger)
+	// Адреса из списка подавления (недоставка и жалобы по webhooks провайдеров, ручные записи) не получают писем.
+	suppressionService := suppressionuc.NewService(pgrepo.NewSuppressionRepository(gormDB))
+	deliverabilityService := deliverabilityuc.NewService(
+		pgrepo.NewDeliverabilityRepository(gormDB), pgrepo.NewSuppressionRepository(gormDB),
+	)
+	emailSender = mailer.NewGuardedSender(emailSender, suppressionService, s.logger)
+	// Запросы и фоновые задачи только ставят письма в очередь; воркер отправляет их через цепочку выше
+	// с повторными попытками. Список подавления проверяется и при постановке, чтобы запрос сразу получил ошибку.
+	outboxRepo := pgrepo.NewEmailOutboxRepository(gormDB)
+	outboxService := emailoutboxuc.NewService(outboxRepo, emailSender, emailoutboxuc.Config{
+		BatchSize:   cfg.Outbox.BatchSize,
+		MaxAttempts: cfg.Outbox.MaxAttempts,
+		RetryBase:   cfg.Outbox.RetryBase,
+		RetryMax:    cfg.Outbox.RetryMax,
+		Retention:   cfg.Outbox.Retention,
+	}, s.logger)
+	outboxJob := worker.NewPeriodic("email-outbox", cfg.Outbox.Interval, outboxService.Run, s.jobMetrics, s.logger)
+	s.startPeriodic(outboxJob)
+	emailSender = mailer.NewGuardedSender(mailer.NewOutboxSender(outboxRepo), suppressionService, s.logger)
+
+	// Запрещённые username: встроенные списки из конфигурации и записи администраторов.
+	usernameBlockService := usernameblockuc.NewService(usernameBlocklistRepo, usernameBlockRules(cfg.Username), usernameBlocklistCacheTTL)
+
+	// Доменные события сохраняются в журнал и доставляются подписчикам; см. cmd/replay.
+	// Письма о событиях аккаунта подписчики ставят в ту же очередь исходящих писем.
+	// Уведомления webhooks подписчик ставит в очередь, а отправляет воркер с подписью и повторными попытками;
+	// без WEBHOOK_SECRET_KEY webhooks отключены.
+	webhookEndpointRepo := s.newWebhookEndpointRepository()
+	webhookDeliveryRepo := pgrepo.NewWebhookDeliveryRepository(gormDB)
+	webhookService := webhookuc.NewService(webhookEndpointRepo, webhookDeliveryRepo, webhookuc.Config{
+		AllowHTTP:   cfg.AppEnv != "production",
+		BatchSize:   cfg.Webhook.BatchSize,
+		MaxAttempts: cfg.Webhook.MaxAttempts,
+		RetryBase:   cfg.Webhook.RetryBase,
+		RetryMax:    cfg.Webhook.RetryMax,
+		Timeout:     cfg.Webhook.Timeout,
+		Retention:   cfg.Webhook.Retention,
+		Client:      s.httpClients.Client("webhooks", cfg.Webhook.Timeout),
+	}, s.logger)
+	if webhookEndpointRepo != nil {
+		webhookJob := worker.NewPeriodic("webhooks", cfg.Webhook.Interval, webhookService.Run, s.jobMetrics, s.logger)
+		s.startPeriodic(webhookJob)
+	}
+	s.webhookHandler = webhookhandler.NewHandler(webhookService, s.logger)
+
+	eventBus := events.NewBus(eventRepo, events.NewRegistry(events.DefaultSubscribers(events.Dependencies{
+		DB:                gormDB,
+		Users:             userRepo,
+		Email:             emailSender,
+		Logger:            s.logger,
+		WebhookEndpoints:  webhookEndpointRepo,
+		WebhookDeliveries: webhookDeliveryRepo,
+		Notifications:     notificationRepo,
+	})...), s.logger)
 
 	authService := authuc.NewService(
+		transactor,
 		userRepo,
+		usernameHistoryRepo,
+		usernameBlockService,
 		emailVerifRepo,
 		s.jwtService,
 		emailSender,
+		eventBus,
 		cfg.Email.VerificationTTL,
 		cfg.Email.VerificationMaxAttempts,
 		cfg.Email.VerificationCodeLength,
+		passwordChangeConfirmRoles(cfg.Email.PasswordChangeConfirmRoles),
+		passwordPolicy(cfg.Password),
 	)
 
 	// userService использует тот же emailSender, что и authService
 	userService := useruc.NewService(
 		userRepo,
+		usernameHistoryRepo,
+		profileHistoryRepo,
+		usernameBlockService,
 		emailVerifRepo,
 		emailSender,
+		eventBus,
 		cfg.Email.VerificationTTL,
 		cfg.Email.VerificationMaxAttempts,
 		cfg.Email.VerificationCodeLength,
+		cfg.Username.ReservationPeriod,
+		userProfileCache,
+		cfg.Redis.UserProfileCacheTTL,
 	)
 
-	s.authHandler = authhandler.NewHandler(authService)
-	s.userHandler = userhandler.NewHandler(userService, s.logger)
+	// Тренер видит данные клиента только из классов, которые клиент ему открыл.
+	consentService := consentuc.NewService(consentRepo, coachNoteRepo, coachClientRepo)
+
+	metricService := metricuc.NewService(bodyMetricRepo, eventBus, consentService)
+	experimentService := experimentuc.NewService(experimentRepo, userRepo)
+	programService := programuc.NewService(transactor, programRepo, trainingMaxRepo, userRepo, coachClientRepo, eventBus, consentService)
+
+	// Присутствие хранится в Redis (общий для всех инстансов), если он настроен.
+	var presenceStore presence.Store = presence.NewMemoryStore()
+	if s.redis != nil {
+		presenceStore = presence.NewRedisStore(s.redis, "presence:")
+	}
+	presenceService := presenceuc.NewService(presenceStore, coachClientRepo, userRepo, coachNoteRepo, presenceTTL)
+
+	s.clientVersionService = clientversionuc.NewService(clientVersionRepo, clientVersionCacheTTL)
+
+	s.statusHandler = health.NewStatusHandler(version.Version, s.startedAt, s.statusChecks())
+	// Кеши, доступные для служебных операций администраторов.
+	maintenanceCaches := map[string]maintenanceuc.Cache{
+		"status":             s.statusHandler,
+		"client_versions":    s.clientVersionService,
+		"username_blocklist": usernameBlockService,
+	}
+	if userProfileCache != nil {
+		maintenanceCaches["user_profiles"] = userProfileCache
+	}
+	if feedCache != nil {
+		maintenanceCaches["feed"] = feedCache
+	}
+	maintenanceService := maintenanceuc.NewService(maintenanceCaches)
+
+	// Auth middleware проверяет не только токен, но и блокировку аккаунта.
+	s.authMiddleware = middleware.Auth(s.jwtService, userService, s.logger)
+	// Opt-in: изменяющие запросы маршрутов с этим middleware выполняются в одной транзакции.
+	s.txMiddleware = middleware.Transaction(transactor, s.logger)
+	// Региональные ограничения функций (REGION_DISABLED_FEATURES); подключаются после authMiddleware.
+	regionPolicy := newRegionPolicy(cfg.Region.DisabledFeatures)
+	s.regionFeature = func(f region.Feature) gin.HandlerFunc {
+		return middleware.RegionFeature(f, userService, regionPolicy, s.logger)
+	}
+
+	s.authHandler = authhandler.NewHandler(authService, cfg.Region.CountryHeader)
+	s.oauthHandler = oauthhandler.NewHandler(
+		oauthuc.NewService(transactor, userRepo, usernameHistoryRepo, usernameBlockService, oauthAccountRepo, oauthVerifiers(cfg, s.httpClients), s.jwtService, eventBus),
+		cfg.Region.CountryHeader,
+		s.logger,
+	)
+	// Окончательное удаление обезличивает пользователя, а не удаляет строки, на которые ссылается чужой контент.
+	anonymizationService := anonymizationuc.NewService(
+		transactor, userRepo, usernameHistoryRepo, profileHistoryRepo, emailVerifRepo, bodyMetricRepo, consentRepo, programRepo, organizationRepo, workoutRepo, checkInRepo, customMetricRepo, oauthAccountRepo, trainingMaxRepo, gymClassRepo, gymCheckInRepo, coachProfileRepo, followRepo, coachNoteRepo, coachClientRepo, notificationRepo, deviceRepo, draftRepo, exportRepo, importRepo, backupRepo, challengeRepo, nutritionRepo, habitRepo, healthSyncRepo,
+		s.storage, s.logger,
+	)
+	s.userHandler = userhandler.NewHandler(userService, authService, anonymizationService, s.logger)
+	// Песочница API для разработки и QA; тестовые пользователи создаются при запуске, после проверки схемы БД.
+	if cfg.Playground.Enabled {
+		playgroundService := playgrounduc.NewService(userRepo, s.jwtService)
+		s.lifecycle.Register("playground-seed", playgroundService.Seed, nil)
+		s.playgroundHandler = playgroundhandler.NewHandler(playgroundService)
+	}
+	// Мягко удалённые аккаунты обезличиваются по истечении срока хранения (GDPR).
+	if cfg.Retention.DeletedUserDays > 0 {
+		retentionService := retentionuc.NewService(
+			userRepo, anonymizationService, time.Duration(cfg.Retention.DeletedUserDays)*24*time.Hour, s.logger,
+		)
+		job := worker.NewPeriodic("retention", cfg.Retention.Interval, func(ctx context.Context) error {
+			_, err := retentionService.Run(ctx)
+			return err
+		}, s.jobMetrics, s.logger)
+		s.startPeriodic(job)
+	}
+	s.legalHoldHandler = legalholdhandler.NewHandler(
+		legalholduc.NewService(transactor, userRepo, legalHoldAuditRepo), s.logger,
+	)
+	s.auditService = audituc.NewService(auditLogRepo)
+	s.auditHandler = audithandler.NewHandler(s.auditService, s.logger)
+	s.metricHandler = metrichandler.NewHandler(metricService, userService, s.logger)
+	s.deliverabilityHandler = deliverabilityhandler.NewHandler(deliverabilityService, cfg.Email.WebhookSecret, s.logger)
+	s.suppressionHandler = suppressionhandler.NewHandler(suppressionService, s.logger)
+	s.usernameBlockHandler = usernameblockhandler.NewHandler(usernameBlockService, s.logger)
+	s.emailOutboxHandler = emailoutboxhandler.NewHandler(outboxService, s.logger)
+	organizationService := organizationuc.NewService(transactor, organizationRepo, userRepo)
+	// Роль в организации из пути запроса; подключается после authMiddleware.
+	s.orgRole = func(roles ...orgdomain.Role) gin.HandlerFunc {
+		return middleware.RequireOrgRole(organizationService, s.logger, roles...)
+	}
+	s.organizationHandler = organizationhandler.NewHandler(organizationService, tenantEmailService, s.logger)
+	s.experimentHandler = experimenthandler.NewHandler(experimentService, s.logger)
+	// Типы задач пересчёта регистрируются здесь по мере появления исторических агрегатов;
+	// для обхода всех пользователей используется backfilluc.NewUserJob.
+	s.backfillService = backfilluc.NewService(backfillRepo, map[string]backfilluc.Job{
+		// Пересчитывает каноническую форму email после изменения EMAIL_FOLD_GMAIL / EMAIL_FOLD_PLUS_TAGS.
+		"user_emails": backfilluc.NewUserJob(userRepo, func(ctx context.Context, userID uuid.UUID) error {
+			u, err := userRepo.GetByID(ctx, userID)
+			if errors.Is(err, repo.ErrNotFound) {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+			err = userRepo.Update(ctx, u)
+			if errors.Is(err, repo.ErrEmailExists) {
+				// Адрес совпал с адресом другого пользователя по новым правилам — оставляем прежнюю форму.
+				s.logger.Warn("user_email_canonical_conflict", map[string]any{"user_id": userID.String()})
+				return nil
+			}
+			if errors.Is(err, repo.ErrNotFound) {
+				return nil
+			}
+			return err
+		}),
+	}, s.logger, backfillBatchPause)
+	s.lifecycle.Register("backfill", func(ctx context.Context) error {
+		// Продолжаем задачи пересчёта, прерванные предыдущей остановкой; ошибка не мешает старту сервера.
+		if err := s.backfillService.Start(ctx); err != nil {
+			log.Printf("Не удалось возобновить задачи пересчёта: %v", err)
+		}
+		return nil
+	}, lifecycle.WaitFunc(s.backfillService.Wait))
+
+	s.programHandler = programhandler.NewHandler(programService, s.logger)
+	s.presenceHandler = presencehandler.NewHandler(presenceService, s.logger)
+	s.consentHandler = consenthandler.NewHandler(consentService, s.logger)
+	s.avatarHandler = avatarhandler.NewHandler(
+		avataruc.NewService(userRepo, profileHistoryRepo, s.storage, cfg.Storage.AvatarMaxBytes, s.logger),
+		cfg.Storage.AvatarMaxBytes,
+		s.logger,
+	)
+	// Видео упражнений отдаются по подписанным ссылкам; транскодер подключается вместо nil (без него файлы не перекодируются).
+	s.videoHandler = videohandler.NewHandler(
+		videouc.NewService(pgrepo.NewVideoRepository(gormDB), s.storage, nil, videouc.Config{
+			MaxBytes:   cfg.Storage.VideoMaxBytes,
+			LinkTTL:    cfg.Storage.VideoLinkTTL,
+			SigningKey: videoSigningKey(cfg),
+			BaseURL:    cfg.Storage.PublicBaseURL,
+		}, s.logger),
+		cfg.Storage.VideoMaxBytes,
+		s.logger,
+	)
+	// Резервные копии шифруются на устройстве; сервер хранит последние версии в пределах квоты.
+	s.backupHandler = backuphandler.NewHandler(
+		backupuc.NewService(backupRepo, s.storage, backupuc.Config{
+			MaxBytes:    cfg.Storage.BackupMaxBytes,
+			QuotaBytes:  cfg.Storage.BackupQuotaBytes,
+			MaxVersions: cfg.Storage.BackupMaxVersions,
+		}, s.logger),
+		cfg.Storage.BackupMaxBytes,
+		s.logger,
+	)
+	socialService := socialuc.NewService(
+		followRepo, userRepo, pgrepo.NewFeedRepository(gormDB), workoutRepo, feedCache, cfg.Redis.FeedCacheTTL,
+	)
+	s.socialHandler = socialhandler.NewHandler(socialService, s.logger)
+	workoutValidator := workoutuc.NewValidator(pgrepo.NewExerciseLimitRepository(gormDB), workoutuc.ValidationConfig{
+		Mode:        workoutdomain.ValidationMode(cfg.Workout.ValidationMode),
+		MaxWeightKg: float64(cfg.Workout.MaxWeightKg),
+		MaxReps:     cfg.Workout.MaxReps,
+		MaxDuration: cfg.Workout.MaxDuration,
+	}, exerciseLimitsCacheTTL)
+	s.workoutHandler = workouthandler.NewHandler(
+		workoutuc.NewService(workoutRepo, programRepo, eventBus, socialService, consentService, workoutValidator, cfg.Workout.AutoPauseAfter),
+		userService,
+		s.logger,
+	)
+	s.shareHandler = sharehandler.NewHandler(
+		shareuc.NewService(pgrepo.NewShareRepository(gormDB), workoutRepo, programRepo, userRepo), s.logger,
+	)
+	s.progressHandler = progresshandler.NewHandler(
+		progressuc.NewService(workoutStatsRepo, userRepo), userService, s.logger,
+	)
+	// Выгрузки данных аккаунта собираются в фоне; ссылка на архив приходит письмом.
+	exportService := exportuc.NewService(
+		exportRepo, userRepo, workoutRepo, bodyMetricRepo, checkInRepo, customMetricRepo, programRepo, trainingMaxRepo, consentRepo, s.storage, emailSender,
+		exportuc.Config{
+			TTL:        cfg.Export.TTL,
+			SigningKey: derivedSigningKey(cfg, "data-exports"),
+			BaseURL:    cfg.Storage.PublicBaseURL,
+		},
+		s.logger,
+	)
+	exportJob := worker.NewPeriodic("data-exports", cfg.Export.Interval, exportService.Run, s.jobMetrics, s.logger)
+	s.startPeriodic(exportJob)
+	s.exportHandler = exporthandler.NewHandler(exportService, s.logger)
+	// Импорт истории из других приложений: файл принимается потоком, записи обрабатываются в фоне пачками.
+	importService := importuc.NewService(
+		importRepo, workoutRepo, bodyMetricRepo, transactor,
+		importuc.Config{
+			MaxRecords:     cfg.Import.MaxRecords,
+			BatchSize:      cfg.Import.BatchSize,
+			UploadTimeout:  cfg.Import.UploadTimeout,
+			Retention:      cfg.Import.Retention,
+			AutoPauseAfter: cfg.Workout.AutoPauseAfter,
+			Validator:      workoutValidator,
+		},
+		s.logger,
+	)
+	importJob := worker.NewPeriodic("data-imports", cfg.Import.Interval, importService.Run, s.jobMetrics, s.logger)
+	s.startPeriodic(importJob)
+	s.importHandler = importhandler.NewHandler(importService, cfg.Import.MaxBytes, cfg.Import.UploadTimeout, s.logger)
+	s.checkInHandler = checkinhandler.NewHandler(checkinuc.NewService(checkInRepo, eventBus, consentService), s.logger)
+	s.customMetricHandler = custommetrichandler.NewHandler(custommetricuc.NewService(customMetricRepo, userRepo), s.logger)
+	s.gymClassHandler = gymclasshandler.NewHandler(
+		gymclassuc.NewService(transactor, gymClassRepo, organizationRepo, eventBus), s.logger,
+	)
+	s.challengeHandler = challengehandler.NewHandler(challengeuc.NewService(transactor, challengeRepo), s.logger)
+	s.nutritionHandler = nutritionhandler.NewHandler(nutritionuc.NewService(nutritionRepo, userRepo), s.logger)
+	s.habitHandler = habithandler.NewHandler(habituc.NewService(habitRepo, userRepo), s.logger)
+	// Импорт из Apple Health и Google Fit: выгрузка обрабатывается в запросе одной транзакцией.
+	s.healthSyncHandler = healthsynchandler.NewHandler(
+		healthsyncuc.NewService(
+			healthSyncRepo, workoutRepo, bodyMetricRepo, habitRepo, userRepo, transactor,
+			healthsyncuc.Config{MaxItems: cfg.HealthSync.MaxItems, AutoPauseAfter: cfg.Workout.AutoPauseAfter},
+		),
+		cfg.HealthSync.MaxBytes,
+		s.logger,
+	)
+	s.gymCheckInHandler = gymcheckinhandler.NewHandler(
+		gymcheckinuc.NewService(gymCheckInRepo, organizationRepo, eventBus), s.logger,
+	)
+	s.strengthHandler = strengthhandler.NewHandler(
+		strengthuc.NewService(strengthStandardRepo, workoutRepo, userRepo, bodyMetricRepo), s.logger,
+	)
+	s.backfillHandler = backfillhandler.NewHandler(s.backfillService, s.logger)
+	s.coachHandler = coachhandler.NewHandler(coachuc.NewService(coachProfileRepo, userRepo), s.logger)
+	s.coachNoteHandler = coachnotehandler.NewHandler(coachnoteuc.NewService(coachNoteRepo, coachClientRepo), s.logger)
+	// Письмо с приглашением тренера ставится в очередь исходящих писем вместе с приглашением.
+	s.coachClientHandler = coachclienthandler.NewHandler(
+		coachclientuc.NewService(transactor, coachClientRepo, userRepo, emailSender), s.logger,
+	)
+	s.notificationHandler = notificationhandler.NewHandler(notificationuc.NewService(notificationRepo), s.logger)
+	// Подписчик notifications помечает уведомления для push, воркер отправляет их после коммита транзакции события.
+	notifier := push.NewNotifier(deviceRepo, s.newPushSenders(), cfg.Email.DefaultLocale, s.logger)
+	pushService := pushuc.NewService(deviceRepo, notificationRepo, userRepo, notifier, pushuc.Config{
+		BatchSize: cfg.Push.BatchSize,
+	}, s.logger)
+	pushJob := worker.NewPeriodic("push", cfg.Push.Interval, pushService.Run, s.jobMetrics, s.logger)
+	s.startPeriodic(pushJob)
+	s.pushHandler = pushhandler.NewHandler(pushService, s.logger)
+	s.draftHandler = drafthandler.NewHandler(draftuc.NewService(draftRepo, programRepo, workoutRepo, cfg.Draft.TTL), s.logger)
+	// Напоминания публикуются событием workout.reminder: уведомление и его копии создаёт подписчик notifications.
+	reminderService := reminderuc.NewService(transactor, userRepo, programRepo, workoutReminderRepo, eventBus, reminderuc.Config{
+		BatchSize: cfg.Reminder.BatchSize,
+	}, s.logger)
+	reminderJob := worker.NewPeriodic("workout-reminders", cfg.Reminder.Interval, reminderService.Run, s.jobMetrics, s.logger)
+	s.startPeriodic(reminderJob)
+	// Сводка отправляется письмом через очередь исходящих писем, без уведомления в приложении.
+	weeklySummaryService := weeklysummaryuc.NewService(transactor, userRepo, workoutStatsRepo, notificationRepo, weeklySummaryRepo, emailSender, weeklysummaryuc.Config{
+		BatchSize: cfg.WeeklySummary.BatchSize,
+		SendHour:  cfg.WeeklySummary.SendHour,
+	}, s.logger)
+	weeklySummaryJob := worker.NewPeriodic("weekly-summaries", cfg.WeeklySummary.Interval, weeklySummaryService.Run, s.jobMetrics, s.logger)
+	s.startPeriodic(weeklySummaryJob)
+
+	// Фоновая очистка истёкших кодов подтверждения; новые таблицы с истекающими записями регистрируются здесь.
+	cleanupService := cleanupuc.NewService(map[string]cleanupuc.Target{
+		"email_verifications": emailVerifRepo,
+		"email_outbox":        outboxService,
+		"webhook_deliveries":  webhookService,
+		"data_imports":        importService,
+		"drafts":              draftRepo,
+		"workout_reminders":   reminderService,
+		"weekly_summaries":    weeklySummaryService,
+	}, cfg.Cleanup.BatchSize, s.logger)
+	var cleanupJob maintenancehandler.JobStats
+	if cfg.Cleanup.Interval > 0 {
+		job := worker.NewPeriodic("cleanup", cfg.Cleanup.Interval, func(ctx context.Context) error {
+			_, err := cleanupService.Run(ctx)
+			return err
+		}, s.jobMetrics, s.logger)
+		s.startPeriodic(job)
+		cleanupJob = job
+	}
+	s.maintenanceHandler = maintenancehandler.NewHandler(maintenanceService, cleanupService, cleanupJob, s.logger)
+	s.httpClientHandler = httpclienthandler.NewHandler(s.httpClients.Metrics())
+	s.dbFastPathHandler = dbfastpathhandler.NewHandler(cfg.Database.UserFastPath, s.userFastPathMetrics)
+	s.jobsHandler = jobshandler.NewHandler(s.jobMetrics, s.periodicJobs, []jobshandler.Queue{
+		{Name: "email-outbox", Depth: func(ctx context.Context) (int64, error) {
+			counts, err := outboxService.Stats(ctx)
+			return counts[emailoutboxdomain.StatusPending], err
+		}},
+		{Name: "webhooks", Depth: webhookService.Pending},
+	}, s.logger)
+	s.clientVersionHandler = clientversionhandler.NewHandler(s.clientVersionService, s.logger)
+
+	s.changelog = compat.MustLoadChangelog()
+	s.metaHandler = metahandler.NewHandler(s.changelog)
 
 	// Настраиваем middleware и роуты
 	s.setupMiddleware()
@@ -122,16 +866,101 @@ func NewServer(cfg *config.Config, db *database.DB) *Server {
 	return s
 }
 
+// newUserRepository создаёт репозиторий пользователей. При DB_USER_FAST_PATH=shadow или on чтения по ID и email
+// обслуживает или сверяет pgx на отдельном пуле соединений; метрики обоих путей — GET /api/v1/admin/db-fast-path.
+func (s *Server) newUserRepository(gormDB *gorm.DB, emails emailaddr.Policy) repo.UserRepository {
+	users := pgrepo.NewUserRepository(gormDB, emails)
+	mode := pgrepo.FastPathMode(s.cfg.Database.UserFastPath)
+	if mode == pgrepo.FastPathOff {
+		return users
+	}
+
+	poolConfig, err := pgxpool.ParseConfig(s.cfg.Database.DSN())
+	if err != nil {
+		s.logger.Error("user_fast_path_config_invalid", map[string]any{"error": err.Error()})
+		return users
+	}
+	poolConfig.MaxConns = int32(s.cfg.Database.UserFastPathMaxConns)
+	poolConfig.MaxConnLifetime = s.cfg.Database.ConnMaxLifetime
+	poolConfig.MaxConnIdleTime = s.cfg.Database.ConnMaxIdleTime
+	// Пул подключается лениво: недоступная БД проявится ошибками чтений, как и у GORM.
+	pool, err := pgxpool.NewWithConfig(context.Background(), poolConfig)
+	if err != nil {
+		s.logger.Error("user_fast_path_config_invalid", map[string]any{"error": err.Error()})
+		return users
+	}
+
+	fastPath := pgrepo.NewUserFastPath(
+		users, pgrepo.NewUserFastReader(pool, emails), mode, s.cfg.Database.UserFastPathMaxConns, s.userFastPathMetrics, s.logger,
+	)
+	s.lifecycle.Register("user-fast-path", nil, func(ctx context.Context) error {
+		err := fastPath.Stop(ctx)
+		pool.Close()
+		return err
+	})
+	return fastPath
+}
+
+// startupGateInterval — пауза между проверками зависимостей, которых ждёт запуск сервера.
+const startupGateInterval = time.Second
+
+// warmUpPasswordHashing один раз проверяет пароль bcrypt до приёма трафика и пишет в лог, сколько это заняло:
+// первый вход не платит за прогрев, а слишком медленное для текущей стоимости железо видно сразу.
+func (s *Server) warmUpPasswordHashing(context.Context) error {
+	took, err := password.Calibrate()
+	if err != nil {
+		return err
+	}
+	fields := map[string]any{"cost": password.Cost, "duration_ms": took.Milliseconds()}
+	if took > s.cfg.Startup.PasswordHashWarn {
+		s.logger.Warn("bcrypt_slow", fields)
+	} else {
+		s.logger.Info("bcrypt_calibrated", fields)
+	}
+	return nil
+}
+
+// startPeriodic регистрирует периодическую задачу в lifecycle и в статистике GET /api/v1/admin/jobs.
+// С Redis запуски задачи защищены распределённой блокировкой: при нескольких репликах
+// каждый запуск выполняет только одна из них.
+func (s *Server) startPeriodic(job *worker.Periodic) {
+	if s.redis != nil {
+		job.UseLock(lock.NewRedisLocker(s.redis, "lock:"), s.cfg.Redis.JobLockTTL)
+	}
+	s.lifecycle.Register(job.Name(), job.Start, job.Stop)
+	s.periodicJobs = append(s.periodicJobs, job)
+}
+
 // setupMiddleware настраивает middleware для роутера
 func (s *Server) setupMiddleware() {
-	// Recovery middleware - должен быть первым для перехвата паник
-	s.router.Use(middleware.Recovery())
+	// RequestID middleware - идентификатор запроса (X-Request-ID) и логгер с полем request_id
+	s.router.Use(middleware.RequestID(s.logger))
 
-	// Logger middleware - логирование всех запросов
-	s.router.Use(middleware.LoggerStructured())
+	// Recovery middleware - должен идти до остальных для перехвата паник
+	s.router.Use(middleware.Recovery(s.logger))
+
+	// ServerTiming middleware - заголовок Server-Timing (db, cache, external) для мобильной команды
+	if s.cfg.Server.TimingEnabled {
+		s.router.Use(middleware.ServerTiming())
+	}
+
+	// Trace middleware - span запроса (traceparent) и поле trace_id в логгере запроса
+	s.router.Use(middleware.Trace())
+
+	// Logger middleware - структурированное логирование всех запросов
+	s.router.Use(middleware.Logger(s.logger))
 
 	// CORS middleware - настройка CORS
 	s.router.Use(middleware.CORS(&s.cfg.CORS))
+
+	// Compat middleware - переименование устаревших полей для старых версий мобильного приложения
+	s.router.Use(middleware.Compat(compat.NewRegistry(compat.DefaultRules()...), s.logger))
+
+	// MinClientVersion middleware - 426 Upgrade Required для неподдерживаемых версий мобильного приложения
+	s.router.Use(middleware.MinClientVersion(s.clientVersionService, s.logger))
+
+	// Deprecation middleware - заголовки Deprecation и Sunset для маршрутов, устаревших по журналу изменений API
+	s.router.Use(middleware.Deprecation(s.changelog, s.logger))
 }
 
 // setupRoutes настраивает маршруты приложения
@@ -139,17 +968,143 @@ func (s *Server) setupRoutes() {
 	s.setupHealthRoutes()
 	s.setupAuthRoutes()
 	s.setupUserRoutes()
+	s.setupMetricRoutes()
+	s.setupProgramRoutes()
+	s.setupVideoRoutes()
+	s.setupWorkoutRoutes()
+	s.setupImportRoutes()
+	s.setupHealthSyncRoutes()
+	s.setupDraftRoutes()
+	s.setupCheckInRoutes()
+	s.setupCustomMetricRoutes()
+	s.setupPresenceRoutes()
+	s.setupOrganizationRoutes()
+	s.setupGymClassRoutes()
+	s.setupGymCheckInRoutes()
+	s.setupChallengeRoutes()
+	s.setupNutritionRoutes()
+	s.setupHabitRoutes()
+	s.setupCoachRoutes()
+	s.setupWebhookRoutes()
+
+	// Публичные файлы локального хранилища (аватары) раздаются самим сервером.
+	// Выгрузки, резервные копии и видео доступны только по подписанным ссылкам своих handler'ов.
+	if s.cfg.Storage.Backend == "local" {
+		public := strings.TrimSuffix(storage.PublicPrefix, "/")
+		s.router.Static(s.cfg.Storage.LocalURLPrefix+"/"+public, filepath.Join(s.cfg.Storage.LocalDir, public))
+	}
 
 	s.router.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
+
+	if s.playgroundHandler != nil {
+		s.setupPlaygroundRoutes()
+	}
+}
+
+// setupPlaygroundRoutes настраивает песочницу API (только при PLAYGROUND_ENABLED=true вне production).
+// Песочница выдаёт токены без пароля, поэтому доступна только с loopback-адреса.
+func (s *Server) setupPlaygroundRoutes() {
+	playground := s.router.Group("/playground", middleware.LoopbackOnly())
+	// GET /playground — страница: выбор тестового пользователя и запросы к API с его токеном.
+	playground.GET("", s.playgroundHandler.Page)
+	// GET /playground/users — тестовые пользователи по ролям.
+	playground.GET("/users", s.playgroundHandler.Users)
+	// POST /playground/token — пара токенов тестового пользователя с указанной ролью, без пароля.
+	playground.POST("/token", s.playgroundHandler.Token)
 }
 
 // setupHealthRoutes настраивает health-check эндпоинты.
 func (s *Server) setupHealthRoutes() {
-	healthHandler := health.NewHandler(s.db, s.cfg.AppEnv)
+	healthHandler := health.NewHandler(s.db, s.cfg.AppEnv, s.startedAt, s.readinessChecks())
 	// GET /health — базовый health-check сервера (жив ли процесс).
 	s.router.GET("/health", healthHandler.Health)
 	// GET /health/db — проверка доступности базы данных.
 	s.router.GET("/health/db", healthHandler.HealthDB)
+	// GET /health/live — liveness probe: процесс обрабатывает запросы, зависимости не проверяются.
+	s.router.GET("/health/live", healthHandler.Live)
+	// GET /health/ready — readiness probe: завершение запуска, БД, актуальность миграций, Redis и SMTP с задержкой каждой проверки.
+	s.router.GET("/health/ready", healthHandler.Ready)
+
+	// GET /status — публичный статус сервиса для status-страницы (ограничен по IP).
+	statusLimiter := ratelimit.NewMemoryLimiter(statusRateLimit, time.Minute)
+	s.router.GET("/status",
+		middleware.RateLimit(statusLimiter, middleware.ByClientIP("status"), s.logger),
+		s.statusHandler.Status,
+	)
+}
+
+// backfillBatchPause — пауза между пачками задач пересчёта.
+const backfillBatchPause = 100 * time.Millisecond
+
+// clientVersionCacheTTL — как долго политики минимальных версий кешируются в памяти инстанса.
+const clientVersionCacheTTL = 30 * time.Second
+
+// exerciseLimitsCacheTTL — как долго справочник правдоподобных границ упражнений кешируется в памяти инстанса.
+const exerciseLimitsCacheTTL = 5 * time.Minute
+
+// usernameBlocklistCacheTTL — как долго запрещённые username, добавленные администраторами, кешируются в памяти инстанса.
+const usernameBlocklistCacheTTL = 30 * time.Second
+
+// statusRateLimit — максимальное количество запросов к /status в минуту с одного IP.
+const statusRateLimit = 60
+
+// statusChecks собирает проверки зависимостей для публичного статуса.
+// Незадействованные в текущей конфигурации подсистемы отображаются как not_configured.
+func (s *Server) statusChecks() []health.DependencyCheck {
+	checks := []health.DependencyCheck{
+		{Name: "db", Critical: true, Check: s.db.PingContext},
+		{Name: "cache"},
+		{Name: "mail"},
+		{Name: "storage"},
+	}
+	if s.redis != nil {
+		checks[1].Check = func(ctx context.Context) error {
+			return s.redis.Ping(ctx).Err()
+		}
+	}
+	if s.smtpSender != nil {
+		checks[2].Check = s.smtpSender.Ping
+	}
+	if s.storage != nil {
+		checks[3].Check = s.storage.Ping
+	}
+	return checks
+}
+
+// setupWebhookRoutes настраивает эндпоинты для webhooks внешних сервисов.
+// Запросы аутентифицируются общим секретом, а не JWT.
+func (s *Server) setupWebhookRoutes() {
+	if s.cfg.Email.WebhookSecret == "" {
+		return
+	}
+	webhookGroup := s.router.Group("/api/v1/webhooks")
+	{
+		// POST /api/v1/webhooks/email/:provider — события доставки писем от почтового провайдера.
+		webhookGroup.POST("/email/:provider", s.deliverabilityHandler.Webhook)
+	}
+}
+
+// readinessChecks собирает зависимости для readiness probe.
+// До завершения запуска (ожидание миграций и Redis, прогрев JWT и bcrypt), без БД и без актуальной схемы
+// сервис не может обслуживать запросы. Redis и SMTP проверяются, только если настроены, и их недоступность
+// после запуска не выводит инстанс из балансировки.
+func (s *Server) readinessChecks() []health.DependencyCheck {
+	checks := []health.DependencyCheck{
+		{Name: "startup", Critical: true, Check: s.lifecycle.Ready},
+		{Name: "db", Critical: true, Check: s.db.PingContext},
+		{Name: "migrations", Critical: true, Check: s.db.CheckSchema},
+		{Name: "cache"},
+		{Name: "smtp"},
+	}
+	if s.redis != nil {
+		checks[3].Check = func(ctx context.Context) error {
+			return s.redis.Ping(ctx).Err()
+		}
+	}
+	if s.smtpSender != nil {
+		checks[4].Check = s.smtpSender.Ping
+	}
+	return checks
 }
 
 // setupAuthRoutes настраивает эндпоинты аутентификации и корневой роут API.
@@ -160,23 +1115,239 @@ func (s *Server) setupAuthRoutes() {
 	v1.GET("/", func(c *gin.Context) {
 		c.JSON(http.StatusOK, gin.H{
 			"message": "Workout App API v1",
-			"version": "1.0.0",
+			"version": version.Version,
 		})
 	})
 
+	// GET /api/v1/meta/changelog — журнал изменений публичного API (?since=YYYY-MM-DD).
+	v1.GET("/meta/changelog", s.metaHandler.Changelog)
+
+	// GET /.well-known/jwks.json — открытые ключи для проверки access-токенов другими сервисами.
+	s.router.GET("/.well-known/jwks.json", jwks.NewHandler(s.jwtService).JWKS)
+
+	rl := s.cfg.RateLimit
 	authGroup := v1.Group("/auth")
 	{
 		// POST /api/v1/auth/register — регистрация нового пользователя по email/паролю/username.
-		authGroup.POST("/register", s.authHandler.Register)
+		authGroup.POST("/register", s.authRateLimit("auth_register", rl.RegisterPerIP, 0, s.authHandler.Register)...)
 		// POST /api/v1/auth/login — аутентификация пользователя по email/паролю.
-		authGroup.POST("/login", s.authHandler.Login)
+		authGroup.POST("/login", s.authRateLimit("auth_login", rl.LoginPerIP, rl.LoginPerEmail, s.authHandler.Login)...)
 		// POST /api/v1/auth/verify-email — подтверждение email одноразовым кодом.
-		authGroup.POST("/verify-email", s.authHandler.VerifyEmail)
+		authGroup.POST("/verify-email", s.authRateLimit("auth_verify", rl.VerifyPerIP, rl.VerifyPerEmail, s.authHandler.VerifyEmail)...)
 		// POST /api/v1/auth/resend-verification — повторная отправка кода подтверждения email.
-		authGroup.POST("/resend-verification", s.authHandler.ResendVerification)
+		authGroup.POST("/resend-verification", s.authRateLimit("auth_resend", rl.ResendPerIP, rl.ResendPerEmail, s.authHandler.ResendVerification)...)
+		// POST /api/v1/auth/reset-password — новый пароль по коду сброса, отправленному администратором.
+		authGroup.POST("/reset-password", s.authRateLimit("auth_reset_password", rl.VerifyPerIP, rl.VerifyPerEmail, s.authHandler.ResetPassword)...)
+		// GET /api/v1/auth/check-username — свободен ли username (для проверки формы регистрации).
+		authGroup.GET("/check-username", s.authRateLimit("auth_check_username", rl.CheckPerIP, 0, s.authHandler.CheckUsername)...)
+		// GET /api/v1/auth/check-email — свободен ли email (без раскрытия статуса подтверждения).
+		authGroup.GET("/check-email", s.authRateLimit("auth_check_email", rl.CheckPerIP, 0, s.authHandler.CheckEmail)...)
 		// POST /api/v1/auth/refresh — обновление пары access/refresh токенов по refresh-токену.
 		authGroup.POST("/refresh", s.authHandler.Refresh)
+		// POST /api/v1/auth/oauth/:provider — вход через Google или Apple по ID-токену провайдера.
+		authGroup.POST("/oauth/:provider", s.authRateLimit("auth_oauth", rl.LoginPerIP, 0, s.oauthHandler.Login)...)
+	}
+}
+
+// authRateLimit добавляет к handler ограничение частоты запросов по IP и (если perEmail > 0) по email из тела.
+// Email приводится к канонической форме, как при поиске пользователя, поэтому варианты написания
+// одного ящика (точки и +метки по EMAIL_FOLD_*) расходуют общий лимит.
+// При RATE_LIMIT_ENABLED=false возвращает handler без ограничений.
+func (s *Server) authRateLimit(name string, perIP, perEmail int, handler gin.HandlerFunc) []gin.HandlerFunc {
+	if !s.cfg.RateLimit.Enabled {
+		return []gin.HandlerFunc{handler}
+	}
+	chain := []gin.HandlerFunc{
+		middleware.RateLimit(s.newRateLimiter(perIP), middleware.ByClientIP(name), s.logger),
+	}
+	if perEmail > 0 {
+		chain = append(chain, middleware.RateLimit(s.newRateLimiter(perEmail), middleware.ByJSONField(name, "email", s.emailPolicy.Canonical), s.logger))
 	}
+	return append(chain, handler)
+}
+
+// userRateLimit добавляет к handler аутентифицированного пользователя ограничение частоты запросов
+// по IP и по ID пользователя. Должно подключаться после Auth.
+func (s *Server) userRateLimit(name string, perIP, perUser int, handler gin.HandlerFunc) []gin.HandlerFunc {
+	if !s.cfg.RateLimit.Enabled {
+		return []gin.HandlerFunc{handler}
+	}
+	return []gin.HandlerFunc{
+		middleware.RateLimit(s.newRateLimiter(perIP), middleware.ByClientIP(name), s.logger),
+		middleware.RateLimit(s.newRateLimiter(perUser), middleware.ByUserID(name), s.logger),
+		handler,
+	}
+}
+
+// newTenantEmailSettingsRepository создаёт репозиторий настроек отправки писем организаций
+// или возвращает nil, если ключ шифрования секретов не задан.
+func (s *Server) newTenantEmailSettingsRepository() repo.OrganizationEmailSettingsRepository {
+	if s.cfg.Email.TenantSecretKey == "" {
+		return nil
+	}
+	// Ключ валидирован в config.Validate, ошибки здесь невозможны.
+	key, _ := secretbox.ParseKey(s.cfg.Email.TenantSecretKey)
+	box, _ := secretbox.New(key)
+	return pgrepo.NewOrganizationEmailSettingsRepository(s.db.DB, box)
+}
+
+// newWebhookEndpointRepository создаёт репозиторий адресов webhooks
+// или возвращает nil, если ключ шифрования секретов не задан.
+func (s *Server) newWebhookEndpointRepository() repo.WebhookEndpointRepository {
+	if s.cfg.Webhook.SecretKey == "" {
+		return nil
+	}
+	// Ключ валидирован в config.Validate, ошибки здесь невозможны.
+	key, _ := secretbox.ParseKey(s.cfg.Webhook.SecretKey)
+	box, _ := secretbox.New(key)
+	return pgrepo.NewWebhookEndpointRepository(s.db.DB, box)
+}
+
+// newEmailProvider создаёт клиент API почтового провайдера, выбранного в EMAIL_PROVIDER.
+// Параметры провайдера проверены в config.Validate.
+func (s *Server) newEmailProvider() mailerpkg.Provider {
+	cfg := s.cfg.Email
+	switch cfg.Provider {
+	case "sendgrid":
+		return mailerpkg.NewSendGrid(mailerpkg.SendGridConfig{
+			APIKey: cfg.SendGridAPIKey,
+			Client: s.httpClients.Client("email-sendgrid", cfg.ProviderTimeout),
+		})
+	case "ses":
+		return mailerpkg.NewSES(mailerpkg.SESConfig{
+			Region:          cfg.SESRegion,
+			AccessKeyID:     cfg.SESAccessKeyID,
+			SecretAccessKey: cfg.SESSecretAccessKey,
+			Client:          s.httpClients.Client("email-ses", cfg.ProviderTimeout),
+		})
+	default:
+		return mailerpkg.NewMailgun(mailerpkg.MailgunConfig{
+			APIKey:  cfg.MailgunAPIKey,
+			Domain:  cfg.MailgunDomain,
+			BaseURL: cfg.MailgunBaseURL,
+			Client:  s.httpClients.Client("email-mailgun", cfg.ProviderTimeout),
+		})
+	}
+}
+
+// newPushSenders создаёт клиенты FCM и APNs по конфигурации.
+// Для платформы без учётных данных уведомления записываются в лог вместо отправки.
+// При ошибке настройки клиента платформа тоже остаётся на логировании, чтобы сервис запустился.
+func (s *Server) newPushSenders() map[device.Platform]pushpkg.Sender {
+	cfg := s.cfg.Push
+	senders := map[device.Platform]pushpkg.Sender{
+		device.PlatformAndroid: push.NewLogSender(string(device.PlatformAndroid), s.logger),
+		device.PlatformIOS:     push.NewLogSender(string(device.PlatformIOS), s.logger),
+	}
+	if cfg.FCMCredentialsFile != "" {
+		if fcm, err := s.newFCMSender(); err != nil {
+			s.logger.Error("push_config_invalid", map[string]any{"platform": string(device.PlatformAndroid), "error": err.Error()})
+		} else {
+			senders[device.PlatformAndroid] = fcm
+		}
+	}
+	if cfg.APNsKeyFile != "" {
+		if apns, err := s.newAPNsSender(); err != nil {
+			s.logger.Error("push_config_invalid", map[string]any{"platform": string(device.PlatformIOS), "error": err.Error()})
+		} else {
+			senders[device.PlatformIOS] = apns
+		}
+	}
+	return senders
+}
+
+// newFCMSender создаёт клиент FCM из файла сервисного аккаунта.
+func (s *Server) newFCMSender() (pushpkg.Sender, error) {
+	cfg := s.cfg.Push
+	creds, err := pushpkg.LoadFCMCredentials(cfg.FCMCredentialsFile)
+	if err != nil {
+		return nil, err
+	}
+	fcm, err := pushpkg.NewFCM(pushpkg.FCMConfig{
+		Credentials: creds,
+		Client:      s.httpClients.Client("push-fcm", cfg.Timeout),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return fcm, nil
+}
+
+// newAPNsSender создаёт клиент APNs из ключа .p8.
+func (s *Server) newAPNsSender() (pushpkg.Sender, error) {
+	cfg := s.cfg.Push
+	key, err := os.ReadFile(cfg.APNsKeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("read apns key: %w", err)
+	}
+	baseURL := pushpkg.APNsSandboxURL
+	if cfg.APNsEnvironment == "production" {
+		baseURL = pushpkg.APNsProductionURL
+	}
+	apns, err := pushpkg.NewAPNs(pushpkg.APNsConfig{
+		KeyID:      cfg.APNsKeyID,
+		TeamID:     cfg.APNsTeamID,
+		PrivateKey: key,
+		Topic:      cfg.APNsTopic,
+		BaseURL:    baseURL,
+		Client:     s.httpClients.Client("push-apns", cfg.Timeout),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return apns, nil
+}
+
+// newTenantEmailLimiter создаёт ограничитель писем организации в час.
+// Счётчики общие для всех инстансов, если настроен Redis.
+func (s *Server) newTenantEmailLimiter(limit int) ratelimit.Limiter {
+	if s.redis != nil {
+		return ratelimit.NewRedisLimiter(s.redis, "tenant_email:", limit, time.Hour)
+	}
+	return ratelimit.NewMemoryLimiter(limit, time.Hour)
+}
+
+// newRateLimiter создаёт ограничитель на окно RATE_LIMIT_WINDOW в выбранном хранилище.
+// Если Redis недоступен по конфигурации, используется in-memory хранилище.
+func (s *Server) newRateLimiter(limit int) ratelimit.Limiter {
+	window := s.cfg.RateLimit.Window
+	if s.cfg.RateLimit.Backend == "redis" && s.redis != nil {
+		return ratelimit.NewRedisLimiter(s.redis, "ratelimit:", limit, window)
+	}
+	return ratelimit.NewMemoryLimiter(limit, window)
+}
+
+// newStorage создаёт хранилище пользовательских файлов по конфигурации.
+// При ошибке настройки S3 используется локальное хранилище, чтобы сервис оставался доступен.
+func (s *Server) newStorage() storage.Storage {
+	cfg := s.cfg.Storage
+	if cfg.Backend == "s3" {
+		st, err := storage.NewS3Storage(storage.S3Config{
+			Endpoint:  cfg.S3Endpoint,
+			Region:    cfg.S3Region,
+			Bucket:    cfg.S3Bucket,
+			AccessKey: cfg.S3AccessKey,
+			SecretKey: cfg.S3SecretKey,
+			PathStyle: cfg.S3PathStyle,
+			PublicURL: cfg.S3PublicURL,
+		}, s.httpClients.Client("storage-s3", 30*time.Second))
+		if err == nil {
+			return st
+		}
+		s.logger.Error("storage_config_invalid", map[string]any{"error": err.Error()})
+	}
+	return storage.NewLocalStorage(cfg.LocalDir, strings.TrimRight(cfg.PublicBaseURL, "/")+cfg.LocalURLPrefix)
+}
+
+// newRegionPolicy строит политику региональных ограничений из записей вида регион:функция.
+// Формат записей проверяется при загрузке конфигурации.
+func newRegionPolicy(entries []string) region.Policy {
+	disabled := make(map[region.Region][]region.Feature)
+	for _, entry := range entries {
+		r, f, _ := strings.Cut(entry, ":")
+		disabled[region.Region(r)] = append(disabled[region.Region(r)], region.Feature(f))
+	}
+	return region.NewPolicy(disabled)
 }
 
 // setupUserRoutes настраивает защищённые эндпоинты пользователя.
@@ -184,28 +1355,746 @@ func (s *Server) setupUserRoutes() {
 	v1 := s.router.Group("/api/v1")
 
 	userGroup := v1.Group("/users")
-	userGroup.Use(middleware.Auth(s.jwtService, s.logger))
+	userGroup.Use(s.authMiddleware)
 	{
 		// GET /api/v1/users/me — получить профиль текущего аутентифицированного пользователя.
 		userGroup.GET("/me", s.userHandler.GetMe)
-		// PUT /api/v1/users/me — обновить профиль текущего пользователя.
-		userGroup.PUT("/me", s.userHandler.UpdateMe)
+		// PUT /api/v1/users/me — обновить профиль текущего пользователя (смена username пишется в историю в той же транзакции).
+		userGroup.PUT("/me", s.txMiddleware, s.userHandler.UpdateMe)
 		// DELETE /api/v1/users/me — мягко удалить (деактивировать) аккаунт текущего пользователя.
 		userGroup.DELETE("/me", s.userHandler.DeleteMe)
+		// POST /api/v1/users/me/avatar — загрузить аватар (multipart/form-data, поле file).
+		userGroup.POST("/me/avatar", s.regionFeature(region.FeatureAvatarUpload), s.avatarHandler.Upload)
+		// GET /api/v1/users/me/backup — версии зашифрованной резервной копии и квота.
+		userGroup.GET("/me/backup", s.backupHandler.Overview)
+		// PUT /api/v1/users/me/backup — загрузить новую версию резервной копии (application/octet-stream, X-Backup-SHA256).
+		userGroup.PUT("/me/backup", s.backupHandler.Upload)
+		// GET /api/v1/users/me/backup/content — скачать версию резервной копии (?version=, по умолчанию последнюю).
+		userGroup.GET("/me/backup/content", s.backupHandler.Download)
+		// DELETE /api/v1/users/me/backup — удалить все версии резервной копии.
+		userGroup.DELETE("/me/backup", s.backupHandler.Delete)
+		// POST /api/v1/users/me/change-password — сменить пароль (с проверкой текущего), отзывает refresh-токены.
+		// Лимит по пользователю не даёт перебирать текущий пароль с украденным access-токеном.
+		userGroup.POST("/me/change-password", s.userRateLimit("change_password", s.cfg.RateLimit.LoginPerIP, s.cfg.RateLimit.LoginPerEmail, s.authHandler.ChangePassword)...)
 		// POST /api/v1/users/me/change-email — запросить изменение email (отправка кода на новый email).
 		userGroup.POST("/me/change-email", s.userHandler.RequestEmailChange)
 		// POST /api/v1/users/me/verify-email-change — подтвердить изменение email по коду.
 		userGroup.POST("/me/verify-email-change", s.userHandler.VerifyEmailChange)
+		// GET /api/v1/users/me/experiments — варианты текущего пользователя в активных A/B-экспериментах.
+		userGroup.GET("/me/experiments", s.regionFeature(region.FeatureExperiments), s.experimentHandler.GetMyAssignments)
+		// POST /api/v1/users/me/experiments/:key/events — записать показ/конверсию в эксперименте.
+		userGroup.POST("/me/experiments/:key/events", s.regionFeature(region.FeatureExperiments), s.experimentHandler.RecordEvent)
+		// GET /api/v1/users/me/coach-consents — классы данных, открытые тренерам.
+		userGroup.GET("/me/coach-consents", s.consentHandler.List)
+		// PUT /api/v1/users/me/coach-consents/:coachId — изменить классы данных, открытые тренеру.
+		userGroup.PUT("/me/coach-consents/:coachId", s.consentHandler.Set)
+		// DELETE /api/v1/users/me/coach-consents/:coachId — отозвать доступ тренера (удаляет его заметки и теги о пользователе).
+		userGroup.DELETE("/me/coach-consents/:coachId", s.txMiddleware, s.consentHandler.Revoke)
+		// GET /api/v1/users/me/coach-invitations — действующие приглашения тренеров.
+		userGroup.GET("/me/coach-invitations", s.coachClientHandler.ListMyInvitations)
+		// POST /api/v1/users/me/coach-invitations/:id/accept — принять приглашение и стать клиентом тренера.
+		userGroup.POST("/me/coach-invitations/:id/accept", s.coachClientHandler.AcceptInvitation)
+		// DELETE /api/v1/users/me/coach-invitations/:id — отклонить приглашение тренера.
+		userGroup.DELETE("/me/coach-invitations/:id", s.coachClientHandler.DeclineInvitation)
+		// GET /api/v1/users/me/organizations — организации текущего пользователя и его роли.
+		userGroup.GET("/me/organizations", s.organizationHandler.ListMine)
+		// GET /api/v1/users/me/organization-invitations — действующие приглашения в команды организаций.
+		userGroup.GET("/me/organization-invitations", s.organizationHandler.ListMyInvitations)
+		// POST /api/v1/users/me/organization-invitations/:id/accept — принять приглашение в команду.
+		userGroup.POST("/me/organization-invitations/:id/accept", s.organizationHandler.AcceptInvitation)
+		// DELETE /api/v1/users/me/organization-invitations/:id — отклонить приглашение.
+		userGroup.DELETE("/me/organization-invitations/:id", s.organizationHandler.DeclineInvitation)
+		// GET /api/v1/users/me/export — выгрузка всех данных аккаунта (ставит в очередь или возвращает ссылку).
+		userGroup.GET("/me/export", s.exportHandler.Request)
+		// GET /api/v1/users/me/stats — статистика тренировок для дашборда (?range=7d|30d|90d|365d|all).
+		userGroup.GET("/me/stats", s.progressHandler.Stats)
+		// GET /api/v1/users/me/username-history — история смены username текущего пользователя.
+		userGroup.GET("/me/username-history", s.userHandler.GetUsernameHistory)
+		// GET /api/v1/users/me/profile-history — история изменений профиля текущего пользователя.
+		userGroup.GET("/me/profile-history", s.userHandler.GetProfileHistory)
+		// GET /api/v1/users/me/followers — подписчики текущего пользователя (?limit=&offset=).
+		userGroup.GET("/me/followers", s.socialHandler.Followers)
+		// GET /api/v1/users/me/following — подписки текущего пользователя (?limit=&offset=).
+		userGroup.GET("/me/following", s.socialHandler.Following)
+		// GET /api/v1/users/me/notifications — уведомления текущего пользователя (?unread=&limit=&offset=).
+		userGroup.GET("/me/notifications", s.notificationHandler.List)
+		// POST /api/v1/users/me/notifications/read-all — отметить все уведомления прочитанными.
+		userGroup.POST("/me/notifications/read-all", s.notificationHandler.MarkAllRead)
+		// POST /api/v1/users/me/notifications/:id/read — отметить уведомление прочитанным.
+		userGroup.POST("/me/notifications/:id/read", s.notificationHandler.MarkRead)
+		// GET /api/v1/users/me/notification-preferences — настройки писем и push по типам уведомлений.
+		userGroup.GET("/me/notification-preferences", s.notificationHandler.GetPreferences)
+		// PUT /api/v1/users/me/notification-preferences — включить или отключить письма и push по типам уведомлений.
+		userGroup.PUT("/me/notification-preferences", s.notificationHandler.UpdatePreferences)
+		// POST /api/v1/users/me/devices — зарегистрировать устройство для push-уведомлений.
+		userGroup.POST("/me/devices", s.pushHandler.RegisterDevice)
+		// GET /api/v1/users/me/devices — устройства текущего пользователя.
+		userGroup.GET("/me/devices", s.pushHandler.ListDevices)
+		// DELETE /api/v1/users/me/devices/:id — отключить push-уведомления на устройстве.
+		userGroup.DELETE("/me/devices/:id", s.pushHandler.DeleteDevice)
+		// GET /api/v1/users/by-username/:username — публичный профиль по username; прежние имена перенаправляются на новое.
+		userGroup.GET("/by-username/:username", s.userHandler.GetByUsername)
 		// GET /api/v1/users/:id — получить публичный профиль пользователя по ID.
 		userGroup.GET("/:id", s.userHandler.GetByID)
+		// POST /api/v1/users/:id/follow — подписаться на пользователя.
+		userGroup.POST("/:id/follow", s.socialHandler.Follow)
+		// DELETE /api/v1/users/:id/follow — отписаться от пользователя.
+		userGroup.DELETE("/:id/follow", s.socialHandler.Unfollow)
+		// GET /api/v1/users/:id/followers — подписчики пользователя (?limit=&offset=).
+		userGroup.GET("/:id/followers", s.socialHandler.Followers)
+		// GET /api/v1/users/:id/following — подписки пользователя (?limit=&offset=).
+		userGroup.GET("/:id/following", s.socialHandler.Following)
+		// GET /api/v1/users/:id/workouts — тренировки пользователя с учётом его настройки видимости (?from=&to=).
+		userGroup.GET("/:id/workouts", s.workoutHandler.ListUserWorkouts)
 	}
 
 	// Админские роуты
 	adminGroup := v1.Group("/admin")
-	adminGroup.Use(middleware.Auth(s.jwtService, s.logger), middleware.RequireRole(s.logger, domain.RoleAdmin))
+	// Каждый запрос администратора, включая чтение списков с фильтрами, записывается в журнал действий.
+	adminGroup.Use(s.authMiddleware, middleware.RequireRole(s.logger, domain.RoleAdmin), middleware.AdminAudit(s.auditService, s.logger))
 	{
+		// GET /api/v1/admin/audit-logs — журнал действий администраторов (?actor_id=&target_id=&action=&from=&to=).
+		adminGroup.GET("/audit-logs", s.auditHandler.List)
 		// GET /api/v1/admin/users — список всех активных пользователей (только для admin).
 		adminGroup.GET("/users", s.userHandler.ListUsers)
+		// PATCH /api/v1/admin/users/:id/role — изменить роль пользователя (user/coach/admin).
+		adminGroup.PATCH("/users/:id/role", s.txMiddleware, s.userHandler.ChangeRole)
+		// POST /api/v1/admin/users/:id/suspend — заблокировать аккаунт пользователя.
+		adminGroup.POST("/users/:id/suspend", s.txMiddleware, s.userHandler.Suspend)
+		// POST /api/v1/admin/users/:id/unsuspend — снять блокировку аккаунта пользователя.
+		adminGroup.POST("/users/:id/unsuspend", s.txMiddleware, s.userHandler.Unsuspend)
+		// GET /api/v1/admin/users/:id — профиль пользователя с состоянием подтверждения email и удаления.
+		adminGroup.GET("/users/:id", s.userHandler.GetUserForAdmin)
+		// GET /api/v1/admin/users/:id/history — история изменений профиля пользователя с автором изменений.
+		adminGroup.GET("/users/:id/history", s.userHandler.GetUserHistory)
+		// POST /api/v1/admin/users/:id/verify-email — подтвердить email пользователя без кода.
+		adminGroup.POST("/users/:id/verify-email", s.txMiddleware, s.userHandler.ForceVerifyEmail)
+		// POST /api/v1/admin/users/:id/password-reset — отправить пользователю код сброса пароля.
+		adminGroup.POST("/users/:id/password-reset", s.userHandler.SendPasswordReset)
+		// POST /api/v1/admin/users/:id/purge — окончательно удалить (обезличить) пользователя.
+		adminGroup.POST("/users/:id/purge", s.userHandler.Purge)
+		// DELETE /api/v1/admin/users/:id — удалить запись пользователя целиком (после обезличивания).
+		adminGroup.DELETE("/users/:id", s.userHandler.HardDelete)
+		// GET /api/v1/admin/users/:id/legal-hold — юридическое удержание пользователя и журнал аудита.
+		adminGroup.GET("/users/:id/legal-hold", s.legalHoldHandler.Get)
+		// PUT /api/v1/admin/users/:id/legal-hold — установить удержание (блокирует окончательное удаление).
+		adminGroup.PUT("/users/:id/legal-hold", s.legalHoldHandler.Place)
+		// DELETE /api/v1/admin/users/:id/legal-hold — снять удержание.
+		adminGroup.DELETE("/users/:id/legal-hold", s.legalHoldHandler.Release)
+		// GET /api/v1/admin/experiments — список всех A/B-экспериментов.
+		adminGroup.GET("/experiments", s.experimentHandler.ListExperiments)
+		// POST /api/v1/admin/experiments — создать A/B-эксперимент.
+		adminGroup.POST("/experiments", s.experimentHandler.CreateExperiment)
+		// PUT /api/v1/admin/experiments/:key — обновить варианты/активность эксперимента.
+		adminGroup.PUT("/experiments/:key", s.experimentHandler.UpdateExperiment)
+		// GET /api/v1/admin/client-versions — минимальные поддерживаемые версии приложения.
+		adminGroup.GET("/client-versions", s.clientVersionHandler.ListPolicies)
+		// PUT /api/v1/admin/client-versions/:platform — задать минимальную версию для платформы.
+		adminGroup.PUT("/client-versions/:platform", s.clientVersionHandler.SetPolicy)
+		// PUT /api/v1/admin/strength-standards/:exercise — заменить норматив силы упражнения для пола.
+		adminGroup.PUT("/strength-standards/:exercise", s.strengthHandler.SetStandard)
+		// DELETE /api/v1/admin/client-versions/:platform — снять ограничение версии для платформы.
+		adminGroup.DELETE("/client-versions/:platform", s.clientVersionHandler.DeletePolicy)
+		// GET /api/v1/admin/jobs — статистика фоновых задач, глубина очередей и последние ошибки.
+		adminGroup.GET("/jobs", s.jobsHandler.Status)
+		// GET /api/v1/admin/http-clients — обращения к внешним сервисам по интеграциям и хостам.
+		adminGroup.GET("/http-clients", s.httpClientHandler.Stats)
+		// GET /api/v1/admin/db-fast-path — чтения пользователей через GORM и pgx и сверка быстрого пути.
+		adminGroup.GET("/db-fast-path", s.dbFastPathHandler.Stats)
+		// GET /api/v1/admin/maintenance — список кешей для служебных операций.
+		adminGroup.GET("/maintenance", s.maintenanceHandler.Info)
+		// POST /api/v1/admin/maintenance/caches/:name/invalidate — сбросить кеш.
+		adminGroup.POST("/maintenance/caches/:name/invalidate", s.maintenanceHandler.InvalidateCache)
+		// GET /api/v1/admin/maintenance/cleanup — статистика фоновой очистки устаревших записей.
+		adminGroup.GET("/maintenance/cleanup", s.maintenanceHandler.CleanupStatus)
+		// POST /api/v1/admin/maintenance/cleanup/run — немедленно удалить истёкшие коды подтверждения и т.п.
+		adminGroup.POST("/maintenance/cleanup/run", s.maintenanceHandler.RunCleanup)
+		// GET /api/v1/admin/backfills — последние задачи пересчёта и доступные типы задач.
+		adminGroup.GET("/backfills", s.backfillHandler.List)
+		// POST /api/v1/admin/backfills — запустить задачу пересчёта.
+		adminGroup.POST("/backfills", s.backfillHandler.Launch)
+		// GET /api/v1/admin/backfills/:id — прогресс задачи пересчёта.
+		adminGroup.GET("/backfills/:id", s.backfillHandler.Get)
+		// POST /api/v1/admin/backfills/:id/cancel — отменить задачу пересчёта.
+		adminGroup.POST("/backfills/:id/cancel", s.backfillHandler.Cancel)
+		// GET /api/v1/admin/username-blocklist — запрещённые username, добавленные администраторами.
+		adminGroup.GET("/username-blocklist", s.usernameBlockHandler.List)
+		// POST /api/v1/admin/username-blocklist — запретить username (совпадение целиком или вхождение).
+		adminGroup.POST("/username-blocklist", s.usernameBlockHandler.Add)
+		// DELETE /api/v1/admin/username-blocklist/:id — удалить запрещённый username.
+		adminGroup.DELETE("/username-blocklist/:id", s.usernameBlockHandler.Remove)
+		// GET /api/v1/admin/email/deliverability — исходы доставки писем по провайдерам (?days=30).
+		adminGroup.GET("/email/deliverability", s.deliverabilityHandler.Report)
+		// GET /api/v1/admin/email/suppressions — список подавления писем (?source=&limit=&offset=).
+		adminGroup.GET("/email/suppressions", s.suppressionHandler.List)
+		// POST /api/v1/admin/email/suppressions — вручную добавить адрес в список подавления.
+		adminGroup.POST("/email/suppressions", s.suppressionHandler.Add)
+		// POST /api/v1/admin/email/suppressions/import — импортировать CSV-выгрузку провайдера.
+		adminGroup.POST("/email/suppressions/import", s.suppressionHandler.Import)
+		// DELETE /api/v1/admin/email/suppressions/:email — удалить адрес из списка подавления.
+		adminGroup.DELETE("/email/suppressions/:email", s.suppressionHandler.Remove)
+		// GET /api/v1/admin/email/outbox — очередь исходящих писем по статусам (?status=dead&limit=&offset=).
+		adminGroup.GET("/email/outbox", s.emailOutboxHandler.List)
+		// POST /api/v1/admin/email/outbox/:id/retry — вернуть письмо из dead letter в очередь.
+		adminGroup.POST("/email/outbox/:id/retry", s.emailOutboxHandler.Retry)
+		// POST /api/v1/admin/organizations — создать организацию (зал) с владельцем.
+		adminGroup.POST("/organizations", s.organizationHandler.Create)
+		// POST /api/v1/admin/organizations/:id/members — добавить участника организации.
+		adminGroup.POST("/organizations/:id/members", s.organizationHandler.AddMember)
+		// DELETE /api/v1/admin/organizations/:id/members/:userId — исключить участника организации.
+		adminGroup.DELETE("/organizations/:id/members/:userId", s.organizationHandler.RemoveMember)
+		// PUT /api/v1/admin/coaches/:id/verification — отметить тренера как проверенного.
+		adminGroup.PUT("/coaches/:id/verification", s.coachHandler.Verify)
+		// DELETE /api/v1/admin/coaches/:id/verification — снять отметку «проверенный тренер».
+		adminGroup.DELETE("/coaches/:id/verification", s.coachHandler.Unverify)
+		// GET /api/v1/admin/webhooks — адреса внешних систем, получающих уведомления о событиях аккаунта.
+		adminGroup.GET("/webhooks", s.webhookHandler.List)
+		// POST /api/v1/admin/webhooks — добавить адрес (ключ подписи возвращается один раз).
+		adminGroup.POST("/webhooks", s.webhookHandler.Create)
+		// PATCH /api/v1/admin/webhooks/:id — изменить адрес, события или выключить его.
+		adminGroup.PATCH("/webhooks/:id", s.webhookHandler.Update)
+		// DELETE /api/v1/admin/webhooks/:id — удалить адрес вместе с его уведомлениями.
+		adminGroup.DELETE("/webhooks/:id", s.webhookHandler.Delete)
+		// POST /api/v1/admin/webhooks/:id/rotate-secret — заменить ключ подписи.
+		adminGroup.POST("/webhooks/:id/rotate-secret", s.webhookHandler.RotateSecret)
+		// GET /api/v1/admin/webhooks/:id/deliveries — уведомления адреса (?status=dead&limit=&offset=).
+		adminGroup.GET("/webhooks/:id/deliveries", s.webhookHandler.ListDeliveries)
+		// POST /api/v1/admin/webhooks/deliveries/:id/retry — вернуть уведомление из dead letter в очередь.
+		adminGroup.POST("/webhooks/deliveries/:id/retry", s.webhookHandler.RetryDelivery)
+	}
+}
+
+// setupOrganizationRoutes настраивает эндпоинты, которыми управляет команда организации.
+// Доступ проверяет orgRole по роли в организации; администраторы проходят проверку с правами владельца.
+func (s *Server) setupOrganizationRoutes() {
+	v1 := s.router.Group("/api/v1")
+
+	owner := s.orgRole(orgdomain.RoleOwner)
+	team := s.orgRole(orgdomain.RoleOwner, orgdomain.RoleManager, orgdomain.RoleStaff)
+
+	orgGroup := v1.Group("/organizations")
+	orgGroup.Use(s.authMiddleware)
+	{
+		// GET /api/v1/organizations/:id/email-settings — собственные настройки отправки писем организации.
+		orgGroup.GET("/:id/email-settings", owner, s.organizationHandler.GetEmailSettings)
+		// PUT /api/v1/organizations/:id/email-settings — задать отправителя и SMTP организации.
+		orgGroup.PUT("/:id/email-settings", owner, s.organizationHandler.SetEmailSettings)
+		// DELETE /api/v1/organizations/:id/email-settings — вернуться к платформенной отправке.
+		orgGroup.DELETE("/:id/email-settings", owner, s.organizationHandler.DeleteEmailSettings)
+		// GET /api/v1/organizations/:id/members — участники организации, команда первой (?limit=&offset=).
+		orgGroup.GET("/:id/members", team, s.organizationHandler.ListMembers)
+		// POST /api/v1/organizations/:id/invitations — пригласить пользователя в команду (владелец, менеджер).
+		orgGroup.POST("/:id/invitations", s.orgRole(orgdomain.RoleOwner, orgdomain.RoleManager), s.organizationHandler.Invite)
+		// POST /api/v1/organizations/:id/transfer-ownership — передать владение участнику организации.
+		orgGroup.POST("/:id/transfer-ownership", owner, s.organizationHandler.TransferOwnership)
+	}
+}
+
+// setupGymClassRoutes настраивает эндпоинты групповых занятий организаций и записей на них.
+func (s *Server) setupGymClassRoutes() {
+	v1 := s.router.Group("/api/v1")
+
+	orgGroup := v1.Group("/organizations")
+	orgGroup.Use(s.authMiddleware, s.txMiddleware)
+	{
+		// POST /api/v1/organizations/:id/classes — создать групповое занятие (владелец).
+		orgGroup.POST("/:id/classes", s.gymClassHandler.Create)
+		// GET /api/v1/organizations/:id/classes — занятия организации с еженедельными слотами.
+		orgGroup.GET("/:id/classes", s.gymClassHandler.List)
+		// GET /api/v1/organizations/:id/class-schedule — расписание занятий за период (?from=&to=).
+		orgGroup.GET("/:id/class-schedule", s.gymClassHandler.Schedule)
+	}
+
+	classGroup := v1.Group("/classes")
+	classGroup.Use(s.authMiddleware, s.txMiddleware)
+	{
+		// PUT /api/v1/classes/:id — изменить занятие (владелец).
+		classGroup.PUT("/:id", s.gymClassHandler.Update)
+		// DELETE /api/v1/classes/:id — удалить занятие (владелец).
+		classGroup.DELETE("/:id", s.gymClassHandler.Delete)
+		// POST /api/v1/classes/:id/slots — добавить еженедельный слот (владелец).
+		classGroup.POST("/:id/slots", s.gymClassHandler.AddSlot)
+		// DELETE /api/v1/classes/:id/slots/:slot_id — удалить слот (владелец).
+		classGroup.DELETE("/:id/slots/:slot_id", s.gymClassHandler.DeleteSlot)
+		// GET /api/v1/classes/:id/roster — записавшиеся на занятие (?starts_at=; тренер или владелец).
+		classGroup.GET("/:id/roster", s.gymClassHandler.Roster)
+		// POST /api/v1/classes/:id/bookings — записаться на занятие или в лист ожидания.
+		classGroup.POST("/:id/bookings", s.gymClassHandler.Book)
+	}
+
+	bookingGroup := v1.Group("/class-bookings")
+	bookingGroup.Use(s.authMiddleware, s.txMiddleware)
+	{
+		// GET /api/v1/class-bookings — мои записи на занятия за период (?from=&to=).
+		bookingGroup.GET("", s.gymClassHandler.ListMine)
+		// DELETE /api/v1/class-bookings/:id — отменить запись; место получает первый из листа ожидания.
+		bookingGroup.DELETE("/:id", s.gymClassHandler.Cancel)
+		// PUT /api/v1/class-bookings/:id/attendance — отметить посещение (тренер или владелец).
+		bookingGroup.PUT("/:id/attendance", s.gymClassHandler.MarkAttendance)
+	}
+}
+
+// setupChallengeRoutes настраивает эндпоинты челленджей.
+func (s *Server) setupChallengeRoutes() {
+	v1 := s.router.Group("/api/v1")
+
+	challengeGroup := v1.Group("/challenges")
+	challengeGroup.Use(s.authMiddleware)
+	{
+		// POST /api/v1/challenges — создать челлендж (создатель становится участником).
+		challengeGroup.POST("", s.challengeHandler.Create)
+		// GET /api/v1/challenges — незавершённые челленджи или челленджи пользователя (?scope=open|joined&limit=&offset=).
+		challengeGroup.GET("", s.challengeHandler.List)
+		// GET /api/v1/challenges/:id — челлендж и прогресс текущего пользователя.
+		challengeGroup.GET("/:id", s.challengeHandler.Get)
+		// DELETE /api/v1/challenges/:id — удалить челлендж (создатель или admin).
+		challengeGroup.DELETE("/:id", s.challengeHandler.Delete)
+		// POST /api/v1/challenges/:id/join — вступить в челлендж.
+		challengeGroup.POST("/:id/join", s.challengeHandler.Join)
+		// DELETE /api/v1/challenges/:id/join — выйти из челленджа.
+		challengeGroup.DELETE("/:id/join", s.challengeHandler.Leave)
+		// GET /api/v1/challenges/:id/leaderboard — таблица лидеров (?limit=).
+		challengeGroup.GET("/:id/leaderboard", s.challengeHandler.Leaderboard)
+	}
+}
+
+// setupNutritionRoutes настраивает эндпоинты дневника питания.
+func (s *Server) setupNutritionRoutes() {
+	v1 := s.router.Group("/api/v1")
+
+	nutritionGroup := v1.Group("/nutrition")
+	nutritionGroup.Use(s.authMiddleware)
+	{
+		// POST /api/v1/nutrition/entries — добавить запись в дневник питания.
+		nutritionGroup.POST("/entries", s.nutritionHandler.AddEntry)
+		// GET /api/v1/nutrition/entries — записи и итоги дня (?date=YYYY-MM-DD).
+		nutritionGroup.GET("/entries", s.nutritionHandler.Diary)
+		// PUT /api/v1/nutrition/entries/:id — изменить запись.
+		nutritionGroup.PUT("/entries/:id", s.nutritionHandler.UpdateEntry)
+		// DELETE /api/v1/nutrition/entries/:id — удалить запись.
+		nutritionGroup.DELETE("/entries/:id", s.nutritionHandler.DeleteEntry)
+		// GET /api/v1/nutrition/totals — калории и БЖУ по дням (?from=&to=).
+		nutritionGroup.GET("/totals", s.nutritionHandler.Totals)
+	}
+}
+
+// setupHealthSyncRoutes настраивает эндпоинты импорта из Apple Health и Google Fit.
+func (s *Server) setupHealthSyncRoutes() {
+	v1 := s.router.Group("/api/v1")
+
+	// Без txMiddleware: сервис сам открывает транзакцию после разбора выгрузки.
+	healthGroup := v1.Group("/integrations/health")
+	healthGroup.Use(s.authMiddleware)
+	{
+		// GET /api/v1/integrations/health — состояние синхронизации с источниками.
+		healthGroup.GET("", s.healthSyncHandler.Connections)
+		// POST /api/v1/integrations/health/:provider — импортировать выгрузку apple_health или google_fit.
+		healthGroup.POST("/:provider", s.healthSyncHandler.Sync)
+	}
+}
+
+// setupHabitRoutes настраивает эндпоинты ежедневных привычек.
+func (s *Server) setupHabitRoutes() {
+	v1 := s.router.Group("/api/v1")
+
+	habitGroup := v1.Group("/habits")
+	habitGroup.Use(s.authMiddleware)
+	{
+		// GET /api/v1/habits — привычки по дням и средние значения (?from=&to=).
+		habitGroup.GET("", s.habitHandler.Range)
+		// PUT /api/v1/habits/:date — сохранить привычки дня (вода, сон, пульс покоя, шаги).
+		habitGroup.PUT("/:date", s.habitHandler.Upsert)
+		// DELETE /api/v1/habits/:date — удалить привычки дня.
+		habitGroup.DELETE("/:date", s.habitHandler.Delete)
+	}
+}
+
+// setupGymCheckInRoutes настраивает эндпоинты залов организаций и отметок в них по QR-коду.
+func (s *Server) setupGymCheckInRoutes() {
+	v1 := s.router.Group("/api/v1")
+
+	orgGroup := v1.Group("/organizations")
+	orgGroup.Use(s.authMiddleware, s.txMiddleware)
+	{
+		// POST /api/v1/organizations/:id/locations — создать зал (владелец).
+		orgGroup.POST("/:id/locations", s.gymCheckInHandler.CreateLocation)
+		// GET /api/v1/organizations/:id/locations — залы организации.
+		orgGroup.GET("/:id/locations", s.gymCheckInHandler.ListLocations)
+		// GET /api/v1/organizations/:id/attendance — посещаемость залов за период (владелец).
+		orgGroup.GET("/:id/attendance", s.gymCheckInHandler.Attendance)
+	}
+
+	locationGroup := v1.Group("/locations")
+	locationGroup.Use(s.authMiddleware, s.txMiddleware)
+	{
+		// DELETE /api/v1/locations/:id — удалить зал (владелец).
+		locationGroup.DELETE("/:id", s.gymCheckInHandler.DeleteLocation)
+		// GET /api/v1/locations/:id/code — текущий QR-код зала для экрана на входе (владелец).
+		locationGroup.GET("/:id/code", s.gymCheckInHandler.Code)
+	}
+
+	// Путь POST /api/v1/checkins занят анкетами готовности, поэтому отметки в зале — в /checkins/gym.
+	visitGroup := v1.Group("/checkins/gym")
+	visitGroup.Use(s.authMiddleware, s.txMiddleware)
+	{
+		// POST /api/v1/checkins/gym — отметиться в зале по отсканированному QR-коду.
+		visitGroup.POST("", s.gymCheckInHandler.CheckIn)
+		// GET /api/v1/checkins/gym — история посещений залов за период.
+		visitGroup.GET("", s.gymCheckInHandler.ListMine)
+	}
+}
+
+// setupMetricRoutes настраивает защищённые эндпоинты замеров параметров тела.
+func (s *Server) setupMetricRoutes() {
+	v1 := s.router.Group("/api/v1")
+
+	metricGroup := v1.Group("/metrics")
+	metricGroup.Use(s.authMiddleware)
+	{
+		// POST /api/v1/metrics — сохранить замер (вес, процент жира, произвольные замеры).
+		metricGroup.POST("", s.metricHandler.Record)
+		// GET /api/v1/metrics — история замеров за период (?from=&to=).
+		metricGroup.GET("", s.metricHandler.List)
+		// GET /api/v1/metrics/latest — последние значения каждой метрики.
+		metricGroup.GET("/latest", s.metricHandler.Latest)
+	}
+}
+
+// setupProgramRoutes настраивает защищённые эндпоинты тренировочных программ.
+func (s *Server) setupProgramRoutes() {
+	v1 := s.router.Group("/api/v1")
+
+	programGroup := v1.Group("/programs")
+	// Назначение программы и публикация события program.assigned фиксируются атомарно.
+	programGroup.Use(s.authMiddleware, s.txMiddleware)
+	{
+		// POST /api/v1/programs — создать программу (недели -> дни -> тренировки).
+		programGroup.POST("", s.programHandler.Create)
+		// GET /api/v1/programs — программы, автором которых является текущий пользователь.
+		programGroup.GET("", s.programHandler.ListMine)
+		// GET /api/v1/programs/assigned — программы, назначенные текущему пользователю.
+		programGroup.GET("/assigned", s.programHandler.ListAssigned)
+		// GET /api/v1/programs/:id — получить программу с полной структурой.
+		programGroup.GET("/:id", s.programHandler.Get)
+		// POST /api/v1/programs/:id/clone — скопировать программу себе.
+		programGroup.POST("/:id/clone", s.programHandler.Clone)
+		// POST /api/v1/programs/:id/assign — назначить программу себе или клиенту.
+		programGroup.POST("/:id/assign", s.programHandler.Assign)
+		// POST /api/v1/programs/:id/assign/bulk — назначить программу группе клиентов (тренер).
+		programGroup.POST("/:id/assign/bulk", s.programHandler.BulkAssign)
+		// GET /api/v1/programs/assignments/:id/schedule — расписание назначения с вычисленными весами.
+		programGroup.GET("/assignments/:id/schedule", s.programHandler.Schedule)
+		// POST /api/v1/programs/:id/share — создать публичную ссылку на свою программу.
+		programGroup.POST("/:id/share", s.shareHandler.ShareProgram)
+	}
+
+	trainingMaxGroup := v1.Group("/training-maxes")
+	trainingMaxGroup.Use(s.authMiddleware)
+	{
+		// GET /api/v1/training-maxes — тренировочные максимумы текущего пользователя.
+		trainingMaxGroup.GET("", s.programHandler.ListTrainingMaxes)
+		// PUT /api/v1/training-maxes — сохранить максимум в упражнении.
+		trainingMaxGroup.PUT("", s.programHandler.SetTrainingMax)
+		// DELETE /api/v1/training-maxes?exercise= — удалить максимум в упражнении.
+		trainingMaxGroup.DELETE("", s.programHandler.DeleteTrainingMax)
+	}
+}
+
+// setupVideoRoutes настраивает эндпоинты видео упражнений.
+func (s *Server) setupVideoRoutes() {
+	v1 := s.router.Group("/api/v1")
+
+	// Без txMiddleware: загрузка и отдача видео не должны буферизоваться в памяти.
+	videoGroup := v1.Group("/videos")
+	{
+		coachOnly := middleware.RequireRole(s.logger, domain.RoleCoach, domain.RoleAdmin)
+		// POST /api/v1/videos — загрузить видео упражнения (multipart/form-data, поля file и title).
+		videoGroup.POST("", s.authMiddleware, coachOnly, s.videoHandler.Upload)
+		// GET /api/v1/videos — видео, загруженные текущим тренером.
+		videoGroup.GET("", s.authMiddleware, coachOnly, s.videoHandler.ListMine)
+		// GET /api/v1/videos/:id — видео и подписанная ссылка на воспроизведение.
+		videoGroup.GET("/:id", s.authMiddleware, s.videoHandler.Get)
+		// DELETE /api/v1/videos/:id — удалить видео (автор или admin).
+		videoGroup.DELETE("/:id", s.authMiddleware, s.videoHandler.Delete)
+		// GET /api/v1/videos/:id/stream — отдать видео по подписанной ссылке (Range, без Authorization).
+		videoGroup.GET("/:id/stream", s.videoHandler.Stream)
+		videoGroup.HEAD("/:id/stream", s.videoHandler.Stream)
+	}
+
+	// GET /api/v1/exports/:id/download — скачать архив выгрузки по подписанной ссылке (без Authorization).
+	v1.GET("/exports/:id/download", s.exportHandler.Download)
+}
+
+// setupWorkoutRoutes настраивает эндпоинты тренировок.
+func (s *Server) setupWorkoutRoutes() {
+	v1 := s.router.Group("/api/v1")
+
+	workoutGroup := v1.Group("/workouts")
+	workoutGroup.Use(s.authMiddleware)
+	{
+		// POST /api/v1/workouts — начать тренировку.
+		workoutGroup.POST("", s.workoutHandler.Start)
+		// GET /api/v1/workouts — тренировки текущего пользователя за период.
+		workoutGroup.GET("", s.workoutHandler.List)
+		// GET /api/v1/workouts/active — идущая тренировка.
+		workoutGroup.GET("/active", s.workoutHandler.GetActive)
+		// GET /api/v1/workouts/stats — итоги тренировок за период с учётом автопаузы.
+		workoutGroup.GET("/stats", s.workoutHandler.Stats)
+		// GET /api/v1/workouts/records — личные рекорды с уровнем и процентилем по нормативам силы.
+		workoutGroup.GET("/records", s.strengthHandler.Records)
+		// GET /api/v1/workouts/:id — тренировка с подходами и итогами.
+		workoutGroup.GET("/:id", s.workoutHandler.Get)
+		// POST /api/v1/workouts/:id/sets — записать подход (время фиксирует сервер).
+		workoutGroup.POST("/:id/sets", s.workoutHandler.LogSet)
+		// POST /api/v1/workouts/:id/finish — завершить тренировку.
+		workoutGroup.POST("/:id/finish", s.workoutHandler.Finish)
+		// PUT /api/v1/workouts/:id/difficulty — оценить сложность завершённой тренировки (RPE 1–10).
+		workoutGroup.PUT("/:id/difficulty", s.workoutHandler.RateDifficulty)
+		// POST /api/v1/workouts/:id/share — создать публичную ссылку на тренировку.
+		workoutGroup.POST("/:id/share", s.shareHandler.ShareWorkout)
+	}
+
+	// GET /api/v1/strength-standards — нормативы силы по упражнениям, полу и весу тела.
+	v1.GET("/strength-standards", s.authMiddleware, s.strengthHandler.ListStandards)
+	// GET /api/v1/feed — лента тренировок и личных рекордов пользователей из подписок.
+	v1.GET("/feed", s.authMiddleware, s.socialHandler.Feed)
+
+	shareGroup := v1.Group("/shares")
+	shareGroup.Use(s.authMiddleware)
+	{
+		// GET /api/v1/shares — неотозванные публичные ссылки текущего пользователя.
+		shareGroup.GET("", s.shareHandler.List)
+		// DELETE /api/v1/shares/:id — отозвать ссылку.
+		shareGroup.DELETE("/:id", s.shareHandler.Revoke)
+	}
+	// GET /api/v1/shared/:token — тренировка или программа по ссылке (без Authorization, только чтение).
+	v1.GET("/shared/:token", s.authRateLimit("shared_view", s.cfg.RateLimit.SharedPerIP, 0, s.shareHandler.View)...)
+}
+
+// setupDraftRoutes настраивает эндпоинты черновиков редактора программ и тренировок.
+func (s *Server) setupDraftRoutes() {
+	v1 := s.router.Group("/api/v1")
+
+	draftGroup := v1.Group("/drafts")
+	draftGroup.Use(s.authMiddleware)
+	{
+		// PUT /api/v1/drafts — автосохранить черновик устройства (с проверкой версии черновика).
+		draftGroup.PUT("", s.draftHandler.Save)
+		// GET /api/v1/drafts — черновики текущего пользователя без содержимого (?device_id=).
+		draftGroup.GET("", s.draftHandler.List)
+		// GET /api/v1/drafts/:id — черновик с содержимым и признаком конфликта с опубликованной версией.
+		draftGroup.GET("/:id", s.draftHandler.Get)
+		// DELETE /api/v1/drafts/:id — удалить черновик после публикации или отказа от правок.
+		draftGroup.DELETE("/:id", s.draftHandler.Delete)
+	}
+}
+
+// setupImportRoutes настраивает эндпоинты импорта исторических данных.
+func (s *Server) setupImportRoutes() {
+	v1 := s.router.Group("/api/v1")
+
+	// Без txMiddleware: файл читается потоком и сохраняется пачками, а записи обрабатывает фоновый воркер.
+	importGroup := v1.Group("/imports")
+	importGroup.Use(s.authMiddleware)
+	{
+		// POST /api/v1/imports — загрузить файл NDJSON с тренировками и замерами (обработка в фоне).
+		importGroup.POST("", s.importHandler.Upload)
+		// GET /api/v1/imports — последние импорты текущего пользователя.
+		importGroup.GET("", s.importHandler.List)
+		// GET /api/v1/imports/:id — статус и счётчики импорта.
+		importGroup.GET("/:id", s.importHandler.Get)
+		// GET /api/v1/imports/:id/records — результат обработки каждой строки файла.
+		importGroup.GET("/:id/records", s.importHandler.Records)
+	}
+}
+
+// setupCheckInRoutes настраивает эндпоинты ежедневных анкет готовности.
+func (s *Server) setupCheckInRoutes() {
+	v1 := s.router.Group("/api/v1")
+
+	checkInGroup := v1.Group("/checkins")
+	checkInGroup.Use(s.authMiddleware)
+	{
+		// POST /api/v1/checkins — заполнить анкету за день (повторная анкета заменяет предыдущую).
+		checkInGroup.POST("", s.checkInHandler.Record)
+		// GET /api/v1/checkins — анкеты текущего пользователя за период.
+		checkInGroup.GET("", s.checkInHandler.List)
+		// GET /api/v1/checkins/summary — серии анкет и готовность с рекомендацией по нагрузке.
+		checkInGroup.GET("/summary", s.checkInHandler.Summary)
+	}
+}
+
+// setupCustomMetricRoutes настраивает эндпоинты пользовательских метрик.
+func (s *Server) setupCustomMetricRoutes() {
+	v1 := s.router.Group("/api/v1")
+
+	metricGroup := v1.Group("/custom-metrics")
+	metricGroup.Use(s.authMiddleware)
+	{
+		// POST /api/v1/custom-metrics — создать метрику с единицей измерения.
+		metricGroup.POST("", s.customMetricHandler.Create)
+		// GET /api/v1/custom-metrics — метрики текущего пользователя с последними значениями.
+		metricGroup.GET("", s.customMetricHandler.List)
+		// PUT /api/v1/custom-metrics/:id — изменить метрику.
+		metricGroup.PUT("/:id", s.customMetricHandler.Update)
+		// DELETE /api/v1/custom-metrics/:id — удалить метрику со всеми значениями.
+		metricGroup.DELETE("/:id", s.customMetricHandler.Delete)
+		// POST /api/v1/custom-metrics/:id/entries — записать значение.
+		metricGroup.POST("/:id/entries", s.customMetricHandler.AddEntry)
+		// GET /api/v1/custom-metrics/:id/entries — значения за период.
+		metricGroup.GET("/:id/entries", s.customMetricHandler.ListEntries)
+		// DELETE /api/v1/custom-metrics/:id/entries/:entry_id — удалить значение.
+		metricGroup.DELETE("/:id/entries/:entry_id", s.customMetricHandler.DeleteEntry)
+		// GET /api/v1/custom-metrics/:id/stats — агрегаты по дням/неделям/месяцам для графика.
+		metricGroup.GET("/:id/stats", s.customMetricHandler.Stats)
+	}
+}
+
+// videoSigningKey возвращает ключ подписи ссылок на видео.
+// Без STORAGE_VIDEO_URL_SECRET ключ выводится из секрета access-токенов, а не совпадает с ним.
+func videoSigningKey(cfg *config.Config) []byte {
+	if cfg.Storage.VideoURLSecret != "" {
+		return []byte(cfg.Storage.VideoURLSecret)
+	}
+	return derivedSigningKey(cfg, "video-links")
+}
+
+// derivedSigningKey выводит из секрета access-токенов отдельный ключ для подписи ссылок с назначением purpose.
+func derivedSigningKey(cfg *config.Config, purpose string) []byte {
+	mac := hmac.New(sha256.New, []byte(cfg.JWT.AccessSecret))
+	mac.Write([]byte(purpose))
+	return mac.Sum(nil)
+}
+
+// passwordChangeConfirmRoles преобразует роли из конфигурации в доменные значения.
+func passwordChangeConfirmRoles(roles []string) []domain.Role {
+	result := make([]domain.Role, 0, len(roles))
+	for _, role := range roles {
+		result = append(result, domain.Role(role))
+	}
+	return result
+}
+
+// passwordPolicy собирает политику паролей из конфигурации.
+func passwordPolicy(cfg config.PasswordConfig) password.Policy {
+	policy := password.Policy{MinLength: cfg.MinLength}
+	for _, class := range cfg.RequiredClasses {
+		policy.RequiredClasses = append(policy.RequiredClasses, password.Class(class))
+	}
+	if cfg.DenyCommon || len(cfg.Denylist) > 0 {
+		policy.Denylist = map[string]struct{}{}
+		if cfg.DenyCommon {
+			policy.Denylist = password.CommonPasswords()
+		}
+		for _, p := range cfg.Denylist {
+			policy.Denylist[strings.ToLower(p)] = struct{}{}
+		}
+	}
+	return policy
+}
+
+// usernameBlockRules собирает встроенные правила проверки username из конфигурации.
+func usernameBlockRules(cfg config.UsernameConfig) []usernamefilter.Rule {
+	var rules []usernamefilter.Rule
+	if cfg.BlockReserved {
+		rules = append(rules, usernamefilter.Reserved()...)
+	}
+	for _, locale := range cfg.ProfanityLocales {
+		// Неизвестные локали отсекает валидация конфигурации; none отключает проверку.
+		if list, err := usernamefilter.Profanity(locale); err == nil {
+			rules = append(rules, list...)
+		}
+	}
+	for _, name := range cfg.Blocklist {
+		rules = append(rules, usernamefilter.Rule{Term: name, Mode: usernamefilter.ModeExact, Category: usernamefilter.CategoryReserved})
+	}
+	return rules
+}
+
+// oauthVerifiers создаёт проверки ID-токенов провайдеров, для которых заданы client ID.
+func oauthVerifiers(cfg *config.Config, clients *httpclient.Factory) map[domain.OAuthProvider]oidc.Verifier {
+	verifiers := map[domain.OAuthProvider]oidc.Verifier{}
+	if len(cfg.OAuth.GoogleClientIDs) > 0 {
+		google := oidc.Google
+		google.Audiences = cfg.OAuth.GoogleClientIDs
+		verifiers[domain.OAuthProviderGoogle] = oidc.NewVerifier(google, clients.Client("oauth-google", 0))
+	}
+	if len(cfg.OAuth.AppleClientIDs) > 0 {
+		apple := oidc.Apple
+		apple.Audiences = cfg.OAuth.AppleClientIDs
+		verifiers[domain.OAuthProviderApple] = oidc.NewVerifier(apple, clients.Client("oauth-apple", 0))
+	}
+	return verifiers
+}
+
+// setupCoachRoutes настраивает публичный каталог тренеров и управление анкетой тренера.
+func (s *Server) setupCoachRoutes() {
+	v1 := s.router.Group("/api/v1")
+
+	// GET /api/v1/coaches — публичный каталог тренеров (?specialty=&language=&country=&city=&online=&verified=&limit=&offset=).
+	v1.GET("/coaches", s.authRateLimit("coach_directory", s.cfg.RateLimit.CheckPerIP, 0, s.coachHandler.Directory)...)
+
+	profileGroup := v1.Group("/coach/profile")
+	profileGroup.Use(s.authMiddleware, middleware.RequireRole(s.logger, domain.RoleCoach))
+	{
+		// GET /api/v1/coach/profile — анкета текущего тренера для каталога.
+		profileGroup.GET("", s.coachHandler.GetProfile)
+		// PUT /api/v1/coach/profile — заменить анкету, опубликовать её в каталоге или снять с публикации.
+		profileGroup.PUT("", s.coachHandler.UpdateProfile)
+	}
+}
+
+// presenceTTL — через сколько без heartbeat пользователь перестаёт считаться тренирующимся.
+const presenceTTL = 90 * time.Second
+
+// setupPresenceRoutes настраивает эндпоинты присутствия («сейчас тренируется»).
+func (s *Server) setupPresenceRoutes() {
+	v1 := s.router.Group("/api/v1")
+
+	presenceGroup := v1.Group("/users/me/presence")
+	presenceGroup.Use(s.authMiddleware, s.regionFeature(region.FeaturePresence))
+	{
+		// POST /api/v1/users/me/presence/heartbeat — отметить активную тренировку (продлевает присутствие на TTL).
+		presenceGroup.POST("/heartbeat", s.presenceHandler.Heartbeat)
+		// DELETE /api/v1/users/me/presence — завершить присутствие.
+		presenceGroup.DELETE("", s.presenceHandler.Stop)
+	}
+
+	coachGroup := v1.Group("/coach")
+	coachGroup.Use(s.authMiddleware, middleware.RequireRole(s.logger, domain.RoleCoach, domain.RoleAdmin))
+	{
+		// GET /api/v1/coach/clients — клиенты тренера, их присутствие и теги (?status=training&q=&tag=).
+		coachGroup.GET("/clients", s.presenceHandler.ListClients)
+		// POST /api/v1/coach/invitations — пригласить пользователя в клиенты по email.
+		coachGroup.POST("/invitations", s.coachClientHandler.Invite)
+		// GET /api/v1/coach/invitations — действующие приглашения тренера.
+		coachGroup.GET("/invitations", s.coachClientHandler.ListSentInvitations)
+		// POST /api/v1/coach/clients/:id/programs — назначить программу тренера клиенту.
+		coachGroup.POST("/clients/:id/programs", s.programHandler.AssignToClient)
+		// GET /api/v1/coach/clients/:id/metrics — замеры клиента (нужно согласие measurements).
+		coachGroup.GET("/clients/:id/metrics", s.metricHandler.ListClientMetrics)
+		// GET /api/v1/coach/clients/:id/assignments — назначенные программы клиента (нужно согласие workouts).
+		coachGroup.GET("/clients/:id/assignments", s.programHandler.ListClientAssignments)
+		// GET /api/v1/coach/clients/:id/adherence — выполнение программ тренера клиентом (нужно согласие workouts).
+		coachGroup.GET("/clients/:id/adherence", s.programHandler.ClientAdherence)
+		// GET /api/v1/coach/clients/:id/workouts — тренировки клиента за период (нужно согласие workouts).
+		coachGroup.GET("/clients/:id/workouts", s.workoutHandler.ListClientWorkouts)
+		// GET /api/v1/coach/clients/:id/workouts/stats — итоги тренировок клиента за период (нужно согласие workouts).
+		coachGroup.GET("/clients/:id/workouts/stats", s.workoutHandler.ClientStats)
+		// GET /api/v1/coach/clients/:id/checkins — анкеты готовности клиента (нужно согласие wellbeing).
+		coachGroup.GET("/clients/:id/checkins", s.checkInHandler.ListClientCheckIns)
+		// GET /api/v1/coach/clients/:id/notes — личные заметки тренера о клиенте.
+		coachGroup.GET("/clients/:id/notes", s.coachNoteHandler.ListNotes)
+		// POST /api/v1/coach/clients/:id/notes — добавить заметку о клиенте.
+		coachGroup.POST("/clients/:id/notes", s.coachNoteHandler.CreateNote)
+		// PUT /api/v1/coach/clients/:id/notes/:noteId — изменить заметку.
+		coachGroup.PUT("/clients/:id/notes/:noteId", s.coachNoteHandler.UpdateNote)
+		// DELETE /api/v1/coach/clients/:id/notes/:noteId — удалить заметку.
+		coachGroup.DELETE("/clients/:id/notes/:noteId", s.coachNoteHandler.DeleteNote)
+		// GET /api/v1/coach/clients/:id/tags — теги клиента.
+		coachGroup.GET("/clients/:id/tags", s.coachNoteHandler.ListTags)
+		// PUT /api/v1/coach/clients/:id/tags — заменить теги клиента.
+		coachGroup.PUT("/clients/:id/tags", s.coachNoteHandler.SetTags)
 	}
 }
 
@@ -222,14 +2111,11 @@ func (s *Server) Start() error {
 		MaxHeaderBytes: 1 << 20, // 1 MB
 	}
 
-	// Канал для получения сигналов ОС
-	quit := make(chan os.Signal, 1)
-	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
-
 	// Канал для ошибок запуска сервера
 	serverErr := make(chan error, 1)
 
-	// Запускаем сервер в отдельной горутине
+	// Запускаем сервер в отдельной горутине до фоновых компонентов: пока они запускаются
+	// (ожидание миграций и Redis, прогрев), /health/live отвечает, а /health/ready возвращает 503.
 	go func() {
 		log.Printf("HTTP сервер запущен на %s", address)
 		if err := s.httpServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
@@ -237,6 +2123,18 @@ func (s *Server) Start() error {
 		}
 	}()
 
+	// Запускаем фоновые компоненты (пересчёты и т.п.)
+	if err := s.lifecycle.Start(context.Background()); err != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = s.httpServer.Shutdown(ctx)
+		return fmt.Errorf("ошибка запуска фоновых компонентов: %w", err)
+	}
+
+	// Канал для получения сигналов ОС
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
+
 	// Ожидаем либо сигнал для graceful shutdown, либо ошибку запуска
 	select {
 	case err := <-serverErr:
@@ -245,6 +2143,7 @@ func (s *Server) Start() error {
 		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 		defer cancel()
 		_ = s.httpServer.Shutdown(ctx)
+		_ = s.lifecycle.Shutdown(ctx)
 		return err
 	case sig := <-quit:
 		log.Printf("Получен сигнал %v для остановки сервера...", sig)
@@ -254,11 +2153,17 @@ func (s *Server) Start() error {
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
 
-	// Останавливаем сервер
+	// Останавливаем сервер, затем фоновые компоненты с тем же контекстом:
+	// запросы, которые ещё обрабатывались, могли ставить задачи в фоновые компоненты.
 	if err := s.httpServer.Shutdown(ctx); err != nil {
+		_ = s.lifecycle.Shutdown(ctx)
 		return fmt.Errorf("ошибка при остановке сервера: %w", err)
 	}
 
+	if err := s.lifecycle.Shutdown(ctx); err != nil {
+		return fmt.Errorf("ошибка при остановке фоновых компонентов: %w", err)
+	}
+
 	log.Println("HTTP сервер успешно остановлен")
 	return nil
 }
@@ -0,0 +1,378 @@
+package anonymization
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	orgdomain "workout-app/internal/domain/organization"
+	domain "workout-app/internal/domain/user"
+	repo "workout-app/internal/repository/interfaces"
+	"workout-app/pkg/logger"
+	"workout-app/pkg/storage"
+)
+
+// Service обезличивает пользователя при окончательном удалении аккаунта
+// и по требованию администратора удаляет запись пользователя целиком.
+//
+// Запись пользователя не удаляется: программы, назначения и другой контент, на который
+// ссылаются остальные пользователи, остаются согласованными и отображаются от имени
+// domain.DeletedUsername. Удаляются персональные данные профиля и личные записи
+// (замеры, коды подтверждения, согласия тренеров, назначенные пользователю программы, анкета тренера,
+// загруженные файлы импорта, зашифрованные резервные копии, участие в челленджах, дневник питания);
+// выданные ему токены отзываются.
+type Service interface {
+	// Anonymize удаляет персональные данные пользователя в одной транзакции.
+	// Применимо и к активному, и к мягко удалённому аккаунту.
+	// Возвращает ErrUnderLegalHold, пока на данные установлено юридическое удержание.
+	Anonymize(ctx context.Context, userID uuid.UUID) error
+
+	// Delete обезличивает пользователя (если это ещё не сделано) и удаляет его запись;
+	// оставшиеся личные записи удаляются каскадно. Действие необратимо.
+	// Возвращает ErrUnderLegalHold при юридическом удержании, ErrCannotDeleteAdmin для администратора
+	// и ErrOwnsSharedContent, если удаление затронет программы или организации пользователя.
+	Delete(ctx context.Context, userID uuid.UUID) error
+}
+
+// Ошибки бизнес-логики usecase-слоя.
+var (
+	ErrAlreadyAnonymized = fmt.Errorf("user is already anonymized")
+	ErrUnderLegalHold    = fmt.Errorf("user data is under legal hold")
+	ErrCannotDeleteAdmin = fmt.Errorf("cannot delete an admin")
+	ErrOwnsSharedContent = fmt.Errorf("user owns programs or organizations")
+)
+
+type service struct {
+	tx            repo.Transactor
+	users         repo.UserRepository
+	usernames     repo.UsernameHistoryRepository
+	profiles      repo.ProfileHistoryRepository
+	verifications repo.EmailVerificationRepository
+	metrics       repo.BodyMetricRepository
+	consents      repo.ConsentRepository
+	programs      repo.ProgramRepository
+	organizations repo.OrganizationRepository
+	workouts      repo.WorkoutSessionRepository
+	checkIns      repo.CheckInRepository
+	customMetrics repo.CustomMetricRepository
+	oauthAccounts repo.OAuthAccountRepository
+	trainingMaxes repo.TrainingMaxRepository
+	gymClasses    repo.GymClassRepository
+	gymCheckIns   repo.GymCheckInRepository
+	coachProfiles repo.CoachProfileRepository
+	follows       repo.FollowRepository
+	coachNotes    repo.CoachNoteRepository
+	coachClients  repo.CoachClientRepository
+	notifications repo.NotificationRepository
+	devices       repo.DeviceRepository
+	drafts        repo.DraftRepository
+	exports       repo.DataExportRepository
+	imports       repo.DataImportRepository
+	backups       repo.BackupRepository
+	challenges    repo.ChallengeRepository
+	nutrition     repo.NutritionRepository
+	habits        repo.HabitRepository
+	healthSync    repo.HealthSyncRepository
+	storage       storage.Storage
+	logger        logger.Logger
+}
+
+// NewService создаёт новый сервис обезличивания.
+// storage используется для удаления файлов аватара и резервных копий после фиксации транзакции.
+func NewService(
+	tx repo.Transactor,
+	users repo.UserRepository,
+	usernames repo.UsernameHistoryRepository,
+	profiles repo.ProfileHistoryRepository,
+	verifications repo.EmailVerificationRepository,
+	metrics repo.BodyMetricRepository,
+	consents repo.ConsentRepository,
+	programs repo.ProgramRepository,
+	organizations repo.OrganizationRepository,
+	workouts repo.WorkoutSessionRepository,
+	checkIns repo.CheckInRepository,
+	customMetrics repo.CustomMetricRepository,
+	oauthAccounts repo.OAuthAccountRepository,
+	trainingMaxes repo.TrainingMaxRepository,
+	gymClasses repo.GymClassRepository,
+	gymCheckIns repo.GymCheckInRepository,
+	coachProfiles repo.CoachProfileRepository,
+	follows repo.FollowRepository,
+	coachNotes repo.CoachNoteRepository,
+	coachClients repo.CoachClientRepository,
+	notifications repo.NotificationRepository,
+	devices repo.DeviceRepository,
+	drafts repo.DraftRepository,
+	exports repo.DataExportRepository,
+	imports repo.DataImportRepository,
+	backups repo.BackupRepository,
+	challenges repo.ChallengeRepository,
+	nutrition repo.NutritionRepository,
+	habits repo.HabitRepository,
+	healthSync repo.HealthSyncRepository,
+	storage storage.Storage,
+	logger logger.Logger,
+) Service {
+	return &service{
+		tx:            tx,
+		users:         users,
+		usernames:     usernames,
+		profiles:      profiles,
+		verifications: verifications,
+		metrics:       metrics,
+		consents:      consents,
+		programs:      programs,
+		organizations: organizations,
+		workouts:      workouts,
+		checkIns:      checkIns,
+		customMetrics: customMetrics,
+		oauthAccounts: oauthAccounts,
+		trainingMaxes: trainingMaxes,
+		gymClasses:    gymClasses,
+		gymCheckIns:   gymCheckIns,
+		coachProfiles: coachProfiles,
+		follows:       follows,
+		coachNotes:    coachNotes,
+		coachClients:  coachClients,
+		notifications: notifications,
+		devices:       devices,
+		drafts:        drafts,
+		exports:       exports,
+		imports:       imports,
+		backups:       backups,
+		challenges:    challenges,
+		nutrition:     nutrition,
+		habits:        habits,
+		healthSync:    healthSync,
+		storage:       storage,
+		logger:        logger,
+	}
+}
+
+// Anonymize удаляет персональные данные пользователя в одной транзакции.
+func (s *service) Anonymize(ctx context.Context, userID uuid.UUID) error {
+	var avatarURL string
+	var backupKeys []string
+
+	err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		user, err := s.users.GetByIDIncludingDeleted(ctx, userID)
+		if err != nil {
+			return err
+		}
+		if user.IsAnonymized() {
+			return ErrAlreadyAnonymized
+		}
+		if user.IsOnLegalHold() {
+			return ErrUnderLegalHold
+		}
+		avatarURL = user.AvatarURL
+		backupKeys, err = s.anonymize(ctx, user)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	s.deleteFiles(ctx, userID, avatarURL, backupKeys)
+	s.logger.Info("user_anonymized", map[string]any{"user_id": userID.String()})
+	return nil
+}
+
+// Delete обезличивает пользователя и удаляет его запись в одной транзакции.
+func (s *service) Delete(ctx context.Context, userID uuid.UUID) error {
+	var avatarURL string
+	var backupKeys []string
+
+	err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		user, err := s.users.GetByIDIncludingDeleted(ctx, userID)
+		if err != nil {
+			return err
+		}
+		if user.IsOnLegalHold() {
+			return ErrUnderLegalHold
+		}
+		if user.Role == domain.RoleAdmin {
+			return ErrCannotDeleteAdmin
+		}
+		if err := s.checkNoSharedContent(ctx, userID); err != nil {
+			return err
+		}
+
+		if !user.IsAnonymized() {
+			avatarURL = user.AvatarURL
+			if backupKeys, err = s.anonymize(ctx, user); err != nil {
+				return err
+			}
+		}
+		// ErrNotFound здесь означает, что параллельный вызов успел удалить пользователя или установить удержание.
+		return s.users.Delete(ctx, userID)
+	})
+	if err != nil {
+		return err
+	}
+
+	s.deleteFiles(ctx, userID, avatarURL, backupKeys)
+	s.logger.Info("user_deleted", map[string]any{"user_id": userID.String()})
+	return nil
+}
+
+// checkNoSharedContent запрещает удаление, которое каскадом затронет других пользователей:
+// программы пользователя назначены клиентам, а организации без владельца не управляются.
+func (s *service) checkNoSharedContent(ctx context.Context, userID uuid.UUID) error {
+	programs, err := s.programs.ListByOwner(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to list owned programs: %w", err)
+	}
+	if len(programs) > 0 {
+		return ErrOwnsSharedContent
+	}
+
+	_, members, err := s.organizations.ListByUser(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to list organizations: %w", err)
+	}
+	for _, m := range members {
+		if m.Role == orgdomain.RoleOwner {
+			return ErrOwnsSharedContent
+		}
+	}
+	return nil
+}
+
+// anonymize удаляет персональные данные пользователя; вызывается в транзакции.
+// Возвращает ключи файлов резервных копий, которые удаляются из хранилища после фиксации.
+func (s *service) anonymize(ctx context.Context, user *domain.User) ([]string, error) {
+	userID := user.ID
+
+	user.Anonymize(time.Now().UTC())
+	if err := s.users.Anonymize(ctx, user); err != nil {
+		if errors.Is(err, repo.ErrNotFound) {
+			// Параллельный вызов успел обезличить пользователя или установить удержание.
+			return nil, ErrAlreadyAnonymized
+		}
+		return nil, fmt.Errorf("failed to anonymize user: %w", err)
+	}
+
+	// Прежние username — тоже персональные данные; резерв снимается вместе с историей.
+	if err := s.usernames.DeleteByUserID(ctx, userID); err != nil {
+		return nil, fmt.Errorf("failed to delete username history: %w", err)
+	}
+	// История изменений профиля хранит прежние имя, email и ссылки.
+	if err := s.profiles.DeleteByUserID(ctx, userID); err != nil {
+		return nil, fmt.Errorf("failed to delete profile history: %w", err)
+	}
+	if err := s.verifications.DeleteByUserID(ctx, userID); err != nil {
+		return nil, fmt.Errorf("failed to delete verification codes: %w", err)
+	}
+	if err := s.metrics.DeleteByUserID(ctx, userID); err != nil {
+		return nil, fmt.Errorf("failed to delete body metrics: %w", err)
+	}
+	if err := s.consents.DeleteByUserID(ctx, userID); err != nil {
+		return nil, fmt.Errorf("failed to delete coach consents: %w", err)
+	}
+	if err := s.programs.DeleteAssignmentsByUserID(ctx, userID); err != nil {
+		return nil, fmt.Errorf("failed to delete program assignments: %w", err)
+	}
+	if err := s.workouts.DeleteByUserID(ctx, userID); err != nil {
+		return nil, fmt.Errorf("failed to delete workout sessions: %w", err)
+	}
+	if err := s.checkIns.DeleteByUserID(ctx, userID); err != nil {
+		return nil, fmt.Errorf("failed to delete check-ins: %w", err)
+	}
+	if err := s.customMetrics.DeleteByUserID(ctx, userID); err != nil {
+		return nil, fmt.Errorf("failed to delete custom metrics: %w", err)
+	}
+	if err := s.oauthAccounts.DeleteByUserID(ctx, userID); err != nil {
+		return nil, fmt.Errorf("failed to unlink oauth accounts: %w", err)
+	}
+	if err := s.trainingMaxes.DeleteByUserID(ctx, userID); err != nil {
+		return nil, fmt.Errorf("failed to delete training maxes: %w", err)
+	}
+	if err := s.gymClasses.DeleteBookingsByUserID(ctx, userID); err != nil {
+		return nil, fmt.Errorf("failed to delete class bookings: %w", err)
+	}
+	if err := s.gymCheckIns.DeleteVisitsByUserID(ctx, userID); err != nil {
+		return nil, fmt.Errorf("failed to delete gym visits: %w", err)
+	}
+	if err := s.coachProfiles.DeleteByUserID(ctx, userID); err != nil {
+		return nil, fmt.Errorf("failed to delete coach profile: %w", err)
+	}
+	if err := s.follows.DeleteByUserID(ctx, userID); err != nil {
+		return nil, fmt.Errorf("failed to delete follows: %w", err)
+	}
+	if err := s.coachNotes.DeleteByUserID(ctx, userID); err != nil {
+		return nil, fmt.Errorf("failed to delete coach notes: %w", err)
+	}
+	if err := s.coachClients.DeleteByUserID(ctx, userID); err != nil {
+		return nil, fmt.Errorf("failed to delete coach clients: %w", err)
+	}
+	if err := s.notifications.DeleteByUserID(ctx, userID); err != nil {
+		return nil, fmt.Errorf("failed to delete notifications: %w", err)
+	}
+	if err := s.devices.DeleteByUserID(ctx, userID); err != nil {
+		return nil, fmt.Errorf("failed to delete push devices: %w", err)
+	}
+	if err := s.drafts.DeleteByUserID(ctx, userID); err != nil {
+		return nil, fmt.Errorf("failed to delete drafts: %w", err)
+	}
+	// Архивы выгрузок удалит фоновая очистка; ссылки из писем перестают действовать сразу.
+	if err := s.exports.ExpireByUserID(ctx, userID, time.Now().UTC()); err != nil {
+		return nil, fmt.Errorf("failed to expire data exports: %w", err)
+	}
+	// Импорты хранят исходные строки файлов с историей тренировок и замеров.
+	if err := s.imports.DeleteByUserID(ctx, userID); err != nil {
+		return nil, fmt.Errorf("failed to delete data imports: %w", err)
+	}
+	// Созданные пользователем челленджи остаются и отображаются от имени удалённого пользователя.
+	if err := s.challenges.DeleteParticipantsByUserID(ctx, userID); err != nil {
+		return nil, fmt.Errorf("failed to delete challenge participation: %w", err)
+	}
+	if err := s.nutrition.DeleteByUserID(ctx, userID); err != nil {
+		return nil, fmt.Errorf("failed to delete food diary: %w", err)
+	}
+	if err := s.habits.DeleteByUserID(ctx, userID); err != nil {
+		return nil, fmt.Errorf("failed to delete habits: %w", err)
+	}
+	if err := s.healthSync.DeleteByUserID(ctx, userID); err != nil {
+		return nil, fmt.Errorf("failed to delete health sync state: %w", err)
+	}
+	backups, err := s.backups.ListByUser(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list backups: %w", err)
+	}
+	if err := s.backups.DeleteByUserID(ctx, userID); err != nil {
+		return nil, fmt.Errorf("failed to delete backups: %w", err)
+	}
+	backupKeys := make([]string, 0, len(backups))
+	for _, b := range backups {
+		backupKeys = append(backupKeys, b.StorageKey)
+	}
+	return backupKeys, nil
+}
+
+// deleteFiles удаляет файлы аватара и резервных копий после фиксации транзакции.
+func (s *service) deleteFiles(ctx context.Context, userID uuid.UUID, avatarURL string, backupKeys []string) {
+	// Файлы удаляются вне транзакции: откатить удаление из хранилища нельзя,
+	// поэтому оно выполняется только после успешной фиксации.
+	if key, ok := s.storage.KeyFromURL(avatarURL); ok {
+		if err := s.storage.Delete(ctx, key); err != nil {
+			s.logger.Error("anonymized_avatar_cleanup_failed", map[string]any{
+				"user_id": userID.String(),
+				"key":     key,
+				"error":   err.Error(),
+			})
+		}
+	}
+	for _, key := range backupKeys {
+		if err := s.storage.Delete(ctx, key); err != nil {
+			s.logger.Error("anonymized_backup_cleanup_failed", map[string]any{
+				"user_id": userID.String(),
+				"key":     key,
+				"error":   err.Error(),
+			})
+		}
+	}
+}
@@ -0,0 +1,71 @@
+package audit
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	domain "workout-app/internal/domain/audit"
+	repo "workout-app/internal/repository/interfaces"
+)
+
+// Service описывает usecase-слой журнала действий администраторов.
+type Service interface {
+	// Record добавляет запись в журнал, заполняя её ID и время.
+	Record(ctx context.Context, e *domain.Entry) error
+
+	// List возвращает записи по фильтру (новые первыми) и общее количество подходящих записей.
+	List(ctx context.Context, filter repo.AuditLogFilter) ([]*domain.Entry, int64, error)
+}
+
+// Ошибки бизнес-логики usecase-слоя.
+var (
+	ErrInvalidPeriod = fmt.Errorf("audit log period start must be before its end")
+)
+
+const (
+	// maxListLimit ограничивает размер страницы журнала.
+	maxListLimit = 200
+	// defaultListLimit — размер страницы по умолчанию.
+	defaultListLimit = 50
+	// maxQueryLength — сколько байт строки запроса сохраняется в записи.
+	maxQueryLength = 2000
+)
+
+type service struct {
+	logs repo.AuditLogRepository
+}
+
+// NewService создаёт новый сервис журнала действий администраторов.
+func NewService(logs repo.AuditLogRepository) Service {
+	return &service{logs: logs}
+}
+
+// Record добавляет запись в журнал, заполняя её ID и время.
+func (s *service) Record(ctx context.Context, e *domain.Entry) error {
+	e.ID = uuid.New()
+	e.At = time.Now().UTC()
+	if len(e.Query) > maxQueryLength {
+		e.Query = e.Query[:maxQueryLength]
+	}
+	return s.logs.Create(ctx, e)
+}
+
+// List возвращает записи по фильтру (новые первыми) и общее количество подходящих записей.
+func (s *service) List(ctx context.Context, filter repo.AuditLogFilter) ([]*domain.Entry, int64, error) {
+	if filter.From != nil && filter.To != nil && !filter.From.Before(*filter.To) {
+		return nil, 0, ErrInvalidPeriod
+	}
+	if filter.Limit <= 0 {
+		filter.Limit = defaultListLimit
+	}
+	if filter.Limit > maxListLimit {
+		filter.Limit = maxListLimit
+	}
+	if filter.Offset < 0 {
+		filter.Offset = 0
+	}
+	return s.logs.List(ctx, filter)
+}
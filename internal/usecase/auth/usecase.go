@@ -8,11 +8,16 @@ import (
 
 	"github.com/google/uuid"
 
+	eventdomain "workout-app/internal/domain/event"
 	domain "workout-app/internal/domain/user"
+	"workout-app/internal/events"
 	repo "workout-app/internal/repository/interfaces"
+	usernameblockuc "workout-app/internal/usecase/usernameblock"
+	"workout-app/pkg/emailaddr"
 	jwtsvc "workout-app/pkg/jwt"
 	"workout-app/pkg/mailer"
 	"workout-app/pkg/password"
+	"workout-app/pkg/usernamefilter"
 	"workout-app/pkg/verification"
 )
 
@@ -20,8 +25,10 @@ import (
 // регистрацию, подтверждение email, логин и refresh токенов.
 type Service interface {
 	// Register регистрирует пользователя, создаёт код подтверждения email и отправляет его.
+	// country — код страны регистрации (ISO 3166-1 alpha-2), по нему определяется регион данных.
 	// Возвращает созданного пользователя (без токенов).
-	Register(ctx context.Context, email, password, username string) (*domain.User, error)
+	// language — язык писем (ru/en); пустое или неподдерживаемое значение оставляет язык по умолчанию.
+	Register(ctx context.Context, email, password, username, country, language string) (*domain.User, error)
 
 	// VerifyEmail проверяет код подтверждения email, активирует пользователя
 	// и возвращает пользователя с парой access/refresh токенов.
@@ -34,9 +41,30 @@ type Service interface {
 	// Refresh обновляет пару access/refresh токенов по действительному refresh-токену.
 	Refresh(ctx context.Context, refreshToken string) (*domain.User, string, string, error)
 
+	// IsUsernameAvailable сообщает, свободен ли username для регистрации.
+	IsUsernameAvailable(ctx context.Context, username string) (bool, error)
+
+	// IsEmailAvailable сообщает, свободен ли email для регистрации.
+	// Подтверждённые и неподтверждённые аккаунты не различаются: email любого из них занят.
+	IsEmailAvailable(ctx context.Context, email string) (bool, error)
+
 	// ResendVerificationCode повторно отправляет код подтверждения email,
 	// если аккаунт существует и ещё не подтверждён.
 	ResendVerificationCode(ctx context.Context, email string) error
+
+	// ChangePassword меняет пароль после проверки текущего и отзывает все ранее выданные refresh-токены.
+	// Для ролей, требующих подтверждения, без code отправляет код на email и возвращает
+	// ErrPasswordChangeConfirmationRequired; пароль меняется повторным вызовом с кодом.
+	// Возвращает новую пару access/refresh токенов для текущей сессии.
+	ChangePassword(ctx context.Context, userID uuid.UUID, currentPassword, newPassword, code string) (*domain.User, string, string, error)
+
+	// SendPasswordReset отправляет пользователю код сброса пароля (административный сценарий).
+	// Прежние коды сброса удаляются; текущий пароль и сессии остаются действительными до сброса.
+	SendPasswordReset(ctx context.Context, userID uuid.UUID) error
+
+	// ResetPassword устанавливает новый пароль по коду сброса и отзывает все ранее выданные токены.
+	// Для несуществующего email возвращает ErrVerificationCodeNotFound, не раскрывая, есть ли аккаунт.
+	ResetPassword(ctx context.Context, email, code, newPassword string) error
 }
 
 // Ошибки бизнес-логики usecase-слоя.
@@ -49,46 +77,135 @@ var (
 	ErrInvalidCredentials           = fmt.Errorf("invalid email or password")
 	ErrInvalidRefreshToken          = fmt.Errorf("invalid refresh token")
 	ErrEmailUnverifiedExists        = fmt.Errorf("unverified account with this email already exists")
+	ErrAccountSuspended             = fmt.Errorf("account suspended")
+	ErrInvalidCurrentPassword       = fmt.Errorf("current password is invalid")
+	ErrSamePassword                 = fmt.Errorf("new password must differ from the current one")
+	ErrWeakPassword                 = fmt.Errorf("password does not satisfy the password policy")
+
+	ErrPasswordChangeConfirmationRequired = fmt.Errorf("password change confirmation code sent")
 )
 
 type service struct {
+	tx              repo.Transactor
 	users           repo.UserRepository
+	usernames       repo.UsernameHistoryRepository
+	usernameFilter  usernameblockuc.Checker
 	emailVerifs     repo.EmailVerificationRepository
 	jwt             jwtsvc.Service
 	emailSender     mailer.EmailSender
+	events          events.Publisher
 	verificationTTL time.Duration
 	maxAttempts     int
 	codeLength      int
+	passwordPolicy  password.Policy
+
+	// passwordChangeConfirmRoles — роли, для которых смена пароля подтверждается кодом из email.
+	passwordChangeConfirmRoles map[domain.Role]struct{}
 }
 
 // NewService создаёт новый auth usecase-сервис.
+// tx объединяет создание пользователя и кода подтверждения в одну транзакцию.
+// usernames — история смены username: зарезервированные старые имена недоступны при регистрации.
 // verificationTTL задаёт время жизни кода подтверждения,
 // maxAttempts — максимальное количество неверных попыток ввода кода.
+// passwordChangeConfirmRoles — роли, для которых смена пароля требует кода из email (пусто — выключено).
+// passwordPolicy проверяет пароли при регистрации и смене пароля.
+// publisher получает события регистрации, подтверждения email и смены пароля; письма о них
+// отправляют подписчики (см. events.AccountEmails).
 func NewService(
+	tx repo.Transactor,
 	users repo.UserRepository,
+	usernames repo.UsernameHistoryRepository,
+	usernameFilter usernameblockuc.Checker,
 	emailVerifs repo.EmailVerificationRepository,
 	jwt jwtsvc.Service,
 	emailSender mailer.EmailSender,
+	publisher events.Publisher,
 	verificationTTL time.Duration,
 	maxAttempts int,
 	codeLength int,
+	passwordChangeConfirmRoles []domain.Role,
+	passwordPolicy password.Policy,
 ) Service {
+	confirmRoles := make(map[domain.Role]struct{}, len(passwordChangeConfirmRoles))
+	for _, role := range passwordChangeConfirmRoles {
+		confirmRoles[role] = struct{}{}
+	}
+
 	return &service{
+		tx:              tx,
 		users:           users,
+		usernames:       usernames,
+		usernameFilter:  usernameFilter,
 		emailVerifs:     emailVerifs,
 		jwt:             jwt,
 		emailSender:     emailSender,
+		events:          publisher,
 		verificationTTL: verificationTTL,
 		maxAttempts:     maxAttempts,
 		codeLength:      codeLength,
+		passwordPolicy:  passwordPolicy,
+
+		passwordChangeConfirmRoles: confirmRoles,
+	}
+}
+
+// IsUsernameAvailable сообщает, свободен ли username для регистрации.
+// Запрещённый username недоступен так же, как занятый.
+func (s *service) IsUsernameAvailable(ctx context.Context, username string) (bool, error) {
+	if err := s.usernameFilter.Check(ctx, username); err != nil {
+		if errors.Is(err, usernamefilter.ErrNotAllowed) {
+			return false, nil
+		}
+		return false, err
+	}
+	taken, err := s.usernameTaken(ctx, username)
+	return !taken, err
+}
+
+// usernameTaken сообщает, занят ли username другим пользователем или зарезервирован после смены имени.
+// Резерв не отличается от занятого имени: ответ не раскрывает историю переименований.
+func (s *service) usernameTaken(ctx context.Context, username string) (bool, error) {
+	if available, err := isAvailable(s.users.GetByUsername(ctx, username)); err != nil || !available {
+		return !available, err
 	}
+	return s.usernames.IsReserved(ctx, username, uuid.Nil, time.Now().UTC())
+}
+
+// IsEmailAvailable сообщает, свободен ли email для регистрации.
+func (s *service) IsEmailAvailable(ctx context.Context, email string) (bool, error) {
+	return isAvailable(s.users.GetByEmail(ctx, emailaddr.Normalize(email)))
+}
+
+// isAvailable переводит результат поиска пользователя в признак свободного значения.
+func isAvailable(_ *domain.User, err error) (bool, error) {
+	if errors.Is(err, repo.ErrNotFound) {
+		return true, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return false, nil
 }
 
 // Register регистрирует нового пользователя и отправляет код подтверждения email.
-func (s *service) Register(ctx context.Context, email, rawPassword, username string) (*domain.User, error) {
+// Пользователь, код и письмо в очереди исходящих писем создаются в одной транзакции: если письмо
+// не удалось поставить в очередь, аккаунт не остаётся без кода и email/username можно использовать повторно.
+func (s *service) Register(ctx context.Context, email, rawPassword, username, country, language string) (*domain.User, error) {
+	email = emailaddr.Normalize(email)
 	if email == "" || rawPassword == "" || username == "" {
 		return nil, fmt.Errorf("email, password and username are required")
 	}
+	if err := s.passwordPolicy.Validate(rawPassword); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrWeakPassword, err)
+	}
+	if err := s.usernameFilter.Check(ctx, username); err != nil {
+		return nil, err
+	}
+	// Занятые email/username отклоняем до дорогого хеширования пароля.
+	if err := s.registrationConflict(ctx, email, username); err != nil {
+		return nil, err
+	}
 
 	// Хешируем пароль на уровне usecase.
 	hashed, err := password.Hash(rawPassword)
@@ -98,34 +215,64 @@ func (s *service) Register(ctx context.Context, email, rawPassword, username str
 
 	user := domain.NewUser(email, hashed, username)
 	user.IsEmailVerified = false
+	user.SetRegistrationCountry(country)
+	if lang := domain.Language(language); lang.IsValid() {
+		user.Language = lang
+	}
 
-	if err := s.users.Create(ctx, user); err != nil {
-		// Дополнительно различаем случай, когда существует неподтверждённый аккаунт.
-		if errors.Is(err, repo.ErrEmailExists) {
-			existing, getErr := s.users.GetByEmail(ctx, email)
-			if getErr != nil {
-				return nil, err
-			}
-			if existing.IsEmailVerified {
-				// Обычный конфликт: подтверждённый email.
-				return nil, repo.ErrEmailExists
+	err = s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.users.Create(ctx, user); err != nil {
+			return err
+		}
+		return s.createAndSendVerificationCode(ctx, user)
+	})
+	if err != nil {
+		// Параллельная регистрация с теми же данными прошла проверку одновременно с нами и успела раньше:
+		// транзакция откачена, конфликт уточняем по зафиксированному аккаунту.
+		if errors.Is(err, repo.ErrEmailExists) || errors.Is(err, repo.ErrUsernameExists) {
+			if conflict := s.registrationConflict(ctx, email, username); conflict != nil {
+				return nil, conflict
 			}
-			// Email уже существует, но не подтверждён.
-			return nil, ErrEmailUnverifiedExists
 		}
 		return nil, err
 	}
 
-	if err := s.createAndSendVerificationCode(ctx, user); err != nil {
-		return nil, err
+	s.events.Publish(ctx, eventdomain.TypeUserRegistered, user.ID.String(), eventdomain.UserRegistered{
+		UserID:       user.ID.String(),
+		Method:       eventdomain.RegistrationPassword,
+		RegisteredAt: user.CreatedAt,
+	})
+	return user, nil
+}
+
+// registrationConflict возвращает ошибку конфликта, если email или username уже заняты:
+// repo.ErrEmailExists для подтверждённого аккаунта, ErrEmailUnverifiedExists для неподтверждённого,
+// repo.ErrUsernameExists для занятого или зарезервированного username.
+func (s *service) registrationConflict(ctx context.Context, email, username string) error {
+	existing, err := s.users.GetByEmail(ctx, email)
+	switch {
+	case err == nil && existing.IsEmailVerified:
+		return repo.ErrEmailExists
+	case err == nil:
+		return ErrEmailUnverifiedExists
+	case !errors.Is(err, repo.ErrNotFound):
+		return err
 	}
 
-	return user, nil
+	taken, err := s.usernameTaken(ctx, username)
+	if err != nil {
+		return err
+	}
+	if taken {
+		return repo.ErrUsernameExists
+	}
+	return nil
 }
 
 // VerifyEmail подтверждает email по коду, активирует пользователя
 // и возвращает пару access/refresh токенов.
 func (s *service) VerifyEmail(ctx context.Context, email, code string) (*domain.User, string, string, error) {
+	email = emailaddr.Normalize(email)
 	if email == "" || code == "" {
 		return nil, "", "", fmt.Errorf("email and code are required")
 	}
@@ -139,52 +286,27 @@ func (s *service) VerifyEmail(ctx context.Context, email, code string) (*domain.
 		return nil, "", "", ErrEmailAlreadyVerified
 	}
 
-	v, err := s.emailVerifs.GetActiveByUserID(ctx, user.ID)
-	if err != nil {
-		if err == repo.ErrNotFound {
-			return nil, "", "", ErrVerificationCodeNotFound
-		}
+	if err := s.checkCode(ctx, user.ID, domain.PurposeRegistration, code); err != nil {
 		return nil, "", "", err
 	}
 
-	// Используем общую функцию проверки кода
-	result, _, err := verification.VerifyCode(ctx, v, code, s.emailVerifs)
-	if err != nil {
-		return nil, "", "", fmt.Errorf("failed to verify code: %w", err)
-	}
-
-	switch result {
-	case verification.VerificationExpired:
-		if err := s.emailVerifs.DeleteByUserID(ctx, user.ID); err != nil {
-			return nil, "", "", fmt.Errorf("failed to delete expired verification: %w", err)
-		}
-		return nil, "", "", ErrVerificationCodeNotFound
-	case verification.VerificationAttemptsExceeded:
-		if err := s.emailVerifs.DeleteByUserID(ctx, user.ID); err != nil {
-			return nil, "", "", fmt.Errorf("failed to delete verification after exceeded attempts: %w", err)
-		}
-		return nil, "", "", ErrVerificationAttemptsExceeded
-	case verification.VerificationCodeInvalid:
-		return nil, "", "", ErrVerificationCodeInvalid
-	case verification.VerificationSuccess:
-		// Продолжаем обработку успешной верификации
-	default:
-		return nil, "", "", fmt.Errorf("unknown verification result: %d", result)
-	}
-
 	// Успешное подтверждение: отмечаем email как подтверждённый.
-	user.IsEmailVerified = true
-	user.UpdatedAt = time.Now().UTC()
-
-	if err := s.users.Update(ctx, user); err != nil {
+	if err := s.users.MarkEmailVerified(ctx, user.ID); err != nil {
 		return nil, "", "", err
 	}
+	user.IsEmailVerified = true
+	user.UpdatedAt = time.Now().UTC()
 
-	// Удаляем все коды для пользователя.
-	if err := s.emailVerifs.DeleteByUserID(ctx, user.ID); err != nil {
+	// Удаляем коды подтверждения регистрации.
+	if err := s.emailVerifs.DeleteByPurpose(ctx, user.ID, domain.PurposeRegistration); err != nil {
 		return nil, "", "", fmt.Errorf("failed to delete verification codes: %w", err)
 	}
 
+	s.events.Publish(ctx, eventdomain.TypeEmailVerified, user.ID.String(), eventdomain.EmailVerified{
+		UserID:     user.ID.String(),
+		VerifiedAt: user.UpdatedAt,
+	})
+
 	// Генерируем access/refresh токены.
 	access, err := s.jwt.GenerateAccessToken(user)
 	if err != nil {
@@ -201,6 +323,7 @@ func (s *service) VerifyEmail(ctx context.Context, email, code string) (*domain.
 
 // Login выполняет вход по email/паролю и проверяет, что email подтверждён.
 func (s *service) Login(ctx context.Context, email, rawPassword string) (*domain.User, string, string, error) {
+	email = emailaddr.Normalize(email)
 	if email == "" || rawPassword == "" {
 		return nil, "", "", fmt.Errorf("email and password are required")
 	}
@@ -221,6 +344,11 @@ func (s *service) Login(ctx context.Context, email, rawPassword string) (*domain
 		return nil, "", "", ErrEmailNotVerified
 	}
 
+	// Блокировку проверяем после пароля, чтобы не раскрывать её статус без знания пароля.
+	if user.IsSuspended(time.Now()) {
+		return nil, "", "", ErrAccountSuspended
+	}
+
 	access, err := s.jwt.GenerateAccessToken(user)
 	if err != nil {
 		return nil, "", "", err
@@ -268,6 +396,89 @@ func (s *service) Refresh(ctx context.Context, refreshToken string) (*domain.Use
 		return nil, "", "", ErrEmailNotVerified
 	}
 
+	// Не выдаём новые токены заблокированным пользователям.
+	if user.IsSuspended(time.Now()) {
+		return nil, "", "", ErrAccountSuspended
+	}
+
+	// Refresh-токены, выданные до смены пароля, отозваны.
+	if claims.IssuedAt == nil || user.IsTokenRevoked(claims.IssuedAt.Time) {
+		return nil, "", "", ErrInvalidRefreshToken
+	}
+
+	access, err := s.jwt.GenerateAccessToken(user)
+	if err != nil {
+		return nil, "", "", err
+	}
+
+	refresh, _, err := s.jwt.GenerateRefreshToken(user)
+	if err != nil {
+		return nil, "", "", err
+	}
+
+	return user, access, refresh, nil
+}
+
+// ChangePassword меняет пароль пользователя и отзывает ранее выданные refresh-токены.
+func (s *service) ChangePassword(ctx context.Context, userID uuid.UUID, currentPassword, newPassword, code string) (*domain.User, string, string, error) {
+	if currentPassword == "" || newPassword == "" {
+		return nil, "", "", fmt.Errorf("current and new password are required")
+	}
+
+	user, err := s.users.GetByID(ctx, userID)
+	if err != nil {
+		return nil, "", "", err
+	}
+
+	if err := password.Compare(user.PasswordHash, currentPassword); err != nil {
+		return nil, "", "", ErrInvalidCurrentPassword
+	}
+	if currentPassword == newPassword {
+		return nil, "", "", ErrSamePassword
+	}
+	// Политика проверяется до отправки кода подтверждения, чтобы не подтверждать заведомо отклонённый пароль.
+	if err := s.passwordPolicy.Validate(newPassword); err != nil {
+		return nil, "", "", fmt.Errorf("%w: %w", ErrWeakPassword, err)
+	}
+
+	if _, ok := s.passwordChangeConfirmRoles[user.Role]; ok {
+		if code == "" {
+			if err := s.emailVerifs.DeleteByPurpose(ctx, user.ID, domain.PurposePasswordChange); err != nil {
+				return nil, "", "", err
+			}
+			if err := s.createAndSendCode(ctx, user, domain.PurposePasswordChange); err != nil {
+				return nil, "", "", err
+			}
+			return nil, "", "", ErrPasswordChangeConfirmationRequired
+		}
+		if err := s.checkCode(ctx, user.ID, domain.PurposePasswordChange, code); err != nil {
+			return nil, "", "", err
+		}
+	}
+
+	hashed, err := password.Hash(newPassword)
+	if err != nil {
+		return nil, "", "", fmt.Errorf("failed to hash password: %w", err)
+	}
+
+	// iat в JWT хранится с точностью до секунды, поэтому граница отзыва округляется вниз:
+	// новые токены, выданные ниже, не должны считаться отозванными.
+	validAfter := time.Now().UTC().Truncate(time.Second)
+	if err := s.users.UpdatePassword(ctx, user.ID, hashed, validAfter); err != nil {
+		return nil, "", "", err
+	}
+	user.PasswordHash = hashed
+	user.TokensValidAfter = &validAfter
+
+	if err := s.emailVerifs.DeleteByPurpose(ctx, user.ID, domain.PurposePasswordChange); err != nil {
+		return nil, "", "", fmt.Errorf("failed to delete verification codes: %w", err)
+	}
+
+	s.events.Publish(ctx, eventdomain.TypePasswordChanged, user.ID.String(), eventdomain.PasswordChanged{
+		UserID:    user.ID.String(),
+		ChangedAt: validAfter,
+	})
+
 	access, err := s.jwt.GenerateAccessToken(user)
 	if err != nil {
 		return nil, "", "", err
@@ -281,9 +492,68 @@ func (s *service) Refresh(ctx context.Context, refreshToken string) (*domain.Use
 	return user, access, refresh, nil
 }
 
+// SendPasswordReset отправляет пользователю код сброса пароля.
+func (s *service) SendPasswordReset(ctx context.Context, userID uuid.UUID) error {
+	user, err := s.users.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.emailVerifs.DeleteByPurpose(ctx, user.ID, domain.PurposePasswordReset); err != nil {
+		return err
+	}
+	return s.createAndSendCode(ctx, user, domain.PurposePasswordReset)
+}
+
+// ResetPassword устанавливает новый пароль по коду сброса.
+func (s *service) ResetPassword(ctx context.Context, email, code, newPassword string) error {
+	email = emailaddr.Normalize(email)
+	if email == "" || code == "" || newPassword == "" {
+		return fmt.Errorf("email, code and new password are required")
+	}
+
+	user, err := s.users.GetByEmail(ctx, email)
+	if err != nil {
+		if errors.Is(err, repo.ErrNotFound) {
+			return ErrVerificationCodeNotFound
+		}
+		return err
+	}
+
+	// Политика проверяется до кода: отклонённый пароль не должен расходовать попытки ввода.
+	if err := s.passwordPolicy.Validate(newPassword); err != nil {
+		return fmt.Errorf("%w: %w", ErrWeakPassword, err)
+	}
+	if err := s.checkCode(ctx, user.ID, domain.PurposePasswordReset, code); err != nil {
+		return err
+	}
+
+	hashed, err := password.Hash(newPassword)
+	if err != nil {
+		return fmt.Errorf("failed to hash password: %w", err)
+	}
+
+	// Сброс отзывает все сессии, включая ту, что могла остаться у злоумышленника.
+	validAfter := time.Now().UTC().Truncate(time.Second)
+	if err := s.users.UpdatePassword(ctx, user.ID, hashed, validAfter); err != nil {
+		return err
+	}
+
+	if err := s.emailVerifs.DeleteByPurpose(ctx, user.ID, domain.PurposePasswordReset); err != nil {
+		return fmt.Errorf("failed to delete verification codes: %w", err)
+	}
+
+	s.events.Publish(ctx, eventdomain.TypePasswordChanged, user.ID.String(), eventdomain.PasswordChanged{
+		UserID:    user.ID.String(),
+		ChangedAt: validAfter,
+	})
+	return nil
+}
+
 // ResendVerificationCode повторно отправляет код подтверждения email,
 // если аккаунт существует и ещё не подтверждён.
 func (s *service) ResendVerificationCode(ctx context.Context, email string) error {
+	email = emailaddr.Normalize(email)
 	if email == "" {
 		return fmt.Errorf("email is required")
 	}
@@ -302,17 +572,60 @@ func (s *service) ResendVerificationCode(ctx context.Context, email string) erro
 		return ErrEmailAlreadyVerified
 	}
 
-	// Удаляем все старые коды для пользователя (если есть).
-	if err := s.emailVerifs.DeleteByUserID(ctx, user.ID); err != nil && err != repo.ErrNotFound {
+	// Удаляем все старые коды подтверждения регистрации (если есть).
+	if err := s.emailVerifs.DeleteByPurpose(ctx, user.ID, domain.PurposeRegistration); err != nil && err != repo.ErrNotFound {
 		return err
 	}
 
 	return s.createAndSendVerificationCode(ctx, user)
 }
 
+// checkCode проверяет активный код пользователя с указанным назначением.
+// Истёкший или исчерпавший попытки код удаляется; после успешной проверки
+// код удаляет вызывающий — когда подтверждаемое действие выполнено.
+func (s *service) checkCode(ctx context.Context, userID uuid.UUID, purpose domain.VerificationPurpose, code string) error {
+	v, err := s.emailVerifs.GetActiveByPurpose(ctx, userID, purpose)
+	if err != nil {
+		if err == repo.ErrNotFound {
+			return ErrVerificationCodeNotFound
+		}
+		return err
+	}
+
+	// Используем общую функцию проверки кода
+	result, _, err := verification.VerifyCode(ctx, v, code, s.emailVerifs)
+	if err != nil {
+		return fmt.Errorf("failed to verify code: %w", err)
+	}
+
+	switch result {
+	case verification.VerificationExpired:
+		if err := s.emailVerifs.DeleteByPurpose(ctx, userID, purpose); err != nil {
+			return fmt.Errorf("failed to delete expired verification: %w", err)
+		}
+		return ErrVerificationCodeNotFound
+	case verification.VerificationAttemptsExceeded:
+		if err := s.emailVerifs.DeleteByPurpose(ctx, userID, purpose); err != nil {
+			return fmt.Errorf("failed to delete verification after exceeded attempts: %w", err)
+		}
+		return ErrVerificationAttemptsExceeded
+	case verification.VerificationCodeInvalid:
+		return ErrVerificationCodeInvalid
+	case verification.VerificationSuccess:
+		return nil
+	default:
+		return fmt.Errorf("unknown verification result: %d", result)
+	}
+}
+
 // createAndSendVerificationCode создаёт запись с кодом подтверждения email
 // и отправляет его пользователю.
 func (s *service) createAndSendVerificationCode(ctx context.Context, user *domain.User) error {
+	return s.createAndSendCode(ctx, user, domain.PurposeRegistration)
+}
+
+// createAndSendCode создаёт запись с кодом указанного назначения и отправляет его на email пользователя.
+func (s *service) createAndSendCode(ctx context.Context, user *domain.User, purpose domain.VerificationPurpose) error {
 	code, err := verification.GenerateNumericCode(s.codeLength)
 	if err != nil {
 		return fmt.Errorf("failed to generate verification code: %w", err)
@@ -331,13 +644,21 @@ func (s *service) createAndSendVerificationCode(ctx context.Context, user *domai
 		Attempts:    0,
 		MaxAttempts: s.maxAttempts,
 		CreatedAt:   now,
+		Purpose:     purpose,
 	}
 
 	if err := s.emailVerifs.Create(ctx, verification); err != nil {
 		return err
 	}
 
-	if err := s.emailSender.SendEmailVerificationCode(ctx, user.Email, code); err != nil {
+	send := s.emailSender.SendEmailVerificationCode
+	switch purpose {
+	case domain.PurposePasswordChange:
+		send = s.emailSender.SendPasswordChangeCode
+	case domain.PurposePasswordReset:
+		send = s.emailSender.SendPasswordResetCode
+	}
+	if err := send(mailer.WithRecipient(ctx, user.MailLocale(), user.Timezone), user.Email, code); err != nil {
 		return fmt.Errorf("failed to send verification email: %w", err)
 	}
 
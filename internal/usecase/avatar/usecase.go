@@ -0,0 +1,132 @@
+package avatar
+
+import (
+	"bufio"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+
+	domain "workout-app/internal/domain/user"
+	repo "workout-app/internal/repository/interfaces"
+	"workout-app/pkg/logger"
+	"workout-app/pkg/storage"
+)
+
+// Service описывает usecase-слой загрузки аватаров пользователей.
+type Service interface {
+	// Upload сохраняет изображение в хранилище и записывает его URL в профиль пользователя.
+	// Тип файла определяется по содержимому, а не по заявленному клиентом Content-Type.
+	// Ранее загруженный аватар удаляется из хранилища, смена аватара записывается в историю изменений профиля.
+	Upload(ctx context.Context, userID uuid.UUID, file io.Reader, size int64) (*domain.User, error)
+}
+
+// Ошибки бизнес-логики usecase-слоя.
+var (
+	ErrFileTooLarge      = fmt.Errorf("avatar file is too large")
+	ErrEmptyFile         = fmt.Errorf("avatar file is empty")
+	ErrUnsupportedFormat = fmt.Errorf("unsupported avatar format")
+)
+
+// allowedTypes сопоставляет допустимые MIME-типы изображений с расширениями файлов.
+var allowedTypes = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/webp": ".webp",
+	"image/gif":  ".gif",
+}
+
+type service struct {
+	users    repo.UserRepository
+	history  repo.ProfileHistoryRepository
+	storage  storage.Storage
+	maxBytes int64
+	logger   logger.Logger
+}
+
+// NewService создаёт новый сервис аватаров. maxBytes ограничивает размер загружаемого файла.
+func NewService(users repo.UserRepository, history repo.ProfileHistoryRepository, storage storage.Storage, maxBytes int64, logger logger.Logger) Service {
+	return &service{
+		users:    users,
+		history:  history,
+		storage:  storage,
+		maxBytes: maxBytes,
+		logger:   logger,
+	}
+}
+
+// Upload сохраняет аватар пользователя.
+func (s *service) Upload(ctx context.Context, userID uuid.UUID, file io.Reader, size int64) (*domain.User, error) {
+	if size > s.maxBytes {
+		return nil, ErrFileTooLarge
+	}
+	if size == 0 {
+		return nil, ErrEmptyFile
+	}
+
+	// Тип определяется по первым 512 байтам (http.DetectContentType читает не больше).
+	buffered := bufio.NewReaderSize(file, 512)
+	head, err := buffered.Peek(512)
+	if err != nil && err != io.EOF && err != bufio.ErrBufferFull {
+		return nil, fmt.Errorf("failed to read avatar: %w", err)
+	}
+	contentType := http.DetectContentType(head)
+	ext, ok := allowedTypes[contentType]
+	if !ok {
+		return nil, ErrUnsupportedFormat
+	}
+
+	user, err := s.users.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := avatarKey(userID, ext)
+	if err != nil {
+		return nil, err
+	}
+	url, err := s.storage.Put(ctx, key, io.LimitReader(buffered, size), size, contentType)
+	if err != nil {
+		return nil, err
+	}
+
+	previous := user.AvatarURL
+	user.AvatarURL = url
+	user.UpdatedAt = time.Now().UTC()
+	if err := s.users.Update(ctx, user); err != nil {
+		if delErr := s.storage.Delete(ctx, key); delErr != nil {
+			s.logger.Error("avatar_cleanup_failed", map[string]any{"key": key, "error": delErr.Error()})
+		}
+		return nil, err
+	}
+
+	change := domain.NewProfileChange(userID, userID, []domain.FieldChange{
+		{Field: domain.FieldAvatarURL, Old: previous, New: url},
+	}, user.UpdatedAt)
+	if err := s.history.Create(ctx, change); err != nil {
+		s.logger.Error("avatar_history_failed", map[string]any{"user_id": userID.String(), "error": err.Error()})
+	}
+
+	// Старый аватар удаляется, только если он лежит в нашем хранилище (а не внешняя ссылка).
+	if oldKey, ok := s.storage.KeyFromURL(previous); ok {
+		if err := s.storage.Delete(ctx, oldKey); err != nil {
+			s.logger.Error("avatar_cleanup_failed", map[string]any{"key": oldKey, "error": err.Error()})
+		}
+	}
+
+	return user, nil
+}
+
+// avatarKey строит ключ объекта со случайным суффиксом, чтобы новые аватары не кешировались по старому URL.
+func avatarKey(userID uuid.UUID, ext string) (string, error) {
+	suffix := make([]byte, 8)
+	if _, err := rand.Read(suffix); err != nil {
+		return "", fmt.Errorf("failed to generate avatar key: %w", err)
+	}
+	return "avatars/" + userID.String() + "/" + hex.EncodeToString(suffix) + ext, nil
+}
@@ -0,0 +1,294 @@
+package backfill
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	domain "workout-app/internal/domain/backfill"
+	repo "workout-app/internal/repository/interfaces"
+	"workout-app/pkg/logger"
+)
+
+// Job описывает тип задачи пересчёта, обрабатывающий элементы пачками по возрастанию курсора.
+type Job interface {
+	// Count возвращает оценку количества элементов для отображения прогресса.
+	Count(ctx context.Context) (int64, error)
+
+	// RunBatch обрабатывает до limit элементов с курсором строго больше after ("" — с начала).
+	// Возвращает курсор последнего обработанного элемента и их количество;
+	// n == 0 означает, что элементы закончились.
+	// Обработка одного элемента должна быть идемпотентной: после рестарта последняя пачка может повториться.
+	RunBatch(ctx context.Context, after string, limit int) (last string, n int, err error)
+}
+
+// Service описывает usecase-слой задач пересчёта исторических данных.
+type Service interface {
+	// Jobs возвращает имена зарегистрированных типов задач.
+	Jobs() []string
+
+	// Launch создаёт задачу пересчёта; выполнение начинается в фоне.
+	Launch(ctx context.Context, job string, batchSize int, actorID uuid.UUID) (*domain.Backfill, error)
+
+	// Get возвращает задачу с текущим прогрессом.
+	Get(ctx context.Context, id uuid.UUID) (*domain.Backfill, error)
+
+	// List возвращает последние задачи.
+	List(ctx context.Context) ([]*domain.Backfill, error)
+
+	// Cancel запрашивает отмену задачи.
+	Cancel(ctx context.Context, id uuid.UUID) error
+
+	// Start продолжает незавершённые задачи (например, прерванные рестартом).
+	// Фоновое выполнение останавливается при отмене ctx; задачи продолжатся при следующем Start.
+	Start(ctx context.Context) error
+
+	// Wait ожидает остановки всех фоновых задач.
+	Wait()
+}
+
+// Ошибки бизнес-логики usecase-слоя.
+var (
+	ErrUnknownJob       = fmt.Errorf("unknown backfill job")
+	ErrInvalidBatchSize = fmt.Errorf("invalid backfill batch size")
+)
+
+// Параметры выполнения задач.
+const (
+	defaultBatchSize = 500
+	maxBatchSize     = 10000
+	listLimit        = 50
+	// leaseTTL — срок аренды задачи; если процесс упал, другой подхватит задачу по истечении аренды.
+	leaseTTL = time.Minute
+)
+
+type service struct {
+	backfills repo.BackfillRepository
+	jobs      map[string]Job
+	logger    logger.Logger
+	// owner идентифицирует текущий процесс при захвате аренды.
+	owner string
+	// batchPause — пауза между пачками, чтобы пересчёт не вытеснял пользовательскую нагрузку.
+	batchPause time.Duration
+
+	mu     sync.Mutex
+	runCtx context.Context
+	active map[uuid.UUID]struct{}
+	wg     sync.WaitGroup
+}
+
+// NewService создаёт новый сервис задач пересчёта с набором зарегистрированных типов задач.
+func NewService(backfills repo.BackfillRepository, jobs map[string]Job, log logger.Logger, batchPause time.Duration) Service {
+	return &service{
+		backfills:  backfills,
+		jobs:       jobs,
+		logger:     log,
+		owner:      uuid.NewString(),
+		batchPause: batchPause,
+		active:     make(map[uuid.UUID]struct{}),
+	}
+}
+
+// Jobs возвращает отсортированные имена зарегистрированных типов задач.
+func (s *service) Jobs() []string {
+	names := make([]string, 0, len(s.jobs))
+	for name := range s.jobs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Launch создаёт задачу пересчёта и запускает её в фоне.
+func (s *service) Launch(ctx context.Context, job string, batchSize int, actorID uuid.UUID) (*domain.Backfill, error) {
+	j, ok := s.jobs[job]
+	if !ok {
+		return nil, ErrUnknownJob
+	}
+	if batchSize == 0 {
+		batchSize = defaultBatchSize
+	}
+	if batchSize < 1 || batchSize > maxBatchSize {
+		return nil, ErrInvalidBatchSize
+	}
+
+	total, err := j.Count(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	b := domain.New(job, batchSize, total, actorID)
+	if err := s.backfills.Create(ctx, b); err != nil {
+		return nil, err
+	}
+
+	s.spawn(b)
+	return b, nil
+}
+
+// Get возвращает задачу с текущим прогрессом.
+func (s *service) Get(ctx context.Context, id uuid.UUID) (*domain.Backfill, error) {
+	return s.backfills.GetByID(ctx, id)
+}
+
+// List возвращает последние задачи.
+func (s *service) List(ctx context.Context) ([]*domain.Backfill, error) {
+	return s.backfills.List(ctx, listLimit)
+}
+
+// Cancel запрашивает отмену задачи.
+func (s *service) Cancel(ctx context.Context, id uuid.UUID) error {
+	return s.backfills.RequestCancel(ctx, id)
+}
+
+// Start продолжает незавершённые задачи.
+func (s *service) Start(ctx context.Context) error {
+	s.mu.Lock()
+	s.runCtx = ctx
+	s.mu.Unlock()
+
+	backfills, err := s.backfills.ListResumable(ctx)
+	if err != nil {
+		return err
+	}
+	for _, b := range backfills {
+		s.spawn(b)
+	}
+	return nil
+}
+
+// Wait ожидает остановки всех фоновых задач.
+func (s *service) Wait() {
+	s.wg.Wait()
+}
+
+// spawn запускает выполнение задачи в фоне, если сервис запущен и задача ещё не выполняется этим процессом.
+// До вызова Start задача остаётся в статусе pending и будет подхвачена при старте.
+func (s *service) spawn(b *domain.Backfill) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.runCtx == nil || s.runCtx.Err() != nil {
+		return
+	}
+	if _, running := s.active[b.ID]; running {
+		return
+	}
+	s.active[b.ID] = struct{}{}
+
+	s.wg.Add(1)
+	go func(ctx context.Context) {
+		defer s.wg.Done()
+		defer func() {
+			s.mu.Lock()
+			delete(s.active, b.ID)
+			s.mu.Unlock()
+		}()
+		s.run(ctx, b)
+	}(s.runCtx)
+}
+
+// run выполняет задачу пачками, сохраняя прогресс после каждой пачки.
+func (s *service) run(ctx context.Context, b *domain.Backfill) {
+	claimed, err := s.backfills.Claim(ctx, b.ID, s.owner, leaseTTL)
+	if err != nil {
+		s.logger.Error("backfill_claim_failed", map[string]any{"backfill_id": b.ID.String(), "error": err.Error()})
+		return
+	}
+	if !claimed {
+		// Задачу выполняет другой процесс
+		return
+	}
+
+	job, ok := s.jobs[b.Job]
+	if !ok {
+		s.finish(b, domain.StatusFailed, ErrUnknownJob.Error())
+		return
+	}
+
+	s.logger.Info("backfill_started", map[string]any{
+		"backfill_id": b.ID.String(),
+		"job":         b.Job,
+		"cursor":      b.Cursor,
+		"processed":   b.Processed,
+	})
+
+	cursor, processed := b.Cursor, b.Processed
+	for {
+		if ctx.Err() != nil {
+			// Остановка процесса: аренда истечёт, и задача продолжится с сохранённого курсора.
+			return
+		}
+
+		current, err := s.backfills.GetByID(ctx, b.ID)
+		if err != nil {
+			if ctx.Err() == nil {
+				s.finish(b, domain.StatusFailed, err.Error())
+			}
+			return
+		}
+		if current.CancelRequested {
+			s.finish(b, domain.StatusCancelled, "")
+			return
+		}
+
+		last, n, err := job.RunBatch(ctx, cursor, b.BatchSize)
+		if err != nil {
+			if ctx.Err() == nil {
+				s.finish(b, domain.StatusFailed, err.Error())
+			}
+			return
+		}
+		if n == 0 {
+			s.finish(b, domain.StatusCompleted, "")
+			return
+		}
+
+		cursor = last
+		processed += int64(n)
+		if err := s.backfills.SaveProgress(ctx, b.ID, s.owner, cursor, processed, leaseTTL); err != nil {
+			if !errors.Is(err, repo.ErrBackfillLeaseLost) && ctx.Err() == nil {
+				s.logger.Error("backfill_save_progress_failed", map[string]any{"backfill_id": b.ID.String(), "error": err.Error()})
+			}
+			return
+		}
+
+		if s.batchPause > 0 {
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(s.batchPause):
+			}
+		}
+	}
+}
+
+// finish переводит задачу в финальное состояние.
+// Использует отдельный контекст, чтобы результат записался даже при остановке процесса.
+func (s *service) finish(b *domain.Backfill, status domain.Status, lastError string) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	fields := map[string]any{
+		"backfill_id": b.ID.String(),
+		"job":         b.Job,
+		"status":      string(status),
+	}
+	if err := s.backfills.Finish(ctx, b.ID, s.owner, status, lastError); err != nil {
+		fields["error"] = err.Error()
+		s.logger.Error("backfill_finish_failed", fields)
+		return
+	}
+
+	if lastError != "" {
+		fields["error"] = lastError
+		s.logger.Error("backfill_finished", fields)
+		return
+	}
+	s.logger.Info("backfill_finished", fields)
+}
@@ -0,0 +1,57 @@
+package backfill
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	repo "workout-app/internal/repository/interfaces"
+)
+
+// UserJob — задача пересчёта, обходящая всех активных пользователей по возрастанию ID.
+// Курсором служит ID последнего обработанного пользователя.
+type UserJob struct {
+	users   repo.UserRepository
+	process func(ctx context.Context, userID uuid.UUID) error
+}
+
+// Убедимся на этапе компиляции, что структура реализует интерфейс.
+var _ Job = (*UserJob)(nil)
+
+// NewUserJob создаёт задачу, вызывающую process для каждого пользователя.
+func NewUserJob(users repo.UserRepository, process func(ctx context.Context, userID uuid.UUID) error) *UserJob {
+	return &UserJob{users: users, process: process}
+}
+
+// Count возвращает количество активных пользователей.
+func (j *UserJob) Count(ctx context.Context) (int64, error) {
+	return j.users.Count(ctx)
+}
+
+// RunBatch обрабатывает следующую пачку пользователей.
+func (j *UserJob) RunBatch(ctx context.Context, after string, limit int) (string, int, error) {
+	afterID := uuid.Nil
+	if after != "" {
+		id, err := uuid.Parse(after)
+		if err != nil {
+			return "", 0, err
+		}
+		afterID = id
+	}
+
+	ids, err := j.users.ListIDsAfter(ctx, afterID, limit)
+	if err != nil {
+		return "", 0, err
+	}
+
+	for _, id := range ids {
+		// При ошибке пачка будет повторена целиком, поэтому process должен быть идемпотентным.
+		if err := j.process(ctx, id); err != nil {
+			return "", 0, err
+		}
+	}
+	if len(ids) == 0 {
+		return after, 0, nil
+	}
+	return ids[len(ids)-1].String(), len(ids), nil
+}
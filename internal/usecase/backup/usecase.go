@@ -0,0 +1,278 @@
+package backup
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	domain "workout-app/internal/domain/backup"
+	repo "workout-app/internal/repository/interfaces"
+	"workout-app/pkg/logger"
+	"workout-app/pkg/storage"
+)
+
+// Service описывает usecase-слой зашифрованных резервных копий локальных данных приложения,
+// которые переносятся на новое устройство. Сервер не расшифровывает копии: он ограничивает размер,
+// проверяет контрольную сумму и хранит несколько последних версий в пределах квоты пользователя.
+type Service interface {
+	// Upload сохраняет новую версию резервной копии. input.BaseVersion — последняя версия,
+	// которую видел клиент (0 — копий ещё нет); если сохранена другая, возвращается
+	// *VersionConflictError. Старые версии сверх лимита и квоты удаляются.
+	Upload(ctx context.Context, userID uuid.UUID, input UploadInput) (*domain.Backup, error)
+
+	// Overview возвращает сохранённые версии резервной копии и занятое ими место.
+	Overview(ctx context.Context, userID uuid.UUID) (*Overview, error)
+
+	// Open открывает версию резервной копии для скачивания (0 — последнюю).
+	Open(ctx context.Context, userID uuid.UUID, version int) (*Download, error)
+
+	// Delete удаляет все версии резервной копии пользователя.
+	Delete(ctx context.Context, userID uuid.UUID) error
+}
+
+// Config задаёт ограничения резервных копий.
+type Config struct {
+	MaxBytes    int64 // Максимальный размер одной версии
+	QuotaBytes  int64 // Суммарный размер хранимых версий пользователя
+	MaxVersions int   // Сколько последних версий хранить
+}
+
+// UploadInput описывает загружаемую версию резервной копии.
+type UploadInput struct {
+	BaseVersion int       // Последняя версия, которую видел клиент; 0 — копий ещё нет
+	SHA256      string    // Контрольная сумма содержимого в hex, посчитанная клиентом
+	Body        io.Reader // Зашифрованное содержимое
+	Size        int64     // Размер содержимого в байтах
+}
+
+// Overview описывает сохранённые версии резервной копии пользователя и ограничения.
+type Overview struct {
+	Backups   []*domain.Backup // Новые первыми
+	UsedBytes int64
+	Config    Config
+}
+
+// Download — версия резервной копии для отдачи клиенту: либо открытый объект, либо ссылка для редиректа.
+type Download struct {
+	Backup      *domain.Backup
+	Object      *storage.Object
+	RedirectURL string
+}
+
+// Ошибки бизнес-логики usecase-слоя.
+var (
+	ErrEmptyBackup         = fmt.Errorf("backup is empty")
+	ErrBackupTooLarge      = fmt.Errorf("backup is too large")
+	ErrInvalidChecksum     = fmt.Errorf("checksum must be a hex-encoded SHA-256")
+	ErrChecksumMismatch    = fmt.Errorf("backup content does not match the checksum")
+	ErrInvalidBaseVersion  = fmt.Errorf("base version must not be negative")
+	ErrBackupNotFound      = fmt.Errorf("backup not found")
+	ErrVersionConflict     = fmt.Errorf("backup was uploaded with another version")
+	ErrDownloadUnsupported = fmt.Errorf("storage does not support downloads")
+)
+
+// VersionConflictError сообщает последнюю сохранённую версию. Совместима с ErrVersionConflict.
+type VersionConflictError struct {
+	Current int
+}
+
+func (e *VersionConflictError) Error() string {
+	return fmt.Sprintf("latest backup version is %d", e.Current)
+}
+
+func (e *VersionConflictError) Unwrap() error {
+	return ErrVersionConflict
+}
+
+// contentType — тип содержимого резервных копий: сервер не знает их формата.
+const contentType = "application/octet-stream"
+
+// downloadLinkTTL — срок действия presigned-ссылки на скачивание резервной копии.
+const downloadLinkTTL = 15 * time.Minute
+
+type service struct {
+	backups repo.BackupRepository
+	storage storage.Storage
+	cfg     Config
+	logger  logger.Logger
+}
+
+// NewService создаёт новый сервис резервных копий.
+func NewService(backups repo.BackupRepository, storage storage.Storage, cfg Config, logger logger.Logger) Service {
+	return &service{
+		backups: backups,
+		storage: storage,
+		cfg:     cfg,
+		logger:  logger,
+	}
+}
+
+// Upload сохраняет новую версию резервной копии.
+func (s *service) Upload(ctx context.Context, userID uuid.UUID, input UploadInput) (*domain.Backup, error) {
+	if input.Size <= 0 {
+		return nil, ErrEmptyBackup
+	}
+	if input.Size > s.cfg.MaxBytes {
+		return nil, ErrBackupTooLarge
+	}
+	if input.BaseVersion < 0 {
+		return nil, ErrInvalidBaseVersion
+	}
+	checksum := strings.ToLower(strings.TrimSpace(input.SHA256))
+	if sum, err := hex.DecodeString(checksum); err != nil || len(sum) != sha256.Size {
+		return nil, ErrInvalidChecksum
+	}
+
+	existing, err := s.backups.ListByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	latest := 0
+	if len(existing) > 0 {
+		latest = existing[0].Version
+	}
+	if input.BaseVersion != latest {
+		return nil, &VersionConflictError{Current: latest}
+	}
+
+	key, err := backupKey()
+	if err != nil {
+		return nil, err
+	}
+	hash := sha256.New()
+	body := io.TeeReader(io.LimitReader(input.Body, input.Size), hash)
+	if _, err := s.storage.Put(ctx, key, body, input.Size, contentType); err != nil {
+		return nil, err
+	}
+	if hex.EncodeToString(hash.Sum(nil)) != checksum {
+		s.deleteObject(ctx, key)
+		return nil, ErrChecksumMismatch
+	}
+
+	backup := domain.New(userID, latest+1, input.Size, checksum, key)
+	if err := s.backups.Create(ctx, backup); err != nil {
+		s.deleteObject(ctx, key)
+		if errors.Is(err, repo.ErrBackupVersionExists) {
+			// Параллельная загрузка с той же базовой версией успела сохраниться первой.
+			return nil, &VersionConflictError{Current: backup.Version}
+		}
+		return nil, err
+	}
+
+	s.prune(ctx, append([]*domain.Backup{backup}, existing...))
+	return backup, nil
+}
+
+// prune удаляет старые версии, не помещающиеся в лимит версий или квоту; последняя версия сохраняется всегда.
+// Ошибки удаления только логируются: загрузка уже выполнена, а лишние версии удалит следующая загрузка.
+func (s *service) prune(ctx context.Context, backups []*domain.Backup) {
+	var used int64
+	for i, b := range backups {
+		used += b.SizeBytes
+		if i == 0 || (i < s.cfg.MaxVersions && used <= s.cfg.QuotaBytes) {
+			continue
+		}
+		if err := s.backups.Delete(ctx, b.ID); err != nil && !errors.Is(err, repo.ErrNotFound) {
+			s.logger.Error("backup_prune_failed", map[string]any{
+				"user_id": b.UserID.String(),
+				"version": b.Version,
+				"error":   err.Error(),
+			})
+			continue
+		}
+		s.deleteObject(ctx, b.StorageKey)
+	}
+}
+
+// Overview возвращает версии резервной копии пользователя.
+func (s *service) Overview(ctx context.Context, userID uuid.UUID) (*Overview, error) {
+	backups, err := s.backups.ListByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	overview := &Overview{Backups: backups, Config: s.cfg}
+	for _, b := range backups {
+		overview.UsedBytes += b.SizeBytes
+	}
+	return overview, nil
+}
+
+// Open открывает версию резервной копии.
+func (s *service) Open(ctx context.Context, userID uuid.UUID, version int) (*Download, error) {
+	backups, err := s.backups.ListByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	var backup *domain.Backup
+	for _, b := range backups {
+		if version == 0 || b.Version == version {
+			backup = b
+			break
+		}
+	}
+	if backup == nil {
+		return nil, ErrBackupNotFound
+	}
+
+	switch st := s.storage.(type) {
+	case storage.Opener:
+		obj, err := st.Open(ctx, backup.StorageKey)
+		if err != nil {
+			if errors.Is(err, storage.ErrNotFound) {
+				return nil, ErrBackupNotFound
+			}
+			return nil, err
+		}
+		return &Download{Backup: backup, Object: obj}, nil
+	case storage.Presigner:
+		redirect, err := st.PresignGet(backup.StorageKey, downloadLinkTTL)
+		if err != nil {
+			return nil, err
+		}
+		return &Download{Backup: backup, RedirectURL: redirect}, nil
+	default:
+		return nil, ErrDownloadUnsupported
+	}
+}
+
+// Delete удаляет все версии резервной копии пользователя.
+func (s *service) Delete(ctx context.Context, userID uuid.UUID) error {
+	backups, err := s.backups.ListByUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if len(backups) == 0 {
+		return ErrBackupNotFound
+	}
+	if err := s.backups.DeleteByUserID(ctx, userID); err != nil {
+		return err
+	}
+	for _, b := range backups {
+		s.deleteObject(ctx, b.StorageKey)
+	}
+	return nil
+}
+
+func (s *service) deleteObject(ctx context.Context, key string) {
+	if err := s.storage.Delete(ctx, key); err != nil {
+		s.logger.Error("backup_cleanup_failed", map[string]any{"key": key, "error": err.Error()})
+	}
+}
+
+// backupKey строит ключ объекта со случайным именем: локальное хранилище раздаётся статически,
+// поэтому ссылка на файл не должна угадываться.
+func backupKey() (string, error) {
+	suffix := make([]byte, 16)
+	if _, err := rand.Read(suffix); err != nil {
+		return "", fmt.Errorf("failed to generate backup key: %w", err)
+	}
+	return "backups/" + hex.EncodeToString(suffix) + ".bin", nil
+}
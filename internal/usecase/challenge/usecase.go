@@ -0,0 +1,346 @@
+package challenge
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+
+	domain "workout-app/internal/domain/challenge"
+	userdomain "workout-app/internal/domain/user"
+	repo "workout-app/internal/repository/interfaces"
+)
+
+// Service описывает usecase-слой челленджей: создание, вступление и выход участников,
+// автоматический подсчёт прогресса по завершённым тренировкам и таблицу лидеров.
+type Service interface {
+	// Create создаёт челлендж; создатель сразу становится участником.
+	Create(ctx context.Context, creatorID uuid.UUID, input Input) (*domain.Listing, error)
+
+	// List возвращает незавершённые челленджи (joinedOnly — только те, где участвует userID,
+	// включая завершённые), ближайшие к завершению первыми.
+	List(ctx context.Context, userID uuid.UUID, joinedOnly bool, limit, offset int) ([]*domain.Listing, error)
+
+	// Get возвращает челлендж с числом участников и прогрессом userID (nil, если он не участвует).
+	Get(ctx context.Context, userID, id uuid.UUID) (*Details, error)
+
+	// Join добавляет userID в участники незавершённого челленджа; повторное вступление не ошибка.
+	Join(ctx context.Context, userID, id uuid.UUID) error
+
+	// Leave удаляет userID из участников незавершённого челленджа; выход без участия не ошибка.
+	Leave(ctx context.Context, userID, id uuid.UUID) error
+
+	// Delete удаляет челлендж. Доступно создателю и администратору.
+	Delete(ctx context.Context, actor Actor, id uuid.UUID) error
+
+	// Leaderboard возвращает limit лучших участников и место userID.
+	Leaderboard(ctx context.Context, userID, id uuid.UUID, limit int) (*Leaderboard, error)
+}
+
+// Actor описывает пользователя, от имени которого выполняется операция.
+type Actor struct {
+	UserID uuid.UUID
+	Role   userdomain.Role
+}
+
+// Input описывает создаваемый челлендж.
+type Input struct {
+	Title       string
+	Description string
+	Metric      domain.Metric
+	Target      int
+	StartsAt    time.Time // Нулевое значение — с текущего момента
+	EndsAt      time.Time
+}
+
+// Standing — место участника в таблице лидеров.
+type Standing struct {
+	Rank      int // Участники с одинаковым значением делят место
+	UserID    uuid.UUID
+	Username  string
+	Value     int
+	Completed bool // Значение достигло цели челленджа
+	JoinedAt  time.Time
+}
+
+// Details — челлендж с прогрессом текущего пользователя.
+type Details struct {
+	Listing *domain.Listing
+	Me      *Standing
+}
+
+// Leaderboard — таблица лидеров челленджа.
+type Leaderboard struct {
+	Challenge    *domain.Challenge
+	Participants int
+	Standings    []Standing // Лучшие участники по местам
+	Me           *Standing  // nil, если пользователь не участвует
+}
+
+// Ошибки бизнес-логики usecase-слоя.
+var (
+	ErrInvalidTitle       = fmt.Errorf("challenge title must be 1-100 characters")
+	ErrInvalidDescription = fmt.Errorf("challenge description is too long")
+	ErrInvalidMetric      = fmt.Errorf("challenge metric must be sessions or active_days")
+	ErrInvalidTarget      = fmt.Errorf("challenge target is out of range")
+	ErrInvalidPeriod      = fmt.Errorf("invalid challenge period")
+	ErrChallengeNotFound  = fmt.Errorf("challenge not found")
+	ErrChallengeFinished  = fmt.Errorf("challenge is finished")
+	ErrForbidden          = fmt.Errorf("only the creator or an admin can delete the challenge")
+)
+
+// Ограничения челленджей.
+const (
+	maxTitleLength       = 100
+	maxDescriptionLength = 1000
+	maxTarget            = 1000
+	maxDuration          = 366 * 24 * time.Hour
+	defaultListLimit     = 20
+	maxListLimit         = 100
+	defaultBoardLimit    = 50
+	maxBoardLimit        = 100
+)
+
+type service struct {
+	tx         repo.Transactor
+	challenges repo.ChallengeRepository
+	now        func() time.Time
+}
+
+// NewService создаёт новый сервис челленджей.
+func NewService(tx repo.Transactor, challenges repo.ChallengeRepository) Service {
+	return &service{
+		tx:         tx,
+		challenges: challenges,
+		now:        func() time.Time { return time.Now().UTC() },
+	}
+}
+
+// Create создаёт челлендж и добавляет в него создателя.
+func (s *service) Create(ctx context.Context, creatorID uuid.UUID, input Input) (*domain.Listing, error) {
+	now := s.now()
+	input.Title = strings.TrimSpace(input.Title)
+	input.Description = strings.TrimSpace(input.Description)
+	if input.Title == "" || utf8.RuneCountInString(input.Title) > maxTitleLength {
+		return nil, ErrInvalidTitle
+	}
+	if utf8.RuneCountInString(input.Description) > maxDescriptionLength {
+		return nil, ErrInvalidDescription
+	}
+	if !input.Metric.IsValid() {
+		return nil, ErrInvalidMetric
+	}
+	if input.Target < 1 || input.Target > maxTarget {
+		return nil, ErrInvalidTarget
+	}
+	if input.StartsAt.IsZero() {
+		input.StartsAt = now
+	}
+	// Начало допускается с начала текущих суток: челлендж «с сегодняшнего дня» учитывает утренние тренировки.
+	y, m, d := now.Date()
+	today := time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+	if input.StartsAt.Before(today) || !input.EndsAt.After(input.StartsAt) ||
+		!input.EndsAt.After(now) || input.EndsAt.Sub(input.StartsAt) > maxDuration {
+		return nil, ErrInvalidPeriod
+	}
+
+	challenge := domain.New(creatorID, input.Title, input.Description, input.Metric, input.Target,
+		input.StartsAt.UTC(), input.EndsAt.UTC())
+	err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.challenges.Create(ctx, challenge); err != nil {
+			return err
+		}
+		return s.challenges.AddParticipant(ctx, &domain.Participant{
+			ChallengeID: challenge.ID,
+			UserID:      creatorID,
+			JoinedAt:    challenge.CreatedAt,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &domain.Listing{Challenge: challenge, Participants: 1, Joined: true}, nil
+}
+
+// List возвращает челленджи.
+func (s *service) List(ctx context.Context, userID uuid.UUID, joinedOnly bool, limit, offset int) ([]*domain.Listing, error) {
+	limit, offset = normalizePage(limit, offset, defaultListLimit, maxListLimit)
+	filter := repo.ChallengeFilter{UserID: userID, JoinedOnly: joinedOnly, Limit: limit, Offset: offset}
+	if !joinedOnly {
+		filter.EndsAfter = s.now()
+	}
+	return s.challenges.List(ctx, filter)
+}
+
+// Get возвращает челлендж и прогресс пользователя.
+func (s *service) Get(ctx context.Context, userID, id uuid.UUID) (*Details, error) {
+	listing, err := s.challenges.GetListing(ctx, id, userID)
+	if err != nil {
+		if errors.Is(err, repo.ErrNotFound) {
+			return nil, ErrChallengeNotFound
+		}
+		return nil, err
+	}
+	details := &Details{Listing: listing}
+	if !listing.Joined {
+		return details, nil
+	}
+
+	standings, err := s.standings(ctx, listing.Challenge)
+	if err != nil {
+		return nil, err
+	}
+	details.Me = findStanding(standings, userID)
+	return details, nil
+}
+
+// Join добавляет пользователя в участники.
+func (s *service) Join(ctx context.Context, userID, id uuid.UUID) error {
+	challenge, err := s.open(ctx, id)
+	if err != nil {
+		return err
+	}
+	return s.challenges.AddParticipant(ctx, &domain.Participant{
+		ChallengeID: challenge.ID,
+		UserID:      userID,
+		JoinedAt:    s.now(),
+	})
+}
+
+// Leave удаляет пользователя из участников.
+func (s *service) Leave(ctx context.Context, userID, id uuid.UUID) error {
+	challenge, err := s.open(ctx, id)
+	if err != nil {
+		return err
+	}
+	return s.challenges.RemoveParticipant(ctx, challenge.ID, userID)
+}
+
+// open возвращает челлендж, состав которого ещё можно менять.
+func (s *service) open(ctx context.Context, id uuid.UUID) (*domain.Challenge, error) {
+	challenge, err := s.get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	// Состав завершённого челленджа фиксируется вместе с таблицей лидеров.
+	if challenge.Status(s.now()) == domain.StatusFinished {
+		return nil, ErrChallengeFinished
+	}
+	return challenge, nil
+}
+
+// Delete удаляет челлендж.
+func (s *service) Delete(ctx context.Context, actor Actor, id uuid.UUID) error {
+	challenge, err := s.get(ctx, id)
+	if err != nil {
+		return err
+	}
+	if challenge.CreatorID != actor.UserID && actor.Role != userdomain.RoleAdmin {
+		return ErrForbidden
+	}
+	if err := s.challenges.Delete(ctx, id); err != nil {
+		if errors.Is(err, repo.ErrNotFound) {
+			return ErrChallengeNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+// Leaderboard возвращает таблицу лидеров.
+func (s *service) Leaderboard(ctx context.Context, userID, id uuid.UUID, limit int) (*Leaderboard, error) {
+	challenge, err := s.get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	standings, err := s.standings(ctx, challenge)
+	if err != nil {
+		return nil, err
+	}
+
+	limit, _ = normalizePage(limit, 0, defaultBoardLimit, maxBoardLimit)
+	board := &Leaderboard{
+		Challenge:    challenge,
+		Participants: len(standings),
+		Standings:    standings,
+		Me:           findStanding(standings, userID),
+	}
+	if len(board.Standings) > limit {
+		board.Standings = board.Standings[:limit]
+	}
+	return board, nil
+}
+
+// standings считает прогресс участников и расставляет их по местам: по убыванию значения,
+// при равенстве раньше вступившие выше, но место у них общее.
+func (s *service) standings(ctx context.Context, c *domain.Challenge) ([]Standing, error) {
+	progress, err := s.challenges.Progress(ctx, c)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(progress, func(i, j int) bool {
+		if progress[i].Value != progress[j].Value {
+			return progress[i].Value > progress[j].Value
+		}
+		if !progress[i].JoinedAt.Equal(progress[j].JoinedAt) {
+			return progress[i].JoinedAt.Before(progress[j].JoinedAt)
+		}
+		return progress[i].UserID.String() < progress[j].UserID.String()
+	})
+
+	standings := make([]Standing, 0, len(progress))
+	for i, p := range progress {
+		rank := i + 1
+		if i > 0 && p.Value == progress[i-1].Value {
+			rank = standings[i-1].Rank
+		}
+		standings = append(standings, Standing{
+			Rank:      rank,
+			UserID:    p.UserID,
+			Username:  p.Username,
+			Value:     p.Value,
+			Completed: p.Value >= c.Target,
+			JoinedAt:  p.JoinedAt,
+		})
+	}
+	return standings, nil
+}
+
+func (s *service) get(ctx context.Context, id uuid.UUID) (*domain.Challenge, error) {
+	challenge, err := s.challenges.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repo.ErrNotFound) {
+			return nil, ErrChallengeNotFound
+		}
+		return nil, err
+	}
+	return challenge, nil
+}
+
+func findStanding(standings []Standing, userID uuid.UUID) *Standing {
+	for i := range standings {
+		if standings[i].UserID == userID {
+			return &standings[i]
+		}
+	}
+	return nil
+}
+
+// normalizePage приводит параметры страницы к допустимым значениям.
+func normalizePage(limit, offset, defaultLimit, maxLimit int) (int, int) {
+	if offset < 0 {
+		offset = 0
+	}
+	if limit <= 0 {
+		limit = defaultLimit
+	}
+	if limit > maxLimit {
+		limit = maxLimit
+	}
+	return limit, offset
+}
@@ -0,0 +1,173 @@
+package checkin
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+
+	domain "workout-app/internal/domain/checkin"
+	consentdomain "workout-app/internal/domain/consent"
+	eventdomain "workout-app/internal/domain/event"
+	"workout-app/internal/events"
+	repo "workout-app/internal/repository/interfaces"
+	consentuc "workout-app/internal/usecase/consent"
+)
+
+// Service описывает usecase-слой ежедневных анкет готовности: запись анкеты за день,
+// серии заполнения и рекомендацию по нагрузке (в том числе разгрузку) по последним анкетам.
+type Service interface {
+	// Record сохраняет анкету за день; повторная анкета за тот же день заменяет предыдущую.
+	Record(ctx context.Context, userID uuid.UUID, input RecordInput) (*domain.CheckIn, error)
+
+	// List возвращает анкеты пользователя за дни [from, to], новые первыми.
+	List(ctx context.Context, userID uuid.UUID, from, to time.Time) ([]*domain.CheckIn, error)
+
+	// Summary возвращает серии заполнения и готовность пользователя за последнюю неделю.
+	Summary(ctx context.Context, userID uuid.UUID) (*Summary, error)
+
+	// ListForCoach возвращает анкеты и готовность клиента тренеру, если клиент открыл ему класс данных wellbeing.
+	ListForCoach(ctx context.Context, coachID, clientID uuid.UUID, from, to time.Time) (*ClientCheckIns, error)
+}
+
+// RecordInput описывает анкету на уровне бизнес-логики.
+type RecordInput struct {
+	Date       *time.Time // День анкеты; nil — сегодня (UTC)
+	Soreness   int
+	Energy     int
+	Motivation int
+	Note       string
+}
+
+// Summary описывает серии заполнения анкет и готовность пользователя.
+type Summary struct {
+	Streak    domain.Streak
+	Readiness domain.Readiness
+}
+
+// ClientCheckIns описывает анкеты клиента для тренера.
+type ClientCheckIns struct {
+	CheckIns  []*domain.CheckIn
+	Readiness domain.Readiness
+}
+
+// Ошибки бизнес-логики usecase-слоя.
+var (
+	ErrInvalidRating = fmt.Errorf("ratings must be between 1 and 10")
+	ErrInvalidNote   = fmt.Errorf("note is too long")
+	ErrInvalidDate   = fmt.Errorf("check-in date is out of the allowed range")
+	ErrInvalidPeriod = fmt.Errorf("invalid period")
+)
+
+// Ограничения анкет.
+const (
+	maxNoteLength = 500
+	// maxBackfillDays — за сколько прошедших дней можно заполнить пропущенную анкету.
+	maxBackfillDays = 2
+	maxListPeriod   = 366 * 24 * time.Hour
+)
+
+type service struct {
+	checkIns repo.CheckInRepository
+	events   events.Publisher
+	consents consentuc.Checker
+}
+
+// NewService создаёт новый сервис ежедневных анкет.
+// consents проверяет согласие клиента перед выдачей его анкет тренеру.
+func NewService(checkIns repo.CheckInRepository, publisher events.Publisher, consents consentuc.Checker) Service {
+	return &service{checkIns: checkIns, events: publisher, consents: consents}
+}
+
+// Record сохраняет анкету за день.
+func (s *service) Record(ctx context.Context, userID uuid.UUID, input RecordInput) (*domain.CheckIn, error) {
+	if !domain.ValidRating(input.Soreness) || !domain.ValidRating(input.Energy) || !domain.ValidRating(input.Motivation) {
+		return nil, ErrInvalidRating
+	}
+	note := strings.TrimSpace(input.Note)
+	if utf8.RuneCountInString(note) > maxNoteLength {
+		return nil, ErrInvalidNote
+	}
+
+	now := time.Now().UTC()
+	today := domain.Day(now)
+	day := today
+	if input.Date != nil {
+		day = domain.Day(*input.Date)
+		// Завтрашний день допускается для пользователей восточнее UTC.
+		if day.Before(today.AddDate(0, 0, -maxBackfillDays)) || day.After(today.AddDate(0, 0, 1)) {
+			return nil, ErrInvalidDate
+		}
+	}
+
+	c := domain.New(userID, day, input.Soreness, input.Energy, input.Motivation, note)
+	if err := s.checkIns.Upsert(ctx, c); err != nil {
+		return nil, err
+	}
+
+	recent, err := s.recent(ctx, userID, now)
+	if err != nil {
+		return nil, err
+	}
+	readiness := domain.AssessReadiness(recent, now)
+	s.events.Publish(ctx, eventdomain.TypeCheckInRecorded, c.UserID.String(), eventdomain.CheckInRecorded{
+		CheckInID:      c.ID.String(),
+		UserID:         c.UserID.String(),
+		Date:           c.Date,
+		Score:          c.Score(),
+		Recommendation: string(readiness.Recommendation),
+	})
+	return c, nil
+}
+
+// List возвращает анкеты пользователя за период.
+func (s *service) List(ctx context.Context, userID uuid.UUID, from, to time.Time) ([]*domain.CheckIn, error) {
+	from, to = domain.Day(from), domain.Day(to)
+	if from.After(to) || to.Sub(from) > maxListPeriod {
+		return nil, ErrInvalidPeriod
+	}
+	return s.checkIns.ListByUser(ctx, userID, from, to)
+}
+
+// Summary возвращает серии заполнения и готовность пользователя.
+func (s *service) Summary(ctx context.Context, userID uuid.UUID) (*Summary, error) {
+	now := time.Now().UTC()
+	days, err := s.checkIns.ListDays(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	recent, err := s.recent(ctx, userID, now)
+	if err != nil {
+		return nil, err
+	}
+	return &Summary{
+		Streak:    domain.ComputeStreak(days, now),
+		Readiness: domain.AssessReadiness(recent, now),
+	}, nil
+}
+
+// ListForCoach возвращает анкеты и готовность клиента тренеру.
+func (s *service) ListForCoach(ctx context.Context, coachID, clientID uuid.UUID, from, to time.Time) (*ClientCheckIns, error) {
+	if err := s.consents.Require(ctx, coachID, clientID, consentdomain.ScopeWellbeing); err != nil {
+		return nil, err
+	}
+	checkIns, err := s.List(ctx, clientID, from, to)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now().UTC()
+	recent, err := s.recent(ctx, clientID, now)
+	if err != nil {
+		return nil, err
+	}
+	return &ClientCheckIns{CheckIns: checkIns, Readiness: domain.AssessReadiness(recent, now)}, nil
+}
+
+// recent возвращает анкеты за окно оценки готовности.
+func (s *service) recent(ctx context.Context, userID uuid.UUID, now time.Time) ([]*domain.CheckIn, error) {
+	today := domain.Day(now)
+	return s.checkIns.ListByUser(ctx, userID, today.Add(-domain.ReadinessWindow), today.AddDate(0, 0, 1))
+}
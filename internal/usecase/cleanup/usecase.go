@@ -0,0 +1,136 @@
+package cleanup
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"workout-app/pkg/logger"
+)
+
+// Target описывает таблицу с записями, которые становятся ненужными после истечения срока.
+type Target interface {
+	// DeleteExpired удаляет не более limit записей, истёкших до before, и возвращает количество удалённых.
+	DeleteExpired(ctx context.Context, before time.Time, limit int) (int64, error)
+}
+
+// Service описывает usecase-слой очистки устаревших записей (истёкших кодов подтверждения и т.п.).
+type Service interface {
+	// Run удаляет истёкшие записи из всех зарегистрированных таблиц и возвращает количество удалённых по таблицам.
+	// Ошибка одной таблицы не мешает очистке остальных.
+	Run(ctx context.Context) (map[string]int64, error)
+
+	// Removed возвращает количество записей, удалённых с момента старта процесса, по таблицам.
+	Removed() map[string]int64
+}
+
+// maxBatchesPerRun ограничивает количество пачек одной таблицы за запуск,
+// чтобы накопившийся объём разбирался за несколько запусков, а не одним долгим.
+const maxBatchesPerRun = 100
+
+type service struct {
+	targets   map[string]Target
+	batchSize int
+	now       func() time.Time
+	logger    logger.Logger
+
+	mu      sync.Mutex
+	removed map[string]int64
+}
+
+// NewService создаёт сервис очистки.
+// Очищаются только явно зарегистрированные таблицы; batchSize — сколько строк удаляется одним запросом.
+func NewService(targets map[string]Target, batchSize int, logger logger.Logger) Service {
+	removed := make(map[string]int64, len(targets))
+	for name := range targets {
+		removed[name] = 0
+	}
+	return &service{
+		targets:   targets,
+		batchSize: batchSize,
+		now:       time.Now,
+		logger:    logger,
+		removed:   removed,
+	}
+}
+
+// Run удаляет истёкшие записи из всех зарегистрированных таблиц.
+func (s *service) Run(ctx context.Context) (map[string]int64, error) {
+	names := make([]string, 0, len(s.targets))
+	for name := range s.targets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	before := s.now()
+	result := make(map[string]int64, len(names))
+	var failed []string
+	for _, name := range names {
+		n, err := s.purge(ctx, s.targets[name], before)
+		result[name] = n
+		s.record(name, n)
+		if err != nil {
+			if ctx.Err() != nil {
+				return result, ctx.Err()
+			}
+			s.logger.Error("cleanup_target_failed", map[string]any{
+				"target":  name,
+				"removed": n,
+				"error":   err.Error(),
+			})
+			failed = append(failed, name)
+			continue
+		}
+		if n > 0 {
+			s.logger.Info("cleanup_rows_removed", map[string]any{
+				"target":  name,
+				"removed": n,
+			})
+		}
+	}
+
+	if len(failed) > 0 {
+		return result, fmt.Errorf("cleanup failed for %v", failed)
+	}
+	return result, nil
+}
+
+// Removed возвращает количество удалённых записей по таблицам с момента старта процесса.
+func (s *service) Removed() map[string]int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	out := make(map[string]int64, len(s.removed))
+	for name, n := range s.removed {
+		out[name] = n
+	}
+	return out
+}
+
+// purge удаляет истёкшие записи таблицы пачками, пока пачка заполняется целиком.
+func (s *service) purge(ctx context.Context, target Target, before time.Time) (int64, error) {
+	var total int64
+	for i := 0; i < maxBatchesPerRun; i++ {
+		if err := ctx.Err(); err != nil {
+			return total, err
+		}
+		n, err := target.DeleteExpired(ctx, before, s.batchSize)
+		total += n
+		if err != nil {
+			return total, err
+		}
+		if n < int64(s.batchSize) {
+			break
+		}
+	}
+	return total, nil
+}
+
+// record добавляет удалённые записи к счётчику таблицы.
+func (s *service) record(name string, n int64) {
+	s.mu.Lock()
+	s.removed[name] += n
+	s.mu.Unlock()
+}
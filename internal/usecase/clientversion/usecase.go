@@ -0,0 +1,154 @@
+package clientversion
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sync"
+	"time"
+
+	"workout-app/internal/compat"
+	domain "workout-app/internal/domain/clientversion"
+	repo "workout-app/internal/repository/interfaces"
+)
+
+// Service описывает usecase-слой минимальных поддерживаемых версий клиента.
+type Service interface {
+	// ListPolicies возвращает политики всех платформ.
+	ListPolicies(ctx context.Context) ([]*domain.Policy, error)
+
+	// SetPolicy задаёт минимальную версию и ссылку на магазин для платформы.
+	SetPolicy(ctx context.Context, platform domain.Platform, minVersion, storeURL string) (*domain.Policy, error)
+
+	// DeletePolicy снимает ограничение версии для платформы.
+	DeletePolicy(ctx context.Context, platform domain.Platform) error
+
+	// Check возвращает политику платформы, если версия клиента ниже минимальной, иначе nil.
+	Check(ctx context.Context, platform domain.Platform, v compat.Version) (*domain.Policy, error)
+
+	// Invalidate сбрасывает закешированные политики; следующая проверка перечитает их из БД.
+	Invalidate(ctx context.Context) error
+}
+
+// Ошибки бизнес-логики usecase-слоя.
+var (
+	ErrInvalidPlatform = fmt.Errorf("invalid platform")
+	ErrInvalidVersion  = fmt.Errorf("invalid version")
+	ErrInvalidStoreURL = fmt.Errorf("invalid store url")
+)
+
+// cachedPolicy — политика с заранее разобранной минимальной версией.
+type cachedPolicy struct {
+	policy *domain.Policy
+	min    compat.Version
+}
+
+type service struct {
+	policies repo.ClientVersionRepository
+	cacheTTL time.Duration
+
+	mu       sync.Mutex
+	cached   map[domain.Platform]cachedPolicy
+	loadedAt time.Time
+}
+
+// NewService создаёт новый сервис минимальных версий клиента.
+// Политики проверяются на каждом запросе, поэтому кешируются в памяти на cacheTTL;
+// изменения через сервис сбрасывают кеш сразу, на остальных инстансах — не позже cacheTTL
+// или по команде сброса кеша из административных служебных операций.
+func NewService(policies repo.ClientVersionRepository, cacheTTL time.Duration) Service {
+	return &service{
+		policies: policies,
+		cacheTTL: cacheTTL,
+	}
+}
+
+// ListPolicies возвращает политики всех платформ.
+func (s *service) ListPolicies(ctx context.Context) ([]*domain.Policy, error) {
+	return s.policies.List(ctx)
+}
+
+// SetPolicy задаёт минимальную версию и ссылку на магазин для платформы.
+func (s *service) SetPolicy(ctx context.Context, platform domain.Platform, minVersion, storeURL string) (*domain.Policy, error) {
+	if !platform.IsValid() {
+		return nil, ErrInvalidPlatform
+	}
+	v, ok := compat.ParseVersion(minVersion)
+	if !ok {
+		return nil, ErrInvalidVersion
+	}
+	u, err := url.Parse(storeURL)
+	if err != nil || (u.Scheme != "https" && u.Scheme != "http" && u.Scheme != "itms-apps" && u.Scheme != "market") || u.Host == "" {
+		return nil, ErrInvalidStoreURL
+	}
+
+	p := &domain.Policy{
+		Platform:   platform,
+		MinVersion: v.String(),
+		StoreURL:   storeURL,
+		UpdatedAt:  time.Now().UTC(),
+	}
+	if err := s.policies.Upsert(ctx, p); err != nil {
+		return nil, err
+	}
+	_ = s.Invalidate(ctx)
+	return p, nil
+}
+
+// DeletePolicy снимает ограничение версии для платформы.
+func (s *service) DeletePolicy(ctx context.Context, platform domain.Platform) error {
+	if !platform.IsValid() {
+		return ErrInvalidPlatform
+	}
+	if err := s.policies.Delete(ctx, platform); err != nil {
+		return err
+	}
+	return s.Invalidate(ctx)
+}
+
+// Check возвращает политику платформы, если версия клиента ниже минимальной, иначе nil.
+func (s *service) Check(ctx context.Context, platform domain.Platform, v compat.Version) (*domain.Policy, error) {
+	policies, err := s.snapshot(ctx)
+	if err != nil {
+		return nil, err
+	}
+	cp, ok := policies[platform]
+	if !ok || !v.Less(cp.min) {
+		return nil, nil
+	}
+	return cp.policy, nil
+}
+
+// Invalidate сбрасывает закешированные политики.
+func (s *service) Invalidate(_ context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.cached = nil
+	return nil
+}
+
+// snapshot возвращает закешированные политики или перечитывает их из БД.
+func (s *service) snapshot(ctx context.Context) (map[domain.Platform]cachedPolicy, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.cached != nil && time.Since(s.loadedAt) < s.cacheTTL {
+		return s.cached, nil
+	}
+
+	list, err := s.policies.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	cached := make(map[domain.Platform]cachedPolicy, len(list))
+	for _, p := range list {
+		v, ok := compat.ParseVersion(p.MinVersion)
+		if !ok {
+			continue
+		}
+		cached[p.Platform] = cachedPolicy{policy: p, min: v}
+	}
+	s.cached = cached
+	s.loadedAt = time.Now()
+	return cached, nil
+}
@@ -0,0 +1,293 @@
+package coach
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+
+	domain "workout-app/internal/domain/coach"
+	"workout-app/internal/domain/region"
+	userdomain "workout-app/internal/domain/user"
+	repo "workout-app/internal/repository/interfaces"
+)
+
+// Service описывает usecase-слой анкет тренеров и публичного каталога.
+// Тренер сам решает, публиковать ли анкету; отметку «проверенный тренер» ставит администратор.
+type Service interface {
+	// GetProfile возвращает анкету тренера; если анкеты нет — пустую неопубликованную анкету.
+	GetProfile(ctx context.Context, userID uuid.UUID) (*domain.Profile, error)
+
+	// UpdateProfile заменяет анкету тренера. Отметка о проверке сохраняется.
+	UpdateProfile(ctx context.Context, userID uuid.UUID, input ProfileInput) (*domain.Profile, error)
+
+	// ListDirectory возвращает страницу публичного каталога и общее количество подходящих анкет.
+	ListDirectory(ctx context.Context, query DirectoryQuery) ([]*domain.DirectoryEntry, int64, error)
+
+	// Verify ставит тренеру отметку «проверенный тренер».
+	Verify(ctx context.Context, actorID, coachID uuid.UUID, note string) (*domain.Profile, error)
+
+	// Unverify снимает отметку «проверенный тренер».
+	Unverify(ctx context.Context, coachID uuid.UUID) error
+}
+
+// ProfileInput описывает анкету тренера целиком.
+type ProfileInput struct {
+	Listed      bool
+	Headline    string
+	Bio         string
+	Specialties []domain.Specialty
+	Languages   []string
+	Country     string
+	City        string
+	Online      bool
+}
+
+// DirectoryQuery описывает фильтры и страницу каталога. Пустые фильтры не ограничивают выборку.
+type DirectoryQuery struct {
+	Specialty    domain.Specialty
+	Language     string
+	Country      string
+	City         string
+	Online       *bool
+	VerifiedOnly bool
+	Limit        int
+	Offset       int
+}
+
+// Ошибки бизнес-логики usecase-слоя.
+var (
+	ErrInvalidSpecialty   = fmt.Errorf("invalid coach specialty")
+	ErrTooManySpecialties = fmt.Errorf("too many coach specialties")
+	ErrInvalidLanguage    = fmt.Errorf("invalid coaching language")
+	ErrTooManyLanguages   = fmt.Errorf("too many coaching languages")
+	ErrInvalidCountry     = fmt.Errorf("invalid country code")
+	ErrHeadlineTooLong    = fmt.Errorf("coach headline is too long")
+	ErrBioTooLong         = fmt.Errorf("coach bio is too long")
+	ErrCityTooLong        = fmt.Errorf("city is too long")
+	ErrIncompleteProfile  = fmt.Errorf("coach profile must have a headline and a specialty to be listed")
+	ErrNoteTooLong        = fmt.Errorf("verification note is too long")
+	ErrNotCoach           = fmt.Errorf("user is not a coach")
+)
+
+const (
+	// maxHeadlineLength совпадает с длиной колонки headline.
+	maxHeadlineLength = 120
+	// maxBioLength ограничивает рассказ о себе.
+	maxBioLength = 2000
+	// maxCityLength совпадает с длиной колонки city.
+	maxCityLength = 100
+	// maxSpecialties и maxLanguages ограничивают длину списков в анкете.
+	maxSpecialties = 5
+	maxLanguages   = 5
+	// maxNoteLength ограничивает комментарий администратора к отметке.
+	maxNoteLength = 1000
+	// maxListLimit ограничивает размер страницы каталога.
+	maxListLimit = 100
+	// defaultListLimit — размер страницы по умолчанию.
+	defaultListLimit = 20
+)
+
+// languageRe — код языка ISO 639-1.
+var languageRe = regexp.MustCompile(`^[a-z]{2}$`)
+
+type service struct {
+	profiles repo.CoachProfileRepository
+	users    repo.UserRepository
+}
+
+// NewService создаёт новый сервис анкет тренеров.
+func NewService(profiles repo.CoachProfileRepository, users repo.UserRepository) Service {
+	return &service{
+		profiles: profiles,
+		users:    users,
+	}
+}
+
+// GetProfile возвращает анкету тренера.
+func (s *service) GetProfile(ctx context.Context, userID uuid.UUID) (*domain.Profile, error) {
+	p, err := s.profiles.Get(ctx, userID)
+	if errors.Is(err, repo.ErrNotFound) {
+		return &domain.Profile{UserID: userID, Specialties: []domain.Specialty{}, Languages: []string{}}, nil
+	}
+	return p, err
+}
+
+// UpdateProfile проверяет и сохраняет анкету тренера.
+func (s *service) UpdateProfile(ctx context.Context, userID uuid.UUID, input ProfileInput) (*domain.Profile, error) {
+	p, err := s.GetProfile(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	headline := strings.TrimSpace(input.Headline)
+	if utf8.RuneCountInString(headline) > maxHeadlineLength {
+		return nil, ErrHeadlineTooLong
+	}
+	bio := strings.TrimSpace(input.Bio)
+	if utf8.RuneCountInString(bio) > maxBioLength {
+		return nil, ErrBioTooLong
+	}
+	city := strings.TrimSpace(input.City)
+	if utf8.RuneCountInString(city) > maxCityLength {
+		return nil, ErrCityTooLong
+	}
+	country, err := normalizeCountry(input.Country)
+	if err != nil {
+		return nil, err
+	}
+	specialties, err := normalizeSpecialties(input.Specialties)
+	if err != nil {
+		return nil, err
+	}
+	languages, err := normalizeLanguages(input.Languages)
+	if err != nil {
+		return nil, err
+	}
+	// Пустые анкеты в каталоге бесполезны для поиска: публикация требует описания и направления.
+	if input.Listed && (headline == "" || len(specialties) == 0) {
+		return nil, ErrIncompleteProfile
+	}
+
+	now := time.Now().UTC()
+	if p.CreatedAt.IsZero() {
+		p.CreatedAt = now
+	}
+	p.Listed = input.Listed
+	p.Headline = headline
+	p.Bio = bio
+	p.Specialties = specialties
+	p.Languages = languages
+	p.Country = country
+	p.City = city
+	p.Online = input.Online
+	p.UpdatedAt = now
+
+	if err := s.profiles.Save(ctx, p); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// ListDirectory проверяет фильтры и возвращает страницу каталога.
+func (s *service) ListDirectory(ctx context.Context, query DirectoryQuery) ([]*domain.DirectoryEntry, int64, error) {
+	if query.Specialty != "" && !query.Specialty.IsValid() {
+		return nil, 0, ErrInvalidSpecialty
+	}
+	language := strings.ToLower(strings.TrimSpace(query.Language))
+	if language != "" && !languageRe.MatchString(language) {
+		return nil, 0, ErrInvalidLanguage
+	}
+	country, err := normalizeCountry(query.Country)
+	if err != nil {
+		return nil, 0, err
+	}
+	if query.Offset < 0 {
+		query.Offset = 0
+	}
+	if query.Limit <= 0 {
+		query.Limit = defaultListLimit
+	}
+	if query.Limit > maxListLimit {
+		query.Limit = maxListLimit
+	}
+
+	return s.profiles.ListDirectory(ctx, repo.CoachDirectoryFilter{
+		Specialty:    query.Specialty,
+		Language:     language,
+		Country:      country,
+		City:         strings.TrimSpace(query.City),
+		Online:       query.Online,
+		VerifiedOnly: query.VerifiedOnly,
+		Limit:        query.Limit,
+		Offset:       query.Offset,
+	})
+}
+
+// Verify ставит отметку «проверенный тренер». Отметку можно поставить только пользователю с ролью coach;
+// анкета при этом не публикуется — это решает сам тренер.
+func (s *service) Verify(ctx context.Context, actorID, coachID uuid.UUID, note string) (*domain.Profile, error) {
+	note = strings.TrimSpace(note)
+	if utf8.RuneCountInString(note) > maxNoteLength {
+		return nil, ErrNoteTooLong
+	}
+	user, err := s.users.GetByID(ctx, coachID)
+	if err != nil {
+		return nil, err
+	}
+	if user.Role != userdomain.RoleCoach {
+		return nil, ErrNotCoach
+	}
+
+	v := &domain.Verification{VerifiedAt: time.Now().UTC(), VerifiedBy: &actorID, Note: note}
+	if err := s.profiles.SetVerification(ctx, coachID, v); err != nil {
+		return nil, err
+	}
+	return s.GetProfile(ctx, coachID)
+}
+
+// Unverify снимает отметку «проверенный тренер».
+func (s *service) Unverify(ctx context.Context, coachID uuid.UUID) error {
+	if _, err := s.users.GetByID(ctx, coachID); err != nil {
+		return err
+	}
+	return s.profiles.SetVerification(ctx, coachID, nil)
+}
+
+// normalizeCountry приводит код страны к ISO 3166-1 alpha-2; пустая строка допустима.
+func normalizeCountry(raw string) (string, error) {
+	if strings.TrimSpace(raw) == "" {
+		return "", nil
+	}
+	country := region.NormalizeCountry(raw)
+	if country == "" {
+		return "", ErrInvalidCountry
+	}
+	return country, nil
+}
+
+// normalizeSpecialties проверяет направления и убирает повторы, сохраняя порядок.
+func normalizeSpecialties(raw []domain.Specialty) ([]domain.Specialty, error) {
+	result := make([]domain.Specialty, 0, len(raw))
+	seen := make(map[domain.Specialty]bool, len(raw))
+	for _, sp := range raw {
+		if !sp.IsValid() {
+			return nil, ErrInvalidSpecialty
+		}
+		if seen[sp] {
+			continue
+		}
+		seen[sp] = true
+		result = append(result, sp)
+	}
+	if len(result) > maxSpecialties {
+		return nil, ErrTooManySpecialties
+	}
+	return result, nil
+}
+
+// normalizeLanguages приводит коды языков к нижнему регистру и убирает повторы.
+func normalizeLanguages(raw []string) ([]string, error) {
+	result := make([]string, 0, len(raw))
+	seen := make(map[string]bool, len(raw))
+	for _, l := range raw {
+		l = strings.ToLower(strings.TrimSpace(l))
+		if !languageRe.MatchString(l) {
+			return nil, ErrInvalidLanguage
+		}
+		if seen[l] {
+			continue
+		}
+		seen[l] = true
+		result = append(result, l)
+	}
+	if len(result) > maxLanguages {
+		return nil, ErrTooManyLanguages
+	}
+	return result, nil
+}
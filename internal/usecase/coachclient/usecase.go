@@ -0,0 +1,272 @@
+package coachclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	domain "workout-app/internal/domain/coach"
+	userdomain "workout-app/internal/domain/user"
+	repo "workout-app/internal/repository/interfaces"
+	"workout-app/pkg/emailaddr"
+	"workout-app/pkg/mailer"
+)
+
+// Service описывает usecase-слой приглашений тренеров: тренер приглашает пользователя по email,
+// пользователь принимает или отклоняет приглашение и становится клиентом тренера.
+type Service interface {
+	// Invite приглашает зарегистрированного пользователя с этим email стать клиентом тренера
+	// и отправляет ему письмо с приглашением.
+	Invite(ctx context.Context, coachID uuid.UUID, email string) (*domain.Invitation, error)
+
+	// ListSentInvitations возвращает действующие приглашения тренера, новые первыми.
+	ListSentInvitations(ctx context.Context, coachID uuid.UUID) ([]SentInvitation, error)
+
+	// ListInvitations возвращает действующие приглашения тренеров пользователю, новые первыми.
+	ListInvitations(ctx context.Context, clientID uuid.UUID) ([]ReceivedInvitation, error)
+
+	// AcceptInvitation принимает приглашение: пользователь становится клиентом тренера.
+	// Какие данные открыть тренеру, клиент решает отдельно согласиями.
+	AcceptInvitation(ctx context.Context, clientID, invitationID uuid.UUID) (*domain.Client, error)
+
+	// DeclineInvitation отклоняет приглашение.
+	DeclineInvitation(ctx context.Context, clientID, invitationID uuid.UUID) error
+}
+
+// SentInvitation описывает приглашение тренера и приглашённого пользователя.
+type SentInvitation struct {
+	Invitation *domain.Invitation
+	Client     *userdomain.User
+}
+
+// ReceivedInvitation описывает приглашение пользователю и пригласившего тренера.
+type ReceivedInvitation struct {
+	Invitation *domain.Invitation
+	Coach      *userdomain.User
+}
+
+// Ошибки бизнес-логики usecase-слоя.
+var (
+	ErrUserNotFound       = fmt.Errorf("user not found")
+	ErrCannotInviteSelf   = fmt.Errorf("coach cannot invite themselves")
+	ErrAlreadyClient      = fmt.Errorf("user is already a client of the coach")
+	ErrAlreadyInvited     = fmt.Errorf("user already has a pending invitation from the coach")
+	ErrTooManyInvitations = fmt.Errorf("too many pending invitations")
+	ErrInvitationNotFound = fmt.Errorf("invitation not found")
+)
+
+const (
+	// invitationTTL — срок действия приглашения тренера.
+	invitationTTL = 7 * 24 * time.Hour
+	// maxPendingInvitations ограничивает число действующих приглашений одного тренера.
+	maxPendingInvitations = 100
+)
+
+type service struct {
+	tx      repo.Transactor
+	clients repo.CoachClientRepository
+	users   repo.UserRepository
+	sender  mailer.EmailSender
+}
+
+// NewService создаёт новый сервис приглашений тренеров. sender ставит письмо с приглашением
+// в очередь исходящих писем в той же транзакции, что и приглашение.
+func NewService(
+	tx repo.Transactor,
+	clients repo.CoachClientRepository,
+	users repo.UserRepository,
+	sender mailer.EmailSender,
+) Service {
+	return &service{
+		tx:      tx,
+		clients: clients,
+		users:   users,
+		sender:  sender,
+	}
+}
+
+// Invite приглашает пользователя стать клиентом тренера. Приглашение и письмо сохраняются в одной транзакции.
+func (s *service) Invite(ctx context.Context, coachID uuid.UUID, email string) (*domain.Invitation, error) {
+	coach, err := s.users.GetByID(ctx, coachID)
+	if err != nil {
+		return nil, err
+	}
+	email = emailaddr.Normalize(email)
+	client, err := s.users.GetByEmail(ctx, email)
+	if err != nil {
+		if errors.Is(err, repo.ErrNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, err
+	}
+	if client.IsDeleted() {
+		return nil, ErrUserNotFound
+	}
+	if client.ID == coachID {
+		return nil, ErrCannotInviteSelf
+	}
+	ok, err := s.clients.IsClient(ctx, coachID, client.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check coach client: %w", err)
+	}
+	if ok {
+		return nil, ErrAlreadyClient
+	}
+
+	now := time.Now().UTC()
+	pending, err := s.clients.ListInvitationsByCoach(ctx, coachID, now)
+	if err != nil {
+		return nil, err
+	}
+	if len(pending) >= maxPendingInvitations {
+		return nil, ErrTooManyInvitations
+	}
+
+	inv := domain.NewInvitation(coachID, client.ID, email, now, invitationTTL)
+	err = s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.clients.CreateInvitation(ctx, inv); err != nil {
+			if errors.Is(err, repo.ErrCoachInvitationExists) {
+				return ErrAlreadyInvited
+			}
+			return err
+		}
+		err := s.sender.SendCoachInvitation(mailer.WithRecipient(ctx, client.MailLocale(), client.Timezone), email, displayName(coach), inv.ExpiresAt)
+		if errors.Is(err, mailer.ErrRecipientUndeliverable) {
+			// Адрес заблокирован: приглашение остаётся в приложении.
+			return nil
+		}
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return inv, nil
+}
+
+// ListSentInvitations возвращает действующие приглашения тренера; приглашения удалённых пользователей пропускаются.
+func (s *service) ListSentInvitations(ctx context.Context, coachID uuid.UUID) ([]SentInvitation, error) {
+	invitations, err := s.clients.ListInvitationsByCoach(ctx, coachID, time.Now().UTC())
+	if err != nil {
+		return nil, err
+	}
+	result := make([]SentInvitation, 0, len(invitations))
+	for _, inv := range invitations {
+		client, err := s.activeUser(ctx, inv.ClientID)
+		if err != nil {
+			return nil, err
+		}
+		if client != nil {
+			result = append(result, SentInvitation{Invitation: inv, Client: client})
+		}
+	}
+	return result, nil
+}
+
+// ListInvitations возвращает действующие приглашения пользователю; приглашения удалённых тренеров пропускаются.
+func (s *service) ListInvitations(ctx context.Context, clientID uuid.UUID) ([]ReceivedInvitation, error) {
+	invitations, err := s.clients.ListInvitationsByClient(ctx, clientID, time.Now().UTC())
+	if err != nil {
+		return nil, err
+	}
+	result := make([]ReceivedInvitation, 0, len(invitations))
+	for _, inv := range invitations {
+		coach, err := s.activeUser(ctx, inv.CoachID)
+		if err != nil {
+			return nil, err
+		}
+		if coach != nil {
+			result = append(result, ReceivedInvitation{Invitation: inv, Coach: coach})
+		}
+	}
+	return result, nil
+}
+
+// AcceptInvitation принимает приглашение в транзакции: связь и удаление приглашения фиксируются вместе.
+func (s *service) AcceptInvitation(ctx context.Context, clientID, invitationID uuid.UUID) (*domain.Client, error) {
+	var client *domain.Client
+	err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		inv, err := s.invitation(ctx, clientID, invitationID)
+		if err != nil {
+			return err
+		}
+		coach, err := s.activeUser(ctx, inv.CoachID)
+		if err != nil {
+			return err
+		}
+		if coach == nil {
+			return ErrInvitationNotFound
+		}
+
+		client = &domain.Client{CoachID: inv.CoachID, ClientID: clientID, CreatedAt: time.Now().UTC()}
+		if err := s.clients.AddClient(ctx, client); err != nil {
+			return err
+		}
+		return s.deleteInvitation(ctx, inv.ID)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return client, nil
+}
+
+// DeclineInvitation отклоняет приглашение.
+func (s *service) DeclineInvitation(ctx context.Context, clientID, invitationID uuid.UUID) error {
+	inv, err := s.invitation(ctx, clientID, invitationID)
+	if err != nil {
+		return err
+	}
+	return s.deleteInvitation(ctx, inv.ID)
+}
+
+// invitation возвращает действующее приглашение пользователя clientID.
+// Чужое и истёкшее приглашения не отличаются от несуществующего.
+func (s *service) invitation(ctx context.Context, clientID, invitationID uuid.UUID) (*domain.Invitation, error) {
+	inv, err := s.clients.GetInvitation(ctx, invitationID)
+	if err != nil {
+		if errors.Is(err, repo.ErrNotFound) {
+			return nil, ErrInvitationNotFound
+		}
+		return nil, err
+	}
+	if inv.ClientID != clientID || inv.IsExpired(time.Now().UTC()) {
+		return nil, ErrInvitationNotFound
+	}
+	return inv, nil
+}
+
+func (s *service) deleteInvitation(ctx context.Context, id uuid.UUID) error {
+	if err := s.clients.DeleteInvitation(ctx, id); err != nil {
+		if errors.Is(err, repo.ErrNotFound) {
+			return ErrInvitationNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+// activeUser возвращает пользователя; nil — пользователь не найден или удалён.
+func (s *service) activeUser(ctx context.Context, id uuid.UUID) (*userdomain.User, error) {
+	u, err := s.users.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repo.ErrNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if u.IsDeleted() {
+		return nil, nil
+	}
+	return u, nil
+}
+
+// displayName возвращает имя тренера для письма: имя и фамилию, а без них — никнейм.
+func displayName(u *userdomain.User) string {
+	if name := strings.TrimSpace(u.FirstName + " " + u.LastName); name != "" {
+		return name
+	}
+	return u.Username
+}
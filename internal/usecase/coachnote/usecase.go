@@ -0,0 +1,180 @@
+package coachnote
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+
+	domain "workout-app/internal/domain/coach"
+	repo "workout-app/internal/repository/interfaces"
+)
+
+// Service описывает usecase-слой личных заметок и тегов тренера о клиентах.
+// Все операции доступны только тренеру, связанному с клиентом; клиенту заметки и теги не отдаются.
+type Service interface {
+	// ListNotes возвращает заметки тренера о клиенте, новые первыми.
+	ListNotes(ctx context.Context, coachID, clientID uuid.UUID) ([]*domain.Note, error)
+
+	// CreateNote добавляет заметку о клиенте.
+	CreateNote(ctx context.Context, coachID, clientID uuid.UUID, body string) (*domain.Note, error)
+
+	// UpdateNote заменяет текст заметки.
+	UpdateNote(ctx context.Context, coachID, clientID, noteID uuid.UUID, body string) (*domain.Note, error)
+
+	// DeleteNote удаляет заметку.
+	DeleteNote(ctx context.Context, coachID, clientID, noteID uuid.UUID) error
+
+	// ListTags возвращает теги клиента.
+	ListTags(ctx context.Context, coachID, clientID uuid.UUID) ([]string, error)
+
+	// SetTags заменяет теги клиента; теги приводятся к нижнему регистру, повторы отбрасываются.
+	SetTags(ctx context.Context, coachID, clientID uuid.UUID, tags []string) ([]string, error)
+}
+
+// Ошибки бизнес-логики usecase-слоя.
+var (
+	ErrNotCoachClient = fmt.Errorf("user is not a client of the coach")
+	ErrNoteNotFound   = fmt.Errorf("note not found")
+	ErrInvalidNote    = fmt.Errorf("note must be 1-%d characters", domain.MaxNoteLength)
+	ErrInvalidTag     = fmt.Errorf("tag must be 1-%d letters, digits, spaces, '-' or '_'", domain.MaxTagLength)
+	ErrTooManyTags    = fmt.Errorf("at most %d tags per client", domain.MaxClientTags)
+)
+
+type service struct {
+	notes   repo.CoachNoteRepository
+	clients repo.CoachClientRepository
+}
+
+// NewService создаёт новый сервис заметок тренера.
+// Связь «тренер — клиент» определяется принятым приглашением.
+func NewService(notes repo.CoachNoteRepository, clients repo.CoachClientRepository) Service {
+	return &service{
+		notes:   notes,
+		clients: clients,
+	}
+}
+
+// ListNotes возвращает заметки тренера о клиенте.
+func (s *service) ListNotes(ctx context.Context, coachID, clientID uuid.UUID) ([]*domain.Note, error) {
+	if err := s.requireClient(ctx, coachID, clientID); err != nil {
+		return nil, err
+	}
+	return s.notes.ListNotes(ctx, coachID, clientID)
+}
+
+// CreateNote добавляет заметку о клиенте.
+func (s *service) CreateNote(ctx context.Context, coachID, clientID uuid.UUID, body string) (*domain.Note, error) {
+	body, err := normalizeBody(body)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.requireClient(ctx, coachID, clientID); err != nil {
+		return nil, err
+	}
+
+	n := domain.NewNote(coachID, clientID, body, time.Now().UTC())
+	if err := s.notes.CreateNote(ctx, n); err != nil {
+		return nil, fmt.Errorf("failed to create note: %w", err)
+	}
+	return n, nil
+}
+
+// UpdateNote заменяет текст заметки.
+func (s *service) UpdateNote(ctx context.Context, coachID, clientID, noteID uuid.UUID, body string) (*domain.Note, error) {
+	body, err := normalizeBody(body)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.requireClient(ctx, coachID, clientID); err != nil {
+		return nil, err
+	}
+
+	n, err := s.notes.GetNote(ctx, coachID, clientID, noteID)
+	if err != nil {
+		return nil, noteError(err)
+	}
+	n.Body = body
+	n.UpdatedAt = time.Now().UTC()
+	if err := s.notes.UpdateNote(ctx, n); err != nil {
+		return nil, noteError(err)
+	}
+	return n, nil
+}
+
+// DeleteNote удаляет заметку.
+func (s *service) DeleteNote(ctx context.Context, coachID, clientID, noteID uuid.UUID) error {
+	if err := s.requireClient(ctx, coachID, clientID); err != nil {
+		return err
+	}
+	return noteError(s.notes.DeleteNote(ctx, coachID, clientID, noteID))
+}
+
+// ListTags возвращает теги клиента.
+func (s *service) ListTags(ctx context.Context, coachID, clientID uuid.UUID) ([]string, error) {
+	if err := s.requireClient(ctx, coachID, clientID); err != nil {
+		return nil, err
+	}
+	return s.notes.ListTags(ctx, coachID, clientID)
+}
+
+// SetTags заменяет теги клиента.
+func (s *service) SetTags(ctx context.Context, coachID, clientID uuid.UUID, tags []string) ([]string, error) {
+	normalized := make([]string, 0, len(tags))
+	seen := make(map[string]bool, len(tags))
+	for _, raw := range tags {
+		tag, ok := domain.NormalizeTag(raw)
+		if !ok {
+			return nil, ErrInvalidTag
+		}
+		if seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		normalized = append(normalized, tag)
+	}
+	if len(normalized) > domain.MaxClientTags {
+		return nil, ErrTooManyTags
+	}
+	if err := s.requireClient(ctx, coachID, clientID); err != nil {
+		return nil, err
+	}
+
+	if err := s.notes.ReplaceTags(ctx, coachID, clientID, normalized); err != nil {
+		return nil, fmt.Errorf("failed to save tags: %w", err)
+	}
+	return s.notes.ListTags(ctx, coachID, clientID)
+}
+
+// requireClient проверяет, что clientID является клиентом coachID.
+func (s *service) requireClient(ctx context.Context, coachID, clientID uuid.UUID) error {
+	ok, err := s.clients.IsClient(ctx, coachID, clientID)
+	if err != nil {
+		return fmt.Errorf("failed to check coach client: %w", err)
+	}
+	if !ok {
+		return ErrNotCoachClient
+	}
+	return nil
+}
+
+// normalizeBody обрезает пробелы по краям и проверяет длину заметки.
+func normalizeBody(body string) (string, error) {
+	body = strings.TrimSpace(body)
+	if body == "" || utf8.RuneCountInString(body) > domain.MaxNoteLength {
+		return "", ErrInvalidNote
+	}
+	return body, nil
+}
+
+// noteError переводит отсутствие заметки в ErrNoteNotFound.
+func noteError(err error) error {
+	if errors.Is(err, repo.ErrNotFound) {
+		return ErrNoteNotFound
+	}
+	return err
+}
@@ -0,0 +1,139 @@
+package consent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	domain "workout-app/internal/domain/consent"
+	repo "workout-app/internal/repository/interfaces"
+)
+
+// Checker проверяет, открыл ли клиент тренеру доступ к классу данных.
+// Используется usecase-слоями, которые отдают данные клиента тренеру.
+type Checker interface {
+	// Require возвращает ErrNotCoachClient, если coachID не является тренером clientID,
+	// и ErrConsentRequired, если клиент не открыл тренеру класс данных scope.
+	Require(ctx context.Context, coachID, clientID uuid.UUID, scope domain.Scope) error
+}
+
+// Service описывает usecase-слой согласий клиента на доступ тренеров к его данным.
+type Service interface {
+	Checker
+
+	// List возвращает согласия, выданные клиентом.
+	List(ctx context.Context, clientID uuid.UUID) ([]*domain.Consent, error)
+
+	// Set заменяет набор классов данных, открытых клиентом тренеру.
+	// Пустой набор сохраняется как явный запрет доступа.
+	Set(ctx context.Context, clientID, coachID uuid.UUID, scopes []domain.Scope) (*domain.Consent, error)
+
+	// Revoke отзывает согласие клиента для тренера и заканчивает связь «тренер — клиент»:
+	// заметки и теги тренера о клиенте удаляются, принятое приглашение тренера перестаёт действовать.
+	Revoke(ctx context.Context, clientID, coachID uuid.UUID) error
+}
+
+// Ошибки бизнес-логики usecase-слоя.
+var (
+	ErrInvalidScope    = fmt.Errorf("invalid consent scope")
+	ErrNotCoachClient  = fmt.Errorf("user is not a client of the coach")
+	ErrConsentRequired = fmt.Errorf("client has not shared this data with the coach")
+)
+
+type service struct {
+	consents repo.ConsentRepository
+	notes    repo.CoachNoteRepository
+	clients  repo.CoachClientRepository
+}
+
+// NewService создаёт новый сервис согласий.
+// Связь «тренер — клиент» устанавливается принятым приглашением тренера.
+func NewService(consents repo.ConsentRepository, notes repo.CoachNoteRepository, clients repo.CoachClientRepository) Service {
+	return &service{
+		consents: consents,
+		notes:    notes,
+		clients:  clients,
+	}
+}
+
+// List возвращает согласия, выданные клиентом.
+func (s *service) List(ctx context.Context, clientID uuid.UUID) ([]*domain.Consent, error) {
+	return s.consents.ListByClient(ctx, clientID)
+}
+
+// Set заменяет набор классов данных, открытых клиентом тренеру.
+func (s *service) Set(ctx context.Context, clientID, coachID uuid.UUID, scopes []domain.Scope) (*domain.Consent, error) {
+	normalized := make([]domain.Scope, 0, len(scopes))
+	seen := make(map[domain.Scope]bool, len(scopes))
+	for _, scope := range scopes {
+		if !scope.IsValid() {
+			return nil, ErrInvalidScope
+		}
+		if seen[scope] {
+			continue
+		}
+		seen[scope] = true
+		normalized = append(normalized, scope)
+	}
+
+	if err := s.requireClient(ctx, coachID, clientID); err != nil {
+		return nil, err
+	}
+
+	c := &domain.Consent{
+		ClientID:  clientID,
+		CoachID:   coachID,
+		Scopes:    normalized,
+		UpdatedAt: time.Now().UTC(),
+	}
+	if err := s.consents.Upsert(ctx, c); err != nil {
+		return nil, fmt.Errorf("failed to save consent: %w", err)
+	}
+	return c, nil
+}
+
+// Revoke отзывает согласие клиента для тренера. Заметки тренера удаляются и без согласия:
+// клиент может закончить связь, даже если ещё не открывал тренеру данные.
+func (s *service) Revoke(ctx context.Context, clientID, coachID uuid.UUID) error {
+	if err := s.notes.DeleteByRelationship(ctx, coachID, clientID); err != nil {
+		return fmt.Errorf("failed to delete coach notes: %w", err)
+	}
+	if err := s.clients.DeleteClient(ctx, coachID, clientID); err != nil {
+		return fmt.Errorf("failed to end coach relationship: %w", err)
+	}
+	return s.consents.Delete(ctx, clientID, coachID)
+}
+
+// Require проверяет связь «тренер — клиент» и согласие клиента на класс данных scope.
+func (s *service) Require(ctx context.Context, coachID, clientID uuid.UUID, scope domain.Scope) error {
+	if err := s.requireClient(ctx, coachID, clientID); err != nil {
+		return err
+	}
+
+	c, err := s.consents.Get(ctx, clientID, coachID)
+	if err != nil {
+		if errors.Is(err, repo.ErrNotFound) {
+			return ErrConsentRequired
+		}
+		return fmt.Errorf("failed to get consent: %w", err)
+	}
+	if !c.Allows(scope) {
+		return ErrConsentRequired
+	}
+	return nil
+}
+
+// requireClient проверяет, что clientID является клиентом coachID и связь не закончена отзывом.
+func (s *service) requireClient(ctx context.Context, coachID, clientID uuid.UUID) error {
+	ok, err := s.clients.IsClient(ctx, coachID, clientID)
+	if err != nil {
+		return fmt.Errorf("failed to check coach client: %w", err)
+	}
+	if !ok {
+		return ErrNotCoachClient
+	}
+	return nil
+}
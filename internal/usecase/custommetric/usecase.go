@@ -0,0 +1,324 @@
+package custommetric
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+
+	domain "workout-app/internal/domain/custommetric"
+	repo "workout-app/internal/repository/interfaces"
+)
+
+// Service описывает usecase-слой пользовательских метрик: пользователь сам заводит метрику
+// с единицей измерения, записывает значения и получает агрегаты для графиков.
+type Service interface {
+	// Create заводит новую метрику пользователя.
+	Create(ctx context.Context, userID uuid.UUID, input DefinitionInput) (*domain.Definition, error)
+
+	// Update изменяет название, единицу или направление улучшения метрики.
+	Update(ctx context.Context, userID, metricID uuid.UUID, input DefinitionInput) (*domain.Definition, error)
+
+	// List возвращает метрики пользователя с последними значениями.
+	List(ctx context.Context, userID uuid.UUID) ([]*MetricWithLatest, error)
+
+	// Delete удаляет метрику вместе со всеми значениями.
+	Delete(ctx context.Context, userID, metricID uuid.UUID) error
+
+	// AddEntry записывает значение метрики.
+	AddEntry(ctx context.Context, userID, metricID uuid.UUID, input EntryInput) (*domain.Entry, error)
+
+	// ListEntries возвращает значения метрики за [from, to), старые первыми.
+	ListEntries(ctx context.Context, userID, metricID uuid.UUID, from, to time.Time) ([]*domain.Entry, error)
+
+	// DeleteEntry удаляет значение метрики.
+	DeleteEntry(ctx context.Context, userID, metricID, entryID uuid.UUID) error
+
+	// Stats возвращает значения метрики за [from, to), агрегированные по интервалам bucket
+	// в часовом поясе пользователя.
+	Stats(ctx context.Context, userID, metricID uuid.UUID, from, to time.Time, bucket domain.Bucket) (*Stats, error)
+}
+
+// DefinitionInput описывает метрику на уровне бизнес-логики.
+type DefinitionInput struct {
+	Name           string
+	Unit           string
+	HigherIsBetter bool
+}
+
+// EntryInput описывает значение метрики на уровне бизнес-логики.
+type EntryInput struct {
+	Value      float64
+	RecordedAt *time.Time // nil — текущий момент
+	Note       string
+}
+
+// MetricWithLatest описывает метрику и её последнее значение (nil, если значений нет).
+type MetricWithLatest struct {
+	Definition *domain.Definition
+	Latest     *domain.Entry
+}
+
+// Stats описывает агрегированные значения метрики за период.
+type Stats struct {
+	Definition *domain.Definition
+	From       time.Time
+	To         time.Time
+	Bucket     domain.Bucket
+	Timezone   string // Часовой пояс интервалов (IANA)
+	Points     []domain.Point
+	Summary    domain.Summary
+}
+
+// Ошибки бизнес-логики usecase-слоя.
+var (
+	ErrMetricNotFound     = fmt.Errorf("custom metric not found")
+	ErrEntryNotFound      = fmt.Errorf("custom metric entry not found")
+	ErrMetricExists       = fmt.Errorf("custom metric with this name already exists")
+	ErrTooManyMetrics     = fmt.Errorf("too many custom metrics")
+	ErrInvalidName        = fmt.Errorf("invalid metric name")
+	ErrInvalidUnit        = fmt.Errorf("invalid metric unit")
+	ErrInvalidValue       = fmt.Errorf("invalid metric value")
+	ErrInvalidNote        = fmt.Errorf("note is too long")
+	ErrInvalidRecordedAt  = fmt.Errorf("recorded_at is in the future")
+	ErrInvalidPeriod      = fmt.Errorf("invalid period")
+	ErrInvalidBucket      = fmt.Errorf("invalid bucket")
+	ErrTooManyStatsPoints = fmt.Errorf("too many entries in period")
+)
+
+// Ограничения пользовательских метрик.
+const (
+	maxMetricsPerUser = 50
+	maxNameLength     = 64
+	maxUnitLength     = 16
+	maxNoteLength     = 500
+	maxPeriod         = 366 * 24 * time.Hour
+	// maxEntriesPerQuery ограничивает число значений, читаемых за один запрос.
+	maxEntriesPerQuery = 5000
+	// futureTolerance допускает небольшое расхождение часов клиента и сервера.
+	futureTolerance = 5 * time.Minute
+)
+
+type service struct {
+	metrics repo.CustomMetricRepository
+	users   repo.UserRepository
+}
+
+// NewService создаёт новый сервис пользовательских метрик.
+// users нужен, чтобы группировать значения по дням в часовом поясе пользователя.
+func NewService(metrics repo.CustomMetricRepository, users repo.UserRepository) Service {
+	return &service{metrics: metrics, users: users}
+}
+
+// Create заводит новую метрику пользователя.
+func (s *service) Create(ctx context.Context, userID uuid.UUID, input DefinitionInput) (*domain.Definition, error) {
+	name, unit, err := normalizeDefinition(input)
+	if err != nil {
+		return nil, err
+	}
+
+	count, err := s.metrics.CountByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if count >= maxMetricsPerUser {
+		return nil, ErrTooManyMetrics
+	}
+
+	d := domain.New(userID, name, unit, input.HigherIsBetter)
+	if err := s.metrics.Create(ctx, d); err != nil {
+		if errors.Is(err, repo.ErrCustomMetricExists) {
+			return nil, ErrMetricExists
+		}
+		return nil, err
+	}
+	return d, nil
+}
+
+// Update изменяет метрику пользователя.
+func (s *service) Update(ctx context.Context, userID, metricID uuid.UUID, input DefinitionInput) (*domain.Definition, error) {
+	name, unit, err := normalizeDefinition(input)
+	if err != nil {
+		return nil, err
+	}
+
+	d, err := s.get(ctx, userID, metricID)
+	if err != nil {
+		return nil, err
+	}
+	d.Name = name
+	d.Unit = unit
+	d.HigherIsBetter = input.HigherIsBetter
+	d.UpdatedAt = time.Now().UTC()
+
+	if err := s.metrics.Update(ctx, d); err != nil {
+		switch {
+		case errors.Is(err, repo.ErrCustomMetricExists):
+			return nil, ErrMetricExists
+		case errors.Is(err, repo.ErrNotFound):
+			return nil, ErrMetricNotFound
+		}
+		return nil, err
+	}
+	return d, nil
+}
+
+// List возвращает метрики пользователя с последними значениями.
+func (s *service) List(ctx context.Context, userID uuid.UUID) ([]*MetricWithLatest, error) {
+	defs, err := s.metrics.ListByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	latest, err := s.metrics.LatestEntries(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*MetricWithLatest, 0, len(defs))
+	for _, d := range defs {
+		result = append(result, &MetricWithLatest{Definition: d, Latest: latest[d.ID]})
+	}
+	return result, nil
+}
+
+// Delete удаляет метрику вместе со значениями.
+func (s *service) Delete(ctx context.Context, userID, metricID uuid.UUID) error {
+	if err := s.metrics.Delete(ctx, userID, metricID); err != nil {
+		if errors.Is(err, repo.ErrNotFound) {
+			return ErrMetricNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+// AddEntry записывает значение метрики.
+func (s *service) AddEntry(ctx context.Context, userID, metricID uuid.UUID, input EntryInput) (*domain.Entry, error) {
+	if math.IsNaN(input.Value) || math.IsInf(input.Value, 0) {
+		return nil, ErrInvalidValue
+	}
+	note := strings.TrimSpace(input.Note)
+	if utf8.RuneCountInString(note) > maxNoteLength {
+		return nil, ErrInvalidNote
+	}
+
+	now := time.Now().UTC()
+	recordedAt := now
+	if input.RecordedAt != nil {
+		recordedAt = input.RecordedAt.UTC()
+		if recordedAt.After(now.Add(futureTolerance)) {
+			return nil, ErrInvalidRecordedAt
+		}
+	}
+
+	d, err := s.get(ctx, userID, metricID)
+	if err != nil {
+		return nil, err
+	}
+
+	e := domain.NewEntry(d, input.Value, recordedAt, note)
+	if err := s.metrics.AddEntry(ctx, e); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// ListEntries возвращает значения метрики за период.
+func (s *service) ListEntries(ctx context.Context, userID, metricID uuid.UUID, from, to time.Time) ([]*domain.Entry, error) {
+	if err := validatePeriod(from, to); err != nil {
+		return nil, err
+	}
+	if _, err := s.get(ctx, userID, metricID); err != nil {
+		return nil, err
+	}
+	return s.metrics.ListEntries(ctx, metricID, from, to, maxEntriesPerQuery)
+}
+
+// DeleteEntry удаляет значение метрики.
+func (s *service) DeleteEntry(ctx context.Context, userID, metricID, entryID uuid.UUID) error {
+	if _, err := s.get(ctx, userID, metricID); err != nil {
+		return err
+	}
+	if err := s.metrics.DeleteEntry(ctx, metricID, entryID); err != nil {
+		if errors.Is(err, repo.ErrNotFound) {
+			return ErrEntryNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+// Stats агрегирует значения метрики за период по интервалам.
+func (s *service) Stats(ctx context.Context, userID, metricID uuid.UUID, from, to time.Time, bucket domain.Bucket) (*Stats, error) {
+	if !bucket.IsValid() {
+		return nil, ErrInvalidBucket
+	}
+	if err := validatePeriod(from, to); err != nil {
+		return nil, err
+	}
+
+	d, err := s.get(ctx, userID, metricID)
+	if err != nil {
+		return nil, err
+	}
+	// Читаем на одно значение больше лимита, чтобы не строить график по обрезанным данным.
+	entries, err := s.metrics.ListEntries(ctx, metricID, from, to, maxEntriesPerQuery+1)
+	if err != nil {
+		return nil, err
+	}
+	if len(entries) > maxEntriesPerQuery {
+		return nil, ErrTooManyStatsPoints
+	}
+
+	user, err := s.users.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	loc := user.Location()
+
+	points, summary := domain.Aggregate(d, entries, bucket, loc)
+	return &Stats{
+		Definition: d,
+		From:       from,
+		To:         to,
+		Bucket:     bucket,
+		Timezone:   loc.String(),
+		Points:     points,
+		Summary:    summary,
+	}, nil
+}
+
+func (s *service) get(ctx context.Context, userID, metricID uuid.UUID) (*domain.Definition, error) {
+	d, err := s.metrics.GetByID(ctx, userID, metricID)
+	if err != nil {
+		if errors.Is(err, repo.ErrNotFound) {
+			return nil, ErrMetricNotFound
+		}
+		return nil, err
+	}
+	return d, nil
+}
+
+func normalizeDefinition(input DefinitionInput) (string, string, error) {
+	name := strings.TrimSpace(input.Name)
+	if name == "" || utf8.RuneCountInString(name) > maxNameLength {
+		return "", "", ErrInvalidName
+	}
+	unit := strings.TrimSpace(input.Unit)
+	if unit == "" || utf8.RuneCountInString(unit) > maxUnitLength {
+		return "", "", ErrInvalidUnit
+	}
+	return name, unit, nil
+}
+
+func validatePeriod(from, to time.Time) error {
+	if !from.Before(to) || to.Sub(from) > maxPeriod {
+		return ErrInvalidPeriod
+	}
+	return nil
+}
@@ -0,0 +1,275 @@
+package dataimport
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+
+	domain "workout-app/internal/domain/dataimport"
+	userdomain "workout-app/internal/domain/user"
+	workoutdomain "workout-app/internal/domain/workout"
+)
+
+// Форматы строк файла импорта. Неизвестные поля игнорируются: выгрузки других приложений
+// обычно содержат больше данных, чем мы храним.
+
+type recordHeader struct {
+	Type string `json:"type"`
+	ID   string `json:"id"`
+}
+
+type workoutLine struct {
+	Title      string     `json:"title"`
+	StartedAt  *time.Time `json:"started_at"`
+	FinishedAt *time.Time `json:"finished_at"`
+	Sets       []setLine  `json:"sets"`
+}
+
+type setLine struct {
+	Exercise string     `json:"exercise"`
+	Reps     int        `json:"reps"`
+	WeightKg *float64   `json:"weight_kg"`
+	LoggedAt *time.Time `json:"logged_at"`
+}
+
+type measurementLine struct {
+	MeasuredAt     *time.Time         `json:"measured_at"`
+	WeightKg       *float64           `json:"weight_kg"`
+	BodyFatPercent *float64           `json:"body_fat_percent"`
+	Measurements   map[string]float64 `json:"measurements"`
+}
+
+// Ограничения записей повторяют проверки ручного ввода тренировок и замеров.
+const (
+	maxExternalIDLength      = 128
+	maxTitleLength           = 200
+	maxExerciseNameLength    = 100
+	maxRepsPerSet            = 1000
+	maxSetWeightKg           = 1000
+	maxSetsPerSession        = 500
+	maxSessionDuration       = 24 * time.Hour
+	maxBodyWeightKg          = 500
+	maxMeasurementNameLength = 64
+	maxMeasurementsPerRecord = 50
+	allowedClockSkew         = 5 * time.Minute
+)
+
+// errInvalidRecord — причина отклонения строки; попадает в отчёт по импорту.
+type errInvalidRecord string
+
+func (e errInvalidRecord) Error() string { return string(e) }
+
+func invalid(format string, args ...any) error {
+	return errInvalidRecord(fmt.Sprintf(format, args...))
+}
+
+// apply проверяет запись и сохраняет её данные пользователю, заполняя статус записи.
+// Ошибка возвращается только при сбое хранилища; отклонённая запись получает статус invalid.
+func (s *service) apply(ctx context.Context, rec *domain.Record) error {
+	var header recordHeader
+	if err := json.Unmarshal(rec.Payload, &header); err != nil {
+		return reject(rec, invalid("invalid JSON"))
+	}
+	rec.Kind = domain.Kind(strings.ToLower(strings.TrimSpace(header.Type)))
+	if !rec.Kind.IsValid() {
+		rec.Kind = ""
+		return reject(rec, invalid("type must be %q or %q", domain.KindWorkout, domain.KindMeasurement))
+	}
+	rec.ExternalID = strings.TrimSpace(header.ID)
+	if utf8.RuneCountInString(rec.ExternalID) > maxExternalIDLength {
+		rec.ExternalID = ""
+		return reject(rec, invalid("id must be at most %d characters", maxExternalIDLength))
+	}
+
+	if rec.ExternalID != "" {
+		// Записи без внешнего ID не сверяются: повторная загрузка такого файла создаст их заново.
+		imported, err := s.imports.IsImported(ctx, rec.UserID, rec.Kind, rec.ExternalID)
+		if err != nil {
+			return err
+		}
+		if imported {
+			rec.Status = domain.RecordDuplicate
+			return nil
+		}
+	}
+
+	var (
+		entityID uuid.UUID
+		err      error
+	)
+	switch rec.Kind {
+	case domain.KindWorkout:
+		entityID, err = s.importWorkout(ctx, rec)
+	case domain.KindMeasurement:
+		entityID, err = s.importMeasurement(ctx, rec)
+	}
+	if err != nil {
+		return reject(rec, err)
+	}
+	rec.Status = domain.RecordImported
+	rec.EntityID = &entityID
+	return nil
+}
+
+// reject помечает запись отклонённой, если err — ошибка проверки, и пробрасывает остальные ошибки.
+func reject(rec *domain.Record, err error) error {
+	var reason errInvalidRecord
+	if !errors.As(err, &reason) {
+		return err
+	}
+	rec.Status = domain.RecordInvalid
+	rec.Error = reason.Error()
+	return nil
+}
+
+// importWorkout сохраняет завершённую тренировку с подходами. Данные за пределами правдоподобных
+// границ в режиме reject делают запись некорректной, в режиме warn подходы сохраняются с пометкой;
+// при неправдоподобной длительности помечаются все подходы тренировки.
+func (s *service) importWorkout(ctx context.Context, rec *domain.Record) (uuid.UUID, error) {
+	var line workoutLine
+	if err := json.Unmarshal(rec.Payload, &line); err != nil {
+		return uuid.Nil, invalid("invalid workout: %s", jsonReason(err))
+	}
+
+	title := strings.TrimSpace(line.Title)
+	if title == "" || utf8.RuneCountInString(title) > maxTitleLength {
+		return uuid.Nil, invalid("title must be 1-%d characters", maxTitleLength)
+	}
+	if line.StartedAt == nil || line.FinishedAt == nil {
+		return uuid.Nil, invalid("started_at and finished_at are required")
+	}
+	startedAt, finishedAt := line.StartedAt.UTC(), line.FinishedAt.UTC()
+	if finishedAt.Before(startedAt) || finishedAt.Sub(startedAt) > maxSessionDuration {
+		return uuid.Nil, invalid("finished_at must be within %s after started_at", maxSessionDuration)
+	}
+	if finishedAt.After(s.now().Add(allowedClockSkew)) {
+		return uuid.Nil, invalid("workout is in the future")
+	}
+	if len(line.Sets) > maxSetsPerSession {
+		return uuid.Nil, invalid("at most %d sets per workout", maxSetsPerSession)
+	}
+
+	rules, err := s.cfg.Validator.Rules(ctx)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	implausibleDuration := len(rules.CheckDuration(finishedAt.Sub(startedAt))) > 0
+	if implausibleDuration && rules.Mode == workoutdomain.ValidationReject {
+		return uuid.Nil, invalid("workout is longer than %s", rules.MaxDuration)
+	}
+
+	session := workoutdomain.NewSession(rec.UserID, title, nil, s.cfg.AutoPauseAfter, startedAt)
+	session.FinishedAt = &finishedAt
+	for i, set := range line.Sets {
+		exercise := strings.TrimSpace(set.Exercise)
+		if exercise == "" || utf8.RuneCountInString(exercise) > maxExerciseNameLength {
+			return uuid.Nil, invalid("sets[%d]: exercise must be 1-%d characters", i, maxExerciseNameLength)
+		}
+		if set.Reps < 1 || set.Reps > maxRepsPerSet {
+			return uuid.Nil, invalid("sets[%d]: reps must be 1-%d", i, maxRepsPerSet)
+		}
+		if set.WeightKg != nil && (*set.WeightKg < 0 || *set.WeightKg > maxSetWeightKg) {
+			return uuid.Nil, invalid("sets[%d]: weight_kg must be 0-%d", i, maxSetWeightKg)
+		}
+		warnings := rules.CheckSet(exercise, set.Reps, set.WeightKg)
+		if len(warnings) > 0 && rules.Mode == workoutdomain.ValidationReject {
+			return uuid.Nil, invalid("sets[%d]: %s is above the plausible limit %g", i, warnings[0].Field, warnings[0].Limit)
+		}
+		loggedAt := spreadSetTime(startedAt, finishedAt, i, len(line.Sets))
+		if set.LoggedAt != nil {
+			loggedAt = set.LoggedAt.UTC()
+			if loggedAt.Before(startedAt) || loggedAt.After(finishedAt) {
+				return uuid.Nil, invalid("sets[%d]: logged_at must be within the workout", i)
+			}
+		}
+		session.Sets = append(session.Sets, workoutdomain.Set{
+			ID:          uuid.New(),
+			SessionID:   session.ID,
+			Exercise:    exercise,
+			Reps:        set.Reps,
+			WeightKg:    set.WeightKg,
+			LoggedAt:    loggedAt,
+			Implausible: implausibleDuration || len(warnings) > 0,
+		})
+	}
+
+	if err := s.workouts.Create(ctx, session); err != nil {
+		return uuid.Nil, err
+	}
+	for i := range session.Sets {
+		if err := s.workouts.AddSet(ctx, &session.Sets[i]); err != nil {
+			return uuid.Nil, err
+		}
+	}
+	return session.ID, nil
+}
+
+// spreadSetTime равномерно распределяет подходы без времени по длительности тренировки,
+// чтобы активное время и автопаузы считались так же, как для записанных вручную.
+func spreadSetTime(startedAt, finishedAt time.Time, i, n int) time.Time {
+	step := finishedAt.Sub(startedAt) / time.Duration(n+1)
+	return startedAt.Add(step * time.Duration(i+1))
+}
+
+// importMeasurement сохраняет замер параметров тела.
+func (s *service) importMeasurement(ctx context.Context, rec *domain.Record) (uuid.UUID, error) {
+	var line measurementLine
+	if err := json.Unmarshal(rec.Payload, &line); err != nil {
+		return uuid.Nil, invalid("invalid measurement: %s", jsonReason(err))
+	}
+
+	if line.MeasuredAt == nil {
+		return uuid.Nil, invalid("measured_at is required")
+	}
+	measuredAt := line.MeasuredAt.UTC()
+	if measuredAt.After(s.now().Add(allowedClockSkew)) {
+		return uuid.Nil, invalid("measurement is in the future")
+	}
+	if line.WeightKg != nil && (*line.WeightKg <= 0 || *line.WeightKg > maxBodyWeightKg) {
+		return uuid.Nil, invalid("weight_kg must be greater than 0 and at most %d", maxBodyWeightKg)
+	}
+	if line.BodyFatPercent != nil && (*line.BodyFatPercent < 0 || *line.BodyFatPercent > 100) {
+		return uuid.Nil, invalid("body_fat_percent must be 0-100")
+	}
+	if len(line.Measurements) > maxMeasurementsPerRecord {
+		return uuid.Nil, invalid("at most %d measurements per record", maxMeasurementsPerRecord)
+	}
+
+	m := userdomain.NewBodyMetric(rec.UserID, measuredAt)
+	m.WeightKg = line.WeightKg
+	m.BodyFatPercent = line.BodyFatPercent
+	for name, value := range line.Measurements {
+		key := strings.ToLower(strings.TrimSpace(name))
+		if key == "" || len(key) > maxMeasurementNameLength || value <= 0 {
+			return uuid.Nil, invalid("measurements.%s: invalid measurement value", name)
+		}
+		m.Measurements[key] = value
+	}
+	if m.IsEmpty() {
+		return uuid.Nil, invalid("at least one metric value is required")
+	}
+
+	if err := s.metrics.Create(ctx, m); err != nil {
+		return uuid.Nil, err
+	}
+	return m.ID, nil
+}
+
+// jsonReason описывает ошибку разбора строки без внутренних типов Go.
+func jsonReason(err error) string {
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) && typeErr.Field != "" {
+		return typeErr.Field + " has invalid type"
+	}
+	var parseErr *time.ParseError
+	if errors.As(err, &parseErr) {
+		return "time must be in RFC 3339 format"
+	}
+	return "malformed record"
+}
@@ -0,0 +1,366 @@
+package dataimport
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	domain "workout-app/internal/domain/dataimport"
+	repo "workout-app/internal/repository/interfaces"
+	workoutuc "workout-app/internal/usecase/workout"
+	"workout-app/pkg/logger"
+)
+
+// Service описывает usecase-слой импорта исторических данных из других приложений:
+// приём файла NDJSON, фоновую обработку записей пачками и отчёт о результате каждой строки.
+type Service interface {
+	// Upload читает файл NDJSON построчно, сохраняет строки и ставит импорт в очередь обработки.
+	// Файл не держится в памяти целиком, поэтому размер ограничен только настройками.
+	Upload(ctx context.Context, userID uuid.UUID, source string, r io.Reader) (*domain.Import, error)
+
+	// Get возвращает импорт пользователя с текущими счётчиками.
+	Get(ctx context.Context, userID, id uuid.UUID) (*domain.Import, error)
+
+	// List возвращает последние импорты пользователя, новые первыми.
+	List(ctx context.Context, userID uuid.UUID) ([]*domain.Import, error)
+
+	// Records возвращает результаты обработки строк импорта, при непустом status — только с этим статусом.
+	Records(ctx context.Context, userID, id uuid.UUID, status domain.RecordStatus, limit, offset int) ([]*domain.Record, error)
+
+	// Run обрабатывает импорты из очереди. Вызывается фоновым воркером.
+	Run(ctx context.Context) error
+
+	// DeleteExpired удаляет не более limit импортов, завершённых раньше before минус срок хранения,
+	// и брошенные загрузки (реализует cleanup.Target).
+	DeleteExpired(ctx context.Context, before time.Time, limit int) (int64, error)
+}
+
+// Config описывает параметры импорта.
+type Config struct {
+	MaxRecords     int           // Максимум записей в одном файле
+	BatchSize      int           // Записей, обрабатываемых одной транзакцией
+	UploadTimeout  time.Duration // Время на загрузку файла; более старая незавершённая загрузка считается брошенной
+	Retention      time.Duration // Срок хранения завершённых импортов и результатов по строкам
+	AutoPauseAfter time.Duration // Порог автопаузы импортированных тренировок
+	// Validator проверяет правдоподобие импортированных тренировок так же, как ручной ввод (nil — не проверять).
+	Validator *workoutuc.Validator
+}
+
+// Ошибки бизнес-логики usecase-слоя.
+var (
+	ErrImportNotFound    = fmt.Errorf("data import not found")
+	ErrImportInProgress  = fmt.Errorf("another data import is in progress")
+	ErrInvalidSource     = fmt.Errorf("import source must be at most 64 characters")
+	ErrEmptyFile         = fmt.Errorf("import file contains no records")
+	ErrTooManyRecords    = fmt.Errorf("import file contains too many records")
+	ErrLineTooLong       = fmt.Errorf("import file line is too long")
+	ErrInvalidStatus     = fmt.Errorf("invalid record status")
+	ErrInvalidPagination = fmt.Errorf("invalid pagination parameters")
+)
+
+const (
+	maxSourceLength = 64
+	// maxLineBytes ограничивает строку файла: тренировка с максимумом подходов укладывается с запасом.
+	maxLineBytes = 256 * 1024
+	// stageBatchSize — строк файла, сохраняемых одним запросом при загрузке.
+	stageBatchSize = 1000
+	// runBatchSize — импортов, которые воркер берёт за один запуск.
+	runBatchSize = 5
+	// claimTTL — аренда обработки; продлевается после каждой пачки, а если процесс упал,
+	// импорт продолжит другой процесс по её истечении.
+	claimTTL = 5 * time.Minute
+	// maxImportsPerList — сколько последних импортов показывается пользователю.
+	maxImportsPerList  = 20
+	defaultRecordsPage = 100
+	maxRecordsPage     = 1000
+)
+
+type service struct {
+	imports  repo.DataImportRepository
+	workouts repo.WorkoutSessionRepository
+	metrics  repo.BodyMetricRepository
+	tx       repo.Transactor
+	cfg      Config
+	logger   logger.Logger
+	now      func() time.Time
+}
+
+// NewService создаёт новый сервис импорта исторических данных.
+func NewService(
+	imports repo.DataImportRepository,
+	workouts repo.WorkoutSessionRepository,
+	metrics repo.BodyMetricRepository,
+	tx repo.Transactor,
+	cfg Config,
+	logger logger.Logger,
+) Service {
+	return &service{
+		imports:  imports,
+		workouts: workouts,
+		metrics:  metrics,
+		tx:       tx,
+		cfg:      cfg,
+		logger:   logger,
+		now:      func() time.Time { return time.Now().UTC() },
+	}
+}
+
+// Upload сохраняет строки файла и ставит импорт в очередь.
+func (s *service) Upload(ctx context.Context, userID uuid.UUID, source string, r io.Reader) (*domain.Import, error) {
+	source = strings.ToLower(strings.TrimSpace(source))
+	if len(source) > maxSourceLength {
+		return nil, ErrInvalidSource
+	}
+
+	imp := domain.New(userID, source, s.now())
+	if err := s.create(ctx, imp); err != nil {
+		return nil, err
+	}
+
+	total, err := s.stage(ctx, imp, r)
+	if err == nil && total == 0 {
+		err = ErrEmptyFile
+	}
+	if err == nil {
+		err = s.imports.Enqueue(ctx, imp.ID, total)
+	}
+	if err != nil {
+		// Частично загруженный файл не обрабатывается: клиент повторит загрузку целиком.
+		// Контекст запроса может быть уже отменён обрывом соединения.
+		if delErr := s.imports.Delete(context.WithoutCancel(ctx), imp.ID); delErr != nil {
+			s.logger.Error("data_import_discard_failed", map[string]any{
+				"import_id": imp.ID.String(),
+				"error":     delErr.Error(),
+			})
+		}
+		return nil, err
+	}
+
+	imp.Status = domain.StatusPending
+	imp.Total = total
+	return imp, nil
+}
+
+// create сохраняет импорт; брошенную загрузку (обрыв соединения, рестарт процесса) заменяет новой.
+func (s *service) create(ctx context.Context, imp *domain.Import) error {
+	err := s.imports.Create(ctx, imp)
+	if !errors.Is(err, repo.ErrImportInProgress) {
+		return err
+	}
+	active, getErr := s.imports.GetActiveByUser(ctx, imp.UserID)
+	if getErr != nil {
+		if errors.Is(getErr, repo.ErrNotFound) {
+			// Предыдущий импорт успел завершиться.
+			return s.imports.Create(ctx, imp)
+		}
+		return getErr
+	}
+	if active.Status != domain.StatusReceiving || active.CreatedAt.After(s.now().Add(-s.cfg.UploadTimeout)) {
+		return ErrImportInProgress
+	}
+	if err := s.imports.Delete(ctx, active.ID); err != nil {
+		return err
+	}
+	if err := s.imports.Create(ctx, imp); err != nil {
+		if errors.Is(err, repo.ErrImportInProgress) {
+			return ErrImportInProgress
+		}
+		return err
+	}
+	return nil
+}
+
+// stage читает файл построчно и сохраняет непустые строки пачками; возвращает количество записей.
+// Строки проверяются при обработке, чтобы результат по каждой был виден в отчёте.
+func (s *service) stage(ctx context.Context, imp *domain.Import, r io.Reader) (int, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxLineBytes)
+
+	batch := make([]*domain.Record, 0, stageBatchSize)
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		if err := s.imports.AddRecords(ctx, batch); err != nil {
+			return fmt.Errorf("failed to save import records: %w", err)
+		}
+		batch = batch[:0]
+		return nil
+	}
+
+	total, line := 0, 0
+	for scanner.Scan() {
+		line++
+		payload := bytes.TrimSpace(scanner.Bytes())
+		if len(payload) == 0 {
+			continue
+		}
+		total++
+		if total > s.cfg.MaxRecords {
+			return 0, ErrTooManyRecords
+		}
+		// Postgres не хранит в тексте невалидный UTF-8 и нулевые байты: заменяем их,
+		// а такая строка будет отклонена при разборе, а не сорвёт загрузку.
+		payload = bytes.ReplaceAll(bytes.ToValidUTF8(payload, []byte("\uFFFD")), []byte{0}, []byte("\uFFFD"))
+		batch = append(batch, &domain.Record{
+			ImportID: imp.ID,
+			UserID:   imp.UserID,
+			Line:     line,
+			Payload:  payload,
+			Status:   domain.RecordPending,
+		})
+		if len(batch) == stageBatchSize {
+			if err := flush(); err != nil {
+				return 0, err
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		if errors.Is(err, bufio.ErrTooLong) {
+			return 0, ErrLineTooLong
+		}
+		return 0, err
+	}
+	if err := flush(); err != nil {
+		return 0, err
+	}
+	return total, nil
+}
+
+// Get возвращает импорт пользователя.
+func (s *service) Get(ctx context.Context, userID, id uuid.UUID) (*domain.Import, error) {
+	imp, err := s.imports.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repo.ErrNotFound) {
+			return nil, ErrImportNotFound
+		}
+		return nil, err
+	}
+	if imp.UserID != userID {
+		return nil, ErrImportNotFound
+	}
+	return imp, nil
+}
+
+// List возвращает последние импорты пользователя.
+func (s *service) List(ctx context.Context, userID uuid.UUID) ([]*domain.Import, error) {
+	return s.imports.ListByUser(ctx, userID, maxImportsPerList)
+}
+
+// Records возвращает результаты обработки строк импорта.
+func (s *service) Records(ctx context.Context, userID, id uuid.UUID, status domain.RecordStatus, limit, offset int) ([]*domain.Record, error) {
+	if status != "" && !status.IsValid() {
+		return nil, ErrInvalidStatus
+	}
+	if limit == 0 {
+		limit = defaultRecordsPage
+	}
+	if limit < 0 || limit > maxRecordsPage || offset < 0 {
+		return nil, ErrInvalidPagination
+	}
+	if _, err := s.Get(ctx, userID, id); err != nil {
+		return nil, err
+	}
+	return s.imports.ListRecords(ctx, id, status, limit, offset)
+}
+
+// Run обрабатывает импорты из очереди.
+func (s *service) Run(ctx context.Context) error {
+	runnable, err := s.imports.ListRunnable(ctx, runBatchSize)
+	if err != nil {
+		return fmt.Errorf("failed to list runnable imports: %w", err)
+	}
+	for _, imp := range runnable {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		claimed, err := s.imports.Claim(ctx, imp.ID, s.now().Add(claimTTL))
+		if err != nil {
+			return fmt.Errorf("failed to claim import: %w", err)
+		}
+		if !claimed {
+			continue
+		}
+		s.process(ctx, imp)
+	}
+	return nil
+}
+
+// process обрабатывает записи импорта пачками до конца файла. Каждая пачка — одна транзакция:
+// созданные тренировки и замеры, результаты строк и счётчики сохраняются вместе, поэтому после
+// остановки процесса обработка продолжается с первой необработанной строки без дублей.
+func (s *service) process(ctx context.Context, imp *domain.Import) {
+	for {
+		if ctx.Err() != nil {
+			// Остановка процесса: импорт продолжится по истечении аренды.
+			return
+		}
+		records, err := s.imports.ListPendingRecords(ctx, imp.ID, s.cfg.BatchSize)
+		if err != nil {
+			s.fail(ctx, imp, fmt.Errorf("failed to load import records: %w", err))
+			return
+		}
+		if len(records) == 0 {
+			s.finish(ctx, imp, domain.StatusCompleted, "")
+			return
+		}
+
+		progress := *imp
+		err = s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+			for _, rec := range records {
+				if err := s.apply(ctx, rec); err != nil {
+					return fmt.Errorf("line %d: %w", rec.Line, err)
+				}
+				if err := s.imports.SaveRecord(ctx, rec); err != nil {
+					return fmt.Errorf("line %d: %w", rec.Line, err)
+				}
+				progress.Count(rec.Status)
+			}
+			return s.imports.SaveProgress(ctx, &progress, s.now().Add(claimTTL))
+		})
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			s.fail(ctx, imp, err)
+			return
+		}
+		*imp = progress
+	}
+}
+
+// fail останавливает импорт с ошибкой; уже импортированные записи сохраняются.
+func (s *service) fail(ctx context.Context, imp *domain.Import, err error) {
+	s.logger.Error("data_import_failed", map[string]any{
+		"import_id": imp.ID.String(),
+		"user_id":   imp.UserID.String(),
+		"error":     err.Error(),
+	})
+	s.finish(ctx, imp, domain.StatusFailed, err.Error())
+}
+
+func (s *service) finish(ctx context.Context, imp *domain.Import, status domain.Status, lastError string) {
+	now := s.now()
+	imp.Status = status
+	imp.LastError = lastError
+	imp.FinishedAt = &now
+	if err := s.imports.Finish(ctx, imp); err != nil {
+		// Аренда истечёт, и следующий запуск повторит завершение.
+		s.logger.Error("data_import_save_failed", map[string]any{
+			"import_id": imp.ID.String(),
+			"error":     err.Error(),
+		})
+	}
+}
+
+// DeleteExpired удаляет завершённые импорты старше срока хранения.
+func (s *service) DeleteExpired(ctx context.Context, before time.Time, limit int) (int64, error) {
+	return s.imports.DeleteFinished(ctx, before.Add(-s.cfg.Retention), limit)
+}
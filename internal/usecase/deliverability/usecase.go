@@ -0,0 +1,116 @@
+package deliverability
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"time"
+
+	domain "workout-app/internal/domain/deliverability"
+	repo "workout-app/internal/repository/interfaces"
+)
+
+// Service описывает usecase-слой доставляемости писем: приём событий от провайдеров,
+// добавление адресов с постоянной недоставкой или жалобами в список подавления и отчёт для администраторов.
+type Service interface {
+	// RecordEvents сохраняет события доставки одного провайдера.
+	// Адреса с постоянной недоставкой или жалобой на спам попадают в список подавления.
+	// Возвращает количество сохранённых событий.
+	RecordEvents(ctx context.Context, provider string, events []domain.Event) (int, error)
+
+	// Report возвращает агрегаты доставки по провайдерам с момента since.
+	Report(ctx context.Context, since time.Time) (*Report, error)
+}
+
+// Report — отчёт о доставляемости писем за период.
+type Report struct {
+	Since           time.Time
+	Providers       []domain.ProviderStats
+	SuppressedTotal int64 // Размер списка подавления (за всё время)
+}
+
+// Ошибки бизнес-логики usecase-слоя.
+var (
+	ErrInvalidProvider = fmt.Errorf("invalid email provider name")
+	ErrInvalidEvent    = fmt.Errorf("invalid email delivery event")
+)
+
+// providerPattern ограничивает имена провайдеров безопасным набором символов.
+var providerPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_\-]{1,31}$`)
+
+// maxReasonLength ограничивает длину сохраняемой диагностики провайдера.
+const maxReasonLength = 1000
+
+type service struct {
+	events       repo.DeliverabilityRepository
+	suppressions repo.SuppressionRepository
+}
+
+// NewService создаёт новый сервис доставляемости писем.
+func NewService(events repo.DeliverabilityRepository, suppressions repo.SuppressionRepository) Service {
+	return &service{events: events, suppressions: suppressions}
+}
+
+// RecordEvents сохраняет события доставки одного провайдера.
+// Пачка проверяется целиком до записи, чтобы некорректный webhook не сохранялся частично.
+func (s *service) RecordEvents(ctx context.Context, provider string, events []domain.Event) (int, error) {
+	if !providerPattern.MatchString(provider) {
+		return 0, ErrInvalidProvider
+	}
+
+	now := time.Now().UTC()
+	for i := range events {
+		ev := &events[i]
+		ev.Provider = provider
+		ev.Email = domain.NormalizeEmail(ev.Email)
+		if ev.Email == "" || !ev.Outcome.IsValid() {
+			return 0, ErrInvalidEvent
+		}
+		if ev.Outcome != domain.OutcomeBounced {
+			ev.BounceType = ""
+		} else if ev.BounceType != domain.BounceHard {
+			ev.BounceType = domain.BounceSoft
+		}
+		if len(ev.Reason) > maxReasonLength {
+			ev.Reason = ev.Reason[:maxReasonLength]
+		}
+		if ev.OccurredAt.IsZero() {
+			ev.OccurredAt = now
+		}
+		ev.CreatedAt = now
+	}
+
+	for i := range events {
+		ev := &events[i]
+		if err := s.events.CreateEvent(ctx, ev); err != nil {
+			return i, err
+		}
+		source, ok := ev.SuppressionSource()
+		if !ok {
+			continue
+		}
+		if _, err := s.suppressions.Add(ctx, &domain.Suppression{
+			Email:     ev.Email,
+			Source:    source,
+			Provider:  provider,
+			Reason:    ev.Reason,
+			CreatedAt: now,
+		}); err != nil {
+			return i + 1, err
+		}
+	}
+	return len(events), nil
+}
+
+// Report возвращает агрегаты доставки по провайдерам с момента since.
+func (s *service) Report(ctx context.Context, since time.Time) (*Report, error) {
+	stats, err := s.events.StatsSince(ctx, since)
+	if err != nil {
+		return nil, err
+	}
+	total, err := s.suppressions.Count(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &Report{Since: since, Providers: stats, SuppressedTotal: total}, nil
+}
@@ -0,0 +1,297 @@
+package draft
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/google/uuid"
+
+	domain "workout-app/internal/domain/draft"
+	repo "workout-app/internal/repository/interfaces"
+)
+
+// Service описывает usecase-слой черновиков: автосохранение редактора программ и тренировок
+// по устройствам пользователя с проверкой, не изменился ли опубликованный объект.
+type Service interface {
+	// Save создаёт или заменяет черновик устройства для объекта. input.Version — версия черновика,
+	// которую видел клиент (0 для первого сохранения); если сохранена другая, возвращается
+	// *VersionConflictError с текущим черновиком.
+	Save(ctx context.Context, userID uuid.UUID, input SaveInput) (*View, error)
+
+	// Get возвращает черновик с содержимым и состоянием опубликованного объекта.
+	Get(ctx context.Context, userID, id uuid.UUID) (*View, error)
+
+	// List возвращает действующие черновики пользователя без содержимого (deviceID пусто — всех устройств).
+	List(ctx context.Context, userID uuid.UUID, deviceID string) ([]*domain.Draft, error)
+
+	// Delete удаляет черновик (после публикации или отказа от правок).
+	Delete(ctx context.Context, userID, id uuid.UUID) error
+}
+
+// SaveInput описывает сохранение черновика.
+type SaveInput struct {
+	DeviceID string
+	Kind     domain.Kind
+	TargetID *uuid.UUID // Редактируемый объект; nil — новый
+	// BaseVersion — версия опубликованного объекта, с которой началось редактирование; обязательна с TargetID.
+	BaseVersion *time.Time
+	Version     int
+	Content     json.RawMessage
+}
+
+// View — черновик и состояние опубликованного объекта.
+type View struct {
+	Draft *domain.Draft
+	// PublishedVersion — текущая версия опубликованного объекта; nil для нового объекта
+	// и для удалённого после начала редактирования.
+	PublishedVersion *time.Time
+	// Conflict сообщает, что объект изменили или удалили после начала редактирования:
+	// публикация черновика затрёт эти изменения.
+	Conflict bool
+}
+
+// Ошибки бизнес-логики usecase-слоя.
+var (
+	ErrInvalidKind         = fmt.Errorf("kind must be program or workout")
+	ErrInvalidDeviceID     = fmt.Errorf("device_id must be 1-%d printable characters", domain.MaxDeviceIDLength)
+	ErrInvalidContent      = fmt.Errorf("content must be a JSON object up to %d bytes", domain.MaxContentBytes)
+	ErrBaseVersionRequired = fmt.Errorf("base_version is required when editing an existing object")
+	ErrTargetNotFound      = fmt.Errorf("draft target not found")
+	ErrTooManyDrafts       = fmt.Errorf("at most %d drafts per user", domain.MaxDraftsPerUser)
+	ErrDraftNotFound       = fmt.Errorf("draft not found")
+	ErrVersionConflict     = fmt.Errorf("draft was saved with another version")
+)
+
+// VersionConflictError возвращает текущий черновик, сохранённый с другой версией. Совместима с ErrVersionConflict.
+type VersionConflictError struct {
+	Current *domain.Draft
+}
+
+func (e *VersionConflictError) Error() string {
+	return fmt.Sprintf("draft version is %d", e.Current.Version)
+}
+
+func (e *VersionConflictError) Unwrap() error {
+	return ErrVersionConflict
+}
+
+type service struct {
+	drafts   repo.DraftRepository
+	programs repo.ProgramRepository
+	workouts repo.WorkoutSessionRepository
+	ttl      time.Duration
+	now      func() time.Time
+}
+
+// NewService создаёт сервис черновиков; черновик удаляется, если его не сохраняли ttl.
+func NewService(drafts repo.DraftRepository, programs repo.ProgramRepository, workouts repo.WorkoutSessionRepository, ttl time.Duration) Service {
+	return &service{
+		drafts:   drafts,
+		programs: programs,
+		workouts: workouts,
+		ttl:      ttl,
+		now:      func() time.Time { return time.Now().UTC() },
+	}
+}
+
+// Save проверяет черновик и сохраняет его с новой версией.
+func (s *service) Save(ctx context.Context, userID uuid.UUID, input SaveInput) (*View, error) {
+	if !input.Kind.IsValid() {
+		return nil, ErrInvalidKind
+	}
+	if !validDeviceID(input.DeviceID) {
+		return nil, ErrInvalidDeviceID
+	}
+	if !validContent(input.Content) {
+		return nil, ErrInvalidContent
+	}
+	var published *time.Time
+	if input.TargetID != nil {
+		if input.BaseVersion == nil {
+			return nil, ErrBaseVersionRequired
+		}
+		version, err := s.publishedVersion(ctx, userID, input.Kind, *input.TargetID)
+		if err != nil {
+			return nil, err
+		}
+		published = &version
+	} else {
+		input.BaseVersion = nil
+	}
+
+	now := s.now()
+	d, err := s.drafts.GetByKey(ctx, userID, input.DeviceID, input.Kind, input.TargetID)
+	switch {
+	case errors.Is(err, repo.ErrNotFound):
+		d, err = s.create(ctx, userID, input, now)
+	case err != nil:
+		return nil, err
+	default:
+		d, err = s.update(ctx, d, input, now)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return newView(d, published), nil
+}
+
+// create сохраняет первый черновик устройства для объекта.
+func (s *service) create(ctx context.Context, userID uuid.UUID, input SaveInput, now time.Time) (*domain.Draft, error) {
+	count, err := s.drafts.CountByUser(ctx, userID, now)
+	if err != nil {
+		return nil, err
+	}
+	if count >= domain.MaxDraftsPerUser {
+		return nil, ErrTooManyDrafts
+	}
+
+	d := domain.NewDraft(userID, input.DeviceID, input.Kind, input.TargetID, now, s.ttl)
+	d.BaseVersion = input.BaseVersion
+	d.Content = input.Content
+	d.Version = 1
+	err = s.drafts.Create(ctx, d)
+	if errors.Is(err, repo.ErrDraftExists) {
+		// Одновременное первое сохранение с того же устройства.
+		return nil, s.conflict(ctx, userID, input)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// update заменяет черновик, если клиент видел его текущую версию. Истёкший черновик
+// заменяется без проверки версии: его содержимое уже устарело.
+func (s *service) update(ctx context.Context, d *domain.Draft, input SaveInput, now time.Time) (*domain.Draft, error) {
+	if !d.IsExpired(now) && input.Version != d.Version {
+		return nil, &VersionConflictError{Current: d}
+	}
+
+	expected := d.Version
+	d.BaseVersion = input.BaseVersion
+	d.Content = input.Content
+	d.Version++
+	d.UpdatedAt = now
+	d.ExpiresAt = now.Add(s.ttl)
+	err := s.drafts.Update(ctx, d, expected)
+	if errors.Is(err, repo.ErrNotFound) {
+		// Черновик сохранили или удалили одновременно с этим запросом.
+		return nil, s.conflict(ctx, d.UserID, input)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// conflict возвращает ошибку конфликта с черновиком, сохранённым параллельным запросом.
+func (s *service) conflict(ctx context.Context, userID uuid.UUID, input SaveInput) error {
+	current, err := s.drafts.GetByKey(ctx, userID, input.DeviceID, input.Kind, input.TargetID)
+	if err != nil {
+		if errors.Is(err, repo.ErrNotFound) {
+			return ErrVersionConflict
+		}
+		return err
+	}
+	return &VersionConflictError{Current: current}
+}
+
+// Get возвращает действующий черновик пользователя.
+func (s *service) Get(ctx context.Context, userID, id uuid.UUID) (*View, error) {
+	d, err := s.drafts.GetByID(ctx, userID, id)
+	if errors.Is(err, repo.ErrNotFound) {
+		return nil, ErrDraftNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if d.IsExpired(s.now()) {
+		return nil, ErrDraftNotFound
+	}
+	if d.TargetID == nil {
+		return newView(d, nil), nil
+	}
+
+	version, err := s.publishedVersion(ctx, userID, d.Kind, *d.TargetID)
+	if errors.Is(err, ErrTargetNotFound) {
+		return &View{Draft: d, Conflict: true}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return newView(d, &version), nil
+}
+
+// List возвращает действующие черновики пользователя.
+func (s *service) List(ctx context.Context, userID uuid.UUID, deviceID string) ([]*domain.Draft, error) {
+	return s.drafts.ListByUser(ctx, userID, deviceID, s.now())
+}
+
+// Delete удаляет черновик пользователя.
+func (s *service) Delete(ctx context.Context, userID, id uuid.UUID) error {
+	err := s.drafts.Delete(ctx, userID, id)
+	if errors.Is(err, repo.ErrNotFound) {
+		return ErrDraftNotFound
+	}
+	return err
+}
+
+// publishedVersion возвращает текущую версию объекта, который пользователь может редактировать:
+// своей программы или своей тренировки.
+func (s *service) publishedVersion(ctx context.Context, userID uuid.UUID, kind domain.Kind, targetID uuid.UUID) (time.Time, error) {
+	switch kind {
+	case domain.KindProgram:
+		p, err := s.programs.GetByID(ctx, targetID)
+		if errors.Is(err, repo.ErrNotFound) || (err == nil && p.OwnerID != userID) {
+			return time.Time{}, ErrTargetNotFound
+		}
+		if err != nil {
+			return time.Time{}, err
+		}
+		return p.UpdatedAt.UTC().Truncate(time.Microsecond), nil
+	default:
+		session, err := s.workouts.GetByID(ctx, targetID)
+		if errors.Is(err, repo.ErrNotFound) || (err == nil && session.UserID != userID) {
+			return time.Time{}, ErrTargetNotFound
+		}
+		if err != nil {
+			return time.Time{}, err
+		}
+		return session.ModifiedAt().UTC().Truncate(time.Microsecond), nil
+	}
+}
+
+// newView сравнивает версию, с которой началось редактирование, с опубликованной.
+// Время сравнивается с точностью Postgres (микросекунды).
+func newView(d *domain.Draft, published *time.Time) *View {
+	v := &View{Draft: d, PublishedVersion: published}
+	if d.BaseVersion != nil && published != nil {
+		v.Conflict = !d.BaseVersion.Truncate(time.Microsecond).Equal(*published)
+	}
+	return v
+}
+
+// validDeviceID проверяет идентификатор устройства: непустой, без пробельных и управляющих символов.
+func validDeviceID(id string) bool {
+	if id == "" || len(id) > domain.MaxDeviceIDLength {
+		return false
+	}
+	return !strings.ContainsFunc(id, func(r rune) bool {
+		return unicode.IsSpace(r) || !unicode.IsPrint(r)
+	})
+}
+
+// validContent проверяет, что содержимое — JSON-объект допустимого размера.
+func validContent(content json.RawMessage) bool {
+	if len(content) > domain.MaxContentBytes {
+		return false
+	}
+	trimmed := bytes.TrimSpace(content)
+	return len(trimmed) > 0 && trimmed[0] == '{' && json.Valid(trimmed)
+}
@@ -0,0 +1,257 @@
+package emailoutbox
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	domain "workout-app/internal/domain/emailoutbox"
+	repo "workout-app/internal/repository/interfaces"
+	"workout-app/internal/worker"
+	"workout-app/pkg/logger"
+	"workout-app/pkg/mailer"
+)
+
+// Service описывает usecase-слой очереди исходящих писем: фоновую отправку с повторными попытками,
+// dead letter и его разбор администратором.
+type Service interface {
+	// Run отправляет письма, время попытки которых наступило. Вызывается фоновым воркером.
+	Run(ctx context.Context) error
+
+	// List возвращает страницу писем в статусе status (пусто — любом), новые первыми, и их общее количество.
+	List(ctx context.Context, status domain.Status, limit, offset int) ([]*domain.Message, int64, error)
+
+	// Stats возвращает количество писем по статусам.
+	Stats(ctx context.Context) (map[domain.Status]int64, error)
+
+	// Retry возвращает письмо из dead letter в очередь для немедленной отправки.
+	Retry(ctx context.Context, id int64) (*domain.Message, error)
+
+	// DeleteExpired удаляет не более limit отправленных и dead-letter писем, завершённых раньше
+	// before минус срок хранения (реализует cleanup.Target).
+	DeleteExpired(ctx context.Context, before time.Time, limit int) (int64, error)
+}
+
+// Config описывает параметры отправки.
+type Config struct {
+	BatchSize   int           // Писем за один запуск воркера
+	MaxAttempts int           // Попыток до перевода письма в dead letter
+	RetryBase   time.Duration // Задержка после первой неудачной попытки; далее удваивается
+	RetryMax    time.Duration // Максимальная задержка между попытками
+	Retention   time.Duration // Срок хранения отправленных и dead-letter писем
+}
+
+// Ошибки бизнес-логики usecase-слоя.
+var (
+	ErrInvalidStatus = fmt.Errorf("invalid outbox status")
+	ErrNotDead       = fmt.Errorf("email is not in dead letter")
+	errUnknownKind   = fmt.Errorf("unknown email kind")
+	errEmptyPayload  = fmt.Errorf("email payload is missing")
+)
+
+// Параметры выборки.
+const (
+	// claimTTL — аренда отправки; если процесс упал, письмо подхватит другой по её истечении.
+	claimTTL     = 5 * time.Minute
+	defaultLimit = 50
+	maxLimit     = 200
+	// maxErrorLength ограничивает сохраняемый текст ошибки отправки (в символах).
+	maxErrorLength = 1000
+)
+
+type service struct {
+	outbox repo.EmailOutboxRepository
+	sender mailer.EmailSender
+	cfg    Config
+	now    func() time.Time
+	logger logger.Logger
+}
+
+// NewService создаёт сервис очереди исходящих писем; sender — отправитель, выполняющий реальную доставку.
+func NewService(outbox repo.EmailOutboxRepository, sender mailer.EmailSender, cfg Config, logger logger.Logger) Service {
+	return &service{
+		outbox: outbox,
+		sender: sender,
+		cfg:    cfg,
+		now:    func() time.Time { return time.Now().UTC() },
+		logger: logger,
+	}
+}
+
+// Run отправляет письма из очереди. Ошибка отправки письма не прерывает запуск:
+// письмо получает следующую попытку или уходит в dead letter.
+func (s *service) Run(ctx context.Context) error {
+	now := s.now()
+	due, err := s.outbox.ListDue(ctx, now, s.cfg.BatchSize)
+	if err != nil {
+		return fmt.Errorf("failed to list due emails: %w", err)
+	}
+	for _, m := range due {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		claimed, err := s.outbox.Claim(ctx, m.ID, now, now.Add(claimTTL))
+		if err != nil {
+			return fmt.Errorf("failed to claim email: %w", err)
+		}
+		if !claimed {
+			continue
+		}
+		if err := s.deliver(ctx, m); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// deliver отправляет письмо и сохраняет результат попытки.
+// Попытка учитывается в метриках типа email:<kind> и продолжает трассу запроса, поставившего письмо в очередь.
+func (s *service) deliver(ctx context.Context, m *domain.Message) error {
+	sendErr := worker.Track(ctx, "email:"+string(m.Kind), m.TraceParent, func(ctx context.Context) error {
+		return s.send(ctx, m)
+	})
+	now := s.now()
+	fields := map[string]any{
+		"email_id": m.ID,
+		"kind":     string(m.Kind),
+		"email":    m.Recipient,
+	}
+
+	switch {
+	case sendErr == nil:
+		m.MarkSent(now)
+	case errors.Is(sendErr, mailer.ErrRecipientUndeliverable), errors.Is(sendErr, mailer.ErrMessageRejected),
+		errors.Is(sendErr, errUnknownKind), errors.Is(sendErr, errEmptyPayload):
+		// Адрес заблокирован, провайдер отклонил письмо или его нельзя собрать: повторные попытки ничего не изменят.
+		m.MarkDead(now, truncateError(sendErr))
+		s.logger.Warn("email_outbox_dead_letter", withError(fields, sendErr))
+	case errors.Is(sendErr, mailer.ErrSendRateLimited):
+		// Исчерпан лимит писем организации или провайдера: откладываем без расхода попыток.
+		m.LastError = truncateError(sendErr)
+		m.NextAttemptAt = now.Add(s.cfg.RetryMax)
+		s.logger.Info("email_outbox_rate_limited", fields)
+	default:
+		m.MarkFailed(now, truncateError(sendErr), domain.RetryDelay(m.Attempts+1, s.cfg.RetryBase, s.cfg.RetryMax))
+		if m.Attempts >= s.cfg.MaxAttempts {
+			m.MarkDead(now, m.LastError)
+			s.logger.Error("email_outbox_dead_letter", withError(fields, sendErr))
+		} else {
+			s.logger.Warn("email_outbox_send_failed", withError(fields, sendErr))
+		}
+	}
+
+	if err := s.outbox.Save(ctx, m); err != nil {
+		return fmt.Errorf("failed to save email attempt: %w", err)
+	}
+	return nil
+}
+
+// send отправляет письмо через отправителя на языке и в часовом поясе, сохранённых при постановке в очередь.
+func (s *service) send(ctx context.Context, m *domain.Message) error {
+	ctx = mailer.WithRecipient(ctx, m.Locale, m.Timezone)
+	switch m.Kind {
+	case domain.KindVerificationCode:
+		return s.sender.SendEmailVerificationCode(ctx, m.Recipient, m.Payload.Code)
+	case domain.KindPasswordChangeCode:
+		return s.sender.SendPasswordChangeCode(ctx, m.Recipient, m.Payload.Code)
+	case domain.KindPasswordResetCode:
+		return s.sender.SendPasswordResetCode(ctx, m.Recipient, m.Payload.Code)
+	case domain.KindDataExportReady:
+		var expiresAt time.Time
+		if m.Payload.ExpiresAt != nil {
+			expiresAt = *m.Payload.ExpiresAt
+		}
+		return s.sender.SendDataExportReady(ctx, m.Recipient, m.Payload.DownloadURL, expiresAt)
+	case domain.KindWelcome:
+		return s.sender.SendWelcome(ctx, m.Recipient, m.Payload.Username)
+	case domain.KindPasswordChanged:
+		return s.sender.SendPasswordChanged(ctx, m.Recipient, occurredAt(m))
+	case domain.KindAccountDeleted:
+		return s.sender.SendAccountDeleted(ctx, m.Recipient, occurredAt(m))
+	case domain.KindNotification:
+		return s.sender.SendNotification(ctx, m.Recipient, m.Payload.NotificationType, occurredAt(m))
+	case domain.KindWeeklySummary:
+		if m.Payload.WeeklySummary == nil {
+			return fmt.Errorf("%w: %s", errEmptyPayload, m.Kind)
+		}
+		return s.sender.SendWeeklySummary(ctx, m.Recipient, *m.Payload.WeeklySummary)
+	case domain.KindCoachInvitation:
+		if m.Payload.ExpiresAt == nil {
+			return fmt.Errorf("%w: %s", errEmptyPayload, m.Kind)
+		}
+		return s.sender.SendCoachInvitation(ctx, m.Recipient, m.Payload.Username, *m.Payload.ExpiresAt)
+	default:
+		return fmt.Errorf("%w %q", errUnknownKind, m.Kind)
+	}
+}
+
+// occurredAt возвращает момент события из данных письма; для писем без него — время постановки в очередь.
+func occurredAt(m *domain.Message) time.Time {
+	if m.Payload.OccurredAt != nil {
+		return *m.Payload.OccurredAt
+	}
+	return m.CreatedAt
+}
+
+// List возвращает страницу писем очереди.
+func (s *service) List(ctx context.Context, status domain.Status, limit, offset int) ([]*domain.Message, int64, error) {
+	if status != "" && !status.IsValid() {
+		return nil, 0, ErrInvalidStatus
+	}
+	if limit <= 0 {
+		limit = defaultLimit
+	}
+	if limit > maxLimit {
+		limit = maxLimit
+	}
+	return s.outbox.List(ctx, status, limit, offset)
+}
+
+// Stats возвращает количество писем по статусам; отсутствующие статусы считаются нулём.
+func (s *service) Stats(ctx context.Context) (map[domain.Status]int64, error) {
+	counts, err := s.outbox.CountByStatus(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, status := range []domain.Status{domain.StatusPending, domain.StatusSent, domain.StatusDead} {
+		if _, ok := counts[status]; !ok {
+			counts[status] = 0
+		}
+	}
+	return counts, nil
+}
+
+// Retry возвращает письмо из dead letter в очередь.
+func (s *service) Retry(ctx context.Context, id int64) (*domain.Message, error) {
+	m, err := s.outbox.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if m.Status != domain.StatusDead {
+		return nil, ErrNotDead
+	}
+	m.Requeue(s.now())
+	if err := s.outbox.Save(ctx, m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// DeleteExpired удаляет завершённые письма старше срока хранения.
+func (s *service) DeleteExpired(ctx context.Context, before time.Time, limit int) (int64, error) {
+	return s.outbox.DeleteFinished(ctx, before.Add(-s.cfg.Retention), limit)
+}
+
+func truncateError(err error) string {
+	msg := []rune(err.Error())
+	if len(msg) > maxErrorLength {
+		msg = msg[:maxErrorLength]
+	}
+	return string(msg)
+}
+
+func withError(fields map[string]any, err error) map[string]any {
+	fields["error"] = err.Error()
+	return fields
+}
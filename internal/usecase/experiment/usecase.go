@@ -0,0 +1,199 @@
+package experiment
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/google/uuid"
+
+	domain "workout-app/internal/domain/experiment"
+	repo "workout-app/internal/repository/interfaces"
+)
+
+// Service описывает usecase-слой A/B-экспериментов:
+// детерминированное назначение вариантов, запись событий и администрирование экспериментов.
+type Service interface {
+	// GetAssignments возвращает варианты пользователя во всех активных экспериментах.
+	GetAssignments(ctx context.Context, userID uuid.UUID) ([]domain.Assignment, error)
+
+	// RecordEvent записывает событие показа или конверсии для пользователя.
+	// Вариант вычисляется на сервере, значение от клиента не принимается.
+	RecordEvent(ctx context.Context, userID uuid.UUID, key string, eventType domain.EventType) (*domain.Event, error)
+
+	// CreateExperiment создаёт новый эксперимент (административный сценарий).
+	CreateExperiment(ctx context.Context, input ExperimentInput) (*domain.Experiment, error)
+
+	// UpdateExperiment обновляет существующий эксперимент (административный сценарий).
+	UpdateExperiment(ctx context.Context, key string, input ExperimentInput) (*domain.Experiment, error)
+
+	// ListExperiments возвращает все эксперименты (административный сценарий).
+	ListExperiments(ctx context.Context) ([]*domain.Experiment, error)
+}
+
+// ExperimentInput описывает данные эксперимента на уровне бизнес-логики.
+type ExperimentInput struct {
+	Key         string
+	Description string
+	Variants    []domain.Variant
+	IsActive    bool
+}
+
+// Ошибки бизнес-логики usecase-слоя.
+var (
+	ErrInvalidExperimentKey = fmt.Errorf("invalid experiment key")
+	ErrInvalidVariants      = fmt.Errorf("experiment must have at least two uniquely named variants with non-negative weights and positive total weight")
+	ErrInvalidEventType     = fmt.Errorf("invalid experiment event type")
+	ErrExperimentInactive   = fmt.Errorf("experiment is not active")
+)
+
+// keyPattern ограничивает ключи экспериментов безопасным набором символов.
+var keyPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_\-]{1,63}$`)
+
+type service struct {
+	experiments repo.ExperimentRepository
+	users       repo.UserRepository
+}
+
+// NewService создаёт новый сервис экспериментов.
+// users используется для тегирования событий регионом пользователя.
+func NewService(experiments repo.ExperimentRepository, users repo.UserRepository) Service {
+	return &service{experiments: experiments, users: users}
+}
+
+// GetAssignments возвращает варианты пользователя во всех активных экспериментах.
+func (s *service) GetAssignments(ctx context.Context, userID uuid.UUID) ([]domain.Assignment, error) {
+	experiments, err := s.experiments.List(ctx, true)
+	if err != nil {
+		return nil, err
+	}
+
+	assignments := make([]domain.Assignment, 0, len(experiments))
+	for _, e := range experiments {
+		variant, ok := e.Assign(userID)
+		if !ok {
+			continue
+		}
+		assignments = append(assignments, domain.Assignment{
+			ExperimentKey: e.Key,
+			Variant:       variant.Name,
+		})
+	}
+	return assignments, nil
+}
+
+// RecordEvent записывает событие показа или конверсии для пользователя.
+func (s *service) RecordEvent(ctx context.Context, userID uuid.UUID, key string, eventType domain.EventType) (*domain.Event, error) {
+	if !eventType.IsValid() {
+		return nil, ErrInvalidEventType
+	}
+
+	e, err := s.experiments.GetByKey(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	if !e.IsActive {
+		return nil, ErrExperimentInactive
+	}
+
+	variant, ok := e.Assign(userID)
+	if !ok {
+		return nil, ErrExperimentInactive
+	}
+
+	u, err := s.users.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	ev := &domain.Event{
+		ExperimentKey: e.Key,
+		UserID:        userID,
+		Variant:       variant.Name,
+		Type:          eventType,
+		Region:        u.Region,
+		CreatedAt:     time.Now().UTC(),
+	}
+	if err := s.experiments.CreateEvent(ctx, ev); err != nil {
+		return nil, err
+	}
+	return ev, nil
+}
+
+// CreateExperiment создаёт новый эксперимент.
+func (s *service) CreateExperiment(ctx context.Context, input ExperimentInput) (*domain.Experiment, error) {
+	if !keyPattern.MatchString(input.Key) {
+		return nil, ErrInvalidExperimentKey
+	}
+	if err := validateVariants(input.Variants); err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	e := &domain.Experiment{
+		Key:         input.Key,
+		Description: input.Description,
+		Variants:    input.Variants,
+		IsActive:    input.IsActive,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	if err := s.experiments.Create(ctx, e); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// UpdateExperiment обновляет существующий эксперимент.
+// Изменение весов вариантов перераспределяет часть пользователей, поэтому
+// менять их у запущенного эксперимента следует осознанно.
+func (s *service) UpdateExperiment(ctx context.Context, key string, input ExperimentInput) (*domain.Experiment, error) {
+	if err := validateVariants(input.Variants); err != nil {
+		return nil, err
+	}
+
+	e, err := s.experiments.GetByKey(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+
+	e.Description = input.Description
+	e.Variants = input.Variants
+	e.IsActive = input.IsActive
+	e.UpdatedAt = time.Now().UTC()
+
+	if err := s.experiments.Update(ctx, e); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// ListExperiments возвращает все эксперименты.
+func (s *service) ListExperiments(ctx context.Context) ([]*domain.Experiment, error) {
+	return s.experiments.List(ctx, false)
+}
+
+// validateVariants проверяет, что вариантов не меньше двух, имена уникальны,
+// а суммарный вес положителен.
+func validateVariants(variants []domain.Variant) error {
+	if len(variants) < 2 {
+		return ErrInvalidVariants
+	}
+	seen := make(map[string]struct{}, len(variants))
+	total := 0
+	for _, v := range variants {
+		if v.Name == "" || v.Weight < 0 {
+			return ErrInvalidVariants
+		}
+		if _, dup := seen[v.Name]; dup {
+			return ErrInvalidVariants
+		}
+		seen[v.Name] = struct{}{}
+		total += v.Weight
+	}
+	if total <= 0 {
+		return ErrInvalidVariants
+	}
+	return nil
+}
@@ -0,0 +1,290 @@
+package export
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"time"
+
+	checkindomain "workout-app/internal/domain/checkin"
+	consentdomain "workout-app/internal/domain/consent"
+	custommetricdomain "workout-app/internal/domain/custommetric"
+	programdomain "workout-app/internal/domain/program"
+	userdomain "workout-app/internal/domain/user"
+	workoutdomain "workout-app/internal/domain/workout"
+)
+
+// Файлы архива описаны отдельными структурами: формат выгрузки не должен меняться
+// вместе с доменными моделями, а служебные поля (хэш пароля) в него не попадают.
+
+type profileRecord struct {
+	ID              string       `json:"id"`
+	Email           string       `json:"email"`
+	Username        string       `json:"username"`
+	FirstName       string       `json:"first_name,omitempty"`
+	LastName        string       `json:"last_name,omitempty"`
+	BirthDate       *time.Time   `json:"birth_date,omitempty"`
+	Gender          string       `json:"gender,omitempty"`
+	AvatarURL       string       `json:"avatar_url,omitempty"`
+	Role            string       `json:"role"`
+	TrainingLevel   string       `json:"training_level,omitempty"`
+	IsEmailVerified bool         `json:"is_email_verified"`
+	Language        string       `json:"language,omitempty"`
+	Country         string       `json:"country,omitempty"`
+	Region          string       `json:"region,omitempty"`
+	Links           []linkRecord `json:"links,omitempty"`
+	CreatedAt       time.Time    `json:"created_at"`
+	UpdatedAt       time.Time    `json:"updated_at"`
+}
+
+type linkRecord struct {
+	Kind       string `json:"kind"`
+	Value      string `json:"value"`
+	Visibility string `json:"visibility"`
+}
+
+type workoutRecord struct {
+	ID                    string      `json:"id"`
+	Title                 string      `json:"title"`
+	AssignmentID          *string     `json:"assignment_id,omitempty"`
+	AutoPauseAfterSeconds int64       `json:"auto_pause_after_seconds"`
+	StartedAt             time.Time   `json:"started_at"`
+	FinishedAt            *time.Time  `json:"finished_at,omitempty"`
+	ActiveSeconds         int64       `json:"active_seconds"`
+	Sets                  []setRecord `json:"sets"`
+}
+
+type setRecord struct {
+	Exercise string    `json:"exercise"`
+	Reps     int       `json:"reps"`
+	WeightKg *float64  `json:"weight_kg,omitempty"`
+	LoggedAt time.Time `json:"logged_at"`
+}
+
+type metricRecord struct {
+	MeasuredAt     time.Time          `json:"measured_at"`
+	WeightKg       *float64           `json:"weight_kg,omitempty"`
+	BodyFatPercent *float64           `json:"body_fat_percent,omitempty"`
+	Measurements   map[string]float64 `json:"measurements,omitempty"`
+}
+
+type checkInRecord struct {
+	Date       string `json:"date"`
+	Soreness   int    `json:"soreness"`
+	Energy     int    `json:"energy"`
+	Motivation int    `json:"motivation"`
+	Note       string `json:"note,omitempty"`
+}
+
+type customMetricRecord struct {
+	Name           string              `json:"name"`
+	Unit           string              `json:"unit"`
+	HigherIsBetter bool                `json:"higher_is_better"`
+	CreatedAt      time.Time           `json:"created_at"`
+	Entries        []customEntryRecord `json:"entries"`
+}
+
+type customEntryRecord struct {
+	Value      float64   `json:"value"`
+	RecordedAt time.Time `json:"recorded_at"`
+	Note       string    `json:"note,omitempty"`
+}
+
+type assignmentRecord struct {
+	ID         string    `json:"id"`
+	ProgramID  string    `json:"program_id"`
+	AssignedBy string    `json:"assigned_by"`
+	StartDate  time.Time `json:"start_date"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+type trainingMaxRecord struct {
+	Exercise  string    `json:"exercise"`
+	WeightKg  float64   `json:"weight_kg"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type consentRecord struct {
+	CoachID   string    `json:"coach_id"`
+	Scopes    []string  `json:"scopes"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// archiveData — данные пользователя, из которых собирается архив.
+type archiveData struct {
+	user     *userdomain.User
+	workouts []*workoutdomain.Session
+	metrics  []*userdomain.BodyMetric
+	checkIns []*checkindomain.CheckIn
+	// customEntries — значения всех пользовательских метрик; в архиве группируются по метрике.
+	customMetrics []*custommetricdomain.Definition
+	customEntries []*custommetricdomain.Entry
+	assignments   []*programdomain.Assignment
+	trainingMaxes []*programdomain.TrainingMax
+	consents      []*consentdomain.Consent
+}
+
+// buildArchive собирает ZIP-архив с JSON-файлом на каждый класс данных.
+func buildArchive(data archiveData, generatedAt time.Time) (*bytes.Buffer, error) {
+	u := data.user
+	profile := profileRecord{
+		ID:              u.ID.String(),
+		Email:           u.Email,
+		Username:        u.Username,
+		FirstName:       u.FirstName,
+		LastName:        u.LastName,
+		BirthDate:       u.BirthDate,
+		Gender:          u.Gender,
+		AvatarURL:       u.AvatarURL,
+		Role:            string(u.Role),
+		TrainingLevel:   string(u.TrainingLevel),
+		IsEmailVerified: u.IsEmailVerified,
+		Language:        string(u.Language),
+		Country:         u.Country,
+		Region:          string(u.Region),
+		CreatedAt:       u.CreatedAt,
+		UpdatedAt:       u.UpdatedAt,
+	}
+	for _, kind := range []userdomain.LinkKind{userdomain.LinkInstagram, userdomain.LinkYouTube, userdomain.LinkWebsite} {
+		if l := u.Links.Get(kind); l.Value != "" {
+			profile.Links = append(profile.Links, linkRecord{Kind: string(kind), Value: l.Value, Visibility: string(l.Visibility)})
+		}
+	}
+
+	workouts := make([]workoutRecord, 0, len(data.workouts))
+	for _, w := range data.workouts {
+		rec := workoutRecord{
+			ID:                    w.ID.String(),
+			Title:                 w.Title,
+			AutoPauseAfterSeconds: int64(w.AutoPauseAfter / time.Second),
+			StartedAt:             w.StartedAt,
+			FinishedAt:            w.FinishedAt,
+			ActiveSeconds:         int64(w.Summarize(generatedAt).ActiveDuration / time.Second),
+			Sets:                  make([]setRecord, 0, len(w.Sets)),
+		}
+		if w.AssignmentID != nil {
+			id := w.AssignmentID.String()
+			rec.AssignmentID = &id
+		}
+		for _, set := range w.Sets {
+			rec.Sets = append(rec.Sets, setRecord{
+				Exercise: set.Exercise,
+				Reps:     set.Reps,
+				WeightKg: set.WeightKg,
+				LoggedAt: set.LoggedAt,
+			})
+		}
+		workouts = append(workouts, rec)
+	}
+
+	metrics := make([]metricRecord, 0, len(data.metrics))
+	for _, m := range data.metrics {
+		metrics = append(metrics, metricRecord{
+			MeasuredAt:     m.MeasuredAt,
+			WeightKg:       m.WeightKg,
+			BodyFatPercent: m.BodyFatPercent,
+			Measurements:   m.Measurements,
+		})
+	}
+
+	checkIns := make([]checkInRecord, 0, len(data.checkIns))
+	for _, c := range data.checkIns {
+		checkIns = append(checkIns, checkInRecord{
+			Date:       c.Date.Format("2006-01-02"),
+			Soreness:   c.Soreness,
+			Energy:     c.Energy,
+			Motivation: c.Motivation,
+			Note:       c.Note,
+		})
+	}
+
+	customMetrics := make([]customMetricRecord, 0, len(data.customMetrics))
+	customIndex := make(map[string]int, len(data.customMetrics))
+	for _, d := range data.customMetrics {
+		customIndex[d.ID.String()] = len(customMetrics)
+		customMetrics = append(customMetrics, customMetricRecord{
+			Name:           d.Name,
+			Unit:           d.Unit,
+			HigherIsBetter: d.HigherIsBetter,
+			CreatedAt:      d.CreatedAt,
+			Entries:        []customEntryRecord{},
+		})
+	}
+	for _, e := range data.customEntries {
+		i, ok := customIndex[e.DefinitionID.String()]
+		if !ok {
+			continue
+		}
+		customMetrics[i].Entries = append(customMetrics[i].Entries, customEntryRecord{
+			Value:      e.Value,
+			RecordedAt: e.RecordedAt,
+			Note:       e.Note,
+		})
+	}
+
+	assignments := make([]assignmentRecord, 0, len(data.assignments))
+	for _, a := range data.assignments {
+		assignments = append(assignments, assignmentRecord{
+			ID:         a.ID.String(),
+			ProgramID:  a.ProgramID.String(),
+			AssignedBy: a.AssignedBy.String(),
+			StartDate:  a.StartDate,
+			CreatedAt:  a.CreatedAt,
+		})
+	}
+
+	trainingMaxes := make([]trainingMaxRecord, 0, len(data.trainingMaxes))
+	for _, tm := range data.trainingMaxes {
+		trainingMaxes = append(trainingMaxes, trainingMaxRecord{
+			Exercise:  tm.Exercise,
+			WeightKg:  tm.WeightKg,
+			UpdatedAt: tm.UpdatedAt,
+		})
+	}
+
+	consents := make([]consentRecord, 0, len(data.consents))
+	for _, c := range data.consents {
+		scopes := make([]string, 0, len(c.Scopes))
+		for _, scope := range c.Scopes {
+			scopes = append(scopes, string(scope))
+		}
+		consents = append(consents, consentRecord{
+			CoachID:   c.CoachID.String(),
+			Scopes:    scopes,
+			UpdatedAt: c.UpdatedAt,
+		})
+	}
+
+	files := []struct {
+		name    string
+		content any
+	}{
+		{"manifest.json", map[string]any{"user_id": u.ID.String(), "generated_at": generatedAt, "format_version": 1}},
+		{"profile.json", profile},
+		{"workouts.json", workouts},
+		{"body_metrics.json", metrics},
+		{"checkins.json", checkIns},
+		{"custom_metrics.json", customMetrics},
+		{"program_assignments.json", assignments},
+		{"training_maxes.json", trainingMaxes},
+		{"coach_consents.json", consents},
+	}
+
+	buf := &bytes.Buffer{}
+	zw := zip.NewWriter(buf)
+	for _, f := range files {
+		w, err := zw.CreateHeader(&zip.FileHeader{Name: f.name, Method: zip.Deflate, Modified: generatedAt})
+		if err != nil {
+			return nil, err
+		}
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(f.content); err != nil {
+			return nil, err
+		}
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf, nil
+}
@@ -0,0 +1,379 @@
+package export
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+
+	domain "workout-app/internal/domain/export"
+	userdomain "workout-app/internal/domain/user"
+	repo "workout-app/internal/repository/interfaces"
+	"workout-app/pkg/logger"
+	"workout-app/pkg/mailer"
+	"workout-app/pkg/storage"
+)
+
+// Service описывает usecase-слой выгрузки данных аккаунта: постановку в очередь,
+// фоновую сборку архивов, уведомление по email и скачивание по подписанной ссылке.
+type Service interface {
+	// Request возвращает последнюю действующую выгрузку пользователя (в очереди или собранную);
+	// если такой нет, ставит в очередь новую.
+	Request(ctx context.Context, userID uuid.UUID) (*domain.Export, error)
+
+	// DownloadURL возвращает подписанную ссылку на собранный архив, действующую до его удаления.
+	DownloadURL(e *domain.Export) (string, error)
+
+	// Open проверяет подписанную ссылку и открывает архив.
+	Open(ctx context.Context, id uuid.UUID, expires, signature string) (*Download, error)
+
+	// Run собирает архивы выгрузок из очереди и удаляет истёкшие архивы.
+	// Вызывается фоновым воркером.
+	Run(ctx context.Context) error
+}
+
+// Config описывает параметры выгрузок.
+type Config struct {
+	TTL        time.Duration // Срок хранения архива и действия ссылки на него
+	SigningKey []byte        // Ключ HMAC-подписи ссылок
+	BaseURL    string        // Публичный адрес API для ссылок в письмах
+}
+
+// Download — архив для отдачи клиенту: либо открытый объект, либо ссылка для редиректа.
+type Download struct {
+	Export      *domain.Export
+	Object      *storage.Object
+	RedirectURL string
+}
+
+// Ошибки бизнес-логики usecase-слоя.
+var (
+	ErrExportNotFound      = fmt.Errorf("data export not found")
+	ErrNotReady            = fmt.Errorf("data export is not ready")
+	ErrInvalidSignature    = fmt.Errorf("invalid download link signature")
+	ErrLinkExpired         = fmt.Errorf("download link expired")
+	ErrDownloadUnsupported = fmt.Errorf("storage does not support downloads")
+	errExportUserNotFound  = fmt.Errorf("user not found")
+)
+
+// Параметры фоновой сборки.
+const (
+	batchSize = 10
+	// claimTTL — аренда сборки; если процесс упал, выгрузку подхватит другой по её истечении.
+	claimTTL = 10 * time.Minute
+	// maxExportedWorkouts ограничивает выборку тренировок с большим запасом.
+	maxExportedWorkouts = 100000
+	// maxExportedMetrics ограничивает выборку замеров с большим запасом.
+	maxExportedMetrics = 100000
+	// minRedirectTTL — минимальный срок presigned-ссылки при редиректе.
+	minRedirectTTL = time.Minute
+)
+
+type service struct {
+	exports  repo.DataExportRepository
+	users    repo.UserRepository
+	workouts repo.WorkoutSessionRepository
+	metrics  repo.BodyMetricRepository
+	checkIns repo.CheckInRepository
+	custom   repo.CustomMetricRepository
+	programs repo.ProgramRepository
+	maxes    repo.TrainingMaxRepository
+	consents repo.ConsentRepository
+	storage  storage.Storage
+	sender   mailer.EmailSender
+	cfg      Config
+	logger   logger.Logger
+}
+
+// NewService создаёт новый сервис выгрузки данных аккаунта.
+func NewService(
+	exports repo.DataExportRepository,
+	users repo.UserRepository,
+	workouts repo.WorkoutSessionRepository,
+	metrics repo.BodyMetricRepository,
+	checkIns repo.CheckInRepository,
+	custom repo.CustomMetricRepository,
+	programs repo.ProgramRepository,
+	maxes repo.TrainingMaxRepository,
+	consents repo.ConsentRepository,
+	storage storage.Storage,
+	sender mailer.EmailSender,
+	cfg Config,
+	logger logger.Logger,
+) Service {
+	return &service{
+		exports:  exports,
+		users:    users,
+		workouts: workouts,
+		metrics:  metrics,
+		checkIns: checkIns,
+		custom:   custom,
+		programs: programs,
+		maxes:    maxes,
+		consents: consents,
+		storage:  storage,
+		sender:   sender,
+		cfg:      cfg,
+		logger:   logger,
+	}
+}
+
+// Request возвращает действующую выгрузку или ставит в очередь новую.
+func (s *service) Request(ctx context.Context, userID uuid.UUID) (*domain.Export, error) {
+	latest, err := s.exports.GetLatestByUser(ctx, userID)
+	if err != nil && !errors.Is(err, repo.ErrNotFound) {
+		return nil, err
+	}
+	now := time.Now().UTC()
+	if latest != nil && (latest.Status == domain.StatusPending || latest.IsAvailable(now)) {
+		return latest, nil
+	}
+
+	e := domain.New(userID, now)
+	if err := s.exports.Create(ctx, e); err != nil {
+		if errors.Is(err, repo.ErrExportPending) {
+			// Параллельный запрос успел поставить выгрузку в очередь.
+			return s.exports.GetLatestByUser(ctx, userID)
+		}
+		return nil, err
+	}
+	return e, nil
+}
+
+// DownloadURL возвращает подписанную ссылку на архив.
+func (s *service) DownloadURL(e *domain.Export) (string, error) {
+	if e.Status != domain.StatusReady || e.ExpiresAt == nil {
+		return "", ErrNotReady
+	}
+	expires := strconv.FormatInt(e.ExpiresAt.Unix(), 10)
+	query := url.Values{}
+	query.Set("expires", expires)
+	query.Set("signature", s.sign(e.ID, expires))
+	return s.cfg.BaseURL + "/api/v1/exports/" + e.ID.String() + "/download?" + query.Encode(), nil
+}
+
+// Open проверяет ссылку и открывает архив.
+func (s *service) Open(ctx context.Context, id uuid.UUID, expires, signature string) (*Download, error) {
+	expected := s.sign(id, expires)
+	if !hmac.Equal([]byte(expected), []byte(signature)) {
+		return nil, ErrInvalidSignature
+	}
+	unix, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil {
+		return nil, ErrInvalidSignature
+	}
+	expiresAt := time.Unix(unix, 0)
+	if !time.Now().Before(expiresAt) {
+		return nil, ErrLinkExpired
+	}
+
+	e, err := s.exports.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repo.ErrNotFound) {
+			return nil, ErrExportNotFound
+		}
+		return nil, err
+	}
+	if !e.IsAvailable(time.Now()) {
+		// Срок хранения сокращается при обезличивании аккаунта: ссылка из письма перестаёт действовать.
+		return nil, ErrLinkExpired
+	}
+
+	switch st := s.storage.(type) {
+	case storage.Opener:
+		obj, err := st.Open(ctx, e.StorageKey)
+		if err != nil {
+			if errors.Is(err, storage.ErrNotFound) {
+				return nil, ErrExportNotFound
+			}
+			return nil, err
+		}
+		return &Download{Export: e, Object: obj}, nil
+	case storage.Presigner:
+		ttl := time.Until(expiresAt)
+		if ttl < minRedirectTTL {
+			ttl = minRedirectTTL
+		}
+		redirect, err := st.PresignGet(e.StorageKey, ttl)
+		if err != nil {
+			return nil, err
+		}
+		return &Download{Export: e, RedirectURL: redirect}, nil
+	default:
+		return nil, ErrDownloadUnsupported
+	}
+}
+
+// Run собирает архивы из очереди и удаляет истёкшие.
+func (s *service) Run(ctx context.Context) error {
+	pending, err := s.exports.ListPending(ctx, batchSize)
+	if err != nil {
+		return fmt.Errorf("failed to list pending exports: %w", err)
+	}
+	for _, e := range pending {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		claimed, err := s.exports.Claim(ctx, e.ID, time.Now().UTC().Add(claimTTL))
+		if err != nil {
+			return fmt.Errorf("failed to claim export: %w", err)
+		}
+		if !claimed {
+			continue
+		}
+		s.process(ctx, e)
+	}
+	return s.purgeExpired(ctx)
+}
+
+// process собирает архив выгрузки, сохраняет результат и уведомляет пользователя.
+func (s *service) process(ctx context.Context, e *domain.Export) {
+	user, key, size, err := s.assemble(ctx, e.UserID)
+
+	now := time.Now().UTC()
+	expiresAt := now.Add(s.cfg.TTL)
+	e.CompletedAt = &now
+	e.ExpiresAt = &expiresAt
+	if err != nil {
+		s.logger.Error("data_export_failed", map[string]any{
+			"export_id": e.ID.String(),
+			"user_id":   e.UserID.String(),
+			"error":     err.Error(),
+		})
+		e.Status = domain.StatusFailed
+		e.Error = err.Error()
+	} else {
+		e.Status = domain.StatusReady
+		e.StorageKey = key
+		e.SizeBytes = size
+	}
+
+	if err := s.exports.Complete(ctx, e); err != nil {
+		s.logger.Error("data_export_save_failed", map[string]any{
+			"export_id": e.ID.String(),
+			"error":     err.Error(),
+		})
+		if key != "" {
+			s.deleteObject(ctx, key)
+		}
+		return
+	}
+	if e.Status != domain.StatusReady {
+		return
+	}
+
+	link, err := s.DownloadURL(e)
+	if err == nil {
+		err = s.sender.SendDataExportReady(mailer.WithRecipient(ctx, user.MailLocale(), user.Timezone), user.Email, link, expiresAt)
+	}
+	if err != nil {
+		// Архив остаётся доступным через GET /users/me/export.
+		s.logger.Warn("data_export_notification_failed", map[string]any{
+			"export_id": e.ID.String(),
+			"user_id":   e.UserID.String(),
+			"error":     err.Error(),
+		})
+	}
+}
+
+// assemble собирает данные пользователя в архив и сохраняет его в хранилище.
+func (s *service) assemble(ctx context.Context, userID uuid.UUID) (*userdomain.User, string, int64, error) {
+	user, err := s.users.GetByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, repo.ErrNotFound) {
+			return nil, "", 0, errExportUserNotFound
+		}
+		return nil, "", 0, err
+	}
+
+	now := time.Now().UTC()
+	data := archiveData{user: user}
+	if data.workouts, err = s.workouts.ListByUser(ctx, userID, time.Time{}, now.Add(time.Hour), maxExportedWorkouts); err != nil {
+		return nil, "", 0, fmt.Errorf("failed to load workouts: %w", err)
+	}
+	if data.metrics, err = s.metrics.ListByUser(ctx, userID, time.Time{}, time.Time{}, maxExportedMetrics, 0); err != nil {
+		return nil, "", 0, fmt.Errorf("failed to load body metrics: %w", err)
+	}
+	if data.checkIns, err = s.checkIns.ListByUser(ctx, userID, time.Time{}, now.AddDate(0, 0, 1)); err != nil {
+		return nil, "", 0, fmt.Errorf("failed to load check-ins: %w", err)
+	}
+	if data.customMetrics, err = s.custom.ListByUser(ctx, userID); err != nil {
+		return nil, "", 0, fmt.Errorf("failed to load custom metrics: %w", err)
+	}
+	if data.customEntries, err = s.custom.ListEntriesByUser(ctx, userID); err != nil {
+		return nil, "", 0, fmt.Errorf("failed to load custom metric entries: %w", err)
+	}
+	if data.assignments, err = s.programs.ListAssignmentsByUser(ctx, userID); err != nil {
+		return nil, "", 0, fmt.Errorf("failed to load program assignments: %w", err)
+	}
+	if data.trainingMaxes, err = s.maxes.ListByUser(ctx, userID); err != nil {
+		return nil, "", 0, fmt.Errorf("failed to load training maxes: %w", err)
+	}
+	if data.consents, err = s.consents.ListByClient(ctx, userID); err != nil {
+		return nil, "", 0, fmt.Errorf("failed to load coach consents: %w", err)
+	}
+
+	buf, err := buildArchive(data, now)
+	if err != nil {
+		return nil, "", 0, fmt.Errorf("failed to build archive: %w", err)
+	}
+	key, err := exportKey()
+	if err != nil {
+		return nil, "", 0, err
+	}
+	size := int64(buf.Len())
+	if _, err := s.storage.Put(ctx, key, buf, size, "application/zip"); err != nil {
+		return nil, "", 0, fmt.Errorf("failed to store archive: %w", err)
+	}
+	return user, key, size, nil
+}
+
+// purgeExpired удаляет архивы и записи выгрузок с истёкшим сроком хранения.
+func (s *service) purgeExpired(ctx context.Context) error {
+	expired, err := s.exports.ListExpired(ctx, time.Now().UTC(), 100)
+	if err != nil {
+		return fmt.Errorf("failed to list expired exports: %w", err)
+	}
+	for _, e := range expired {
+		if e.StorageKey != "" {
+			if err := s.storage.Delete(ctx, e.StorageKey); err != nil {
+				// Запись остаётся, удаление повторится при следующем запуске.
+				s.logger.Error("data_export_cleanup_failed", map[string]any{"key": e.StorageKey, "error": err.Error()})
+				continue
+			}
+		}
+		if err := s.exports.Delete(ctx, e.ID); err != nil {
+			return fmt.Errorf("failed to delete expired export: %w", err)
+		}
+	}
+	return nil
+}
+
+func (s *service) sign(id uuid.UUID, expires string) string {
+	mac := hmac.New(sha256.New, s.cfg.SigningKey)
+	mac.Write([]byte(id.String() + "." + expires))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func (s *service) deleteObject(ctx context.Context, key string) {
+	if err := s.storage.Delete(ctx, key); err != nil {
+		s.logger.Error("data_export_cleanup_failed", map[string]any{"key": key, "error": err.Error()})
+	}
+}
+
+// exportKey строит ключ архива со случайным именем: ссылка на файл не должна угадываться.
+func exportKey() (string, error) {
+	suffix := make([]byte, 16)
+	if _, err := rand.Read(suffix); err != nil {
+		return "", fmt.Errorf("failed to generate export key: %w", err)
+	}
+	return "exports/" + hex.EncodeToString(suffix) + ".zip", nil
+}
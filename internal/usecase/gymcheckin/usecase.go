@@ -0,0 +1,377 @@
+package gymcheckin
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+
+	eventdomain "workout-app/internal/domain/event"
+	domain "workout-app/internal/domain/gymcheckin"
+	orgdomain "workout-app/internal/domain/organization"
+	userdomain "workout-app/internal/domain/user"
+	"workout-app/internal/events"
+	repo "workout-app/internal/repository/interfaces"
+)
+
+// Service описывает usecase-слой отметок в залах организаций по ротируемым QR-кодам:
+// управление залами, выдачу текущего кода, отметку участников и отчёты о посещаемости.
+type Service interface {
+	// CreateLocation создаёт зал организации. Доступно владельцу организации и админу.
+	CreateLocation(ctx context.Context, actor Actor, orgID uuid.UUID, input LocationInput) (*domain.Location, error)
+
+	// ListLocations возвращает залы организации. Доступно участникам организации.
+	ListLocations(ctx context.Context, actor Actor, orgID uuid.UUID) ([]*domain.Location, error)
+
+	// DeleteLocation удаляет зал вместе с отметками. Доступно владельцу организации и админу.
+	DeleteLocation(ctx context.Context, actor Actor, locationID uuid.UUID) error
+
+	// CurrentCode возвращает действующий QR-код зала для экрана на входе.
+	// Доступно владельцу организации и админу.
+	CurrentCode(ctx context.Context, actor Actor, locationID uuid.UUID) (LocationCode, error)
+
+	// CheckIn проверяет QR-код и отмечает actor в зале. Повторная отметка в том же зале
+	// в течение domain.RepeatWindow возвращает уже записанный визит с created=false.
+	CheckIn(ctx context.Context, actor Actor, code string) (visit *domain.Visit, created bool, err error)
+
+	// ListMyVisits возвращает отметки пользователя за [from, to), новые первыми.
+	ListMyVisits(ctx context.Context, userID uuid.UUID, from, to time.Time) ([]*domain.Visit, error)
+
+	// AttendanceReport возвращает посещаемость залов организации за [from, to).
+	// Доступно владельцу организации и админу.
+	AttendanceReport(ctx context.Context, actor Actor, orgID uuid.UUID, filter ReportFilter) (*AttendanceReport, error)
+}
+
+// Actor описывает пользователя, от имени которого выполняется операция.
+type Actor struct {
+	UserID uuid.UUID
+	Role   userdomain.Role
+}
+
+// LocationInput описывает поля зала.
+type LocationInput struct {
+	Name    string
+	Address string
+}
+
+// LocationCode — действующий QR-код зала.
+type LocationCode struct {
+	Code      string
+	ExpiresAt time.Time // Когда экран должен запросить новый код
+}
+
+// ReportFilter задаёт период отчёта о посещаемости и (опционально) зал.
+type ReportFilter struct {
+	From       time.Time
+	To         time.Time
+	LocationID *uuid.UUID
+}
+
+// AttendanceReport — посещаемость залов организации за период.
+type AttendanceReport struct {
+	From          time.Time
+	To            time.Time
+	TotalVisits   int
+	UniqueMembers int
+	Days          []DayAttendance    // Дни с отметками (UTC) по возрастанию
+	Members       []MemberAttendance // Участники по убыванию числа визитов
+}
+
+// DayAttendance — число отметок за день.
+type DayAttendance struct {
+	Date   time.Time
+	Visits int
+}
+
+// MemberAttendance — посещаемость участника за период.
+type MemberAttendance struct {
+	UserID      uuid.UUID
+	Visits      int
+	LastVisitAt time.Time
+}
+
+// Ошибки бизнес-логики usecase-слоя.
+var (
+	ErrInvalidLocation  = fmt.Errorf("invalid location")
+	ErrLocationNotFound = fmt.Errorf("location not found")
+	ErrForbidden        = fmt.Errorf("insufficient organization role")
+	ErrInvalidCode      = fmt.Errorf("invalid or expired check-in code")
+	ErrNotMember        = fmt.Errorf("user is not a member of the organization")
+	ErrInvalidPeriod    = fmt.Errorf("invalid period")
+)
+
+// Ограничения залов и отчётов.
+const (
+	maxNameLength    = 200
+	maxAddressLength = 500
+	secretLength     = 32
+	// maxReportPeriod ограничивает период одного отчёта и истории отметок.
+	maxReportPeriod = 366 * 24 * time.Hour
+)
+
+type service struct {
+	checkIns repo.GymCheckInRepository
+	orgs     repo.OrganizationRepository
+	events   events.Publisher
+}
+
+// NewService создаёт новый сервис отметок в залах.
+// publisher доставляет события gym.checked_in подписчикам.
+func NewService(checkIns repo.GymCheckInRepository, orgs repo.OrganizationRepository, publisher events.Publisher) Service {
+	return &service{
+		checkIns: checkIns,
+		orgs:     orgs,
+		events:   publisher,
+	}
+}
+
+// CreateLocation создаёт зал организации с новым ключом подписи QR-кодов.
+func (s *service) CreateLocation(ctx context.Context, actor Actor, orgID uuid.UUID, input LocationInput) (*domain.Location, error) {
+	if err := s.requireOwner(ctx, actor, orgID); err != nil {
+		return nil, err
+	}
+	name := strings.TrimSpace(input.Name)
+	address := strings.TrimSpace(input.Address)
+	if name == "" || utf8.RuneCountInString(name) > maxNameLength {
+		return nil, fmt.Errorf("%w: name must be 1-%d characters", ErrInvalidLocation, maxNameLength)
+	}
+	if utf8.RuneCountInString(address) > maxAddressLength {
+		return nil, fmt.Errorf("%w: address must be at most %d characters", ErrInvalidLocation, maxAddressLength)
+	}
+
+	secret := make([]byte, secretLength)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, fmt.Errorf("failed to generate location secret: %w", err)
+	}
+	now := time.Now().UTC()
+	l := &domain.Location{
+		ID:             uuid.New(),
+		OrganizationID: orgID,
+		Name:           name,
+		Address:        address,
+		Secret:         secret,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+	if err := s.checkIns.CreateLocation(ctx, l); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+// ListLocations возвращает залы организации.
+func (s *service) ListLocations(ctx context.Context, actor Actor, orgID uuid.UUID) ([]*domain.Location, error) {
+	if _, err := s.requireMember(ctx, actor, orgID); err != nil {
+		return nil, err
+	}
+	return s.checkIns.ListLocations(ctx, orgID)
+}
+
+// DeleteLocation удаляет зал.
+func (s *service) DeleteLocation(ctx context.Context, actor Actor, locationID uuid.UUID) error {
+	l, err := s.getLocation(ctx, locationID)
+	if err != nil {
+		return err
+	}
+	if err := s.requireOwner(ctx, actor, l.OrganizationID); err != nil {
+		return err
+	}
+	if err := s.checkIns.DeleteLocation(ctx, locationID); err != nil {
+		if errors.Is(err, repo.ErrNotFound) {
+			return ErrLocationNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+// CurrentCode возвращает действующий QR-код зала.
+func (s *service) CurrentCode(ctx context.Context, actor Actor, locationID uuid.UUID) (LocationCode, error) {
+	l, err := s.getLocation(ctx, locationID)
+	if err != nil {
+		return LocationCode{}, err
+	}
+	if err := s.requireOwner(ctx, actor, l.OrganizationID); err != nil {
+		return LocationCode{}, err
+	}
+	code, expiresAt := l.Code(time.Now())
+	return LocationCode{Code: code, ExpiresAt: expiresAt}, nil
+}
+
+// CheckIn отмечает actor в зале по QR-коду.
+func (s *service) CheckIn(ctx context.Context, actor Actor, code string) (*domain.Visit, bool, error) {
+	locationID, period, signature, err := domain.ParseCode(code)
+	if err != nil {
+		return nil, false, ErrInvalidCode
+	}
+	l, err := s.checkIns.GetLocation(ctx, locationID)
+	if err != nil {
+		if errors.Is(err, repo.ErrNotFound) {
+			// Код удалённого зала не отличается от поддельного.
+			return nil, false, ErrInvalidCode
+		}
+		return nil, false, err
+	}
+	now := time.Now().UTC()
+	if !l.VerifyCode(period, signature, now) {
+		return nil, false, ErrInvalidCode
+	}
+	if _, err := s.orgs.GetMember(ctx, l.OrganizationID, actor.UserID); err != nil {
+		if errors.Is(err, repo.ErrNotFound) {
+			return nil, false, ErrNotMember
+		}
+		return nil, false, err
+	}
+
+	last, err := s.checkIns.LastVisit(ctx, actor.UserID, l.ID)
+	if err != nil && !errors.Is(err, repo.ErrNotFound) {
+		return nil, false, err
+	}
+	if last != nil && now.Sub(last.CheckedInAt) < domain.RepeatWindow {
+		return last, false, nil
+	}
+
+	v := &domain.Visit{
+		ID:             uuid.New(),
+		LocationID:     l.ID,
+		OrganizationID: l.OrganizationID,
+		UserID:         actor.UserID,
+		CheckedInAt:    now,
+	}
+	if err := s.checkIns.CreateVisit(ctx, v); err != nil {
+		return nil, false, err
+	}
+
+	s.events.Publish(ctx, eventdomain.TypeGymCheckedIn, v.ID.String(), eventdomain.GymCheckIn{
+		VisitID:        v.ID.String(),
+		LocationID:     v.LocationID.String(),
+		OrganizationID: v.OrganizationID.String(),
+		UserID:         v.UserID.String(),
+		CheckedInAt:    v.CheckedInAt,
+	})
+	return v, true, nil
+}
+
+// ListMyVisits возвращает отметки пользователя за период.
+func (s *service) ListMyVisits(ctx context.Context, userID uuid.UUID, from, to time.Time) ([]*domain.Visit, error) {
+	if err := validatePeriod(from, to); err != nil {
+		return nil, err
+	}
+	return s.checkIns.ListVisitsByUser(ctx, userID, from, to)
+}
+
+// AttendanceReport возвращает посещаемость залов организации за период.
+func (s *service) AttendanceReport(ctx context.Context, actor Actor, orgID uuid.UUID, filter ReportFilter) (*AttendanceReport, error) {
+	if err := validatePeriod(filter.From, filter.To); err != nil {
+		return nil, err
+	}
+	if err := s.requireOwner(ctx, actor, orgID); err != nil {
+		return nil, err
+	}
+	if filter.LocationID != nil {
+		l, err := s.getLocation(ctx, *filter.LocationID)
+		if err != nil {
+			return nil, err
+		}
+		if l.OrganizationID != orgID {
+			return nil, ErrLocationNotFound
+		}
+	}
+
+	visits, err := s.checkIns.ListVisitsByOrganization(ctx, orgID, filter.LocationID, filter.From, filter.To)
+	if err != nil {
+		return nil, err
+	}
+	return buildReport(filter.From, filter.To, visits), nil
+}
+
+// buildReport группирует отметки по дням (UTC) и участникам. visits упорядочены по времени.
+func buildReport(from, to time.Time, visits []*domain.Visit) *AttendanceReport {
+	report := &AttendanceReport{
+		From:        from,
+		To:          to,
+		TotalVisits: len(visits),
+		Days:        []DayAttendance{},
+		Members:     []MemberAttendance{},
+	}
+	members := make(map[uuid.UUID]int)
+	for _, v := range visits {
+		y, m, d := v.CheckedInAt.UTC().Date()
+		day := time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+		if n := len(report.Days); n > 0 && report.Days[n-1].Date.Equal(day) {
+			report.Days[n-1].Visits++
+		} else {
+			report.Days = append(report.Days, DayAttendance{Date: day, Visits: 1})
+		}
+
+		i, ok := members[v.UserID]
+		if !ok {
+			i = len(report.Members)
+			members[v.UserID] = i
+			report.Members = append(report.Members, MemberAttendance{UserID: v.UserID})
+		}
+		report.Members[i].Visits++
+		report.Members[i].LastVisitAt = v.CheckedInAt
+	}
+	report.UniqueMembers = len(report.Members)
+
+	sort.SliceStable(report.Members, func(i, j int) bool {
+		return report.Members[i].Visits > report.Members[j].Visits
+	})
+	return report
+}
+
+func validatePeriod(from, to time.Time) error {
+	if !from.Before(to) || to.Sub(from) > maxReportPeriod {
+		return fmt.Errorf("%w: period must be positive and at most %d days", ErrInvalidPeriod, int(maxReportPeriod.Hours()/24))
+	}
+	return nil
+}
+
+func (s *service) getLocation(ctx context.Context, id uuid.UUID) (*domain.Location, error) {
+	l, err := s.checkIns.GetLocation(ctx, id)
+	if err != nil {
+		if errors.Is(err, repo.ErrNotFound) {
+			return nil, ErrLocationNotFound
+		}
+		return nil, err
+	}
+	return l, nil
+}
+
+// requireMember проверяет, что actor состоит в организации (админ — всегда).
+// Не участникам залы организации не видны: возвращается ErrLocationNotFound.
+func (s *service) requireMember(ctx context.Context, actor Actor, orgID uuid.UUID) (*orgdomain.Member, error) {
+	m, err := s.orgs.GetMember(ctx, orgID, actor.UserID)
+	if err != nil {
+		if errors.Is(err, repo.ErrNotFound) {
+			if actor.Role == userdomain.RoleAdmin {
+				return nil, nil
+			}
+			return nil, ErrLocationNotFound
+		}
+		return nil, err
+	}
+	return m, nil
+}
+
+// requireOwner проверяет, что actor — владелец организации или админ.
+func (s *service) requireOwner(ctx context.Context, actor Actor, orgID uuid.UUID) error {
+	if actor.Role == userdomain.RoleAdmin {
+		return nil
+	}
+	m, err := s.requireMember(ctx, actor, orgID)
+	if err != nil {
+		return err
+	}
+	if m.Role != orgdomain.RoleOwner {
+		return ErrForbidden
+	}
+	return nil
+}
@@ -0,0 +1,760 @@
+package gymclass
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+
+	eventdomain "workout-app/internal/domain/event"
+	domain "workout-app/internal/domain/gymclass"
+	orgdomain "workout-app/internal/domain/organization"
+	userdomain "workout-app/internal/domain/user"
+	"workout-app/internal/events"
+	repo "workout-app/internal/repository/interfaces"
+)
+
+// Service описывает usecase-слой групповых занятий организаций (залов):
+// расписание занятий, запись участников с листом ожидания и отметку посещаемости.
+type Service interface {
+	// CreateClass создаёт занятие организации. Доступно владельцу организации и админу.
+	CreateClass(ctx context.Context, actor Actor, orgID uuid.UUID, input ClassInput) (*domain.Class, error)
+
+	// UpdateClass изменяет занятие. При увеличении вместимости участники из листа ожидания
+	// предстоящих занятий получают освободившиеся места.
+	UpdateClass(ctx context.Context, actor Actor, classID uuid.UUID, input ClassInput) (*domain.Class, error)
+
+	// DeleteClass удаляет занятие вместе с расписанием и записями.
+	DeleteClass(ctx context.Context, actor Actor, classID uuid.UUID) error
+
+	// AddSlot добавляет занятию еженедельный слот: день недели (1 — понедельник) и время начала в минутах от полуночи UTC.
+	AddSlot(ctx context.Context, actor Actor, classID uuid.UUID, weekday, startMinute int) (*domain.Slot, error)
+
+	// DeleteSlot удаляет слот занятия.
+	DeleteSlot(ctx context.Context, actor Actor, classID, slotID uuid.UUID) error
+
+	// ListClasses возвращает занятия организации с их слотами. Доступно участникам организации.
+	ListClasses(ctx context.Context, actor Actor, orgID uuid.UUID) ([]ClassWithSlots, error)
+
+	// Schedule возвращает занятия организации с началом в [from, to) с числом записей и записью actor.
+	Schedule(ctx context.Context, actor Actor, orgID uuid.UUID, from, to time.Time) ([]ScheduledClass, error)
+
+	// Book записывает actor на занятие, начинающееся в startsAt. Если мест нет, запись попадает в лист ожидания.
+	Book(ctx context.Context, actor Actor, classID uuid.UUID, startsAt time.Time) (*domain.Booking, error)
+
+	// Cancel отменяет запись до начала занятия. Освободившееся место получает первый из листа ожидания.
+	// Отменить запись может сам участник, владелец организации и админ.
+	Cancel(ctx context.Context, actor Actor, bookingID uuid.UUID) (*domain.Booking, error)
+
+	// Roster возвращает записи на занятие (сначала подтверждённые, затем лист ожидания).
+	// Доступно тренеру занятия, владельцу организации и админу.
+	Roster(ctx context.Context, actor Actor, classID uuid.UUID, startsAt time.Time) ([]*domain.Booking, error)
+
+	// MarkAttendance отмечает, пришёл ли участник на занятие. Доступно после начала занятия
+	// тренеру занятия, владельцу организации и админу.
+	MarkAttendance(ctx context.Context, actor Actor, bookingID uuid.UUID, attended bool) (*domain.Booking, error)
+
+	// ListMyBookings возвращает записи пользователя на занятия с началом в [from, to).
+	ListMyBookings(ctx context.Context, userID uuid.UUID, from, to time.Time) ([]*domain.Booking, error)
+}
+
+// Actor описывает пользователя, от имени которого выполняется операция.
+type Actor struct {
+	UserID uuid.UUID
+	Role   userdomain.Role
+}
+
+// ClassInput описывает изменяемые поля занятия.
+type ClassInput struct {
+	Title           string
+	Description     string
+	CoachID         *uuid.UUID // Должен состоять в организации
+	Capacity        int
+	DurationMinutes int
+}
+
+// ClassWithSlots — занятие с еженедельным расписанием.
+type ClassWithSlots struct {
+	Class *domain.Class
+	Slots []*domain.Slot
+}
+
+// ScheduledClass — конкретное занятие расписания организации.
+type ScheduledClass struct {
+	Class      *domain.Class
+	Occurrence domain.Occurrence
+	Booked     int
+	Waitlisted int
+	MyBooking  *domain.Booking // Активная запись actor на занятие (nil — не записан)
+}
+
+// Ошибки бизнес-логики usecase-слоя.
+var (
+	ErrInvalidClass      = fmt.Errorf("invalid class")
+	ErrInvalidSlot       = fmt.Errorf("invalid class slot")
+	ErrInvalidPeriod     = fmt.Errorf("invalid schedule period")
+	ErrClassNotFound     = fmt.Errorf("class not found")
+	ErrSlotNotFound      = fmt.Errorf("class slot not found")
+	ErrSlotExists        = fmt.Errorf("class slot already exists")
+	ErrCoachNotMember    = fmt.Errorf("coach is not a member of the organization")
+	ErrForbidden         = fmt.Errorf("insufficient organization role")
+	ErrNotScheduled      = fmt.Errorf("class is not scheduled at this time")
+	ErrBookingClosed     = fmt.Errorf("booking is closed for this class")
+	ErrAlreadyBooked     = fmt.Errorf("already booked")
+	ErrWaitlistFull      = fmt.Errorf("waitlist is full")
+	ErrBookingNotFound   = fmt.Errorf("booking not found")
+	ErrCancelNotAllowed  = fmt.Errorf("booking cannot be cancelled")
+	ErrAttendanceTooSoon = fmt.Errorf("attendance can be marked after the class starts")
+	ErrNotBooked         = fmt.Errorf("booking has no confirmed spot")
+)
+
+// Ограничения занятий и записей.
+const (
+	maxTitleLength       = 200
+	maxDescriptionLength = 2000
+	maxCapacity          = 500
+	maxDurationMinutes   = 24 * 60
+	maxSlotsPerClass     = 50
+	maxWaitlistSize      = 50
+	// bookingHorizon — насколько вперёд открыта запись и строится расписание.
+	bookingHorizon = 60 * 24 * time.Hour
+	// maxSchedulePeriod ограничивает период одного запроса расписания.
+	maxSchedulePeriod = 31 * 24 * time.Hour
+)
+
+type service struct {
+	tx      repo.Transactor
+	classes repo.GymClassRepository
+	orgs    repo.OrganizationRepository
+	events  events.Publisher
+}
+
+// NewService создаёт новый сервис групповых занятий.
+// publisher доставляет события записи (class.booked, class.waitlist_promoted) подписчикам уведомлений.
+func NewService(tx repo.Transactor, classes repo.GymClassRepository, orgs repo.OrganizationRepository, publisher events.Publisher) Service {
+	return &service{
+		tx:      tx,
+		classes: classes,
+		orgs:    orgs,
+		events:  publisher,
+	}
+}
+
+// CreateClass создаёт занятие организации.
+func (s *service) CreateClass(ctx context.Context, actor Actor, orgID uuid.UUID, input ClassInput) (*domain.Class, error) {
+	if err := s.requireOwner(ctx, actor, orgID); err != nil {
+		return nil, err
+	}
+	input, err := s.normalizeClassInput(ctx, orgID, input)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	c := &domain.Class{
+		ID:              uuid.New(),
+		OrganizationID:  orgID,
+		Title:           input.Title,
+		Description:     input.Description,
+		CoachID:         input.CoachID,
+		Capacity:        input.Capacity,
+		DurationMinutes: input.DurationMinutes,
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	}
+	if err := s.classes.Create(ctx, c); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// UpdateClass изменяет занятие.
+func (s *service) UpdateClass(ctx context.Context, actor Actor, classID uuid.UUID, input ClassInput) (*domain.Class, error) {
+	var updated *domain.Class
+	err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		c, err := s.getClass(ctx, classID, true)
+		if err != nil {
+			return err
+		}
+		if err := s.requireOwner(ctx, actor, c.OrganizationID); err != nil {
+			return err
+		}
+		input, err := s.normalizeClassInput(ctx, c.OrganizationID, input)
+		if err != nil {
+			return err
+		}
+
+		previousCapacity := c.Capacity
+		c.Title = input.Title
+		c.Description = input.Description
+		c.CoachID = input.CoachID
+		c.Capacity = input.Capacity
+		c.DurationMinutes = input.DurationMinutes
+		c.UpdatedAt = time.Now().UTC()
+		if err := s.classes.Update(ctx, c); err != nil {
+			return err
+		}
+		if c.Capacity > previousCapacity {
+			if err := s.promoteUpcoming(ctx, c); err != nil {
+				return err
+			}
+		}
+		updated = c
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+// DeleteClass удаляет занятие.
+func (s *service) DeleteClass(ctx context.Context, actor Actor, classID uuid.UUID) error {
+	c, err := s.getClass(ctx, classID, false)
+	if err != nil {
+		return err
+	}
+	if err := s.requireOwner(ctx, actor, c.OrganizationID); err != nil {
+		return err
+	}
+	if err := s.classes.Delete(ctx, classID); err != nil {
+		if errors.Is(err, repo.ErrNotFound) {
+			return ErrClassNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+// AddSlot добавляет занятию еженедельный слот.
+func (s *service) AddSlot(ctx context.Context, actor Actor, classID uuid.UUID, weekday, startMinute int) (*domain.Slot, error) {
+	if weekday < 1 || weekday > 7 {
+		return nil, fmt.Errorf("%w: weekday must be between 1 and 7", ErrInvalidSlot)
+	}
+	if startMinute < 0 || startMinute >= 24*60 {
+		return nil, fmt.Errorf("%w: start minute must be between 0 and 1439", ErrInvalidSlot)
+	}
+
+	c, err := s.getClass(ctx, classID, false)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.requireOwner(ctx, actor, c.OrganizationID); err != nil {
+		return nil, err
+	}
+	slots, err := s.classes.ListSlots(ctx, []uuid.UUID{classID})
+	if err != nil {
+		return nil, err
+	}
+	if len(slots) >= maxSlotsPerClass {
+		return nil, fmt.Errorf("%w: class must have at most %d slots", ErrInvalidSlot, maxSlotsPerClass)
+	}
+
+	slot := &domain.Slot{
+		ID:          uuid.New(),
+		ClassID:     classID,
+		Weekday:     weekday,
+		StartMinute: startMinute,
+		CreatedAt:   time.Now().UTC(),
+	}
+	if err := s.classes.AddSlot(ctx, slot); err != nil {
+		if errors.Is(err, repo.ErrSlotExists) {
+			return nil, ErrSlotExists
+		}
+		return nil, err
+	}
+	return slot, nil
+}
+
+// DeleteSlot удаляет слот занятия.
+func (s *service) DeleteSlot(ctx context.Context, actor Actor, classID, slotID uuid.UUID) error {
+	c, err := s.getClass(ctx, classID, false)
+	if err != nil {
+		return err
+	}
+	if err := s.requireOwner(ctx, actor, c.OrganizationID); err != nil {
+		return err
+	}
+	if err := s.classes.DeleteSlot(ctx, classID, slotID); err != nil {
+		if errors.Is(err, repo.ErrNotFound) {
+			return ErrSlotNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+// ListClasses возвращает занятия организации с их слотами.
+func (s *service) ListClasses(ctx context.Context, actor Actor, orgID uuid.UUID) ([]ClassWithSlots, error) {
+	if _, err := s.requireMember(ctx, actor, orgID); err != nil {
+		return nil, err
+	}
+	classes, slots, err := s.classesWithSlots(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	byClass := make(map[uuid.UUID][]*domain.Slot, len(classes))
+	for _, slot := range slots {
+		byClass[slot.ClassID] = append(byClass[slot.ClassID], slot)
+	}
+	result := make([]ClassWithSlots, 0, len(classes))
+	for _, c := range classes {
+		result = append(result, ClassWithSlots{Class: c, Slots: byClass[c.ID]})
+	}
+	return result, nil
+}
+
+// Schedule возвращает занятия организации с началом в [from, to).
+func (s *service) Schedule(ctx context.Context, actor Actor, orgID uuid.UUID, from, to time.Time) ([]ScheduledClass, error) {
+	if !from.Before(to) || to.Sub(from) > maxSchedulePeriod {
+		return nil, fmt.Errorf("%w: period must be positive and at most %d days", ErrInvalidPeriod, int(maxSchedulePeriod.Hours()/24))
+	}
+	if _, err := s.requireMember(ctx, actor, orgID); err != nil {
+		return nil, err
+	}
+	classes, slots, err := s.classesWithSlots(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	if len(classes) == 0 {
+		return []ScheduledClass{}, nil
+	}
+
+	classIDs := make([]uuid.UUID, 0, len(classes))
+	for _, c := range classes {
+		classIDs = append(classIDs, c.ID)
+	}
+	bookings, err := s.classes.ListActiveBookings(ctx, classIDs, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	type occurrenceKey struct {
+		classID  uuid.UUID
+		startsAt int64
+	}
+	counts := make(map[occurrenceKey]*ScheduledClass)
+	var result []ScheduledClass
+	for _, c := range classes {
+		for _, o := range c.Occurrences(slots, from, to) {
+			result = append(result, ScheduledClass{Class: c, Occurrence: o})
+		}
+	}
+	for i := range result {
+		counts[occurrenceKey{result[i].Class.ID, result[i].Occurrence.StartsAt.Unix()}] = &result[i]
+	}
+	for _, b := range bookings {
+		sc, ok := counts[occurrenceKey{b.ClassID, b.StartsAt.Unix()}]
+		if !ok {
+			// Запись на занятие из удалённого слота: в расписании его уже нет.
+			continue
+		}
+		if b.Status == domain.StatusBooked {
+			sc.Booked++
+		} else {
+			sc.Waitlisted++
+		}
+		if b.UserID == actor.UserID {
+			sc.MyBooking = b
+		}
+	}
+
+	sortScheduled(result)
+	return result, nil
+}
+
+// Book записывает actor на занятие.
+func (s *service) Book(ctx context.Context, actor Actor, classID uuid.UUID, startsAt time.Time) (*domain.Booking, error) {
+	startsAt = startsAt.UTC()
+	now := time.Now().UTC()
+	if !startsAt.After(now) || startsAt.After(now.Add(bookingHorizon)) {
+		return nil, ErrBookingClosed
+	}
+
+	var booking *domain.Booking
+	var class *domain.Class
+	err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		// Блокировка занятия сериализует записи: два участника не займут последнее место одновременно.
+		c, err := s.getClass(ctx, classID, true)
+		if err != nil {
+			return err
+		}
+		if _, err := s.requireMember(ctx, actor, c.OrganizationID); err != nil {
+			return err
+		}
+		slots, err := s.classes.ListSlots(ctx, []uuid.UUID{classID})
+		if err != nil {
+			return err
+		}
+		if _, ok := c.Occurrence(slots, startsAt); !ok {
+			return ErrNotScheduled
+		}
+
+		existing, err := s.classes.ListOccurrenceBookings(ctx, classID, startsAt)
+		if err != nil {
+			return err
+		}
+		booked, waitlisted := countByStatus(existing)
+		status := domain.StatusBooked
+		if booked >= c.Capacity {
+			if waitlisted >= maxWaitlistSize {
+				return ErrWaitlistFull
+			}
+			status = domain.StatusWaitlisted
+		}
+
+		b := &domain.Booking{
+			ID:        uuid.New(),
+			ClassID:   classID,
+			UserID:    actor.UserID,
+			StartsAt:  startsAt,
+			Status:    status,
+			CreatedAt: now,
+			UpdatedAt: now,
+		}
+		if err := s.classes.CreateBooking(ctx, b); err != nil {
+			if errors.Is(err, repo.ErrAlreadyBooked) {
+				return ErrAlreadyBooked
+			}
+			return err
+		}
+		booking, class = b, c
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.publish(ctx, eventdomain.TypeClassBooked, class, booking)
+	return booking, nil
+}
+
+// Cancel отменяет запись до начала занятия.
+func (s *service) Cancel(ctx context.Context, actor Actor, bookingID uuid.UUID) (*domain.Booking, error) {
+	var cancelled, promoted *domain.Booking
+	var class *domain.Class
+	err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		b, err := s.getBooking(ctx, bookingID)
+		if err != nil {
+			return err
+		}
+		c, err := s.getClass(ctx, b.ClassID, true)
+		if err != nil {
+			return err
+		}
+		if b.UserID != actor.UserID {
+			if err := s.requireOwner(ctx, actor, c.OrganizationID); err != nil {
+				// Чужие записи для остальных не существуют.
+				return ErrBookingNotFound
+			}
+		}
+
+		now := time.Now().UTC()
+		if !b.IsActive() || !b.StartsAt.After(now) {
+			return ErrCancelNotAllowed
+		}
+		wasBooked := b.Status == domain.StatusBooked
+		b.Status = domain.StatusCancelled
+		b.UpdatedAt = now
+		if err := s.classes.UpdateBooking(ctx, b); err != nil {
+			return err
+		}
+		cancelled, class = b, c
+
+		if wasBooked {
+			promoted, err = s.promoteNext(ctx, c, b.StartsAt)
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if promoted != nil {
+		s.publish(ctx, eventdomain.TypeClassWaitlistPromoted, class, promoted)
+	}
+	return cancelled, nil
+}
+
+// Roster возвращает записи на занятие.
+func (s *service) Roster(ctx context.Context, actor Actor, classID uuid.UUID, startsAt time.Time) ([]*domain.Booking, error) {
+	c, err := s.getClass(ctx, classID, false)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.requireStaff(ctx, actor, c); err != nil {
+		return nil, err
+	}
+
+	bookings, err := s.classes.ListOccurrenceBookings(ctx, classID, startsAt.UTC())
+	if err != nil {
+		return nil, err
+	}
+	// Подтверждённые записи — первыми, внутри статуса сохраняется порядок записи.
+	roster := make([]*domain.Booking, 0, len(bookings))
+	for _, status := range []domain.BookingStatus{domain.StatusBooked, domain.StatusWaitlisted} {
+		for _, b := range bookings {
+			if b.Status == status {
+				roster = append(roster, b)
+			}
+		}
+	}
+	return roster, nil
+}
+
+// MarkAttendance отмечает посещение занятия.
+func (s *service) MarkAttendance(ctx context.Context, actor Actor, bookingID uuid.UUID, attended bool) (*domain.Booking, error) {
+	b, err := s.getBooking(ctx, bookingID)
+	if err != nil {
+		return nil, err
+	}
+	c, err := s.getClass(ctx, b.ClassID, false)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.requireStaff(ctx, actor, c); err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	if b.Status != domain.StatusBooked {
+		return nil, ErrNotBooked
+	}
+	if now.Before(b.StartsAt) {
+		return nil, ErrAttendanceTooSoon
+	}
+
+	b.Attended = &attended
+	b.UpdatedAt = now
+	if err := s.classes.UpdateBooking(ctx, b); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// ListMyBookings возвращает записи пользователя на занятия.
+func (s *service) ListMyBookings(ctx context.Context, userID uuid.UUID, from, to time.Time) ([]*domain.Booking, error) {
+	if !from.Before(to) {
+		return nil, fmt.Errorf("%w: from must be before to", ErrInvalidPeriod)
+	}
+	return s.classes.ListBookingsByUser(ctx, userID, from, to)
+}
+
+// promoteNext отдаёт освободившееся место на занятии первому из листа ожидания.
+// Вызывается под блокировкой занятия.
+func (s *service) promoteNext(ctx context.Context, c *domain.Class, startsAt time.Time) (*domain.Booking, error) {
+	bookings, err := s.classes.ListOccurrenceBookings(ctx, c.ID, startsAt)
+	if err != nil {
+		return nil, err
+	}
+	booked, _ := countByStatus(bookings)
+	if booked >= c.Capacity {
+		return nil, nil
+	}
+	for _, b := range bookings {
+		if b.Status != domain.StatusWaitlisted {
+			continue
+		}
+		b.Status = domain.StatusBooked
+		b.UpdatedAt = time.Now().UTC()
+		if err := s.classes.UpdateBooking(ctx, b); err != nil {
+			return nil, err
+		}
+		return b, nil
+	}
+	return nil, nil
+}
+
+// promoteUpcoming заполняет освободившиеся после увеличения вместимости места на предстоящих занятиях.
+// События о переводе из листа ожидания публикуются внутри транзакции изменения занятия.
+func (s *service) promoteUpcoming(ctx context.Context, c *domain.Class) error {
+	now := time.Now().UTC()
+	bookings, err := s.classes.ListActiveBookings(ctx, []uuid.UUID{c.ID}, now, now.Add(bookingHorizon))
+	if err != nil {
+		return err
+	}
+
+	byOccurrence := make(map[int64][]*domain.Booking)
+	var order []int64
+	for _, b := range bookings {
+		key := b.StartsAt.Unix()
+		if _, ok := byOccurrence[key]; !ok {
+			order = append(order, key)
+		}
+		byOccurrence[key] = append(byOccurrence[key], b)
+	}
+	for _, key := range order {
+		group := byOccurrence[key]
+		booked, _ := countByStatus(group)
+		for _, b := range group {
+			if booked >= c.Capacity {
+				break
+			}
+			if b.Status != domain.StatusWaitlisted {
+				continue
+			}
+			b.Status = domain.StatusBooked
+			b.UpdatedAt = now
+			if err := s.classes.UpdateBooking(ctx, b); err != nil {
+				return err
+			}
+			booked++
+			s.publish(ctx, eventdomain.TypeClassWaitlistPromoted, c, b)
+		}
+	}
+	return nil
+}
+
+// publish публикует событие записи на занятие.
+func (s *service) publish(ctx context.Context, eventType string, c *domain.Class, b *domain.Booking) {
+	s.events.Publish(ctx, eventType, b.ID.String(), eventdomain.ClassBooking{
+		BookingID:      b.ID.String(),
+		ClassID:        c.ID.String(),
+		OrganizationID: c.OrganizationID.String(),
+		UserID:         b.UserID.String(),
+		StartsAt:       b.StartsAt,
+		Status:         string(b.Status),
+	})
+}
+
+// normalizeClassInput проверяет поля занятия и тренера.
+func (s *service) normalizeClassInput(ctx context.Context, orgID uuid.UUID, input ClassInput) (ClassInput, error) {
+	input.Title = strings.TrimSpace(input.Title)
+	input.Description = strings.TrimSpace(input.Description)
+	if input.Title == "" || utf8.RuneCountInString(input.Title) > maxTitleLength {
+		return input, fmt.Errorf("%w: title must be 1-%d characters", ErrInvalidClass, maxTitleLength)
+	}
+	if utf8.RuneCountInString(input.Description) > maxDescriptionLength {
+		return input, fmt.Errorf("%w: description must be at most %d characters", ErrInvalidClass, maxDescriptionLength)
+	}
+	if input.Capacity < 1 || input.Capacity > maxCapacity {
+		return input, fmt.Errorf("%w: capacity must be between 1 and %d", ErrInvalidClass, maxCapacity)
+	}
+	if input.DurationMinutes < 1 || input.DurationMinutes > maxDurationMinutes {
+		return input, fmt.Errorf("%w: duration must be between 1 and %d minutes", ErrInvalidClass, maxDurationMinutes)
+	}
+	if input.CoachID != nil {
+		if _, err := s.orgs.GetMember(ctx, orgID, *input.CoachID); err != nil {
+			if errors.Is(err, repo.ErrNotFound) {
+				return input, ErrCoachNotMember
+			}
+			return input, err
+		}
+	}
+	return input, nil
+}
+
+// classesWithSlots возвращает занятия организации и слоты всех этих занятий.
+func (s *service) classesWithSlots(ctx context.Context, orgID uuid.UUID) ([]*domain.Class, []*domain.Slot, error) {
+	classes, err := s.classes.ListByOrganization(ctx, orgID)
+	if err != nil {
+		return nil, nil, err
+	}
+	ids := make([]uuid.UUID, 0, len(classes))
+	for _, c := range classes {
+		ids = append(ids, c.ID)
+	}
+	slots, err := s.classes.ListSlots(ctx, ids)
+	if err != nil {
+		return nil, nil, err
+	}
+	return classes, slots, nil
+}
+
+// getClass возвращает занятие, при forUpdate — с блокировкой строки.
+func (s *service) getClass(ctx context.Context, id uuid.UUID, forUpdate bool) (*domain.Class, error) {
+	get := s.classes.GetByID
+	if forUpdate {
+		get = s.classes.GetByIDForUpdate
+	}
+	c, err := get(ctx, id)
+	if err != nil {
+		if errors.Is(err, repo.ErrNotFound) {
+			return nil, ErrClassNotFound
+		}
+		return nil, err
+	}
+	return c, nil
+}
+
+// getBooking возвращает запись или ErrBookingNotFound.
+func (s *service) getBooking(ctx context.Context, id uuid.UUID) (*domain.Booking, error) {
+	b, err := s.classes.GetBooking(ctx, id)
+	if err != nil {
+		if errors.Is(err, repo.ErrNotFound) {
+			return nil, ErrBookingNotFound
+		}
+		return nil, err
+	}
+	return b, nil
+}
+
+// requireMember проверяет, что actor состоит в организации (админу — всегда можно).
+// Не участникам занятия организации не видны: возвращается ErrClassNotFound.
+func (s *service) requireMember(ctx context.Context, actor Actor, orgID uuid.UUID) (*orgdomain.Member, error) {
+	m, err := s.orgs.GetMember(ctx, orgID, actor.UserID)
+	if err != nil {
+		if errors.Is(err, repo.ErrNotFound) {
+			if actor.Role == userdomain.RoleAdmin {
+				return nil, nil
+			}
+			return nil, ErrClassNotFound
+		}
+		return nil, err
+	}
+	return m, nil
+}
+
+// requireOwner проверяет, что actor — владелец организации или админ.
+func (s *service) requireOwner(ctx context.Context, actor Actor, orgID uuid.UUID) error {
+	if actor.Role == userdomain.RoleAdmin {
+		return nil
+	}
+	m, err := s.requireMember(ctx, actor, orgID)
+	if err != nil {
+		return err
+	}
+	if m.Role != orgdomain.RoleOwner {
+		return ErrForbidden
+	}
+	return nil
+}
+
+// requireStaff проверяет, что actor — тренер занятия, владелец организации или админ.
+func (s *service) requireStaff(ctx context.Context, actor Actor, c *domain.Class) error {
+	if c.CoachID != nil && *c.CoachID == actor.UserID {
+		return nil
+	}
+	return s.requireOwner(ctx, actor, c.OrganizationID)
+}
+
+// countByStatus считает подтверждённые записи и записи в листе ожидания.
+func countByStatus(bookings []*domain.Booking) (booked, waitlisted int) {
+	for _, b := range bookings {
+		switch b.Status {
+		case domain.StatusBooked:
+			booked++
+		case domain.StatusWaitlisted:
+			waitlisted++
+		}
+	}
+	return booked, waitlisted
+}
+
+// sortScheduled сортирует занятия расписания по времени начала, затем по названию.
+func sortScheduled(items []ScheduledClass) {
+	sort.SliceStable(items, func(i, j int) bool {
+		a, b := items[i].Occurrence.StartsAt, items[j].Occurrence.StartsAt
+		if !a.Equal(b) {
+			return a.Before(b)
+		}
+		return items[i].Class.Title < items[j].Class.Title
+	})
+}
@@ -0,0 +1,227 @@
+package habit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+
+	domain "workout-app/internal/domain/habit"
+	userdomain "workout-app/internal/domain/user"
+	repo "workout-app/internal/repository/interfaces"
+)
+
+// Service описывает usecase-слой ежедневных привычек: вода, сон, пульс покоя и шаги —
+// одна запись на день и выборки за период со средними значениями.
+type Service interface {
+	// Upsert сохраняет привычки дня, заменяя прежнюю запись этого дня.
+	Upsert(ctx context.Context, userID uuid.UUID, date time.Time, input DayInput) (*domain.Day, error)
+
+	// Range возвращает записи дней [from, to] и средние значения за период;
+	// без to — сегодня в часовом поясе пользователя, без from — 30 дней до to.
+	Range(ctx context.Context, userID uuid.UUID, from, to *time.Time) (*Range, error)
+
+	// Delete удаляет запись дня.
+	Delete(ctx context.Context, userID uuid.UUID, date time.Time) error
+}
+
+// DayInput описывает привычки дня; nil — показатель не заполнен.
+type DayInput struct {
+	WaterMl    *int
+	SleepHours *float64
+	RestingHR  *int
+	Steps      *int
+}
+
+// Averages — средние значения показателей по заполненным дням периода; nil — показатель не заполнялся.
+type Averages struct {
+	WaterMl    *float64
+	SleepHours *float64
+	RestingHR  *float64
+	Steps      *float64
+}
+
+// Range — привычки за период.
+type Range struct {
+	From     time.Time
+	To       time.Time
+	Days     []*domain.Day // Только дни с записями, по возрастанию даты
+	Averages Averages
+}
+
+// Ошибки бизнес-логики usecase-слоя.
+var (
+	ErrInvalidWater     = fmt.Errorf("water intake is out of range")
+	ErrInvalidSleep     = fmt.Errorf("sleep hours are out of range")
+	ErrInvalidRestingHR = fmt.Errorf("resting heart rate is out of range")
+	ErrInvalidSteps     = fmt.Errorf("steps are out of range")
+	ErrEmptyDay         = fmt.Errorf("habit day has no values")
+	ErrInvalidDate      = fmt.Errorf("habit date is in the future")
+	ErrInvalidPeriod    = fmt.Errorf("invalid period")
+	ErrDayNotFound      = fmt.Errorf("habit day not found")
+)
+
+const (
+	// defaultRangeDays — длина периода по умолчанию.
+	defaultRangeDays = 30
+	// maxRangeDays — наибольшее число дней в выборке.
+	maxRangeDays = 366
+)
+
+type service struct {
+	days  repo.HabitRepository
+	users repo.UserRepository
+	now   func() time.Time
+}
+
+// NewService создаёт новый сервис ежедневных привычек.
+// users нужен для часового пояса пользователя.
+func NewService(days repo.HabitRepository, users repo.UserRepository) Service {
+	return &service{
+		days:  days,
+		users: users,
+		now:   func() time.Time { return time.Now().UTC() },
+	}
+}
+
+// Upsert сохраняет привычки дня.
+func (s *service) Upsert(ctx context.Context, userID uuid.UUID, date time.Time, input DayInput) (*domain.Day, error) {
+	if input.WaterMl != nil && (*input.WaterMl < 0 || *input.WaterMl > domain.MaxWaterMl) {
+		return nil, ErrInvalidWater
+	}
+	if input.SleepHours != nil && (*input.SleepHours < 0 || *input.SleepHours > domain.MaxSleepHours) {
+		return nil, ErrInvalidSleep
+	}
+	if input.RestingHR != nil && (*input.RestingHR < domain.MinRestingHR || *input.RestingHR > domain.MaxRestingHR) {
+		return nil, ErrInvalidRestingHR
+	}
+	if input.Steps != nil && (*input.Steps < 0 || *input.Steps > domain.MaxSteps) {
+		return nil, ErrInvalidSteps
+	}
+
+	user, err := s.users.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	day := dateOf(date)
+	if day.After(s.today(user)) {
+		return nil, ErrInvalidDate
+	}
+
+	d := &domain.Day{
+		UserID:    userID,
+		Date:      day,
+		WaterMl:   input.WaterMl,
+		RestingHR: input.RestingHR,
+		Steps:     input.Steps,
+		UpdatedAt: s.now(),
+	}
+	if input.SleepHours != nil {
+		// Сон хранится с точностью до десятых часа.
+		hours := math.Round(*input.SleepHours*10) / 10
+		d.SleepHours = &hours
+	}
+	if d.IsEmpty() {
+		return nil, ErrEmptyDay
+	}
+	if err := s.days.Upsert(ctx, d); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// Range возвращает привычки за период.
+func (s *service) Range(ctx context.Context, userID uuid.UUID, from, to *time.Time) (*Range, error) {
+	user, err := s.users.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	end := s.today(user)
+	if to != nil {
+		end = dateOf(*to)
+	}
+	start := end.AddDate(0, 0, -(defaultRangeDays - 1))
+	if from != nil {
+		start = dateOf(*from)
+	}
+	if start.After(end) || start.AddDate(0, 0, maxRangeDays-1).Before(end) {
+		return nil, ErrInvalidPeriod
+	}
+
+	days, err := s.days.ListByPeriod(ctx, userID, start, end)
+	if err != nil {
+		return nil, err
+	}
+	return &Range{From: start, To: end, Days: days, Averages: averages(days)}, nil
+}
+
+// Delete удаляет запись дня.
+func (s *service) Delete(ctx context.Context, userID uuid.UUID, date time.Time) error {
+	if err := s.days.Delete(ctx, userID, dateOf(date)); err != nil {
+		if errors.Is(err, repo.ErrNotFound) {
+			return ErrDayNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+// today возвращает текущий день в часовом поясе пользователя.
+func (s *service) today(user *userdomain.User) time.Time {
+	return dateOf(s.now().In(user.Location()))
+}
+
+// averages считает средние показателей по дням, где они заполнены:
+// вода и шаги — до целых, сон и пульс — до десятых.
+func averages(days []*domain.Day) Averages {
+	var water, sleep, hr, steps mean
+	for _, d := range days {
+		if d.WaterMl != nil {
+			water.add(float64(*d.WaterMl))
+		}
+		if d.SleepHours != nil {
+			sleep.add(*d.SleepHours)
+		}
+		if d.RestingHR != nil {
+			hr.add(float64(*d.RestingHR))
+		}
+		if d.Steps != nil {
+			steps.add(float64(*d.Steps))
+		}
+	}
+	return Averages{
+		WaterMl:    water.value(1),
+		SleepHours: sleep.value(10),
+		RestingHR:  hr.value(10),
+		Steps:      steps.value(1),
+	}
+}
+
+// mean накапливает среднее значение.
+type mean struct {
+	sum float64
+	n   int
+}
+
+func (m *mean) add(v float64) {
+	m.sum += v
+	m.n++
+}
+
+// value возвращает среднее, округлённое до 1/scale, или nil без значений.
+func (m *mean) value(scale float64) *float64 {
+	if m.n == 0 {
+		return nil
+	}
+	v := math.Round(m.sum/float64(m.n)*scale) / scale
+	return &v
+}
+
+// dateOf приводит момент времени к дню (полночь UTC той же календарной даты).
+func dateOf(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
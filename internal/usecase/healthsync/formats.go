@@ -0,0 +1,299 @@
+package healthsync
+
+import (
+	"bytes"
+	"encoding/json"
+	"strconv"
+	"strings"
+	"time"
+
+	domain "workout-app/internal/domain/healthsync"
+)
+
+// Форматы выгрузок. Неизвестные поля игнорируются, записи неподдерживаемых типов
+// пропускаются: выгрузки содержат больше данных, чем мы храним.
+
+// appleExport — выгрузка HealthKit: тренировки (HKWorkout) и количественные замеры (HKQuantitySample).
+type appleExport struct {
+	Workouts []appleWorkout `json:"workouts"`
+	Samples  []appleSample  `json:"samples"`
+}
+
+type appleWorkout struct {
+	UUID         string `json:"uuid"`
+	ActivityType string `json:"workoutActivityType"`
+	StartDate    string `json:"startDate"`
+	EndDate      string `json:"endDate"`
+}
+
+type appleSample struct {
+	UUID      string   `json:"uuid"`
+	Type      string   `json:"type"`
+	StartDate string   `json:"startDate"`
+	EndDate   string   `json:"endDate"`
+	Value     *float64 `json:"value"`
+	Unit      string   `json:"unit"`
+}
+
+// googleFitExport — ответы Google Fit REST API: users.sessions.list и точки users.dataSources.datasets.get.
+type googleFitExport struct {
+	Sessions []googleSession `json:"session"`
+	Points   []googlePoint   `json:"point"`
+}
+
+type googleSession struct {
+	ID              string    `json:"id"`
+	Name            string    `json:"name"`
+	ActivityType    int       `json:"activityType"`
+	StartTimeMillis flexInt64 `json:"startTimeMillis"`
+	EndTimeMillis   flexInt64 `json:"endTimeMillis"`
+}
+
+type googlePoint struct {
+	DataTypeName       string        `json:"dataTypeName"`
+	StartTimeNanos     flexInt64     `json:"startTimeNanos"`
+	EndTimeNanos       flexInt64     `json:"endTimeNanos"`
+	OriginDataSourceID string        `json:"originDataSourceId"`
+	Value              []googleValue `json:"value"`
+}
+
+type googleValue struct {
+	IntVal *int64   `json:"intVal"`
+	FpVal  *float64 `json:"fpVal"`
+}
+
+// flexInt64 принимает int64 и числом, и строкой: Google Fit REST API передаёт int64 строками.
+type flexInt64 int64
+
+func (v *flexInt64) UnmarshalJSON(data []byte) error {
+	data = bytes.Trim(data, `"`)
+	if len(data) == 0 || string(data) == "null" {
+		*v = 0
+		return nil
+	}
+	n, err := strconv.ParseInt(string(data), 10, 64)
+	if err != nil {
+		return err
+	}
+	*v = flexInt64(n)
+	return nil
+}
+
+// rawItem — разобранная запись выгрузки; непустой err — причина, по которой запись некорректна.
+type rawItem struct {
+	domain.Item
+	err string
+}
+
+// Типы замеров HealthKit.
+const (
+	appleStepCount        = "HKQuantityTypeIdentifierStepCount"
+	appleHeartRate        = "HKQuantityTypeIdentifierHeartRate"
+	appleRestingHeartRate = "HKQuantityTypeIdentifierRestingHeartRate"
+	appleBodyMass         = "HKQuantityTypeIdentifierBodyMass"
+)
+
+// Типы данных Google Fit.
+const (
+	googleStepCount = "com.google.step_count.delta"
+	googleHeartRate = "com.google.heart_rate.bpm"
+	googleWeight    = "com.google.weight"
+)
+
+// poundKg — килограммов в фунте (HealthKit может отдавать вес в фунтах).
+const poundKg = 0.45359237
+
+// defaultWorkoutTitle — название тренировки неизвестного вида.
+const defaultWorkoutTitle = "Тренировка"
+
+// appleActivityTitles — названия распространённых видов тренировок HealthKit.
+var appleActivityTitles = map[string]string{
+	"HKWorkoutActivityTypeTraditionalStrengthTraining":   "Силовая тренировка",
+	"HKWorkoutActivityTypeFunctionalStrengthTraining":    "Функциональная тренировка",
+	"HKWorkoutActivityTypeHighIntensityIntervalTraining": "Интервальная тренировка",
+	"HKWorkoutActivityTypeCrossTraining":                 "Кросс-тренинг",
+	"HKWorkoutActivityTypeRunning":                       "Бег",
+	"HKWorkoutActivityTypeWalking":                       "Ходьба",
+	"HKWorkoutActivityTypeCycling":                       "Велосипед",
+	"HKWorkoutActivityTypeSwimming":                      "Плавание",
+	"HKWorkoutActivityTypeRowing":                        "Гребля",
+	"HKWorkoutActivityTypeYoga":                          "Йога",
+}
+
+// googleActivityTitles — названия распространённых видов активности Google Fit.
+var googleActivityTitles = map[int]string{
+	1:   "Велосипед",
+	7:   "Ходьба",
+	8:   "Бег",
+	80:  "Силовая тренировка",
+	82:  "Плавание",
+	100: "Йога",
+	103: "Гребля",
+	113: "Кросс-тренинг",
+	114: "Интервальная тренировка",
+}
+
+// parseAppleHealth приводит выгрузку HealthKit к общему виду.
+// Возвращает записи и число пропущенных записей неподдерживаемых типов.
+func parseAppleHealth(dec *json.Decoder) ([]rawItem, int, error) {
+	var export appleExport
+	if err := dec.Decode(&export); err != nil {
+		return nil, 0, err
+	}
+
+	items := make([]rawItem, 0, len(export.Workouts)+len(export.Samples))
+	for _, w := range export.Workouts {
+		title, ok := appleActivityTitles[w.ActivityType]
+		if !ok {
+			title = defaultWorkoutTitle
+		}
+		item := rawItem{Item: domain.Item{ExternalID: w.UUID, Kind: domain.KindWorkout, Title: title}}
+		item.setAppleTimes(w.StartDate, w.EndDate)
+		items = append(items, item)
+	}
+
+	skipped := 0
+	for _, sample := range export.Samples {
+		item := rawItem{Item: domain.Item{ExternalID: sample.UUID}}
+		switch sample.Type {
+		case appleStepCount:
+			item.Kind = domain.KindSteps
+		case appleHeartRate, appleRestingHeartRate:
+			item.Kind = domain.KindHeartRate
+		case appleBodyMass:
+			item.Kind = domain.KindWeight
+		default:
+			skipped++
+			continue
+		}
+		item.setAppleTimes(sample.StartDate, sample.EndDate)
+		if sample.Value == nil {
+			item.err = "value is required"
+		} else {
+			item.Value = *sample.Value
+		}
+		if item.Kind == domain.KindWeight {
+			switch strings.ToLower(sample.Unit) {
+			case "", "kg":
+			case "lb":
+				item.Value *= poundKg
+			default:
+				item.err = "unit must be kg or lb"
+			}
+		}
+		items = append(items, item)
+	}
+	return items, skipped, nil
+}
+
+// parseGoogleFit приводит ответы Google Fit к общему виду.
+// Возвращает записи и число пропущенных записей неподдерживаемых типов.
+func parseGoogleFit(dec *json.Decoder) ([]rawItem, int, error) {
+	var export googleFitExport
+	if err := dec.Decode(&export); err != nil {
+		return nil, 0, err
+	}
+
+	items := make([]rawItem, 0, len(export.Sessions)+len(export.Points))
+	for _, s := range export.Sessions {
+		title := strings.TrimSpace(s.Name)
+		if title == "" {
+			title = googleActivityTitles[s.ActivityType]
+		}
+		if title == "" {
+			title = defaultWorkoutTitle
+		}
+		items = append(items, rawItem{
+			Item: domain.Item{
+				ExternalID: s.ID,
+				Kind:       domain.KindWorkout,
+				Start:      googleMillis(int64(s.StartTimeMillis)),
+				End:        googleMillis(int64(s.EndTimeMillis)),
+				Title:      title,
+			},
+		})
+	}
+
+	skipped := 0
+	for _, p := range export.Points {
+		item := rawItem{
+			Item: domain.Item{
+				// У точек нет собственного ID: точку однозначно задают тип, интервал и источник данных.
+				ExternalID: strings.Join([]string{
+					p.DataTypeName,
+					strconv.FormatInt(int64(p.StartTimeNanos), 10),
+					strconv.FormatInt(int64(p.EndTimeNanos), 10),
+					p.OriginDataSourceID,
+				}, ":"),
+				Start: googleNanos(int64(p.StartTimeNanos)),
+				End:   googleNanos(int64(p.EndTimeNanos)),
+			},
+		}
+		switch p.DataTypeName {
+		case googleStepCount:
+			item.Kind = domain.KindSteps
+		case googleHeartRate:
+			item.Kind = domain.KindHeartRate
+		case googleWeight:
+			item.Kind = domain.KindWeight
+		default:
+			skipped++
+			continue
+		}
+		switch {
+		case len(p.Value) == 0:
+			item.err = "value is required"
+		case p.Value[0].IntVal != nil:
+			item.Value = float64(*p.Value[0].IntVal)
+		case p.Value[0].FpVal != nil:
+			item.Value = *p.Value[0].FpVal
+		default:
+			item.err = "value must contain intVal or fpVal"
+		}
+		items = append(items, item)
+	}
+	return items, skipped, nil
+}
+
+// googleMillis переводит метку времени Google Fit в миллисекундах во время; 0 — не задано.
+func googleMillis(v int64) time.Time {
+	if v <= 0 {
+		return time.Time{}
+	}
+	return time.UnixMilli(v).UTC()
+}
+
+// googleNanos переводит метку времени Google Fit в наносекундах во время; 0 — не задано.
+func googleNanos(v int64) time.Time {
+	if v <= 0 {
+		return time.Time{}
+	}
+	return time.Unix(0, v).UTC()
+}
+
+// appleTimeLayouts — форматы дат выгрузок HealthKit: RFC 3339 и формат экспорта приложения «Здоровье».
+var appleTimeLayouts = []string{time.RFC3339Nano, "2006-01-02 15:04:05 -0700"}
+
+// setAppleTimes разбирает начало и конец записи HealthKit; пустая строка — не задано.
+func (it *rawItem) setAppleTimes(start, end string) {
+	var ok bool
+	if it.Start, ok = parseAppleTime(start); !ok {
+		it.err = "startDate has invalid format"
+	}
+	if it.End, ok = parseAppleTime(end); !ok {
+		it.err = "endDate has invalid format"
+	}
+}
+
+func parseAppleTime(raw string) (time.Time, bool) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return time.Time{}, true
+	}
+	for _, layout := range appleTimeLayouts {
+		if t, err := time.Parse(layout, raw); err == nil {
+			return t.UTC(), true
+		}
+	}
+	return time.Time{}, false
+}
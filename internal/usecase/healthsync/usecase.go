@@ -0,0 +1,334 @@
+package healthsync
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+
+	habitdomain "workout-app/internal/domain/habit"
+	domain "workout-app/internal/domain/healthsync"
+	userdomain "workout-app/internal/domain/user"
+	workoutdomain "workout-app/internal/domain/workout"
+	repo "workout-app/internal/repository/interfaces"
+)
+
+// Service описывает usecase-слой импорта данных Apple Health и Google Fit: тренировки становятся
+// завершёнными тренировками, вес — замерами, шаги и пульс — привычками дня. Уже импортированные
+// записи пропускаются по внешнему ID, поэтому клиент может присылать пересекающиеся выгрузки.
+type Service interface {
+	// Sync импортирует выгрузку источника и возвращает итог по записям.
+	Sync(ctx context.Context, userID uuid.UUID, provider domain.Provider, r io.Reader) (*Result, error)
+
+	// Connections возвращает состояние синхронизации с источниками.
+	Connections(ctx context.Context, userID uuid.UUID) ([]*domain.Connection, error)
+}
+
+// Config описывает параметры импорта.
+type Config struct {
+	MaxItems       int           // Максимум записей в одной выгрузке
+	AutoPauseAfter time.Duration // Порог автопаузы импортированных тренировок
+}
+
+// Result — итог синхронизации.
+type Result struct {
+	Imported   map[domain.Kind]int // Импортировано записей по типам
+	Duplicates int                 // Уже импортированы раньше или повторяются в выгрузке
+	Skipped    int                 // Неподдерживаемые типы данных
+	Invalid    int                 // Не прошли проверку
+	Errors     []ItemError         // Причины отклонения первых некорректных записей
+	Connection *domain.Connection
+}
+
+// ItemError — причина отклонения записи выгрузки.
+type ItemError struct {
+	ExternalID string
+	Error      string
+}
+
+// Ошибки бизнес-логики usecase-слоя.
+var (
+	ErrInvalidProvider = fmt.Errorf("provider must be apple_health or google_fit")
+	ErrInvalidPayload  = fmt.Errorf("malformed health data export")
+	ErrEmptyPayload    = fmt.Errorf("health data export contains no records")
+	ErrTooManyItems    = fmt.Errorf("health data export contains too many records")
+)
+
+// Ограничения записей повторяют проверки ручного ввода.
+const (
+	maxExternalIDLength = 255
+	maxTitleLength      = 200
+	maxWorkoutDuration  = 24 * time.Hour
+	maxStepsPerItem     = habitdomain.MaxSteps
+	maxBodyWeightKg     = 500
+	allowedClockSkew    = 5 * time.Minute
+	// maxItemErrors — сколько причин отклонения возвращается в ответе.
+	maxItemErrors = 20
+)
+
+type service struct {
+	links    repo.HealthSyncRepository
+	workouts repo.WorkoutSessionRepository
+	metrics  repo.BodyMetricRepository
+	habits   repo.HabitRepository
+	users    repo.UserRepository
+	tx       repo.Transactor
+	cfg      Config
+	now      func() time.Time
+}
+
+// NewService создаёт новый сервис импорта из Apple Health и Google Fit.
+// users нужен для часового пояса пользователя: шаги и пульс относятся к его календарным дням.
+func NewService(
+	links repo.HealthSyncRepository,
+	workouts repo.WorkoutSessionRepository,
+	metrics repo.BodyMetricRepository,
+	habits repo.HabitRepository,
+	users repo.UserRepository,
+	tx repo.Transactor,
+	cfg Config,
+) Service {
+	return &service{
+		links:    links,
+		workouts: workouts,
+		metrics:  metrics,
+		habits:   habits,
+		users:    users,
+		tx:       tx,
+		cfg:      cfg,
+		now:      func() time.Time { return time.Now().UTC() },
+	}
+}
+
+// Sync импортирует выгрузку источника.
+func (s *service) Sync(ctx context.Context, userID uuid.UUID, provider domain.Provider, r io.Reader) (*Result, error) {
+	if !provider.IsValid() {
+		return nil, ErrInvalidProvider
+	}
+	items, skipped, err := s.parse(provider, r)
+	if err != nil {
+		return nil, err
+	}
+	if len(items)+skipped == 0 {
+		return nil, ErrEmptyPayload
+	}
+	if len(items) > s.cfg.MaxItems {
+		return nil, ErrTooManyItems
+	}
+	user, err := s.users.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.now()
+	result := &Result{Imported: map[domain.Kind]int{}, Skipped: skipped}
+	valid := make([]domain.Item, 0, len(items))
+	for _, item := range items {
+		if reason := s.check(&item, now); reason != "" {
+			result.Invalid++
+			if len(result.Errors) < maxItemErrors {
+				result.Errors = append(result.Errors, ItemError{ExternalID: item.ExternalID, Error: reason})
+			}
+			continue
+		}
+		valid = append(valid, item.Item)
+	}
+
+	err = s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		conn, err := s.links.LockConnection(ctx, &domain.Connection{UserID: userID, Provider: provider, CreatedAt: now})
+		if err != nil {
+			return err
+		}
+		if err := s.importItems(ctx, user, provider, valid, conn, result); err != nil {
+			return err
+		}
+		conn.LastSyncedAt = &now
+		if err := s.links.UpdateConnection(ctx, conn); err != nil {
+			return err
+		}
+		result.Connection = conn
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// Connections возвращает состояние синхронизации с источниками.
+func (s *service) Connections(ctx context.Context, userID uuid.UUID) ([]*domain.Connection, error) {
+	return s.links.ListConnections(ctx, userID)
+}
+
+// parse разбирает выгрузку в формате источника.
+func (s *service) parse(provider domain.Provider, r io.Reader) ([]rawItem, int, error) {
+	dec := json.NewDecoder(r)
+	var (
+		items   []rawItem
+		skipped int
+		err     error
+	)
+	switch provider {
+	case domain.ProviderAppleHealth:
+		items, skipped, err = parseAppleHealth(dec)
+	case domain.ProviderGoogleFit:
+		items, skipped, err = parseGoogleFit(dec)
+	}
+	if err != nil {
+		// Исходная ошибка сохраняется: превышение размера тела отличается от неверного формата.
+		return nil, 0, fmt.Errorf("%w: %w", ErrInvalidPayload, err)
+	}
+	return items, skipped, nil
+}
+
+// check проверяет запись и возвращает причину отклонения; пустая строка — запись корректна.
+// Момент замера без конца интервала считается мгновенным.
+func (s *service) check(item *rawItem, now time.Time) string {
+	if item.err != "" {
+		return item.err
+	}
+	item.ExternalID = strings.TrimSpace(item.ExternalID)
+	if item.ExternalID == "" || utf8.RuneCountInString(item.ExternalID) > maxExternalIDLength {
+		return fmt.Sprintf("id must be 1-%d characters", maxExternalIDLength)
+	}
+	if item.Start.IsZero() {
+		return "start time is required"
+	}
+	if item.End.IsZero() {
+		if item.Kind == domain.KindWorkout {
+			return "end time is required"
+		}
+		item.End = item.Start
+	}
+	if item.End.Before(item.Start) {
+		return "end time must not be before start time"
+	}
+	if item.End.After(now.Add(allowedClockSkew)) {
+		return "record is in the future"
+	}
+
+	switch item.Kind {
+	case domain.KindWorkout:
+		if item.End.Sub(item.Start) > maxWorkoutDuration {
+			return fmt.Sprintf("workout must be at most %s long", maxWorkoutDuration)
+		}
+		if utf8.RuneCountInString(item.Title) > maxTitleLength {
+			item.Title = string([]rune(item.Title)[:maxTitleLength])
+		}
+	case domain.KindSteps:
+		if item.Value < 0 || item.Value > maxStepsPerItem {
+			return fmt.Sprintf("steps must be 0-%d", maxStepsPerItem)
+		}
+	case domain.KindHeartRate:
+		if item.Value < habitdomain.MinRestingHR || item.Value > habitdomain.MaxRestingHR {
+			return fmt.Sprintf("heart rate must be %d-%d bpm", habitdomain.MinRestingHR, habitdomain.MaxRestingHR)
+		}
+	case domain.KindWeight:
+		if item.Value <= 0 || item.Value > maxBodyWeightKg {
+			return fmt.Sprintf("weight must be greater than 0 and at most %d kg", maxBodyWeightKg)
+		}
+	}
+	return ""
+}
+
+// importItems сохраняет новые записи и связи с ними, пропуская уже импортированные.
+// Шаги суммируются, а пульс сводится к минимуму по календарным дням пользователя.
+func (s *service) importItems(ctx context.Context, user *userdomain.User, provider domain.Provider, items []domain.Item, conn *domain.Connection, result *Result) error {
+	ids := make([]string, 0, len(items))
+	for _, item := range items {
+		ids = append(ids, item.ExternalID)
+	}
+	linked, err := s.links.LinkedIDs(ctx, user.ID, provider, ids)
+	if err != nil {
+		return err
+	}
+	seen := make(map[string]bool, len(items))
+	for _, id := range linked {
+		seen[id] = true
+	}
+
+	now := s.now()
+	loc := user.Location()
+	steps := map[time.Time]float64{}
+	restingHR := map[time.Time]float64{}
+	var links []*domain.Link
+	for _, item := range items {
+		if conn.SyncedUntil == nil || item.End.After(*conn.SyncedUntil) {
+			end := item.End
+			conn.SyncedUntil = &end
+		}
+		if seen[item.ExternalID] {
+			result.Duplicates++
+			continue
+		}
+		seen[item.ExternalID] = true
+
+		link := &domain.Link{UserID: user.ID, Provider: provider, ExternalID: item.ExternalID, Kind: item.Kind, CreatedAt: now}
+		day := dateOf(item.Start.In(loc))
+		switch item.Kind {
+		case domain.KindWorkout:
+			session := workoutdomain.NewSession(user.ID, item.Title, nil, s.cfg.AutoPauseAfter, item.Start)
+			session.FinishedAt = &item.End
+			if err := s.workouts.Create(ctx, session); err != nil {
+				return err
+			}
+			link.EntityID = &session.ID
+		case domain.KindWeight:
+			m := userdomain.NewBodyMetric(user.ID, item.End)
+			weight := math.Round(item.Value*10) / 10
+			m.WeightKg = &weight
+			if err := s.metrics.Create(ctx, m); err != nil {
+				return err
+			}
+			link.EntityID = &m.ID
+		case domain.KindSteps:
+			steps[day] += item.Value
+		case domain.KindHeartRate:
+			if bpm, ok := restingHR[day]; !ok || item.Value < bpm {
+				restingHR[day] = item.Value
+			}
+		}
+		links = append(links, link)
+		result.Imported[item.Kind]++
+	}
+
+	// Дни обновляются по порядку, чтобы параллельные транзакции блокировали строки в одном порядке.
+	for _, day := range sortedDays(steps) {
+		if err := s.habits.AddSteps(ctx, user.ID, day, int(math.Round(steps[day])), now); err != nil {
+			return err
+		}
+	}
+	for _, day := range sortedDays(restingHR) {
+		if err := s.habits.LowerRestingHR(ctx, user.ID, day, int(math.Round(restingHR[day])), now); err != nil {
+			return err
+		}
+	}
+	if err := s.links.CreateLinks(ctx, links); err != nil {
+		return err
+	}
+	conn.Items += len(links)
+	return nil
+}
+
+// sortedDays возвращает дни по возрастанию.
+func sortedDays(values map[time.Time]float64) []time.Time {
+	days := make([]time.Time, 0, len(values))
+	for day := range values {
+		days = append(days, day)
+	}
+	slices.SortFunc(days, func(a, b time.Time) int { return a.Compare(b) })
+	return days
+}
+
+// dateOf приводит момент времени к дню (полночь UTC той же календарной даты).
+func dateOf(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
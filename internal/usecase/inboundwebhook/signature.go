@@ -0,0 +1,101 @@
+package inboundwebhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Verifier проверяет подпись запроса провайдера по сырому телу.
+type Verifier interface {
+	// Verify возвращает ErrInvalidSignature, если подпись отсутствует или не сходится,
+	// и момент подписи (нулевой, если провайдер не подписывает время отправки).
+	Verify(header http.Header, body []byte) (time.Time, error)
+}
+
+// TimestampedHMAC проверяет подпись вида t=<unix-время>,v1=<hex(HMAC-SHA256(ключ, "<t>.<тело>"))>
+// (Stripe; так же подписываются исходящие webhooks сервиса). Подписанное время позволяет отклонить
+// повтор старого запроса. Подписей v1 может быть несколько, а ключей — два на время их смены:
+// запрос принимается, если подходит любая пара. Пустые ключи не используются.
+type TimestampedHMAC struct {
+	Header  string   // Заголовок подписи, например Stripe-Signature
+	Secrets []string // Действующие ключи подписи
+}
+
+// Verify проверяет подпись и возвращает подписанное время.
+func (v TimestampedHMAC) Verify(header http.Header, body []byte) (time.Time, error) {
+	var timestamp string
+	var signatures []string
+	for _, part := range strings.Split(header.Get(v.Header), ",") {
+		key, value, ok := strings.Cut(strings.TrimSpace(part), "=")
+		if !ok {
+			continue
+		}
+		switch key {
+		case "t":
+			timestamp = value
+		case "v1":
+			signatures = append(signatures, value)
+		}
+	}
+	unix, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil || len(signatures) == 0 {
+		return time.Time{}, ErrInvalidSignature
+	}
+	for _, secret := range v.Secrets {
+		if secret == "" {
+			continue
+		}
+		expected := hmacSHA256(secret, []byte(timestamp), []byte("."), body)
+		for _, signature := range signatures {
+			if equalHex(signature, expected) {
+				return time.Unix(unix, 0).UTC(), nil
+			}
+		}
+	}
+	return time.Time{}, ErrInvalidSignature
+}
+
+// BodyHMAC проверяет подпись hex(HMAC-SHA256(ключ, тело)) в заголовке с необязательным префиксом
+// (например, X-Hub-Signature-256: sha256=<hex>). Время отправки не подписано: повтор запроса
+// отсекается только по ID события.
+type BodyHMAC struct {
+	Header  string   // Заголовок подписи
+	Prefix  string   // Префикс значения перед hex-подписью (пусто — без префикса)
+	Secrets []string // Действующие ключи подписи
+}
+
+// Verify проверяет подпись; момент подписи всегда нулевой.
+func (v BodyHMAC) Verify(header http.Header, body []byte) (time.Time, error) {
+	signature, ok := strings.CutPrefix(header.Get(v.Header), v.Prefix)
+	if !ok || signature == "" {
+		return time.Time{}, ErrInvalidSignature
+	}
+	for _, secret := range v.Secrets {
+		if secret != "" && equalHex(signature, hmacSHA256(secret, body)) {
+			return time.Time{}, nil
+		}
+	}
+	return time.Time{}, ErrInvalidSignature
+}
+
+func hmacSHA256(secret string, parts ...[]byte) []byte {
+	mac := hmac.New(sha256.New, []byte(secret))
+	for _, p := range parts {
+		mac.Write(p)
+	}
+	return mac.Sum(nil)
+}
+
+// equalHex сравнивает hex-подпись с ожидаемой за постоянное время.
+func equalHex(signature string, expected []byte) bool {
+	raw, err := hex.DecodeString(signature)
+	if err != nil {
+		return false
+	}
+	return hmac.Equal(raw, expected)
+}
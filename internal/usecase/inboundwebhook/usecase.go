@@ -0,0 +1,243 @@
+package inboundwebhook
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"workout-app/internal/domain/emailoutbox"
+	domain "workout-app/internal/domain/inboundwebhook"
+	repo "workout-app/internal/repository/interfaces"
+	"workout-app/internal/worker"
+	"workout-app/pkg/logger"
+	"workout-app/pkg/tracing"
+)
+
+// Service описывает usecase-слой входящих webhooks внешних провайдеров: проверку подписи и защиту
+// от повтора при получении, идемпотентное сохранение событий и их фоновую обработку с повторными попытками.
+// Эндпоинт приёма и фоновая задача подключаются в сервере вместе с первым провайдером.
+type Service interface {
+	// Receive проверяет подпись запроса провайдера и сохраняет событие для фоновой обработки.
+	// Возвращает false, если событие с тем же ID уже было получено (повторная доставка).
+	Receive(ctx context.Context, provider string, header http.Header, body []byte) (bool, error)
+
+	// Run обрабатывает события, время попытки которых наступило. Вызывается фоновым воркером.
+	Run(ctx context.Context) error
+
+	// Pending возвращает количество событий в очереди.
+	Pending(ctx context.Context) (int64, error)
+
+	// DeleteExpired удаляет не более limit обработанных и dead-letter событий, завершённых раньше
+	// before минус срок хранения (реализует cleanup.Target).
+	DeleteExpired(ctx context.Context, before time.Time, limit int) (int64, error)
+}
+
+// Provider описывает подключённого провайдера: как проверить подпись его запросов,
+// как извлечь ID и тип события и как событие обработать.
+type Provider struct {
+	Verifier Verifier
+	// Parse извлекает ID и тип события из запроса (nil — поля id и type JSON-тела, как у Stripe).
+	Parse func(header http.Header, body []byte) (Envelope, error)
+	// Process обрабатывает сохранённое событие в фоне. Ошибка означает повторную попытку с задержкой,
+	// поэтому обработка должна быть идемпотентной.
+	Process func(ctx context.Context, e *domain.Event) error
+}
+
+// Envelope — ID и тип события провайдера.
+type Envelope struct {
+	ID   string `json:"id"`
+	Type string `json:"type"`
+}
+
+// Config описывает параметры приёма и обработки событий.
+type Config struct {
+	BatchSize   int           // Событий за один запуск воркера
+	MaxAttempts int           // Попыток до перевода события в dead letter
+	RetryBase   time.Duration // Задержка после первой неудачной попытки; далее удваивается
+	RetryMax    time.Duration // Максимальная задержка между попытками
+	// Tolerance — допустимое расхождение подписанного времени запроса с часами сервера;
+	// запросы старше отклоняются как повтор.
+	Tolerance time.Duration
+	// Retention — срок хранения обработанных событий; в течение него повтор события отсекается по ID.
+	Retention time.Duration
+}
+
+// Ошибки бизнес-логики usecase-слоя.
+var (
+	ErrUnknownProvider  = fmt.Errorf("unknown webhook provider")
+	ErrInvalidSignature = fmt.Errorf("invalid webhook signature")
+	ErrSignatureExpired = fmt.Errorf("webhook signature timestamp is outside the tolerance")
+	ErrInvalidPayload   = fmt.Errorf("invalid webhook payload")
+)
+
+const (
+	// claimTTL — аренда обработки; если процесс упал, событие подхватит другой по её истечении.
+	claimTTL = 5 * time.Minute
+	// maxErrorLength ограничивает сохраняемый текст ошибки обработки (в символах).
+	maxErrorLength = 1000
+	// maxExternalIDLength и maxTypeLength совпадают с длиной колонок external_id и event_type.
+	maxExternalIDLength = 255
+	maxTypeLength       = 128
+)
+
+type service struct {
+	events    repo.InboundWebhookRepository
+	providers map[string]Provider
+	cfg       Config
+	now       func() time.Time
+	logger    logger.Logger
+}
+
+// NewService создаёт сервис входящих webhooks. providers — подключённые провайдеры по имени из адреса
+// webhook; запросы к другим именам отклоняются с ErrUnknownProvider.
+func NewService(events repo.InboundWebhookRepository, providers map[string]Provider, cfg Config, logger logger.Logger) Service {
+	return &service{
+		events:    events,
+		providers: providers,
+		cfg:       cfg,
+		now:       func() time.Time { return time.Now().UTC() },
+		logger:    logger,
+	}
+}
+
+// Receive проверяет подпись и подписанное время, затем сохраняет событие.
+// Повтор запроса внутри окна Tolerance отсекается по ID события, за его пределами — по времени подписи.
+func (s *service) Receive(ctx context.Context, provider string, header http.Header, body []byte) (bool, error) {
+	p, ok := s.providers[provider]
+	if !ok {
+		return false, ErrUnknownProvider
+	}
+
+	signedAt, err := p.Verifier.Verify(header, body)
+	if err != nil {
+		return false, err
+	}
+	now := s.now()
+	if !signedAt.IsZero() && (signedAt.Before(now.Add(-s.cfg.Tolerance)) || signedAt.After(now.Add(s.cfg.Tolerance))) {
+		return false, ErrSignatureExpired
+	}
+
+	parse := p.Parse
+	if parse == nil {
+		parse = parseJSONEnvelope
+	}
+	envelope, err := parse(header, body)
+	if err != nil {
+		return false, fmt.Errorf("%w: %v", ErrInvalidPayload, err)
+	}
+	if envelope.ID == "" || len(envelope.ID) > maxExternalIDLength || len(envelope.Type) > maxTypeLength {
+		return false, ErrInvalidPayload
+	}
+
+	e := domain.NewEvent(provider, envelope.ID, envelope.Type, body, now)
+	e.TraceParent = tracing.Header(ctx)
+	created, err := s.events.Create(ctx, e)
+	if err != nil {
+		return false, err
+	}
+	if !created {
+		s.logger.Info("inbound_webhook_duplicate", map[string]any{
+			"provider":    provider,
+			"external_id": envelope.ID,
+		})
+	}
+	return created, nil
+}
+
+// parseJSONEnvelope читает ID и тип события из полей id и type JSON-тела.
+func parseJSONEnvelope(_ http.Header, body []byte) (Envelope, error) {
+	var envelope Envelope
+	err := json.Unmarshal(body, &envelope)
+	return envelope, err
+}
+
+// Run обрабатывает события из очереди. Ошибка обработки события не прерывает запуск:
+// событие получает следующую попытку или уходит в dead letter.
+func (s *service) Run(ctx context.Context) error {
+	now := s.now()
+	due, err := s.events.ListDue(ctx, now, s.cfg.BatchSize)
+	if err != nil {
+		return fmt.Errorf("failed to list due inbound webhook events: %w", err)
+	}
+	for _, e := range due {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		claimed, err := s.events.Claim(ctx, e.ID, now, now.Add(claimTTL))
+		if err != nil {
+			return fmt.Errorf("failed to claim inbound webhook event: %w", err)
+		}
+		if !claimed {
+			continue
+		}
+		if err := s.process(ctx, e); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// process обрабатывает событие и сохраняет результат попытки.
+func (s *service) process(ctx context.Context, e *domain.Event) error {
+	fields := map[string]any{
+		"event_id":    e.ID,
+		"provider":    e.Provider,
+		"external_id": e.ExternalID,
+		"event_type":  e.Type,
+	}
+
+	p, ok := s.providers[e.Provider]
+	if !ok {
+		// Провайдер отключён после получения события; событие сохраняется в dead letter для разбора.
+		e.MarkDead(s.now(), "provider is not configured")
+		s.logger.Warn("inbound_webhook_dead_letter", fields)
+		return s.save(ctx, e)
+	}
+
+	// Попытка учитывается в метриках типа inbound-webhook:<провайдер> и продолжает трассу запроса провайдера.
+	processErr := worker.Track(ctx, "inbound-webhook:"+e.Provider, e.TraceParent, func(ctx context.Context) error {
+		return p.Process(ctx, e)
+	})
+	now := s.now()
+	if processErr == nil {
+		e.MarkProcessed(now)
+		return s.save(ctx, e)
+	}
+
+	e.MarkFailed(now, truncateError(processErr), emailoutbox.RetryDelay(e.Attempts+1, s.cfg.RetryBase, s.cfg.RetryMax))
+	fields["error"] = processErr.Error()
+	if e.Attempts >= s.cfg.MaxAttempts {
+		e.MarkDead(now, e.LastError)
+		s.logger.Error("inbound_webhook_dead_letter", fields)
+	} else {
+		s.logger.Warn("inbound_webhook_processing_failed", fields)
+	}
+	return s.save(ctx, e)
+}
+
+func (s *service) save(ctx context.Context, e *domain.Event) error {
+	if err := s.events.Save(ctx, e); err != nil {
+		return fmt.Errorf("failed to save inbound webhook event attempt: %w", err)
+	}
+	return nil
+}
+
+// Pending возвращает количество событий в очереди.
+func (s *service) Pending(ctx context.Context) (int64, error) {
+	return s.events.CountPending(ctx)
+}
+
+// DeleteExpired удаляет завершённые события старше срока хранения.
+func (s *service) DeleteExpired(ctx context.Context, before time.Time, limit int) (int64, error) {
+	return s.events.DeleteFinished(ctx, before.Add(-s.cfg.Retention), limit)
+}
+
+func truncateError(err error) string {
+	msg := []rune(err.Error())
+	if len(msg) > maxErrorLength {
+		msg = msg[:maxErrorLength]
+	}
+	return string(msg)
+}
@@ -0,0 +1,138 @@
+package legalhold
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	domain "workout-app/internal/domain/user"
+	repo "workout-app/internal/repository/interfaces"
+)
+
+// Service описывает usecase-слой юридических удержаний данных аккаунта.
+// Пока удержание установлено, аккаунт нельзя обезличить (окончательно удалить),
+// а автоматические задачи очистки и удаления выгрузок должны его пропускать.
+// Каждая установка и снятие записываются в журнал аудита в одной транзакции с изменением.
+type Service interface {
+	// Place устанавливает удержание на данные пользователя.
+	Place(ctx context.Context, actorID, userID uuid.UUID, reason string) (*domain.LegalHold, error)
+
+	// Release снимает удержание; reason фиксируется в журнале аудита.
+	Release(ctx context.Context, actorID, userID uuid.UUID, reason string) error
+
+	// Get возвращает текущее удержание (nil, если его нет) и журнал аудита пользователя.
+	Get(ctx context.Context, userID uuid.UUID) (*domain.LegalHold, []*domain.LegalHoldRecord, error)
+}
+
+// Ошибки бизнес-логики usecase-слоя.
+var (
+	ErrReasonRequired = fmt.Errorf("legal hold reason is required")
+	ErrAlreadyOnHold  = fmt.Errorf("user is already on legal hold")
+	ErrNotOnHold      = fmt.Errorf("user is not on legal hold")
+)
+
+// maxReasonLength ограничивает длину основания удержания.
+const maxReasonLength = 500
+
+type service struct {
+	tx    repo.Transactor
+	users repo.UserRepository
+	audit repo.LegalHoldAuditRepository
+}
+
+// NewService создаёт новый сервис юридических удержаний.
+func NewService(tx repo.Transactor, users repo.UserRepository, audit repo.LegalHoldAuditRepository) Service {
+	return &service{
+		tx:    tx,
+		users: users,
+		audit: audit,
+	}
+}
+
+// Place устанавливает удержание на данные пользователя.
+func (s *service) Place(ctx context.Context, actorID, userID uuid.UUID, reason string) (*domain.LegalHold, error) {
+	reason, err := normalizeReason(reason)
+	if err != nil {
+		return nil, err
+	}
+
+	hold := &domain.LegalHold{At: time.Now().UTC(), By: actorID, Reason: reason}
+	err = s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		user, err := s.users.GetByIDIncludingDeleted(ctx, userID)
+		if err != nil {
+			return err
+		}
+		if user.IsOnLegalHold() {
+			return ErrAlreadyOnHold
+		}
+		if err := s.users.SetLegalHold(ctx, userID, hold); err != nil {
+			return err
+		}
+		return s.record(ctx, userID, actorID, domain.LegalHoldPlaced, reason)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return hold, nil
+}
+
+// Release снимает удержание.
+func (s *service) Release(ctx context.Context, actorID, userID uuid.UUID, reason string) error {
+	reason, err := normalizeReason(reason)
+	if err != nil {
+		return err
+	}
+
+	return s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		user, err := s.users.GetByIDIncludingDeleted(ctx, userID)
+		if err != nil {
+			return err
+		}
+		if !user.IsOnLegalHold() {
+			return ErrNotOnHold
+		}
+		if err := s.users.SetLegalHold(ctx, userID, nil); err != nil {
+			return err
+		}
+		return s.record(ctx, userID, actorID, domain.LegalHoldReleased, reason)
+	})
+}
+
+// Get возвращает текущее удержание и журнал аудита пользователя.
+func (s *service) Get(ctx context.Context, userID uuid.UUID) (*domain.LegalHold, []*domain.LegalHoldRecord, error) {
+	user, err := s.users.GetByIDIncludingDeleted(ctx, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+	records, err := s.audit.ListByUser(ctx, userID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list legal hold audit: %w", err)
+	}
+	return user.LegalHold, records, nil
+}
+
+// record добавляет запись в журнал аудита.
+func (s *service) record(ctx context.Context, userID, actorID uuid.UUID, action domain.LegalHoldAction, reason string) error {
+	err := s.audit.Create(ctx, &domain.LegalHoldRecord{
+		UserID:  userID,
+		Action:  action,
+		ActorID: actorID,
+		Reason:  reason,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to write legal hold audit: %w", err)
+	}
+	return nil
+}
+
+// normalizeReason проверяет, что основание указано и не слишком длинное.
+func normalizeReason(reason string) (string, error) {
+	reason = strings.TrimSpace(reason)
+	if reason == "" || len([]rune(reason)) > maxReasonLength {
+		return "", ErrReasonRequired
+	}
+	return reason, nil
+}
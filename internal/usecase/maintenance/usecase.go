@@ -0,0 +1,55 @@
+package maintenance
+
+import (
+	"context"
+	"fmt"
+	"sort"
+)
+
+// Service описывает административные служебные операции: сброс кешей.
+type Service interface {
+	// ListCaches возвращает имена кешей, которые можно сбросить.
+	ListCaches() []string
+
+	// InvalidateCache сбрасывает кеш по имени.
+	InvalidateCache(ctx context.Context, name string) error
+}
+
+// Cache описывает кеш, который можно сбросить административной операцией.
+type Cache interface {
+	Invalidate(ctx context.Context) error
+}
+
+// Ошибки бизнес-логики usecase-слоя.
+var (
+	ErrUnknownCache = fmt.Errorf("unknown cache")
+)
+
+type service struct {
+	caches map[string]Cache
+}
+
+// NewService создаёт новый сервис служебных операций.
+// Операции выполняются только над явно зарегистрированными кешами.
+func NewService(caches map[string]Cache) Service {
+	return &service{caches: caches}
+}
+
+// ListCaches возвращает отсортированные имена зарегистрированных кешей.
+func (s *service) ListCaches() []string {
+	names := make([]string, 0, len(s.caches))
+	for name := range s.caches {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// InvalidateCache сбрасывает кеш по имени.
+func (s *service) InvalidateCache(ctx context.Context, name string) error {
+	cache, ok := s.caches[name]
+	if !ok {
+		return ErrUnknownCache
+	}
+	return cache.Invalidate(ctx)
+}
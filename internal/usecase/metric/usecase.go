@@ -0,0 +1,150 @@
+package metric
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	consentdomain "workout-app/internal/domain/consent"
+	eventdomain "workout-app/internal/domain/event"
+	domain "workout-app/internal/domain/user"
+	"workout-app/internal/events"
+	repo "workout-app/internal/repository/interfaces"
+	consentuc "workout-app/internal/usecase/consent"
+)
+
+// Service описывает usecase-слой для отслеживания параметров тела пользователя.
+type Service interface {
+	// Record сохраняет новый замер пользователя.
+	Record(ctx context.Context, userID uuid.UUID, input RecordInput) (*domain.BodyMetric, error)
+
+	// List возвращает страницу замеров пользователя за период [from, to], новые первыми.
+	// limit=0 — страница по умолчанию; limit больше максимального уменьшается до него.
+	List(ctx context.Context, userID uuid.UUID, from, to time.Time, limit, offset int) ([]*domain.BodyMetric, error)
+
+	// LatestSummary возвращает последние значения всех метрик пользователя.
+	LatestSummary(ctx context.Context, userID uuid.UUID) (*domain.BodyMetricsSummary, error)
+
+	// ListForCoach возвращает страницу замеров клиента тренеру, если клиент открыл ему класс данных measurements.
+	ListForCoach(ctx context.Context, coachID, clientID uuid.UUID, from, to time.Time, limit, offset int) ([]*domain.BodyMetric, error)
+}
+
+// RecordInput описывает данные нового замера на уровне бизнес-логики.
+type RecordInput struct {
+	MeasuredAt     *time.Time
+	WeightKg       *float64
+	BodyFatPercent *float64
+	Measurements   map[string]float64
+}
+
+// Ошибки бизнес-логики usecase-слоя.
+var (
+	ErrEmptyMeasurement   = fmt.Errorf("at least one metric value is required")
+	ErrInvalidMeasurement = fmt.Errorf("invalid measurement value")
+	ErrInvalidRange       = fmt.Errorf("invalid date range")
+	ErrMeasuredInFuture   = fmt.Errorf("measurement time is in the future")
+)
+
+const (
+	// maxMeasurementNameLength ограничивает длину названия произвольного замера.
+	maxMeasurementNameLength = 64
+	// defaultListLimit — размер страницы истории замеров по умолчанию.
+	defaultListLimit = 100
+	// maxListLimit ограничивает размер страницы истории замеров.
+	maxListLimit = 500
+)
+
+type service struct {
+	metrics  repo.BodyMetricRepository
+	events   events.Publisher
+	consents consentuc.Checker
+}
+
+// NewService создаёт новый сервис параметров тела.
+// consents проверяет согласие клиента перед выдачей его замеров тренеру.
+func NewService(metrics repo.BodyMetricRepository, publisher events.Publisher, consents consentuc.Checker) Service {
+	return &service{metrics: metrics, events: publisher, consents: consents}
+}
+
+// Record сохраняет новый замер пользователя.
+func (s *service) Record(ctx context.Context, userID uuid.UUID, input RecordInput) (*domain.BodyMetric, error) {
+	var measuredAt time.Time
+	if input.MeasuredAt != nil {
+		measuredAt = *input.MeasuredAt
+		// Допускаем небольшой рассинхрон часов клиента.
+		if measuredAt.After(time.Now().Add(5 * time.Minute)) {
+			return nil, ErrMeasuredInFuture
+		}
+	}
+
+	m := domain.NewBodyMetric(userID, measuredAt)
+	m.WeightKg = input.WeightKg
+	m.BodyFatPercent = input.BodyFatPercent
+
+	for name, value := range input.Measurements {
+		key := strings.ToLower(strings.TrimSpace(name))
+		if key == "" || len(key) > maxMeasurementNameLength || value <= 0 {
+			return nil, ErrInvalidMeasurement
+		}
+		// Замеры в имперских единицах хранятся в СИ; один замер нельзя передать в двух единицах.
+		key, value = domain.CanonicalMeasurement(key, value)
+		if _, ok := m.Measurements[key]; ok || len(key) > maxMeasurementNameLength {
+			return nil, ErrInvalidMeasurement
+		}
+		m.Measurements[key] = value
+	}
+
+	if m.IsEmpty() {
+		return nil, ErrEmptyMeasurement
+	}
+
+	if err := s.metrics.Create(ctx, m); err != nil {
+		return nil, err
+	}
+
+	s.events.Publish(ctx, eventdomain.TypeMetricRecorded, m.UserID.String(), eventdomain.MetricRecorded{
+		MetricID:   m.ID.String(),
+		UserID:     m.UserID.String(),
+		MeasuredAt: m.MeasuredAt,
+	})
+	return m, nil
+}
+
+// List возвращает страницу замеров пользователя за период [from, to].
+func (s *service) List(ctx context.Context, userID uuid.UUID, from, to time.Time, limit, offset int) ([]*domain.BodyMetric, error) {
+	if !from.IsZero() && !to.IsZero() && from.After(to) {
+		return nil, ErrInvalidRange
+	}
+	limit, offset = normalizePage(limit, offset)
+	return s.metrics.ListByUser(ctx, userID, from, to, limit, offset)
+}
+
+// LatestSummary возвращает последние значения всех метрик пользователя.
+func (s *service) LatestSummary(ctx context.Context, userID uuid.UUID) (*domain.BodyMetricsSummary, error) {
+	return s.metrics.GetLatestSummary(ctx, userID)
+}
+
+// ListForCoach возвращает замеры клиента тренеру, если клиент открыл ему класс данных measurements.
+func (s *service) ListForCoach(ctx context.Context, coachID, clientID uuid.UUID, from, to time.Time, limit, offset int) ([]*domain.BodyMetric, error) {
+	if err := s.consents.Require(ctx, coachID, clientID, consentdomain.ScopeMeasurements); err != nil {
+		return nil, err
+	}
+	return s.List(ctx, clientID, from, to, limit, offset)
+}
+
+// normalizePage приводит параметры страницы к допустимым значениям.
+func normalizePage(limit, offset int) (int, int) {
+	if offset < 0 {
+		offset = 0
+	}
+	if limit <= 0 {
+		limit = defaultListLimit
+	}
+	if limit > maxListLimit {
+		limit = maxListLimit
+	}
+	return limit, offset
+}
@@ -0,0 +1,167 @@
+package notification
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	domain "workout-app/internal/domain/notification"
+	repo "workout-app/internal/repository/interfaces"
+)
+
+// Service описывает usecase-слой уведомлений в приложении и настроек их копий (письмо, push).
+// Уведомления создаёт подписчик шины событий (events.Notifications), здесь они только читаются.
+type Service interface {
+	// List возвращает страницу уведомлений пользователя, новые первыми.
+	List(ctx context.Context, userID uuid.UUID, unreadOnly bool, limit, offset int) (*Page, error)
+
+	// MarkRead отмечает уведомление прочитанным.
+	MarkRead(ctx context.Context, userID, id uuid.UUID) error
+
+	// MarkAllRead отмечает прочитанными все уведомления пользователя.
+	MarkAllRead(ctx context.Context, userID uuid.UUID) error
+
+	// GetPreferences возвращает настройки по всем типам уведомлений.
+	GetPreferences(ctx context.Context, userID uuid.UUID) ([]domain.Preference, error)
+
+	// UpdatePreferences применяет изменения настроек; типы и поля, которых нет в запросе, не меняются.
+	UpdatePreferences(ctx context.Context, userID uuid.UUID, updates []PreferenceUpdate) ([]domain.Preference, error)
+}
+
+// PreferenceUpdate — изменение настройки одного типа уведомлений; nil-поля не меняются.
+type PreferenceUpdate struct {
+	Type  string
+	Email *bool
+	Push  *bool
+}
+
+// Page — страница уведомлений. Unread — количество всех непрочитанных уведомлений пользователя.
+type Page struct {
+	Items  []*domain.Notification
+	Total  int64
+	Unread int64
+}
+
+// Ошибки бизнес-логики usecase-слоя.
+var (
+	ErrNotificationNotFound = fmt.Errorf("notification not found")
+	ErrInvalidType          = fmt.Errorf("unknown notification type")
+)
+
+const (
+	// maxListLimit ограничивает размер страницы списка.
+	maxListLimit = 100
+	// defaultListLimit — размер страницы по умолчанию.
+	defaultListLimit = 20
+)
+
+type service struct {
+	notifications repo.NotificationRepository
+}
+
+// NewService создаёт новый сервис уведомлений.
+func NewService(notifications repo.NotificationRepository) Service {
+	return &service{notifications: notifications}
+}
+
+// List возвращает страницу уведомлений и количество непрочитанных.
+func (s *service) List(ctx context.Context, userID uuid.UUID, unreadOnly bool, limit, offset int) (*Page, error) {
+	limit, offset = normalizePage(limit, offset)
+	items, total, err := s.notifications.List(ctx, userID, unreadOnly, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	unread := total
+	if !unreadOnly {
+		if unread, err = s.notifications.CountUnread(ctx, userID); err != nil {
+			return nil, err
+		}
+	}
+	return &Page{Items: items, Total: total, Unread: unread}, nil
+}
+
+// MarkRead отмечает уведомление прочитанным; повторная отметка не меняет время прочтения.
+func (s *service) MarkRead(ctx context.Context, userID, id uuid.UUID) error {
+	err := s.notifications.MarkRead(ctx, userID, id, time.Now().UTC())
+	if errors.Is(err, repo.ErrNotFound) {
+		return ErrNotificationNotFound
+	}
+	return err
+}
+
+// MarkAllRead отмечает прочитанными все уведомления пользователя.
+func (s *service) MarkAllRead(ctx context.Context, userID uuid.UUID) error {
+	return s.notifications.MarkAllRead(ctx, userID, time.Now().UTC())
+}
+
+// GetPreferences дополняет сохранённые настройки значениями по умолчанию.
+func (s *service) GetPreferences(ctx context.Context, userID uuid.UUID) ([]domain.Preference, error) {
+	saved, err := s.notifications.GetPreferences(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	prefs := domain.DefaultPreferences()
+	for i := range prefs {
+		for _, p := range saved {
+			if p.Type == prefs[i].Type {
+				prefs[i].Email = p.Email
+				prefs[i].Push = p.Push
+			}
+		}
+	}
+	return prefs, nil
+}
+
+// UpdatePreferences проверяет типы, применяет изменения к текущим настройкам и сохраняет изменённые типы.
+func (s *service) UpdatePreferences(ctx context.Context, userID uuid.UUID, updates []PreferenceUpdate) ([]domain.Preference, error) {
+	for _, u := range updates {
+		if !domain.IsKnownType(u.Type) {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidType, u.Type)
+		}
+	}
+	prefs, err := s.GetPreferences(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	changed := make([]domain.Preference, 0, len(updates))
+	for i := range prefs {
+		touched := false
+		for _, u := range updates {
+			if u.Type != prefs[i].Type {
+				continue
+			}
+			touched = true
+			if u.Email != nil {
+				prefs[i].Email = *u.Email
+			}
+			if u.Push != nil {
+				prefs[i].Push = *u.Push
+			}
+		}
+		if touched {
+			changed = append(changed, prefs[i])
+		}
+	}
+	if err := s.notifications.SetPreferences(ctx, userID, changed); err != nil {
+		return nil, err
+	}
+	return prefs, nil
+}
+
+// normalizePage приводит параметры страницы к допустимым значениям.
+func normalizePage(limit, offset int) (int, int) {
+	if offset < 0 {
+		offset = 0
+	}
+	if limit <= 0 {
+		limit = defaultListLimit
+	}
+	if limit > maxListLimit {
+		limit = maxListLimit
+	}
+	return limit, offset
+}
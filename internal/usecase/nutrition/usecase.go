@@ -0,0 +1,300 @@
+package nutrition
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+
+	domain "workout-app/internal/domain/nutrition"
+	userdomain "workout-app/internal/domain/user"
+	repo "workout-app/internal/repository/interfaces"
+)
+
+// Service описывает usecase-слой дневника питания: записи продуктов и блюд по дням
+// и итоги дней в сравнении с дневной целью по калориям из профиля.
+type Service interface {
+	// AddEntry добавляет запись в дневник.
+	AddEntry(ctx context.Context, userID uuid.UUID, input EntryInput) (*domain.Entry, error)
+
+	// UpdateEntry заменяет данные записи.
+	UpdateEntry(ctx context.Context, userID, id uuid.UUID, input EntryInput) (*domain.Entry, error)
+
+	// DeleteEntry удаляет запись.
+	DeleteEntry(ctx context.Context, userID, id uuid.UUID) error
+
+	// Diary возвращает записи и итоги дня; nil — сегодня в часовом поясе пользователя.
+	Diary(ctx context.Context, userID uuid.UUID, date *time.Time) (*Diary, error)
+
+	// Totals возвращает итоги каждого дня [from, to], включая дни без записей;
+	// nil — сегодня в часовом поясе пользователя.
+	Totals(ctx context.Context, userID uuid.UUID, from, to *time.Time) (*Totals, error)
+}
+
+// EntryInput описывает запись дневника на уровне бизнес-логики.
+type EntryInput struct {
+	Date     *time.Time // День дневника; nil — сегодня в часовом поясе пользователя
+	Meal     domain.Meal
+	Name     string
+	Calories int
+	ProteinG float64
+	CarbsG   float64
+	FatG     float64
+}
+
+// Day — итоги дня с остатком до цели.
+type Day struct {
+	domain.DayTotals
+	Remaining *int // Сколько ккал осталось до цели (отрицательное — цель превышена); nil без цели
+}
+
+// Diary — дневник питания за день.
+type Diary struct {
+	Date          time.Time
+	CalorieTarget *int
+	Entries       []*domain.Entry
+	Totals        Day
+}
+
+// Totals — итоги дней периода.
+type Totals struct {
+	From          time.Time
+	To            time.Time
+	CalorieTarget *int
+	Days          []Day
+}
+
+// Ошибки бизнес-логики usecase-слоя.
+var (
+	ErrInvalidMeal     = fmt.Errorf("meal must be breakfast, lunch, dinner or snack")
+	ErrInvalidName     = fmt.Errorf("food name must be 1-100 characters")
+	ErrInvalidCalories = fmt.Errorf("calories are out of range")
+	ErrInvalidMacros   = fmt.Errorf("macronutrients are out of range")
+	ErrInvalidDate     = fmt.Errorf("diary date is in the future")
+	ErrInvalidPeriod   = fmt.Errorf("invalid period")
+	ErrEntryNotFound   = fmt.Errorf("food entry not found")
+)
+
+// Ограничения дневника питания.
+const (
+	maxNameLength = 100
+	maxCalories   = 10000 // ккал на одну запись
+	maxMacroGrams = 1000
+	// maxTotalsDays — наибольшее число дней в выборке итогов.
+	maxTotalsDays = 92
+)
+
+type service struct {
+	entries repo.NutritionRepository
+	users   repo.UserRepository
+	now     func() time.Time
+}
+
+// NewService создаёт новый сервис дневника питания.
+// users нужен для часового пояса пользователя и дневной цели по калориям.
+func NewService(entries repo.NutritionRepository, users repo.UserRepository) Service {
+	return &service{
+		entries: entries,
+		users:   users,
+		now:     func() time.Time { return time.Now().UTC() },
+	}
+}
+
+// AddEntry добавляет запись в дневник.
+func (s *service) AddEntry(ctx context.Context, userID uuid.UUID, input EntryInput) (*domain.Entry, error) {
+	user, err := s.users.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	date, err := s.entryDate(user, input)
+	if err != nil {
+		return nil, err
+	}
+
+	e := domain.NewEntry(userID, date, input.Meal, strings.TrimSpace(input.Name), input.Calories,
+		roundGrams(input.ProteinG), roundGrams(input.CarbsG), roundGrams(input.FatG))
+	if err := s.entries.Create(ctx, e); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// UpdateEntry заменяет данные записи.
+func (s *service) UpdateEntry(ctx context.Context, userID, id uuid.UUID, input EntryInput) (*domain.Entry, error) {
+	e, err := s.entries.GetByID(ctx, userID, id)
+	if err != nil {
+		if errors.Is(err, repo.ErrNotFound) {
+			return nil, ErrEntryNotFound
+		}
+		return nil, err
+	}
+	user, err := s.users.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if input.Date == nil {
+		input.Date = &e.Date
+	}
+	date, err := s.entryDate(user, input)
+	if err != nil {
+		return nil, err
+	}
+
+	e.Date = date
+	e.Meal = input.Meal
+	e.Name = strings.TrimSpace(input.Name)
+	e.Calories = input.Calories
+	e.ProteinG = roundGrams(input.ProteinG)
+	e.CarbsG = roundGrams(input.CarbsG)
+	e.FatG = roundGrams(input.FatG)
+	e.UpdatedAt = s.now()
+	if err := s.entries.Update(ctx, e); err != nil {
+		if errors.Is(err, repo.ErrNotFound) {
+			return nil, ErrEntryNotFound
+		}
+		return nil, err
+	}
+	return e, nil
+}
+
+// DeleteEntry удаляет запись.
+func (s *service) DeleteEntry(ctx context.Context, userID, id uuid.UUID) error {
+	if err := s.entries.Delete(ctx, userID, id); err != nil {
+		if errors.Is(err, repo.ErrNotFound) {
+			return ErrEntryNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+// Diary возвращает записи и итоги дня.
+func (s *service) Diary(ctx context.Context, userID uuid.UUID, date *time.Time) (*Diary, error) {
+	user, err := s.users.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	day := s.today(user)
+	if date != nil {
+		day = dateOf(*date)
+	}
+
+	entries, err := s.entries.ListByDate(ctx, userID, day)
+	if err != nil {
+		return nil, err
+	}
+	totals := domain.DayTotals{Date: day}
+	for _, e := range entries {
+		totals.Entries++
+		totals.Calories += e.Calories
+		totals.ProteinG += e.ProteinG
+		totals.CarbsG += e.CarbsG
+		totals.FatG += e.FatG
+	}
+	return &Diary{
+		Date:          day,
+		CalorieTarget: user.CalorieTarget,
+		Entries:       entries,
+		Totals:        newDay(totals, user.CalorieTarget),
+	}, nil
+}
+
+// Totals возвращает итоги каждого дня периода.
+func (s *service) Totals(ctx context.Context, userID uuid.UUID, from, to *time.Time) (*Totals, error) {
+	user, err := s.users.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	end := s.today(user)
+	if to != nil {
+		end = dateOf(*to)
+	}
+	start := end
+	if from != nil {
+		start = dateOf(*from)
+	}
+	if start.After(end) || start.AddDate(0, 0, maxTotalsDays-1).Before(end) {
+		return nil, ErrInvalidPeriod
+	}
+
+	stored, err := s.entries.DailyTotals(ctx, userID, start, end)
+	if err != nil {
+		return nil, err
+	}
+	byDate := make(map[time.Time]*domain.DayTotals, len(stored))
+	for _, t := range stored {
+		byDate[t.Date] = t
+	}
+	result := &Totals{From: start, To: end, CalorieTarget: user.CalorieTarget}
+	for day := start; !day.After(end); day = day.AddDate(0, 0, 1) {
+		totals := domain.DayTotals{Date: day}
+		if t, ok := byDate[day]; ok {
+			totals = *t
+		}
+		result.Days = append(result.Days, newDay(totals, user.CalorieTarget))
+	}
+	return result, nil
+}
+
+// entryDate проверяет запись и возвращает её день в дневнике.
+func (s *service) entryDate(user *userdomain.User, input EntryInput) (time.Time, error) {
+	if !input.Meal.IsValid() {
+		return time.Time{}, ErrInvalidMeal
+	}
+	name := strings.TrimSpace(input.Name)
+	if name == "" || utf8.RuneCountInString(name) > maxNameLength {
+		return time.Time{}, ErrInvalidName
+	}
+	if input.Calories < 0 || input.Calories > maxCalories {
+		return time.Time{}, ErrInvalidCalories
+	}
+	for _, grams := range []float64{input.ProteinG, input.CarbsG, input.FatG} {
+		if grams < 0 || grams > maxMacroGrams {
+			return time.Time{}, ErrInvalidMacros
+		}
+	}
+
+	today := s.today(user)
+	if input.Date == nil {
+		return today, nil
+	}
+	date := dateOf(*input.Date)
+	if date.After(today) {
+		return time.Time{}, ErrInvalidDate
+	}
+	return date, nil
+}
+
+// today возвращает текущий день в часовом поясе пользователя.
+func (s *service) today(user *userdomain.User) time.Time {
+	return dateOf(s.now().In(user.Location()))
+}
+
+// newDay дополняет итоги дня остатком до цели.
+func newDay(totals domain.DayTotals, target *int) Day {
+	totals.ProteinG = roundGrams(totals.ProteinG)
+	totals.CarbsG = roundGrams(totals.CarbsG)
+	totals.FatG = roundGrams(totals.FatG)
+	day := Day{DayTotals: totals}
+	if target != nil {
+		remaining := *target - totals.Calories
+		day.Remaining = &remaining
+	}
+	return day
+}
+
+// dateOf приводит момент времени к дню дневника (полночь UTC той же календарной даты).
+func dateOf(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+// roundGrams округляет граммы до десятых, как они хранятся в БД.
+func roundGrams(v float64) float64 {
+	return math.Round(v*10) / 10
+}
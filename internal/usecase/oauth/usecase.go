@@ -0,0 +1,304 @@
+package oauth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/google/uuid"
+
+	eventdomain "workout-app/internal/domain/event"
+	domain "workout-app/internal/domain/user"
+	"workout-app/internal/events"
+	repo "workout-app/internal/repository/interfaces"
+	usernameblockuc "workout-app/internal/usecase/usernameblock"
+	"workout-app/pkg/emailaddr"
+	jwtsvc "workout-app/pkg/jwt"
+	"workout-app/pkg/oidc"
+	"workout-app/pkg/usernamefilter"
+	"workout-app/pkg/verification"
+)
+
+// Service описывает usecase-слой входа через внешних провайдеров (Google, Apple) по ID-токену.
+type Service interface {
+	// Login проверяет ID-токен провайдера, находит привязанного пользователя, привязывает аккаунт
+	// к пользователю с тем же подтверждённым email или создаёт нового пользователя,
+	// и возвращает пару access/refresh токенов.
+	// nonce, если задан, должен совпадать с nonce в ID-токене.
+	// country — код страны клиента (ISO 3166-1 alpha-2) для нового пользователя.
+	Login(ctx context.Context, provider, idToken, nonce, country string) (*LoginResult, error)
+}
+
+// LoginResult описывает результат входа через провайдера.
+type LoginResult struct {
+	User         *domain.User
+	AccessToken  string
+	RefreshToken string
+	Created      bool // Пользователь создан при этом входе
+}
+
+// Ошибки бизнес-логики usecase-слоя.
+var (
+	ErrProviderNotSupported = fmt.Errorf("oauth provider is not supported")
+	ErrInvalidIDToken       = fmt.Errorf("invalid id token")
+	ErrProviderUnavailable  = fmt.Errorf("oauth provider keys are unavailable")
+	ErrEmailNotVerified     = fmt.Errorf("provider did not confirm the email")
+	ErrProviderLinked       = fmt.Errorf("another account of this provider is already linked")
+	ErrAccountDeleted       = fmt.Errorf("account deleted")
+	ErrAccountSuspended     = fmt.Errorf("account suspended")
+)
+
+// Ограничения имени пользователя, генерируемого при регистрации через провайдера.
+const (
+	minUsernameLength   = 3
+	maxUsernameLength   = 32
+	usernameSuffixLen   = 4
+	maxUsernameAttempts = 5
+	// fallbackUsernameBase — основа username, если из email не получилось допустимого имени.
+	fallbackUsernameBase = "user"
+)
+
+type service struct {
+	tx             repo.Transactor
+	users          repo.UserRepository
+	usernames      repo.UsernameHistoryRepository
+	usernameFilter usernameblockuc.Checker
+	accounts       repo.OAuthAccountRepository
+	verifiers      map[domain.OAuthProvider]oidc.Verifier
+	jwt            jwtsvc.Service
+	events         events.Publisher
+}
+
+// NewService создаёт новый сервис входа через внешних провайдеров.
+// verifiers — проверки ID-токенов включённых провайдеров; провайдеры без проверки недоступны.
+// publisher получает события регистрации и подтверждения email через провайдера.
+func NewService(
+	tx repo.Transactor,
+	users repo.UserRepository,
+	usernames repo.UsernameHistoryRepository,
+	usernameFilter usernameblockuc.Checker,
+	accounts repo.OAuthAccountRepository,
+	verifiers map[domain.OAuthProvider]oidc.Verifier,
+	jwt jwtsvc.Service,
+	publisher events.Publisher,
+) Service {
+	return &service{
+		tx:             tx,
+		users:          users,
+		usernames:      usernames,
+		usernameFilter: usernameFilter,
+		accounts:       accounts,
+		verifiers:      verifiers,
+		jwt:            jwt,
+		events:         publisher,
+	}
+}
+
+// Login выполняет вход через провайдера по ID-токену.
+func (s *service) Login(ctx context.Context, provider, idToken, nonce, country string) (*LoginResult, error) {
+	p := domain.OAuthProvider(provider)
+	verifier, ok := s.verifiers[p]
+	if !ok {
+		return nil, ErrProviderNotSupported
+	}
+
+	identity, err := verifier.Verify(ctx, idToken)
+	if err != nil {
+		if errors.Is(err, oidc.ErrInvalidToken) {
+			return nil, ErrInvalidIDToken
+		}
+		return nil, fmt.Errorf("%w: %v", ErrProviderUnavailable, err)
+	}
+	if nonce != "" && identity.Nonce != nonce {
+		return nil, ErrInvalidIDToken
+	}
+	identity.Email = emailaddr.Normalize(identity.Email)
+
+	result := &LoginResult{}
+	verified := false // Неподтверждённый аккаунт с этим email подтверждён провайдером
+	err = s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		account, err := s.accounts.GetByProviderSubject(ctx, p, identity.Subject)
+		switch {
+		case err == nil:
+			user, err := s.users.GetByID(ctx, account.UserID)
+			if err != nil {
+				if errors.Is(err, repo.ErrNotFound) {
+					return ErrAccountDeleted
+				}
+				return err
+			}
+			result.User = user
+			return s.accounts.TouchLogin(ctx, account.ID, time.Now().UTC())
+		case !errors.Is(err, repo.ErrNotFound):
+			return err
+		}
+
+		// Привязка по email безопасна, только если провайдер подтвердил владение адресом.
+		if identity.Email == "" || !identity.EmailVerified {
+			return ErrEmailNotVerified
+		}
+
+		user, err := s.users.GetByEmail(ctx, identity.Email)
+		switch {
+		case err == nil:
+			if !user.IsEmailVerified {
+				// Неподтверждённый аккаунт мог зарегистрировать кто угодно: пароль сбрасывается,
+				// а выданные токены отзываются, чтобы автор регистрации не получил доступ к аккаунту владельца email.
+				// iat в JWT хранится с точностью до секунды: токены, выданные ниже, не должны считаться отозванными.
+				now := time.Now().UTC().Truncate(time.Second)
+				if err := s.users.MarkEmailVerified(ctx, user.ID); err != nil {
+					return err
+				}
+				if err := s.users.UpdatePassword(ctx, user.ID, "", now); err != nil {
+					return err
+				}
+				user.IsEmailVerified = true
+				user.PasswordHash = ""
+				user.TokensValidAfter = &now
+				user.Touch(now)
+				verified = true
+			}
+		case errors.Is(err, repo.ErrNotFound):
+			if user, err = s.createUser(ctx, identity.Email, country); err != nil {
+				return err
+			}
+			result.Created = true
+		default:
+			return err
+		}
+
+		if err := s.accounts.Create(ctx, domain.NewOAuthAccount(user.ID, p, identity.Subject, identity.Email)); err != nil {
+			if errors.Is(err, repo.ErrOAuthAccountLinked) {
+				return ErrProviderLinked
+			}
+			return err
+		}
+		result.User = user
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.publishAccountEvents(ctx, p, result.User, result.Created, verified)
+
+	if result.User.IsSuspended(time.Now()) {
+		return nil, ErrAccountSuspended
+	}
+
+	if result.AccessToken, err = s.jwt.GenerateAccessToken(result.User); err != nil {
+		return nil, err
+	}
+	if result.RefreshToken, _, err = s.jwt.GenerateRefreshToken(result.User); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// publishAccountEvents публикует регистрацию нового пользователя или подтверждение email
+// существующего. События публикуются после успешного завершения транзакции входа.
+func (s *service) publishAccountEvents(ctx context.Context, provider domain.OAuthProvider, user *domain.User, created, verified bool) {
+	switch {
+	case created:
+		s.events.Publish(ctx, eventdomain.TypeUserRegistered, user.ID.String(), eventdomain.UserRegistered{
+			UserID:        user.ID.String(),
+			Method:        string(provider),
+			EmailVerified: true,
+			RegisteredAt:  user.CreatedAt,
+		})
+	case verified:
+		s.events.Publish(ctx, eventdomain.TypeEmailVerified, user.ID.String(), eventdomain.EmailVerified{
+			UserID:     user.ID.String(),
+			VerifiedAt: user.UpdatedAt,
+		})
+	}
+}
+
+// createUser создаёт пользователя с подтверждённым email и без пароля: войти можно только через провайдера.
+// Имя пользователя выводится из email; если оно занято или зарезервировано после смены имени,
+// добавляется случайный суффикс.
+func (s *service) createUser(ctx context.Context, email, country string) (*domain.User, error) {
+	base := usernameBase(email)
+	// Запрещённое имя не спасает цифровой суффикс (admin1234 запрещён так же, как admin),
+	// поэтому для таких email сразу берём нейтральную основу.
+	if allowed, err := s.usernameAllowed(ctx, base); err != nil {
+		return nil, err
+	} else if !allowed {
+		base = fallbackUsernameBase
+	}
+	for attempt := 0; attempt < maxUsernameAttempts; attempt++ {
+		username := base
+		if attempt > 0 {
+			suffix, err := verification.GenerateNumericCode(usernameSuffixLen)
+			if err != nil {
+				return nil, err
+			}
+			username = base[:min(len(base), maxUsernameLength-usernameSuffixLen)] + suffix
+		}
+		allowed, err := s.usernameAllowed(ctx, username)
+		if err != nil {
+			return nil, err
+		}
+		if !allowed {
+			continue
+		}
+		reserved, err := s.usernames.IsReserved(ctx, username, uuid.Nil, time.Now().UTC())
+		if err != nil {
+			return nil, err
+		}
+		if reserved {
+			continue
+		}
+
+		user := domain.NewUser(email, "", username)
+		user.IsEmailVerified = true
+		user.SetRegistrationCountry(country)
+
+		// Точка сохранения: ошибка уникальности не должна прерывать внешнюю транзакцию.
+		err = s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+			return s.users.Create(ctx, user)
+		})
+		switch {
+		case err == nil:
+			return user, nil
+		case errors.Is(err, repo.ErrUsernameExists):
+			continue
+		case errors.Is(err, repo.ErrEmailExists):
+			// Email занят мягко удалённым аккаунтом.
+			return nil, ErrAccountDeleted
+		default:
+			return nil, err
+		}
+	}
+	return nil, fmt.Errorf("failed to generate unique username for %q", base)
+}
+
+// usernameAllowed сообщает, не запрещён ли username списком запрещённых имён.
+func (s *service) usernameAllowed(ctx context.Context, username string) (bool, error) {
+	err := s.usernameFilter.Check(ctx, username)
+	if errors.Is(err, usernamefilter.ErrNotAllowed) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// usernameBase оставляет из локальной части email только латинские буквы и цифры.
+func usernameBase(email string) string {
+	local, _, _ := strings.Cut(email, "@")
+	var b strings.Builder
+	for _, r := range strings.ToLower(local) {
+		if r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			b.WriteRune(r)
+		}
+	}
+	base := b.String()
+	if len(base) > maxUsernameLength {
+		base = base[:maxUsernameLength]
+	}
+	if len(base) < minUsernameLength {
+		base = fallbackUsernameBase + base
+	}
+	return base
+}
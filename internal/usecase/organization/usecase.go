@@ -0,0 +1,455 @@
+package organization
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+
+	domain "workout-app/internal/domain/organization"
+	repo "workout-app/internal/repository/interfaces"
+	"workout-app/pkg/emailaddr"
+)
+
+// Service описывает usecase-слой организаций (залов) и их участников.
+type Service interface {
+	// Create создаёт организацию и делает ownerID её владельцем.
+	Create(ctx context.Context, name string, ownerID uuid.UUID) (*domain.Organization, error)
+
+	// AddMember добавляет пользователя в организацию с ролью role.
+	AddMember(ctx context.Context, orgID, userID uuid.UUID, role domain.Role) (*domain.Member, error)
+
+	// RemoveMember исключает пользователя из организации. Владельца исключить нельзя.
+	RemoveMember(ctx context.Context, orgID, userID uuid.UUID) error
+
+	// ListForUser возвращает организации пользователя и его роль в каждой.
+	ListForUser(ctx context.Context, userID uuid.UUID) ([]Membership, error)
+
+	// RequireRole проверяет, что пользователь состоит в организации с одной из ролей.
+	// Возвращает ErrOrganizationNotFound, если организации нет или пользователь в ней не состоит,
+	// и ErrForbidden, если роль не подходит.
+	RequireRole(ctx context.Context, orgID, userID uuid.UUID, roles ...domain.Role) error
+
+	// MemberRole возвращает роль пользователя в организации; false — пользователь в ней не состоит.
+	MemberRole(ctx context.Context, orgID, userID uuid.UUID) (domain.Role, bool, error)
+
+	// ListMembers возвращает страницу участников организации, команду первой.
+	ListMembers(ctx context.Context, orgID uuid.UUID, limit, offset int) (*MemberPage, error)
+
+	// Invite приглашает зарегистрированного пользователя с этим email в команду с ролью role
+	// (manager или staff). inviterRole — роль приглашающего: владелец приглашает менеджеров и сотрудников,
+	// менеджер — только сотрудников.
+	Invite(ctx context.Context, orgID, inviterID uuid.UUID, inviterRole domain.Role, email string, role domain.Role) (*domain.Invitation, error)
+
+	// ListInvitations возвращает действующие приглашения пользователя, новые первыми.
+	ListInvitations(ctx context.Context, userID uuid.UUID) ([]InvitationWithOrganization, error)
+
+	// AcceptInvitation принимает приглашение: пользователь вступает в организацию с ролью из приглашения
+	// или получает её, если уже состоит в организации как участник.
+	AcceptInvitation(ctx context.Context, userID, invitationID uuid.UUID) (*domain.Member, error)
+
+	// DeclineInvitation отклоняет приглашение.
+	DeclineInvitation(ctx context.Context, userID, invitationID uuid.UUID) error
+
+	// TransferOwnership делает участника newOwnerID владельцем организации; прежний владелец становится менеджером.
+	TransferOwnership(ctx context.Context, orgID, newOwnerID uuid.UUID) (*domain.Member, error)
+}
+
+// Membership описывает организацию пользователя и его роль в ней.
+type Membership struct {
+	Organization *domain.Organization
+	Role         domain.Role
+	JoinedAt     time.Time
+}
+
+// MemberPage — страница участников организации.
+type MemberPage struct {
+	Items []*domain.Member
+	Total int64
+}
+
+// InvitationWithOrganization описывает приглашение и организацию, в которую оно ведёт.
+type InvitationWithOrganization struct {
+	Invitation   *domain.Invitation
+	Organization *domain.Organization
+}
+
+// Ошибки бизнес-логики usecase-слоя.
+var (
+	ErrInvalidName          = fmt.Errorf("invalid organization name")
+	ErrInvalidRole          = fmt.Errorf("invalid organization role")
+	ErrOrganizationNotFound = fmt.Errorf("organization not found")
+	ErrUserNotFound         = fmt.Errorf("user not found")
+	ErrAlreadyMember        = fmt.Errorf("user is already a member of the organization")
+	ErrMemberNotFound       = fmt.Errorf("user is not a member of the organization")
+	ErrCannotRemoveOwner    = fmt.Errorf("organization owner cannot be removed")
+	ErrForbidden            = fmt.Errorf("insufficient organization role")
+	ErrInvalidInviteRole    = fmt.Errorf("invitation role must be manager or staff")
+	ErrAlreadyInvited       = fmt.Errorf("user already has a pending invitation")
+	ErrInvitationNotFound   = fmt.Errorf("invitation not found")
+	ErrAlreadyOwner         = fmt.Errorf("user is already the organization owner")
+)
+
+const (
+	// maxNameLength ограничивает длину названия организации.
+	maxNameLength = 200
+	// invitationTTL — срок действия приглашения в команду.
+	invitationTTL = 7 * 24 * time.Hour
+	// maxListLimit ограничивает размер страницы участников.
+	maxListLimit = 100
+	// defaultListLimit — размер страницы участников по умолчанию.
+	defaultListLimit = 50
+)
+
+type service struct {
+	tx    repo.Transactor
+	orgs  repo.OrganizationRepository
+	users repo.UserRepository
+}
+
+// NewService создаёт новый сервис организаций.
+func NewService(tx repo.Transactor, orgs repo.OrganizationRepository, users repo.UserRepository) Service {
+	return &service{
+		tx:    tx,
+		orgs:  orgs,
+		users: users,
+	}
+}
+
+// Create создаёт организацию и делает ownerID её владельцем.
+func (s *service) Create(ctx context.Context, name string, ownerID uuid.UUID) (*domain.Organization, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || utf8.RuneCountInString(name) > maxNameLength {
+		return nil, ErrInvalidName
+	}
+	if err := s.requireUser(ctx, ownerID); err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	org := &domain.Organization{ID: uuid.New(), Name: name, CreatedAt: now}
+	err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.orgs.Create(ctx, org); err != nil {
+			return err
+		}
+		return s.orgs.AddMember(ctx, &domain.Member{
+			OrganizationID: org.ID,
+			UserID:         ownerID,
+			Role:           domain.RoleOwner,
+			CreatedAt:      now,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return org, nil
+}
+
+// AddMember добавляет пользователя в организацию с ролью role.
+func (s *service) AddMember(ctx context.Context, orgID, userID uuid.UUID, role domain.Role) (*domain.Member, error) {
+	if !role.IsValid() {
+		return nil, ErrInvalidRole
+	}
+	if _, err := s.orgs.GetByID(ctx, orgID); err != nil {
+		if errors.Is(err, repo.ErrNotFound) {
+			return nil, ErrOrganizationNotFound
+		}
+		return nil, err
+	}
+	if err := s.requireUser(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	m := &domain.Member{
+		OrganizationID: orgID,
+		UserID:         userID,
+		Role:           role,
+		CreatedAt:      time.Now().UTC(),
+	}
+	if err := s.orgs.AddMember(ctx, m); err != nil {
+		if errors.Is(err, repo.ErrMemberExists) {
+			return nil, ErrAlreadyMember
+		}
+		return nil, err
+	}
+	return m, nil
+}
+
+// RemoveMember исключает пользователя из организации.
+func (s *service) RemoveMember(ctx context.Context, orgID, userID uuid.UUID) error {
+	m, err := s.orgs.GetMember(ctx, orgID, userID)
+	if err != nil {
+		if errors.Is(err, repo.ErrNotFound) {
+			return ErrMemberNotFound
+		}
+		return err
+	}
+	if m.Role == domain.RoleOwner {
+		return ErrCannotRemoveOwner
+	}
+	if err := s.orgs.RemoveMember(ctx, orgID, userID); err != nil {
+		if errors.Is(err, repo.ErrNotFound) {
+			return ErrMemberNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+// ListForUser возвращает организации пользователя и его роль в каждой.
+func (s *service) ListForUser(ctx context.Context, userID uuid.UUID) ([]Membership, error) {
+	orgs, members, err := s.orgs.ListByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]Membership, 0, len(orgs))
+	for i := range orgs {
+		result = append(result, Membership{
+			Organization: orgs[i],
+			Role:         members[i].Role,
+			JoinedAt:     members[i].CreatedAt,
+		})
+	}
+	return result, nil
+}
+
+// RequireRole проверяет, что пользователь состоит в организации с одной из ролей.
+func (s *service) RequireRole(ctx context.Context, orgID, userID uuid.UUID, roles ...domain.Role) error {
+	m, err := s.orgs.GetMember(ctx, orgID, userID)
+	if err != nil {
+		if errors.Is(err, repo.ErrNotFound) {
+			return ErrOrganizationNotFound
+		}
+		return err
+	}
+	for _, role := range roles {
+		if m.Role == role {
+			return nil
+		}
+	}
+	return ErrForbidden
+}
+
+// MemberRole возвращает роль пользователя в организации.
+func (s *service) MemberRole(ctx context.Context, orgID, userID uuid.UUID) (domain.Role, bool, error) {
+	m, err := s.orgs.GetMember(ctx, orgID, userID)
+	if err != nil {
+		if errors.Is(err, repo.ErrNotFound) {
+			return "", false, nil
+		}
+		return "", false, err
+	}
+	return m.Role, true, nil
+}
+
+// ListMembers возвращает страницу участников организации.
+func (s *service) ListMembers(ctx context.Context, orgID uuid.UUID, limit, offset int) (*MemberPage, error) {
+	if limit <= 0 {
+		limit = defaultListLimit
+	}
+	limit = min(limit, maxListLimit)
+	offset = max(offset, 0)
+
+	items, total, err := s.orgs.ListMembers(ctx, orgID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	return &MemberPage{Items: items, Total: total}, nil
+}
+
+// Invite приглашает пользователя в команду организации.
+func (s *service) Invite(ctx context.Context, orgID, inviterID uuid.UUID, inviterRole domain.Role, email string, role domain.Role) (*domain.Invitation, error) {
+	if role != domain.RoleManager && role != domain.RoleStaff {
+		return nil, ErrInvalidInviteRole
+	}
+	if !inviterRole.CanInvite(role) {
+		return nil, ErrForbidden
+	}
+	if _, err := s.orgs.GetByID(ctx, orgID); err != nil {
+		if errors.Is(err, repo.ErrNotFound) {
+			return nil, ErrOrganizationNotFound
+		}
+		return nil, err
+	}
+	user, err := s.users.GetByEmail(ctx, emailaddr.Normalize(email))
+	if err != nil {
+		if errors.Is(err, repo.ErrNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, err
+	}
+	// Участника (клиента зала) можно пригласить в команду; уже состоящего в ней — нет.
+	existingRole, member, err := s.MemberRole(ctx, orgID, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	if member && existingRole.IsTeam() {
+		return nil, ErrAlreadyMember
+	}
+
+	now := time.Now().UTC()
+	inv := &domain.Invitation{
+		ID:             uuid.New(),
+		OrganizationID: orgID,
+		UserID:         user.ID,
+		Role:           role,
+		InvitedBy:      inviterID,
+		CreatedAt:      now,
+		ExpiresAt:      now.Add(invitationTTL),
+	}
+	if err := s.orgs.CreateInvitation(ctx, inv); err != nil {
+		if errors.Is(err, repo.ErrInvitationExists) {
+			return nil, ErrAlreadyInvited
+		}
+		return nil, err
+	}
+	return inv, nil
+}
+
+// ListInvitations возвращает действующие приглашения пользователя.
+func (s *service) ListInvitations(ctx context.Context, userID uuid.UUID) ([]InvitationWithOrganization, error) {
+	invitations, orgs, err := s.orgs.ListInvitationsByUser(ctx, userID, time.Now().UTC())
+	if err != nil {
+		return nil, err
+	}
+	result := make([]InvitationWithOrganization, 0, len(invitations))
+	for i := range invitations {
+		result = append(result, InvitationWithOrganization{Invitation: invitations[i], Organization: orgs[i]})
+	}
+	return result, nil
+}
+
+// AcceptInvitation принимает приглашение в транзакции: членство и удаление приглашения фиксируются вместе.
+func (s *service) AcceptInvitation(ctx context.Context, userID, invitationID uuid.UUID) (*domain.Member, error) {
+	var member *domain.Member
+	err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		inv, err := s.invitation(ctx, userID, invitationID)
+		if err != nil {
+			return err
+		}
+
+		existing, err := s.orgs.GetMember(ctx, inv.OrganizationID, userID)
+		switch {
+		case errors.Is(err, repo.ErrNotFound):
+			member = &domain.Member{
+				OrganizationID: inv.OrganizationID,
+				UserID:         userID,
+				Role:           inv.Role,
+				CreatedAt:      time.Now().UTC(),
+			}
+			if err := s.orgs.AddMember(ctx, member); err != nil {
+				if errors.Is(err, repo.ErrMemberExists) {
+					return ErrAlreadyMember
+				}
+				return err
+			}
+		case err != nil:
+			return err
+		case existing.Role.IsTeam():
+			// Роль в команде уже получена другим путём; приглашение больше не нужно.
+			return ErrAlreadyMember
+		default:
+			if err := s.orgs.UpdateMemberRole(ctx, inv.OrganizationID, userID, inv.Role); err != nil {
+				return err
+			}
+			existing.Role = inv.Role
+			member = existing
+		}
+		return s.deleteInvitation(ctx, inv.ID)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return member, nil
+}
+
+// DeclineInvitation отклоняет приглашение.
+func (s *service) DeclineInvitation(ctx context.Context, userID, invitationID uuid.UUID) error {
+	inv, err := s.invitation(ctx, userID, invitationID)
+	if err != nil {
+		return err
+	}
+	return s.deleteInvitation(ctx, inv.ID)
+}
+
+// TransferOwnership передаёт владение организацией участнику newOwnerID.
+func (s *service) TransferOwnership(ctx context.Context, orgID, newOwnerID uuid.UUID) (*domain.Member, error) {
+	var member *domain.Member
+	err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		m, err := s.orgs.GetMember(ctx, orgID, newOwnerID)
+		if err != nil {
+			if errors.Is(err, repo.ErrNotFound) {
+				return ErrMemberNotFound
+			}
+			return err
+		}
+		if m.Role == domain.RoleOwner {
+			return ErrAlreadyOwner
+		}
+
+		// Владельцы идут первыми в списке участников, поэтому все они на первой странице.
+		members, _, err := s.orgs.ListMembers(ctx, orgID, maxListLimit, 0)
+		if err != nil {
+			return err
+		}
+		for _, owner := range members {
+			if owner.Role != domain.RoleOwner {
+				break
+			}
+			if err := s.orgs.UpdateMemberRole(ctx, orgID, owner.UserID, domain.RoleManager); err != nil {
+				return err
+			}
+		}
+		if err := s.orgs.UpdateMemberRole(ctx, orgID, newOwnerID, domain.RoleOwner); err != nil {
+			return err
+		}
+		m.Role = domain.RoleOwner
+		member = m
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return member, nil
+}
+
+// invitation возвращает действующее приглашение пользователя userID.
+// Чужое и истёкшее приглашения не отличаются от несуществующего.
+func (s *service) invitation(ctx context.Context, userID, invitationID uuid.UUID) (*domain.Invitation, error) {
+	inv, err := s.orgs.GetInvitation(ctx, invitationID)
+	if err != nil {
+		if errors.Is(err, repo.ErrNotFound) {
+			return nil, ErrInvitationNotFound
+		}
+		return nil, err
+	}
+	if inv.UserID != userID || inv.IsExpired(time.Now().UTC()) {
+		return nil, ErrInvitationNotFound
+	}
+	return inv, nil
+}
+
+func (s *service) deleteInvitation(ctx context.Context, id uuid.UUID) error {
+	if err := s.orgs.DeleteInvitation(ctx, id); err != nil {
+		if errors.Is(err, repo.ErrNotFound) {
+			return ErrInvitationNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+// requireUser возвращает ErrUserNotFound, если пользователя нет.
+func (s *service) requireUser(ctx context.Context, userID uuid.UUID) error {
+	if _, err := s.users.GetByID(ctx, userID); err != nil {
+		if errors.Is(err, repo.ErrNotFound) {
+			return ErrUserNotFound
+		}
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,123 @@
+package playground
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	domain "workout-app/internal/domain/user"
+	repo "workout-app/internal/repository/interfaces"
+	jwtsvc "workout-app/pkg/jwt"
+)
+
+// Service описывает usecase-слой песочницы API для разработки и QA: тестовых пользователей
+// каждой роли и выпуск для них токенов без регистрации, подтверждения email и входа.
+// Песочница включается только вне production (см. config.PlaygroundConfig).
+type Service interface {
+	// Seed создаёт недостающих тестовых пользователей. Существующие аккаунты не меняются.
+	Seed(ctx context.Context) error
+
+	// Users возвращает тестовых пользователей в порядке ролей user, coach, admin.
+	Users(ctx context.Context) ([]*domain.User, error)
+
+	// IssueTokens выпускает пару access/refresh токенов тестовому пользователю с ролью role.
+	IssueTokens(ctx context.Context, role domain.Role) (*domain.User, string, string, error)
+}
+
+// Ошибки бизнес-логики usecase-слоя.
+var (
+	ErrUnknownRole = fmt.Errorf("no playground user with this role")
+	ErrNotSeeded   = fmt.Errorf("playground user is not seeded")
+)
+
+// seedUser описывает тестового пользователя песочницы.
+type seedUser struct {
+	Role     domain.Role
+	Email    string
+	Username string
+}
+
+// seedUsers — тестовые пользователи по одному на роль. Пароль не задан: войти можно только через песочницу.
+var seedUsers = []seedUser{
+	{Role: domain.RoleUser, Email: "playground-user@example.com", Username: "playgrounduser"},
+	{Role: domain.RoleCoach, Email: "playground-coach@example.com", Username: "playgroundcoach"},
+	{Role: domain.RoleAdmin, Email: "playground-admin@example.com", Username: "playgroundadmin"},
+}
+
+type service struct {
+	users repo.UserRepository
+	jwt   jwtsvc.Service
+}
+
+// NewService создаёт сервис песочницы API.
+func NewService(users repo.UserRepository, jwt jwtsvc.Service) Service {
+	return &service{users: users, jwt: jwt}
+}
+
+// Seed создаёт тестовых пользователей с подтверждённым email.
+func (s *service) Seed(ctx context.Context) error {
+	for _, seed := range seedUsers {
+		_, err := s.users.GetByEmail(ctx, seed.Email)
+		if err == nil {
+			continue
+		}
+		if !errors.Is(err, repo.ErrNotFound) {
+			return err
+		}
+
+		user := domain.NewUser(seed.Email, "", seed.Username)
+		user.Role = seed.Role
+		user.IsEmailVerified = true
+		if err := s.users.Create(ctx, user); err != nil {
+			return fmt.Errorf("failed to seed playground %s: %w", seed.Role, err)
+		}
+	}
+	return nil
+}
+
+// Users возвращает тестовых пользователей; ещё не созданные пропускаются.
+func (s *service) Users(ctx context.Context) ([]*domain.User, error) {
+	users := make([]*domain.User, 0, len(seedUsers))
+	for _, seed := range seedUsers {
+		user, err := s.users.GetByEmail(ctx, seed.Email)
+		if errors.Is(err, repo.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		users = append(users, user)
+	}
+	return users, nil
+}
+
+// IssueTokens выпускает токены только тестовым пользователям: аккаунты с другими email песочница не трогает.
+func (s *service) IssueTokens(ctx context.Context, role domain.Role) (*domain.User, string, string, error) {
+	var email string
+	for _, seed := range seedUsers {
+		if seed.Role == role {
+			email = seed.Email
+		}
+	}
+	if email == "" {
+		return nil, "", "", ErrUnknownRole
+	}
+
+	user, err := s.users.GetByEmail(ctx, email)
+	if err != nil {
+		if errors.Is(err, repo.ErrNotFound) {
+			return nil, "", "", ErrNotSeeded
+		}
+		return nil, "", "", err
+	}
+
+	access, err := s.jwt.GenerateAccessToken(user)
+	if err != nil {
+		return nil, "", "", err
+	}
+	refresh, _, err := s.jwt.GenerateRefreshToken(user)
+	if err != nil {
+		return nil, "", "", err
+	}
+	return user, access, refresh, nil
+}
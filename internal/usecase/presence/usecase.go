@@ -0,0 +1,202 @@
+package presence
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	coachdomain "workout-app/internal/domain/coach"
+	repo "workout-app/internal/repository/interfaces"
+	"workout-app/pkg/presence"
+)
+
+// Статусы присутствия клиента.
+const (
+	StatusTraining = "training" // клиент сейчас тренируется (активная сессия шлёт heartbeat)
+	StatusOffline  = "offline"  // heartbeat не приходил дольше TTL
+)
+
+// Service описывает usecase-слой присутствия: heartbeat активной тренировки и сводку для тренера.
+type Service interface {
+	// Heartbeat отмечает, что пользователь сейчас тренируется. Продлевает присутствие на TTL.
+	Heartbeat(ctx context.Context, userID uuid.UUID, sessionID string) (*presence.State, error)
+
+	// Stop завершает присутствие пользователя (тренировка окончена).
+	Stop(ctx context.Context, userID uuid.UUID) error
+
+	// ListClients возвращает клиентов тренера с их присутствием и тегами тренера.
+	ListClients(ctx context.Context, coachID uuid.UUID, filter ClientFilter) ([]ClientPresence, error)
+}
+
+// ClientFilter задаёт выборку клиентов тренера. Пустые поля не ограничивают выборку.
+type ClientFilter struct {
+	Status string // StatusTraining или StatusOffline
+	// Query ищется без учёта регистра в username, тегах и заметках тренера о клиенте.
+	Query string
+	Tag   string // Клиент отмечен тренером этим тегом
+}
+
+// ClientPresence описывает присутствие одного клиента тренера.
+type ClientPresence struct {
+	UserID   uuid.UUID
+	Username string
+	Status   string
+	State    *presence.State // nil, если клиент offline
+	Tags     []string        // Теги тренера; клиенту не показываются
+}
+
+// Ошибки бизнес-логики usecase-слоя.
+var (
+	ErrInvalidStatus    = fmt.Errorf("invalid presence status")
+	ErrInvalidSessionID = fmt.Errorf("invalid session id")
+	ErrInvalidTag       = fmt.Errorf("invalid tag")
+)
+
+// maxSessionIDLength ограничивает длину идентификатора клиентской сессии.
+const maxSessionIDLength = 64
+
+type service struct {
+	store   presence.Store
+	clients repo.CoachClientRepository
+	users   repo.UserRepository
+	notes   repo.CoachNoteRepository
+	ttl     time.Duration
+}
+
+// NewService создаёт новый сервис присутствия. ttl — время, через которое присутствие истекает без heartbeat.
+// notes используется для тегов и поиска в списке клиентов тренера.
+func NewService(store presence.Store, clients repo.CoachClientRepository, users repo.UserRepository, notes repo.CoachNoteRepository, ttl time.Duration) Service {
+	return &service{
+		store:   store,
+		clients: clients,
+		users:   users,
+		notes:   notes,
+		ttl:     ttl,
+	}
+}
+
+// Heartbeat отмечает, что пользователь сейчас тренируется.
+func (s *service) Heartbeat(ctx context.Context, userID uuid.UUID, sessionID string) (*presence.State, error) {
+	if len(sessionID) > maxSessionIDLength {
+		return nil, ErrInvalidSessionID
+	}
+
+	now := time.Now().UTC()
+	state := presence.State{
+		Status:     StatusTraining,
+		SessionID:  sessionID,
+		Since:      now,
+		LastSeenAt: now,
+	}
+
+	// Продолжение той же сессии сохраняет время начала тренировки.
+	current, err := s.store.Get(ctx, userID.String())
+	if err != nil {
+		return nil, err
+	}
+	if current != nil && current.SessionID == sessionID {
+		state.Since = current.Since
+	}
+
+	if err := s.store.Set(ctx, userID.String(), state, s.ttl); err != nil {
+		return nil, err
+	}
+	return &state, nil
+}
+
+// Stop завершает присутствие пользователя.
+func (s *service) Stop(ctx context.Context, userID uuid.UUID) error {
+	return s.store.Delete(ctx, userID.String())
+}
+
+// ListClients возвращает клиентов тренера с их присутствием.
+// Клиентами считаются пользователи, которые приняли приглашение тренера.
+func (s *service) ListClients(ctx context.Context, coachID uuid.UUID, filter ClientFilter) ([]ClientPresence, error) {
+	if filter.Status != "" && filter.Status != StatusTraining && filter.Status != StatusOffline {
+		return nil, ErrInvalidStatus
+	}
+	if filter.Tag != "" {
+		tag, ok := coachdomain.NormalizeTag(filter.Tag)
+		if !ok {
+			return nil, ErrInvalidTag
+		}
+		filter.Tag = tag
+	}
+	query := strings.ToLower(strings.TrimSpace(filter.Query))
+
+	clientIDs, err := s.clients.ListClientIDs(ctx, coachID)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([]string, len(clientIDs))
+	for i, id := range clientIDs {
+		keys[i] = id.String()
+	}
+	states, err := s.store.GetMany(ctx, keys)
+	if err != nil {
+		return nil, err
+	}
+	tags, err := s.notes.ListTagsByClient(ctx, coachID)
+	if err != nil {
+		return nil, err
+	}
+	noteMatches := make(map[uuid.UUID]bool)
+	if query != "" {
+		matched, err := s.notes.SearchClients(ctx, coachID, query)
+		if err != nil {
+			return nil, err
+		}
+		for _, id := range matched {
+			noteMatches[id] = true
+		}
+	}
+
+	result := make([]ClientPresence, 0, len(clientIDs))
+	for _, id := range clientIDs {
+		cp := ClientPresence{UserID: id, Status: StatusOffline, Tags: tags[id]}
+		if st, ok := states[id.String()]; ok {
+			cp.Status = st.Status
+			cp.State = &st
+		}
+		if filter.Status != "" && cp.Status != filter.Status {
+			continue
+		}
+		if filter.Tag != "" && !slices.Contains(cp.Tags, filter.Tag) {
+			continue
+		}
+
+		u, err := s.users.GetByID(ctx, id)
+		if err != nil {
+			// Удалённые пользователи не показываются тренеру.
+			if errors.Is(err, repo.ErrNotFound) {
+				continue
+			}
+			return nil, err
+		}
+		cp.Username = u.Username
+		if query != "" && !noteMatches[id] && !matchesClient(cp, query) {
+			continue
+		}
+		result = append(result, cp)
+	}
+	return result, nil
+}
+
+// matchesClient ищет query (в нижнем регистре) в username и тегах клиента.
+func matchesClient(cp ClientPresence, query string) bool {
+	if strings.Contains(strings.ToLower(cp.Username), query) {
+		return true
+	}
+	for _, tag := range cp.Tags {
+		if strings.Contains(tag, query) {
+			return true
+		}
+	}
+	return false
+}
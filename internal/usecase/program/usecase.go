@@ -0,0 +1,873 @@
+package program
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	consentdomain "workout-app/internal/domain/consent"
+	eventdomain "workout-app/internal/domain/event"
+	domain "workout-app/internal/domain/program"
+	userdomain "workout-app/internal/domain/user"
+	"workout-app/internal/events"
+	repo "workout-app/internal/repository/interfaces"
+	consentuc "workout-app/internal/usecase/consent"
+)
+
+// Service описывает usecase-слой тренировочных программ:
+// составление программ, копирование и назначение пользователям.
+type Service interface {
+	// Create создаёт программу от имени actor.
+	Create(ctx context.Context, actor Actor, input ProgramInput) (*domain.Program, error)
+
+	// Get возвращает программу, если actor — её автор, пользователь с назначением или админ.
+	// Программа возвращается со средней сложностью по оценкам тренировок её назначений.
+	Get(ctx context.Context, actor Actor, id uuid.UUID) (*domain.Program, error)
+
+	// ListOwn возвращает программы, автором которых является пользователь, со средней сложностью каждой.
+	ListOwn(ctx context.Context, ownerID uuid.UUID) ([]*domain.Program, error)
+
+	// Clone создаёт копию доступной actor программы, автором копии становится actor.
+	Clone(ctx context.Context, actor Actor, id uuid.UUID) (*domain.Program, error)
+
+	// Assign назначает программу пользователю.
+	// Себе программу может назначить автор; другим пользователям — автор с ролью coach
+	// (только своим клиентам, принявшим приглашение) или admin.
+	Assign(ctx context.Context, actor Actor, programID uuid.UUID, input AssignInput) (*domain.Assignment, error)
+
+	// BulkAssign назначает программу группе клиентов: каждому — со своей датой начала и, при необходимости,
+	// тренировочными максимумами, по которым вычисляются процентные веса программы.
+	// Клиенты обрабатываются независимо: ошибка одного не отменяет назначения остальным и возвращается в его результате.
+	// Доступно автору программы с ролью coach (только для его клиентов, принявших приглашение) и админу.
+	BulkAssign(ctx context.Context, actor Actor, programID uuid.UUID, input BulkAssignInput) ([]BulkAssignResult, error)
+
+	// AssignToClient назначает программу автора клиенту тренера: пользователю, принявшему приглашение тренера
+	// или уже получавшему от него программы. Возвращает consentuc.ErrNotCoachClient для остальных пользователей.
+	AssignToClient(ctx context.Context, actor Actor, clientID, programID uuid.UUID, startDate time.Time) (*domain.Assignment, error)
+
+	// ListAssigned возвращает назначения программ пользователю.
+	ListAssigned(ctx context.Context, userID uuid.UUID) ([]*domain.Assignment, error)
+
+	// ListAssignedForCoach возвращает назначения клиента тренеру, если клиент открыл ему класс данных workouts.
+	ListAssignedForCoach(ctx context.Context, coachID, clientID uuid.UUID) ([]*domain.Assignment, error)
+
+	// AdherenceForCoach возвращает выполнение программ, назначенных клиенту этим тренером,
+	// если клиент открыл тренеру класс данных workouts. Новые назначения первыми.
+	AdherenceForCoach(ctx context.Context, coachID, clientID uuid.UUID) ([]Adherence, error)
+
+	// Schedule раскладывает назначенную программу по датам и вычисляет процентные веса
+	// по тренировочным максимумам пользователя, которому она назначена.
+	// По оценкам сложности последних тренировок назначения предлагается изменение нагрузки;
+	// с ScheduleInput.ApplyFeedback оно применяется к весам расписания.
+	// Доступно самому пользователю, админу и назначившему тренеру с согласием клиента на класс workouts.
+	Schedule(ctx context.Context, actor Actor, assignmentID uuid.UUID, input ScheduleInput) (*AssignmentSchedule, error)
+
+	// SetTrainingMax сохраняет тренировочный максимум пользователя в упражнении.
+	SetTrainingMax(ctx context.Context, userID uuid.UUID, exercise string, weightKg float64) (*domain.TrainingMax, error)
+
+	// ListTrainingMaxes возвращает тренировочные максимумы пользователя.
+	ListTrainingMaxes(ctx context.Context, userID uuid.UUID) ([]*domain.TrainingMax, error)
+
+	// DeleteTrainingMax удаляет тренировочный максимум пользователя в упражнении.
+	DeleteTrainingMax(ctx context.Context, userID uuid.UUID, exercise string) error
+}
+
+// Actor описывает пользователя, от имени которого выполняется операция.
+type Actor struct {
+	UserID uuid.UUID
+	Role   userdomain.Role
+}
+
+// ProgramInput описывает данные новой программы на уровне бизнес-логики.
+// Номера недель вычисляются по порядку в Weeks.
+type ProgramInput struct {
+	Title       string
+	Description string
+	Weeks       []domain.Week
+}
+
+// AssignInput описывает параметры назначения программы.
+type AssignInput struct {
+	UserID    uuid.UUID // Кому назначить; uuid.Nil — самому actor
+	StartDate time.Time // Дата начала; нулевое значение — сегодня
+}
+
+// BulkAssignInput описывает параметры группового назначения программы.
+type BulkAssignInput struct {
+	StartDate time.Time // Дата начала по умолчанию; нулевое значение — сегодня
+	Clients   []BulkAssignClient
+}
+
+// BulkAssignClient описывает клиента группового назначения.
+type BulkAssignClient struct {
+	UserID    uuid.UUID
+	StartDate time.Time // Дата начала клиента; нулевое значение — BulkAssignInput.StartDate
+	// TrainingMaxes — максимумы клиента по упражнениям, которые нужно сохранить перед назначением;
+	// прежние значения этих упражнений заменяются.
+	TrainingMaxes map[string]float64
+}
+
+// BulkAssignResult — результат назначения программы одному клиенту.
+type BulkAssignResult struct {
+	UserID     uuid.UUID
+	Assignment *domain.Assignment // nil, если назначение не удалось
+	// MissingTrainingMaxes — упражнения процентных назначений программы, для которых у клиента нет максимума:
+	// их веса в расписании не будут вычислены, пока клиент или тренер не задаст максимум.
+	MissingTrainingMaxes []string
+	Err                  error
+}
+
+// ScheduleInput описывает параметры построения расписания назначения.
+type ScheduleInput struct {
+	From  time.Time        // Начало периода; нулевое значение — дата начала назначения
+	To    time.Time        // Конец периода (не включительно); нулевое значение — конец программы
+	Rules domain.LoadRules // Правила округления и раскладки блинов
+	// ApplyFeedback применяет к весам изменение нагрузки, предложенное по оценкам сложности тренировок.
+	ApplyFeedback bool
+}
+
+// Adherence — выполнение назначенной программы к текущему дню.
+type Adherence struct {
+	Assignment *domain.Assignment
+	Program    *domain.Program
+	Total      int // Тренировок в программе
+	Scheduled  int // Тренировок по расписанию с начала программы по сегодняшний день (UTC) включительно
+	Completed  int // Завершённых тренировок по назначению
+}
+
+// Rate возвращает долю выполненных тренировок расписания (0..1); false — по расписанию ещё не было тренировок.
+// Тренировки сверх расписания (выполненные заранее) долю не увеличивают сверх 1.
+func (a Adherence) Rate() (float64, bool) {
+	if a.Scheduled == 0 {
+		return 0, false
+	}
+	return float64(min(a.Completed, a.Scheduled)) / float64(a.Scheduled), true
+}
+
+// AssignmentSchedule — назначенная программа, разложенная по датам.
+type AssignmentSchedule struct {
+	Assignment *domain.Assignment
+	Program    *domain.Program
+	Workouts   []ScheduledWorkout
+	// Difficulty — оценки сложности последних тренировок назначения.
+	Difficulty domain.DifficultyStats
+	// LoadAdjustmentPercent — предлагаемое по оценкам изменение рабочих весов, %.
+	LoadAdjustmentPercent float64
+	// FeedbackApplied — изменение нагрузки применено к весам расписания.
+	FeedbackApplied bool
+}
+
+// ScheduledWorkout — тренировка расписания с вычисленными весами упражнений.
+type ScheduledWorkout struct {
+	Date      time.Time
+	Week      int
+	Day       int
+	Title     string
+	Notes     string
+	Exercises []ResolvedExercise
+}
+
+// ResolvedExercise — упражнение с весом, вычисленным для конкретного пользователя.
+type ResolvedExercise struct {
+	domain.Exercise
+	ResolvedWeightKg   *float64  // Итоговый вес: абсолютный из программы или вычисленный из процента
+	TrainingMaxKg      *float64  // Максимум, от которого посчитан процент
+	MissingTrainingMax bool      // Процентное назначение, для которого у пользователя нет максимума
+	PlatesPerSideKg    []float64 // Блины на одну сторону грифа
+	PlateRemainderKg   float64   // Вес, который не удалось набрать блинами
+}
+
+// Ошибки бизнес-логики usecase-слоя.
+var (
+	ErrInvalidProgram      = fmt.Errorf("invalid program")
+	ErrProgramAccessDenied = fmt.Errorf("program access denied")
+	ErrAssigneeNotFound    = fmt.Errorf("assignee not found")
+	ErrAssignmentNotFound  = fmt.Errorf("assignment not found")
+	ErrInvalidTrainingMax  = fmt.Errorf("invalid training max")
+	ErrTooManyTrainingMax  = fmt.Errorf("too many training maxes")
+	ErrTrainingMaxNotFound = fmt.Errorf("training max not found")
+	ErrInvalidSchedule     = fmt.Errorf("invalid schedule parameters")
+	ErrInvalidBulkAssign   = fmt.Errorf("invalid bulk assignment")
+)
+
+// Ограничения на размер программы.
+const (
+	maxTitleLength            = 200
+	maxWeeks                  = 52
+	maxWorkoutsPerDay         = 5
+	maxExercisesPerWorkout    = 30
+	maxSetsPerExercise        = 50
+	maxRepsPerSet             = 1000
+	maxExerciseWeightKg       = 1000
+	maxExerciseNameLength     = 100
+	maxProgramWorkoutsInTotal = maxWeeks * 7 * 2
+	maxLoadPercent            = 150
+	maxTrainingMaxesPerUser   = 100
+	maxScheduleDays           = 366
+	maxRoundingIncrementKg    = 50
+	maxBarWeightKg            = 50
+	maxBulkAssignClients      = 100
+	// feedbackWindow — по скольким последним оценённым тренировкам назначения предлагается изменение нагрузки.
+	feedbackWindow = 5
+)
+
+type service struct {
+	tx       repo.Transactor
+	programs repo.ProgramRepository
+	maxes    repo.TrainingMaxRepository
+	users    repo.UserRepository
+	clients  repo.CoachClientRepository
+	events   events.Publisher
+	consents consentuc.Checker
+}
+
+// NewService создаёт новый сервис тренировочных программ.
+// tx изолирует клиентов группового назначения друг от друга;
+// clients задаёт связи «тренер — клиент»: тренер назначает программы только своим клиентам;
+// consents проверяет согласие клиента перед выдачей его назначений тренеру.
+func NewService(
+	tx repo.Transactor,
+	programs repo.ProgramRepository,
+	maxes repo.TrainingMaxRepository,
+	users repo.UserRepository,
+	clients repo.CoachClientRepository,
+	publisher events.Publisher,
+	consents consentuc.Checker,
+) Service {
+	return &service{
+		tx:       tx,
+		programs: programs,
+		maxes:    maxes,
+		users:    users,
+		clients:  clients,
+		events:   publisher,
+		consents: consents,
+	}
+}
+
+// Create создаёт программу от имени actor.
+func (s *service) Create(ctx context.Context, actor Actor, input ProgramInput) (*domain.Program, error) {
+	title := strings.TrimSpace(input.Title)
+	if title == "" || len(title) > maxTitleLength {
+		return nil, fmt.Errorf("%w: title must be 1-%d characters", ErrInvalidProgram, maxTitleLength)
+	}
+
+	weeks, err := normalizeWeeks(input.Weeks)
+	if err != nil {
+		return nil, err
+	}
+
+	p := domain.New(actor.UserID, title, strings.TrimSpace(input.Description), weeks)
+	if err := s.programs.Create(ctx, p); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// Get возвращает программу с проверкой прав доступа.
+func (s *service) Get(ctx context.Context, actor Actor, id uuid.UUID) (*domain.Program, error) {
+	p, err := s.programs.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkReadAccess(ctx, actor, p); err != nil {
+		return nil, err
+	}
+	if err := s.withDifficulty(ctx, []*domain.Program{p}); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// ListOwn возвращает программы, автором которых является пользователь.
+func (s *service) ListOwn(ctx context.Context, ownerID uuid.UUID) ([]*domain.Program, error) {
+	programs, err := s.programs.ListByOwner(ctx, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.withDifficulty(ctx, programs); err != nil {
+		return nil, err
+	}
+	return programs, nil
+}
+
+// withDifficulty заполняет среднюю сложность программ одним запросом.
+func (s *service) withDifficulty(ctx context.Context, programs []*domain.Program) error {
+	if len(programs) == 0 {
+		return nil
+	}
+	ids := make([]uuid.UUID, 0, len(programs))
+	for _, p := range programs {
+		ids = append(ids, p.ID)
+	}
+	stats, err := s.programs.DifficultyStats(ctx, ids)
+	if err != nil {
+		return err
+	}
+	for _, p := range programs {
+		p.Difficulty = stats[p.ID]
+	}
+	return nil
+}
+
+// Clone создаёт копию программы для actor.
+func (s *service) Clone(ctx context.Context, actor Actor, id uuid.UUID) (*domain.Program, error) {
+	source, err := s.Get(ctx, actor, id)
+	if err != nil {
+		return nil, err
+	}
+
+	clone := source.Clone(actor.UserID)
+	if err := s.programs.Create(ctx, clone); err != nil {
+		return nil, err
+	}
+	return clone, nil
+}
+
+// Assign назначает программу пользователю.
+func (s *service) Assign(ctx context.Context, actor Actor, programID uuid.UUID, input AssignInput) (*domain.Assignment, error) {
+	p, err := s.programs.GetByID(ctx, programID)
+	if err != nil {
+		return nil, err
+	}
+
+	isAdmin := actor.Role == userdomain.RoleAdmin
+	if p.OwnerID != actor.UserID && !isAdmin {
+		return nil, ErrProgramAccessDenied
+	}
+
+	assigneeID := input.UserID
+	if assigneeID == uuid.Nil {
+		assigneeID = actor.UserID
+	}
+	if assigneeID != actor.UserID {
+		if actor.Role != userdomain.RoleCoach && !isAdmin {
+			return nil, ErrProgramAccessDenied
+		}
+		if _, err := s.users.GetByID(ctx, assigneeID); err != nil {
+			if errors.Is(err, repo.ErrNotFound) {
+				return nil, ErrAssigneeNotFound
+			}
+			return nil, err
+		}
+		if err := s.requireClient(ctx, actor, assigneeID); err != nil {
+			return nil, err
+		}
+	}
+
+	return s.createAssignment(ctx, actor, p, assigneeID, input.StartDate)
+}
+
+// requireClient проверяет, что тренер назначает программу своему клиенту (принявшему приглашение).
+// Админ может назначать программы любому пользователю.
+func (s *service) requireClient(ctx context.Context, actor Actor, clientID uuid.UUID) error {
+	if actor.Role == userdomain.RoleAdmin {
+		return nil
+	}
+	ok, err := s.clients.IsClient(ctx, actor.UserID, clientID)
+	if err != nil {
+		return fmt.Errorf("failed to check coach client: %w", err)
+	}
+	if !ok {
+		return consentuc.ErrNotCoachClient
+	}
+	return nil
+}
+
+// BulkAssign назначает программу группе клиентов.
+func (s *service) BulkAssign(ctx context.Context, actor Actor, programID uuid.UUID, input BulkAssignInput) ([]BulkAssignResult, error) {
+	if len(input.Clients) == 0 || len(input.Clients) > maxBulkAssignClients {
+		return nil, fmt.Errorf("%w: must have 1-%d clients", ErrInvalidBulkAssign, maxBulkAssignClients)
+	}
+	seen := make(map[uuid.UUID]struct{}, len(input.Clients))
+	for _, client := range input.Clients {
+		if client.UserID == uuid.Nil {
+			return nil, fmt.Errorf("%w: client user id is required", ErrInvalidBulkAssign)
+		}
+		if _, dup := seen[client.UserID]; dup {
+			return nil, fmt.Errorf("%w: duplicate client %s", ErrInvalidBulkAssign, client.UserID)
+		}
+		seen[client.UserID] = struct{}{}
+	}
+
+	p, err := s.programs.GetByID(ctx, programID)
+	if err != nil {
+		return nil, err
+	}
+	isAdmin := actor.Role == userdomain.RoleAdmin
+	if !isAdmin && (p.OwnerID != actor.UserID || actor.Role != userdomain.RoleCoach) {
+		return nil, ErrProgramAccessDenied
+	}
+
+	references := p.LoadReferences()
+	results := make([]BulkAssignResult, 0, len(input.Clients))
+	for _, client := range input.Clients {
+		result := BulkAssignResult{UserID: client.UserID}
+		startDate := client.StartDate
+		if startDate.IsZero() {
+			startDate = input.StartDate
+		}
+		// Каждый клиент — в своей (вложенной) транзакции: при ошибке откатываются только его изменения.
+		result.Err = s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+			if _, err := s.users.GetByID(ctx, client.UserID); err != nil {
+				if errors.Is(err, repo.ErrNotFound) {
+					return ErrAssigneeNotFound
+				}
+				return err
+			}
+			if err := s.requireClient(ctx, actor, client.UserID); err != nil {
+				return err
+			}
+			for exercise, weightKg := range client.TrainingMaxes {
+				if _, err := s.SetTrainingMax(ctx, client.UserID, exercise, weightKg); err != nil {
+					return err
+				}
+			}
+			if len(references) > 0 {
+				maxes, err := s.maxes.ListByUser(ctx, client.UserID)
+				if err != nil {
+					return err
+				}
+				result.MissingTrainingMaxes = missingTrainingMaxes(references, maxes)
+			}
+
+			a, err := s.createAssignment(ctx, actor, p, client.UserID, startDate)
+			if err != nil {
+				return err
+			}
+			result.Assignment = a
+			return nil
+		})
+		if result.Err != nil {
+			result.Assignment = nil
+			result.MissingTrainingMaxes = nil
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// AssignToClient проверяет связь «тренер — клиент» и назначает программу клиенту.
+// Связь проверяется и для админа: эндпоинт работает только с клиентами тренера.
+func (s *service) AssignToClient(ctx context.Context, actor Actor, clientID, programID uuid.UUID, startDate time.Time) (*domain.Assignment, error) {
+	ok, err := s.clients.IsClient(ctx, actor.UserID, clientID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check coach client: %w", err)
+	}
+	if !ok {
+		return nil, consentuc.ErrNotCoachClient
+	}
+	return s.Assign(ctx, actor, programID, AssignInput{UserID: clientID, StartDate: startDate})
+}
+
+// createAssignment сохраняет назначение программы и публикует событие program.assigned.
+func (s *service) createAssignment(ctx context.Context, actor Actor, p *domain.Program, assigneeID uuid.UUID, startDate time.Time) (*domain.Assignment, error) {
+	now := time.Now().UTC()
+	if startDate.IsZero() {
+		startDate = now
+	}
+
+	a := &domain.Assignment{
+		ID:         uuid.New(),
+		ProgramID:  p.ID,
+		UserID:     assigneeID,
+		AssignedBy: actor.UserID,
+		StartDate:  time.Date(startDate.Year(), startDate.Month(), startDate.Day(), 0, 0, 0, 0, time.UTC),
+		CreatedAt:  now,
+	}
+	if err := s.programs.CreateAssignment(ctx, a); err != nil {
+		return nil, err
+	}
+
+	s.events.Publish(ctx, eventdomain.TypeProgramAssigned, a.ID.String(), eventdomain.ProgramAssigned{
+		AssignmentID: a.ID.String(),
+		ProgramID:    a.ProgramID.String(),
+		UserID:       a.UserID.String(),
+		AssignedBy:   a.AssignedBy.String(),
+		StartDate:    a.StartDate,
+	})
+	return a, nil
+}
+
+// missingTrainingMaxes возвращает упражнения из references, для которых нет максимума среди maxes.
+func missingTrainingMaxes(references []string, maxes []*domain.TrainingMax) []string {
+	have := make(map[string]struct{}, len(maxes))
+	for _, tm := range maxes {
+		have[domain.ExerciseKey(tm.Exercise)] = struct{}{}
+	}
+	var missing []string
+	for _, ref := range references {
+		if _, ok := have[domain.ExerciseKey(ref)]; !ok {
+			missing = append(missing, ref)
+		}
+	}
+	return missing
+}
+
+// ListAssigned возвращает назначения программ пользователю.
+func (s *service) ListAssigned(ctx context.Context, userID uuid.UUID) ([]*domain.Assignment, error) {
+	return s.programs.ListAssignmentsByUser(ctx, userID)
+}
+
+// ListAssignedForCoach возвращает назначения клиента тренеру, если клиент открыл ему класс данных workouts.
+func (s *service) ListAssignedForCoach(ctx context.Context, coachID, clientID uuid.UUID) ([]*domain.Assignment, error) {
+	if err := s.consents.Require(ctx, coachID, clientID, consentdomain.ScopeWorkouts); err != nil {
+		return nil, err
+	}
+	return s.programs.ListAssignmentsByUser(ctx, clientID)
+}
+
+// AdherenceForCoach считает выполнение программ тренера клиентом: тренировки расписания
+// по сегодняшний день против завершённых тренировок по назначению.
+func (s *service) AdherenceForCoach(ctx context.Context, coachID, clientID uuid.UUID) ([]Adherence, error) {
+	if err := s.consents.Require(ctx, coachID, clientID, consentdomain.ScopeWorkouts); err != nil {
+		return nil, err
+	}
+	assignments, err := s.programs.ListAssignmentsByUser(ctx, clientID)
+	if err != nil {
+		return nil, err
+	}
+
+	var own []*domain.Assignment
+	ids := make([]uuid.UUID, 0, len(assignments))
+	for _, a := range assignments {
+		if a.AssignedBy == coachID {
+			own = append(own, a)
+			ids = append(ids, a.ID)
+		}
+	}
+	completed, err := s.programs.CompletedSessions(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	y, m, d := time.Now().UTC().Date()
+	tomorrow := time.Date(y, m, d, 0, 0, 0, 0, time.UTC).AddDate(0, 0, 1)
+	result := make([]Adherence, 0, len(own))
+	for _, a := range own {
+		p, err := s.programs.GetByID(ctx, a.ProgramID)
+		if err != nil {
+			return nil, err
+		}
+		end := p.EndDate(a.StartDate)
+		result = append(result, Adherence{
+			Assignment: a,
+			Program:    p,
+			Total:      len(p.Schedule(a.StartDate, a.StartDate, end)),
+			Scheduled:  len(p.Schedule(a.StartDate, a.StartDate, minTime(tomorrow, end))),
+			Completed:  completed[a.ID],
+		})
+	}
+	return result, nil
+}
+
+func minTime(a, b time.Time) time.Time {
+	if a.Before(b) {
+		return a
+	}
+	return b
+}
+
+// Schedule раскладывает назначенную программу по датам и вычисляет веса упражнений.
+func (s *service) Schedule(ctx context.Context, actor Actor, assignmentID uuid.UUID, input ScheduleInput) (*AssignmentSchedule, error) {
+	if err := validateLoadRules(input.Rules); err != nil {
+		return nil, err
+	}
+
+	a, err := s.programs.GetAssignmentByID(ctx, assignmentID)
+	if err != nil {
+		if errors.Is(err, repo.ErrNotFound) {
+			return nil, ErrAssignmentNotFound
+		}
+		return nil, err
+	}
+	if a.UserID != actor.UserID && actor.Role != userdomain.RoleAdmin {
+		// Чужое назначение видно только назначившему тренеру, и то с согласия клиента;
+		// для остальных оно не существует.
+		if a.AssignedBy != actor.UserID {
+			return nil, ErrAssignmentNotFound
+		}
+		if err := s.consents.Require(ctx, actor.UserID, a.UserID, consentdomain.ScopeWorkouts); err != nil {
+			return nil, err
+		}
+	}
+
+	p, err := s.programs.GetByID(ctx, a.ProgramID)
+	if err != nil {
+		return nil, err
+	}
+
+	from, to := input.From, input.To
+	if from.IsZero() {
+		from = a.StartDate
+	}
+	if to.IsZero() {
+		to = p.EndDate(a.StartDate)
+	}
+	if !from.Before(to) || to.Sub(from) > maxScheduleDays*24*time.Hour {
+		return nil, fmt.Errorf("%w: period must be positive and at most %d days", ErrInvalidSchedule, maxScheduleDays)
+	}
+
+	maxes, err := s.maxes.ListByUser(ctx, a.UserID)
+	if err != nil {
+		return nil, err
+	}
+	byExercise := make(map[string]float64, len(maxes))
+	for _, tm := range maxes {
+		byExercise[domain.ExerciseKey(tm.Exercise)] = tm.WeightKg
+	}
+
+	difficulty, err := s.programs.RecentDifficulty(ctx, a.ID, feedbackWindow)
+	if err != nil {
+		return nil, err
+	}
+	adjustment := difficulty.LoadAdjustmentPercent()
+	applied := 0.0
+	if input.ApplyFeedback {
+		applied = adjustment
+	}
+
+	scheduled := p.Schedule(a.StartDate, from, to)
+	workouts := make([]ScheduledWorkout, 0, len(scheduled))
+	for _, sw := range scheduled {
+		exercises := make([]ResolvedExercise, 0, len(sw.Workout.Exercises))
+		for _, e := range sw.Workout.Exercises {
+			exercises = append(exercises, resolveExercise(e, byExercise, input.Rules, applied))
+		}
+		workouts = append(workouts, ScheduledWorkout{
+			Date:      sw.Date,
+			Week:      sw.Week,
+			Day:       sw.Day,
+			Title:     sw.Workout.Title,
+			Notes:     sw.Workout.Notes,
+			Exercises: exercises,
+		})
+	}
+	return &AssignmentSchedule{
+		Assignment:            a,
+		Program:               p,
+		Workouts:              workouts,
+		Difficulty:            difficulty,
+		LoadAdjustmentPercent: adjustment,
+		FeedbackApplied:       applied != 0,
+	}, nil
+}
+
+// resolveExercise вычисляет вес упражнения: процентный — от максимума пользователя с округлением,
+// абсолютный остаётся как есть. Ненулевое adjustPercent меняет итоговый вес (с округлением).
+// Для итогового веса считается раскладка блинов.
+func resolveExercise(e domain.Exercise, maxes map[string]float64, rules domain.LoadRules, adjustPercent float64) ResolvedExercise {
+	r := ResolvedExercise{Exercise: e, ResolvedWeightKg: e.WeightKg}
+	if e.LoadPercent != nil {
+		reference := e.LoadReference
+		if reference == "" {
+			reference = e.Name
+		}
+		tm, ok := maxes[domain.ExerciseKey(reference)]
+		if !ok {
+			r.MissingTrainingMax = true
+			return r
+		}
+		weight := rules.ResolvePercent(*e.LoadPercent, tm)
+		r.TrainingMaxKg = &tm
+		r.ResolvedWeightKg = &weight
+	}
+	if r.ResolvedWeightKg != nil && adjustPercent != 0 {
+		weight := rules.Adjust(*r.ResolvedWeightKg, adjustPercent)
+		r.ResolvedWeightKg = &weight
+	}
+	if r.ResolvedWeightKg != nil {
+		r.PlatesPerSideKg, r.PlateRemainderKg = rules.Plates(*r.ResolvedWeightKg)
+	}
+	return r
+}
+
+// SetTrainingMax сохраняет тренировочный максимум пользователя в упражнении.
+func (s *service) SetTrainingMax(ctx context.Context, userID uuid.UUID, exercise string, weightKg float64) (*domain.TrainingMax, error) {
+	exercise = strings.Join(strings.Fields(exercise), " ")
+	if exercise == "" || len(exercise) > maxExerciseNameLength {
+		return nil, fmt.Errorf("%w: exercise must be 1-%d characters", ErrInvalidTrainingMax, maxExerciseNameLength)
+	}
+	if weightKg <= 0 || weightKg > maxExerciseWeightKg {
+		return nil, fmt.Errorf("%w: weight must be between 0 and %d kg", ErrInvalidTrainingMax, maxExerciseWeightKg)
+	}
+
+	existing, err := s.maxes.ListByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if len(existing) >= maxTrainingMaxesPerUser {
+		key := domain.ExerciseKey(exercise)
+		replaces := false
+		for _, tm := range existing {
+			if domain.ExerciseKey(tm.Exercise) == key {
+				replaces = true
+				break
+			}
+		}
+		if !replaces {
+			return nil, ErrTooManyTrainingMax
+		}
+	}
+
+	tm := &domain.TrainingMax{
+		UserID:    userID,
+		Exercise:  exercise,
+		WeightKg:  weightKg,
+		UpdatedAt: time.Now().UTC(),
+	}
+	if err := s.maxes.Upsert(ctx, tm); err != nil {
+		return nil, err
+	}
+	return tm, nil
+}
+
+// ListTrainingMaxes возвращает тренировочные максимумы пользователя.
+func (s *service) ListTrainingMaxes(ctx context.Context, userID uuid.UUID) ([]*domain.TrainingMax, error) {
+	return s.maxes.ListByUser(ctx, userID)
+}
+
+// DeleteTrainingMax удаляет тренировочный максимум пользователя в упражнении.
+func (s *service) DeleteTrainingMax(ctx context.Context, userID uuid.UUID, exercise string) error {
+	if err := s.maxes.Delete(ctx, userID, exercise); err != nil {
+		if errors.Is(err, repo.ErrNotFound) {
+			return ErrTrainingMaxNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+// checkReadAccess проверяет, что actor может просматривать программу.
+func (s *service) checkReadAccess(ctx context.Context, actor Actor, p *domain.Program) error {
+	if p.OwnerID == actor.UserID || actor.Role == userdomain.RoleAdmin {
+		return nil
+	}
+	assigned, err := s.programs.IsAssigned(ctx, p.ID, actor.UserID)
+	if err != nil {
+		return err
+	}
+	if !assigned {
+		return ErrProgramAccessDenied
+	}
+	return nil
+}
+
+// normalizeWeeks проверяет структуру программы, проставляет номера недель
+// и сортирует дни внутри недели по номеру.
+func normalizeWeeks(weeks []domain.Week) ([]domain.Week, error) {
+	if len(weeks) == 0 || len(weeks) > maxWeeks {
+		return nil, fmt.Errorf("%w: program must have 1-%d weeks", ErrInvalidProgram, maxWeeks)
+	}
+
+	total := 0
+	result := make([]domain.Week, len(weeks))
+	for i, w := range weeks {
+		weekNumber := i + 1
+		seen := make(map[int]struct{}, len(w.Days))
+		days := make([]domain.Day, 0, len(w.Days))
+		for _, d := range w.Days {
+			if d.Number < 1 || d.Number > 7 {
+				return nil, fmt.Errorf("%w: week %d: day must be between 1 and 7", ErrInvalidProgram, weekNumber)
+			}
+			if _, dup := seen[d.Number]; dup {
+				return nil, fmt.Errorf("%w: week %d: duplicate day %d", ErrInvalidProgram, weekNumber, d.Number)
+			}
+			seen[d.Number] = struct{}{}
+
+			if len(d.Workouts) == 0 || len(d.Workouts) > maxWorkoutsPerDay {
+				return nil, fmt.Errorf("%w: week %d day %d: must have 1-%d workouts", ErrInvalidProgram, weekNumber, d.Number, maxWorkoutsPerDay)
+			}
+			for _, wo := range d.Workouts {
+				if err := validateWorkout(wo); err != nil {
+					return nil, fmt.Errorf("%w: week %d day %d: %s", ErrInvalidProgram, weekNumber, d.Number, err)
+				}
+			}
+			total += len(d.Workouts)
+			days = append(days, d)
+		}
+		sort.Slice(days, func(a, b int) bool { return days[a].Number < days[b].Number })
+		result[i] = domain.Week{Number: weekNumber, Days: days}
+	}
+
+	if total == 0 {
+		return nil, fmt.Errorf("%w: program must contain at least one workout", ErrInvalidProgram)
+	}
+	if total > maxProgramWorkoutsInTotal {
+		return nil, fmt.Errorf("%w: program must contain at most %d workouts", ErrInvalidProgram, maxProgramWorkoutsInTotal)
+	}
+	return result, nil
+}
+
+// validateWorkout проверяет тренировку и её упражнения; темп упражнений приводится к каноничному виду.
+func validateWorkout(wo domain.Workout) error {
+	if strings.TrimSpace(wo.Title) == "" || len(wo.Title) > maxTitleLength {
+		return fmt.Errorf("workout title must be 1-%d characters", maxTitleLength)
+	}
+	if len(wo.Exercises) == 0 || len(wo.Exercises) > maxExercisesPerWorkout {
+		return fmt.Errorf("workout %q must have 1-%d exercises", wo.Title, maxExercisesPerWorkout)
+	}
+	for i := range wo.Exercises {
+		e := &wo.Exercises[i]
+		if strings.TrimSpace(e.Name) == "" || len(e.Name) > maxExerciseNameLength {
+			return fmt.Errorf("exercise name must be 1-%d characters", maxExerciseNameLength)
+		}
+		if e.Sets < 1 || e.Sets > maxSetsPerExercise {
+			return fmt.Errorf("exercise %q: sets must be between 1 and %d", e.Name, maxSetsPerExercise)
+		}
+		if e.Reps < 1 || e.Reps > maxRepsPerSet {
+			return fmt.Errorf("exercise %q: reps must be between 1 and %d", e.Name, maxRepsPerSet)
+		}
+		if e.WeightKg != nil && (*e.WeightKg < 0 || *e.WeightKg > maxExerciseWeightKg) {
+			return fmt.Errorf("exercise %q: weight must be between 0 and %d kg", e.Name, maxExerciseWeightKg)
+		}
+		if e.LoadPercent != nil {
+			if e.WeightKg != nil {
+				return fmt.Errorf("exercise %q: weight and load percent are mutually exclusive", e.Name)
+			}
+			if *e.LoadPercent <= 0 || *e.LoadPercent > maxLoadPercent {
+				return fmt.Errorf("exercise %q: load percent must be between 0 and %d", e.Name, maxLoadPercent)
+			}
+		} else if e.LoadReference != "" {
+			return fmt.Errorf("exercise %q: load reference requires load percent", e.Name)
+		}
+		if len(e.LoadReference) > maxExerciseNameLength {
+			return fmt.Errorf("exercise %q: load reference must be at most %d characters", e.Name, maxExerciseNameLength)
+		}
+		if e.Tempo != "" {
+			tempo, ok := domain.NormalizeTempo(e.Tempo)
+			if !ok {
+				return fmt.Errorf("exercise %q: tempo must be four phases of 0-%d seconds or X, e.g. 3-1-1-0", e.Name, domain.MaxTempoPhaseSeconds)
+			}
+			e.Tempo = tempo
+		}
+		if n := len(e.RestSeconds); n > 1 && n != e.Sets {
+			return fmt.Errorf("exercise %q: rest must be one value for all sets or one value per set", e.Name)
+		}
+		for _, rest := range e.RestSeconds {
+			if rest < 0 || rest > domain.MaxRestSeconds {
+				return fmt.Errorf("exercise %q: rest must be between 0 and %d seconds", e.Name, domain.MaxRestSeconds)
+			}
+		}
+	}
+	return nil
+}
+
+// validateLoadRules проверяет правила округления и раскладки блинов.
+func validateLoadRules(r domain.LoadRules) error {
+	if r.IncrementKg < 0 || r.IncrementKg > maxRoundingIncrementKg {
+		return fmt.Errorf("%w: rounding increment must be between 0 and %d kg", ErrInvalidSchedule, maxRoundingIncrementKg)
+	}
+	if !r.Mode.IsValid() {
+		return fmt.Errorf("%w: rounding must be one of nearest, down, up", ErrInvalidSchedule)
+	}
+	if r.BarKg < 0 || r.BarKg > maxBarWeightKg {
+		return fmt.Errorf("%w: bar weight must be between 0 and %d kg", ErrInvalidSchedule, maxBarWeightKg)
+	}
+	return nil
+}
@@ -0,0 +1,202 @@
+package progress
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	domain "workout-app/internal/domain/workout"
+	repo "workout-app/internal/repository/interfaces"
+)
+
+// Service описывает usecase-слой сводной статистики тренировок пользователя для дашборда:
+// тренировки по неделям, тоннаж, серии и самые нагружаемые группы мышц.
+type Service interface {
+	// Stats возвращает статистику завершённых тренировок за диапазон rng
+	// (7d, 30d, 90d, 365d или all) в часовом поясе пользователя.
+	Stats(ctx context.Context, userID uuid.UUID, rng Range) (*Stats, error)
+}
+
+// Range — диапазон статистики, отсчитываемый назад от сегодняшнего дня.
+type Range string
+
+const (
+	Range7Days   Range = "7d"
+	Range30Days  Range = "30d"
+	Range90Days  Range = "90d"
+	Range365Days Range = "365d"
+	RangeAll     Range = "all"
+)
+
+// DefaultRange — диапазон по умолчанию.
+const DefaultRange = Range90Days
+
+// rangeDays — длина диапазонов в днях, включая сегодняшний.
+var rangeDays = map[Range]int{
+	Range7Days:   7,
+	Range30Days:  30,
+	Range90Days:  90,
+	Range365Days: 365,
+}
+
+// IsValid проверяет, что диапазон поддерживается.
+func (r Range) IsValid() bool {
+	_, ok := rangeDays[r]
+	return ok || r == RangeAll
+}
+
+// Stats описывает статистику тренировок за диапазон.
+type Stats struct {
+	Range    Range
+	From     time.Time // Начало диапазона (полночь первого дня в часовом поясе пользователя)
+	To       time.Time
+	Timezone string // Часовой пояс дней и недель (IANA)
+
+	Totals Totals
+	// Weeks — недели диапазона по порядку, включая недели без тренировок.
+	Weeks []domain.WeekStats
+	// MuscleGroups — группы мышц по убыванию числа подходов.
+	MuscleGroups []domain.MuscleGroupStats
+	// UnclassifiedSets — подходы упражнений, которых нет в справочнике групп мышц.
+	UnclassifiedSets int
+	Streaks          Streaks
+}
+
+// Totals — итоги диапазона.
+type Totals struct {
+	Sessions        int
+	Sets            int
+	Reps            int
+	VolumeKg        float64
+	TrainingDays    int
+	SessionsPerWeek float64 // Среднее число тренировок в неделю диапазона
+}
+
+// Streaks — серии подряд идущих дней и недель с тренировками за всё время.
+// Текущая серия не прерывается, пока не закончились сегодняшний день или текущая неделя.
+type Streaks struct {
+	CurrentDays  int
+	LongestDays  int
+	CurrentWeeks int
+	LongestWeeks int
+}
+
+// Ошибки бизнес-логики usecase-слоя.
+var (
+	ErrInvalidRange = fmt.Errorf("invalid stats range")
+)
+
+type service struct {
+	stats repo.WorkoutStatsRepository
+	users repo.UserRepository
+	now   func() time.Time
+}
+
+// NewService создаёт сервис статистики тренировок.
+// users нужен, чтобы считать дни и недели в часовом поясе пользователя.
+func NewService(stats repo.WorkoutStatsRepository, users repo.UserRepository) Service {
+	return &service{
+		stats: stats,
+		users: users,
+		now:   time.Now,
+	}
+}
+
+// Stats собирает статистику из агрегатов репозитория: строки тренировок в сервис не загружаются.
+func (s *service) Stats(ctx context.Context, userID uuid.UUID, rng Range) (*Stats, error) {
+	if !rng.IsValid() {
+		return nil, ErrInvalidRange
+	}
+
+	user, err := s.users.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	loc := user.Location()
+	now := s.now().In(loc)
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, loc)
+
+	// Для all нижняя граница не нужна: первая неделя определяется по данным.
+	var from time.Time
+	if days, ok := rangeDays[rng]; ok {
+		from = today.AddDate(0, 0, -(days - 1))
+	}
+
+	weeks, err := s.stats.Weekly(ctx, userID, from, now, loc.String())
+	if err != nil {
+		return nil, err
+	}
+	groups, err := s.stats.MuscleGroups(ctx, userID, from, now)
+	if err != nil {
+		return nil, err
+	}
+	days, weekRuns, err := s.stats.Streaks(ctx, userID, loc.String())
+	if err != nil {
+		return nil, err
+	}
+
+	if rng == RangeAll {
+		from = today
+		if len(weeks) > 0 {
+			first := weeks[0].WeekStart
+			from = time.Date(first.Year(), first.Month(), first.Day(), 0, 0, 0, 0, loc)
+		}
+	}
+	stats := &Stats{
+		Range:        rng,
+		From:         from,
+		To:           now,
+		Timezone:     loc.String(),
+		MuscleGroups: groups,
+		Streaks: Streaks{
+			CurrentDays:  days.Current(civilDate(today.AddDate(0, 0, -1))),
+			LongestDays:  days.Longest,
+			CurrentWeeks: weekRuns.Current(civilDate(weekStart(today).AddDate(0, 0, -7))),
+			LongestWeeks: weekRuns.Longest,
+		},
+	}
+	stats.Weeks = fillWeeks(weeks, civilDate(weekStart(stats.From)), civilDate(weekStart(today)))
+
+	classified := 0
+	for _, g := range groups {
+		classified += g.Sets
+	}
+	for _, w := range stats.Weeks {
+		stats.Totals.Sessions += w.Sessions
+		stats.Totals.Sets += w.Sets
+		stats.Totals.Reps += w.Reps
+		stats.Totals.VolumeKg += w.VolumeKg
+		stats.Totals.TrainingDays += w.TrainingDays
+	}
+	stats.Totals.SessionsPerWeek = float64(stats.Totals.Sessions) / float64(len(stats.Weeks))
+	stats.UnclassifiedSets = max(stats.Totals.Sets-classified, 0)
+	return stats, nil
+}
+
+// fillWeeks дополняет недели без тренировок нулями, чтобы график не имел пропусков.
+func fillWeeks(weeks []domain.WeekStats, first, last time.Time) []domain.WeekStats {
+	byStart := make(map[time.Time]domain.WeekStats, len(weeks))
+	for _, w := range weeks {
+		byStart[civilDate(w.WeekStart)] = w
+	}
+	filled := make([]domain.WeekStats, 0, len(weeks))
+	for start := first; !start.After(last); start = start.AddDate(0, 0, 7) {
+		w := byStart[start]
+		w.WeekStart = start
+		filled = append(filled, w)
+	}
+	return filled
+}
+
+// weekStart возвращает понедельник недели дня t.
+func weekStart(t time.Time) time.Time {
+	offset := (int(t.Weekday()) + 6) % 7
+	return t.AddDate(0, 0, -offset)
+}
+
+// civilDate переносит календарную дату t в полночь UTC — так даты возвращает репозиторий.
+func civilDate(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,158 @@
+package push
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	domain "workout-app/internal/domain/device"
+	"workout-app/internal/domain/notification"
+	repo "workout-app/internal/repository/interfaces"
+	"workout-app/pkg/logger"
+)
+
+// Service описывает usecase-слой push-уведомлений: устройства пользователя и воркер отправки
+// копий уведомлений в приложении на эти устройства.
+type Service interface {
+	// RegisterDevice регистрирует токен устройства текущего пользователя. Повторная регистрация
+	// того же токена обновляет время активности; токен другого пользователя переходит к текущему.
+	RegisterDevice(ctx context.Context, userID uuid.UUID, platform domain.Platform, token string) (*domain.Device, error)
+
+	// ListDevices возвращает устройства пользователя.
+	ListDevices(ctx context.Context, userID uuid.UUID) ([]*domain.Device, error)
+
+	// DeleteDevice отключает push-уведомления на устройстве (например, при выходе из аккаунта).
+	DeleteDevice(ctx context.Context, userID, id uuid.UUID) error
+
+	// Run отправляет ожидающие копии уведомлений на устройства; вызывается периодическим воркером.
+	Run(ctx context.Context) error
+}
+
+// Notifier доставляет копию уведомления на устройства пользователя (internal/push.Notifier).
+type Notifier interface {
+	Notify(ctx context.Context, userID uuid.UUID, locale string, n *notification.Notification) (int, error)
+}
+
+// Config хранит параметры воркера отправки.
+type Config struct {
+	BatchSize int // Уведомлений за один запуск воркера
+}
+
+// Ошибки бизнес-логики usecase-слоя.
+var (
+	ErrInvalidPlatform = fmt.Errorf("platform must be android or ios")
+	ErrInvalidToken    = fmt.Errorf("device token must be 1-%d characters without spaces", domain.MaxTokenLength)
+	ErrDeviceNotFound  = fmt.Errorf("device not found")
+)
+
+// pushMaxAge — копии, не отправленные за этот срок (воркер не работал), отбрасываются:
+// запоздавшее push-уведомление о прошедшем событии только мешает. Уведомление в приложении остаётся.
+const pushMaxAge = time.Hour
+
+type service struct {
+	devices       repo.DeviceRepository
+	notifications repo.NotificationRepository
+	users         repo.UserRepository
+	notifier      Notifier
+	cfg           Config
+	now           func() time.Time
+	logger        logger.Logger
+}
+
+// NewService создаёт сервис push-уведомлений.
+func NewService(
+	devices repo.DeviceRepository,
+	notifications repo.NotificationRepository,
+	users repo.UserRepository,
+	notifier Notifier,
+	cfg Config,
+	logger logger.Logger,
+) Service {
+	return &service{
+		devices:       devices,
+		notifications: notifications,
+		users:         users,
+		notifier:      notifier,
+		cfg:           cfg,
+		now:           func() time.Time { return time.Now().UTC() },
+		logger:        logger,
+	}
+}
+
+// RegisterDevice проверяет платформу и токен и сохраняет устройство.
+func (s *service) RegisterDevice(ctx context.Context, userID uuid.UUID, platform domain.Platform, token string) (*domain.Device, error) {
+	if !platform.IsValid() {
+		return nil, ErrInvalidPlatform
+	}
+	if token == "" || len(token) > domain.MaxTokenLength || strings.ContainsAny(token, " \t\r\n") {
+		return nil, ErrInvalidToken
+	}
+
+	d := domain.NewDevice(userID, platform, token, s.now())
+	if err := s.devices.Upsert(ctx, d); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// ListDevices возвращает устройства пользователя.
+func (s *service) ListDevices(ctx context.Context, userID uuid.UUID) ([]*domain.Device, error) {
+	return s.devices.ListByUser(ctx, userID)
+}
+
+// DeleteDevice удаляет устройство пользователя.
+func (s *service) DeleteDevice(ctx context.Context, userID, id uuid.UUID) error {
+	err := s.devices.Delete(ctx, userID, id)
+	if errors.Is(err, repo.ErrNotFound) {
+		return ErrDeviceNotFound
+	}
+	return err
+}
+
+// Run отправляет ожидающие копии уведомлений. Копия отправляется не более одного раза: отметка ожидания
+// снимается до отправки, а ошибки сервисов push не повторяются (см. internal/push.Notifier).
+func (s *service) Run(ctx context.Context) error {
+	expired, err := s.notifications.ExpirePush(ctx, s.now().Add(-pushMaxAge))
+	if err != nil {
+		return fmt.Errorf("failed to expire push notifications: %w", err)
+	}
+	if expired > 0 {
+		s.logger.Warn("push_notifications_expired", map[string]any{"count": expired})
+	}
+
+	pending, err := s.notifications.ListPushPending(ctx, s.cfg.BatchSize)
+	if err != nil {
+		return fmt.Errorf("failed to list pending push notifications: %w", err)
+	}
+	for _, n := range pending {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		claimed, err := s.notifications.ClaimPush(ctx, n.ID)
+		if err != nil {
+			return fmt.Errorf("failed to claim push notification: %w", err)
+		}
+		if !claimed {
+			continue
+		}
+
+		user, err := s.users.GetByIDIncludingDeleted(ctx, n.UserID)
+		if errors.Is(err, repo.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if user.IsDeleted() || user.IsAnonymized() {
+			continue
+		}
+		if _, err := s.notifier.Notify(ctx, n.UserID, string(user.Language), n); err != nil {
+			return fmt.Errorf("failed to push notification: %w", err)
+		}
+	}
+	return nil
+}
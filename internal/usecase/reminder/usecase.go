@@ -0,0 +1,156 @@
+package reminder
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	eventdomain "workout-app/internal/domain/event"
+	domain "workout-app/internal/domain/reminder"
+	userdomain "workout-app/internal/domain/user"
+	"workout-app/internal/events"
+	repo "workout-app/internal/repository/interfaces"
+	"workout-app/pkg/logger"
+)
+
+// Service описывает usecase-слой напоминаний о тренировках, запланированных на день
+// по назначенным программам. Напоминание публикуется событием workout.reminder; уведомление в приложении
+// и его копии письмом и push создаёт подписчик events.Notifications по настройкам пользователя.
+type Service interface {
+	// Run отправляет напоминания пользователям, у которых в их часовом поясе наступило время напоминания.
+	// Вызывается периодическим воркером.
+	Run(ctx context.Context) error
+
+	// DeleteExpired удаляет не более limit отметок обработанных напоминаний старше срока хранения
+	// (реализует cleanup.Target).
+	DeleteExpired(ctx context.Context, before time.Time, limit int) (int64, error)
+}
+
+// Config хранит параметры воркера напоминаний.
+type Config struct {
+	BatchSize int // Пользователей, читаемых из БД за один запрос
+}
+
+const (
+	// maxReminderDelay — напоминание, которое не удалось отправить в течение этого срока после наступления
+	// времени напоминания (воркер не работал), в этот день уже не отправляется: тренировка могла пройти.
+	maxReminderDelay = 2 * time.Hour
+
+	// reminderRetention — срок хранения отметок: день должен закончиться во всех часовых поясах.
+	reminderRetention = 3 * 24 * time.Hour
+)
+
+type service struct {
+	tx        repo.Transactor
+	users     repo.UserRepository
+	programs  repo.ProgramRepository
+	reminders repo.WorkoutReminderRepository
+	events    events.Publisher
+	cfg       Config
+	now       func() time.Time
+	logger    logger.Logger
+}
+
+// NewService создаёт сервис напоминаний о тренировках.
+func NewService(
+	tx repo.Transactor,
+	users repo.UserRepository,
+	programs repo.ProgramRepository,
+	reminders repo.WorkoutReminderRepository,
+	publisher events.Publisher,
+	cfg Config,
+	logger logger.Logger,
+) Service {
+	return &service{
+		tx:        tx,
+		users:     users,
+		programs:  programs,
+		reminders: reminders,
+		events:    publisher,
+		cfg:       cfg,
+		now:       func() time.Time { return time.Now().UTC() },
+		logger:    logger,
+	}
+}
+
+// Run обходит пользователей с включёнными напоминаниями пачками по ID.
+// Ошибка одного пользователя логируется и не мешает остальным; он будет обработан следующим запуском.
+func (s *service) Run(ctx context.Context) error {
+	now := s.now()
+	after := uuid.Nil
+	for {
+		users, err := s.users.ListReminderRecipients(ctx, after, s.cfg.BatchSize)
+		if err != nil {
+			return fmt.Errorf("failed to list reminder recipients: %w", err)
+		}
+		for _, u := range users {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			if err := s.remind(ctx, u, now); err != nil {
+				s.logger.Error("workout_reminder_failed", map[string]any{"user_id": u.ID.String(), "error": err.Error()})
+			}
+		}
+		if len(users) < s.cfg.BatchSize {
+			return nil
+		}
+		after = users[len(users)-1].ID
+	}
+}
+
+// remind отправляет пользователю напоминание за текущий день, если наступило его время и оно ещё не отправлено.
+// Отметка и событие сохраняются в одной транзакции, поэтому параллельные воркеры не отправят напоминание дважды.
+func (s *service) remind(ctx context.Context, u *userdomain.User, now time.Time) error {
+	if u.ReminderTime == nil || u.IsSuspended(now) {
+		return nil
+	}
+	local := now.In(u.Location())
+	since := time.Duration(local.Hour()*60+local.Minute()-int(*u.ReminderTime)) * time.Minute
+	if since < 0 || since > maxReminderDelay {
+		return nil
+	}
+	date := domain.LocalDate(now, u.Location())
+
+	return s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		claimed, err := s.reminders.Claim(ctx, domain.New(u.ID, date, now))
+		if err != nil || !claimed {
+			return err
+		}
+		workouts, err := s.plannedWorkouts(ctx, u.ID, date)
+		if err != nil || len(workouts) == 0 {
+			return err
+		}
+		s.events.Publish(ctx, eventdomain.TypeWorkoutReminder, u.ID.String(), eventdomain.WorkoutReminder{
+			UserID:   u.ID.String(),
+			Date:     date,
+			Workouts: workouts,
+		})
+		return nil
+	})
+}
+
+// plannedWorkouts возвращает названия тренировок назначенных пользователю программ на день date.
+func (s *service) plannedWorkouts(ctx context.Context, userID uuid.UUID, date time.Time) ([]string, error) {
+	assignments, err := s.programs.ListAssignmentsByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	var titles []string
+	for _, a := range assignments {
+		p, err := s.programs.GetByID(ctx, a.ProgramID)
+		if err != nil {
+			return nil, err
+		}
+		for _, sw := range p.Schedule(a.StartDate, date, date.AddDate(0, 0, 1)) {
+			titles = append(titles, sw.Workout.Title)
+		}
+	}
+	return titles, nil
+}
+
+// DeleteExpired удаляет отметки старше срока хранения.
+func (s *service) DeleteExpired(ctx context.Context, before time.Time, limit int) (int64, error) {
+	return s.reminders.DeleteBefore(ctx, before.Add(-reminderRetention), limit)
+}
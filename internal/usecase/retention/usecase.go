@@ -0,0 +1,121 @@
+package retention
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/google/uuid"
+
+	repo "workout-app/internal/repository/interfaces"
+	anonymizationuc "workout-app/internal/usecase/anonymization"
+	"workout-app/pkg/logger"
+)
+
+// Service описывает usecase-слой окончательного удаления аккаунтов, мягко удалённых
+// раньше срока хранения (требование GDPR о сроке хранения персональных данных).
+type Service interface {
+	// Run обезличивает аккаунты с истёкшим сроком хранения и возвращает итог запуска.
+	// Аккаунты под юридическим удержанием пропускаются до его снятия.
+	Run(ctx context.Context) (Result, error)
+
+	// Purged возвращает количество аккаунтов, обезличенных с момента старта процесса.
+	Purged() int64
+}
+
+// Result описывает итог одного запуска.
+type Result struct {
+	Purged  int // Обезличено аккаунтов
+	Skipped int // Пропущено: удержание установлено или аккаунт обезличен параллельно
+}
+
+// batchSize — сколько аккаунтов выбирается за раз; каждый обезличивается в своей транзакции.
+const batchSize = 100
+
+// maxBatchesPerRun ограничивает длительность одного запуска; остаток обрабатывается следующим.
+const maxBatchesPerRun = 50
+
+type service struct {
+	users     repo.UserRepository
+	purger    anonymizationuc.Service
+	retention time.Duration
+	now       func() time.Time
+	logger    logger.Logger
+
+	purged atomic.Int64
+}
+
+// NewService создаёт сервис хранения. Аккаунт обезличивается через retention после мягкого удаления.
+func NewService(users repo.UserRepository, purger anonymizationuc.Service, retention time.Duration, logger logger.Logger) Service {
+	return &service{
+		users:     users,
+		purger:    purger,
+		retention: retention,
+		now:       time.Now,
+		logger:    logger,
+	}
+}
+
+// Run обезличивает аккаунты с истёкшим сроком хранения пачками.
+func (s *service) Run(ctx context.Context) (Result, error) {
+	var result Result
+	before := s.now().UTC().Add(-s.retention)
+
+	for i := 0; i < maxBatchesPerRun; i++ {
+		ids, err := s.users.ListPurgeCandidates(ctx, before, batchSize)
+		if err != nil {
+			return result, fmt.Errorf("failed to list accounts to purge: %w", err)
+		}
+		for _, id := range ids {
+			if err := ctx.Err(); err != nil {
+				return result, err
+			}
+			purged, err := s.purge(ctx, id)
+			if err != nil {
+				// Повторная выборка вернула бы тот же аккаунт, поэтому запуск прерывается до следующего.
+				return result, err
+			}
+			if purged {
+				result.Purged++
+			} else {
+				result.Skipped++
+			}
+		}
+		if len(ids) < batchSize {
+			break
+		}
+	}
+
+	if result.Purged > 0 || result.Skipped > 0 {
+		s.logger.Info("retention_run_completed", map[string]any{
+			"purged":  result.Purged,
+			"skipped": result.Skipped,
+		})
+	}
+	return result, nil
+}
+
+// purge обезличивает один аккаунт. Возвращает false, если аккаунт пропущен.
+func (s *service) purge(ctx context.Context, id uuid.UUID) (bool, error) {
+	err := s.purger.Anonymize(ctx, id)
+	switch {
+	case err == nil:
+		s.purged.Add(1)
+		s.logger.Info("retention_user_purged", map[string]any{"user_id": id.String()})
+		return true, nil
+	case errors.Is(err, anonymizationuc.ErrUnderLegalHold),
+		errors.Is(err, anonymizationuc.ErrAlreadyAnonymized),
+		errors.Is(err, repo.ErrNotFound):
+		// Удержание установлено или аккаунт обработан параллельно после выборки.
+		return false, nil
+	default:
+		return false, fmt.Errorf("failed to purge user %s: %w", id, err)
+	}
+}
+
+// Purged возвращает количество обезличенных аккаунтов с момента старта процесса.
+func (s *service) Purged() int64 {
+	return s.purged.Load()
+}
@@ -0,0 +1,208 @@
+package share
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	programdomain "workout-app/internal/domain/program"
+	domain "workout-app/internal/domain/share"
+	workoutdomain "workout-app/internal/domain/workout"
+	repo "workout-app/internal/repository/interfaces"
+)
+
+// Service описывает usecase-слой публичных ссылок: владелец тренировки или автор программы
+// создаёт ссылку, по которой её можно посмотреть без аутентификации, и может отозвать ссылку.
+type Service interface {
+	// Create создаёт ссылку на тренировку или программу пользователя. Токен возвращается
+	// только здесь: в БД хранится его хэш.
+	Create(ctx context.Context, userID uuid.UUID, resourceType domain.ResourceType, resourceID uuid.UUID) (*Created, error)
+
+	// List возвращает неотозванные ссылки пользователя, новые первыми.
+	List(ctx context.Context, userID uuid.UUID) ([]*domain.Share, error)
+
+	// Revoke отзывает ссылку пользователя.
+	Revoke(ctx context.Context, userID, shareID uuid.UUID) error
+
+	// View возвращает данные по токену ссылки. Отозванная ссылка, удалённые данные и удалённый
+	// или заблокированный владелец неотличимы от несуществующей ссылки.
+	View(ctx context.Context, token string) (*View, error)
+}
+
+// Created описывает новую ссылку с токеном.
+type Created struct {
+	Share *domain.Share
+	Token string
+}
+
+// View описывает данные, открытые по ссылке: задано одно из полей Workout и Program.
+type View struct {
+	Share   *domain.Share
+	Workout *workoutdomain.Session
+	Program *programdomain.Program
+}
+
+// Ошибки бизнес-логики usecase-слоя.
+var (
+	ErrInvalidResourceType = fmt.Errorf("only workouts and programs can be shared")
+	ErrResourceNotFound    = fmt.Errorf("workout or program not found")
+	ErrShareNotFound       = fmt.Errorf("share link not found")
+	ErrTooManyShares       = fmt.Errorf("too many active share links")
+)
+
+const (
+	// tokenBytes — длина случайной части токена: подобрать его перебором невозможно.
+	tokenBytes = 32
+	// maxActiveShares ограничивает неотозванные ссылки одного пользователя.
+	maxActiveShares = 100
+)
+
+type service struct {
+	shares   repo.ShareRepository
+	workouts repo.WorkoutSessionRepository
+	programs repo.ProgramRepository
+	users    repo.UserRepository
+}
+
+// NewService создаёт новый сервис публичных ссылок.
+func NewService(shares repo.ShareRepository, workouts repo.WorkoutSessionRepository, programs repo.ProgramRepository, users repo.UserRepository) Service {
+	return &service{
+		shares:   shares,
+		workouts: workouts,
+		programs: programs,
+		users:    users,
+	}
+}
+
+// Create создаёт ссылку; делиться можно только своей тренировкой или программой, автором которой является пользователь.
+func (s *service) Create(ctx context.Context, userID uuid.UUID, resourceType domain.ResourceType, resourceID uuid.UUID) (*Created, error) {
+	if !resourceType.IsValid() {
+		return nil, ErrInvalidResourceType
+	}
+	ownerID, err := s.resourceOwner(ctx, resourceType, resourceID)
+	if err != nil {
+		return nil, err
+	}
+	if ownerID != userID {
+		return nil, ErrResourceNotFound
+	}
+
+	active, err := s.shares.CountActiveByOwner(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if active >= maxActiveShares {
+		return nil, ErrTooManyShares
+	}
+
+	token, err := generateToken()
+	if err != nil {
+		return nil, err
+	}
+	share := domain.New(userID, resourceType, resourceID, token, time.Now().UTC())
+	if err := s.shares.Create(ctx, share); err != nil {
+		return nil, err
+	}
+	return &Created{Share: share, Token: token}, nil
+}
+
+// List возвращает неотозванные ссылки пользователя.
+func (s *service) List(ctx context.Context, userID uuid.UUID) ([]*domain.Share, error) {
+	return s.shares.ListActiveByOwner(ctx, userID)
+}
+
+// Revoke отзывает ссылку пользователя.
+func (s *service) Revoke(ctx context.Context, userID, shareID uuid.UUID) error {
+	err := s.shares.Revoke(ctx, shareID, userID, time.Now().UTC())
+	if errors.Is(err, repo.ErrNotFound) {
+		return ErrShareNotFound
+	}
+	return err
+}
+
+// View возвращает данные по токену ссылки.
+func (s *service) View(ctx context.Context, token string) (*View, error) {
+	if token == "" {
+		return nil, ErrShareNotFound
+	}
+	share, err := s.shares.GetByTokenHash(ctx, domain.HashToken(token))
+	if errors.Is(err, repo.ErrNotFound) {
+		return nil, ErrShareNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if share.IsRevoked() {
+		return nil, ErrShareNotFound
+	}
+
+	owner, err := s.users.GetByID(ctx, share.OwnerID)
+	if errors.Is(err, repo.ErrNotFound) {
+		return nil, ErrShareNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if owner.IsSuspended(time.Now()) {
+		return nil, ErrShareNotFound
+	}
+
+	view := &View{Share: share}
+	switch share.ResourceType {
+	case domain.ResourceWorkout:
+		view.Workout, err = s.workouts.GetByID(ctx, share.ResourceID)
+		if err == nil && view.Workout.UserID != share.OwnerID {
+			err = repo.ErrNotFound
+		}
+	case domain.ResourceProgram:
+		view.Program, err = s.programs.GetByID(ctx, share.ResourceID)
+		if err == nil && view.Program.OwnerID != share.OwnerID {
+			err = repo.ErrNotFound
+		}
+	default:
+		err = repo.ErrNotFound
+	}
+	if errors.Is(err, repo.ErrNotFound) {
+		return nil, ErrShareNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return view, nil
+}
+
+// resourceOwner возвращает владельца тренировки или автора программы.
+func (s *service) resourceOwner(ctx context.Context, resourceType domain.ResourceType, resourceID uuid.UUID) (uuid.UUID, error) {
+	var ownerID uuid.UUID
+	var err error
+	switch resourceType {
+	case domain.ResourceWorkout:
+		var session *workoutdomain.Session
+		if session, err = s.workouts.GetByID(ctx, resourceID); err == nil {
+			ownerID = session.UserID
+		}
+	case domain.ResourceProgram:
+		var program *programdomain.Program
+		if program, err = s.programs.GetByID(ctx, resourceID); err == nil {
+			ownerID = program.OwnerID
+		}
+	}
+	if errors.Is(err, repo.ErrNotFound) {
+		return uuid.Nil, ErrResourceNotFound
+	}
+	return ownerID, err
+}
+
+// generateToken возвращает случайный токен ссылки.
+func generateToken() (string, error) {
+	raw := make([]byte, tokenBytes)
+	if _, err := rand.Read(raw); err != nil {
+		return "", fmt.Errorf("failed to generate share token: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(raw), nil
+}
@@ -0,0 +1,120 @@
+package social
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"workout-app/internal/domain/program"
+	domain "workout-app/internal/domain/social"
+	"workout-app/internal/domain/strength"
+)
+
+// recordMaxReps — подходы с большим числом повторений не учитываются в рекордах:
+// формула Эпли на них заметно завышает разовый максимум (как в отчёте о силовых рекордах).
+const recordMaxReps = 10
+
+// Feed собирает страницу ленты при чтении и кеширует её. Кеш не источник истины:
+// ошибки кеша не прерывают запрос. Подписки и отписки в кеше не сбрасываются —
+// лента обновляется по истечении feedTTL.
+func (s *service) Feed(ctx context.Context, viewerID uuid.UUID, limit, offset int) ([]*domain.FeedEntry, int64, error) {
+	limit, offset = normalizePage(limit, offset)
+	key := fmt.Sprintf("%s:%d:%d", viewerID, limit, offset)
+
+	if s.feedCache != nil {
+		if raw, err := s.feedCache.Get(ctx, key); err == nil {
+			var page feedPage
+			if err := json.Unmarshal(raw, &page); err == nil {
+				return page.Entries, page.Total, nil
+			}
+		}
+	}
+
+	entries, total, err := s.feed.ListWorkouts(ctx, viewerID, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	if err := s.attachRecords(ctx, entries); err != nil {
+		return nil, 0, err
+	}
+
+	if s.feedCache != nil {
+		if raw, err := json.Marshal(feedPage{Entries: entries, Total: total}); err == nil {
+			_ = s.feedCache.Set(ctx, key, raw, s.feedTTL)
+		}
+	}
+	return entries, total, nil
+}
+
+// feedPage — страница ленты в кеше.
+type feedPage struct {
+	Entries []*domain.FeedEntry `json:"entries"`
+	Total   int64               `json:"total"`
+}
+
+// attachRecords находит личные рекорды, поставленные в тренировках страницы.
+// История подходов читается один раз на автора страницы.
+func (s *service) attachRecords(ctx context.Context, entries []*domain.FeedEntry) error {
+	bySession := make(map[uuid.UUID]*domain.FeedEntry, len(entries))
+	authors := make([]uuid.UUID, 0, len(entries))
+	seen := make(map[uuid.UUID]bool, len(entries))
+	for _, entry := range entries {
+		entry.Records = []domain.Record{}
+		bySession[entry.Session.ID] = entry
+		if !seen[entry.Author.UserID] {
+			seen[entry.Author.UserID] = true
+			authors = append(authors, entry.Author.UserID)
+		}
+	}
+
+	for _, authorID := range authors {
+		sets, err := s.workouts.ListWeightedSets(ctx, authorID, recordMaxReps)
+		if err != nil {
+			return err
+		}
+		// Подходы идут по времени записи: рекорд — подход, превысивший лучшую оценку всех предыдущих.
+		// Первый подход упражнения рекордом не считается: сравнивать не с чем.
+		best := make(map[string]float64)
+		recordIdx := make(map[uuid.UUID]map[string]int)
+		for _, set := range sets {
+			if set.WeightKg == nil || *set.WeightKg <= 0 || set.Reps < 1 {
+				continue
+			}
+			key := program.ExerciseKey(set.Exercise)
+			oneRM := strength.EstimateOneRepMax(*set.WeightKg, set.Reps)
+			previous, ok := best[key]
+			if ok && oneRM <= previous {
+				continue
+			}
+			best[key] = oneRM
+			entry, onPage := bySession[set.SessionID]
+			if !ok || !onPage {
+				continue
+			}
+
+			// В одной тренировке по упражнению остаётся лучший подход, а прежним рекордом —
+			// результат до тренировки.
+			if recordIdx[set.SessionID] == nil {
+				recordIdx[set.SessionID] = make(map[string]int)
+			}
+			record := domain.Record{
+				Exercise:            set.Exercise,
+				WeightKg:            *set.WeightKg,
+				Reps:                set.Reps,
+				OneRepMaxKg:         oneRM,
+				PreviousOneRepMaxKg: previous,
+				AchievedAt:          set.LoggedAt,
+			}
+			if i, exists := recordIdx[set.SessionID][key]; exists {
+				record.PreviousOneRepMaxKg = entry.Records[i].PreviousOneRepMaxKg
+				entry.Records[i] = record
+				continue
+			}
+			recordIdx[set.SessionID][key] = len(entry.Records)
+			entry.Records = append(entry.Records, record)
+		}
+	}
+	return nil
+}
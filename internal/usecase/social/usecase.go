@@ -0,0 +1,166 @@
+package social
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	domain "workout-app/internal/domain/social"
+	userdomain "workout-app/internal/domain/user"
+	repo "workout-app/internal/repository/interfaces"
+	"workout-app/pkg/cache"
+)
+
+// Checker проверяет, может ли пользователь видеть тренировки другого пользователя.
+// Используется usecase-слоями, которые отдают тренировки не их владельцу.
+type Checker interface {
+	// RequireWorkoutsAccess возвращает repo.ErrNotFound, если владельца нет,
+	// и ErrWorkoutsHidden, если его настройка видимости не открывает тренировки viewerID.
+	RequireWorkoutsAccess(ctx context.Context, viewerID, ownerID uuid.UUID) error
+}
+
+// Service описывает usecase-слой подписок пользователей друг на друга.
+type Service interface {
+	Checker
+
+	// Follow подписывает followerID на followeeID. Повторная подписка не считается ошибкой.
+	Follow(ctx context.Context, followerID, followeeID uuid.UUID) error
+
+	// Unfollow отменяет подписку. Отсутствие подписки не считается ошибкой.
+	Unfollow(ctx context.Context, followerID, followeeID uuid.UUID) error
+
+	// ListFollowers возвращает страницу подписчиков пользователя и их общее количество.
+	ListFollowers(ctx context.Context, userID uuid.UUID, limit, offset int) ([]*domain.Connection, int64, error)
+
+	// ListFollowing возвращает страницу подписок пользователя и их общее количество.
+	ListFollowing(ctx context.Context, userID uuid.UUID, limit, offset int) ([]*domain.Connection, int64, error)
+
+	// Feed возвращает страницу ленты активности viewerID: завершённые тренировки пользователей,
+	// на которых он подписан, с личными рекордами, новые первыми, и общее количество записей.
+	Feed(ctx context.Context, viewerID uuid.UUID, limit, offset int) ([]*domain.FeedEntry, int64, error)
+}
+
+// Ошибки бизнес-логики usecase-слоя.
+var (
+	ErrCannotFollowSelf = fmt.Errorf("cannot follow yourself")
+	ErrWorkoutsHidden   = fmt.Errorf("workouts are hidden by the owner")
+)
+
+const (
+	// maxListLimit ограничивает размер страницы списков.
+	maxListLimit = 100
+	// defaultListLimit — размер страницы по умолчанию.
+	defaultListLimit = 20
+)
+
+type service struct {
+	follows  repo.FollowRepository
+	users    repo.UserRepository
+	feed     repo.FeedRepository
+	workouts repo.WorkoutSessionRepository
+	// feedCache — кеш страниц ленты (nil — без кеша).
+	feedCache cache.Cache
+	feedTTL   time.Duration
+}
+
+// NewService создаёт новый сервис подписок.
+// feedCache кеширует страницы ленты на feedTTL; nil или нулевой feedTTL отключают кеш.
+func NewService(
+	follows repo.FollowRepository,
+	users repo.UserRepository,
+	feed repo.FeedRepository,
+	workouts repo.WorkoutSessionRepository,
+	feedCache cache.Cache,
+	feedTTL time.Duration,
+) Service {
+	if feedTTL <= 0 {
+		feedCache = nil
+	}
+	return &service{
+		follows:   follows,
+		users:     users,
+		feed:      feed,
+		workouts:  workouts,
+		feedCache: feedCache,
+		feedTTL:   feedTTL,
+	}
+}
+
+// Follow подписывает пользователя на другого пользователя.
+func (s *service) Follow(ctx context.Context, followerID, followeeID uuid.UUID) error {
+	if followerID == followeeID {
+		return ErrCannotFollowSelf
+	}
+	if _, err := s.users.GetByID(ctx, followeeID); err != nil {
+		return err
+	}
+	return s.follows.Create(ctx, domain.NewFollow(followerID, followeeID, time.Now().UTC()))
+}
+
+// Unfollow отменяет подписку.
+func (s *service) Unfollow(ctx context.Context, followerID, followeeID uuid.UUID) error {
+	if followerID == followeeID {
+		return ErrCannotFollowSelf
+	}
+	return s.follows.Delete(ctx, followerID, followeeID)
+}
+
+// ListFollowers возвращает подписчиков пользователя.
+func (s *service) ListFollowers(ctx context.Context, userID uuid.UUID, limit, offset int) ([]*domain.Connection, int64, error) {
+	if _, err := s.users.GetByID(ctx, userID); err != nil {
+		return nil, 0, err
+	}
+	limit, offset = normalizePage(limit, offset)
+	return s.follows.ListFollowers(ctx, userID, limit, offset)
+}
+
+// ListFollowing возвращает подписки пользователя.
+func (s *service) ListFollowing(ctx context.Context, userID uuid.UUID, limit, offset int) ([]*domain.Connection, int64, error) {
+	if _, err := s.users.GetByID(ctx, userID); err != nil {
+		return nil, 0, err
+	}
+	limit, offset = normalizePage(limit, offset)
+	return s.follows.ListFollowing(ctx, userID, limit, offset)
+}
+
+// RequireWorkoutsAccess проверяет настройку видимости тренировок владельца.
+// Владелец всегда видит свои тренировки; пустая настройка означает private.
+func (s *service) RequireWorkoutsAccess(ctx context.Context, viewerID, ownerID uuid.UUID) error {
+	if viewerID == ownerID {
+		return nil
+	}
+	owner, err := s.users.GetByID(ctx, ownerID)
+	if err != nil {
+		return err
+	}
+
+	switch owner.WorkoutsVisibility {
+	case userdomain.WorkoutsPublic:
+		return nil
+	case userdomain.WorkoutsFollowers:
+		following, err := s.follows.Exists(ctx, viewerID, ownerID)
+		if err != nil {
+			return err
+		}
+		if following {
+			return nil
+		}
+	}
+	return ErrWorkoutsHidden
+}
+
+// normalizePage приводит параметры страницы к допустимым значениям.
+func normalizePage(limit, offset int) (int, int) {
+	if offset < 0 {
+		offset = 0
+	}
+	if limit <= 0 {
+		limit = defaultListLimit
+	}
+	if limit > maxListLimit {
+		limit = maxListLimit
+	}
+	return limit, offset
+}
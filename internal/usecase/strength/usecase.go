@@ -0,0 +1,256 @@
+package strength
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+
+	"workout-app/internal/domain/program"
+	domain "workout-app/internal/domain/strength"
+	repo "workout-app/internal/repository/interfaces"
+)
+
+// Service описывает usecase-слой личных рекордов и нормативов силы: лучший результат
+// пользователя в каждом упражнении с уровнем и процентилем относительно атлетов того же пола и веса.
+type Service interface {
+	// Records возвращает личные рекорды пользователя. Пол и вес тела берутся из профиля
+	// и последнего замера, если не заданы в opts; без них рекорды возвращаются без оценки.
+	Records(ctx context.Context, userID uuid.UUID, opts RecordsOptions) (*RecordsReport, error)
+
+	// ListStandards возвращает все нормативы силы.
+	ListStandards(ctx context.Context) ([]*domain.Standard, error)
+
+	// SetStandard заменяет норматив упражнения для пола (администрирование).
+	SetStandard(ctx context.Context, input StandardInput) (*domain.Standard, error)
+}
+
+// RecordsOptions переопределяет пол и вес тела, по которым оцениваются рекорды.
+type RecordsOptions struct {
+	Gender       string
+	BodyWeightKg *float64
+}
+
+// RecordsReport — личные рекорды пользователя и параметры, по которым они оценены.
+type RecordsReport struct {
+	Gender       domain.Gender // Пусто, если пол неизвестен
+	BodyWeightKg *float64      // nil, если вес тела неизвестен
+	Records      []Record      // По алфавиту упражнений
+}
+
+// Record — лучший подход пользователя в упражнении.
+type Record struct {
+	Exercise       string
+	WeightKg       float64
+	Reps           int
+	OneRepMaxKg    float64 // Оценка разового максимума по лучшему подходу
+	SessionID      uuid.UUID
+	AchievedAt     time.Time
+	Standard       string                 // Упражнение норматива; пусто, если норматива нет
+	Classification *domain.Classification // nil без норматива, пола или веса тела
+}
+
+// StandardInput описывает новый норматив упражнения для пола.
+type StandardInput struct {
+	Exercise string
+	Gender   string
+	Aliases  []string // nil — оставить прежние синонимы
+	Rows     []domain.Row
+}
+
+// Ошибки бизнес-логики usecase-слоя.
+var (
+	ErrUserNotFound      = fmt.Errorf("user not found")
+	ErrInvalidGender     = fmt.Errorf("invalid gender")
+	ErrInvalidBodyWeight = fmt.Errorf("invalid body weight")
+	ErrInvalidStandard   = fmt.Errorf("invalid strength standard")
+	ErrAliasTaken        = fmt.Errorf("exercise alias already belongs to another exercise")
+)
+
+// Ограничения рекордов и нормативов.
+const (
+	// maxRecordReps — подходы с большим числом повторений плохо оценивают разовый максимум.
+	maxRecordReps     = 10
+	minBodyWeightKg   = 20
+	maxBodyWeightKg   = 400
+	maxExerciseLength = 100
+	maxStandardRows   = 50
+	maxAliases        = 20
+)
+
+type service struct {
+	standards   repo.StrengthStandardRepository
+	workouts    repo.WorkoutSessionRepository
+	users       repo.UserRepository
+	bodyMetrics repo.BodyMetricRepository
+}
+
+// NewService создаёт новый сервис рекордов и нормативов силы.
+func NewService(
+	standards repo.StrengthStandardRepository,
+	workouts repo.WorkoutSessionRepository,
+	users repo.UserRepository,
+	bodyMetrics repo.BodyMetricRepository,
+) Service {
+	return &service{
+		standards:   standards,
+		workouts:    workouts,
+		users:       users,
+		bodyMetrics: bodyMetrics,
+	}
+}
+
+// Records собирает лучший по оценке разового максимума подход в каждом упражнении и оценивает его по нормативу.
+func (s *service) Records(ctx context.Context, userID uuid.UUID, opts RecordsOptions) (*RecordsReport, error) {
+	report := &RecordsReport{Records: []Record{}}
+
+	if opts.Gender != "" {
+		gender, ok := domain.ParseGender(opts.Gender)
+		if !ok {
+			return nil, ErrInvalidGender
+		}
+		report.Gender = gender
+	} else {
+		u, err := s.users.GetByID(ctx, userID)
+		if err != nil {
+			if errors.Is(err, repo.ErrNotFound) {
+				return nil, ErrUserNotFound
+			}
+			return nil, err
+		}
+		report.Gender, _ = domain.ParseGender(u.Gender)
+	}
+
+	if opts.BodyWeightKg != nil {
+		bw := *opts.BodyWeightKg
+		if bw < minBodyWeightKg || bw > maxBodyWeightKg {
+			return nil, ErrInvalidBodyWeight
+		}
+		report.BodyWeightKg = &bw
+	} else {
+		summary, err := s.bodyMetrics.GetLatestSummary(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+		if summary.WeightKg != nil {
+			bw := summary.WeightKg.Value
+			report.BodyWeightKg = &bw
+		}
+	}
+
+	sets, err := s.workouts.ListWeightedSets(ctx, userID, maxRecordReps)
+	if err != nil {
+		return nil, err
+	}
+	best := make(map[string]*Record)
+	for _, set := range sets {
+		if set.WeightKg == nil || *set.WeightKg <= 0 || set.Reps < 1 {
+			continue
+		}
+		key := program.ExerciseKey(set.Exercise)
+		oneRM := domain.EstimateOneRepMax(*set.WeightKg, set.Reps)
+		// Подходы идут по времени записи: при равной оценке рекордом остаётся более ранний.
+		if current, ok := best[key]; ok && current.OneRepMaxKg >= oneRM {
+			continue
+		}
+		best[key] = &Record{
+			Exercise:    set.Exercise,
+			WeightKg:    *set.WeightKg,
+			Reps:        set.Reps,
+			OneRepMaxKg: oneRM,
+			SessionID:   set.SessionID,
+			AchievedAt:  set.LoggedAt,
+		}
+	}
+
+	standards, err := s.standards.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	catalog := domain.NewCatalog(standards)
+
+	for _, rec := range best {
+		if report.Gender != "" {
+			if std := catalog.Find(rec.Exercise, report.Gender); std != nil {
+				rec.Standard = std.Exercise
+				if report.BodyWeightKg != nil {
+					c := std.Classify(*report.BodyWeightKg, rec.OneRepMaxKg)
+					rec.Classification = &c
+				}
+			}
+		}
+		report.Records = append(report.Records, *rec)
+	}
+	sort.Slice(report.Records, func(i, j int) bool {
+		return program.ExerciseKey(report.Records[i].Exercise) < program.ExerciseKey(report.Records[j].Exercise)
+	})
+	return report, nil
+}
+
+// ListStandards возвращает все нормативы силы.
+func (s *service) ListStandards(ctx context.Context) ([]*domain.Standard, error) {
+	return s.standards.List(ctx)
+}
+
+// SetStandard проверяет и сохраняет норматив; упражнение и синонимы хранятся в виде ключей program.ExerciseKey.
+func (s *service) SetStandard(ctx context.Context, input StandardInput) (*domain.Standard, error) {
+	exercise := program.ExerciseKey(input.Exercise)
+	if exercise == "" || utf8.RuneCountInString(exercise) > maxExerciseLength {
+		return nil, ErrInvalidStandard
+	}
+	gender, ok := domain.ParseGender(input.Gender)
+	if !ok {
+		return nil, ErrInvalidGender
+	}
+	if len(input.Rows) > maxStandardRows || len(input.Aliases) > maxAliases {
+		return nil, ErrInvalidStandard
+	}
+
+	rows := append([]domain.Row(nil), input.Rows...)
+	sort.Slice(rows, func(i, j int) bool { return rows[i].BodyWeightKg < rows[j].BodyWeightKg })
+	std := &domain.Standard{Exercise: exercise, Gender: gender, Rows: rows}
+	if !std.Validate() {
+		return nil, ErrInvalidStandard
+	}
+
+	if input.Aliases != nil {
+		std.Aliases = []string{}
+		seen := map[string]bool{exercise: true}
+		for _, alias := range input.Aliases {
+			key := program.ExerciseKey(alias)
+			if key == "" || utf8.RuneCountInString(key) > maxExerciseLength {
+				return nil, ErrInvalidStandard
+			}
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			std.Aliases = append(std.Aliases, key)
+		}
+	}
+
+	if err := s.standards.Replace(ctx, std); err != nil {
+		if errors.Is(err, repo.ErrAliasTaken) {
+			return nil, ErrAliasTaken
+		}
+		return nil, err
+	}
+	if std.Aliases == nil {
+		// Синонимы не менялись — возвращаем сохранённые.
+		standards, err := s.standards.List(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, existing := range standards {
+			if existing.Exercise == std.Exercise {
+				std.Aliases = existing.Aliases
+				break
+			}
+		}
+	}
+	return std, nil
+}
@@ -0,0 +1,85 @@
+package suppression
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// importRow — адрес и комментарий из одной строки выгрузки.
+type importRow struct {
+	email  string
+	reason string
+}
+
+// Названия колонок с адресом и причиной в выгрузках SendGrid, Mailgun, Amazon SES и т.п.
+var (
+	emailColumns  = map[string]bool{"email": true, "address": true, "recipient": true, "email address": true, "emailaddress": true}
+	reasonColumns = map[string]bool{"reason": true, "error": true, "status": true, "description": true}
+)
+
+// parseImport разбирает CSV-выгрузку. Если первая строка содержит заголовок с колонкой адреса,
+// используются колонки из заголовка; иначе адрес берётся из первой колонки каждой строки.
+func parseImport(data io.Reader) ([]importRow, error) {
+	reader := csv.NewReader(data)
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+	reader.TrimLeadingSpace = true
+
+	emailCol, reasonCol := 0, -1
+	var rows []importRow
+	first := true
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidImportFile, err)
+		}
+		if len(record) == 0 || (len(record) == 1 && strings.TrimSpace(record[0]) == "") {
+			continue
+		}
+
+		if first {
+			first = false
+			if col, reason, ok := headerColumns(record); ok {
+				emailCol, reasonCol = col, reason
+				continue
+			}
+		}
+
+		if len(rows) >= maxImportRows {
+			return nil, ErrImportTooLarge
+		}
+		row := importRow{}
+		if emailCol < len(record) {
+			row.email = record[emailCol]
+		}
+		if reasonCol >= 0 && reasonCol < len(record) {
+			row.reason = strings.TrimSpace(record[reasonCol])
+		}
+		rows = append(rows, row)
+	}
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("%w: no addresses found", ErrInvalidImportFile)
+	}
+	return rows, nil
+}
+
+// headerColumns ищет в строке заголовка колонки адреса и причины.
+func headerColumns(record []string) (emailCol, reasonCol int, ok bool) {
+	emailCol, reasonCol = -1, -1
+	for i, cell := range record {
+		name := strings.ToLower(strings.TrimSpace(strings.TrimPrefix(cell, "\ufeff")))
+		switch {
+		case emailColumns[name] && emailCol < 0:
+			emailCol = i
+		case reasonColumns[name] && reasonCol < 0:
+			reasonCol = i
+		}
+	}
+	return emailCol, reasonCol, emailCol >= 0
+}
@@ -0,0 +1,206 @@
+package suppression
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/mail"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	domain "workout-app/internal/domain/deliverability"
+	repo "workout-app/internal/repository/interfaces"
+)
+
+// Service описывает usecase-слой глобального списка подавления писем.
+// Список пополняется автоматически по webhooks провайдеров (bounce, complaint),
+// вручную администраторами и импортом выгрузок провайдеров; отправитель писем проверяет его перед каждой отправкой.
+type Service interface {
+	// IsSuppressed сообщает, запрещена ли отправка писем на адрес.
+	IsSuppressed(ctx context.Context, email string) (bool, error)
+
+	// Add вручную добавляет адрес в список.
+	Add(ctx context.Context, actorID uuid.UUID, email, reason string) (*domain.Suppression, error)
+
+	// Remove удаляет адрес из списка, снова разрешая отправку.
+	Remove(ctx context.Context, email string) error
+
+	// List возвращает записи списка (новые первыми) и общее количество подходящих записей.
+	List(ctx context.Context, source domain.SuppressionSource, limit, offset int) ([]*domain.Suppression, int64, error)
+
+	// Import добавляет адреса из CSV-выгрузки провайдера. Уже присутствующие адреса не меняются.
+	Import(ctx context.Context, actorID uuid.UUID, input ImportInput) (*ImportResult, error)
+}
+
+// ImportInput описывает выгрузку провайдера для импорта.
+type ImportInput struct {
+	Provider string                   // Провайдер, из которого сделана выгрузка
+	Source   domain.SuppressionSource // Тип выгрузки: bounce, complaint или manual
+	Data     io.Reader                // CSV; колонка с адресом определяется по заголовку (email, address, recipient), иначе — первая
+}
+
+// ImportResult описывает итог импорта.
+type ImportResult struct {
+	Added   int // Новых адресов в списке
+	Skipped int // Уже были в списке
+	Invalid int // Строк с некорректным адресом
+}
+
+// Ошибки бизнес-логики usecase-слоя.
+var (
+	ErrInvalidEmail      = fmt.Errorf("invalid email address")
+	ErrInvalidSource     = fmt.Errorf("invalid suppression source")
+	ErrInvalidProvider   = fmt.Errorf("invalid email provider name")
+	ErrAlreadySuppressed = fmt.Errorf("email is already suppressed")
+	ErrInvalidImportFile = fmt.Errorf("invalid suppression import file")
+	ErrImportTooLarge    = fmt.Errorf("suppression import has too many rows")
+	ErrReasonTooLong     = fmt.Errorf("suppression reason is too long")
+)
+
+const (
+	// maxReasonLength ограничивает длину комментария к записи.
+	maxReasonLength = 1000
+	// maxImportRows ограничивает количество строк в одном импорте.
+	maxImportRows = 100000
+	// maxListLimit ограничивает размер страницы списка.
+	maxListLimit = 200
+	// defaultListLimit — размер страницы по умолчанию.
+	defaultListLimit = 50
+	// manualProvider — провайдер для записей, добавленных вручную.
+	manualProvider = "manual"
+)
+
+type service struct {
+	suppressions repo.SuppressionRepository
+}
+
+// NewService создаёт новый сервис списка подавления.
+func NewService(suppressions repo.SuppressionRepository) Service {
+	return &service{suppressions: suppressions}
+}
+
+// IsSuppressed сообщает, запрещена ли отправка писем на адрес.
+func (s *service) IsSuppressed(ctx context.Context, email string) (bool, error) {
+	return s.suppressions.Exists(ctx, domain.NormalizeEmail(email))
+}
+
+// Add вручную добавляет адрес в список.
+func (s *service) Add(ctx context.Context, actorID uuid.UUID, email, reason string) (*domain.Suppression, error) {
+	email, ok := normalizeAddress(email)
+	if !ok {
+		return nil, ErrInvalidEmail
+	}
+	reason = strings.TrimSpace(reason)
+	if len(reason) > maxReasonLength {
+		return nil, ErrReasonTooLong
+	}
+
+	entry := &domain.Suppression{
+		Email:     email,
+		Source:    domain.SourceManual,
+		Provider:  manualProvider,
+		Reason:    reason,
+		CreatedBy: &actorID,
+		CreatedAt: time.Now().UTC(),
+	}
+	added, err := s.suppressions.Add(ctx, entry)
+	if err != nil {
+		return nil, err
+	}
+	if !added {
+		return nil, ErrAlreadySuppressed
+	}
+	return entry, nil
+}
+
+// Remove удаляет адрес из списка.
+func (s *service) Remove(ctx context.Context, email string) error {
+	return s.suppressions.Remove(ctx, domain.NormalizeEmail(email))
+}
+
+// List возвращает записи списка.
+func (s *service) List(ctx context.Context, source domain.SuppressionSource, limit, offset int) ([]*domain.Suppression, int64, error) {
+	if source != "" && !source.IsValid() {
+		return nil, 0, ErrInvalidSource
+	}
+	if limit <= 0 {
+		limit = defaultListLimit
+	}
+	if limit > maxListLimit {
+		limit = maxListLimit
+	}
+	if offset < 0 {
+		offset = 0
+	}
+	return s.suppressions.List(ctx, repo.SuppressionFilter{Source: source, Limit: limit, Offset: offset})
+}
+
+// Import добавляет адреса из CSV-выгрузки провайдера.
+// Файл разбирается целиком до записи, чтобы ошибка формата не оставляла импорт выполненным наполовину.
+func (s *service) Import(ctx context.Context, actorID uuid.UUID, input ImportInput) (*ImportResult, error) {
+	if !input.Source.IsValid() {
+		return nil, ErrInvalidSource
+	}
+	provider := strings.ToLower(strings.TrimSpace(input.Provider))
+	if provider == "" || len(provider) > 32 {
+		return nil, ErrInvalidProvider
+	}
+
+	rows, err := parseImport(input.Data)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ImportResult{}
+	now := time.Now().UTC()
+	seen := make(map[string]bool, len(rows))
+	for _, row := range rows {
+		email, ok := normalizeAddress(row.email)
+		if !ok {
+			result.Invalid++
+			continue
+		}
+		if seen[email] {
+			result.Skipped++
+			continue
+		}
+		seen[email] = true
+
+		reason := row.reason
+		if len(reason) > maxReasonLength {
+			reason = reason[:maxReasonLength]
+		}
+		added, err := s.suppressions.Add(ctx, &domain.Suppression{
+			Email:     email,
+			Source:    input.Source,
+			Provider:  provider,
+			Reason:    reason,
+			CreatedBy: &actorID,
+			CreatedAt: now,
+		})
+		if err != nil {
+			return result, err
+		}
+		if added {
+			result.Added++
+		} else {
+			result.Skipped++
+		}
+	}
+	return result, nil
+}
+
+// normalizeAddress проверяет, что строка — одиночный email-адрес без имени, и нормализует его.
+func normalizeAddress(raw string) (string, bool) {
+	email := domain.NormalizeEmail(raw)
+	if email == "" || len(email) > 255 {
+		return "", false
+	}
+	addr, err := mail.ParseAddress(email)
+	if err != nil || addr.Address != email {
+		return "", false
+	}
+	return email, true
+}
@@ -0,0 +1,187 @@
+package tenantemail
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/mail"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+
+	domain "workout-app/internal/domain/organization"
+	repo "workout-app/internal/repository/interfaces"
+)
+
+// Service описывает usecase-слой собственных настроек отправки писем организаций.
+// Письма участникам организации отправляются с её адреса и через её провайдера
+// с лимитом организации; без настроек используется платформенная отправка.
+type Service interface {
+	// Get возвращает настройки организации.
+	// Возвращает ErrNotConfigured, если организация использует платформенную отправку.
+	Get(ctx context.Context, orgID uuid.UUID) (*domain.EmailSettings, error)
+
+	// Set создаёт или заменяет настройки организации.
+	// При изменении существующих настроек пустой пароль означает «оставить прежний».
+	Set(ctx context.Context, actorID, orgID uuid.UUID, input SettingsInput) (*domain.EmailSettings, error)
+
+	// Delete возвращает организацию к платформенной отправке.
+	Delete(ctx context.Context, orgID uuid.UUID) error
+
+	// SettingsFor возвращает настройки организации получателя или nil, если письмо отправляет платформа.
+	SettingsFor(ctx context.Context, email string) (*domain.EmailSettings, error)
+}
+
+// SettingsInput описывает изменяемые поля настроек отправки писем.
+type SettingsInput struct {
+	FromEmail        string
+	FromName         string
+	Provider         domain.EmailProvider
+	SMTPHost         string
+	SMTPPort         int
+	SMTPUsername     string
+	SMTPPassword     string
+	RateLimitPerHour int // 0 — лимит платформы по умолчанию
+}
+
+// Ошибки бизнес-логики usecase-слоя.
+var (
+	ErrDisabled         = fmt.Errorf("tenant email settings are disabled")
+	ErrNotConfigured    = fmt.Errorf("organization uses platform email delivery")
+	ErrInvalidFromEmail = fmt.Errorf("invalid from email address")
+	ErrInvalidFromName  = fmt.Errorf("invalid from name")
+	ErrInvalidProvider  = fmt.Errorf("unsupported email provider")
+	ErrInvalidSMTP      = fmt.Errorf("invalid smtp host, port or username")
+	ErrPasswordRequired = fmt.Errorf("smtp password is required")
+	ErrInvalidRateLimit = fmt.Errorf("invalid tenant email rate limit")
+)
+
+// maxFromNameLength ограничивает длину имени отправителя.
+const maxFromNameLength = 100
+
+type service struct {
+	settings     repo.OrganizationEmailSettingsRepository
+	maxRateLimit int
+}
+
+// NewService создаёт новый сервис настроек отправки писем организаций.
+// settings == nil отключает собственные настройки (не задан ключ шифрования секретов):
+// изменение настроек возвращает ErrDisabled, а все письма отправляет платформа.
+// maxRateLimit — максимальный и используемый по умолчанию лимит писем организации в час.
+func NewService(settings repo.OrganizationEmailSettingsRepository, maxRateLimit int) Service {
+	return &service{
+		settings:     settings,
+		maxRateLimit: maxRateLimit,
+	}
+}
+
+// Get возвращает настройки организации.
+func (s *service) Get(ctx context.Context, orgID uuid.UUID) (*domain.EmailSettings, error) {
+	if s.settings == nil {
+		return nil, ErrDisabled
+	}
+	settings, err := s.settings.Get(ctx, orgID)
+	if err != nil {
+		if errors.Is(err, repo.ErrNotFound) {
+			return nil, ErrNotConfigured
+		}
+		return nil, err
+	}
+	return settings, nil
+}
+
+// Set создаёт или заменяет настройки организации.
+func (s *service) Set(ctx context.Context, actorID, orgID uuid.UUID, input SettingsInput) (*domain.EmailSettings, error) {
+	if s.settings == nil {
+		return nil, ErrDisabled
+	}
+
+	settings := &domain.EmailSettings{
+		OrganizationID:   orgID,
+		FromEmail:        strings.TrimSpace(input.FromEmail),
+		FromName:         strings.TrimSpace(input.FromName),
+		Provider:         input.Provider,
+		SMTPHost:         strings.TrimSpace(input.SMTPHost),
+		SMTPPort:         input.SMTPPort,
+		SMTPUsername:     strings.TrimSpace(input.SMTPUsername),
+		SMTPPassword:     input.SMTPPassword,
+		RateLimitPerHour: input.RateLimitPerHour,
+		UpdatedBy:        actorID,
+		UpdatedAt:        time.Now().UTC(),
+	}
+	if err := s.validate(settings); err != nil {
+		return nil, err
+	}
+
+	if settings.SMTPPassword == "" {
+		current, err := s.settings.Get(ctx, orgID)
+		if err != nil {
+			if errors.Is(err, repo.ErrNotFound) {
+				return nil, ErrPasswordRequired
+			}
+			return nil, err
+		}
+		settings.SMTPPassword = current.SMTPPassword
+	}
+
+	if err := s.settings.Upsert(ctx, settings); err != nil {
+		return nil, err
+	}
+	return settings, nil
+}
+
+// Delete возвращает организацию к платформенной отправке.
+func (s *service) Delete(ctx context.Context, orgID uuid.UUID) error {
+	if s.settings == nil {
+		return ErrDisabled
+	}
+	if err := s.settings.Delete(ctx, orgID); err != nil {
+		if errors.Is(err, repo.ErrNotFound) {
+			return ErrNotConfigured
+		}
+		return err
+	}
+	return nil
+}
+
+// SettingsFor возвращает настройки организации получателя или nil, если письмо отправляет платформа.
+func (s *service) SettingsFor(ctx context.Context, email string) (*domain.EmailSettings, error) {
+	if s.settings == nil {
+		return nil, nil
+	}
+	settings, err := s.settings.GetForRecipient(ctx, strings.ToLower(strings.TrimSpace(email)))
+	if err != nil {
+		if errors.Is(err, repo.ErrNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return settings, nil
+}
+
+// validate проверяет настройки и подставляет лимит по умолчанию.
+func (s *service) validate(settings *domain.EmailSettings) error {
+	addr, err := mail.ParseAddress(settings.FromEmail)
+	if err != nil || addr.Address != settings.FromEmail {
+		return ErrInvalidFromEmail
+	}
+	if utf8.RuneCountInString(settings.FromName) > maxFromNameLength || strings.ContainsAny(settings.FromName, "\r\n") {
+		return ErrInvalidFromName
+	}
+	if !settings.Provider.IsValid() {
+		return ErrInvalidProvider
+	}
+	if settings.SMTPHost == "" || strings.ContainsAny(settings.SMTPHost, " /:") ||
+		settings.SMTPPort <= 0 || settings.SMTPPort > 65535 || settings.SMTPUsername == "" {
+		return ErrInvalidSMTP
+	}
+	if settings.RateLimitPerHour == 0 {
+		settings.RateLimitPerHour = s.maxRateLimit
+	}
+	if settings.RateLimitPerHour < 0 || settings.RateLimitPerHour > s.maxRateLimit {
+		return ErrInvalidRateLimit
+	}
+	return nil
+}
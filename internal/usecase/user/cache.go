@@ -0,0 +1,123 @@
+package user
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+
+	domain "workout-app/internal/domain/user"
+	repo "workout-app/internal/repository/interfaces"
+	"workout-app/pkg/cache"
+	"workout-app/pkg/logger"
+)
+
+// getCached читает пользователя через кеш профилей. Кеш не источник истины:
+// ошибки чтения и записи кеша не прерывают запрос, а пользователь читается из БД.
+func (s *service) getCached(ctx context.Context, id uuid.UUID) (*domain.User, error) {
+	if s.profiles == nil {
+		return s.users.GetByID(ctx, id)
+	}
+
+	if raw, err := s.profiles.Get(ctx, id.String()); err == nil {
+		var user domain.User
+		if err := json.Unmarshal(raw, &user); err == nil {
+			return &user, nil
+		}
+	}
+
+	user, err := s.users.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	// Хеш пароля в кеш не попадает: профили отдаются клиентам, а пароль проверяет auth по данным из БД.
+	cached := *user
+	cached.PasswordHash = ""
+	if raw, err := json.Marshal(&cached); err == nil {
+		_ = s.profiles.Set(ctx, id.String(), raw, s.profileTTL)
+	}
+	return &cached, nil
+}
+
+// cacheInvalidator сбрасывает кеш профилей после каждого изменения пользователя в хранилище.
+// Оборачивает репозиторий для всех сервисов, поэтому изменения из auth, аватаров, обезличивания
+// и административных операций сразу видны в профиле.
+type cacheInvalidator struct {
+	repo.UserRepository
+	profiles cache.Cache
+	logger   logger.Logger
+}
+
+// NewCacheInvalidator оборачивает репозиторий пользователей сбросом кеша профилей.
+// Без кеша (profiles == nil) возвращает репозиторий без изменений.
+func NewCacheInvalidator(users repo.UserRepository, profiles cache.Cache, logger logger.Logger) repo.UserRepository {
+	if profiles == nil {
+		return users
+	}
+	return &cacheInvalidator{UserRepository: users, profiles: profiles, logger: logger}
+}
+
+// invalidate сбрасывает профиль после успешного изменения. Внутри транзакции сброс откладывается
+// до COMMIT: иначе параллельное чтение до фиксации вернуло бы в кеш старый профиль.
+// Ошибка кеша логируется: изменение уже сохранено, а устаревший профиль истечёт по TTL.
+func (r *cacheInvalidator) invalidate(ctx context.Context, id uuid.UUID, err error) error {
+	if err != nil {
+		return err
+	}
+	repo.AfterCommit(ctx, func() {
+		if cacheErr := r.profiles.Delete(ctx, id.String()); cacheErr != nil {
+			r.logger.Warn("user_profile_cache_invalidate_failed", map[string]any{
+				"user_id": id.String(),
+				"error":   cacheErr.Error(),
+			})
+		}
+	})
+	return nil
+}
+
+// Update сохраняет пользователя и сбрасывает его профиль в кеше.
+func (r *cacheInvalidator) Update(ctx context.Context, user *domain.User) error {
+	return r.invalidate(ctx, user.ID, r.UserRepository.Update(ctx, user))
+}
+
+// MarkEmailVerified подтверждает email и сбрасывает профиль в кеше.
+func (r *cacheInvalidator) MarkEmailVerified(ctx context.Context, id uuid.UUID) error {
+	return r.invalidate(ctx, id, r.UserRepository.MarkEmailVerified(ctx, id))
+}
+
+// UpdatePassword меняет пароль и сбрасывает профиль в кеше.
+func (r *cacheInvalidator) UpdatePassword(ctx context.Context, id uuid.UUID, passwordHash string, tokensValidAfter time.Time) error {
+	return r.invalidate(ctx, id, r.UserRepository.UpdatePassword(ctx, id, passwordHash, tokensValidAfter))
+}
+
+// UpdateRole меняет роль и сбрасывает профиль в кеше.
+func (r *cacheInvalidator) UpdateRole(ctx context.Context, id uuid.UUID, role domain.Role) (domain.Role, error) {
+	previous, err := r.UserRepository.UpdateRole(ctx, id, role)
+	return previous, r.invalidate(ctx, id, err)
+}
+
+// SetSuspension меняет блокировку и сбрасывает профиль в кеше.
+func (r *cacheInvalidator) SetSuspension(ctx context.Context, id uuid.UUID, s *domain.Suspension) error {
+	return r.invalidate(ctx, id, r.UserRepository.SetSuspension(ctx, id, s))
+}
+
+// SetLegalHold меняет юридическое удержание и сбрасывает профиль в кеше.
+func (r *cacheInvalidator) SetLegalHold(ctx context.Context, id uuid.UUID, h *domain.LegalHold) error {
+	return r.invalidate(ctx, id, r.UserRepository.SetLegalHold(ctx, id, h))
+}
+
+// SoftDelete удаляет пользователя и сбрасывает профиль в кеше.
+func (r *cacheInvalidator) SoftDelete(ctx context.Context, id uuid.UUID) error {
+	return r.invalidate(ctx, id, r.UserRepository.SoftDelete(ctx, id))
+}
+
+// Anonymize обезличивает пользователя и сбрасывает профиль в кеше.
+func (r *cacheInvalidator) Anonymize(ctx context.Context, u *domain.User) error {
+	return r.invalidate(ctx, u.ID, r.UserRepository.Anonymize(ctx, u))
+}
+
+// Delete удаляет пользователя и сбрасывает профиль в кеше.
+func (r *cacheInvalidator) Delete(ctx context.Context, id uuid.UUID) error {
+	return r.invalidate(ctx, id, r.UserRepository.Delete(ctx, id))
+}
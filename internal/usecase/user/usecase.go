@@ -2,21 +2,37 @@ package user
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
 	"github.com/google/uuid"
 
+	eventdomain "workout-app/internal/domain/event"
+	"workout-app/internal/domain/region"
 	domain "workout-app/internal/domain/user"
+	"workout-app/internal/events"
 	repo "workout-app/internal/repository/interfaces"
+	usernameblockuc "workout-app/internal/usecase/usernameblock"
+	"workout-app/pkg/cache"
+	"workout-app/pkg/emailaddr"
 	"workout-app/pkg/mailer"
 	"workout-app/pkg/password"
 	"workout-app/pkg/verification"
 )
 
+// UnitsProvider возвращает систему единиц пользователя. Используется обработчиками,
+// которые принимают и отдают вес и замеры в единицах пользователя.
+type UnitsProvider interface {
+	// Units возвращает систему единиц пользователя (метрическую, если она не выбрана).
+	Units(ctx context.Context, userID uuid.UUID) (domain.Units, error)
+}
+
 // Service описывает usecase-слой для работы с пользователем:
 // регистрацию, получение/обновление профиля и мягкое удаление аккаунта.
 type Service interface {
+	UnitsProvider
+
 	// Register регистрирует нового пользователя на основе минимального контракта:
 	// email, хэш пароля, username. Валидация и хеширование выполняются выше (на уровне хендлера/другого usecase).
 	// Возвращает созданного пользователя или ошибку (включая ErrEmailExists/ErrUsernameExists).
@@ -28,16 +44,56 @@ type Service interface {
 	// GetProfile возвращает профиль текущего пользователя (по его ID).
 	GetProfile(ctx context.Context, userID uuid.UUID) (*domain.User, error)
 
+	// ResolveUsername возвращает пользователя по username. Если username — прежнее имя пользователя,
+	// сменившего его, возвращает этого пользователя и renamed = true (старые ссылки на профиль).
+	ResolveUsername(ctx context.Context, username string) (user *domain.User, renamed bool, err error)
+
+	// ListUsernameHistory возвращает последние смены username пользователя, новые первыми.
+	ListUsernameHistory(ctx context.Context, userID uuid.UUID) ([]*domain.UsernameChange, error)
+
+	// ListProfileHistory возвращает страницу истории изменений профиля пользователя, новые первыми,
+	// и общее количество записей.
+	ListProfileHistory(ctx context.Context, userID uuid.UUID, limit, offset int) ([]*domain.ProfileChange, int64, error)
+
 	// UpdateProfile обновляет профиль пользователя (без изменения пароля).
+	// Смена username записывается в историю, старое имя резервируется за пользователем.
+	// Изменённые поля записываются в историю изменений профиля.
+	// Вызывается в транзакции запроса: профиль и записи истории сохраняются вместе.
 	UpdateProfile(ctx context.Context, userID uuid.UUID, input ProfileUpdateInput) (*domain.User, error)
 
 	// DeleteAccount выполняет мягкое удаление аккаунта.
 	DeleteAccount(ctx context.Context, userID uuid.UUID) error
 
+	// ChangeRole меняет роль пользователя (административный сценарий); actorID — администратор,
+	// он записывается в историю изменений профиля. Возвращает обновлённого пользователя и его предыдущую роль.
+	// Уже выданные access-токены сохраняют старую роль до истечения срока; новая роль попадает в токены при refresh.
+	ChangeRole(ctx context.Context, actorID, userID uuid.UUID, role domain.Role) (*domain.User, domain.Role, error)
+
+	// Suspend блокирует аккаунт пользователя до until (nil — бессрочно).
+	// Заблокированный пользователь не может войти, обновить токены и обращаться к защищённым эндпоинтам.
+	Suspend(ctx context.Context, actorID, userID uuid.UUID, until *time.Time, reason string) (*domain.User, error)
+
+	// Unsuspend снимает блокировку аккаунта пользователя.
+	Unsuspend(ctx context.Context, userID uuid.UUID) (*domain.User, error)
+
+	// IsSuspended сообщает, заблокирован ли аккаунт пользователя в данный момент.
+	IsSuspended(ctx context.Context, userID uuid.UUID) (bool, error)
+
+	// GetRegion возвращает регион хранения данных пользователя.
+	GetRegion(ctx context.Context, userID uuid.UUID) (region.Region, error)
+
 	// ListUsers возвращает список всех активных пользователей.
 	// Предназначено для административных сценариев.
 	ListUsers(ctx context.Context) ([]*domain.User, error)
 
+	// GetForAdmin возвращает пользователя по идентификатору, в том числе мягко удалённого и обезличенного.
+	// Предназначено для административных сценариев.
+	GetForAdmin(ctx context.Context, userID uuid.UUID) (*domain.User, error)
+
+	// ForceVerifyEmail отмечает email пользователя подтверждённым без кода (административный сценарий);
+	// actorID — администратор. Возвращает ErrEmailAlreadyVerified, если email уже подтверждён.
+	ForceVerifyEmail(ctx context.Context, actorID, userID uuid.UUID) (*domain.User, error)
+
 	// RequestEmailChange запрашивает изменение email пользователя.
 	// Отправляет код подтверждения на новый email.
 	RequestEmailChange(ctx context.Context, userID uuid.UUID, newEmail string) error
@@ -50,6 +106,7 @@ type Service interface {
 // ProfileUpdateInput описывает допустимые изменения в профиле пользователя
 // на уровне бизнес-логики (usecase). Все поля опциональны.
 // Email нельзя изменить через этот метод, используйте RequestEmailChange и VerifyEmailChange.
+// Роль меняется только администратором через ChangeRole.
 type ProfileUpdateInput struct {
 	Username      *string
 	FirstName     *string
@@ -57,43 +114,129 @@ type ProfileUpdateInput struct {
 	BirthDate     *time.Time
 	Gender        *string
 	AvatarURL     *string
-	Role          *domain.Role
 	TrainingLevel *domain.TrainingLevel
+	Language      *domain.Language
+	// Units — система единиц, в которой API принимает и отдаёт вес и замеры.
+	Units *domain.Units
+
+	// Ссылки профиля в любом виде, который принимает domain.LinkKind.Normalize; пустая строка удаляет ссылку.
+	Instagram *string
+	YouTube   *string
+	Website   *string
+	// Видимость ссылок; без изменения ссылка сохраняет прежнюю видимость (по умолчанию public).
+	InstagramVisibility *domain.LinkVisibility
+	YouTubeVisibility   *domain.LinkVisibility
+	WebsiteVisibility   *domain.LinkVisibility
+
+	// WorkoutsVisibility — кто видит тренировки пользователя.
+	WorkoutsVisibility *domain.WorkoutsVisibility
+
+	// Timezone — часовой пояс IANA; пустая строка сбрасывает его на UTC.
+	Timezone *string
+	// Locale — локаль BCP 47 для форматов дат (например, en-GB); пустая строка — форматы языка.
+	Locale *string
+	// ReminderTime — время напоминания о тренировках дня в формате HH:MM; пустая строка выключает напоминания.
+	ReminderTime *string
+	// CalorieTarget — дневная цель по калориям, ккал; 0 удаляет цель.
+	CalorieTarget *int
 }
 
 // Ошибки бизнес-логики usecase-слоя.
 var (
 	ErrEmailSameAsCurrent           = fmt.Errorf("new email is the same as current email")
+	ErrEmailAlreadyVerified         = fmt.Errorf("email already verified")
 	ErrVerificationCodeNotFound     = fmt.Errorf("verification code not found")
 	ErrVerificationCodeInvalid      = fmt.Errorf("verification code invalid")
 	ErrVerificationAttemptsExceeded = fmt.Errorf("verification attempts exceeded")
+	ErrInvalidRole                  = fmt.Errorf("invalid role")
+	ErrCannotSuspendSelf            = fmt.Errorf("cannot suspend own account")
+	ErrCannotSuspendAdmin           = fmt.Errorf("cannot suspend an admin")
+	ErrSuspensionInPast             = fmt.Errorf("suspension end is in the past")
+	ErrInvalidProfileLink           = fmt.Errorf("invalid profile link")
+	ErrInvalidLinkVisibility        = fmt.Errorf("invalid profile link visibility")
+	ErrInvalidWorkoutsVisibility    = fmt.Errorf("invalid workouts visibility")
+	ErrInvalidTimezone              = fmt.Errorf("invalid timezone")
+	ErrInvalidLocale                = fmt.Errorf("invalid locale")
+	ErrInvalidReminderTime          = fmt.Errorf("invalid reminder time")
+	ErrInvalidCalorieTarget         = fmt.Errorf("invalid calorie target")
+)
+
+// ProfileLinkError сообщает, какая ссылка профиля не прошла проверку. Совместима с ErrInvalidProfileLink.
+type ProfileLinkError struct {
+	Kind domain.LinkKind
+}
+
+func (e *ProfileLinkError) Error() string {
+	return fmt.Sprintf("invalid %s profile link", e.Kind)
+}
+
+func (e *ProfileLinkError) Unwrap() error {
+	return ErrInvalidProfileLink
+}
+
+// maxUsernameHistory — сколько последних смен username возвращает ListUsernameHistory.
+const maxUsernameHistory = 50
+
+const (
+	// maxListLimit ограничивает размер страницы истории изменений профиля.
+	maxListLimit = 100
+	// defaultListLimit — размер страницы по умолчанию.
+	defaultListLimit = 20
 )
 
 type service struct {
 	users           repo.UserRepository
+	usernames       repo.UsernameHistoryRepository
+	history         repo.ProfileHistoryRepository
+	usernameFilter  usernameblockuc.Checker
 	emailVerifs     repo.EmailVerificationRepository
 	emailSender     mailer.EmailSender
+	events          events.Publisher
 	verificationTTL time.Duration
 	maxAttempts     int
 	codeLength      int
+
+	// usernameReservation — срок резервирования старого username после смены.
+	usernameReservation time.Duration
+
+	// profiles — кеш профилей для GetByID и GetProfile (nil — без кеша); сбрасывается NewCacheInvalidator.
+	profiles   cache.Cache
+	profileTTL time.Duration
 }
 
 // NewService создаёт новый сервис пользователей.
+// usernameReservation — сколько после смены username старое имя зарезервировано за пользователем.
+// history хранит изменения профиля; publisher получает событие удаления аккаунта.
+// profiles кеширует профили на profileTTL; users должен быть обёрнут NewCacheInvalidator с тем же кешем.
 func NewService(
 	users repo.UserRepository,
+	usernames repo.UsernameHistoryRepository,
+	history repo.ProfileHistoryRepository,
+	usernameFilter usernameblockuc.Checker,
 	emailVerifs repo.EmailVerificationRepository,
 	emailSender mailer.EmailSender,
+	publisher events.Publisher,
 	verificationTTL time.Duration,
 	maxAttempts int,
 	codeLength int,
+	usernameReservation time.Duration,
+	profiles cache.Cache,
+	profileTTL time.Duration,
 ) Service {
 	return &service{
-		users:           users,
-		emailVerifs:     emailVerifs,
-		emailSender:     emailSender,
-		verificationTTL: verificationTTL,
-		maxAttempts:     maxAttempts,
-		codeLength:      codeLength,
+		users:               users,
+		usernames:           usernames,
+		history:             history,
+		usernameFilter:      usernameFilter,
+		emailVerifs:         emailVerifs,
+		emailSender:         emailSender,
+		events:              publisher,
+		verificationTTL:     verificationTTL,
+		maxAttempts:         maxAttempts,
+		codeLength:          codeLength,
+		usernameReservation: usernameReservation,
+		profiles:            profiles,
+		profileTTL:          profileTTL,
 	}
 }
 
@@ -102,8 +245,11 @@ func (s *service) Register(ctx context.Context, email, passwordHash, username st
 	if email == "" || passwordHash == "" || username == "" {
 		return nil, fmt.Errorf("email, passwordHash и username обязательны")
 	}
+	if err := s.usernameFilter.Check(ctx, username); err != nil {
+		return nil, err
+	}
 
-	user := domain.NewUser(email, passwordHash, username)
+	user := domain.NewUser(emailaddr.Normalize(email), passwordHash, username)
 
 	if err := s.users.Create(ctx, user); err != nil {
 		return nil, err
@@ -112,14 +258,23 @@ func (s *service) Register(ctx context.Context, email, passwordHash, username st
 	return user, nil
 }
 
-// GetByID возвращает пользователя по ID.
+// GetByID возвращает пользователя по ID (через кеш профилей).
 func (s *service) GetByID(ctx context.Context, id uuid.UUID) (*domain.User, error) {
-	return s.users.GetByID(ctx, id)
+	return s.getCached(ctx, id)
 }
 
-// GetProfile возвращает профиль пользователя.
+// GetProfile возвращает профиль пользователя (через кеш профилей).
 func (s *service) GetProfile(ctx context.Context, userID uuid.UUID) (*domain.User, error) {
-	return s.users.GetByID(ctx, userID)
+	return s.getCached(ctx, userID)
+}
+
+// Units возвращает систему единиц пользователя (через кеш профилей).
+func (s *service) Units(ctx context.Context, userID uuid.UUID) (domain.Units, error) {
+	user, err := s.getCached(ctx, userID)
+	if err != nil {
+		return "", err
+	}
+	return user.Units.OrDefault(), nil
 }
 
 // UpdateProfile обновляет профиль пользователя.
@@ -131,7 +286,20 @@ func (s *service) UpdateProfile(ctx context.Context, userID uuid.UUID, input Pro
 	}
 
 	// Применяем изменения к доменной модели
-	if input.Username != nil {
+	before := user.ProfileFields()
+	oldUsername := user.Username
+	if input.Username != nil && *input.Username != user.Username {
+		if err := s.usernameFilter.Check(ctx, *input.Username); err != nil {
+			return nil, err
+		}
+		// Имя, зарезервированное за другим пользователем, считается занятым; своё прежнее имя можно вернуть.
+		reserved, err := s.usernames.IsReserved(ctx, *input.Username, userID, time.Now().UTC())
+		if err != nil {
+			return nil, err
+		}
+		if reserved {
+			return nil, repo.ErrUsernameExists
+		}
 		user.Username = *input.Username
 	}
 	if input.FirstName != nil {
@@ -149,24 +317,269 @@ func (s *service) UpdateProfile(ctx context.Context, userID uuid.UUID, input Pro
 	if input.AvatarURL != nil {
 		user.AvatarURL = *input.AvatarURL
 	}
-	if input.Role != nil {
-		user.Role = *input.Role
-	}
 	if input.TrainingLevel != nil {
 		user.TrainingLevel = *input.TrainingLevel
 	}
+	if input.Language != nil {
+		user.Language = *input.Language
+	}
+	if input.Units != nil {
+		user.Units = *input.Units
+	}
+	links := []struct {
+		kind       domain.LinkKind
+		value      *string
+		visibility *domain.LinkVisibility
+	}{
+		{domain.LinkInstagram, input.Instagram, input.InstagramVisibility},
+		{domain.LinkYouTube, input.YouTube, input.YouTubeVisibility},
+		{domain.LinkWebsite, input.Website, input.WebsiteVisibility},
+	}
+	for _, l := range links {
+		if err := applyProfileLink(user.Links.Get(l.kind), l.kind, l.value, l.visibility); err != nil {
+			return nil, err
+		}
+	}
+
+	if input.WorkoutsVisibility != nil {
+		if !input.WorkoutsVisibility.IsValid() {
+			return nil, ErrInvalidWorkoutsVisibility
+		}
+		user.WorkoutsVisibility = *input.WorkoutsVisibility
+	}
+	if input.Timezone != nil {
+		if !domain.IsValidTimezone(*input.Timezone) {
+			return nil, ErrInvalidTimezone
+		}
+		user.Timezone = *input.Timezone
+	}
+	if input.Locale != nil {
+		locale, ok := domain.NormalizeLocale(*input.Locale)
+		if !ok {
+			return nil, ErrInvalidLocale
+		}
+		user.Locale = locale
+	}
+	if input.ReminderTime != nil {
+		if *input.ReminderTime == "" {
+			user.ReminderTime = nil
+		} else {
+			t, ok := domain.ParseReminderTime(*input.ReminderTime)
+			if !ok {
+				return nil, ErrInvalidReminderTime
+			}
+			user.ReminderTime = &t
+		}
+	}
+	if input.CalorieTarget != nil {
+		switch target := *input.CalorieTarget; {
+		case target == 0:
+			user.CalorieTarget = nil
+		case target < domain.MinCalorieTarget || target > domain.MaxCalorieTarget:
+			return nil, ErrInvalidCalorieTarget
+		default:
+			user.CalorieTarget = &target
+		}
+	}
 
 	// Обновляем пользователя в хранилище
 	if err := s.users.Update(ctx, user); err != nil {
 		return nil, err
 	}
 
+	if user.Username != oldUsername {
+		change := domain.NewUsernameChange(userID, oldUsername, user.Username, time.Now().UTC(), s.usernameReservation)
+		if err := s.usernames.Create(ctx, change); err != nil {
+			return nil, fmt.Errorf("failed to record username change: %w", err)
+		}
+	}
+	if err := s.recordProfileChange(ctx, userID, before, user); err != nil {
+		return nil, err
+	}
+
 	return user, nil
 }
 
-// DeleteAccount выполняет мягкое удаление аккаунта.
+// recordProfileChange записывает в историю поля профиля, изменившиеся относительно снимка before.
+// Если ничего не изменилось, запись не создаётся.
+func (s *service) recordProfileChange(ctx context.Context, actorID uuid.UUID, before domain.ProfileFields, user *domain.User) error {
+	changes := before.Diff(user.ProfileFields())
+	if len(changes) == 0 {
+		return nil
+	}
+	change := domain.NewProfileChange(user.ID, actorID, changes, time.Now().UTC())
+	if err := s.history.Create(ctx, change); err != nil {
+		return fmt.Errorf("failed to record profile change: %w", err)
+	}
+	return nil
+}
+
+// applyProfileLink нормализует и сохраняет новое значение и видимость ссылки профиля.
+func applyProfileLink(link *domain.ProfileLink, kind domain.LinkKind, value *string, visibility *domain.LinkVisibility) error {
+	if value != nil {
+		if *value == "" {
+			link.Value = ""
+		} else {
+			normalized, ok := kind.Normalize(*value)
+			if !ok {
+				return &ProfileLinkError{Kind: kind}
+			}
+			link.Value = normalized
+		}
+	}
+	if visibility != nil {
+		if !visibility.IsValid() {
+			return ErrInvalidLinkVisibility
+		}
+		link.Visibility = *visibility
+	}
+	return nil
+}
+
+// ResolveUsername ищет пользователя по текущему username, затем по истории смен.
+// Текущий владелец имени имеет приоритет над пользователем, который носил его раньше.
+func (s *service) ResolveUsername(ctx context.Context, username string) (*domain.User, bool, error) {
+	user, err := s.users.GetByUsername(ctx, username)
+	if err == nil {
+		return user, false, nil
+	}
+	if !errors.Is(err, repo.ErrNotFound) {
+		return nil, false, err
+	}
+
+	change, err := s.usernames.GetLatestByOldUsername(ctx, username)
+	if err != nil {
+		return nil, false, err
+	}
+	user, err = s.users.GetByID(ctx, change.UserID)
+	if err != nil {
+		return nil, false, err
+	}
+	return user, true, nil
+}
+
+// ListUsernameHistory возвращает последние смены username пользователя.
+func (s *service) ListUsernameHistory(ctx context.Context, userID uuid.UUID) ([]*domain.UsernameChange, error) {
+	return s.usernames.ListByUser(ctx, userID, maxUsernameHistory)
+}
+
+// ListProfileHistory возвращает страницу истории изменений профиля пользователя.
+func (s *service) ListProfileHistory(ctx context.Context, userID uuid.UUID, limit, offset int) ([]*domain.ProfileChange, int64, error) {
+	limit, offset = normalizePage(limit, offset)
+	return s.history.ListByUser(ctx, userID, limit, offset)
+}
+
+// normalizePage приводит параметры страницы к допустимым значениям.
+func normalizePage(limit, offset int) (int, int) {
+	if offset < 0 {
+		offset = 0
+	}
+	if limit <= 0 {
+		limit = defaultListLimit
+	}
+	if limit > maxListLimit {
+		limit = maxListLimit
+	}
+	return limit, offset
+}
+
+// DeleteAccount выполняет мягкое удаление аккаунта и публикует событие удаления.
 func (s *service) DeleteAccount(ctx context.Context, userID uuid.UUID) error {
-	return s.users.SoftDelete(ctx, userID)
+	if err := s.users.SoftDelete(ctx, userID); err != nil {
+		return err
+	}
+	s.events.Publish(ctx, eventdomain.TypeAccountDeleted, userID.String(), eventdomain.AccountDeleted{
+		UserID:    userID.String(),
+		DeletedAt: time.Now().UTC(),
+	})
+	return nil
+}
+
+// ChangeRole меняет роль пользователя с защитой от снятия роли с последнего администратора.
+func (s *service) ChangeRole(ctx context.Context, actorID, userID uuid.UUID, role domain.Role) (*domain.User, domain.Role, error) {
+	if !role.IsValid() {
+		return nil, "", ErrInvalidRole
+	}
+
+	previous, err := s.users.UpdateRole(ctx, userID, role)
+	if err != nil {
+		return nil, "", err
+	}
+
+	user, err := s.users.GetByID(ctx, userID)
+	if err != nil {
+		return nil, "", err
+	}
+	if previous != role {
+		change := domain.NewProfileChange(userID, actorID, []domain.FieldChange{
+			{Field: domain.FieldRole, Old: string(previous), New: string(role)},
+		}, time.Now().UTC())
+		if err := s.history.Create(ctx, change); err != nil {
+			return nil, "", fmt.Errorf("failed to record profile change: %w", err)
+		}
+	}
+	return user, previous, nil
+}
+
+// Suspend блокирует аккаунт пользователя.
+// Администраторов блокировать нельзя — сначала нужно снять с них роль admin.
+func (s *service) Suspend(ctx context.Context, actorID, userID uuid.UUID, until *time.Time, reason string) (*domain.User, error) {
+	if actorID == userID {
+		return nil, ErrCannotSuspendSelf
+	}
+	now := time.Now().UTC()
+	if until != nil && !until.After(now) {
+		return nil, ErrSuspensionInPast
+	}
+
+	user, err := s.users.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.Role == domain.RoleAdmin {
+		return nil, ErrCannotSuspendAdmin
+	}
+
+	suspension := &domain.Suspension{At: now, Until: until, Reason: reason}
+	if err := s.users.SetSuspension(ctx, userID, suspension); err != nil {
+		return nil, err
+	}
+	user.Suspension = suspension
+	return user, nil
+}
+
+// Unsuspend снимает блокировку аккаунта пользователя.
+func (s *service) Unsuspend(ctx context.Context, userID uuid.UUID) (*domain.User, error) {
+	if err := s.users.SetSuspension(ctx, userID, nil); err != nil {
+		return nil, err
+	}
+	return s.users.GetByID(ctx, userID)
+}
+
+// IsSuspended сообщает, заблокирован ли аккаунт пользователя в данный момент.
+// Для ненайденных пользователей возвращает false: отсутствие аккаунта обрабатывается в самих сценариях.
+func (s *service) IsSuspended(ctx context.Context, userID uuid.UUID) (bool, error) {
+	user, err := s.users.GetByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, repo.ErrNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+	return user.IsSuspended(time.Now()), nil
+}
+
+// GetRegion возвращает регион хранения данных пользователя.
+// Для старых записей без региона возвращает global.
+func (s *service) GetRegion(ctx context.Context, userID uuid.UUID) (region.Region, error) {
+	user, err := s.users.GetByID(ctx, userID)
+	if err != nil {
+		return "", err
+	}
+	if !user.Region.IsValid() {
+		return region.RegionGlobal, nil
+	}
+	return user.Region, nil
 }
 
 // ListUsers возвращает всех активных пользователей.
@@ -174,9 +587,47 @@ func (s *service) ListUsers(ctx context.Context) ([]*domain.User, error) {
 	return s.users.List(ctx)
 }
 
+// GetForAdmin возвращает пользователя вместе с мягко удалёнными; кеш профилей не используется,
+// чтобы администратор видел актуальное состояние аккаунта.
+func (s *service) GetForAdmin(ctx context.Context, userID uuid.UUID) (*domain.User, error) {
+	return s.users.GetByIDIncludingDeleted(ctx, userID)
+}
+
+// ForceVerifyEmail подтверждает email пользователя без кода и удаляет неиспользованные коды регистрации.
+func (s *service) ForceVerifyEmail(ctx context.Context, actorID, userID uuid.UUID) (*domain.User, error) {
+	user, err := s.users.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.IsEmailVerified {
+		return nil, ErrEmailAlreadyVerified
+	}
+
+	before := user.ProfileFields()
+	if err := s.users.MarkEmailVerified(ctx, user.ID); err != nil {
+		return nil, err
+	}
+	user.IsEmailVerified = true
+	user.UpdatedAt = time.Now().UTC()
+	if err := s.emailVerifs.DeleteByPurpose(ctx, user.ID, domain.PurposeRegistration); err != nil {
+		return nil, fmt.Errorf("failed to delete verification codes: %w", err)
+	}
+	if err := s.recordProfileChange(ctx, actorID, before, user); err != nil {
+		return nil, err
+	}
+
+	// Подписчики реагируют так же, как на подтверждение кодом (например, отправляют приветствие).
+	s.events.Publish(ctx, eventdomain.TypeEmailVerified, user.ID.String(), eventdomain.EmailVerified{
+		UserID:     user.ID.String(),
+		VerifiedAt: user.UpdatedAt,
+	})
+	return user, nil
+}
+
 // RequestEmailChange запрашивает изменение email пользователя.
 // Отправляет код подтверждения на новый email.
 func (s *service) RequestEmailChange(ctx context.Context, userID uuid.UUID, newEmail string) error {
+	newEmail = emailaddr.Normalize(newEmail)
 	if newEmail == "" {
 		return fmt.Errorf("newEmail is required")
 	}
@@ -201,7 +652,7 @@ func (s *service) RequestEmailChange(ctx context.Context, userID uuid.UUID, newE
 	}
 
 	// Удаляем старые коды изменения email для этого пользователя
-	if err := s.emailVerifs.DeleteEmailChangeByUserID(ctx, userID); err != nil {
+	if err := s.emailVerifs.DeleteByPurpose(ctx, userID, domain.PurposeEmailChange); err != nil {
 		return fmt.Errorf("failed to delete old email change codes: %w", err)
 	}
 
@@ -222,7 +673,7 @@ func (s *service) VerifyEmailChange(ctx context.Context, userID uuid.UUID, code
 	}
 
 	// Находим активный код изменения email
-	v, err := s.emailVerifs.GetActiveEmailChangeByUserID(ctx, userID)
+	v, err := s.emailVerifs.GetActiveByPurpose(ctx, userID, domain.PurposeEmailChange)
 	if err != nil {
 		if err == repo.ErrNotFound {
 			return nil, ErrVerificationCodeNotFound
@@ -243,12 +694,12 @@ func (s *service) VerifyEmailChange(ctx context.Context, userID uuid.UUID, code
 
 	switch result {
 	case verification.VerificationExpired:
-		if err := s.emailVerifs.DeleteEmailChangeByUserID(ctx, userID); err != nil {
+		if err := s.emailVerifs.DeleteByPurpose(ctx, userID, domain.PurposeEmailChange); err != nil {
 			return nil, fmt.Errorf("failed to delete expired verification: %w", err)
 		}
 		return nil, ErrVerificationCodeNotFound
 	case verification.VerificationAttemptsExceeded:
-		if err := s.emailVerifs.DeleteEmailChangeByUserID(ctx, userID); err != nil {
+		if err := s.emailVerifs.DeleteByPurpose(ctx, userID, domain.PurposeEmailChange); err != nil {
 			return nil, fmt.Errorf("failed to delete verification after exceeded attempts: %w", err)
 		}
 		return nil, ErrVerificationAttemptsExceeded
@@ -267,25 +718,34 @@ func (s *service) VerifyEmailChange(ctx context.Context, userID uuid.UUID, code
 	}
 	if err == nil && existingUser.ID != userID {
 		// Email занят другим пользователем
-		if err := s.emailVerifs.DeleteEmailChangeByUserID(ctx, userID); err != nil {
+		if err := s.emailVerifs.DeleteByPurpose(ctx, userID, domain.PurposeEmailChange); err != nil {
 			return nil, fmt.Errorf("failed to delete verification after email conflict: %w", err)
 		}
 		return nil, repo.ErrEmailExists
 	}
 
 	// Успешное подтверждение: обновляем email пользователя
+	before := user.ProfileFields()
 	user.Email = *updatedVerification.NewEmail
-	user.IsEmailVerified = true
 	user.UpdatedAt = time.Now().UTC()
 
 	if err := s.users.Update(ctx, user); err != nil {
 		return nil, fmt.Errorf("failed to update user email: %w", err)
 	}
+	if !user.IsEmailVerified {
+		if err := s.users.MarkEmailVerified(ctx, user.ID); err != nil {
+			return nil, fmt.Errorf("failed to mark email verified: %w", err)
+		}
+		user.IsEmailVerified = true
+	}
 
 	// Удаляем коды изменения email для пользователя
-	if err := s.emailVerifs.DeleteEmailChangeByUserID(ctx, user.ID); err != nil {
+	if err := s.emailVerifs.DeleteByPurpose(ctx, user.ID, domain.PurposeEmailChange); err != nil {
 		return nil, fmt.Errorf("failed to delete verification codes: %w", err)
 	}
+	if err := s.recordProfileChange(ctx, userID, before, user); err != nil {
+		return nil, err
+	}
 
 	return user, nil
 }
@@ -312,13 +772,14 @@ func (s *service) createAndSendEmailChangeCode(ctx context.Context, user *domain
 		MaxAttempts: s.maxAttempts,
 		CreatedAt:   now,
 		NewEmail:    &newEmail,
+		Purpose:     domain.PurposeEmailChange,
 	}
 
 	if err := s.emailVerifs.Create(ctx, verification); err != nil {
 		return fmt.Errorf("failed to create verification code: %w", err)
 	}
 
-	if err := s.emailSender.SendEmailVerificationCode(ctx, newEmail, code); err != nil {
+	if err := s.emailSender.SendEmailVerificationCode(mailer.WithRecipient(ctx, user.MailLocale(), user.Timezone), newEmail, code); err != nil {
 		return fmt.Errorf("failed to send verification email: %w", err)
 	}
 
@@ -0,0 +1,187 @@
+package usernameblock
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	domain "workout-app/internal/domain/usernameblock"
+	repo "workout-app/internal/repository/interfaces"
+	"workout-app/pkg/usernamefilter"
+)
+
+// Checker проверяет username по списку запрещённых имён.
+// Регистрация, смена username и создание аккаунта через OAuth зависят только от проверки.
+type Checker interface {
+	// Check возвращает ошибку, совместимую с usernamefilter.ErrNotAllowed (*usernamefilter.Error),
+	// если username запрещён встроенными списками или записями администраторов.
+	Check(ctx context.Context, username string) error
+}
+
+// Service описывает usecase-слой списка запрещённых username.
+// Встроенные списки (служебные имена, ненормативная лексика выбранных локалей) задаются конфигурацией,
+// дополнительные записи администраторы добавляют и удаляют во время работы сервиса.
+type Service interface {
+	Checker
+
+	// List возвращает записи, добавленные администраторами.
+	List(ctx context.Context) ([]*domain.Entry, error)
+
+	// Add добавляет запрещённое слово.
+	Add(ctx context.Context, actorID uuid.UUID, input AddInput) (*domain.Entry, error)
+
+	// Remove удаляет запись, добавленную администратором.
+	Remove(ctx context.Context, id uuid.UUID) error
+
+	// Invalidate сбрасывает закешированные записи; следующая проверка перечитает их из БД.
+	Invalidate(ctx context.Context) error
+}
+
+// AddInput описывает новое запрещённое слово.
+type AddInput struct {
+	Term     string
+	Mode     usernamefilter.Mode     // По умолчанию exact
+	Category usernamefilter.Category // По умолчанию reserved
+	Reason   string
+}
+
+// Ошибки бизнес-логики usecase-слоя.
+var (
+	ErrInvalidTerm     = fmt.Errorf("invalid blocked username term")
+	ErrInvalidMode     = fmt.Errorf("invalid blocked username match mode")
+	ErrInvalidCategory = fmt.Errorf("invalid blocked username category")
+	ErrReasonTooLong   = fmt.Errorf("blocked username reason is too long")
+	ErrAlreadyBlocked  = fmt.Errorf("username term is already blocked")
+)
+
+const (
+	// maxTermLength совпадает с максимальной длиной username.
+	maxTermLength = 32
+	// maxReasonLength ограничивает длину комментария к записи.
+	maxReasonLength = 1000
+)
+
+type service struct {
+	entries  repo.UsernameBlocklistRepository
+	builtin  []usernamefilter.Rule
+	cacheTTL time.Duration
+
+	mu       sync.Mutex
+	matcher  *usernamefilter.Matcher
+	loadedAt time.Time
+}
+
+// NewService создаёт новый сервис списка запрещённых username.
+// builtin — правила из встроенных списков и конфигурации. Записи администраторов проверяются на каждой
+// регистрации, поэтому кешируются в памяти на cacheTTL; изменения через сервис сбрасывают кеш сразу,
+// на остальных инстансах — не позже cacheTTL.
+func NewService(entries repo.UsernameBlocklistRepository, builtin []usernamefilter.Rule, cacheTTL time.Duration) Service {
+	return &service{
+		entries:  entries,
+		builtin:  builtin,
+		cacheTTL: cacheTTL,
+	}
+}
+
+// Check проверяет username по встроенным спискам и записям администраторов.
+func (s *service) Check(ctx context.Context, username string) error {
+	matcher, err := s.snapshot(ctx)
+	if err != nil {
+		return err
+	}
+	return matcher.Check(username)
+}
+
+// List возвращает записи, добавленные администраторами.
+func (s *service) List(ctx context.Context) ([]*domain.Entry, error) {
+	return s.entries.List(ctx)
+}
+
+// Add добавляет запрещённое слово. Слово хранится в нормализованной форме,
+// поэтому adm1n и Admin2 считаются тем же словом, что и admin.
+func (s *service) Add(ctx context.Context, actorID uuid.UUID, input AddInput) (*domain.Entry, error) {
+	if len(strings.TrimSpace(input.Term)) > maxTermLength {
+		return nil, ErrInvalidTerm
+	}
+	term := usernamefilter.Normalize(input.Term)
+	if term == "" {
+		return nil, ErrInvalidTerm
+	}
+	if input.Mode == "" {
+		input.Mode = usernamefilter.ModeExact
+	}
+	if !input.Mode.IsValid() {
+		return nil, ErrInvalidMode
+	}
+	if input.Category == "" {
+		input.Category = usernamefilter.CategoryReserved
+	}
+	if !input.Category.IsValid() {
+		return nil, ErrInvalidCategory
+	}
+	reason := strings.TrimSpace(input.Reason)
+	if len(reason) > maxReasonLength {
+		return nil, ErrReasonTooLong
+	}
+
+	entry := &domain.Entry{
+		ID:        uuid.New(),
+		Term:      term,
+		Mode:      input.Mode,
+		Category:  input.Category,
+		Reason:    reason,
+		CreatedBy: &actorID,
+		CreatedAt: time.Now().UTC(),
+	}
+	added, err := s.entries.Create(ctx, entry)
+	if err != nil {
+		return nil, err
+	}
+	if !added {
+		return nil, ErrAlreadyBlocked
+	}
+	_ = s.Invalidate(ctx)
+	return entry, nil
+}
+
+// Remove удаляет запись, добавленную администратором.
+func (s *service) Remove(ctx context.Context, id uuid.UUID) error {
+	if err := s.entries.Delete(ctx, id); err != nil {
+		return err
+	}
+	return s.Invalidate(ctx)
+}
+
+// Invalidate сбрасывает закешированные записи.
+func (s *service) Invalidate(_ context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.matcher = nil
+	return nil
+}
+
+// snapshot возвращает закешированный набор правил или перечитывает записи администраторов из БД.
+func (s *service) snapshot(ctx context.Context) (*usernamefilter.Matcher, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.matcher != nil && time.Since(s.loadedAt) < s.cacheTTL {
+		return s.matcher, nil
+	}
+
+	entries, err := s.entries.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	rules := make([]usernamefilter.Rule, 0, len(entries))
+	for _, e := range entries {
+		rules = append(rules, e.Rule())
+	}
+	s.matcher = usernamefilter.NewMatcher(s.builtin, rules)
+	s.loadedAt = time.Now()
+	return s.matcher, nil
+}
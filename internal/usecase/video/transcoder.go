@@ -0,0 +1,29 @@
+package video
+
+import "context"
+
+// Source описывает файл видео в хранилище.
+type Source struct {
+	Key         string
+	ContentType string
+	Size        int64
+}
+
+// Transcoder — точка расширения для приведения загруженных видео к единому формату,
+// который воспроизводится и на iOS, и на Android (например, H.264/AAC в MP4 с faststart).
+// Реализация читает исходный файл из хранилища и сохраняет результат под новым ключом;
+// исходный файл после успешного перекодирования сервис удаляет сам.
+type Transcoder interface {
+	// Transcode обрабатывает исходный файл и возвращает файл для воспроизведения.
+	// Если перекодирование не требуется, возвращает src без изменений.
+	Transcode(ctx context.Context, src Source) (Source, error)
+}
+
+// PassthroughTranscoder оставляет загруженный файл как есть.
+// Используется, пока не подключён внешний транскодер (ffmpeg, облачный сервис).
+type PassthroughTranscoder struct{}
+
+// Transcode возвращает исходный файл.
+func (PassthroughTranscoder) Transcode(_ context.Context, src Source) (Source, error) {
+	return src, nil
+}
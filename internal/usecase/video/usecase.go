@@ -0,0 +1,326 @@
+package video
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+
+	domain "workout-app/internal/domain/video"
+	repo "workout-app/internal/repository/interfaces"
+	"workout-app/pkg/logger"
+	"workout-app/pkg/storage"
+)
+
+// Service описывает usecase-слой видео упражнений: загрузку, перекодирование
+// и выдачу подписанных ссылок на воспроизведение с поддержкой Range-запросов.
+type Service interface {
+	// Upload сохраняет видео в хранилище и передаёт его транскодеру.
+	// Тип файла определяется по содержимому, а не по заявленному клиентом Content-Type.
+	Upload(ctx context.Context, ownerID uuid.UUID, title string, file io.Reader, size int64) (*domain.Video, error)
+
+	// Get возвращает видео по ID.
+	Get(ctx context.Context, id uuid.UUID) (*domain.Video, error)
+
+	// ListMine возвращает видео, загруженные пользователем.
+	ListMine(ctx context.Context, ownerID uuid.UUID) ([]*domain.Video, error)
+
+	// Delete удаляет видео и его файл. Удалять может автор или администратор (isAdmin).
+	Delete(ctx context.Context, actorID uuid.UUID, isAdmin bool, id uuid.UUID) error
+
+	// StreamLink выдаёт подписанную ссылку на воспроизведение готового видео.
+	// Ссылка не требует заголовка Authorization, поэтому подходит нативным плеерам.
+	StreamLink(ctx context.Context, id uuid.UUID) (*StreamLink, error)
+
+	// OpenStream проверяет подпись ссылки и возвращает источник для отдачи видео.
+	OpenStream(ctx context.Context, id uuid.UUID, expires, signature string) (*Stream, error)
+}
+
+// Config задаёт параметры сервиса видео.
+type Config struct {
+	MaxBytes   int64         // Максимальный размер загружаемого файла
+	LinkTTL    time.Duration // Срок действия ссылки на воспроизведение
+	SigningKey []byte        // Ключ HMAC-подписи ссылок
+	BaseURL    string        // Внешний адрес сервиса; пусто — относительные ссылки
+}
+
+// StreamLink описывает подписанную ссылку на воспроизведение.
+type StreamLink struct {
+	URL       string
+	ExpiresAt time.Time
+}
+
+// Stream описывает источник видео для ответа на запрос воспроизведения.
+// Заполнено ровно одно поле из Object и RedirectURL.
+type Stream struct {
+	Video *domain.Video
+	// Object — открытый файл для хранилищ, которые сервер отдаёт сам; закрывает вызывающий.
+	Object *storage.Object
+	// RedirectURL — временная ссылка хранилища (S3), которое само обрабатывает Range.
+	RedirectURL string
+}
+
+// Ошибки бизнес-логики usecase-слоя.
+var (
+	ErrFileTooLarge         = fmt.Errorf("video file is too large")
+	ErrEmptyFile            = fmt.Errorf("video file is empty")
+	ErrUnsupportedFormat    = fmt.Errorf("unsupported video format")
+	ErrInvalidTitle         = fmt.Errorf("video title must be 1-200 characters")
+	ErrVideoNotFound        = fmt.Errorf("video not found")
+	ErrForbidden            = fmt.Errorf("only the author or an admin can delete the video")
+	ErrNotReady             = fmt.Errorf("video is not ready for playback")
+	ErrInvalidSignature     = fmt.Errorf("invalid video link signature")
+	ErrLinkExpired          = fmt.Errorf("video link has expired")
+	ErrStreamingUnsupported = fmt.Errorf("storage backend does not support video streaming")
+)
+
+// allowedTypes сопоставляет допустимые MIME-типы видео с расширениями файлов.
+var allowedTypes = map[string]string{
+	"video/mp4":       ".mp4",
+	"video/webm":      ".webm",
+	"video/quicktime": ".mov",
+}
+
+// minRedirectTTL — минимальный срок действия ссылки хранилища при перенаправлении,
+// чтобы плеер успел начать загрузку даже для почти истёкшей ссылки сервиса.
+const minRedirectTTL = time.Minute
+
+type service struct {
+	videos     repo.VideoRepository
+	storage    storage.Storage
+	transcoder Transcoder
+	cfg        Config
+	logger     logger.Logger
+}
+
+// NewService создаёт новый сервис видео. Если transcoder == nil, файлы сохраняются без перекодирования.
+func NewService(videos repo.VideoRepository, storage storage.Storage, transcoder Transcoder, cfg Config, logger logger.Logger) Service {
+	if transcoder == nil {
+		transcoder = PassthroughTranscoder{}
+	}
+	cfg.BaseURL = strings.TrimRight(cfg.BaseURL, "/")
+	return &service{
+		videos:     videos,
+		storage:    storage,
+		transcoder: transcoder,
+		cfg:        cfg,
+		logger:     logger,
+	}
+}
+
+// Upload сохраняет видео и перекодирует его.
+func (s *service) Upload(ctx context.Context, ownerID uuid.UUID, title string, file io.Reader, size int64) (*domain.Video, error) {
+	title = strings.TrimSpace(title)
+	if title == "" || utf8.RuneCountInString(title) > 200 {
+		return nil, ErrInvalidTitle
+	}
+	if size > s.cfg.MaxBytes {
+		return nil, ErrFileTooLarge
+	}
+	if size == 0 {
+		return nil, ErrEmptyFile
+	}
+
+	buffered := bufio.NewReaderSize(file, 512)
+	head, err := buffered.Peek(512)
+	if err != nil && err != io.EOF && err != bufio.ErrBufferFull {
+		return nil, fmt.Errorf("failed to read video: %w", err)
+	}
+	contentType := detectContentType(head)
+	ext, ok := allowedTypes[contentType]
+	if !ok {
+		return nil, ErrUnsupportedFormat
+	}
+
+	key, err := videoKey(ext)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.storage.Put(ctx, key, io.LimitReader(buffered, size), size, contentType); err != nil {
+		return nil, err
+	}
+
+	video := domain.New(ownerID, title, key, contentType, size)
+	if err := s.videos.Create(ctx, video); err != nil {
+		s.deleteObject(ctx, key)
+		return nil, err
+	}
+
+	s.transcode(ctx, video)
+	return video, nil
+}
+
+// transcode передаёт видео транскодеру и сохраняет итоговый статус.
+// Ошибка перекодирования не отменяет загрузку: видео остаётся в статусе failed.
+func (s *service) transcode(ctx context.Context, video *domain.Video) {
+	src := Source{Key: video.StorageKey, ContentType: video.ContentType, Size: video.SizeBytes}
+	result, err := s.transcoder.Transcode(ctx, src)
+	if err != nil {
+		s.logger.Error("video_transcode_failed", map[string]any{"video_id": video.ID.String(), "error": err.Error()})
+		video.Status = domain.StatusFailed
+	} else {
+		video.StorageKey = result.Key
+		video.ContentType = result.ContentType
+		video.SizeBytes = result.Size
+		video.Status = domain.StatusReady
+	}
+	video.UpdatedAt = time.Now().UTC()
+
+	if err := s.videos.Update(ctx, video); err != nil {
+		s.logger.Error("video_status_update_failed", map[string]any{"video_id": video.ID.String(), "error": err.Error()})
+		return
+	}
+	if err == nil && result.Key != src.Key {
+		s.deleteObject(ctx, src.Key)
+	}
+}
+
+// Get возвращает видео по ID.
+func (s *service) Get(ctx context.Context, id uuid.UUID) (*domain.Video, error) {
+	video, err := s.videos.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repo.ErrNotFound) {
+			return nil, ErrVideoNotFound
+		}
+		return nil, err
+	}
+	return video, nil
+}
+
+// ListMine возвращает видео пользователя.
+func (s *service) ListMine(ctx context.Context, ownerID uuid.UUID) ([]*domain.Video, error) {
+	return s.videos.ListByOwner(ctx, ownerID)
+}
+
+// Delete удаляет видео и его файл.
+func (s *service) Delete(ctx context.Context, actorID uuid.UUID, isAdmin bool, id uuid.UUID) error {
+	video, err := s.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+	if video.OwnerID != actorID && !isAdmin {
+		return ErrForbidden
+	}
+	if err := s.videos.Delete(ctx, id); err != nil {
+		if errors.Is(err, repo.ErrNotFound) {
+			return ErrVideoNotFound
+		}
+		return err
+	}
+	s.deleteObject(ctx, video.StorageKey)
+	return nil
+}
+
+// StreamLink выдаёт подписанную ссылку на воспроизведение.
+func (s *service) StreamLink(ctx context.Context, id uuid.UUID) (*StreamLink, error) {
+	video, err := s.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if video.Status != domain.StatusReady {
+		return nil, ErrNotReady
+	}
+
+	expiresAt := time.Now().UTC().Add(s.cfg.LinkTTL).Truncate(time.Second)
+	expires := strconv.FormatInt(expiresAt.Unix(), 10)
+	query := url.Values{}
+	query.Set("expires", expires)
+	query.Set("signature", s.sign(video.ID, expires))
+
+	return &StreamLink{
+		URL:       s.cfg.BaseURL + "/api/v1/videos/" + video.ID.String() + "/stream?" + query.Encode(),
+		ExpiresAt: expiresAt,
+	}, nil
+}
+
+// OpenStream проверяет ссылку и открывает видео.
+func (s *service) OpenStream(ctx context.Context, id uuid.UUID, expires, signature string) (*Stream, error) {
+	expected := s.sign(id, expires)
+	if !hmac.Equal([]byte(expected), []byte(signature)) {
+		return nil, ErrInvalidSignature
+	}
+	unix, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil {
+		return nil, ErrInvalidSignature
+	}
+	expiresAt := time.Unix(unix, 0)
+	if !time.Now().Before(expiresAt) {
+		return nil, ErrLinkExpired
+	}
+
+	video, err := s.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if video.Status != domain.StatusReady {
+		return nil, ErrNotReady
+	}
+
+	switch st := s.storage.(type) {
+	case storage.Opener:
+		obj, err := st.Open(ctx, video.StorageKey)
+		if err != nil {
+			if errors.Is(err, storage.ErrNotFound) {
+				return nil, ErrVideoNotFound
+			}
+			return nil, err
+		}
+		return &Stream{Video: video, Object: obj}, nil
+	case storage.Presigner:
+		ttl := time.Until(expiresAt)
+		if ttl < minRedirectTTL {
+			ttl = minRedirectTTL
+		}
+		redirect, err := st.PresignGet(video.StorageKey, ttl)
+		if err != nil {
+			return nil, err
+		}
+		return &Stream{Video: video, RedirectURL: redirect}, nil
+	}
+	return nil, ErrStreamingUnsupported
+}
+
+// sign возвращает HMAC-SHA256 подпись ссылки на видео id, действующей до expires.
+func (s *service) sign(id uuid.UUID, expires string) string {
+	mac := hmac.New(sha256.New, s.cfg.SigningKey)
+	mac.Write([]byte(id.String() + "." + expires))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func (s *service) deleteObject(ctx context.Context, key string) {
+	if err := s.storage.Delete(ctx, key); err != nil {
+		s.logger.Error("video_cleanup_failed", map[string]any{"key": key, "error": err.Error()})
+	}
+}
+
+// detectContentType определяет тип видео по первым байтам файла.
+// http.DetectContentType не распознаёт QuickTime (.mov с iPhone), поэтому бренд ftyp "qt  " проверяется отдельно.
+func detectContentType(head []byte) string {
+	if len(head) >= 12 && bytes.Equal(head[4:8], []byte("ftyp")) && bytes.Equal(head[8:12], []byte("qt  ")) {
+		return "video/quicktime"
+	}
+	return http.DetectContentType(head)
+}
+
+// videoKey строит ключ объекта со случайным именем: ссылка на файл не должна угадываться.
+func videoKey(ext string) (string, error) {
+	suffix := make([]byte, 16)
+	if _, err := rand.Read(suffix); err != nil {
+		return "", fmt.Errorf("failed to generate video key: %w", err)
+	}
+	return "videos/" + hex.EncodeToString(suffix) + ext, nil
+}
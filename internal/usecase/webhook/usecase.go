@@ -0,0 +1,524 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+
+	"workout-app/internal/domain/emailoutbox"
+	domain "workout-app/internal/domain/webhook"
+	repo "workout-app/internal/repository/interfaces"
+	"workout-app/internal/worker"
+	"workout-app/pkg/logger"
+)
+
+// Заголовки запроса уведомления.
+const (
+	HeaderEvent     = "X-Webhook-Event"     // Тип события
+	HeaderDelivery  = "X-Webhook-Delivery"  // ID уведомления; одинаков во всех попытках
+	HeaderSignature = "X-Webhook-Signature" // t=<unix-время>,v1=<hex HMAC-SHA256>
+)
+
+// Service описывает usecase-слой webhooks: управление адресами администратором
+// и фоновую доставку уведомлений с подписью и повторными попытками.
+type Service interface {
+	// ListEndpoints возвращает все адреса.
+	ListEndpoints(ctx context.Context) ([]*domain.Endpoint, error)
+
+	// CreateEndpoint добавляет адрес. Если ключ подписи не задан, он генерируется.
+	CreateEndpoint(ctx context.Context, actorID uuid.UUID, input EndpointInput) (*domain.Endpoint, error)
+
+	// UpdateEndpoint меняет заданные поля адреса.
+	UpdateEndpoint(ctx context.Context, id uuid.UUID, input EndpointUpdate) (*domain.Endpoint, error)
+
+	// RotateSecret заменяет ключ подписи адреса новым сгенерированным.
+	RotateSecret(ctx context.Context, id uuid.UUID) (*domain.Endpoint, error)
+
+	// DeleteEndpoint удаляет адрес вместе с его уведомлениями.
+	DeleteEndpoint(ctx context.Context, id uuid.UUID) error
+
+	// ListDeliveries возвращает страницу уведомлений адреса в статусе status (пусто — любом),
+	// новые первыми, и их общее количество.
+	ListDeliveries(ctx context.Context, endpointID uuid.UUID, status domain.Status, limit, offset int) ([]*domain.Delivery, int64, error)
+
+	// RetryDelivery возвращает уведомление из dead letter в очередь для немедленной отправки.
+	RetryDelivery(ctx context.Context, id int64) (*domain.Delivery, error)
+
+	// Run отправляет уведомления, время попытки которых наступило. Вызывается фоновым воркером.
+	Run(ctx context.Context) error
+
+	// Pending возвращает количество уведомлений в очереди.
+	Pending(ctx context.Context) (int64, error)
+
+	// DeleteExpired удаляет не более limit доставленных и dead-letter уведомлений, завершённых раньше
+	// before минус срок хранения (реализует cleanup.Target).
+	DeleteExpired(ctx context.Context, before time.Time, limit int) (int64, error)
+}
+
+// EndpointInput описывает новый адрес.
+type EndpointInput struct {
+	URL         string
+	Secret      string // Пусто — сгенерировать
+	EventTypes  []string
+	Description string
+}
+
+// EndpointUpdate описывает изменение адреса; nil-поля не меняются.
+type EndpointUpdate struct {
+	URL         *string
+	EventTypes  []string // nil — не менять
+	Active      *bool
+	Description *string
+}
+
+// Config описывает параметры доставки.
+type Config struct {
+	AllowHTTP   bool          // Разрешить адреса http:// (только для разработки)
+	BatchSize   int           // Уведомлений за один запуск воркера
+	MaxAttempts int           // Попыток до перевода уведомления в dead letter
+	RetryBase   time.Duration // Задержка после первой неудачной попытки; далее удваивается
+	RetryMax    time.Duration // Максимальная задержка между попытками
+	Timeout     time.Duration // Таймаут запроса к адресу
+	Retention   time.Duration // Срок хранения доставленных и dead-letter уведомлений
+	// Client — HTTP-клиент отправки (nil — отдельный клиент с таймаутом Timeout).
+	Client *http.Client
+}
+
+// Ошибки бизнес-логики usecase-слоя.
+var (
+	ErrInvalidURL         = fmt.Errorf("invalid webhook url")
+	ErrInsecureURL        = fmt.Errorf("webhook url must use https")
+	ErrInvalidEventType   = fmt.Errorf("unsupported webhook event type")
+	ErrNoEventTypes       = fmt.Errorf("webhook must subscribe to at least one event type")
+	ErrSecretTooShort     = fmt.Errorf("webhook secret is too short")
+	ErrDescriptionTooLong = fmt.Errorf("webhook description is too long")
+	ErrInvalidStatus      = fmt.Errorf("invalid webhook delivery status")
+	ErrNotDead            = fmt.Errorf("webhook delivery is not in dead letter")
+	ErrDisabled           = fmt.Errorf("webhooks are disabled")
+)
+
+const (
+	// claimTTL — аренда отправки; если процесс упал, уведомление подхватит другой по её истечении.
+	claimTTL     = 5 * time.Minute
+	defaultLimit = 50
+	maxLimit     = 200
+	// maxErrorLength ограничивает сохраняемый текст ошибки отправки (в символах).
+	maxErrorLength = 1000
+	// maxURLLength совпадает с длиной колонки url.
+	maxURLLength = 2048
+	// maxDescriptionLength совпадает с длиной колонки description.
+	maxDescriptionLength = 255
+	// minSecretLength — минимальная длина ключа подписи, заданного администратором.
+	minSecretLength = 16
+	// secretPrefix отличает сгенерированные ключи подписи в конфигурации получателя.
+	secretPrefix = "whsec_"
+	// userAgent позволяет получателю отличить уведомления от прочих запросов.
+	userAgent = "workout-app-webhooks/1.0"
+	// maxResponseBody — сколько байт ответа адреса сохраняется в ошибке.
+	maxResponseBody = 512
+)
+
+type service struct {
+	endpoints  repo.WebhookEndpointRepository
+	deliveries repo.WebhookDeliveryRepository
+	client     *http.Client
+	cfg        Config
+	now        func() time.Time
+	logger     logger.Logger
+}
+
+// NewService создаёт сервис webhooks.
+// endpoints == nil отключает webhooks (не задан ключ шифрования секретов): управление адресами
+// возвращает ErrDisabled, а воркер ничего не отправляет.
+func NewService(
+	endpoints repo.WebhookEndpointRepository,
+	deliveries repo.WebhookDeliveryRepository,
+	cfg Config,
+	logger logger.Logger,
+) Service {
+	return &service{
+		endpoints:  endpoints,
+		deliveries: deliveries,
+		client:     newHTTPClient(cfg),
+		cfg:        cfg,
+		now:        func() time.Time { return time.Now().UTC() },
+		logger:     logger,
+	}
+}
+
+// newHTTPClient возвращает копию клиента из конфигурации с таймаутом Timeout, не выполняющую перенаправления:
+// адрес задаёт администратор, и 3xx считается ошибкой доставки.
+func newHTTPClient(cfg Config) *http.Client {
+	client := &http.Client{}
+	if cfg.Client != nil {
+		*client = *cfg.Client
+	}
+	client.Timeout = cfg.Timeout
+	client.CheckRedirect = func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse }
+	return client
+}
+
+// ListEndpoints возвращает все адреса.
+func (s *service) ListEndpoints(ctx context.Context) ([]*domain.Endpoint, error) {
+	if s.endpoints == nil {
+		return nil, ErrDisabled
+	}
+	return s.endpoints.List(ctx)
+}
+
+// CreateEndpoint проверяет и сохраняет новый адрес.
+func (s *service) CreateEndpoint(ctx context.Context, actorID uuid.UUID, input EndpointInput) (*domain.Endpoint, error) {
+	if s.endpoints == nil {
+		return nil, ErrDisabled
+	}
+	rawURL, err := s.normalizeURL(input.URL)
+	if err != nil {
+		return nil, err
+	}
+	eventTypes, err := normalizeEventTypes(input.EventTypes)
+	if err != nil {
+		return nil, err
+	}
+	description, err := normalizeDescription(input.Description)
+	if err != nil {
+		return nil, err
+	}
+	secret := strings.TrimSpace(input.Secret)
+	if secret == "" {
+		if secret, err = generateSecret(); err != nil {
+			return nil, err
+		}
+	} else if len(secret) < minSecretLength {
+		return nil, ErrSecretTooShort
+	}
+
+	now := s.now()
+	e := &domain.Endpoint{
+		ID:          uuid.New(),
+		URL:         rawURL,
+		Secret:      secret,
+		EventTypes:  eventTypes,
+		Active:      true,
+		Description: description,
+		CreatedBy:   &actorID,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	if err := s.endpoints.Create(ctx, e); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// UpdateEndpoint меняет заданные поля адреса.
+func (s *service) UpdateEndpoint(ctx context.Context, id uuid.UUID, input EndpointUpdate) (*domain.Endpoint, error) {
+	if s.endpoints == nil {
+		return nil, ErrDisabled
+	}
+	e, err := s.endpoints.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if input.URL != nil {
+		if e.URL, err = s.normalizeURL(*input.URL); err != nil {
+			return nil, err
+		}
+	}
+	if input.EventTypes != nil {
+		if e.EventTypes, err = normalizeEventTypes(input.EventTypes); err != nil {
+			return nil, err
+		}
+	}
+	if input.Description != nil {
+		if e.Description, err = normalizeDescription(*input.Description); err != nil {
+			return nil, err
+		}
+	}
+	if input.Active != nil {
+		e.Active = *input.Active
+	}
+	e.UpdatedAt = s.now()
+
+	if err := s.endpoints.Update(ctx, e); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// RotateSecret генерирует новый ключ подписи. Уведомления в очереди будут подписаны новым ключом.
+func (s *service) RotateSecret(ctx context.Context, id uuid.UUID) (*domain.Endpoint, error) {
+	if s.endpoints == nil {
+		return nil, ErrDisabled
+	}
+	e, err := s.endpoints.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if e.Secret, err = generateSecret(); err != nil {
+		return nil, err
+	}
+	e.UpdatedAt = s.now()
+	if err := s.endpoints.Update(ctx, e); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// DeleteEndpoint удаляет адрес.
+func (s *service) DeleteEndpoint(ctx context.Context, id uuid.UUID) error {
+	if s.endpoints == nil {
+		return ErrDisabled
+	}
+	return s.endpoints.Delete(ctx, id)
+}
+
+// ListDeliveries возвращает страницу уведомлений адреса.
+func (s *service) ListDeliveries(ctx context.Context, endpointID uuid.UUID, status domain.Status, limit, offset int) ([]*domain.Delivery, int64, error) {
+	if s.endpoints == nil {
+		return nil, 0, ErrDisabled
+	}
+	if status != "" && !status.IsValid() {
+		return nil, 0, ErrInvalidStatus
+	}
+	if _, err := s.endpoints.GetByID(ctx, endpointID); err != nil {
+		return nil, 0, err
+	}
+	if limit <= 0 {
+		limit = defaultLimit
+	}
+	if limit > maxLimit {
+		limit = maxLimit
+	}
+	return s.deliveries.List(ctx, endpointID, status, limit, offset)
+}
+
+// RetryDelivery возвращает уведомление из dead letter в очередь.
+func (s *service) RetryDelivery(ctx context.Context, id int64) (*domain.Delivery, error) {
+	if s.endpoints == nil {
+		return nil, ErrDisabled
+	}
+	d, err := s.deliveries.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if d.Status != domain.StatusDead {
+		return nil, ErrNotDead
+	}
+	d.Requeue(s.now())
+	if err := s.deliveries.Save(ctx, d); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// Run отправляет уведомления из очереди. Ошибка отправки уведомления не прерывает запуск:
+// уведомление получает следующую попытку или уходит в dead letter.
+func (s *service) Run(ctx context.Context) error {
+	if s.endpoints == nil {
+		return nil
+	}
+	now := s.now()
+	due, err := s.deliveries.ListDue(ctx, now, s.cfg.BatchSize)
+	if err != nil {
+		return fmt.Errorf("failed to list due webhook deliveries: %w", err)
+	}
+	for _, d := range due {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		claimed, err := s.deliveries.Claim(ctx, d.ID, now, now.Add(claimTTL))
+		if err != nil {
+			return fmt.Errorf("failed to claim webhook delivery: %w", err)
+		}
+		if !claimed {
+			continue
+		}
+		if err := s.deliver(ctx, d); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Pending возвращает количество уведомлений в очереди; без настроенных webhooks очередь пуста.
+func (s *service) Pending(ctx context.Context) (int64, error) {
+	if s.endpoints == nil {
+		return 0, nil
+	}
+	return s.deliveries.CountPending(ctx)
+}
+
+// deliver отправляет уведомление и сохраняет результат попытки.
+func (s *service) deliver(ctx context.Context, d *domain.Delivery) error {
+	fields := map[string]any{
+		"delivery_id": d.ID,
+		"endpoint_id": d.EndpointID.String(),
+		"event_type":  d.EventType,
+	}
+
+	endpoint, err := s.endpoints.GetByID(ctx, d.EndpointID)
+	if err != nil {
+		return fmt.Errorf("failed to load webhook endpoint: %w", err)
+	}
+	if !endpoint.Active {
+		// Уведомления выключенного адреса не отправляются; после включения их можно повторить из dead letter.
+		d.MarkDead(s.now(), "endpoint is disabled")
+		s.logger.Warn("webhook_dead_letter", fields)
+		return s.save(ctx, d)
+	}
+
+	// Попытка учитывается в метриках типа webhook:<event> и продолжает трассу запроса, вызвавшего событие.
+	var statusCode int
+	sendErr := worker.Track(ctx, "webhook:"+d.EventType, d.TraceParent, func(ctx context.Context) error {
+		var err error
+		statusCode, err = s.send(ctx, endpoint, d)
+		return err
+	})
+	now := s.now()
+	fields["status_code"] = statusCode
+	if sendErr == nil {
+		d.MarkDelivered(now, statusCode)
+		return s.save(ctx, d)
+	}
+
+	d.MarkFailed(now, statusCode, truncateError(sendErr), emailoutbox.RetryDelay(d.Attempts+1, s.cfg.RetryBase, s.cfg.RetryMax))
+	fields["error"] = sendErr.Error()
+	if d.Attempts >= s.cfg.MaxAttempts {
+		d.MarkDead(now, d.LastError)
+		s.logger.Error("webhook_dead_letter", fields)
+	} else {
+		s.logger.Warn("webhook_delivery_failed", fields)
+	}
+	return s.save(ctx, d)
+}
+
+func (s *service) save(ctx context.Context, d *domain.Delivery) error {
+	if err := s.deliveries.Save(ctx, d); err != nil {
+		return fmt.Errorf("failed to save webhook delivery attempt: %w", err)
+	}
+	return nil
+}
+
+// send выполняет POST-запрос с подписанным телом. Успехом считается только ответ 2xx.
+func (s *service) send(ctx context.Context, e *domain.Endpoint, d *domain.Delivery) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.URL, bytes.NewReader(d.Body))
+	if err != nil {
+		return 0, err
+	}
+	timestamp := s.now().Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(HeaderEvent, d.EventType)
+	req.Header.Set(HeaderDelivery, strconv.FormatInt(d.ID, 10))
+	req.Header.Set(HeaderSignature, SignatureHeader(e.Secret, timestamp, d.Body))
+	req.Header.Set("User-Agent", userAgent)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, maxResponseBody))
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("unexpected status %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+	return resp.StatusCode, nil
+}
+
+// DeleteExpired удаляет завершённые уведомления старше срока хранения.
+func (s *service) DeleteExpired(ctx context.Context, before time.Time, limit int) (int64, error) {
+	return s.deliveries.DeleteFinished(ctx, before.Add(-s.cfg.Retention), limit)
+}
+
+// SignatureHeader возвращает значение заголовка X-Webhook-Signature:
+// t=<timestamp>,v1=<hex(HMAC-SHA256(secret, "<timestamp>.<body>"))>.
+// Получатель вычисляет подпись так же и отклоняет запросы со старым timestamp.
+func SignatureHeader(secret string, timestamp int64, body []byte) string {
+	ts := strconv.FormatInt(timestamp, 10)
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(ts))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "t=" + ts + ",v1=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// normalizeURL проверяет адрес: абсолютный http(s) без учётных данных; http — только если разрешён.
+func (s *service) normalizeURL(raw string) (string, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" || len(raw) > maxURLLength {
+		return "", ErrInvalidURL
+	}
+	u, err := url.Parse(raw)
+	if err != nil || u.Host == "" || u.User != nil || u.Fragment != "" {
+		return "", ErrInvalidURL
+	}
+	switch u.Scheme {
+	case "https":
+	case "http":
+		if !s.cfg.AllowHTTP {
+			return "", ErrInsecureURL
+		}
+	default:
+		return "", ErrInvalidURL
+	}
+	return u.String(), nil
+}
+
+// normalizeEventTypes проверяет типы событий и убирает повторы, сохраняя порядок.
+func normalizeEventTypes(raw []string) ([]string, error) {
+	result := make([]string, 0, len(raw))
+	seen := make(map[string]bool, len(raw))
+	for _, t := range raw {
+		t = strings.TrimSpace(t)
+		if !domain.IsSupportedEventType(t) {
+			return nil, ErrInvalidEventType
+		}
+		if seen[t] {
+			continue
+		}
+		seen[t] = true
+		result = append(result, t)
+	}
+	if len(result) == 0 {
+		return nil, ErrNoEventTypes
+	}
+	return result, nil
+}
+
+func normalizeDescription(raw string) (string, error) {
+	description := strings.TrimSpace(raw)
+	if utf8.RuneCountInString(description) > maxDescriptionLength {
+		return "", ErrDescriptionTooLong
+	}
+	return description, nil
+}
+
+// generateSecret возвращает случайный ключ подписи (32 байта).
+func generateSecret() (string, error) {
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		return "", fmt.Errorf("failed to generate webhook secret: %w", err)
+	}
+	return secretPrefix + base64.RawURLEncoding.EncodeToString(raw), nil
+}
+
+func truncateError(err error) string {
+	msg := []rune(err.Error())
+	if len(msg) > maxErrorLength {
+		msg = msg[:maxErrorLength]
+	}
+	return string(msg)
+}
@@ -0,0 +1,227 @@
+package weeklysummary
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	notificationdomain "workout-app/internal/domain/notification"
+	userdomain "workout-app/internal/domain/user"
+	domain "workout-app/internal/domain/weeklysummary"
+	workoutdomain "workout-app/internal/domain/workout"
+	repo "workout-app/internal/repository/interfaces"
+	"workout-app/pkg/logger"
+	"workout-app/pkg/mailer"
+)
+
+// Service описывает usecase-слой еженедельной сводки тренировок: в понедельник по часовому поясу
+// пользователя ему приходит письмо с итогами прошлой недели — тренировками, подходами, тоннажем
+// и личными рекордами. Письма отключаются настройкой уведомлений workout.weekly_summary.
+type Service interface {
+	// Run отправляет сводки пользователям, у которых в их часовом поясе наступило время сводки.
+	// Вызывается периодическим воркером.
+	Run(ctx context.Context) error
+
+	// DeleteExpired удаляет не более limit отметок обработанных сводок старше срока хранения
+	// (реализует cleanup.Target).
+	DeleteExpired(ctx context.Context, before time.Time, limit int) (int64, error)
+}
+
+// Config хранит параметры воркера сводок.
+type Config struct {
+	BatchSize int // Пользователей, читаемых из БД за один запрос
+	SendHour  int // Час понедельника по часовому поясу пользователя, с которого отправляется сводка
+	// Now возвращает текущее время (nil — time.Now в UTC). Сводка зависит от дня недели,
+	// поэтому время задаётся явно в тестах.
+	Now func() time.Time
+}
+
+const (
+	// recipientWindow — получатели ищутся по тренировкам за это время: в понедельник пользователя
+	// прошлая неделя началась меньше 8 суток назад; запас покрывает разницу часовых поясов.
+	recipientWindow = 9 * 24 * time.Hour
+
+	// maxRecords — сколько личных рекордов попадает в письмо; больше прирост — раньше.
+	maxRecords = 5
+
+	// summaryRetention — срок хранения отметок: неделя должна закончиться во всех часовых поясах.
+	summaryRetention = 14 * 24 * time.Hour
+
+	// Понедельник идёт где-то на Земле с 14:00 воскресенья до 12:00 вторника по UTC (UTC+14 … UTC−12).
+	mondayStartsBefore = 14 * time.Hour
+	mondayEndsAfter    = 36 * time.Hour
+)
+
+type service struct {
+	tx            repo.Transactor
+	users         repo.UserRepository
+	stats         repo.WorkoutStatsRepository
+	notifications repo.NotificationRepository
+	summaries     repo.WeeklySummaryRepository
+	sender        mailer.EmailSender
+	cfg           Config
+	now           func() time.Time
+	logger        logger.Logger
+}
+
+// NewService создаёт сервис еженедельных сводок. sender ставит письмо в очередь исходящих писем
+// в той же транзакции, что и отметка недели.
+func NewService(
+	tx repo.Transactor,
+	users repo.UserRepository,
+	stats repo.WorkoutStatsRepository,
+	notifications repo.NotificationRepository,
+	summaries repo.WeeklySummaryRepository,
+	sender mailer.EmailSender,
+	cfg Config,
+	logger logger.Logger,
+) Service {
+	now := cfg.Now
+	if now == nil {
+		now = func() time.Time { return time.Now().UTC() }
+	}
+	return &service{
+		tx:            tx,
+		users:         users,
+		stats:         stats,
+		notifications: notifications,
+		summaries:     summaries,
+		sender:        sender,
+		cfg:           cfg,
+		now:           now,
+		logger:        logger,
+	}
+}
+
+// Run обходит пачками по ID пользователей, тренировавшихся за последние дни. Вне понедельника
+// во всех часовых поясах обход не выполняется. Ошибка одного пользователя логируется и не мешает
+// остальным; он будет обработан следующим запуском.
+func (s *service) Run(ctx context.Context) error {
+	now := s.now()
+	if !isMondaySomewhere(now) {
+		return nil
+	}
+	after := uuid.Nil
+	for {
+		users, err := s.users.ListWeeklySummaryRecipients(ctx, now.Add(-recipientWindow), after, s.cfg.BatchSize)
+		if err != nil {
+			return fmt.Errorf("failed to list weekly summary recipients: %w", err)
+		}
+		for _, u := range users {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			if err := s.summarize(ctx, u, now); err != nil {
+				s.logger.Error("weekly_summary_failed", map[string]any{"user_id": u.ID.String(), "error": err.Error()})
+			}
+		}
+		if len(users) < s.cfg.BatchSize {
+			return nil
+		}
+		after = users[len(users)-1].ID
+	}
+}
+
+// summarize отправляет пользователю сводку за прошлую неделю, если у него понедельник и наступил час отправки.
+// Отметка и письмо сохраняются в одной транзакции, поэтому параллельные воркеры не отправят сводку дважды.
+func (s *service) summarize(ctx context.Context, u *userdomain.User, now time.Time) error {
+	if u.IsSuspended(now) {
+		return nil
+	}
+	loc := u.Location()
+	local := now.In(loc)
+	if local.Weekday() != time.Monday || local.Hour() < s.cfg.SendHour {
+		return nil
+	}
+	weekStart := domain.PreviousWeek(now, loc)
+	from := time.Date(weekStart.Year(), weekStart.Month(), weekStart.Day(), 0, 0, 0, 0, loc)
+	to := from.AddDate(0, 0, 7)
+
+	return s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		claimed, err := s.summaries.Claim(ctx, domain.New(u.ID, weekStart, now))
+		if err != nil || !claimed {
+			return err
+		}
+		enabled, err := s.emailEnabled(ctx, u.ID)
+		if err != nil || !enabled {
+			return err
+		}
+		// Недели без тренировок репозиторий не возвращает.
+		weeks, err := s.stats.Weekly(ctx, u.ID, from, to, loc.String())
+		if err != nil || len(weeks) == 0 {
+			return err
+		}
+		records, err := s.stats.PersonalRecords(ctx, u.ID, from, to)
+		if err != nil {
+			return err
+		}
+
+		summary := newSummary(weekStart, weeks[0], records, u.Units.OrDefault())
+
+		err = s.sender.SendWeeklySummary(mailer.WithRecipient(ctx, u.MailLocale(), u.Timezone), u.Email, summary)
+		if errors.Is(err, mailer.ErrRecipientUndeliverable) {
+			// Адрес заблокирован: неделя остаётся отмеченной, чтобы не проверять её каждый запуск.
+			s.logger.Info("weekly_summary_undeliverable", map[string]any{"user_id": u.ID.String()})
+			return nil
+		}
+		return err
+	})
+}
+
+// emailEnabled сообщает, включены ли у пользователя письма со сводкой; без сохранённой настройки — включены.
+func (s *service) emailEnabled(ctx context.Context, userID uuid.UUID) (bool, error) {
+	prefs, err := s.notifications.GetPreferences(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+	for _, p := range prefs {
+		if p.Type == notificationdomain.TypeWeeklySummary {
+			return p.Email, nil
+		}
+	}
+	return true, nil
+}
+
+// newSummary собирает данные письма в единицах пользователя; в письмо попадают первые maxRecords рекордов.
+func newSummary(weekStart time.Time, week workoutdomain.WeekStats, records []workoutdomain.PersonalRecord, units userdomain.Units) mailer.WeeklySummary {
+	summary := mailer.WeeklySummary{
+		WeekStart:    weekStart,
+		Sessions:     week.Sessions,
+		TrainingDays: week.TrainingDays,
+		Sets:         week.Sets,
+		Volume:       userdomain.RoundUnit(units.Weight(week.VolumeKg)),
+		WeightUnit:   mailer.WeightUnitKg,
+	}
+	if units == userdomain.UnitsImperial {
+		summary.WeightUnit = mailer.WeightUnitLb
+	}
+	for _, r := range records[:min(len(records), maxRecords)] {
+		summary.PersonalRecords = append(summary.PersonalRecords, mailer.PersonalRecord{
+			Exercise: r.Exercise,
+			Weight:   userdomain.RoundUnit(units.Weight(r.WeightKg)),
+			Previous: userdomain.RoundUnit(units.Weight(r.PreviousKg)),
+		})
+	}
+	return summary
+}
+
+// isMondaySomewhere сообщает, идёт ли в момент now понедельник хотя бы в одном часовом поясе.
+func isMondaySomewhere(now time.Time) bool {
+	y, m, d := now.UTC().Date()
+	today := time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+	monday := today.AddDate(0, 0, -((int(today.Weekday()) + 6) % 7))
+	for _, start := range []time.Time{monday, monday.AddDate(0, 0, 7)} {
+		if !now.Before(start.Add(-mondayStartsBefore)) && now.Before(start.Add(mondayEndsAfter)) {
+			return true
+		}
+	}
+	return false
+}
+
+// DeleteExpired удаляет отметки старше срока хранения.
+func (s *service) DeleteExpired(ctx context.Context, before time.Time, limit int) (int64, error) {
+	return s.summaries.DeleteBefore(ctx, before.Add(-summaryRetention), limit)
+}
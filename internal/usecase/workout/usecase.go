@@ -0,0 +1,481 @@
+package workout
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+
+	consentdomain "workout-app/internal/domain/consent"
+	eventdomain "workout-app/internal/domain/event"
+	programdomain "workout-app/internal/domain/program"
+	domain "workout-app/internal/domain/workout"
+	"workout-app/internal/events"
+	repo "workout-app/internal/repository/interfaces"
+	consentuc "workout-app/internal/usecase/consent"
+	socialuc "workout-app/internal/usecase/social"
+)
+
+// Service описывает usecase-слой тренировок: старт, запись подходов со временем сервера,
+// завершение и итоги с учётом автопаузы.
+type Service interface {
+	// Start начинает тренировку. Незавершённая тренировка без активности дольше staleSessionAfter
+	// завершается автоматически; иначе возвращается ErrSessionActive.
+	Start(ctx context.Context, userID uuid.UUID, input StartInput) (*domain.Session, error)
+
+	// LogSet записывает подход идущей тренировки; время подхода задаёт сервер.
+	// Значения за пределами правдоподобных границ в режиме warn сохраняются с пометкой Implausible
+	// и возвращаются предупреждениями, в режиме reject — отклоняются ошибкой *ImplausibleError.
+	LogSet(ctx context.Context, userID, sessionID uuid.UUID, input SetInput) (*domain.Set, []domain.Warning, error)
+
+	// Finish завершает тренировку и публикует событие workout.finished.
+	Finish(ctx context.Context, userID, sessionID uuid.UUID) (*domain.Session, error)
+
+	// RateDifficulty сохраняет субъективную сложность завершённой тренировки (RPE 1–10).
+	// Оценку можно изменить; оценки тренировок по программе формируют её среднюю сложность
+	// и предлагаемое изменение нагрузки в расписании назначения.
+	RateDifficulty(ctx context.Context, userID, sessionID uuid.UUID, difficulty int) (*domain.Session, error)
+
+	// Get возвращает тренировку пользователя.
+	Get(ctx context.Context, userID, sessionID uuid.UUID) (*domain.Session, error)
+
+	// GetActive возвращает идущую тренировку пользователя.
+	GetActive(ctx context.Context, userID uuid.UUID) (*domain.Session, error)
+
+	// List возвращает тренировки пользователя, начатые в [from, to), новые первыми.
+	List(ctx context.Context, userID uuid.UUID, from, to time.Time) ([]*domain.Session, error)
+
+	// ListForViewer возвращает тренировки ownerID пользователю viewerID, если настройка видимости владельца это позволяет.
+	ListForViewer(ctx context.Context, viewerID, ownerID uuid.UUID, from, to time.Time) ([]*domain.Session, error)
+
+	// Stats суммирует итоги завершённых тренировок, начатых в [from, to).
+	Stats(ctx context.Context, userID uuid.UUID, from, to time.Time) (*Stats, error)
+
+	// ListForCoach возвращает тренировки клиента тренеру, если клиент открыл ему класс данных workouts.
+	ListForCoach(ctx context.Context, coachID, clientID uuid.UUID, from, to time.Time) ([]*domain.Session, error)
+
+	// StatsForCoach возвращает итоги тренировок клиента тренеру, если клиент открыл ему класс данных workouts.
+	StatsForCoach(ctx context.Context, coachID, clientID uuid.UUID, from, to time.Time) (*Stats, error)
+}
+
+// StartInput описывает параметры новой тренировки.
+type StartInput struct {
+	Title          string
+	AssignmentID   *uuid.UUID    // Назначение программы пользователю (опционально)
+	AutoPauseAfter time.Duration // Порог автопаузы; 0 — значение по умолчанию
+	// ProgramWorkout — тренировка программы назначения, план которой (упражнения, темп, отдых)
+	// фиксируется в тренировке; требует AssignmentID.
+	ProgramWorkout *ProgramWorkoutRef
+}
+
+// ProgramWorkoutRef указывает тренировку программы: неделя, день недели и порядковый номер
+// тренировки в дне (с 0).
+type ProgramWorkoutRef struct {
+	Week  int
+	Day   int
+	Index int
+}
+
+// SetInput описывает выполненный подход.
+type SetInput struct {
+	Exercise string
+	Reps     int
+	WeightKg *float64
+}
+
+// Stats описывает суммарные итоги тренировок за период.
+type Stats struct {
+	Sessions        int
+	Sets            int
+	VolumeKg        float64
+	ActiveDuration  time.Duration
+	PausedDuration  time.Duration
+	VolumePerMinute float64 // Тоннаж на минуту активного времени за период
+}
+
+// Ошибки бизнес-логики usecase-слоя.
+var (
+	ErrInvalidTitle        = fmt.Errorf("workout title must be 1-200 characters")
+	ErrInvalidAutoPause    = fmt.Errorf("auto-pause threshold is out of range")
+	ErrInvalidSet          = fmt.Errorf("invalid workout set")
+	ErrInvalidPeriod       = fmt.Errorf("invalid period")
+	ErrSessionNotFound     = fmt.Errorf("workout session not found")
+	ErrSessionFinished     = fmt.Errorf("workout session is already finished")
+	ErrSessionActive       = fmt.Errorf("another workout session is in progress")
+	ErrTooManySets         = fmt.Errorf("too many sets in workout session")
+	ErrAssignmentNotFound  = fmt.Errorf("program assignment not found")
+	ErrPlanNeedsAssignment = fmt.Errorf("program workout requires assignment")
+	ErrPlanNotFound        = fmt.Errorf("program workout not found")
+	ErrInvalidDifficulty   = fmt.Errorf("workout difficulty must be between 1 and 10")
+	ErrSessionNotFinished  = fmt.Errorf("workout session is not finished yet")
+)
+
+// Ограничения тренировок.
+const (
+	MinAutoPauseAfter     = 30 * time.Second
+	MaxAutoPauseAfter     = time.Hour
+	maxTitleLength        = 200
+	maxExerciseNameLength = 100
+	maxRepsPerSet         = 1000
+	maxSetWeightKg        = 1000
+	maxSetsPerSession     = 500
+	maxSessionsPerList    = 100
+	maxStatsPeriod        = 366 * 24 * time.Hour
+	// staleSessionAfter — через сколько без подходов незавершённая тренировка считается брошенной.
+	staleSessionAfter = 12 * time.Hour
+)
+
+type service struct {
+	sessions         repo.WorkoutSessionRepository
+	programs         repo.ProgramRepository
+	events           events.Publisher
+	access           socialuc.Checker
+	consents         consentuc.Checker
+	validator        *Validator
+	defaultAutoPause time.Duration
+	now              func() time.Time
+}
+
+// NewService создаёт новый сервис тренировок.
+// defaultAutoPause — порог автопаузы для тренировок, где клиент его не задал.
+// access проверяет видимость тренировок перед выдачей их другим пользователям, consents — согласия
+// клиентов перед выдачей тренировок тренеру; validator проверяет правдоподобие подходов (nil — проверка отключена).
+func NewService(sessions repo.WorkoutSessionRepository, programs repo.ProgramRepository, publisher events.Publisher, access socialuc.Checker, consents consentuc.Checker, validator *Validator, defaultAutoPause time.Duration) Service {
+	return &service{
+		sessions:         sessions,
+		programs:         programs,
+		events:           publisher,
+		access:           access,
+		consents:         consents,
+		validator:        validator,
+		defaultAutoPause: defaultAutoPause,
+		now:              time.Now,
+	}
+}
+
+// Start начинает тренировку.
+func (s *service) Start(ctx context.Context, userID uuid.UUID, input StartInput) (*domain.Session, error) {
+	title := strings.TrimSpace(input.Title)
+	if title == "" || utf8.RuneCountInString(title) > maxTitleLength {
+		return nil, ErrInvalidTitle
+	}
+	autoPause := input.AutoPauseAfter
+	if autoPause == 0 {
+		autoPause = s.defaultAutoPause
+	}
+	if autoPause < MinAutoPauseAfter || autoPause > MaxAutoPauseAfter {
+		return nil, ErrInvalidAutoPause
+	}
+	if input.ProgramWorkout != nil && input.AssignmentID == nil {
+		return nil, ErrPlanNeedsAssignment
+	}
+	var plan []domain.PlannedExercise
+	if input.AssignmentID != nil {
+		assignment, err := s.findAssignment(ctx, userID, *input.AssignmentID)
+		if err != nil {
+			return nil, err
+		}
+		if input.ProgramWorkout != nil {
+			if plan, err = s.programPlan(ctx, assignment.ProgramID, *input.ProgramWorkout); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	now := s.now().UTC()
+	if err := s.finishStale(ctx, userID, now); err != nil {
+		return nil, err
+	}
+
+	session := domain.NewSession(userID, title, input.AssignmentID, autoPause, now)
+	session.Plan = plan
+	if err := s.sessions.Create(ctx, session); err != nil {
+		if errors.Is(err, repo.ErrActiveSessionExists) {
+			return nil, ErrSessionActive
+		}
+		return nil, err
+	}
+	session.Sets = []domain.Set{}
+	return session, nil
+}
+
+// finishStale завершает брошенную тренировку пользователя временем последней активности.
+func (s *service) finishStale(ctx context.Context, userID uuid.UUID, now time.Time) error {
+	active, err := s.sessions.GetActive(ctx, userID)
+	if errors.Is(err, repo.ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	lastActivity := active.LastActivityAt()
+	if now.Sub(lastActivity) < staleSessionAfter {
+		return ErrSessionActive
+	}
+	if err := s.sessions.Finish(ctx, active.ID, lastActivity); err != nil && !errors.Is(err, repo.ErrNotFound) {
+		return err
+	}
+	active.FinishedAt = &lastActivity
+	s.publishFinished(ctx, active)
+	return nil
+}
+
+// findAssignment возвращает назначение, если оно принадлежит пользователю.
+func (s *service) findAssignment(ctx context.Context, userID, assignmentID uuid.UUID) (*programdomain.Assignment, error) {
+	assignments, err := s.programs.ListAssignmentsByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	for _, a := range assignments {
+		if a.ID == assignmentID {
+			return a, nil
+		}
+	}
+	return nil, ErrAssignmentNotFound
+}
+
+// programPlan собирает план тренировки программы: упражнения с весом, темпом и отдыхом
+// после каждого подхода.
+func (s *service) programPlan(ctx context.Context, programID uuid.UUID, ref ProgramWorkoutRef) ([]domain.PlannedExercise, error) {
+	p, err := s.programs.GetByID(ctx, programID)
+	if err != nil {
+		if errors.Is(err, repo.ErrNotFound) {
+			return nil, ErrPlanNotFound
+		}
+		return nil, err
+	}
+	for _, w := range p.Weeks {
+		if w.Number != ref.Week {
+			continue
+		}
+		for _, d := range w.Days {
+			if d.Number != ref.Day || ref.Index < 0 || ref.Index >= len(d.Workouts) {
+				continue
+			}
+			exercises := d.Workouts[ref.Index].Exercises
+			plan := make([]domain.PlannedExercise, 0, len(exercises))
+			for _, e := range exercises {
+				planned := domain.PlannedExercise{
+					Exercise: e.Name,
+					Sets:     e.Sets,
+					Reps:     e.Reps,
+					WeightKg: e.WeightKg,
+					Tempo:    e.Tempo,
+				}
+				for set := 1; set <= e.Sets; set++ {
+					rest := e.RestAfterSet(set)
+					if rest == nil {
+						break
+					}
+					planned.RestSeconds = append(planned.RestSeconds, *rest)
+				}
+				plan = append(plan, planned)
+			}
+			return plan, nil
+		}
+	}
+	return nil, ErrPlanNotFound
+}
+
+// LogSet записывает подход. Жёсткие ограничения отсекают невозможные значения всегда,
+// правдоподобные границы проверяются по режиму валидатора.
+func (s *service) LogSet(ctx context.Context, userID, sessionID uuid.UUID, input SetInput) (*domain.Set, []domain.Warning, error) {
+	exercise := strings.TrimSpace(input.Exercise)
+	if exercise == "" || utf8.RuneCountInString(exercise) > maxExerciseNameLength ||
+		input.Reps < 1 || input.Reps > maxRepsPerSet ||
+		(input.WeightKg != nil && (*input.WeightKg < 0 || *input.WeightKg > maxSetWeightKg)) {
+		return nil, nil, ErrInvalidSet
+	}
+
+	rules, err := s.validator.Rules(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	warnings := rules.CheckSet(exercise, input.Reps, input.WeightKg)
+	if len(warnings) > 0 && rules.Mode == domain.ValidationReject {
+		return nil, nil, &ImplausibleError{Warnings: warnings}
+	}
+
+	session, err := s.Get(ctx, userID, sessionID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !session.IsActive() {
+		return nil, nil, ErrSessionFinished
+	}
+	if len(session.Sets) >= maxSetsPerSession {
+		return nil, nil, ErrTooManySets
+	}
+
+	set := &domain.Set{
+		ID:          uuid.New(),
+		SessionID:   session.ID,
+		Exercise:    exercise,
+		Reps:        input.Reps,
+		WeightKg:    input.WeightKg,
+		LoggedAt:    s.now().UTC(),
+		Implausible: len(warnings) > 0,
+	}
+	if err := s.sessions.AddSet(ctx, set); err != nil {
+		return nil, nil, err
+	}
+	session.Sets = append(session.Sets, *set)
+	set.PrescribedRest = session.PrescribedRest(len(session.Sets) - 1)
+	return set, warnings, nil
+}
+
+// Finish завершает тренировку.
+func (s *service) Finish(ctx context.Context, userID, sessionID uuid.UUID) (*domain.Session, error) {
+	session, err := s.Get(ctx, userID, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	if !session.IsActive() {
+		return nil, ErrSessionFinished
+	}
+
+	now := s.now().UTC()
+	if err := s.sessions.Finish(ctx, session.ID, now); err != nil {
+		if errors.Is(err, repo.ErrNotFound) {
+			// Параллельный запрос успел завершить тренировку.
+			return nil, ErrSessionFinished
+		}
+		return nil, err
+	}
+	session.FinishedAt = &now
+	s.publishFinished(ctx, session)
+	return session, nil
+}
+
+// RateDifficulty сохраняет оценку сложности завершённой тренировки.
+func (s *service) RateDifficulty(ctx context.Context, userID, sessionID uuid.UUID, difficulty int) (*domain.Session, error) {
+	if difficulty < domain.MinDifficulty || difficulty > domain.MaxDifficulty {
+		return nil, ErrInvalidDifficulty
+	}
+
+	session, err := s.Get(ctx, userID, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	if session.IsActive() {
+		return nil, ErrSessionNotFinished
+	}
+
+	now := s.now().UTC()
+	if err := s.sessions.SetDifficulty(ctx, session.ID, difficulty, now); err != nil {
+		if errors.Is(err, repo.ErrNotFound) {
+			return nil, ErrSessionNotFound
+		}
+		return nil, err
+	}
+	session.Difficulty = &difficulty
+	session.DifficultyRatedAt = &now
+	return session, nil
+}
+
+// publishFinished публикует итоги завершённой тренировки.
+func (s *service) publishFinished(ctx context.Context, session *domain.Session) {
+	summary := session.Summarize(*session.FinishedAt)
+	s.events.Publish(ctx, eventdomain.TypeWorkoutFinished, session.ID.String(), eventdomain.WorkoutFinished{
+		SessionID:     session.ID.String(),
+		UserID:        session.UserID.String(),
+		StartedAt:     session.StartedAt,
+		FinishedAt:    *session.FinishedAt,
+		ActiveSeconds: int64(summary.ActiveDuration / time.Second),
+		Sets:          summary.Sets,
+		VolumeKg:      summary.VolumeKg,
+	})
+}
+
+// Get возвращает тренировку пользователя; чужие тренировки не раскрываются.
+func (s *service) Get(ctx context.Context, userID, sessionID uuid.UUID) (*domain.Session, error) {
+	session, err := s.sessions.GetByID(ctx, sessionID)
+	if err != nil {
+		if errors.Is(err, repo.ErrNotFound) {
+			return nil, ErrSessionNotFound
+		}
+		return nil, err
+	}
+	if session.UserID != userID {
+		return nil, ErrSessionNotFound
+	}
+	return session, nil
+}
+
+// GetActive возвращает идущую тренировку пользователя.
+func (s *service) GetActive(ctx context.Context, userID uuid.UUID) (*domain.Session, error) {
+	session, err := s.sessions.GetActive(ctx, userID)
+	if err != nil {
+		if errors.Is(err, repo.ErrNotFound) {
+			return nil, ErrSessionNotFound
+		}
+		return nil, err
+	}
+	return session, nil
+}
+
+// List возвращает тренировки пользователя за период.
+func (s *service) List(ctx context.Context, userID uuid.UUID, from, to time.Time) ([]*domain.Session, error) {
+	if !from.Before(to) {
+		return nil, ErrInvalidPeriod
+	}
+	return s.sessions.ListByUser(ctx, userID, from, to, maxSessionsPerList)
+}
+
+// ListForViewer возвращает тренировки владельца другому пользователю с учётом настройки видимости.
+func (s *service) ListForViewer(ctx context.Context, viewerID, ownerID uuid.UUID, from, to time.Time) ([]*domain.Session, error) {
+	if err := s.access.RequireWorkoutsAccess(ctx, viewerID, ownerID); err != nil {
+		return nil, err
+	}
+	return s.List(ctx, ownerID, from, to)
+}
+
+// Stats суммирует итоги завершённых тренировок за период.
+func (s *service) Stats(ctx context.Context, userID uuid.UUID, from, to time.Time) (*Stats, error) {
+	if !from.Before(to) || to.Sub(from) > maxStatsPeriod {
+		return nil, ErrInvalidPeriod
+	}
+	// За год набирается не больше нескольких сотен тренировок, лимит выборки с запасом.
+	sessions, err := s.sessions.ListByUser(ctx, userID, from, to, 2*366)
+	if err != nil {
+		return nil, err
+	}
+
+	stats := &Stats{}
+	for _, session := range sessions {
+		if session.IsActive() {
+			continue
+		}
+		summary := session.Summarize(*session.FinishedAt)
+		stats.Sessions++
+		stats.Sets += summary.Sets
+		stats.VolumeKg += summary.VolumeKg
+		stats.ActiveDuration += summary.ActiveDuration
+		stats.PausedDuration += summary.PausedDuration
+	}
+	if minutes := stats.ActiveDuration.Minutes(); minutes > 0 {
+		stats.VolumePerMinute = stats.VolumeKg / minutes
+	}
+	return stats, nil
+}
+
+// ListForCoach возвращает тренировки клиента за период тренеру с согласия клиента.
+func (s *service) ListForCoach(ctx context.Context, coachID, clientID uuid.UUID, from, to time.Time) ([]*domain.Session, error) {
+	if err := s.consents.Require(ctx, coachID, clientID, consentdomain.ScopeWorkouts); err != nil {
+		return nil, err
+	}
+	return s.List(ctx, clientID, from, to)
+}
+
+// StatsForCoach возвращает итоги тренировок клиента за период тренеру с согласия клиента.
+func (s *service) StatsForCoach(ctx context.Context, coachID, clientID uuid.UUID, from, to time.Time) (*Stats, error) {
+	if err := s.consents.Require(ctx, coachID, clientID, consentdomain.ScopeWorkouts); err != nil {
+		return nil, err
+	}
+	return s.Stats(ctx, clientID, from, to)
+}
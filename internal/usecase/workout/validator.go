@@ -0,0 +1,97 @@
+package workout
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	domain "workout-app/internal/domain/workout"
+	repo "workout-app/internal/repository/interfaces"
+)
+
+// ErrImplausibleSet возвращается в режиме reject, если данные за пределами правдоподобных границ.
+var ErrImplausibleSet = fmt.Errorf("workout data is outside plausible limits")
+
+// ImplausibleError описывает отклонённые данные; совместима с ErrImplausibleSet.
+type ImplausibleError struct {
+	Warnings []domain.Warning
+}
+
+func (e *ImplausibleError) Error() string {
+	return ErrImplausibleSet.Error()
+}
+
+func (e *ImplausibleError) Unwrap() error {
+	return ErrImplausibleSet
+}
+
+// ValidationConfig задаёт режим проверки правдоподобия и общие границы.
+type ValidationConfig struct {
+	Mode        domain.ValidationMode
+	MaxWeightKg float64
+	MaxReps     int
+	MaxDuration time.Duration
+}
+
+// Validator выдаёт правила проверки правдоподобия: общие границы из конфигурации и границы
+// упражнений из справочника exercise_limits. Справочник читается на каждом подходе, поэтому
+// кешируется в памяти на cacheTTL. Безопасен для конкурентного использования; nil — проверка отключена.
+type Validator struct {
+	limits   repo.ExerciseLimitRepository
+	cfg      ValidationConfig
+	cacheTTL time.Duration
+
+	mu        sync.Mutex
+	exercises map[string]domain.ExerciseLimits
+	loadedAt  time.Time
+}
+
+// NewValidator создаёт валидатор записанных тренировок.
+func NewValidator(limits repo.ExerciseLimitRepository, cfg ValidationConfig, cacheTTL time.Duration) *Validator {
+	return &Validator{
+		limits:   limits,
+		cfg:      cfg,
+		cacheTTL: cacheTTL,
+	}
+}
+
+// Rules возвращает действующие правила. В режиме off справочник не читается.
+func (v *Validator) Rules(ctx context.Context) (domain.Rules, error) {
+	if v == nil || v.cfg.Mode == domain.ValidationOff {
+		return domain.Rules{Mode: domain.ValidationOff}, nil
+	}
+	exercises, err := v.snapshot(ctx)
+	if err != nil {
+		return domain.Rules{}, err
+	}
+	return domain.Rules{
+		Mode:        v.cfg.Mode,
+		MaxWeightKg: v.cfg.MaxWeightKg,
+		MaxReps:     v.cfg.MaxReps,
+		MaxDuration: v.cfg.MaxDuration,
+		Exercises:   exercises,
+	}, nil
+}
+
+// snapshot возвращает закешированные границы упражнений или перечитывает справочник из БД.
+func (v *Validator) snapshot(ctx context.Context) (map[string]domain.ExerciseLimits, error) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	if v.exercises != nil && time.Since(v.loadedAt) < v.cacheTTL {
+		return v.exercises, nil
+	}
+
+	limits, err := v.limits.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	exercises := make(map[string]domain.ExerciseLimits, len(limits))
+	for _, l := range limits {
+		exercises[l.Exercise] = l
+	}
+	v.exercises = exercises
+	v.loadedAt = time.Now()
+	return v.exercises, nil
+}
@@ -0,0 +1,5 @@
+package version
+
+// Version — версия приложения.
+// Переопределяется при сборке: go build -ldflags "-X workout-app/internal/version.Version=1.2.3".
+var Version = "1.0.0"
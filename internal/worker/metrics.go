@@ -0,0 +1,153 @@
+package worker
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"workout-app/pkg/logger"
+	"workout-app/pkg/tracing"
+)
+
+// recentFailuresLimit — сколько последних ошибок фоновых задач хранится для администратора.
+const recentFailuresLimit = 50
+
+// DurationBuckets — верхние границы корзин гистограммы длительности выполнения.
+var DurationBuckets = []time.Duration{
+	10 * time.Millisecond,
+	50 * time.Millisecond,
+	100 * time.Millisecond,
+	500 * time.Millisecond,
+	time.Second,
+	5 * time.Second,
+	30 * time.Second,
+}
+
+// JobMetrics описывает метрики одного типа задач с момента старта процесса.
+type JobMetrics struct {
+	Type          string
+	Runs          int64
+	Failures      int64
+	TotalDuration time.Duration
+	MaxDuration   time.Duration
+	// Buckets[i] — число выполнений не дольше DurationBuckets[i]; последний элемент — дольше всех границ.
+	Buckets []int64
+}
+
+// Failure описывает неудачное выполнение задачи.
+type Failure struct {
+	Type     string
+	At       time.Time
+	Duration time.Duration
+	Error    string
+	TraceID  string // Трасса исходного запроса или запуска воркера
+}
+
+// Metrics накапливает длительность и ошибки выполнений фоновых задач по их типам
+// и хранит последние ошибки. Безопасен для конкурентного использования.
+type Metrics struct {
+	mu       sync.Mutex
+	jobs     map[string]*JobMetrics
+	failures []Failure // Кольцевой буфер; next — позиция следующей записи
+	next     int
+}
+
+// NewMetrics создаёт пустой набор метрик.
+func NewMetrics() *Metrics {
+	return &Metrics{jobs: make(map[string]*JobMetrics)}
+}
+
+// Observe учитывает выполнение задачи типа jobType. Безопасно вызывать на nil.
+func (m *Metrics) Observe(jobType string, at time.Time, d time.Duration, err error, traceID string) {
+	if m == nil {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	job, ok := m.jobs[jobType]
+	if !ok {
+		job = &JobMetrics{Type: jobType, Buckets: make([]int64, len(DurationBuckets)+1)}
+		m.jobs[jobType] = job
+	}
+	job.Runs++
+	job.TotalDuration += d
+	if d > job.MaxDuration {
+		job.MaxDuration = d
+	}
+	bucket := sort.Search(len(DurationBuckets), func(i int) bool { return d <= DurationBuckets[i] })
+	job.Buckets[bucket]++
+
+	if err == nil {
+		return
+	}
+	job.Failures++
+	failure := Failure{Type: jobType, At: at, Duration: d, Error: err.Error(), TraceID: traceID}
+	if len(m.failures) < recentFailuresLimit {
+		m.failures = append(m.failures, failure)
+	} else {
+		m.failures[m.next] = failure
+	}
+	m.next = (m.next + 1) % recentFailuresLimit
+}
+
+// Jobs возвращает метрики всех типов задач, упорядоченные по типу.
+func (m *Metrics) Jobs() []JobMetrics {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	jobs := make([]JobMetrics, 0, len(m.jobs))
+	for _, job := range m.jobs {
+		copied := *job
+		copied.Buckets = append([]int64(nil), job.Buckets...)
+		jobs = append(jobs, copied)
+	}
+	sort.Slice(jobs, func(i, j int) bool { return jobs[i].Type < jobs[j].Type })
+	return jobs
+}
+
+// RecentFailures возвращает последние ошибки, новые первыми.
+func (m *Metrics) RecentFailures() []Failure {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	failures := make([]Failure, 0, len(m.failures))
+	for i := 1; i <= len(m.failures); i++ {
+		failures = append(failures, m.failures[(m.next-i+len(m.failures))%len(m.failures)])
+	}
+	return failures
+}
+
+type metricsContextKey struct{}
+
+// WithMetrics возвращает контекст, в котором Track учитывает выполнения в m.
+func WithMetrics(ctx context.Context, m *Metrics) context.Context {
+	return context.WithValue(ctx, metricsContextKey{}, m)
+}
+
+// MetricsFromContext возвращает метрики из контекста или nil.
+func MetricsFromContext(ctx context.Context) *Metrics {
+	m, _ := ctx.Value(metricsContextKey{}).(*Metrics)
+	return m
+}
+
+// Track выполняет единицу работы фоновой задачи (отправку письма, уведомления) в span'е jobType.
+// traceParent — сохранённый при постановке задачи traceparent исходного запроса: span продолжает его трассу.
+// Выполнение учитывается в метриках из контекста и пишется в лог из контекста (уровень debug, при ошибке — warn).
+func Track(ctx context.Context, jobType, traceParent string, fn func(ctx context.Context) error) error {
+	parent, _ := tracing.Parse(traceParent)
+	ctx, span := tracing.Start(ctx, jobType, parent)
+	err := fn(ctx)
+	duration := time.Since(span.Start)
+
+	MetricsFromContext(ctx).Observe(jobType, span.Start, duration, err, span.Context.TraceID)
+	if log := logger.FromContext(ctx, nil); log != nil {
+		if err != nil {
+			log.Warn("job_span", span.Fields(err))
+		} else {
+			log.Debug("job_span", span.Fields(nil))
+		}
+	}
+	return err
+}
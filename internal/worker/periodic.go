@@ -0,0 +1,232 @@
+// Package worker содержит исполнителей фоновых задач процесса.
+package worker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"workout-app/pkg/lock"
+	"workout-app/pkg/logger"
+	"workout-app/pkg/tracing"
+)
+
+// RunFunc выполняет один запуск периодической задачи.
+type RunFunc func(ctx context.Context) error
+
+// Stats описывает статистику запусков периодической задачи с момента старта процесса.
+type Stats struct {
+	Runs         int64         // Завершённых запусков
+	Failures     int64         // Из них с ошибкой
+	Skipped      int64         // Пропущено: задачу в это время выполняла другая реплика
+	LastRunAt    time.Time     // Начало последнего запуска (нулевое — запусков ещё не было)
+	LastDuration time.Duration // Длительность последнего запуска
+	LastError    string        // Ошибка последнего запуска (пусто — успешный)
+}
+
+// Periodic запускает задачу сразу после старта и далее с заданным интервалом.
+// Запуски не перекрываются: следующий начинается через interval после окончания предыдущего.
+// Регистрируется в lifecycle.Manager методами Start и Stop.
+// Каждый запуск выполняется в новой трассе и учитывается в метриках под именем задачи;
+// через контекст запуска метрики и логгер доступны worker.Track.
+// С распределённой блокировкой (UseLock) запуск выполняет только одна реплика.
+type Periodic struct {
+	name     string
+	interval time.Duration
+	run      RunFunc
+	metrics  *Metrics
+	logger   logger.Logger
+
+	locker  lock.Locker
+	lockTTL time.Duration
+
+	wg     sync.WaitGroup
+	cancel context.CancelFunc
+
+	mu    sync.Mutex
+	stats Stats
+}
+
+// NewPeriodic создаёт периодическую задачу. metrics может быть nil — тогда метрики не собираются.
+func NewPeriodic(name string, interval time.Duration, run RunFunc, metrics *Metrics, logger logger.Logger) *Periodic {
+	return &Periodic{
+		name:     name,
+		interval: interval,
+		run:      run,
+		metrics:  metrics,
+		logger:   logger,
+	}
+}
+
+// UseLock включает распределённую блокировку запусков: перед запуском захватывается блокировка
+// "job:<имя>" на ttl, которая продлевается, пока запуск идёт. Если блокировку держит другая реплика,
+// запуск пропускается. Если блокировку продлить не удалось, контекст запуска отменяется.
+// Вызывается до Start.
+func (p *Periodic) UseLock(locker lock.Locker, ttl time.Duration) {
+	p.locker = locker
+	p.lockTTL = ttl
+}
+
+// Name возвращает имя задачи.
+func (p *Periodic) Name() string {
+	return p.name
+}
+
+// Start запускает цикл задачи в фоне. Цикл завершается при отмене ctx или вызове Stop.
+func (p *Periodic) Start(ctx context.Context) error {
+	ctx, cancel := context.WithCancel(ctx)
+	p.cancel = cancel
+
+	p.wg.Add(1)
+	go func() {
+		defer p.wg.Done()
+
+		timer := time.NewTimer(0)
+		defer timer.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-timer.C:
+			}
+			p.runOnce(ctx)
+			timer.Reset(p.interval)
+		}
+	}()
+	return nil
+}
+
+// Stop останавливает цикл и дожидается завершения текущего запуска.
+// Возвращает ошибку ctx, если запуск не завершился до его отмены.
+func (p *Periodic) Stop(ctx context.Context) error {
+	if p.cancel != nil {
+		p.cancel()
+	}
+
+	done := make(chan struct{})
+	go func() {
+		p.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Stats возвращает статистику запусков.
+func (p *Periodic) Stats() Stats {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.stats
+}
+
+// runOnce выполняет задачу, перехватывая панику, и обновляет статистику.
+func (p *Periodic) runOnce(ctx context.Context) {
+	ctx, span := tracing.Start(ctx, p.name, tracing.SpanContext{})
+	ctx = WithMetrics(ctx, p.metrics)
+	ctx = logger.NewContext(ctx, p.logger.With(map[string]any{"job": p.name, "trace_id": span.Context.TraceID}))
+
+	start := span.Start
+	err := p.withLock(ctx, func(ctx context.Context) (err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				err = fmt.Errorf("panic: %v", r)
+			}
+		}()
+		return p.run(ctx)
+	})
+	if errors.Is(err, lock.ErrNotAcquired) {
+		p.mu.Lock()
+		p.stats.Skipped++
+		p.mu.Unlock()
+		p.logger.Debug("periodic_job_skipped", map[string]any{"job": p.name, "reason": "locked"})
+		return
+	}
+	duration := time.Since(start)
+
+	p.mu.Lock()
+	p.stats.Runs++
+	p.stats.LastRunAt = start
+	p.stats.LastDuration = duration
+	p.stats.LastError = ""
+	if err != nil {
+		p.stats.Failures++
+		p.stats.LastError = err.Error()
+	}
+	p.mu.Unlock()
+	p.metrics.Observe(p.name, start, duration, err, span.Context.TraceID)
+
+	if err != nil && ctx.Err() == nil {
+		p.logger.Error("periodic_job_failed", map[string]any{
+			"job":         p.name,
+			"trace_id":    span.Context.TraceID,
+			"duration_ms": duration.Milliseconds(),
+			"error":       err.Error(),
+		})
+	}
+}
+
+// withLock выполняет fn под распределённой блокировкой задачи, если она включена.
+// Возвращает lock.ErrNotAcquired, если блокировку держит другая реплика.
+func (p *Periodic) withLock(ctx context.Context, fn func(ctx context.Context) error) error {
+	if p.locker == nil {
+		return fn(ctx)
+	}
+	l, err := p.locker.TryAcquire(ctx, "job:"+p.name, p.lockTTL)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	done := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		p.keepAlive(ctx, l, done, cancel)
+	}()
+
+	err = fn(ctx)
+	close(done)
+	wg.Wait()
+
+	// Освобождаем блокировку и после отмены ctx запуска, чтобы следующий запуск не ждал её истечения.
+	releaseCtx, releaseCancel := context.WithTimeout(context.WithoutCancel(ctx), 5*time.Second)
+	defer releaseCancel()
+	if releaseErr := l.Release(releaseCtx); releaseErr != nil {
+		p.logger.Warn("periodic_job_lock_release_failed", map[string]any{"job": p.name, "error": releaseErr.Error()})
+	}
+	return err
+}
+
+// keepAlive продлевает блокировку каждую треть её срока, пока не закрыт done.
+// Если блокировка потеряна, отменяет запуск: работу мог подхватить другой владелец.
+func (p *Periodic) keepAlive(ctx context.Context, l *lock.Lock, done <-chan struct{}, cancel context.CancelFunc) {
+	ticker := time.NewTicker(max(p.lockTTL/3, time.Millisecond))
+	defer ticker.Stop()
+	for {
+		select {
+		case <-done:
+			return
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := l.Refresh(ctx, p.lockTTL); err != nil {
+				if ctx.Err() != nil {
+					return
+				}
+				p.logger.Warn("periodic_job_lock_lost", map[string]any{"job": p.name, "error": err.Error()})
+				if errors.Is(err, lock.ErrLost) {
+					cancel()
+					return
+				}
+			}
+		}
+	}
+}
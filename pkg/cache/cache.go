@@ -0,0 +1,92 @@
+// Package cache хранит готовые к отдаче данные (например, профили пользователей) рядом с приложением,
+// чтобы снизить нагрузку на БД. Кеш не является источником истины: ошибка кеша не должна ломать запрос.
+package cache
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrMiss возвращается, если ключа нет в кеше или срок его жизни истёк.
+var ErrMiss = errors.New("cache: miss")
+
+// Cache хранит значения с ограниченным временем жизни.
+type Cache interface {
+	// Get возвращает значение по ключу или ErrMiss.
+	Get(ctx context.Context, key string) ([]byte, error)
+	// Set сохраняет значение на ttl.
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+	// Delete удаляет ключи; отсутствующие ключи не считаются ошибкой.
+	Delete(ctx context.Context, keys ...string) error
+	// Invalidate удаляет все ключи кеша (реализует maintenance.Cache).
+	Invalidate(ctx context.Context) error
+}
+
+type memoryEntry struct {
+	value     []byte
+	expiresAt time.Time
+}
+
+// MemoryCache хранит значения в памяти процесса.
+// Подходит для одного инстанса и тестов: сброс ключа не виден другим инстансам, используйте RedisCache.
+type MemoryCache struct {
+	now func() time.Time
+
+	mu      sync.Mutex
+	entries map[string]memoryEntry
+}
+
+// NewMemoryCache создаёт in-memory кеш.
+func NewMemoryCache() *MemoryCache {
+	return &MemoryCache{
+		now:     time.Now,
+		entries: make(map[string]memoryEntry),
+	}
+}
+
+// Get возвращает значение по ключу.
+func (c *MemoryCache) Get(_ context.Context, key string) ([]byte, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.entries[key]
+	if !ok {
+		return nil, ErrMiss
+	}
+	if !c.now().Before(e.expiresAt) {
+		delete(c.entries, key)
+		return nil, ErrMiss
+	}
+	return append([]byte(nil), e.value...), nil
+}
+
+// Set сохраняет значение на ttl.
+func (c *MemoryCache) Set(_ context.Context, key string, value []byte, ttl time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries[key] = memoryEntry{value: append([]byte(nil), value...), expiresAt: c.now().Add(ttl)}
+	return nil
+}
+
+// Delete удаляет ключи.
+func (c *MemoryCache) Delete(_ context.Context, keys ...string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, key := range keys {
+		delete(c.entries, key)
+	}
+	return nil
+}
+
+// Invalidate удаляет все ключи.
+func (c *MemoryCache) Invalidate(_ context.Context) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries = make(map[string]memoryEntry)
+	return nil
+}
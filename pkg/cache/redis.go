@@ -0,0 +1,81 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// invalidateBatch — сколько ключей за раз перебирается и удаляется при сбросе кеша.
+const invalidateBatch = 500
+
+// RedisCache хранит значения в Redis; истечение обеспечивается TTL ключей.
+// Кеш общий для всех инстансов, поэтому сброс ключа после изменения данных виден везде.
+type RedisCache struct {
+	client redis.Cmdable
+	prefix string
+}
+
+// NewRedisCache создаёт кеш в Redis. prefix добавляется ко всем ключам и отделяет кеши друг от друга.
+func NewRedisCache(client redis.Cmdable, prefix string) *RedisCache {
+	return &RedisCache{client: client, prefix: prefix}
+}
+
+// Get возвращает значение по ключу.
+func (c *RedisCache) Get(ctx context.Context, key string) ([]byte, error) {
+	raw, err := c.client.Get(ctx, c.prefix+key).Bytes()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, ErrMiss
+		}
+		return nil, fmt.Errorf("redis cache get: %w", err)
+	}
+	return raw, nil
+}
+
+// Set сохраняет значение на ttl.
+func (c *RedisCache) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	if err := c.client.Set(ctx, c.prefix+key, value, ttl).Err(); err != nil {
+		return fmt.Errorf("redis cache set: %w", err)
+	}
+	return nil
+}
+
+// Delete удаляет ключи.
+func (c *RedisCache) Delete(ctx context.Context, keys ...string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+	redisKeys := make([]string, len(keys))
+	for i, key := range keys {
+		redisKeys[i] = c.prefix + key
+	}
+	if err := c.client.Del(ctx, redisKeys...).Err(); err != nil {
+		return fmt.Errorf("redis cache del: %w", err)
+	}
+	return nil
+}
+
+// Invalidate удаляет все ключи с префиксом кеша. Ключи перебираются через SCAN,
+// чтобы не блокировать Redis на больших кешах.
+func (c *RedisCache) Invalidate(ctx context.Context) error {
+	var cursor uint64
+	for {
+		keys, next, err := c.client.Scan(ctx, cursor, c.prefix+"*", invalidateBatch).Result()
+		if err != nil {
+			return fmt.Errorf("redis cache scan: %w", err)
+		}
+		if len(keys) > 0 {
+			if err := c.client.Del(ctx, keys...).Err(); err != nil {
+				return fmt.Errorf("redis cache del: %w", err)
+			}
+		}
+		if next == 0 {
+			return nil
+		}
+		cursor = next
+	}
+}
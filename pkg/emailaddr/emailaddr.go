@@ -0,0 +1,42 @@
+// Package emailaddr приводит email-адреса к единому виду для хранения и сравнения.
+package emailaddr
+
+import "strings"
+
+// gmailDomains — домены Gmail: в них точки в имени ящика не значимы, а googlemail.com — синоним gmail.com.
+var gmailDomains = map[string]bool{"gmail.com": true, "googlemail.com": true}
+
+// Normalize приводит адрес к виду, в котором он хранится и на который отправляются письма:
+// без пробелов по краям и в нижнем регистре.
+func Normalize(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+// Policy описывает, какие варианты написания адреса считаются одним ящиком при проверке уникальности и поиске.
+type Policy struct {
+	FoldGmail    bool // Для Gmail отбрасывать точки и +метку в имени ящика, googlemail.com считать gmail.com
+	FoldPlusTags bool // Для всех доменов отбрасывать +метку в имени ящика (user+tag@example.com → user@example.com)
+}
+
+// Canonical возвращает каноническую форму адреса: Normalize и свёртку вариантов по политике.
+// Используется только для сравнения: письма отправляются на адрес в форме Normalize.
+func (p Policy) Canonical(email string) string {
+	email = Normalize(email)
+	at := strings.LastIndexByte(email, '@')
+	if at <= 0 || at == len(email)-1 {
+		return email
+	}
+	local, domain := email[:at], email[at+1:]
+
+	gmail := p.FoldGmail && gmailDomains[domain]
+	if gmail || p.FoldPlusTags {
+		if i := strings.IndexByte(local, '+'); i > 0 {
+			local = local[:i]
+		}
+	}
+	if gmail {
+		local = strings.ReplaceAll(local, ".", "")
+		domain = "gmail.com"
+	}
+	return local + "@" + domain
+}
@@ -0,0 +1,210 @@
+// Package httpclient создаёт HTTP-клиенты для обращений к внешним сервисам (OAuth-провайдеры, почтовые API,
+// хранилище, webhooks): общий пул соединений с ограничением на хост, таймауты, повторные попытки
+// идемпотентных запросов, метрики по интеграциям и хостам и передачу контекста трассировки (traceparent).
+package httpclient
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"workout-app/pkg/logger"
+	"workout-app/pkg/tracing"
+)
+
+// HeaderIdempotencyKey — заголовок, с которым неидемпотентный запрос (POST) тоже можно повторять:
+// получатель по ключу распознаёт повтор.
+const HeaderIdempotencyKey = "Idempotency-Key"
+
+// maxDrainBody — сколько байт ответа дочитывается перед повтором; соединение с большим телом закрывается.
+const maxDrainBody = 64 << 10
+
+// Значения по умолчанию.
+const (
+	DefaultTimeout         = 10 * time.Second
+	DefaultMaxConnsPerHost = 32
+	DefaultRetryBase       = 200 * time.Millisecond
+	DefaultRetryMax        = 5 * time.Second
+)
+
+// Config описывает параметры клиентов; нулевые поля, кроме MaxRetries, заменяются значениями по умолчанию.
+type Config struct {
+	Timeout         time.Duration // Таймаут запроса целиком, включая повторные попытки
+	MaxConnsPerHost int           // Одновременных соединений с одним хостом на все интеграции
+	MaxRetries      int           // Повторных попыток идемпотентного запроса; 0 отключает повторы
+	RetryBase       time.Duration // Задержка перед первым повтором; далее удваивается
+	RetryMax        time.Duration // Максимальная задержка между попытками (и предел учитываемого Retry-After)
+}
+
+// Factory создаёт клиентов интеграций поверх общего пула соединений и собирает их метрики.
+type Factory struct {
+	cfg       Config
+	transport *http.Transport
+	metrics   *Metrics
+	logger    logger.Logger
+}
+
+// NewFactory создаёт фабрику клиентов.
+func NewFactory(cfg Config, logger logger.Logger) *Factory {
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = DefaultTimeout
+	}
+	if cfg.MaxConnsPerHost <= 0 {
+		cfg.MaxConnsPerHost = DefaultMaxConnsPerHost
+	}
+	if cfg.RetryBase <= 0 {
+		cfg.RetryBase = DefaultRetryBase
+	}
+	if cfg.RetryMax < cfg.RetryBase {
+		cfg.RetryMax = max(DefaultRetryMax, cfg.RetryBase)
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.MaxConnsPerHost = cfg.MaxConnsPerHost
+	transport.MaxIdleConnsPerHost = cfg.MaxConnsPerHost
+	return &Factory{cfg: cfg, transport: transport, metrics: NewMetrics(), logger: logger}
+}
+
+// Client возвращает клиента интеграции name; метрики учитываются под этим именем.
+// timeout == 0 — таймаут из конфигурации фабрики. Клиент можно донастроить (например, CheckRedirect):
+// каждый вызов возвращает новый экземпляр.
+func (f *Factory) Client(name string, timeout time.Duration) *http.Client {
+	if timeout <= 0 {
+		timeout = f.cfg.Timeout
+	}
+	return &http.Client{
+		Timeout:   timeout,
+		Transport: &roundTripper{name: name, next: f.transport, cfg: f.cfg, metrics: f.metrics, logger: f.logger},
+	}
+}
+
+// Metrics возвращает метрики всех клиентов фабрики.
+func (f *Factory) Metrics() *Metrics {
+	return f.metrics
+}
+
+// Close закрывает простаивающие соединения пула.
+func (f *Factory) Close() error {
+	f.transport.CloseIdleConnections()
+	return nil
+}
+
+// roundTripper выполняет запрос с повторами, метриками и заголовком traceparent.
+type roundTripper struct {
+	name    string
+	next    http.RoundTripper
+	cfg     Config
+	metrics *Metrics
+	logger  logger.Logger
+}
+
+func (t *roundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx, span := tracing.Start(req.Context(), "http_client."+t.name, tracing.SpanContext{})
+	if req.Header.Get(tracing.HeaderName) == "" {
+		// RoundTrip не должен менять исходный запрос: заголовок добавляется в копию.
+		req = req.Clone(ctx)
+		req.Header.Set(tracing.HeaderName, span.Context.String())
+	}
+
+	retryable := t.cfg.MaxRetries > 0 && isRetryable(req)
+	for attempt := 0; ; attempt++ {
+		start := time.Now()
+		resp, err := t.next.RoundTrip(req)
+		failed := err != nil || resp.StatusCode >= http.StatusInternalServerError
+		t.metrics.observe(t.name, req.URL.Host, time.Since(start), failed, attempt > 0)
+
+		if !retryable || attempt >= t.cfg.MaxRetries || !shouldRetry(ctx, resp, err) {
+			if err != nil {
+				t.log(req, span, err)
+			}
+			return resp, err
+		}
+
+		delay := t.backoff(attempt, resp)
+		if resp != nil {
+			drain(resp)
+		}
+		if req.GetBody != nil {
+			body, bodyErr := req.GetBody()
+			if bodyErr != nil {
+				return nil, bodyErr
+			}
+			req = req.Clone(ctx)
+			req.Body = body
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// backoff возвращает задержку перед повтором: Retry-After ответа, если он не больше RetryMax,
+// иначе экспоненциальную от RetryBase.
+func (t *roundTripper) backoff(attempt int, resp *http.Response) time.Duration {
+	if resp != nil {
+		if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && seconds >= 0 {
+			if d := time.Duration(seconds) * time.Second; d <= t.cfg.RetryMax {
+				return d
+			}
+		}
+	}
+	delay := t.cfg.RetryBase << attempt
+	if delay <= 0 || delay > t.cfg.RetryMax {
+		delay = t.cfg.RetryMax
+	}
+	return delay
+}
+
+func (t *roundTripper) log(req *http.Request, span *tracing.Span, err error) {
+	if t.logger == nil {
+		return
+	}
+	fields := span.Fields(err)
+	fields["client"] = t.name
+	fields["method"] = req.Method
+	fields["host"] = req.URL.Host
+	logger.FromContext(req.Context(), t.logger).Warn("http_client_request_failed", fields)
+}
+
+// isRetryable возвращает true для идемпотентных запросов, тело которых можно отправить повторно.
+func isRetryable(req *http.Request) bool {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+	default:
+		if req.Header.Get(HeaderIdempotencyKey) == "" {
+			return false
+		}
+	}
+	return req.Body == nil || req.Body == http.NoBody || req.GetBody != nil
+}
+
+// shouldRetry возвращает true для сетевых ошибок и ответов 429, 502, 503, 504.
+func shouldRetry(ctx context.Context, resp *http.Response, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	if err != nil {
+		var netErr net.Error
+		return errors.As(err, &netErr) || errors.Is(err, net.ErrClosed)
+	}
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// drain дочитывает и закрывает тело ответа, чтобы соединение вернулось в пул.
+func drain(resp *http.Response) {
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, maxDrainBody))
+	_ = resp.Body.Close()
+}
@@ -0,0 +1,76 @@
+package httpclient
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// HostStats описывает обращения клиента интеграции к одному хосту с момента старта процесса.
+type HostStats struct {
+	Client        string
+	Host          string
+	Requests      int64 // Попыток, включая повторные
+	Retries       int64 // Из них повторных
+	Failures      int64 // Из них завершившихся сетевой ошибкой или ответом 5xx
+	TotalDuration time.Duration
+	MaxDuration   time.Duration
+}
+
+type hostKey struct {
+	client string
+	host   string
+}
+
+// Metrics накапливает статистику запросов по клиентам и хостам. Безопасен для конкурентного использования.
+type Metrics struct {
+	mu    sync.Mutex
+	hosts map[hostKey]*HostStats
+}
+
+// NewMetrics создаёт пустой набор метрик.
+func NewMetrics() *Metrics {
+	return &Metrics{hosts: make(map[hostKey]*HostStats)}
+}
+
+// observe учитывает одну попытку запроса.
+func (m *Metrics) observe(client, host string, d time.Duration, failed, retry bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	key := hostKey{client: client, host: host}
+	stats, ok := m.hosts[key]
+	if !ok {
+		stats = &HostStats{Client: client, Host: host}
+		m.hosts[key] = stats
+	}
+	stats.Requests++
+	if retry {
+		stats.Retries++
+	}
+	if failed {
+		stats.Failures++
+	}
+	stats.TotalDuration += d
+	if d > stats.MaxDuration {
+		stats.MaxDuration = d
+	}
+}
+
+// Hosts возвращает статистику, упорядоченную по клиенту и хосту.
+func (m *Metrics) Hosts() []HostStats {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	hosts := make([]HostStats, 0, len(m.hosts))
+	for _, stats := range m.hosts {
+		hosts = append(hosts, *stats)
+	}
+	sort.Slice(hosts, func(i, j int) bool {
+		if hosts[i].Client != hosts[j].Client {
+			return hosts[i].Client < hosts[j].Client
+		}
+		return hosts[i].Host < hosts[j].Host
+	})
+	return hosts
+}
@@ -0,0 +1,213 @@
+package jwt
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"os"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// Алгоритмы подписи access-токенов.
+const (
+	AlgorithmHS256 = "HS256"
+	AlgorithmRS256 = "RS256"
+	AlgorithmEdDSA = "EdDSA"
+)
+
+// minRSABits — минимальный размер RSA-ключа подписи.
+const minRSABits = 2048
+
+// JWK — открытый ключ в формате JSON Web Key (RFC 7517).
+type JWK struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	// RSA
+	N string `json:"n,omitempty"`
+	E string `json:"e,omitempty"`
+	// Ed25519
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+}
+
+// JWKSet — набор открытых ключей, которыми проверяются access-токены.
+type JWKSet struct {
+	Keys []JWK `json:"keys"`
+}
+
+// asymmetricKeys описывает пару ключей подписи access-токенов и открытые ключи прежних пар.
+// kid каждого ключа — его отпечаток по RFC 7638, поэтому не требует отдельной настройки.
+type asymmetricKeys struct {
+	method     jwt.SigningMethod
+	currentKID string
+	private    crypto.PrivateKey
+	public     map[string]crypto.PublicKey
+	jwks       JWKSet
+}
+
+// loadAsymmetricKeys читает PEM закрытого ключа и открытых ключей прежних пар.
+func loadAsymmetricKeys(algorithm, privatePath string, publicPaths []string) (*asymmetricKeys, error) {
+	k := &asymmetricKeys{public: make(map[string]crypto.PublicKey)}
+	switch algorithm {
+	case AlgorithmRS256:
+		k.method = jwt.SigningMethodRS256
+	case AlgorithmEdDSA:
+		k.method = jwt.SigningMethodEdDSA
+	default:
+		return nil, fmt.Errorf("unsupported signing algorithm %q", algorithm)
+	}
+
+	private, err := readPrivateKey(privatePath)
+	if err != nil {
+		return nil, err
+	}
+	signer, ok := private.(crypto.Signer)
+	if !ok {
+		return nil, fmt.Errorf("%s: unsupported private key type %T", privatePath, private)
+	}
+	k.private = private
+	if k.currentKID, err = k.add(signer.Public()); err != nil {
+		return nil, fmt.Errorf("%s: %w", privatePath, err)
+	}
+
+	for _, path := range publicPaths {
+		public, err := readPublicKey(path)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := k.add(public); err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+	}
+	return k, nil
+}
+
+// add проверяет, что ключ подходит алгоритму, и добавляет его в набор проверки и JWKS.
+func (k *asymmetricKeys) add(public crypto.PublicKey) (string, error) {
+	var jwk JWK
+	switch key := public.(type) {
+	case *rsa.PublicKey:
+		if k.method != jwt.SigningMethodRS256 {
+			return "", fmt.Errorf("RSA key does not match algorithm %s", k.method.Alg())
+		}
+		if key.N.BitLen() < minRSABits {
+			return "", fmt.Errorf("RSA key must be at least %d bits", minRSABits)
+		}
+		jwk = JWK{
+			Kty: "RSA",
+			N:   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		}
+	case ed25519.PublicKey:
+		if k.method != jwt.SigningMethodEdDSA {
+			return "", fmt.Errorf("Ed25519 key does not match algorithm %s", k.method.Alg())
+		}
+		jwk = JWK{Kty: "OKP", Crv: "Ed25519", X: base64.RawURLEncoding.EncodeToString(key)}
+	default:
+		return "", fmt.Errorf("unsupported public key type %T", public)
+	}
+
+	jwk.Kid = thumbprint(jwk)
+	jwk.Use = "sig"
+	jwk.Alg = k.method.Alg()
+	if _, ok := k.public[jwk.Kid]; !ok {
+		k.public[jwk.Kid] = public
+		k.jwks.Keys = append(k.jwks.Keys, jwk)
+	}
+	return jwk.Kid, nil
+}
+
+// sign подписывает токен закрытым ключом и указывает kid текущей пары.
+func (k *asymmetricKeys) sign(claims *Claims) (string, error) {
+	token := jwt.NewWithClaims(k.method, claims)
+	token.Header["kid"] = k.currentKID
+	return token.SignedString(k.private)
+}
+
+// verificationKey выбирает открытый ключ по kid; алгоритм токена должен совпадать с настроенным.
+func (k *asymmetricKeys) verificationKey(token *jwt.Token) (interface{}, error) {
+	if token.Method.Alg() != k.method.Alg() {
+		return nil, jwt.ErrTokenSignatureInvalid
+	}
+	kid, _ := token.Header["kid"].(string)
+	key, ok := k.public[kid]
+	if !ok {
+		return nil, jwt.ErrTokenUnverifiable
+	}
+	return key, nil
+}
+
+// thumbprint вычисляет отпечаток JWK по RFC 7638: SHA-256 от обязательных полей в лексикографическом порядке.
+func thumbprint(jwk JWK) string {
+	var members any
+	if jwk.Kty == "RSA" {
+		members = struct {
+			E   string `json:"e"`
+			Kty string `json:"kty"`
+			N   string `json:"n"`
+		}{jwk.E, jwk.Kty, jwk.N}
+	} else {
+		members = struct {
+			Crv string `json:"crv"`
+			Kty string `json:"kty"`
+			X   string `json:"x"`
+		}{jwk.Crv, jwk.Kty, jwk.X}
+	}
+	// Поля — base64url-строки без символов, требующих экранирования, поэтому вывод Marshal канонический.
+	raw, _ := json.Marshal(members)
+	sum := sha256.Sum256(raw)
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+func readPEM(path string) (*pem.Block, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	block, _ := pem.Decode(raw)
+	if block == nil {
+		return nil, fmt.Errorf("%s: no PEM block found", path)
+	}
+	return block, nil
+}
+
+// readPrivateKey читает закрытый ключ PKCS#8 («PRIVATE KEY») или RSA PKCS#1 («RSA PRIVATE KEY»).
+func readPrivateKey(path string) (crypto.PrivateKey, error) {
+	block, err := readPEM(path)
+	if err != nil {
+		return nil, err
+	}
+	var key crypto.PrivateKey
+	if block.Type == "RSA PRIVATE KEY" {
+		key, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	} else {
+		key, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return key, nil
+}
+
+// readPublicKey читает открытый ключ PKIX («PUBLIC KEY»).
+func readPublicKey(path string) (crypto.PublicKey, error) {
+	block, err := readPEM(path)
+	if err != nil {
+		return nil, err
+	}
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return key, nil
+}
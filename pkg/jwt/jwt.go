@@ -1,6 +1,7 @@
 package jwt
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
@@ -27,15 +28,89 @@ type Service interface {
 	GenerateRefreshToken(user *domain.User) (string, string, error) // token, jti
 	ParseAccessToken(tokenString string) (*Claims, error)
 	ParseRefreshToken(tokenString string) (*Claims, error)
+	// JWKS возвращает открытые ключи проверки access-токенов; пустой набор при подписи HS256.
+	JWKS() JWKSet
+}
+
+// keyring описывает ключи одного типа токенов: текущий ключ подписи и все ключи, которыми
+// токены проверяются.
+type keyring struct {
+	currentKID string            // kid текущего ключа; пусто — подпись legacy без kid
+	keys       map[string][]byte // Ключи проверки по kid
+	legacy     []byte            // Секрет токенов без kid; nil — такие токены не принимаются
 }
 
 type service struct {
-	cfg *config.JWTConfig
+	cfg     *config.JWTConfig
+	access  keyring
+	refresh keyring
+	// asymmetric — ключи RS256/EdDSA для access-токенов; nil при подписи HS256.
+	asymmetric *asymmetricKeys
 }
 
 // NewService создаёт JWT-сервис на основе конфигурации.
-func NewService(cfg *config.JWTConfig) Service {
-	return &service{cfg: cfg}
+// Для RS256/EdDSA читает PEM-ключи из файлов и возвращает ошибку, если они не подходят алгоритму.
+func NewService(cfg *config.JWTConfig) (Service, error) {
+	s := &service{
+		cfg:     cfg,
+		access:  newKeyring(cfg.AccessKeys, cfg.AccessKeyID, cfg.AccessSecret, cfg.AcceptTokensWithoutKID),
+		refresh: newKeyring(cfg.RefreshKeys, cfg.RefreshKeyID, cfg.RefreshSecret, cfg.AcceptTokensWithoutKID),
+	}
+	if cfg.SigningAlgorithm != "" && cfg.SigningAlgorithm != AlgorithmHS256 {
+		keys, err := loadAsymmetricKeys(cfg.SigningAlgorithm, cfg.PrivateKeyPath, cfg.PublicKeyPaths)
+		if err != nil {
+			return nil, fmt.Errorf("load jwt signing keys: %w", err)
+		}
+		s.asymmetric = keys
+	}
+	return s, nil
+}
+
+func newKeyring(keys map[string]string, currentKID, legacy string, acceptWithoutKID bool) keyring {
+	k := keyring{currentKID: currentKID, keys: make(map[string][]byte, len(keys))}
+	for kid, secret := range keys {
+		k.keys[kid] = []byte(secret)
+	}
+	// Пока текущего ключа нет, токены подписываются legacy-секретом и должны им же проверяться.
+	if acceptWithoutKID || currentKID == "" {
+		k.legacy = []byte(legacy)
+	}
+	return k
+}
+
+// sign подписывает токен текущим ключом и указывает его kid в заголовке.
+func (k keyring) sign(claims *Claims) (string, error) {
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	if k.currentKID == "" {
+		return token.SignedString(k.legacy)
+	}
+	token.Header["kid"] = k.currentKID
+	return token.SignedString(k.keys[k.currentKID])
+}
+
+// verificationKey выбирает ключ проверки по kid из заголовка токена.
+func (k keyring) verificationKey(token *jwt.Token) (interface{}, error) {
+	// Дополнительная защита: убеждаемся, что метод подписи ожидаемый
+	if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+		return nil, jwt.ErrTokenSignatureInvalid
+	}
+	raw, ok := token.Header["kid"]
+	if !ok {
+		if k.legacy == nil {
+			return nil, jwt.ErrTokenUnverifiable
+		}
+		return k.legacy, nil
+	}
+	kid, ok := raw.(string)
+	if !ok {
+		return nil, jwt.ErrTokenUnverifiable
+	}
+	key, ok := k.keys[kid]
+	if !ok {
+		// Ключ удалён из набора после ротации: выданные им токены больше не действуют.
+		return nil, jwt.ErrTokenUnverifiable
+	}
+	return key, nil
 }
 
 // GenerateAccessToken генерирует короткоживущий access-токен для пользователя.
@@ -56,8 +131,10 @@ func (s *service) GenerateAccessToken(user *domain.User) (string, error) {
 		},
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString([]byte(s.cfg.AccessSecret))
+	if s.asymmetric != nil {
+		return s.asymmetric.sign(claims)
+	}
+	return s.access.sign(claims)
 }
 
 // GenerateRefreshToken генерирует долгоживущий refresh-токен для пользователя и возвращает его jti.
@@ -80,8 +157,7 @@ func (s *service) GenerateRefreshToken(user *domain.User) (string, string, error
 		},
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	signed, err := token.SignedString([]byte(s.cfg.RefreshSecret))
+	signed, err := s.refresh.sign(claims)
 	if err != nil {
 		return "", "", err
 	}
@@ -89,24 +165,35 @@ func (s *service) GenerateRefreshToken(user *domain.User) (string, string, error
 }
 
 // ParseAccessToken парсит и валидирует access-токен.
+// После перехода на RS256/EdDSA токены HS256 принимаются до истечения, чтобы не разлогинивать клиентов.
 func (s *service) ParseAccessToken(tokenString string) (*Claims, error) {
-	return s.parseToken(tokenString, []byte(s.cfg.AccessSecret))
+	if s.asymmetric == nil {
+		return s.parseToken(tokenString, s.access.verificationKey)
+	}
+	return s.parseToken(tokenString, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); ok {
+			return s.access.verificationKey(token)
+		}
+		return s.asymmetric.verificationKey(token)
+	})
 }
 
 // ParseRefreshToken парсит и валидирует refresh-токен.
 func (s *service) ParseRefreshToken(tokenString string) (*Claims, error) {
-	return s.parseToken(tokenString, []byte(s.cfg.RefreshSecret))
+	return s.parseToken(tokenString, s.refresh.verificationKey)
+}
+
+// JWKS возвращает открытые ключи проверки access-токенов.
+func (s *service) JWKS() JWKSet {
+	if s.asymmetric == nil {
+		return JWKSet{Keys: []JWK{}}
+	}
+	return s.asymmetric.jwks
 }
 
 // parseToken — общая логика парсинга JWT.
-func (s *service) parseToken(tokenString string, secret []byte) (*Claims, error) {
-	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, func(token *jwt.Token) (interface{}, error) {
-		// Дополнительная защита: убеждаемся, что метод подписи ожидаемый
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, jwt.ErrTokenSignatureInvalid
-		}
-		return secret, nil
-	})
+func (s *service) parseToken(tokenString string, keyFunc jwt.Keyfunc) (*Claims, error) {
+	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, keyFunc)
 	if err != nil {
 		return nil, err
 	}
@@ -123,3 +210,27 @@ func (s *service) parseToken(tokenString string, secret []byte) (*Claims, error)
 
 	return claims, nil
 }
+
+// SelfCheck выпускает и проверяет access- и refresh-токен служебного пользователя текущими ключами.
+// Вызывается при старте сервера, чтобы ключ, которым нельзя подписать или проверить токен,
+// обнаружился до первого входа пользователя.
+func SelfCheck(s Service) error {
+	probe := &domain.User{ID: uuid.New(), Role: domain.RoleUser}
+
+	access, err := s.GenerateAccessToken(probe)
+	if err != nil {
+		return fmt.Errorf("sign access token: %w", err)
+	}
+	if _, err := s.ParseAccessToken(access); err != nil {
+		return fmt.Errorf("verify access token: %w", err)
+	}
+
+	refresh, _, err := s.GenerateRefreshToken(probe)
+	if err != nil {
+		return fmt.Errorf("sign refresh token: %w", err)
+	}
+	if _, err := s.ParseRefreshToken(refresh); err != nil {
+		return fmt.Errorf("verify refresh token: %w", err)
+	}
+	return nil
+}
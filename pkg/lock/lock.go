@@ -0,0 +1,123 @@
+// Package lock реализует распределённые блокировки: фоновые задачи (очереди писем и webhooks,
+// очистка, выгрузки) захватывают блокировку перед запуском, чтобы при нескольких репликах
+// одну и ту же работу выполнял только один процесс.
+//
+// Блокировка выдаётся на срок ttl и должна продлеваться, пока работа идёт. Она не защищает
+// от записи процесса, чья блокировка уже истекла (например, после долгой паузы GC):
+// задачи под блокировкой должны оставаться идемпотентными.
+package lock
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"sync"
+	"time"
+)
+
+// Ошибки блокировок.
+var (
+	// ErrNotAcquired возвращается, если блокировку держит другой владелец.
+	ErrNotAcquired = errors.New("lock: not acquired")
+	// ErrLost возвращается при продлении или освобождении блокировки, срок которой истёк
+	// и которую, возможно, уже захватил другой владелец.
+	ErrLost = errors.New("lock: lost")
+)
+
+// Locker выдаёт блокировки по ключам.
+type Locker interface {
+	// TryAcquire захватывает блокировку key на ttl без ожидания.
+	// Возвращает ErrNotAcquired, если блокировку держит другой владелец.
+	TryAcquire(ctx context.Context, key string, ttl time.Duration) (*Lock, error)
+}
+
+// backend продлевает и освобождает блокировки конкретного хранилища.
+type backend interface {
+	refresh(ctx context.Context, key, owner string, ttl time.Duration) error
+	release(ctx context.Context, key, owner string) error
+}
+
+// Lock — захваченная блокировка.
+type Lock struct {
+	Key string
+
+	owner   string
+	backend backend
+}
+
+// Refresh продлевает блокировку на ttl. Возвращает ErrLost, если блокировка уже истекла.
+func (l *Lock) Refresh(ctx context.Context, ttl time.Duration) error {
+	return l.backend.refresh(ctx, l.Key, l.owner, ttl)
+}
+
+// Release освобождает блокировку. Возвращает ErrLost, если блокировка уже истекла.
+func (l *Lock) Release(ctx context.Context) error {
+	return l.backend.release(ctx, l.Key, l.owner)
+}
+
+// newOwner возвращает случайный идентификатор владельца блокировки.
+func newOwner() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		panic("lock: failed to read random bytes: " + err.Error())
+	}
+	return hex.EncodeToString(b)
+}
+
+// MemoryLocker хранит блокировки в памяти процесса.
+// Подходит для одного инстанса и тестов; несколько реплик должны использовать RedisLocker.
+type MemoryLocker struct {
+	mu    sync.Mutex
+	locks map[string]memoryEntry
+}
+
+type memoryEntry struct {
+	owner     string
+	expiresAt time.Time
+}
+
+// NewMemoryLocker создаёт хранилище блокировок в памяти.
+func NewMemoryLocker() *MemoryLocker {
+	return &MemoryLocker{locks: make(map[string]memoryEntry)}
+}
+
+// TryAcquire захватывает блокировку, если она свободна или истекла.
+func (m *MemoryLocker) TryAcquire(_ context.Context, key string, ttl time.Duration) (*Lock, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := time.Now()
+	if entry, ok := m.locks[key]; ok && now.Before(entry.expiresAt) {
+		return nil, ErrNotAcquired
+	}
+	owner := newOwner()
+	m.locks[key] = memoryEntry{owner: owner, expiresAt: now.Add(ttl)}
+	return &Lock{Key: key, owner: owner, backend: m}, nil
+}
+
+func (m *MemoryLocker) refresh(_ context.Context, key, owner string, ttl time.Duration) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := time.Now()
+	entry, ok := m.locks[key]
+	if !ok || entry.owner != owner || !now.Before(entry.expiresAt) {
+		return ErrLost
+	}
+	entry.expiresAt = now.Add(ttl)
+	m.locks[key] = entry
+	return nil
+}
+
+func (m *MemoryLocker) release(_ context.Context, key, owner string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	entry, ok := m.locks[key]
+	if !ok || entry.owner != owner || !time.Now().Before(entry.expiresAt) {
+		return ErrLost
+	}
+	delete(m.locks, key)
+	return nil
+}
@@ -0,0 +1,69 @@
+package lock
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// refreshScript продлевает ключ, только если он всё ещё принадлежит владельцу.
+var refreshScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("PEXPIRE", KEYS[1], ARGV[2])
+end
+return 0`)
+
+// releaseScript удаляет ключ, только если он всё ещё принадлежит владельцу.
+var releaseScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0`)
+
+// RedisLocker хранит блокировки в Redis (SET NX PX), общий для всех реплик.
+type RedisLocker struct {
+	client redis.Cmdable
+	prefix string
+}
+
+// NewRedisLocker создаёт хранилище блокировок в Redis. prefix добавляется ко всем ключам.
+func NewRedisLocker(client redis.Cmdable, prefix string) *RedisLocker {
+	return &RedisLocker{client: client, prefix: prefix}
+}
+
+// TryAcquire захватывает блокировку командой SET NX.
+func (r *RedisLocker) TryAcquire(ctx context.Context, key string, ttl time.Duration) (*Lock, error) {
+	owner := newOwner()
+	ok, err := r.client.SetNX(ctx, r.prefix+key, owner, ttl).Result()
+	if err != nil {
+		return nil, fmt.Errorf("redis lock acquire: %w", err)
+	}
+	if !ok {
+		return nil, ErrNotAcquired
+	}
+	return &Lock{Key: key, owner: owner, backend: r}, nil
+}
+
+func (r *RedisLocker) refresh(ctx context.Context, key, owner string, ttl time.Duration) error {
+	n, err := refreshScript.Run(ctx, r.client, []string{r.prefix + key}, owner, ttl.Milliseconds()).Int64()
+	if err != nil {
+		return fmt.Errorf("redis lock refresh: %w", err)
+	}
+	if n == 0 {
+		return ErrLost
+	}
+	return nil
+}
+
+func (r *RedisLocker) release(ctx context.Context, key, owner string) error {
+	n, err := releaseScript.Run(ctx, r.client, []string{r.prefix + key}, owner).Int64()
+	if err != nil {
+		return fmt.Errorf("redis lock release: %w", err)
+	}
+	if n == 0 {
+		return ErrLost
+	}
+	return nil
+}
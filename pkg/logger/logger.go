@@ -1,28 +1,132 @@
 package logger
 
 import (
-	"log"
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"runtime"
+	"sort"
+	"strings"
+	"time"
 )
 
 // Logger описывает минимальный интерфейс структурированного логгера,
 // достаточный для использования в handler'ах и middleware.
 type Logger interface {
+	Debug(msg string, fields map[string]any)
 	Info(msg string, fields map[string]any)
+	Warn(msg string, fields map[string]any)
 	Error(msg string, fields map[string]any)
+
+	// With возвращает логгер, который добавляет fields к каждой записи (например, request_id).
+	With(fields map[string]any) Logger
+}
+
+// Форматы вывода логов.
+const (
+	FormatJSON = "json" // одна JSON-запись на строку, для сбора логов в production
+	FormatText = "text" // key=value, удобно читать при локальной разработке
+)
+
+// ParseLevel разбирает уровень логирования: debug, info, warn или error.
+func ParseLevel(raw string) (slog.Level, error) {
+	switch strings.ToLower(raw) {
+	case "debug":
+		return slog.LevelDebug, nil
+	case "info", "":
+		return slog.LevelInfo, nil
+	case "warn", "warning":
+		return slog.LevelWarn, nil
+	case "error":
+		return slog.LevelError, nil
+	}
+	return 0, fmt.Errorf("unknown log level %q", raw)
 }
 
-type stdLogger struct{}
+type slogLogger struct {
+	l *slog.Logger
+}
+
+// New создаёт структурированный логгер на базе log/slog.
+// Каждая запись содержит время, уровень, сообщение, место вызова (source) и переданные поля.
+func New(w io.Writer, level slog.Level, format string) Logger {
+	opts := &slog.HandlerOptions{AddSource: true, Level: level}
+	var h slog.Handler
+	if format == FormatText {
+		h = slog.NewTextHandler(w, opts)
+	} else {
+		h = slog.NewJSONHandler(w, opts)
+	}
+	return &slogLogger{l: slog.New(h)}
+}
 
-// Default возвращает простой логгер на базе стандартного log.Printf.
-// В будущем реализацию можно заменить на zap/logrus/zerolog без изменения интерфейса.
+// Default возвращает JSON-логгер уровня info, пишущий в stderr.
 func Default() Logger {
-	return &stdLogger{}
+	return New(os.Stderr, slog.LevelInfo, FormatJSON)
+}
+
+// RedirectStdLog направляет вывод стандартного пакета log (log.Printf) в l,
+// чтобы сообщения, ещё не переведённые на Logger, тоже попадали в структурированный поток.
+func RedirectStdLog(l Logger) {
+	if sl, ok := l.(*slogLogger); ok {
+		slog.SetDefault(sl.l)
+	}
 }
 
-func (l *stdLogger) Info(msg string, fields map[string]any) {
-	log.Printf("INFO: %s %v", msg, fields)
+func (l *slogLogger) Debug(msg string, fields map[string]any) { l.log(slog.LevelDebug, msg, fields) }
+func (l *slogLogger) Info(msg string, fields map[string]any)  { l.log(slog.LevelInfo, msg, fields) }
+func (l *slogLogger) Warn(msg string, fields map[string]any)  { l.log(slog.LevelWarn, msg, fields) }
+func (l *slogLogger) Error(msg string, fields map[string]any) { l.log(slog.LevelError, msg, fields) }
+
+func (l *slogLogger) With(fields map[string]any) Logger {
+	return &slogLogger{l: l.l.With(toArgs(fields)...)}
+}
+
+// log формирует запись вручную, чтобы source указывал на вызывающий код, а не на эту обёртку.
+func (l *slogLogger) log(level slog.Level, msg string, fields map[string]any) {
+	ctx := context.Background()
+	if !l.l.Enabled(ctx, level) {
+		return
+	}
+	var pcs [1]uintptr
+	runtime.Callers(3, pcs[:]) // runtime.Callers, log, Info/Error/...
+	r := slog.NewRecord(time.Now(), level, msg, pcs[0])
+	r.Add(toArgs(fields)...)
+	_ = l.l.Handler().Handle(ctx, r)
+}
+
+// toArgs превращает поля в пары ключ-значение в стабильном порядке.
+func toArgs(fields map[string]any) []any {
+	keys := make([]string, 0, len(fields))
+	for k := range fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	args := make([]any, 0, len(fields)*2)
+	for _, k := range keys {
+		v := fields[k]
+		if err, ok := v.(error); ok {
+			v = err.Error()
+		}
+		args = append(args, k, v)
+	}
+	return args
+}
+
+type contextKey struct{}
+
+// NewContext возвращает контекст с логгером запроса (например, с полем request_id).
+func NewContext(ctx context.Context, l Logger) context.Context {
+	return context.WithValue(ctx, contextKey{}, l)
 }
 
-func (l *stdLogger) Error(msg string, fields map[string]any) {
-	log.Printf("ERROR: %s %v", msg, fields)
+// FromContext возвращает логгер запроса из контекста или fallback, если его там нет.
+func FromContext(ctx context.Context, fallback Logger) Logger {
+	if l, ok := ctx.Value(contextKey{}).(Logger); ok {
+		return l
+	}
+	return fallback
 }
@@ -0,0 +1,37 @@
+package mailer
+
+import "context"
+
+type (
+	localeKey   struct{}
+	timezoneKey struct{}
+)
+
+// WithLocale возвращает контекст, в котором письма отправляются на языке locale (например, "ru" или "en").
+// Пустая строка оставляет язык по умолчанию отправителя.
+func WithLocale(ctx context.Context, locale string) context.Context {
+	return context.WithValue(ctx, localeKey{}, locale)
+}
+
+// LocaleFromContext возвращает язык письма, заданный через WithLocale, или пустую строку.
+func LocaleFromContext(ctx context.Context) string {
+	locale, _ := ctx.Value(localeKey{}).(string)
+	return locale
+}
+
+// WithTimezone возвращает контекст, в котором даты в письмах выводятся в часовом поясе IANA timezone.
+// Пустая строка означает UTC.
+func WithTimezone(ctx context.Context, timezone string) context.Context {
+	return context.WithValue(ctx, timezoneKey{}, timezone)
+}
+
+// TimezoneFromContext возвращает часовой пояс писем, заданный через WithTimezone, или пустую строку.
+func TimezoneFromContext(ctx context.Context) string {
+	timezone, _ := ctx.Value(timezoneKey{}).(string)
+	return timezone
+}
+
+// WithRecipient задаёт язык и часовой пояс письма конкретному получателю (см. WithLocale и WithTimezone).
+func WithRecipient(ctx context.Context, locale, timezone string) context.Context {
+	return WithTimezone(WithLocale(ctx, locale), timezone)
+}
@@ -0,0 +1,72 @@
+package mailer
+
+import (
+	"context"
+	"net/http"
+	"net/mail"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// defaultMailgunURL — адрес API Mailgun в регионе US; для EU используется https://api.eu.mailgun.net.
+const defaultMailgunURL = "https://api.mailgun.net"
+
+// MailgunConfig описывает подключение к Mailgun.
+type MailgunConfig struct {
+	APIKey  string
+	Domain  string        // Домен отправки, настроенный в Mailgun
+	BaseURL string        // Адрес API (пусто — https://api.mailgun.net)
+	Timeout time.Duration // Таймаут запроса (0 — DefaultProviderTimeout)
+	Client  *http.Client  // HTTP-клиент (nil — отдельный клиент с таймаутом Timeout)
+}
+
+// Mailgun отправляет письма через Mailgun Messages API (POST /v3/<domain>/messages).
+type Mailgun struct {
+	apiKey  string
+	domain  string
+	baseURL string
+	client  *http.Client
+}
+
+// Убедимся на этапе компиляции, что структура реализует интерфейс.
+var _ Provider = (*Mailgun)(nil)
+
+// NewMailgun создаёт клиент Mailgun.
+func NewMailgun(cfg MailgunConfig) *Mailgun {
+	return &Mailgun{
+		apiKey:  cfg.APIKey,
+		domain:  cfg.Domain,
+		baseURL: baseURLOrDefault(cfg.BaseURL, defaultMailgunURL),
+		client:  newHTTPClient(cfg.Client, cfg.Timeout),
+	}
+}
+
+// Name возвращает имя провайдера.
+func (p *Mailgun) Name() string {
+	return "mailgun"
+}
+
+// Send отправляет письмо.
+func (p *Mailgun) Send(ctx context.Context, email *Email) error {
+	from := email.From
+	if email.FromName != "" {
+		from = (&mail.Address{Name: email.FromName, Address: email.From}).String()
+	}
+	form := url.Values{
+		"from":    {from},
+		"to":      {email.To},
+		"subject": {email.Subject},
+		"text":    {email.Text},
+		"html":    {email.HTML},
+	}
+
+	endpoint := p.baseURL + "/v3/" + url.PathEscape(p.domain) + "/messages"
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
+	req.SetBasicAuth("api", p.apiKey)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	return doRequest(p.client, p.Name(), req)
+}
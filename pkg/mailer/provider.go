@@ -0,0 +1,110 @@
+package mailer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// ErrMessageRejected возвращается, если провайдер отклонил письмо как некорректное
+// (неверный адрес, отправитель не подтверждён и т.п.): повторная отправка того же письма не поможет.
+var ErrMessageRejected = errors.New("email rejected by provider")
+
+// DefaultProviderTimeout — таймаут запроса к API провайдера, если он не задан явно.
+const DefaultProviderTimeout = 10 * time.Second
+
+// maxErrorBody ограничивает размер тела ответа с ошибкой, попадающего в текст ошибки (в байтах).
+const maxErrorBody = 512
+
+// Email — готовое к отправке письмо одному получателю.
+type Email struct {
+	From     string // Адрес отправителя
+	FromName string // Отображаемое имя отправителя (пусто — только адрес)
+	To       string
+	Subject  string
+	Text     string
+	HTML     string
+}
+
+// Provider доставляет готовые письма через API почтового провайдера.
+type Provider interface {
+	// Name возвращает имя провайдера для логов (sendgrid, ses, mailgun).
+	Name() string
+	// Send отправляет письмо. Ошибки отказа провайдера оборачивают ErrMessageRejected или ErrSendRateLimited;
+	// остальные ошибки (сеть, 5xx) временные.
+	Send(ctx context.Context, email *Email) error
+}
+
+// ProviderError описывает неуспешный ответ API провайдера.
+type ProviderError struct {
+	Provider   string
+	StatusCode int
+	Body       string
+	cause      error // ErrMessageRejected, ErrSendRateLimited или nil для временных ошибок
+}
+
+func (e *ProviderError) Error() string {
+	return fmt.Sprintf("%s: unexpected status %d: %s", e.Provider, e.StatusCode, e.Body)
+}
+
+// Unwrap позволяет сравнивать ошибку с ErrMessageRejected и ErrSendRateLimited через errors.Is.
+func (e *ProviderError) Unwrap() error {
+	return e.cause
+}
+
+// newProviderError сопоставляет код ответа провайдера с ошибками пакета:
+// 429 — лимит отправки, остальные 4xx (кроме таймаута запроса) — отказ, 5xx — временная ошибка.
+func newProviderError(provider string, resp *http.Response) error {
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBody))
+	err := &ProviderError{
+		Provider:   provider,
+		StatusCode: resp.StatusCode,
+		Body:       strings.TrimSpace(string(body)),
+	}
+	switch {
+	case resp.StatusCode == http.StatusTooManyRequests:
+		err.cause = ErrSendRateLimited
+	case resp.StatusCode == http.StatusRequestTimeout:
+	case resp.StatusCode >= 400 && resp.StatusCode < 500:
+		err.cause = ErrMessageRejected
+	}
+	return err
+}
+
+// doRequest выполняет запрос к API провайдера и возвращает ошибку для неуспешного (не 2xx) ответа.
+func doRequest(client *http.Client, provider string, req *http.Request) error {
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("%s: %w", provider, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return newProviderError(provider, resp)
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+	return nil
+}
+
+// newHTTPClient возвращает client или, если он не задан, HTTP-клиент провайдера с таймаутом запроса.
+func newHTTPClient(client *http.Client, timeout time.Duration) *http.Client {
+	if client != nil {
+		return client
+	}
+	if timeout <= 0 {
+		timeout = DefaultProviderTimeout
+	}
+	return &http.Client{Timeout: timeout}
+}
+
+// baseURLOrDefault возвращает base без завершающего слэша или def, если base пуст.
+func baseURLOrDefault(base, def string) string {
+	if base == "" {
+		return def
+	}
+	return strings.TrimRight(base, "/")
+}
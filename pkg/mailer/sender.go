@@ -1,8 +1,61 @@
 package mailer
 
-import "context"
+import (
+	"context"
+	"errors"
+	"time"
+)
 
-// EmailSender описывает контракт для отправки кода подтверждения email.
+// ErrRecipientUndeliverable возвращается, если отправка на адрес заблокирована
+// (постоянная недоставка или жалоба на спам).
+var ErrRecipientUndeliverable = errors.New("recipient address is undeliverable")
+
+// ErrSendRateLimited возвращается, если исчерпан лимит отправки писем (например, лимит организации).
+var ErrSendRateLimited = errors.New("email sending rate limit exceeded")
+
+// EmailSender описывает контракт для отправки кодов подтверждения и уведомлений по email.
 type EmailSender interface {
 	SendEmailVerificationCode(ctx context.Context, email, code string) error
+	SendPasswordChangeCode(ctx context.Context, email, code string) error
+	// SendPasswordResetCode отправляет код сброса пароля (сброс инициирует администратор).
+	SendPasswordResetCode(ctx context.Context, email, code string) error
+	// SendDataExportReady сообщает, что архив с данными аккаунта собран и доступен по ссылке до expiresAt.
+	SendDataExportReady(ctx context.Context, email, downloadURL string, expiresAt time.Time) error
+	// SendWelcome приветствует пользователя, подтвердившего email.
+	SendWelcome(ctx context.Context, email, username string) error
+	// SendPasswordChanged сообщает о смене пароля в changedAt.
+	SendPasswordChanged(ctx context.Context, email string, changedAt time.Time) error
+	// SendAccountDeleted подтверждает удаление аккаунта в deletedAt.
+	SendAccountDeleted(ctx context.Context, email string, deletedAt time.Time) error
+	// SendNotification дублирует на email уведомление типа notificationType (например, program.assigned);
+	// at — момент, к которому относится уведомление (начало занятия, время назначения программы).
+	SendNotification(ctx context.Context, email, notificationType string, at time.Time) error
+	// SendWeeklySummary отправляет итоги недели тренировок.
+	SendWeeklySummary(ctx context.Context, email string, summary WeeklySummary) error
+	// SendCoachInvitation приглашает пользователя стать клиентом тренера coachName; приглашение действует до expiresAt.
+	SendCoachInvitation(ctx context.Context, email, coachName string, expiresAt time.Time) error
+}
+
+// Единицы веса в WeeklySummary.
+const (
+	WeightUnitKg = "kg"
+	WeightUnitLb = "lb"
+)
+
+// WeeklySummary — итоги недели тренировок для еженедельного письма. Вес указан в единицах пользователя.
+type WeeklySummary struct {
+	WeekStart       time.Time        `json:"week_start"` // Понедельник недели в часовом поясе пользователя (полночь UTC)
+	Sessions        int              `json:"sessions"`
+	TrainingDays    int              `json:"training_days"`
+	Sets            int              `json:"sets"`
+	Volume          float64          `json:"volume"` // Тоннаж: сумма повторений, умноженных на вес
+	WeightUnit      string           `json:"weight_unit"`
+	PersonalRecords []PersonalRecord `json:"personal_records,omitempty"`
+}
+
+// PersonalRecord — личный рекорд упражнения за неделю.
+type PersonalRecord struct {
+	Exercise string  `json:"exercise"`
+	Weight   float64 `json:"weight"`
+	Previous float64 `json:"previous"` // Лучший вес до этой недели
 }
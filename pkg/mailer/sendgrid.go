@@ -0,0 +1,90 @@
+package mailer
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+)
+
+// defaultSendGridURL — адрес API SendGrid.
+const defaultSendGridURL = "https://api.sendgrid.com"
+
+// SendGridConfig описывает подключение к SendGrid.
+type SendGridConfig struct {
+	APIKey  string
+	BaseURL string        // Адрес API (пусто — https://api.sendgrid.com)
+	Timeout time.Duration // Таймаут запроса (0 — DefaultProviderTimeout)
+	Client  *http.Client  // HTTP-клиент (nil — отдельный клиент с таймаутом Timeout)
+}
+
+// SendGrid отправляет письма через SendGrid Web API v3 (POST /v3/mail/send).
+type SendGrid struct {
+	apiKey  string
+	baseURL string
+	client  *http.Client
+}
+
+// Убедимся на этапе компиляции, что структура реализует интерфейс.
+var _ Provider = (*SendGrid)(nil)
+
+// NewSendGrid создаёт клиент SendGrid.
+func NewSendGrid(cfg SendGridConfig) *SendGrid {
+	return &SendGrid{
+		apiKey:  cfg.APIKey,
+		baseURL: baseURLOrDefault(cfg.BaseURL, defaultSendGridURL),
+		client:  newHTTPClient(cfg.Client, cfg.Timeout),
+	}
+}
+
+// Name возвращает имя провайдера.
+func (p *SendGrid) Name() string {
+	return "sendgrid"
+}
+
+type sendGridAddress struct {
+	Email string `json:"email"`
+	Name  string `json:"name,omitempty"`
+}
+
+type sendGridContent struct {
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+type sendGridRequest struct {
+	Personalizations []struct {
+		To []sendGridAddress `json:"to"`
+	} `json:"personalizations"`
+	From    sendGridAddress   `json:"from"`
+	Subject string            `json:"subject"`
+	Content []sendGridContent `json:"content"`
+}
+
+// Send отправляет письмо. SendGrid требует, чтобы text/plain шёл перед text/html.
+func (p *SendGrid) Send(ctx context.Context, email *Email) error {
+	var payload sendGridRequest
+	payload.Personalizations = make([]struct {
+		To []sendGridAddress `json:"to"`
+	}, 1)
+	payload.Personalizations[0].To = []sendGridAddress{{Email: email.To}}
+	payload.From = sendGridAddress{Email: email.From, Name: email.FromName}
+	payload.Subject = email.Subject
+	payload.Content = []sendGridContent{
+		{Type: "text/plain", Value: email.Text},
+		{Type: "text/html", Value: email.HTML},
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.baseURL+"/v3/mail/send", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+p.apiKey)
+	req.Header.Set("Content-Type", "application/json")
+	return doRequest(p.client, p.Name(), req)
+}
@@ -0,0 +1,149 @@
+package mailer
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/mail"
+	"strings"
+	"time"
+)
+
+// SESConfig описывает подключение к Amazon SES.
+type SESConfig struct {
+	Region          string
+	AccessKeyID     string
+	SecretAccessKey string
+	BaseURL         string        // Адрес API (пусто — https://email.<region>.amazonaws.com)
+	Timeout         time.Duration // Таймаут запроса (0 — DefaultProviderTimeout)
+	Client          *http.Client  // HTTP-клиент (nil — отдельный клиент с таймаутом Timeout)
+}
+
+// SES отправляет письма через Amazon SES API v2 (POST /v2/email/outbound-emails).
+// Запросы подписываются AWS Signature Version 4.
+type SES struct {
+	region    string
+	keyID     string
+	secretKey string
+	baseURL   string
+	client    *http.Client
+	now       func() time.Time
+}
+
+// Убедимся на этапе компиляции, что структура реализует интерфейс.
+var _ Provider = (*SES)(nil)
+
+// NewSES создаёт клиент Amazon SES.
+func NewSES(cfg SESConfig) *SES {
+	return &SES{
+		region:    cfg.Region,
+		keyID:     cfg.AccessKeyID,
+		secretKey: cfg.SecretAccessKey,
+		baseURL:   baseURLOrDefault(cfg.BaseURL, fmt.Sprintf("https://email.%s.amazonaws.com", cfg.Region)),
+		client:    newHTTPClient(cfg.Client, cfg.Timeout),
+		now:       time.Now,
+	}
+}
+
+// Name возвращает имя провайдера.
+func (p *SES) Name() string {
+	return "ses"
+}
+
+type sesContent struct {
+	Data    string `json:"Data"`
+	Charset string `json:"Charset"`
+}
+
+type sesRequest struct {
+	FromEmailAddress string `json:"FromEmailAddress"`
+	Destination      struct {
+		ToAddresses []string `json:"ToAddresses"`
+	} `json:"Destination"`
+	Content struct {
+		Simple struct {
+			Subject sesContent `json:"Subject"`
+			Body    struct {
+				Text sesContent `json:"Text"`
+				HTML sesContent `json:"Html"`
+			} `json:"Body"`
+		} `json:"Simple"`
+	} `json:"Content"`
+}
+
+// Send отправляет письмо.
+func (p *SES) Send(ctx context.Context, email *Email) error {
+	var payload sesRequest
+	payload.FromEmailAddress = email.From
+	if email.FromName != "" {
+		payload.FromEmailAddress = (&mail.Address{Name: email.FromName, Address: email.From}).String()
+	}
+	payload.Destination.ToAddresses = []string{email.To}
+	payload.Content.Simple.Subject = sesContent{Data: email.Subject, Charset: "UTF-8"}
+	payload.Content.Simple.Body.Text = sesContent{Data: email.Text, Charset: "UTF-8"}
+	payload.Content.Simple.Body.HTML = sesContent{Data: email.HTML, Charset: "UTF-8"}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.baseURL+"/v2/email/outbound-emails", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	p.sign(req, body, p.now().UTC())
+	return doRequest(p.client, p.Name(), req)
+}
+
+// sign подписывает запрос AWS Signature Version 4 для сервиса ses.
+// Подписываются заголовки Host, Content-Type и X-Amz-Date.
+func (p *SES) sign(req *http.Request, body []byte, now time.Time) {
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+	req.Header.Set("X-Amz-Date", amzDate)
+
+	signedHeaders := "content-type;host;x-amz-date"
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		req.URL.RawQuery,
+		"content-type:" + req.Header.Get("Content-Type") + "\n" +
+			"host:" + req.URL.Host + "\n" +
+			"x-amz-date:" + amzDate + "\n",
+		signedHeaders,
+		hexSHA256(string(body)),
+	}, "\n")
+
+	scope := date + "/" + p.region + "/ses/aws4_request"
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + hexSHA256(canonicalRequest)
+
+	signature := hex.EncodeToString(hmacSHA256(p.signingKey(date), stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		p.keyID, scope, signedHeaders, signature))
+}
+
+// signingKey выводит ключ подписи для даты date (формат 20060102).
+func (p *SES) signingKey(date string) []byte {
+	key := hmacSHA256([]byte("AWS4"+p.secretKey), date)
+	key = hmacSHA256(key, p.region)
+	key = hmacSHA256(key, "ses")
+	return hmacSHA256(key, "aws4_request")
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+func hexSHA256(data string) string {
+	sum := sha256.Sum256([]byte(data))
+	return hex.EncodeToString(sum[:])
+}
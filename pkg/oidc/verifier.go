@@ -0,0 +1,123 @@
+package oidc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// ErrInvalidToken возвращается, если ID-токен не прошёл проверку подписи, издателя, аудитории или срока.
+var ErrInvalidToken = errors.New("invalid id token")
+
+// Identity описывает пользователя, подтверждённого ID-токеном провайдера.
+type Identity struct {
+	Subject       string // Постоянный ID пользователя у провайдера (sub)
+	Email         string
+	EmailVerified bool
+	Nonce         string
+}
+
+// Verifier проверяет ID-токены OpenID Connect одного провайдера.
+type Verifier interface {
+	Verify(ctx context.Context, rawIDToken string) (*Identity, error)
+}
+
+// Config описывает провайдера OpenID Connect.
+type Config struct {
+	Issuers   []string // Допустимые значения iss
+	Audiences []string // Допустимые значения aud (client ID приложений)
+	JWKSURL   string   // Адрес публичных ключей подписи
+}
+
+// Параметры известных провайдеров; client ID задаются конфигурацией.
+var (
+	Google = Config{
+		Issuers: []string{"https://accounts.google.com", "accounts.google.com"},
+		JWKSURL: "https://www.googleapis.com/oauth2/v3/certs",
+	}
+	Apple = Config{
+		Issuers: []string{"https://appleid.apple.com"},
+		JWKSURL: "https://appleid.apple.com/auth/keys",
+	}
+)
+
+type verifier struct {
+	cfg  Config
+	keys *keySet
+}
+
+// NewVerifier создаёт проверку ID-токенов провайдера; ключи подписи загружаются через client и кэшируются.
+// Если client == nil, используется клиент с таймаутом по умолчанию.
+func NewVerifier(cfg Config, client *http.Client) Verifier {
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+	return &verifier{cfg: cfg, keys: newKeySet(cfg.JWKSURL, client)}
+}
+
+// idTokenClaims — поля ID-токена. Apple передаёт email_verified строкой, Google — булевым значением.
+type idTokenClaims struct {
+	Email         string   `json:"email"`
+	EmailVerified flexBool `json:"email_verified"`
+	Nonce         string   `json:"nonce"`
+	jwt.RegisteredClaims
+}
+
+// Verify проверяет подпись, издателя, аудиторию и срок действия ID-токена.
+func (v *verifier) Verify(ctx context.Context, rawIDToken string) (*Identity, error) {
+	var claims idTokenClaims
+	_, err := jwt.ParseWithClaims(rawIDToken, &claims, func(t *jwt.Token) (any, error) {
+		kid, _ := t.Header["kid"].(string)
+		return v.keys.get(ctx, kid)
+	},
+		jwt.WithValidMethods([]string{"RS256", "ES256"}),
+		jwt.WithExpirationRequired(),
+		jwt.WithIssuedAt(),
+	)
+	if err != nil {
+		// Ошибка загрузки JWKS не означает, что токен поддельный, и возвращается как есть.
+		if errors.Is(err, jwt.ErrTokenUnverifiable) && !errors.Is(err, ErrInvalidToken) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+
+	if !slices.Contains(v.cfg.Issuers, claims.Issuer) {
+		return nil, fmt.Errorf("%w: unexpected issuer %q", ErrInvalidToken, claims.Issuer)
+	}
+	if !slices.ContainsFunc(claims.Audience, func(aud string) bool { return slices.Contains(v.cfg.Audiences, aud) }) {
+		return nil, fmt.Errorf("%w: unexpected audience", ErrInvalidToken)
+	}
+	if claims.Subject == "" {
+		return nil, fmt.Errorf("%w: missing subject", ErrInvalidToken)
+	}
+
+	return &Identity{
+		Subject:       claims.Subject,
+		Email:         claims.Email,
+		EmailVerified: bool(claims.EmailVerified),
+		Nonce:         claims.Nonce,
+	}, nil
+}
+
+// flexBool разбирает булево значение, переданное как true или "true".
+type flexBool bool
+
+func (b *flexBool) UnmarshalJSON(data []byte) error {
+	s := string(data)
+	if unquoted, err := strconv.Unquote(s); err == nil {
+		s = unquoted
+	}
+	v, err := strconv.ParseBool(s)
+	if err != nil {
+		return err
+	}
+	*b = flexBool(v)
+	return nil
+}
@@ -1,12 +1,17 @@
 package password
 
 import (
+	"time"
+
 	"golang.org/x/crypto/bcrypt"
 )
 
+// Cost — стоимость bcrypt, с которой хешируются пароли.
+const Cost = bcrypt.DefaultCost
+
 // Hash хеширует пароль с использованием bcrypt.
 func Hash(password string) (string, error) {
-	bytes, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	bytes, err := bcrypt.GenerateFromPassword([]byte(password), Cost)
 	if err != nil {
 		return "", err
 	}
@@ -17,3 +22,19 @@ func Hash(password string) (string, error) {
 func Compare(hash, password string) error {
 	return bcrypt.CompareHashAndPassword([]byte(hash), []byte(password))
 }
+
+// Calibrate хеширует и проверяет служебный пароль и возвращает время проверки одного пароля с Cost.
+// Вызывается при старте сервера: показывает, во сколько обходится вход на этом железе,
+// до того как первые пользователи начнут входить.
+func Calibrate() (time.Duration, error) {
+	const probe = "calibration-probe"
+	hash, err := Hash(probe)
+	if err != nil {
+		return 0, err
+	}
+	started := time.Now()
+	if err := Compare(hash, probe); err != nil {
+		return 0, err
+	}
+	return time.Since(started), nil
+}
@@ -0,0 +1,140 @@
+package password
+
+import (
+	"bufio"
+	_ "embed"
+	"errors"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// MaxBytes — предел bcrypt: более длинные пароли не хешируются.
+const MaxBytes = 72
+
+// Class — класс символов, который политика может требовать в пароле.
+type Class string
+
+const (
+	ClassLower  Class = "lower"  // Строчная буква
+	ClassUpper  Class = "upper"  // Заглавная буква
+	ClassDigit  Class = "digit"  // Цифра
+	ClassSymbol Class = "symbol" // Любой символ, кроме букв и цифр
+)
+
+// Коды нарушений политики паролей.
+const (
+	ViolationTooShort      = "password_too_short"
+	ViolationTooLong       = "password_too_long"
+	ViolationMissingLower  = "password_missing_lowercase"
+	ViolationMissingUpper  = "password_missing_uppercase"
+	ViolationMissingDigit  = "password_missing_digit"
+	ViolationMissingSymbol = "password_missing_symbol"
+	ViolationCommon        = "password_common"
+)
+
+// ErrPolicyViolation — пароль не удовлетворяет политике; подробности — в *PolicyError.
+var ErrPolicyViolation = errors.New("password does not satisfy policy")
+
+// Violation — нарушенное правило политики и его параметр (например, минимальная длина).
+type Violation struct {
+	Code  string
+	Param string
+}
+
+// PolicyError перечисляет все нарушения политики в пароле.
+type PolicyError struct {
+	Violations []Violation
+}
+
+func (e *PolicyError) Error() string {
+	codes := make([]string, 0, len(e.Violations))
+	for _, v := range e.Violations {
+		codes = append(codes, v.Code)
+	}
+	return ErrPolicyViolation.Error() + ": " + strings.Join(codes, ", ")
+}
+
+func (e *PolicyError) Unwrap() error {
+	return ErrPolicyViolation
+}
+
+// Policy задаёт требования к новым паролям.
+type Policy struct {
+	MinLength       int                 // Минимальная длина в символах
+	RequiredClasses []Class             // Обязательные классы символов
+	Denylist        map[string]struct{} // Запрещённые пароли в нижнем регистре; nil — без проверки
+}
+
+//go:embed common.txt
+var commonPasswords string
+
+// CommonPasswords возвращает встроенный список распространённых паролей (в нижнем регистре).
+func CommonPasswords() map[string]struct{} {
+	return ParseDenylist(commonPasswords)
+}
+
+// ParseDenylist разбирает список паролей по одному в строке; пустые строки и строки с # пропускаются.
+func ParseDenylist(list string) map[string]struct{} {
+	result := make(map[string]struct{})
+	scanner := bufio.NewScanner(strings.NewReader(list))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		result[strings.ToLower(line)] = struct{}{}
+	}
+	return result
+}
+
+// Validate проверяет пароль по политике и возвращает *PolicyError со всеми нарушениями или nil.
+func (p Policy) Validate(password string) error {
+	var violations []Violation
+
+	if utf8.RuneCountInString(password) < p.MinLength {
+		violations = append(violations, Violation{Code: ViolationTooShort, Param: strconv.Itoa(p.MinLength)})
+	}
+	if len(password) > MaxBytes {
+		violations = append(violations, Violation{Code: ViolationTooLong, Param: strconv.Itoa(MaxBytes)})
+	}
+
+	present := make(map[Class]bool, 4)
+	for _, r := range password {
+		switch {
+		case unicode.IsLower(r):
+			present[ClassLower] = true
+		case unicode.IsUpper(r):
+			present[ClassUpper] = true
+		case unicode.IsDigit(r):
+			present[ClassDigit] = true
+		case !unicode.IsLetter(r):
+			present[ClassSymbol] = true
+		}
+	}
+	for _, class := range p.RequiredClasses {
+		if present[class] {
+			continue
+		}
+		switch class {
+		case ClassLower:
+			violations = append(violations, Violation{Code: ViolationMissingLower})
+		case ClassUpper:
+			violations = append(violations, Violation{Code: ViolationMissingUpper})
+		case ClassDigit:
+			violations = append(violations, Violation{Code: ViolationMissingDigit})
+		case ClassSymbol:
+			violations = append(violations, Violation{Code: ViolationMissingSymbol})
+		}
+	}
+
+	if _, denied := p.Denylist[strings.ToLower(password)]; denied {
+		violations = append(violations, Violation{Code: ViolationCommon})
+	}
+
+	if len(violations) == 0 {
+		return nil
+	}
+	return &PolicyError{Violations: violations}
+}
@@ -0,0 +1,88 @@
+package presence
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// RedisStore хранит присутствие в Redis; истечение обеспечивается TTL ключей.
+type RedisStore struct {
+	client redis.Cmdable
+	prefix string
+}
+
+// NewRedisStore создаёт хранилище присутствия в Redis. prefix добавляется ко всем ключам.
+func NewRedisStore(client redis.Cmdable, prefix string) *RedisStore {
+	return &RedisStore{client: client, prefix: prefix}
+}
+
+// Get возвращает присутствие по ключу.
+func (s *RedisStore) Get(ctx context.Context, key string) (*State, error) {
+	raw, err := s.client.Get(ctx, s.prefix+key).Bytes()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("redis presence get: %w", err)
+	}
+	var state State
+	if err := json.Unmarshal(raw, &state); err != nil {
+		return nil, fmt.Errorf("decode presence: %w", err)
+	}
+	return &state, nil
+}
+
+// GetMany возвращает присутствие для набора ключей одним запросом MGET.
+func (s *RedisStore) GetMany(ctx context.Context, keys []string) (map[string]State, error) {
+	result := make(map[string]State, len(keys))
+	if len(keys) == 0 {
+		return result, nil
+	}
+
+	redisKeys := make([]string, len(keys))
+	for i, key := range keys {
+		redisKeys[i] = s.prefix + key
+	}
+	values, err := s.client.MGet(ctx, redisKeys...).Result()
+	if err != nil {
+		return nil, fmt.Errorf("redis presence mget: %w", err)
+	}
+
+	for i, v := range values {
+		raw, ok := v.(string)
+		if !ok {
+			continue
+		}
+		var state State
+		if err := json.Unmarshal([]byte(raw), &state); err != nil {
+			return nil, fmt.Errorf("decode presence: %w", err)
+		}
+		result[keys[i]] = state
+	}
+	return result, nil
+}
+
+// Set сохраняет присутствие на ttl.
+func (s *RedisStore) Set(ctx context.Context, key string, state State, ttl time.Duration) error {
+	raw, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+	if err := s.client.Set(ctx, s.prefix+key, raw, ttl).Err(); err != nil {
+		return fmt.Errorf("redis presence set: %w", err)
+	}
+	return nil
+}
+
+// Delete удаляет присутствие.
+func (s *RedisStore) Delete(ctx context.Context, key string) error {
+	if err := s.client.Del(ctx, s.prefix+key).Err(); err != nil {
+		return fmt.Errorf("redis presence del: %w", err)
+	}
+	return nil
+}
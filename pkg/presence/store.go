@@ -0,0 +1,106 @@
+package presence
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// State описывает текущее присутствие пользователя (например, «идёт тренировка»).
+type State struct {
+	Status     string    `json:"status"`               // Статус присутствия
+	SessionID  string    `json:"session_id,omitempty"` // Идентификатор активной сессии клиента (опционально)
+	Since      time.Time `json:"since"`                // Когда начался текущий статус
+	LastSeenAt time.Time `json:"last_seen_at"`         // Время последнего heartbeat
+}
+
+// Store хранит присутствие с ограниченным временем жизни.
+// Запись исчезает, если её не продлили heartbeat'ом в течение ttl.
+type Store interface {
+	// Get возвращает присутствие по ключу или nil, если записи нет (или она истекла).
+	Get(ctx context.Context, key string) (*State, error)
+	// GetMany возвращает присутствие для набора ключей; отсутствующие ключи не попадают в результат.
+	GetMany(ctx context.Context, keys []string) (map[string]State, error)
+	// Set сохраняет присутствие на ttl.
+	Set(ctx context.Context, key string, state State, ttl time.Duration) error
+	// Delete удаляет присутствие.
+	Delete(ctx context.Context, key string) error
+}
+
+type memoryEntry struct {
+	state     State
+	expiresAt time.Time
+}
+
+// MemoryStore хранит присутствие в памяти процесса.
+// Подходит для одного инстанса; при горизонтальном масштабировании используйте RedisStore.
+type MemoryStore struct {
+	now func() time.Time
+
+	mu      sync.Mutex
+	entries map[string]memoryEntry
+}
+
+// NewMemoryStore создаёт in-memory хранилище присутствия.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		now:     time.Now,
+		entries: make(map[string]memoryEntry),
+	}
+}
+
+// Get возвращает присутствие по ключу.
+func (s *MemoryStore) Get(_ context.Context, key string) (*State, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	e, ok := s.entries[key]
+	if !ok {
+		return nil, nil
+	}
+	if !s.now().Before(e.expiresAt) {
+		delete(s.entries, key)
+		return nil, nil
+	}
+	state := e.state
+	return &state, nil
+}
+
+// GetMany возвращает присутствие для набора ключей.
+func (s *MemoryStore) GetMany(_ context.Context, keys []string) (map[string]State, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	result := make(map[string]State, len(keys))
+	for _, key := range keys {
+		e, ok := s.entries[key]
+		if !ok {
+			continue
+		}
+		if !now.Before(e.expiresAt) {
+			delete(s.entries, key)
+			continue
+		}
+		result[key] = e.state
+	}
+	return result, nil
+}
+
+// Set сохраняет присутствие на ttl.
+func (s *MemoryStore) Set(_ context.Context, key string, state State, ttl time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.entries[key] = memoryEntry{state: state, expiresAt: s.now().Add(ttl)}
+	return nil
+}
+
+// Delete удаляет присутствие.
+func (s *MemoryStore) Delete(_ context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.entries, key)
+	return nil
+}
@@ -0,0 +1,165 @@
+package push
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	// APNsProductionURL — адрес APNs для приложений из App Store и TestFlight.
+	APNsProductionURL = "https://api.push.apple.com"
+	// APNsSandboxURL — адрес APNs для отладочных сборок приложения.
+	APNsSandboxURL = "https://api.sandbox.push.apple.com"
+	// apnsTokenLifetime — срок использования токена провайдера: Apple отклоняет токены старше часа
+	// и ограничивает их обновление чаще чем раз в 20 минут.
+	apnsTokenLifetime = 50 * time.Minute
+)
+
+// APNsConfig описывает подключение к Apple Push Notification service.
+type APNsConfig struct {
+	KeyID      string        // ID ключа .p8 в Apple Developer
+	TeamID     string        // ID команды Apple Developer
+	PrivateKey []byte        // PEM закрытого ключа .p8 (ECDSA P-256)
+	Topic      string        // Bundle ID приложения
+	BaseURL    string        // Адрес API (пусто — APNsSandboxURL)
+	Timeout    time.Duration // Таймаут запроса (0 — DefaultTimeout)
+	Client     *http.Client  // HTTP-клиент (nil — отдельный клиент с таймаутом Timeout); APNs требует HTTP/2
+}
+
+// APNs отправляет уведомления через APNs HTTP/2 API (POST /3/device/<token>).
+// Запросы подписываются токеном провайдера (JWT ES256), который переиспользуется apnsTokenLifetime.
+type APNs struct {
+	keyID   string
+	teamID  string
+	key     *ecdsa.PrivateKey
+	topic   string
+	baseURL string
+	client  *http.Client
+	now     func() time.Time
+
+	mu       sync.Mutex
+	token    string
+	issuedAt time.Time
+}
+
+// Убедимся на этапе компиляции, что структура реализует интерфейс.
+var _ Sender = (*APNs)(nil)
+
+// NewAPNs создаёт клиент APNs. Возвращает ошибку, если закрытый ключ некорректен.
+func NewAPNs(cfg APNsConfig) (*APNs, error) {
+	key, err := jwt.ParseECPrivateKeyFromPEM(cfg.PrivateKey)
+	if err != nil {
+		return nil, fmt.Errorf("parse apns private key: %w", err)
+	}
+	return &APNs{
+		keyID:   cfg.KeyID,
+		teamID:  cfg.TeamID,
+		key:     key,
+		topic:   cfg.Topic,
+		baseURL: baseURLOrDefault(cfg.BaseURL, APNsSandboxURL),
+		client:  newHTTPClient(cfg.Client, cfg.Timeout),
+		now:     time.Now,
+	}, nil
+}
+
+// Name возвращает имя сервиса.
+func (s *APNs) Name() string {
+	return "apns"
+}
+
+// Send отправляет уведомление. Data передаётся ключами верхнего уровня рядом с aps.
+func (s *APNs) Send(ctx context.Context, msg *Message) error {
+	token, err := s.providerToken()
+	if err != nil {
+		return err
+	}
+
+	payload := make(map[string]any, len(msg.Data)+1)
+	for k, v := range msg.Data {
+		payload[k] = v
+	}
+	payload["aps"] = map[string]any{
+		"alert": map[string]string{"title": msg.Title, "body": msg.Body},
+		"sound": "default",
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("apns: encode message: %w", err)
+	}
+
+	endpoint := s.baseURL + "/3/device/" + url.PathEscape(msg.Token)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("apns: %w", err)
+	}
+	req.Header.Set("Authorization", "bearer "+token)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("apns-topic", s.topic)
+	req.Header.Set("apns-push-type", "alert")
+	req.Header.Set("apns-priority", "10")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("apns: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusOK {
+		return nil
+	}
+	return newAPNsError(resp)
+}
+
+// newAPNsError сопоставляет ответ APNs с ошибками пакета: 410 и ошибки токена — токен недействителен
+// (BadDeviceToken приходит и для токена другого окружения), прочие 400 и 413 — некорректное сообщение.
+func newAPNsError(resp *http.Response) error {
+	raw := readErrorBody(resp)
+	err := &ServiceError{Service: "apns", StatusCode: resp.StatusCode, Reason: strings.TrimSpace(string(raw))}
+
+	var body struct {
+		Reason string `json:"reason"`
+	}
+	if json.Unmarshal(raw, &body) == nil && body.Reason != "" {
+		err.Reason = body.Reason
+	}
+	switch {
+	case resp.StatusCode == http.StatusGone,
+		err.Reason == "BadDeviceToken", err.Reason == "DeviceTokenNotForTopic", err.Reason == "Unregistered":
+		err.cause = ErrTokenInvalid
+	case resp.StatusCode == http.StatusBadRequest, resp.StatusCode == http.StatusRequestEntityTooLarge:
+		err.cause = ErrMessageRejected
+	}
+	return err
+}
+
+// providerToken возвращает действующий токен провайдера, при необходимости подписывая новый.
+func (s *APNs) providerToken() (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	if s.token != "" && now.Sub(s.issuedAt) < apnsTokenLifetime {
+		return s.token, nil
+	}
+	t := jwt.NewWithClaims(jwt.SigningMethodES256, jwt.MapClaims{
+		"iss": s.teamID,
+		"iat": now.Unix(),
+	})
+	t.Header["kid"] = s.keyID
+	signed, err := t.SignedString(s.key)
+	if err != nil {
+		return "", fmt.Errorf("apns: sign provider token: %w", err)
+	}
+	s.token, s.issuedAt = signed, now
+	return signed, nil
+}
@@ -0,0 +1,239 @@
+package push
+
+import (
+	"bytes"
+	"context"
+	"crypto/rsa"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	// defaultFCMURL — адрес FCM HTTP v1 API.
+	defaultFCMURL = "https://fcm.googleapis.com"
+	// defaultGoogleTokenURL — адрес обмена подписанного JWT сервисного аккаунта на access-токен.
+	defaultGoogleTokenURL = "https://oauth2.googleapis.com/token"
+	// fcmScope — область доступа access-токена для отправки сообщений.
+	fcmScope = "https://www.googleapis.com/auth/firebase.messaging"
+	// fcmTokenRefreshMargin — access-токен обновляется заранее, чтобы не истечь во время запроса.
+	fcmTokenRefreshMargin = time.Minute
+)
+
+// FCMCredentials — поля JSON-ключа сервисного аккаунта Google, нужные для отправки сообщений.
+type FCMCredentials struct {
+	ProjectID   string `json:"project_id"`
+	ClientEmail string `json:"client_email"`
+	PrivateKey  string `json:"private_key"` // PEM закрытого RSA-ключа
+	TokenURI    string `json:"token_uri"`
+}
+
+// LoadFCMCredentials читает JSON-ключ сервисного аккаунта из файла.
+func LoadFCMCredentials(path string) (FCMCredentials, error) {
+	var creds FCMCredentials
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return creds, fmt.Errorf("read fcm credentials: %w", err)
+	}
+	if err := json.Unmarshal(raw, &creds); err != nil {
+		return creds, fmt.Errorf("decode fcm credentials: %w", err)
+	}
+	if creds.ProjectID == "" || creds.ClientEmail == "" || creds.PrivateKey == "" {
+		return creds, fmt.Errorf("fcm credentials must contain project_id, client_email and private_key")
+	}
+	return creds, nil
+}
+
+// FCMConfig описывает подключение к Firebase Cloud Messaging.
+type FCMConfig struct {
+	Credentials FCMCredentials
+	BaseURL     string        // Адрес API (пусто — https://fcm.googleapis.com)
+	Timeout     time.Duration // Таймаут запроса (0 — DefaultTimeout)
+	Client      *http.Client  // HTTP-клиент (nil — отдельный клиент с таймаутом Timeout)
+}
+
+// FCM отправляет уведомления через FCM HTTP v1 API (POST /v1/projects/<project>/messages:send).
+// Access-токен OAuth 2.0 получается по JWT, подписанному ключом сервисного аккаунта, и кешируется до истечения.
+type FCM struct {
+	projectID   string
+	clientEmail string
+	key         *rsa.PrivateKey
+	tokenURL    string
+	baseURL     string
+	client      *http.Client
+	now         func() time.Time
+
+	mu          sync.Mutex
+	accessToken string
+	expiresAt   time.Time
+}
+
+// Убедимся на этапе компиляции, что структура реализует интерфейс.
+var _ Sender = (*FCM)(nil)
+
+// NewFCM создаёт клиент FCM. Возвращает ошибку, если закрытый ключ сервисного аккаунта некорректен.
+func NewFCM(cfg FCMConfig) (*FCM, error) {
+	key, err := jwt.ParseRSAPrivateKeyFromPEM([]byte(cfg.Credentials.PrivateKey))
+	if err != nil {
+		return nil, fmt.Errorf("parse fcm private key: %w", err)
+	}
+	tokenURL := cfg.Credentials.TokenURI
+	if tokenURL == "" {
+		tokenURL = defaultGoogleTokenURL
+	}
+	return &FCM{
+		projectID:   cfg.Credentials.ProjectID,
+		clientEmail: cfg.Credentials.ClientEmail,
+		key:         key,
+		tokenURL:    tokenURL,
+		baseURL:     baseURLOrDefault(cfg.BaseURL, defaultFCMURL),
+		client:      newHTTPClient(cfg.Client, cfg.Timeout),
+		now:         time.Now,
+	}, nil
+}
+
+// Name возвращает имя сервиса.
+func (s *FCM) Name() string {
+	return "fcm"
+}
+
+type fcmNotification struct {
+	Title string `json:"title,omitempty"`
+	Body  string `json:"body,omitempty"`
+}
+
+type fcmMessage struct {
+	Token        string            `json:"token"`
+	Notification fcmNotification   `json:"notification"`
+	Data         map[string]string `json:"data,omitempty"`
+}
+
+// fcmErrorBody — тело ответа FCM с ошибкой; код ошибки FCM передаётся в details.
+type fcmErrorBody struct {
+	Error struct {
+		Status  string `json:"status"`
+		Message string `json:"message"`
+		Details []struct {
+			ErrorCode string `json:"errorCode"`
+		} `json:"details"`
+	} `json:"error"`
+}
+
+// Send отправляет уведомление.
+func (s *FCM) Send(ctx context.Context, msg *Message) error {
+	accessToken, err := s.token(ctx)
+	if err != nil {
+		return err
+	}
+
+	body, err := json.Marshal(map[string]fcmMessage{"message": {
+		Token:        msg.Token,
+		Notification: fcmNotification{Title: msg.Title, Body: msg.Body},
+		Data:         msg.Data,
+	}})
+	if err != nil {
+		return fmt.Errorf("fcm: encode message: %w", err)
+	}
+
+	endpoint := fmt.Sprintf("%s/v1/projects/%s/messages:send", s.baseURL, url.PathEscape(s.projectID))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("fcm: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("fcm: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return nil
+	}
+	return newFCMError(resp)
+}
+
+// newFCMError сопоставляет ответ FCM с ошибками пакета: UNREGISTERED (или 404) — токен недействителен,
+// SENDER_ID_MISMATCH — токен другого проекта, INVALID_ARGUMENT — некорректное сообщение.
+func newFCMError(resp *http.Response) error {
+	raw := readErrorBody(resp)
+	err := &ServiceError{Service: "fcm", StatusCode: resp.StatusCode, Reason: strings.TrimSpace(string(raw))}
+
+	var body fcmErrorBody
+	if json.Unmarshal(raw, &body) == nil && body.Error.Status != "" {
+		err.Reason = body.Error.Status
+		for _, d := range body.Error.Details {
+			if d.ErrorCode != "" {
+				err.Reason = d.ErrorCode
+			}
+		}
+	}
+	switch {
+	case err.Reason == "UNREGISTERED" || err.Reason == "SENDER_ID_MISMATCH" || resp.StatusCode == http.StatusNotFound:
+		err.cause = ErrTokenInvalid
+	case err.Reason == "INVALID_ARGUMENT":
+		err.cause = ErrMessageRejected
+	}
+	return err
+}
+
+// token возвращает действующий access-токен, при необходимости получая новый.
+func (s *FCM) token(ctx context.Context) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	if s.accessToken != "" && now.Before(s.expiresAt.Add(-fcmTokenRefreshMargin)) {
+		return s.accessToken, nil
+	}
+
+	assertion, err := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{
+		"iss":   s.clientEmail,
+		"scope": fcmScope,
+		"aud":   s.tokenURL,
+		"iat":   now.Unix(),
+		"exp":   now.Add(time.Hour).Unix(),
+	}).SignedString(s.key)
+	if err != nil {
+		return "", fmt.Errorf("fcm: sign token request: %w", err)
+	}
+
+	form := url.Values{
+		"grant_type": {"urn:ietf:params:oauth:grant-type:jwt-bearer"},
+		"assertion":  {assertion},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.tokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", fmt.Errorf("fcm: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("fcm: token request: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", &ServiceError{Service: "fcm", StatusCode: resp.StatusCode, Reason: strings.TrimSpace(string(readErrorBody(resp)))}
+	}
+
+	var token struct {
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int64  `json:"expires_in"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&token); err != nil || token.AccessToken == "" {
+		return "", fmt.Errorf("fcm: invalid token response")
+	}
+	s.accessToken = token.AccessToken
+	s.expiresAt = now.Add(time.Duration(token.ExpiresIn) * time.Second)
+	return s.accessToken, nil
+}
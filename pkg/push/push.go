@@ -0,0 +1,84 @@
+// Package push доставляет push-уведомления на устройства через Firebase Cloud Messaging и APNs.
+package push
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// ErrTokenInvalid возвращается, если токен устройства больше не действителен (приложение удалено,
+// токен выпущен для другого окружения или приложения): устройство нужно забыть.
+var ErrTokenInvalid = errors.New("push token is no longer valid")
+
+// ErrMessageRejected возвращается, если сервис отклонил сообщение как некорректное: повторная отправка не поможет.
+var ErrMessageRejected = errors.New("push message rejected")
+
+// DefaultTimeout — таймаут запроса к сервису, если он не задан явно.
+const DefaultTimeout = 10 * time.Second
+
+// maxErrorBody ограничивает размер тела ответа с ошибкой, попадающего в текст ошибки (в байтах).
+const maxErrorBody = 512
+
+// Message — push-уведомление на одно устройство.
+type Message struct {
+	Token string            // Токен устройства, выданный FCM или APNs
+	Title string            // Заголовок уведомления
+	Body  string            // Текст уведомления
+	Data  map[string]string // Данные для приложения (тип, ID объекта)
+}
+
+// Sender доставляет push-уведомления через сервис платформы.
+type Sender interface {
+	// Name возвращает имя сервиса для логов (fcm, apns).
+	Name() string
+	// Send отправляет уведомление. Ошибки оборачивают ErrTokenInvalid или ErrMessageRejected;
+	// остальные ошибки (сеть, 429, 5xx, неверные учётные данные) временные.
+	Send(ctx context.Context, msg *Message) error
+}
+
+// ServiceError описывает неуспешный ответ сервиса push-уведомлений.
+type ServiceError struct {
+	Service    string
+	StatusCode int
+	Reason     string // Код ошибки сервиса (UNREGISTERED, BadDeviceToken и т.п.) или тело ответа
+	cause      error  // ErrTokenInvalid, ErrMessageRejected или nil для временных ошибок
+}
+
+func (e *ServiceError) Error() string {
+	return fmt.Sprintf("%s: unexpected status %d: %s", e.Service, e.StatusCode, e.Reason)
+}
+
+// Unwrap позволяет сравнивать ошибку с ErrTokenInvalid и ErrMessageRejected через errors.Is.
+func (e *ServiceError) Unwrap() error {
+	return e.cause
+}
+
+// readErrorBody читает начало тела ответа с ошибкой.
+func readErrorBody(resp *http.Response) []byte {
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBody))
+	return body
+}
+
+// newHTTPClient возвращает client или, если он не задан, HTTP-клиент с таймаутом запроса.
+func newHTTPClient(client *http.Client, timeout time.Duration) *http.Client {
+	if client != nil {
+		return client
+	}
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+	return &http.Client{Timeout: timeout}
+}
+
+// baseURLOrDefault возвращает base без завершающего слэша или def, если base пуст.
+func baseURLOrDefault(base, def string) string {
+	if base == "" {
+		return def
+	}
+	return strings.TrimRight(base, "/")
+}
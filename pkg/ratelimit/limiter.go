@@ -0,0 +1,94 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Result описывает результат проверки лимита для ключа.
+type Result struct {
+	Allowed    bool          // Разрешён ли запрос
+	Limit      int           // Лимит запросов в окне
+	Remaining  int           // Сколько запросов осталось в текущем окне
+	RetryAfter time.Duration // Через сколько откроется следующее окно (если запрос отклонён)
+}
+
+// Limiter описывает ограничитель частоты запросов по произвольному ключу (IP, email и т.п.).
+type Limiter interface {
+	Allow(ctx context.Context, key string) (Result, error)
+}
+
+// window — состояние фиксированного окна для одного ключа.
+type window struct {
+	start time.Time
+	count int
+}
+
+// MemoryLimiter — ограничитель на фиксированных окнах, хранящий счётчики в памяти процесса.
+// Подходит для одного инстанса; при горизонтальном масштабировании счётчики не разделяются.
+type MemoryLimiter struct {
+	limit  int
+	period time.Duration
+	now    func() time.Time
+
+	mu        sync.Mutex
+	windows   map[string]*window
+	lastSweep time.Time
+}
+
+// NewMemoryLimiter создаёт ограничитель, пропускающий не более limit запросов за period на ключ.
+func NewMemoryLimiter(limit int, period time.Duration) *MemoryLimiter {
+	return &MemoryLimiter{
+		limit:   limit,
+		period:  period,
+		now:     time.Now,
+		windows: make(map[string]*window),
+	}
+}
+
+// Allow учитывает запрос для ключа и сообщает, укладывается ли он в лимит.
+func (l *MemoryLimiter) Allow(_ context.Context, key string) (Result, error) {
+	now := l.now()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.sweep(now)
+
+	w, ok := l.windows[key]
+	if !ok || now.Sub(w.start) >= l.period {
+		w = &window{start: now}
+		l.windows[key] = w
+	}
+
+	if w.count >= l.limit {
+		return Result{
+			Allowed:    false,
+			Limit:      l.limit,
+			Remaining:  0,
+			RetryAfter: w.start.Add(l.period).Sub(now),
+		}, nil
+	}
+
+	w.count++
+	return Result{
+		Allowed:   true,
+		Limit:     l.limit,
+		Remaining: l.limit - w.count,
+	}, nil
+}
+
+// sweep удаляет истёкшие окна не чаще одного раза за период,
+// чтобы карта не росла бесконечно при большом количестве уникальных ключей.
+func (l *MemoryLimiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < l.period {
+		return
+	}
+	for key, w := range l.windows {
+		if now.Sub(w.start) >= l.period {
+			delete(l.windows, key)
+		}
+	}
+	l.lastSweep = now
+}
@@ -0,0 +1,70 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// fixedWindowScript атомарно увеличивает счётчик окна и выставляет TTL при первом запросе.
+// Возвращает текущее значение счётчика и оставшееся время жизни окна в миллисекундах.
+var fixedWindowScript = redis.NewScript(`
+local count = redis.call("INCR", KEYS[1])
+if count == 1 then
+	redis.call("PEXPIRE", KEYS[1], ARGV[1])
+end
+local ttl = redis.call("PTTL", KEYS[1])
+return {count, ttl}
+`)
+
+// RedisLimiter — ограничитель на фиксированных окнах, хранящий счётчики в Redis.
+// Счётчики разделяются между всеми инстансами сервиса.
+type RedisLimiter struct {
+	client redis.Scripter
+	prefix string
+	limit  int
+	period time.Duration
+}
+
+// NewRedisLimiter создаёт ограничитель, пропускающий не более limit запросов за period на ключ.
+// prefix добавляется ко всем ключам Redis.
+func NewRedisLimiter(client redis.Scripter, prefix string, limit int, period time.Duration) *RedisLimiter {
+	return &RedisLimiter{
+		client: client,
+		prefix: prefix,
+		limit:  limit,
+		period: period,
+	}
+}
+
+// Allow учитывает запрос для ключа и сообщает, укладывается ли он в лимит.
+func (l *RedisLimiter) Allow(ctx context.Context, key string) (Result, error) {
+	values, err := fixedWindowScript.Run(ctx, l.client, []string{l.prefix + key}, l.period.Milliseconds()).Int64Slice()
+	if err != nil {
+		return Result{}, fmt.Errorf("redis rate limit: %w", err)
+	}
+	if len(values) != 2 {
+		return Result{}, fmt.Errorf("redis rate limit: unexpected reply %v", values)
+	}
+
+	count, ttl := int(values[0]), time.Duration(values[1])*time.Millisecond
+	if ttl < 0 {
+		ttl = l.period
+	}
+
+	if count > l.limit {
+		return Result{
+			Allowed:    false,
+			Limit:      l.limit,
+			Remaining:  0,
+			RetryAfter: ttl,
+		}, nil
+	}
+	return Result{
+		Allowed:   true,
+		Limit:     l.limit,
+		Remaining: l.limit - count,
+	}, nil
+}
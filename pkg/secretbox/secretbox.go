@@ -0,0 +1,74 @@
+// Package secretbox шифрует небольшие секреты (пароли, ключи API) для хранения в БД.
+// Используется AES-256-GCM; nonce хранится в начале шифротекста.
+package secretbox
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+)
+
+// KeySize — длина ключа в байтах (AES-256).
+const KeySize = 32
+
+var (
+	// ErrInvalidKey возвращается для ключа неверной длины или кодировки.
+	ErrInvalidKey = errors.New("secretbox: key must be 32 bytes encoded in base64")
+	// ErrDecrypt возвращается, если шифротекст повреждён или зашифрован другим ключом.
+	ErrDecrypt = errors.New("secretbox: decryption failed")
+)
+
+// Box шифрует и расшифровывает данные одним ключом. Безопасен для конкурентного использования.
+type Box struct {
+	aead cipher.AEAD
+}
+
+// ParseKey декодирует ключ из base64 (стандартный алфавит) и проверяет его длину.
+func ParseKey(encoded string) ([]byte, error) {
+	key, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil || len(key) != KeySize {
+		return nil, ErrInvalidKey
+	}
+	return key, nil
+}
+
+// New создаёт Box с ключом длины KeySize.
+func New(key []byte) (*Box, error) {
+	if len(key) != KeySize {
+		return nil, ErrInvalidKey
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("secretbox: %w", err)
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("secretbox: %w", err)
+	}
+	return &Box{aead: aead}, nil
+}
+
+// Seal шифрует plaintext. Каждый вызов использует новый случайный nonce.
+func (b *Box) Seal(plaintext []byte) ([]byte, error) {
+	nonce := make([]byte, b.aead.NonceSize(), b.aead.NonceSize()+len(plaintext)+b.aead.Overhead())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("secretbox: %w", err)
+	}
+	return b.aead.Seal(nonce, nonce, plaintext, nil), nil
+}
+
+// Open расшифровывает данные, полученные от Seal.
+func (b *Box) Open(sealed []byte) ([]byte, error) {
+	n := b.aead.NonceSize()
+	if len(sealed) < n+b.aead.Overhead() {
+		return nil, ErrDecrypt
+	}
+	plaintext, err := b.aead.Open(nil, sealed[:n], sealed[n:], nil)
+	if err != nil {
+		return nil, ErrDecrypt
+	}
+	return plaintext, nil
+}
@@ -0,0 +1,34 @@
+package servertiming
+
+import (
+	"context"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// RedisHook учитывает команды Redis в категории cache.
+type RedisHook struct{}
+
+var _ redis.Hook = RedisHook{}
+
+// DialHook не учитывает установку соединения: она не относится к конкретному запросу.
+func (RedisHook) DialHook(next redis.DialHook) redis.DialHook {
+	return next
+}
+
+// ProcessHook учитывает одиночную команду.
+func (RedisHook) ProcessHook(next redis.ProcessHook) redis.ProcessHook {
+	return func(ctx context.Context, cmd redis.Cmder) error {
+		defer Track(ctx, Cache, time.Now())
+		return next(ctx, cmd)
+	}
+}
+
+// ProcessPipelineHook учитывает конвейер команд как одно обращение.
+func (RedisHook) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return func(ctx context.Context, cmds []redis.Cmder) error {
+		defer Track(ctx, Cache, time.Now())
+		return next(ctx, cmds)
+	}
+}
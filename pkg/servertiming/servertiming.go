@@ -0,0 +1,102 @@
+// Package servertiming собирает длительность обращений к зависимостям за время запроса
+// и формирует заголовок Server-Timing (https://www.w3.org/TR/server-timing/).
+package servertiming
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// HeaderName — имя HTTP-заголовка с метриками.
+const HeaderName = "Server-Timing"
+
+// Категории метрик.
+const (
+	DB       = "db"       // запросы к базе данных
+	Cache    = "cache"    // обращения к кешу (Redis)
+	External = "external" // вызовы внешних сервисов (SMTP, HTTP)
+	Total    = "total"    // полное время обработки запроса
+)
+
+// metricOrder задаёт порядок метрик в заголовке.
+var metricOrder = []string{DB, Cache, External}
+
+type contextKey struct{}
+
+// metric — суммарная длительность и число обращений одной категории.
+type metric struct {
+	dur   time.Duration
+	count int
+}
+
+// Timings накапливает длительности обращений к зависимостям за один запрос.
+// Безопасен для конкурентного использования.
+type Timings struct {
+	mu      sync.Mutex
+	metrics map[string]*metric
+}
+
+// NewContext возвращает контекст с новым накопителем метрик.
+func NewContext(ctx context.Context) (context.Context, *Timings) {
+	t := &Timings{metrics: make(map[string]*metric)}
+	return context.WithValue(ctx, contextKey{}, t), t
+}
+
+// FromContext возвращает накопитель метрик запроса или nil, если его нет.
+func FromContext(ctx context.Context) *Timings {
+	if ctx == nil {
+		return nil
+	}
+	t, _ := ctx.Value(contextKey{}).(*Timings)
+	return t
+}
+
+// Add добавляет длительность к категории. Безопасно вызывать на nil.
+func (t *Timings) Add(name string, d time.Duration) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	m, ok := t.metrics[name]
+	if !ok {
+		m = &metric{}
+		t.metrics[name] = m
+	}
+	m.dur += d
+	m.count++
+}
+
+// Track добавляет к категории время, прошедшее с start, если в контексте есть накопитель.
+// Удобно использовать как defer servertiming.Track(ctx, servertiming.DB, time.Now()).
+func Track(ctx context.Context, name string, start time.Time) {
+	FromContext(ctx).Add(name, time.Since(start))
+}
+
+// Header формирует значение заголовка Server-Timing, добавляя в конец полное время запроса.
+// Категории без обращений не выводятся; в desc указывается число обращений.
+func (t *Timings) Header(total time.Duration) string {
+	parts := make([]string, 0, len(metricOrder)+1)
+
+	t.mu.Lock()
+	for _, name := range metricOrder {
+		m, ok := t.metrics[name]
+		if !ok {
+			continue
+		}
+		parts = append(parts, formatMetric(name, m.dur)+`;desc="`+strconv.Itoa(m.count)+`"`)
+	}
+	t.mu.Unlock()
+
+	parts = append(parts, formatMetric(Total, total))
+	return strings.Join(parts, ", ")
+}
+
+// formatMetric форматирует метрику как name;dur=<миллисекунды>.
+func formatMetric(name string, d time.Duration) string {
+	ms := float64(d.Microseconds()) / 1000
+	return name + ";dur=" + strconv.FormatFloat(ms, 'f', 1, 64)
+}
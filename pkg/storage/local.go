@@ -0,0 +1,108 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// LocalStorage хранит объекты в каталоге на локальном диске.
+// Публичные файлы (см. IsPublic) должны раздаваться HTTP-сервером по адресу baseURL
+// (см. server.setupRoutes); остальные отдаются через Open.
+type LocalStorage struct {
+	dir     string
+	baseURL string
+}
+
+var (
+	_ Storage = (*LocalStorage)(nil)
+	_ Opener  = (*LocalStorage)(nil)
+)
+
+// NewLocalStorage создаёт хранилище в каталоге dir; публичные URL строятся от baseURL.
+func NewLocalStorage(dir, baseURL string) *LocalStorage {
+	return &LocalStorage{dir: dir, baseURL: strings.TrimRight(baseURL, "/")}
+}
+
+// Put записывает объект во временный файл и атомарно переименовывает его в итоговый.
+func (s *LocalStorage) Put(_ context.Context, key string, body io.Reader, _ int64, _ string) (string, error) {
+	if !validKey(key) {
+		return "", ErrInvalidKey
+	}
+	path := filepath.Join(s.dir, filepath.FromSlash(key))
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return "", fmt.Errorf("failed to create storage directory: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".upload-*")
+	if err != nil {
+		return "", fmt.Errorf("failed to create temp file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := io.Copy(tmp, body); err != nil {
+		tmp.Close()
+		return "", fmt.Errorf("failed to write object: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return "", fmt.Errorf("failed to write object: %w", err)
+	}
+	if err := os.Chmod(tmp.Name(), 0o644); err != nil {
+		return "", fmt.Errorf("failed to set object permissions: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return "", fmt.Errorf("failed to store object: %w", err)
+	}
+
+	return s.baseURL + "/" + key, nil
+}
+
+// Delete удаляет файл объекта.
+func (s *LocalStorage) Delete(_ context.Context, key string) error {
+	if !validKey(key) {
+		return ErrInvalidKey
+	}
+	err := os.Remove(filepath.Join(s.dir, filepath.FromSlash(key)))
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// KeyFromURL возвращает ключ объекта по его публичному URL.
+func (s *LocalStorage) KeyFromURL(url string) (string, bool) {
+	return keyFromURL(s.baseURL, url)
+}
+
+// Ping проверяет, что каталог хранилища существует или может быть создан.
+func (s *LocalStorage) Ping(_ context.Context) error {
+	return os.MkdirAll(s.dir, 0o755)
+}
+
+// Open открывает файл объекта для чтения.
+func (s *LocalStorage) Open(_ context.Context, key string) (*Object, error) {
+	if !validKey(key) {
+		return nil, ErrInvalidKey
+	}
+	f, err := os.Open(filepath.Join(s.dir, filepath.FromSlash(key)))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	if info.IsDir() {
+		f.Close()
+		return nil, ErrNotFound
+	}
+	return &Object{ReadSeekCloser: f, Size: info.Size(), ModTime: info.ModTime()}, nil
+}
@@ -0,0 +1,268 @@
+package storage
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"workout-app/pkg/servertiming"
+)
+
+// maxPresignTTL — максимальный срок действия подписанной ссылки в Signature Version 4.
+const maxPresignTTL = 7 * 24 * time.Hour
+
+// unsignedPayload позволяет не хешировать тело запроса заранее и передавать его потоком.
+const unsignedPayload = "UNSIGNED-PAYLOAD"
+
+// S3Config описывает подключение к S3-совместимому хранилищу (AWS S3, MinIO, Yandex Object Storage и т.п.).
+type S3Config struct {
+	Endpoint  string // Адрес API, например https://s3.eu-central-1.amazonaws.com
+	Region    string // Регион для подписи запросов
+	Bucket    string // Имя бакета
+	AccessKey string
+	SecretKey string
+	// PathStyle — адресация endpoint/bucket/key вместо bucket.endpoint/key (нужно для MinIO).
+	PathStyle bool
+	// PublicURL — базовый URL для публичных ссылок (например, CDN). По умолчанию — адрес бакета.
+	PublicURL string
+}
+
+// S3Storage хранит объекты в S3-совместимом хранилище.
+// Запросы подписываются AWS Signature Version 4. Публичные объекты (см. IsPublic) загружаются
+// с ACL public-read, чтобы ссылки на них открывались без подписи; остальные — с ACL private.
+type S3Storage struct {
+	cfg       S3Config
+	bucketURL *url.URL
+	publicURL string
+	client    *http.Client
+}
+
+var (
+	_ Storage   = (*S3Storage)(nil)
+	_ Presigner = (*S3Storage)(nil)
+)
+
+// NewS3Storage создаёт S3-хранилище. Если client == nil, используется клиент с таймаутом 30 секунд.
+func NewS3Storage(cfg S3Config, client *http.Client) (*S3Storage, error) {
+	endpoint, err := url.Parse(strings.TrimRight(cfg.Endpoint, "/"))
+	if err != nil || endpoint.Scheme == "" || endpoint.Host == "" {
+		return nil, fmt.Errorf("invalid S3 endpoint %q", cfg.Endpoint)
+	}
+	if cfg.Bucket == "" || cfg.Region == "" || cfg.AccessKey == "" || cfg.SecretKey == "" {
+		return nil, fmt.Errorf("S3 bucket, region and credentials are required")
+	}
+
+	bucketURL := *endpoint
+	if cfg.PathStyle {
+		bucketURL.Path = endpoint.Path + "/" + cfg.Bucket
+	} else {
+		bucketURL.Host = cfg.Bucket + "." + endpoint.Host
+	}
+
+	publicURL := strings.TrimRight(cfg.PublicURL, "/")
+	if publicURL == "" {
+		publicURL = bucketURL.String()
+	}
+	if client == nil {
+		client = &http.Client{Timeout: 30 * time.Second}
+	}
+
+	return &S3Storage{cfg: cfg, bucketURL: &bucketURL, publicURL: publicURL, client: client}, nil
+}
+
+// Put загружает объект (PutObject).
+func (s *S3Storage) Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) (string, error) {
+	if !validKey(key) {
+		return "", ErrInvalidKey
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, s.objectURL(key), body)
+	if err != nil {
+		return "", err
+	}
+	req.ContentLength = size
+	req.Header.Set("Content-Type", contentType)
+	acl := "private"
+	if IsPublic(key) {
+		acl = "public-read"
+	}
+	req.Header.Set("X-Amz-Acl", acl)
+
+	if err := s.do(req, http.StatusOK); err != nil {
+		return "", fmt.Errorf("failed to upload object: %w", err)
+	}
+	return s.publicURL + "/" + key, nil
+}
+
+// Delete удаляет объект (DeleteObject). S3 отвечает 204 и для отсутствующих объектов.
+func (s *S3Storage) Delete(ctx context.Context, key string) error {
+	if !validKey(key) {
+		return ErrInvalidKey
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, s.objectURL(key), nil)
+	if err != nil {
+		return err
+	}
+	if err := s.do(req, http.StatusNoContent, http.StatusOK, http.StatusNotFound); err != nil {
+		return fmt.Errorf("failed to delete object: %w", err)
+	}
+	return nil
+}
+
+// KeyFromURL возвращает ключ объекта по его публичному URL.
+func (s *S3Storage) KeyFromURL(url string) (string, bool) {
+	return keyFromURL(s.publicURL, url)
+}
+
+// Ping проверяет доступность бакета (HeadBucket).
+func (s *S3Storage) Ping(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, s.bucketURL.String(), nil)
+	if err != nil {
+		return err
+	}
+	return s.do(req, http.StatusOK)
+}
+
+// PresignGet возвращает подписанную ссылку на GetObject (подпись в параметрах запроса).
+// Хранилище само обрабатывает Range-запросы по такой ссылке.
+func (s *S3Storage) PresignGet(key string, ttl time.Duration) (string, error) {
+	if !validKey(key) {
+		return "", ErrInvalidKey
+	}
+	if ttl <= 0 || ttl > maxPresignTTL {
+		return "", fmt.Errorf("presign ttl must be between 1s and %s", maxPresignTTL)
+	}
+	u, err := url.Parse(s.objectURL(key))
+	if err != nil {
+		return "", err
+	}
+
+	now := time.Now().UTC()
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+	scope := date + "/" + s.cfg.Region + "/s3/aws4_request"
+
+	query := url.Values{}
+	query.Set("X-Amz-Algorithm", "AWS4-HMAC-SHA256")
+	query.Set("X-Amz-Credential", s.cfg.AccessKey+"/"+scope)
+	query.Set("X-Amz-Date", amzDate)
+	query.Set("X-Amz-Expires", strconv.Itoa(int(ttl.Seconds())))
+	query.Set("X-Amz-SignedHeaders", "host")
+	// Encode сортирует параметры по имени, как требует канонический запрос.
+	canonicalQuery := strings.ReplaceAll(query.Encode(), "+", "%20")
+
+	canonicalRequest := strings.Join([]string{
+		http.MethodGet,
+		u.EscapedPath(),
+		canonicalQuery,
+		"host:" + u.Host + "\n",
+		"host",
+		unsignedPayload,
+	}, "\n")
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + hexSHA256(canonicalRequest)
+	signature := hex.EncodeToString(hmacSHA256(s.signingKey(date), stringToSign))
+
+	u.RawQuery = canonicalQuery + "&X-Amz-Signature=" + signature
+	return u.String(), nil
+}
+
+// objectURL возвращает адрес объекта в API хранилища.
+func (s *S3Storage) objectURL(key string) string {
+	segments := strings.Split(key, "/")
+	for i, seg := range segments {
+		segments[i] = url.PathEscape(seg)
+	}
+	return s.bucketURL.String() + "/" + strings.Join(segments, "/")
+}
+
+// do подписывает и выполняет запрос, ожидая один из статусов ok.
+func (s *S3Storage) do(req *http.Request, ok ...int) error {
+	defer servertiming.Track(req.Context(), servertiming.External, time.Now())
+
+	s.sign(req, time.Now().UTC())
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	for _, code := range ok {
+		if resp.StatusCode == code {
+			_, _ = io.Copy(io.Discard, resp.Body)
+			return nil
+		}
+	}
+	msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	return fmt.Errorf("unexpected status %d: %s", resp.StatusCode, strings.TrimSpace(string(msg)))
+}
+
+// sign добавляет к запросу подпись AWS Signature Version 4.
+func (s *S3Storage) sign(req *http.Request, now time.Time) {
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", unsignedPayload)
+
+	// Подписываются host и все x-amz-* заголовки.
+	headers := map[string]string{"host": req.URL.Host}
+	names := []string{"host"}
+	for name, values := range req.Header {
+		lower := strings.ToLower(name)
+		if strings.HasPrefix(lower, "x-amz-") {
+			headers[lower] = strings.TrimSpace(strings.Join(values, ","))
+			names = append(names, lower)
+		}
+	}
+	sort.Strings(names)
+
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		req.URL.RawQuery,
+		canonicalHeaders.String(),
+		signedHeaders,
+		unsignedPayload,
+	}, "\n")
+
+	scope := date + "/" + s.cfg.Region + "/s3/aws4_request"
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + hexSHA256(canonicalRequest)
+
+	signature := hex.EncodeToString(hmacSHA256(s.signingKey(date), stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s.cfg.AccessKey, scope, signedHeaders, signature))
+}
+
+// signingKey выводит ключ подписи для даты date (формат 20060102).
+func (s *S3Storage) signingKey(date string) []byte {
+	key := hmacSHA256([]byte("AWS4"+s.cfg.SecretKey), date)
+	key = hmacSHA256(key, s.cfg.Region)
+	key = hmacSHA256(key, "s3")
+	return hmacSHA256(key, "aws4_request")
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+func hexSHA256(data string) string {
+	sum := sha256.Sum256([]byte(data))
+	return hex.EncodeToString(sum[:])
+}
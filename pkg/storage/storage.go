@@ -0,0 +1,88 @@
+// Package storage описывает хранилище пользовательских файлов (аватары и т.п.)
+// с реализациями на локальном диске и в S3-совместимом объектном хранилище.
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"time"
+)
+
+// ErrInvalidKey возвращается для ключей, выходящих за пределы хранилища ("..", абсолютные пути).
+var ErrInvalidKey = errors.New("invalid storage key")
+
+// ErrNotFound возвращается, если объекта с указанным ключом нет в хранилище.
+var ErrNotFound = errors.New("storage object not found")
+
+// PublicPrefix — префикс ключей публичных объектов (аватаров): только они открываются по URL без подписи.
+// Остальные объекты (выгрузки данных, резервные копии, видео) приватны и отдаются
+// через PresignGet или самим сервером по подписанной ссылке.
+const PublicPrefix = "avatars/"
+
+// IsPublic сообщает, доступен ли объект с ключом key по публичному URL.
+func IsPublic(key string) bool {
+	return strings.HasPrefix(key, PublicPrefix)
+}
+
+// Storage описывает хранилище объектов.
+type Storage interface {
+	// Put сохраняет объект под ключом key и возвращает его URL.
+	// Без подписи URL открывается только для публичных ключей (см. IsPublic).
+	Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) (string, error)
+
+	// Delete удаляет объект по ключу. Отсутствие объекта ошибкой не считается.
+	Delete(ctx context.Context, key string) error
+
+	// KeyFromURL возвращает ключ объекта по его публичному URL,
+	// если URL указывает на это хранилище.
+	KeyFromURL(url string) (string, bool)
+
+	// Ping проверяет доступность хранилища (для страницы статуса).
+	Ping(ctx context.Context) error
+}
+
+// Object — открытый для чтения объект хранилища. Поддерживает Seek,
+// поэтому может отдаваться через http.ServeContent с Range-запросами.
+type Object struct {
+	io.ReadSeekCloser
+	Size    int64
+	ModTime time.Time
+}
+
+// Opener реализуется хранилищами, объекты которых сервер отдаёт сам (локальный диск).
+type Opener interface {
+	// Open открывает объект для чтения. Для отсутствующего объекта возвращает ErrNotFound.
+	Open(ctx context.Context, key string) (*Object, error)
+}
+
+// Presigner реализуется хранилищами, которые умеют выдавать временные подписанные
+// ссылки на объекты, так что клиент скачивает их напрямую из хранилища.
+type Presigner interface {
+	// PresignGet возвращает ссылку на чтение объекта, действующую ttl.
+	PresignGet(key string, ttl time.Duration) (string, error)
+}
+
+// validKey проверяет, что ключ — относительный путь без выхода за пределы хранилища.
+func validKey(key string) bool {
+	if key == "" || strings.HasPrefix(key, "/") || strings.Contains(key, "\\") {
+		return false
+	}
+	for _, part := range strings.Split(key, "/") {
+		if part == "" || part == "." || part == ".." {
+			return false
+		}
+	}
+	return true
+}
+
+// keyFromURL отрезает от URL публичный префикс хранилища.
+func keyFromURL(baseURL, url string) (string, bool) {
+	prefix := strings.TrimRight(baseURL, "/") + "/"
+	if !strings.HasPrefix(url, prefix) {
+		return "", false
+	}
+	key := strings.TrimPrefix(url, prefix)
+	return key, validKey(key)
+}
@@ -0,0 +1,135 @@
+// Package tracing реализует контекст трассировки в формате W3C Trace Context
+// (https://www.w3.org/TR/trace-context/): заголовок traceparent, span'ы и их связь между процессами.
+// Span'ы не экспортируются во внешнюю систему, а пишутся в структурированный лог.
+package tracing
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"strings"
+	"time"
+)
+
+// HeaderName — имя HTTP-заголовка с контекстом трассировки.
+const HeaderName = "traceparent"
+
+// SpanContext идентифицирует span внутри трассы.
+type SpanContext struct {
+	TraceID string // 32 hex-символа
+	SpanID  string // 16 hex-символов
+}
+
+// IsValid возвращает true, если идентификаторы заданы.
+func (sc SpanContext) IsValid() bool {
+	return sc.TraceID != "" && sc.SpanID != ""
+}
+
+// String возвращает значение заголовка traceparent; для пустого контекста — пустую строку.
+func (sc SpanContext) String() string {
+	if !sc.IsValid() {
+		return ""
+	}
+	return "00-" + sc.TraceID + "-" + sc.SpanID + "-01"
+}
+
+// Parse разбирает значение заголовка traceparent версии 00.
+// Возвращает false для некорректного значения и нулевых идентификаторов.
+func Parse(raw string) (SpanContext, bool) {
+	parts := strings.Split(strings.TrimSpace(raw), "-")
+	if len(parts) != 4 || parts[0] != "00" || len(parts[3]) != 2 || !isHex(parts[3]) {
+		return SpanContext{}, false
+	}
+	traceID, spanID := parts[1], parts[2]
+	if len(traceID) != 32 || len(spanID) != 16 || !isHex(traceID) || !isHex(spanID) ||
+		isZero(traceID) || isZero(spanID) {
+		return SpanContext{}, false
+	}
+	return SpanContext{TraceID: traceID, SpanID: spanID}, true
+}
+
+// Span описывает одну операцию трассы.
+type Span struct {
+	Name    string
+	Context SpanContext
+	Parent  SpanContext // Пусто — корневой span
+	Start   time.Time
+}
+
+// Start начинает span с именем name и возвращает контекст, в котором он текущий.
+// Родителем становится parent, если он задан (например, сохранённый вместе с фоновой задачей
+// контекст исходного запроса), иначе текущий span из ctx; без них начинается новая трасса.
+func Start(ctx context.Context, name string, parent SpanContext) (context.Context, *Span) {
+	if !parent.IsValid() {
+		parent = FromContext(ctx)
+	}
+	sc := SpanContext{TraceID: parent.TraceID, SpanID: randomHex(8)}
+	if sc.TraceID == "" {
+		sc.TraceID = randomHex(16)
+	}
+	span := &Span{Name: name, Context: sc, Parent: parent, Start: time.Now()}
+	return NewContext(ctx, sc), span
+}
+
+// Fields возвращает поля записи лога о завершённом span'е.
+func (s *Span) Fields(err error) map[string]any {
+	fields := map[string]any{
+		"span":        s.Name,
+		"trace_id":    s.Context.TraceID,
+		"span_id":     s.Context.SpanID,
+		"duration_ms": time.Since(s.Start).Milliseconds(),
+	}
+	if s.Parent.IsValid() {
+		fields["parent_span_id"] = s.Parent.SpanID
+	}
+	if err != nil {
+		fields["error"] = err.Error()
+	}
+	return fields
+}
+
+type contextKey struct{}
+
+// NewContext возвращает контекст с текущим span'ом sc.
+func NewContext(ctx context.Context, sc SpanContext) context.Context {
+	return context.WithValue(ctx, contextKey{}, sc)
+}
+
+// FromContext возвращает текущий span или пустой SpanContext, если его нет.
+func FromContext(ctx context.Context) SpanContext {
+	if ctx == nil {
+		return SpanContext{}
+	}
+	sc, _ := ctx.Value(contextKey{}).(SpanContext)
+	return sc
+}
+
+// Header возвращает значение traceparent текущего span'а, чтобы сохранить его вместе с фоновой задачей.
+func Header(ctx context.Context) string {
+	return FromContext(ctx).String()
+}
+
+func randomHex(n int) string {
+	b := make([]byte, n)
+	for {
+		if _, err := rand.Read(b); err != nil {
+			panic("tracing: failed to read random bytes: " + err.Error())
+		}
+		if s := hex.EncodeToString(b); !isZero(s) {
+			return s
+		}
+	}
+}
+
+func isHex(s string) bool {
+	for _, r := range s {
+		if (r < '0' || r > '9') && (r < 'a' || r > 'f') {
+			return false
+		}
+	}
+	return true
+}
+
+func isZero(s string) bool {
+	return strings.Trim(s, "0") == ""
+}
@@ -0,0 +1,201 @@
+package usernamefilter
+
+import (
+	"bufio"
+	_ "embed"
+	"errors"
+	"sort"
+	"strings"
+)
+
+// Category — причина, по которой username запрещён.
+type Category string
+
+const (
+	CategoryReserved  Category = "reserved"  // Служебное имя (admin, support, system и т.п.)
+	CategoryProfanity Category = "profanity" // Ненормативная лексика
+)
+
+// IsValid возвращает true для известных категорий.
+func (c Category) IsValid() bool {
+	return c == CategoryReserved || c == CategoryProfanity
+}
+
+// Mode — способ сопоставления username с запрещённым словом.
+type Mode string
+
+const (
+	ModeExact    Mode = "exact"    // Нормализованный username совпадает со словом целиком
+	ModeContains Mode = "contains" // Нормализованный username содержит слово
+)
+
+// IsValid возвращает true для известных способов сопоставления.
+func (m Mode) IsValid() bool {
+	return m == ModeExact || m == ModeContains
+}
+
+// Rule — запрещённое слово и способ его сопоставления.
+type Rule struct {
+	Term     string
+	Mode     Mode
+	Category Category
+}
+
+// ErrNotAllowed — username запрещён; подробности — в *Error.
+var ErrNotAllowed = errors.New("username is not allowed")
+
+// ErrUnknownLocale — для локали нет встроенного списка ненормативной лексики.
+var ErrUnknownLocale = errors.New("unknown profanity locale")
+
+// Error сообщает категорию и слово, из-за которых username запрещён.
+// Слово предназначено для журналов и администраторов; пользователю достаточно категории.
+type Error struct {
+	Category Category
+	Term     string
+}
+
+func (e *Error) Error() string {
+	return ErrNotAllowed.Error() + ": " + string(e.Category)
+}
+
+func (e *Error) Unwrap() error {
+	return ErrNotAllowed
+}
+
+//go:embed reserved.txt
+var reservedNames string
+
+//go:embed profanity_en.txt
+var profanityEN string
+
+//go:embed profanity_ru.txt
+var profanityRU string
+
+// profanityLists — встроенные списки ненормативной лексики по локалям.
+var profanityLists = map[string]string{
+	"en": profanityEN,
+	"ru": profanityRU,
+}
+
+// Reserved возвращает встроенный список служебных имён.
+func Reserved() []Rule {
+	return ParseRules(reservedNames, CategoryReserved, ModeExact)
+}
+
+// Profanity возвращает встроенный список ненормативной лексики для локали.
+func Profanity(locale string) ([]Rule, error) {
+	list, ok := profanityLists[strings.ToLower(strings.TrimSpace(locale))]
+	if !ok {
+		return nil, ErrUnknownLocale
+	}
+	return ParseRules(list, CategoryProfanity, ModeContains), nil
+}
+
+// Locales возвращает локали, для которых есть встроенные списки ненормативной лексики.
+func Locales() []string {
+	locales := make([]string, 0, len(profanityLists))
+	for locale := range profanityLists {
+		locales = append(locales, locale)
+	}
+	sort.Strings(locales)
+	return locales
+}
+
+// ParseRules разбирает список слов по одному в строке; пустые строки и строки с # пропускаются.
+// Слово с префиксом = сопоставляется целиком, остальные — способом mode.
+func ParseRules(list string, category Category, mode Mode) []Rule {
+	var rules []Rule
+	scanner := bufio.NewScanner(strings.NewReader(list))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		rule := Rule{Term: line, Mode: mode, Category: category}
+		if term, exact := strings.CutPrefix(line, "="); exact {
+			rule.Term = term
+			rule.Mode = ModeExact
+		}
+		rules = append(rules, rule)
+	}
+	return rules
+}
+
+// leet заменяет цифры, которыми часто маскируют буквы.
+var leet = strings.NewReplacer("0", "o", "1", "i", "3", "e", "4", "a", "5", "s", "7", "t", "8", "b")
+
+// Normalize приводит username или запрещённое слово к форме для сравнения:
+// нижний регистр, без символов кроме латиницы и цифр, без цифрового суффикса (admin2 → admin),
+// с заменой цифр-подстановок на буквы (adm1n → admin) и схлопнутыми повторами букв (fuuuck → fuck).
+func Normalize(s string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(s) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			b.WriteRune(r)
+		}
+	}
+	folded := leet.Replace(strings.TrimRight(b.String(), "0123456789"))
+
+	b.Reset()
+	var prev rune
+	for _, r := range folded {
+		if r != prev {
+			b.WriteRune(r)
+		}
+		prev = r
+	}
+	return b.String()
+}
+
+// Matcher проверяет username по набору правил. Нулевое значение ничего не запрещает.
+type Matcher struct {
+	exact    map[string]Rule
+	contains []Rule
+}
+
+// NewMatcher собирает Matcher из правил; слова нормализуются, пустые и повторные отбрасываются.
+func NewMatcher(rules ...[]Rule) *Matcher {
+	m := &Matcher{exact: make(map[string]Rule)}
+	seen := make(map[string]bool)
+	for _, list := range rules {
+		for _, rule := range list {
+			term := Normalize(rule.Term)
+			if term == "" {
+				continue
+			}
+			rule.Term = term
+			switch rule.Mode {
+			case ModeExact:
+				if _, ok := m.exact[term]; !ok {
+					m.exact[term] = rule
+				}
+			case ModeContains:
+				if !seen[term] {
+					seen[term] = true
+					m.contains = append(m.contains, rule)
+				}
+			}
+		}
+	}
+	return m
+}
+
+// Check возвращает *Error, если username запрещён хотя бы одним правилом, иначе nil.
+func (m *Matcher) Check(username string) error {
+	if m == nil {
+		return nil
+	}
+	normalized := Normalize(username)
+	if normalized == "" {
+		return nil
+	}
+	if rule, ok := m.exact[normalized]; ok {
+		return &Error{Category: rule.Category, Term: rule.Term}
+	}
+	for _, rule := range m.contains {
+		if strings.Contains(normalized, rule.Term) {
+			return &Error{Category: rule.Category, Term: rule.Term}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,516 @@
+package anonymization_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	backupdomain "workout-app/internal/domain/backup"
+	orgdomain "workout-app/internal/domain/organization"
+	programdomain "workout-app/internal/domain/program"
+	domain "workout-app/internal/domain/user"
+	repo "workout-app/internal/repository/interfaces"
+	anonymizationuc "workout-app/internal/usecase/anonymization"
+	"workout-app/pkg/logger"
+	"workout-app/pkg/storage"
+)
+
+// fakeTx выполняет fn без настоящей транзакции; при ошибке откатывает пользователя к снимку.
+type fakeTx struct {
+	users *fakeUsers
+}
+
+func (t *fakeTx) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	snapshot := *t.users.user
+	if err := fn(ctx); err != nil {
+		t.users.user = &snapshot
+		return err
+	}
+	return nil
+}
+
+type fakeUsers struct {
+	repo.UserRepository
+	user    *domain.User
+	deleted bool
+}
+
+func (r *fakeUsers) GetByIDIncludingDeleted(_ context.Context, id uuid.UUID) (*domain.User, error) {
+	if r.user.ID != id {
+		return nil, repo.ErrNotFound
+	}
+	u := *r.user
+	return &u, nil
+}
+
+func (r *fakeUsers) Anonymize(_ context.Context, u *domain.User) error {
+	if r.user.IsAnonymized() {
+		return repo.ErrNotFound
+	}
+	r.user = u
+	return nil
+}
+
+func (r *fakeUsers) Delete(context.Context, uuid.UUID) error {
+	if r.user.IsOnLegalHold() {
+		return repo.ErrNotFound
+	}
+	r.deleted = true
+	return nil
+}
+
+type fakeUsernameHistory struct {
+	repo.UsernameHistoryRepository
+	deleted bool
+}
+
+func (r *fakeUsernameHistory) DeleteByUserID(context.Context, uuid.UUID) error {
+	r.deleted = true
+	return nil
+}
+
+type fakeProfileHistory struct {
+	repo.ProfileHistoryRepository
+	deleted bool
+}
+
+func (r *fakeProfileHistory) DeleteByUserID(context.Context, uuid.UUID) error {
+	r.deleted = true
+	return nil
+}
+
+type fakeVerifications struct {
+	repo.EmailVerificationRepository
+	deleted bool
+}
+
+func (r *fakeVerifications) DeleteByUserID(context.Context, uuid.UUID) error {
+	r.deleted = true
+	return nil
+}
+
+type fakeMetrics struct {
+	repo.BodyMetricRepository
+	err error
+}
+
+func (r *fakeMetrics) DeleteByUserID(context.Context, uuid.UUID) error { return r.err }
+
+type fakeConsents struct {
+	repo.ConsentRepository
+}
+
+func (r *fakeConsents) DeleteByUserID(context.Context, uuid.UUID) error { return nil }
+
+type fakePrograms struct {
+	repo.ProgramRepository
+	deleted bool
+	owned   []*programdomain.Program
+}
+
+func (r *fakePrograms) DeleteAssignmentsByUserID(context.Context, uuid.UUID) error {
+	r.deleted = true
+	return nil
+}
+
+func (r *fakePrograms) ListByOwner(context.Context, uuid.UUID) ([]*programdomain.Program, error) {
+	return r.owned, nil
+}
+
+type fakeOrganizations struct {
+	repo.OrganizationRepository
+	members []*orgdomain.Member
+}
+
+func (r *fakeOrganizations) ListByUser(context.Context, uuid.UUID) ([]*orgdomain.Organization, []*orgdomain.Member, error) {
+	return nil, r.members, nil
+}
+
+type fakeWorkouts struct {
+	repo.WorkoutSessionRepository
+	deleted bool
+}
+
+func (r *fakeWorkouts) DeleteByUserID(context.Context, uuid.UUID) error {
+	r.deleted = true
+	return nil
+}
+
+type fakeCheckIns struct {
+	repo.CheckInRepository
+	deleted bool
+}
+
+func (r *fakeCheckIns) DeleteByUserID(context.Context, uuid.UUID) error {
+	r.deleted = true
+	return nil
+}
+
+type fakeCustomMetrics struct {
+	repo.CustomMetricRepository
+	deleted bool
+}
+
+func (r *fakeCustomMetrics) DeleteByUserID(context.Context, uuid.UUID) error {
+	r.deleted = true
+	return nil
+}
+
+type fakeOAuthAccounts struct {
+	repo.OAuthAccountRepository
+	deleted bool
+}
+
+func (r *fakeOAuthAccounts) DeleteByUserID(context.Context, uuid.UUID) error {
+	r.deleted = true
+	return nil
+}
+
+type fakeTrainingMaxes struct {
+	repo.TrainingMaxRepository
+	deleted bool
+}
+
+func (r *fakeTrainingMaxes) DeleteByUserID(context.Context, uuid.UUID) error {
+	r.deleted = true
+	return nil
+}
+
+type fakeGymClasses struct {
+	repo.GymClassRepository
+	deleted bool
+}
+
+func (r *fakeGymClasses) DeleteBookingsByUserID(context.Context, uuid.UUID) error {
+	r.deleted = true
+	return nil
+}
+
+type fakeGymCheckIns struct {
+	repo.GymCheckInRepository
+	deleted bool
+}
+
+func (r *fakeGymCheckIns) DeleteVisitsByUserID(context.Context, uuid.UUID) error {
+	r.deleted = true
+	return nil
+}
+
+type fakeCoachProfiles struct {
+	repo.CoachProfileRepository
+	deleted bool
+}
+
+func (r *fakeCoachProfiles) DeleteByUserID(context.Context, uuid.UUID) error {
+	r.deleted = true
+	return nil
+}
+
+type fakeFollows struct {
+	repo.FollowRepository
+	deleted bool
+}
+
+func (r *fakeFollows) DeleteByUserID(context.Context, uuid.UUID) error {
+	r.deleted = true
+	return nil
+}
+
+type fakeCoachNotes struct {
+	repo.CoachNoteRepository
+	deleted bool
+}
+
+func (r *fakeCoachNotes) DeleteByUserID(context.Context, uuid.UUID) error {
+	r.deleted = true
+	return nil
+}
+
+type fakeCoachClients struct {
+	repo.CoachClientRepository
+	deleted bool
+}
+
+func (r *fakeCoachClients) DeleteByUserID(context.Context, uuid.UUID) error {
+	r.deleted = true
+	return nil
+}
+
+type fakeNotifications struct {
+	repo.NotificationRepository
+	deleted bool
+}
+
+func (r *fakeNotifications) DeleteByUserID(context.Context, uuid.UUID) error {
+	r.deleted = true
+	return nil
+}
+
+type fakeDevices struct {
+	repo.DeviceRepository
+	deleted bool
+}
+
+func (r *fakeDevices) DeleteByUserID(context.Context, uuid.UUID) error {
+	r.deleted = true
+	return nil
+}
+
+type fakeDrafts struct {
+	repo.DraftRepository
+	deleted bool
+}
+
+func (r *fakeDrafts) DeleteByUserID(context.Context, uuid.UUID) error {
+	r.deleted = true
+	return nil
+}
+
+type fakeExports struct {
+	repo.DataExportRepository
+	expired bool
+}
+
+func (r *fakeExports) ExpireByUserID(context.Context, uuid.UUID, time.Time) error {
+	r.expired = true
+	return nil
+}
+
+type fakeImports struct {
+	repo.DataImportRepository
+	deleted bool
+}
+
+func (r *fakeImports) DeleteByUserID(context.Context, uuid.UUID) error {
+	r.deleted = true
+	return nil
+}
+
+type fakeBackups struct {
+	repo.BackupRepository
+	items   []*backupdomain.Backup
+	deleted bool
+}
+
+func (r *fakeBackups) ListByUser(context.Context, uuid.UUID) ([]*backupdomain.Backup, error) {
+	return r.items, nil
+}
+
+func (r *fakeBackups) DeleteByUserID(context.Context, uuid.UUID) error {
+	r.deleted = true
+	return nil
+}
+
+type fakeChallenges struct {
+	repo.ChallengeRepository
+	deleted bool
+}
+
+func (r *fakeChallenges) DeleteParticipantsByUserID(context.Context, uuid.UUID) error {
+	r.deleted = true
+	return nil
+}
+
+type fakeNutrition struct {
+	repo.NutritionRepository
+	deleted bool
+}
+
+func (r *fakeNutrition) DeleteByUserID(context.Context, uuid.UUID) error {
+	r.deleted = true
+	return nil
+}
+
+type fakeHabits struct {
+	repo.HabitRepository
+	deleted bool
+}
+
+func (r *fakeHabits) DeleteByUserID(context.Context, uuid.UUID) error {
+	r.deleted = true
+	return nil
+}
+
+type fakeHealthSync struct {
+	repo.HealthSyncRepository
+	deleted bool
+}
+
+func (r *fakeHealthSync) DeleteByUserID(context.Context, uuid.UUID) error {
+	r.deleted = true
+	return nil
+}
+
+func newUser() *domain.User {
+	u := domain.NewUser("user@example.com", "hash", "user1")
+	u.FirstName = "Иван"
+	u.LastName = "Иванов"
+	birth := time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC)
+	u.BirthDate = &birth
+	return u
+}
+
+func TestAnonymize_ScrubsPersonalDataAndKeepsRecord(t *testing.T) {
+	dir := t.TempDir()
+	store := storage.NewLocalStorage(dir, "/uploads")
+	ctx := context.Background()
+
+	user := newUser()
+	url, err := store.Put(ctx, "avatars/a.png", bytes.NewReader([]byte("png")), 3, "image/png")
+	require.NoError(t, err)
+	user.AvatarURL = url
+	_, err = store.Put(ctx, "backups/b.bin", bytes.NewReader([]byte("enc")), 3, "application/octet-stream")
+	require.NoError(t, err)
+
+	users := &fakeUsers{user: user}
+	usernames := &fakeUsernameHistory{}
+	profileHistory := &fakeProfileHistory{}
+	verifications := &fakeVerifications{}
+	programs := &fakePrograms{}
+	workouts := &fakeWorkouts{}
+	checkIns := &fakeCheckIns{}
+	customMetrics := &fakeCustomMetrics{}
+	oauthAccounts := &fakeOAuthAccounts{}
+	trainingMaxes := &fakeTrainingMaxes{}
+	gymClasses := &fakeGymClasses{}
+	gymCheckIns := &fakeGymCheckIns{}
+	coachProfiles := &fakeCoachProfiles{}
+	follows := &fakeFollows{}
+	coachNotes := &fakeCoachNotes{}
+	coachClients := &fakeCoachClients{}
+	notifications := &fakeNotifications{}
+	devices := &fakeDevices{}
+	drafts := &fakeDrafts{}
+	exports := &fakeExports{}
+	imports := &fakeImports{}
+	backups := &fakeBackups{items: []*backupdomain.Backup{{ID: uuid.New(), UserID: user.ID, Version: 1, StorageKey: "backups/b.bin"}}}
+	challenges := &fakeChallenges{}
+	nutrition := &fakeNutrition{}
+	habits := &fakeHabits{}
+	healthSync := &fakeHealthSync{}
+	svc := anonymizationuc.NewService(&fakeTx{users: users}, users, usernames, profileHistory, verifications, &fakeMetrics{}, &fakeConsents{}, programs, &fakeOrganizations{}, workouts, checkIns, customMetrics, oauthAccounts, trainingMaxes, gymClasses, gymCheckIns, coachProfiles, follows, coachNotes, coachClients, notifications, devices, drafts, exports, imports, backups, challenges, nutrition, habits, healthSync, store, logger.Default())
+
+	require.NoError(t, svc.Anonymize(ctx, user.ID))
+
+	got := users.user
+	require.Equal(t, user.ID, got.ID)
+	require.Equal(t, domain.DeletedUsername, got.Username)
+	require.True(t, strings.HasSuffix(got.Email, "@anonymized.invalid"))
+	require.Empty(t, got.PasswordHash)
+	require.Empty(t, got.FirstName)
+	require.Empty(t, got.LastName)
+	require.Nil(t, got.BirthDate)
+	require.Empty(t, got.AvatarURL)
+	require.True(t, got.IsDeleted())
+	require.True(t, got.IsAnonymized())
+	require.True(t, usernames.deleted)
+	require.True(t, profileHistory.deleted)
+	require.True(t, verifications.deleted)
+	require.True(t, programs.deleted)
+	require.True(t, workouts.deleted)
+	require.True(t, checkIns.deleted)
+	require.True(t, customMetrics.deleted)
+	require.True(t, oauthAccounts.deleted)
+	require.True(t, trainingMaxes.deleted)
+	require.True(t, gymClasses.deleted)
+	require.True(t, gymCheckIns.deleted)
+	require.True(t, coachProfiles.deleted)
+	require.True(t, follows.deleted)
+	require.True(t, coachNotes.deleted)
+	require.True(t, coachClients.deleted)
+	require.True(t, notifications.deleted)
+	require.True(t, devices.deleted)
+	require.True(t, drafts.deleted)
+	require.True(t, exports.expired)
+	require.True(t, imports.deleted)
+	require.True(t, backups.deleted)
+	require.True(t, challenges.deleted)
+	require.True(t, nutrition.deleted)
+	require.True(t, habits.deleted)
+	require.True(t, healthSync.deleted)
+
+	_, err = os.Stat(filepath.Join(dir, "avatars", "a.png"))
+	require.True(t, os.IsNotExist(err), "файл аватара должен быть удалён")
+	_, err = os.Stat(filepath.Join(dir, "backups", "b.bin"))
+	require.True(t, os.IsNotExist(err), "файл резервной копии должен быть удалён")
+
+	err = svc.Anonymize(ctx, user.ID)
+	require.ErrorIs(t, err, anonymizationuc.ErrAlreadyAnonymized)
+}
+
+func TestAnonymize_RollsBackOnFailure(t *testing.T) {
+	user := newUser()
+	users := &fakeUsers{user: user}
+	metrics := &fakeMetrics{err: errors.New("db down")}
+	svc := anonymizationuc.NewService(&fakeTx{users: users}, users, &fakeUsernameHistory{}, &fakeProfileHistory{}, &fakeVerifications{}, metrics, &fakeConsents{}, &fakePrograms{}, &fakeOrganizations{}, &fakeWorkouts{}, &fakeCheckIns{}, &fakeCustomMetrics{}, &fakeOAuthAccounts{}, &fakeTrainingMaxes{}, &fakeGymClasses{}, &fakeGymCheckIns{}, &fakeCoachProfiles{}, &fakeFollows{}, &fakeCoachNotes{}, &fakeCoachClients{}, &fakeNotifications{}, &fakeDevices{}, &fakeDrafts{}, &fakeExports{}, &fakeImports{}, &fakeBackups{}, &fakeChallenges{}, &fakeNutrition{}, &fakeHabits{}, &fakeHealthSync{},
+		storage.NewLocalStorage(t.TempDir(), "/uploads"), logger.Default())
+
+	require.Error(t, svc.Anonymize(context.Background(), user.ID))
+	require.Equal(t, "user1", users.user.Username)
+	require.False(t, users.user.IsAnonymized())
+}
+
+func TestAnonymize_BlockedByLegalHold(t *testing.T) {
+	user := newUser()
+	user.LegalHold = &domain.LegalHold{At: time.Now(), By: uuid.New(), Reason: "dispute #42"}
+	users := &fakeUsers{user: user}
+	svc := anonymizationuc.NewService(&fakeTx{users: users}, users, &fakeUsernameHistory{}, &fakeProfileHistory{}, &fakeVerifications{}, &fakeMetrics{}, &fakeConsents{}, &fakePrograms{}, &fakeOrganizations{}, &fakeWorkouts{}, &fakeCheckIns{}, &fakeCustomMetrics{}, &fakeOAuthAccounts{}, &fakeTrainingMaxes{}, &fakeGymClasses{}, &fakeGymCheckIns{}, &fakeCoachProfiles{}, &fakeFollows{}, &fakeCoachNotes{}, &fakeCoachClients{}, &fakeNotifications{}, &fakeDevices{}, &fakeDrafts{}, &fakeExports{}, &fakeImports{}, &fakeBackups{}, &fakeChallenges{}, &fakeNutrition{}, &fakeHabits{}, &fakeHealthSync{},
+		storage.NewLocalStorage(t.TempDir(), "/uploads"), logger.Default())
+
+	err := svc.Anonymize(context.Background(), user.ID)
+	require.ErrorIs(t, err, anonymizationuc.ErrUnderLegalHold)
+	require.False(t, users.user.IsAnonymized())
+}
+
+// newDeleteService собирает сервис для тестов окончательного удаления записи.
+func newDeleteService(t *testing.T, users *fakeUsers, programs *fakePrograms, orgs *fakeOrganizations) anonymizationuc.Service {
+	t.Helper()
+	return anonymizationuc.NewService(&fakeTx{users: users}, users, &fakeUsernameHistory{}, &fakeProfileHistory{}, &fakeVerifications{}, &fakeMetrics{}, &fakeConsents{}, programs, orgs, &fakeWorkouts{}, &fakeCheckIns{}, &fakeCustomMetrics{}, &fakeOAuthAccounts{}, &fakeTrainingMaxes{}, &fakeGymClasses{}, &fakeGymCheckIns{}, &fakeCoachProfiles{}, &fakeFollows{}, &fakeCoachNotes{}, &fakeCoachClients{}, &fakeNotifications{}, &fakeDevices{}, &fakeDrafts{}, &fakeExports{}, &fakeImports{}, &fakeBackups{}, &fakeChallenges{}, &fakeNutrition{}, &fakeHabits{}, &fakeHealthSync{},
+		storage.NewLocalStorage(t.TempDir(), "/uploads"), logger.Default())
+}
+
+func TestDelete_AnonymizesAndDeletesRecord(t *testing.T) {
+	user := newUser()
+	users := &fakeUsers{user: user}
+	svc := newDeleteService(t, users, &fakePrograms{}, &fakeOrganizations{})
+
+	require.NoError(t, svc.Delete(context.Background(), user.ID))
+	require.True(t, users.deleted)
+	require.True(t, users.user.IsAnonymized())
+}
+
+func TestDelete_AlreadyAnonymizedUser(t *testing.T) {
+	user := newUser()
+	user.Anonymize(time.Now().UTC())
+	users := &fakeUsers{user: user}
+	svc := newDeleteService(t, users, &fakePrograms{}, &fakeOrganizations{})
+
+	require.NoError(t, svc.Delete(context.Background(), user.ID))
+	require.True(t, users.deleted)
+}
+
+func TestDelete_RejectsSharedContentAndAdmins(t *testing.T) {
+	ctx := context.Background()
+
+	user := newUser()
+	users := &fakeUsers{user: user}
+	programs := &fakePrograms{owned: []*programdomain.Program{{ID: uuid.New(), OwnerID: user.ID}}}
+	require.ErrorIs(t, newDeleteService(t, users, programs, &fakeOrganizations{}).Delete(ctx, user.ID), anonymizationuc.ErrOwnsSharedContent)
+
+	orgs := &fakeOrganizations{members: []*orgdomain.Member{{UserID: user.ID, Role: orgdomain.RoleOwner}}}
+	require.ErrorIs(t, newDeleteService(t, users, &fakePrograms{}, orgs).Delete(ctx, user.ID), anonymizationuc.ErrOwnsSharedContent)
+
+	users.user.Role = domain.RoleAdmin
+	require.ErrorIs(t, newDeleteService(t, users, &fakePrograms{}, &fakeOrganizations{}).Delete(ctx, user.ID), anonymizationuc.ErrCannotDeleteAdmin)
+
+	require.False(t, users.deleted)
+	require.False(t, users.user.IsAnonymized())
+}
@@ -0,0 +1,45 @@
+package auth_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	domain "workout-app/internal/domain/user"
+	"workout-app/internal/events"
+	authuc "workout-app/internal/usecase/auth"
+	"workout-app/pkg/password"
+)
+
+func TestIsEmailAvailable_DoesNotDistinguishVerification(t *testing.T) {
+	verified := domain.NewUser("verified@example.com", "hash", "verified1")
+	verified.IsEmailVerified = true
+	unverified := domain.NewUser("pending@example.com", "hash", "pending1")
+	userRepo := &fakeUserRepo{usersByEmail: map[string]*domain.User{
+		verified.Email:   verified,
+		unverified.Email: unverified,
+	}}
+	svc := authuc.NewService(fakeTx{}, userRepo, &fakeUsernameHistory{}, fakeUsernameFilter{}, &fakeEmailVerifRepo{}, &fakeJWT{}, &fakeEmailSender{}, events.NopPublisher{}, 15*time.Minute, 5, 6, nil, password.Policy{MinLength: 8})
+	ctx := context.Background()
+
+	for _, email := range []string{verified.Email, unverified.Email} {
+		available, err := svc.IsEmailAvailable(ctx, email)
+		require.NoError(t, err)
+		require.False(t, available, email)
+	}
+
+	// Адрес сравнивается без учёта регистра и пробелов по краям.
+	available, err := svc.IsEmailAvailable(ctx, " Verified@Example.COM ")
+	require.NoError(t, err)
+	require.False(t, available)
+
+	available, err = svc.IsEmailAvailable(ctx, "new@example.com")
+	require.NoError(t, err)
+	require.True(t, available)
+
+	available, err = svc.IsUsernameAvailable(ctx, "newuser")
+	require.NoError(t, err)
+	require.True(t, available)
+}
@@ -0,0 +1,86 @@
+package auth_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	domain "workout-app/internal/domain/user"
+	"workout-app/internal/events"
+	authuc "workout-app/internal/usecase/auth"
+	"workout-app/pkg/password"
+)
+
+func newChangePasswordFixture(t *testing.T) (authuc.Service, *domain.User) {
+	t.Helper()
+	hash, err := password.Hash("oldPassword1")
+	require.NoError(t, err)
+
+	user := domain.NewUser("user@example.com", hash, "user1")
+	user.IsEmailVerified = true
+	userRepo := &fakeUserRepo{usersByEmail: map[string]*domain.User{user.Email: user}}
+
+	svc := authuc.NewService(fakeTx{}, userRepo, &fakeUsernameHistory{}, fakeUsernameFilter{}, &fakeEmailVerifRepo{}, &fakeJWT{}, &fakeEmailSender{}, events.NopPublisher{}, 15*time.Minute, 5, 6, nil, password.Policy{MinLength: 8})
+	return svc, user
+}
+
+func TestChangePassword_ConfirmationRequiredForConfiguredRole(t *testing.T) {
+	hash, err := password.Hash("oldPassword1")
+	require.NoError(t, err)
+
+	user := domain.NewUser("coach@example.com", hash, "coach1")
+	user.IsEmailVerified = true
+	user.Role = domain.RoleCoach
+	userRepo := &fakeUserRepo{usersByEmail: map[string]*domain.User{user.Email: user}}
+	verifRepo := &fakeEmailVerifRepo{}
+	sender := &fakeEmailSender{}
+
+	svc := authuc.NewService(fakeTx{}, userRepo, &fakeUsernameHistory{}, fakeUsernameFilter{}, verifRepo, &fakeJWT{}, sender, events.NopPublisher{}, 15*time.Minute, 5, 6, []domain.Role{domain.RoleCoach}, password.Policy{MinLength: 8})
+	ctx := context.Background()
+
+	_, _, _, err = svc.ChangePassword(ctx, user.ID, "oldPassword1", "newPassword1", "")
+	require.ErrorIs(t, err, authuc.ErrPasswordChangeConfirmationRequired)
+	require.True(t, sender.passwordChange)
+	require.Equal(t, user.Email, sender.sentTo)
+	require.NotNil(t, verifRepo.created)
+	require.Equal(t, domain.PurposePasswordChange, verifRepo.created.Purpose)
+	require.NoError(t, password.Compare(user.PasswordHash, "oldPassword1"))
+
+	_, _, _, err = svc.ChangePassword(ctx, user.ID, "oldPassword1", "newPassword1", "000000x")
+	require.ErrorIs(t, err, authuc.ErrVerificationCodeInvalid)
+
+	_, _, _, err = svc.ChangePassword(ctx, user.ID, "oldPassword1", "newPassword1", sender.code)
+	require.NoError(t, err)
+	require.NoError(t, password.Compare(user.PasswordHash, "newPassword1"))
+	require.Nil(t, verifRepo.created)
+}
+
+func TestChangePassword_WrongCurrentPassword(t *testing.T) {
+	svc, user := newChangePasswordFixture(t)
+
+	_, _, _, err := svc.ChangePassword(context.Background(), user.ID, "wrong", "newPassword1", "")
+	require.ErrorIs(t, err, authuc.ErrInvalidCurrentPassword)
+	require.Nil(t, user.TokensValidAfter)
+}
+
+func TestChangePassword_SamePassword(t *testing.T) {
+	svc, user := newChangePasswordFixture(t)
+
+	_, _, _, err := svc.ChangePassword(context.Background(), user.ID, "oldPassword1", "oldPassword1", "")
+	require.ErrorIs(t, err, authuc.ErrSamePassword)
+}
+
+func TestChangePassword_UpdatesHashAndRevokesOldTokens(t *testing.T) {
+	svc, user := newChangePasswordFixture(t)
+	issuedBefore := time.Now().Add(-time.Minute)
+
+	_, _, _, err := svc.ChangePassword(context.Background(), user.ID, "oldPassword1", "newPassword1", "")
+	require.NoError(t, err)
+
+	require.NoError(t, password.Compare(user.PasswordHash, "newPassword1"))
+	require.NotNil(t, user.TokensValidAfter)
+	require.True(t, user.IsTokenRevoked(issuedBefore))
+	require.False(t, user.IsTokenRevoked(time.Now()))
+}
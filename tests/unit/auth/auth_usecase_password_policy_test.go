@@ -0,0 +1,78 @@
+package auth_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	domain "workout-app/internal/domain/user"
+	"workout-app/internal/events"
+	authuc "workout-app/internal/usecase/auth"
+	"workout-app/pkg/password"
+)
+
+func strictPolicy() password.Policy {
+	return password.Policy{
+		MinLength:       10,
+		RequiredClasses: []password.Class{password.ClassLower, password.ClassUpper, password.ClassDigit},
+		Denylist:        password.CommonPasswords(),
+	}
+}
+
+func violationCodes(t *testing.T, err error) []string {
+	t.Helper()
+	var policyErr *password.PolicyError
+	require.ErrorAs(t, err, &policyErr)
+	codes := make([]string, 0, len(policyErr.Violations))
+	for _, v := range policyErr.Violations {
+		codes = append(codes, v.Code)
+	}
+	return codes
+}
+
+func TestPasswordPolicy_ReportsEveryViolation(t *testing.T) {
+	policy := strictPolicy()
+
+	require.Equal(t, []string{
+		password.ViolationTooShort,
+		password.ViolationMissingUpper,
+		password.ViolationMissingDigit,
+	}, violationCodes(t, policy.Validate("short")))
+
+	require.Equal(t, []string{password.ViolationCommon}, violationCodes(t, password.Policy{Denylist: password.CommonPasswords()}.Validate("QWERTY123")))
+	require.Equal(t, []string{password.ViolationTooLong}, violationCodes(t, password.Policy{}.Validate(string(make([]byte, password.MaxBytes+1)))))
+	require.Equal(t, []string{password.ViolationMissingSymbol}, violationCodes(t, password.Policy{RequiredClasses: []password.Class{password.ClassSymbol}}.Validate("Пароль2026")))
+
+	require.NoError(t, policy.Validate("ПарольДлинный7"))
+	require.NoError(t, policy.Validate("Correct1Horse"))
+}
+
+func TestRegister_RejectsPasswordViolatingPolicy(t *testing.T) {
+	userRepo := &fakeUserRepo{usersByEmail: map[string]*domain.User{}}
+	sender := &fakeEmailSender{}
+	svc := authuc.NewService(fakeTx{}, userRepo, &fakeUsernameHistory{}, fakeUsernameFilter{}, &fakeEmailVerifRepo{}, &fakeJWT{}, sender, events.NopPublisher{}, 15*time.Minute, 5, 6, nil, strictPolicy())
+
+	_, err := svc.Register(context.Background(), "new@example.com", "password123", "newuser", "", "")
+	require.ErrorIs(t, err, authuc.ErrWeakPassword)
+	require.Equal(t, []string{password.ViolationMissingUpper, password.ViolationCommon}, violationCodes(t, err))
+	require.Empty(t, sender.sentTo)
+}
+
+func TestChangePassword_RejectsWeakPasswordBeforeSendingCode(t *testing.T) {
+	hash, err := password.Hash("oldPassword1")
+	require.NoError(t, err)
+	user := domain.NewUser("coach@example.com", hash, "coach1")
+	user.IsEmailVerified = true
+	user.Role = domain.RoleCoach
+	userRepo := &fakeUserRepo{usersByEmail: map[string]*domain.User{user.Email: user}}
+	sender := &fakeEmailSender{}
+	svc := authuc.NewService(fakeTx{}, userRepo, &fakeUsernameHistory{}, fakeUsernameFilter{}, &fakeEmailVerifRepo{}, &fakeJWT{}, sender, events.NopPublisher{}, 15*time.Minute, 5, 6, []domain.Role{domain.RoleCoach}, strictPolicy())
+
+	_, _, _, err = svc.ChangePassword(context.Background(), user.ID, "oldPassword1", "newpassword", "")
+	require.ErrorIs(t, err, authuc.ErrWeakPassword)
+	require.Equal(t, []string{password.ViolationMissingUpper, password.ViolationMissingDigit}, violationCodes(t, err))
+	require.Empty(t, sender.sentTo)
+	require.NoError(t, password.Compare(user.PasswordHash, "oldPassword1"))
+}
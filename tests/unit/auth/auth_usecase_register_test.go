@@ -0,0 +1,149 @@
+package auth_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	domain "workout-app/internal/domain/user"
+	"workout-app/internal/events"
+	repo "workout-app/internal/repository/interfaces"
+	authuc "workout-app/internal/usecase/auth"
+	"workout-app/pkg/password"
+	"workout-app/pkg/usernamefilter"
+)
+
+// racingUserRepo имитирует параллельную регистрацию: к моменту вставки аккаунт с тем же email
+// уже зафиксирован другим запросом, хотя предварительная проверка его не видела.
+type racingUserRepo struct {
+	fakeUserRepo
+	winner  *domain.User
+	creates int
+}
+
+func (r *racingUserRepo) Create(context.Context, *domain.User) error {
+	r.creates++
+	r.usersByEmail[r.winner.Email] = r.winner
+	return repo.ErrEmailExists
+}
+
+type takenUsernameRepo struct {
+	fakeUserRepo
+	username string
+}
+
+func (r *takenUsernameRepo) GetByUsername(_ context.Context, username string) (*domain.User, error) {
+	if username == r.username {
+		return domain.NewUser("other@example.com", "hash", username), nil
+	}
+	return nil, repo.ErrNotFound
+}
+
+// recordingTx запоминает, была ли транзакция откачена.
+type recordingTx struct {
+	rolledBack bool
+}
+
+func (t *recordingTx) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	err := fn(ctx)
+	t.rolledBack = err != nil
+	return err
+}
+
+type failingEmailSender struct {
+	fakeEmailSender
+}
+
+func (s *failingEmailSender) SendEmailVerificationCode(context.Context, string, string) error {
+	return errors.New("smtp unavailable")
+}
+
+func newRegisterService(tx repo.Transactor, users repo.UserRepository, sender *fakeEmailSender) authuc.Service {
+	return authuc.NewService(tx, users, &fakeUsernameHistory{}, fakeUsernameFilter{}, &fakeEmailVerifRepo{}, &fakeJWT{}, sender, events.NopPublisher{}, 15*time.Minute, 5, 6, nil, password.Policy{MinLength: 8})
+}
+
+func TestRegister_LateEmailConflictResolvedAfterRollback(t *testing.T) {
+	users := &racingUserRepo{
+		fakeUserRepo: fakeUserRepo{usersByEmail: map[string]*domain.User{}},
+		winner:       domain.NewUser("race@example.com", "hash", "racer1"),
+	}
+	sender := &fakeEmailSender{}
+	tx := &recordingTx{}
+
+	_, err := newRegisterService(tx, users, sender).Register(context.Background(), "race@example.com", "Password123!", "racer2", "", "")
+	require.ErrorIs(t, err, authuc.ErrEmailUnverifiedExists)
+	require.Equal(t, 1, users.creates)
+	require.True(t, tx.rolledBack)
+	require.Empty(t, sender.sentTo)
+
+	users.winner.IsEmailVerified = true
+	_, err = newRegisterService(tx, users, sender).Register(context.Background(), "race@example.com", "Password123!", "racer3", "", "")
+	require.ErrorIs(t, err, repo.ErrEmailExists)
+	require.Equal(t, 1, users.creates, "pre-check must reject before creating the user")
+}
+
+func TestRegister_TakenUsernameRejectedBeforeCreate(t *testing.T) {
+	users := &takenUsernameRepo{fakeUserRepo: fakeUserRepo{usersByEmail: map[string]*domain.User{}}, username: "taken"}
+
+	_, err := newRegisterService(fakeTx{}, users, &fakeEmailSender{}).Register(context.Background(), "new@example.com", "Password123!", "taken", "", "")
+	require.ErrorIs(t, err, repo.ErrUsernameExists)
+}
+
+func TestRegister_RollsBackWhenCodeCannotBeSent(t *testing.T) {
+	users := &fakeUserRepo{usersByEmail: map[string]*domain.User{}}
+	sender := &failingEmailSender{}
+	tx := &recordingTx{}
+	svc := authuc.NewService(tx, users, &fakeUsernameHistory{}, fakeUsernameFilter{}, &fakeEmailVerifRepo{}, &fakeJWT{}, sender, events.NopPublisher{}, 15*time.Minute, 5, 6, nil, password.Policy{MinLength: 8})
+
+	_, err := svc.Register(context.Background(), "new@example.com", "Password123!", "newuser", "", "")
+	require.Error(t, err)
+	require.True(t, tx.rolledBack)
+}
+
+func TestRegister_StoresLanguageAndSendsCodeInIt(t *testing.T) {
+	users := &fakeUserRepo{usersByEmail: map[string]*domain.User{}}
+	sender := &fakeEmailSender{}
+
+	user, err := newRegisterService(fakeTx{}, users, sender).Register(context.Background(), "new@example.com", "Password123!", "newuser", "", "ru")
+	require.NoError(t, err)
+	require.Equal(t, domain.LanguageRussian, user.Language)
+	require.Equal(t, "ru", sender.locale)
+
+	user, err = newRegisterService(fakeTx{}, users, sender).Register(context.Background(), "other@example.com", "Password123!", "other", "", "de")
+	require.NoError(t, err)
+	require.Empty(t, user.Language)
+	require.Empty(t, sender.locale)
+}
+
+func TestRegister_ReservedUsernameTreatedAsTaken(t *testing.T) {
+	users := &fakeUserRepo{usersByEmail: map[string]*domain.User{}}
+	usernames := &fakeUsernameHistory{reserved: map[string]uuid.UUID{"oldname": uuid.New()}}
+	svc := authuc.NewService(fakeTx{}, users, usernames, fakeUsernameFilter{}, &fakeEmailVerifRepo{}, &fakeJWT{}, &fakeEmailSender{}, events.NopPublisher{}, 15*time.Minute, 5, 6, nil, password.Policy{MinLength: 8})
+
+	available, err := svc.IsUsernameAvailable(context.Background(), "oldname")
+	require.NoError(t, err)
+	require.False(t, available)
+
+	_, err = svc.Register(context.Background(), "new@example.com", "Password123!", "oldname", "", "")
+	require.ErrorIs(t, err, repo.ErrUsernameExists)
+}
+
+func TestRegister_BlockedUsernameRejectedWithCategory(t *testing.T) {
+	users := &fakeUserRepo{usersByEmail: map[string]*domain.User{}}
+	filter := fakeUsernameFilter{matcher: usernamefilter.NewMatcher(usernamefilter.Reserved())}
+	svc := authuc.NewService(fakeTx{}, users, &fakeUsernameHistory{}, filter, &fakeEmailVerifRepo{}, &fakeJWT{}, &fakeEmailSender{}, events.NopPublisher{}, 15*time.Minute, 5, 6, nil, password.Policy{MinLength: 8})
+
+	available, err := svc.IsUsernameAvailable(context.Background(), "Admin2")
+	require.NoError(t, err)
+	require.False(t, available)
+
+	_, err = svc.Register(context.Background(), "new@example.com", "Password123!", "Adm1n", "", "")
+	require.ErrorIs(t, err, usernamefilter.ErrNotAllowed)
+	var filterErr *usernamefilter.Error
+	require.ErrorAs(t, err, &filterErr)
+	require.Equal(t, usernamefilter.CategoryReserved, filterErr.Category)
+}
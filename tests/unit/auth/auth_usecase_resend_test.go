@@ -9,27 +9,87 @@ import (
 	"github.com/stretchr/testify/require"
 
 	domain "workout-app/internal/domain/user"
+	"workout-app/internal/events"
 	repo "workout-app/internal/repository/interfaces"
 	authuc "workout-app/internal/usecase/auth"
 	jwtsvc "workout-app/pkg/jwt"
+	"workout-app/pkg/mailer"
+	"workout-app/pkg/password"
+	"workout-app/pkg/usernamefilter"
 )
 
 // ==== Fakes for repositories and services ====
 
+// fakeTx выполняет функцию без транзакции.
+type fakeTx struct{}
+
+func (fakeTx) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
 type fakeUserRepo struct {
 	usersByEmail map[string]*domain.User
 }
 
 func (r *fakeUserRepo) Create(context.Context, *domain.User) error { return nil }
-func (r *fakeUserRepo) GetByID(context.Context, uuid.UUID) (*domain.User, error) {
+func (r *fakeUserRepo) GetByID(_ context.Context, id uuid.UUID) (*domain.User, error) {
+	for _, u := range r.usersByEmail {
+		if u.ID == id {
+			return u, nil
+		}
+	}
 	return nil, repo.ErrNotFound
 }
 func (r *fakeUserRepo) GetByUsername(context.Context, string) (*domain.User, error) {
 	return nil, repo.ErrNotFound
 }
-func (r *fakeUserRepo) Update(context.Context, *domain.User) error   { return nil }
-func (r *fakeUserRepo) SoftDelete(context.Context, uuid.UUID) error  { return nil }
-func (r *fakeUserRepo) List(context.Context) ([]*domain.User, error) { return nil, nil }
+func (r *fakeUserRepo) Update(context.Context, *domain.User) error { return nil }
+func (r *fakeUserRepo) MarkEmailVerified(ctx context.Context, id uuid.UUID) error {
+	u, err := r.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	u.IsEmailVerified = true
+	return nil
+}
+func (r *fakeUserRepo) UpdateRole(context.Context, uuid.UUID, domain.Role) (domain.Role, error) {
+	return domain.RoleUser, nil
+}
+func (r *fakeUserRepo) SetSuspension(context.Context, uuid.UUID, *domain.Suspension) error {
+	return nil
+}
+func (r *fakeUserRepo) SetLegalHold(context.Context, uuid.UUID, *domain.LegalHold) error {
+	return nil
+}
+func (r *fakeUserRepo) UpdatePassword(ctx context.Context, id uuid.UUID, hash string, validAfter time.Time) error {
+	u, err := r.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	u.PasswordHash = hash
+	u.TokensValidAfter = &validAfter
+	return nil
+}
+func (r *fakeUserRepo) SoftDelete(context.Context, uuid.UUID) error   { return nil }
+func (r *fakeUserRepo) Anonymize(context.Context, *domain.User) error { return nil }
+func (r *fakeUserRepo) Delete(context.Context, uuid.UUID) error       { return nil }
+func (r *fakeUserRepo) List(context.Context) ([]*domain.User, error)  { return nil, nil }
+func (r *fakeUserRepo) GetByIDIncludingDeleted(ctx context.Context, id uuid.UUID) (*domain.User, error) {
+	return r.GetByID(ctx, id)
+}
+func (r *fakeUserRepo) ListIDsAfter(context.Context, uuid.UUID, int) ([]uuid.UUID, error) {
+	return nil, nil
+}
+func (r *fakeUserRepo) ListPurgeCandidates(context.Context, time.Time, int) ([]uuid.UUID, error) {
+	return nil, nil
+}
+func (r *fakeUserRepo) ListReminderRecipients(context.Context, uuid.UUID, int) ([]*domain.User, error) {
+	return nil, nil
+}
+func (r *fakeUserRepo) ListWeeklySummaryRecipients(context.Context, time.Time, uuid.UUID, int) ([]*domain.User, error) {
+	return nil, nil
+}
+func (r *fakeUserRepo) Count(context.Context) (int64, error) { return 0, nil }
 func (r *fakeUserRepo) GetByEmail(_ context.Context, email string) (*domain.User, error) {
 	u, ok := r.usersByEmail[email]
 	if !ok {
@@ -38,6 +98,26 @@ func (r *fakeUserRepo) GetByEmail(_ context.Context, email string) (*domain.User
 	return u, nil
 }
 
+// fakeUsernameFilter проверяет username по заданным правилам; нулевое значение ничего не запрещает.
+type fakeUsernameFilter struct {
+	matcher *usernamefilter.Matcher
+}
+
+func (f fakeUsernameFilter) Check(_ context.Context, username string) error {
+	return f.matcher.Check(username)
+}
+
+// fakeUsernameHistory хранит зарезервированные username: имя → владелец резерва.
+type fakeUsernameHistory struct {
+	repo.UsernameHistoryRepository
+	reserved map[string]uuid.UUID
+}
+
+func (r *fakeUsernameHistory) IsReserved(_ context.Context, username string, exceptUserID uuid.UUID, _ time.Time) (bool, error) {
+	owner, ok := r.reserved[username]
+	return ok && owner != exceptUserID, nil
+}
+
 type fakeEmailVerifRepo struct {
 	deletedForUser uuid.UUID
 	created        *domain.EmailVerification
@@ -47,36 +127,85 @@ func (r *fakeEmailVerifRepo) Create(_ context.Context, v *domain.EmailVerificati
 	r.created = v
 	return nil
 }
-func (r *fakeEmailVerifRepo) GetActiveByUserID(context.Context, uuid.UUID) (*domain.EmailVerification, error) {
-	return nil, repo.ErrNotFound
-}
-func (r *fakeEmailVerifRepo) GetActiveByUserIDAndNewEmail(context.Context, uuid.UUID, string) (*domain.EmailVerification, error) {
-	return nil, repo.ErrNotFound
+func (r *fakeEmailVerifRepo) GetActiveByPurpose(_ context.Context, userID uuid.UUID, purpose domain.VerificationPurpose) (*domain.EmailVerification, error) {
+	if r.created == nil || r.created.UserID != userID || r.created.Purpose != purpose {
+		return nil, repo.ErrNotFound
+	}
+	return r.created, nil
 }
-func (r *fakeEmailVerifRepo) GetActiveEmailChangeByUserID(context.Context, uuid.UUID) (*domain.EmailVerification, error) {
-	return nil, repo.ErrNotFound
+func (r *fakeEmailVerifRepo) GetByID(_ context.Context, id int64) (*domain.EmailVerification, error) {
+	if r.created == nil || r.created.ID != id {
+		return nil, repo.ErrNotFound
+	}
+	return r.created, nil
 }
-func (r *fakeEmailVerifRepo) GetByID(context.Context, int64) (*domain.EmailVerification, error) {
-	return nil, repo.ErrNotFound
+func (r *fakeEmailVerifRepo) IncrementAttempts(_ context.Context, id int64) error {
+	if r.created != nil && r.created.ID == id {
+		r.created.Attempts++
+	}
+	return nil
 }
-func (r *fakeEmailVerifRepo) IncrementAttempts(context.Context, int64) error { return nil }
 func (r *fakeEmailVerifRepo) DeleteByUserID(_ context.Context, userID uuid.UUID) error {
 	r.deletedForUser = userID
 	return nil
 }
-func (r *fakeEmailVerifRepo) DeleteEmailChangeByUserID(_ context.Context, userID uuid.UUID) error {
+func (r *fakeEmailVerifRepo) DeleteByPurpose(_ context.Context, userID uuid.UUID, purpose domain.VerificationPurpose) error {
 	r.deletedForUser = userID
+	if r.created != nil && r.created.UserID == userID && r.created.Purpose == purpose {
+		r.created = nil
+	}
 	return nil
 }
+func (r *fakeEmailVerifRepo) DeleteExpired(_ context.Context, _ time.Time, _ int) (int64, error) {
+	return 0, nil
+}
 
 type fakeEmailSender struct {
-	sentTo string
-	code   string
+	sentTo         string
+	code           string
+	locale         string
+	passwordChange bool
+	passwordReset  bool
+}
+
+func (s *fakeEmailSender) SendEmailVerificationCode(ctx context.Context, email, code string) error {
+	s.sentTo = email
+	s.code = code
+	s.locale = mailer.LocaleFromContext(ctx)
+	return nil
+}
+
+func (s *fakeEmailSender) SendPasswordChangeCode(_ context.Context, email, code string) error {
+	s.sentTo = email
+	s.code = code
+	s.passwordChange = true
+	return nil
 }
 
-func (s *fakeEmailSender) SendEmailVerificationCode(_ context.Context, email, code string) error {
+func (s *fakeEmailSender) SendPasswordResetCode(_ context.Context, email, code string) error {
 	s.sentTo = email
 	s.code = code
+	s.passwordReset = true
+	return nil
+}
+
+func (s *fakeEmailSender) SendDataExportReady(_ context.Context, email, _ string, _ time.Time) error {
+	s.sentTo = email
+	return nil
+}
+
+func (s *fakeEmailSender) SendWelcome(context.Context, string, string) error { return nil }
+func (s *fakeEmailSender) SendPasswordChanged(context.Context, string, time.Time) error {
+	return nil
+}
+func (s *fakeEmailSender) SendAccountDeleted(context.Context, string, time.Time) error { return nil }
+func (s *fakeEmailSender) SendNotification(context.Context, string, string, time.Time) error {
+	return nil
+}
+func (s *fakeEmailSender) SendWeeklySummary(context.Context, string, mailer.WeeklySummary) error {
+	return nil
+}
+func (s *fakeEmailSender) SendCoachInvitation(context.Context, string, string, time.Time) error {
 	return nil
 }
 
@@ -87,6 +216,7 @@ func (f *fakeJWT) GenerateAccessToken(*domain.User) (string, error)          { r
 func (f *fakeJWT) GenerateRefreshToken(*domain.User) (string, string, error) { return "", "", nil }
 func (f *fakeJWT) ParseAccessToken(string) (*jwtsvc.Claims, error)           { return &jwtsvc.Claims{}, nil }
 func (f *fakeJWT) ParseRefreshToken(string) (*jwtsvc.Claims, error)          { return &jwtsvc.Claims{}, nil }
+func (f *fakeJWT) JWKS() jwtsvc.JWKSet                                       { return jwtsvc.JWKSet{} }
 
 // ==== Tests for ResendVerificationCode ====
 
@@ -95,7 +225,7 @@ func TestResendVerificationCode_NoUser_SilentSuccess(t *testing.T) {
 	verifRepo := &fakeEmailVerifRepo{}
 	sender := &fakeEmailSender{}
 
-	svc := authuc.NewService(userRepo, verifRepo, &fakeJWT{}, sender, 15*time.Minute, 5, 6)
+	svc := authuc.NewService(fakeTx{}, userRepo, &fakeUsernameHistory{}, fakeUsernameFilter{}, verifRepo, &fakeJWT{}, sender, events.NopPublisher{}, 15*time.Minute, 5, 6, nil, password.Policy{MinLength: 8})
 
 	err := svc.ResendVerificationCode(context.Background(), "nouser@example.com")
 	require.NoError(t, err)
@@ -115,7 +245,7 @@ func TestResendVerificationCode_AlreadyVerified(t *testing.T) {
 	verifRepo := &fakeEmailVerifRepo{}
 	sender := &fakeEmailSender{}
 
-	svc := authuc.NewService(userRepo, verifRepo, &fakeJWT{}, sender, 15*time.Minute, 5, 6)
+	svc := authuc.NewService(fakeTx{}, userRepo, &fakeUsernameHistory{}, fakeUsernameFilter{}, verifRepo, &fakeJWT{}, sender, events.NopPublisher{}, 15*time.Minute, 5, 6, nil, password.Policy{MinLength: 8})
 
 	err := svc.ResendVerificationCode(context.Background(), u.Email)
 	require.Error(t, err)
@@ -136,7 +266,7 @@ func TestResendVerificationCode_Unverified_CreatesNewCodeAndDeletesOld(t *testin
 	verifRepo := &fakeEmailVerifRepo{}
 	sender := &fakeEmailSender{}
 
-	svc := authuc.NewService(userRepo, verifRepo, &fakeJWT{}, sender, 15*time.Minute, 5, 6)
+	svc := authuc.NewService(fakeTx{}, userRepo, &fakeUsernameHistory{}, fakeUsernameFilter{}, verifRepo, &fakeJWT{}, sender, events.NopPublisher{}, 15*time.Minute, 5, 6, nil, password.Policy{MinLength: 8})
 
 	err := svc.ResendVerificationCode(context.Background(), u.Email)
 	require.NoError(t, err)
@@ -144,6 +274,7 @@ func TestResendVerificationCode_Unverified_CreatesNewCodeAndDeletesOld(t *testin
 	require.Equal(t, u.ID, verifRepo.deletedForUser)
 	require.NotNil(t, verifRepo.created)
 	require.Equal(t, u.ID, verifRepo.created.UserID)
+	require.Equal(t, domain.PurposeRegistration, verifRepo.created.Purpose)
 	require.Equal(t, u.Email, sender.sentTo)
 	require.NotEmpty(t, sender.code)
 }
@@ -0,0 +1,53 @@
+package auth_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	domain "workout-app/internal/domain/user"
+	"workout-app/internal/events"
+	authuc "workout-app/internal/usecase/auth"
+	"workout-app/pkg/password"
+)
+
+func TestResetPassword_CodeFromAdminResetsPasswordAndRevokesTokens(t *testing.T) {
+	hash, err := password.Hash("oldPassword1")
+	require.NoError(t, err)
+
+	user := domain.NewUser("user@example.com", hash, "user1")
+	user.IsEmailVerified = true
+	userRepo := &fakeUserRepo{usersByEmail: map[string]*domain.User{user.Email: user}}
+	verifRepo := &fakeEmailVerifRepo{}
+	sender := &fakeEmailSender{}
+
+	svc := authuc.NewService(fakeTx{}, userRepo, &fakeUsernameHistory{}, fakeUsernameFilter{}, verifRepo, &fakeJWT{}, sender, events.NopPublisher{}, 15*time.Minute, 5, 6, nil, password.Policy{MinLength: 8})
+	ctx := context.Background()
+	issuedBefore := time.Now().Add(-time.Minute)
+
+	require.NoError(t, svc.SendPasswordReset(ctx, user.ID))
+	require.True(t, sender.passwordReset)
+	require.Equal(t, user.Email, sender.sentTo)
+	require.Equal(t, domain.PurposePasswordReset, verifRepo.created.Purpose)
+
+	require.ErrorIs(t, svc.ResetPassword(ctx, user.Email, sender.code, "short"), authuc.ErrWeakPassword)
+	require.ErrorIs(t, svc.ResetPassword(ctx, user.Email, "000000x", "newPassword1"), authuc.ErrVerificationCodeInvalid)
+	require.NoError(t, password.Compare(user.PasswordHash, "oldPassword1"))
+
+	require.NoError(t, svc.ResetPassword(ctx, user.Email, sender.code, "newPassword1"))
+	require.NoError(t, password.Compare(user.PasswordHash, "newPassword1"))
+	require.True(t, user.IsTokenRevoked(issuedBefore))
+	require.Nil(t, verifRepo.created)
+
+	// Код одноразовый.
+	require.ErrorIs(t, svc.ResetPassword(ctx, user.Email, sender.code, "newPassword2"), authuc.ErrVerificationCodeNotFound)
+}
+
+func TestResetPassword_UnknownEmailLooksLikeMissingCode(t *testing.T) {
+	svc, _ := newChangePasswordFixture(t)
+
+	err := svc.ResetPassword(context.Background(), "nobody@example.com", "123456", "newPassword1")
+	require.ErrorIs(t, err, authuc.ErrVerificationCodeNotFound)
+}
@@ -0,0 +1,159 @@
+package avatar_test
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	domain "workout-app/internal/domain/user"
+	repo "workout-app/internal/repository/interfaces"
+	avataruc "workout-app/internal/usecase/avatar"
+	"workout-app/pkg/logger"
+	"workout-app/pkg/storage"
+)
+
+// fakeUsers реализует только методы UserRepository, нужные для загрузки аватара.
+type fakeUsers struct {
+	repo.UserRepository
+	user *domain.User
+}
+
+func (r *fakeUsers) GetByID(_ context.Context, id uuid.UUID) (*domain.User, error) {
+	if r.user == nil || r.user.ID != id {
+		return nil, repo.ErrNotFound
+	}
+	return r.user, nil
+}
+
+func (r *fakeUsers) Update(_ context.Context, u *domain.User) error {
+	r.user = u
+	return nil
+}
+
+// fakeProfileHistory запоминает записи истории изменений профиля.
+type fakeProfileHistory struct {
+	repo.ProfileHistoryRepository
+	changes []*domain.ProfileChange
+}
+
+func (r *fakeProfileHistory) Create(_ context.Context, c *domain.ProfileChange) error {
+	r.changes = append(r.changes, c)
+	return nil
+}
+
+// pngHeader — сигнатура PNG, по которой определяется тип файла.
+var pngHeader = []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+
+func newAvatarFixture(t *testing.T) (avataruc.Service, *fakeUsers, *fakeProfileHistory, string) {
+	t.Helper()
+	dir := t.TempDir()
+	users := &fakeUsers{user: domain.NewUser("user@example.com", "hash", "user1")}
+	history := &fakeProfileHistory{}
+	svc := avataruc.NewService(users, history, storage.NewLocalStorage(dir, "/uploads"), 1024, logger.Default())
+	return svc, users, history, dir
+}
+
+func TestUpload_StoresImageAndReplacesPrevious(t *testing.T) {
+	svc, users, _, dir := newAvatarFixture(t)
+	ctx := context.Background()
+
+	user, err := svc.Upload(ctx, users.user.ID, bytes.NewReader(pngHeader), int64(len(pngHeader)))
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(user.AvatarURL, "/uploads/avatars/"+user.ID.String()+"/"))
+	require.True(t, strings.HasSuffix(user.AvatarURL, ".png"))
+
+	firstPath := filepath.Join(dir, strings.TrimPrefix(user.AvatarURL, "/uploads/"))
+	stored, err := os.ReadFile(firstPath)
+	require.NoError(t, err)
+	require.Equal(t, pngHeader, stored)
+
+	user, err = svc.Upload(ctx, users.user.ID, bytes.NewReader(pngHeader), int64(len(pngHeader)))
+	require.NoError(t, err)
+	_, err = os.Stat(firstPath)
+	require.ErrorIs(t, err, os.ErrNotExist)
+	_, err = os.Stat(filepath.Join(dir, strings.TrimPrefix(user.AvatarURL, "/uploads/")))
+	require.NoError(t, err)
+}
+
+func TestUpload_KeepsExternalAvatarUntouched(t *testing.T) {
+	svc, users, history, _ := newAvatarFixture(t)
+	users.user.AvatarURL = "https://example.com/me.png"
+
+	user, err := svc.Upload(context.Background(), users.user.ID, bytes.NewReader(pngHeader), int64(len(pngHeader)))
+	require.NoError(t, err)
+	require.NotEqual(t, "https://example.com/me.png", user.AvatarURL)
+
+	require.Len(t, history.changes, 1)
+	require.Equal(t, []domain.FieldChange{
+		{Field: domain.FieldAvatarURL, Old: "https://example.com/me.png", New: user.AvatarURL},
+	}, history.changes[0].Changes)
+	require.True(t, history.changes[0].BySelf())
+}
+
+func TestUpload_RejectsInvalidFiles(t *testing.T) {
+	svc, users, _, dir := newAvatarFixture(t)
+	ctx := context.Background()
+
+	_, err := svc.Upload(ctx, users.user.ID, strings.NewReader("<html>not an image</html>"), 25)
+	require.ErrorIs(t, err, avataruc.ErrUnsupportedFormat)
+
+	_, err = svc.Upload(ctx, users.user.ID, bytes.NewReader(make([]byte, 2048)), 2048)
+	require.ErrorIs(t, err, avataruc.ErrFileTooLarge)
+
+	_, err = svc.Upload(ctx, users.user.ID, bytes.NewReader(nil), 0)
+	require.ErrorIs(t, err, avataruc.ErrEmptyFile)
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Empty(t, entries)
+	require.Empty(t, users.user.AvatarURL)
+}
+
+func TestLocalStorage_RejectsKeysOutsideDirectory(t *testing.T) {
+	st := storage.NewLocalStorage(t.TempDir(), "/uploads")
+
+	_, err := st.Put(context.Background(), "../escape.png", bytes.NewReader(pngHeader), int64(len(pngHeader)), "image/png")
+	require.ErrorIs(t, err, storage.ErrInvalidKey)
+
+	_, ok := st.KeyFromURL("/uploads/../secret")
+	require.False(t, ok)
+}
+
+func TestS3Storage_OnlyAvatarsArePublic(t *testing.T) {
+	acls := make(map[string]string)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		acls[strings.TrimPrefix(r.URL.Path, "/bucket/")] = r.Header.Get("X-Amz-Acl")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	st, err := storage.NewS3Storage(storage.S3Config{
+		Endpoint:  srv.URL,
+		Region:    "us-east-1",
+		Bucket:    "bucket",
+		AccessKey: "key",
+		SecretKey: "secret",
+		PathStyle: true,
+	}, srv.Client())
+	require.NoError(t, err)
+
+	for _, key := range []string{"avatars/u/a.png", "exports/e.zip", "backups/b.bin", "videos/v.mp4"} {
+		_, err := st.Put(context.Background(), key, bytes.NewReader(pngHeader), int64(len(pngHeader)), "application/octet-stream")
+		require.NoError(t, err)
+	}
+
+	require.Equal(t, map[string]string{
+		"avatars/u/a.png": "public-read",
+		"exports/e.zip":   "private",
+		"backups/b.bin":   "private",
+		"videos/v.mp4":    "private",
+	}, acls)
+}
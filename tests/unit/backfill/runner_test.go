@@ -0,0 +1,192 @@
+package backfill_test
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	domain "workout-app/internal/domain/backfill"
+	repo "workout-app/internal/repository/interfaces"
+	backfilluc "workout-app/internal/usecase/backfill"
+	"workout-app/pkg/logger"
+)
+
+// ==== In-memory fakes ====
+
+type fakeBackfillRepo struct {
+	mu        sync.Mutex
+	backfills map[uuid.UUID]*domain.Backfill
+	owners    map[uuid.UUID]string
+}
+
+func newFakeBackfillRepo() *fakeBackfillRepo {
+	return &fakeBackfillRepo{
+		backfills: make(map[uuid.UUID]*domain.Backfill),
+		owners:    make(map[uuid.UUID]string),
+	}
+}
+
+func (r *fakeBackfillRepo) Create(_ context.Context, b *domain.Backfill) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	cp := *b
+	r.backfills[b.ID] = &cp
+	return nil
+}
+
+func (r *fakeBackfillRepo) GetByID(_ context.Context, id uuid.UUID) (*domain.Backfill, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	b, ok := r.backfills[id]
+	if !ok {
+		return nil, repo.ErrNotFound
+	}
+	cp := *b
+	return &cp, nil
+}
+
+func (r *fakeBackfillRepo) List(context.Context, int) ([]*domain.Backfill, error) { return nil, nil }
+
+func (r *fakeBackfillRepo) ListResumable(context.Context) ([]*domain.Backfill, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var result []*domain.Backfill
+	for _, b := range r.backfills {
+		if !b.Status.IsFinal() {
+			cp := *b
+			result = append(result, &cp)
+		}
+	}
+	return result, nil
+}
+
+func (r *fakeBackfillRepo) Claim(_ context.Context, id uuid.UUID, owner string, _ time.Duration) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	b := r.backfills[id]
+	if b.Status.IsFinal() {
+		return false, nil
+	}
+	r.owners[id] = owner
+	b.Status = domain.StatusRunning
+	return true, nil
+}
+
+func (r *fakeBackfillRepo) SaveProgress(_ context.Context, id uuid.UUID, owner, cursor string, processed int64, _ time.Duration) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.owners[id] != owner {
+		return repo.ErrBackfillLeaseLost
+	}
+	r.backfills[id].Cursor = cursor
+	r.backfills[id].Processed = processed
+	return nil
+}
+
+func (r *fakeBackfillRepo) Finish(_ context.Context, id uuid.UUID, _ string, status domain.Status, lastError string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.backfills[id].Status = status
+	r.backfills[id].LastError = lastError
+	return nil
+}
+
+func (r *fakeBackfillRepo) RequestCancel(_ context.Context, id uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.backfills[id].CancelRequested = true
+	return nil
+}
+
+// numbersJob обрабатывает числа 1..n, запоминая обработанные элементы.
+type numbersJob struct {
+	mu        sync.Mutex
+	n         int
+	processed []int
+}
+
+func (j *numbersJob) Count(context.Context) (int64, error) { return int64(j.n), nil }
+
+func (j *numbersJob) RunBatch(_ context.Context, after string, limit int) (string, int, error) {
+	start := 0
+	if after != "" {
+		start, _ = strconv.Atoi(after)
+	}
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	count := 0
+	for i := start + 1; i <= j.n && count < limit; i++ {
+		j.processed = append(j.processed, i)
+		count++
+	}
+	if count == 0 {
+		return after, 0, nil
+	}
+	return strconv.Itoa(start + count), count, nil
+}
+
+func waitFinal(t *testing.T, backfills *fakeBackfillRepo, id uuid.UUID) *domain.Backfill {
+	t.Helper()
+	var b *domain.Backfill
+	require.Eventually(t, func() bool {
+		b, _ = backfills.GetByID(context.Background(), id)
+		return b.Status.IsFinal()
+	}, 2*time.Second, 5*time.Millisecond)
+	return b
+}
+
+// ==== Tests ====
+
+func TestBackfill_LaunchProcessesAllItemsInBatches(t *testing.T) {
+	backfills := newFakeBackfillRepo()
+	job := &numbersJob{n: 25}
+	svc := backfilluc.NewService(backfills, map[string]backfilluc.Job{"numbers": job}, logger.Default(), 0)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	require.NoError(t, svc.Start(ctx))
+
+	b, err := svc.Launch(ctx, "numbers", 10, uuid.New())
+	require.NoError(t, err)
+	require.Equal(t, int64(25), b.Total)
+
+	final := waitFinal(t, backfills, b.ID)
+	require.Equal(t, domain.StatusCompleted, final.Status)
+	require.Equal(t, int64(25), final.Processed)
+	require.Equal(t, "25", final.Cursor)
+	require.Len(t, job.processed, 25)
+}
+
+func TestBackfill_ResumesFromSavedCursor(t *testing.T) {
+	backfills := newFakeBackfillRepo()
+	job := &numbersJob{n: 10}
+
+	// Задача, прерванная рестартом после обработки первых 6 элементов
+	interrupted := domain.New("numbers", 4, 10, uuid.New())
+	interrupted.Status = domain.StatusRunning
+	interrupted.Cursor = "6"
+	interrupted.Processed = 6
+	require.NoError(t, backfills.Create(context.Background(), interrupted))
+
+	svc := backfilluc.NewService(backfills, map[string]backfilluc.Job{"numbers": job}, logger.Default(), 0)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	require.NoError(t, svc.Start(ctx))
+
+	final := waitFinal(t, backfills, interrupted.ID)
+	require.Equal(t, domain.StatusCompleted, final.Status)
+	require.Equal(t, int64(10), final.Processed)
+	require.Equal(t, []int{7, 8, 9, 10}, job.processed)
+}
+
+func TestBackfill_UnknownJobRejected(t *testing.T) {
+	svc := backfilluc.NewService(newFakeBackfillRepo(), map[string]backfilluc.Job{}, logger.Default(), 0)
+
+	_, err := svc.Launch(context.Background(), "missing", 10, uuid.New())
+	require.ErrorIs(t, err, backfilluc.ErrUnknownJob)
+}
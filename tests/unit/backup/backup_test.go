@@ -0,0 +1,231 @@
+package backup_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	domain "workout-app/internal/domain/backup"
+	repo "workout-app/internal/repository/interfaces"
+	backupuc "workout-app/internal/usecase/backup"
+	"workout-app/pkg/logger"
+	"workout-app/pkg/storage"
+)
+
+// fakeBackups — in-memory реализация BackupRepository.
+type fakeBackups struct {
+	items map[uuid.UUID]*domain.Backup
+}
+
+func (r *fakeBackups) Create(_ context.Context, b *domain.Backup) error {
+	for _, existing := range r.items {
+		if existing.UserID == b.UserID && existing.Version == b.Version {
+			return repo.ErrBackupVersionExists
+		}
+	}
+	r.items[b.ID] = b
+	return nil
+}
+
+func (r *fakeBackups) ListByUser(_ context.Context, userID uuid.UUID) ([]*domain.Backup, error) {
+	var out []*domain.Backup
+	for _, b := range r.items {
+		if b.UserID == userID {
+			out = append(out, b)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Version > out[j].Version })
+	return out, nil
+}
+
+func (r *fakeBackups) Delete(_ context.Context, id uuid.UUID) error {
+	if _, ok := r.items[id]; !ok {
+		return repo.ErrNotFound
+	}
+	delete(r.items, id)
+	return nil
+}
+
+func (r *fakeBackups) DeleteByUserID(_ context.Context, userID uuid.UUID) error {
+	for id, b := range r.items {
+		if b.UserID == userID {
+			delete(r.items, id)
+		}
+	}
+	return nil
+}
+
+type fixture struct {
+	svc     backupuc.Service
+	backups *fakeBackups
+	dir     string
+	userID  uuid.UUID
+}
+
+func newFixture(t *testing.T, cfg backupuc.Config) *fixture {
+	dir := t.TempDir()
+	backups := &fakeBackups{items: map[uuid.UUID]*domain.Backup{}}
+	return &fixture{
+		svc:     backupuc.NewService(backups, storage.NewLocalStorage(dir, "/uploads"), cfg, logger.Default()),
+		backups: backups,
+		dir:     dir,
+		userID:  uuid.New(),
+	}
+}
+
+func (f *fixture) upload(baseVersion int, content []byte) (*domain.Backup, error) {
+	sum := sha256.Sum256(content)
+	return f.svc.Upload(context.Background(), f.userID, backupuc.UploadInput{
+		BaseVersion: baseVersion,
+		SHA256:      hex.EncodeToString(sum[:]),
+		Body:        bytes.NewReader(content),
+		Size:        int64(len(content)),
+	})
+}
+
+// files возвращает число файлов резервных копий в хранилище.
+func (f *fixture) files(t *testing.T) int {
+	entries, err := os.ReadDir(filepath.Join(f.dir, "backups"))
+	if os.IsNotExist(err) {
+		return 0
+	}
+	require.NoError(t, err)
+	return len(entries)
+}
+
+var defaultConfig = backupuc.Config{MaxBytes: 1 << 10, QuotaBytes: 4 << 10, MaxVersions: 3}
+
+func TestUpload_StoresVersionsAndDownloads(t *testing.T) {
+	f := newFixture(t, defaultConfig)
+	ctx := context.Background()
+
+	first, err := f.upload(0, []byte("encrypted-v1"))
+	require.NoError(t, err)
+	require.Equal(t, 1, first.Version)
+
+	second, err := f.upload(1, []byte("encrypted-v2"))
+	require.NoError(t, err)
+	require.Equal(t, 2, second.Version)
+
+	download, err := f.svc.Open(ctx, f.userID, 0)
+	require.NoError(t, err)
+	content, err := io.ReadAll(download.Object)
+	require.NoError(t, download.Object.Close())
+	require.NoError(t, err)
+	require.Equal(t, "encrypted-v2", string(content))
+	require.Equal(t, second.SHA256, download.Backup.SHA256)
+
+	download, err = f.svc.Open(ctx, f.userID, 1)
+	require.NoError(t, err)
+	content, err = io.ReadAll(download.Object)
+	require.NoError(t, download.Object.Close())
+	require.NoError(t, err)
+	require.Equal(t, "encrypted-v1", string(content))
+
+	_, err = f.svc.Open(ctx, f.userID, 5)
+	require.ErrorIs(t, err, backupuc.ErrBackupNotFound)
+	_, err = f.svc.Open(ctx, uuid.New(), 0)
+	require.ErrorIs(t, err, backupuc.ErrBackupNotFound)
+}
+
+func TestUpload_VersionConflict(t *testing.T) {
+	f := newFixture(t, defaultConfig)
+	_, err := f.upload(0, []byte("encrypted-v1"))
+	require.NoError(t, err)
+
+	_, err = f.upload(0, []byte("from another device"))
+	var conflictErr *backupuc.VersionConflictError
+	require.ErrorAs(t, err, &conflictErr)
+	require.Equal(t, 1, conflictErr.Current)
+	require.ErrorIs(t, err, backupuc.ErrVersionConflict)
+	require.Equal(t, 1, f.files(t))
+}
+
+func TestUpload_ChecksumMismatchRemovesFile(t *testing.T) {
+	f := newFixture(t, defaultConfig)
+	sum := sha256.Sum256([]byte("something else"))
+
+	_, err := f.svc.Upload(context.Background(), f.userID, backupuc.UploadInput{
+		SHA256: hex.EncodeToString(sum[:]),
+		Body:   bytes.NewReader([]byte("encrypted")),
+		Size:   9,
+	})
+	require.ErrorIs(t, err, backupuc.ErrChecksumMismatch)
+	require.Empty(t, f.backups.items)
+	require.Zero(t, f.files(t))
+}
+
+func TestUpload_Rejects(t *testing.T) {
+	f := newFixture(t, defaultConfig)
+	ctx := context.Background()
+
+	_, err := f.upload(0, nil)
+	require.ErrorIs(t, err, backupuc.ErrEmptyBackup)
+
+	_, err = f.upload(0, make([]byte, 2<<10))
+	require.ErrorIs(t, err, backupuc.ErrBackupTooLarge)
+
+	_, err = f.svc.Upload(ctx, f.userID, backupuc.UploadInput{SHA256: "not-a-hash", Body: bytes.NewReader([]byte("x")), Size: 1})
+	require.ErrorIs(t, err, backupuc.ErrInvalidChecksum)
+
+	_, err = f.upload(-1, []byte("x"))
+	require.ErrorIs(t, err, backupuc.ErrInvalidBaseVersion)
+	require.Zero(t, f.files(t))
+}
+
+func TestUpload_PrunesOldVersionsByCountAndQuota(t *testing.T) {
+	f := newFixture(t, backupuc.Config{MaxBytes: 100, QuotaBytes: 150, MaxVersions: 3})
+	ctx := context.Background()
+
+	for version := 0; version < 4; version++ {
+		_, err := f.upload(version, bytes.Repeat([]byte{byte(version)}, 40))
+		require.NoError(t, err)
+	}
+	overview, err := f.svc.Overview(ctx, f.userID)
+	require.NoError(t, err)
+	require.Len(t, overview.Backups, 3)
+	require.Equal(t, []int{4, 3, 2}, versions(overview.Backups))
+	require.Equal(t, int64(120), overview.UsedBytes)
+	require.Equal(t, 3, f.files(t))
+
+	// Новая версия в 100 байт не помещается в квоту вместе с двумя предыдущими.
+	_, err = f.upload(4, bytes.Repeat([]byte{9}, 100))
+	require.NoError(t, err)
+	overview, err = f.svc.Overview(ctx, f.userID)
+	require.NoError(t, err)
+	require.Equal(t, []int{5, 4}, versions(overview.Backups))
+	require.Equal(t, int64(140), overview.UsedBytes)
+	require.Equal(t, 2, f.files(t))
+}
+
+func TestDelete_RemovesAllVersions(t *testing.T) {
+	f := newFixture(t, defaultConfig)
+	ctx := context.Background()
+	_, err := f.upload(0, []byte("v1"))
+	require.NoError(t, err)
+	_, err = f.upload(1, []byte("v2"))
+	require.NoError(t, err)
+
+	require.NoError(t, f.svc.Delete(ctx, f.userID))
+	require.Empty(t, f.backups.items)
+	require.Zero(t, f.files(t))
+
+	require.ErrorIs(t, f.svc.Delete(ctx, f.userID), backupuc.ErrBackupNotFound)
+}
+
+func versions(backups []*domain.Backup) []int {
+	out := make([]int, 0, len(backups))
+	for _, b := range backups {
+		out = append(out, b.Version)
+	}
+	return out
+}
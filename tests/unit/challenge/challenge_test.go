@@ -0,0 +1,238 @@
+package challenge_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	domain "workout-app/internal/domain/challenge"
+	userdomain "workout-app/internal/domain/user"
+	repo "workout-app/internal/repository/interfaces"
+	challengeuc "workout-app/internal/usecase/challenge"
+)
+
+type fakeTx struct{}
+
+func (fakeTx) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+type participantKey struct{ challenge, user uuid.UUID }
+
+// fakeChallenges хранит челленджи и участников в памяти; прогресс задаётся тестом в values.
+type fakeChallenges struct {
+	repo.ChallengeRepository
+	items        map[uuid.UUID]*domain.Challenge
+	participants map[participantKey]*domain.Participant
+	values       map[uuid.UUID]int
+}
+
+func newFakeChallenges() *fakeChallenges {
+	return &fakeChallenges{
+		items:        map[uuid.UUID]*domain.Challenge{},
+		participants: map[participantKey]*domain.Participant{},
+		values:       map[uuid.UUID]int{},
+	}
+}
+
+func (r *fakeChallenges) Create(_ context.Context, c *domain.Challenge) error {
+	r.items[c.ID] = c
+	return nil
+}
+
+func (r *fakeChallenges) GetByID(_ context.Context, id uuid.UUID) (*domain.Challenge, error) {
+	c, ok := r.items[id]
+	if !ok {
+		return nil, repo.ErrNotFound
+	}
+	return c, nil
+}
+
+func (r *fakeChallenges) GetListing(ctx context.Context, id, userID uuid.UUID) (*domain.Listing, error) {
+	c, err := r.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	listing := &domain.Listing{Challenge: c}
+	for key := range r.participants {
+		if key.challenge == id {
+			listing.Participants++
+			listing.Joined = listing.Joined || key.user == userID
+		}
+	}
+	return listing, nil
+}
+
+func (r *fakeChallenges) Delete(_ context.Context, id uuid.UUID) error {
+	if _, ok := r.items[id]; !ok {
+		return repo.ErrNotFound
+	}
+	delete(r.items, id)
+	return nil
+}
+
+func (r *fakeChallenges) AddParticipant(_ context.Context, p *domain.Participant) error {
+	key := participantKey{p.ChallengeID, p.UserID}
+	if _, ok := r.participants[key]; !ok {
+		r.participants[key] = p
+	}
+	return nil
+}
+
+func (r *fakeChallenges) RemoveParticipant(_ context.Context, challengeID, userID uuid.UUID) error {
+	delete(r.participants, participantKey{challengeID, userID})
+	return nil
+}
+
+func (r *fakeChallenges) Progress(_ context.Context, c *domain.Challenge) ([]*domain.Progress, error) {
+	var out []*domain.Progress
+	for key, p := range r.participants {
+		if key.challenge == c.ID {
+			out = append(out, &domain.Progress{UserID: key.user, Username: key.user.String()[:8], Value: r.values[key.user], JoinedAt: p.JoinedAt})
+		}
+	}
+	return out, nil
+}
+
+func validInput() challengeuc.Input {
+	return challengeuc.Input{
+		Title:  " 30 тренировок за 30 дней ",
+		Metric: domain.MetricSessions,
+		Target: 30,
+		EndsAt: time.Now().Add(30 * 24 * time.Hour),
+	}
+}
+
+func TestCreate_JoinsCreator(t *testing.T) {
+	challenges := newFakeChallenges()
+	svc := challengeuc.NewService(fakeTx{}, challenges)
+	creatorID := uuid.New()
+
+	listing, err := svc.Create(context.Background(), creatorID, validInput())
+	require.NoError(t, err)
+	require.Equal(t, "30 тренировок за 30 дней", listing.Challenge.Title)
+	require.True(t, listing.Joined)
+	require.Equal(t, domain.StatusActive, listing.Challenge.Status(time.Now()))
+	require.Contains(t, challenges.participants, participantKey{listing.Challenge.ID, creatorID})
+}
+
+func TestCreate_Validation(t *testing.T) {
+	svc := challengeuc.NewService(fakeTx{}, newFakeChallenges())
+	ctx := context.Background()
+
+	cases := []struct {
+		mutate func(*challengeuc.Input)
+		err    error
+	}{
+		{func(in *challengeuc.Input) { in.Title = "  " }, challengeuc.ErrInvalidTitle},
+		{func(in *challengeuc.Input) { in.Metric = "volume" }, challengeuc.ErrInvalidMetric},
+		{func(in *challengeuc.Input) { in.Target = 0 }, challengeuc.ErrInvalidTarget},
+		{func(in *challengeuc.Input) { in.EndsAt = time.Now().Add(-time.Hour) }, challengeuc.ErrInvalidPeriod},
+		{func(in *challengeuc.Input) { in.StartsAt = time.Now().Add(-72 * time.Hour) }, challengeuc.ErrInvalidPeriod},
+		{func(in *challengeuc.Input) { in.EndsAt = time.Now().Add(400 * 24 * time.Hour) }, challengeuc.ErrInvalidPeriod},
+	}
+	for _, tc := range cases {
+		input := validInput()
+		tc.mutate(&input)
+		_, err := svc.Create(ctx, uuid.New(), input)
+		require.ErrorIs(t, err, tc.err)
+	}
+}
+
+func TestJoinAndLeave(t *testing.T) {
+	challenges := newFakeChallenges()
+	svc := challengeuc.NewService(fakeTx{}, challenges)
+	ctx := context.Background()
+	listing, err := svc.Create(ctx, uuid.New(), validInput())
+	require.NoError(t, err)
+	id := listing.Challenge.ID
+	userID := uuid.New()
+
+	require.NoError(t, svc.Join(ctx, userID, id))
+	require.NoError(t, svc.Join(ctx, userID, id))
+	details, err := svc.Get(ctx, userID, id)
+	require.NoError(t, err)
+	require.Equal(t, 2, details.Listing.Participants)
+	require.NotNil(t, details.Me)
+
+	require.NoError(t, svc.Leave(ctx, userID, id))
+	details, err = svc.Get(ctx, userID, id)
+	require.NoError(t, err)
+	require.False(t, details.Listing.Joined)
+	require.Nil(t, details.Me)
+
+	require.ErrorIs(t, svc.Join(ctx, userID, uuid.New()), challengeuc.ErrChallengeNotFound)
+}
+
+func TestJoin_FinishedChallenge(t *testing.T) {
+	challenges := newFakeChallenges()
+	svc := challengeuc.NewService(fakeTx{}, challenges)
+	finished := domain.New(uuid.New(), "Сентябрь", "", domain.MetricActiveDays, 20,
+		time.Now().AddDate(0, -1, 0), time.Now().Add(-time.Hour))
+	challenges.items[finished.ID] = finished
+
+	require.ErrorIs(t, svc.Join(context.Background(), uuid.New(), finished.ID), challengeuc.ErrChallengeFinished)
+	require.ErrorIs(t, svc.Leave(context.Background(), finished.CreatorID, finished.ID), challengeuc.ErrChallengeFinished)
+}
+
+func TestLeaderboard_RanksByProgress(t *testing.T) {
+	challenges := newFakeChallenges()
+	svc := challengeuc.NewService(fakeTx{}, challenges)
+	ctx := context.Background()
+	creatorID := uuid.New()
+	listing, err := svc.Create(ctx, creatorID, validInput())
+	require.NoError(t, err)
+	id := listing.Challenge.ID
+
+	first, second, third := uuid.New(), uuid.New(), uuid.New()
+	for i, userID := range []uuid.UUID{first, second, third} {
+		challenges.participants[participantKey{id, userID}] = &domain.Participant{
+			ChallengeID: id, UserID: userID, JoinedAt: time.Now().Add(time.Duration(i) * time.Minute),
+		}
+	}
+	challenges.values[creatorID] = 4
+	challenges.values[first] = 31
+	challenges.values[second] = 12
+	challenges.values[third] = 12
+
+	board, err := svc.Leaderboard(ctx, creatorID, id, 3)
+	require.NoError(t, err)
+	require.Equal(t, 4, board.Participants)
+	require.Len(t, board.Standings, 3)
+	require.Equal(t, first, board.Standings[0].UserID)
+	require.True(t, board.Standings[0].Completed)
+	require.Equal(t, []int{1, 2, 2}, []int{board.Standings[0].Rank, board.Standings[1].Rank, board.Standings[2].Rank})
+	require.Equal(t, second, board.Standings[1].UserID, "при равенстве выше тот, кто вступил раньше")
+	require.False(t, board.Standings[1].Completed)
+
+	require.NotNil(t, board.Me)
+	require.Equal(t, 4, board.Me.Rank)
+	require.Equal(t, 4, board.Me.Value)
+
+	board, err = svc.Leaderboard(ctx, uuid.New(), id, 0)
+	require.NoError(t, err)
+	require.Len(t, board.Standings, 4)
+	require.Nil(t, board.Me)
+}
+
+func TestDelete_OnlyCreatorOrAdmin(t *testing.T) {
+	challenges := newFakeChallenges()
+	svc := challengeuc.NewService(fakeTx{}, challenges)
+	ctx := context.Background()
+	creatorID := uuid.New()
+	listing, err := svc.Create(ctx, creatorID, validInput())
+	require.NoError(t, err)
+	id := listing.Challenge.ID
+
+	err = svc.Delete(ctx, challengeuc.Actor{UserID: uuid.New(), Role: userdomain.RoleUser}, id)
+	require.ErrorIs(t, err, challengeuc.ErrForbidden)
+
+	require.NoError(t, svc.Delete(ctx, challengeuc.Actor{UserID: uuid.New(), Role: userdomain.RoleAdmin}, id))
+	require.Empty(t, challenges.items)
+
+	err = svc.Delete(ctx, challengeuc.Actor{UserID: creatorID, Role: userdomain.RoleUser}, id)
+	require.ErrorIs(t, err, challengeuc.ErrChallengeNotFound)
+}
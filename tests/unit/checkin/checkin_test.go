@@ -0,0 +1,162 @@
+package checkin_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	domain "workout-app/internal/domain/checkin"
+	consentdomain "workout-app/internal/domain/consent"
+	repo "workout-app/internal/repository/interfaces"
+	checkinuc "workout-app/internal/usecase/checkin"
+	consentuc "workout-app/internal/usecase/consent"
+)
+
+// fakeCheckIns хранит анкеты в памяти с ключом «пользователь + день».
+type fakeCheckIns struct {
+	repo.CheckInRepository
+	items map[string]*domain.CheckIn
+}
+
+func key(userID uuid.UUID, day time.Time) string {
+	return userID.String() + day.Format("2006-01-02")
+}
+
+func (r *fakeCheckIns) Upsert(_ context.Context, c *domain.CheckIn) error {
+	if existing, ok := r.items[key(c.UserID, c.Date)]; ok {
+		c.ID = existing.ID
+		c.CreatedAt = existing.CreatedAt
+	}
+	cp := *c
+	r.items[key(c.UserID, c.Date)] = &cp
+	return nil
+}
+
+func (r *fakeCheckIns) ListByUser(_ context.Context, userID uuid.UUID, from, to time.Time) ([]*domain.CheckIn, error) {
+	var out []*domain.CheckIn
+	for _, c := range r.items {
+		if c.UserID == userID && !c.Date.Before(from) && !c.Date.After(to) {
+			out = append(out, c)
+		}
+	}
+	return out, nil
+}
+
+func (r *fakeCheckIns) ListDays(_ context.Context, userID uuid.UUID) ([]time.Time, error) {
+	var days []time.Time
+	for _, c := range r.items {
+		if c.UserID == userID {
+			days = append(days, c.Date)
+		}
+	}
+	return days, nil
+}
+
+type nopPublisher struct{ published int }
+
+func (p *nopPublisher) Publish(context.Context, string, string, any) { p.published++ }
+
+type fakeConsents struct{ err error }
+
+func (c fakeConsents) Require(context.Context, uuid.UUID, uuid.UUID, consentdomain.Scope) error {
+	return c.err
+}
+
+func newService(consentErr error) (checkinuc.Service, *fakeCheckIns, *nopPublisher) {
+	store := &fakeCheckIns{items: map[string]*domain.CheckIn{}}
+	publisher := &nopPublisher{}
+	return checkinuc.NewService(store, publisher, fakeConsents{err: consentErr}), store, publisher
+}
+
+func day(offset int) time.Time {
+	return domain.Day(time.Now()).AddDate(0, 0, offset)
+}
+
+func TestScore(t *testing.T) {
+	require.Equal(t, 100, (&domain.CheckIn{Soreness: 1, Energy: 10, Motivation: 10}).Score())
+	require.Equal(t, 0, (&domain.CheckIn{Soreness: 10, Energy: 1, Motivation: 1}).Score())
+	require.Equal(t, 52, (&domain.CheckIn{Soreness: 5, Energy: 5, Motivation: 6}).Score())
+}
+
+func TestComputeStreak(t *testing.T) {
+	now := time.Date(2026, 10, 15, 9, 0, 0, 0, time.UTC)
+	d := func(offset int) time.Time { return domain.Day(now).AddDate(0, 0, offset) }
+
+	s := domain.ComputeStreak([]time.Time{d(-10), d(-9), d(-8), d(-7), d(-2), d(-1)}, now)
+	require.Equal(t, 2, s.Current, "серия до вчера не прерывается, пока идёт сегодняшний день")
+	require.Equal(t, 4, s.Longest)
+	require.False(t, s.CheckedToday)
+
+	s = domain.ComputeStreak([]time.Time{d(-3), d(-1), d(0), d(0)}, now)
+	require.Equal(t, 2, s.Current)
+	require.True(t, s.CheckedToday)
+
+	s = domain.ComputeStreak([]time.Time{d(-5), d(-4), d(-3)}, now)
+	require.Zero(t, s.Current)
+	require.Equal(t, 3, s.Longest)
+
+	require.Equal(t, domain.Streak{}, domain.ComputeStreak(nil, now))
+}
+
+func TestAssessReadiness_RecommendsDeloadOnPoorWeek(t *testing.T) {
+	now := time.Date(2026, 10, 15, 9, 0, 0, 0, time.UTC)
+	at := func(offset, soreness, energy, motivation int) *domain.CheckIn {
+		return &domain.CheckIn{Date: domain.Day(now).AddDate(0, 0, offset), Soreness: soreness, Energy: energy, Motivation: motivation}
+	}
+
+	r := domain.AssessReadiness([]*domain.CheckIn{at(0, 2, 9, 9), at(-1, 2, 8, 8)}, now)
+	require.Equal(t, domain.RecommendationNone, r.Recommendation, "двух анкет недостаточно для рекомендации")
+
+	r = domain.AssessReadiness([]*domain.CheckIn{at(0, 2, 9, 9), at(-1, 3, 8, 8), at(-3, 2, 9, 8)}, now)
+	require.Equal(t, domain.RecommendationTrain, r.Recommendation)
+
+	r = domain.AssessReadiness([]*domain.CheckIn{at(0, 9, 3, 3), at(-2, 8, 3, 4), at(-4, 9, 2, 3), at(-20, 1, 10, 10)}, now)
+	require.Equal(t, 3, r.CheckIns, "анкеты старше недели не учитываются")
+	require.Equal(t, domain.RecommendationDeload, r.Recommendation)
+}
+
+func TestRecord_ReplacesSameDayAndValidates(t *testing.T) {
+	svc, store, publisher := newService(nil)
+	ctx := context.Background()
+	userID := uuid.New()
+
+	first, err := svc.Record(ctx, userID, checkinuc.RecordInput{Soreness: 3, Energy: 7, Motivation: 8})
+	require.NoError(t, err)
+	second, err := svc.Record(ctx, userID, checkinuc.RecordInput{Soreness: 6, Energy: 5, Motivation: 5, Note: "  плохо спал  "})
+	require.NoError(t, err)
+	require.Equal(t, first.ID, second.ID, "повторная анкета за день заменяет предыдущую")
+	require.Equal(t, "плохо спал", second.Note)
+	require.Len(t, store.items, 1)
+	require.Equal(t, 2, publisher.published)
+
+	yesterday := day(-1)
+	_, err = svc.Record(ctx, userID, checkinuc.RecordInput{Date: &yesterday, Soreness: 2, Energy: 8, Motivation: 8})
+	require.NoError(t, err)
+
+	old := day(-5)
+	_, err = svc.Record(ctx, userID, checkinuc.RecordInput{Date: &old, Soreness: 2, Energy: 8, Motivation: 8})
+	require.ErrorIs(t, err, checkinuc.ErrInvalidDate)
+	_, err = svc.Record(ctx, userID, checkinuc.RecordInput{Soreness: 0, Energy: 8, Motivation: 8})
+	require.ErrorIs(t, err, checkinuc.ErrInvalidRating)
+
+	summary, err := svc.Summary(ctx, userID)
+	require.NoError(t, err)
+	require.Equal(t, 2, summary.Streak.Current)
+	require.True(t, summary.Streak.CheckedToday)
+	require.Equal(t, 2, summary.Readiness.CheckIns)
+}
+
+func TestListForCoach_RequiresWellbeingConsent(t *testing.T) {
+	svc, _, _ := newService(consentuc.ErrConsentRequired)
+	_, err := svc.ListForCoach(context.Background(), uuid.New(), uuid.New(), day(-7), day(0))
+	require.ErrorIs(t, err, consentuc.ErrConsentRequired)
+
+	svc, _, _ = newService(nil)
+	result, err := svc.ListForCoach(context.Background(), uuid.New(), uuid.New(), day(-7), day(0))
+	require.NoError(t, err)
+	require.Empty(t, result.CheckIns)
+	require.Equal(t, domain.RecommendationNone, result.Readiness.Recommendation)
+}
@@ -0,0 +1,102 @@
+package cleanup_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	cleanupuc "workout-app/internal/usecase/cleanup"
+	"workout-app/internal/worker"
+	"workout-app/pkg/logger"
+)
+
+// fakeTarget хранит время истечения записей в памяти.
+type fakeTarget struct {
+	mu      sync.Mutex
+	expires []time.Time
+	calls   int
+	err     error
+}
+
+func (t *fakeTarget) DeleteExpired(_ context.Context, before time.Time, limit int) (int64, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.calls++
+	if t.err != nil {
+		return 0, t.err
+	}
+	var kept []time.Time
+	var removed int64
+	for _, exp := range t.expires {
+		if exp.Before(before) && removed < int64(limit) {
+			removed++
+			continue
+		}
+		kept = append(kept, exp)
+	}
+	t.expires = kept
+	return removed, nil
+}
+
+func newLogger() logger.Logger {
+	return logger.New(io.Discard, slog.LevelError, logger.FormatJSON)
+}
+
+func TestRun_DeletesExpiredInBatchesAndCountsPerTarget(t *testing.T) {
+	past, future := time.Now().Add(-time.Minute), time.Now().Add(time.Hour)
+	codes := &fakeTarget{expires: []time.Time{past, past, past, past, past, future}}
+	broken := &fakeTarget{err: errors.New("db is down")}
+	svc := cleanupuc.NewService(map[string]cleanupuc.Target{
+		"email_verifications": codes,
+		"broken":              broken,
+	}, 2, newLogger())
+
+	removed, err := svc.Run(context.Background())
+	require.Error(t, err)
+	require.Equal(t, int64(5), removed["email_verifications"])
+	require.Equal(t, 3, codes.calls)
+	require.Len(t, codes.expires, 1)
+
+	removed, err = svc.Run(context.Background())
+	require.Error(t, err)
+	require.Zero(t, removed["email_verifications"])
+	require.Equal(t, map[string]int64{"email_verifications": 5, "broken": 0}, svc.Removed())
+}
+
+func TestPeriodic_RunsImmediatelyAndStopsOnRequest(t *testing.T) {
+	runs := make(chan struct{}, 10)
+	calls := 0
+	job := worker.NewPeriodic("test", 10*time.Millisecond, func(context.Context) error {
+		calls++
+		runs <- struct{}{}
+		if calls == 2 {
+			return errors.New("boom")
+		}
+		return nil
+	}, nil, newLogger())
+
+	require.NoError(t, job.Start(context.Background()))
+	for i := 0; i < 3; i++ {
+		select {
+		case <-runs:
+		case <-time.After(time.Second):
+			t.Fatal("periodic job did not run")
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	require.NoError(t, job.Stop(ctx))
+
+	stats := job.Stats()
+	require.GreaterOrEqual(t, stats.Runs, int64(3))
+	require.Equal(t, int64(1), stats.Failures)
+	require.False(t, stats.LastRunAt.IsZero())
+}
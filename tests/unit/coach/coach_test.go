@@ -0,0 +1,188 @@
+package coach_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	domain "workout-app/internal/domain/coach"
+	userdomain "workout-app/internal/domain/user"
+	repo "workout-app/internal/repository/interfaces"
+	coachuc "workout-app/internal/usecase/coach"
+)
+
+type fakeProfiles struct {
+	profiles   map[uuid.UUID]*domain.Profile
+	lastFilter repo.CoachDirectoryFilter
+}
+
+func newFakeProfiles() *fakeProfiles {
+	return &fakeProfiles{profiles: map[uuid.UUID]*domain.Profile{}}
+}
+
+func (r *fakeProfiles) Get(_ context.Context, userID uuid.UUID) (*domain.Profile, error) {
+	p, ok := r.profiles[userID]
+	if !ok {
+		return nil, repo.ErrNotFound
+	}
+	cp := *p
+	return &cp, nil
+}
+
+func (r *fakeProfiles) Save(_ context.Context, p *domain.Profile) error {
+	cp := *p
+	if existing, ok := r.profiles[p.UserID]; ok {
+		cp.Verification = existing.Verification
+	}
+	r.profiles[p.UserID] = &cp
+	return nil
+}
+
+func (r *fakeProfiles) SetVerification(_ context.Context, userID uuid.UUID, v *domain.Verification) error {
+	p, ok := r.profiles[userID]
+	if !ok {
+		p = &domain.Profile{UserID: userID}
+		r.profiles[userID] = p
+	}
+	p.Verification = v
+	return nil
+}
+
+func (r *fakeProfiles) ListDirectory(_ context.Context, filter repo.CoachDirectoryFilter) ([]*domain.DirectoryEntry, int64, error) {
+	r.lastFilter = filter
+	return nil, 0, nil
+}
+
+func (r *fakeProfiles) DeleteByUserID(_ context.Context, userID uuid.UUID) error {
+	delete(r.profiles, userID)
+	return nil
+}
+
+type fakeUsers struct {
+	repo.UserRepository
+	users map[uuid.UUID]*userdomain.User
+}
+
+func (r *fakeUsers) GetByID(_ context.Context, id uuid.UUID) (*userdomain.User, error) {
+	u, ok := r.users[id]
+	if !ok {
+		return nil, repo.ErrNotFound
+	}
+	return u, nil
+}
+
+func newFixture(role userdomain.Role) (coachuc.Service, *fakeProfiles, *userdomain.User) {
+	u := userdomain.NewUser("coach@example.com", "hash", "coach1")
+	u.Role = role
+	profiles := newFakeProfiles()
+	svc := coachuc.NewService(profiles, &fakeUsers{users: map[uuid.UUID]*userdomain.User{u.ID: u}})
+	return svc, profiles, u
+}
+
+func TestUpdateProfile_NormalizesInput(t *testing.T) {
+	svc, _, u := newFixture(userdomain.RoleCoach)
+	ctx := context.Background()
+
+	p, err := svc.UpdateProfile(ctx, u.ID, coachuc.ProfileInput{
+		Listed:      true,
+		Headline:    "  Strength coach  ",
+		Specialties: []domain.Specialty{domain.SpecialtyStrength, domain.SpecialtyMobility, domain.SpecialtyStrength},
+		Languages:   []string{"EN", "ru", "en"},
+		Country:     "de",
+		City:        " Berlin ",
+		Online:      true,
+	})
+	require.NoError(t, err)
+	require.Equal(t, "Strength coach", p.Headline)
+	require.Equal(t, []domain.Specialty{domain.SpecialtyStrength, domain.SpecialtyMobility}, p.Specialties)
+	require.Equal(t, []string{"en", "ru"}, p.Languages)
+	require.Equal(t, "DE", p.Country)
+	require.Equal(t, "Berlin", p.City)
+
+	got, err := svc.GetProfile(ctx, u.ID)
+	require.NoError(t, err)
+	require.True(t, got.Listed)
+}
+
+func TestUpdateProfile_Validation(t *testing.T) {
+	svc, _, u := newFixture(userdomain.RoleCoach)
+	ctx := context.Background()
+
+	_, err := svc.UpdateProfile(ctx, u.ID, coachuc.ProfileInput{Listed: true, Headline: "Coach"})
+	require.ErrorIs(t, err, coachuc.ErrIncompleteProfile)
+
+	// Неопубликованную анкету можно сохранить незаполненной.
+	_, err = svc.UpdateProfile(ctx, u.ID, coachuc.ProfileInput{})
+	require.NoError(t, err)
+
+	_, err = svc.UpdateProfile(ctx, u.ID, coachuc.ProfileInput{Specialties: []domain.Specialty{"yoga-ish"}})
+	require.ErrorIs(t, err, coachuc.ErrInvalidSpecialty)
+
+	_, err = svc.UpdateProfile(ctx, u.ID, coachuc.ProfileInput{Languages: []string{"eng"}})
+	require.ErrorIs(t, err, coachuc.ErrInvalidLanguage)
+
+	_, err = svc.UpdateProfile(ctx, u.ID, coachuc.ProfileInput{Country: "Germany"})
+	require.ErrorIs(t, err, coachuc.ErrInvalidCountry)
+
+	_, err = svc.UpdateProfile(ctx, u.ID, coachuc.ProfileInput{Languages: []string{"en", "ru", "de", "fr", "es", "it"}})
+	require.ErrorIs(t, err, coachuc.ErrTooManyLanguages)
+}
+
+func TestVerify_KeepsBadgeAcrossProfileUpdates(t *testing.T) {
+	svc, _, u := newFixture(userdomain.RoleCoach)
+	ctx := context.Background()
+	adminID := uuid.New()
+
+	p, err := svc.Verify(ctx, adminID, u.ID, "certificate checked")
+	require.NoError(t, err)
+	require.True(t, p.IsVerified())
+	require.False(t, p.Listed)
+	require.Equal(t, adminID, *p.Verification.VerifiedBy)
+
+	p, err = svc.UpdateProfile(ctx, u.ID, coachuc.ProfileInput{
+		Listed: true, Headline: "Coach", Specialties: []domain.Specialty{domain.SpecialtyNutrition},
+	})
+	require.NoError(t, err)
+	require.True(t, p.IsVerified())
+
+	require.NoError(t, svc.Unverify(ctx, u.ID))
+	p, err = svc.GetProfile(ctx, u.ID)
+	require.NoError(t, err)
+	require.False(t, p.IsVerified())
+	require.True(t, p.Listed)
+}
+
+func TestVerify_RejectsNonCoach(t *testing.T) {
+	svc, _, u := newFixture(userdomain.RoleUser)
+
+	_, err := svc.Verify(context.Background(), uuid.New(), u.ID, "")
+	require.ErrorIs(t, err, coachuc.ErrNotCoach)
+
+	_, err = svc.Verify(context.Background(), uuid.New(), uuid.New(), "")
+	require.ErrorIs(t, err, repo.ErrNotFound)
+}
+
+func TestListDirectory_FiltersAndPagination(t *testing.T) {
+	svc, profiles, _ := newFixture(userdomain.RoleCoach)
+	ctx := context.Background()
+
+	_, _, err := svc.ListDirectory(ctx, coachuc.DirectoryQuery{
+		Specialty: domain.SpecialtyPowerlifting,
+		Language:  "EN",
+		Country:   "us",
+		Limit:     1000,
+	})
+	require.NoError(t, err)
+	require.Equal(t, "en", profiles.lastFilter.Language)
+	require.Equal(t, "US", profiles.lastFilter.Country)
+	require.Equal(t, 100, profiles.lastFilter.Limit)
+
+	_, _, err = svc.ListDirectory(ctx, coachuc.DirectoryQuery{})
+	require.NoError(t, err)
+	require.Equal(t, 20, profiles.lastFilter.Limit)
+
+	_, _, err = svc.ListDirectory(ctx, coachuc.DirectoryQuery{Specialty: "unknown"})
+	require.ErrorIs(t, err, coachuc.ErrInvalidSpecialty)
+}
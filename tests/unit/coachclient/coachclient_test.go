@@ -0,0 +1,250 @@
+package coachclient_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	domain "workout-app/internal/domain/coach"
+	userdomain "workout-app/internal/domain/user"
+	repo "workout-app/internal/repository/interfaces"
+	coachclientuc "workout-app/internal/usecase/coachclient"
+	"workout-app/pkg/mailer"
+)
+
+type fakeTx struct{}
+
+func (fakeTx) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+type clientKey struct{ coach, client uuid.UUID }
+
+// fakeClients хранит приглашения и связи «тренер — клиент» в памяти.
+type fakeClients struct {
+	repo.CoachClientRepository
+	invitations map[uuid.UUID]*domain.Invitation
+	clients     map[clientKey]*domain.Client
+}
+
+func (r *fakeClients) CreateInvitation(_ context.Context, inv *domain.Invitation) error {
+	for id, existing := range r.invitations {
+		if existing.CoachID == inv.CoachID && existing.ClientID == inv.ClientID {
+			if !existing.IsExpired(inv.CreatedAt) {
+				return repo.ErrCoachInvitationExists
+			}
+			delete(r.invitations, id)
+		}
+	}
+	r.invitations[inv.ID] = inv
+	return nil
+}
+
+func (r *fakeClients) GetInvitation(_ context.Context, id uuid.UUID) (*domain.Invitation, error) {
+	inv, ok := r.invitations[id]
+	if !ok {
+		return nil, repo.ErrNotFound
+	}
+	return inv, nil
+}
+
+func (r *fakeClients) ListInvitationsByCoach(_ context.Context, coachID uuid.UUID, now time.Time) ([]*domain.Invitation, error) {
+	var out []*domain.Invitation
+	for _, inv := range r.invitations {
+		if inv.CoachID == coachID && !inv.IsExpired(now) {
+			out = append(out, inv)
+		}
+	}
+	return out, nil
+}
+
+func (r *fakeClients) ListInvitationsByClient(_ context.Context, clientID uuid.UUID, now time.Time) ([]*domain.Invitation, error) {
+	var out []*domain.Invitation
+	for _, inv := range r.invitations {
+		if inv.ClientID == clientID && !inv.IsExpired(now) {
+			out = append(out, inv)
+		}
+	}
+	return out, nil
+}
+
+func (r *fakeClients) DeleteInvitation(_ context.Context, id uuid.UUID) error {
+	if _, ok := r.invitations[id]; !ok {
+		return repo.ErrNotFound
+	}
+	delete(r.invitations, id)
+	return nil
+}
+
+func (r *fakeClients) AddClient(_ context.Context, c *domain.Client) error {
+	r.clients[clientKey{c.CoachID, c.ClientID}] = c
+	return nil
+}
+
+func (r *fakeClients) IsClient(_ context.Context, coachID, clientID uuid.UUID) (bool, error) {
+	_, ok := r.clients[clientKey{coachID, clientID}]
+	return ok, nil
+}
+
+type fakeUsers struct {
+	repo.UserRepository
+	users map[uuid.UUID]*userdomain.User
+}
+
+func (r *fakeUsers) add(u *userdomain.User) *userdomain.User {
+	r.users[u.ID] = u
+	return u
+}
+
+func (r *fakeUsers) GetByID(_ context.Context, id uuid.UUID) (*userdomain.User, error) {
+	u, ok := r.users[id]
+	if !ok {
+		return nil, repo.ErrNotFound
+	}
+	return u, nil
+}
+
+func (r *fakeUsers) GetByEmail(_ context.Context, email string) (*userdomain.User, error) {
+	for _, u := range r.users {
+		if u.Email == email {
+			return u, nil
+		}
+	}
+	return nil, repo.ErrNotFound
+}
+
+type sentInvitation struct {
+	email, coachName, locale string
+}
+
+type fakeSender struct {
+	mailer.EmailSender
+	sent []sentInvitation
+}
+
+func (s *fakeSender) SendCoachInvitation(ctx context.Context, email, coachName string, _ time.Time) error {
+	s.sent = append(s.sent, sentInvitation{email: email, coachName: coachName, locale: mailer.LocaleFromContext(ctx)})
+	return nil
+}
+
+type fixture struct {
+	svc     coachclientuc.Service
+	clients *fakeClients
+	users   *fakeUsers
+	sender  *fakeSender
+	coach   *userdomain.User
+	client  *userdomain.User
+}
+
+func newFixture() *fixture {
+	clients := &fakeClients{invitations: map[uuid.UUID]*domain.Invitation{}, clients: map[clientKey]*domain.Client{}}
+	users := &fakeUsers{users: map[uuid.UUID]*userdomain.User{}}
+	sender := &fakeSender{}
+	return &fixture{
+		svc:     coachclientuc.NewService(fakeTx{}, clients, users, sender),
+		clients: clients,
+		users:   users,
+		sender:  sender,
+		coach:   users.add(&userdomain.User{ID: uuid.New(), Email: "coach@example.com", Username: "coach", FirstName: "Анна", LastName: "Иванова"}),
+		client:  users.add(&userdomain.User{ID: uuid.New(), Email: "client@example.com", Username: "client", Language: "ru"}),
+	}
+}
+
+func TestInvite_SendsEmail(t *testing.T) {
+	f := newFixture()
+
+	inv, err := f.svc.Invite(context.Background(), f.coach.ID, " Client@Example.com ")
+	require.NoError(t, err)
+	require.Equal(t, f.client.ID, inv.ClientID)
+	require.Equal(t, "client@example.com", inv.Email)
+	require.Contains(t, f.clients.invitations, inv.ID)
+	require.Equal(t, []sentInvitation{{email: "client@example.com", coachName: "Анна Иванова", locale: "ru"}}, f.sender.sent)
+
+	_, err = f.svc.Invite(context.Background(), f.coach.ID, "client@example.com")
+	require.ErrorIs(t, err, coachclientuc.ErrAlreadyInvited)
+	require.Len(t, f.sender.sent, 1)
+}
+
+func TestInvite_Rejects(t *testing.T) {
+	f := newFixture()
+	ctx := context.Background()
+
+	_, err := f.svc.Invite(ctx, f.coach.ID, "nobody@example.com")
+	require.ErrorIs(t, err, coachclientuc.ErrUserNotFound)
+
+	deletedAt := time.Now()
+	f.users.add(&userdomain.User{ID: uuid.New(), Email: "gone@example.com", DeletedAt: &deletedAt})
+	_, err = f.svc.Invite(ctx, f.coach.ID, "gone@example.com")
+	require.ErrorIs(t, err, coachclientuc.ErrUserNotFound)
+
+	_, err = f.svc.Invite(ctx, f.coach.ID, "coach@example.com")
+	require.ErrorIs(t, err, coachclientuc.ErrCannotInviteSelf)
+
+	f.clients.clients[clientKey{f.coach.ID, f.client.ID}] = &domain.Client{CoachID: f.coach.ID, ClientID: f.client.ID}
+	_, err = f.svc.Invite(ctx, f.coach.ID, "client@example.com")
+	require.ErrorIs(t, err, coachclientuc.ErrAlreadyClient)
+
+	require.Empty(t, f.sender.sent)
+}
+
+func TestInvite_ReplacesExpiredInvitation(t *testing.T) {
+	f := newFixture()
+	old := domain.NewInvitation(f.coach.ID, f.client.ID, f.client.Email, time.Now().Add(-8*24*time.Hour), 7*24*time.Hour)
+	f.clients.invitations[old.ID] = old
+
+	inv, err := f.svc.Invite(context.Background(), f.coach.ID, f.client.Email)
+	require.NoError(t, err)
+	require.NotContains(t, f.clients.invitations, old.ID)
+	require.Contains(t, f.clients.invitations, inv.ID)
+}
+
+func TestAcceptInvitation(t *testing.T) {
+	f := newFixture()
+	ctx := context.Background()
+	inv, err := f.svc.Invite(ctx, f.coach.ID, f.client.Email)
+	require.NoError(t, err)
+
+	received, err := f.svc.ListInvitations(ctx, f.client.ID)
+	require.NoError(t, err)
+	require.Len(t, received, 1)
+	require.Equal(t, f.coach.ID, received[0].Coach.ID)
+
+	other := f.users.add(&userdomain.User{ID: uuid.New(), Email: "other@example.com"})
+	_, err = f.svc.AcceptInvitation(ctx, other.ID, inv.ID)
+	require.ErrorIs(t, err, coachclientuc.ErrInvitationNotFound)
+
+	client, err := f.svc.AcceptInvitation(ctx, f.client.ID, inv.ID)
+	require.NoError(t, err)
+	require.Equal(t, f.coach.ID, client.CoachID)
+	require.Contains(t, f.clients.clients, clientKey{f.coach.ID, f.client.ID})
+	require.Empty(t, f.clients.invitations)
+
+	_, err = f.svc.Invite(ctx, f.coach.ID, f.client.Email)
+	require.ErrorIs(t, err, coachclientuc.ErrAlreadyClient)
+}
+
+func TestDeclineInvitation(t *testing.T) {
+	f := newFixture()
+	ctx := context.Background()
+	inv, err := f.svc.Invite(ctx, f.coach.ID, f.client.Email)
+	require.NoError(t, err)
+
+	require.NoError(t, f.svc.DeclineInvitation(ctx, f.client.ID, inv.ID))
+	require.Empty(t, f.clients.invitations)
+	require.Empty(t, f.clients.clients)
+
+	require.ErrorIs(t, f.svc.DeclineInvitation(ctx, f.client.ID, inv.ID), coachclientuc.ErrInvitationNotFound)
+}
+
+func TestAcceptInvitation_Expired(t *testing.T) {
+	f := newFixture()
+	inv := domain.NewInvitation(f.coach.ID, f.client.ID, f.client.Email, time.Now().Add(-8*24*time.Hour), 7*24*time.Hour)
+	f.clients.invitations[inv.ID] = inv
+
+	_, err := f.svc.AcceptInvitation(context.Background(), f.client.ID, inv.ID)
+	require.ErrorIs(t, err, coachclientuc.ErrInvitationNotFound)
+	require.Empty(t, f.clients.clients)
+}
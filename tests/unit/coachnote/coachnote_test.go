@@ -0,0 +1,206 @@
+package coachnote_test
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	domain "workout-app/internal/domain/coach"
+	repo "workout-app/internal/repository/interfaces"
+	coachnoteuc "workout-app/internal/usecase/coachnote"
+)
+
+type fakeClients struct {
+	repo.CoachClientRepository
+	clients map[uuid.UUID]uuid.UUID // clientID -> coachID
+}
+
+func (f *fakeClients) IsClient(_ context.Context, coachID, clientID uuid.UUID) (bool, error) {
+	return f.clients[clientID] == coachID, nil
+}
+
+type pairKey struct{ coach, client uuid.UUID }
+
+type fakeNotes struct {
+	notes map[uuid.UUID]*domain.Note
+	tags  map[pairKey][]string
+}
+
+func newFakeNotes() *fakeNotes {
+	return &fakeNotes{notes: map[uuid.UUID]*domain.Note{}, tags: map[pairKey][]string{}}
+}
+
+func (f *fakeNotes) CreateNote(_ context.Context, n *domain.Note) error {
+	f.notes[n.ID] = n
+	return nil
+}
+
+func (f *fakeNotes) GetNote(_ context.Context, coachID, clientID, id uuid.UUID) (*domain.Note, error) {
+	n, ok := f.notes[id]
+	if !ok || n.CoachID != coachID || n.ClientID != clientID {
+		return nil, repo.ErrNotFound
+	}
+	copied := *n
+	return &copied, nil
+}
+
+func (f *fakeNotes) UpdateNote(ctx context.Context, n *domain.Note) error {
+	if _, err := f.GetNote(ctx, n.CoachID, n.ClientID, n.ID); err != nil {
+		return err
+	}
+	f.notes[n.ID] = n
+	return nil
+}
+
+func (f *fakeNotes) DeleteNote(ctx context.Context, coachID, clientID, id uuid.UUID) error {
+	if _, err := f.GetNote(ctx, coachID, clientID, id); err != nil {
+		return err
+	}
+	delete(f.notes, id)
+	return nil
+}
+
+func (f *fakeNotes) ListNotes(_ context.Context, coachID, clientID uuid.UUID) ([]*domain.Note, error) {
+	var result []*domain.Note
+	for _, n := range f.notes {
+		if n.CoachID == coachID && n.ClientID == clientID {
+			result = append(result, n)
+		}
+	}
+	return result, nil
+}
+
+func (f *fakeNotes) ReplaceTags(_ context.Context, coachID, clientID uuid.UUID, tags []string) error {
+	f.tags[pairKey{coachID, clientID}] = slices.Clone(tags)
+	return nil
+}
+
+func (f *fakeNotes) ListTags(_ context.Context, coachID, clientID uuid.UUID) ([]string, error) {
+	tags := slices.Clone(f.tags[pairKey{coachID, clientID}])
+	slices.Sort(tags)
+	return tags, nil
+}
+
+func (f *fakeNotes) ListTagsByClient(_ context.Context, coachID uuid.UUID) (map[uuid.UUID][]string, error) {
+	result := map[uuid.UUID][]string{}
+	for k, tags := range f.tags {
+		if k.coach == coachID {
+			result[k.client] = tags
+		}
+	}
+	return result, nil
+}
+
+func (f *fakeNotes) SearchClients(_ context.Context, coachID uuid.UUID, query string) ([]uuid.UUID, error) {
+	var result []uuid.UUID
+	for _, n := range f.notes {
+		if n.CoachID == coachID && strings.Contains(strings.ToLower(n.Body), strings.ToLower(query)) {
+			result = append(result, n.ClientID)
+		}
+	}
+	return result, nil
+}
+
+func (f *fakeNotes) DeleteByRelationship(_ context.Context, coachID, clientID uuid.UUID) error {
+	for id, n := range f.notes {
+		if n.CoachID == coachID && n.ClientID == clientID {
+			delete(f.notes, id)
+		}
+	}
+	delete(f.tags, pairKey{coachID, clientID})
+	return nil
+}
+
+func (f *fakeNotes) DeleteByUserID(_ context.Context, userID uuid.UUID) error {
+	for id, n := range f.notes {
+		if n.CoachID == userID || n.ClientID == userID {
+			delete(f.notes, id)
+		}
+	}
+	for k := range f.tags {
+		if k.coach == userID || k.client == userID {
+			delete(f.tags, k)
+		}
+	}
+	return nil
+}
+
+func newService(clientID, coachID uuid.UUID) (coachnoteuc.Service, *fakeNotes) {
+	notes := newFakeNotes()
+	clients := &fakeClients{clients: map[uuid.UUID]uuid.UUID{clientID: coachID}}
+	return coachnoteuc.NewService(notes, clients), notes
+}
+
+func TestNotes_CRUDScopedToCoach(t *testing.T) {
+	ctx := context.Background()
+	clientID, coachID := uuid.New(), uuid.New()
+	svc, _ := newService(clientID, coachID)
+
+	n, err := svc.CreateNote(ctx, coachID, clientID, "  Болит левое колено  ")
+	require.NoError(t, err)
+	require.Equal(t, "Болит левое колено", n.Body)
+
+	updated, err := svc.UpdateNote(ctx, coachID, clientID, n.ID, "Колено прошло")
+	require.NoError(t, err)
+	require.Equal(t, "Колено прошло", updated.Body)
+	require.Equal(t, n.CreatedAt, updated.CreatedAt)
+
+	notes, err := svc.ListNotes(ctx, coachID, clientID)
+	require.NoError(t, err)
+	require.Len(t, notes, 1)
+
+	// Другой тренер не видит ни клиента, ни его заметок.
+	stranger := uuid.New()
+	_, err = svc.ListNotes(ctx, stranger, clientID)
+	require.ErrorIs(t, err, coachnoteuc.ErrNotCoachClient)
+	_, err = svc.CreateNote(ctx, stranger, clientID, "чужая заметка")
+	require.ErrorIs(t, err, coachnoteuc.ErrNotCoachClient)
+
+	require.NoError(t, svc.DeleteNote(ctx, coachID, clientID, n.ID))
+	require.ErrorIs(t, svc.DeleteNote(ctx, coachID, clientID, n.ID), coachnoteuc.ErrNoteNotFound)
+	_, err = svc.UpdateNote(ctx, coachID, clientID, n.ID, "снова")
+	require.ErrorIs(t, err, coachnoteuc.ErrNoteNotFound)
+}
+
+func TestNotes_RejectsEmptyAndTooLongBody(t *testing.T) {
+	ctx := context.Background()
+	clientID, coachID := uuid.New(), uuid.New()
+	svc, _ := newService(clientID, coachID)
+
+	_, err := svc.CreateNote(ctx, coachID, clientID, "   ")
+	require.ErrorIs(t, err, coachnoteuc.ErrInvalidNote)
+	_, err = svc.CreateNote(ctx, coachID, clientID, strings.Repeat("я", domain.MaxNoteLength+1))
+	require.ErrorIs(t, err, coachnoteuc.ErrInvalidNote)
+	_, err = svc.CreateNote(ctx, coachID, clientID, strings.Repeat("я", domain.MaxNoteLength))
+	require.NoError(t, err)
+}
+
+func TestSetTags_NormalizesAndValidates(t *testing.T) {
+	ctx := context.Background()
+	clientID, coachID := uuid.New(), uuid.New()
+	svc, _ := newService(clientID, coachID)
+
+	tags, err := svc.SetTags(ctx, coachID, clientID, []string{"Марафон  2027", "injury", "INJURY", "off-season"})
+	require.NoError(t, err)
+	require.Equal(t, []string{"injury", "off-season", "марафон 2027"}, tags)
+
+	_, err = svc.SetTags(ctx, coachID, clientID, []string{"vip!"})
+	require.ErrorIs(t, err, coachnoteuc.ErrInvalidTag)
+	_, err = svc.SetTags(ctx, coachID, clientID, []string{" "})
+	require.ErrorIs(t, err, coachnoteuc.ErrInvalidTag)
+
+	many := make([]string, 0, domain.MaxClientTags+1)
+	for i := range domain.MaxClientTags + 1 {
+		many = append(many, strings.Repeat("t", i+1))
+	}
+	_, err = svc.SetTags(ctx, coachID, clientID, many)
+	require.ErrorIs(t, err, coachnoteuc.ErrTooManyTags)
+
+	tags, err = svc.SetTags(ctx, coachID, clientID, []string{})
+	require.NoError(t, err)
+	require.Empty(t, tags)
+}
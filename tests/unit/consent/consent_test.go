@@ -0,0 +1,169 @@
+package consent_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	domain "workout-app/internal/domain/consent"
+	repo "workout-app/internal/repository/interfaces"
+	consentuc "workout-app/internal/usecase/consent"
+)
+
+type consentKey struct{ client, coach uuid.UUID }
+
+type fakeConsentRepo struct {
+	items map[consentKey]*domain.Consent
+}
+
+func (f *fakeConsentRepo) Get(_ context.Context, clientID, coachID uuid.UUID) (*domain.Consent, error) {
+	c, ok := f.items[consentKey{clientID, coachID}]
+	if !ok {
+		return nil, repo.ErrNotFound
+	}
+	return c, nil
+}
+
+func (f *fakeConsentRepo) ListByClient(_ context.Context, clientID uuid.UUID) ([]*domain.Consent, error) {
+	var result []*domain.Consent
+	for k, c := range f.items {
+		if k.client == clientID {
+			result = append(result, c)
+		}
+	}
+	return result, nil
+}
+
+func (f *fakeConsentRepo) Upsert(_ context.Context, c *domain.Consent) error {
+	f.items[consentKey{c.ClientID, c.CoachID}] = c
+	return nil
+}
+
+func (f *fakeConsentRepo) Delete(_ context.Context, clientID, coachID uuid.UUID) error {
+	if _, ok := f.items[consentKey{clientID, coachID}]; !ok {
+		return repo.ErrNotFound
+	}
+	delete(f.items, consentKey{clientID, coachID})
+	return nil
+}
+
+func (f *fakeConsentRepo) DeleteByUserID(_ context.Context, userID uuid.UUID) error {
+	for k := range f.items {
+		if k.client == userID || k.coach == userID {
+			delete(f.items, k)
+		}
+	}
+	return nil
+}
+
+// fakeNoteRepo запоминает пары, для которых удалены заметки тренера; остальные методы не используются.
+type fakeNoteRepo struct {
+	repo.CoachNoteRepository
+	wiped []consentKey
+}
+
+func (f *fakeNoteRepo) DeleteByRelationship(_ context.Context, coachID, clientID uuid.UUID) error {
+	f.wiped = append(f.wiped, consentKey{clientID, coachID})
+	return nil
+}
+
+// fakeClientRepo хранит связи «тренер — клиент» и запоминает удалённые; остальные методы не используются.
+type fakeClientRepo struct {
+	repo.CoachClientRepository
+	clients map[consentKey]bool
+	ended   []consentKey
+}
+
+func (f *fakeClientRepo) IsClient(_ context.Context, coachID, clientID uuid.UUID) (bool, error) {
+	return f.clients[consentKey{clientID, coachID}], nil
+}
+
+func (f *fakeClientRepo) DeleteClient(_ context.Context, coachID, clientID uuid.UUID) error {
+	delete(f.clients, consentKey{clientID, coachID})
+	f.ended = append(f.ended, consentKey{clientID, coachID})
+	return nil
+}
+
+func newService(clientID, coachID uuid.UUID) consentuc.Service {
+	svc, _, _ := newServiceWithNotes(clientID, coachID)
+	return svc
+}
+
+func newServiceWithNotes(clientID, coachID uuid.UUID) (consentuc.Service, *fakeNoteRepo, *fakeClientRepo) {
+	notes := &fakeNoteRepo{}
+	clients := &fakeClientRepo{clients: map[consentKey]bool{{clientID, coachID}: true}}
+	return consentuc.NewService(
+		&fakeConsentRepo{items: map[consentKey]*domain.Consent{}},
+		notes,
+		clients,
+	), notes, clients
+}
+
+func TestRequire_DeniedWithoutConsent(t *testing.T) {
+	ctx := context.Background()
+	clientID, coachID := uuid.New(), uuid.New()
+	svc := newService(clientID, coachID)
+
+	err := svc.Require(ctx, coachID, clientID, domain.ScopeWorkouts)
+	require.ErrorIs(t, err, consentuc.ErrConsentRequired)
+}
+
+func TestRequire_OnlyGrantedScopes(t *testing.T) {
+	ctx := context.Background()
+	clientID, coachID := uuid.New(), uuid.New()
+	svc := newService(clientID, coachID)
+
+	c, err := svc.Set(ctx, clientID, coachID, []domain.Scope{domain.ScopeMeasurements, domain.ScopeMeasurements})
+	require.NoError(t, err)
+	require.Equal(t, []domain.Scope{domain.ScopeMeasurements}, c.Scopes)
+
+	require.NoError(t, svc.Require(ctx, coachID, clientID, domain.ScopeMeasurements))
+	require.ErrorIs(t, svc.Require(ctx, coachID, clientID, domain.ScopeWorkouts), consentuc.ErrConsentRequired)
+
+	// После отзыва доступ закрыт полностью.
+	require.NoError(t, svc.Revoke(ctx, clientID, coachID))
+	require.ErrorIs(t, svc.Require(ctx, coachID, clientID, domain.ScopeMeasurements), consentuc.ErrNotCoachClient)
+}
+
+func TestSet_RejectsUnknownScopeAndForeignCoach(t *testing.T) {
+	ctx := context.Background()
+	clientID, coachID := uuid.New(), uuid.New()
+	svc := newService(clientID, coachID)
+
+	_, err := svc.Set(ctx, clientID, coachID, []domain.Scope{"messages"})
+	require.ErrorIs(t, err, consentuc.ErrInvalidScope)
+
+	stranger := uuid.New()
+	_, err = svc.Set(ctx, clientID, stranger, []domain.Scope{domain.ScopeWorkouts})
+	require.ErrorIs(t, err, consentuc.ErrNotCoachClient)
+	require.ErrorIs(t, svc.Require(ctx, stranger, clientID, domain.ScopeWorkouts), consentuc.ErrNotCoachClient)
+}
+
+func TestRevoke_WipesCoachNotes(t *testing.T) {
+	ctx := context.Background()
+	clientID, coachID := uuid.New(), uuid.New()
+	svc, notes, clients := newServiceWithNotes(clientID, coachID)
+
+	_, err := svc.Set(ctx, clientID, coachID, []domain.Scope{domain.ScopeWorkouts})
+	require.NoError(t, err)
+	require.NoError(t, svc.Revoke(ctx, clientID, coachID))
+	require.Equal(t, []consentKey{{clientID, coachID}}, notes.wiped)
+	require.Equal(t, []consentKey{{clientID, coachID}}, clients.ended)
+}
+
+func TestRevoke_EndsRelationship(t *testing.T) {
+	ctx := context.Background()
+	clientID, coachID := uuid.New(), uuid.New()
+	svc := newService(clientID, coachID)
+
+	_, err := svc.Set(ctx, clientID, coachID, []domain.Scope{domain.ScopeWorkouts})
+	require.NoError(t, err)
+	require.NoError(t, svc.Revoke(ctx, clientID, coachID))
+
+	// Без принятого приглашения тренер больше не считается тренером клиента.
+	require.ErrorIs(t, svc.Require(ctx, coachID, clientID, domain.ScopeWorkouts), consentuc.ErrNotCoachClient)
+	_, err = svc.Set(ctx, clientID, coachID, []domain.Scope{domain.ScopeWorkouts})
+	require.ErrorIs(t, err, consentuc.ErrNotCoachClient)
+}
@@ -0,0 +1,191 @@
+package custommetric_test
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	domain "workout-app/internal/domain/custommetric"
+	userdomain "workout-app/internal/domain/user"
+	repo "workout-app/internal/repository/interfaces"
+	custommetricuc "workout-app/internal/usecase/custommetric"
+)
+
+// fakeMetrics хранит метрики и значения в памяти.
+type fakeMetrics struct {
+	repo.CustomMetricRepository
+	defs    map[uuid.UUID]*domain.Definition
+	entries []*domain.Entry
+}
+
+func newFakeMetrics() *fakeMetrics {
+	return &fakeMetrics{defs: map[uuid.UUID]*domain.Definition{}}
+}
+
+func (r *fakeMetrics) Create(_ context.Context, d *domain.Definition) error {
+	for _, existing := range r.defs {
+		if existing.UserID == d.UserID && strings.EqualFold(existing.Name, d.Name) {
+			return repo.ErrCustomMetricExists
+		}
+	}
+	r.defs[d.ID] = d
+	return nil
+}
+
+func (r *fakeMetrics) GetByID(_ context.Context, userID, id uuid.UUID) (*domain.Definition, error) {
+	d, ok := r.defs[id]
+	if !ok || d.UserID != userID {
+		return nil, repo.ErrNotFound
+	}
+	return d, nil
+}
+
+func (r *fakeMetrics) CountByUser(_ context.Context, userID uuid.UUID) (int64, error) {
+	var n int64
+	for _, d := range r.defs {
+		if d.UserID == userID {
+			n++
+		}
+	}
+	return n, nil
+}
+
+func (r *fakeMetrics) AddEntry(_ context.Context, e *domain.Entry) error {
+	r.entries = append(r.entries, e)
+	return nil
+}
+
+func (r *fakeMetrics) ListEntries(_ context.Context, metricID uuid.UUID, from, to time.Time, limit int) ([]*domain.Entry, error) {
+	var out []*domain.Entry
+	for _, e := range r.entries {
+		if e.DefinitionID == metricID && !e.RecordedAt.Before(from) && e.RecordedAt.Before(to) {
+			out = append(out, e)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].RecordedAt.Before(out[j].RecordedAt) })
+	if len(out) > limit {
+		out = out[:limit]
+	}
+	return out, nil
+}
+
+// fakeUsers возвращает любого пользователя с заданным часовым поясом.
+type fakeUsers struct {
+	repo.UserRepository
+	timezone string
+}
+
+func (r fakeUsers) GetByID(_ context.Context, id uuid.UUID) (*userdomain.User, error) {
+	return &userdomain.User{ID: id, Timezone: r.timezone}, nil
+}
+
+func TestAggregate_GroupsByWeekAndPicksBestByDirection(t *testing.T) {
+	def := domain.New(uuid.New(), "Время на 500 м", "с", false)
+	// 2026-03-02 — понедельник.
+	at := func(day, hour int) time.Time { return time.Date(2026, 3, day, hour, 0, 0, 0, time.UTC) }
+	entries := []*domain.Entry{
+		domain.NewEntry(def, 100, at(10, 9), ""),
+		domain.NewEntry(def, 110, at(2, 9), ""),
+		domain.NewEntry(def, 104, at(8, 20), ""),
+		domain.NewEntry(def, 98, at(11, 9), ""),
+	}
+
+	points, summary := domain.Aggregate(def, entries, domain.BucketWeek, time.UTC)
+
+	require.Len(t, points, 2)
+	require.Equal(t, at(2, 0), points[0].Start)
+	require.Equal(t, 2, points[0].Count)
+	require.InDelta(t, 107, points[0].Avg, 1e-9)
+	require.Equal(t, 104.0, points[0].Last)
+	require.Equal(t, at(9, 0), points[1].Start)
+	require.Equal(t, 98.0, points[1].Min)
+	require.Equal(t, 100.0, points[1].Max)
+
+	require.Equal(t, 4, summary.Count)
+	require.Equal(t, 110.0, summary.First)
+	require.Equal(t, 98.0, summary.Last)
+	require.Equal(t, 98.0, summary.Best, "для «меньше — лучше» лучшим считается минимум")
+	require.Equal(t, -12.0, summary.Change)
+}
+
+func TestBucketStart_Month(t *testing.T) {
+	got := domain.BucketMonth.Start(time.Date(2026, 2, 28, 23, 0, 0, 0, time.UTC), time.UTC)
+	require.Equal(t, time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC), got)
+
+	// 28 февраля 23:00 UTC в Москве — уже 1 марта.
+	moscow, err := time.LoadLocation("Europe/Moscow")
+	require.NoError(t, err)
+	got = domain.BucketMonth.Start(time.Date(2026, 2, 28, 23, 0, 0, 0, time.UTC), moscow)
+	require.Equal(t, time.Date(2026, 3, 1, 0, 0, 0, 0, moscow), got)
+}
+
+func TestCreate_RejectsDuplicateNameAndValidates(t *testing.T) {
+	ctx := context.Background()
+	svc := custommetricuc.NewService(newFakeMetrics(), fakeUsers{})
+	userID := uuid.New()
+
+	def, err := svc.Create(ctx, userID, custommetricuc.DefinitionInput{Name: "  Сила хвата ", Unit: "кг", HigherIsBetter: true})
+	require.NoError(t, err)
+	require.Equal(t, "Сила хвата", def.Name)
+
+	_, err = svc.Create(ctx, userID, custommetricuc.DefinitionInput{Name: "сила ХВАТА", Unit: "кг"})
+	require.ErrorIs(t, err, custommetricuc.ErrMetricExists)
+
+	_, err = svc.Create(ctx, userID, custommetricuc.DefinitionInput{Name: "Прыжок", Unit: " "})
+	require.ErrorIs(t, err, custommetricuc.ErrInvalidUnit)
+}
+
+func TestAddEntry_ChecksOwnershipAndTime(t *testing.T) {
+	ctx := context.Background()
+	svc := custommetricuc.NewService(newFakeMetrics(), fakeUsers{})
+	userID := uuid.New()
+	def, err := svc.Create(ctx, userID, custommetricuc.DefinitionInput{Name: "Прыжок", Unit: "см", HigherIsBetter: true})
+	require.NoError(t, err)
+
+	_, err = svc.AddEntry(ctx, uuid.New(), def.ID, custommetricuc.EntryInput{Value: 50})
+	require.ErrorIs(t, err, custommetricuc.ErrMetricNotFound)
+
+	future := time.Now().Add(time.Hour)
+	_, err = svc.AddEntry(ctx, userID, def.ID, custommetricuc.EntryInput{Value: 50, RecordedAt: &future})
+	require.ErrorIs(t, err, custommetricuc.ErrInvalidRecordedAt)
+
+	_, err = svc.AddEntry(ctx, userID, def.ID, custommetricuc.EntryInput{Value: 52})
+	require.NoError(t, err)
+
+	now := time.Now().UTC()
+	stats, err := svc.Stats(ctx, userID, def.ID, now.Add(-24*time.Hour), now.Add(time.Hour), domain.BucketDay)
+	require.NoError(t, err)
+	require.Equal(t, 1, stats.Summary.Count)
+	require.Equal(t, 52.0, stats.Summary.Best)
+
+	_, err = svc.Stats(ctx, userID, def.ID, now.Add(-24*time.Hour), now, domain.Bucket("year"))
+	require.ErrorIs(t, err, custommetricuc.ErrInvalidBucket)
+}
+
+func TestStats_GroupsDaysInUserTimezone(t *testing.T) {
+	ctx := context.Background()
+	svc := custommetricuc.NewService(newFakeMetrics(), fakeUsers{timezone: "America/New_York"})
+	userID := uuid.New()
+	def, err := svc.Create(ctx, userID, custommetricuc.DefinitionInput{Name: "Вес", Unit: "кг"})
+	require.NoError(t, err)
+
+	// Вечер 9 марта по Нью-Йорку — это уже 10 марта по UTC.
+	evening := time.Date(2026, 3, 10, 1, 0, 0, 0, time.UTC)
+	morning := time.Date(2026, 3, 9, 13, 0, 0, 0, time.UTC)
+	for _, at := range []time.Time{evening, morning} {
+		_, err = svc.AddEntry(ctx, userID, def.ID, custommetricuc.EntryInput{Value: 80, RecordedAt: &at})
+		require.NoError(t, err)
+	}
+
+	stats, err := svc.Stats(ctx, userID, def.ID, morning.Add(-time.Hour), evening.Add(time.Hour), domain.BucketDay)
+	require.NoError(t, err)
+	require.Equal(t, "America/New_York", stats.Timezone)
+	require.Len(t, stats.Points, 1)
+	require.Equal(t, 2, stats.Points[0].Count)
+	require.Equal(t, "2026-03-09T00:00:00-04:00", stats.Points[0].Start.Format(time.RFC3339))
+}
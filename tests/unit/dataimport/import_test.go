@@ -0,0 +1,407 @@
+package dataimport_test
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	domain "workout-app/internal/domain/dataimport"
+	userdomain "workout-app/internal/domain/user"
+	workoutdomain "workout-app/internal/domain/workout"
+	repo "workout-app/internal/repository/interfaces"
+	importuc "workout-app/internal/usecase/dataimport"
+	workoutuc "workout-app/internal/usecase/workout"
+	"workout-app/pkg/logger"
+)
+
+type fakeImports struct {
+	repo.DataImportRepository
+	items   map[uuid.UUID]*domain.Import
+	records map[uuid.UUID][]*domain.Record
+}
+
+func newFakeImports() *fakeImports {
+	return &fakeImports{items: map[uuid.UUID]*domain.Import{}, records: map[uuid.UUID][]*domain.Record{}}
+}
+
+func (r *fakeImports) Create(_ context.Context, i *domain.Import) error {
+	for _, existing := range r.items {
+		if existing.UserID == i.UserID && !existing.Status.IsFinal() {
+			return repo.ErrImportInProgress
+		}
+	}
+	cp := *i
+	r.items[i.ID] = &cp
+	return nil
+}
+
+func (r *fakeImports) AddRecords(_ context.Context, records []*domain.Record) error {
+	for _, rec := range records {
+		cp := *rec
+		r.records[rec.ImportID] = append(r.records[rec.ImportID], &cp)
+	}
+	return nil
+}
+
+func (r *fakeImports) Enqueue(_ context.Context, id uuid.UUID, total int) error {
+	i, ok := r.items[id]
+	if !ok || i.Status != domain.StatusReceiving {
+		return repo.ErrNotFound
+	}
+	i.Status = domain.StatusPending
+	i.Total = total
+	return nil
+}
+
+func (r *fakeImports) GetByID(_ context.Context, id uuid.UUID) (*domain.Import, error) {
+	i, ok := r.items[id]
+	if !ok {
+		return nil, repo.ErrNotFound
+	}
+	cp := *i
+	return &cp, nil
+}
+
+func (r *fakeImports) GetActiveByUser(_ context.Context, userID uuid.UUID) (*domain.Import, error) {
+	for _, i := range r.items {
+		if i.UserID == userID && !i.Status.IsFinal() {
+			cp := *i
+			return &cp, nil
+		}
+	}
+	return nil, repo.ErrNotFound
+}
+
+func (r *fakeImports) ListRunnable(_ context.Context, limit int) ([]*domain.Import, error) {
+	var out []*domain.Import
+	for _, i := range r.items {
+		if i.Status == domain.StatusPending || i.Status == domain.StatusRunning {
+			cp := *i
+			out = append(out, &cp)
+		}
+	}
+	return out, nil
+}
+
+func (r *fakeImports) Claim(_ context.Context, id uuid.UUID, _ time.Time) (bool, error) {
+	i := r.items[id]
+	i.Status = domain.StatusRunning
+	return true, nil
+}
+
+func (r *fakeImports) ListPendingRecords(_ context.Context, importID uuid.UUID, limit int) ([]*domain.Record, error) {
+	var out []*domain.Record
+	for _, rec := range r.records[importID] {
+		if rec.Status == domain.RecordPending && len(out) < limit {
+			cp := *rec
+			out = append(out, &cp)
+		}
+	}
+	return out, nil
+}
+
+func (r *fakeImports) IsImported(_ context.Context, userID uuid.UUID, kind domain.Kind, externalID string) (bool, error) {
+	for _, records := range r.records {
+		for _, rec := range records {
+			if rec.UserID == userID && rec.Kind == kind && rec.ExternalID == externalID && rec.Status == domain.RecordImported {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+func (r *fakeImports) SaveRecord(_ context.Context, rec *domain.Record) error {
+	for i, existing := range r.records[rec.ImportID] {
+		if existing.Line == rec.Line {
+			cp := *rec
+			r.records[rec.ImportID][i] = &cp
+			return nil
+		}
+	}
+	return repo.ErrNotFound
+}
+
+func (r *fakeImports) SaveProgress(_ context.Context, i *domain.Import, _ time.Time) error {
+	stored := r.items[i.ID]
+	stored.Processed, stored.Imported, stored.Invalid, stored.Duplicates = i.Processed, i.Imported, i.Invalid, i.Duplicates
+	return nil
+}
+
+func (r *fakeImports) Finish(_ context.Context, i *domain.Import) error {
+	cp := *i
+	r.items[i.ID] = &cp
+	return nil
+}
+
+func (r *fakeImports) ListRecords(_ context.Context, importID uuid.UUID, status domain.RecordStatus, limit, offset int) ([]*domain.Record, error) {
+	var out []*domain.Record
+	for _, rec := range r.records[importID] {
+		if status == "" || rec.Status == status {
+			out = append(out, rec)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Line < out[j].Line })
+	return out, nil
+}
+
+func (r *fakeImports) Delete(_ context.Context, id uuid.UUID) error {
+	delete(r.items, id)
+	delete(r.records, id)
+	return nil
+}
+
+type fakeWorkouts struct {
+	repo.WorkoutSessionRepository
+	sessions map[uuid.UUID]*workoutdomain.Session
+}
+
+func (r *fakeWorkouts) Create(_ context.Context, s *workoutdomain.Session) error {
+	cp := *s
+	cp.Sets = nil
+	r.sessions[s.ID] = &cp
+	return nil
+}
+
+func (r *fakeWorkouts) AddSet(_ context.Context, set *workoutdomain.Set) error {
+	s := r.sessions[set.SessionID]
+	s.Sets = append(s.Sets, *set)
+	return nil
+}
+
+type fakeMetrics struct {
+	repo.BodyMetricRepository
+	created []*userdomain.BodyMetric
+}
+
+func (r *fakeMetrics) Create(_ context.Context, m *userdomain.BodyMetric) error {
+	r.created = append(r.created, m)
+	return nil
+}
+
+type fakeTx struct{}
+
+func (fakeTx) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+type fixture struct {
+	imports  *fakeImports
+	workouts *fakeWorkouts
+	metrics  *fakeMetrics
+	svc      importuc.Service
+}
+
+func newFixture() *fixture {
+	return newFixtureWithValidator(nil)
+}
+
+func newFixtureWithValidator(validator *workoutuc.Validator) *fixture {
+	f := &fixture{
+		imports:  newFakeImports(),
+		workouts: &fakeWorkouts{sessions: map[uuid.UUID]*workoutdomain.Session{}},
+		metrics:  &fakeMetrics{},
+	}
+	f.svc = importuc.NewService(f.imports, f.workouts, f.metrics, fakeTx{}, importuc.Config{
+		MaxRecords:     5,
+		BatchSize:      2,
+		UploadTimeout:  10 * time.Minute,
+		Retention:      24 * time.Hour,
+		AutoPauseAfter: 5 * time.Minute,
+		Validator:      validator,
+	}, logger.New(io.Discard, slog.LevelError, logger.FormatJSON))
+	return f
+}
+
+const sampleFile = `{"type":"workout","id":"w1","title":"Legs","started_at":"2024-03-01T10:00:00Z","finished_at":"2024-03-01T11:00:00Z","sets":[{"exercise":"Squat","reps":5,"weight_kg":100},{"exercise":"Squat","reps":5,"weight_kg":100}]}
+
+{"type":"measurement","id":"m1","measured_at":"2024-03-02T08:00:00Z","weight_kg":80.5,"measurements":{"Waist_cm":82}}
+{"type":"workout","id":"w1","title":"Legs again","started_at":"2024-03-01T10:00:00Z","finished_at":"2024-03-01T11:00:00Z"}
+not json
+{"type":"workout","id":"w2","title":"Bad","started_at":"2024-03-03T10:00:00Z","finished_at":"2024-03-03T11:00:00Z","sets":[{"exercise":"Bench","reps":0}]}
+`
+
+func TestImport_UploadAndProcess(t *testing.T) {
+	ctx := context.Background()
+	f := newFixture()
+	userID := uuid.New()
+
+	imp, err := f.svc.Upload(ctx, userID, " Strong ", strings.NewReader(sampleFile))
+	require.NoError(t, err)
+	require.Equal(t, domain.StatusPending, imp.Status)
+	require.Equal(t, "strong", imp.Source)
+	require.Equal(t, 5, imp.Total)
+
+	// Пачки по две записи: обработка продолжается до конца файла за один запуск.
+	require.NoError(t, f.svc.Run(ctx))
+
+	got, err := f.svc.Get(ctx, userID, imp.ID)
+	require.NoError(t, err)
+	require.Equal(t, domain.StatusCompleted, got.Status)
+	require.Equal(t, 5, got.Processed)
+	require.Equal(t, 2, got.Imported)
+	require.Equal(t, 2, got.Invalid)
+	require.Equal(t, 1, got.Duplicates)
+	require.NotNil(t, got.FinishedAt)
+
+	records, err := f.svc.Records(ctx, userID, imp.ID, "", 0, 0)
+	require.NoError(t, err)
+	require.Len(t, records, 5)
+	require.Equal(t, []int{1, 3, 4, 5, 6}, []int{records[0].Line, records[1].Line, records[2].Line, records[3].Line, records[4].Line})
+	require.Equal(t, domain.RecordImported, records[0].Status)
+	require.Equal(t, domain.RecordImported, records[1].Status)
+	require.Equal(t, domain.RecordDuplicate, records[2].Status)
+	require.Equal(t, domain.RecordInvalid, records[3].Status)
+	require.Equal(t, "invalid JSON", records[3].Error)
+	require.Equal(t, domain.RecordInvalid, records[4].Status)
+	require.Contains(t, records[4].Error, "sets[0]: reps")
+
+	require.Len(t, f.workouts.sessions, 1)
+	session := f.workouts.sessions[*records[0].EntityID]
+	require.Equal(t, "Legs", session.Title)
+	require.False(t, session.IsActive())
+	require.Len(t, session.Sets, 2)
+	// Подходы без времени распределяются по длительности тренировки.
+	require.Equal(t, time.Date(2024, 3, 1, 10, 20, 0, 0, time.UTC), session.Sets[0].LoggedAt)
+	require.Equal(t, time.Date(2024, 3, 1, 10, 40, 0, 0, time.UTC), session.Sets[1].LoggedAt)
+
+	require.Len(t, f.metrics.created, 1)
+	require.Equal(t, 82.0, f.metrics.created[0].Measurements["waist_cm"])
+
+	invalid, err := f.svc.Records(ctx, userID, imp.ID, domain.RecordInvalid, 0, 0)
+	require.NoError(t, err)
+	require.Len(t, invalid, 2)
+}
+
+type fakeExerciseLimits struct{}
+
+func (fakeExerciseLimits) List(context.Context) ([]workoutdomain.ExerciseLimits, error) {
+	return nil, nil
+}
+
+func newValidator(mode workoutdomain.ValidationMode) *workoutuc.Validator {
+	return workoutuc.NewValidator(fakeExerciseLimits{}, workoutuc.ValidationConfig{
+		Mode:        mode,
+		MaxWeightKg: 300,
+		MaxReps:     200,
+		MaxDuration: 6 * time.Hour,
+	}, time.Minute)
+}
+
+const implausibleFile = `{"type":"workout","id":"w1","title":"Typo","started_at":"2024-03-01T10:00:00Z","finished_at":"2024-03-01T11:00:00Z","sets":[{"exercise":"Squat","reps":5,"weight_kg":100},{"exercise":"Squat","reps":5,"weight_kg":1000}]}
+{"type":"workout","id":"w2","title":"Forgot to stop","started_at":"2024-03-02T10:00:00Z","finished_at":"2024-03-02T20:00:00Z","sets":[{"exercise":"Squat","reps":5,"weight_kg":100}]}
+`
+
+func TestImport_ImplausibleDataByValidationMode(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+
+	// reject: записи некорректны.
+	f := newFixtureWithValidator(newValidator(workoutdomain.ValidationReject))
+	imp, err := f.svc.Upload(ctx, userID, "", strings.NewReader(implausibleFile))
+	require.NoError(t, err)
+	require.NoError(t, f.svc.Run(ctx))
+	records, err := f.svc.Records(ctx, userID, imp.ID, "", 0, 0)
+	require.NoError(t, err)
+	require.Equal(t, domain.RecordInvalid, records[0].Status)
+	require.Contains(t, records[0].Error, "sets[1]: weight_kg")
+	require.Equal(t, domain.RecordInvalid, records[1].Status)
+	require.Contains(t, records[1].Error, "longer than")
+	require.Empty(t, f.workouts.sessions)
+
+	// warn: тренировки импортированы, неправдоподобные подходы помечены.
+	f = newFixtureWithValidator(newValidator(workoutdomain.ValidationWarn))
+	imp, err = f.svc.Upload(ctx, userID, "", strings.NewReader(implausibleFile))
+	require.NoError(t, err)
+	require.NoError(t, f.svc.Run(ctx))
+	records, err = f.svc.Records(ctx, userID, imp.ID, "", 0, 0)
+	require.NoError(t, err)
+	require.Equal(t, domain.RecordImported, records[0].Status)
+	require.Equal(t, domain.RecordImported, records[1].Status)
+	typo := f.workouts.sessions[*records[0].EntityID].Sets
+	require.False(t, typo[0].Implausible)
+	require.True(t, typo[1].Implausible)
+	require.True(t, f.workouts.sessions[*records[1].EntityID].Sets[0].Implausible)
+}
+
+func TestImport_ReuploadSkipsImportedRecords(t *testing.T) {
+	ctx := context.Background()
+	f := newFixture()
+	userID := uuid.New()
+	line := `{"type":"measurement","id":"m1","measured_at":"2024-03-02T08:00:00Z","weight_kg":80}` + "\n"
+
+	_, err := f.svc.Upload(ctx, userID, "", strings.NewReader(line))
+	require.NoError(t, err)
+	require.NoError(t, f.svc.Run(ctx))
+
+	second, err := f.svc.Upload(ctx, userID, "", strings.NewReader(line))
+	require.NoError(t, err)
+	require.NoError(t, f.svc.Run(ctx))
+
+	got, err := f.svc.Get(ctx, userID, second.ID)
+	require.NoError(t, err)
+	require.Equal(t, 1, got.Duplicates)
+	require.Len(t, f.metrics.created, 1)
+}
+
+func TestImport_UploadLimits(t *testing.T) {
+	ctx := context.Background()
+	f := newFixture()
+	userID := uuid.New()
+
+	_, err := f.svc.Upload(ctx, userID, "", strings.NewReader("\n  \n"))
+	require.ErrorIs(t, err, importuc.ErrEmptyFile)
+
+	_, err = f.svc.Upload(ctx, userID, "", strings.NewReader(strings.Repeat("{}\n", 6)))
+	require.ErrorIs(t, err, importuc.ErrTooManyRecords)
+
+	_, err = f.svc.Upload(ctx, userID, strings.Repeat("a", 65), strings.NewReader("{}\n"))
+	require.ErrorIs(t, err, importuc.ErrInvalidSource)
+
+	// Отклонённые загрузки не остаются в очереди и не блокируют следующий импорт.
+	require.Empty(t, f.imports.items)
+	_, err = f.svc.Upload(ctx, userID, "", strings.NewReader("{}\n"))
+	require.NoError(t, err)
+}
+
+func TestImport_OneActiveImportPerUser(t *testing.T) {
+	ctx := context.Background()
+	f := newFixture()
+	userID := uuid.New()
+
+	first, err := f.svc.Upload(ctx, userID, "", strings.NewReader("{}\n"))
+	require.NoError(t, err)
+	_, err = f.svc.Upload(ctx, userID, "", strings.NewReader("{}\n"))
+	require.ErrorIs(t, err, importuc.ErrImportInProgress)
+
+	// Брошенная загрузка старше UploadTimeout заменяется новой.
+	abandoned := f.imports.items[first.ID]
+	abandoned.Status = domain.StatusReceiving
+	abandoned.CreatedAt = time.Now().Add(-time.Hour)
+	second, err := f.svc.Upload(ctx, userID, "", strings.NewReader("{}\n"))
+	require.NoError(t, err)
+	require.NotContains(t, f.imports.items, first.ID)
+	require.Contains(t, f.imports.items, second.ID)
+}
+
+func TestImport_OtherUsersImportIsHidden(t *testing.T) {
+	ctx := context.Background()
+	f := newFixture()
+
+	imp, err := f.svc.Upload(ctx, uuid.New(), "", strings.NewReader("{}\n"))
+	require.NoError(t, err)
+
+	_, err = f.svc.Get(ctx, uuid.New(), imp.ID)
+	require.ErrorIs(t, err, importuc.ErrImportNotFound)
+	_, err = f.svc.Records(ctx, uuid.New(), imp.ID, "", 0, 0)
+	require.ErrorIs(t, err, importuc.ErrImportNotFound)
+	_, err = f.svc.Records(ctx, uuid.New(), imp.ID, "bogus", 0, 0)
+	require.ErrorIs(t, err, importuc.ErrInvalidStatus)
+}
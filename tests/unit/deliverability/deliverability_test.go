@@ -0,0 +1,296 @@
+package deliverability_test
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	domain "workout-app/internal/domain/deliverability"
+	deliverabilityhandler "workout-app/internal/handler/deliverability"
+	"workout-app/internal/mailer"
+	repoiface "workout-app/internal/repository/interfaces"
+	deliverabilityuc "workout-app/internal/usecase/deliverability"
+	suppressionuc "workout-app/internal/usecase/suppression"
+	"workout-app/pkg/logger"
+	mailerpkg "workout-app/pkg/mailer"
+)
+
+// fakeDeliverabilityRepo хранит события и список подавления в памяти.
+type fakeDeliverabilityRepo struct {
+	events       []domain.Event
+	suppressions map[string]domain.Suppression
+}
+
+func newFakeRepo() *fakeDeliverabilityRepo {
+	return &fakeDeliverabilityRepo{suppressions: map[string]domain.Suppression{}}
+}
+
+func (r *fakeDeliverabilityRepo) CreateEvent(_ context.Context, ev *domain.Event) error {
+	ev.ID = int64(len(r.events) + 1)
+	r.events = append(r.events, *ev)
+	return nil
+}
+
+func (r *fakeDeliverabilityRepo) StatsSince(_ context.Context, _ time.Time) ([]domain.ProviderStats, error) {
+	return nil, nil
+}
+
+func (r *fakeDeliverabilityRepo) Add(_ context.Context, sup *domain.Suppression) (bool, error) {
+	if _, ok := r.suppressions[sup.Email]; ok {
+		return false, nil
+	}
+	r.suppressions[sup.Email] = *sup
+	return true, nil
+}
+
+func (r *fakeDeliverabilityRepo) Remove(_ context.Context, email string) error {
+	if _, ok := r.suppressions[email]; !ok {
+		return repoiface.ErrNotFound
+	}
+	delete(r.suppressions, email)
+	return nil
+}
+
+func (r *fakeDeliverabilityRepo) Exists(_ context.Context, email string) (bool, error) {
+	_, ok := r.suppressions[email]
+	return ok, nil
+}
+
+func (r *fakeDeliverabilityRepo) List(_ context.Context, filter repoiface.SuppressionFilter) ([]*domain.Suppression, int64, error) {
+	var out []*domain.Suppression
+	for _, sup := range r.suppressions {
+		if filter.Source == "" || sup.Source == filter.Source {
+			sup := sup
+			out = append(out, &sup)
+		}
+	}
+	return out, int64(len(out)), nil
+}
+
+func (r *fakeDeliverabilityRepo) Count(_ context.Context) (int64, error) {
+	return int64(len(r.suppressions)), nil
+}
+
+// countingSender считает реально отправленные письма.
+type countingSender struct{ sent int }
+
+func (s *countingSender) SendEmailVerificationCode(context.Context, string, string) error {
+	s.sent++
+	return nil
+}
+
+func (s *countingSender) SendPasswordChangeCode(context.Context, string, string) error {
+	s.sent++
+	return nil
+}
+
+func (s *countingSender) SendPasswordResetCode(context.Context, string, string) error {
+	s.sent++
+	return nil
+}
+
+func (s *countingSender) SendDataExportReady(context.Context, string, string, time.Time) error {
+	s.sent++
+	return nil
+}
+
+func (s *countingSender) SendWelcome(context.Context, string, string) error {
+	s.sent++
+	return nil
+}
+
+func (s *countingSender) SendPasswordChanged(context.Context, string, time.Time) error {
+	s.sent++
+	return nil
+}
+
+func (s *countingSender) SendAccountDeleted(context.Context, string, time.Time) error {
+	s.sent++
+	return nil
+}
+
+func (s *countingSender) SendNotification(context.Context, string, string, time.Time) error {
+	s.sent++
+	return nil
+}
+
+func (s *countingSender) SendWeeklySummary(context.Context, string, mailerpkg.WeeklySummary) error {
+	s.sent++
+	return nil
+}
+
+func (s *countingSender) SendCoachInvitation(context.Context, string, string, time.Time) error {
+	s.sent++
+	return nil
+}
+
+func TestRecordEvents_SuppressesHardBouncesAndComplaints(t *testing.T) {
+	repo := newFakeRepo()
+	svc := deliverabilityuc.NewService(repo, repo)
+	suppressions := suppressionuc.NewService(repo)
+	ctx := context.Background()
+
+	n, err := svc.RecordEvents(ctx, "smtp", []domain.Event{
+		{Email: "Hard@Example.com", Outcome: domain.OutcomeBounced, BounceType: domain.BounceHard},
+		{Email: "soft@example.com", Outcome: domain.OutcomeBounced},
+		{Email: "spam@example.com", Outcome: domain.OutcomeComplaint},
+		{Email: "ok@example.com", Outcome: domain.OutcomeAccepted},
+	})
+	require.NoError(t, err)
+	require.Equal(t, 4, n)
+	require.Equal(t, domain.BounceSoft, repo.events[1].BounceType)
+
+	for email, want := range map[string]bool{
+		"hard@example.com": true,
+		"soft@example.com": false,
+		"spam@example.com": true,
+		"ok@example.com":   false,
+	} {
+		blocked, err := suppressions.IsSuppressed(ctx, email)
+		require.NoError(t, err)
+		require.Equal(t, want, blocked, email)
+	}
+	require.Equal(t, domain.SourceComplaint, repo.suppressions["spam@example.com"].Source)
+}
+
+func TestRecordEvents_RejectsInvalidBatchWithoutSaving(t *testing.T) {
+	repo := newFakeRepo()
+	svc := deliverabilityuc.NewService(repo, repo)
+
+	_, err := svc.RecordEvents(context.Background(), "smtp", []domain.Event{
+		{Email: "ok@example.com", Outcome: domain.OutcomeAccepted},
+		{Email: "bad@example.com", Outcome: "opened"},
+	})
+	require.ErrorIs(t, err, deliverabilityuc.ErrInvalidEvent)
+	require.Empty(t, repo.events)
+
+	_, err = svc.RecordEvents(context.Background(), "Bad Provider", nil)
+	require.ErrorIs(t, err, deliverabilityuc.ErrInvalidProvider)
+}
+
+func TestGuardedSender_BlocksSuppressedAddresses(t *testing.T) {
+	repo := newFakeRepo()
+	svc := deliverabilityuc.NewService(repo, repo)
+	next := &countingSender{}
+	log := logger.New(io.Discard, slog.LevelError, logger.FormatJSON)
+	sender := mailer.NewGuardedSender(next, suppressionuc.NewService(repo), log)
+	ctx := context.Background()
+
+	_, err := svc.RecordEvents(ctx, "smtp", []domain.Event{
+		{Email: "gone@example.com", Outcome: domain.OutcomeBounced, BounceType: domain.BounceHard},
+	})
+	require.NoError(t, err)
+
+	require.ErrorIs(t, sender.SendEmailVerificationCode(ctx, "GONE@example.com", "123456"), mailerpkg.ErrRecipientUndeliverable)
+	require.NoError(t, sender.SendPasswordChangeCode(ctx, "alive@example.com", "123456"))
+	require.Equal(t, 1, next.sent)
+}
+
+func TestWebhook_SendGridPayloadAndToken(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	repo := newFakeRepo()
+	log := logger.New(io.Discard, slog.LevelError, logger.FormatJSON)
+	h := deliverabilityhandler.NewHandler(deliverabilityuc.NewService(repo, repo), "0123456789abcdef", log)
+
+	r := gin.New()
+	r.POST("/webhooks/email/:provider", h.Webhook)
+
+	body := `[
+		{"email":"a@example.com","timestamp":1700000000,"event":"delivered","sg_message_id":"m1"},
+		{"email":"b@example.com","timestamp":1700000000,"event":"bounce","type":"bounce","reason":"550 no such user"},
+		{"email":"c@example.com","timestamp":1700000000,"event":"open"}
+	]`
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/webhooks/email/sendgrid", strings.NewReader(body))
+	req.Header.Set(deliverabilityhandler.HeaderWebhookToken, "wrong")
+	r.ServeHTTP(w, req)
+	require.Equal(t, http.StatusUnauthorized, w.Code)
+
+	// Секрет в URL не принимается, даже верный.
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/webhooks/email/sendgrid?token=0123456789abcdef", strings.NewReader(body)))
+	require.Equal(t, http.StatusUnauthorized, w.Code)
+	require.Empty(t, repo.events)
+
+	req = httptest.NewRequest(http.MethodPost, "/webhooks/email/sendgrid", strings.NewReader(body))
+	req.Header.Set(deliverabilityhandler.HeaderWebhookToken, "0123456789abcdef")
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.JSONEq(t, `{"recorded":2}`, w.Body.String())
+
+	require.Len(t, repo.events, 2)
+	require.Equal(t, "sendgrid", repo.events[1].Provider)
+	require.Equal(t, domain.BounceHard, repo.events[1].BounceType)
+	require.Contains(t, repo.suppressions, "b@example.com")
+}
+
+func TestSuppression_ManualAddAndRemove(t *testing.T) {
+	repo := newFakeRepo()
+	svc := suppressionuc.NewService(repo)
+	ctx := context.Background()
+	actorID := uuid.New()
+
+	entry, err := svc.Add(ctx, actorID, " Manual@Example.com ", "request from user")
+	require.NoError(t, err)
+	require.Equal(t, "manual@example.com", entry.Email)
+	require.Equal(t, domain.SourceManual, entry.Source)
+	require.Equal(t, actorID, *entry.CreatedBy)
+
+	_, err = svc.Add(ctx, actorID, "manual@example.com", "")
+	require.ErrorIs(t, err, suppressionuc.ErrAlreadySuppressed)
+	_, err = svc.Add(ctx, actorID, "not-an-email", "")
+	require.ErrorIs(t, err, suppressionuc.ErrInvalidEmail)
+
+	require.NoError(t, svc.Remove(ctx, "MANUAL@example.com"))
+	require.ErrorIs(t, svc.Remove(ctx, "manual@example.com"), repoiface.ErrNotFound)
+}
+
+func TestSuppression_ImportProviderExport(t *testing.T) {
+	repo := newFakeRepo()
+	svc := suppressionuc.NewService(repo)
+	ctx := context.Background()
+
+	_, err := svc.Add(ctx, uuid.New(), "known@example.com", "")
+	require.NoError(t, err)
+
+	csv := "\ufeffcreated,email,reason,status\n" +
+		"1700000000,Gone@Example.com,550 no such user,5.1.1\n" +
+		"1700000000,known@example.com,mailbox full,4.2.2\n" +
+		"1700000000,broken,invalid,5.1.3\n"
+	res, err := svc.Import(ctx, uuid.New(), suppressionuc.ImportInput{
+		Provider: "sendgrid",
+		Source:   domain.SourceBounce,
+		Data:     strings.NewReader(csv),
+	})
+	require.NoError(t, err)
+	require.Equal(t, suppressionuc.ImportResult{Added: 1, Skipped: 1, Invalid: 1}, *res)
+
+	gone := repo.suppressions["gone@example.com"]
+	require.Equal(t, "sendgrid", gone.Provider)
+	require.Equal(t, "550 no such user", gone.Reason)
+
+	_, err = svc.Import(ctx, uuid.New(), suppressionuc.ImportInput{
+		Provider: "sendgrid",
+		Source:   "unknown",
+		Data:     strings.NewReader(csv),
+	})
+	require.ErrorIs(t, err, suppressionuc.ErrInvalidSource)
+
+	_, err = svc.Import(ctx, uuid.New(), suppressionuc.ImportInput{
+		Provider: "sendgrid",
+		Source:   domain.SourceBounce,
+		Data:     strings.NewReader(""),
+	})
+	require.ErrorIs(t, err, suppressionuc.ErrInvalidImportFile)
+}
@@ -0,0 +1,68 @@
+package doctor_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"workout-app/internal/config"
+	"workout-app/internal/doctor"
+)
+
+func strongConfig() config.JWTConfig {
+	return config.JWTConfig{
+		AccessSecret:  strings.Repeat("a", 32),
+		RefreshSecret: strings.Repeat("r", 32),
+	}
+}
+
+func TestCheckJWT_StrongSecretsPass(t *testing.T) {
+	cfg := strongConfig()
+	res := doctor.CheckJWT(&cfg, "production")
+	require.Equal(t, doctor.StatusOK, res.Status, res.Message)
+}
+
+func TestCheckJWT_WeakSecretsFailOnlyInProduction(t *testing.T) {
+	cfg := strongConfig()
+	cfg.AccessSecret = "dev_access_secret_change_me_1234567890"
+	cfg.RefreshKeys = map[string]string{"v1": "short"}
+
+	res := doctor.CheckJWT(&cfg, "production")
+	require.Equal(t, doctor.StatusFail, res.Status)
+	require.Contains(t, res.Message, "JWT_ACCESS_SECRET")
+	require.Contains(t, res.Message, "JWT_REFRESH_KEYS[v1]")
+
+	require.Equal(t, doctor.StatusWarn, doctor.CheckJWT(&cfg, "development").Status)
+
+	cfg = strongConfig()
+	cfg.RefreshSecret = cfg.AccessSecret
+	require.Contains(t, doctor.CheckJWT(&cfg, "production").Message, "совпадают")
+}
+
+func TestCheckJWT_MissingPrivateKeyFails(t *testing.T) {
+	cfg := strongConfig()
+	cfg.SigningAlgorithm = "EdDSA"
+	cfg.PrivateKeyPath = t.TempDir() + "/missing.pem"
+
+	res := doctor.CheckJWT(&cfg, "development")
+	require.Equal(t, doctor.StatusFail, res.Status)
+	require.Contains(t, res.Message, "missing.pem")
+}
+
+func TestReport_FailsOnlyOnFailedChecks(t *testing.T) {
+	report := &doctor.Report{Results: []doctor.Result{
+		{Name: "config", Status: doctor.StatusOK},
+		{Name: "smtp", Status: doctor.StatusWarn, Message: "EMAIL_SMTP_HOST не задан"},
+	}}
+	require.False(t, report.Failed())
+
+	report.Results = append(report.Results, doctor.Result{Name: "database", Status: doctor.StatusFail, Message: "connection refused"})
+	require.True(t, report.Failed())
+
+	var out bytes.Buffer
+	report.Write(&out)
+	require.Contains(t, out.String(), "[fail] database   connection refused")
+	require.Contains(t, out.String(), "FAIL:")
+}
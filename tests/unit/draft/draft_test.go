@@ -0,0 +1,285 @@
+package draft_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	domain "workout-app/internal/domain/draft"
+	programdomain "workout-app/internal/domain/program"
+	workoutdomain "workout-app/internal/domain/workout"
+	repo "workout-app/internal/repository/interfaces"
+	draftuc "workout-app/internal/usecase/draft"
+)
+
+type fakeDrafts struct {
+	drafts []*domain.Draft
+}
+
+func sameTarget(a, b *uuid.UUID) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
+
+func (r *fakeDrafts) Create(_ context.Context, d *domain.Draft) error {
+	for _, existing := range r.drafts {
+		if existing.UserID == d.UserID && existing.DeviceID == d.DeviceID && existing.Kind == d.Kind && sameTarget(existing.TargetID, d.TargetID) {
+			return repo.ErrDraftExists
+		}
+	}
+	stored := *d
+	r.drafts = append(r.drafts, &stored)
+	return nil
+}
+
+func (r *fakeDrafts) Update(_ context.Context, d *domain.Draft, expectedVersion int) error {
+	for i, existing := range r.drafts {
+		if existing.ID == d.ID && existing.Version == expectedVersion {
+			stored := *d
+			r.drafts[i] = &stored
+			return nil
+		}
+	}
+	return repo.ErrNotFound
+}
+
+func (r *fakeDrafts) GetByKey(_ context.Context, userID uuid.UUID, deviceID string, kind domain.Kind, targetID *uuid.UUID) (*domain.Draft, error) {
+	for _, d := range r.drafts {
+		if d.UserID == userID && d.DeviceID == deviceID && d.Kind == kind && sameTarget(d.TargetID, targetID) {
+			stored := *d
+			return &stored, nil
+		}
+	}
+	return nil, repo.ErrNotFound
+}
+
+func (r *fakeDrafts) GetByID(_ context.Context, userID, id uuid.UUID) (*domain.Draft, error) {
+	for _, d := range r.drafts {
+		if d.ID == id && d.UserID == userID {
+			stored := *d
+			return &stored, nil
+		}
+	}
+	return nil, repo.ErrNotFound
+}
+
+func (r *fakeDrafts) ListByUser(_ context.Context, userID uuid.UUID, deviceID string, now time.Time) ([]*domain.Draft, error) {
+	var out []*domain.Draft
+	for _, d := range r.drafts {
+		if d.UserID == userID && (deviceID == "" || d.DeviceID == deviceID) && !d.IsExpired(now) {
+			out = append(out, d)
+		}
+	}
+	return out, nil
+}
+
+func (r *fakeDrafts) CountByUser(ctx context.Context, userID uuid.UUID, now time.Time) (int64, error) {
+	drafts, err := r.ListByUser(ctx, userID, "", now)
+	return int64(len(drafts)), err
+}
+
+func (r *fakeDrafts) Delete(_ context.Context, userID, id uuid.UUID) error {
+	for i, d := range r.drafts {
+		if d.ID == id && d.UserID == userID {
+			r.drafts = append(r.drafts[:i], r.drafts[i+1:]...)
+			return nil
+		}
+	}
+	return repo.ErrNotFound
+}
+
+func (r *fakeDrafts) DeleteExpired(context.Context, time.Time, int) (int64, error) { return 0, nil }
+
+func (r *fakeDrafts) DeleteByUserID(context.Context, uuid.UUID) error { return nil }
+
+type fakePrograms struct {
+	repo.ProgramRepository
+	programs map[uuid.UUID]*programdomain.Program
+}
+
+func (r *fakePrograms) GetByID(_ context.Context, id uuid.UUID) (*programdomain.Program, error) {
+	if p, ok := r.programs[id]; ok {
+		return p, nil
+	}
+	return nil, repo.ErrNotFound
+}
+
+type fakeWorkouts struct {
+	repo.WorkoutSessionRepository
+	sessions map[uuid.UUID]*workoutdomain.Session
+}
+
+func (r *fakeWorkouts) GetByID(_ context.Context, id uuid.UUID) (*workoutdomain.Session, error) {
+	if s, ok := r.sessions[id]; ok {
+		return s, nil
+	}
+	return nil, repo.ErrNotFound
+}
+
+type fixture struct {
+	svc      draftuc.Service
+	drafts   *fakeDrafts
+	programs *fakePrograms
+	workouts *fakeWorkouts
+}
+
+func newFixture(ttl time.Duration) *fixture {
+	f := &fixture{
+		drafts:   &fakeDrafts{},
+		programs: &fakePrograms{programs: map[uuid.UUID]*programdomain.Program{}},
+		workouts: &fakeWorkouts{sessions: map[uuid.UUID]*workoutdomain.Session{}},
+	}
+	f.svc = draftuc.NewService(f.drafts, f.programs, f.workouts, ttl)
+	return f
+}
+
+func (f *fixture) addProgram(ownerID uuid.UUID) *programdomain.Program {
+	p := programdomain.New(ownerID, "Сила 5×5", "", nil)
+	f.programs.programs[p.ID] = p
+	return p
+}
+
+var content = json.RawMessage(`{"title":"Сила 5×5","weeks":[]}`)
+
+func TestSave_VersionsAndDetectsLostUpdate(t *testing.T) {
+	f := newFixture(24 * time.Hour)
+	ctx := context.Background()
+	userID := uuid.New()
+	input := draftuc.SaveInput{DeviceID: "phone-1", Kind: domain.KindProgram, Content: content}
+
+	view, err := f.svc.Save(ctx, userID, input)
+	require.NoError(t, err)
+	require.Equal(t, 1, view.Draft.Version)
+	require.False(t, view.Conflict)
+
+	input.Version = 1
+	view, err = f.svc.Save(ctx, userID, input)
+	require.NoError(t, err)
+	require.Equal(t, 2, view.Draft.Version)
+	require.Len(t, f.drafts.drafts, 1)
+
+	// Ответ на второе сохранение не дошёл, клиент повторяет его со старой версией.
+	_, err = f.svc.Save(ctx, userID, input)
+	require.ErrorIs(t, err, draftuc.ErrVersionConflict)
+	var conflictErr *draftuc.VersionConflictError
+	require.ErrorAs(t, err, &conflictErr)
+	require.Equal(t, 2, conflictErr.Current.Version)
+
+	// Другое устройство сохраняет свой черновик.
+	view, err = f.svc.Save(ctx, userID, draftuc.SaveInput{DeviceID: "tablet-1", Kind: domain.KindProgram, Content: content})
+	require.NoError(t, err)
+	require.Equal(t, 1, view.Draft.Version)
+	require.Len(t, f.drafts.drafts, 2)
+}
+
+func TestSave_DetectsPublishedProgramChange(t *testing.T) {
+	f := newFixture(24 * time.Hour)
+	ctx := context.Background()
+	userID := uuid.New()
+	p := f.addProgram(userID)
+	base := p.UpdatedAt
+
+	view, err := f.svc.Save(ctx, userID, draftuc.SaveInput{
+		DeviceID: "phone-1", Kind: domain.KindProgram, TargetID: &p.ID, BaseVersion: &base, Content: content,
+	})
+	require.NoError(t, err)
+	require.False(t, view.Conflict)
+
+	p.UpdatedAt = p.UpdatedAt.Add(time.Minute)
+	view, err = f.svc.Get(ctx, userID, view.Draft.ID)
+	require.NoError(t, err)
+	require.True(t, view.Conflict)
+	require.Equal(t, p.UpdatedAt.Truncate(time.Microsecond), *view.PublishedVersion)
+	require.JSONEq(t, string(content), string(view.Draft.Content))
+
+	delete(f.programs.programs, p.ID)
+	view, err = f.svc.Get(ctx, userID, view.Draft.ID)
+	require.NoError(t, err)
+	require.True(t, view.Conflict)
+	require.Nil(t, view.PublishedVersion)
+}
+
+func TestSave_WorkoutUsesLastModification(t *testing.T) {
+	f := newFixture(24 * time.Hour)
+	ctx := context.Background()
+	userID := uuid.New()
+	started := time.Now().UTC().Add(-time.Hour)
+	session := workoutdomain.NewSession(userID, "Ноги", nil, 5*time.Minute, started)
+	f.workouts.sessions[session.ID] = session
+
+	view, err := f.svc.Save(ctx, userID, draftuc.SaveInput{
+		DeviceID: "phone-1", Kind: domain.KindWorkout, TargetID: &session.ID, BaseVersion: &started, Content: content,
+	})
+	require.NoError(t, err)
+	require.False(t, view.Conflict)
+
+	finished := started.Add(45 * time.Minute)
+	session.FinishedAt = &finished
+	view, err = f.svc.Get(ctx, userID, view.Draft.ID)
+	require.NoError(t, err)
+	require.True(t, view.Conflict)
+}
+
+func TestSave_Validation(t *testing.T) {
+	f := newFixture(24 * time.Hour)
+	ctx := context.Background()
+	userID := uuid.New()
+	foreign := f.addProgram(uuid.New())
+	now := time.Now().UTC()
+
+	cases := []struct {
+		name  string
+		input draftuc.SaveInput
+		want  error
+	}{
+		{"kind", draftuc.SaveInput{DeviceID: "phone-1", Kind: "class", Content: content}, draftuc.ErrInvalidKind},
+		{"device", draftuc.SaveInput{DeviceID: "my phone", Kind: domain.KindProgram, Content: content}, draftuc.ErrInvalidDeviceID},
+		{"content", draftuc.SaveInput{DeviceID: "phone-1", Kind: domain.KindProgram, Content: json.RawMessage(`[1,2]`)}, draftuc.ErrInvalidContent},
+		{"base version", draftuc.SaveInput{DeviceID: "phone-1", Kind: domain.KindProgram, TargetID: &foreign.ID, Content: content}, draftuc.ErrBaseVersionRequired},
+		{"foreign program", draftuc.SaveInput{DeviceID: "phone-1", Kind: domain.KindProgram, TargetID: &foreign.ID, BaseVersion: &now, Content: content}, draftuc.ErrTargetNotFound},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := f.svc.Save(ctx, userID, tc.input)
+			require.ErrorIs(t, err, tc.want)
+		})
+	}
+	require.Empty(t, f.drafts.drafts)
+}
+
+func TestSave_ExpiredDraftReplacedWithoutVersionCheck(t *testing.T) {
+	f := newFixture(time.Nanosecond)
+	ctx := context.Background()
+	userID := uuid.New()
+	input := draftuc.SaveInput{DeviceID: "phone-1", Kind: domain.KindProgram, Content: content}
+
+	first, err := f.svc.Save(ctx, userID, input)
+	require.NoError(t, err)
+	_, err = f.svc.Get(ctx, userID, first.Draft.ID)
+	require.ErrorIs(t, err, draftuc.ErrDraftNotFound)
+
+	view, err := f.svc.Save(ctx, userID, input)
+	require.NoError(t, err)
+	require.Equal(t, first.Draft.ID, view.Draft.ID)
+	require.Equal(t, 2, view.Draft.Version)
+}
+
+func TestDelete_OtherUsersDraftNotFound(t *testing.T) {
+	f := newFixture(24 * time.Hour)
+	ctx := context.Background()
+	userID := uuid.New()
+	view, err := f.svc.Save(ctx, userID, draftuc.SaveInput{DeviceID: "phone-1", Kind: domain.KindWorkout, Content: content})
+	require.NoError(t, err)
+
+	require.ErrorIs(t, f.svc.Delete(ctx, uuid.New(), view.Draft.ID), draftuc.ErrDraftNotFound)
+	require.NoError(t, f.svc.Delete(ctx, userID, view.Draft.ID))
+	drafts, err := f.svc.List(ctx, userID, "")
+	require.NoError(t, err)
+	require.Empty(t, drafts)
+}
@@ -0,0 +1,40 @@
+package emailaddr_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"workout-app/pkg/emailaddr"
+)
+
+func TestNormalize_TrimsAndLowercases(t *testing.T) {
+	require.Equal(t, "john.doe+gym@example.com", emailaddr.Normalize("  John.Doe+Gym@Example.COM \t"))
+}
+
+func TestCanonical_DefaultPolicyOnlyNormalizes(t *testing.T) {
+	var p emailaddr.Policy
+	require.Equal(t, "j.doe+gym@gmail.com", p.Canonical(" J.Doe+Gym@Gmail.com"))
+}
+
+func TestCanonical_FoldGmail(t *testing.T) {
+	p := emailaddr.Policy{FoldGmail: true}
+	require.Equal(t, "jdoe@gmail.com", p.Canonical("J.Doe+gym@googlemail.com"))
+	require.Equal(t, "jdoe@gmail.com", p.Canonical("jdoe@gmail.com"))
+	// Для остальных доменов точки и +метки значимы.
+	require.Equal(t, "j.doe+gym@example.com", p.Canonical("j.doe+gym@example.com"))
+}
+
+func TestCanonical_FoldPlusTags(t *testing.T) {
+	p := emailaddr.Policy{FoldPlusTags: true}
+	require.Equal(t, "j.doe@example.com", p.Canonical("j.doe+gym+1@example.com"))
+	require.Equal(t, "j.doe@gmail.com", p.Canonical("j.doe+gym@gmail.com"))
+	// Адрес, начинающийся с "+", не сворачивается в пустое имя ящика.
+	require.Equal(t, "+tag@example.com", p.Canonical("+tag@example.com"))
+}
+
+func TestCanonical_LeavesMalformedAddressNormalized(t *testing.T) {
+	p := emailaddr.Policy{FoldGmail: true, FoldPlusTags: true}
+	require.Equal(t, "not-an-email", p.Canonical(" Not-An-Email "))
+	require.Equal(t, "user+x@", p.Canonical("user+x@"))
+}
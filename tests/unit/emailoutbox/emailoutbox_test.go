@@ -0,0 +1,337 @@
+package emailoutbox_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	domain "workout-app/internal/domain/emailoutbox"
+	"workout-app/internal/mailer"
+	repo "workout-app/internal/repository/interfaces"
+	emailoutboxuc "workout-app/internal/usecase/emailoutbox"
+	"workout-app/pkg/logger"
+	mailerpkg "workout-app/pkg/mailer"
+)
+
+// fakeOutboxRepo хранит очередь в памяти.
+type fakeOutboxRepo struct {
+	messages map[int64]*domain.Message
+	nextID   int64
+}
+
+func newFakeOutboxRepo() *fakeOutboxRepo {
+	return &fakeOutboxRepo{messages: map[int64]*domain.Message{}}
+}
+
+func (r *fakeOutboxRepo) Enqueue(_ context.Context, m *domain.Message) error {
+	r.nextID++
+	m.ID = r.nextID
+	copied := *m
+	r.messages[m.ID] = &copied
+	return nil
+}
+
+func (r *fakeOutboxRepo) ListDue(_ context.Context, now time.Time, limit int) ([]*domain.Message, error) {
+	var due []*domain.Message
+	for id := int64(1); id <= r.nextID && len(due) < limit; id++ {
+		m, ok := r.messages[id]
+		if ok && m.Status == domain.StatusPending && !m.NextAttemptAt.After(now) {
+			copied := *m
+			due = append(due, &copied)
+		}
+	}
+	return due, nil
+}
+
+func (r *fakeOutboxRepo) Claim(_ context.Context, id int64, now, until time.Time) (bool, error) {
+	m, ok := r.messages[id]
+	if !ok || m.Status != domain.StatusPending || m.NextAttemptAt.After(now) {
+		return false, nil
+	}
+	m.NextAttemptAt = until
+	return true, nil
+}
+
+func (r *fakeOutboxRepo) Save(_ context.Context, m *domain.Message) error {
+	if _, ok := r.messages[m.ID]; !ok {
+		return repo.ErrNotFound
+	}
+	copied := *m
+	r.messages[m.ID] = &copied
+	return nil
+}
+
+func (r *fakeOutboxRepo) GetByID(_ context.Context, id int64) (*domain.Message, error) {
+	m, ok := r.messages[id]
+	if !ok {
+		return nil, repo.ErrNotFound
+	}
+	copied := *m
+	return &copied, nil
+}
+
+func (r *fakeOutboxRepo) List(context.Context, domain.Status, int, int) ([]*domain.Message, int64, error) {
+	return nil, 0, nil
+}
+
+func (r *fakeOutboxRepo) CountByStatus(context.Context) (map[domain.Status]int64, error) {
+	counts := map[domain.Status]int64{}
+	for _, m := range r.messages {
+		counts[m.Status]++
+	}
+	return counts, nil
+}
+
+func (r *fakeOutboxRepo) DeleteFinished(_ context.Context, before time.Time, _ int) (int64, error) {
+	var n int64
+	for id, m := range r.messages {
+		if m.FinishedAt != nil && m.FinishedAt.Before(before) {
+			delete(r.messages, id)
+			n++
+		}
+	}
+	return n, nil
+}
+
+// scriptedSender возвращает ошибки по очереди из errs, затем успех; запоминает язык последнего письма.
+type scriptedSender struct {
+	errs   []error
+	calls  int
+	code   string
+	locale string
+}
+
+func (s *scriptedSender) next(ctx context.Context) error {
+	s.calls++
+	s.locale = mailerpkg.LocaleFromContext(ctx)
+	if len(s.errs) == 0 {
+		return nil
+	}
+	err := s.errs[0]
+	s.errs = s.errs[1:]
+	return err
+}
+
+func (s *scriptedSender) SendEmailVerificationCode(ctx context.Context, _, code string) error {
+	s.code = code
+	return s.next(ctx)
+}
+
+func (s *scriptedSender) SendPasswordChangeCode(ctx context.Context, _, code string) error {
+	s.code = code
+	return s.next(ctx)
+}
+
+func (s *scriptedSender) SendPasswordResetCode(ctx context.Context, _, code string) error {
+	s.code = code
+	return s.next(ctx)
+}
+
+func (s *scriptedSender) SendDataExportReady(ctx context.Context, _, _ string, _ time.Time) error {
+	return s.next(ctx)
+}
+
+func (s *scriptedSender) SendWelcome(ctx context.Context, _, _ string) error {
+	return s.next(ctx)
+}
+
+func (s *scriptedSender) SendPasswordChanged(ctx context.Context, _ string, _ time.Time) error {
+	return s.next(ctx)
+}
+
+func (s *scriptedSender) SendAccountDeleted(ctx context.Context, _ string, _ time.Time) error {
+	return s.next(ctx)
+}
+
+func (s *scriptedSender) SendNotification(ctx context.Context, _, _ string, _ time.Time) error {
+	return s.next(ctx)
+}
+
+func (s *scriptedSender) SendWeeklySummary(ctx context.Context, _ string, _ mailerpkg.WeeklySummary) error {
+	return s.next(ctx)
+}
+
+func (s *scriptedSender) SendCoachInvitation(ctx context.Context, _, _ string, _ time.Time) error {
+	return s.next(ctx)
+}
+
+var testConfig = emailoutboxuc.Config{
+	BatchSize:   10,
+	MaxAttempts: 3,
+	RetryBase:   time.Second,
+	RetryMax:    time.Hour,
+	Retention:   24 * time.Hour,
+}
+
+func newService(outbox *fakeOutboxRepo, sender mailerpkg.EmailSender) emailoutboxuc.Service {
+	log := logger.New(io.Discard, slog.LevelError, logger.FormatJSON)
+	return emailoutboxuc.NewService(outbox, sender, testConfig, log)
+}
+
+// makeDue сдвигает время следующей попытки всех писем в прошлое.
+func makeDue(outbox *fakeOutboxRepo) {
+	for _, m := range outbox.messages {
+		m.NextAttemptAt = time.Now().Add(-time.Second)
+	}
+}
+
+func TestOutboxSender_EnqueuesWithLocale(t *testing.T) {
+	outbox := newFakeOutboxRepo()
+	sender := mailer.NewOutboxSender(outbox)
+
+	ctx := mailerpkg.WithLocale(context.Background(), "ru")
+	require.NoError(t, sender.SendPasswordChangeCode(ctx, "user@example.com", "123456"))
+
+	m := outbox.messages[1]
+	require.Equal(t, domain.KindPasswordChangeCode, m.Kind)
+	require.Equal(t, "user@example.com", m.Recipient)
+	require.Equal(t, "ru", m.Locale)
+	require.Equal(t, "123456", m.Payload.Code)
+	require.Equal(t, domain.StatusPending, m.Status)
+}
+
+func TestRun_SendsAndClearsPayload(t *testing.T) {
+	outbox := newFakeOutboxRepo()
+	require.NoError(t, mailer.NewOutboxSender(outbox).SendEmailVerificationCode(
+		mailerpkg.WithLocale(context.Background(), "en"), "user@example.com", "654321"))
+	makeDue(outbox)
+
+	sender := &scriptedSender{}
+	require.NoError(t, newService(outbox, sender).Run(context.Background()))
+
+	require.Equal(t, 1, sender.calls)
+	require.Equal(t, "654321", sender.code)
+	require.Equal(t, "en", sender.locale)
+	m := outbox.messages[1]
+	require.Equal(t, domain.StatusSent, m.Status)
+	require.Empty(t, m.Payload.Code)
+	require.NotNil(t, m.FinishedAt)
+}
+
+func TestRun_RetriesWithBackoffThenDeadLetters(t *testing.T) {
+	outbox := newFakeOutboxRepo()
+	require.NoError(t, mailer.NewOutboxSender(outbox).SendEmailVerificationCode(context.Background(), "user@example.com", "111111"))
+	makeDue(outbox)
+
+	smtpDown := errors.New("dial tcp: connection refused")
+	sender := &scriptedSender{errs: []error{smtpDown, smtpDown, smtpDown}}
+	svc := newService(outbox, sender)
+
+	require.NoError(t, svc.Run(context.Background()))
+	m := outbox.messages[1]
+	require.Equal(t, domain.StatusPending, m.Status)
+	require.Equal(t, 1, m.Attempts)
+	require.Equal(t, smtpDown.Error(), m.LastError)
+	require.True(t, m.NextAttemptAt.After(time.Now()))
+
+	// Пока время попытки не наступило, письмо не отправляется повторно.
+	require.NoError(t, svc.Run(context.Background()))
+	require.Equal(t, 1, sender.calls)
+
+	makeDue(outbox)
+	require.NoError(t, svc.Run(context.Background()))
+	makeDue(outbox)
+	require.NoError(t, svc.Run(context.Background()))
+
+	m = outbox.messages[1]
+	require.Equal(t, 3, sender.calls)
+	require.Equal(t, domain.StatusDead, m.Status)
+	require.Equal(t, 3, m.Attempts)
+	require.NotNil(t, m.FinishedAt)
+}
+
+func TestRun_UndeliverableGoesStraightToDeadLetter(t *testing.T) {
+	outbox := newFakeOutboxRepo()
+	require.NoError(t, mailer.NewOutboxSender(outbox).SendEmailVerificationCode(context.Background(), "bounced@example.com", "111111"))
+	makeDue(outbox)
+
+	sender := &scriptedSender{errs: []error{mailerpkg.ErrRecipientUndeliverable}}
+	require.NoError(t, newService(outbox, sender).Run(context.Background()))
+
+	m := outbox.messages[1]
+	require.Equal(t, domain.StatusDead, m.Status)
+	require.Equal(t, 0, m.Attempts)
+}
+
+func TestRun_ProviderRejectionGoesStraightToDeadLetter(t *testing.T) {
+	outbox := newFakeOutboxRepo()
+	require.NoError(t, mailer.NewOutboxSender(outbox).SendEmailVerificationCode(context.Background(), "user@example.com", "111111"))
+	makeDue(outbox)
+
+	rejected := fmt.Errorf("sendgrid: unexpected status 400: %w", mailerpkg.ErrMessageRejected)
+	sender := &scriptedSender{errs: []error{rejected}}
+	require.NoError(t, newService(outbox, sender).Run(context.Background()))
+
+	m := outbox.messages[1]
+	require.Equal(t, domain.StatusDead, m.Status)
+	require.Equal(t, rejected.Error(), m.LastError)
+}
+
+func TestRun_RateLimitedDoesNotConsumeAttempts(t *testing.T) {
+	outbox := newFakeOutboxRepo()
+	require.NoError(t, mailer.NewOutboxSender(outbox).SendEmailVerificationCode(context.Background(), "member@example.com", "111111"))
+	makeDue(outbox)
+
+	sender := &scriptedSender{errs: []error{mailerpkg.ErrSendRateLimited}}
+	require.NoError(t, newService(outbox, sender).Run(context.Background()))
+
+	m := outbox.messages[1]
+	require.Equal(t, domain.StatusPending, m.Status)
+	require.Equal(t, 0, m.Attempts)
+	require.True(t, m.NextAttemptAt.After(time.Now().Add(testConfig.RetryMax-time.Minute)))
+}
+
+func TestRetry_RequeuesOnlyDeadLetters(t *testing.T) {
+	outbox := newFakeOutboxRepo()
+	require.NoError(t, mailer.NewOutboxSender(outbox).SendEmailVerificationCode(context.Background(), "user@example.com", "111111"))
+	svc := newService(outbox, &scriptedSender{})
+
+	_, err := svc.Retry(context.Background(), 1)
+	require.ErrorIs(t, err, emailoutboxuc.ErrNotDead)
+	_, err = svc.Retry(context.Background(), 42)
+	require.ErrorIs(t, err, repo.ErrNotFound)
+
+	outbox.messages[1].MarkDead(time.Now(), "smtp unavailable")
+	outbox.messages[1].Attempts = 3
+	m, err := svc.Retry(context.Background(), 1)
+	require.NoError(t, err)
+	require.Equal(t, domain.StatusPending, m.Status)
+	require.Equal(t, 0, m.Attempts)
+	require.Nil(t, m.FinishedAt)
+
+	require.NoError(t, svc.Run(context.Background()))
+	require.Equal(t, domain.StatusSent, outbox.messages[1].Status)
+}
+
+func TestDeleteExpired_KeepsMessagesWithinRetention(t *testing.T) {
+	outbox := newFakeOutboxRepo()
+	sender := mailer.NewOutboxSender(outbox)
+	require.NoError(t, sender.SendEmailVerificationCode(context.Background(), "old@example.com", "1"))
+	require.NoError(t, sender.SendEmailVerificationCode(context.Background(), "new@example.com", "2"))
+	require.NoError(t, sender.SendEmailVerificationCode(context.Background(), "pending@example.com", "3"))
+	now := time.Now()
+	outbox.messages[1].MarkSent(now.Add(-48 * time.Hour))
+	outbox.messages[2].MarkSent(now.Add(-time.Hour))
+
+	n, err := newService(outbox, &scriptedSender{}).DeleteExpired(context.Background(), now, 100)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), n)
+	require.NotContains(t, outbox.messages, int64(1))
+	require.Contains(t, outbox.messages, int64(2))
+	require.Contains(t, outbox.messages, int64(3))
+}
+
+func TestRetryDelay_DoublesUpToMax(t *testing.T) {
+	base, max := 30*time.Second, 5*time.Minute
+	require.Equal(t, 30*time.Second, domain.RetryDelay(1, base, max))
+	require.Equal(t, time.Minute, domain.RetryDelay(2, base, max))
+	require.Equal(t, 2*time.Minute, domain.RetryDelay(3, base, max))
+	require.Equal(t, 5*time.Minute, domain.RetryDelay(5, base, max))
+	require.Equal(t, 5*time.Minute, domain.RetryDelay(100, base, max))
+}
@@ -0,0 +1,162 @@
+package events_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	domain "workout-app/internal/domain/event"
+	userdomain "workout-app/internal/domain/user"
+	"workout-app/internal/events"
+	repo "workout-app/internal/repository/interfaces"
+	"workout-app/pkg/mailer"
+)
+
+type fakeUsers struct {
+	repo.UserRepository
+	users map[uuid.UUID]*userdomain.User
+}
+
+func (r *fakeUsers) GetByIDIncludingDeleted(_ context.Context, id uuid.UUID) (*userdomain.User, error) {
+	u, ok := r.users[id]
+	if !ok {
+		return nil, repo.ErrNotFound
+	}
+	return u, nil
+}
+
+type sentEmail struct {
+	kind   string
+	to     string
+	locale string
+}
+
+type recordingSender struct {
+	sent []sentEmail
+}
+
+func (s *recordingSender) record(ctx context.Context, kind, to string) error {
+	s.sent = append(s.sent, sentEmail{kind: kind, to: to, locale: mailer.LocaleFromContext(ctx)})
+	return nil
+}
+
+func (s *recordingSender) SendEmailVerificationCode(ctx context.Context, email, _ string) error {
+	return s.record(ctx, "verification_code", email)
+}
+
+func (s *recordingSender) SendPasswordChangeCode(ctx context.Context, email, _ string) error {
+	return s.record(ctx, "password_change_code", email)
+}
+
+func (s *recordingSender) SendPasswordResetCode(ctx context.Context, email, _ string) error {
+	return s.record(ctx, "password_reset_code", email)
+}
+
+func (s *recordingSender) SendDataExportReady(ctx context.Context, email, _ string, _ time.Time) error {
+	return s.record(ctx, "data_export_ready", email)
+}
+
+func (s *recordingSender) SendWelcome(ctx context.Context, email, _ string) error {
+	return s.record(ctx, "welcome", email)
+}
+
+func (s *recordingSender) SendPasswordChanged(ctx context.Context, email string, _ time.Time) error {
+	return s.record(ctx, "password_changed", email)
+}
+
+func (s *recordingSender) SendAccountDeleted(ctx context.Context, email string, _ time.Time) error {
+	return s.record(ctx, "account_deleted", email)
+}
+
+func (s *recordingSender) SendNotification(ctx context.Context, email, notificationType string, _ time.Time) error {
+	return s.record(ctx, "notification:"+notificationType, email)
+}
+
+func (s *recordingSender) SendWeeklySummary(ctx context.Context, email string, _ mailer.WeeklySummary) error {
+	return s.record(ctx, "weekly_summary", email)
+}
+
+func (s *recordingSender) SendCoachInvitation(ctx context.Context, email, _ string, _ time.Time) error {
+	return s.record(ctx, "coach_invitation", email)
+}
+
+func newAccountEmailsFixture(t *testing.T) (*events.AccountEmails, *recordingSender, *userdomain.User) {
+	t.Helper()
+	u := userdomain.NewUser("user@example.com", "hash", "user1")
+	u.Language = userdomain.LanguageRussian
+	sender := &recordingSender{}
+	sub := events.NewAccountEmails(&fakeUsers{users: map[uuid.UUID]*userdomain.User{u.ID: u}}, sender)
+	return sub, sender, u
+}
+
+func accountEvent(t *testing.T, eventType string, occurredAt time.Time, payload any) domain.Event {
+	t.Helper()
+	raw, err := json.Marshal(payload)
+	require.NoError(t, err)
+	return domain.Event{ID: 1, Type: eventType, Payload: raw, OccurredAt: occurredAt}
+}
+
+func TestAccountEmails_WelcomeAfterEmailVerified(t *testing.T) {
+	sub, sender, u := newAccountEmailsFixture(t)
+	now := time.Now().UTC()
+
+	changes, err := sub.Handle(context.Background(), accountEvent(t, domain.TypeEmailVerified, now,
+		domain.EmailVerified{UserID: u.ID.String(), VerifiedAt: now}), false)
+	require.NoError(t, err)
+	require.Len(t, changes, 1)
+	require.Equal(t, []sentEmail{{kind: "welcome", to: "user@example.com", locale: "ru"}}, sender.sent)
+}
+
+func TestAccountEmails_RegistrationWaitsForVerification(t *testing.T) {
+	sub, sender, u := newAccountEmailsFixture(t)
+	now := time.Now().UTC()
+
+	_, err := sub.Handle(context.Background(), accountEvent(t, domain.TypeUserRegistered, now,
+		domain.UserRegistered{UserID: u.ID.String(), Method: domain.RegistrationPassword, RegisteredAt: now}), false)
+	require.NoError(t, err)
+	require.Empty(t, sender.sent)
+
+	_, err = sub.Handle(context.Background(), accountEvent(t, domain.TypeUserRegistered, now,
+		domain.UserRegistered{UserID: u.ID.String(), Method: "google", EmailVerified: true, RegisteredAt: now}), false)
+	require.NoError(t, err)
+	require.Len(t, sender.sent, 1)
+	require.Equal(t, "welcome", sender.sent[0].kind)
+}
+
+func TestAccountEmails_SkipsStaleEventsAndAnonymizedUsers(t *testing.T) {
+	sub, sender, u := newAccountEmailsFixture(t)
+	now := time.Now().UTC()
+
+	stale := now.Add(-48 * time.Hour)
+	changes, err := sub.Handle(context.Background(), accountEvent(t, domain.TypePasswordChanged, stale,
+		domain.PasswordChanged{UserID: u.ID.String(), ChangedAt: stale}), false)
+	require.NoError(t, err)
+	require.Empty(t, changes)
+
+	u.Anonymize(now)
+	changes, err = sub.Handle(context.Background(), accountEvent(t, domain.TypeAccountDeleted, now,
+		domain.AccountDeleted{UserID: u.ID.String(), DeletedAt: now}), false)
+	require.NoError(t, err)
+	require.Empty(t, changes)
+
+	changes, err = sub.Handle(context.Background(), accountEvent(t, domain.TypeAccountDeleted, now,
+		domain.AccountDeleted{UserID: uuid.NewString(), DeletedAt: now}), false)
+	require.NoError(t, err)
+	require.Empty(t, changes)
+	require.Empty(t, sender.sent)
+}
+
+func TestAccountEmails_DryRunDoesNotSend(t *testing.T) {
+	sub, sender, u := newAccountEmailsFixture(t)
+	now := time.Now().UTC()
+
+	changes, err := sub.Handle(context.Background(), accountEvent(t, domain.TypePasswordChanged, now,
+		domain.PasswordChanged{UserID: u.ID.String(), ChangedAt: now}), true)
+	require.NoError(t, err)
+	require.Equal(t, []string{"send password_changed email to user " + u.ID.String()}, changes)
+	require.Empty(t, sender.sent)
+}
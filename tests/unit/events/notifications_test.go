@@ -0,0 +1,152 @@
+package events_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	domain "workout-app/internal/domain/event"
+	"workout-app/internal/domain/gymclass"
+	"workout-app/internal/domain/notification"
+	userdomain "workout-app/internal/domain/user"
+	"workout-app/internal/events"
+	repo "workout-app/internal/repository/interfaces"
+)
+
+type fakeNotifications struct {
+	repo.NotificationRepository
+	created []*notification.Notification
+	prefs   []notification.Preference
+}
+
+func (r *fakeNotifications) Create(_ context.Context, n *notification.Notification) (bool, error) {
+	for _, c := range r.created {
+		if c.EventID == n.EventID && c.UserID == n.UserID {
+			return false, nil
+		}
+	}
+	r.created = append(r.created, n)
+	return true, nil
+}
+
+func (r *fakeNotifications) GetPreferences(context.Context, uuid.UUID) ([]notification.Preference, error) {
+	return r.prefs, nil
+}
+
+func newNotificationsFixture(t *testing.T) (*events.Notifications, *fakeNotifications, *recordingSender, *userdomain.User) {
+	t.Helper()
+	u := userdomain.NewUser("user@example.com", "hash", "user1")
+	u.Language = userdomain.LanguageRussian
+	store := &fakeNotifications{}
+	sender := &recordingSender{}
+	sub := events.NewNotifications(store, &fakeUsers{users: map[uuid.UUID]*userdomain.User{u.ID: u}}, sender)
+	return sub, store, sender, u
+}
+
+func TestNotifications_ProgramAssignedCreatesNotificationAndEmail(t *testing.T) {
+	sub, store, sender, u := newNotificationsFixture(t)
+	now := time.Now().UTC()
+
+	changes, err := sub.Handle(context.Background(), accountEvent(t, domain.TypeProgramAssigned, now,
+		domain.ProgramAssigned{UserID: u.ID.String(), AssignedBy: uuid.NewString(), StartDate: now}), false)
+	require.NoError(t, err)
+	require.Len(t, changes, 2)
+	require.Len(t, store.created, 1)
+	require.Equal(t, notification.TypeProgramAssigned, store.created[0].Type)
+	require.Equal(t, u.ID, store.created[0].UserID)
+	require.True(t, store.created[0].PushPending)
+	require.Equal(t, []sentEmail{{kind: "notification:program.assigned", to: "user@example.com", locale: "ru"}}, sender.sent)
+}
+
+func TestNotifications_SelfAssignedProgramSkipped(t *testing.T) {
+	sub, store, sender, u := newNotificationsFixture(t)
+	now := time.Now().UTC()
+
+	changes, err := sub.Handle(context.Background(), accountEvent(t, domain.TypeProgramAssigned, now,
+		domain.ProgramAssigned{UserID: u.ID.String(), AssignedBy: u.ID.String(), StartDate: now}), false)
+	require.NoError(t, err)
+	require.Empty(t, changes)
+	require.Empty(t, store.created)
+	require.Empty(t, sender.sent)
+}
+
+func TestNotifications_WaitlistedBookingUsesOwnType(t *testing.T) {
+	sub, store, sender, u := newNotificationsFixture(t)
+	now := time.Now().UTC()
+
+	_, err := sub.Handle(context.Background(), accountEvent(t, domain.TypeClassBooked, now,
+		domain.ClassBooking{UserID: u.ID.String(), StartsAt: now.Add(24 * time.Hour), Status: string(gymclass.StatusWaitlisted)}), false)
+	require.NoError(t, err)
+	require.Len(t, store.created, 1)
+	require.Equal(t, notification.TypeClassWaitlisted, store.created[0].Type)
+	require.Equal(t, "notification:class.waitlisted", sender.sent[0].kind)
+}
+
+func TestNotifications_EmailDisabledByPreference(t *testing.T) {
+	sub, store, sender, u := newNotificationsFixture(t)
+	store.prefs = []notification.Preference{{Type: notification.TypeClassWaitlistPromoted, Email: false, Push: true}}
+	now := time.Now().UTC()
+
+	changes, err := sub.Handle(context.Background(), accountEvent(t, domain.TypeClassWaitlistPromoted, now,
+		domain.ClassBooking{UserID: u.ID.String(), StartsAt: now.Add(time.Hour), Status: string(gymclass.StatusBooked)}), false)
+	require.NoError(t, err)
+	require.Len(t, changes, 1)
+	require.Len(t, store.created, 1)
+	require.True(t, store.created[0].PushPending)
+	require.Empty(t, sender.sent)
+}
+
+func TestNotifications_PushDisabledByPreference(t *testing.T) {
+	sub, store, sender, u := newNotificationsFixture(t)
+	store.prefs = []notification.Preference{{Type: notification.TypeClassBooked, Email: true, Push: false}}
+	now := time.Now().UTC()
+
+	_, err := sub.Handle(context.Background(), accountEvent(t, domain.TypeClassBooked, now,
+		domain.ClassBooking{UserID: u.ID.String(), StartsAt: now.Add(time.Hour), Status: string(gymclass.StatusBooked)}), false)
+	require.NoError(t, err)
+	require.Len(t, store.created, 1)
+	require.False(t, store.created[0].PushPending)
+	require.Len(t, sender.sent, 1)
+}
+
+func TestNotifications_ReplayDoesNotDuplicate(t *testing.T) {
+	sub, store, sender, u := newNotificationsFixture(t)
+	now := time.Now().UTC()
+	ev := accountEvent(t, domain.TypeClassBooked, now,
+		domain.ClassBooking{UserID: u.ID.String(), StartsAt: now.Add(time.Hour), Status: string(gymclass.StatusBooked)})
+
+	_, err := sub.Handle(context.Background(), ev, false)
+	require.NoError(t, err)
+	changes, err := sub.Handle(context.Background(), ev, false)
+	require.NoError(t, err)
+	require.Empty(t, changes)
+	require.Len(t, store.created, 1)
+	require.Len(t, sender.sent, 1)
+}
+
+func TestNotifications_StaleEventCreatesNotificationWithoutEmail(t *testing.T) {
+	sub, store, sender, u := newNotificationsFixture(t)
+	old := time.Now().UTC().Add(-48 * time.Hour)
+
+	_, err := sub.Handle(context.Background(), accountEvent(t, domain.TypeClassBooked, old,
+		domain.ClassBooking{UserID: u.ID.String(), StartsAt: old, Status: string(gymclass.StatusBooked)}), false)
+	require.NoError(t, err)
+	require.Len(t, store.created, 1)
+	require.False(t, store.created[0].PushPending)
+	require.Empty(t, sender.sent)
+}
+
+func TestNotifications_DryRunChangesNothing(t *testing.T) {
+	sub, store, sender, u := newNotificationsFixture(t)
+	now := time.Now().UTC()
+
+	changes, err := sub.Handle(context.Background(), accountEvent(t, domain.TypeClassBooked, now,
+		domain.ClassBooking{UserID: u.ID.String(), StartsAt: now, Status: string(gymclass.StatusBooked)}), true)
+	require.NoError(t, err)
+	require.Len(t, changes, 1)
+	require.Empty(t, store.created)
+	require.Empty(t, sender.sent)
+}
@@ -0,0 +1,139 @@
+package events_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	domain "workout-app/internal/domain/event"
+	"workout-app/internal/events"
+)
+
+type fakeEventStore struct {
+	events      []domain.Event
+	checkpoints map[string]int64
+}
+
+func newFakeEventStore(types ...string) *fakeEventStore {
+	s := &fakeEventStore{checkpoints: map[string]int64{}}
+	for i, t := range types {
+		s.events = append(s.events, domain.Event{ID: int64(i + 1), Type: t})
+	}
+	return s
+}
+
+func (s *fakeEventStore) Append(_ context.Context, ev *domain.Event) error {
+	ev.ID = int64(len(s.events) + 1)
+	s.events = append(s.events, *ev)
+	return nil
+}
+
+func (s *fakeEventStore) ListAfter(_ context.Context, afterID, toID int64, types []string, limit int) ([]domain.Event, error) {
+	var result []domain.Event
+	for _, ev := range s.events {
+		if ev.ID <= afterID || (toID > 0 && ev.ID > toID) {
+			continue
+		}
+		if len(types) > 0 && !contains(types, ev.Type) {
+			continue
+		}
+		result = append(result, ev)
+		if len(result) == limit {
+			break
+		}
+	}
+	return result, nil
+}
+
+func (s *fakeEventStore) GetCheckpoint(_ context.Context, name string) (int64, error) {
+	return s.checkpoints[name], nil
+}
+
+func (s *fakeEventStore) SaveCheckpoint(_ context.Context, name string, lastEventID int64) error {
+	s.checkpoints[name] = lastEventID
+	return nil
+}
+
+type recordingSubscriber struct {
+	types   []string
+	handled []int64
+	failOn  int64
+}
+
+func (s *recordingSubscriber) Name() string    { return "recorder" }
+func (s *recordingSubscriber) Types() []string { return s.types }
+
+func (s *recordingSubscriber) Handle(_ context.Context, ev domain.Event, dryRun bool) ([]string, error) {
+	if ev.ID == s.failOn {
+		return nil, errors.New("boom")
+	}
+	if !dryRun {
+		s.handled = append(s.handled, ev.ID)
+	}
+	return []string{"changed " + ev.Type}, nil
+}
+
+func contains(list []string, v string) bool {
+	for _, item := range list {
+		if item == v {
+			return true
+		}
+	}
+	return false
+}
+
+func TestReplay_ResumesFromCheckpoint(t *testing.T) {
+	store := newFakeEventStore("a", "b", "a", "a")
+	sub := &recordingSubscriber{types: []string{"a"}}
+	replayer := events.NewReplayer(store)
+
+	result, err := replayer.Replay(context.Background(), sub, events.ReplayOptions{BatchSize: 2}, nil)
+	require.NoError(t, err)
+	require.Equal(t, 3, result.Delivered)
+	require.Equal(t, []int64{1, 3, 4}, sub.handled)
+	require.Equal(t, int64(4), store.checkpoints[events.CheckpointName("recorder")])
+
+	// Повторный запуск без новых событий ничего не доставляет.
+	result, err = replayer.Replay(context.Background(), sub, events.ReplayOptions{}, nil)
+	require.NoError(t, err)
+	require.Zero(t, result.Delivered)
+
+	// Reset доставляет всё заново.
+	result, err = replayer.Replay(context.Background(), sub, events.ReplayOptions{Reset: true}, nil)
+	require.NoError(t, err)
+	require.Equal(t, 3, result.Delivered)
+}
+
+func TestReplay_DryRunDoesNotMoveCheckpoint(t *testing.T) {
+	store := newFakeEventStore("a", "a")
+	sub := &recordingSubscriber{}
+	replayer := events.NewReplayer(store)
+
+	var outcomes []events.Outcome
+	result, err := replayer.Replay(context.Background(), sub, events.ReplayOptions{DryRun: true}, func(o events.Outcome) {
+		outcomes = append(outcomes, o)
+	})
+	require.NoError(t, err)
+	require.Equal(t, 2, result.Changed)
+	require.Len(t, outcomes, 2)
+	require.Empty(t, sub.handled)
+	require.NotContains(t, store.checkpoints, events.CheckpointName("recorder"))
+}
+
+func TestReplay_StopsOnErrorAndKeepsProgress(t *testing.T) {
+	store := newFakeEventStore("a", "a", "a")
+	sub := &recordingSubscriber{failOn: 2}
+	replayer := events.NewReplayer(store)
+
+	_, err := replayer.Replay(context.Background(), sub, events.ReplayOptions{}, nil)
+	require.Error(t, err)
+	require.Equal(t, int64(1), store.checkpoints[events.CheckpointName("recorder")])
+
+	sub.failOn = 0
+	result, err := replayer.Replay(context.Background(), sub, events.ReplayOptions{FromID: 2, ToID: 2}, nil)
+	require.NoError(t, err)
+	require.Equal(t, 1, result.Delivered)
+	require.Equal(t, []int64{1, 2}, sub.handled)
+}
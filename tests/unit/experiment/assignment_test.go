@@ -0,0 +1,66 @@
+package experiment_test
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	domain "workout-app/internal/domain/experiment"
+)
+
+func TestAssign_IsDeterministic(t *testing.T) {
+	e := &domain.Experiment{
+		Key:      "onboarding_v2",
+		Variants: []domain.Variant{{Name: "control", Weight: 50}, {Name: "treatment", Weight: 50}},
+	}
+	userID := uuid.New()
+
+	first, ok := e.Assign(userID)
+	require.True(t, ok)
+	for i := 0; i < 10; i++ {
+		v, ok := e.Assign(userID)
+		require.True(t, ok)
+		require.Equal(t, first, v)
+	}
+}
+
+func TestAssign_RespectsWeights(t *testing.T) {
+	e := &domain.Experiment{
+		Key:      "paywall_copy",
+		Variants: []domain.Variant{{Name: "control", Weight: 90}, {Name: "treatment", Weight: 10}},
+	}
+
+	counts := map[string]int{}
+	const n = 10000
+	for i := 0; i < n; i++ {
+		v, ok := e.Assign(uuid.New())
+		require.True(t, ok)
+		counts[v.Name]++
+	}
+
+	// Допуск ±3 п.п. для 10k пользователей более чем достаточен.
+	require.InDelta(t, 0.9, float64(counts["control"])/n, 0.03)
+	require.InDelta(t, 0.1, float64(counts["treatment"])/n, 0.03)
+}
+
+func TestAssign_ZeroWeightVariantNeverChosen(t *testing.T) {
+	e := &domain.Experiment{
+		Key:      "disabled_arm",
+		Variants: []domain.Variant{{Name: "control", Weight: 1}, {Name: "off", Weight: 0}},
+	}
+	for i := 0; i < 1000; i++ {
+		v, ok := e.Assign(uuid.New())
+		require.True(t, ok)
+		require.Equal(t, "control", v.Name)
+	}
+}
+
+func TestAssign_NoPositiveWeights(t *testing.T) {
+	e := &domain.Experiment{
+		Key:      "empty",
+		Variants: []domain.Variant{{Name: "a", Weight: 0}},
+	}
+	_, ok := e.Assign(uuid.New())
+	require.False(t, ok)
+}
@@ -0,0 +1,341 @@
+package export_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	checkindomain "workout-app/internal/domain/checkin"
+	consentdomain "workout-app/internal/domain/consent"
+	custommetricdomain "workout-app/internal/domain/custommetric"
+	domain "workout-app/internal/domain/export"
+	programdomain "workout-app/internal/domain/program"
+	userdomain "workout-app/internal/domain/user"
+	workoutdomain "workout-app/internal/domain/workout"
+	repo "workout-app/internal/repository/interfaces"
+	exportuc "workout-app/internal/usecase/export"
+	"workout-app/pkg/logger"
+	"workout-app/pkg/mailer"
+	"workout-app/pkg/storage"
+)
+
+type fakeExports struct {
+	repo.DataExportRepository
+	items map[uuid.UUID]*domain.Export
+}
+
+func (r *fakeExports) Create(_ context.Context, e *domain.Export) error {
+	for _, existing := range r.items {
+		if existing.UserID == e.UserID && existing.Status == domain.StatusPending {
+			return repo.ErrExportPending
+		}
+	}
+	cp := *e
+	r.items[e.ID] = &cp
+	return nil
+}
+
+func (r *fakeExports) GetByID(_ context.Context, id uuid.UUID) (*domain.Export, error) {
+	e, ok := r.items[id]
+	if !ok {
+		return nil, repo.ErrNotFound
+	}
+	cp := *e
+	return &cp, nil
+}
+
+func (r *fakeExports) GetLatestByUser(_ context.Context, userID uuid.UUID) (*domain.Export, error) {
+	var latest *domain.Export
+	for _, e := range r.items {
+		if e.UserID == userID && (latest == nil || e.RequestedAt.After(latest.RequestedAt)) {
+			latest = e
+		}
+	}
+	if latest == nil {
+		return nil, repo.ErrNotFound
+	}
+	cp := *latest
+	return &cp, nil
+}
+
+func (r *fakeExports) ListPending(context.Context, int) ([]*domain.Export, error) {
+	var out []*domain.Export
+	for _, e := range r.items {
+		if e.Status == domain.StatusPending {
+			cp := *e
+			out = append(out, &cp)
+		}
+	}
+	return out, nil
+}
+
+func (r *fakeExports) Claim(context.Context, uuid.UUID, time.Time) (bool, error) {
+	return true, nil
+}
+
+func (r *fakeExports) Complete(_ context.Context, e *domain.Export) error {
+	cp := *e
+	r.items[e.ID] = &cp
+	return nil
+}
+
+func (r *fakeExports) ListExpired(_ context.Context, now time.Time, _ int) ([]*domain.Export, error) {
+	var out []*domain.Export
+	for _, e := range r.items {
+		if e.ExpiresAt != nil && !e.ExpiresAt.After(now) {
+			cp := *e
+			out = append(out, &cp)
+		}
+	}
+	return out, nil
+}
+
+func (r *fakeExports) Delete(_ context.Context, id uuid.UUID) error {
+	delete(r.items, id)
+	return nil
+}
+
+type fakeUsers struct {
+	repo.UserRepository
+	user *userdomain.User
+}
+
+func (r *fakeUsers) GetByID(_ context.Context, id uuid.UUID) (*userdomain.User, error) {
+	if r.user == nil || r.user.ID != id {
+		return nil, repo.ErrNotFound
+	}
+	return r.user, nil
+}
+
+type fakeWorkouts struct {
+	repo.WorkoutSessionRepository
+	sessions []*workoutdomain.Session
+}
+
+func (r *fakeWorkouts) ListByUser(context.Context, uuid.UUID, time.Time, time.Time, int) ([]*workoutdomain.Session, error) {
+	return r.sessions, nil
+}
+
+type fakeMetrics struct {
+	repo.BodyMetricRepository
+}
+
+func (r *fakeMetrics) ListByUser(context.Context, uuid.UUID, time.Time, time.Time, int, int) ([]*userdomain.BodyMetric, error) {
+	return nil, nil
+}
+
+type fakeCheckIns struct {
+	repo.CheckInRepository
+}
+
+func (r *fakeCheckIns) ListByUser(context.Context, uuid.UUID, time.Time, time.Time) ([]*checkindomain.CheckIn, error) {
+	return nil, nil
+}
+
+type fakeCustomMetrics struct {
+	repo.CustomMetricRepository
+}
+
+func (r *fakeCustomMetrics) ListByUser(context.Context, uuid.UUID) ([]*custommetricdomain.Definition, error) {
+	return nil, nil
+}
+
+func (r *fakeCustomMetrics) ListEntriesByUser(context.Context, uuid.UUID) ([]*custommetricdomain.Entry, error) {
+	return nil, nil
+}
+
+type fakePrograms struct {
+	repo.ProgramRepository
+}
+
+func (r *fakePrograms) ListAssignmentsByUser(context.Context, uuid.UUID) ([]*programdomain.Assignment, error) {
+	return nil, nil
+}
+
+type fakeTrainingMaxes struct {
+	repo.TrainingMaxRepository
+}
+
+func (r *fakeTrainingMaxes) ListByUser(context.Context, uuid.UUID) ([]*programdomain.TrainingMax, error) {
+	return nil, nil
+}
+
+type fakeConsents struct {
+	repo.ConsentRepository
+}
+
+func (r *fakeConsents) ListByClient(context.Context, uuid.UUID) ([]*consentdomain.Consent, error) {
+	return nil, nil
+}
+
+type fakeSender struct {
+	sentTo string
+	link   string
+}
+
+func (s *fakeSender) SendEmailVerificationCode(context.Context, string, string) error { return nil }
+func (s *fakeSender) SendPasswordChangeCode(context.Context, string, string) error    { return nil }
+func (s *fakeSender) SendPasswordResetCode(context.Context, string, string) error     { return nil }
+
+func (s *fakeSender) SendDataExportReady(_ context.Context, email, link string, _ time.Time) error {
+	s.sentTo = email
+	s.link = link
+	return nil
+}
+
+func (s *fakeSender) SendWelcome(context.Context, string, string) error            { return nil }
+func (s *fakeSender) SendPasswordChanged(context.Context, string, time.Time) error { return nil }
+func (s *fakeSender) SendAccountDeleted(context.Context, string, time.Time) error  { return nil }
+func (s *fakeSender) SendNotification(context.Context, string, string, time.Time) error {
+	return nil
+}
+
+func (s *fakeSender) SendWeeklySummary(context.Context, string, mailer.WeeklySummary) error {
+	return nil
+}
+
+func (s *fakeSender) SendCoachInvitation(context.Context, string, string, time.Time) error {
+	return nil
+}
+
+type fixture struct {
+	svc     exportuc.Service
+	exports *fakeExports
+	sender  *fakeSender
+	user    *userdomain.User
+}
+
+func newFixture(t *testing.T) fixture {
+	t.Helper()
+	user := userdomain.NewUser("athlete@example.com", "secret-hash", "athlete")
+	start := time.Now().UTC().Add(-2 * time.Hour)
+	finish := start.Add(time.Hour)
+	session := workoutdomain.NewSession(user.ID, "Ноги", nil, 5*time.Minute, start)
+	session.FinishedAt = &finish
+
+	exports := &fakeExports{items: map[uuid.UUID]*domain.Export{}}
+	sender := &fakeSender{}
+	svc := exportuc.NewService(
+		exports, &fakeUsers{user: user}, &fakeWorkouts{sessions: []*workoutdomain.Session{session}},
+		&fakeMetrics{}, &fakeCheckIns{}, &fakeCustomMetrics{}, &fakePrograms{}, &fakeTrainingMaxes{}, &fakeConsents{},
+		storage.NewLocalStorage(t.TempDir(), "/uploads"), sender,
+		exportuc.Config{TTL: 24 * time.Hour, SigningKey: []byte("test-key"), BaseURL: "https://api.example.com"},
+		logger.New(io.Discard, slog.LevelError, logger.FormatJSON),
+	)
+	return fixture{svc: svc, exports: exports, sender: sender, user: user}
+}
+
+func linkParams(t *testing.T, link string) (uuid.UUID, string, string) {
+	t.Helper()
+	u, err := url.Parse(link)
+	require.NoError(t, err)
+	parts := strings.Split(strings.Trim(u.Path, "/"), "/")
+	id, err := uuid.Parse(parts[len(parts)-2])
+	require.NoError(t, err)
+	return id, u.Query().Get("expires"), u.Query().Get("signature")
+}
+
+func TestExport_AssemblesArchiveAndNotifies(t *testing.T) {
+	f := newFixture(t)
+	ctx := context.Background()
+
+	e, err := f.svc.Request(ctx, f.user.ID)
+	require.NoError(t, err)
+	require.Equal(t, domain.StatusPending, e.Status)
+
+	again, err := f.svc.Request(ctx, f.user.ID)
+	require.NoError(t, err)
+	require.Equal(t, e.ID, again.ID, "повторный запрос не должен ставить вторую выгрузку в очередь")
+
+	require.NoError(t, f.svc.Run(ctx))
+
+	ready, err := f.svc.Request(ctx, f.user.ID)
+	require.NoError(t, err)
+	require.Equal(t, e.ID, ready.ID)
+	require.Equal(t, domain.StatusReady, ready.Status)
+	require.Equal(t, f.user.Email, f.sender.sentTo)
+	require.True(t, strings.HasPrefix(f.sender.link, "https://api.example.com/api/v1/exports/"))
+
+	id, expires, signature := linkParams(t, f.sender.link)
+	download, err := f.svc.Open(ctx, id, expires, signature)
+	require.NoError(t, err)
+	defer download.Object.Close()
+
+	raw, err := io.ReadAll(download.Object)
+	require.NoError(t, err)
+	zr, err := zip.NewReader(bytes.NewReader(raw), int64(len(raw)))
+	require.NoError(t, err)
+
+	files := map[string][]byte{}
+	for _, file := range zr.File {
+		rc, err := file.Open()
+		require.NoError(t, err)
+		files[file.Name], err = io.ReadAll(rc)
+		require.NoError(t, err)
+		rc.Close()
+	}
+	require.Contains(t, files, "workouts.json")
+	require.Contains(t, files, "body_metrics.json")
+	require.Contains(t, files, "checkins.json")
+	require.Contains(t, files, "custom_metrics.json")
+	require.Contains(t, files, "training_maxes.json")
+
+	var profile map[string]any
+	require.NoError(t, json.Unmarshal(files["profile.json"], &profile))
+	require.Equal(t, "athlete@example.com", profile["email"])
+	require.NotContains(t, string(files["profile.json"]), "secret-hash")
+
+	var workouts []map[string]any
+	require.NoError(t, json.Unmarshal(files["workouts.json"], &workouts))
+	require.Len(t, workouts, 1)
+	require.Equal(t, "Ноги", workouts[0]["title"])
+}
+
+func TestExport_RejectsTamperedAndExpiredLinks(t *testing.T) {
+	f := newFixture(t)
+	ctx := context.Background()
+
+	_, err := f.svc.Request(ctx, f.user.ID)
+	require.NoError(t, err)
+	require.NoError(t, f.svc.Run(ctx))
+
+	id, expires, signature := linkParams(t, f.sender.link)
+	_, err = f.svc.Open(ctx, id, expires, strings.Repeat("0", len(signature)))
+	require.ErrorIs(t, err, exportuc.ErrInvalidSignature)
+	_, err = f.svc.Open(ctx, uuid.New(), expires, signature)
+	require.ErrorIs(t, err, exportuc.ErrInvalidSignature)
+
+	// Обезличивание сокращает срок хранения: ссылка из письма перестаёт действовать.
+	past := time.Now().UTC().Add(-time.Minute)
+	f.exports.items[id].ExpiresAt = &past
+	_, err = f.svc.Open(ctx, id, expires, signature)
+	require.ErrorIs(t, err, exportuc.ErrLinkExpired)
+
+	require.NoError(t, f.svc.Run(ctx))
+	require.Empty(t, f.exports.items, "истёкшая выгрузка должна быть удалена")
+}
+
+func TestExport_FailsForMissingUser(t *testing.T) {
+	f := newFixture(t)
+	ctx := context.Background()
+
+	_, err := f.svc.Request(ctx, uuid.New())
+	require.NoError(t, err)
+	require.NoError(t, f.svc.Run(ctx))
+
+	for _, e := range f.exports.items {
+		require.Equal(t, domain.StatusFailed, e.Status)
+		require.NotNil(t, e.ExpiresAt)
+	}
+	require.Empty(t, f.sender.sentTo)
+}
@@ -0,0 +1,184 @@
+package gymcheckin_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	domain "workout-app/internal/domain/gymcheckin"
+	orgdomain "workout-app/internal/domain/organization"
+	userdomain "workout-app/internal/domain/user"
+	repo "workout-app/internal/repository/interfaces"
+	gymcheckinuc "workout-app/internal/usecase/gymcheckin"
+)
+
+type fakeOrgs struct {
+	repo.OrganizationRepository
+	members map[uuid.UUID]orgdomain.Role
+}
+
+func (r *fakeOrgs) GetMember(_ context.Context, orgID, userID uuid.UUID) (*orgdomain.Member, error) {
+	role, ok := r.members[userID]
+	if !ok {
+		return nil, repo.ErrNotFound
+	}
+	return &orgdomain.Member{OrganizationID: orgID, UserID: userID, Role: role}, nil
+}
+
+type fakeCheckIns struct {
+	repo.GymCheckInRepository
+	location *domain.Location
+	visits   []*domain.Visit
+}
+
+func (r *fakeCheckIns) GetLocation(_ context.Context, id uuid.UUID) (*domain.Location, error) {
+	if r.location == nil || r.location.ID != id {
+		return nil, repo.ErrNotFound
+	}
+	return r.location, nil
+}
+
+func (r *fakeCheckIns) CreateVisit(_ context.Context, v *domain.Visit) error {
+	r.visits = append(r.visits, v)
+	return nil
+}
+
+func (r *fakeCheckIns) LastVisit(_ context.Context, userID, locationID uuid.UUID) (*domain.Visit, error) {
+	var last *domain.Visit
+	for _, v := range r.visits {
+		if v.UserID == userID && v.LocationID == locationID && (last == nil || v.CheckedInAt.After(last.CheckedInAt)) {
+			last = v
+		}
+	}
+	if last == nil {
+		return nil, repo.ErrNotFound
+	}
+	return last, nil
+}
+
+func (r *fakeCheckIns) ListVisitsByOrganization(_ context.Context, orgID uuid.UUID, _ *uuid.UUID, from, to time.Time) ([]*domain.Visit, error) {
+	var result []*domain.Visit
+	for _, v := range r.visits {
+		if v.OrganizationID == orgID && !v.CheckedInAt.Before(from) && v.CheckedInAt.Before(to) {
+			result = append(result, v)
+		}
+	}
+	return result, nil
+}
+
+type fakePublisher struct {
+	published int
+}
+
+func (p *fakePublisher) Publish(context.Context, string, string, any) {
+	p.published++
+}
+
+func newLocation() *domain.Location {
+	return &domain.Location{ID: uuid.New(), OrganizationID: uuid.New(), Name: "Центр", Secret: []byte("0123456789abcdef0123456789abcdef")}
+}
+
+func TestLocationCode_RotatesAndAcceptsPreviousPeriod(t *testing.T) {
+	l := newLocation()
+	at := time.Date(2026, 10, 15, 12, 0, 10, 0, time.UTC)
+
+	code, expiresAt := l.Code(at)
+	require.Equal(t, time.Date(2026, 10, 15, 12, 0, 30, 0, time.UTC), expiresAt)
+
+	id, period, signature, err := domain.ParseCode(code)
+	require.NoError(t, err)
+	require.Equal(t, l.ID, id)
+	require.True(t, l.VerifyCode(period, signature, at))
+	require.True(t, l.VerifyCode(period, signature, at.Add(domain.CodeRotation)))
+	require.False(t, l.VerifyCode(period, signature, at.Add(2*domain.CodeRotation)))
+
+	next, _ := l.Code(at.Add(domain.CodeRotation))
+	require.NotEqual(t, code, next)
+
+	other := newLocation()
+	other.ID = l.ID
+	other.Secret = []byte("another-secret-another-secret-00")
+	require.False(t, other.VerifyCode(period, signature, at))
+
+	_, _, _, err = domain.ParseCode("not-a-code")
+	require.ErrorIs(t, err, domain.ErrMalformedCode)
+}
+
+func TestCheckIn_RecordsVisitOnce(t *testing.T) {
+	member := gymcheckinuc.Actor{UserID: uuid.New(), Role: userdomain.RoleUser}
+	outsider := gymcheckinuc.Actor{UserID: uuid.New(), Role: userdomain.RoleUser}
+	checkIns := &fakeCheckIns{location: newLocation()}
+	publisher := &fakePublisher{}
+	svc := gymcheckinuc.NewService(checkIns, &fakeOrgs{members: map[uuid.UUID]orgdomain.Role{member.UserID: orgdomain.RoleMember}}, publisher)
+	ctx := context.Background()
+	code, _ := checkIns.location.Code(time.Now())
+
+	visit, created, err := svc.CheckIn(ctx, member, code)
+	require.NoError(t, err)
+	require.True(t, created)
+	require.Equal(t, checkIns.location.OrganizationID, visit.OrganizationID)
+
+	again, created, err := svc.CheckIn(ctx, member, code)
+	require.NoError(t, err)
+	require.False(t, created)
+	require.Equal(t, visit.ID, again.ID)
+	require.Len(t, checkIns.visits, 1)
+	require.Equal(t, 1, publisher.published)
+
+	_, _, err = svc.CheckIn(ctx, outsider, code)
+	require.ErrorIs(t, err, gymcheckinuc.ErrNotMember)
+
+	stale, _ := checkIns.location.Code(time.Now().Add(-3 * domain.CodeRotation))
+	_, _, err = svc.CheckIn(ctx, member, stale)
+	require.ErrorIs(t, err, gymcheckinuc.ErrInvalidCode)
+
+	last := "0"
+	if strings.HasSuffix(code, "0") {
+		last = "1"
+	}
+	tampered := code[:len(code)-1] + last
+	_, _, err = svc.CheckIn(ctx, member, tampered)
+	require.ErrorIs(t, err, gymcheckinuc.ErrInvalidCode)
+}
+
+func TestAttendanceReport(t *testing.T) {
+	owner := gymcheckinuc.Actor{UserID: uuid.New(), Role: userdomain.RoleUser}
+	member := gymcheckinuc.Actor{UserID: uuid.New(), Role: userdomain.RoleUser}
+	location := newLocation()
+	frequent, rare := uuid.New(), uuid.New()
+	day1 := time.Date(2026, 10, 12, 8, 0, 0, 0, time.UTC)
+	day2 := day1.AddDate(0, 0, 1)
+	checkIns := &fakeCheckIns{location: location, visits: []*domain.Visit{
+		{ID: uuid.New(), LocationID: location.ID, OrganizationID: location.OrganizationID, UserID: rare, CheckedInAt: day1},
+		{ID: uuid.New(), LocationID: location.ID, OrganizationID: location.OrganizationID, UserID: frequent, CheckedInAt: day1.Add(time.Hour)},
+		{ID: uuid.New(), LocationID: location.ID, OrganizationID: location.OrganizationID, UserID: frequent, CheckedInAt: day2},
+	}}
+	svc := gymcheckinuc.NewService(checkIns, &fakeOrgs{members: map[uuid.UUID]orgdomain.Role{
+		owner.UserID:  orgdomain.RoleOwner,
+		member.UserID: orgdomain.RoleMember,
+	}}, &fakePublisher{})
+	ctx := context.Background()
+	filter := gymcheckinuc.ReportFilter{From: day1.AddDate(0, 0, -1), To: day2.AddDate(0, 0, 1)}
+
+	_, err := svc.AttendanceReport(ctx, member, location.OrganizationID, filter)
+	require.ErrorIs(t, err, gymcheckinuc.ErrForbidden)
+
+	report, err := svc.AttendanceReport(ctx, owner, location.OrganizationID, filter)
+	require.NoError(t, err)
+	require.Equal(t, 3, report.TotalVisits)
+	require.Equal(t, 2, report.UniqueMembers)
+	require.Equal(t, []gymcheckinuc.DayAttendance{
+		{Date: time.Date(2026, 10, 12, 0, 0, 0, 0, time.UTC), Visits: 2},
+		{Date: time.Date(2026, 10, 13, 0, 0, 0, 0, time.UTC), Visits: 1},
+	}, report.Days)
+	require.Equal(t, frequent, report.Members[0].UserID)
+	require.Equal(t, 2, report.Members[0].Visits)
+	require.Equal(t, day2, report.Members[0].LastVisitAt)
+
+	_, err = svc.AttendanceReport(ctx, owner, location.OrganizationID, gymcheckinuc.ReportFilter{From: day2, To: day1})
+	require.ErrorIs(t, err, gymcheckinuc.ErrInvalidPeriod)
+}
@@ -0,0 +1,224 @@
+package gymclass_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	eventdomain "workout-app/internal/domain/event"
+	domain "workout-app/internal/domain/gymclass"
+	orgdomain "workout-app/internal/domain/organization"
+	userdomain "workout-app/internal/domain/user"
+	repo "workout-app/internal/repository/interfaces"
+	gymclassuc "workout-app/internal/usecase/gymclass"
+)
+
+type fakeTx struct{}
+
+func (fakeTx) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+type fakeOrgs struct {
+	repo.OrganizationRepository
+	members map[uuid.UUID]orgdomain.Role
+}
+
+func (r *fakeOrgs) GetMember(_ context.Context, orgID, userID uuid.UUID) (*orgdomain.Member, error) {
+	role, ok := r.members[userID]
+	if !ok {
+		return nil, repo.ErrNotFound
+	}
+	return &orgdomain.Member{OrganizationID: orgID, UserID: userID, Role: role}, nil
+}
+
+type fakeClasses struct {
+	repo.GymClassRepository
+	class    *domain.Class
+	slots    []*domain.Slot
+	bookings []*domain.Booking
+}
+
+func (r *fakeClasses) GetByID(_ context.Context, id uuid.UUID) (*domain.Class, error) {
+	if r.class == nil || r.class.ID != id {
+		return nil, repo.ErrNotFound
+	}
+	return r.class, nil
+}
+
+func (r *fakeClasses) GetByIDForUpdate(ctx context.Context, id uuid.UUID) (*domain.Class, error) {
+	return r.GetByID(ctx, id)
+}
+
+func (r *fakeClasses) ListSlots(context.Context, []uuid.UUID) ([]*domain.Slot, error) {
+	return r.slots, nil
+}
+
+func (r *fakeClasses) CreateBooking(_ context.Context, b *domain.Booking) error {
+	for _, existing := range r.bookings {
+		if existing.IsActive() && existing.UserID == b.UserID && existing.StartsAt.Equal(b.StartsAt) {
+			return repo.ErrAlreadyBooked
+		}
+	}
+	r.bookings = append(r.bookings, b)
+	return nil
+}
+
+func (r *fakeClasses) UpdateBooking(context.Context, *domain.Booking) error {
+	return nil
+}
+
+func (r *fakeClasses) GetBooking(_ context.Context, id uuid.UUID) (*domain.Booking, error) {
+	for _, b := range r.bookings {
+		if b.ID == id {
+			return b, nil
+		}
+	}
+	return nil, repo.ErrNotFound
+}
+
+func (r *fakeClasses) ListOccurrenceBookings(_ context.Context, classID uuid.UUID, startsAt time.Time) ([]*domain.Booking, error) {
+	var result []*domain.Booking
+	for _, b := range r.bookings {
+		if b.ClassID == classID && b.StartsAt.Equal(startsAt) && b.IsActive() {
+			result = append(result, b)
+		}
+	}
+	return result, nil
+}
+
+type fakePublisher struct {
+	types []string
+}
+
+func (p *fakePublisher) Publish(_ context.Context, eventType, _ string, _ any) {
+	p.types = append(p.types, eventType)
+}
+
+// newFixture создаёт занятие на 1 место со слотом на завтра в 18:30 UTC.
+func newFixture(members map[uuid.UUID]orgdomain.Role) (*fakeClasses, *fakePublisher, gymclassuc.Service, time.Time) {
+	tomorrow := time.Now().UTC().AddDate(0, 0, 1)
+	y, m, d := tomorrow.Date()
+	startsAt := time.Date(y, m, d, 18, 30, 0, 0, time.UTC)
+
+	class := &domain.Class{ID: uuid.New(), OrganizationID: uuid.New(), Title: "Кроссфит", Capacity: 1, DurationMinutes: 60}
+	classes := &fakeClasses{
+		class: class,
+		slots: []*domain.Slot{{ID: uuid.New(), ClassID: class.ID, Weekday: domain.Weekday(startsAt), StartMinute: 18*60 + 30}},
+	}
+	publisher := &fakePublisher{}
+	svc := gymclassuc.NewService(fakeTx{}, classes, &fakeOrgs{members: members}, publisher)
+	return classes, publisher, svc, startsAt
+}
+
+func TestOccurrences_ExpandsWeeklySlots(t *testing.T) {
+	class := &domain.Class{ID: uuid.New(), DurationMinutes: 45}
+	slots := []*domain.Slot{
+		{ID: uuid.New(), ClassID: class.ID, Weekday: 1, StartMinute: 9 * 60},
+		{ID: uuid.New(), ClassID: class.ID, Weekday: 3, StartMinute: 19 * 60},
+	}
+	// 2026-10-12 — понедельник.
+	from := time.Date(2026, 10, 12, 10, 0, 0, 0, time.UTC)
+	to := time.Date(2026, 10, 19, 10, 0, 0, 0, time.UTC)
+
+	got := class.Occurrences(slots, from, to)
+	require.Len(t, got, 2)
+	require.Equal(t, time.Date(2026, 10, 14, 19, 0, 0, 0, time.UTC), got[0].StartsAt)
+	require.Equal(t, time.Date(2026, 10, 19, 9, 0, 0, 0, time.UTC), got[1].StartsAt)
+	require.Equal(t, got[1].StartsAt.Add(45*time.Minute), got[1].EndsAt)
+
+	_, ok := class.Occurrence(slots, time.Date(2026, 10, 14, 19, 15, 0, 0, time.UTC))
+	require.False(t, ok)
+}
+
+func TestBook_WaitlistAndPromotionOnCancel(t *testing.T) {
+	first := gymclassuc.Actor{UserID: uuid.New(), Role: userdomain.RoleUser}
+	second := gymclassuc.Actor{UserID: uuid.New(), Role: userdomain.RoleUser}
+	classes, publisher, svc, startsAt := newFixture(map[uuid.UUID]orgdomain.Role{
+		first.UserID:  orgdomain.RoleMember,
+		second.UserID: orgdomain.RoleMember,
+	})
+	ctx := context.Background()
+
+	b1, err := svc.Book(ctx, first, classes.class.ID, startsAt)
+	require.NoError(t, err)
+	require.Equal(t, domain.StatusBooked, b1.Status)
+
+	b2, err := svc.Book(ctx, second, classes.class.ID, startsAt)
+	require.NoError(t, err)
+	require.Equal(t, domain.StatusWaitlisted, b2.Status)
+
+	_, err = svc.Book(ctx, second, classes.class.ID, startsAt)
+	require.ErrorIs(t, err, gymclassuc.ErrAlreadyBooked)
+
+	// Чужую запись участник отменить не может.
+	_, err = svc.Cancel(ctx, second, b1.ID)
+	require.ErrorIs(t, err, gymclassuc.ErrBookingNotFound)
+
+	cancelled, err := svc.Cancel(ctx, first, b1.ID)
+	require.NoError(t, err)
+	require.Equal(t, domain.StatusCancelled, cancelled.Status)
+	require.Equal(t, domain.StatusBooked, b2.Status)
+	require.Equal(t, []string{
+		eventdomain.TypeClassBooked,
+		eventdomain.TypeClassBooked,
+		eventdomain.TypeClassWaitlistPromoted,
+	}, publisher.types)
+}
+
+func TestBook_Rejected(t *testing.T) {
+	member := gymclassuc.Actor{UserID: uuid.New(), Role: userdomain.RoleUser}
+	outsider := gymclassuc.Actor{UserID: uuid.New(), Role: userdomain.RoleUser}
+	classes, _, svc, startsAt := newFixture(map[uuid.UUID]orgdomain.Role{member.UserID: orgdomain.RoleMember})
+	ctx := context.Background()
+
+	_, err := svc.Book(ctx, outsider, classes.class.ID, startsAt)
+	require.ErrorIs(t, err, gymclassuc.ErrClassNotFound)
+
+	_, err = svc.Book(ctx, member, classes.class.ID, startsAt.Add(15*time.Minute))
+	require.ErrorIs(t, err, gymclassuc.ErrNotScheduled)
+
+	_, err = svc.Book(ctx, member, classes.class.ID, startsAt.AddDate(0, 0, -7))
+	require.ErrorIs(t, err, gymclassuc.ErrBookingClosed)
+}
+
+func TestMarkAttendance(t *testing.T) {
+	coachID := uuid.New()
+	member := gymclassuc.Actor{UserID: uuid.New(), Role: userdomain.RoleUser}
+	classes, _, svc, startsAt := newFixture(map[uuid.UUID]orgdomain.Role{
+		coachID:       orgdomain.RoleMember,
+		member.UserID: orgdomain.RoleMember,
+	})
+	classes.class.CoachID = &coachID
+	coach := gymclassuc.Actor{UserID: coachID, Role: userdomain.RoleUser}
+	ctx := context.Background()
+
+	upcoming, err := svc.Book(ctx, member, classes.class.ID, startsAt)
+	require.NoError(t, err)
+	_, err = svc.MarkAttendance(ctx, coach, upcoming.ID, true)
+	require.ErrorIs(t, err, gymclassuc.ErrAttendanceTooSoon)
+
+	past := &domain.Booking{
+		ID:       uuid.New(),
+		ClassID:  classes.class.ID,
+		UserID:   member.UserID,
+		StartsAt: startsAt.AddDate(0, 0, -7),
+		Status:   domain.StatusBooked,
+	}
+	classes.bookings = append(classes.bookings, past)
+
+	_, err = svc.MarkAttendance(ctx, member, past.ID, true)
+	require.ErrorIs(t, err, gymclassuc.ErrForbidden)
+
+	marked, err := svc.MarkAttendance(ctx, coach, past.ID, false)
+	require.NoError(t, err)
+	require.NotNil(t, marked.Attended)
+	require.False(t, *marked.Attended)
+
+	past.Status = domain.StatusWaitlisted
+	_, err = svc.MarkAttendance(ctx, coach, past.ID, true)
+	require.ErrorIs(t, err, gymclassuc.ErrNotBooked)
+}
@@ -0,0 +1,157 @@
+package habit_test
+
+import (
+	"context"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	domain "workout-app/internal/domain/habit"
+	userdomain "workout-app/internal/domain/user"
+	repo "workout-app/internal/repository/interfaces"
+	habituc "workout-app/internal/usecase/habit"
+)
+
+type fakeUsers struct {
+	repo.UserRepository
+	user *userdomain.User
+}
+
+func (r *fakeUsers) GetByID(_ context.Context, id uuid.UUID) (*userdomain.User, error) {
+	if r.user.ID != id {
+		return nil, repo.ErrNotFound
+	}
+	return r.user, nil
+}
+
+// fakeDays — in-memory реализация HabitRepository: одна запись на день.
+type fakeDays struct {
+	repo.HabitRepository
+	items map[time.Time]*domain.Day
+}
+
+func (r *fakeDays) Upsert(_ context.Context, d *domain.Day) error {
+	r.items[d.Date] = d
+	return nil
+}
+
+func (r *fakeDays) ListByPeriod(_ context.Context, userID uuid.UUID, from, to time.Time) ([]*domain.Day, error) {
+	var out []*domain.Day
+	for _, d := range r.items {
+		if d.UserID == userID && !d.Date.Before(from) && !d.Date.After(to) {
+			out = append(out, d)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Date.Before(out[j].Date) })
+	return out, nil
+}
+
+func (r *fakeDays) Delete(_ context.Context, _ uuid.UUID, date time.Time) error {
+	if _, ok := r.items[date]; !ok {
+		return repo.ErrNotFound
+	}
+	delete(r.items, date)
+	return nil
+}
+
+func newService() (habituc.Service, *fakeDays, uuid.UUID) {
+	user := userdomain.NewUser("jane@example.com", "hash", "jane")
+	days := &fakeDays{items: map[time.Time]*domain.Day{}}
+	return habituc.NewService(days, &fakeUsers{user: user}), days, user.ID
+}
+
+func intPtr(v int) *int           { return &v }
+func floatPtr(v float64) *float64 { return &v }
+
+func day(offset int) time.Time {
+	y, m, d := time.Now().UTC().AddDate(0, 0, offset).Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+func TestUpsert_ReplacesDay(t *testing.T) {
+	svc, days, userID := newService()
+	ctx := context.Background()
+
+	_, err := svc.Upsert(ctx, userID, day(-1), habituc.DayInput{WaterMl: intPtr(1500), SleepHours: floatPtr(7.26)})
+	require.NoError(t, err)
+	require.Equal(t, 7.3, *days.items[day(-1)].SleepHours)
+
+	d, err := svc.Upsert(ctx, userID, day(-1).Add(15*time.Hour), habituc.DayInput{RestingHR: intPtr(58)})
+	require.NoError(t, err)
+	require.Equal(t, day(-1), d.Date)
+	require.Len(t, days.items, 1)
+	require.Nil(t, days.items[day(-1)].WaterMl, "запись дня заменяется целиком")
+	require.Equal(t, 58, *days.items[day(-1)].RestingHR)
+}
+
+func TestUpsert_Validation(t *testing.T) {
+	svc, _, userID := newService()
+	ctx := context.Background()
+
+	cases := []struct {
+		date  time.Time
+		input habituc.DayInput
+		err   error
+	}{
+		{day(0), habituc.DayInput{WaterMl: intPtr(-1)}, habituc.ErrInvalidWater},
+		{day(0), habituc.DayInput{WaterMl: intPtr(domain.MaxWaterMl + 1)}, habituc.ErrInvalidWater},
+		{day(0), habituc.DayInput{SleepHours: floatPtr(24.5)}, habituc.ErrInvalidSleep},
+		{day(0), habituc.DayInput{RestingHR: intPtr(15)}, habituc.ErrInvalidRestingHR},
+		{day(0), habituc.DayInput{Steps: intPtr(domain.MaxSteps + 1)}, habituc.ErrInvalidSteps},
+		{day(0), habituc.DayInput{}, habituc.ErrEmptyDay},
+		{day(2), habituc.DayInput{WaterMl: intPtr(500)}, habituc.ErrInvalidDate},
+	}
+	for _, tc := range cases {
+		_, err := svc.Upsert(ctx, userID, tc.date, tc.input)
+		require.ErrorIs(t, err, tc.err)
+	}
+}
+
+func TestRange_DefaultPeriodAndAverages(t *testing.T) {
+	svc, _, userID := newService()
+	ctx := context.Background()
+
+	for offset, input := range map[int]habituc.DayInput{
+		-40: {WaterMl: intPtr(5000)},
+		-2:  {WaterMl: intPtr(2000), SleepHours: floatPtr(7), RestingHR: intPtr(60)},
+		-1:  {WaterMl: intPtr(2500), SleepHours: floatPtr(8.5)},
+		0:   {RestingHR: intPtr(57)},
+	} {
+		_, err := svc.Upsert(ctx, userID, day(offset), input)
+		require.NoError(t, err)
+	}
+
+	r, err := svc.Range(ctx, userID, nil, nil)
+	require.NoError(t, err)
+	require.Equal(t, day(0), r.To)
+	require.Equal(t, day(-29), r.From)
+	require.Len(t, r.Days, 3)
+	require.Equal(t, day(-2), r.Days[0].Date)
+	require.Equal(t, 2250.0, *r.Averages.WaterMl)
+	require.Equal(t, 7.8, *r.Averages.SleepHours)
+	require.Equal(t, 58.5, *r.Averages.RestingHR)
+
+	from, to := day(-1), day(-1)
+	r, err = svc.Range(ctx, userID, &from, &to)
+	require.NoError(t, err)
+	require.Len(t, r.Days, 1)
+	require.Nil(t, r.Averages.RestingHR)
+
+	from = day(-400)
+	_, err = svc.Range(ctx, userID, &from, nil)
+	require.ErrorIs(t, err, habituc.ErrInvalidPeriod)
+}
+
+func TestDelete(t *testing.T) {
+	svc, days, userID := newService()
+	ctx := context.Background()
+	_, err := svc.Upsert(ctx, userID, day(0), habituc.DayInput{WaterMl: intPtr(300)})
+	require.NoError(t, err)
+
+	require.NoError(t, svc.Delete(ctx, userID, day(0)))
+	require.Empty(t, days.items)
+	require.ErrorIs(t, svc.Delete(ctx, userID, day(0)), habituc.ErrDayNotFound)
+}
@@ -0,0 +1,60 @@
+package health_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+
+	"workout-app/internal/handler/health"
+)
+
+func serveReady(t *testing.T, appEnv string, checks []health.DependencyCheck) (int, health.ReadinessResponse) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	h := health.NewHandler(nil, appEnv, time.Now(), checks)
+
+	r := gin.New()
+	r.GET("/health/ready", h.Ready)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/health/ready", nil))
+
+	var resp health.ReadinessResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	return w.Code, resp
+}
+
+func ok(context.Context) error { return nil }
+
+func TestReady_NonCriticalFailureKeepsInstanceReady(t *testing.T) {
+	code, resp := serveReady(t, "development", []health.DependencyCheck{
+		{Name: "db", Critical: true, Check: ok},
+		{Name: "smtp", Check: func(context.Context) error { return errors.New("connection refused") }},
+		{Name: "cache"},
+	})
+
+	require.Equal(t, http.StatusOK, code)
+	require.Equal(t, health.StatusReady, resp.Status)
+	require.Equal(t, health.StatusOK, resp.Dependencies["db"].Status)
+	require.Equal(t, health.StatusDown, resp.Dependencies["smtp"].Status)
+	require.Equal(t, "connection refused", resp.Dependencies["smtp"].Error)
+	require.Equal(t, health.StatusNotConfigured, resp.Dependencies["cache"].Status)
+}
+
+func TestReady_CriticalFailureReturns503WithoutDetailsInProduction(t *testing.T) {
+	code, resp := serveReady(t, "production", []health.DependencyCheck{
+		{Name: "db", Critical: true, Check: ok},
+		{Name: "migrations", Critical: true, Check: func(context.Context) error { return errors.New("dirty") }},
+	})
+
+	require.Equal(t, http.StatusServiceUnavailable, code)
+	require.Equal(t, health.StatusNotReady, resp.Status)
+	require.Equal(t, health.StatusDown, resp.Dependencies["migrations"].Status)
+	require.Empty(t, resp.Dependencies["migrations"].Error)
+}
@@ -0,0 +1,72 @@
+package health_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+
+	"workout-app/internal/handler/health"
+)
+
+func serveStatus(t *testing.T, h *health.StatusHandler) (*httptest.ResponseRecorder, health.StatusResponse) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/status", h.Status)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/status", nil))
+
+	var resp health.StatusResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	return w, resp
+}
+
+func TestStatus_HidesDependencyErrors(t *testing.T) {
+	const secret = "dial tcp 10.0.3.7:5432: password authentication failed for user \"app\""
+	h := health.NewStatusHandler("1.2.3", time.Now().Add(-time.Minute), []health.DependencyCheck{
+		{Name: "db", Critical: true, Check: ok},
+		{Name: "mail", Check: func(context.Context) error { return errors.New(secret) }},
+		{Name: "storage"},
+	})
+
+	w, resp := serveStatus(t, h)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, health.StatusDegraded, resp.Status)
+	require.Equal(t, "1.2.3", resp.Version)
+	require.GreaterOrEqual(t, resp.UptimeSeconds, int64(60))
+	require.Equal(t, map[string]string{
+		"db":      health.StatusOK,
+		"mail":    health.StatusDown,
+		"storage": health.StatusNotConfigured,
+	}, resp.Dependencies)
+	require.NotContains(t, w.Body.String(), "10.0.3.7")
+	require.NotContains(t, w.Body.String(), "password")
+}
+
+func TestStatus_CriticalFailureAndCache(t *testing.T) {
+	calls := 0
+	h := health.NewStatusHandler("1.2.3", time.Now(), []health.DependencyCheck{
+		{Name: "db", Critical: true, Check: func(context.Context) error {
+			calls++
+			return errors.New("connection refused")
+		}},
+	})
+
+	_, resp := serveStatus(t, h)
+	require.Equal(t, health.StatusDown, resp.Status)
+	require.Equal(t, health.StatusDown, resp.Dependencies["db"])
+
+	// Повторный запрос отдаётся из кеша, после сброса проверки выполняются заново.
+	serveStatus(t, h)
+	require.Equal(t, 1, calls)
+	require.NoError(t, h.Invalidate(context.Background()))
+	serveStatus(t, h)
+	require.Equal(t, 2, calls)
+}
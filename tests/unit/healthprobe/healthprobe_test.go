@@ -0,0 +1,59 @@
+package healthprobe_test
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"workout-app/pkg/healthprobe"
+)
+
+func TestProbeHost(t *testing.T) {
+	cases := []struct {
+		host string
+		want string
+	}{
+		{host: "", want: "127.0.0.1"},
+		{host: "0.0.0.0", want: "127.0.0.1"},
+		{host: "::", want: "::1"},
+		{host: "[::]", want: "::1"},
+		{host: "localhost", want: "localhost"},
+		{host: "10.0.0.5", want: "10.0.0.5"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.host, func(t *testing.T) {
+			require.Equal(t, tc.want, healthprobe.ProbeHost(tc.host))
+		})
+	}
+
+	// Адрес IPv6 собирается в корректный URL.
+	require.Equal(t, "[::1]:8080", net.JoinHostPort(healthprobe.ProbeHost("[::]"), "8080"))
+}
+
+func TestCheck(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/ready":
+			w.WriteHeader(http.StatusOK)
+		case "/slow":
+			select {
+			case <-r.Context().Done():
+			case <-time.After(time.Second):
+			}
+		default:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer srv.Close()
+
+	require.NoError(t, healthprobe.Check(srv.URL+"/ready", time.Second))
+	require.ErrorContains(t, healthprobe.Check(srv.URL+"/not-ready", time.Second), "returned 503")
+
+	started := time.Now()
+	require.Error(t, healthprobe.Check(srv.URL+"/slow", 50*time.Millisecond))
+	require.Less(t, time.Since(started), time.Second, "запрос прерывается по таймауту")
+}
@@ -0,0 +1,233 @@
+package healthsync_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	domain "workout-app/internal/domain/healthsync"
+	userdomain "workout-app/internal/domain/user"
+	workoutdomain "workout-app/internal/domain/workout"
+	repo "workout-app/internal/repository/interfaces"
+	healthsyncuc "workout-app/internal/usecase/healthsync"
+)
+
+type fakeTx struct{}
+
+func (fakeTx) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+type fakeUsers struct {
+	repo.UserRepository
+	user *userdomain.User
+}
+
+func (r *fakeUsers) GetByID(context.Context, uuid.UUID) (*userdomain.User, error) {
+	return r.user, nil
+}
+
+// fakeLinks хранит связи и состояние синхронизации в памяти.
+type fakeLinks struct {
+	repo.HealthSyncRepository
+	links map[string]*domain.Link
+	conns map[domain.Provider]*domain.Connection
+}
+
+func (r *fakeLinks) LockConnection(_ context.Context, c *domain.Connection) (*domain.Connection, error) {
+	if stored, ok := r.conns[c.Provider]; ok {
+		copied := *stored
+		return &copied, nil
+	}
+	copied := *c
+	r.conns[c.Provider] = &copied
+	return c, nil
+}
+
+func (r *fakeLinks) UpdateConnection(_ context.Context, c *domain.Connection) error {
+	copied := *c
+	r.conns[c.Provider] = &copied
+	return nil
+}
+
+func (r *fakeLinks) LinkedIDs(_ context.Context, _ uuid.UUID, provider domain.Provider, ids []string) ([]string, error) {
+	var linked []string
+	for _, id := range ids {
+		if _, ok := r.links[string(provider)+"/"+id]; ok {
+			linked = append(linked, id)
+		}
+	}
+	return linked, nil
+}
+
+func (r *fakeLinks) CreateLinks(_ context.Context, links []*domain.Link) error {
+	for _, l := range links {
+		r.links[string(l.Provider)+"/"+l.ExternalID] = l
+	}
+	return nil
+}
+
+type fakeWorkouts struct {
+	repo.WorkoutSessionRepository
+	sessions []*workoutdomain.Session
+}
+
+func (r *fakeWorkouts) Create(_ context.Context, s *workoutdomain.Session) error {
+	r.sessions = append(r.sessions, s)
+	return nil
+}
+
+type fakeMetrics struct {
+	repo.BodyMetricRepository
+	metrics []*userdomain.BodyMetric
+}
+
+func (r *fakeMetrics) Create(_ context.Context, m *userdomain.BodyMetric) error {
+	r.metrics = append(r.metrics, m)
+	return nil
+}
+
+// fakeHabits повторяет слияние шагов и пульса покоя в БД.
+type fakeHabits struct {
+	repo.HabitRepository
+	steps     map[string]int
+	restingHR map[string]int
+}
+
+func (r *fakeHabits) AddSteps(_ context.Context, _ uuid.UUID, date time.Time, steps int, _ time.Time) error {
+	r.steps[date.Format(time.DateOnly)] += steps
+	return nil
+}
+
+func (r *fakeHabits) LowerRestingHR(_ context.Context, _ uuid.UUID, date time.Time, bpm int, _ time.Time) error {
+	key := date.Format(time.DateOnly)
+	if current, ok := r.restingHR[key]; !ok || bpm < current {
+		r.restingHR[key] = bpm
+	}
+	return nil
+}
+
+type env struct {
+	svc      healthsyncuc.Service
+	links    *fakeLinks
+	workouts *fakeWorkouts
+	metrics  *fakeMetrics
+	habits   *fakeHabits
+	user     *userdomain.User
+}
+
+func newEnv() *env {
+	user := userdomain.NewUser("jane@example.com", "hash", "jane")
+	user.Timezone = "Europe/Moscow"
+	e := &env{
+		links:    &fakeLinks{links: map[string]*domain.Link{}, conns: map[domain.Provider]*domain.Connection{}},
+		workouts: &fakeWorkouts{},
+		metrics:  &fakeMetrics{},
+		habits:   &fakeHabits{steps: map[string]int{}, restingHR: map[string]int{}},
+		user:     user,
+	}
+	e.svc = healthsyncuc.NewService(e.links, e.workouts, e.metrics, e.habits, &fakeUsers{user: user}, fakeTx{},
+		healthsyncuc.Config{MaxItems: 100, AutoPauseAfter: 5 * time.Minute})
+	return e
+}
+
+const appleExport = `{
+  "workouts": [
+    {"uuid": "W1", "workoutActivityType": "HKWorkoutActivityTypeTraditionalStrengthTraining",
+     "startDate": "2026-01-10T07:30:00+03:00", "endDate": "2026-01-10T08:30:00+03:00"}
+  ],
+  "samples": [
+    {"uuid": "S1", "type": "HKQuantityTypeIdentifierStepCount", "startDate": "2026-01-10T01:00:00+03:00", "endDate": "2026-01-10T02:00:00+03:00", "value": 1200},
+    {"uuid": "S2", "type": "HKQuantityTypeIdentifierStepCount", "startDate": "2026-01-09 22:30:00 +0000", "endDate": "2026-01-09 23:00:00 +0000", "value": 800},
+    {"uuid": "H1", "type": "HKQuantityTypeIdentifierHeartRate", "startDate": "2026-01-10T10:00:00+03:00", "value": 72},
+    {"uuid": "H2", "type": "HKQuantityTypeIdentifierRestingHeartRate", "startDate": "2026-01-10T06:00:00+03:00", "value": 55},
+    {"uuid": "B1", "type": "HKQuantityTypeIdentifierBodyMass", "startDate": "2026-01-10T07:00:00+03:00", "value": 180, "unit": "lb"},
+    {"uuid": "E1", "type": "HKQuantityTypeIdentifierActiveEnergyBurned", "startDate": "2026-01-10T07:00:00+03:00", "value": 300},
+    {"uuid": "X1", "type": "HKQuantityTypeIdentifierHeartRate", "startDate": "2026-01-10T07:00:00+03:00", "value": 400}
+  ]
+}`
+
+func TestSync_AppleHealthMapsAndDeduplicates(t *testing.T) {
+	e := newEnv()
+	ctx := context.Background()
+
+	result, err := e.svc.Sync(ctx, e.user.ID, domain.ProviderAppleHealth, strings.NewReader(appleExport))
+	require.NoError(t, err)
+	require.Equal(t, map[domain.Kind]int{
+		domain.KindWorkout: 1, domain.KindSteps: 2, domain.KindHeartRate: 2, domain.KindWeight: 1,
+	}, result.Imported)
+	require.Equal(t, 1, result.Skipped)
+	require.Equal(t, 1, result.Invalid)
+	require.Equal(t, "X1", result.Errors[0].ExternalID)
+
+	require.Len(t, e.workouts.sessions, 1)
+	require.Equal(t, "Силовая тренировка", e.workouts.sessions[0].Title)
+	require.Equal(t, time.Hour, e.workouts.sessions[0].FinishedAt.Sub(e.workouts.sessions[0].StartedAt))
+	require.Equal(t, 81.6, *e.metrics.metrics[0].WeightKg)
+	// 22:30 UTC 9 января — уже 10 января по Москве.
+	require.Equal(t, map[string]int{"2026-01-10": 2000}, e.habits.steps)
+	require.Equal(t, map[string]int{"2026-01-10": 55}, e.habits.restingHR)
+	require.Equal(t, 6, result.Connection.Items)
+	require.Equal(t, time.Date(2026, 1, 10, 7, 0, 0, 0, time.UTC), *result.Connection.SyncedUntil)
+
+	again, err := e.svc.Sync(ctx, e.user.ID, domain.ProviderAppleHealth, strings.NewReader(appleExport))
+	require.NoError(t, err)
+	require.Empty(t, again.Imported)
+	require.Equal(t, 6, again.Duplicates)
+	require.Len(t, e.workouts.sessions, 1)
+	require.Equal(t, 2000, e.habits.steps["2026-01-10"], "повторная выгрузка не удваивает шаги")
+	require.Equal(t, 6, again.Connection.Items)
+}
+
+func TestSync_GoogleFit(t *testing.T) {
+	e := newEnv()
+	export := `{
+	  "session": [
+	    {"id": "s-1", "name": "", "activityType": 8, "startTimeMillis": "1768021200000", "endTimeMillis": 1768023000000},
+	    {"id": "s-2", "name": "Утренняя пробежка", "activityType": 8, "startTimeMillis": "1768021200000"}
+	  ],
+	  "point": [
+	    {"dataTypeName": "com.google.step_count.delta", "startTimeNanos": "1768021200000000000", "endTimeNanos": "1768024800000000000",
+	     "originDataSourceId": "raw:pedometer", "value": [{"intVal": 3000}]},
+	    {"dataTypeName": "com.google.step_count.delta", "startTimeNanos": "1768021200000000000", "endTimeNanos": "1768024800000000000",
+	     "originDataSourceId": "raw:pedometer", "value": [{"intVal": 3000}]},
+	    {"dataTypeName": "com.google.heart_rate.bpm", "startTimeNanos": "1768021200000000000", "endTimeNanos": "1768021200000000000",
+	     "value": [{"fpVal": 61.6}]},
+	    {"dataTypeName": "com.google.calories.expended", "startTimeNanos": "1768021200000000000", "value": [{"fpVal": 200}]}
+	  ]
+	}`
+
+	result, err := e.svc.Sync(context.Background(), e.user.ID, domain.ProviderGoogleFit, strings.NewReader(export))
+	require.NoError(t, err)
+	require.Equal(t, map[domain.Kind]int{domain.KindWorkout: 1, domain.KindSteps: 1, domain.KindHeartRate: 1}, result.Imported)
+	require.Equal(t, 1, result.Duplicates, "точка повторяется в выгрузке")
+	require.Equal(t, 1, result.Skipped)
+	require.Equal(t, []healthsyncuc.ItemError{{ExternalID: "s-2", Error: "end time is required"}}, result.Errors)
+	require.Equal(t, "Бег", e.workouts.sessions[0].Title)
+	require.Equal(t, 3000, e.habits.steps["2026-01-10"])
+	require.Equal(t, 62, e.habits.restingHR["2026-01-10"])
+}
+
+func TestSync_RejectsPayload(t *testing.T) {
+	e := newEnv()
+	ctx := context.Background()
+
+	_, err := e.svc.Sync(ctx, e.user.ID, "fitbit", strings.NewReader(`{}`))
+	require.ErrorIs(t, err, healthsyncuc.ErrInvalidProvider)
+	_, err = e.svc.Sync(ctx, e.user.ID, domain.ProviderAppleHealth, strings.NewReader(`{"samples": 1}`))
+	require.ErrorIs(t, err, healthsyncuc.ErrInvalidPayload)
+	_, err = e.svc.Sync(ctx, e.user.ID, domain.ProviderGoogleFit, strings.NewReader(`{"session": []}`))
+	require.ErrorIs(t, err, healthsyncuc.ErrEmptyPayload)
+
+	future := time.Now().Add(time.Hour).Format(time.RFC3339)
+	result, err := e.svc.Sync(ctx, e.user.ID, domain.ProviderAppleHealth, strings.NewReader(
+		`{"samples": [{"uuid": "F1", "type": "HKQuantityTypeIdentifierStepCount", "startDate": "`+future+`", "value": 10}]}`))
+	require.NoError(t, err)
+	require.Equal(t, 1, result.Invalid)
+	require.Nil(t, result.Connection.SyncedUntil)
+	require.Empty(t, e.habits.steps)
+}
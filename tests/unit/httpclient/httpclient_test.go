@@ -0,0 +1,138 @@
+package httpclient_test
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"workout-app/pkg/httpclient"
+	"workout-app/pkg/logger"
+	"workout-app/pkg/tracing"
+)
+
+func newFactory(retries int) *httpclient.Factory {
+	return httpclient.NewFactory(httpclient.Config{
+		MaxRetries: retries,
+		RetryBase:  time.Millisecond,
+		RetryMax:   10 * time.Millisecond,
+	}, logger.New(io.Discard, slog.LevelError, logger.FormatJSON))
+}
+
+// flakyServer отвечает 503 на первые failures запросов, затем 200, и запоминает тела запросов.
+func flakyServer(t *testing.T, failures int32) (*httptest.Server, *atomic.Int32, chan string) {
+	var calls atomic.Int32
+	bodies := make(chan string, 10)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		bodies <- string(body)
+		if calls.Add(1) <= failures {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &calls, bodies
+}
+
+func TestClient_RetriesIdempotentRequestsAndRecordsMetrics(t *testing.T) {
+	srv, calls, bodies := flakyServer(t, 2)
+	factory := newFactory(2)
+
+	req, err := http.NewRequest(http.MethodPut, srv.URL+"/object", strings.NewReader("payload"))
+	require.NoError(t, err)
+	resp, err := factory.Client("storage", 0).Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, int32(3), calls.Load())
+	for i := 0; i < 3; i++ {
+		require.Equal(t, "payload", <-bodies)
+	}
+
+	hosts := factory.Metrics().Hosts()
+	require.Len(t, hosts, 1)
+	require.Equal(t, "storage", hosts[0].Client)
+	require.Equal(t, int64(3), hosts[0].Requests)
+	require.Equal(t, int64(2), hosts[0].Retries)
+	require.Equal(t, int64(2), hosts[0].Failures)
+}
+
+func TestClient_DoesNotRetryPostWithoutIdempotencyKey(t *testing.T) {
+	srv, calls, _ := flakyServer(t, 1)
+	client := newFactory(2).Client("email", 0)
+
+	resp, err := client.Post(srv.URL, "application/json", strings.NewReader("{}"))
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+	require.Equal(t, int32(1), calls.Load())
+
+	req, err := http.NewRequest(http.MethodPost, srv.URL, strings.NewReader("{}"))
+	require.NoError(t, err)
+	req.Header.Set(httpclient.HeaderIdempotencyKey, "key-1")
+	resp, err = client.Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, int32(2), calls.Load())
+}
+
+func TestClient_DisabledRetries(t *testing.T) {
+	srv, calls, _ := flakyServer(t, 1)
+
+	resp, err := newFactory(0).Client("oauth", 0).Get(srv.URL)
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+	require.Equal(t, int32(1), calls.Load())
+}
+
+func TestClient_PropagatesTraceContext(t *testing.T) {
+	headers := make(chan string, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		headers <- r.Header.Get(tracing.HeaderName)
+	}))
+	defer srv.Close()
+
+	parent, ok := tracing.Parse("00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	require.True(t, ok)
+	ctx := tracing.NewContext(context.Background(), parent)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL, nil)
+	require.NoError(t, err)
+	resp, err := newFactory(0).Client("oauth", 0).Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	sent, ok := tracing.Parse(<-headers)
+	require.True(t, ok)
+	require.Equal(t, parent.TraceID, sent.TraceID)
+	require.NotEqual(t, parent.SpanID, sent.SpanID)
+	require.Empty(t, req.Header.Get(tracing.HeaderName), "исходный запрос не меняется")
+}
+
+func TestClient_StopsRetryingOnNetworkErrorAfterLimit(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+	target, _ := url.Parse(srv.URL)
+	srv.Close()
+
+	factory := newFactory(1)
+	_, err := factory.Client("food-db", time.Second).Get(target.String())
+	require.Error(t, err)
+
+	hosts := factory.Metrics().Hosts()
+	require.Len(t, hosts, 1)
+	require.Equal(t, int64(2), hosts[0].Requests)
+	require.Equal(t, int64(2), hosts[0].Failures)
+}
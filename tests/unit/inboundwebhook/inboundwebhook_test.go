@@ -0,0 +1,190 @@
+package inboundwebhook_test
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	domain "workout-app/internal/domain/inboundwebhook"
+	inboundwebhookuc "workout-app/internal/usecase/inboundwebhook"
+	webhookuc "workout-app/internal/usecase/webhook"
+	"workout-app/pkg/logger"
+)
+
+type fakeEvents struct {
+	items []*domain.Event
+}
+
+func (r *fakeEvents) Create(_ context.Context, e *domain.Event) (bool, error) {
+	for _, existing := range r.items {
+		if existing.Provider == e.Provider && existing.ExternalID == e.ExternalID {
+			return false, nil
+		}
+	}
+	e.ID = int64(len(r.items) + 1)
+	cp := *e
+	r.items = append(r.items, &cp)
+	return true, nil
+}
+
+func (r *fakeEvents) ListDue(_ context.Context, now time.Time, limit int) ([]*domain.Event, error) {
+	var due []*domain.Event
+	for _, e := range r.items {
+		if e.Status == domain.StatusPending && !e.NextAttemptAt.After(now) && len(due) < limit {
+			cp := *e
+			due = append(due, &cp)
+		}
+	}
+	return due, nil
+}
+
+func (r *fakeEvents) Claim(_ context.Context, id int64, now, until time.Time) (bool, error) {
+	e := r.items[id-1]
+	if e.Status != domain.StatusPending || e.NextAttemptAt.After(now) {
+		return false, nil
+	}
+	e.NextAttemptAt = until
+	return true, nil
+}
+
+func (r *fakeEvents) Save(_ context.Context, e *domain.Event) error {
+	cp := *e
+	r.items[e.ID-1] = &cp
+	return nil
+}
+
+func (r *fakeEvents) CountPending(context.Context) (int64, error) {
+	var n int64
+	for _, e := range r.items {
+		if e.Status == domain.StatusPending {
+			n++
+		}
+	}
+	return n, nil
+}
+
+func (r *fakeEvents) DeleteFinished(context.Context, time.Time, int) (int64, error) { return 0, nil }
+
+const secret = "whsec_test_secret_value"
+
+var cfg = inboundwebhookuc.Config{
+	BatchSize:   10,
+	MaxAttempts: 2,
+	RetryBase:   time.Nanosecond,
+	RetryMax:    time.Nanosecond,
+	Tolerance:   5 * time.Minute,
+	Retention:   24 * time.Hour,
+}
+
+func newService(events *fakeEvents, process func(context.Context, *domain.Event) error) inboundwebhookuc.Service {
+	log := logger.New(io.Discard, slog.LevelError, logger.FormatJSON)
+	return inboundwebhookuc.NewService(events, map[string]inboundwebhookuc.Provider{
+		"stripe": {
+			Verifier: inboundwebhookuc.TimestampedHMAC{Header: "Stripe-Signature", Secrets: []string{"old_secret_value", secret}},
+			Process:  process,
+		},
+		"hub": {
+			Verifier: inboundwebhookuc.BodyHMAC{Header: "X-Hub-Signature-256", Prefix: "sha256=", Secrets: []string{secret}},
+			Process:  process,
+		},
+	}, cfg, log)
+}
+
+func signed(at time.Time, body []byte) http.Header {
+	header := http.Header{}
+	header.Set("Stripe-Signature", webhookuc.SignatureHeader(secret, at.Unix(), body))
+	return header
+}
+
+func TestReceive_VerifiesSignatureAndRejectsReplays(t *testing.T) {
+	events := &fakeEvents{}
+	svc := newService(events, func(context.Context, *domain.Event) error { return nil })
+	ctx := context.Background()
+	body := []byte(`{"id":"evt_1","type":"invoice.paid"}`)
+
+	created, err := svc.Receive(ctx, "stripe", signed(time.Now(), body), body)
+	require.NoError(t, err)
+	require.True(t, created)
+	require.Equal(t, "invoice.paid", events.items[0].Type)
+	require.Equal(t, body, events.items[0].Payload)
+
+	// Повторная доставка внутри окна — отсекается по ID события.
+	created, err = svc.Receive(ctx, "stripe", signed(time.Now(), body), body)
+	require.NoError(t, err)
+	require.False(t, created)
+	require.Len(t, events.items, 1)
+
+	// Перехваченный запрос, повторённый позже окна, — по времени подписи.
+	_, err = svc.Receive(ctx, "stripe", signed(time.Now().Add(-time.Hour), body), body)
+	require.ErrorIs(t, err, inboundwebhookuc.ErrSignatureExpired)
+
+	tampered := []byte(`{"id":"evt_2","type":"invoice.paid"}`)
+	_, err = svc.Receive(ctx, "stripe", signed(time.Now(), body), tampered)
+	require.ErrorIs(t, err, inboundwebhookuc.ErrInvalidSignature)
+	_, err = svc.Receive(ctx, "stripe", http.Header{}, body)
+	require.ErrorIs(t, err, inboundwebhookuc.ErrInvalidSignature)
+	_, err = svc.Receive(ctx, "strava", signed(time.Now(), body), body)
+	require.ErrorIs(t, err, inboundwebhookuc.ErrUnknownProvider)
+
+	noID := []byte(`{"type":"invoice.paid"}`)
+	_, err = svc.Receive(ctx, "stripe", signed(time.Now(), noID), noID)
+	require.ErrorIs(t, err, inboundwebhookuc.ErrInvalidPayload)
+}
+
+func TestReceive_BodySignature(t *testing.T) {
+	events := &fakeEvents{}
+	svc := newService(events, func(context.Context, *domain.Event) error { return nil })
+	body := []byte(`{"id":"42","type":"activity.create"}`)
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+
+	header := http.Header{}
+	header.Set("X-Hub-Signature-256", "sha256="+hex.EncodeToString(mac.Sum(nil)))
+	created, err := svc.Receive(context.Background(), "hub", header, body)
+	require.NoError(t, err)
+	require.True(t, created)
+
+	header.Set("X-Hub-Signature-256", hex.EncodeToString(mac.Sum(nil)))
+	_, err = svc.Receive(context.Background(), "hub", header, body)
+	require.ErrorIs(t, err, inboundwebhookuc.ErrInvalidSignature)
+}
+
+func TestRun_RetriesAndMovesToDeadLetter(t *testing.T) {
+	events := &fakeEvents{}
+	var calls []string
+	svc := newService(events, func(_ context.Context, e *domain.Event) error {
+		calls = append(calls, e.ExternalID)
+		if e.ExternalID == "evt_bad" {
+			return errors.New("downstream unavailable")
+		}
+		return nil
+	})
+	ctx := context.Background()
+	for _, body := range [][]byte{[]byte(`{"id":"evt_ok"}`), []byte(`{"id":"evt_bad"}`)} {
+		_, err := svc.Receive(ctx, "stripe", signed(time.Now(), body), body)
+		require.NoError(t, err)
+	}
+
+	require.NoError(t, svc.Run(ctx))
+	require.Equal(t, []string{"evt_ok", "evt_bad"}, calls)
+	require.Equal(t, domain.StatusProcessed, events.items[0].Status)
+	require.Equal(t, domain.StatusPending, events.items[1].Status)
+	require.Equal(t, 1, events.items[1].Attempts)
+
+	time.Sleep(time.Millisecond)
+	require.NoError(t, svc.Run(ctx))
+	require.Equal(t, domain.StatusDead, events.items[1].Status)
+	require.Equal(t, "downstream unavailable", events.items[1].LastError)
+	pending, err := svc.Pending(ctx)
+	require.NoError(t, err)
+	require.Zero(t, pending)
+}
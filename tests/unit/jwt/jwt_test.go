@@ -0,0 +1,240 @@
+package jwt_test
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	gojwt "github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/require"
+
+	"workout-app/internal/config"
+	domain "workout-app/internal/domain/user"
+	jwtsvc "workout-app/pkg/jwt"
+)
+
+func baseConfig() config.JWTConfig {
+	return config.JWTConfig{
+		AccessSecret:           "legacy-access",
+		RefreshSecret:          "legacy-refresh",
+		AccessTTL:              time.Minute,
+		RefreshTTL:             time.Hour,
+		Issuer:                 "test",
+		AcceptTokensWithoutKID: true,
+	}
+}
+
+func newService(t *testing.T, cfg config.JWTConfig) jwtsvc.Service {
+	t.Helper()
+	svc, err := jwtsvc.NewService(&cfg)
+	require.NoError(t, err)
+	return svc
+}
+
+func kidOf(t *testing.T, token string) any {
+	t.Helper()
+	parsed, _, err := gojwt.NewParser().ParseUnverified(token, &gojwt.RegisteredClaims{})
+	require.NoError(t, err)
+	return parsed.Header["kid"]
+}
+
+func TestJWT_RotationKeepsOutstandingTokensValid(t *testing.T) {
+	user := domain.NewUser("user@example.com", "hash", "user1")
+
+	// До ротации: токены без kid, подписанные legacy-секретом.
+	legacyCfg := baseConfig()
+	legacyToken, err := newService(t, legacyCfg).GenerateAccessToken(user)
+	require.NoError(t, err)
+	require.Nil(t, kidOf(t, legacyToken))
+
+	// Первый ключ набора становится текущим.
+	v1Cfg := baseConfig()
+	v1Cfg.AccessKeys = map[string]string{"v1": "secret-one"}
+	v1Cfg.AccessKeyID = "v1"
+	v1Token, err := newService(t, v1Cfg).GenerateAccessToken(user)
+	require.NoError(t, err)
+	require.Equal(t, "v1", kidOf(t, v1Token))
+
+	// Ротация: v2 текущий, v1 ещё проверяется, токены без kid больше не принимаются.
+	v2Cfg := baseConfig()
+	v2Cfg.AccessKeys = map[string]string{"v1": "secret-one", "v2": "secret-two"}
+	v2Cfg.AccessKeyID = "v2"
+	v2Cfg.AcceptTokensWithoutKID = false
+	v2 := newService(t, v2Cfg)
+	v2Token, err := v2.GenerateAccessToken(user)
+	require.NoError(t, err)
+	require.Equal(t, "v2", kidOf(t, v2Token))
+
+	for _, token := range []string{v1Token, v2Token} {
+		claims, err := v2.ParseAccessToken(token)
+		require.NoError(t, err)
+		require.Equal(t, user.ID.String(), claims.UserID)
+	}
+	_, err = v2.ParseAccessToken(legacyToken)
+	require.ErrorIs(t, err, gojwt.ErrTokenUnverifiable)
+
+	// Удалённый из набора ключ отзывает выданные им токены.
+	v3Cfg := v2Cfg
+	v3Cfg.AccessKeys = map[string]string{"v2": "secret-two"}
+	_, err = newService(t, v3Cfg).ParseAccessToken(v1Token)
+	require.ErrorIs(t, err, gojwt.ErrTokenUnverifiable)
+}
+
+func TestJWT_RefreshKeysetIsSeparate(t *testing.T) {
+	user := domain.NewUser("user@example.com", "hash", "user1")
+	cfg := baseConfig()
+	cfg.AccessKeys = map[string]string{"k1": "access-one"}
+	cfg.AccessKeyID = "k1"
+	cfg.RefreshKeys = map[string]string{"k1": "refresh-one"}
+	cfg.RefreshKeyID = "k1"
+	svc := newService(t, cfg)
+
+	refresh, jti, err := svc.GenerateRefreshToken(user)
+	require.NoError(t, err)
+	require.NotEmpty(t, jti)
+
+	claims, err := svc.ParseRefreshToken(refresh)
+	require.NoError(t, err)
+	require.Equal(t, jti, claims.ID)
+
+	// Одинаковый kid не позволяет выдать refresh-токен за access-токен.
+	_, err = svc.ParseAccessToken(refresh)
+	require.ErrorIs(t, err, gojwt.ErrTokenSignatureInvalid)
+}
+
+func TestSelfCheck_DetectsMissingSigningKey(t *testing.T) {
+	require.NoError(t, jwtsvc.SelfCheck(newService(t, baseConfig())))
+
+	// Текущий kid отсутствует в наборе: токен подписывается, но не проверяется.
+	cfg := baseConfig()
+	cfg.AccessKeys = map[string]string{"k1": "access-one"}
+	cfg.AccessKeyID = "k2"
+	err := jwtsvc.SelfCheck(newService(t, cfg))
+	require.ErrorIs(t, err, gojwt.ErrTokenUnverifiable)
+	require.ErrorContains(t, err, "verify access token")
+}
+
+func TestJWTConfig_ValidatesKeyset(t *testing.T) {
+	t.Setenv("JWT_ACCESS_SECRET", "access")
+	t.Setenv("JWT_REFRESH_SECRET", "refresh")
+	t.Setenv("JWT_ACCESS_KEYS", "v1:secret:with:colons, v2:other")
+	t.Setenv("JWT_ACCESS_KEY_ID", "v2")
+
+	cfg, err := config.Load()
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"v1": "secret:with:colons", "v2": "other"}, cfg.JWT.AccessKeys)
+
+	t.Setenv("JWT_ACCESS_KEY_ID", "v3")
+	_, err = config.Load()
+	require.ErrorContains(t, err, "JWT_ACCESS_KEY_ID")
+
+	t.Setenv("JWT_ACCESS_KEY_ID", "v2")
+	t.Setenv("JWT_ACCEPT_TOKENS_WITHOUT_KID", "false")
+	_, err = config.Load()
+	require.ErrorContains(t, err, "JWT_REFRESH_KEY_ID")
+}
+
+// writePEM сохраняет PEM-блок в файл во временном каталоге теста.
+func writePEM(t *testing.T, blockType string, der []byte) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "key.pem")
+	require.NoError(t, os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der}), 0o600))
+	return path
+}
+
+func writePrivateKey(t *testing.T, key any) string {
+	t.Helper()
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+	return writePEM(t, "PRIVATE KEY", der)
+}
+
+func writePublicKey(t *testing.T, key any) string {
+	t.Helper()
+	der, err := x509.MarshalPKIXPublicKey(key)
+	require.NoError(t, err)
+	return writePEM(t, "PUBLIC KEY", der)
+}
+
+func TestJWT_EdDSASigningPublishesJWKS(t *testing.T) {
+	user := domain.NewUser("user@example.com", "hash", "user1")
+	hmacToken, err := newService(t, baseConfig()).GenerateAccessToken(user)
+	require.NoError(t, err)
+
+	oldPublic, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	_, private, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	cfg := baseConfig()
+	cfg.SigningAlgorithm = jwtsvc.AlgorithmEdDSA
+	cfg.PrivateKeyPath = writePrivateKey(t, private)
+	cfg.PublicKeyPaths = []string{writePublicKey(t, oldPublic)}
+	svc := newService(t, cfg)
+
+	token, err := svc.GenerateAccessToken(user)
+	require.NoError(t, err)
+	parsed, _, err := gojwt.NewParser().ParseUnverified(token, &gojwt.RegisteredClaims{})
+	require.NoError(t, err)
+	require.Equal(t, "EdDSA", parsed.Method.Alg())
+
+	jwks := svc.JWKS()
+	require.Len(t, jwks.Keys, 2)
+	require.Equal(t, kidOf(t, token), jwks.Keys[0].Kid)
+	require.Equal(t, "OKP", jwks.Keys[0].Kty)
+	require.Equal(t, "sig", jwks.Keys[0].Use)
+
+	// Сторонний сервис проверяет токен только по открытому ключу из JWKS.
+	_, err = gojwt.Parse(token, func(*gojwt.Token) (interface{}, error) { return private.Public(), nil },
+		gojwt.WithValidMethods([]string{"EdDSA"}))
+	require.NoError(t, err)
+
+	// Токены, подписанные HS256 до перехода, действуют до истечения.
+	for _, tok := range []string{token, hmacToken} {
+		claims, err := svc.ParseAccessToken(tok)
+		require.NoError(t, err)
+		require.Equal(t, user.ID.String(), claims.UserID)
+	}
+
+	// Refresh-токены по-прежнему подписываются секретом и не принимаются как access.
+	refresh, _, err := svc.GenerateRefreshToken(user)
+	require.NoError(t, err)
+	_, err = svc.ParseRefreshToken(refresh)
+	require.NoError(t, err)
+	_, err = svc.ParseAccessToken(refresh)
+	require.ErrorIs(t, err, gojwt.ErrTokenSignatureInvalid)
+}
+
+func TestJWT_RS256RejectsMismatchedKeys(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	cfg := baseConfig()
+	cfg.SigningAlgorithm = jwtsvc.AlgorithmRS256
+	cfg.PrivateKeyPath = writePrivateKey(t, rsaKey)
+	svc := newService(t, cfg)
+	require.Equal(t, "RSA", svc.JWKS().Keys[0].Kty)
+	require.Equal(t, "AQAB", svc.JWKS().Keys[0].E)
+
+	// Ключ Ed25519 не подходит алгоритму RS256.
+	cfg.PrivateKeyPath = writePrivateKey(t, edKey)
+	_, err = jwtsvc.NewService(&cfg)
+	require.ErrorContains(t, err, "does not match algorithm RS256")
+
+	// Токен, подписанный неизвестным ключом того же алгоритма, не проверяется.
+	other, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	cfg.PrivateKeyPath = writePrivateKey(t, other)
+	forged, err := newService(t, cfg).GenerateAccessToken(domain.NewUser("user@example.com", "hash", "user1"))
+	require.NoError(t, err)
+	_, err = svc.ParseAccessToken(forged)
+	require.ErrorIs(t, err, gojwt.ErrTokenUnverifiable)
+}
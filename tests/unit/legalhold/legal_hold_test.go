@@ -0,0 +1,85 @@
+package legalhold_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	domain "workout-app/internal/domain/user"
+	repo "workout-app/internal/repository/interfaces"
+	legalholduc "workout-app/internal/usecase/legalhold"
+)
+
+type passthroughTx struct{}
+
+func (passthroughTx) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+type fakeUsers struct {
+	repo.UserRepository
+	user *domain.User
+}
+
+func (r *fakeUsers) GetByIDIncludingDeleted(_ context.Context, id uuid.UUID) (*domain.User, error) {
+	if r.user.ID != id {
+		return nil, repo.ErrNotFound
+	}
+	return r.user, nil
+}
+
+func (r *fakeUsers) SetLegalHold(_ context.Context, _ uuid.UUID, h *domain.LegalHold) error {
+	r.user.LegalHold = h
+	return nil
+}
+
+type fakeAudit struct {
+	records []*domain.LegalHoldRecord
+}
+
+func (r *fakeAudit) Create(_ context.Context, rec *domain.LegalHoldRecord) error {
+	rec.ID = int64(len(r.records) + 1)
+	r.records = append(r.records, rec)
+	return nil
+}
+
+func (r *fakeAudit) ListByUser(context.Context, uuid.UUID) ([]*domain.LegalHoldRecord, error) {
+	return r.records, nil
+}
+
+func TestLegalHold_PlaceAndReleaseAreAudited(t *testing.T) {
+	ctx := context.Background()
+	user := domain.NewUser("user@example.com", "hash", "user1")
+	deletedAt := user.CreatedAt
+	user.DeletedAt = &deletedAt // удержание применимо и к мягко удалённым аккаунтам
+	users := &fakeUsers{user: user}
+	audit := &fakeAudit{}
+	svc := legalholduc.NewService(passthroughTx{}, users, audit)
+	adminID := uuid.New()
+
+	_, err := svc.Place(ctx, adminID, user.ID, "   ")
+	require.ErrorIs(t, err, legalholduc.ErrReasonRequired)
+
+	hold, err := svc.Place(ctx, adminID, user.ID, " dispute #42 ")
+	require.NoError(t, err)
+	require.Equal(t, "dispute #42", hold.Reason)
+	require.Equal(t, adminID, hold.By)
+	require.True(t, user.IsOnLegalHold())
+
+	_, err = svc.Place(ctx, adminID, user.ID, "again")
+	require.ErrorIs(t, err, legalholduc.ErrAlreadyOnHold)
+
+	require.NoError(t, svc.Release(ctx, adminID, user.ID, "dispute closed"))
+	require.False(t, user.IsOnLegalHold())
+	require.ErrorIs(t, svc.Release(ctx, adminID, user.ID, "again"), legalholduc.ErrNotOnHold)
+
+	current, history, err := svc.Get(ctx, user.ID)
+	require.NoError(t, err)
+	require.Nil(t, current)
+	require.Len(t, history, 2)
+	require.Equal(t, domain.LegalHoldPlaced, history[0].Action)
+	require.Equal(t, domain.LegalHoldReleased, history[1].Action)
+	require.Equal(t, "dispute closed", history[1].Reason)
+}
@@ -0,0 +1,140 @@
+package lifecycle_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"workout-app/internal/lifecycle"
+	"workout-app/pkg/logger"
+)
+
+func newManager() *lifecycle.Manager {
+	return lifecycle.NewManager(logger.New(io.Discard, slog.LevelError, logger.FormatJSON))
+}
+
+func TestManager_StopsInReverseOrderAfterCancellingRunContext(t *testing.T) {
+	m := newManager()
+	var order []string
+	var wg sync.WaitGroup
+
+	m.Register("redis", nil, func(context.Context) error {
+		order = append(order, "redis")
+		return nil
+	})
+	m.Register("worker", func(ctx context.Context) error {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-ctx.Done()
+		}()
+		return nil
+	}, func(ctx context.Context) error {
+		if err := lifecycle.WaitFunc(wg.Wait)(ctx); err != nil {
+			return err
+		}
+		order = append(order, "worker")
+		return nil
+	})
+
+	require.NoError(t, m.Start(context.Background()))
+	require.ErrorIs(t, m.Start(context.Background()), lifecycle.ErrAlreadyStarted)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	require.NoError(t, m.Shutdown(ctx))
+	require.Equal(t, []string{"worker", "redis"}, order)
+
+	// Повторная остановка ничего не делает.
+	require.NoError(t, m.Shutdown(ctx))
+	require.Len(t, order, 2)
+}
+
+func TestManager_StartFailureStopsStartedComponents(t *testing.T) {
+	m := newManager()
+	var stopped []string
+	boom := errors.New("boom")
+
+	m.Register("first", func(context.Context) error { return nil }, func(context.Context) error {
+		stopped = append(stopped, "first")
+		return nil
+	})
+	m.Register("broken", func(context.Context) error { return boom }, func(context.Context) error {
+		stopped = append(stopped, "broken")
+		return nil
+	})
+	m.Register("never", func(context.Context) error { return nil }, func(context.Context) error {
+		stopped = append(stopped, "never")
+		return nil
+	})
+
+	require.ErrorIs(t, m.Start(context.Background()), boom)
+	require.Equal(t, []string{"first"}, stopped)
+}
+
+func TestManager_ShutdownReportsTimeoutAndContinues(t *testing.T) {
+	m := newManager()
+	var closed bool
+
+	m.Register("client", nil, lifecycle.CloseFunc(func() error {
+		closed = true
+		return nil
+	}))
+	m.Register("stuck", nil, lifecycle.WaitFunc(func() { select {} }))
+
+	require.NoError(t, m.Start(context.Background()))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	err := m.Shutdown(ctx)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.ErrorContains(t, err, "stuck")
+	require.True(t, closed)
+}
+
+func TestManager_ReadyOnlyBetweenStartAndShutdown(t *testing.T) {
+	m := newManager()
+	ctx := context.Background()
+	release := make(chan struct{})
+	m.Register("warmup", func(context.Context) error {
+		<-release
+		return nil
+	}, nil)
+
+	require.ErrorIs(t, m.Ready(ctx), lifecycle.ErrNotReady)
+	started := make(chan error, 1)
+	go func() { started <- m.Start(ctx) }()
+	require.ErrorIs(t, m.Ready(ctx), lifecycle.ErrNotReady)
+
+	close(release)
+	require.NoError(t, <-started)
+	require.NoError(t, m.Ready(ctx))
+
+	require.NoError(t, m.Shutdown(ctx))
+	require.ErrorIs(t, m.Ready(ctx), lifecycle.ErrNotReady)
+}
+
+func TestGate_WaitsForDependencyAndTimesOut(t *testing.T) {
+	ctx := context.Background()
+	pending := errors.New("pending migrations")
+
+	attempts := 0
+	gate := lifecycle.Gate(func(context.Context) error {
+		attempts++
+		if attempts < 3 {
+			return pending
+		}
+		return nil
+	}, time.Second, time.Millisecond)
+	require.NoError(t, gate(ctx))
+	require.Equal(t, 3, attempts)
+
+	gate = lifecycle.Gate(func(context.Context) error { return pending }, 20*time.Millisecond, time.Millisecond)
+	require.ErrorIs(t, gate(ctx), pending)
+}
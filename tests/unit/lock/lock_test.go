@@ -0,0 +1,49 @@
+package lock_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"workout-app/pkg/lock"
+)
+
+func TestMemoryLocker_Exclusive(t *testing.T) {
+	ctx := context.Background()
+	locker := lock.NewMemoryLocker()
+
+	first, err := locker.TryAcquire(ctx, "job:cleanup", time.Minute)
+	require.NoError(t, err)
+	_, err = locker.TryAcquire(ctx, "job:cleanup", time.Minute)
+	require.ErrorIs(t, err, lock.ErrNotAcquired)
+
+	other, err := locker.TryAcquire(ctx, "job:exports", time.Minute)
+	require.NoError(t, err, "разные ключи блокируются независимо")
+	require.NoError(t, other.Release(ctx))
+
+	require.NoError(t, first.Refresh(ctx, time.Minute))
+	require.NoError(t, first.Release(ctx))
+	require.ErrorIs(t, first.Release(ctx), lock.ErrLost)
+
+	second, err := locker.TryAcquire(ctx, "job:cleanup", time.Minute)
+	require.NoError(t, err)
+	require.NoError(t, second.Release(ctx))
+}
+
+func TestMemoryLocker_ExpiredLockIsLostAndCanBeTaken(t *testing.T) {
+	ctx := context.Background()
+	locker := lock.NewMemoryLocker()
+
+	stale, err := locker.TryAcquire(ctx, "job:webhooks", 10*time.Millisecond)
+	require.NoError(t, err)
+	time.Sleep(20 * time.Millisecond)
+
+	fresh, err := locker.TryAcquire(ctx, "job:webhooks", time.Minute)
+	require.NoError(t, err)
+
+	require.ErrorIs(t, stale.Refresh(ctx, time.Minute), lock.ErrLost)
+	require.ErrorIs(t, stale.Release(ctx), lock.ErrLost)
+	require.NoError(t, fresh.Release(ctx), "прежний владелец не должен снимать чужую блокировку")
+}
@@ -0,0 +1,162 @@
+package mailer_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"workout-app/internal/mailer"
+	"workout-app/pkg/logger"
+	mailerpkg "workout-app/pkg/mailer"
+)
+
+// capturedRequest — запрос, полученный тестовым сервером провайдера.
+type capturedRequest struct {
+	method string
+	path   string
+	header http.Header
+	body   string
+}
+
+// newProviderServer запускает сервер, отвечающий status и запоминающий последний запрос.
+func newProviderServer(t *testing.T, status int) (*httptest.Server, *capturedRequest) {
+	t.Helper()
+	captured := &capturedRequest{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		*captured = capturedRequest{method: r.Method, path: r.URL.Path, header: r.Header.Clone(), body: string(body)}
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(`{"message":"response"}`))
+	}))
+	t.Cleanup(srv.Close)
+	return srv, captured
+}
+
+var testEmail = &mailerpkg.Email{
+	From:     "noreply@example.com",
+	FromName: "Workout",
+	To:       "user@example.com",
+	Subject:  "Код подтверждения",
+	Text:     "code 123456",
+	HTML:     "<p>code 123456</p>",
+}
+
+func TestSendGrid_SendsMailSendRequest(t *testing.T) {
+	srv, req := newProviderServer(t, http.StatusAccepted)
+	provider := mailerpkg.NewSendGrid(mailerpkg.SendGridConfig{APIKey: "sg-key", BaseURL: srv.URL})
+
+	require.NoError(t, provider.Send(context.Background(), testEmail))
+	require.Equal(t, http.MethodPost, req.method)
+	require.Equal(t, "/v3/mail/send", req.path)
+	require.Equal(t, "Bearer sg-key", req.header.Get("Authorization"))
+
+	var payload struct {
+		Personalizations []struct {
+			To []struct{ Email string } `json:"to"`
+		} `json:"personalizations"`
+		From    struct{ Email, Name string }   `json:"from"`
+		Subject string                         `json:"subject"`
+		Content []struct{ Type, Value string } `json:"content"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(req.body), &payload))
+	require.Equal(t, "user@example.com", payload.Personalizations[0].To[0].Email)
+	require.Equal(t, "noreply@example.com", payload.From.Email)
+	require.Equal(t, "Workout", payload.From.Name)
+	require.Equal(t, "Код подтверждения", payload.Subject)
+	require.Equal(t, "text/plain", payload.Content[0].Type)
+	require.Equal(t, "text/html", payload.Content[1].Type)
+}
+
+func TestMailgun_SendsFormWithBasicAuth(t *testing.T) {
+	srv, req := newProviderServer(t, http.StatusOK)
+	provider := mailerpkg.NewMailgun(mailerpkg.MailgunConfig{APIKey: "mg-key", Domain: "mg.example.com", BaseURL: srv.URL + "/"})
+
+	require.NoError(t, provider.Send(context.Background(), testEmail))
+	require.Equal(t, "/v3/mg.example.com/messages", req.path)
+	user, pass, ok := (&http.Request{Header: req.header}).BasicAuth()
+	require.True(t, ok)
+	require.Equal(t, "api", user)
+	require.Equal(t, "mg-key", pass)
+	require.Contains(t, req.body, "to=user%40example.com")
+	require.Contains(t, req.body, "html=%3Cp%3Ecode+123456%3C%2Fp%3E")
+}
+
+func TestSES_SignsRequest(t *testing.T) {
+	srv, req := newProviderServer(t, http.StatusOK)
+	provider := mailerpkg.NewSES(mailerpkg.SESConfig{
+		Region:          "eu-west-1",
+		AccessKeyID:     "AKIDEXAMPLE",
+		SecretAccessKey: "secret",
+		BaseURL:         srv.URL,
+	})
+
+	require.NoError(t, provider.Send(context.Background(), testEmail))
+	require.Equal(t, "/v2/email/outbound-emails", req.path)
+	auth := req.header.Get("Authorization")
+	require.True(t, strings.HasPrefix(auth, "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/"), auth)
+	require.Contains(t, auth, "/eu-west-1/ses/aws4_request, SignedHeaders=content-type;host;x-amz-date, Signature=")
+	require.NotEmpty(t, req.header.Get("X-Amz-Date"))
+	require.Contains(t, req.body, `"ToAddresses":["user@example.com"]`)
+}
+
+func TestProviders_MapErrorStatuses(t *testing.T) {
+	cases := []struct {
+		status      int
+		rejected    bool
+		rateLimited bool
+	}{
+		{status: http.StatusBadRequest, rejected: true},
+		{status: http.StatusUnauthorized, rejected: true},
+		{status: http.StatusTooManyRequests, rateLimited: true},
+		{status: http.StatusRequestTimeout},
+		{status: http.StatusServiceUnavailable},
+	}
+	for _, tc := range cases {
+		srv, _ := newProviderServer(t, tc.status)
+		err := mailerpkg.NewSendGrid(mailerpkg.SendGridConfig{APIKey: "k", BaseURL: srv.URL}).Send(context.Background(), testEmail)
+		require.Error(t, err)
+		require.Equal(t, tc.rejected, errors.Is(err, mailerpkg.ErrMessageRejected), tc.status)
+		require.Equal(t, tc.rateLimited, errors.Is(err, mailerpkg.ErrSendRateLimited), tc.status)
+
+		var providerErr *mailerpkg.ProviderError
+		require.True(t, errors.As(err, &providerErr))
+		require.Equal(t, tc.status, providerErr.StatusCode)
+		require.Equal(t, "sendgrid", providerErr.Provider)
+	}
+}
+
+// recordingProvider запоминает последнее письмо.
+type recordingProvider struct {
+	email *mailerpkg.Email
+}
+
+func (p *recordingProvider) Name() string { return "test" }
+
+func (p *recordingProvider) Send(_ context.Context, email *mailerpkg.Email) error {
+	p.email = email
+	return nil
+}
+
+func TestProviderSender_RendersTemplateInLocale(t *testing.T) {
+	templates, err := mailer.LoadTemplates("en")
+	require.NoError(t, err)
+	provider := &recordingProvider{}
+	log := logger.New(io.Discard, slog.LevelError, logger.FormatJSON)
+	sender := mailer.NewProviderSender(provider, "noreply@example.com", templates, log)
+
+	ctx := mailerpkg.WithLocale(context.Background(), "ru")
+	require.NoError(t, sender.SendPasswordChangeCode(ctx, "user@example.com", "654321"))
+	require.Equal(t, "noreply@example.com", provider.email.From)
+	require.Equal(t, "user@example.com", provider.email.To)
+	require.Equal(t, "Подтвердите смену пароля", provider.email.Subject)
+	require.Contains(t, provider.email.Text, "654321")
+	require.Contains(t, provider.email.HTML, "654321")
+}
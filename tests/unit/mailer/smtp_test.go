@@ -0,0 +1,186 @@
+package mailer_test
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"log/slog"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"workout-app/internal/config"
+	"workout-app/internal/mailer"
+	"workout-app/pkg/logger"
+)
+
+// fakeSMTPServer — минимальный SMTP-сервер без TLS: считает соединения, AUTH и принятые письма.
+type fakeSMTPServer struct {
+	ln        net.Listener
+	startTLS  bool // Предлагать STARTTLS в ответе на EHLO
+	stallData bool // Не отвечать после передачи письма
+
+	mu       sync.Mutex
+	conns    int
+	auths    int
+	messages int
+}
+
+func newFakeSMTPServer(t *testing.T) *fakeSMTPServer {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	s := &fakeSMTPServer{ln: ln}
+	t.Cleanup(func() { _ = ln.Close() })
+	go s.serve()
+	return s
+}
+
+func (s *fakeSMTPServer) serve() {
+	for {
+		conn, err := s.ln.Accept()
+		if err != nil {
+			return
+		}
+		s.mu.Lock()
+		s.conns++
+		s.mu.Unlock()
+		go s.handle(conn)
+	}
+}
+
+func (s *fakeSMTPServer) handle(conn net.Conn) {
+	defer conn.Close()
+	r := bufio.NewReader(conn)
+	reply := func(lines ...string) { _, _ = io.WriteString(conn, strings.Join(lines, "\r\n")+"\r\n") }
+
+	reply("220 localhost ESMTP")
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+		cmd := strings.ToUpper(strings.Fields(line + " x")[0])
+		switch cmd {
+		case "EHLO":
+			if s.startTLS {
+				reply("250-localhost", "250-STARTTLS", "250 AUTH PLAIN")
+			} else {
+				reply("250-localhost", "250 AUTH PLAIN")
+			}
+		case "AUTH":
+			s.mu.Lock()
+			s.auths++
+			s.mu.Unlock()
+			reply("235 ok")
+		case "DATA":
+			reply("354 go ahead")
+			for {
+				l, err := r.ReadString('\n')
+				if err != nil {
+					return
+				}
+				if l == ".\r\n" {
+					break
+				}
+			}
+			if s.stallData {
+				time.Sleep(5 * time.Second)
+				return
+			}
+			s.mu.Lock()
+			s.messages++
+			s.mu.Unlock()
+			reply("250 queued")
+		case "QUIT":
+			reply("221 bye")
+			return
+		default: // MAIL, RCPT, RSET, NOOP
+			reply("250 ok")
+		}
+	}
+}
+
+func (s *fakeSMTPServer) stats() (conns, auths, messages int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.conns, s.auths, s.messages
+}
+
+func newTestSMTPSender(t *testing.T, server *fakeSMTPServer, modify func(*config.EmailConfig)) *mailer.SMTPSender {
+	port, err := strconv.Atoi(strings.TrimPrefix(server.ln.Addr().String(), "127.0.0.1:"))
+	require.NoError(t, err)
+	cfg := &config.EmailConfig{
+		SMTPHost:               "127.0.0.1",
+		SMTPPort:               port,
+		SMTPUsername:           "user",
+		SMTPPassword:           "secret",
+		SMTPTLS:                mailer.SMTPTLSNone,
+		SMTPTimeout:            time.Second,
+		SMTPPoolSize:           2,
+		SMTPIdleTimeout:        time.Minute,
+		SMTPMaxMessagesPerConn: 100,
+		FromEmail:              "noreply@example.com",
+	}
+	if modify != nil {
+		modify(cfg)
+	}
+	sender := mailer.NewSMTPSender(cfg, mailer.MustLoadTemplates("en"), logger.New(io.Discard, slog.LevelError, logger.FormatJSON))
+	t.Cleanup(func() { _ = sender.Close() })
+	return sender
+}
+
+func TestSMTPSender_ReusesPooledConnection(t *testing.T) {
+	server := newFakeSMTPServer(t)
+	sender := newTestSMTPSender(t, server, nil)
+
+	for i := 0; i < 3; i++ {
+		require.NoError(t, sender.SendEmailVerificationCode(context.Background(), "user@example.com", "123456"))
+	}
+
+	conns, auths, messages := server.stats()
+	require.Equal(t, 1, conns)
+	require.Equal(t, 1, auths)
+	require.Equal(t, 3, messages)
+}
+
+func TestSMTPSender_ReconnectsAfterMessageLimit(t *testing.T) {
+	server := newFakeSMTPServer(t)
+	sender := newTestSMTPSender(t, server, func(cfg *config.EmailConfig) { cfg.SMTPMaxMessagesPerConn = 1 })
+
+	require.NoError(t, sender.SendEmailVerificationCode(context.Background(), "a@example.com", "111111"))
+	require.NoError(t, sender.SendEmailVerificationCode(context.Background(), "b@example.com", "222222"))
+
+	conns, _, messages := server.stats()
+	require.Equal(t, 2, conns)
+	require.Equal(t, 2, messages)
+}
+
+func TestSMTPSender_RequiresStartTLS(t *testing.T) {
+	server := newFakeSMTPServer(t)
+	sender := newTestSMTPSender(t, server, func(cfg *config.EmailConfig) { cfg.SMTPTLS = mailer.SMTPTLSStartTLS })
+
+	err := sender.SendEmailVerificationCode(context.Background(), "user@example.com", "123456")
+	require.ErrorIs(t, err, mailer.ErrSMTPStartTLSUnsupported)
+
+	_, auths, messages := server.stats()
+	require.Zero(t, auths, "credentials must not be sent without TLS")
+	require.Zero(t, messages)
+}
+
+func TestSMTPSender_HonorsContextDeadline(t *testing.T) {
+	server := newFakeSMTPServer(t)
+	server.stallData = true
+	sender := newTestSMTPSender(t, server, func(cfg *config.EmailConfig) { cfg.SMTPTimeout = time.Minute })
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	started := time.Now()
+	err := sender.SendEmailVerificationCode(ctx, "user@example.com", "123456")
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.Less(t, time.Since(started), 2*time.Second)
+}
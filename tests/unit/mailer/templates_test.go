@@ -0,0 +1,155 @@
+package mailer_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"workout-app/internal/mailer"
+)
+
+func TestTemplates_RenderDefaultLocale(t *testing.T) {
+	templates, err := mailer.LoadTemplates("en")
+	require.NoError(t, err)
+
+	msg, err := templates.Render("", "", mailer.TemplateVerificationCode, map[string]any{"Code": "123456"})
+	require.NoError(t, err)
+	require.Equal(t, "Your verification code", msg.Subject)
+	require.Contains(t, msg.Text, "Your verification code is: 123456")
+	require.Contains(t, msg.HTML, `<html lang="en">`)
+	require.Contains(t, msg.HTML, "123456")
+}
+
+func TestTemplates_RenderUserLocale(t *testing.T) {
+	templates, err := mailer.LoadTemplates("en")
+	require.NoError(t, err)
+
+	expiresAt := time.Date(2026, 3, 5, 14, 30, 0, 0, time.UTC)
+	data := map[string]any{"DownloadURL": "https://example.com/export.zip", "ExpiresAt": expiresAt}
+
+	msg, err := templates.Render("ru-RU", "", mailer.TemplateDataExportReady, data)
+	require.NoError(t, err)
+	require.Equal(t, "Архив с вашими данными готов", msg.Subject)
+	require.Contains(t, msg.Text, "05.03.2026 14:30 UTC")
+	require.Contains(t, msg.HTML, `<html lang="ru">`)
+	require.Contains(t, msg.HTML, `href="https://example.com/export.zip"`)
+
+	msg, err = templates.Render("en", "", mailer.TemplateDataExportReady, data)
+	require.NoError(t, err)
+	require.Contains(t, msg.Text, "2026-03-05 14:30 UTC")
+}
+
+func TestTemplates_UnsupportedLocaleFallsBackToDefault(t *testing.T) {
+	templates, err := mailer.LoadTemplates("ru")
+	require.NoError(t, err)
+
+	msg, err := templates.Render("de", "", mailer.TemplatePasswordChangeCode, map[string]any{"Code": "654321"})
+	require.NoError(t, err)
+	require.Equal(t, "Подтвердите смену пароля", msg.Subject)
+	require.Contains(t, msg.Text, "654321")
+}
+
+func TestTemplates_HTMLEscapesData(t *testing.T) {
+	templates, err := mailer.LoadTemplates("en")
+	require.NoError(t, err)
+
+	msg, err := templates.Render("en", "", mailer.TemplateVerificationCode, map[string]any{"Code": "<b>1</b>"})
+	require.NoError(t, err)
+	require.False(t, strings.Contains(msg.HTML, "<b>1</b>"))
+	require.Contains(t, msg.HTML, "&lt;b&gt;1&lt;/b&gt;")
+	require.Contains(t, msg.Text, "<b>1</b>")
+}
+
+func TestTemplates_ErrorsOnUnknownDefaultLocaleOrTemplate(t *testing.T) {
+	_, err := mailer.LoadTemplates("de")
+	require.Error(t, err)
+
+	templates, err := mailer.LoadTemplates("en")
+	require.NoError(t, err)
+	_, err = templates.Render("en", "", "unknown", nil)
+	require.Error(t, err)
+}
+
+func TestTemplates_RenderAccountEmails(t *testing.T) {
+	templates, err := mailer.LoadTemplates("en")
+	require.NoError(t, err)
+
+	msg, err := templates.Render("ru", "", mailer.TemplateWelcome, map[string]any{"Username": "jane"})
+	require.NoError(t, err)
+	require.Equal(t, "Добро пожаловать, jane!", msg.Subject)
+	require.Contains(t, msg.HTML, "jane")
+
+	at := time.Date(2026, 3, 5, 14, 30, 0, 0, time.UTC)
+	msg, err = templates.Render("en", "", mailer.TemplatePasswordChanged, map[string]any{"ChangedAt": at})
+	require.NoError(t, err)
+	require.Contains(t, msg.Text, "2026-03-05 14:30 UTC")
+
+	msg, err = templates.Render("en", "", mailer.TemplateAccountDeleted, map[string]any{"DeletedAt": at})
+	require.NoError(t, err)
+	require.Equal(t, "Your account was deleted", msg.Subject)
+	require.Contains(t, msg.Text, "2026-03-05 14:30 UTC")
+}
+
+func TestTemplates_RenderWeeklySummary(t *testing.T) {
+	templates, err := mailer.LoadTemplates("en")
+	require.NoError(t, err)
+	data := map[string]any{
+		"WeekStart":    time.Date(2026, 10, 5, 0, 0, 0, 0, time.UTC),
+		"WeekEnd":      time.Date(2026, 10, 11, 0, 0, 0, 0, time.UTC),
+		"Sessions":     3,
+		"TrainingDays": 3,
+		"Sets":         30,
+		"Volume":       2204.62,
+		"WeightUnit":   "lb",
+		"PersonalRecords": []map[string]any{
+			{"Exercise": "Squat", "Weight": 264.55, "Previous": 242.51},
+		},
+	}
+
+	// Даты недели не переводятся в часовой пояс получателя: это календарные дни.
+	msg, err := templates.Render("en", "America/New_York", mailer.TemplateWeeklySummary, data)
+	require.NoError(t, err)
+	require.Equal(t, "Your training week: Oct 5, 2026 – Oct 11, 2026", msg.Subject)
+	require.Contains(t, msg.Text, "Volume: 2205 lb")
+	require.Contains(t, msg.Text, "- Squat: 264.55 lb (previous best 242.51 lb)")
+	require.Contains(t, msg.HTML, "Squat")
+
+	msg, err = templates.Render("ru", "Asia/Tokyo", mailer.TemplateWeeklySummary, data)
+	require.NoError(t, err)
+	require.Equal(t, "Итоги недели тренировок: 05.10.2026 – 11.10.2026", msg.Subject)
+	require.Contains(t, msg.Text, "Тоннаж: 2205 фунт.")
+}
+
+func TestTemplates_RenderCoachInvitation(t *testing.T) {
+	templates, err := mailer.LoadTemplates("en")
+	require.NoError(t, err)
+	data := map[string]any{"CoachName": "Anna <Ivanova>", "ExpiresAt": time.Date(2026, 3, 5, 14, 30, 0, 0, time.UTC)}
+
+	msg, err := templates.Render("ru", "", mailer.TemplateCoachInvitation, data)
+	require.NoError(t, err)
+	require.Equal(t, "Anna <Ivanova> приглашает вас тренироваться вместе", msg.Subject)
+	require.Contains(t, msg.Text, "05.03.2026 14:30 UTC")
+	require.NotContains(t, msg.HTML, "<Ivanova>")
+}
+
+func TestTemplates_RenderDatesInRecipientLocaleAndTimezone(t *testing.T) {
+	templates, err := mailer.LoadTemplates("en")
+	require.NoError(t, err)
+	at := time.Date(2026, 3, 5, 14, 30, 0, 0, time.UTC)
+	data := map[string]any{"ChangedAt": at}
+
+	msg, err := templates.Render("en-US", "America/New_York", mailer.TemplatePasswordChanged, data)
+	require.NoError(t, err)
+	require.Contains(t, msg.Text, "Mar 5, 2026 9:30 AM EST")
+
+	msg, err = templates.Render("en-GB", "Europe/London", mailer.TemplatePasswordChanged, data)
+	require.NoError(t, err)
+	require.Contains(t, msg.Text, "05/03/2026 14:30 GMT")
+
+	// Для региона без своего формата используется формат языка, неизвестный часовой пояс — UTC.
+	msg, err = templates.Render("ru-BY", "Mars/Olympus", mailer.TemplatePasswordChanged, data)
+	require.NoError(t, err)
+	require.Contains(t, msg.Text, "05.03.2026 14:30 UTC")
+}
@@ -0,0 +1,51 @@
+package maintenance_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	maintenanceuc "workout-app/internal/usecase/maintenance"
+)
+
+// fakeCache считает сбросы и возвращает заданную ошибку.
+type fakeCache struct {
+	invalidated int
+	err         error
+}
+
+func (c *fakeCache) Invalidate(context.Context) error {
+	c.invalidated++
+	return c.err
+}
+
+func TestListCaches_Sorted(t *testing.T) {
+	svc := maintenanceuc.NewService(map[string]maintenanceuc.Cache{
+		"status": &fakeCache{},
+		"feed":   &fakeCache{},
+	})
+	require.Equal(t, []string{"feed", "status"}, svc.ListCaches())
+	require.Empty(t, maintenanceuc.NewService(nil).ListCaches())
+}
+
+func TestInvalidateCache(t *testing.T) {
+	ctx := context.Background()
+	feed, status := &fakeCache{}, &fakeCache{}
+	svc := maintenanceuc.NewService(map[string]maintenanceuc.Cache{"feed": feed, "status": status})
+
+	require.NoError(t, svc.InvalidateCache(ctx, "feed"))
+	require.Equal(t, 1, feed.invalidated)
+	require.Zero(t, status.invalidated, "сбрасывается только указанный кеш")
+
+	// Имя сверяется с зарегистрированными точно.
+	for _, name := range []string{"", "Feed", "users", "feed "} {
+		require.ErrorIs(t, svc.InvalidateCache(ctx, name), maintenanceuc.ErrUnknownCache, name)
+	}
+	require.Equal(t, 1, feed.invalidated)
+
+	status.err = errors.New("redis unavailable")
+	require.ErrorContains(t, svc.InvalidateCache(ctx, "status"), "redis unavailable")
+	require.Equal(t, 1, status.invalidated)
+}
@@ -0,0 +1,123 @@
+package metric_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	consentdomain "workout-app/internal/domain/consent"
+	domain "workout-app/internal/domain/user"
+	repo "workout-app/internal/repository/interfaces"
+	consentuc "workout-app/internal/usecase/consent"
+	metricuc "workout-app/internal/usecase/metric"
+)
+
+// fakeMetrics запоминает параметры выборки и возвращает заданную сводку.
+type fakeMetrics struct {
+	repo.BodyMetricRepository
+	calls   int
+	from    time.Time
+	to      time.Time
+	limit   int
+	offset  int
+	summary *domain.BodyMetricsSummary
+}
+
+func (r *fakeMetrics) ListByUser(_ context.Context, _ uuid.UUID, from, to time.Time, limit, offset int) ([]*domain.BodyMetric, error) {
+	r.calls++
+	r.from, r.to, r.limit, r.offset = from, to, limit, offset
+	return nil, nil
+}
+
+func (r *fakeMetrics) GetLatestSummary(context.Context, uuid.UUID) (*domain.BodyMetricsSummary, error) {
+	return r.summary, nil
+}
+
+type fakePublisher struct{}
+
+func (fakePublisher) Publish(context.Context, string, string, any) {}
+
+// fakeConsents разрешает доступ только тренеру allowed.
+type fakeConsents struct {
+	allowed uuid.UUID
+}
+
+func (c fakeConsents) Require(_ context.Context, coachID, _ uuid.UUID, _ consentdomain.Scope) error {
+	if coachID != c.allowed {
+		return consentuc.ErrConsentRequired
+	}
+	return nil
+}
+
+func TestList_RangeValidation(t *testing.T) {
+	metrics := &fakeMetrics{}
+	svc := metricuc.NewService(metrics, fakePublisher{}, fakeConsents{})
+	ctx := context.Background()
+	userID := uuid.New()
+	day := time.Date(2026, 10, 15, 0, 0, 0, 0, time.UTC)
+
+	_, err := svc.List(ctx, userID, day, day.Add(-time.Second), 0, 0)
+	require.ErrorIs(t, err, metricuc.ErrInvalidRange)
+	require.Zero(t, metrics.calls)
+
+	// Открытые границы и совпадающие концы — корректный период.
+	for _, r := range [][2]time.Time{{day, day}, {day, {}}, {{}, day}, {{}, {}}} {
+		_, err = svc.List(ctx, userID, r[0], r[1], 0, 0)
+		require.NoError(t, err)
+		require.Equal(t, r[0], metrics.from)
+		require.Equal(t, r[1], metrics.to)
+	}
+}
+
+func TestList_Pagination(t *testing.T) {
+	metrics := &fakeMetrics{}
+	svc := metricuc.NewService(metrics, fakePublisher{}, fakeConsents{})
+	ctx := context.Background()
+
+	// Без периода выборка всё равно ограничена страницей.
+	_, err := svc.List(ctx, uuid.New(), time.Time{}, time.Time{}, 0, 0)
+	require.NoError(t, err)
+	require.Equal(t, 100, metrics.limit)
+	require.Zero(t, metrics.offset)
+
+	_, err = svc.List(ctx, uuid.New(), time.Time{}, time.Time{}, 10000, 40)
+	require.NoError(t, err)
+	require.Equal(t, 500, metrics.limit)
+	require.Equal(t, 40, metrics.offset)
+
+	_, err = svc.List(ctx, uuid.New(), time.Time{}, time.Time{}, 20, -5)
+	require.NoError(t, err)
+	require.Equal(t, 20, metrics.limit)
+	require.Zero(t, metrics.offset)
+}
+
+func TestListForCoach_RequiresConsent(t *testing.T) {
+	metrics := &fakeMetrics{}
+	coachID := uuid.New()
+	svc := metricuc.NewService(metrics, fakePublisher{}, fakeConsents{allowed: coachID})
+	ctx := context.Background()
+
+	_, err := svc.ListForCoach(ctx, uuid.New(), uuid.New(), time.Time{}, time.Time{}, 0, 0)
+	require.ErrorIs(t, err, consentuc.ErrConsentRequired)
+	require.Zero(t, metrics.calls)
+
+	_, err = svc.ListForCoach(ctx, coachID, uuid.New(), time.Time{}, time.Time{}, 0, 0)
+	require.NoError(t, err)
+	require.Equal(t, 100, metrics.limit)
+}
+
+func TestLatestSummary(t *testing.T) {
+	weight := domain.MetricValue{Value: 80.5, MeasuredAt: time.Date(2026, 10, 14, 8, 0, 0, 0, time.UTC)}
+	summary := &domain.BodyMetricsSummary{
+		WeightKg:     &weight,
+		Measurements: map[string]domain.MetricValue{"waist_cm": {Value: 82, MeasuredAt: weight.MeasuredAt}},
+	}
+	svc := metricuc.NewService(&fakeMetrics{summary: summary}, fakePublisher{}, fakeConsents{})
+
+	got, err := svc.LatestSummary(context.Background(), uuid.New())
+	require.NoError(t, err)
+	require.Equal(t, summary, got)
+}
@@ -0,0 +1,89 @@
+package middleware_test
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"workout-app/internal/domain/audit"
+	"workout-app/internal/handler/middleware"
+	"workout-app/pkg/logger"
+)
+
+// fakeAuditRecorder сохраняет записанные действия.
+type fakeAuditRecorder struct {
+	entries []*audit.Entry
+}
+
+func (f *fakeAuditRecorder) Record(_ context.Context, e *audit.Entry) error {
+	f.entries = append(f.entries, e)
+	return nil
+}
+
+func TestAdminAudit_RecordsActionTargetDiffAndStatus(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	log := logger.New(io.Discard, slog.LevelError, logger.FormatJSON)
+	actorID := uuid.New()
+	recorder := &fakeAuditRecorder{}
+
+	r := gin.New()
+	admin := r.Group("/api/v1/admin")
+	admin.Use(func(c *gin.Context) {
+		c.Set(middleware.ContextUserIDKey, actorID.String())
+	}, middleware.AdminAudit(recorder, log))
+	admin.GET("/users", func(c *gin.Context) { c.JSON(http.StatusOK, []string{}) })
+	admin.PATCH("/users/:id/role", func(c *gin.Context) {
+		if c.Param("id") == "missing" {
+			c.Status(http.StatusNotFound)
+			return
+		}
+		middleware.SetAuditDiff(c, audit.Diff{"role": {Old: "user", New: "coach"}})
+		c.Status(http.StatusOK)
+	})
+	admin.DELETE("/organizations/:id/members/:userId", middleware.Transaction(&fakeTransactor{}, log), func(c *gin.Context) {
+		c.Status(http.StatusNoContent)
+	})
+
+	targetID := uuid.New().String()
+	for _, req := range []*http.Request{
+		httptest.NewRequest(http.MethodGet, "/api/v1/admin/users?role=coach&suspended=true", nil),
+		httptest.NewRequest(http.MethodPatch, "/api/v1/admin/users/"+targetID+"/role", nil),
+		httptest.NewRequest(http.MethodPatch, "/api/v1/admin/users/missing/role", nil),
+		httptest.NewRequest(http.MethodDelete, "/api/v1/admin/organizations/org-1/members/"+targetID, nil),
+	} {
+		r.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	require.Len(t, recorder.entries, 4)
+	for _, e := range recorder.entries {
+		require.Equal(t, actorID, e.ActorID)
+		require.NotEmpty(t, e.IP)
+	}
+
+	list := recorder.entries[0]
+	require.Equal(t, "GET /api/v1/admin/users", list.Action)
+	require.Empty(t, list.TargetID)
+	require.Equal(t, "role=coach&suspended=true", list.Query)
+
+	changed := recorder.entries[1]
+	require.Equal(t, "PATCH /api/v1/admin/users/:id/role", changed.Action)
+	require.Equal(t, targetID, changed.TargetID)
+	require.Equal(t, audit.Diff{"role": {Old: "user", New: "coach"}}, changed.Diff)
+	require.Equal(t, http.StatusOK, changed.Status)
+
+	// Отклонённые действия тоже попадают в журнал, но без изменений.
+	require.Equal(t, http.StatusNotFound, recorder.entries[2].Status)
+	require.Nil(t, recorder.entries[2].Diff)
+
+	// Целью считается последний параметр пути; статус — после фиксации транзакции.
+	removed := recorder.entries[3]
+	require.Equal(t, targetID, removed.TargetID)
+	require.Equal(t, http.StatusNoContent, removed.Status)
+}
@@ -0,0 +1,108 @@
+package middleware_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+
+	"workout-app/internal/compat"
+	domain "workout-app/internal/domain/clientversion"
+	"workout-app/internal/handler/middleware"
+	repo "workout-app/internal/repository/interfaces"
+	clientversionuc "workout-app/internal/usecase/clientversion"
+	"workout-app/pkg/logger"
+)
+
+type fakeClientVersionRepo struct {
+	policies map[domain.Platform]*domain.Policy
+}
+
+func (r *fakeClientVersionRepo) List(context.Context) ([]*domain.Policy, error) {
+	result := make([]*domain.Policy, 0, len(r.policies))
+	for _, p := range r.policies {
+		result = append(result, p)
+	}
+	return result, nil
+}
+
+func (r *fakeClientVersionRepo) Upsert(_ context.Context, p *domain.Policy) error {
+	r.policies[p.Platform] = p
+	return nil
+}
+
+func (r *fakeClientVersionRepo) Delete(_ context.Context, platform domain.Platform) error {
+	if _, ok := r.policies[platform]; !ok {
+		return repo.ErrNotFound
+	}
+	delete(r.policies, platform)
+	return nil
+}
+
+func newClientVersionRouter(svc clientversionuc.Service) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(middleware.MinClientVersion(svc, logger.Default()))
+	r.GET("/ping", func(c *gin.Context) { c.Status(http.StatusOK) })
+	return r
+}
+
+func getPing(r *gin.Engine, platform, version string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/ping", nil)
+	if platform != "" {
+		req.Header.Set(domain.HeaderPlatform, platform)
+	}
+	if version != "" {
+		req.Header.Set(compat.HeaderAppVersion, version)
+	}
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestMinClientVersion_OutdatedClientGetsUpgradeRequired(t *testing.T) {
+	svc := clientversionuc.NewService(&fakeClientVersionRepo{policies: map[domain.Platform]*domain.Policy{}}, time.Minute)
+	_, err := svc.SetPolicy(context.Background(), domain.PlatformIOS, "2.3", "https://apps.apple.com/app/id1")
+	require.NoError(t, err)
+	r := newClientVersionRouter(svc)
+
+	w := getPing(r, "iOS", "2.2.9")
+	require.Equal(t, http.StatusUpgradeRequired, w.Code)
+	require.JSONEq(t, `{"error":{"code":"upgrade_required","message":"This app version is no longer supported. Please update the app.",
+		"details":{"platform":"ios","min_version":"2.3.0","store_url":"https://apps.apple.com/app/id1"}}}`, w.Body.String())
+
+	require.Equal(t, http.StatusOK, getPing(r, "ios", "2.3.0").Code)
+	require.Equal(t, http.StatusOK, getPing(r, "android", "1.0.0").Code)
+	require.Equal(t, http.StatusOK, getPing(r, "", "1.0.0").Code)
+}
+
+func TestMinClientVersion_PolicyChangesApplyWithoutRestart(t *testing.T) {
+	svc := clientversionuc.NewService(&fakeClientVersionRepo{policies: map[domain.Platform]*domain.Policy{}}, time.Hour)
+	r := newClientVersionRouter(svc)
+	ctx := context.Background()
+
+	require.Equal(t, http.StatusOK, getPing(r, "android", "1.0.0").Code)
+
+	_, err := svc.SetPolicy(ctx, domain.PlatformAndroid, "1.1.0", "https://play.google.com/store/apps/details?id=app")
+	require.NoError(t, err)
+	require.Equal(t, http.StatusUpgradeRequired, getPing(r, "android", "1.0.0").Code)
+
+	require.NoError(t, svc.DeletePolicy(ctx, domain.PlatformAndroid))
+	require.Equal(t, http.StatusOK, getPing(r, "android", "1.0.0").Code)
+}
+
+func TestMinClientVersion_RejectsInvalidPolicy(t *testing.T) {
+	svc := clientversionuc.NewService(&fakeClientVersionRepo{policies: map[domain.Platform]*domain.Policy{}}, time.Minute)
+	ctx := context.Background()
+
+	_, err := svc.SetPolicy(ctx, "windows", "1.0.0", "https://example.com")
+	require.ErrorIs(t, err, clientversionuc.ErrInvalidPlatform)
+	_, err = svc.SetPolicy(ctx, domain.PlatformIOS, "latest", "https://example.com")
+	require.ErrorIs(t, err, clientversionuc.ErrInvalidVersion)
+	_, err = svc.SetPolicy(ctx, domain.PlatformIOS, "1.0.0", "not a url")
+	require.ErrorIs(t, err, clientversionuc.ErrInvalidStoreURL)
+}
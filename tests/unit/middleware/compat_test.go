@@ -0,0 +1,73 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+
+	"workout-app/internal/compat"
+	"workout-app/internal/handler/middleware"
+	"workout-app/pkg/logger"
+)
+
+func newCompatRouter() *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	registry := compat.NewRegistry(compat.Rule{
+		Method:   http.MethodPost,
+		Route:    "/items/:id",
+		Before:   compat.Version{Major: 2},
+		Request:  []compat.FieldRename{{Path: "fat", To: "body_fat_percent"}},
+		Response: []compat.FieldRename{{Path: "items.body_fat_percent", To: "fat"}},
+	})
+
+	r := gin.New()
+	r.Use(middleware.Compat(registry, logger.Default()))
+	r.POST("/items/:id", func(c *gin.Context) {
+		var req map[string]any
+		_ = c.ShouldBindJSON(&req)
+		c.JSON(http.StatusOK, gin.H{"items": []gin.H{{"body_fat_percent": req["body_fat_percent"]}}})
+	})
+	return r
+}
+
+func postItem(r *gin.Engine, version, body string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/items/1", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	if version != "" {
+		req.Header.Set(compat.HeaderAppVersion, version)
+	}
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestCompat_LegacyClientGetsFieldsRenamedBothWays(t *testing.T) {
+	w := postItem(newCompatRouter(), "1.9.3", `{"fat":12.5}`)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	require.JSONEq(t, `{"items":[{"fat":12.5}]}`, w.Body.String())
+}
+
+func TestCompat_CurrentClientIsUntouched(t *testing.T) {
+	r := newCompatRouter()
+
+	w := postItem(r, "2.0.0", `{"body_fat_percent":12.5}`)
+	require.JSONEq(t, `{"items":[{"body_fat_percent":12.5}]}`, w.Body.String())
+
+	w = postItem(r, "", `{"body_fat_percent":12.5}`)
+	require.JSONEq(t, `{"items":[{"body_fat_percent":12.5}]}`, w.Body.String())
+}
+
+func TestParseVersion(t *testing.T) {
+	v, ok := compat.ParseVersion("v1.4-beta")
+	require.True(t, ok)
+	require.Equal(t, compat.Version{Major: 1, Minor: 4}, v)
+	require.True(t, v.Less(compat.Version{Major: 1, Minor: 4, Patch: 1}))
+
+	_, ok = compat.ParseVersion("latest")
+	require.False(t, ok)
+}
@@ -0,0 +1,85 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+
+	"workout-app/internal/compat"
+	"workout-app/internal/handler/middleware"
+	"workout-app/pkg/logger"
+)
+
+const testChangelog = `[
+  {"id": "items-v2", "type": "added", "date": "2026-09-01", "title": "Items v2",
+   "endpoints": [{"method": "GET", "route": "/v2/items/:id"}]},
+  {"id": "items-v1", "type": "deprecated", "date": "2026-10-01", "title": "Items v1",
+   "endpoints": [{"method": "get", "route": "/v1/items/:id"}], "sunset": "2027-04-01",
+   "replacement": "GET /v2/items/:id"}
+]`
+
+func newDeprecationRouter(t *testing.T) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	changelog, err := compat.ParseChangelog([]byte(testChangelog))
+	require.NoError(t, err)
+
+	r := gin.New()
+	r.Use(middleware.Deprecation(changelog, logger.Default()))
+	ok := func(c *gin.Context) { c.Status(http.StatusOK) }
+	r.GET("/v1/items/:id", ok)
+	r.GET("/v2/items/:id", ok)
+	return r
+}
+
+func TestDeprecation_FlaggedRouteGetsHeaders(t *testing.T) {
+	w := httptest.NewRecorder()
+	newDeprecationRouter(t).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/v1/items/42", nil))
+
+	require.Equal(t, http.StatusOK, w.Code)
+	deprecatedAt := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	require.Equal(t, "@"+strconv.FormatInt(deprecatedAt.Unix(), 10), w.Header().Get("Deprecation"))
+	require.Equal(t, "Thu, 01 Apr 2027 00:00:00 GMT", w.Header().Get("Sunset"))
+	require.Equal(t, `</api/v1/meta/changelog>; rel="deprecation"; type="application/json"`, w.Header().Get("Link"))
+}
+
+func TestDeprecation_OtherRoutesAreUntouched(t *testing.T) {
+	w := httptest.NewRecorder()
+	newDeprecationRouter(t).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/v2/items/42", nil))
+
+	require.Empty(t, w.Header().Get("Deprecation"))
+	require.Empty(t, w.Header().Get("Sunset"))
+}
+
+func TestParseChangelog(t *testing.T) {
+	changelog, err := compat.ParseChangelog([]byte(testChangelog))
+	require.NoError(t, err)
+
+	entries := changelog.Entries(time.Time{})
+	require.Len(t, entries, 2)
+	require.Equal(t, "items-v1", entries[0].ID)
+	require.Len(t, changelog.Entries(time.Date(2026, 9, 15, 0, 0, 0, 0, time.UTC)), 1)
+
+	invalid := []string{
+		`[{"id": "x", "type": "renamed", "date": "2026-10-01", "title": "X"}]`,
+		`[{"id": "x", "type": "added", "date": "01.10.2026", "title": "X"}]`,
+		`[{"id": "x", "type": "deprecated", "date": "2026-10-01", "title": "X"}]`,
+		`[{"id": "x", "type": "added", "date": "2026-10-01", "title": "X", "sunset": "2027-01-01"}]`,
+		`[{"id": "x", "type": "deprecated", "date": "2026-10-01", "title": "X",
+		   "endpoints": [{"method": "GET", "route": "/a"}], "sunset": "2026-09-01"}]`,
+		`[{"id": "x", "type": "added", "date": "2026-10-01", "title": "X"},
+		  {"id": "x", "type": "added", "date": "2026-10-02", "title": "Y"}]`,
+	}
+	for _, data := range invalid {
+		_, err := compat.ParseChangelog([]byte(data))
+		require.Error(t, err, data)
+	}
+}
+
+func TestMustLoadChangelog_EmbeddedChangelogIsValid(t *testing.T) {
+	require.NotPanics(t, func() { compat.MustLoadChangelog() })
+}
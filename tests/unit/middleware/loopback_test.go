@@ -0,0 +1,45 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+
+	"workout-app/internal/handler/middleware"
+)
+
+func TestLoopbackOnly(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/playground", middleware.LoopbackOnly(), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	cases := []struct {
+		name       string
+		remoteAddr string
+		forwarded  string
+		want       int
+	}{
+		{name: "ipv4 loopback", remoteAddr: "127.0.0.1:51000", want: http.StatusOK},
+		{name: "ipv6 loopback", remoteAddr: "[::1]:51000", want: http.StatusOK},
+		{name: "remote", remoteAddr: "203.0.113.7:51000", want: http.StatusNotFound},
+		// X-Forwarded-For задаёт клиент: он не делает запрос локальным.
+		{name: "forged forwarded header", remoteAddr: "203.0.113.7:51000", forwarded: "127.0.0.1", want: http.StatusNotFound},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/playground", nil)
+			req.RemoteAddr = tc.remoteAddr
+			if tc.forwarded != "" {
+				req.Header.Set("X-Forwarded-For", tc.forwarded)
+			}
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+			require.Equal(t, tc.want, w.Code)
+		})
+	}
+}
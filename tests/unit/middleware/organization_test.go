@@ -0,0 +1,71 @@
+package middleware_test
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"workout-app/internal/domain/organization"
+	"workout-app/internal/domain/user"
+	"workout-app/internal/handler/middleware"
+	"workout-app/pkg/logger"
+)
+
+// fakeOrgRoles возвращает роль пользователя из карты; отсутствующий в ней не состоит в организации.
+type fakeOrgRoles map[uuid.UUID]organization.Role
+
+func (f fakeOrgRoles) MemberRole(_ context.Context, _, userID uuid.UUID) (organization.Role, bool, error) {
+	role, ok := f[userID]
+	return role, ok, nil
+}
+
+func serveOrgRole(roles fakeOrgRoles, userID uuid.UUID, globalRole user.Role, path string) *httptest.ResponseRecorder {
+	gin.SetMode(gin.TestMode)
+	log := logger.New(io.Discard, slog.LevelError, logger.FormatJSON)
+
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		c.Set(middleware.ContextUserIDKey, userID.String())
+		c.Set(middleware.ContextUserRoleKey, string(globalRole))
+	})
+	r.GET("/organizations/:id/members",
+		middleware.RequireOrgRole(roles, log, organization.RoleOwner, organization.RoleManager),
+		func(c *gin.Context) { c.String(http.StatusOK, string(middleware.OrgRoleFromContext(c))) },
+	)
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+	return w
+}
+
+func TestRequireOrgRole(t *testing.T) {
+	manager, staff, outsider := uuid.New(), uuid.New(), uuid.New()
+	roles := fakeOrgRoles{manager: organization.RoleManager, staff: organization.RoleStaff}
+	path := "/organizations/" + uuid.NewString() + "/members"
+
+	w := serveOrgRole(roles, manager, user.RoleUser, path)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, "manager", w.Body.String())
+
+	w = serveOrgRole(roles, staff, user.RoleUser, path)
+	require.Equal(t, http.StatusForbidden, w.Code)
+
+	w = serveOrgRole(roles, outsider, user.RoleUser, path)
+	require.Equal(t, http.StatusNotFound, w.Code)
+	require.Contains(t, w.Body.String(), "organization_not_found")
+
+	// Администратор платформы проходит проверку с правами владельца.
+	w = serveOrgRole(roles, outsider, user.RoleAdmin, path)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, "owner", w.Body.String())
+
+	w = serveOrgRole(roles, manager, user.RoleUser, "/organizations/not-a-uuid/members")
+	require.Equal(t, http.StatusBadRequest, w.Code)
+}
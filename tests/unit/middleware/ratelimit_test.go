@@ -0,0 +1,192 @@
+package middleware_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+
+	"workout-app/internal/handler/middleware"
+	"workout-app/internal/handler/response"
+	"workout-app/pkg/emailaddr"
+	"workout-app/pkg/logger"
+	"workout-app/pkg/ratelimit"
+)
+
+func newLimitedRouter(limit int) *gin.Engine {
+	return newEmailLimitedRouter(limit, emailaddr.Policy{})
+}
+
+func newEmailLimitedRouter(limit int, emails emailaddr.Policy) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	limiter := ratelimit.NewMemoryLimiter(limit, time.Minute)
+	r.POST("/login",
+		middleware.RateLimit(limiter, middleware.ByJSONField("login", "email", emails.Canonical), logger.Default()),
+		func(c *gin.Context) {
+			body, _ := io.ReadAll(c.Request.Body)
+			c.String(http.StatusOK, string(body))
+		},
+	)
+	return r
+}
+
+func post(r *gin.Engine, body string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/login", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestRateLimit_ByEmailNormalizesAndKeepsBody(t *testing.T) {
+	r := newLimitedRouter(2)
+
+	body := `{"email":"User@Example.com","password":"x"}`
+	w := post(r, body)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, body, w.Body.String())
+
+	require.Equal(t, http.StatusOK, post(r, `{"email":" user@example.com "}`).Code)
+
+	w = post(r, `{"email":"USER@example.com"}`)
+	require.Equal(t, http.StatusTooManyRequests, w.Code)
+	require.NotEmpty(t, w.Header().Get("Retry-After"))
+
+	// Другой email считается отдельно.
+	require.Equal(t, http.StatusOK, post(r, `{"email":"other@example.com"}`).Code)
+}
+
+func TestRateLimit_ByEmailUsesCanonicalForm(t *testing.T) {
+	r := newEmailLimitedRouter(2, emailaddr.Policy{FoldGmail: true, FoldPlusTags: true})
+
+	require.Equal(t, http.StatusOK, post(r, `{"email":"john.doe@gmail.com"}`).Code)
+	require.Equal(t, http.StatusOK, post(r, `{"email":"JohnDoe+1@googlemail.com"}`).Code)
+	// Ещё одно написание того же ящика не даёт нового лимита.
+	require.Equal(t, http.StatusTooManyRequests, post(r, `{"email":"j.o.h.n.doe+2@gmail.com"}`).Code)
+
+	require.Equal(t, http.StatusOK, post(r, `{"email":"user+a@example.com"}`).Code)
+	require.Equal(t, http.StatusOK, post(r, `{"email":"user+b@example.com"}`).Code)
+	require.Equal(t, http.StatusTooManyRequests, post(r, `{"email":"user@example.com"}`).Code)
+}
+
+func TestRateLimit_ByEmailSkipsRequestsWithoutEmail(t *testing.T) {
+	r := newLimitedRouter(1)
+
+	for i := 0; i < 3; i++ {
+		require.Equal(t, http.StatusOK, post(r, `{"password":"x"}`).Code)
+		require.Equal(t, http.StatusOK, post(r, `not json`).Code)
+	}
+}
+
+func decodeRetry(t *testing.T, w *httptest.ResponseRecorder) *response.RetryHint {
+	t.Helper()
+	var body struct {
+		Error response.ErrorBody `json:"error"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	require.NotNil(t, body.Error.Retry)
+	require.Equal(t, w.Header().Get("Retry-After"), strconv.Itoa(body.Error.Retry.AfterSeconds))
+	return body.Error.Retry
+}
+
+func TestRateLimit_RetryHintMatchesHeader(t *testing.T) {
+	r := newLimitedRouter(1)
+	require.Equal(t, http.StatusOK, post(r, `{"email":"user@example.com"}`).Code)
+
+	w := post(r, `{"email":"user@example.com"}`)
+	require.Equal(t, http.StatusTooManyRequests, w.Code)
+	hint := decodeRetry(t, w)
+	require.Equal(t, response.RetryFixed, hint.Strategy)
+	require.Positive(t, hint.AfterSeconds)
+	require.LessOrEqual(t, hint.AfterSeconds, 60)
+}
+
+func TestResponseError_AddsDefaultRetryHintFor429And503(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/:status", func(c *gin.Context) {
+		status, _ := strconv.Atoi(c.Param("status"))
+		response.Error(c, status, "failed", "failed", nil)
+	})
+
+	cases := map[int]time.Duration{
+		http.StatusTooManyRequests:    response.DefaultRateLimitRetryAfter,
+		http.StatusServiceUnavailable: response.DefaultUnavailableRetryAfter,
+	}
+	for status, want := range cases {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/"+strconv.Itoa(status), nil))
+		hint := decodeRetry(t, w)
+		require.Equal(t, response.RetryExponential, hint.Strategy)
+		require.Equal(t, int(want.Seconds()), hint.AfterSeconds)
+	}
+
+	// Остальные ошибки подсказку не получают.
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/400", nil))
+	require.Empty(t, w.Header().Get("Retry-After"))
+	require.NotContains(t, w.Body.String(), "retry")
+}
+
+func TestRateLimit_ByUserID(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	limiter := ratelimit.NewMemoryLimiter(1, time.Minute)
+	r := gin.New()
+	r.POST("/change-password",
+		func(c *gin.Context) {
+			if id := c.GetHeader("X-Test-User"); id != "" {
+				c.Set(middleware.ContextUserIDKey, id)
+			}
+		},
+		middleware.RateLimit(limiter, middleware.ByUserID("change_password"), logger.Default()),
+		func(c *gin.Context) { c.Status(http.StatusOK) },
+	)
+	send := func(userID string) int {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/change-password", nil)
+		req.Header.Set("X-Test-User", userID)
+		r.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	require.Equal(t, http.StatusOK, send("u1"))
+	require.Equal(t, http.StatusTooManyRequests, send("u1"))
+	// Лимит считается по пользователю, а не по IP.
+	require.Equal(t, http.StatusOK, send("u2"))
+	// Без пользователя в контексте запрос не ограничивается.
+	require.Equal(t, http.StatusOK, send(""))
+	require.Equal(t, http.StatusOK, send(""))
+}
+
+func TestRateLimit_ByClientIPIgnoresSpoofedForwardedFor(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	limiter := ratelimit.NewMemoryLimiter(1, time.Minute)
+	r := gin.New()
+	// Как в NewServer при пустом SERVER_TRUSTED_PROXIES.
+	require.NoError(t, r.SetTrustedProxies(nil))
+	r.POST("/login",
+		middleware.RateLimit(limiter, middleware.ByClientIP("login"), logger.Default()),
+		func(c *gin.Context) { c.Status(http.StatusOK) },
+	)
+	send := func(remoteAddr, forwardedFor string) int {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/login", nil)
+		req.RemoteAddr = remoteAddr
+		req.Header.Set("X-Forwarded-For", forwardedFor)
+		r.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	require.Equal(t, http.StatusOK, send("203.0.113.7:40000", "198.51.100.1"))
+	// Подменённый X-Forwarded-For не даёт нового ключа: лимит считается по адресу соединения.
+	require.Equal(t, http.StatusTooManyRequests, send("203.0.113.7:40001", "198.51.100.2"))
+	require.Equal(t, http.StatusOK, send("203.0.113.8:40000", "198.51.100.2"))
+}
@@ -0,0 +1,86 @@
+package middleware_test
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"workout-app/internal/domain/region"
+	"workout-app/internal/handler/middleware"
+	"workout-app/pkg/logger"
+)
+
+// fakeRegionResolver возвращает фиксированный регион и считает обращения.
+type fakeRegionResolver struct {
+	region region.Region
+	calls  int
+}
+
+func (f *fakeRegionResolver) GetRegion(_ context.Context, _ uuid.UUID) (region.Region, error) {
+	f.calls++
+	return f.region, nil
+}
+
+func newRegionRouter(resolver middleware.RegionResolver, policy region.Policy) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	log := logger.New(io.Discard, slog.LevelError, logger.FormatJSON)
+
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		c.Set(middleware.ContextUserIDKey, uuid.New().String())
+	})
+	r.GET("/experiments", middleware.RegionFeature(region.FeatureExperiments, resolver, policy, log), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+	return r
+}
+
+func TestRegionFeature_BlocksDisabledRegion(t *testing.T) {
+	policy := region.NewPolicy(map[region.Region][]region.Feature{
+		region.RegionEU: {region.FeatureExperiments},
+	})
+
+	w := httptest.NewRecorder()
+	newRegionRouter(&fakeRegionResolver{region: region.RegionEU}, policy).
+		ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/experiments", nil))
+	require.Equal(t, http.StatusForbidden, w.Code)
+	require.Contains(t, w.Body.String(), "feature_unavailable_in_region")
+
+	w = httptest.NewRecorder()
+	newRegionRouter(&fakeRegionResolver{region: region.RegionGlobal}, policy).
+		ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/experiments", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestRegionFeature_SkipsLookupWhenAllowedEverywhere(t *testing.T) {
+	resolver := &fakeRegionResolver{region: region.RegionEU}
+
+	w := httptest.NewRecorder()
+	newRegionRouter(resolver, region.Policy{}).
+		ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/experiments", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Zero(t, resolver.calls)
+}
+
+func TestFromCountry(t *testing.T) {
+	cases := map[string]region.Region{
+		"de":  region.RegionEU,
+		" NO": region.RegionEU,
+		"US":  region.RegionGlobal,
+		"XX":  region.RegionGlobal,
+		"":    region.RegionGlobal,
+		"D1":  region.RegionGlobal,
+	}
+	for raw, want := range cases {
+		require.Equal(t, want, region.FromCountry(region.NormalizeCountry(raw)), raw)
+	}
+	require.Equal(t, "", region.NormalizeCountry("T1"))
+	require.Equal(t, "FR", region.NormalizeCountry("fr"))
+}
@@ -0,0 +1,86 @@
+package middleware_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+
+	"workout-app/internal/handler/middleware"
+	"workout-app/pkg/logger"
+)
+
+// decodeLines разбирает JSON-записи лога, по одной на строку.
+func decodeLines(t *testing.T, buf *bytes.Buffer) []map[string]any {
+	t.Helper()
+	var records []map[string]any
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		var rec map[string]any
+		require.NoError(t, json.Unmarshal([]byte(line), &rec))
+		records = append(records, rec)
+	}
+	return records
+}
+
+func TestRequestLogger_WritesJSONWithRequestID(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	var buf bytes.Buffer
+	log := logger.New(&buf, slog.LevelInfo, logger.FormatJSON)
+
+	r := gin.New()
+	r.Use(middleware.RequestID(log), middleware.Logger(log))
+	r.GET("/items", func(c *gin.Context) {
+		middleware.LoggerFromContext(c, log).Info("handler_called", map[string]any{"items": 3})
+		c.Status(http.StatusNotFound)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/items?page=2", nil)
+	req.Header.Set(middleware.HeaderRequestID, "req-123")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	require.Equal(t, "req-123", w.Header().Get(middleware.HeaderRequestID))
+
+	records := decodeLines(t, &buf)
+	require.Len(t, records, 2)
+
+	require.Equal(t, "handler_called", records[0]["msg"])
+	require.Equal(t, "req-123", records[0]["request_id"])
+	require.EqualValues(t, 3, records[0]["items"])
+	source, ok := records[0]["source"].(map[string]any)
+	require.True(t, ok, "запись должна содержать место вызова")
+	require.Contains(t, source["file"], "request_logger_test.go")
+
+	require.Equal(t, "http_request", records[1]["msg"])
+	require.Equal(t, "WARN", records[1]["level"])
+	require.Equal(t, "req-123", records[1]["request_id"])
+	require.Equal(t, "/items?page=2", records[1]["path"])
+	require.EqualValues(t, http.StatusNotFound, records[1]["status"])
+}
+
+func TestRequestID_ReplacesInvalidHeader(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	var buf bytes.Buffer
+	log := logger.New(&buf, slog.LevelWarn, logger.FormatJSON)
+
+	r := gin.New()
+	r.Use(middleware.RequestID(log), middleware.Logger(log))
+	r.GET("/ok", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	req := httptest.NewRequest(http.MethodGet, "/ok", nil)
+	req.Header.Set(middleware.HeaderRequestID, "bad id with spaces")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	id := w.Header().Get(middleware.HeaderRequestID)
+	require.NotEmpty(t, id)
+	require.NotEqual(t, "bad id with spaces", id)
+	// Успешный запрос логируется на уровне info и отсекается уровнем warn.
+	require.Empty(t, buf.String())
+}
@@ -0,0 +1,49 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+
+	"workout-app/internal/handler/middleware"
+	"workout-app/pkg/servertiming"
+)
+
+func TestServerTiming_ReportsTrackedCategories(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(middleware.ServerTiming())
+	r.GET("/items", func(c *gin.Context) {
+		timings := servertiming.FromContext(c.Request.Context())
+		timings.Add(servertiming.DB, 5*time.Millisecond)
+		timings.Add(servertiming.DB, 7*time.Millisecond)
+		timings.Add(servertiming.External, 40*time.Millisecond)
+		c.JSON(http.StatusOK, gin.H{"ok": true})
+	})
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/items", nil))
+
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Regexp(t,
+		regexp.MustCompile(`^db;dur=12\.0;desc="2", external;dur=40\.0;desc="1", total;dur=\d+\.\d$`),
+		w.Header().Get(servertiming.HeaderName))
+}
+
+func TestServerTiming_ResponseWithoutBody(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(middleware.ServerTiming())
+	r.DELETE("/items/1", func(c *gin.Context) { c.Status(http.StatusNoContent) })
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodDelete, "/items/1", nil))
+
+	require.Equal(t, http.StatusNoContent, w.Code)
+	require.Regexp(t, `^total;dur=\d+\.\d$`, w.Header().Get(servertiming.HeaderName))
+}
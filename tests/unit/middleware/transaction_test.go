@@ -0,0 +1,90 @@
+package middleware_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+
+	"workout-app/internal/handler/middleware"
+	"workout-app/pkg/logger"
+)
+
+type txCtxKey struct{}
+
+type fakeTransactor struct {
+	commits   int
+	rollbacks int
+	commitErr error
+}
+
+func (f *fakeTransactor) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if err := fn(context.WithValue(ctx, txCtxKey{}, true)); err != nil {
+		f.rollbacks++
+		return err
+	}
+	if f.commitErr != nil {
+		f.rollbacks++
+		return f.commitErr
+	}
+	f.commits++
+	return nil
+}
+
+func newTxRouter(tx *fakeTransactor, status int) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	handler := func(c *gin.Context) {
+		_, inTx := c.Request.Context().Value(txCtxKey{}).(bool)
+		c.JSON(status, gin.H{"in_tx": inTx})
+	}
+	r.Use(middleware.Transaction(tx, logger.Default()))
+	r.POST("/", handler)
+	r.GET("/", handler)
+	return r
+}
+
+func serve(r *gin.Engine, method string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(method, "/", nil))
+	return w
+}
+
+func TestTransaction_CommitsSuccessfulMutation(t *testing.T) {
+	tx := &fakeTransactor{}
+	w := serve(newTxRouter(tx, http.StatusCreated), http.MethodPost)
+
+	require.Equal(t, http.StatusCreated, w.Code)
+	require.JSONEq(t, `{"in_tx":true}`, w.Body.String())
+	require.Equal(t, 1, tx.commits)
+}
+
+func TestTransaction_RollsBackOnErrorStatus(t *testing.T) {
+	tx := &fakeTransactor{}
+	w := serve(newTxRouter(tx, http.StatusConflict), http.MethodPost)
+
+	require.Equal(t, http.StatusConflict, w.Code)
+	require.Equal(t, 0, tx.commits)
+	require.Equal(t, 1, tx.rollbacks)
+}
+
+func TestTransaction_CommitFailureReturns500(t *testing.T) {
+	tx := &fakeTransactor{commitErr: errors.New("connection reset")}
+	w := serve(newTxRouter(tx, http.StatusOK), http.MethodPost)
+
+	require.Equal(t, http.StatusInternalServerError, w.Code)
+	require.NotContains(t, w.Body.String(), "in_tx")
+}
+
+func TestTransaction_SkipsReadRequests(t *testing.T) {
+	tx := &fakeTransactor{}
+	w := serve(newTxRouter(tx, http.StatusOK), http.MethodGet)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	require.JSONEq(t, `{"in_tx":false}`, w.Body.String())
+	require.Zero(t, tx.commits+tx.rollbacks)
+}
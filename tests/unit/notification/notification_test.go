@@ -0,0 +1,187 @@
+package notification_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	domain "workout-app/internal/domain/notification"
+	repo "workout-app/internal/repository/interfaces"
+	notificationuc "workout-app/internal/usecase/notification"
+)
+
+type fakeNotifications struct {
+	items []*domain.Notification
+	prefs map[uuid.UUID]map[string]domain.Preference
+}
+
+func newFakeNotifications() *fakeNotifications {
+	return &fakeNotifications{prefs: map[uuid.UUID]map[string]domain.Preference{}}
+}
+
+func (f *fakeNotifications) Create(_ context.Context, n *domain.Notification) (bool, error) {
+	f.items = append(f.items, n)
+	return true, nil
+}
+
+func (f *fakeNotifications) List(_ context.Context, userID uuid.UUID, unreadOnly bool, limit, offset int) ([]*domain.Notification, int64, error) {
+	var matched []*domain.Notification
+	for i := len(f.items) - 1; i >= 0; i-- {
+		n := f.items[i]
+		if n.UserID == userID && (!unreadOnly || !n.IsRead()) {
+			matched = append(matched, n)
+		}
+	}
+	total := int64(len(matched))
+	if offset >= len(matched) {
+		return nil, total, nil
+	}
+	matched = matched[offset:]
+	if len(matched) > limit {
+		matched = matched[:limit]
+	}
+	return matched, total, nil
+}
+
+func (f *fakeNotifications) CountUnread(ctx context.Context, userID uuid.UUID) (int64, error) {
+	_, total, err := f.List(ctx, userID, true, 0, 0)
+	return total, err
+}
+
+func (f *fakeNotifications) MarkRead(_ context.Context, userID, id uuid.UUID, at time.Time) error {
+	for _, n := range f.items {
+		if n.ID == id && n.UserID == userID {
+			if n.ReadAt == nil {
+				n.ReadAt = &at
+			}
+			return nil
+		}
+	}
+	return repo.ErrNotFound
+}
+
+func (f *fakeNotifications) MarkAllRead(_ context.Context, userID uuid.UUID, at time.Time) error {
+	for _, n := range f.items {
+		if n.UserID == userID && n.ReadAt == nil {
+			n.ReadAt = &at
+		}
+	}
+	return nil
+}
+
+func (f *fakeNotifications) GetPreferences(_ context.Context, userID uuid.UUID) ([]domain.Preference, error) {
+	var prefs []domain.Preference
+	for _, p := range f.prefs[userID] {
+		prefs = append(prefs, p)
+	}
+	return prefs, nil
+}
+
+func (f *fakeNotifications) SetPreferences(_ context.Context, userID uuid.UUID, prefs []domain.Preference) error {
+	if f.prefs[userID] == nil {
+		f.prefs[userID] = map[string]domain.Preference{}
+	}
+	for _, p := range prefs {
+		f.prefs[userID][p.Type] = p
+	}
+	return nil
+}
+
+func (f *fakeNotifications) ListPushPending(context.Context, int) ([]*domain.Notification, error) {
+	return nil, nil
+}
+
+func (f *fakeNotifications) ClaimPush(context.Context, uuid.UUID) (bool, error) { return false, nil }
+
+func (f *fakeNotifications) ExpirePush(context.Context, time.Time) (int64, error) { return 0, nil }
+
+func (f *fakeNotifications) DeleteByUserID(context.Context, uuid.UUID) error { return nil }
+
+func boolPtr(v bool) *bool { return &v }
+
+func seed(f *fakeNotifications, userID uuid.UUID, n int) {
+	for i := 0; i < n; i++ {
+		f.items = append(f.items, domain.NewNotification(userID, domain.TypeClassBooked, []byte(`{}`), int64(i+1), time.Now().UTC()))
+	}
+}
+
+func TestList_ReturnsNewestFirstWithUnreadCount(t *testing.T) {
+	store := newFakeNotifications()
+	userID := uuid.New()
+	seed(store, userID, 3)
+	seed(store, uuid.New(), 2)
+	svc := notificationuc.NewService(store)
+	ctx := context.Background()
+
+	require.NoError(t, svc.MarkRead(ctx, userID, store.items[0].ID))
+
+	page, err := svc.List(ctx, userID, false, 0, 0)
+	require.NoError(t, err)
+	require.Equal(t, int64(3), page.Total)
+	require.Equal(t, int64(2), page.Unread)
+	require.Equal(t, store.items[2].ID, page.Items[0].ID)
+
+	page, err = svc.List(ctx, userID, true, 0, 0)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), page.Total)
+	require.Len(t, page.Items, 2)
+}
+
+func TestMarkRead_OtherUsersNotificationNotFound(t *testing.T) {
+	store := newFakeNotifications()
+	seed(store, uuid.New(), 1)
+	svc := notificationuc.NewService(store)
+
+	err := svc.MarkRead(context.Background(), uuid.New(), store.items[0].ID)
+	require.ErrorIs(t, err, notificationuc.ErrNotificationNotFound)
+}
+
+func TestMarkAllRead(t *testing.T) {
+	store := newFakeNotifications()
+	userID := uuid.New()
+	seed(store, userID, 2)
+	svc := notificationuc.NewService(store)
+	ctx := context.Background()
+
+	require.NoError(t, svc.MarkAllRead(ctx, userID))
+	page, err := svc.List(ctx, userID, false, 0, 0)
+	require.NoError(t, err)
+	require.Zero(t, page.Unread)
+}
+
+func TestPreferences_DefaultsAndUpdate(t *testing.T) {
+	store := newFakeNotifications()
+	userID := uuid.New()
+	svc := notificationuc.NewService(store)
+	ctx := context.Background()
+
+	prefs, err := svc.GetPreferences(ctx, userID)
+	require.NoError(t, err)
+	require.Equal(t, domain.DefaultPreferences(), prefs)
+
+	prefs, err = svc.UpdatePreferences(ctx, userID, []notificationuc.PreferenceUpdate{{Type: domain.TypeClassBooked, Email: boolPtr(false)}})
+	require.NoError(t, err)
+	require.Len(t, prefs, len(domain.Types))
+	for _, p := range prefs {
+		require.Equal(t, p.Type != domain.TypeClassBooked, p.Email, p.Type)
+		require.True(t, p.Push, p.Type)
+	}
+
+	// Канал, не указанный в запросе, сохраняет прежнее значение.
+	prefs, err = svc.UpdatePreferences(ctx, userID, []notificationuc.PreferenceUpdate{{Type: domain.TypeClassBooked, Push: boolPtr(false)}})
+	require.NoError(t, err)
+	for _, p := range prefs {
+		require.Equal(t, p.Type != domain.TypeClassBooked, p.Email, p.Type)
+		require.Equal(t, p.Type != domain.TypeClassBooked, p.Push, p.Type)
+	}
+}
+
+func TestPreferences_RejectsUnknownType(t *testing.T) {
+	svc := notificationuc.NewService(newFakeNotifications())
+
+	_, err := svc.UpdatePreferences(context.Background(), uuid.New(), []notificationuc.PreferenceUpdate{{Type: "user.registered", Email: boolPtr(false)}})
+	require.ErrorIs(t, err, notificationuc.ErrInvalidType)
+}
@@ -0,0 +1,238 @@
+package nutrition_test
+
+import (
+	"context"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	domain "workout-app/internal/domain/nutrition"
+	userdomain "workout-app/internal/domain/user"
+	repo "workout-app/internal/repository/interfaces"
+	nutritionuc "workout-app/internal/usecase/nutrition"
+)
+
+type fakeUsers struct {
+	repo.UserRepository
+	user *userdomain.User
+}
+
+func (r *fakeUsers) GetByID(_ context.Context, id uuid.UUID) (*userdomain.User, error) {
+	if r.user.ID != id {
+		return nil, repo.ErrNotFound
+	}
+	return r.user, nil
+}
+
+// fakeEntries — in-memory реализация NutritionRepository.
+type fakeEntries struct {
+	repo.NutritionRepository
+	items map[uuid.UUID]*domain.Entry
+}
+
+func (r *fakeEntries) Create(_ context.Context, e *domain.Entry) error {
+	r.items[e.ID] = e
+	return nil
+}
+
+func (r *fakeEntries) Update(_ context.Context, e *domain.Entry) error {
+	if _, ok := r.items[e.ID]; !ok {
+		return repo.ErrNotFound
+	}
+	r.items[e.ID] = e
+	return nil
+}
+
+func (r *fakeEntries) GetByID(_ context.Context, userID, id uuid.UUID) (*domain.Entry, error) {
+	e, ok := r.items[id]
+	if !ok || e.UserID != userID {
+		return nil, repo.ErrNotFound
+	}
+	copied := *e
+	return &copied, nil
+}
+
+func (r *fakeEntries) ListByDate(_ context.Context, userID uuid.UUID, date time.Time) ([]*domain.Entry, error) {
+	var out []*domain.Entry
+	for _, e := range r.items {
+		if e.UserID == userID && e.Date.Equal(date) {
+			out = append(out, e)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].CreatedAt.Before(out[j].CreatedAt) })
+	return out, nil
+}
+
+func (r *fakeEntries) DailyTotals(_ context.Context, userID uuid.UUID, from, to time.Time) ([]*domain.DayTotals, error) {
+	byDate := map[time.Time]*domain.DayTotals{}
+	for _, e := range r.items {
+		if e.UserID != userID || e.Date.Before(from) || e.Date.After(to) {
+			continue
+		}
+		t, ok := byDate[e.Date]
+		if !ok {
+			t = &domain.DayTotals{Date: e.Date}
+			byDate[e.Date] = t
+		}
+		t.Entries++
+		t.Calories += e.Calories
+		t.ProteinG += e.ProteinG
+		t.CarbsG += e.CarbsG
+		t.FatG += e.FatG
+	}
+	out := make([]*domain.DayTotals, 0, len(byDate))
+	for _, t := range byDate {
+		out = append(out, t)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Date.Before(out[j].Date) })
+	return out, nil
+}
+
+func (r *fakeEntries) Delete(_ context.Context, userID, id uuid.UUID) error {
+	e, ok := r.items[id]
+	if !ok || e.UserID != userID {
+		return repo.ErrNotFound
+	}
+	delete(r.items, id)
+	return nil
+}
+
+func newService(user *userdomain.User) (nutritionuc.Service, *fakeEntries) {
+	entries := &fakeEntries{items: map[uuid.UUID]*domain.Entry{}}
+	return nutritionuc.NewService(entries, &fakeUsers{user: user}), entries
+}
+
+func day(s string) *time.Time {
+	t, err := time.Parse("2006-01-02", s)
+	if err != nil {
+		panic(err)
+	}
+	return &t
+}
+
+func oatmeal(date *time.Time) nutritionuc.EntryInput {
+	return nutritionuc.EntryInput{
+		Date:     date,
+		Meal:     domain.MealBreakfast,
+		Name:     " Овсянка ",
+		Calories: 320,
+		ProteinG: 11.54,
+		CarbsG:   48,
+		FatG:     8.2,
+	}
+}
+
+func TestAddEntry_ValidatesAndRounds(t *testing.T) {
+	user := userdomain.NewUser("jane@example.com", "hash", "jane")
+	svc, _ := newService(user)
+	ctx := context.Background()
+
+	entry, err := svc.AddEntry(ctx, user.ID, oatmeal(day("2026-10-01")))
+	require.NoError(t, err)
+	require.Equal(t, "Овсянка", entry.Name)
+	require.Equal(t, 11.5, entry.ProteinG)
+	require.Equal(t, *day("2026-10-01"), entry.Date)
+
+	cases := []struct {
+		mutate func(*nutritionuc.EntryInput)
+		err    error
+	}{
+		{func(in *nutritionuc.EntryInput) { in.Meal = "brunch" }, nutritionuc.ErrInvalidMeal},
+		{func(in *nutritionuc.EntryInput) { in.Name = " " }, nutritionuc.ErrInvalidName},
+		{func(in *nutritionuc.EntryInput) { in.Calories = -5 }, nutritionuc.ErrInvalidCalories},
+		{func(in *nutritionuc.EntryInput) { in.FatG = 1500 }, nutritionuc.ErrInvalidMacros},
+		{func(in *nutritionuc.EntryInput) { in.Date = day("2999-01-01") }, nutritionuc.ErrInvalidDate},
+	}
+	for _, tc := range cases {
+		input := oatmeal(nil)
+		tc.mutate(&input)
+		_, err := svc.AddEntry(ctx, user.ID, input)
+		require.ErrorIs(t, err, tc.err)
+	}
+}
+
+func TestAddEntry_DefaultsToTodayInUserTimezone(t *testing.T) {
+	user := userdomain.NewUser("jane@example.com", "hash", "jane")
+	user.Timezone = "Pacific/Kiritimati" // UTC+14: местная дата почти всегда опережает UTC
+	svc, _ := newService(user)
+
+	entry, err := svc.AddEntry(context.Background(), user.ID, oatmeal(nil))
+	require.NoError(t, err)
+	y, m, d := time.Now().In(user.Location()).Date()
+	require.Equal(t, time.Date(y, m, d, 0, 0, 0, 0, time.UTC), entry.Date)
+}
+
+func TestDiary_TotalsAgainstTarget(t *testing.T) {
+	user := userdomain.NewUser("jane@example.com", "hash", "jane")
+	target := 2000
+	user.CalorieTarget = &target
+	svc, _ := newService(user)
+	ctx := context.Background()
+
+	_, err := svc.AddEntry(ctx, user.ID, oatmeal(day("2026-10-01")))
+	require.NoError(t, err)
+	dinner := oatmeal(day("2026-10-01"))
+	dinner.Meal, dinner.Name, dinner.Calories, dinner.ProteinG = domain.MealDinner, "Курица с рисом", 650, 45.1
+	_, err = svc.AddEntry(ctx, user.ID, dinner)
+	require.NoError(t, err)
+	_, err = svc.AddEntry(ctx, user.ID, oatmeal(day("2026-10-02")))
+	require.NoError(t, err)
+
+	diary, err := svc.Diary(ctx, user.ID, day("2026-10-01"))
+	require.NoError(t, err)
+	require.Len(t, diary.Entries, 2)
+	require.Equal(t, 2, diary.Totals.Entries)
+	require.Equal(t, 970, diary.Totals.Calories)
+	require.Equal(t, 56.6, diary.Totals.ProteinG)
+	require.Equal(t, 1030, *diary.Totals.Remaining)
+	require.Equal(t, 2000, *diary.CalorieTarget)
+}
+
+func TestTotals_FillsEmptyDays(t *testing.T) {
+	user := userdomain.NewUser("jane@example.com", "hash", "jane")
+	svc, _ := newService(user)
+	ctx := context.Background()
+
+	_, err := svc.AddEntry(ctx, user.ID, oatmeal(day("2026-10-01")))
+	require.NoError(t, err)
+	_, err = svc.AddEntry(ctx, user.ID, oatmeal(day("2026-10-03")))
+	require.NoError(t, err)
+
+	totals, err := svc.Totals(ctx, user.ID, day("2026-10-01"), day("2026-10-03"))
+	require.NoError(t, err)
+	require.Nil(t, totals.CalorieTarget)
+	require.Len(t, totals.Days, 3)
+	require.Equal(t, []int{320, 0, 320}, []int{totals.Days[0].Calories, totals.Days[1].Calories, totals.Days[2].Calories})
+	require.Equal(t, *day("2026-10-02"), totals.Days[1].Date)
+	require.Nil(t, totals.Days[0].Remaining)
+
+	_, err = svc.Totals(ctx, user.ID, day("2026-10-03"), day("2026-10-01"))
+	require.ErrorIs(t, err, nutritionuc.ErrInvalidPeriod)
+	_, err = svc.Totals(ctx, user.ID, day("2026-01-01"), day("2026-10-01"))
+	require.ErrorIs(t, err, nutritionuc.ErrInvalidPeriod)
+}
+
+func TestUpdateAndDeleteEntry(t *testing.T) {
+	user := userdomain.NewUser("jane@example.com", "hash", "jane")
+	svc, entries := newService(user)
+	ctx := context.Background()
+	entry, err := svc.AddEntry(ctx, user.ID, oatmeal(day("2026-10-01")))
+	require.NoError(t, err)
+
+	// Без даты запись остаётся в прежнем дне.
+	input := oatmeal(nil)
+	input.Calories = 280
+	updated, err := svc.UpdateEntry(ctx, user.ID, entry.ID, input)
+	require.NoError(t, err)
+	require.Equal(t, 280, updated.Calories)
+	require.Equal(t, *day("2026-10-01"), entries.items[entry.ID].Date)
+
+	_, err = svc.UpdateEntry(ctx, uuid.New(), entry.ID, input)
+	require.ErrorIs(t, err, nutritionuc.ErrEntryNotFound)
+
+	require.NoError(t, svc.DeleteEntry(ctx, user.ID, entry.ID))
+	require.ErrorIs(t, svc.DeleteEntry(ctx, user.ID, entry.ID), nutritionuc.ErrEntryNotFound)
+}
@@ -0,0 +1,333 @@
+package oauth_test
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"workout-app/internal/config"
+	domain "workout-app/internal/domain/user"
+	"workout-app/internal/events"
+	repo "workout-app/internal/repository/interfaces"
+	oauthuc "workout-app/internal/usecase/oauth"
+	jwtsvc "workout-app/pkg/jwt"
+	"workout-app/pkg/oidc"
+	"workout-app/pkg/usernamefilter"
+)
+
+// jwksServer раздаёт публичный ключ key под идентификатором kid.
+func jwksServer(t *testing.T, kid string, key *rsa.PublicKey) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]any{"keys": []map[string]string{{
+			"kid": kid,
+			"kty": "RSA",
+			"use": "sig",
+			"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		}}})
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func signIDToken(t *testing.T, key *rsa.PrivateKey, kid string, claims jwt.MapClaims) string {
+	t.Helper()
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = kid
+	raw, err := token.SignedString(key)
+	require.NoError(t, err)
+	return raw
+}
+
+func TestVerifier_ChecksSignatureAudienceAndAppleEmailVerified(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	srv := jwksServer(t, "k1", &key.PublicKey)
+
+	verifier := oidc.NewVerifier(oidc.Config{
+		Issuers:   []string{"https://appleid.apple.com"},
+		Audiences: []string{"com.example.workout"},
+		JWKSURL:   srv.URL,
+	}, srv.Client())
+
+	now := time.Now()
+	claims := jwt.MapClaims{
+		"iss":            "https://appleid.apple.com",
+		"aud":            "com.example.workout",
+		"sub":            "001234.abcdef",
+		"email":          "user@privaterelay.appleid.com",
+		"email_verified": "true",
+		"iat":            now.Unix(),
+		"exp":            now.Add(time.Hour).Unix(),
+	}
+	identity, err := verifier.Verify(context.Background(), signIDToken(t, key, "k1", claims))
+	require.NoError(t, err)
+	require.Equal(t, "001234.abcdef", identity.Subject)
+	require.True(t, identity.EmailVerified)
+
+	claims["aud"] = "com.other.app"
+	_, err = verifier.Verify(context.Background(), signIDToken(t, key, "k1", claims))
+	require.ErrorIs(t, err, oidc.ErrInvalidToken)
+
+	other, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	claims["aud"] = "com.example.workout"
+	_, err = verifier.Verify(context.Background(), signIDToken(t, other, "k1", claims))
+	require.ErrorIs(t, err, oidc.ErrInvalidToken, "подпись чужим ключом")
+
+	claims["exp"] = now.Add(-time.Minute).Unix()
+	_, err = verifier.Verify(context.Background(), signIDToken(t, key, "k1", claims))
+	require.ErrorIs(t, err, oidc.ErrInvalidToken, "истёкший токен")
+}
+
+// fakeVerifier принимает токен, равный ключу в identities.
+type fakeVerifier struct {
+	identities map[string]*oidc.Identity
+}
+
+func (v *fakeVerifier) Verify(_ context.Context, raw string) (*oidc.Identity, error) {
+	identity, ok := v.identities[raw]
+	if !ok {
+		return nil, oidc.ErrInvalidToken
+	}
+	return identity, nil
+}
+
+type fakeTx struct{}
+
+func (fakeTx) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+// fakeUsernameFilter проверяет username по заданным правилам; нулевое значение ничего не запрещает.
+type fakeUsernameFilter struct {
+	matcher *usernamefilter.Matcher
+}
+
+func (f fakeUsernameFilter) Check(_ context.Context, username string) error {
+	return f.matcher.Check(username)
+}
+
+// fakeUsernameHistory — история смен username без зарезервированных имён.
+type fakeUsernameHistory struct {
+	repo.UsernameHistoryRepository
+}
+
+func (fakeUsernameHistory) IsReserved(context.Context, string, uuid.UUID, time.Time) (bool, error) {
+	return false, nil
+}
+
+type fakeUsers struct {
+	repo.UserRepository
+	byID map[uuid.UUID]*domain.User
+}
+
+func (r *fakeUsers) Create(_ context.Context, u *domain.User) error {
+	for _, existing := range r.byID {
+		if existing.Username == u.Username {
+			return repo.ErrUsernameExists
+		}
+	}
+	r.byID[u.ID] = u
+	return nil
+}
+
+func (r *fakeUsers) GetByID(_ context.Context, id uuid.UUID) (*domain.User, error) {
+	if u, ok := r.byID[id]; ok {
+		return u, nil
+	}
+	return nil, repo.ErrNotFound
+}
+
+func (r *fakeUsers) GetByEmail(_ context.Context, email string) (*domain.User, error) {
+	for _, u := range r.byID {
+		if u.Email == email {
+			return u, nil
+		}
+	}
+	return nil, repo.ErrNotFound
+}
+
+func (r *fakeUsers) MarkEmailVerified(_ context.Context, id uuid.UUID) error {
+	stored := *r.byID[id]
+	stored.IsEmailVerified = true
+	r.byID[id] = &stored
+	return nil
+}
+
+func (r *fakeUsers) UpdatePassword(_ context.Context, id uuid.UUID, hash string, validAfter time.Time) error {
+	stored := *r.byID[id]
+	stored.PasswordHash = hash
+	stored.TokensValidAfter = &validAfter
+	r.byID[id] = &stored
+	return nil
+}
+
+type fakeAccounts struct {
+	repo.OAuthAccountRepository
+	items []*domain.OAuthAccount
+}
+
+func (r *fakeAccounts) Create(_ context.Context, a *domain.OAuthAccount) error {
+	for _, existing := range r.items {
+		if existing.Provider == a.Provider && (existing.Subject == a.Subject || existing.UserID == a.UserID) {
+			return repo.ErrOAuthAccountLinked
+		}
+	}
+	r.items = append(r.items, a)
+	return nil
+}
+
+func (r *fakeAccounts) GetByProviderSubject(_ context.Context, provider domain.OAuthProvider, subject string) (*domain.OAuthAccount, error) {
+	for _, a := range r.items {
+		if a.Provider == provider && a.Subject == subject {
+			return a, nil
+		}
+	}
+	return nil, repo.ErrNotFound
+}
+
+func (r *fakeAccounts) TouchLogin(context.Context, uuid.UUID, time.Time) error { return nil }
+
+type fixture struct {
+	svc      oauthuc.Service
+	users    *fakeUsers
+	accounts *fakeAccounts
+}
+
+func newFixture(identities map[string]*oidc.Identity) fixture {
+	users := &fakeUsers{byID: map[uuid.UUID]*domain.User{}}
+	accounts := &fakeAccounts{}
+	jwt, _ := jwtsvc.NewService(&config.JWTConfig{
+		AccessSecret:  "access-secret",
+		RefreshSecret: "refresh-secret",
+		AccessTTL:     time.Minute,
+		RefreshTTL:    time.Hour,
+		Issuer:        "test",
+	})
+	verifiers := map[domain.OAuthProvider]oidc.Verifier{
+		domain.OAuthProviderGoogle: &fakeVerifier{identities: identities},
+	}
+	return fixture{
+		svc:      oauthuc.NewService(fakeTx{}, users, &fakeUsernameHistory{}, fakeUsernameFilter{}, accounts, verifiers, jwt, events.NopPublisher{}),
+		users:    users,
+		accounts: accounts,
+	}
+}
+
+func TestLogin_CreatesUserThenReusesLink(t *testing.T) {
+	ctx := context.Background()
+	f := newFixture(map[string]*oidc.Identity{
+		"token": {Subject: "g-1", Email: "jane.doe@example.com", EmailVerified: true},
+	})
+
+	first, err := f.svc.Login(ctx, "google", "token", "", "DE")
+	require.NoError(t, err)
+	require.True(t, first.Created)
+	require.True(t, first.User.IsEmailVerified)
+	require.Empty(t, first.User.PasswordHash, "вход по паролю невозможен")
+	require.Equal(t, "janedoe", first.User.Username)
+	require.NotEmpty(t, first.AccessToken)
+	require.NotEmpty(t, first.RefreshToken)
+	require.Len(t, f.accounts.items, 1)
+
+	second, err := f.svc.Login(ctx, "google", "token", "", "")
+	require.NoError(t, err)
+	require.False(t, second.Created)
+	require.Equal(t, first.User.ID, second.User.ID)
+	require.Len(t, f.accounts.items, 1)
+}
+
+func TestLogin_LinksExistingUnverifiedAccountAndResetsPassword(t *testing.T) {
+	ctx := context.Background()
+	f := newFixture(map[string]*oidc.Identity{
+		"token": {Subject: "g-2", Email: "owner@example.com", EmailVerified: true},
+	})
+	existing := domain.NewUser("owner@example.com", "hash-set-by-someone-else", "owner")
+	f.users.byID[existing.ID] = existing
+
+	result, err := f.svc.Login(ctx, "google", "token", "", "")
+	require.NoError(t, err)
+	require.False(t, result.Created)
+	require.Equal(t, existing.ID, result.User.ID)
+	require.True(t, result.User.IsEmailVerified)
+	require.Empty(t, result.User.PasswordHash)
+
+	// Сброс сохранён в хранилище, а токены автора регистрации отозваны.
+	stored := f.users.byID[existing.ID]
+	require.True(t, stored.IsEmailVerified)
+	require.Empty(t, stored.PasswordHash)
+	require.NotNil(t, stored.TokensValidAfter)
+}
+
+func TestLogin_GeneratesUniqueUsername(t *testing.T) {
+	ctx := context.Background()
+	f := newFixture(map[string]*oidc.Identity{
+		"token": {Subject: "g-3", Email: "al@example.com", EmailVerified: true},
+	})
+	taken := domain.NewUser("other@example.com", "hash", "useral")
+	f.users.byID[taken.ID] = taken
+
+	result, err := f.svc.Login(ctx, "google", "token", "", "")
+	require.NoError(t, err)
+	require.NotEqual(t, "useral", result.User.Username)
+	require.Regexp(t, `^useral\d{4}$`, result.User.Username)
+}
+
+func TestLogin_Rejections(t *testing.T) {
+	ctx := context.Background()
+	f := newFixture(map[string]*oidc.Identity{
+		"unverified": {Subject: "g-4", Email: "x@example.com", EmailVerified: false},
+		"nonce":      {Subject: "g-5", Email: "y@example.com", EmailVerified: true, Nonce: "n-1"},
+	})
+
+	_, err := f.svc.Login(ctx, "apple", "nonce", "", "")
+	require.ErrorIs(t, err, oauthuc.ErrProviderNotSupported)
+
+	_, err = f.svc.Login(ctx, "google", "forged", "", "")
+	require.ErrorIs(t, err, oauthuc.ErrInvalidIDToken)
+
+	_, err = f.svc.Login(ctx, "google", "unverified", "", "")
+	require.ErrorIs(t, err, oauthuc.ErrEmailNotVerified)
+
+	_, err = f.svc.Login(ctx, "google", "nonce", "n-2", "")
+	require.ErrorIs(t, err, oauthuc.ErrInvalidIDToken)
+
+	require.Empty(t, f.users.byID)
+}
+
+func TestLogin_BlockedEmailLocalPartFallsBackToNeutralUsername(t *testing.T) {
+	ctx := context.Background()
+	users := &fakeUsers{byID: map[uuid.UUID]*domain.User{}}
+	jwt, _ := jwtsvc.NewService(&config.JWTConfig{
+		AccessSecret:  "access-secret",
+		RefreshSecret: "refresh-secret",
+		AccessTTL:     time.Minute,
+		RefreshTTL:    time.Hour,
+		Issuer:        "test",
+	})
+	verifiers := map[domain.OAuthProvider]oidc.Verifier{
+		domain.OAuthProviderGoogle: &fakeVerifier{identities: map[string]*oidc.Identity{
+			"token": {Subject: "g-6", Email: "support@example.com", EmailVerified: true},
+		}},
+	}
+	filter := fakeUsernameFilter{matcher: usernamefilter.NewMatcher(usernamefilter.Reserved())}
+	svc := oauthuc.NewService(fakeTx{}, users, &fakeUsernameHistory{}, filter, &fakeAccounts{}, verifiers, jwt, events.NopPublisher{})
+
+	result, err := svc.Login(ctx, "google", "token", "", "")
+	require.NoError(t, err)
+	require.True(t, result.Created)
+	require.Equal(t, "user", result.User.Username)
+}
@@ -0,0 +1,218 @@
+package organization_test
+
+import (
+	"context"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	domain "workout-app/internal/domain/organization"
+	userdomain "workout-app/internal/domain/user"
+	repo "workout-app/internal/repository/interfaces"
+	organizationuc "workout-app/internal/usecase/organization"
+)
+
+type fakeTx struct{}
+
+func (fakeTx) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+// fakeOrgs хранит одну организацию, её участников и приглашения в памяти.
+type fakeOrgs struct {
+	repo.OrganizationRepository
+	org         *domain.Organization
+	members     map[uuid.UUID]*domain.Member
+	invitations map[uuid.UUID]*domain.Invitation
+}
+
+func newFakeOrgs() *fakeOrgs {
+	return &fakeOrgs{
+		org:         &domain.Organization{ID: uuid.New(), Name: "Gym"},
+		members:     map[uuid.UUID]*domain.Member{},
+		invitations: map[uuid.UUID]*domain.Invitation{},
+	}
+}
+
+func (r *fakeOrgs) add(userID uuid.UUID, role domain.Role) {
+	r.members[userID] = &domain.Member{OrganizationID: r.org.ID, UserID: userID, Role: role, CreatedAt: time.Now()}
+}
+
+func (r *fakeOrgs) GetByID(_ context.Context, id uuid.UUID) (*domain.Organization, error) {
+	if id != r.org.ID {
+		return nil, repo.ErrNotFound
+	}
+	return r.org, nil
+}
+
+func (r *fakeOrgs) AddMember(_ context.Context, m *domain.Member) error {
+	if _, ok := r.members[m.UserID]; ok {
+		return repo.ErrMemberExists
+	}
+	r.members[m.UserID] = m
+	return nil
+}
+
+func (r *fakeOrgs) GetMember(_ context.Context, orgID, userID uuid.UUID) (*domain.Member, error) {
+	m, ok := r.members[userID]
+	if !ok || orgID != r.org.ID {
+		return nil, repo.ErrNotFound
+	}
+	copied := *m
+	return &copied, nil
+}
+
+func (r *fakeOrgs) UpdateMemberRole(_ context.Context, _, userID uuid.UUID, role domain.Role) error {
+	m, ok := r.members[userID]
+	if !ok {
+		return repo.ErrNotFound
+	}
+	m.Role = role
+	return nil
+}
+
+func (r *fakeOrgs) ListMembers(_ context.Context, _ uuid.UUID, limit, offset int) ([]*domain.Member, int64, error) {
+	rank := map[domain.Role]int{domain.RoleOwner: 0, domain.RoleManager: 1, domain.RoleStaff: 2, domain.RoleMember: 3}
+	var out []*domain.Member
+	for _, m := range r.members {
+		out = append(out, m)
+	}
+	sort.Slice(out, func(i, j int) bool { return rank[out[i].Role] < rank[out[j].Role] })
+	total := int64(len(out))
+	out = out[min(offset, len(out)):]
+	return out[:min(limit, len(out))], total, nil
+}
+
+func (r *fakeOrgs) CreateInvitation(_ context.Context, inv *domain.Invitation) error {
+	for id, existing := range r.invitations {
+		if existing.UserID == inv.UserID {
+			if !existing.IsExpired(inv.CreatedAt) {
+				return repo.ErrInvitationExists
+			}
+			delete(r.invitations, id)
+		}
+	}
+	r.invitations[inv.ID] = inv
+	return nil
+}
+
+func (r *fakeOrgs) GetInvitation(_ context.Context, id uuid.UUID) (*domain.Invitation, error) {
+	inv, ok := r.invitations[id]
+	if !ok {
+		return nil, repo.ErrNotFound
+	}
+	return inv, nil
+}
+
+func (r *fakeOrgs) DeleteInvitation(_ context.Context, id uuid.UUID) error {
+	if _, ok := r.invitations[id]; !ok {
+		return repo.ErrNotFound
+	}
+	delete(r.invitations, id)
+	return nil
+}
+
+// fakeUsers находит пользователей по email.
+type fakeUsers struct {
+	repo.UserRepository
+	byEmail map[string]uuid.UUID
+}
+
+func (r *fakeUsers) GetByEmail(_ context.Context, email string) (*userdomain.User, error) {
+	id, ok := r.byEmail[email]
+	if !ok {
+		return nil, repo.ErrNotFound
+	}
+	return &userdomain.User{ID: id, Email: email}, nil
+}
+
+func TestRole_CanInvite(t *testing.T) {
+	require.True(t, domain.RoleOwner.CanInvite(domain.RoleManager))
+	require.True(t, domain.RoleOwner.CanInvite(domain.RoleStaff))
+	require.True(t, domain.RoleManager.CanInvite(domain.RoleStaff))
+	require.False(t, domain.RoleManager.CanInvite(domain.RoleManager))
+	require.False(t, domain.RoleStaff.CanInvite(domain.RoleStaff))
+	require.False(t, domain.RoleOwner.CanInvite(domain.RoleOwner))
+}
+
+func TestInvite_ManagerCannotInviteManager(t *testing.T) {
+	orgs := newFakeOrgs()
+	invitee := uuid.New()
+	svc := organizationuc.NewService(fakeTx{}, orgs, &fakeUsers{byEmail: map[string]uuid.UUID{"coach@example.com": invitee}})
+
+	_, err := svc.Invite(context.Background(), orgs.org.ID, uuid.New(), domain.RoleManager, "coach@example.com", domain.RoleManager)
+	require.ErrorIs(t, err, organizationuc.ErrForbidden)
+
+	_, err = svc.Invite(context.Background(), orgs.org.ID, uuid.New(), domain.RoleOwner, "coach@example.com", domain.RoleOwner)
+	require.ErrorIs(t, err, organizationuc.ErrInvalidInviteRole)
+}
+
+func TestInvite_AcceptPromotesExistingMember(t *testing.T) {
+	orgs := newFakeOrgs()
+	invitee := uuid.New()
+	orgs.add(invitee, domain.RoleMember)
+	svc := organizationuc.NewService(fakeTx{}, orgs, &fakeUsers{byEmail: map[string]uuid.UUID{"coach@example.com": invitee}})
+	ctx := context.Background()
+
+	inv, err := svc.Invite(ctx, orgs.org.ID, uuid.New(), domain.RoleManager, " Coach@Example.com ", domain.RoleStaff)
+	require.NoError(t, err)
+	require.Equal(t, invitee, inv.UserID)
+
+	_, err = svc.Invite(ctx, orgs.org.ID, uuid.New(), domain.RoleOwner, "coach@example.com", domain.RoleManager)
+	require.ErrorIs(t, err, organizationuc.ErrAlreadyInvited)
+
+	// Чужое приглашение принять нельзя.
+	_, err = svc.AcceptInvitation(ctx, uuid.New(), inv.ID)
+	require.ErrorIs(t, err, organizationuc.ErrInvitationNotFound)
+
+	m, err := svc.AcceptInvitation(ctx, invitee, inv.ID)
+	require.NoError(t, err)
+	require.Equal(t, domain.RoleStaff, m.Role)
+	require.Equal(t, domain.RoleStaff, orgs.members[invitee].Role)
+	require.Empty(t, orgs.invitations)
+
+	_, err = svc.Invite(ctx, orgs.org.ID, uuid.New(), domain.RoleOwner, "coach@example.com", domain.RoleManager)
+	require.ErrorIs(t, err, organizationuc.ErrAlreadyMember)
+}
+
+func TestAcceptInvitation_RejectsExpired(t *testing.T) {
+	orgs := newFakeOrgs()
+	invitee := uuid.New()
+	inv := &domain.Invitation{
+		ID:             uuid.New(),
+		OrganizationID: orgs.org.ID,
+		UserID:         invitee,
+		Role:           domain.RoleStaff,
+		CreatedAt:      time.Now().Add(-8 * 24 * time.Hour),
+		ExpiresAt:      time.Now().Add(-24 * time.Hour),
+	}
+	orgs.invitations[inv.ID] = inv
+	svc := organizationuc.NewService(fakeTx{}, orgs, &fakeUsers{})
+
+	_, err := svc.AcceptInvitation(context.Background(), invitee, inv.ID)
+	require.ErrorIs(t, err, organizationuc.ErrInvitationNotFound)
+	require.NotContains(t, orgs.members, invitee)
+}
+
+func TestTransferOwnership_DemotesPreviousOwner(t *testing.T) {
+	orgs := newFakeOrgs()
+	owner, staff := uuid.New(), uuid.New()
+	orgs.add(owner, domain.RoleOwner)
+	orgs.add(staff, domain.RoleStaff)
+	svc := organizationuc.NewService(fakeTx{}, orgs, &fakeUsers{})
+	ctx := context.Background()
+
+	_, err := svc.TransferOwnership(ctx, orgs.org.ID, uuid.New())
+	require.ErrorIs(t, err, organizationuc.ErrMemberNotFound)
+	_, err = svc.TransferOwnership(ctx, orgs.org.ID, owner)
+	require.ErrorIs(t, err, organizationuc.ErrAlreadyOwner)
+
+	m, err := svc.TransferOwnership(ctx, orgs.org.ID, staff)
+	require.NoError(t, err)
+	require.Equal(t, domain.RoleOwner, m.Role)
+	require.Equal(t, domain.RoleOwner, orgs.members[staff].Role)
+	require.Equal(t, domain.RoleManager, orgs.members[owner].Role)
+}
@@ -0,0 +1,114 @@
+package playground_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"workout-app/internal/config"
+	domain "workout-app/internal/domain/user"
+	repo "workout-app/internal/repository/interfaces"
+	playgrounduc "workout-app/internal/usecase/playground"
+	jwtsvc "workout-app/pkg/jwt"
+)
+
+type fakeUsers struct {
+	repo.UserRepository
+	byID map[uuid.UUID]*domain.User
+}
+
+func (r *fakeUsers) Create(_ context.Context, u *domain.User) error {
+	r.byID[u.ID] = u
+	return nil
+}
+
+func (r *fakeUsers) GetByEmail(_ context.Context, email string) (*domain.User, error) {
+	for _, u := range r.byID {
+		if u.Email == email {
+			return u, nil
+		}
+	}
+	return nil, repo.ErrNotFound
+}
+
+func newService(t *testing.T) (playgrounduc.Service, *fakeUsers, jwtsvc.Service) {
+	t.Helper()
+	users := &fakeUsers{byID: map[uuid.UUID]*domain.User{}}
+	jwt, err := jwtsvc.NewService(&config.JWTConfig{
+		AccessSecret:  "access-secret",
+		RefreshSecret: "refresh-secret",
+		AccessTTL:     time.Minute,
+		RefreshTTL:    time.Hour,
+		Issuer:        "test",
+	})
+	require.NoError(t, err)
+	return playgrounduc.NewService(users, jwt), users, jwt
+}
+
+func TestSeed_CreatesVerifiedUserPerRoleOnce(t *testing.T) {
+	svc, users, _ := newService(t)
+	ctx := context.Background()
+
+	_, _, _, err := svc.IssueTokens(ctx, domain.RoleUser)
+	require.ErrorIs(t, err, playgrounduc.ErrNotSeeded)
+
+	require.NoError(t, svc.Seed(ctx))
+	require.NoError(t, svc.Seed(ctx))
+	require.Len(t, users.byID, 3)
+
+	seeded, err := svc.Users(ctx)
+	require.NoError(t, err)
+	roles := make([]domain.Role, 0, len(seeded))
+	for _, u := range seeded {
+		roles = append(roles, u.Role)
+		require.True(t, u.IsEmailVerified)
+		// Без пароля войти можно только через песочницу.
+		require.Empty(t, u.PasswordHash)
+	}
+	require.Equal(t, []domain.Role{domain.RoleUser, domain.RoleCoach, domain.RoleAdmin}, roles)
+}
+
+func TestIssueTokens_ForSeededRole(t *testing.T) {
+	svc, _, jwt := newService(t)
+	ctx := context.Background()
+	require.NoError(t, svc.Seed(ctx))
+
+	user, access, refresh, err := svc.IssueTokens(ctx, domain.RoleCoach)
+	require.NoError(t, err)
+	require.Equal(t, domain.RoleCoach, user.Role)
+	require.NotEmpty(t, refresh)
+
+	claims, err := jwt.ParseAccessToken(access)
+	require.NoError(t, err)
+	require.Equal(t, user.ID.String(), claims.UserID)
+
+	_, _, _, err = svc.IssueTokens(ctx, domain.Role("owner"))
+	require.ErrorIs(t, err, playgrounduc.ErrUnknownRole)
+}
+
+func TestConfig_PlaygroundOptIn(t *testing.T) {
+	t.Setenv("JWT_ACCESS_SECRET", "access")
+	t.Setenv("JWT_REFRESH_SECRET", "refresh")
+	t.Setenv("PLAYGROUND_ENABLED", "")
+
+	// Окружение разработки не включает песочницу: она выдаёт токены без пароля.
+	for _, env := range []string{"", "development", "staging"} {
+		t.Setenv("APP_ENV", env)
+		cfg, err := config.Load()
+		require.NoError(t, err)
+		require.False(t, cfg.Playground.Enabled, "APP_ENV=%q", env)
+	}
+
+	t.Setenv("APP_ENV", "development")
+	t.Setenv("PLAYGROUND_ENABLED", "true")
+	cfg, err := config.Load()
+	require.NoError(t, err)
+	require.True(t, cfg.Playground.Enabled)
+
+	t.Setenv("APP_ENV", "production")
+	_, err = config.Load()
+	require.ErrorContains(t, err, "PLAYGROUND_ENABLED")
+}
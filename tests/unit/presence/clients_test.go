@@ -0,0 +1,96 @@
+package presence_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	userdomain "workout-app/internal/domain/user"
+	repo "workout-app/internal/repository/interfaces"
+	presenceuc "workout-app/internal/usecase/presence"
+	"workout-app/pkg/presence"
+)
+
+type fakeClients struct {
+	repo.CoachClientRepository
+	clients []uuid.UUID
+}
+
+func (f *fakeClients) ListClientIDs(context.Context, uuid.UUID) ([]uuid.UUID, error) {
+	return f.clients, nil
+}
+
+type fakeUsers struct {
+	repo.UserRepository
+	users map[uuid.UUID]*userdomain.User
+}
+
+func (f *fakeUsers) GetByID(_ context.Context, id uuid.UUID) (*userdomain.User, error) {
+	u, ok := f.users[id]
+	if !ok {
+		return nil, repo.ErrNotFound
+	}
+	return u, nil
+}
+
+type fakeNotes struct {
+	repo.CoachNoteRepository
+	tags    map[uuid.UUID][]string
+	matches []uuid.UUID
+}
+
+func (f *fakeNotes) ListTagsByClient(context.Context, uuid.UUID) (map[uuid.UUID][]string, error) {
+	return f.tags, nil
+}
+
+func (f *fakeNotes) SearchClients(context.Context, uuid.UUID, string) ([]uuid.UUID, error) {
+	return f.matches, nil
+}
+
+func TestListClients_SearchesUsernameTagsAndNotes(t *testing.T) {
+	users := &fakeUsers{users: map[uuid.UUID]*userdomain.User{}}
+	var ids []uuid.UUID
+	for _, name := range []string{"anna", "boris", "vera", "gleb"} {
+		u := userdomain.NewUser(name+"@example.com", "hash", name)
+		users.users[u.ID] = u
+		ids = append(ids, u.ID)
+	}
+	anna, boris, vera, gleb := ids[0], ids[1], ids[2], ids[3]
+	notes := &fakeNotes{
+		tags:    map[uuid.UUID][]string{boris: {"marathon"}, vera: {"marathon", "injury"}},
+		matches: []uuid.UUID{gleb},
+	}
+	svc := presenceuc.NewService(presence.NewMemoryStore(), &fakeClients{clients: ids}, users, notes, time.Minute)
+	ctx := context.Background()
+
+	all, err := svc.ListClients(ctx, uuid.New(), presenceuc.ClientFilter{})
+	require.NoError(t, err)
+	require.Len(t, all, 4)
+	require.Equal(t, []string{"marathon", "injury"}, all[2].Tags)
+
+	byTag, err := svc.ListClients(ctx, uuid.New(), presenceuc.ClientFilter{Tag: " Marathon "})
+	require.NoError(t, err)
+	require.Equal(t, []uuid.UUID{boris, vera}, clientIDs(byTag))
+
+	// Поиск находит username, тег и клиента по тексту заметки.
+	found, err := svc.ListClients(ctx, uuid.New(), presenceuc.ClientFilter{Query: "ANN"})
+	require.NoError(t, err)
+	require.Equal(t, []uuid.UUID{anna, gleb}, clientIDs(found))
+	found, err = svc.ListClients(ctx, uuid.New(), presenceuc.ClientFilter{Query: "inj"})
+	require.NoError(t, err)
+	require.Equal(t, []uuid.UUID{vera, gleb}, clientIDs(found))
+
+	_, err = svc.ListClients(ctx, uuid.New(), presenceuc.ClientFilter{Tag: "vip!"})
+	require.ErrorIs(t, err, presenceuc.ErrInvalidTag)
+}
+
+func clientIDs(clients []presenceuc.ClientPresence) []uuid.UUID {
+	ids := make([]uuid.UUID, 0, len(clients))
+	for _, c := range clients {
+		ids = append(ids, c.UserID)
+	}
+	return ids
+}
@@ -0,0 +1,69 @@
+package presence_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	presenceuc "workout-app/internal/usecase/presence"
+	"workout-app/pkg/presence"
+)
+
+func TestHeartbeat_KeepsSinceWithinSameSession(t *testing.T) {
+	store := presence.NewMemoryStore()
+	svc := presenceuc.NewService(store, nil, nil, nil, time.Minute)
+	ctx := context.Background()
+	userID := uuid.New()
+
+	first, err := svc.Heartbeat(ctx, userID, "s1")
+	require.NoError(t, err)
+	require.Equal(t, presenceuc.StatusTraining, first.Status)
+
+	time.Sleep(5 * time.Millisecond)
+	second, err := svc.Heartbeat(ctx, userID, "s1")
+	require.NoError(t, err)
+	require.Equal(t, first.Since, second.Since)
+	require.True(t, second.LastSeenAt.After(first.LastSeenAt))
+
+	// Новая сессия начинает отсчёт заново.
+	third, err := svc.Heartbeat(ctx, userID, "s2")
+	require.NoError(t, err)
+	require.True(t, third.Since.After(first.Since))
+}
+
+func TestHeartbeat_ExpiresAfterTTL(t *testing.T) {
+	store := presence.NewMemoryStore()
+	svc := presenceuc.NewService(store, nil, nil, nil, 10*time.Millisecond)
+	ctx := context.Background()
+	userID := uuid.New()
+
+	_, err := svc.Heartbeat(ctx, userID, "")
+	require.NoError(t, err)
+
+	state, err := store.Get(ctx, userID.String())
+	require.NoError(t, err)
+	require.NotNil(t, state)
+
+	time.Sleep(20 * time.Millisecond)
+	state, err = store.Get(ctx, userID.String())
+	require.NoError(t, err)
+	require.Nil(t, state)
+}
+
+func TestStop_ClearsPresence(t *testing.T) {
+	store := presence.NewMemoryStore()
+	svc := presenceuc.NewService(store, nil, nil, nil, time.Minute)
+	ctx := context.Background()
+	userID := uuid.New()
+
+	_, err := svc.Heartbeat(ctx, userID, "")
+	require.NoError(t, err)
+	require.NoError(t, svc.Stop(ctx, userID))
+
+	states, err := store.GetMany(ctx, []string{userID.String()})
+	require.NoError(t, err)
+	require.Empty(t, states)
+}
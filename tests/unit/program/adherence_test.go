@@ -0,0 +1,80 @@
+package program_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	domain "workout-app/internal/domain/program"
+	consentuc "workout-app/internal/usecase/consent"
+	programuc "workout-app/internal/usecase/program"
+)
+
+func (r *fakeProgramRepo) ListAssignmentsByUser(_ context.Context, userID uuid.UUID) ([]*domain.Assignment, error) {
+	var out []*domain.Assignment
+	for _, a := range r.created {
+		if a.UserID == userID {
+			out = append(out, a)
+		}
+	}
+	return out, nil
+}
+
+func (r *fakeProgramRepo) CompletedSessions(_ context.Context, ids []uuid.UUID) (map[uuid.UUID]int, error) {
+	out := map[uuid.UUID]int{}
+	for _, id := range ids {
+		if n, ok := r.completed[id]; ok {
+			out[id] = n
+		}
+	}
+	return out, nil
+}
+
+func TestAdherenceForCoach(t *testing.T) {
+	coachID, clientID := uuid.New(), uuid.New()
+	day := func(n int) domain.Day {
+		return domain.Day{Number: n, Workouts: []domain.Workout{{Title: "A", Exercises: []domain.Exercise{{Name: "Присед", Sets: 5, Reps: 5}}}}}
+	}
+	// Две недели по две тренировки: дни 1 и 3.
+	p := domain.New(coachID, "Сила", "", []domain.Week{
+		{Number: 1, Days: []domain.Day{day(1), day(3)}},
+		{Number: 2, Days: []domain.Day{day(1), day(3)}},
+	})
+
+	y, m, d := time.Now().UTC().Date()
+	today := time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+	running := &domain.Assignment{ID: uuid.New(), ProgramID: p.ID, UserID: clientID, AssignedBy: coachID, StartDate: today.AddDate(0, 0, -8)}
+	upcoming := &domain.Assignment{ID: uuid.New(), ProgramID: p.ID, UserID: clientID, AssignedBy: coachID, StartDate: today.AddDate(0, 0, 1)}
+	otherCoach := &domain.Assignment{ID: uuid.New(), ProgramID: p.ID, UserID: clientID, AssignedBy: uuid.New(), StartDate: today}
+
+	consents := &fakeConsentChecker{}
+	programs := &fakeProgramRepo{
+		program:   p,
+		created:   []*domain.Assignment{running, upcoming, otherCoach},
+		completed: map[uuid.UUID]int{running.ID: 2, otherCoach.ID: 1},
+	}
+	svc := programuc.NewService(nil, programs, newFakeTrainingMaxRepo(), nil, nil, nil, consents)
+
+	adherence, err := svc.AdherenceForCoach(context.Background(), coachID, clientID)
+	require.NoError(t, err)
+	require.Len(t, adherence, 2)
+
+	require.Equal(t, running.ID, adherence[0].Assignment.ID)
+	require.Equal(t, 4, adherence[0].Total)
+	require.Equal(t, 3, adherence[0].Scheduled)
+	require.Equal(t, 2, adherence[0].Completed)
+	rate, ok := adherence[0].Rate()
+	require.True(t, ok)
+	require.InDelta(t, 2.0/3, rate, 1e-9)
+
+	require.Equal(t, 0, adherence[1].Scheduled)
+	_, ok = adherence[1].Rate()
+	require.False(t, ok)
+
+	consents.err = consentuc.ErrConsentRequired
+	_, err = svc.AdherenceForCoach(context.Background(), coachID, clientID)
+	require.ErrorIs(t, err, consentuc.ErrConsentRequired)
+}
@@ -0,0 +1,201 @@
+package program_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	domain "workout-app/internal/domain/program"
+	userdomain "workout-app/internal/domain/user"
+	repo "workout-app/internal/repository/interfaces"
+	consentuc "workout-app/internal/usecase/consent"
+	programuc "workout-app/internal/usecase/program"
+)
+
+func (r *fakeProgramRepo) CreateAssignment(_ context.Context, a *domain.Assignment) error {
+	for _, existing := range r.created {
+		if existing.UserID == a.UserID {
+			return repo.ErrProgramAlreadyAssigned
+		}
+	}
+	r.created = append(r.created, a)
+	return nil
+}
+
+type fakeTx struct{}
+
+func (fakeTx) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+type fakeUserRepo struct {
+	repo.UserRepository
+	known map[uuid.UUID]bool
+}
+
+func (r *fakeUserRepo) GetByID(_ context.Context, id uuid.UUID) (*userdomain.User, error) {
+	if !r.known[id] {
+		return nil, repo.ErrNotFound
+	}
+	return &userdomain.User{ID: id}, nil
+}
+
+type fakePublisher struct {
+	published int
+}
+
+func (p *fakePublisher) Publish(context.Context, string, string, any) {
+	p.published++
+}
+
+func TestBulkAssign_PerClientResults(t *testing.T) {
+	percent := 75.0
+	coach := programuc.Actor{UserID: uuid.New(), Role: userdomain.RoleCoach}
+	p := domain.New(coach.UserID, "Группа", "", []domain.Week{{Number: 1, Days: []domain.Day{
+		{Number: 1, Workouts: []domain.Workout{{
+			Title:     "A",
+			Exercises: []domain.Exercise{{Name: "Присед", Sets: 5, Reps: 5, LoadPercent: &percent}},
+		}}},
+	}}})
+
+	withMax, withoutMax, alreadyAssigned, unknown, stranger := uuid.New(), uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	programs := &fakeProgramRepo{program: p, created: []*domain.Assignment{{UserID: alreadyAssigned}}}
+	clients := &fakeClientRepo{clients: map[uuid.UUID]bool{withMax: true, withoutMax: true, alreadyAssigned: true}}
+	users := &fakeUserRepo{known: map[uuid.UUID]bool{withMax: true, withoutMax: true, alreadyAssigned: true, stranger: true}}
+	events := &fakePublisher{}
+	maxes := newFakeTrainingMaxRepo()
+	svc := programuc.NewService(fakeTx{}, programs, maxes, users, clients, events, nil)
+
+	start := time.Date(2025, 1, 6, 0, 0, 0, 0, time.UTC)
+	results, err := svc.BulkAssign(context.Background(), coach, p.ID, programuc.BulkAssignInput{
+		StartDate: start,
+		Clients: []programuc.BulkAssignClient{
+			{UserID: withMax, StartDate: start.AddDate(0, 0, 7), TrainingMaxes: map[string]float64{"присед": 140}},
+			{UserID: withoutMax},
+			{UserID: alreadyAssigned},
+			{UserID: unknown},
+			{UserID: stranger},
+		},
+	})
+	require.NoError(t, err)
+	require.Len(t, results, 5)
+
+	require.NoError(t, results[0].Err)
+	require.Equal(t, start.AddDate(0, 0, 7), results[0].Assignment.StartDate)
+	require.Empty(t, results[0].MissingTrainingMaxes)
+
+	require.NoError(t, results[1].Err)
+	require.Equal(t, start, results[1].Assignment.StartDate)
+	require.Equal(t, []string{"Присед"}, results[1].MissingTrainingMaxes)
+
+	require.ErrorIs(t, results[2].Err, repo.ErrProgramAlreadyAssigned)
+	require.Nil(t, results[2].Assignment)
+	require.ErrorIs(t, results[3].Err, programuc.ErrAssigneeNotFound)
+	require.ErrorIs(t, results[4].Err, consentuc.ErrNotCoachClient)
+
+	require.Equal(t, 2, events.published)
+}
+
+func TestBulkAssign_ReportsMissingTrainingMaxes(t *testing.T) {
+	percent := 80.0
+	coach := programuc.Actor{UserID: uuid.New(), Role: userdomain.RoleCoach}
+	p := domain.New(coach.UserID, "Жим", "", []domain.Week{{Number: 1, Days: []domain.Day{
+		{Number: 2, Workouts: []domain.Workout{{
+			Title:     "B",
+			Exercises: []domain.Exercise{{Name: "Жим узким", Sets: 3, Reps: 8, LoadPercent: &percent, LoadReference: "Жим  лёжа"}},
+		}}},
+	}}})
+	client := uuid.New()
+	svc := programuc.NewService(fakeTx{}, &fakeProgramRepo{program: p},
+		newFakeTrainingMaxRepo(),
+		&fakeUserRepo{known: map[uuid.UUID]bool{client: true}},
+		&fakeClientRepo{clients: map[uuid.UUID]bool{client: true}}, &fakePublisher{}, nil)
+
+	results, err := svc.BulkAssign(context.Background(), coach, p.ID, programuc.BulkAssignInput{
+		Clients: []programuc.BulkAssignClient{{UserID: client}},
+	})
+	require.NoError(t, err)
+	require.NoError(t, results[0].Err)
+	require.Equal(t, []string{"Жим лёжа"}, results[0].MissingTrainingMaxes)
+}
+
+func TestBulkAssign_Validation(t *testing.T) {
+	coach := programuc.Actor{UserID: uuid.New(), Role: userdomain.RoleCoach}
+	p := domain.New(coach.UserID, "X", "", nil)
+	svc := programuc.NewService(fakeTx{}, &fakeProgramRepo{program: p}, newFakeTrainingMaxRepo(), &fakeUserRepo{}, &fakeClientRepo{}, &fakePublisher{}, nil)
+	ctx := context.Background()
+
+	_, err := svc.BulkAssign(ctx, coach, p.ID, programuc.BulkAssignInput{})
+	require.ErrorIs(t, err, programuc.ErrInvalidBulkAssign)
+
+	client := uuid.New()
+	_, err = svc.BulkAssign(ctx, coach, p.ID, programuc.BulkAssignInput{
+		Clients: []programuc.BulkAssignClient{{UserID: client}, {UserID: client}},
+	})
+	require.ErrorIs(t, err, programuc.ErrInvalidBulkAssign)
+
+	// Обычный пользователь не может назначать программы другим, даже если он автор.
+	owner := programuc.Actor{UserID: coach.UserID, Role: userdomain.RoleUser}
+	_, err = svc.BulkAssign(ctx, owner, p.ID, programuc.BulkAssignInput{
+		Clients: []programuc.BulkAssignClient{{UserID: client}},
+	})
+	require.ErrorIs(t, err, programuc.ErrProgramAccessDenied)
+}
+
+// fakeClientRepo хранит клиентов тренера; остальные методы не используются.
+type fakeClientRepo struct {
+	repo.CoachClientRepository
+	clients map[uuid.UUID]bool
+}
+
+func (r *fakeClientRepo) IsClient(_ context.Context, _, clientID uuid.UUID) (bool, error) {
+	return r.clients[clientID], nil
+}
+
+func TestAssignToClient(t *testing.T) {
+	coach := programuc.Actor{UserID: uuid.New(), Role: userdomain.RoleCoach}
+	p := domain.New(coach.UserID, "Сила", "", []domain.Week{{Number: 1, Days: []domain.Day{
+		{Number: 1, Workouts: []domain.Workout{{Title: "A", Exercises: []domain.Exercise{{Name: "Присед", Sets: 5, Reps: 5}}}}},
+	}}})
+	client, stranger := uuid.New(), uuid.New()
+	programs := &fakeProgramRepo{program: p}
+	users := &fakeUserRepo{known: map[uuid.UUID]bool{client: true, stranger: true}}
+	clients := &fakeClientRepo{clients: map[uuid.UUID]bool{client: true}}
+	svc := programuc.NewService(fakeTx{}, programs, newFakeTrainingMaxRepo(), users, clients, &fakePublisher{}, nil)
+
+	start := time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)
+	a, err := svc.AssignToClient(context.Background(), coach, client, p.ID, start)
+	require.NoError(t, err)
+	require.Equal(t, client, a.UserID)
+	require.Equal(t, start, a.StartDate)
+
+	_, err = svc.AssignToClient(context.Background(), coach, stranger, p.ID, start)
+	require.ErrorIs(t, err, consentuc.ErrNotCoachClient)
+	require.Len(t, programs.created, 1)
+}
+
+func TestAssign_CoachOnlyToClients(t *testing.T) {
+	coach := programuc.Actor{UserID: uuid.New(), Role: userdomain.RoleCoach}
+	p := domain.New(coach.UserID, "Сила", "", []domain.Week{{Number: 1, Days: []domain.Day{
+		{Number: 1, Workouts: []domain.Workout{{Title: "A", Exercises: []domain.Exercise{{Name: "Присед", Sets: 5, Reps: 5}}}}},
+	}}})
+	stranger := uuid.New()
+	programs := &fakeProgramRepo{program: p}
+	users := &fakeUserRepo{known: map[uuid.UUID]bool{stranger: true}}
+	svc := programuc.NewService(fakeTx{}, programs, newFakeTrainingMaxRepo(), users, &fakeClientRepo{}, &fakePublisher{}, nil)
+	ctx := context.Background()
+
+	// Назначение программы не делает пользователя клиентом: нужно принятое приглашение.
+	_, err := svc.Assign(ctx, coach, p.ID, programuc.AssignInput{UserID: stranger})
+	require.ErrorIs(t, err, consentuc.ErrNotCoachClient)
+	require.Empty(t, programs.created)
+
+	// Админ назначает программы без связи «тренер — клиент».
+	admin := programuc.Actor{UserID: uuid.New(), Role: userdomain.RoleAdmin}
+	a, err := svc.Assign(ctx, admin, p.ID, programuc.AssignInput{UserID: stranger})
+	require.NoError(t, err)
+	require.Equal(t, stranger, a.UserID)
+}
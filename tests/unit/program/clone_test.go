@@ -0,0 +1,50 @@
+package program_test
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	domain "workout-app/internal/domain/program"
+)
+
+func sampleProgram() *domain.Program {
+	weight := 60.0
+	return domain.New(uuid.New(), "Full body", "3 дня в неделю", []domain.Week{
+		{Number: 1, Days: []domain.Day{
+			{Number: 1, Workouts: []domain.Workout{{
+				Title: "A",
+				Exercises: []domain.Exercise{
+					{Name: "Squat", Sets: 5, Reps: 5, WeightKg: &weight},
+				},
+			}}},
+		}},
+	})
+}
+
+func TestProgramClone_NewOwnerAndSourceReference(t *testing.T) {
+	source := sampleProgram()
+	newOwner := uuid.New()
+
+	clone := source.Clone(newOwner)
+
+	require.NotEqual(t, source.ID, clone.ID)
+	require.Equal(t, newOwner, clone.OwnerID)
+	require.NotNil(t, clone.SourceProgramID)
+	require.Equal(t, source.ID, *clone.SourceProgramID)
+	require.Equal(t, source.Title, clone.Title)
+	require.Equal(t, source.Weeks, clone.Weeks)
+}
+
+func TestProgramClone_IsDeepCopy(t *testing.T) {
+	source := sampleProgram()
+	clone := source.Clone(uuid.New())
+
+	clone.Weeks[0].Days[0].Workouts[0].Exercises[0].Reps = 10
+	*clone.Weeks[0].Days[0].Workouts[0].Exercises[0].WeightKg = 80
+
+	original := source.Weeks[0].Days[0].Workouts[0].Exercises[0]
+	require.Equal(t, 5, original.Reps)
+	require.Equal(t, 60.0, *original.WeightKg)
+}
@@ -0,0 +1,297 @@
+package program_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	consentdomain "workout-app/internal/domain/consent"
+	domain "workout-app/internal/domain/program"
+	userdomain "workout-app/internal/domain/user"
+	repo "workout-app/internal/repository/interfaces"
+	consentuc "workout-app/internal/usecase/consent"
+	programuc "workout-app/internal/usecase/program"
+)
+
+func TestLoadRules_Round(t *testing.T) {
+	rules := domain.DefaultLoadRules()
+	require.Equal(t, 102.5, rules.ResolvePercent(75, 137.5)) // 103.125 -> 102.5
+	require.Equal(t, 105.0, rules.ResolvePercent(76, 137.5)) // 104.5 -> 105
+
+	rules.Mode = domain.RoundDown
+	require.Equal(t, 102.5, rules.Round(104.9))
+	require.Equal(t, 105.0, rules.Round(105))
+
+	rules.Mode = domain.RoundUp
+	require.Equal(t, 105.0, rules.Round(102.6))
+
+	rules.IncrementKg = 0
+	require.Equal(t, 103.125, rules.Round(103.125))
+}
+
+func TestLoadRules_Plates(t *testing.T) {
+	rules := domain.DefaultLoadRules()
+
+	plates, remainder := rules.Plates(102.5)
+	require.Equal(t, []float64{25, 15, 1.25}, plates)
+	require.Zero(t, remainder)
+
+	plates, remainder = rules.Plates(21)
+	require.Empty(t, plates)
+	require.Equal(t, 1.0, remainder)
+
+	plates, _ = rules.Plates(15)
+	require.Empty(t, plates)
+
+	rules.BarKg = 0
+	plates, _ = rules.Plates(100)
+	require.Empty(t, plates)
+}
+
+func TestProgramSchedule_Dates(t *testing.T) {
+	p := domain.New(uuid.New(), "Split", "", []domain.Week{
+		{Number: 1, Days: []domain.Day{
+			{Number: 1, Workouts: []domain.Workout{{Title: "A"}}},
+			{Number: 3, Workouts: []domain.Workout{{Title: "B"}}},
+		}},
+		{Number: 2, Days: []domain.Day{
+			{Number: 1, Workouts: []domain.Workout{{Title: "C"}}},
+		}},
+	})
+	start := time.Date(2025, 1, 6, 0, 0, 0, 0, time.UTC)
+
+	all := p.Schedule(start, start, p.EndDate(start))
+	require.Len(t, all, 3)
+	require.Equal(t, start, all[0].Date)
+	require.Equal(t, start.AddDate(0, 0, 2), all[1].Date)
+	require.Equal(t, start.AddDate(0, 0, 7), all[2].Date)
+	require.Equal(t, 2, all[2].Week)
+
+	secondWeek := p.Schedule(start, start.AddDate(0, 0, 7), start.AddDate(0, 0, 14))
+	require.Len(t, secondWeek, 1)
+	require.Equal(t, "C", secondWeek[0].Workout.Title)
+}
+
+type fakeProgramRepo struct {
+	repo.ProgramRepository
+	program    *domain.Program
+	assignment *domain.Assignment
+	created    []*domain.Assignment
+	difficulty domain.DifficultyStats
+	completed  map[uuid.UUID]int
+}
+
+func (r *fakeProgramRepo) GetByID(context.Context, uuid.UUID) (*domain.Program, error) {
+	return r.program, nil
+}
+
+func (r *fakeProgramRepo) GetAssignmentByID(_ context.Context, id uuid.UUID) (*domain.Assignment, error) {
+	if r.assignment == nil || r.assignment.ID != id {
+		return nil, repo.ErrNotFound
+	}
+	return r.assignment, nil
+}
+
+func (r *fakeProgramRepo) DifficultyStats(_ context.Context, ids []uuid.UUID) (map[uuid.UUID]domain.DifficultyStats, error) {
+	stats := map[uuid.UUID]domain.DifficultyStats{}
+	for _, id := range ids {
+		if r.program != nil && r.program.ID == id && r.difficulty.Ratings > 0 {
+			stats[id] = r.difficulty
+		}
+	}
+	return stats, nil
+}
+
+func (r *fakeProgramRepo) RecentDifficulty(context.Context, uuid.UUID, int) (domain.DifficultyStats, error) {
+	return r.difficulty, nil
+}
+
+type fakeTrainingMaxRepo struct {
+	items map[uuid.UUID]map[string]*domain.TrainingMax
+}
+
+func newFakeTrainingMaxRepo() *fakeTrainingMaxRepo {
+	return &fakeTrainingMaxRepo{items: map[uuid.UUID]map[string]*domain.TrainingMax{}}
+}
+
+func (r *fakeTrainingMaxRepo) Upsert(_ context.Context, tm *domain.TrainingMax) error {
+	if r.items[tm.UserID] == nil {
+		r.items[tm.UserID] = map[string]*domain.TrainingMax{}
+	}
+	r.items[tm.UserID][domain.ExerciseKey(tm.Exercise)] = tm
+	return nil
+}
+
+func (r *fakeTrainingMaxRepo) ListByUser(_ context.Context, userID uuid.UUID) ([]*domain.TrainingMax, error) {
+	maxes := make([]*domain.TrainingMax, 0, len(r.items[userID]))
+	for _, tm := range r.items[userID] {
+		maxes = append(maxes, tm)
+	}
+	return maxes, nil
+}
+
+func (r *fakeTrainingMaxRepo) Delete(_ context.Context, userID uuid.UUID, exercise string) error {
+	key := domain.ExerciseKey(exercise)
+	if _, ok := r.items[userID][key]; !ok {
+		return repo.ErrNotFound
+	}
+	delete(r.items[userID], key)
+	return nil
+}
+
+func (r *fakeTrainingMaxRepo) DeleteByUserID(_ context.Context, userID uuid.UUID) error {
+	delete(r.items, userID)
+	return nil
+}
+
+type fakeConsentChecker struct {
+	err error
+}
+
+func (c *fakeConsentChecker) Require(context.Context, uuid.UUID, uuid.UUID, consentdomain.Scope) error {
+	return c.err
+}
+
+type scheduleFixture struct {
+	svc        programuc.Service
+	programs   *fakeProgramRepo
+	assignment *domain.Assignment
+	consents   *fakeConsentChecker
+}
+
+func newScheduleFixture(t *testing.T) scheduleFixture {
+	t.Helper()
+	percent := 75.0
+	benchPercent := 80.0
+	fixed := 40.0
+	p := domain.New(uuid.New(), "5/3/1", "", []domain.Week{
+		{Number: 1, Days: []domain.Day{
+			{Number: 1, Workouts: []domain.Workout{{
+				Title: "Ноги",
+				Exercises: []domain.Exercise{
+					{Name: "Присед", Sets: 5, Reps: 5, LoadPercent: &percent},
+					{Name: "Присед с паузой", Sets: 3, Reps: 3, LoadPercent: &percent, LoadReference: "  присед "},
+					{Name: "Жим лёжа", Sets: 3, Reps: 8, LoadPercent: &benchPercent},
+					{Name: "Выпады", Sets: 3, Reps: 10, WeightKg: &fixed},
+				},
+			}}},
+		}},
+	})
+	coachID := uuid.New()
+	a := &domain.Assignment{
+		ID:         uuid.New(),
+		ProgramID:  p.ID,
+		UserID:     uuid.New(),
+		AssignedBy: coachID,
+		StartDate:  time.Date(2025, 1, 6, 0, 0, 0, 0, time.UTC),
+	}
+	maxes := newFakeTrainingMaxRepo()
+	consents := &fakeConsentChecker{}
+	programs := &fakeProgramRepo{program: p, assignment: a}
+	svc := programuc.NewService(nil, programs, maxes, nil, nil, nil, consents)
+
+	_, err := svc.SetTrainingMax(context.Background(), a.UserID, "ПРИСЕД", 137.5)
+	require.NoError(t, err)
+	return scheduleFixture{svc: svc, programs: programs, assignment: a, consents: consents}
+}
+
+func TestSchedule_ResolvesPercentLoads(t *testing.T) {
+	f := newScheduleFixture(t)
+	actor := programuc.Actor{UserID: f.assignment.UserID, Role: userdomain.RoleUser}
+
+	schedule, err := f.svc.Schedule(context.Background(), actor, f.assignment.ID, programuc.ScheduleInput{Rules: domain.DefaultLoadRules()})
+	require.NoError(t, err)
+	require.Len(t, schedule.Workouts, 1)
+	require.Equal(t, f.assignment.StartDate, schedule.Workouts[0].Date)
+
+	exercises := schedule.Workouts[0].Exercises
+	require.Equal(t, 102.5, *exercises[0].ResolvedWeightKg)
+	require.Equal(t, 137.5, *exercises[0].TrainingMaxKg)
+	require.Equal(t, []float64{25, 15, 1.25}, exercises[0].PlatesPerSideKg)
+	require.Equal(t, 102.5, *exercises[1].ResolvedWeightKg, "reference should match case- and space-insensitively")
+	require.True(t, exercises[2].MissingTrainingMax)
+	require.Nil(t, exercises[2].ResolvedWeightKg)
+	require.Equal(t, 40.0, *exercises[3].ResolvedWeightKg)
+	require.Nil(t, exercises[3].TrainingMaxKg)
+}
+
+func TestDifficultyStats_LoadAdjustment(t *testing.T) {
+	require.Zero(t, domain.DifficultyStats{Ratings: 2, Average: 10}.LoadAdjustmentPercent(), "too few ratings")
+	require.Equal(t, -5.0, domain.DifficultyStats{Ratings: 3, Average: 9.2}.LoadAdjustmentPercent())
+	require.Equal(t, -2.5, domain.DifficultyStats{Ratings: 5, Average: 8.4}.LoadAdjustmentPercent())
+	require.Zero(t, domain.DifficultyStats{Ratings: 5, Average: 7}.LoadAdjustmentPercent())
+	require.Equal(t, 2.5, domain.DifficultyStats{Ratings: 5, Average: 5.6}.LoadAdjustmentPercent())
+	require.Equal(t, 5.0, domain.DifficultyStats{Ratings: 4, Average: 3}.LoadAdjustmentPercent())
+}
+
+func TestSchedule_DifficultyFeedback(t *testing.T) {
+	f := newScheduleFixture(t)
+	f.programs.difficulty = domain.DifficultyStats{Ratings: 5, Average: 9.4}
+	ctx := context.Background()
+	actor := programuc.Actor{UserID: f.assignment.UserID, Role: userdomain.RoleUser}
+
+	suggested, err := f.svc.Schedule(ctx, actor, f.assignment.ID, programuc.ScheduleInput{Rules: domain.DefaultLoadRules()})
+	require.NoError(t, err)
+	require.Equal(t, -5.0, suggested.LoadAdjustmentPercent)
+	require.False(t, suggested.FeedbackApplied)
+	require.Equal(t, 102.5, *suggested.Workouts[0].Exercises[0].ResolvedWeightKg)
+
+	applied, err := f.svc.Schedule(ctx, actor, f.assignment.ID, programuc.ScheduleInput{Rules: domain.DefaultLoadRules(), ApplyFeedback: true})
+	require.NoError(t, err)
+	require.True(t, applied.FeedbackApplied)
+	exercises := applied.Workouts[0].Exercises
+	require.Equal(t, 97.5, *exercises[0].ResolvedWeightKg) // 102.5 × 0.95 = 97.375 -> 97.5
+	require.Equal(t, 137.5, *exercises[0].TrainingMaxKg)
+	require.Equal(t, 37.5, *exercises[3].ResolvedWeightKg) // 40 × 0.95 = 38 -> 37.5
+	require.Equal(t, 40.0, *exercises[3].WeightKg, "program weight must stay unchanged")
+
+	p, err := f.svc.Get(ctx, programuc.Actor{UserID: f.programs.program.OwnerID, Role: userdomain.RoleCoach}, f.programs.program.ID)
+	require.NoError(t, err)
+	require.Equal(t, 5, p.Difficulty.Ratings)
+}
+
+func TestSchedule_Access(t *testing.T) {
+	f := newScheduleFixture(t)
+	ctx := context.Background()
+	input := programuc.ScheduleInput{Rules: domain.DefaultLoadRules()}
+
+	stranger := programuc.Actor{UserID: uuid.New(), Role: userdomain.RoleCoach}
+	_, err := f.svc.Schedule(ctx, stranger, f.assignment.ID, input)
+	require.ErrorIs(t, err, programuc.ErrAssignmentNotFound)
+
+	coach := programuc.Actor{UserID: f.assignment.AssignedBy, Role: userdomain.RoleCoach}
+	_, err = f.svc.Schedule(ctx, coach, f.assignment.ID, input)
+	require.NoError(t, err)
+
+	f.consents.err = consentuc.ErrConsentRequired
+	_, err = f.svc.Schedule(ctx, coach, f.assignment.ID, input)
+	require.ErrorIs(t, err, consentuc.ErrConsentRequired)
+}
+
+func TestSchedule_InvalidRules(t *testing.T) {
+	f := newScheduleFixture(t)
+	actor := programuc.Actor{UserID: f.assignment.UserID, Role: userdomain.RoleUser}
+
+	rules := domain.DefaultLoadRules()
+	rules.Mode = "sideways"
+	_, err := f.svc.Schedule(context.Background(), actor, f.assignment.ID, programuc.ScheduleInput{Rules: rules})
+	require.ErrorIs(t, err, programuc.ErrInvalidSchedule)
+}
+
+func TestCreate_RejectsWeightWithPercent(t *testing.T) {
+	percent := 70.0
+	weight := 100.0
+	svc := programuc.NewService(nil, &fakeProgramRepo{}, &fakeTrainingMaxRepo{}, nil, nil, nil, nil)
+
+	_, err := svc.Create(context.Background(), programuc.Actor{UserID: uuid.New()}, programuc.ProgramInput{
+		Title: "Bad",
+		Weeks: []domain.Week{{Days: []domain.Day{{Number: 1, Workouts: []domain.Workout{{
+			Title:     "A",
+			Exercises: []domain.Exercise{{Name: "Присед", Sets: 1, Reps: 1, WeightKg: &weight, LoadPercent: &percent}},
+		}}}}}},
+	})
+	require.ErrorIs(t, err, programuc.ErrInvalidProgram)
+}
@@ -0,0 +1,94 @@
+package program_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	domain "workout-app/internal/domain/program"
+	programuc "workout-app/internal/usecase/program"
+)
+
+func (r *fakeProgramRepo) Create(_ context.Context, p *domain.Program) error {
+	r.program = p
+	return nil
+}
+
+func TestNormalizeTempo(t *testing.T) {
+	cases := []struct {
+		in   string
+		want string
+		ok   bool
+	}{
+		{"3-1-1-0", "3-1-1-0", true},
+		{" 2-0-x-0 ", "2-0-X-0", true},
+		{"03-1-1-0", "3-1-1-0", true},
+		{"30-0-X-30", "30-0-X-30", true},
+		{"3-1-1", "", false},
+		{"3-1-1-0-0", "", false},
+		{"31-0-1-0", "", false},
+		{"3-1-a-0", "", false},
+		{"-1-1-1-1", "", false},
+		{"", "", false},
+	}
+	for _, tc := range cases {
+		got, ok := domain.NormalizeTempo(tc.in)
+		require.Equal(t, tc.ok, ok, tc.in)
+		require.Equal(t, tc.want, got, tc.in)
+	}
+}
+
+func TestExercise_RestAfterSet(t *testing.T) {
+	e := domain.Exercise{Sets: 3}
+	require.Nil(t, e.RestAfterSet(1))
+
+	e.RestSeconds = []int{90}
+	require.Equal(t, 90, *e.RestAfterSet(3))
+
+	e.RestSeconds = []int{60, 90, 120}
+	require.Equal(t, 60, *e.RestAfterSet(1))
+	require.Equal(t, 120, *e.RestAfterSet(3))
+	require.Nil(t, e.RestAfterSet(4))
+	require.Nil(t, e.RestAfterSet(0))
+}
+
+func TestCreate_ValidatesTempoAndRest(t *testing.T) {
+	programs := &fakeProgramRepo{}
+	svc := programuc.NewService(nil, programs, &fakeTrainingMaxRepo{}, nil, nil, nil, nil)
+	create := func(e domain.Exercise) (*domain.Program, error) {
+		return svc.Create(context.Background(), programuc.Actor{UserID: uuid.New()}, programuc.ProgramInput{
+			Title: "Сила",
+			Weeks: []domain.Week{{Days: []domain.Day{{Number: 1, Workouts: []domain.Workout{{
+				Title:     "A",
+				Exercises: []domain.Exercise{e},
+			}}}}}},
+		})
+	}
+
+	p, err := create(domain.Exercise{Name: "Присед", Sets: 3, Reps: 5, Tempo: "3-1-x-0", RestSeconds: []int{120, 150, 180}})
+	require.NoError(t, err)
+	e := p.Weeks[0].Days[0].Workouts[0].Exercises[0]
+	require.Equal(t, "3-1-X-0", e.Tempo)
+	require.Equal(t, []int{120, 150, 180}, e.RestSeconds)
+
+	for _, bad := range []domain.Exercise{
+		{Name: "Присед", Sets: 3, Reps: 5, Tempo: "3-1"},
+		{Name: "Присед", Sets: 3, Reps: 5, RestSeconds: []int{120, 150}},
+		{Name: "Присед", Sets: 1, Reps: 5, RestSeconds: []int{-1}},
+		{Name: "Присед", Sets: 1, Reps: 5, RestSeconds: []int{domain.MaxRestSeconds + 1}},
+	} {
+		_, err := create(bad)
+		require.ErrorIs(t, err, programuc.ErrInvalidProgram)
+	}
+}
+
+func TestProgramClone_CopiesRestPrescriptions(t *testing.T) {
+	source := sampleProgram()
+	source.Weeks[0].Days[0].Workouts[0].Exercises[0].RestSeconds = []int{180}
+	clone := source.Clone(uuid.New())
+
+	clone.Weeks[0].Days[0].Workouts[0].Exercises[0].RestSeconds[0] = 60
+	require.Equal(t, 180, source.Weeks[0].Days[0].Workouts[0].Exercises[0].RestSeconds[0])
+}
@@ -0,0 +1,129 @@
+package progress_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	userdomain "workout-app/internal/domain/user"
+	domain "workout-app/internal/domain/workout"
+	repo "workout-app/internal/repository/interfaces"
+	progressuc "workout-app/internal/usecase/progress"
+)
+
+// fakeStats возвращает заранее заданные агрегаты и запоминает параметры запроса.
+type fakeStats struct {
+	weeks             []domain.WeekStats
+	groups            []domain.MuscleGroupStats
+	dayRuns, weekRuns domain.Runs
+
+	from, to time.Time
+	tz       string
+}
+
+func (r *fakeStats) Weekly(_ context.Context, _ uuid.UUID, from, to time.Time, tz string) ([]domain.WeekStats, error) {
+	r.from, r.to, r.tz = from, to, tz
+	return r.weeks, nil
+}
+
+func (r *fakeStats) MuscleGroups(_ context.Context, _ uuid.UUID, _, _ time.Time) ([]domain.MuscleGroupStats, error) {
+	return r.groups, nil
+}
+
+func (r *fakeStats) Streaks(_ context.Context, _ uuid.UUID, _ string) (domain.Runs, domain.Runs, error) {
+	return r.dayRuns, r.weekRuns, nil
+}
+
+func (r *fakeStats) PersonalRecords(context.Context, uuid.UUID, time.Time, time.Time) ([]domain.PersonalRecord, error) {
+	return nil, nil
+}
+
+// fakeUsers возвращает любого пользователя с заданным часовым поясом.
+type fakeUsers struct {
+	repo.UserRepository
+	timezone string
+}
+
+func (r *fakeUsers) GetByID(_ context.Context, id uuid.UUID) (*userdomain.User, error) {
+	return &userdomain.User{ID: id, Timezone: r.timezone}, nil
+}
+
+// monday возвращает понедельник недели t как дату в полночь UTC.
+func monday(t time.Time) time.Time {
+	t = time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	return t.AddDate(0, 0, -((int(t.Weekday()) + 6) % 7))
+}
+
+func TestStats_RejectsUnknownRange(t *testing.T) {
+	svc := progressuc.NewService(&fakeStats{}, &fakeUsers{})
+
+	_, err := svc.Stats(context.Background(), uuid.New(), "14d")
+	require.ErrorIs(t, err, progressuc.ErrInvalidRange)
+}
+
+func TestStats_FillsEmptyWeeksAndSumsTotals(t *testing.T) {
+	thisWeek := monday(time.Now().UTC())
+	stats := &fakeStats{
+		weeks: []domain.WeekStats{
+			{WeekStart: thisWeek.AddDate(0, 0, -14), Sessions: 2, Sets: 10, Reps: 80, VolumeKg: 4000, TrainingDays: 2},
+			{WeekStart: thisWeek, Sessions: 1, Sets: 6, Reps: 30, VolumeKg: 1500.5, TrainingDays: 1},
+		},
+		groups: []domain.MuscleGroupStats{
+			{Group: domain.MuscleLegs, Sets: 8, VolumeKg: 4000},
+			{Group: domain.MuscleChest, Sets: 5, VolumeKg: 1000},
+		},
+	}
+	svc := progressuc.NewService(stats, &fakeUsers{})
+
+	got, err := svc.Stats(context.Background(), uuid.New(), progressuc.RangeAll)
+	require.NoError(t, err)
+
+	require.Len(t, got.Weeks, 3)
+	require.Equal(t, thisWeek.AddDate(0, 0, -7), got.Weeks[1].WeekStart)
+	require.Zero(t, got.Weeks[1].Sessions)
+	require.Equal(t, 3, got.Totals.Sessions)
+	require.Equal(t, 16, got.Totals.Sets)
+	require.Equal(t, 110, got.Totals.Reps)
+	require.InDelta(t, 5500.5, got.Totals.VolumeKg, 1e-9)
+	require.InDelta(t, 1.0, got.Totals.SessionsPerWeek, 1e-9)
+	require.Equal(t, 3, got.UnclassifiedSets)
+	require.Equal(t, thisWeek.AddDate(0, 0, -14), got.From)
+}
+
+func TestStats_RangeStartsAtUserMidnight(t *testing.T) {
+	stats := &fakeStats{}
+	svc := progressuc.NewService(stats, &fakeUsers{timezone: "Asia/Tokyo"})
+
+	got, err := svc.Stats(context.Background(), uuid.New(), progressuc.Range7Days)
+	require.NoError(t, err)
+
+	loc, err := time.LoadLocation("Asia/Tokyo")
+	require.NoError(t, err)
+	from, to := stats.from.In(loc), stats.to.In(loc)
+	require.Equal(t, "Asia/Tokyo", stats.tz)
+	require.Zero(t, from.Hour())
+	require.Equal(t, to.AddDate(0, 0, -6).Format(time.DateOnly), from.Format(time.DateOnly))
+	require.Equal(t, "Asia/Tokyo", got.Timezone)
+	require.NotEmpty(t, got.Weeks)
+	require.Zero(t, got.Totals.Sessions)
+	require.Zero(t, got.Totals.SessionsPerWeek)
+}
+
+func TestStats_CurrentStreakEndsAfterMissedPeriod(t *testing.T) {
+	now := time.Now().UTC()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	stats := &fakeStats{
+		// Последняя тренировка вчера: серия дней продолжается, пока не закончился сегодняшний день.
+		dayRuns: domain.Runs{Longest: 9, Last: 4, LastEnd: today.AddDate(0, 0, -1)},
+		// Последняя неделя с тренировкой — позапрошлая: серия недель прервана.
+		weekRuns: domain.Runs{Longest: 6, Last: 3, LastEnd: monday(now).AddDate(0, 0, -14)},
+	}
+	svc := progressuc.NewService(stats, &fakeUsers{})
+
+	got, err := svc.Stats(context.Background(), uuid.New(), progressuc.Range30Days)
+	require.NoError(t, err)
+	require.Equal(t, progressuc.Streaks{CurrentDays: 4, LongestDays: 9, CurrentWeeks: 0, LongestWeeks: 6}, got.Streaks)
+}
@@ -0,0 +1,356 @@
+package push_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"workout-app/internal/domain/device"
+	"workout-app/internal/domain/notification"
+	userdomain "workout-app/internal/domain/user"
+	"workout-app/internal/push"
+	repo "workout-app/internal/repository/interfaces"
+	pushuc "workout-app/internal/usecase/push"
+	"workout-app/pkg/logger"
+	pushpkg "workout-app/pkg/push"
+)
+
+// pkcs8PEM кодирует закрытый ключ в PEM, как в файлах ключей Google и Apple.
+func pkcs8PEM(t *testing.T, key any) []byte {
+	t.Helper()
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})
+}
+
+// newFCMServer запускает сервер, выдающий access-токен и отвечающий на отправку status и body.
+func newFCMServer(t *testing.T, status int, body string) (*httptest.Server, *int, *http.Header, *string) {
+	t.Helper()
+	tokenRequests := 0
+	var header http.Header
+	var sent string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/token" {
+			tokenRequests++
+			require.NoError(t, r.ParseForm())
+			require.Equal(t, "urn:ietf:params:oauth:grant-type:jwt-bearer", r.PostForm.Get("grant_type"))
+			_, _ = w.Write([]byte(`{"access_token":"access-1","expires_in":3600}`))
+			return
+		}
+		raw, _ := io.ReadAll(r.Body)
+		header, sent = r.Header.Clone(), r.URL.Path+" "+string(raw)
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(body))
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &tokenRequests, &header, &sent
+}
+
+func newFCM(t *testing.T, srv *httptest.Server) *pushpkg.FCM {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	fcm, err := pushpkg.NewFCM(pushpkg.FCMConfig{
+		Credentials: pushpkg.FCMCredentials{
+			ProjectID:   "workout",
+			ClientEmail: "push@workout.iam.gserviceaccount.com",
+			PrivateKey:  string(pkcs8PEM(t, key)),
+			TokenURI:    srv.URL + "/token",
+		},
+		BaseURL: srv.URL,
+	})
+	require.NoError(t, err)
+	return fcm
+}
+
+func TestFCM_SendsMessageAndCachesAccessToken(t *testing.T) {
+	srv, tokenRequests, header, sent := newFCMServer(t, http.StatusOK, `{"name":"projects/workout/messages/1"}`)
+	fcm := newFCM(t, srv)
+	msg := &pushpkg.Message{Token: "device-1", Title: "Заголовок", Body: "Текст", Data: map[string]string{"type": "class.booked"}}
+
+	require.NoError(t, fcm.Send(context.Background(), msg))
+	require.NoError(t, fcm.Send(context.Background(), msg))
+	require.Equal(t, 1, *tokenRequests)
+	require.Equal(t, "Bearer access-1", header.Get("Authorization"))
+
+	path, body, _ := strings.Cut(*sent, " ")
+	require.Equal(t, "/v1/projects/workout/messages:send", path)
+	var payload struct {
+		Message struct {
+			Token        string            `json:"token"`
+			Notification map[string]string `json:"notification"`
+			Data         map[string]string `json:"data"`
+		} `json:"message"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(body), &payload))
+	require.Equal(t, "device-1", payload.Message.Token)
+	require.Equal(t, "Заголовок", payload.Message.Notification["title"])
+	require.Equal(t, "class.booked", payload.Message.Data["type"])
+}
+
+func TestFCM_MapsErrors(t *testing.T) {
+	cases := []struct {
+		name   string
+		status int
+		body   string
+		want   error
+	}{
+		{"unregistered", http.StatusNotFound, `{"error":{"status":"NOT_FOUND","details":[{"errorCode":"UNREGISTERED"}]}}`, pushpkg.ErrTokenInvalid},
+		{"sender mismatch", http.StatusForbidden, `{"error":{"status":"PERMISSION_DENIED","details":[{"errorCode":"SENDER_ID_MISMATCH"}]}}`, pushpkg.ErrTokenInvalid},
+		{"invalid argument", http.StatusBadRequest, `{"error":{"status":"INVALID_ARGUMENT"}}`, pushpkg.ErrMessageRejected},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			srv, _, _, _ := newFCMServer(t, tc.status, tc.body)
+			err := newFCM(t, srv).Send(context.Background(), &pushpkg.Message{Token: "device-1"})
+			require.ErrorIs(t, err, tc.want)
+		})
+	}
+
+	srv, _, _, _ := newFCMServer(t, http.StatusServiceUnavailable, `{"error":{"status":"UNAVAILABLE"}}`)
+	err := newFCM(t, srv).Send(context.Background(), &pushpkg.Message{Token: "device-1"})
+	var serviceErr *pushpkg.ServiceError
+	require.ErrorAs(t, err, &serviceErr)
+	require.Equal(t, "UNAVAILABLE", serviceErr.Reason)
+	require.False(t, errors.Is(err, pushpkg.ErrTokenInvalid))
+}
+
+func newAPNs(t *testing.T, status int, body string) (*pushpkg.APNs, *http.Request) {
+	t.Helper()
+	captured := &http.Request{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*captured = *r.Clone(context.Background())
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(body))
+	}))
+	t.Cleanup(srv.Close)
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	apns, err := pushpkg.NewAPNs(pushpkg.APNsConfig{
+		KeyID:      "KEY123",
+		TeamID:     "TEAM123",
+		PrivateKey: pkcs8PEM(t, key),
+		Topic:      "com.example.workout",
+		BaseURL:    srv.URL,
+	})
+	require.NoError(t, err)
+	return apns, captured
+}
+
+func TestAPNs_SendsAlertToDevice(t *testing.T) {
+	apns, req := newAPNs(t, http.StatusOK, "")
+
+	require.NoError(t, apns.Send(context.Background(), &pushpkg.Message{Token: "abc123", Title: "Title", Body: "Body"}))
+	require.Equal(t, "/3/device/abc123", req.URL.Path)
+	require.Equal(t, "com.example.workout", req.Header.Get("apns-topic"))
+	require.Equal(t, "alert", req.Header.Get("apns-push-type"))
+	require.True(t, strings.HasPrefix(req.Header.Get("Authorization"), "bearer "))
+}
+
+func TestAPNs_MapsErrors(t *testing.T) {
+	apns, _ := newAPNs(t, http.StatusGone, `{"reason":"Unregistered"}`)
+	require.ErrorIs(t, apns.Send(context.Background(), &pushpkg.Message{Token: "abc123"}), pushpkg.ErrTokenInvalid)
+
+	apns, _ = newAPNs(t, http.StatusBadRequest, `{"reason":"BadDeviceToken"}`)
+	require.ErrorIs(t, apns.Send(context.Background(), &pushpkg.Message{Token: "abc123"}), pushpkg.ErrTokenInvalid)
+
+	apns, _ = newAPNs(t, http.StatusBadRequest, `{"reason":"PayloadEmpty"}`)
+	require.ErrorIs(t, apns.Send(context.Background(), &pushpkg.Message{Token: "abc123"}), pushpkg.ErrMessageRejected)
+}
+
+type fakeDevices struct {
+	devices []*device.Device
+}
+
+func (r *fakeDevices) Upsert(_ context.Context, d *device.Device) error {
+	for _, existing := range r.devices {
+		if existing.Token == d.Token {
+			existing.UserID, existing.Platform, existing.LastSeenAt = d.UserID, d.Platform, d.LastSeenAt
+			d.ID, d.CreatedAt = existing.ID, existing.CreatedAt
+			return nil
+		}
+	}
+	r.devices = append(r.devices, d)
+	return nil
+}
+
+func (r *fakeDevices) ListByUser(_ context.Context, userID uuid.UUID) ([]*device.Device, error) {
+	var out []*device.Device
+	for _, d := range r.devices {
+		if d.UserID == userID {
+			out = append(out, d)
+		}
+	}
+	return out, nil
+}
+
+func (r *fakeDevices) Delete(_ context.Context, userID, id uuid.UUID) error {
+	for i, d := range r.devices {
+		if d.ID == id && d.UserID == userID {
+			r.devices = append(r.devices[:i], r.devices[i+1:]...)
+			return nil
+		}
+	}
+	return repo.ErrNotFound
+}
+
+func (r *fakeDevices) DeleteByToken(_ context.Context, token string) error {
+	for i, d := range r.devices {
+		if d.Token == token {
+			r.devices = append(r.devices[:i], r.devices[i+1:]...)
+			return nil
+		}
+	}
+	return nil
+}
+
+func (r *fakeDevices) DeleteByUserID(context.Context, uuid.UUID) error { return nil }
+
+// fakeSender отвечает ошибкой errs[token] и запоминает отправленные сообщения.
+type fakeSender struct {
+	errs map[string]error
+	sent []pushpkg.Message
+}
+
+func (s *fakeSender) Name() string { return "fake" }
+
+func (s *fakeSender) Send(_ context.Context, msg *pushpkg.Message) error {
+	if err := s.errs[msg.Token]; err != nil {
+		return err
+	}
+	s.sent = append(s.sent, *msg)
+	return nil
+}
+
+func TestNotifier_RemovesInvalidTokens(t *testing.T) {
+	userID := uuid.New()
+	now := time.Now().UTC()
+	devices := &fakeDevices{devices: []*device.Device{
+		device.NewDevice(userID, device.PlatformAndroid, "valid", now),
+		device.NewDevice(userID, device.PlatformAndroid, "expired", now),
+		device.NewDevice(userID, device.PlatformIOS, "failing", now),
+	}}
+	android := &fakeSender{errs: map[string]error{"expired": pushpkg.ErrTokenInvalid}}
+	ios := &fakeSender{errs: map[string]error{"failing": errors.New("unavailable")}}
+	notifier := push.NewNotifier(devices, map[device.Platform]pushpkg.Sender{
+		device.PlatformAndroid: android,
+		device.PlatformIOS:     ios,
+	}, "en", logger.Default())
+
+	item := notification.NewNotification(userID, notification.TypeClassBooked, []byte(`{}`), 1, now)
+	delivered, err := notifier.Notify(context.Background(), userID, "ru", item)
+	require.NoError(t, err)
+	require.Equal(t, 1, delivered)
+	require.Len(t, android.sent, 1)
+	require.Equal(t, item.ID.String(), android.sent[0].Data["notification_id"])
+	require.NotEmpty(t, android.sent[0].Title)
+
+	// Токен с временной ошибкой остаётся, недействительный удаляется.
+	remaining, _ := devices.ListByUser(context.Background(), userID)
+	require.Len(t, remaining, 2)
+	for _, d := range remaining {
+		require.NotEqual(t, "expired", d.Token)
+	}
+}
+
+type fakeNotifications struct {
+	repo.NotificationRepository
+	pending []*notification.Notification
+	claimed map[uuid.UUID]bool
+}
+
+func (r *fakeNotifications) ExpirePush(context.Context, time.Time) (int64, error) { return 0, nil }
+
+func (r *fakeNotifications) ListPushPending(context.Context, int) ([]*notification.Notification, error) {
+	return r.pending, nil
+}
+
+func (r *fakeNotifications) ClaimPush(_ context.Context, id uuid.UUID) (bool, error) {
+	if r.claimed[id] {
+		return false, nil
+	}
+	r.claimed[id] = true
+	return true, nil
+}
+
+type fakeUsers struct {
+	repo.UserRepository
+	users map[uuid.UUID]*userdomain.User
+}
+
+func (r *fakeUsers) GetByIDIncludingDeleted(_ context.Context, id uuid.UUID) (*userdomain.User, error) {
+	if u, ok := r.users[id]; ok {
+		return u, nil
+	}
+	return nil, repo.ErrNotFound
+}
+
+type recordingNotifier struct {
+	notified []uuid.UUID
+}
+
+func (n *recordingNotifier) Notify(_ context.Context, _ uuid.UUID, _ string, item *notification.Notification) (int, error) {
+	n.notified = append(n.notified, item.ID)
+	return 1, nil
+}
+
+func TestRun_SendsClaimedNotificationsOfActiveUsers(t *testing.T) {
+	now := time.Now().UTC()
+	active := userdomain.NewUser("active@example.com", "hash", "active")
+	deleted := userdomain.NewUser("deleted@example.com", "hash", "deleted")
+	deleted.DeletedAt = &now
+
+	toActive := notification.NewNotification(active.ID, notification.TypeClassBooked, []byte(`{}`), 1, now)
+	toDeleted := notification.NewNotification(deleted.ID, notification.TypeClassBooked, []byte(`{}`), 2, now)
+	alreadyClaimed := notification.NewNotification(active.ID, notification.TypeClassBooked, []byte(`{}`), 3, now)
+	store := &fakeNotifications{
+		pending: []*notification.Notification{toActive, toDeleted, alreadyClaimed},
+		claimed: map[uuid.UUID]bool{alreadyClaimed.ID: true},
+	}
+	notifier := &recordingNotifier{}
+	users := &fakeUsers{users: map[uuid.UUID]*userdomain.User{active.ID: active, deleted.ID: deleted}}
+	svc := pushuc.NewService(&fakeDevices{}, store, users, notifier, pushuc.Config{BatchSize: 10}, logger.Default())
+
+	require.NoError(t, svc.Run(context.Background()))
+	require.Equal(t, []uuid.UUID{toActive.ID}, notifier.notified)
+	require.True(t, store.claimed[toDeleted.ID])
+}
+
+func TestRegisterDevice_ValidatesAndReassignsToken(t *testing.T) {
+	devices := &fakeDevices{}
+	svc := pushuc.NewService(devices, &fakeNotifications{}, &fakeUsers{}, &recordingNotifier{}, pushuc.Config{BatchSize: 10}, logger.Default())
+	ctx := context.Background()
+
+	_, err := svc.RegisterDevice(ctx, uuid.New(), "web", "token")
+	require.ErrorIs(t, err, pushuc.ErrInvalidPlatform)
+	_, err = svc.RegisterDevice(ctx, uuid.New(), device.PlatformIOS, "bad token")
+	require.ErrorIs(t, err, pushuc.ErrInvalidToken)
+
+	first, err := svc.RegisterDevice(ctx, uuid.New(), device.PlatformIOS, "token-1")
+	require.NoError(t, err)
+	newOwner := uuid.New()
+	second, err := svc.RegisterDevice(ctx, newOwner, device.PlatformIOS, "token-1")
+	require.NoError(t, err)
+	require.Equal(t, first.ID, second.ID)
+	require.Len(t, devices.devices, 1)
+	require.Equal(t, newOwner, devices.devices[0].UserID)
+
+	require.ErrorIs(t, svc.DeleteDevice(ctx, uuid.New(), first.ID), pushuc.ErrDeviceNotFound)
+	require.NoError(t, svc.DeleteDevice(ctx, newOwner, first.ID))
+}
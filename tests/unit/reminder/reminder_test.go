@@ -0,0 +1,179 @@
+package reminder_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log/slog"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	eventdomain "workout-app/internal/domain/event"
+	programdomain "workout-app/internal/domain/program"
+	domain "workout-app/internal/domain/reminder"
+	userdomain "workout-app/internal/domain/user"
+	repo "workout-app/internal/repository/interfaces"
+	reminderuc "workout-app/internal/usecase/reminder"
+	"workout-app/pkg/logger"
+)
+
+type fakeTx struct{}
+
+func (fakeTx) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+type fakeUsers struct {
+	repo.UserRepository
+	users []*userdomain.User
+}
+
+func (r *fakeUsers) ListReminderRecipients(_ context.Context, after uuid.UUID, limit int) ([]*userdomain.User, error) {
+	sort.Slice(r.users, func(i, j int) bool { return bytes.Compare(r.users[i].ID[:], r.users[j].ID[:]) < 0 })
+	var out []*userdomain.User
+	for _, u := range r.users {
+		if u.ReminderTime != nil && bytes.Compare(u.ID[:], after[:]) > 0 && len(out) < limit {
+			out = append(out, u)
+		}
+	}
+	return out, nil
+}
+
+type fakePrograms struct {
+	repo.ProgramRepository
+	programs    map[uuid.UUID]*programdomain.Program
+	assignments map[uuid.UUID][]*programdomain.Assignment
+}
+
+func (r *fakePrograms) ListAssignmentsByUser(_ context.Context, userID uuid.UUID) ([]*programdomain.Assignment, error) {
+	return r.assignments[userID], nil
+}
+
+func (r *fakePrograms) GetByID(_ context.Context, id uuid.UUID) (*programdomain.Program, error) {
+	if p, ok := r.programs[id]; ok {
+		return p, nil
+	}
+	return nil, repo.ErrNotFound
+}
+
+type claimKey struct {
+	userID uuid.UUID
+	date   time.Time
+}
+
+type fakeReminders struct {
+	claimed map[claimKey]bool
+}
+
+func (r *fakeReminders) Claim(_ context.Context, rem *domain.Reminder) (bool, error) {
+	key := claimKey{rem.UserID, rem.Date}
+	if r.claimed[key] {
+		return false, nil
+	}
+	r.claimed[key] = true
+	return true, nil
+}
+
+func (r *fakeReminders) DeleteBefore(context.Context, time.Time, int) (int64, error) { return 0, nil }
+
+type fakePublisher struct {
+	events []eventdomain.WorkoutReminder
+}
+
+func (p *fakePublisher) Publish(_ context.Context, eventType, _ string, payload any) {
+	if eventType == eventdomain.TypeWorkoutReminder {
+		p.events = append(p.events, payload.(eventdomain.WorkoutReminder))
+	}
+}
+
+type fixture struct {
+	svc       reminderuc.Service
+	users     *fakeUsers
+	programs  *fakePrograms
+	reminders *fakeReminders
+	events    *fakePublisher
+}
+
+func newFixture(batchSize int) *fixture {
+	f := &fixture{
+		users: &fakeUsers{},
+		programs: &fakePrograms{
+			programs:    map[uuid.UUID]*programdomain.Program{},
+			assignments: map[uuid.UUID][]*programdomain.Assignment{},
+		},
+		reminders: &fakeReminders{claimed: map[claimKey]bool{}},
+		events:    &fakePublisher{},
+	}
+	log := logger.New(io.Discard, slog.LevelError, logger.FormatJSON)
+	f.svc = reminderuc.NewService(fakeTx{}, f.users, f.programs, f.reminders, f.events, reminderuc.Config{BatchSize: batchSize}, log)
+	return f
+}
+
+// addUser добавляет пользователя, время напоминания которого наступает через offset от текущего момента
+// в его часовом поясе.
+func (f *fixture) addUser(timezone string, offset time.Duration) *userdomain.User {
+	u := userdomain.NewUser(uuid.NewString()+"@example.com", "hash", "")
+	u.Timezone = timezone
+	local := time.Now().In(u.Location()).Add(offset)
+	at := userdomain.ReminderTime(local.Hour()*60 + local.Minute())
+	u.ReminderTime = &at
+	f.users.users = append(f.users.users, u)
+	return u
+}
+
+// assignToday назначает пользователю программу с тренировкой в первый день, начинающуюся сегодня.
+func (f *fixture) assignToday(u *userdomain.User, title string) {
+	p := programdomain.New(uuid.New(), "Сила 5×5", "", []programdomain.Week{
+		{Number: 1, Days: []programdomain.Day{{Number: 1, Workouts: []programdomain.Workout{{Title: title}}}}},
+	})
+	f.programs.programs[p.ID] = p
+	f.programs.assignments[u.ID] = append(f.programs.assignments[u.ID], &programdomain.Assignment{
+		ID:        uuid.New(),
+		ProgramID: p.ID,
+		UserID:    u.ID,
+		StartDate: domain.LocalDate(time.Now(), u.Location()),
+	})
+}
+
+func TestRun_SendsOncePerDay(t *testing.T) {
+	f := newFixture(2)
+	ctx := context.Background()
+	due := f.addUser("Asia/Tokyo", 0)
+	f.assignToday(due, "Ноги")
+	f.assignToday(due, "Спина")
+	idle := f.addUser("", 0)
+	early := f.addUser("America/New_York", 3*time.Hour)
+	f.assignToday(early, "Грудь")
+
+	require.NoError(t, f.svc.Run(ctx))
+	require.Len(t, f.events.events, 1)
+	event := f.events.events[0]
+	require.Equal(t, due.ID.String(), event.UserID)
+	require.Equal(t, domain.LocalDate(time.Now(), due.Location()), event.Date)
+	require.ElementsMatch(t, []string{"Ноги", "Спина"}, event.Workouts)
+
+	// Пользователь без тренировок отмечен, пользователь, чьё время не наступило, — нет.
+	require.True(t, f.reminders.claimed[claimKey{idle.ID, domain.LocalDate(time.Now(), idle.Location())}])
+	require.Len(t, f.reminders.claimed, 2)
+
+	require.NoError(t, f.svc.Run(ctx))
+	require.Len(t, f.events.events, 1)
+}
+
+func TestRun_SkipsLateAndSuspended(t *testing.T) {
+	f := newFixture(10)
+	ctx := context.Background()
+	late := f.addUser("Europe/Moscow", -3*time.Hour)
+	f.assignToday(late, "Ноги")
+	suspended := f.addUser("Europe/Moscow", 0)
+	suspended.Suspension = &userdomain.Suspension{At: time.Now(), Reason: "spam"}
+	f.assignToday(suspended, "Ноги")
+
+	require.NoError(t, f.svc.Run(ctx))
+	require.Empty(t, f.events.events)
+	require.Empty(t, f.reminders.claimed)
+}
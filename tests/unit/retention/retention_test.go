@@ -0,0 +1,93 @@
+package retention_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	repo "workout-app/internal/repository/interfaces"
+	anonymizationuc "workout-app/internal/usecase/anonymization"
+	retentionuc "workout-app/internal/usecase/retention"
+	"workout-app/pkg/logger"
+)
+
+// fakeUsers возвращает кандидатов, ещё не обработанных fakePurger.
+type fakeUsers struct {
+	repo.UserRepository
+	candidates []uuid.UUID
+	before     time.Time
+	purger     *fakePurger
+}
+
+func (r *fakeUsers) ListPurgeCandidates(_ context.Context, before time.Time, limit int) ([]uuid.UUID, error) {
+	r.before = before
+	var ids []uuid.UUID
+	for _, id := range r.candidates {
+		if !r.purger.done[id] && len(ids) < limit {
+			ids = append(ids, id)
+		}
+	}
+	return ids, nil
+}
+
+type fakePurger struct {
+	errs map[uuid.UUID]error
+	done map[uuid.UUID]bool
+}
+
+func (p *fakePurger) Anonymize(_ context.Context, id uuid.UUID) error {
+	p.done[id] = true
+	return p.errs[id]
+}
+
+func (p *fakePurger) Delete(context.Context, uuid.UUID) error { return nil }
+
+func newService(t *testing.T, candidates []uuid.UUID, errs map[uuid.UUID]error) (retentionuc.Service, *fakeUsers, *fakePurger) {
+	t.Helper()
+	purger := &fakePurger{errs: errs, done: map[uuid.UUID]bool{}}
+	users := &fakeUsers{candidates: candidates, purger: purger}
+	log := logger.New(io.Discard, slog.LevelError, logger.FormatJSON)
+	return retentionuc.NewService(users, purger, 30*24*time.Hour, log), users, purger
+}
+
+func TestRun_PurgesExpiredAccountsAndSkipsLegalHold(t *testing.T) {
+	held := uuid.New()
+	candidates := []uuid.UUID{uuid.New(), held, uuid.New()}
+	svc, users, purger := newService(t, candidates, map[uuid.UUID]error{held: anonymizationuc.ErrUnderLegalHold})
+
+	result, err := svc.Run(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, retentionuc.Result{Purged: 2, Skipped: 1}, result)
+	require.Equal(t, int64(2), svc.Purged())
+	require.Len(t, purger.done, 3)
+	require.WithinDuration(t, time.Now().Add(-30*24*time.Hour), users.before, time.Minute)
+}
+
+func TestRun_ProcessesMultipleBatches(t *testing.T) {
+	candidates := make([]uuid.UUID, 250)
+	for i := range candidates {
+		candidates[i] = uuid.New()
+	}
+	svc, _, _ := newService(t, candidates, nil)
+
+	result, err := svc.Run(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 250, result.Purged)
+}
+
+func TestRun_StopsOnUnexpectedError(t *testing.T) {
+	broken := uuid.New()
+	candidates := []uuid.UUID{uuid.New(), broken, uuid.New()}
+	svc, _, purger := newService(t, candidates, map[uuid.UUID]error{broken: errors.New("db down")})
+
+	result, err := svc.Run(context.Background())
+	require.Error(t, err)
+	require.Equal(t, 1, result.Purged)
+	require.Len(t, purger.done, 2)
+}
@@ -0,0 +1,217 @@
+package share_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	programdomain "workout-app/internal/domain/program"
+	domain "workout-app/internal/domain/share"
+	userdomain "workout-app/internal/domain/user"
+	workoutdomain "workout-app/internal/domain/workout"
+	repo "workout-app/internal/repository/interfaces"
+	shareuc "workout-app/internal/usecase/share"
+)
+
+// fakeShares хранит ссылки в памяти.
+type fakeShares struct {
+	shares map[uuid.UUID]*domain.Share
+}
+
+func (r *fakeShares) Create(_ context.Context, s *domain.Share) error {
+	cp := *s
+	r.shares[s.ID] = &cp
+	return nil
+}
+
+func (r *fakeShares) GetByTokenHash(_ context.Context, tokenHash string) (*domain.Share, error) {
+	for _, s := range r.shares {
+		if s.TokenHash == tokenHash {
+			cp := *s
+			return &cp, nil
+		}
+	}
+	return nil, repo.ErrNotFound
+}
+
+func (r *fakeShares) ListActiveByOwner(_ context.Context, ownerID uuid.UUID) ([]*domain.Share, error) {
+	var out []*domain.Share
+	for _, s := range r.shares {
+		if s.OwnerID == ownerID && !s.IsRevoked() {
+			out = append(out, s)
+		}
+	}
+	return out, nil
+}
+
+func (r *fakeShares) CountActiveByOwner(ctx context.Context, ownerID uuid.UUID) (int, error) {
+	active, err := r.ListActiveByOwner(ctx, ownerID)
+	return len(active), err
+}
+
+func (r *fakeShares) Revoke(_ context.Context, id, ownerID uuid.UUID, at time.Time) error {
+	s, ok := r.shares[id]
+	if !ok || s.OwnerID != ownerID || s.IsRevoked() {
+		return repo.ErrNotFound
+	}
+	s.RevokedAt = &at
+	return nil
+}
+
+type fakeWorkouts struct {
+	repo.WorkoutSessionRepository
+	sessions map[uuid.UUID]*workoutdomain.Session
+}
+
+func (r *fakeWorkouts) GetByID(_ context.Context, id uuid.UUID) (*workoutdomain.Session, error) {
+	if s, ok := r.sessions[id]; ok {
+		return s, nil
+	}
+	return nil, repo.ErrNotFound
+}
+
+type fakePrograms struct {
+	repo.ProgramRepository
+	programs map[uuid.UUID]*programdomain.Program
+}
+
+func (r *fakePrograms) GetByID(_ context.Context, id uuid.UUID) (*programdomain.Program, error) {
+	if p, ok := r.programs[id]; ok {
+		return p, nil
+	}
+	return nil, repo.ErrNotFound
+}
+
+// fakeUsers возвращает пользователей, кроме мягко удалённых, как настоящий репозиторий.
+type fakeUsers struct {
+	repo.UserRepository
+	users map[uuid.UUID]*userdomain.User
+}
+
+func (r *fakeUsers) GetByID(_ context.Context, id uuid.UUID) (*userdomain.User, error) {
+	if u, ok := r.users[id]; ok && !u.IsDeleted() {
+		return u, nil
+	}
+	return nil, repo.ErrNotFound
+}
+
+type fixture struct {
+	svc      shareuc.Service
+	shares   *fakeShares
+	workouts *fakeWorkouts
+	programs *fakePrograms
+	users    *fakeUsers
+}
+
+func newFixture() *fixture {
+	f := &fixture{
+		shares:   &fakeShares{shares: map[uuid.UUID]*domain.Share{}},
+		workouts: &fakeWorkouts{sessions: map[uuid.UUID]*workoutdomain.Session{}},
+		programs: &fakePrograms{programs: map[uuid.UUID]*programdomain.Program{}},
+		users:    &fakeUsers{users: map[uuid.UUID]*userdomain.User{}},
+	}
+	f.svc = shareuc.NewService(f.shares, f.workouts, f.programs, f.users)
+	return f
+}
+
+func (f *fixture) addUser() *userdomain.User {
+	u := userdomain.NewUser(uuid.NewString()+"@example.com", "hash", "")
+	f.users.users[u.ID] = u
+	return u
+}
+
+func (f *fixture) addWorkout(userID uuid.UUID) *workoutdomain.Session {
+	s := workoutdomain.NewSession(userID, "Ноги", nil, 5*time.Minute, time.Now().UTC().Add(-time.Hour))
+	f.workouts.sessions[s.ID] = s
+	return s
+}
+
+func TestShare_CreateViewRevoke(t *testing.T) {
+	f := newFixture()
+	ctx := context.Background()
+	owner := f.addUser()
+	workout := f.addWorkout(owner.ID)
+
+	created, err := f.svc.Create(ctx, owner.ID, domain.ResourceWorkout, workout.ID)
+	require.NoError(t, err)
+	require.NotEmpty(t, created.Token)
+	// В хранилище только хэш токена.
+	stored := f.shares.shares[created.Share.ID]
+	require.NotEqual(t, created.Token, stored.TokenHash)
+	require.Equal(t, domain.HashToken(created.Token), stored.TokenHash)
+
+	view, err := f.svc.View(ctx, created.Token)
+	require.NoError(t, err)
+	require.Equal(t, workout.ID, view.Workout.ID)
+	require.Nil(t, view.Program)
+
+	_, err = f.svc.View(ctx, created.Token+"x")
+	require.ErrorIs(t, err, shareuc.ErrShareNotFound)
+
+	// Чужую ссылку отозвать нельзя.
+	require.ErrorIs(t, f.svc.Revoke(ctx, uuid.New(), created.Share.ID), shareuc.ErrShareNotFound)
+	require.NoError(t, f.svc.Revoke(ctx, owner.ID, created.Share.ID))
+	require.ErrorIs(t, f.svc.Revoke(ctx, owner.ID, created.Share.ID), shareuc.ErrShareNotFound)
+
+	_, err = f.svc.View(ctx, created.Token)
+	require.ErrorIs(t, err, shareuc.ErrShareNotFound)
+	shares, err := f.svc.List(ctx, owner.ID)
+	require.NoError(t, err)
+	require.Empty(t, shares)
+}
+
+func TestShare_OnlyOwnResources(t *testing.T) {
+	f := newFixture()
+	ctx := context.Background()
+	owner := f.addUser()
+	other := f.addUser()
+	workout := f.addWorkout(owner.ID)
+	program := programdomain.New(owner.ID, "5/3/1", "", nil)
+	f.programs.programs[program.ID] = program
+
+	_, err := f.svc.Create(ctx, other.ID, domain.ResourceWorkout, workout.ID)
+	require.ErrorIs(t, err, shareuc.ErrResourceNotFound)
+	_, err = f.svc.Create(ctx, other.ID, domain.ResourceProgram, program.ID)
+	require.ErrorIs(t, err, shareuc.ErrResourceNotFound)
+	_, err = f.svc.Create(ctx, owner.ID, domain.ResourceProgram, uuid.New())
+	require.ErrorIs(t, err, shareuc.ErrResourceNotFound)
+	_, err = f.svc.Create(ctx, owner.ID, domain.ResourceType("user"), owner.ID)
+	require.ErrorIs(t, err, shareuc.ErrInvalidResourceType)
+
+	created, err := f.svc.Create(ctx, owner.ID, domain.ResourceProgram, program.ID)
+	require.NoError(t, err)
+	view, err := f.svc.View(ctx, created.Token)
+	require.NoError(t, err)
+	require.Equal(t, "5/3/1", view.Program.Title)
+}
+
+func TestShare_ViewHidesDeletedDataAndOwners(t *testing.T) {
+	f := newFixture()
+	ctx := context.Background()
+
+	deletedWorkout := f.addUser()
+	workout := f.addWorkout(deletedWorkout.ID)
+	first, err := f.svc.Create(ctx, deletedWorkout.ID, domain.ResourceWorkout, workout.ID)
+	require.NoError(t, err)
+	delete(f.workouts.sessions, workout.ID)
+	_, err = f.svc.View(ctx, first.Token)
+	require.ErrorIs(t, err, shareuc.ErrShareNotFound)
+
+	deletedOwner := f.addUser()
+	second, err := f.svc.Create(ctx, deletedOwner.ID, domain.ResourceWorkout, f.addWorkout(deletedOwner.ID).ID)
+	require.NoError(t, err)
+	now := time.Now().UTC()
+	deletedOwner.DeletedAt = &now
+	_, err = f.svc.View(ctx, second.Token)
+	require.ErrorIs(t, err, shareuc.ErrShareNotFound)
+
+	suspended := f.addUser()
+	third, err := f.svc.Create(ctx, suspended.ID, domain.ResourceWorkout, f.addWorkout(suspended.ID).ID)
+	require.NoError(t, err)
+	suspended.Suspension = &userdomain.Suspension{At: now.Add(-time.Minute), Reason: "spam"}
+	_, err = f.svc.View(ctx, third.Token)
+	require.ErrorIs(t, err, shareuc.ErrShareNotFound)
+}
@@ -0,0 +1,124 @@
+package social_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	domain "workout-app/internal/domain/social"
+	userdomain "workout-app/internal/domain/user"
+	workoutdomain "workout-app/internal/domain/workout"
+	repo "workout-app/internal/repository/interfaces"
+	socialuc "workout-app/internal/usecase/social"
+	"workout-app/pkg/cache"
+)
+
+type fakeFeed struct {
+	entries []*domain.FeedEntry
+	calls   int
+}
+
+func (r *fakeFeed) ListWorkouts(_ context.Context, _ uuid.UUID, limit, offset int) ([]*domain.FeedEntry, int64, error) {
+	r.calls++
+	if offset >= len(r.entries) {
+		return nil, int64(len(r.entries)), nil
+	}
+	end := min(offset+limit, len(r.entries))
+	return r.entries[offset:end], int64(len(r.entries)), nil
+}
+
+type fakeWorkouts struct {
+	repo.WorkoutSessionRepository
+	sets map[uuid.UUID][]workoutdomain.Set
+}
+
+func (r *fakeWorkouts) ListWeightedSets(_ context.Context, userID uuid.UUID, maxReps int) ([]workoutdomain.Set, error) {
+	var result []workoutdomain.Set
+	for _, set := range r.sets[userID] {
+		if set.WeightKg != nil && set.Reps <= maxReps {
+			result = append(result, set)
+		}
+	}
+	return result, nil
+}
+
+func weighted(sessionID uuid.UUID, exercise string, weight float64, reps int, at time.Time) workoutdomain.Set {
+	return workoutdomain.Set{ID: uuid.New(), SessionID: sessionID, Exercise: exercise, Reps: reps, WeightKg: &weight, LoggedAt: at}
+}
+
+func feedEntry(authorID uuid.UUID, sets ...workoutdomain.Set) *domain.FeedEntry {
+	finished := time.Now()
+	session := &workoutdomain.Session{ID: sets[0].SessionID, UserID: authorID, StartedAt: finished.Add(-time.Hour), FinishedAt: &finished, Sets: sets}
+	return &domain.FeedEntry{Author: domain.Author{UserID: authorID}, Session: session}
+}
+
+func TestFeed_MarksPersonalRecordsSetInSession(t *testing.T) {
+	author := uuid.New()
+	older, latest := uuid.New(), uuid.New()
+	day := time.Date(2026, 10, 1, 10, 0, 0, 0, time.UTC)
+
+	history := []workoutdomain.Set{
+		weighted(older, "Bench Press", 100, 1, day),
+		weighted(older, "Squat", 140, 1, day.Add(time.Minute)),
+		weighted(latest, "bench  press", 102.5, 1, day.AddDate(0, 0, 3)),
+		weighted(latest, "Bench Press", 105, 1, day.AddDate(0, 0, 3).Add(time.Minute)),
+		weighted(latest, "Squat", 130, 1, day.AddDate(0, 0, 3).Add(2*time.Minute)),
+		weighted(latest, "Deadlift", 180, 1, day.AddDate(0, 0, 3).Add(3*time.Minute)),
+	}
+	feed := &fakeFeed{entries: []*domain.FeedEntry{feedEntry(author, history[2:]...), feedEntry(author, history[:2]...)}}
+	workouts := &fakeWorkouts{sets: map[uuid.UUID][]workoutdomain.Set{author: history}}
+	svc := socialuc.NewService(newFakeFollows(), &fakeUsers{}, feed, workouts, nil, 0)
+
+	entries, total, err := svc.Feed(context.Background(), uuid.New(), 0, 0)
+	require.NoError(t, err)
+	require.EqualValues(t, 2, total)
+
+	// Рекорд в жиме — лучший подход тренировки, сравнение с результатом до неё;
+	// присед хуже прежнего, а первая становая сравнивается не с чем.
+	records := entries[0].Records
+	require.Len(t, records, 1)
+	require.Equal(t, "Bench Press", records[0].Exercise)
+	require.InDelta(t, 105, records[0].OneRepMaxKg, 0.001)
+	require.InDelta(t, 100, records[0].PreviousOneRepMaxKg, 0.001)
+	require.Empty(t, entries[1].Records)
+}
+
+func TestFeed_CachesPagesForTTL(t *testing.T) {
+	author := uuid.New()
+	viewer := uuid.New()
+	feed := &fakeFeed{entries: []*domain.FeedEntry{feedEntry(author, weighted(uuid.New(), "Squat", 100, 5, time.Now()))}}
+	svc := socialuc.NewService(newFakeFollows(), &fakeUsers{}, feed, &fakeWorkouts{}, cache.NewMemoryCache(), time.Minute)
+	ctx := context.Background()
+
+	first, _, err := svc.Feed(ctx, viewer, 10, 0)
+	require.NoError(t, err)
+	second, total, err := svc.Feed(ctx, viewer, 10, 0)
+	require.NoError(t, err)
+	require.Equal(t, 1, feed.calls)
+	require.EqualValues(t, 1, total)
+	require.Equal(t, first[0].Session.ID, second[0].Session.ID)
+
+	// Другая страница и другой читатель кешируются отдельно.
+	_, _, err = svc.Feed(ctx, viewer, 10, 10)
+	require.NoError(t, err)
+	_, _, err = svc.Feed(ctx, uuid.New(), 10, 0)
+	require.NoError(t, err)
+	require.Equal(t, 3, feed.calls)
+}
+
+func TestFeed_ZeroTTLDisablesCache(t *testing.T) {
+	feed := &fakeFeed{}
+	users := &fakeUsers{users: map[uuid.UUID]*userdomain.User{}}
+	svc := socialuc.NewService(newFakeFollows(), users, feed, &fakeWorkouts{}, cache.NewMemoryCache(), 0)
+	viewer := uuid.New()
+
+	for range 2 {
+		entries, _, err := svc.Feed(context.Background(), viewer, 0, 0)
+		require.NoError(t, err)
+		require.Empty(t, entries)
+	}
+	require.Equal(t, 2, feed.calls)
+}
@@ -0,0 +1,175 @@
+package social_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	domain "workout-app/internal/domain/social"
+	userdomain "workout-app/internal/domain/user"
+	repo "workout-app/internal/repository/interfaces"
+	socialuc "workout-app/internal/usecase/social"
+)
+
+type followKey struct{ follower, followee uuid.UUID }
+
+type fakeFollows struct {
+	follows   map[followKey]*domain.Follow
+	lastLimit int
+}
+
+func newFakeFollows() *fakeFollows {
+	return &fakeFollows{follows: map[followKey]*domain.Follow{}}
+}
+
+func (r *fakeFollows) Create(_ context.Context, f *domain.Follow) error {
+	key := followKey{f.FollowerID, f.FolloweeID}
+	if _, ok := r.follows[key]; !ok {
+		r.follows[key] = f
+	}
+	return nil
+}
+
+func (r *fakeFollows) Delete(_ context.Context, followerID, followeeID uuid.UUID) error {
+	delete(r.follows, followKey{followerID, followeeID})
+	return nil
+}
+
+func (r *fakeFollows) Exists(_ context.Context, followerID, followeeID uuid.UUID) (bool, error) {
+	_, ok := r.follows[followKey{followerID, followeeID}]
+	return ok, nil
+}
+
+func (r *fakeFollows) ListFollowers(_ context.Context, userID uuid.UUID, limit, _ int) ([]*domain.Connection, int64, error) {
+	r.lastLimit = limit
+	var result []*domain.Connection
+	for key, f := range r.follows {
+		if key.followee == userID {
+			result = append(result, &domain.Connection{UserID: key.follower, FollowedAt: f.CreatedAt})
+		}
+	}
+	return result, int64(len(result)), nil
+}
+
+func (r *fakeFollows) ListFollowing(_ context.Context, userID uuid.UUID, limit, _ int) ([]*domain.Connection, int64, error) {
+	r.lastLimit = limit
+	var result []*domain.Connection
+	for key, f := range r.follows {
+		if key.follower == userID {
+			result = append(result, &domain.Connection{UserID: key.followee, FollowedAt: f.CreatedAt})
+		}
+	}
+	return result, int64(len(result)), nil
+}
+
+func (r *fakeFollows) DeleteByUserID(_ context.Context, userID uuid.UUID) error {
+	for key := range r.follows {
+		if key.follower == userID || key.followee == userID {
+			delete(r.follows, key)
+		}
+	}
+	return nil
+}
+
+type fakeUsers struct {
+	repo.UserRepository
+	users map[uuid.UUID]*userdomain.User
+}
+
+func (r *fakeUsers) GetByID(_ context.Context, id uuid.UUID) (*userdomain.User, error) {
+	u, ok := r.users[id]
+	if !ok {
+		return nil, repo.ErrNotFound
+	}
+	return u, nil
+}
+
+func newUser(users *fakeUsers, visibility userdomain.WorkoutsVisibility) uuid.UUID {
+	u := userdomain.NewUser(uuid.NewString()+"@example.com", "hash", "user")
+	u.WorkoutsVisibility = visibility
+	users.users[u.ID] = u
+	return u.ID
+}
+
+func newService() (socialuc.Service, *fakeFollows, *fakeUsers) {
+	follows := newFakeFollows()
+	users := &fakeUsers{users: map[uuid.UUID]*userdomain.User{}}
+	return socialuc.NewService(follows, users, &fakeFeed{}, &fakeWorkouts{}, nil, 0), follows, users
+}
+
+func TestFollow_ListsBothDirectionsAndIsIdempotent(t *testing.T) {
+	svc, _, users := newService()
+	ctx := context.Background()
+	alice := newUser(users, userdomain.WorkoutsPrivate)
+	bob := newUser(users, userdomain.WorkoutsPrivate)
+
+	require.NoError(t, svc.Follow(ctx, alice, bob))
+	require.NoError(t, svc.Follow(ctx, alice, bob))
+
+	followers, total, err := svc.ListFollowers(ctx, bob, 0, 0)
+	require.NoError(t, err)
+	require.EqualValues(t, 1, total)
+	require.Equal(t, alice, followers[0].UserID)
+
+	following, total, err := svc.ListFollowing(ctx, alice, 0, 0)
+	require.NoError(t, err)
+	require.EqualValues(t, 1, total)
+	require.Equal(t, bob, following[0].UserID)
+
+	require.NoError(t, svc.Unfollow(ctx, alice, bob))
+	_, total, err = svc.ListFollowers(ctx, bob, 0, 0)
+	require.NoError(t, err)
+	require.Zero(t, total)
+}
+
+func TestFollow_RejectsSelfAndUnknownUser(t *testing.T) {
+	svc, _, users := newService()
+	ctx := context.Background()
+	alice := newUser(users, userdomain.WorkoutsPrivate)
+
+	require.ErrorIs(t, svc.Follow(ctx, alice, alice), socialuc.ErrCannotFollowSelf)
+	require.ErrorIs(t, svc.Follow(ctx, alice, uuid.New()), repo.ErrNotFound)
+
+	_, _, err := svc.ListFollowers(ctx, uuid.New(), 0, 0)
+	require.ErrorIs(t, err, repo.ErrNotFound)
+}
+
+func TestListFollowers_ClampsPageSize(t *testing.T) {
+	svc, follows, users := newService()
+	ctx := context.Background()
+	alice := newUser(users, userdomain.WorkoutsPrivate)
+
+	_, _, err := svc.ListFollowers(ctx, alice, 0, 0)
+	require.NoError(t, err)
+	require.Equal(t, 20, follows.lastLimit)
+
+	_, _, err = svc.ListFollowing(ctx, alice, 1000, 0)
+	require.NoError(t, err)
+	require.Equal(t, 100, follows.lastLimit)
+}
+
+func TestRequireWorkoutsAccess_FollowsVisibilitySetting(t *testing.T) {
+	svc, _, users := newService()
+	ctx := context.Background()
+	viewer := newUser(users, userdomain.WorkoutsPrivate)
+	public := newUser(users, userdomain.WorkoutsPublic)
+	followersOnly := newUser(users, userdomain.WorkoutsFollowers)
+	private := newUser(users, userdomain.WorkoutsPrivate)
+	unset := newUser(users, "")
+
+	require.NoError(t, svc.RequireWorkoutsAccess(ctx, viewer, public))
+	require.NoError(t, svc.RequireWorkoutsAccess(ctx, private, private), "владелец всегда видит свои тренировки")
+	require.ErrorIs(t, svc.RequireWorkoutsAccess(ctx, viewer, private), socialuc.ErrWorkoutsHidden)
+	require.ErrorIs(t, svc.RequireWorkoutsAccess(ctx, viewer, unset), socialuc.ErrWorkoutsHidden)
+
+	require.ErrorIs(t, svc.RequireWorkoutsAccess(ctx, viewer, followersOnly), socialuc.ErrWorkoutsHidden)
+	require.NoError(t, svc.Follow(ctx, viewer, followersOnly))
+	require.NoError(t, svc.RequireWorkoutsAccess(ctx, viewer, followersOnly))
+
+	require.NoError(t, svc.Follow(ctx, viewer, private))
+	require.ErrorIs(t, svc.RequireWorkoutsAccess(ctx, viewer, private), socialuc.ErrWorkoutsHidden)
+
+	require.ErrorIs(t, svc.RequireWorkoutsAccess(ctx, viewer, uuid.New()), repo.ErrNotFound)
+}
@@ -0,0 +1,186 @@
+package strength_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	domain "workout-app/internal/domain/strength"
+	userdomain "workout-app/internal/domain/user"
+	workoutdomain "workout-app/internal/domain/workout"
+	repo "workout-app/internal/repository/interfaces"
+	strengthuc "workout-app/internal/usecase/strength"
+)
+
+type fakeStandards struct {
+	repo.StrengthStandardRepository
+	standards []*domain.Standard
+	replaced  *domain.Standard
+}
+
+func (r *fakeStandards) List(context.Context) ([]*domain.Standard, error) {
+	return r.standards, nil
+}
+
+func (r *fakeStandards) Replace(_ context.Context, s *domain.Standard) error {
+	r.replaced = s
+	return nil
+}
+
+type fakeWorkouts struct {
+	repo.WorkoutSessionRepository
+	sets []workoutdomain.Set
+}
+
+func (r *fakeWorkouts) ListWeightedSets(_ context.Context, _ uuid.UUID, maxReps int) ([]workoutdomain.Set, error) {
+	var result []workoutdomain.Set
+	for _, s := range r.sets {
+		if s.WeightKg != nil && s.Reps <= maxReps {
+			result = append(result, s)
+		}
+	}
+	return result, nil
+}
+
+type fakeUsers struct {
+	repo.UserRepository
+	user *userdomain.User
+}
+
+func (r *fakeUsers) GetByID(context.Context, uuid.UUID) (*userdomain.User, error) {
+	return r.user, nil
+}
+
+type fakeBodyMetrics struct {
+	repo.BodyMetricRepository
+	weightKg float64
+}
+
+func (r *fakeBodyMetrics) GetLatestSummary(context.Context, uuid.UUID) (*userdomain.BodyMetricsSummary, error) {
+	summary := &userdomain.BodyMetricsSummary{}
+	if r.weightKg > 0 {
+		summary.WeightKg = &userdomain.MetricValue{Value: r.weightKg, MeasuredAt: time.Now()}
+	}
+	return summary, nil
+}
+
+func squatMale() *domain.Standard {
+	return &domain.Standard{
+		Exercise: "squat",
+		Gender:   domain.GenderMale,
+		Aliases:  []string{"приседания"},
+		Rows: []domain.Row{
+			{BodyWeightKg: 60, ThresholdsKg: [5]float64{40, 60, 85, 115, 150}},
+			{BodyWeightKg: 80, ThresholdsKg: [5]float64{55, 80, 110, 150, 190}},
+		},
+	}
+}
+
+func weight(kg float64) *float64 { return &kg }
+
+func TestStandard_InterpolatesAndClassifies(t *testing.T) {
+	s := squatMale()
+
+	require.Equal(t, [5]float64{47.5, 70, 97.5, 132.5, 170}, s.ThresholdsAt(70))
+	require.Equal(t, s.Rows[0].ThresholdsKg, s.ThresholdsAt(50))
+	require.Equal(t, s.Rows[1].ThresholdsKg, s.ThresholdsAt(120))
+
+	c := s.Classify(80, 130)
+	require.Equal(t, domain.LevelIntermediate, c.Level)
+	require.Equal(t, 65.0, c.Percentile)
+	require.Equal(t, domain.LevelAdvanced, c.NextLevel)
+	require.Equal(t, 20.0, c.ToNextKg)
+
+	below := s.Classify(80, 27.5)
+	require.Empty(t, below.Level)
+	require.Equal(t, 2.5, below.Percentile)
+	require.Equal(t, domain.LevelBeginner, below.NextLevel)
+
+	elite := s.Classify(80, 209)
+	require.Equal(t, domain.LevelElite, elite.Level)
+	require.Equal(t, 97.0, elite.Percentile)
+	require.Empty(t, elite.NextLevel)
+
+	require.True(t, s.Validate())
+	s.Rows[1].ThresholdsKg[2] = 70
+	require.False(t, s.Validate())
+}
+
+func TestEstimateOneRepMax(t *testing.T) {
+	require.Equal(t, 100.0, domain.EstimateOneRepMax(100, 1))
+	require.Equal(t, 116.7, domain.EstimateOneRepMax(100, 5))
+}
+
+func TestRecords_BestSetClassifiedByProfile(t *testing.T) {
+	userID := uuid.New()
+	sessionID := uuid.New()
+	day := time.Date(2026, 10, 1, 10, 0, 0, 0, time.UTC)
+	workouts := &fakeWorkouts{sets: []workoutdomain.Set{
+		{ID: uuid.New(), SessionID: sessionID, Exercise: "Приседания", Reps: 5, WeightKg: weight(100), LoggedAt: day},
+		{ID: uuid.New(), SessionID: sessionID, Exercise: "приседания", Reps: 1, WeightKg: weight(120), LoggedAt: day.Add(time.Hour)},
+		{ID: uuid.New(), SessionID: sessionID, Exercise: "Подтягивания", Reps: 8, LoggedAt: day},
+		{ID: uuid.New(), SessionID: sessionID, Exercise: "Тяга блока", Reps: 10, WeightKg: weight(50), LoggedAt: day},
+	}}
+	svc := strengthuc.NewService(
+		&fakeStandards{standards: []*domain.Standard{squatMale()}},
+		workouts,
+		&fakeUsers{user: &userdomain.User{ID: userID, Gender: "мужской"}},
+		&fakeBodyMetrics{weightKg: 80},
+	)
+
+	report, err := svc.Records(context.Background(), userID, strengthuc.RecordsOptions{})
+	require.NoError(t, err)
+	require.Equal(t, domain.GenderMale, report.Gender)
+	require.Equal(t, 80.0, *report.BodyWeightKg)
+	require.Len(t, report.Records, 2)
+
+	squat := report.Records[0]
+	require.Equal(t, "приседания", squat.Exercise)
+	require.Equal(t, 120.0, squat.OneRepMaxKg)
+	require.Equal(t, "squat", squat.Standard)
+	require.Equal(t, domain.LevelIntermediate, squat.Classification.Level)
+
+	rows := report.Records[1]
+	require.Equal(t, "Тяга блока", rows.Exercise)
+	require.Nil(t, rows.Classification)
+
+	female, err := svc.Records(context.Background(), userID, strengthuc.RecordsOptions{Gender: "female"})
+	require.NoError(t, err)
+	require.Nil(t, female.Records[0].Classification)
+
+	_, err = svc.Records(context.Background(), userID, strengthuc.RecordsOptions{BodyWeightKg: weight(5)})
+	require.ErrorIs(t, err, strengthuc.ErrInvalidBodyWeight)
+	_, err = svc.Records(context.Background(), userID, strengthuc.RecordsOptions{Gender: "other"})
+	require.ErrorIs(t, err, strengthuc.ErrInvalidGender)
+}
+
+func TestSetStandard_NormalizesAndValidates(t *testing.T) {
+	standards := &fakeStandards{}
+	svc := strengthuc.NewService(standards, &fakeWorkouts{}, &fakeUsers{}, &fakeBodyMetrics{})
+	ctx := context.Background()
+
+	s, err := svc.SetStandard(ctx, strengthuc.StandardInput{
+		Exercise: "  Front  Squat ",
+		Gender:   "female",
+		Aliases:  []string{"Фронтальный присед", "фронтальный  присед", "front squat"},
+		Rows: []domain.Row{
+			{BodyWeightKg: 70, ThresholdsKg: [5]float64{30, 45, 60, 80, 100}},
+			{BodyWeightKg: 50, ThresholdsKg: [5]float64{20, 35, 50, 65, 85}},
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, "front squat", s.Exercise)
+	require.Equal(t, []string{"фронтальный присед"}, s.Aliases)
+	require.Equal(t, 50.0, s.Rows[0].BodyWeightKg)
+	require.Same(t, s, standards.replaced)
+
+	_, err = svc.SetStandard(ctx, strengthuc.StandardInput{
+		Exercise: "squat",
+		Gender:   "male",
+		Rows:     []domain.Row{{BodyWeightKg: 80, ThresholdsKg: [5]float64{55, 80, 80, 150, 190}}},
+	})
+	require.ErrorIs(t, err, strengthuc.ErrInvalidStandard)
+}
@@ -0,0 +1,240 @@
+package tenantemail_test
+
+import (
+	"context"
+	"encoding/base64"
+	"io"
+	"log/slog"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	domain "workout-app/internal/domain/organization"
+	"workout-app/internal/mailer"
+	repo "workout-app/internal/repository/interfaces"
+	"workout-app/internal/usecase/tenantemail"
+	"workout-app/pkg/logger"
+	mailerpkg "workout-app/pkg/mailer"
+	"workout-app/pkg/ratelimit"
+	"workout-app/pkg/secretbox"
+)
+
+// fakeSettingsRepo хранит настройки организаций в памяти; получатели сопоставлены организациям явно.
+type fakeSettingsRepo struct {
+	settings   map[uuid.UUID]domain.EmailSettings
+	recipients map[string]uuid.UUID
+}
+
+func newFakeSettingsRepo() *fakeSettingsRepo {
+	return &fakeSettingsRepo{
+		settings:   map[uuid.UUID]domain.EmailSettings{},
+		recipients: map[string]uuid.UUID{},
+	}
+}
+
+func (r *fakeSettingsRepo) Get(_ context.Context, orgID uuid.UUID) (*domain.EmailSettings, error) {
+	s, ok := r.settings[orgID]
+	if !ok {
+		return nil, repo.ErrNotFound
+	}
+	return &s, nil
+}
+
+func (r *fakeSettingsRepo) GetForRecipient(ctx context.Context, email string) (*domain.EmailSettings, error) {
+	orgID, ok := r.recipients[email]
+	if !ok {
+		return nil, repo.ErrNotFound
+	}
+	return r.Get(ctx, orgID)
+}
+
+func (r *fakeSettingsRepo) Upsert(_ context.Context, s *domain.EmailSettings) error {
+	r.settings[s.OrganizationID] = *s
+	return nil
+}
+
+func (r *fakeSettingsRepo) Delete(_ context.Context, orgID uuid.UUID) error {
+	if _, ok := r.settings[orgID]; !ok {
+		return repo.ErrNotFound
+	}
+	delete(r.settings, orgID)
+	return nil
+}
+
+// countingSender считает письма платформенного отправителя.
+type countingSender struct{ sent int }
+
+func (s *countingSender) SendEmailVerificationCode(context.Context, string, string) error {
+	s.sent++
+	return nil
+}
+
+func (s *countingSender) SendPasswordChangeCode(context.Context, string, string) error {
+	s.sent++
+	return nil
+}
+
+func (s *countingSender) SendPasswordResetCode(context.Context, string, string) error {
+	s.sent++
+	return nil
+}
+
+func (s *countingSender) SendDataExportReady(context.Context, string, string, time.Time) error {
+	s.sent++
+	return nil
+}
+
+func (s *countingSender) SendWelcome(context.Context, string, string) error {
+	s.sent++
+	return nil
+}
+
+func (s *countingSender) SendPasswordChanged(context.Context, string, time.Time) error {
+	s.sent++
+	return nil
+}
+
+func (s *countingSender) SendAccountDeleted(context.Context, string, time.Time) error {
+	s.sent++
+	return nil
+}
+
+func (s *countingSender) SendNotification(context.Context, string, string, time.Time) error {
+	s.sent++
+	return nil
+}
+
+func (s *countingSender) SendWeeklySummary(context.Context, string, mailerpkg.WeeklySummary) error {
+	s.sent++
+	return nil
+}
+
+func (s *countingSender) SendCoachInvitation(context.Context, string, string, time.Time) error {
+	s.sent++
+	return nil
+}
+
+func validInput() tenantemail.SettingsInput {
+	return tenantemail.SettingsInput{
+		FromEmail:    "noreply@gym.example.com",
+		FromName:     "Iron Gym",
+		Provider:     domain.EmailProviderSMTP,
+		SMTPHost:     "smtp.gym.example.com",
+		SMTPPort:     587,
+		SMTPUsername: "mailer",
+		SMTPPassword: "s3cret",
+	}
+}
+
+func TestSecretbox_RoundTripAndKeyValidation(t *testing.T) {
+	key := make([]byte, secretbox.KeySize)
+	for i := range key {
+		key[i] = byte(i)
+	}
+	parsed, err := secretbox.ParseKey(base64.StdEncoding.EncodeToString(key))
+	require.NoError(t, err)
+	box, err := secretbox.New(parsed)
+	require.NoError(t, err)
+
+	sealed, err := box.Seal([]byte("s3cret"))
+	require.NoError(t, err)
+	require.NotContains(t, string(sealed), "s3cret")
+	again, err := box.Seal([]byte("s3cret"))
+	require.NoError(t, err)
+	require.NotEqual(t, sealed, again)
+
+	plain, err := box.Open(sealed)
+	require.NoError(t, err)
+	require.Equal(t, "s3cret", string(plain))
+
+	sealed[len(sealed)-1] ^= 0xff
+	_, err = box.Open(sealed)
+	require.ErrorIs(t, err, secretbox.ErrDecrypt)
+
+	_, err = secretbox.ParseKey(base64.StdEncoding.EncodeToString(key[:16]))
+	require.ErrorIs(t, err, secretbox.ErrInvalidKey)
+}
+
+func TestSet_ValidatesAndKeepsPasswordOnUpdate(t *testing.T) {
+	settingsRepo := newFakeSettingsRepo()
+	svc := tenantemail.NewService(settingsRepo, 500)
+	ctx := context.Background()
+	orgID, actorID := uuid.New(), uuid.New()
+
+	noPassword := validInput()
+	noPassword.SMTPPassword = ""
+	_, err := svc.Set(ctx, actorID, orgID, noPassword)
+	require.ErrorIs(t, err, tenantemail.ErrPasswordRequired)
+
+	settings, err := svc.Set(ctx, actorID, orgID, validInput())
+	require.NoError(t, err)
+	require.Equal(t, 500, settings.RateLimitPerHour)
+
+	noPassword.RateLimitPerHour = 50
+	settings, err = svc.Set(ctx, actorID, orgID, noPassword)
+	require.NoError(t, err)
+	require.Equal(t, "s3cret", settings.SMTPPassword)
+	require.Equal(t, 50, settings.RateLimitPerHour)
+
+	for name, mutate := range map[string]func(*tenantemail.SettingsInput){
+		"from email": func(in *tenantemail.SettingsInput) { in.FromEmail = "Gym <noreply@gym.example.com>" },
+		"from name":  func(in *tenantemail.SettingsInput) { in.FromName = "Gym\r\nBcc: victim@example.com" },
+		"provider":   func(in *tenantemail.SettingsInput) { in.Provider = "sendgrid" },
+		"port":       func(in *tenantemail.SettingsInput) { in.SMTPPort = 70000 },
+		"rate limit": func(in *tenantemail.SettingsInput) { in.RateLimitPerHour = 501 },
+	} {
+		in := validInput()
+		mutate(&in)
+		_, err := svc.Set(ctx, actorID, orgID, in)
+		require.Error(t, err, name)
+	}
+
+	require.NoError(t, svc.Delete(ctx, orgID))
+	_, err = svc.Get(ctx, orgID)
+	require.ErrorIs(t, err, tenantemail.ErrNotConfigured)
+
+	_, err = tenantemail.NewService(nil, 500).Set(ctx, actorID, orgID, validInput())
+	require.ErrorIs(t, err, tenantemail.ErrDisabled)
+}
+
+func TestTenantSender_RoutesByRecipientAndEnforcesTenantLimit(t *testing.T) {
+	// Порт без SMTP-сервера: письмо организации уходит в её SMTP и завершается ошибкой соединения.
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	port := ln.Addr().(*net.TCPAddr).Port
+	require.NoError(t, ln.Close())
+
+	settingsRepo := newFakeSettingsRepo()
+	svc := tenantemail.NewService(settingsRepo, 500)
+	ctx := context.Background()
+	orgID := uuid.New()
+
+	in := validInput()
+	in.SMTPHost = "127.0.0.1"
+	in.SMTPPort = port
+	in.RateLimitPerHour = 1
+	_, err = svc.Set(ctx, uuid.New(), orgID, in)
+	require.NoError(t, err)
+	settingsRepo.recipients["member@example.com"] = orgID
+
+	platform := &countingSender{}
+	log := logger.New(io.Discard, slog.LevelError, logger.FormatJSON)
+	sender := mailer.NewTenantSender(platform, mailer.MustLoadTemplates("en"), svc, func(limit int) ratelimit.Limiter {
+		return ratelimit.NewMemoryLimiter(limit, time.Hour)
+	}, log)
+
+	require.NoError(t, sender.SendEmailVerificationCode(ctx, "outsider@example.com", "123456"))
+	require.Equal(t, 1, platform.sent)
+
+	err = sender.SendEmailVerificationCode(ctx, "Member@Example.com ", "123456")
+	require.Error(t, err)
+	require.False(t, strings.Contains(err.Error(), "rate limit"))
+	require.Equal(t, 1, platform.sent)
+
+	require.ErrorIs(t, sender.SendPasswordChangeCode(ctx, "member@example.com", "123456"), mailerpkg.ErrSendRateLimited)
+	require.Equal(t, 1, platform.sent)
+}
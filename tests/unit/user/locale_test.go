@@ -0,0 +1,42 @@
+package user_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	domain "workout-app/internal/domain/user"
+	useruc "workout-app/internal/usecase/user"
+)
+
+func TestUpdateProfile_NormalizesLocale(t *testing.T) {
+	ctx := context.Background()
+	u := domain.NewUser("jane@example.com", "hash", "jane")
+	svc, _ := newHistoryService(u)
+
+	locale := "en_gb"
+	updated, err := svc.UpdateProfile(ctx, u.ID, useruc.ProfileUpdateInput{Locale: &locale})
+	require.NoError(t, err)
+	require.Equal(t, "en-GB", updated.Locale)
+
+	invalid := "english please"
+	_, err = svc.UpdateProfile(ctx, u.ID, useruc.ProfileUpdateInput{Locale: &invalid})
+	require.ErrorIs(t, err, useruc.ErrInvalidLocale)
+
+	reset := ""
+	updated, err = svc.UpdateProfile(ctx, u.ID, useruc.ProfileUpdateInput{Locale: &reset})
+	require.NoError(t, err)
+	require.Empty(t, updated.Locale)
+}
+
+func TestMailLocale_KeepsRegionOnlyForSameLanguage(t *testing.T) {
+	u := &domain.User{Language: domain.LanguageEnglish, Locale: "en-GB"}
+	require.Equal(t, "en-GB", u.MailLocale())
+
+	u.Locale = "de-DE"
+	require.Equal(t, "en", u.MailLocale())
+
+	u.Language = ""
+	require.Empty(t, u.MailLocale())
+}
@@ -0,0 +1,183 @@
+package user_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	domain "workout-app/internal/domain/user"
+	repo "workout-app/internal/repository/interfaces"
+	useruc "workout-app/internal/usecase/user"
+	"workout-app/pkg/cache"
+	"workout-app/pkg/logger"
+)
+
+type countingUsers struct {
+	repo.UserRepository
+	byID  map[uuid.UUID]*domain.User
+	reads int
+}
+
+func (r *countingUsers) GetByID(_ context.Context, id uuid.UUID) (*domain.User, error) {
+	r.reads++
+	if u, ok := r.byID[id]; ok {
+		copied := *u
+		return &copied, nil
+	}
+	return nil, repo.ErrNotFound
+}
+
+func (r *countingUsers) Update(_ context.Context, u *domain.User) error {
+	copied := *u
+	r.byID[u.ID] = &copied
+	return nil
+}
+
+func newProfileCacheService(users repo.UserRepository, profiles cache.Cache) useruc.Service {
+	return useruc.NewService(users, nil, nil, nil, nil, nil, nil, time.Hour, 5, 6, 0, profiles, time.Minute)
+}
+
+func TestGetProfile_ReadsThroughCacheWithoutPasswordHash(t *testing.T) {
+	ctx := context.Background()
+	u := &domain.User{ID: uuid.New(), Username: "jane", Email: "jane@example.com", PasswordHash: "secret-hash"}
+	users := &countingUsers{byID: map[uuid.UUID]*domain.User{u.ID: u}}
+	profiles := cache.NewMemoryCache()
+	svc := newProfileCacheService(users, profiles)
+
+	first, err := svc.GetProfile(ctx, u.ID)
+	require.NoError(t, err)
+	require.Equal(t, "jane", first.Username)
+	require.Empty(t, first.PasswordHash)
+
+	second, err := svc.GetByID(ctx, u.ID)
+	require.NoError(t, err)
+	require.Equal(t, "jane", second.Username)
+	require.Empty(t, second.PasswordHash)
+	require.Equal(t, 1, users.reads)
+
+	raw, err := profiles.Get(ctx, u.ID.String())
+	require.NoError(t, err)
+	require.NotContains(t, string(raw), "secret-hash")
+}
+
+func TestCacheInvalidator_ResetsProfileOnUpdate(t *testing.T) {
+	ctx := context.Background()
+	u := &domain.User{ID: uuid.New(), Username: "jane"}
+	users := &countingUsers{byID: map[uuid.UUID]*domain.User{u.ID: u}}
+	profiles := cache.NewMemoryCache()
+	log := logger.New(io.Discard, slog.LevelError, logger.FormatJSON)
+	wrapped := useruc.NewCacheInvalidator(users, profiles, log)
+	svc := newProfileCacheService(wrapped, profiles)
+
+	_, err := svc.GetProfile(ctx, u.ID)
+	require.NoError(t, err)
+
+	require.NoError(t, wrapped.Update(ctx, &domain.User{ID: u.ID, Username: "jane_doe"}))
+	_, err = profiles.Get(ctx, u.ID.String())
+	require.ErrorIs(t, err, cache.ErrMiss)
+
+	got, err := svc.GetProfile(ctx, u.ID)
+	require.NoError(t, err)
+	require.Equal(t, "jane_doe", got.Username)
+	require.Equal(t, 2, users.reads)
+}
+
+// hookTransactor выполняет fn без БД, соблюдая контракт repo.Transactor для AfterCommit.
+type hookTransactor struct{}
+
+func (hookTransactor) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	txCtx, committed := repo.WithAfterCommit(ctx)
+	if err := fn(txCtx); err != nil {
+		return err
+	}
+	committed()
+	return nil
+}
+
+func TestCacheInvalidator_ResetsProfileAfterCommit(t *testing.T) {
+	ctx := context.Background()
+	u := &domain.User{ID: uuid.New(), Username: "jane"}
+	users := &countingUsers{byID: map[uuid.UUID]*domain.User{u.ID: u}}
+	profiles := cache.NewMemoryCache()
+	log := logger.New(io.Discard, slog.LevelError, logger.FormatJSON)
+	wrapped := useruc.NewCacheInvalidator(users, profiles, log)
+	svc := newProfileCacheService(wrapped, profiles)
+	var tx hookTransactor
+
+	_, err := svc.GetProfile(ctx, u.ID)
+	require.NoError(t, err)
+
+	errAbort := errors.New("abort")
+	err = tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		require.NoError(t, wrapped.Update(ctx, &domain.User{ID: u.ID, Username: "rolled_back"}))
+		return errAbort
+	})
+	require.ErrorIs(t, err, errAbort)
+	_, err = profiles.Get(ctx, u.ID.String())
+	require.NoError(t, err)
+
+	err = tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		require.NoError(t, tx.WithinTransaction(ctx, func(ctx context.Context) error {
+			return wrapped.Update(ctx, &domain.User{ID: u.ID, Username: "jane_doe"})
+		}))
+		_, err := profiles.Get(ctx, u.ID.String())
+		require.NoError(t, err)
+		return nil
+	})
+	require.NoError(t, err)
+	_, err = profiles.Get(ctx, u.ID.String())
+	require.ErrorIs(t, err, cache.ErrMiss)
+
+	got, err := svc.GetProfile(ctx, u.ID)
+	require.NoError(t, err)
+	require.Equal(t, "jane_doe", got.Username)
+}
+
+func TestProfileCache_DisabledPassesThrough(t *testing.T) {
+	ctx := context.Background()
+	u := &domain.User{ID: uuid.New(), Username: "jane", PasswordHash: "secret-hash"}
+	users := &countingUsers{byID: map[uuid.UUID]*domain.User{u.ID: u}}
+	log := logger.New(io.Discard, slog.LevelError, logger.FormatJSON)
+	require.Same(t, repo.UserRepository(users), useruc.NewCacheInvalidator(users, nil, log))
+
+	svc := newProfileCacheService(users, nil)
+	for i := 0; i < 2; i++ {
+		got, err := svc.GetByID(ctx, u.ID)
+		require.NoError(t, err)
+		require.Equal(t, "secret-hash", got.PasswordHash)
+	}
+	require.Equal(t, 2, users.reads)
+
+	_, err := svc.GetProfile(ctx, uuid.New())
+	require.ErrorIs(t, err, repo.ErrNotFound)
+}
+
+func TestMemoryCache_ExpiresAndInvalidates(t *testing.T) {
+	ctx := context.Background()
+	c := cache.NewMemoryCache()
+
+	require.NoError(t, c.Set(ctx, "a", []byte("1"), time.Minute))
+	require.NoError(t, c.Set(ctx, "b", []byte("2"), time.Millisecond))
+	time.Sleep(5 * time.Millisecond)
+
+	got, err := c.Get(ctx, "a")
+	require.NoError(t, err)
+	require.Equal(t, []byte("1"), got)
+	_, err = c.Get(ctx, "b")
+	require.ErrorIs(t, err, cache.ErrMiss)
+
+	require.NoError(t, c.Delete(ctx, "a", "missing"))
+	_, err = c.Get(ctx, "a")
+	require.ErrorIs(t, err, cache.ErrMiss)
+
+	require.NoError(t, c.Set(ctx, "c", []byte("3"), time.Minute))
+	require.NoError(t, c.Invalidate(ctx))
+	_, err = c.Get(ctx, "c")
+	require.ErrorIs(t, err, cache.ErrMiss)
+}
@@ -0,0 +1,144 @@
+package user_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	domain "workout-app/internal/domain/user"
+	repo "workout-app/internal/repository/interfaces"
+	useruc "workout-app/internal/usecase/user"
+)
+
+type historyUsers struct {
+	countingUsers
+}
+
+func (r *historyUsers) UpdateRole(_ context.Context, id uuid.UUID, role domain.Role) (domain.Role, error) {
+	u, ok := r.byID[id]
+	if !ok {
+		return "", repo.ErrNotFound
+	}
+	previous := u.Role
+	u.Role = role
+	return previous, nil
+}
+
+type fakeProfileHistory struct {
+	repo.ProfileHistoryRepository
+	changes []*domain.ProfileChange
+}
+
+func (r *fakeProfileHistory) Create(_ context.Context, c *domain.ProfileChange) error {
+	r.changes = append(r.changes, c)
+	return nil
+}
+
+func newHistoryService(u *domain.User) (useruc.Service, *fakeProfileHistory) {
+	users := &historyUsers{countingUsers{byID: map[uuid.UUID]*domain.User{u.ID: u}}}
+	history := &fakeProfileHistory{}
+	return useruc.NewService(users, nil, history, nil, nil, nil, nil, time.Hour, 5, 6, 0, nil, 0), history
+}
+
+func TestUpdateProfile_RecordsChangedFields(t *testing.T) {
+	ctx := context.Background()
+	u := domain.NewUser("jane@example.com", "hash", "jane")
+	u.FirstName = "Jane"
+	svc, history := newHistoryService(u)
+
+	first, last, instagram := "Janet", "Doe", "@jane.lifts"
+	birth := time.Date(1990, 5, 17, 0, 0, 0, 0, time.UTC)
+	_, err := svc.UpdateProfile(ctx, u.ID, useruc.ProfileUpdateInput{
+		FirstName: &first, LastName: &last, BirthDate: &birth, Instagram: &instagram,
+	})
+	require.NoError(t, err)
+
+	require.Len(t, history.changes, 1)
+	change := history.changes[0]
+	require.Equal(t, u.ID, change.UserID)
+	require.True(t, change.BySelf())
+	require.Equal(t, []domain.FieldChange{
+		{Field: domain.FieldBirthDate, Old: "", New: "1990-05-17"},
+		{Field: domain.FieldFirstName, Old: "Jane", New: "Janet"},
+		{Field: "instagram", Old: "", New: "jane.lifts"},
+		{Field: domain.FieldLastName, Old: "", New: "Doe"},
+	}, change.Changes)
+
+	// Повторная отправка тех же значений не создаёт пустую запись.
+	_, err = svc.UpdateProfile(ctx, u.ID, useruc.ProfileUpdateInput{FirstName: &first})
+	require.NoError(t, err)
+	require.Len(t, history.changes, 1)
+}
+
+func TestChangeRole_RecordsAdminActor(t *testing.T) {
+	ctx := context.Background()
+	u := domain.NewUser("jane@example.com", "hash", "jane")
+	svc, history := newHistoryService(u)
+	adminID := uuid.New()
+
+	_, previous, err := svc.ChangeRole(ctx, adminID, u.ID, domain.RoleCoach)
+	require.NoError(t, err)
+	require.Equal(t, domain.RoleUser, previous)
+
+	require.Len(t, history.changes, 1)
+	require.Equal(t, adminID, history.changes[0].ActorID)
+	require.False(t, history.changes[0].BySelf())
+	require.Equal(t, []domain.FieldChange{{Field: domain.FieldRole, Old: "user", New: "coach"}}, history.changes[0].Changes)
+
+	_, _, err = svc.ChangeRole(ctx, adminID, u.ID, domain.RoleCoach)
+	require.NoError(t, err)
+	require.Len(t, history.changes, 1)
+}
+
+func TestUpdateProfile_TimezoneAndReminderTime(t *testing.T) {
+	ctx := context.Background()
+	u := domain.NewUser("jane@example.com", "hash", "jane")
+	svc, history := newHistoryService(u)
+
+	bad, local, unknown := "25:00", "Local", "Mars/Olympus"
+	_, err := svc.UpdateProfile(ctx, u.ID, useruc.ProfileUpdateInput{ReminderTime: &bad})
+	require.ErrorIs(t, err, useruc.ErrInvalidReminderTime)
+	for _, tz := range []*string{&local, &unknown} {
+		_, err = svc.UpdateProfile(ctx, u.ID, useruc.ProfileUpdateInput{Timezone: tz})
+		require.ErrorIs(t, err, useruc.ErrInvalidTimezone)
+	}
+
+	tz, at := "Europe/Moscow", "08:30"
+	updated, err := svc.UpdateProfile(ctx, u.ID, useruc.ProfileUpdateInput{Timezone: &tz, ReminderTime: &at})
+	require.NoError(t, err)
+	require.Equal(t, "08:30", updated.ReminderTime.String())
+	require.Equal(t, []domain.FieldChange{
+		{Field: domain.FieldReminderTime, Old: "", New: "08:30"},
+		{Field: domain.FieldTimezone, Old: "", New: "Europe/Moscow"},
+	}, history.changes[0].Changes)
+
+	off := ""
+	updated, err = svc.UpdateProfile(ctx, u.ID, useruc.ProfileUpdateInput{ReminderTime: &off})
+	require.NoError(t, err)
+	require.Nil(t, updated.ReminderTime)
+}
+
+func TestUpdateProfile_CalorieTarget(t *testing.T) {
+	ctx := context.Background()
+	u := domain.NewUser("jane@example.com", "hash", "jane")
+	svc, history := newHistoryService(u)
+
+	for _, bad := range []int{-1, 100, 20000} {
+		_, err := svc.UpdateProfile(ctx, u.ID, useruc.ProfileUpdateInput{CalorieTarget: &bad})
+		require.ErrorIs(t, err, useruc.ErrInvalidCalorieTarget)
+	}
+
+	target := 2200
+	updated, err := svc.UpdateProfile(ctx, u.ID, useruc.ProfileUpdateInput{CalorieTarget: &target})
+	require.NoError(t, err)
+	require.Equal(t, 2200, *updated.CalorieTarget)
+	require.Equal(t, []domain.FieldChange{{Field: domain.FieldCalorieTarget, Old: "", New: "2200"}}, history.changes[0].Changes)
+
+	off := 0
+	updated, err = svc.UpdateProfile(ctx, u.ID, useruc.ProfileUpdateInput{CalorieTarget: &off})
+	require.NoError(t, err)
+	require.Nil(t, updated.CalorieTarget)
+}
@@ -0,0 +1,19 @@
+package user_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	domain "workout-app/internal/domain/user"
+)
+
+func TestRole_OutranksDetectsDemotion(t *testing.T) {
+	require.True(t, domain.RoleAdmin.Outranks(domain.RoleCoach))
+	require.True(t, domain.RoleAdmin.Outranks(domain.RoleUser))
+	require.True(t, domain.RoleCoach.Outranks(domain.RoleUser))
+
+	require.False(t, domain.RoleUser.Outranks(domain.RoleCoach))
+	require.False(t, domain.RoleCoach.Outranks(domain.RoleAdmin))
+	require.False(t, domain.RoleAdmin.Outranks(domain.RoleAdmin))
+}
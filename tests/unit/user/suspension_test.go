@@ -0,0 +1,32 @@
+package user_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	domain "workout-app/internal/domain/user"
+)
+
+func TestIsSuspended_NoSuspension(t *testing.T) {
+	u := domain.NewUser("user@example.com", "hash", "user1")
+	require.False(t, u.IsSuspended(time.Now()))
+}
+
+func TestIsSuspended_Indefinite(t *testing.T) {
+	u := domain.NewUser("user@example.com", "hash", "user1")
+	u.Suspension = &domain.Suspension{At: time.Now()}
+	require.True(t, u.IsSuspended(time.Now().Add(365*24*time.Hour)))
+}
+
+func TestIsSuspended_ExpiresAtUntil(t *testing.T) {
+	now := time.Now()
+	until := now.Add(time.Hour)
+	u := domain.NewUser("user@example.com", "hash", "user1")
+	u.Suspension = &domain.Suspension{At: now, Until: &until}
+
+	require.True(t, u.IsSuspended(now))
+	require.False(t, u.IsSuspended(until))
+	require.False(t, u.IsSuspended(until.Add(time.Minute)))
+}
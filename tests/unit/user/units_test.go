@@ -0,0 +1,52 @@
+package user_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	domain "workout-app/internal/domain/user"
+	useruc "workout-app/internal/usecase/user"
+)
+
+func TestUnits_ConvertsToAndFromSI(t *testing.T) {
+	imperial := domain.UnitsImperial
+	require.InDelta(t, 220.46, imperial.Weight(100), 0.01)
+	require.InDelta(t, 100, imperial.Weight(domain.PoundsToKg(100)), 1e-9)
+	require.Equal(t, 100.0, domain.UnitsMetric.Weight(100))
+
+	name, value := imperial.Measurement("waist_cm", 81.28)
+	require.Equal(t, "waist_in", name)
+	require.Equal(t, 32.0, value)
+	name, value = imperial.Measurement("run_km", 5)
+	require.Equal(t, "run_mi", name)
+	require.Equal(t, 3.11, value)
+	name, value = domain.UnitsMetric.Measurement("waist_cm", 81.28)
+	require.Equal(t, "waist_cm", name)
+	require.Equal(t, 81.28, value)
+
+	name, value = domain.CanonicalMeasurement("waist_in", 32)
+	require.Equal(t, "waist_cm", name)
+	require.InDelta(t, 81.28, value, 1e-9)
+	name, _ = domain.CanonicalMeasurement("pulse", 60)
+	require.Equal(t, "pulse", name)
+}
+
+func TestUnits_ProfilePreference(t *testing.T) {
+	ctx := context.Background()
+	u := domain.NewUser("jane@example.com", "hash", "jane")
+	svc, history := newHistoryService(u)
+
+	units, err := svc.Units(ctx, u.ID)
+	require.NoError(t, err)
+	require.Equal(t, domain.UnitsMetric, units)
+
+	imperial := domain.UnitsImperial
+	_, err = svc.UpdateProfile(ctx, u.ID, useruc.ProfileUpdateInput{Units: &imperial})
+	require.NoError(t, err)
+	units, err = svc.Units(ctx, u.ID)
+	require.NoError(t, err)
+	require.Equal(t, domain.UnitsImperial, units)
+	require.Equal(t, []domain.FieldChange{{Field: domain.FieldUnits, Old: "", New: "imperial"}}, history.changes[0].Changes)
+}
@@ -0,0 +1,137 @@
+package userfastpath_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	domain "workout-app/internal/domain/user"
+	repo "workout-app/internal/repository/interfaces"
+	pgrepo "workout-app/internal/repository/postgres"
+	"workout-app/pkg/logger"
+)
+
+// fakeReader возвращает заданного пользователя (nil — не найден) или ошибку и считает вызовы.
+type fakeReader struct {
+	repo.UserRepository
+	user  *domain.User
+	err   error
+	calls int
+}
+
+func (r *fakeReader) get() (*domain.User, error) {
+	r.calls++
+	if r.err != nil {
+		return nil, r.err
+	}
+	if r.user == nil {
+		return nil, repo.ErrNotFound
+	}
+	u := *r.user
+	return &u, nil
+}
+
+func (r *fakeReader) GetByID(context.Context, uuid.UUID) (*domain.User, error) { return r.get() }
+func (r *fakeReader) GetByEmail(context.Context, string) (*domain.User, error) { return r.get() }
+
+func newUser() *domain.User {
+	u := domain.NewUser("athlete@example.com", "hash", "athlete")
+	u.CreatedAt = time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	return u
+}
+
+func newFastPath(primary, fast *fakeReader, mode pgrepo.FastPathMode) (*pgrepo.UserFastPath, *pgrepo.FastPathMetrics) {
+	metrics := pgrepo.NewFastPathMetrics()
+	log := logger.New(io.Discard, slog.LevelError, logger.FormatJSON)
+	return pgrepo.NewUserFastPath(primary, fast, mode, 4, metrics, log), metrics
+}
+
+func TestShadow_AnswersFromPrimaryAndReportsMismatchedFields(t *testing.T) {
+	user := newUser()
+	sameInOtherZone := *user
+	sameInOtherZone.CreatedAt = user.CreatedAt.In(time.FixedZone("MSK", 3*60*60))
+	primary := &fakeReader{user: user}
+	fast := &fakeReader{user: &sameInOtherZone}
+	fastPath, metrics := newFastPath(primary, fast, pgrepo.FastPathShadow)
+	ctx := context.Background()
+
+	got, err := fastPath.GetByEmail(ctx, user.Email)
+	require.NoError(t, err)
+	require.Equal(t, user.ID, got.ID)
+	require.NoError(t, fastPath.Stop(ctx))
+
+	changed := *user
+	changed.Role = domain.RoleAdmin
+	fast.user = &changed
+	_, err = fastPath.GetByID(ctx, user.ID)
+	require.NoError(t, err)
+	require.NoError(t, fastPath.Stop(ctx))
+
+	// Пользователь есть в GORM, но не найден через pgx.
+	fast.user = nil
+	_, err = fastPath.GetByID(ctx, user.ID)
+	require.NoError(t, err)
+	require.NoError(t, fastPath.Stop(ctx))
+
+	require.Equal(t, 3, primary.calls)
+	require.Equal(t, 3, fast.calls)
+	require.Equal(t, []pgrepo.ShadowStats{
+		{Method: "get_by_email", Compared: 1},
+		{Method: "get_by_id", Compared: 2, Mismatches: 2},
+	}, metrics.Shadow())
+
+	reads := metrics.Reads()
+	require.Len(t, reads, 4)
+	for _, r := range reads {
+		require.Zero(t, r.Errors)
+	}
+}
+
+func TestOn_AnswersFromFastPathAndFallsBackOnError(t *testing.T) {
+	user := newUser()
+	primary := &fakeReader{user: user}
+	fast := &fakeReader{}
+	fastPath, metrics := newFastPath(primary, fast, pgrepo.FastPathOn)
+	ctx := context.Background()
+
+	_, err := fastPath.GetByID(ctx, user.ID)
+	require.ErrorIs(t, err, repo.ErrNotFound)
+	require.Zero(t, primary.calls)
+
+	fast.err = errors.New("connection refused")
+	got, err := fastPath.GetByID(ctx, user.ID)
+	require.NoError(t, err)
+	require.Equal(t, user.ID, got.ID)
+	require.Equal(t, 1, primary.calls)
+
+	require.Equal(t, []pgrepo.ReadPathStats{
+		{Method: "get_by_id", Path: "gorm", Reads: 1},
+		{Method: "get_by_id", Path: "pgx", Reads: 2, Errors: 1},
+	}, withoutDurations(metrics.Reads()))
+	require.Empty(t, metrics.Shadow())
+}
+
+func TestOff_UsesOnlyPrimary(t *testing.T) {
+	primary := &fakeReader{user: newUser()}
+	fast := &fakeReader{user: newUser()}
+	fastPath, metrics := newFastPath(primary, fast, pgrepo.FastPathOff)
+
+	_, err := fastPath.GetByEmail(context.Background(), "athlete@example.com")
+	require.NoError(t, err)
+	require.Equal(t, 1, primary.calls)
+	require.Zero(t, fast.calls)
+	require.Empty(t, metrics.Reads())
+}
+
+func withoutDurations(reads []pgrepo.ReadPathStats) []pgrepo.ReadPathStats {
+	for i := range reads {
+		reads[i].TotalDuration, reads[i].MaxDuration = 0, 0
+	}
+	return reads
+}
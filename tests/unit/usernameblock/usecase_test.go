@@ -0,0 +1,95 @@
+package usernameblock_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	domain "workout-app/internal/domain/usernameblock"
+	repo "workout-app/internal/repository/interfaces"
+	usernameblockuc "workout-app/internal/usecase/usernameblock"
+	"workout-app/pkg/usernamefilter"
+)
+
+// fakeBlocklist хранит записи в памяти и считает обращения к списку.
+type fakeBlocklist struct {
+	entries []*domain.Entry
+	lists   int
+}
+
+func (r *fakeBlocklist) Create(_ context.Context, e *domain.Entry) (bool, error) {
+	for _, existing := range r.entries {
+		if existing.Term == e.Term && existing.Mode == e.Mode {
+			return false, nil
+		}
+	}
+	r.entries = append(r.entries, e)
+	return true, nil
+}
+
+func (r *fakeBlocklist) Delete(_ context.Context, id uuid.UUID) error {
+	for i, e := range r.entries {
+		if e.ID == id {
+			r.entries = append(r.entries[:i], r.entries[i+1:]...)
+			return nil
+		}
+	}
+	return repo.ErrNotFound
+}
+
+func (r *fakeBlocklist) List(context.Context) ([]*domain.Entry, error) {
+	r.lists++
+	return r.entries, nil
+}
+
+func TestCheck_CombinesBuiltinRulesAndAdminEntries(t *testing.T) {
+	ctx := context.Background()
+	entries := &fakeBlocklist{}
+	svc := usernameblockuc.NewService(entries, usernamefilter.Reserved(), time.Hour)
+
+	require.ErrorIs(t, svc.Check(ctx, "admin"), usernamefilter.ErrNotAllowed)
+	require.NoError(t, svc.Check(ctx, "ironcoachpro"))
+
+	entry, err := svc.Add(ctx, uuid.New(), usernameblockuc.AddInput{Term: "IronCoach1", Mode: usernamefilter.ModeContains})
+	require.NoError(t, err)
+	require.Equal(t, "ironcoach", entry.Term)
+	require.Equal(t, usernamefilter.CategoryReserved, entry.Category)
+
+	// Добавление сбрасывает кеш: новое правило действует сразу.
+	require.ErrorIs(t, svc.Check(ctx, "ironcoachpro"), usernamefilter.ErrNotAllowed)
+
+	_, err = svc.Add(ctx, uuid.New(), usernameblockuc.AddInput{Term: "ir0nc0ach", Mode: usernamefilter.ModeContains})
+	require.ErrorIs(t, err, usernameblockuc.ErrAlreadyBlocked)
+
+	require.NoError(t, svc.Remove(ctx, entry.ID))
+	require.NoError(t, svc.Check(ctx, "ironcoachpro"))
+}
+
+func TestCheck_CachesAdminEntries(t *testing.T) {
+	ctx := context.Background()
+	entries := &fakeBlocklist{}
+	svc := usernameblockuc.NewService(entries, nil, time.Hour)
+
+	require.NoError(t, svc.Check(ctx, "first"))
+	require.NoError(t, svc.Check(ctx, "second"))
+	require.Equal(t, 1, entries.lists)
+
+	require.NoError(t, svc.Invalidate(ctx))
+	require.NoError(t, svc.Check(ctx, "third"))
+	require.Equal(t, 2, entries.lists)
+}
+
+func TestAdd_ValidatesInput(t *testing.T) {
+	ctx := context.Background()
+	svc := usernameblockuc.NewService(&fakeBlocklist{}, nil, time.Hour)
+
+	_, err := svc.Add(ctx, uuid.New(), usernameblockuc.AddInput{Term: "123"})
+	require.ErrorIs(t, err, usernameblockuc.ErrInvalidTerm)
+	_, err = svc.Add(ctx, uuid.New(), usernameblockuc.AddInput{Term: "name", Mode: "prefix"})
+	require.ErrorIs(t, err, usernameblockuc.ErrInvalidMode)
+	_, err = svc.Add(ctx, uuid.New(), usernameblockuc.AddInput{Term: "name", Category: "spam"})
+	require.ErrorIs(t, err, usernameblockuc.ErrInvalidCategory)
+}
@@ -0,0 +1,57 @@
+package usernamefilter_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"workout-app/pkg/usernamefilter"
+)
+
+func TestNormalize_FoldsCaseSuffixLeetAndRepeats(t *testing.T) {
+	require.Equal(t, "admin", usernamefilter.Normalize("Admin2024"))
+	require.Equal(t, "admin", usernamefilter.Normalize("adm1n"))
+	require.Equal(t, "fuck", usernamefilter.Normalize("FUUUCK"))
+	require.Empty(t, usernamefilter.Normalize("12345"))
+}
+
+func TestMatcher_ReservedNamesMatchWholeName(t *testing.T) {
+	m := usernamefilter.NewMatcher(usernamefilter.Reserved())
+
+	for _, name := range []string{"admin", "Admin2", "adm1n", "Support", "system7"} {
+		err := m.Check(name)
+		require.ErrorIs(t, err, usernamefilter.ErrNotAllowed, name)
+		var filterErr *usernamefilter.Error
+		require.ErrorAs(t, err, &filterErr)
+		require.Equal(t, usernamefilter.CategoryReserved, filterErr.Category)
+	}
+	for _, name := range []string{"administratorfan", "ivanadmin", "user42"} {
+		require.NoError(t, m.Check(name), name)
+	}
+}
+
+func TestMatcher_ProfanityByLocale(t *testing.T) {
+	en, err := usernamefilter.Profanity("en")
+	require.NoError(t, err)
+	ru, err := usernamefilter.Profanity("RU")
+	require.NoError(t, err)
+	m := usernamefilter.NewMatcher(en, ru)
+
+	// Вхождение с цифрами-подстановками и повторами букв.
+	require.ErrorIs(t, m.Check("xXfuuuck3rXx"), usernamefilter.ErrNotAllowed)
+	require.ErrorIs(t, m.Check("blyat777"), usernamefilter.ErrNotAllowed)
+	// Короткие слова запрещены только целиком.
+	require.ErrorIs(t, m.Check("suka"), usernamefilter.ErrNotAllowed)
+	require.NoError(t, m.Check("sukaboy"))
+	require.NoError(t, m.Check("dickens"))
+	require.NoError(t, m.Check("rebate"))
+
+	_, err = usernamefilter.Profanity("de")
+	require.ErrorIs(t, err, usernamefilter.ErrUnknownLocale)
+	require.Equal(t, []string{"en", "ru"}, usernamefilter.Locales())
+}
+
+func TestMatcher_NilAllowsEverything(t *testing.T) {
+	var m *usernamefilter.Matcher
+	require.NoError(t, m.Check("admin"))
+}
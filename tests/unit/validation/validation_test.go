@@ -0,0 +1,80 @@
+package validation_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+
+	"workout-app/internal/handler/validation"
+	"workout-app/pkg/password"
+)
+
+type registerRequest struct {
+	Email    string `json:"email" binding:"required,email"`
+	Password string `json:"password" binding:"required,min=8"`
+	Age      int    `json:"age" binding:"omitempty,max=120"`
+}
+
+func bind(body, acceptLanguage string) []validation.FieldError {
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+	c.Request.Header.Set("Content-Type", "application/json")
+	if acceptLanguage != "" {
+		c.Request.Header.Set("Accept-Language", acceptLanguage)
+	}
+
+	var req registerRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		return validation.Details(c, err)
+	}
+	return nil
+}
+
+func TestDetails_ValidationRules(t *testing.T) {
+	got := bind(`{"email":"not-an-email","password":"short","age":300}`, "")
+	require.Equal(t, []validation.FieldError{
+		{Field: "email", Rule: "email", Message: "Некорректный email"},
+		{Field: "password", Rule: "min", Param: "8", Message: "Минимальная длина — 8 символов"},
+		{Field: "age", Rule: "max", Param: "120", Message: "Значение должно быть не больше 120"},
+	}, got)
+}
+
+func TestDetails_LanguageFromAcceptLanguage(t *testing.T) {
+	got := bind(`{"password":"longenough"}`, "en-US,en;q=0.9")
+	require.Equal(t, []validation.FieldError{
+		{Field: "email", Rule: "required", Message: "This field is required"},
+	}, got)
+}
+
+func TestDetails_DecodeErrors(t *testing.T) {
+	got := bind(`{"email":"a@b.c","password":"longenough","age":"old"}`, "en")
+	require.Equal(t, []validation.FieldError{
+		{Field: "age", Rule: "type", Param: "integer", Message: "Expected a value of type integer"},
+	}, got)
+
+	got = bind(`{"email":`, "")
+	require.Len(t, got, 1)
+	require.Equal(t, "malformed", got[0].Rule)
+	require.Empty(t, got[0].Field)
+}
+
+func TestPassword_PolicyViolations(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request = httptest.NewRequest(http.MethodPost, "/", nil)
+	c.Request.Header.Set("Accept-Language", "en")
+
+	err := password.Policy{MinLength: 10, RequiredClasses: []password.Class{password.ClassDigit}}.Validate("short")
+	require.Equal(t, []validation.FieldError{
+		{Field: "new_password", Rule: password.ViolationTooShort, Param: "10", Message: "Password must be at least 10 characters long"},
+		{Field: "new_password", Rule: password.ViolationMissingDigit, Message: "Password must contain a digit"},
+	}, validation.Password(c, "new_password", err))
+
+	require.Nil(t, validation.Password(c, "new_password", nil))
+}
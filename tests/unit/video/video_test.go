@@ -0,0 +1,228 @@
+package video_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	domain "workout-app/internal/domain/video"
+	repo "workout-app/internal/repository/interfaces"
+	videouc "workout-app/internal/usecase/video"
+	"workout-app/pkg/logger"
+	"workout-app/pkg/storage"
+)
+
+// fakeVideos — in-memory реализация VideoRepository.
+type fakeVideos struct {
+	items map[uuid.UUID]domain.Video
+}
+
+func newFakeVideos() *fakeVideos {
+	return &fakeVideos{items: map[uuid.UUID]domain.Video{}}
+}
+
+func (r *fakeVideos) Create(_ context.Context, v *domain.Video) error {
+	r.items[v.ID] = *v
+	return nil
+}
+
+func (r *fakeVideos) GetByID(_ context.Context, id uuid.UUID) (*domain.Video, error) {
+	v, ok := r.items[id]
+	if !ok {
+		return nil, repo.ErrNotFound
+	}
+	return &v, nil
+}
+
+func (r *fakeVideos) Update(_ context.Context, v *domain.Video) error {
+	if _, ok := r.items[v.ID]; !ok {
+		return repo.ErrNotFound
+	}
+	r.items[v.ID] = *v
+	return nil
+}
+
+func (r *fakeVideos) Delete(_ context.Context, id uuid.UUID) error {
+	if _, ok := r.items[id]; !ok {
+		return repo.ErrNotFound
+	}
+	delete(r.items, id)
+	return nil
+}
+
+func (r *fakeVideos) ListByOwner(_ context.Context, ownerID uuid.UUID) ([]*domain.Video, error) {
+	var result []*domain.Video
+	for _, v := range r.items {
+		if v.OwnerID == ownerID {
+			v := v
+			result = append(result, &v)
+		}
+	}
+	return result, nil
+}
+
+// transcoderFunc адаптирует функцию к интерфейсу Transcoder.
+type transcoderFunc func(ctx context.Context, src videouc.Source) (videouc.Source, error)
+
+func (f transcoderFunc) Transcode(ctx context.Context, src videouc.Source) (videouc.Source, error) {
+	return f(ctx, src)
+}
+
+// mp4File — минимальный заголовок ftyp MP4, по которому определяется тип, и немного данных.
+var mp4File = append([]byte("\x00\x00\x00\x18ftypmp42\x00\x00\x00\x00isommp42"), bytes.Repeat([]byte{0xAB}, 100)...)
+
+type fixture struct {
+	svc    videouc.Service
+	videos *fakeVideos
+	dir    string
+}
+
+func newFixture(t *testing.T, transcoder videouc.Transcoder, ttl time.Duration) *fixture {
+	t.Helper()
+	dir := t.TempDir()
+	videos := newFakeVideos()
+	svc := videouc.NewService(videos, storage.NewLocalStorage(dir, "/uploads"), transcoder, videouc.Config{
+		MaxBytes:   1024,
+		LinkTTL:    ttl,
+		SigningKey: []byte("test-key"),
+		BaseURL:    "https://api.example.com/",
+	}, logger.New(io.Discard, slog.LevelError, logger.FormatJSON))
+	return &fixture{svc: svc, videos: videos, dir: dir}
+}
+
+func (f *fixture) upload(t *testing.T) *domain.Video {
+	t.Helper()
+	v, err := f.svc.Upload(context.Background(), uuid.New(), "Присед", bytes.NewReader(mp4File), int64(len(mp4File)))
+	require.NoError(t, err)
+	return v
+}
+
+// streamParams извлекает из ссылки на воспроизведение ID видео, expires и signature.
+func streamParams(t *testing.T, link string) (string, string, string) {
+	t.Helper()
+	u, err := url.Parse(link)
+	require.NoError(t, err)
+	return u.Path, u.Query().Get("expires"), u.Query().Get("signature")
+}
+
+func TestUpload_StoresVideoAndMarksReady(t *testing.T) {
+	f := newFixture(t, nil, time.Hour)
+	v := f.upload(t)
+
+	require.Equal(t, domain.StatusReady, v.Status)
+	require.Equal(t, "video/mp4", v.ContentType)
+	stored, err := os.ReadFile(filepath.Join(f.dir, v.StorageKey))
+	require.NoError(t, err)
+	require.Equal(t, mp4File, stored)
+}
+
+func TestUpload_RejectsUnsupportedFormatAndOversizedFile(t *testing.T) {
+	f := newFixture(t, nil, time.Hour)
+	ctx := context.Background()
+
+	png := []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+	_, err := f.svc.Upload(ctx, uuid.New(), "Присед", bytes.NewReader(png), int64(len(png)))
+	require.ErrorIs(t, err, videouc.ErrUnsupportedFormat)
+
+	_, err = f.svc.Upload(ctx, uuid.New(), "Присед", bytes.NewReader(mp4File), 2048)
+	require.ErrorIs(t, err, videouc.ErrFileTooLarge)
+
+	_, err = f.svc.Upload(ctx, uuid.New(), "  ", bytes.NewReader(mp4File), int64(len(mp4File)))
+	require.ErrorIs(t, err, videouc.ErrInvalidTitle)
+}
+
+func TestUpload_TranscoderReplacesOriginal(t *testing.T) {
+	var original string
+	f := newFixture(t, transcoderFunc(func(ctx context.Context, src videouc.Source) (videouc.Source, error) {
+		original = src.Key
+		return videouc.Source{Key: "videos/transcoded.mp4", ContentType: "video/mp4", Size: 42}, nil
+	}), time.Hour)
+
+	v := f.upload(t)
+	require.Equal(t, "videos/transcoded.mp4", v.StorageKey)
+	require.Equal(t, int64(42), v.SizeBytes)
+	_, err := os.Stat(filepath.Join(f.dir, original))
+	require.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestUpload_TranscoderFailureMarksFailed(t *testing.T) {
+	f := newFixture(t, transcoderFunc(func(ctx context.Context, src videouc.Source) (videouc.Source, error) {
+		return videouc.Source{}, errors.New("ffmpeg exited with code 1")
+	}), time.Hour)
+
+	v := f.upload(t)
+	require.Equal(t, domain.StatusFailed, v.Status)
+
+	_, err := f.svc.StreamLink(context.Background(), v.ID)
+	require.ErrorIs(t, err, videouc.ErrNotReady)
+}
+
+func TestStream_SignedLinkOpensVideo(t *testing.T) {
+	f := newFixture(t, nil, time.Hour)
+	v := f.upload(t)
+	ctx := context.Background()
+
+	link, err := f.svc.StreamLink(ctx, v.ID)
+	require.NoError(t, err)
+	path, expires, signature := streamParams(t, link.URL)
+	require.Equal(t, "/api/v1/videos/"+v.ID.String()+"/stream", path)
+	require.Contains(t, link.URL, "https://api.example.com/api/v1/videos/")
+
+	stream, err := f.svc.OpenStream(ctx, v.ID, expires, signature)
+	require.NoError(t, err)
+	require.NotNil(t, stream.Object)
+	defer stream.Object.Close()
+	require.Equal(t, int64(len(mp4File)), stream.Object.Size)
+
+	// Объект поддерживает произвольный доступ для Range-запросов.
+	_, err = stream.Object.Seek(24, io.SeekStart)
+	require.NoError(t, err)
+	rest, err := io.ReadAll(stream.Object)
+	require.NoError(t, err)
+	require.Equal(t, mp4File[24:], rest)
+}
+
+func TestStream_RejectsTamperedAndExpiredLinks(t *testing.T) {
+	f := newFixture(t, nil, time.Hour)
+	v := f.upload(t)
+	ctx := context.Background()
+
+	link, err := f.svc.StreamLink(ctx, v.ID)
+	require.NoError(t, err)
+	_, expires, signature := streamParams(t, link.URL)
+
+	_, err = f.svc.OpenStream(ctx, uuid.New(), expires, signature)
+	require.ErrorIs(t, err, videouc.ErrInvalidSignature)
+	_, err = f.svc.OpenStream(ctx, v.ID, expires+"0", signature)
+	require.ErrorIs(t, err, videouc.ErrInvalidSignature)
+
+	expired := newFixture(t, nil, -time.Minute)
+	expired.videos.items[v.ID] = f.videos.items[v.ID]
+	link, err = expired.svc.StreamLink(ctx, v.ID)
+	require.NoError(t, err)
+	_, expires, signature = streamParams(t, link.URL)
+	_, err = expired.svc.OpenStream(ctx, v.ID, expires, signature)
+	require.ErrorIs(t, err, videouc.ErrLinkExpired)
+}
+
+func TestDelete_OnlyOwnerOrAdmin(t *testing.T) {
+	f := newFixture(t, nil, time.Hour)
+	v := f.upload(t)
+	ctx := context.Background()
+
+	require.ErrorIs(t, f.svc.Delete(ctx, uuid.New(), false, v.ID), videouc.ErrForbidden)
+	require.NoError(t, f.svc.Delete(ctx, v.OwnerID, false, v.ID))
+	_, err := os.Stat(filepath.Join(f.dir, v.StorageKey))
+	require.ErrorIs(t, err, os.ErrNotExist)
+	require.ErrorIs(t, f.svc.Delete(ctx, v.OwnerID, true, v.ID), videouc.ErrVideoNotFound)
+}
@@ -0,0 +1,258 @@
+package weeklysummary_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log/slog"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	notificationdomain "workout-app/internal/domain/notification"
+	userdomain "workout-app/internal/domain/user"
+	domain "workout-app/internal/domain/weeklysummary"
+	workoutdomain "workout-app/internal/domain/workout"
+	repo "workout-app/internal/repository/interfaces"
+	weeklysummaryuc "workout-app/internal/usecase/weeklysummary"
+	"workout-app/pkg/logger"
+	"workout-app/pkg/mailer"
+)
+
+// monday8UTC — понедельник 08:00 UTC: в Токио уже 17:00, а в UTC час отправки (09:00) ещё не наступил.
+var monday8UTC = time.Date(2026, 10, 12, 8, 0, 0, 0, time.UTC)
+
+type fakeTx struct{}
+
+func (fakeTx) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+type fakeUsers struct {
+	repo.UserRepository
+	users []*userdomain.User
+	calls int
+}
+
+func (r *fakeUsers) ListWeeklySummaryRecipients(_ context.Context, _ time.Time, after uuid.UUID, limit int) ([]*userdomain.User, error) {
+	r.calls++
+	sort.Slice(r.users, func(i, j int) bool { return bytes.Compare(r.users[i].ID[:], r.users[j].ID[:]) < 0 })
+	var out []*userdomain.User
+	for _, u := range r.users {
+		if bytes.Compare(u.ID[:], after[:]) > 0 && len(out) < limit {
+			out = append(out, u)
+		}
+	}
+	return out, nil
+}
+
+// fakeStats возвращает итоги недели и рекорды по пользователю и запоминает границы недели.
+type fakeStats struct {
+	repo.WorkoutStatsRepository
+	weeks   map[uuid.UUID]workoutdomain.WeekStats
+	records map[uuid.UUID][]workoutdomain.PersonalRecord
+
+	from, to time.Time
+}
+
+func (r *fakeStats) Weekly(_ context.Context, userID uuid.UUID, from, to time.Time, _ string) ([]workoutdomain.WeekStats, error) {
+	r.from, r.to = from, to
+	if week, ok := r.weeks[userID]; ok {
+		return []workoutdomain.WeekStats{week}, nil
+	}
+	return nil, nil
+}
+
+func (r *fakeStats) PersonalRecords(_ context.Context, userID uuid.UUID, _, _ time.Time) ([]workoutdomain.PersonalRecord, error) {
+	return r.records[userID], nil
+}
+
+type fakeNotifications struct {
+	repo.NotificationRepository
+	prefs map[uuid.UUID][]notificationdomain.Preference
+}
+
+func (r *fakeNotifications) GetPreferences(_ context.Context, userID uuid.UUID) ([]notificationdomain.Preference, error) {
+	return r.prefs[userID], nil
+}
+
+type claimKey struct {
+	userID    uuid.UUID
+	weekStart time.Time
+}
+
+type fakeSummaries struct {
+	claimed map[claimKey]bool
+}
+
+func (r *fakeSummaries) Claim(_ context.Context, s *domain.Summary) (bool, error) {
+	key := claimKey{s.UserID, s.WeekStart}
+	if r.claimed[key] {
+		return false, nil
+	}
+	r.claimed[key] = true
+	return true, nil
+}
+
+func (r *fakeSummaries) DeleteBefore(context.Context, time.Time, int) (int64, error) { return 0, nil }
+
+type sentSummary struct {
+	email    string
+	locale   string
+	timezone string
+	summary  mailer.WeeklySummary
+}
+
+type fakeSender struct {
+	mailer.EmailSender
+	sent []sentSummary
+}
+
+func (s *fakeSender) SendWeeklySummary(ctx context.Context, email string, summary mailer.WeeklySummary) error {
+	s.sent = append(s.sent, sentSummary{
+		email:    email,
+		locale:   mailer.LocaleFromContext(ctx),
+		timezone: mailer.TimezoneFromContext(ctx),
+		summary:  summary,
+	})
+	return nil
+}
+
+type fixture struct {
+	svc       weeklysummaryuc.Service
+	users     *fakeUsers
+	stats     *fakeStats
+	prefs     *fakeNotifications
+	summaries *fakeSummaries
+	sender    *fakeSender
+}
+
+func newFixture(batchSize int, now time.Time) *fixture {
+	f := &fixture{
+		users: &fakeUsers{},
+		stats: &fakeStats{
+			weeks:   map[uuid.UUID]workoutdomain.WeekStats{},
+			records: map[uuid.UUID][]workoutdomain.PersonalRecord{},
+		},
+		prefs:     &fakeNotifications{prefs: map[uuid.UUID][]notificationdomain.Preference{}},
+		summaries: &fakeSummaries{claimed: map[claimKey]bool{}},
+		sender:    &fakeSender{},
+	}
+	log := logger.New(io.Discard, slog.LevelError, logger.FormatJSON)
+	f.svc = weeklysummaryuc.NewService(fakeTx{}, f.users, f.stats, f.prefs, f.summaries, f.sender, weeklysummaryuc.Config{
+		BatchSize: batchSize,
+		SendHour:  9,
+		Now:       func() time.Time { return now },
+	}, log)
+	return f
+}
+
+// addUser добавляет пользователя, который тренировался на прошлой неделе.
+func (f *fixture) addUser(timezone string, sessions int) *userdomain.User {
+	u := userdomain.NewUser(uuid.NewString()+"@example.com", "hash", "")
+	u.Timezone = timezone
+	f.users.users = append(f.users.users, u)
+	if sessions > 0 {
+		f.stats.weeks[u.ID] = workoutdomain.WeekStats{Sessions: sessions, TrainingDays: sessions, Sets: 10 * sessions, VolumeKg: 1000}
+	}
+	return u
+}
+
+func TestRun_SendsPreviousWeekOnce(t *testing.T) {
+	f := newFixture(2, monday8UTC)
+	ctx := context.Background()
+	due := f.addUser("Asia/Tokyo", 3)
+	due.Language = userdomain.LanguageRussian
+	f.stats.records[due.ID] = []workoutdomain.PersonalRecord{
+		{Exercise: "Присед", WeightKg: 120, PreviousKg: 110},
+		{Exercise: "Жим лёжа", WeightKg: 90, PreviousKg: 85},
+	}
+	idle := f.addUser("Asia/Tokyo", 0)
+	early := f.addUser("", 2)
+
+	require.NoError(t, f.svc.Run(ctx))
+	require.Len(t, f.sender.sent, 1)
+	sent := f.sender.sent[0]
+	require.Equal(t, due.Email, sent.email)
+	require.Equal(t, "ru", sent.locale)
+	require.Equal(t, "Asia/Tokyo", sent.timezone)
+	require.Equal(t, mailer.WeeklySummary{
+		WeekStart:    time.Date(2026, 10, 5, 0, 0, 0, 0, time.UTC),
+		Sessions:     3,
+		TrainingDays: 3,
+		Sets:         30,
+		Volume:       1000,
+		WeightUnit:   mailer.WeightUnitKg,
+		PersonalRecords: []mailer.PersonalRecord{
+			{Exercise: "Присед", Weight: 120, Previous: 110},
+			{Exercise: "Жим лёжа", Weight: 90, Previous: 85},
+		},
+	}, sent.summary)
+
+	// Неделя считается по часовому поясу пользователя: с полуночи прошлого понедельника до полуночи текущего.
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	require.NoError(t, err)
+	require.True(t, f.stats.from.Equal(time.Date(2026, 10, 5, 0, 0, 0, 0, tokyo)))
+	require.True(t, f.stats.to.Equal(time.Date(2026, 10, 12, 0, 0, 0, 0, tokyo)))
+
+	// Неделя без тренировок отмечена, пользователь, у которого час отправки не наступил, — нет.
+	require.True(t, f.summaries.claimed[claimKey{idle.ID, time.Date(2026, 10, 5, 0, 0, 0, 0, time.UTC)}])
+	require.False(t, f.summaries.claimed[claimKey{early.ID, time.Date(2026, 10, 5, 0, 0, 0, 0, time.UTC)}])
+	require.Len(t, f.summaries.claimed, 2)
+
+	require.NoError(t, f.svc.Run(ctx))
+	require.Len(t, f.sender.sent, 1)
+}
+
+func TestRun_ConvertsUnitsAndLimitsRecords(t *testing.T) {
+	f := newFixture(10, monday8UTC)
+	u := f.addUser("Asia/Tokyo", 1)
+	u.Units = userdomain.UnitsImperial
+	for i := 0; i < 7; i++ {
+		f.stats.records[u.ID] = append(f.stats.records[u.ID], workoutdomain.PersonalRecord{Exercise: "Тяга", WeightKg: 100, PreviousKg: 90})
+	}
+
+	require.NoError(t, f.svc.Run(context.Background()))
+	require.Len(t, f.sender.sent, 1)
+	summary := f.sender.sent[0].summary
+	require.Equal(t, mailer.WeightUnitLb, summary.WeightUnit)
+	require.Equal(t, 2204.62, summary.Volume)
+	require.Len(t, summary.PersonalRecords, 5)
+	require.Equal(t, 220.46, summary.PersonalRecords[0].Weight)
+}
+
+func TestRun_RespectsPreferenceAndSuspension(t *testing.T) {
+	f := newFixture(10, monday8UTC)
+	optedOut := f.addUser("Asia/Tokyo", 2)
+	f.prefs.prefs[optedOut.ID] = []notificationdomain.Preference{{Type: notificationdomain.TypeWeeklySummary, Email: false, Push: true}}
+	suspended := f.addUser("Asia/Tokyo", 2)
+	suspended.Suspension = &userdomain.Suspension{At: monday8UTC.Add(-time.Hour), Reason: "spam"}
+
+	require.NoError(t, f.svc.Run(context.Background()))
+	require.Empty(t, f.sender.sent)
+	require.Len(t, f.summaries.claimed, 1)
+}
+
+func TestRun_SkipsWhenItIsNotMondayAnywhere(t *testing.T) {
+	f := newFixture(10, time.Date(2026, 10, 14, 12, 0, 0, 0, time.UTC))
+	f.addUser("Asia/Tokyo", 2)
+
+	require.NoError(t, f.svc.Run(context.Background()))
+	require.Zero(t, f.users.calls)
+	require.Empty(t, f.sender.sent)
+}
+
+func TestPreviousWeek(t *testing.T) {
+	// Воскресенье 20:00 UTC — в Токио уже понедельник, в Нью-Йорке ещё воскресенье.
+	at := time.Date(2026, 10, 11, 20, 0, 0, 0, time.UTC)
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	require.NoError(t, err)
+	newYork, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+
+	require.Equal(t, time.Date(2026, 10, 5, 0, 0, 0, 0, time.UTC), domain.PreviousWeek(at, tokyo))
+	require.Equal(t, time.Date(2026, 9, 28, 0, 0, 0, 0, time.UTC), domain.PreviousWeek(at, newYork))
+}
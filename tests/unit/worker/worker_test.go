@@ -0,0 +1,142 @@
+package worker_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"workout-app/internal/worker"
+	"workout-app/pkg/lock"
+	"workout-app/pkg/logger"
+	"workout-app/pkg/tracing"
+)
+
+func newLogger() logger.Logger {
+	return logger.New(io.Discard, slog.LevelError, logger.FormatJSON)
+}
+
+func TestTrack_ContinuesStoredTraceAndRecordsMetrics(t *testing.T) {
+	metrics := worker.NewMetrics()
+	ctx := worker.WithMetrics(context.Background(), metrics)
+	parent := "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+
+	var inner tracing.SpanContext
+	err := worker.Track(ctx, "email:welcome", parent, func(ctx context.Context) error {
+		inner = tracing.FromContext(ctx)
+		return errors.New("smtp down")
+	})
+	require.EqualError(t, err, "smtp down")
+	require.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", inner.TraceID)
+	require.NotEqual(t, "00f067aa0ba902b7", inner.SpanID)
+
+	require.NoError(t, worker.Track(ctx, "email:welcome", "", func(context.Context) error { return nil }))
+
+	jobs := metrics.Jobs()
+	require.Len(t, jobs, 1)
+	require.Equal(t, "email:welcome", jobs[0].Type)
+	require.Equal(t, int64(2), jobs[0].Runs)
+	require.Equal(t, int64(1), jobs[0].Failures)
+	require.Equal(t, int64(2), jobs[0].Buckets[0])
+
+	failures := metrics.RecentFailures()
+	require.Len(t, failures, 1)
+	require.Equal(t, "smtp down", failures[0].Error)
+	require.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", failures[0].TraceID)
+}
+
+func TestMetrics_KeepsOnlyRecentFailuresNewestFirst(t *testing.T) {
+	metrics := worker.NewMetrics()
+	start := time.Now()
+	for i := 0; i < 60; i++ {
+		metrics.Observe("webhooks", start.Add(time.Duration(i)*time.Second), time.Minute, fmt.Errorf("fail %d", i), "")
+	}
+
+	failures := metrics.RecentFailures()
+	require.Len(t, failures, 50)
+	require.Equal(t, "fail 59", failures[0].Error)
+	require.Equal(t, "fail 10", failures[49].Error)
+
+	job := metrics.Jobs()[0]
+	require.Equal(t, int64(60), job.Failures)
+	require.Equal(t, int64(60), job.Buckets[len(worker.DurationBuckets)])
+	require.Equal(t, time.Minute, job.MaxDuration)
+}
+
+func TestPeriodic_RecordsRunsInMetrics(t *testing.T) {
+	metrics := worker.NewMetrics()
+	runs := make(chan tracing.SpanContext, 10)
+	job := worker.NewPeriodic("exports", 10*time.Millisecond, func(ctx context.Context) error {
+		runs <- tracing.FromContext(ctx)
+		return worker.Track(ctx, "export", "", func(context.Context) error { return nil })
+	}, metrics, newLogger())
+
+	require.NoError(t, job.Start(context.Background()))
+	var first tracing.SpanContext
+	select {
+	case first = <-runs:
+	case <-time.After(time.Second):
+		t.Fatal("periodic job did not run")
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	require.NoError(t, job.Stop(ctx))
+
+	require.True(t, first.IsValid())
+	types := map[string]int64{}
+	for _, m := range metrics.Jobs() {
+		types[m.Type] = m.Runs
+	}
+	require.GreaterOrEqual(t, types["exports"], int64(1))
+	require.GreaterOrEqual(t, types["export"], int64(1))
+}
+
+func TestPeriodic_SkipsRunsWhileAnotherReplicaHoldsLock(t *testing.T) {
+	locker := lock.NewMemoryLocker()
+	held, err := locker.TryAcquire(context.Background(), "job:webhooks", time.Minute)
+	require.NoError(t, err)
+
+	runs := make(chan struct{}, 10)
+	job := worker.NewPeriodic("webhooks", 5*time.Millisecond, func(ctx context.Context) error {
+		runs <- struct{}{}
+		return nil
+	}, nil, newLogger())
+	job.UseLock(locker, time.Minute)
+
+	require.NoError(t, job.Start(context.Background()))
+	require.Eventually(t, func() bool { return job.Stats().Skipped >= 2 }, time.Second, time.Millisecond)
+	require.Zero(t, job.Stats().Runs)
+
+	require.NoError(t, held.Release(context.Background()))
+	select {
+	case <-runs:
+	case <-time.After(time.Second):
+		t.Fatal("periodic job did not run after the lock was released")
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	require.NoError(t, job.Stop(ctx))
+}
+
+func TestParse_RejectsMalformedTraceparent(t *testing.T) {
+	for _, raw := range []string{
+		"",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7",
+		"01-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+		"00-00000000000000000000000000000000-00f067aa0ba902b7-01",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-0000000000000000-01",
+		"00-4BF92F3577B34DA6A3CE929D0E0E4736-00f067aa0ba902b7-01",
+	} {
+		_, ok := tracing.Parse(raw)
+		require.False(t, ok, raw)
+	}
+
+	sc, ok := tracing.Parse("00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	require.True(t, ok)
+	require.Equal(t, "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01", sc.String())
+}
@@ -0,0 +1,81 @@
+package workout_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	programdomain "workout-app/internal/domain/program"
+	domain "workout-app/internal/domain/workout"
+	workoutuc "workout-app/internal/usecase/workout"
+)
+
+// fakePlanPrograms дополняет fakePrograms программой назначения.
+type fakePlanPrograms struct {
+	fakePrograms
+	program *programdomain.Program
+}
+
+func (r *fakePlanPrograms) GetByID(context.Context, uuid.UUID) (*programdomain.Program, error) {
+	return r.program, nil
+}
+
+func TestStart_ProgramWorkoutPlanDrivesRest(t *testing.T) {
+	userID := uuid.New()
+	p := programdomain.New(uuid.New(), "Сила", "", []programdomain.Week{
+		{Number: 1, Days: []programdomain.Day{
+			{Number: 3, Workouts: []programdomain.Workout{{
+				Title: "Ноги",
+				Exercises: []programdomain.Exercise{
+					{Name: "Присед", Sets: 2, Reps: 5, WeightKg: weight(100), Tempo: "3-1-X-0", RestSeconds: []int{120, 180}},
+					{Name: "Выпады", Sets: 2, Reps: 10, RestSeconds: []int{60}},
+				},
+			}}},
+		}},
+	})
+	assignment := &programdomain.Assignment{ID: uuid.New(), ProgramID: p.ID, UserID: userID}
+	programs := &fakePlanPrograms{fakePrograms: fakePrograms{assignments: []*programdomain.Assignment{assignment}}, program: p}
+	sessions := &fakeSessions{sessions: map[uuid.UUID]*domain.Session{}}
+	svc := workoutuc.NewService(sessions, programs, &fakePublisher{}, &fakeAccess{}, &fakeConsents{}, nil, 5*time.Minute)
+	ctx := context.Background()
+
+	_, err := svc.Start(ctx, userID, workoutuc.StartInput{Title: "Ноги", ProgramWorkout: &workoutuc.ProgramWorkoutRef{Week: 1, Day: 3}})
+	require.ErrorIs(t, err, workoutuc.ErrPlanNeedsAssignment)
+	_, err = svc.Start(ctx, userID, workoutuc.StartInput{
+		Title: "Ноги", AssignmentID: &assignment.ID, ProgramWorkout: &workoutuc.ProgramWorkoutRef{Week: 1, Day: 4},
+	})
+	require.ErrorIs(t, err, workoutuc.ErrPlanNotFound)
+
+	session, err := svc.Start(ctx, userID, workoutuc.StartInput{
+		Title: "Ноги", AssignmentID: &assignment.ID, ProgramWorkout: &workoutuc.ProgramWorkoutRef{Week: 1, Day: 3},
+	})
+	require.NoError(t, err)
+	require.Len(t, session.Plan, 2)
+	require.Equal(t, "3-1-X-0", session.Plan[0].Tempo)
+	require.Equal(t, []int{60, 60}, session.Plan[1].RestSeconds)
+
+	var rests []*int
+	for _, in := range []workoutuc.SetInput{
+		{Exercise: "присед", Reps: 5},
+		{Exercise: "Выпады", Reps: 10},
+		{Exercise: "Присед", Reps: 5},
+		{Exercise: "Присед", Reps: 3},
+		{Exercise: "Жим", Reps: 8},
+	} {
+		set, _, err := svc.LogSet(ctx, userID, session.ID, in)
+		require.NoError(t, err)
+		rests = append(rests, set.PrescribedRest)
+	}
+	require.Equal(t, 120, *rests[0])
+	require.Equal(t, 60, *rests[1])
+	require.Equal(t, 180, *rests[2])
+	require.Nil(t, rests[3], "подход сверх плана")
+	require.Nil(t, rests[4], "упражнение вне плана")
+
+	stored, err := svc.Get(ctx, userID, session.ID)
+	require.NoError(t, err)
+	require.Equal(t, 180, *stored.PrescribedRest(2))
+}
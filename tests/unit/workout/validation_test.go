@@ -0,0 +1,122 @@
+package workout_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	domain "workout-app/internal/domain/workout"
+	workoutuc "workout-app/internal/usecase/workout"
+)
+
+// fakeExerciseLimits отдаёт справочник границ и считает обращения к нему.
+type fakeExerciseLimits struct {
+	limits []domain.ExerciseLimits
+	calls  int
+}
+
+func (r *fakeExerciseLimits) List(context.Context) ([]domain.ExerciseLimits, error) {
+	r.calls++
+	return r.limits, nil
+}
+
+func intPtr(v int) *int { return &v }
+
+func newValidator(mode domain.ValidationMode) (*workoutuc.Validator, *fakeExerciseLimits) {
+	limits := &fakeExerciseLimits{limits: []domain.ExerciseLimits{
+		{Exercise: "жим лёжа", MaxWeightKg: weight(450)},
+		{Exercise: "подтягивания", MaxReps: intPtr(150)},
+	}}
+	return workoutuc.NewValidator(limits, workoutuc.ValidationConfig{
+		Mode:        mode,
+		MaxWeightKg: 300,
+		MaxReps:     200,
+		MaxDuration: 6 * time.Hour,
+	}, time.Minute), limits
+}
+
+func TestRules_CheckSetUsesExerciseLimits(t *testing.T) {
+	validator, limits := newValidator(domain.ValidationWarn)
+	rules, err := validator.Rules(context.Background())
+	require.NoError(t, err)
+
+	// Граница упражнения выше общей; название сравнивается по ключу.
+	require.Empty(t, rules.CheckSet("  Жим   ЛЁЖА ", 5, weight(400)))
+	require.Equal(t, []domain.Warning{
+		{Code: domain.WarningWeight, Field: "weight_kg", Value: 400, Limit: 300},
+	}, rules.CheckSet("Присед", 5, weight(400)))
+	require.Equal(t, []domain.Warning{
+		{Code: domain.WarningReps, Field: "reps", Value: 180, Limit: 150},
+	}, rules.CheckSet("Подтягивания", 180, nil))
+	require.Empty(t, rules.CheckSet("Скручивания", 180, nil))
+
+	require.Empty(t, rules.CheckDuration(6*time.Hour))
+	require.Equal(t, domain.WarningDuration, rules.CheckDuration(7 * time.Hour)[0].Code)
+
+	// Справочник кешируется.
+	_, err = validator.Rules(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 1, limits.calls)
+}
+
+func TestRules_OffModeSkipsChecks(t *testing.T) {
+	validator, limits := newValidator(domain.ValidationOff)
+	rules, err := validator.Rules(context.Background())
+	require.NoError(t, err)
+	require.Empty(t, rules.CheckSet("Присед", 900, weight(900)))
+	require.Zero(t, limits.calls)
+
+	var disabled *workoutuc.Validator
+	rules, err = disabled.Rules(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, domain.ValidationOff, rules.Mode)
+}
+
+func TestLogSet_WarnModeFlagsImplausibleSet(t *testing.T) {
+	sessions := &fakeSessions{sessions: map[uuid.UUID]*domain.Session{}}
+	publisher := &fakePublisher{}
+	validator, _ := newValidator(domain.ValidationWarn)
+	svc := workoutuc.NewService(sessions, &fakePrograms{}, publisher, &fakeAccess{}, nil, validator, 5*time.Minute)
+	ctx := context.Background()
+	userID := uuid.New()
+
+	session, err := svc.Start(ctx, userID, workoutuc.StartInput{Title: "Ноги"})
+	require.NoError(t, err)
+	_, warnings, err := svc.LogSet(ctx, userID, session.ID, workoutuc.SetInput{Exercise: "Присед", Reps: 5, WeightKg: weight(100)})
+	require.NoError(t, err)
+	require.Empty(t, warnings)
+
+	// Опечатка: 1000 вместо 100.
+	set, warnings, err := svc.LogSet(ctx, userID, session.ID, workoutuc.SetInput{Exercise: "Присед", Reps: 5, WeightKg: weight(1000)})
+	require.NoError(t, err)
+	require.True(t, set.Implausible)
+	require.Len(t, warnings, 1)
+	require.Equal(t, domain.WarningWeight, warnings[0].Code)
+
+	// Неправдоподобный подход сохранён, но не входит в тоннаж.
+	_, err = svc.Finish(ctx, userID, session.ID)
+	require.NoError(t, err)
+	require.Len(t, sessions.sessions[session.ID].Sets, 2)
+	require.Equal(t, 2, publisher.events[0].Sets)
+	require.InDelta(t, 500.0, publisher.events[0].VolumeKg, 1e-9)
+}
+
+func TestLogSet_RejectModeReturnsWarnings(t *testing.T) {
+	sessions := &fakeSessions{sessions: map[uuid.UUID]*domain.Session{}}
+	validator, _ := newValidator(domain.ValidationReject)
+	svc := workoutuc.NewService(sessions, &fakePrograms{}, &fakePublisher{}, &fakeAccess{}, nil, validator, 5*time.Minute)
+	ctx := context.Background()
+	userID := uuid.New()
+
+	session, err := svc.Start(ctx, userID, workoutuc.StartInput{Title: "Спина"})
+	require.NoError(t, err)
+	_, _, err = svc.LogSet(ctx, userID, session.ID, workoutuc.SetInput{Exercise: "Подтягивания", Reps: 500})
+	require.ErrorIs(t, err, workoutuc.ErrImplausibleSet)
+	var implausibleErr *workoutuc.ImplausibleError
+	require.ErrorAs(t, err, &implausibleErr)
+	require.Equal(t, []domain.Warning{{Code: domain.WarningReps, Field: "reps", Value: 500, Limit: 150}}, implausibleErr.Warnings)
+	require.Empty(t, sessions.sessions[session.ID].Sets)
+}
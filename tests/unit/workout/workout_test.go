@@ -0,0 +1,339 @@
+package workout_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	consentdomain "workout-app/internal/domain/consent"
+	eventdomain "workout-app/internal/domain/event"
+	programdomain "workout-app/internal/domain/program"
+	domain "workout-app/internal/domain/workout"
+	repo "workout-app/internal/repository/interfaces"
+	consentuc "workout-app/internal/usecase/consent"
+	socialuc "workout-app/internal/usecase/social"
+	workoutuc "workout-app/internal/usecase/workout"
+)
+
+// fakeSessions хранит тренировки в памяти и повторяет ограничение «одна активная на пользователя».
+type fakeSessions struct {
+	repo.WorkoutSessionRepository
+	sessions map[uuid.UUID]*domain.Session
+}
+
+func (r *fakeSessions) Create(_ context.Context, s *domain.Session) error {
+	for _, existing := range r.sessions {
+		if existing.UserID == s.UserID && existing.IsActive() {
+			return repo.ErrActiveSessionExists
+		}
+	}
+	cp := *s
+	r.sessions[s.ID] = &cp
+	return nil
+}
+
+func (r *fakeSessions) GetByID(_ context.Context, id uuid.UUID) (*domain.Session, error) {
+	s, ok := r.sessions[id]
+	if !ok {
+		return nil, repo.ErrNotFound
+	}
+	cp := *s
+	return &cp, nil
+}
+
+func (r *fakeSessions) GetActive(_ context.Context, userID uuid.UUID) (*domain.Session, error) {
+	for _, s := range r.sessions {
+		if s.UserID == userID && s.IsActive() {
+			cp := *s
+			return &cp, nil
+		}
+	}
+	return nil, repo.ErrNotFound
+}
+
+func (r *fakeSessions) AddSet(_ context.Context, set *domain.Set) error {
+	s := r.sessions[set.SessionID]
+	s.Sets = append(s.Sets, *set)
+	return nil
+}
+
+func (r *fakeSessions) Finish(_ context.Context, id uuid.UUID, at time.Time) error {
+	s, ok := r.sessions[id]
+	if !ok || !s.IsActive() {
+		return repo.ErrNotFound
+	}
+	s.FinishedAt = &at
+	return nil
+}
+
+func (r *fakeSessions) SetDifficulty(_ context.Context, id uuid.UUID, difficulty int, at time.Time) error {
+	s, ok := r.sessions[id]
+	if !ok || s.IsActive() {
+		return repo.ErrNotFound
+	}
+	s.Difficulty = &difficulty
+	s.DifficultyRatedAt = &at
+	return nil
+}
+
+func (r *fakeSessions) ListByUser(_ context.Context, userID uuid.UUID, from, to time.Time, _ int) ([]*domain.Session, error) {
+	var result []*domain.Session
+	for _, s := range r.sessions {
+		if s.UserID == userID && !s.StartedAt.Before(from) && s.StartedAt.Before(to) {
+			cp := *s
+			result = append(result, &cp)
+		}
+	}
+	return result, nil
+}
+
+type fakePrograms struct {
+	repo.ProgramRepository
+	assignments []*programdomain.Assignment
+}
+
+func (r *fakePrograms) ListAssignmentsByUser(context.Context, uuid.UUID) ([]*programdomain.Assignment, error) {
+	return r.assignments, nil
+}
+
+type fakePublisher struct {
+	events []eventdomain.WorkoutFinished
+}
+
+func (p *fakePublisher) Publish(_ context.Context, eventType, _ string, payload any) {
+	if eventType == eventdomain.TypeWorkoutFinished {
+		p.events = append(p.events, payload.(eventdomain.WorkoutFinished))
+	}
+}
+
+// fakeAccess открывает тренировки всем, кроме пользователей из hidden.
+type fakeAccess struct {
+	hidden map[uuid.UUID]bool
+}
+
+func (a *fakeAccess) RequireWorkoutsAccess(_ context.Context, viewerID, ownerID uuid.UUID) error {
+	if viewerID != ownerID && a.hidden[ownerID] {
+		return socialuc.ErrWorkoutsHidden
+	}
+	return nil
+}
+
+// fakeConsents открывает тренеру классы данных клиентов из granted.
+type fakeConsents struct {
+	granted map[uuid.UUID]consentdomain.Scope
+}
+
+func (c *fakeConsents) Require(_ context.Context, _, clientID uuid.UUID, scope consentdomain.Scope) error {
+	if c.granted[clientID] != scope {
+		return consentuc.ErrConsentRequired
+	}
+	return nil
+}
+
+func newService() (workoutuc.Service, *fakeSessions, *fakePublisher) {
+	svc, sessions, publisher, _ := newServiceWithAccess()
+	return svc, sessions, publisher
+}
+
+func newServiceWithAccess() (workoutuc.Service, *fakeSessions, *fakePublisher, *fakeAccess) {
+	sessions := &fakeSessions{sessions: map[uuid.UUID]*domain.Session{}}
+	publisher := &fakePublisher{}
+	access := &fakeAccess{hidden: map[uuid.UUID]bool{}}
+	return workoutuc.NewService(sessions, &fakePrograms{}, publisher, access, &fakeConsents{}, nil, 5*time.Minute), sessions, publisher, access
+}
+
+func weight(kg float64) *float64 { return &kg }
+
+func TestSummarize_ExcludesIdleGapsBeyondAutoPause(t *testing.T) {
+	start := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	finish := start.Add(60 * time.Minute)
+	s := &domain.Session{
+		StartedAt:      start,
+		AutoPauseAfter: 5 * time.Minute,
+		FinishedAt:     &finish,
+		Sets: []domain.Set{
+			// Подходы записаны не по порядку: итоги не должны от этого зависеть.
+			{Reps: 10, WeightKg: weight(50), LoggedAt: start.Add(4 * time.Minute)},
+			{Reps: 5, WeightKg: weight(100), LoggedAt: start.Add(30 * time.Minute)},
+			{Reps: 8, WeightKg: weight(50), LoggedAt: start.Add(8 * time.Minute)},
+			{Reps: 20, LoggedAt: start.Add(33 * time.Minute)},
+		},
+	}
+
+	summary := s.Summarize(finish.Add(time.Hour))
+
+	// Промежутки: 4, 4, 22 (простой 17), 3, 27 (простой 22) минут.
+	require.Equal(t, 60*time.Minute, summary.TotalDuration)
+	require.Equal(t, 39*time.Minute, summary.PausedDuration)
+	require.Equal(t, 21*time.Minute, summary.ActiveDuration)
+	require.Equal(t, 2, summary.Pauses)
+	require.Equal(t, 4, summary.Sets)
+	require.InDelta(t, 1400.0, summary.VolumeKg, 1e-9)
+	require.InDelta(t, 1400.0/21, summary.VolumePerMinute, 1e-9)
+}
+
+func TestSummarize_ActiveSessionUsesNow(t *testing.T) {
+	start := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	s := &domain.Session{StartedAt: start, AutoPauseAfter: 5 * time.Minute}
+
+	summary := s.Summarize(start.Add(3 * time.Minute))
+	require.Equal(t, 3*time.Minute, summary.ActiveDuration)
+	require.Zero(t, summary.Pauses)
+	require.Zero(t, summary.VolumePerMinute)
+}
+
+func TestStartLogFinish_PublishesSummary(t *testing.T) {
+	svc, _, publisher := newService()
+	ctx := context.Background()
+	userID := uuid.New()
+
+	session, err := svc.Start(ctx, userID, workoutuc.StartInput{Title: "  Ноги  "})
+	require.NoError(t, err)
+	require.Equal(t, "Ноги", session.Title)
+	require.Equal(t, 5*time.Minute, session.AutoPauseAfter)
+
+	_, err = svc.Start(ctx, userID, workoutuc.StartInput{Title: "Ещё одна"})
+	require.ErrorIs(t, err, workoutuc.ErrSessionActive)
+
+	set, warnings, err := svc.LogSet(ctx, userID, session.ID, workoutuc.SetInput{Exercise: "Присед", Reps: 5, WeightKg: weight(100)})
+	require.NoError(t, err)
+	require.Empty(t, warnings)
+	require.False(t, set.LoggedAt.IsZero())
+
+	_, _, err = svc.LogSet(ctx, uuid.New(), session.ID, workoutuc.SetInput{Exercise: "Присед", Reps: 5})
+	require.ErrorIs(t, err, workoutuc.ErrSessionNotFound)
+
+	finished, err := svc.Finish(ctx, userID, session.ID)
+	require.NoError(t, err)
+	require.False(t, finished.IsActive())
+	require.Len(t, publisher.events, 1)
+	require.Equal(t, 1, publisher.events[0].Sets)
+	require.InDelta(t, 500.0, publisher.events[0].VolumeKg, 1e-9)
+
+	_, err = svc.Finish(ctx, userID, session.ID)
+	require.ErrorIs(t, err, workoutuc.ErrSessionFinished)
+	_, _, err = svc.LogSet(ctx, userID, session.ID, workoutuc.SetInput{Exercise: "Присед", Reps: 5})
+	require.ErrorIs(t, err, workoutuc.ErrSessionFinished)
+}
+
+func TestStart_FinishesStaleSessionAtLastActivity(t *testing.T) {
+	svc, sessions, publisher := newService()
+	ctx := context.Background()
+	userID := uuid.New()
+
+	started := time.Now().UTC().Add(-20 * time.Hour)
+	lastSet := started.Add(40 * time.Minute)
+	stale := domain.NewSession(userID, "Забытая", nil, 5*time.Minute, started)
+	stale.Sets = []domain.Set{{ID: uuid.New(), SessionID: stale.ID, Exercise: "Жим", Reps: 8, LoggedAt: lastSet}}
+	sessions.sessions[stale.ID] = stale
+
+	_, err := svc.Start(ctx, userID, workoutuc.StartInput{Title: "Новая"})
+	require.NoError(t, err)
+
+	require.NotNil(t, sessions.sessions[stale.ID].FinishedAt)
+	require.True(t, sessions.sessions[stale.ID].FinishedAt.Equal(lastSet))
+	require.Len(t, publisher.events, 1)
+	require.Equal(t, stale.ID.String(), publisher.events[0].SessionID)
+}
+
+func TestStart_ValidatesInput(t *testing.T) {
+	svc, _, _ := newService()
+	ctx := context.Background()
+
+	_, err := svc.Start(ctx, uuid.New(), workoutuc.StartInput{Title: " "})
+	require.ErrorIs(t, err, workoutuc.ErrInvalidTitle)
+
+	_, err = svc.Start(ctx, uuid.New(), workoutuc.StartInput{Title: "Спина", AutoPauseAfter: 10 * time.Second})
+	require.ErrorIs(t, err, workoutuc.ErrInvalidAutoPause)
+
+	assignmentID := uuid.New()
+	_, err = svc.Start(ctx, uuid.New(), workoutuc.StartInput{Title: "Спина", AssignmentID: &assignmentID})
+	require.ErrorIs(t, err, workoutuc.ErrAssignmentNotFound)
+}
+
+func TestRateDifficulty_OnlyFinishedOwnSessions(t *testing.T) {
+	svc, sessions, _ := newService()
+	ctx := context.Background()
+	userID := uuid.New()
+
+	session, err := svc.Start(ctx, userID, workoutuc.StartInput{Title: "Ноги"})
+	require.NoError(t, err)
+	_, err = svc.RateDifficulty(ctx, userID, session.ID, 7)
+	require.ErrorIs(t, err, workoutuc.ErrSessionNotFinished)
+
+	_, err = svc.Finish(ctx, userID, session.ID)
+	require.NoError(t, err)
+
+	for _, invalid := range []int{0, 11} {
+		_, err = svc.RateDifficulty(ctx, userID, session.ID, invalid)
+		require.ErrorIs(t, err, workoutuc.ErrInvalidDifficulty)
+	}
+	_, err = svc.RateDifficulty(ctx, uuid.New(), session.ID, 7)
+	require.ErrorIs(t, err, workoutuc.ErrSessionNotFound)
+
+	rated, err := svc.RateDifficulty(ctx, userID, session.ID, 7)
+	require.NoError(t, err)
+	require.Equal(t, 7, *rated.Difficulty)
+	require.NotNil(t, rated.DifficultyRatedAt)
+
+	// Оценку можно исправить.
+	_, err = svc.RateDifficulty(ctx, userID, session.ID, 9)
+	require.NoError(t, err)
+	require.Equal(t, 9, *sessions.sessions[session.ID].Difficulty)
+}
+
+func TestListForViewer_RespectsOwnerVisibility(t *testing.T) {
+	svc, _, _, access := newServiceWithAccess()
+	ctx := context.Background()
+	owner, viewer := uuid.New(), uuid.New()
+
+	session, err := svc.Start(ctx, owner, workoutuc.StartInput{Title: "Ноги"})
+	require.NoError(t, err)
+	from, to := time.Now().Add(-time.Hour), time.Now().Add(time.Hour)
+
+	sessions, err := svc.ListForViewer(ctx, viewer, owner, from, to)
+	require.NoError(t, err)
+	require.Len(t, sessions, 1)
+	require.Equal(t, session.ID, sessions[0].ID)
+
+	access.hidden[owner] = true
+	_, err = svc.ListForViewer(ctx, viewer, owner, from, to)
+	require.ErrorIs(t, err, socialuc.ErrWorkoutsHidden)
+
+	sessions, err = svc.ListForViewer(ctx, owner, owner, from, to)
+	require.NoError(t, err)
+	require.Len(t, sessions, 1)
+}
+
+func TestListForCoach_RequiresWorkoutsConsent(t *testing.T) {
+	sessions := &fakeSessions{sessions: map[uuid.UUID]*domain.Session{}}
+	consents := &fakeConsents{granted: map[uuid.UUID]consentdomain.Scope{}}
+	svc := workoutuc.NewService(sessions, &fakePrograms{}, &fakePublisher{}, &fakeAccess{}, consents, nil, 5*time.Minute)
+	ctx := context.Background()
+	coach, client := uuid.New(), uuid.New()
+
+	session, err := svc.Start(ctx, client, workoutuc.StartInput{Title: "Ноги"})
+	require.NoError(t, err)
+	_, _, err = svc.LogSet(ctx, client, session.ID, workoutuc.SetInput{Exercise: "Присед", Reps: 5, WeightKg: weight(100)})
+	require.NoError(t, err)
+	_, err = svc.Finish(ctx, client, session.ID)
+	require.NoError(t, err)
+	from, to := time.Now().Add(-time.Hour), time.Now().Add(time.Hour)
+
+	_, err = svc.ListForCoach(ctx, coach, client, from, to)
+	require.ErrorIs(t, err, consentuc.ErrConsentRequired)
+	_, err = svc.StatsForCoach(ctx, coach, client, from, to)
+	require.ErrorIs(t, err, consentuc.ErrConsentRequired)
+
+	consents.granted[client] = consentdomain.ScopeWorkouts
+	list, err := svc.ListForCoach(ctx, coach, client, from, to)
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+	stats, err := svc.StatsForCoach(ctx, coach, client, from, to)
+	require.NoError(t, err)
+	require.Equal(t, 1, stats.Sessions)
+	require.InDelta(t, 500, stats.VolumeKg, 0.001)
+}